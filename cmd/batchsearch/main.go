@@ -0,0 +1,181 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// batchsearch screens a CSV of names against Watchman and writes a CSV or JSON report of their top matches.
+//
+//	batchsearch -address http://localhost:8084 -format csv -output report.csv customers.csv
+//
+// The input needs a header row with a name column. The optional type, birthDate (or dob) and country columns
+// are sent with each name, and every other column is copied into the report. With -offline the lists are
+// downloaded and searched in process, so a Watchman server isn't needed.
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman"
+	"github.com/moov-io/watchman/internal/download"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+)
+
+func main() {
+	logger := log.NewDefaultLogger().With(log.Fields{
+		"app":     log.String("batchsearch"),
+		"version": log.String(watchman.Version),
+	})
+
+	err := run(context.Background(), logger, os.Args[1:], os.Stdin, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+type options struct {
+	address   string
+	offline   bool
+	lists     string
+	dataDir   string
+	output    string
+	format    string
+	limit     int
+	minMatch  float64
+	batchSize int
+	timeout   time.Duration
+}
+
+func run(ctx context.Context, logger log.Logger, args []string, stdin io.Reader, stdout io.Writer) error {
+	var opts options
+
+	fs := flag.NewFlagSet("batchsearch", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&opts.address, "address", cmp.Or(os.Getenv("WATCHMAN_ADDRESS"), "http://localhost:8084"), "address of the Watchman server")
+	fs.BoolVar(&opts.offline, "offline", false, "download the lists and search them in process, instead of a Watchman server")
+	fs.StringVar(&opts.lists, "lists", "us_ofac,us_csl", "comma separated lists searched with -offline")
+	fs.StringVar(&opts.dataDir, "data-dir", "", "directory the list files are read from with -offline, in place of downloading them")
+	fs.StringVar(&opts.output, "output", "", "file the report is written to, stdout when empty")
+	fs.StringVar(&opts.format, "format", "csv", "report format, csv or json")
+	fs.IntVar(&opts.limit, "limit", 3, "most matches reported for each name")
+	fs.Float64Var(&opts.minMatch, "min-match", 0.85, "lowest score of a reported match")
+	fs.IntVar(&opts.batchSize, "batch-size", 1000, "names sent in each request to the Watchman server")
+	fs.DurationVar(&opts.timeout, "timeout", time.Minute, "timeout of each request to the Watchman server")
+
+	usage := errors.New("usage: batchsearch [-address url | -offline] [-format csv|json] [-limit 3] [-min-match 0.85] [-output file] <names.csv>")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 {
+		return usage
+	}
+	if opts.format != "csv" && opts.format != "json" {
+		return usage
+	}
+	if opts.limit <= 0 || opts.batchSize <= 0 {
+		return usage
+	}
+
+	var input io.Reader = stdin
+	if path := fs.Arg(0); path != "-" {
+		fd, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("opening input: %w", err)
+		}
+		defer fd.Close()
+		input = fd
+	}
+	rows, err := readRows(input)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", fs.Arg(0), err)
+	}
+
+	client, err := newClient(ctx, logger, opts)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	results, err := screen(ctx, client, rows, opts)
+	if err != nil {
+		return err
+	}
+	logger.Info().Logf("screened %d names in %v", len(rows.rows), time.Since(start))
+
+	w := stdout
+	if opts.output != "" {
+		fd, err := os.Create(opts.output)
+		if err != nil {
+			return fmt.Errorf("creating output: %w", err)
+		}
+		defer fd.Close()
+		w = fd
+	}
+	if opts.format == "json" {
+		return writeJSON(w, rows, results)
+	}
+	return writeCSV(w, rows, results)
+}
+
+func newClient(ctx context.Context, logger log.Logger, opts options) (search.Client, error) {
+	if !opts.offline {
+		httpClient := &http.Client{
+			Timeout: opts.timeout,
+		}
+		return search.NewClient(httpClient, strings.TrimSuffix(opts.address, "/")), nil
+	}
+
+	conf := download.Config{
+		InitialDataDirectory: opts.dataDir,
+		Offline:              opts.dataDir != "",
+	}
+	for _, list := range strings.Split(opts.lists, ",") {
+		if list = strings.TrimSpace(list); list != "" {
+			conf.IncludedLists = append(conf.IncludedLists, search.SourceList(list))
+		}
+	}
+	return newOfflineClient(ctx, logger, conf)
+}
+
+// screen searches rows in batches of opts.batchSize, returning the results in the order of rows
+func screen(ctx context.Context, client search.Client, rows rows, opts options) ([]search.BatchSearchResult, error) {
+	out := make([]search.BatchSearchResult, 0, len(rows.rows))
+	for start := 0; start < len(rows.rows); start += opts.batchSize {
+		end := start + opts.batchSize
+		if end > len(rows.rows) {
+			end = len(rows.rows)
+		}
+
+		req := search.BatchSearchRequest{
+			Limit:    opts.limit,
+			MinMatch: opts.minMatch,
+		}
+		for _, row := range rows.rows[start:end] {
+			req.Queries = append(req.Queries, row.query)
+		}
+
+		resp, err := client.SearchBatch(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("screening lines %d to %d: %w", rows.rows[start].line, rows.rows[end-1].line, err)
+		}
+		if len(resp.Results) != len(req.Queries) {
+			return nil, fmt.Errorf("screening lines %d to %d: got %d results for %d names",
+				rows.rows[start].line, rows.rows[end-1].line, len(resp.Results), len(req.Queries))
+		}
+		// A name which wasn't searched can't be reported as having no matches
+		for i, result := range resp.Results {
+			if result.Error != "" {
+				return nil, fmt.Errorf("screening line %d: %s", rows.rows[start+i].line, result.Error)
+			}
+		}
+		out = append(out, resp.Results...)
+	}
+	return out, nil
+}
@@ -0,0 +1,126 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+var testInput = `customerID,Name,DOB,Country,Type
+c-1,Nicolas Maduro Moros,1962-11-23,Venezuela,person
+c-2,Jane Doe,,US,person
+`
+
+func testServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	logger := log.NewTestLogger()
+
+	searchService := search.NewService(logger)
+	searchService.UpdateEntities([]pubsearch.Entity[pubsearch.Value]{
+		{
+			Name:     "Nicolas Maduro Moros",
+			Type:     pubsearch.EntityPerson,
+			Source:   pubsearch.SourceUSOFAC,
+			SourceID: "22790",
+			Person: &pubsearch.Person{
+				Name: "Nicolas Maduro Moros",
+			},
+		},
+	})
+
+	router := mux.NewRouter()
+	search.NewController(logger, searchService).AppendRoutes(router)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+	server := testServer(t)
+
+	var buf bytes.Buffer
+	require.ErrorContains(t, run(ctx, logger, nil, nil, &buf), "usage: batchsearch")
+	require.ErrorContains(t, run(ctx, logger, []string{"-format", "xml", "-"}, nil, &buf), "usage: batchsearch")
+
+	args := []string{"-address", server.URL, "-batch-size", "1", "-"}
+	require.NoError(t, run(ctx, logger, args, strings.NewReader(testInput), &buf))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Equal(t, []string{"customerID", "Name", "DOB", "Country", "Type",
+		"matchRank", "matchScore", "matchName", "matchEntityType", "matchSourceList", "matchSourceID"}, rows[0])
+	require.Equal(t, "c-1", rows[1][0])
+	require.Equal(t, "1", rows[1][5])
+	require.Equal(t, "22790", rows[1][10])
+	require.Equal(t, []string{"c-2", "Jane Doe", "", "US", "person", "", "", "", "", "", ""}, rows[2])
+
+	// JSON reports
+	buf.Reset()
+	args = []string{"-address", server.URL, "-format", "json", "-"}
+	require.NoError(t, run(ctx, logger, args, strings.NewReader(testInput), &buf))
+
+	var report []jsonRow
+	require.NoError(t, json.NewDecoder(&buf).Decode(&report))
+	require.Len(t, report, 2)
+	require.Equal(t, 2, report[0].Line)
+	require.Equal(t, "c-1", report[0].Input["customerID"])
+	require.Equal(t, "1962-11-23", report[0].Query.BirthDate)
+	require.Len(t, report[0].Matches, 1)
+	require.Equal(t, "22790", report[0].Matches[0].SourceID)
+	require.Empty(t, report[1].Matches)
+}
+
+func TestRun_Offline(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+
+	dir := filepath.Join("..", "..", "pkg", "ofac", "testdata")
+	args := []string{"-offline", "-lists", "us_ofac", "-data-dir", dir, "-min-match", "0.9", "-"}
+
+	var buf bytes.Buffer
+	input := "name,type\nNicolas MADURO MOROS,person\n"
+	require.NoError(t, run(ctx, logger, args, strings.NewReader(input), &buf))
+	require.Contains(t, buf.String(), "MADURO MOROS")
+	require.Contains(t, buf.String(), "us_ofac")
+}
+
+func TestReadRows(t *testing.T) {
+	_, err := readRows(strings.NewReader(""))
+	require.ErrorContains(t, err, "missing header row")
+
+	_, err = readRows(strings.NewReader("id,country\n1,US\n"))
+	require.ErrorContains(t, err, "missing name column")
+
+	_, err = readRows(strings.NewReader("name,country\n,US\n"))
+	require.ErrorContains(t, err, "line 2 is missing a name")
+
+	_, err = readRows(strings.NewReader("name\n"))
+	require.ErrorContains(t, err, "no names to screen")
+
+	found, err := readRows(strings.NewReader("\ufeffFull_Name,Entity_Type,date_of_birth\nAcme Corp,Business,\n"))
+	require.NoError(t, err)
+	require.Equal(t, "Full_Name", found.header[0])
+	require.Equal(t, pubsearch.BatchSearchQuery{Name: "Acme Corp", Type: "business"}, found.rows[0].query)
+}
@@ -0,0 +1,122 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/moov-io/watchman/internal/download"
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"golang.org/x/sync/errgroup"
+)
+
+// offlineClient searches lists loaded into this process, rather than sending requests to a Watchman server
+type offlineClient struct {
+	service search.Service
+}
+
+var _ pubsearch.Client = (&offlineClient{})
+
+func newOfflineClient(ctx context.Context, logger log.Logger, conf download.Config) (*offlineClient, error) {
+	dl, err := download.NewDownloader(logger, conf)
+	if err != nil {
+		return nil, fmt.Errorf("setting up downloader: %w", err)
+	}
+	stats, err := dl.RefreshAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading lists: %w", err)
+	}
+	logger.Info().Logf("loaded %d entities", len(stats.Entities))
+
+	service := search.NewService(logger)
+	service.UpdateEntities(stats.Entities)
+
+	return &offlineClient{service: service}, nil
+}
+
+func (c *offlineClient) SearchByEntity(ctx context.Context, entity pubsearch.Entity[pubsearch.Value], opts pubsearch.SearchOpts) (pubsearch.SearchResponse, error) {
+	entities, err := c.service.Search(ctx, entity, search.SearchOpts{
+		Limit:    opts.Limit,
+		MinMatch: opts.MinMatch,
+	})
+	return pubsearch.SearchResponse{Entities: entities}, err
+}
+
+func (c *offlineClient) SearchBatch(ctx context.Context, req pubsearch.BatchSearchRequest) (pubsearch.BatchSearchResponse, error) {
+	out := pubsearch.BatchSearchResponse{
+		Results: make([]pubsearch.BatchSearchResult, len(req.Queries)),
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(runtime.NumCPU())
+
+	for i := range req.Queries {
+		i := i
+		g.Go(func() error {
+			q := req.Queries[i]
+			entities, err := c.service.Search(ctx, queryEntity(q), search.SearchOpts{
+				Limit:    cmp.Or(q.Limit, req.Limit),
+				MinMatch: cmp.Or(q.MinMatch, req.MinMatch),
+			})
+			if err != nil {
+				return err
+			}
+			out.Results[i] = pubsearch.BatchSearchResult{
+				Query:    q,
+				Entities: entities,
+			}
+			return nil
+		})
+	}
+	return out, g.Wait()
+}
+
+var (
+	birthDateFormats = []string{"2006-01-02", "2006-01", "2006"}
+)
+
+// queryEntity is the entity a Watchman server searches for a batch query
+func queryEntity(q pubsearch.BatchSearchQuery) pubsearch.Entity[pubsearch.Value] {
+	out := pubsearch.Entity[pubsearch.Value]{
+		Name:   q.Name,
+		Type:   pubsearch.EntityType(q.Type),
+		Source: pubsearch.SourceAPIRequest,
+	}
+
+	switch out.Type {
+	case pubsearch.EntityPerson:
+		out.Person = &pubsearch.Person{Name: q.Name}
+		for _, format := range birthDateFormats {
+			if tt, err := time.Parse(format, q.BirthDate); err == nil {
+				out.Person.BirthDate = &tt
+				out.Person.BirthDates = []pubsearch.DateRange{pubsearch.DateRangeFor(tt, format)}
+				break
+			}
+		}
+	case pubsearch.EntityBusiness:
+		out.Business = &pubsearch.Business{Name: q.Name}
+	case pubsearch.EntityOrganization:
+		out.Organization = &pubsearch.Organization{Name: q.Name}
+	case pubsearch.EntityAircraft:
+		out.Aircraft = &pubsearch.Aircraft{Name: q.Name}
+	case pubsearch.EntityVessel:
+		out.Vessel = &pubsearch.Vessel{Name: q.Name}
+	}
+
+	if q.Country != "" {
+		out.Addresses = []pubsearch.Address{
+			{Country: q.Country},
+		}
+	}
+
+	return out
+}
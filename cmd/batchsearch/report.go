@@ -0,0 +1,187 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+type rows struct {
+	header []string
+	rows   []row
+}
+
+type row struct {
+	line   int
+	values []string
+	query  search.BatchSearchQuery
+}
+
+// columns are the headers each query field is read from, compared without case
+var columns = map[string][]string{
+	"name":      {"name", "fullname", "full_name"},
+	"type":      {"type", "entitytype", "entity_type"},
+	"birthDate": {"birthdate", "birth_date", "dob", "dateofbirth", "date_of_birth"},
+	"country":   {"country"},
+}
+
+func readRows(r io.Reader) (rows, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return rows{}, errors.New("missing header row")
+		}
+		return rows{}, err
+	}
+	// Spreadsheets often start their CSV exports with a byte order mark
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	out := rows{header: header}
+
+	index := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		for field, names := range columns {
+			for _, n := range names {
+				if name == n {
+					index[field] = i
+				}
+			}
+		}
+	}
+	if _, ok := index["name"]; !ok {
+		return rows{}, errors.New("missing name column")
+	}
+	get := func(values []string, field string) string {
+		if i, ok := index[field]; ok && i < len(values) {
+			return strings.TrimSpace(values[i])
+		}
+		return ""
+	}
+
+	for line := 2; ; line++ {
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return rows{}, err
+		}
+
+		query := search.BatchSearchQuery{
+			Name:      get(values, "name"),
+			Type:      strings.ToLower(get(values, "type")),
+			BirthDate: get(values, "birthDate"),
+			Country:   get(values, "country"),
+		}
+		if query.Name == "" {
+			return rows{}, fmt.Errorf("line %d is missing a name", line)
+		}
+		out.rows = append(out.rows, row{
+			line:   line,
+			values: values,
+			query:  query,
+		})
+	}
+	if len(out.rows) == 0 {
+		return rows{}, errors.New("no names to screen")
+	}
+	return out, nil
+}
+
+// match is how each matched entity is reported
+type match struct {
+	Rank       int     `json:"rank"`
+	Score      float64 `json:"score"`
+	Name       string  `json:"name"`
+	EntityType string  `json:"entityType"`
+	SourceList string  `json:"sourceList"`
+	SourceID   string  `json:"sourceID"`
+}
+
+func matches(result search.BatchSearchResult) []match {
+	out := make([]match, len(result.Entities))
+	for i, entity := range result.Entities {
+		out[i] = match{
+			Rank:       i + 1,
+			Score:      entity.Match,
+			Name:       entity.Name,
+			EntityType: string(entity.Type),
+			SourceList: string(entity.Source),
+			SourceID:   entity.SourceID,
+		}
+	}
+	return out
+}
+
+// writeCSV copies each input row once for every match, with the match's columns added. Rows without
+// a match are written once with those columns left empty.
+func writeCSV(w io.Writer, rows rows, results []search.BatchSearchResult) error {
+	out := csv.NewWriter(w)
+	out.Write(append(rows.header[:len(rows.header):len(rows.header)],
+		"matchRank", "matchScore", "matchName", "matchEntityType", "matchSourceList", "matchSourceID"))
+
+	for i, row := range rows.rows {
+		values := make([]string, len(rows.header))
+		copy(values, row.values)
+
+		found := matches(results[i])
+		if len(found) == 0 {
+			out.Write(append(values, "", "", "", "", "", ""))
+			continue
+		}
+		for _, m := range found {
+			out.Write(append(values[:len(values):len(values)],
+				strconv.Itoa(m.Rank),
+				strconv.FormatFloat(m.Score, 'f', 4, 64),
+				m.Name,
+				m.EntityType,
+				m.SourceList,
+				m.SourceID,
+			))
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+type jsonRow struct {
+	Line    int                     `json:"line"`
+	Input   map[string]string       `json:"input"`
+	Query   search.BatchSearchQuery `json:"query"`
+	Matches []match                 `json:"matches"`
+}
+
+func writeJSON(w io.Writer, rows rows, results []search.BatchSearchResult) error {
+	out := make([]jsonRow, len(rows.rows))
+	for i, row := range rows.rows {
+		input := make(map[string]string, len(rows.header))
+		for j, name := range rows.header {
+			if j < len(row.values) {
+				input[name] = row.values[j]
+			}
+		}
+		out[i] = jsonRow{
+			Line:    row.line,
+			Input:   input,
+			Query:   row.query,
+			Matches: matches(results[i]),
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
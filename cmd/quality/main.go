@@ -0,0 +1,199 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// quality runs labeled cases through the search pipeline and reports their precision and recall, so the effect
+// of a change to name preparation or scoring can be measured.
+//
+//	quality -data-dir ./pkg/ofac/testdata -output report.json
+//	quality -data-dir ./pkg/ofac/testdata -baseline report.json
+//	quality -cases labeled.csv -lists us_ofac,us_csl
+//
+// Without -cases the built-in corpus is run, which is labeled against the OFAC list in pkg/ofac/testdata.
+// With -baseline the results are compared to an earlier report and any regressed case is an error.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/moov-io/watchman"
+	"github.com/moov-io/watchman/internal/download"
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/internal/quality"
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+)
+
+func main() {
+	logger := log.NewDefaultLogger().With(log.Fields{
+		"app":     log.String("quality"),
+		"version": log.String(watchman.Version),
+	})
+
+	err := run(context.Background(), logger, os.Args[1:], os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+type options struct {
+	cases     string
+	lists     string
+	dataDir   string
+	baseline  string
+	output    string
+	limit     int
+	minMatch  float64
+	algorithm string
+	prepare   string
+	weights   string
+	overlap   float64
+}
+
+func run(ctx context.Context, logger log.Logger, args []string, stdout io.Writer) error {
+	var opts options
+
+	fs := flag.NewFlagSet("quality", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&opts.cases, "cases", "", "CSV of labeled cases, the built-in corpus when empty")
+	fs.StringVar(&opts.lists, "lists", "us_ofac", "comma separated lists the cases are searched against")
+	fs.StringVar(&opts.dataDir, "data-dir", "", "directory the list files are read from, in place of downloading them")
+	fs.StringVar(&opts.baseline, "baseline", "", "earlier report the results are compared to")
+	fs.StringVar(&opts.output, "output", "", "file the JSON report is written to")
+	fs.IntVar(&opts.limit, "limit", 10, "results searched for each case")
+	fs.Float64Var(&opts.minMatch, "min-match", 0.85, "lowest score of a returned result")
+	fs.StringVar(&opts.algorithm, "algorithm", "", "name scoring algorithm, see /v2/search")
+	fs.StringVar(&opts.prepare, "prepare", "", "comma separated prepare stages run over each query's name")
+	fs.StringVar(&opts.weights, "weights", "", "field weights, written as field:weight pairs such as name:40,address:5")
+	fs.Float64Var(&opts.overlap, "min-trigram-overlap", 0, "skip entities sharing less than this fraction of a name's trigrams")
+
+	usage := errors.New("usage: quality [-cases labeled.csv] [-lists us_ofac] [-data-dir dir] [-baseline report.json] [-output report.json]")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 0 {
+		return usage
+	}
+	if opts.limit <= 0 || opts.overlap < 0 || opts.overlap > 1 {
+		return usage
+	}
+
+	cases := quality.DefaultCases()
+	if opts.cases != "" {
+		var err error
+		cases, err = quality.LoadCasesFile(opts.cases)
+		if err != nil {
+			return err
+		}
+	}
+	stages, err := prepare.ParseStages(opts.prepare)
+	if err != nil {
+		return fmt.Errorf("invalid -prepare: %w", err)
+	}
+	weights, err := pubsearch.ParseWeights(opts.weights)
+	if err != nil {
+		return fmt.Errorf("invalid -weights: %w", err)
+	}
+
+	var baseline *quality.Report
+	if opts.baseline != "" {
+		baseline, err = readReport(opts.baseline)
+		if err != nil {
+			return err
+		}
+	}
+
+	service, err := loadService(ctx, logger, opts, weights)
+	if err != nil {
+		return err
+	}
+	report, err := quality.Run(ctx, service, cases, quality.Options{
+		Limit:     opts.limit,
+		MinMatch:  opts.minMatch,
+		Algorithm: opts.algorithm,
+		Prepare:   stages,
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.output != "" {
+		if err := writeReport(opts.output, report); err != nil {
+			return err
+		}
+	}
+	if err := quality.WriteSummary(stdout, report); err != nil {
+		return err
+	}
+	if baseline == nil {
+		return nil
+	}
+
+	comparison := quality.Compare(*baseline, report)
+	fmt.Fprintln(stdout)
+	if err := comparison.WriteText(stdout); err != nil {
+		return err
+	}
+	if n := len(comparison.Regressions); n > 0 {
+		return fmt.Errorf("%d cases regressed from %s", n, opts.baseline)
+	}
+	return nil
+}
+
+func loadService(ctx context.Context, logger log.Logger, opts options, weights pubsearch.Weights) (search.Service, error) {
+	conf := download.Config{
+		InitialDataDirectory: opts.dataDir,
+		Offline:              opts.dataDir != "",
+	}
+	for _, list := range strings.Split(opts.lists, ",") {
+		if list = strings.TrimSpace(list); list != "" {
+			conf.IncludedLists = append(conf.IncludedLists, pubsearch.SourceList(list))
+		}
+	}
+	dl, err := download.NewDownloader(logger, conf)
+	if err != nil {
+		return nil, fmt.Errorf("setting up downloader: %w", err)
+	}
+	stats, err := dl.RefreshAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("loading lists: %w", err)
+	}
+	logger.Info().Logf("loaded %d entities", len(stats.Entities))
+
+	service := search.NewServiceWithConfig(logger, search.ServiceConfig{
+		Weights:           search.WeightsConfig{Default: weights},
+		MinTrigramOverlap: opts.overlap,
+	})
+	service.UpdateEntities(stats.Entities)
+	return service, nil
+}
+
+func readReport(path string) (*quality.Report, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline: %w", err)
+	}
+	var report quality.Report
+	if err := json.Unmarshal(bs, &report); err != nil {
+		return nil, fmt.Errorf("reading baseline %s: %w", path, err)
+	}
+	return &report, nil
+}
+
+func writeReport(path string, report quality.Report) error {
+	bs, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(bs, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	return nil
+}
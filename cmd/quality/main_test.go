@@ -0,0 +1,60 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/quality"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+	dir := t.TempDir()
+
+	cases := filepath.Join(dir, "cases.csv")
+	require.NoError(t, os.WriteFile(cases, []byte(strings.Join([]string{
+		"id,name,type,sourceList,sourceID,expect",
+		"maduro,Nicolas Maduro Moros,person,us_ofac,22790,hit",
+		"clean,Margaret Thompson,person,,,miss",
+	}, "\n")), 0600))
+
+	dataDir := filepath.Join("..", "..", "pkg", "ofac", "testdata")
+	output := filepath.Join(dir, "report.json")
+
+	var stdout strings.Builder
+	err := run(ctx, logger, []string{"-cases", cases, "-data-dir", dataDir, "-output", output}, &stdout)
+	require.NoError(t, err)
+	require.Contains(t, stdout.String(), "precision")
+
+	bs, err := os.ReadFile(output)
+	require.NoError(t, err)
+	var report quality.Report
+	require.NoError(t, json.Unmarshal(bs, &report))
+	require.Equal(t, 1, report.TruePositives)
+	require.Equal(t, 1, report.TrueNegatives)
+	require.Equal(t, 1.0, report.Recall)
+
+	// A stricter minMatch loses the hit, which is a regression from the first report
+	stdout.Reset()
+	err = run(ctx, logger, []string{"-cases", cases, "-data-dir", dataDir, "-baseline", output, "-min-match", "0.999"}, &stdout)
+	require.ErrorContains(t, err, "1 cases regressed")
+	require.Contains(t, stdout.String(), "regressions")
+
+	err = run(ctx, logger, []string{"-weights", "name:500"}, &stdout)
+	require.ErrorContains(t, err, "invalid -weights")
+
+	err = run(ctx, logger, []string{"extra"}, &stdout)
+	require.ErrorContains(t, err, "usage")
+}
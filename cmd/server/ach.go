@@ -0,0 +1,74 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/moov-io/watchman/internal/ach"
+	"github.com/moov-io/watchman/internal/download"
+	"github.com/moov-io/watchman/internal/payments"
+	"github.com/moov-io/watchman/internal/search"
+
+	"github.com/moov-io/base/log"
+)
+
+// runACHScreening loads the lists, screens the NACHA file given as an argument (watchman ach file.ach)
+// and writes a report of the entries with hits to w
+func runACHScreening(ctx context.Context, logger log.Logger, dl download.Downloader, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("ach", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	minMatch := fs.Float64("min-match", 0.85, "lowest score of a reported hit")
+	limit := fs.Int("limit", 5, "most hits reported for each originator and receiver")
+	format := fs.String("format", "text", "report format, text or json")
+
+	usage := errors.New("usage: watchman ach [-min-match 0.85] [-limit 5] [-format text|json] <file>")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 {
+		return usage
+	}
+	if *format != "text" && *format != "json" {
+		return usage
+	}
+
+	fd, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return fmt.Errorf("opening ACH file: %w", err)
+	}
+	defer fd.Close()
+
+	file, err := ach.ParseFile(fd)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", fs.Arg(0), err)
+	}
+
+	stats, err := dl.RefreshAll(ctx)
+	if err != nil {
+		return fmt.Errorf("loading lists: %w", err)
+	}
+	searchService := search.NewService(logger)
+	searchService.UpdateEntities(stats.Entities)
+
+	service := ach.NewService(payments.NewService(logger, searchService))
+	report, err := service.Screen(ctx, file, payments.ScreenOpts{
+		Limit:    *limit,
+		MinMatch: *minMatch,
+	})
+	if err != nil {
+		return err
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+	return report.WriteText(w)
+}
@@ -0,0 +1,38 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/download"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunACHScreening(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+
+	dl, err := download.NewDownloader(logger, download.Config{
+		InitialDataDirectory: filepath.Join("..", "..", "pkg", "ofac", "testdata"),
+		IncludedLists:        []pubsearch.SourceList{pubsearch.SourceUSOFAC},
+	})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.ErrorContains(t, runACHScreening(ctx, logger, dl, nil, &buf), "usage: watchman ach")
+	require.ErrorContains(t, runACHScreening(ctx, logger, dl, []string{"-format", "xml", "file.ach"}, &buf), "usage: watchman ach")
+
+	path := filepath.Join("..", "..", "internal", "ach", "testdata", "payroll.ach")
+	require.NoError(t, runACHScreening(ctx, logger, dl, []string{"-min-match", "0.9", path}, &buf))
+	require.Contains(t, buf.String(), "screened 4 entries in 3 batches")
+	require.Contains(t, buf.String(), "NICOLAS MADURO MOROS")
+}
@@ -0,0 +1,85 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/download"
+
+	"github.com/moov-io/base/log"
+)
+
+// getBundleConfig returns the bundle lists are loaded from, overridden by BUNDLE_URL, BUNDLE_PUBLIC_KEY
+// and BUNDLE_MAX_SIZE
+func getBundleConfig(conf download.Config) (download.BundleConfig, error) {
+	out := conf.Bundle
+	out.URL = strings.TrimSpace(cmp.Or(os.Getenv("BUNDLE_URL"), out.URL))
+	out.PublicKey = strings.TrimSpace(cmp.Or(os.Getenv("BUNDLE_PUBLIC_KEY"), out.PublicKey))
+
+	if v := strings.TrimSpace(os.Getenv("BUNDLE_MAX_SIZE")); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return out, fmt.Errorf("invalid BUNDLE_MAX_SIZE: %w", err)
+		}
+		out.MaxSize = n
+	}
+	if out.MaxSize < 0 {
+		return out, fmt.Errorf("bundle max size of %d must not be negative", out.MaxSize)
+	}
+
+	if out.URL != "" {
+		if _, err := download.ParseBundlePublicKey(out.PublicKey); err != nil {
+			return out, fmt.Errorf("BUNDLE_URL is set: %w", err)
+		}
+	}
+	return out, nil
+}
+
+// runBundle downloads and prepares every included list, then writes them as a bundle signed with
+// BUNDLE_SIGNING_KEY to the bucket or directory given as an argument (watchman bundle s3://bucket).
+// "watchman bundle keygen" writes a new public and signing key to w.
+func runBundle(ctx context.Context, logger log.Logger, conf download.Config, args []string, w io.Writer) error {
+	if len(args) == 1 && args[0] == "keygen" {
+		public, signing, err := download.GenerateBundleKeys()
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "BUNDLE_PUBLIC_KEY=%s\nBUNDLE_SIGNING_KEY=%s\n", public, signing)
+		return err
+	}
+	if len(args) != 1 || strings.TrimSpace(args[0]) == "" {
+		return errors.New("usage: watchman bundle <s3://bucket | gs://bucket | directory> or watchman bundle keygen")
+	}
+	key, err := download.ParseBundleSigningKey(os.Getenv("BUNDLE_SIGNING_KEY"))
+	if err != nil {
+		return fmt.Errorf("BUNDLE_SIGNING_KEY: %w", err)
+	}
+
+	// Bundles are built from the lists themselves, not an earlier bundle
+	conf.Bundle = download.BundleConfig{}
+	downloader, err := download.NewDownloader(logger, conf)
+	if err != nil {
+		return fmt.Errorf("setting up downloader: %w", err)
+	}
+	stats, err := downloader.RefreshAll(ctx)
+	if err != nil {
+		return fmt.Errorf("loading lists: %w", err)
+	}
+
+	manifest, err := download.PublishBundle(ctx, strings.TrimSpace(args[0]), key, stats.EndedAt, stats.Entities)
+	if err != nil {
+		return err
+	}
+	logger.Info().Logf("wrote bundle of %d entities from %d lists (sha256 %s)", manifest.Entities, len(manifest.Lists), manifest.SHA256)
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/download"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBundleConfig(t *testing.T) {
+	got, err := getBundleConfig(download.Config{})
+	require.NoError(t, err)
+	require.Empty(t, got.URL)
+
+	t.Setenv("BUNDLE_URL", "https://lists.example.com/watchman.bundle")
+	_, err = getBundleConfig(download.Config{})
+	require.ErrorContains(t, err, "BUNDLE_URL is set")
+
+	public, _, err := download.GenerateBundleKeys()
+	require.NoError(t, err)
+	t.Setenv("BUNDLE_PUBLIC_KEY", public)
+	got, err = getBundleConfig(download.Config{})
+	require.NoError(t, err)
+	require.Equal(t, download.BundleConfig{URL: "https://lists.example.com/watchman.bundle", PublicKey: public}, got)
+
+	t.Setenv("BUNDLE_MAX_SIZE", "2147483648")
+	got, err = getBundleConfig(download.Config{})
+	require.NoError(t, err)
+	require.Equal(t, int64(2<<30), got.MaxSize)
+
+	t.Setenv("BUNDLE_MAX_SIZE", "2GB")
+	_, err = getBundleConfig(download.Config{})
+	require.ErrorContains(t, err, "invalid BUNDLE_MAX_SIZE")
+
+	t.Setenv("BUNDLE_MAX_SIZE", "-1")
+	_, err = getBundleConfig(download.Config{})
+	require.ErrorContains(t, err, "must not be negative")
+}
+
+func TestRunBundle(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+
+	var keys bytes.Buffer
+	require.NoError(t, runBundle(ctx, logger, download.Config{}, []string{"keygen"}, &keys))
+	lines := strings.Split(strings.TrimSpace(keys.String()), "\n")
+	require.Len(t, lines, 2)
+	public := strings.TrimPrefix(lines[0], "BUNDLE_PUBLIC_KEY=")
+	t.Setenv("BUNDLE_SIGNING_KEY", strings.TrimPrefix(lines[1], "BUNDLE_SIGNING_KEY="))
+
+	conf := download.Config{
+		InitialDataDirectory: filepath.Join("..", "..", "pkg", "ofac", "testdata"),
+		IncludedLists:        []pubsearch.SourceList{pubsearch.SourceUSOFAC},
+	}
+	require.ErrorContains(t, runBundle(ctx, logger, conf, nil, &keys), "usage: watchman bundle")
+
+	dir := t.TempDir()
+	require.NoError(t, runBundle(ctx, logger, conf, []string{dir}, &keys))
+
+	publicKey, err := download.ParseBundlePublicKey(public)
+	require.NoError(t, err)
+	fd, err := os.Open(filepath.Join(dir, download.BundleFileName))
+	require.NoError(t, err)
+	defer fd.Close()
+
+	bundle, err := download.ReadBundle(fd, publicKey, 0)
+	require.NoError(t, err)
+	require.Greater(t, bundle.Manifest.Lists["us_ofac"], 0)
+	require.Len(t, bundle.Entities, bundle.Manifest.Entities)
+
+	t.Setenv("BUNDLE_SIGNING_KEY", "")
+	require.ErrorContains(t, runBundle(ctx, logger, conf, []string{dir}, &keys), "BUNDLE_SIGNING_KEY")
+}
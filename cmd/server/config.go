@@ -5,11 +5,30 @@
 package main
 
 import (
+	"cmp"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
 	watchman "github.com/moov-io/watchman"
+	"github.com/moov-io/watchman/internal/audit"
+	"github.com/moov-io/watchman/internal/auth"
+	"github.com/moov-io/watchman/internal/cases"
+	"github.com/moov-io/watchman/internal/database"
 	"github.com/moov-io/watchman/internal/download"
+	"github.com/moov-io/watchman/internal/events"
+	"github.com/moov-io/watchman/internal/reports"
+	"github.com/moov-io/watchman/internal/search"
+	"github.com/moov-io/watchman/internal/tracing"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
 
 	"github.com/moov-io/base/config"
 	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/strx"
 )
 
 type GlobalConfig struct {
@@ -19,12 +38,105 @@ type GlobalConfig struct {
 type Config struct {
 	Download download.Config
 
+	// Database is optional and shares list data, refreshes, watches and the allowlist between instances
+	Database *database.Config
+
+	// Events are published to Kafka or NATS when either is configured
+	Events events.Config
+
+	// Audit records each search to a file, the database or Kafka when any is configured
+	Audit audit.Config
+
+	// Cases records the search results scoring at least Cases.MinMatch as hits, which are reviewed and dispositioned
+	Cases cases.Config
+
+	// Reports summarize the searches, hits, list versions and watch matches of each day or week, which are
+	// delivered to a webhook or bucket when scheduled
+	Reports reports.Config
+
+	// Auth requires API keys on the HTTP server when any are configured
+	Auth auth.Config
+
+	// Health chooses when lists are stale and if readiness checks fail while they are
+	Health download.HealthConfig
+
+	// Tracing sends OpenTelemetry spans of downloads, preparation, indexing and searches to a collector
+	Tracing tracing.Config
+
+	// GraphQL serves entities, searches, list versions and watches from /v2/graphql when enabled
+	GraphQL bool
+
+	// LogRedaction hashes or removes the personal details of searches written to logs
+	LogRedaction search.LogRedaction
+
+	// SearchCache answers repeated searches from memory or Redis until the lists are refreshed
+	SearchCache search.CacheConfig
+
+	// SearchWeights change how much each field counts towards search scores, overall or by entity type
+	SearchWeights search.WeightsConfig
+
+	// SearchCalibration moves the scores of each source list onto a common scale
+	SearchCalibration search.CalibrationConfig
+
+	// SearchMinMatch is the lowest score of results from each source list, for searches without their own minMatch
+	SearchMinMatch search.MinMatchConfig
+
+	// SearchAlgorithm is how names are compared by searches which don't choose an algorithm, see
+	// pubsearch.Algorithms(). Jaro-Winkler is used when it's empty.
+	SearchAlgorithm string
+
+	// SearchPhonetic boosts name terms which sound alike, such as "Mohammed" and "Muhamad", and scores the entities
+	// with names which sound like a query's, indexing the phonetic codes of their names
+	SearchPhonetic bool
+
+	// SearchShards is how many parts the entities are split into and searched concurrently, one per core when zero
+	SearchShards int
+
+	// SearchMinTrigramOverlap skips scoring entities whose names share less than this fraction of the trigrams
+	// in a query's name, scoring every entity when zero
+	SearchMinTrigramOverlap float64
+
+	// SearchBudget limits how long each search scores entities for and how many it scores, returning the best
+	// results found before either runs out
+	SearchBudget search.BudgetConfig
+
+	// SearchBirthYearTolerance is how many years apart dates of birth can be and still count a little towards the score
+	SearchBirthYearTolerance int
+
+	// SearchTypoTolerance is how many typos name terms can have by their length, as comma separated rules of
+	// minLength:edits such as "4:1,8:2". Names aren't corrected when it's empty.
+	SearchTypoTolerance string
+
+	// SearchAltNameBoost raises the score of entities with several names or aliases matching the query
+	SearchAltNameBoost float64
+
+	// SearchConflictFactors multiply the score of a person whose gender or nationality differs from the query's, or
+	// of a bank with other BICs or LEIs
+	SearchConflictFactors pubsearch.ConflictFactors
+
+	// SearchWeakTerms are the generic words of names, such as "trading" or "company", which count for less
+	// than others when names are compared
+	SearchWeakTerms pubsearch.WeakTerms
+
+	// SearchRerankModel is a JSON file of logistic regression weights, see search.LogisticModel, which reorders
+	// the results of searches sorted by score
+	SearchRerankModel string
+
+	// SearchFinancialIDMapping is a CSV file pairing the BICs and LEIs of banks, such as GLEIF's BIC-to-LEI
+	// relationship file, see search.FinancialIDMapping
+	SearchFinancialIDMapping string
+
+	// SearchProfiles are named sets of weights, minimum scores, preparation stages and lists, chosen by each
+	// search, its API key or its tenant
+	SearchProfiles search.ProfilesConfig
+
 	Servers ServerConfig
 }
 
 type ServerConfig struct {
 	BindAddress  string
 	AdminAddress string
+	GRPCAddress  string
 
 	TLSCertFile string
 	TLSKeyFile  string
@@ -40,3 +152,544 @@ func LoadConfig(logger log.Logger) (*Config, error) {
 
 	return &global.Watchman, nil
 }
+
+// getDatabaseConfig returns the configured database, overridden by DATABASE_TYPE and DATABASE_DSN.
+// nil is returned when no database is configured.
+func getDatabaseConfig(conf *Config) *database.Config {
+	var out database.Config
+	if conf.Database != nil {
+		out = *conf.Database
+	}
+	if v := strings.TrimSpace(os.Getenv("DATABASE_TYPE")); v != "" {
+		out.Type = v
+	}
+	if v := strings.TrimSpace(os.Getenv("DATABASE_DSN")); v != "" {
+		out.DSN = v
+	}
+	if out.Type == "" && out.DSN == "" {
+		return nil
+	}
+	return &out
+}
+
+// getEventsConfig returns the configured event broker, overridden by EVENTS_KAFKA_BROKERS and EVENTS_KAFKA_TOPIC
+// or EVENTS_NATS_URL and EVENTS_NATS_SUBJECT.
+func getEventsConfig(conf *Config) events.Config {
+	out := conf.Events
+
+	if v := strings.TrimSpace(os.Getenv("EVENTS_KAFKA_BROKERS")); v != "" {
+		out.Kafka = &events.KafkaConfig{
+			Brokers: strings.Split(v, ","),
+			Topic:   strings.TrimSpace(os.Getenv("EVENTS_KAFKA_TOPIC")),
+		}
+	}
+	if v := strings.TrimSpace(os.Getenv("EVENTS_NATS_URL")); v != "" {
+		out.NATS = &events.NATSConfig{
+			URL:     v,
+			Subject: strings.TrimSpace(os.Getenv("EVENTS_NATS_SUBJECT")),
+		}
+	}
+	return out
+}
+
+// getAuditConfig returns where searches are recorded, overridden by AUDIT_FILE, AUDIT_DATABASE, AUDIT_KAFKA_BROKERS
+// and AUDIT_KAFKA_TOPIC, along with how many results of each are recorded from AUDIT_TOP_RESULTS.
+func getAuditConfig(conf *Config) (audit.Config, error) {
+	out := conf.Audit
+
+	if v := strings.TrimSpace(os.Getenv("AUDIT_FILE")); v != "" {
+		out.File = &audit.FileConfig{Path: v}
+	}
+	if v := strings.TrimSpace(os.Getenv("AUDIT_DATABASE")); v != "" {
+		out.Database = strx.Yes(v)
+	}
+	if v := strings.TrimSpace(os.Getenv("AUDIT_KAFKA_BROKERS")); v != "" {
+		out.Kafka = &audit.KafkaConfig{
+			Brokers: strings.Split(v, ","),
+			Topic:   strings.TrimSpace(os.Getenv("AUDIT_KAFKA_TOPIC")),
+		}
+	}
+	if v := strings.TrimSpace(os.Getenv("AUDIT_TOP_RESULTS")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return out, fmt.Errorf("invalid AUDIT_TOP_RESULTS: %w", err)
+		}
+		out.TopResults = n
+	}
+	if out.TopResults < 0 {
+		return out, fmt.Errorf("audit top results of %d can't be negative", out.TopResults)
+	}
+	return out, nil
+}
+
+// getReportsConfig returns when summary reports are delivered and where, overridden by REPORTS_SCHEDULE,
+// REPORTS_PERIOD, REPORTS_MIN_MATCH, REPORTS_WEBHOOK_URL, REPORTS_WEBHOOK_SECRET and REPORTS_BUCKET_URL
+func getReportsConfig(conf *Config) (reports.Config, error) {
+	out := conf.Reports
+
+	out.Schedule = strings.TrimSpace(cmp.Or(os.Getenv("REPORTS_SCHEDULE"), out.Schedule))
+	if v := strings.TrimSpace(os.Getenv("REPORTS_PERIOD")); v != "" {
+		out.Period = reports.Period(v)
+	}
+	if v := strings.TrimSpace(os.Getenv("REPORTS_MIN_MATCH")); v != "" {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return out, fmt.Errorf("invalid REPORTS_MIN_MATCH: %w", err)
+		}
+		out.MinMatch = n
+	}
+	if v := strings.TrimSpace(os.Getenv("REPORTS_WEBHOOK_URL")); v != "" {
+		out.Webhook = &reports.WebhookConfig{
+			URL:    v,
+			Secret: strings.TrimSpace(os.Getenv("REPORTS_WEBHOOK_SECRET")),
+		}
+	}
+	out.BucketURL = strings.TrimSpace(cmp.Or(os.Getenv("REPORTS_BUCKET_URL"), out.BucketURL))
+
+	return out, out.Validate()
+}
+
+// getCasesConfig returns which search results are recorded as hits, overridden by CASES_MIN_MATCH
+func getCasesConfig(conf *Config) (cases.Config, error) {
+	out := conf.Cases
+	if v := strings.TrimSpace(os.Getenv("CASES_MIN_MATCH")); v != "" {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return out, fmt.Errorf("invalid CASES_MIN_MATCH: %w", err)
+		}
+		out.MinMatch = n
+	}
+	if math.IsNaN(out.MinMatch) || out.MinMatch < 0 || out.MinMatch > 1 {
+		return out, fmt.Errorf("cases minMatch of %v must be between 0 and 1", out.MinMatch)
+	}
+	return out, nil
+}
+
+// getLogRedaction returns how the details of searches are logged, overridden by LOG_REDACTION
+func getLogRedaction(conf *Config) (search.LogRedaction, error) {
+	v := cmp.Or(strings.TrimSpace(os.Getenv("LOG_REDACTION")), string(conf.LogRedaction))
+	out, err := search.ParseLogRedaction(v)
+	if err != nil {
+		return out, fmt.Errorf("invalid LOG_REDACTION: %w", err)
+	}
+	return out, nil
+}
+
+// getGraphQLEnabled returns if the GraphQL endpoint is served, overridden by GRAPHQL_ENABLED
+func getGraphQLEnabled(conf *Config) bool {
+	if v := strings.TrimSpace(os.Getenv("GRAPHQL_ENABLED")); v != "" {
+		return strx.Yes(v)
+	}
+	return conf.GraphQL
+}
+
+// getHealthConfig returns when lists are stale and what readiness checks report, overridden by
+// HEALTH_STALE_AFTER and HEALTH_READINESS
+func getHealthConfig(conf *Config) (download.HealthConfig, error) {
+	out := conf.Health
+
+	if v := strings.TrimSpace(os.Getenv("HEALTH_STALE_AFTER")); v != "" {
+		dur, err := time.ParseDuration(v)
+		if err != nil {
+			return out, fmt.Errorf("invalid HEALTH_STALE_AFTER: %w", err)
+		}
+		out.StaleAfter = dur
+	}
+	out.Readiness = strings.ToLower(strings.TrimSpace(cmp.Or(os.Getenv("HEALTH_READINESS"), out.Readiness)))
+
+	return out, out.Validate()
+}
+
+// getTracingConfig returns where spans are sent, overridden by TRACING_ENDPOINT, TRACING_HEADERS,
+// TRACING_SERVICE_NAME and TRACING_SAMPLE_RATIO. TRACING_HEADERS is a comma separated list of name=value pairs.
+func getTracingConfig(conf *Config) (tracing.Config, error) {
+	out := conf.Tracing
+
+	if v := strings.TrimSpace(os.Getenv("TRACING_ENDPOINT")); v != "" {
+		out.Endpoint = v
+	}
+	if v := strings.TrimSpace(os.Getenv("TRACING_HEADERS")); v != "" {
+		out.Headers = make(map[string]string)
+		for _, pair := range strings.Split(v, ",") {
+			name, value, found := strings.Cut(pair, "=")
+			if !found || strings.TrimSpace(name) == "" {
+				return out, fmt.Errorf("invalid TRACING_HEADERS %q, expected name=value", pair)
+			}
+			out.Headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+	}
+	if v := strings.TrimSpace(os.Getenv("TRACING_SERVICE_NAME")); v != "" {
+		out.ServiceName = v
+	}
+	if v := strings.TrimSpace(os.Getenv("TRACING_SAMPLE_RATIO")); v != "" {
+		ratio, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return out, fmt.Errorf("invalid TRACING_SAMPLE_RATIO: %w", err)
+		}
+		out.SampleRatio = ratio
+	}
+	return out, out.Validate()
+}
+
+// getAuthConfig returns the configured API keys, OIDC issuer and rate limit, overridden by API_KEYS, OIDC_ISSUER
+// (along with OIDC_AUDIENCE and OIDC_JWKS_URL) and API_RATE_LIMIT.
+// API_KEYS is a comma separated list of name:key pairs and API_RATE_LIMIT is written as requests/interval, such as 100/1m.
+func getAuthConfig(conf *Config) (auth.Config, error) {
+	out := conf.Auth
+
+	if v := strings.TrimSpace(os.Getenv("API_KEYS")); v != "" {
+		out.APIKeys = nil
+		for _, pair := range strings.Split(v, ",") {
+			name, key, found := strings.Cut(strings.TrimSpace(pair), ":")
+			if !found || name == "" || key == "" {
+				return out, fmt.Errorf("invalid API_KEYS entry %q, expected name:key", pair)
+			}
+			out.APIKeys = append(out.APIKeys, auth.APIKey{Name: name, Key: key})
+		}
+	}
+	if v := strings.TrimSpace(os.Getenv("OIDC_ISSUER")); v != "" {
+		oidc := auth.OIDCConfig{}
+		if out.OIDC != nil {
+			oidc = *out.OIDC
+		}
+		oidc.Issuer = v
+		oidc.Audience = strings.TrimSpace(cmp.Or(os.Getenv("OIDC_AUDIENCE"), oidc.Audience))
+		oidc.JWKSURL = strings.TrimSpace(cmp.Or(os.Getenv("OIDC_JWKS_URL"), oidc.JWKSURL))
+		out.OIDC = &oidc
+	}
+	if v := strings.TrimSpace(os.Getenv("API_RATE_LIMIT")); v != "" {
+		limit, err := parseRateLimit(v)
+		if err != nil {
+			return out, fmt.Errorf("invalid API_RATE_LIMIT: %w", err)
+		}
+		out.RateLimit = limit
+	}
+	return out, nil
+}
+
+// getSearchCacheConfig returns the configured search cache, overridden by SEARCH_CACHE_SIZE, SEARCH_CACHE_TTL
+// and SEARCH_CACHE_REDIS_URL
+func getSearchCacheConfig(conf *Config) (search.CacheConfig, error) {
+	out := conf.SearchCache
+
+	if v := strings.TrimSpace(os.Getenv("SEARCH_CACHE_SIZE")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return out, fmt.Errorf("invalid SEARCH_CACHE_SIZE: %w", err)
+		}
+		out.Size = n
+	}
+	if v := strings.TrimSpace(os.Getenv("SEARCH_CACHE_TTL")); v != "" {
+		dur, err := time.ParseDuration(v)
+		if err != nil {
+			return out, fmt.Errorf("invalid SEARCH_CACHE_TTL: %w", err)
+		}
+		out.TTL = dur
+	}
+	out.RedisURL = strings.TrimSpace(cmp.Or(os.Getenv("SEARCH_CACHE_REDIS_URL"), out.RedisURL))
+	return out, nil
+}
+
+// getSearchWeights returns the configured search weights, overridden by SEARCH_WEIGHTS for every entity type and
+// SEARCH_WEIGHTS_PERSON, SEARCH_WEIGHTS_BUSINESS, etc. for one. Each is written as field:weight pairs, such as
+// name:40,address:5.
+func getSearchWeights(conf *Config) (search.WeightsConfig, error) {
+	out := search.WeightsConfig{
+		Default: conf.SearchWeights.Default,
+		Types:   make(map[pubsearch.EntityType]pubsearch.Weights),
+	}
+	for entityType, weights := range conf.SearchWeights.Types {
+		out.Types[pubsearch.EntityType(strings.ToLower(string(entityType)))] = weights
+	}
+
+	if v := strings.TrimSpace(os.Getenv("SEARCH_WEIGHTS")); v != "" {
+		weights, err := pubsearch.ParseWeights(v)
+		if err != nil {
+			return out, fmt.Errorf("invalid SEARCH_WEIGHTS: %w", err)
+		}
+		out.Default = weights
+	}
+	entityTypes := []pubsearch.EntityType{
+		pubsearch.EntityPerson, pubsearch.EntityBusiness, pubsearch.EntityOrganization,
+		pubsearch.EntityAircraft, pubsearch.EntityVessel,
+	}
+	for _, entityType := range entityTypes {
+		key := "SEARCH_WEIGHTS_" + strings.ToUpper(string(entityType))
+		if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+			weights, err := pubsearch.ParseWeights(v)
+			if err != nil {
+				return out, fmt.Errorf("invalid %s: %w", key, err)
+			}
+			out.Types[entityType] = weights
+		}
+	}
+	return out, out.Validate()
+}
+
+// configuredSources are the lists which can be calibrated, or given a minimum score, by environment variables
+var configuredSources = []pubsearch.SourceList{
+	pubsearch.SourceAUCSL, pubsearch.SourceCACSL, pubsearch.SourceCHCSL, pubsearch.SourceEUCSL,
+	pubsearch.SourceUKCSL, pubsearch.SourceUNCSL, pubsearch.SourceUSBIS, pubsearch.SourceUSCSL,
+	pubsearch.SourceUSFinCEN311, pubsearch.SourceUSOFAC, pubsearch.SourceOpenSanctions, pubsearch.SourceInterpolRed,
+	pubsearch.SourceCustomList,
+}
+
+// getSearchCalibration returns the configured score calibration, overridden by SEARCH_CALIBRATION_US_OFAC,
+// SEARCH_CALIBRATION_UN_CSL, etc. for each source list. Each is written as raw:calibrated pairs, such as
+// 0.85:0.80,0.95:0.93.
+func getSearchCalibration(conf *Config) (search.CalibrationConfig, error) {
+	out := search.CalibrationConfig{
+		Sources: make(map[pubsearch.SourceList]pubsearch.Calibration),
+	}
+	for source, calibration := range conf.SearchCalibration.Sources {
+		out.Sources[pubsearch.SourceList(strings.ToLower(string(source)))] = calibration
+	}
+	for _, source := range configuredSources {
+		key := "SEARCH_CALIBRATION_" + strings.ToUpper(string(source))
+		if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+			calibration, err := pubsearch.ParseCalibration(v)
+			if err != nil {
+				return out, fmt.Errorf("invalid %s: %w", key, err)
+			}
+			out.Sources[source] = calibration
+		}
+	}
+	return out, out.Validate()
+}
+
+// getSearchMinMatch returns the configured minimum score of each list, overridden by SEARCH_MIN_MATCH_US_CSL,
+// SEARCH_MIN_MATCH_US_OFAC, etc.
+func getSearchMinMatch(conf *Config) (search.MinMatchConfig, error) {
+	out := search.MinMatchConfig{
+		Sources: make(map[pubsearch.SourceList]float64),
+	}
+	for source, minMatch := range conf.SearchMinMatch.Sources {
+		out.Sources[pubsearch.SourceList(strings.ToLower(string(source)))] = minMatch
+	}
+
+	for _, source := range configuredSources {
+		key := "SEARCH_MIN_MATCH_" + strings.ToUpper(string(source))
+		if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return out, fmt.Errorf("invalid %s: %w", key, err)
+			}
+			out.Sources[source] = n
+		}
+	}
+	return out, out.Validate()
+}
+
+// getSearchAlgorithm returns the configured default algorithm of searches, overridden by SEARCH_ALGORITHM
+func getSearchAlgorithm(conf *Config) (string, error) {
+	out := strings.ToLower(strings.TrimSpace(conf.SearchAlgorithm))
+	if v := strings.TrimSpace(os.Getenv("SEARCH_ALGORITHM")); v != "" {
+		out = strings.ToLower(v)
+	}
+	if _, err := pubsearch.NameScorerFor(out); err != nil {
+		return out, err
+	}
+	return out, nil
+}
+
+// getSearchPhonetic returns if searches match names which sound alike, overridden by ENABLE_PHONETIC_MATCHING
+func getSearchPhonetic(conf *Config) bool {
+	if v := strings.TrimSpace(os.Getenv("ENABLE_PHONETIC_MATCHING")); v != "" {
+		return strx.Yes(v)
+	}
+	return conf.SearchPhonetic
+}
+
+// getSearchShards returns the configured number of search shards, overridden by SEARCH_SHARDS
+func getSearchShards(conf *Config) (int, error) {
+	out := conf.SearchShards
+	if v := strings.TrimSpace(os.Getenv("SEARCH_SHARDS")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return out, fmt.Errorf("invalid SEARCH_SHARDS: %w", err)
+		}
+		out = n
+	}
+	if out < 0 {
+		return out, fmt.Errorf("search shards of %d must not be negative", out)
+	}
+	return out, nil
+}
+
+// getSearchMinTrigramOverlap returns the configured trigram overlap, overridden by SEARCH_MIN_TRIGRAM_OVERLAP
+func getSearchMinTrigramOverlap(conf *Config) (float64, error) {
+	out := conf.SearchMinTrigramOverlap
+	if v := strings.TrimSpace(os.Getenv("SEARCH_MIN_TRIGRAM_OVERLAP")); v != "" {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return out, fmt.Errorf("invalid SEARCH_MIN_TRIGRAM_OVERLAP: %w", err)
+		}
+		out = n
+	}
+	if math.IsNaN(out) || out < 0 || out > 1 {
+		return out, fmt.Errorf("trigram overlap of %v must be between 0 and 1", out)
+	}
+	return out, nil
+}
+
+// getSearchBudget returns the configured limits of each search, overridden by SEARCH_TIMEOUT and
+// SEARCH_MAX_CANDIDATES
+func getSearchBudget(conf *Config) (search.BudgetConfig, error) {
+	out := conf.SearchBudget
+
+	if v := strings.TrimSpace(os.Getenv("SEARCH_TIMEOUT")); v != "" {
+		dur, err := time.ParseDuration(v)
+		if err != nil {
+			return out, fmt.Errorf("invalid SEARCH_TIMEOUT: %w", err)
+		}
+		out.Timeout = dur
+	}
+	if v := strings.TrimSpace(os.Getenv("SEARCH_MAX_CANDIDATES")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return out, fmt.Errorf("invalid SEARCH_MAX_CANDIDATES: %w", err)
+		}
+		out.MaxCandidates = n
+	}
+	return out, out.Validate()
+}
+
+// getSearchBirthYearTolerance returns the configured tolerance of dates of birth, overridden by SEARCH_BIRTH_YEAR_TOLERANCE
+func getSearchBirthYearTolerance(conf *Config) (int, error) {
+	out := conf.SearchBirthYearTolerance
+	if v := strings.TrimSpace(os.Getenv("SEARCH_BIRTH_YEAR_TOLERANCE")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return out, fmt.Errorf("invalid SEARCH_BIRTH_YEAR_TOLERANCE: %w", err)
+		}
+		out = n
+	}
+	if out < 0 {
+		return out, fmt.Errorf("birth year tolerance of %d must not be negative", out)
+	}
+	return out, nil
+}
+
+// getSearchTypoTolerance returns the configured tolerance of typos in name terms, overridden by SEARCH_TYPO_TOLERANCE
+func getSearchTypoTolerance(conf *Config) (pubsearch.TypoTolerance, error) {
+	value := conf.SearchTypoTolerance
+	if v := strings.TrimSpace(os.Getenv("SEARCH_TYPO_TOLERANCE")); v != "" {
+		value = v
+	}
+	out, err := pubsearch.ParseTypoTolerance(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid typo tolerance: %w", err)
+	}
+	return out, nil
+}
+
+// getSearchAltNameBoost returns the configured boost of entities with several matching names, overridden by
+// SEARCH_ALT_NAME_BOOST
+func getSearchAltNameBoost(conf *Config) (float64, error) {
+	out := conf.SearchAltNameBoost
+	if v := strings.TrimSpace(os.Getenv("SEARCH_ALT_NAME_BOOST")); v != "" {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return out, fmt.Errorf("invalid SEARCH_ALT_NAME_BOOST: %w", err)
+		}
+		out = n
+	}
+	if math.IsNaN(out) || out < 0 || out >= 1 {
+		return out, fmt.Errorf("alt name boost of %v must be at least 0 and below 1", out)
+	}
+	return out, nil
+}
+
+// getSearchConflictFactors returns the configured conflict factors, overridden by SEARCH_CONFLICT_FACTORS
+func getSearchConflictFactors(conf *Config) (pubsearch.ConflictFactors, error) {
+	out := conf.SearchConflictFactors
+	if v := strings.TrimSpace(os.Getenv("SEARCH_CONFLICT_FACTORS")); v != "" {
+		factors, err := pubsearch.ParseConflictFactors(v)
+		if err != nil {
+			return out, fmt.Errorf("invalid SEARCH_CONFLICT_FACTORS: %w", err)
+		}
+		out = factors.Or(out)
+	}
+	return out, out.Validate()
+}
+
+// getSearchWeakTerms returns the configured weak terms. SEARCH_WEAK_TERMS adds to their terms, while
+// SEARCH_WEAK_TERMS_LANGUAGES and SEARCH_WEAK_TERM_WEIGHT override their languages and weight.
+func getSearchWeakTerms(conf *Config) (*pubsearch.WeakTermSet, error) {
+	out := conf.SearchWeakTerms
+	if v := strings.TrimSpace(os.Getenv("SEARCH_WEAK_TERMS")); v != "" {
+		out.Terms = append(slices.Clone(out.Terms), strings.Split(v, ",")...)
+	}
+	if v := strings.TrimSpace(os.Getenv("SEARCH_WEAK_TERMS_LANGUAGES")); v != "" {
+		out.Languages = strings.Split(v, ",")
+	}
+	if v := strings.TrimSpace(os.Getenv("SEARCH_WEAK_TERM_WEIGHT")); v != "" {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SEARCH_WEAK_TERM_WEIGHT: %w", err)
+		}
+		// Zero ignores weak terms, rather than leaving the default weight
+		out.Weight, out.Ignore = n, n == 0
+	}
+	return pubsearch.NewWeakTermSet(out)
+}
+
+// getSearchReranker returns the re-ranking model of SearchRerankModel, overridden by SEARCH_RERANK_MODEL, or nil
+// when results aren't re-ranked
+func getSearchReranker(conf *Config) (search.Reranker, error) {
+	path := strings.TrimSpace(cmp.Or(os.Getenv("SEARCH_RERANK_MODEL"), conf.SearchRerankModel))
+	if path == "" {
+		return nil, nil
+	}
+	return search.LoadLogisticModel(path)
+}
+
+// getSearchFinancialIDs returns the BIC/LEI mapping of SearchFinancialIDMapping, overridden by
+// SEARCH_FINANCIAL_ID_MAPPING, or nil when there isn't one
+func getSearchFinancialIDs(conf *Config) (*search.FinancialIDMapping, error) {
+	path := strings.TrimSpace(cmp.Or(os.Getenv("SEARCH_FINANCIAL_ID_MAPPING"), conf.SearchFinancialIDMapping))
+	if path == "" {
+		return nil, nil
+	}
+	return search.LoadFinancialIDMapping(path)
+}
+
+// getSearchProfiles returns the configured scoring profiles, with their default overridden by SEARCH_DEFAULT_PROFILE
+func getSearchProfiles(conf *Config) (search.ProfilesConfig, error) {
+	out := conf.SearchProfiles
+	out.Profiles = make(map[string]search.Profile, len(conf.SearchProfiles.Profiles))
+	for name, profile := range conf.SearchProfiles.Profiles {
+		types := make(map[pubsearch.EntityType]pubsearch.Weights, len(profile.Weights.Types))
+		for entityType, weights := range profile.Weights.Types {
+			types[pubsearch.EntityType(strings.ToLower(string(entityType)))] = weights
+		}
+		profile.Weights.Types = types
+
+		sources := make([]pubsearch.SourceList, len(profile.Sources))
+		for i, source := range profile.Sources {
+			sources[i] = pubsearch.SourceList(strings.ToLower(strings.TrimSpace(string(source))))
+		}
+		profile.Sources = sources
+		out.Profiles[name] = profile
+	}
+	if v := strings.TrimSpace(os.Getenv("SEARCH_DEFAULT_PROFILE")); v != "" {
+		out.Default = v
+	}
+	return out, out.Validate()
+}
+
+func parseRateLimit(v string) (auth.RateLimit, error) {
+	requests, interval, found := strings.Cut(v, "/")
+	if !found {
+		return auth.RateLimit{}, fmt.Errorf("%q is not written as requests/interval", v)
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if err != nil {
+		return auth.RateLimit{}, fmt.Errorf("requests: %w", err)
+	}
+	dur, err := time.ParseDuration(strings.TrimSpace(interval))
+	if err != nil {
+		return auth.RateLimit{}, fmt.Errorf("interval: %w", err)
+	}
+	return auth.RateLimit{Requests: n, Interval: dur}, nil
+}
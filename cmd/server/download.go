@@ -7,37 +7,100 @@ package main
 import (
 	"cmp"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/moov-io/watchman/internal/customlists"
 	"github.com/moov-io/watchman/internal/download"
+	"github.com/moov-io/watchman/internal/events"
+	"github.com/moov-io/watchman/internal/prepare"
 	"github.com/moov-io/watchman/internal/search"
+	"github.com/moov-io/watchman/internal/versions"
+	"github.com/moov-io/watchman/internal/watches"
+	"github.com/moov-io/watchman/internal/webhooks"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
 
+	"github.com/moov-io/base"
 	"github.com/moov-io/base/log"
 )
 
-func setupPeriodicRefreshing(ctx context.Context, logger log.Logger, errs chan error, conf download.Config, downloader download.Downloader, searchService search.Service) error {
-	err := refreshAllSources(ctx, logger, downloader, searchService)
+// refreshListener is called after each successful refresh with the changes applied to the search index
+// and the latest entities. changes is nil after the initial refresh.
+type refreshListener func(ctx context.Context, changes *search.EntityChanges, current []pubsearch.Entity[pubsearch.Value])
+
+// alertListener is called when a refresh of a list isn't applied, so its previous entities are still searched
+type alertListener func(ctx context.Context, alert download.ListAlert)
+
+func setupPeriodicRefreshing(ctx context.Context, logger log.Logger, errs chan error, conf download.Config, downloader download.Downloader, scheduler *download.Scheduler, searchService search.Service, store download.Store, versionService versions.Service, alerts []alertListener, listeners ...refreshListener) error {
+	shrinkThreshold, err := getShrinkThreshold(conf)
 	if err != nil {
 		return err
 	}
+	state := &refreshState{
+		snapshotPath:    getSnapshotPath(conf),
+		store:           store,
+		versions:        versionService,
+		host:            instanceName(),
+		interval:        getRefreshInterval(conf),
+		scheduler:       scheduler,
+		shrinkThreshold: shrinkThreshold,
+		alerts:          alerts,
+	}
+
+	// Every enabled list is downloaded below, then refreshed on its own schedule
+	scheduler.Refreshed(scheduler.Enabled(), time.Now())
 
-	// Setup periodic refreshing
-	ticker := time.NewTicker(getRefreshInterval(conf))
-	defer ticker.Stop()
+	if state.restoreFromStore(ctx, logger, searchService) || restoreSnapshot(logger, state.snapshotPath, searchService) {
+		// Searches are served from the restored entities while the lists are downloaded
+		go func() {
+			err := refreshAllSources(ctx, logger, downloader, searchService, false, state, listeners)
+			if err != nil {
+				logger.Error().LogErrorf("problem refreshing lists after restoring entities: %v", err)
+			}
+		}()
+	} else {
+		err := refreshAllSources(ctx, logger, downloader, searchService, true, state, listeners)
+		if err != nil {
+			return err
+		}
+	}
 
+	// Refresh each list when it's due, or right away after it's enabled
 	go func() {
 		for {
+			timer := time.NewTimer(time.Until(nextRefresh(scheduler)))
 			select {
 			case <-ctx.Done():
+				timer.Stop()
 				errs <- nil
 				return
 
-			case <-ticker.C:
-				err := refreshAllSources(ctx, logger, downloader, searchService)
+			case <-scheduler.Changed():
+				removeDisabledLists(ctx, logger, searchService, state, listeners)
+
+			case req := <-scheduler.Requests():
+				if req.Approve {
+					req.Reply(approveList(ctx, logger, searchService, state, req.List, listeners))
+				} else {
+					req.Reply(refreshList(ctx, logger, downloader, searchService, state, req.List, listeners))
+				}
+
+			case <-timer.C:
+			}
+			timer.Stop()
+
+			if due := scheduler.Due(time.Now()); len(due) > 0 {
+				err := refreshSources(ctx, logger, downloader, searchService, false, state, due, listeners)
 				if err != nil {
-					errs <- err
+					// Failed lists keep their previous entities and are retried on their next schedule
+					logger.Error().LogErrorf("problem refreshing %v: %v", due, err)
 				}
 			}
 		}
@@ -46,6 +109,15 @@ func setupPeriodicRefreshing(ctx context.Context, logger log.Logger, errs chan e
 	return nil
 }
 
+// nextRefresh returns when the next list is due, which is far off when there aren't any scheduled
+func nextRefresh(scheduler *download.Scheduler) time.Time {
+	next, ok := scheduler.NextRefresh()
+	if !ok {
+		return time.Now().Add(defaultRefreshInterval)
+	}
+	return next
+}
+
 const (
 	defaultRefreshInterval = 12 * time.Hour
 )
@@ -61,17 +133,613 @@ func getRefreshInterval(conf download.Config) time.Duration {
 	return cmp.Or(conf.RefreshInterval, defaultRefreshInterval)
 }
 
-func refreshAllSources(ctx context.Context, logger log.Logger, downloader download.Downloader, searchService search.Service) error {
-	// Initial data load
-	stats, err := downloader.RefreshAll(ctx)
+const (
+	defaultShrinkThreshold = 0.2
+)
+
+// getShrinkThreshold returns the fraction of a list's entities a refresh can remove before it's held,
+// overridden by DOWNLOAD_SHRINK_THRESHOLD
+func getShrinkThreshold(conf download.Config) (float64, error) {
+	threshold := defaultShrinkThreshold
+	if conf.ShrinkThreshold != nil {
+		threshold = *conf.ShrinkThreshold
+	}
+	if override := strings.TrimSpace(os.Getenv("DOWNLOAD_SHRINK_THRESHOLD")); override != "" {
+		n, err := strconv.ParseFloat(override, 64)
+		if err != nil {
+			return threshold, fmt.Errorf("invalid DOWNLOAD_SHRINK_THRESHOLD: %w", err)
+		}
+		threshold = n
+	}
+	if threshold < 0 || threshold > 1 {
+		return threshold, fmt.Errorf("download shrink threshold of %v must be between 0 and 1", threshold)
+	}
+	return threshold, nil
+}
+
+func getSnapshotPath(conf download.Config) string {
+	return strings.TrimSpace(cmp.Or(os.Getenv("SNAPSHOT_PATH"), conf.SnapshotPath))
+}
+
+// getDownloadCacheDirectory returns where downloaded files are kept, overridden by DOWNLOAD_CACHE_DIR.
+// Files are kept under the system's temp directory by default, and "off" downloads every file each refresh.
+func getDownloadCacheDirectory(conf download.Config) string {
+	dir := strings.TrimSpace(cmp.Or(os.Getenv("DOWNLOAD_CACHE_DIR"), conf.CacheDirectory))
+	switch {
+	case strings.EqualFold(dir, "off"):
+		return ""
+	case dir == "":
+		return filepath.Join(os.TempDir(), "watchman-downloads")
+	}
+	return dir
+}
+
+// getDownloadChecksums returns the SHA-256 list files must have by file name, along with those from
+// DOWNLOAD_CHECKSUMS (sdn.csv=<sha256>,add.csv=<sha256>)
+func getDownloadChecksums(conf download.Config) (map[string]string, error) {
+	out := make(map[string]string)
+	for name, sum := range conf.Checksums {
+		out[strings.TrimSpace(name)] = strings.TrimSpace(sum)
+	}
+	if v := strings.TrimSpace(os.Getenv("DOWNLOAD_CHECKSUMS")); v != "" {
+		for _, pair := range strings.Split(v, ",") {
+			name, sum, found := strings.Cut(pair, "=")
+			if !found {
+				return nil, fmt.Errorf("invalid DOWNLOAD_CHECKSUMS entry %q", pair)
+			}
+			out[strings.TrimSpace(name)] = strings.TrimSpace(sum)
+		}
+	}
+	for name, sum := range out {
+		if bs, err := hex.DecodeString(sum); err != nil || len(bs) != sha256.Size {
+			return nil, fmt.Errorf("checksum of %s isn't a hex encoded SHA-256", name)
+		}
+	}
+	return out, nil
+}
+
+// getDownloadConcurrency returns how many lists are downloaded at once, overridden by DOWNLOAD_CONCURRENCY
+func getDownloadConcurrency(conf download.Config) int {
+	if override := strings.TrimSpace(os.Getenv("DOWNLOAD_CONCURRENCY")); override != "" {
+		n, err := strconv.Atoi(override)
+		if err == nil && n > 0 {
+			return n
+		}
+	}
+	return conf.Concurrency
+}
+
+// getDownloadListTimeout returns how long each list can take to download and parse, overridden by
+// DOWNLOAD_LIST_TIMEOUT
+func getDownloadListTimeout(conf download.Config) time.Duration {
+	if override := strings.TrimSpace(os.Getenv("DOWNLOAD_LIST_TIMEOUT")); override != "" {
+		dur, err := time.ParseDuration(override)
+		if err == nil {
+			return dur
+		}
+	}
+	return conf.ListTimeout
+}
+
+// getOFACFormat returns which OFAC files are downloaded, overridden by OFAC_FORMAT
+func getOFACFormat(conf download.Config) string {
+	return strings.ToLower(strings.TrimSpace(cmp.Or(os.Getenv("OFAC_FORMAT"), conf.OFACFormat, download.OFACFormatCSV)))
+}
+
+// getDownloadHTTPConfig reads the download proxy, CA bundle and timeout, overridden by DOWNLOAD_PROXY_URL,
+// DOWNLOAD_CA_BUNDLE and DOWNLOAD_TIMEOUT
+func getDownloadHTTPConfig(conf download.Config) download.HTTPConfig {
+	out := conf.HTTP
+	out.ProxyURL = strings.TrimSpace(cmp.Or(os.Getenv("DOWNLOAD_PROXY_URL"), out.ProxyURL))
+	out.CABundle = strings.TrimSpace(cmp.Or(os.Getenv("DOWNLOAD_CA_BUNDLE"), out.CABundle))
+
+	if override := strings.TrimSpace(os.Getenv("DOWNLOAD_TIMEOUT")); override != "" {
+		dur, err := time.ParseDuration(override)
+		if err == nil {
+			out.Timeout = dur
+		}
+	}
+	return out
+}
+
+// restoreSnapshot loads the entities saved by a previous refresh, returning false when there isn't a snapshot
+func restoreSnapshot(logger log.Logger, path string, searchService search.Service) bool {
+	if path == "" {
+		return false
+	}
+	snapshot, err := download.ReadSnapshot(path)
 	if err != nil {
-		return err
+		if !errors.Is(err, os.ErrNotExist) {
+			logger.Warn().Logf("unable to restore snapshot: %v", err)
+		}
+		return false
+	}
+	searchService.UpdateEntities(snapshot.Entities)
+
+	// The restored lists are only as fresh as the snapshot
+	lists := make(map[string]int)
+	for _, entity := range snapshot.Entities {
+		lists[string(entity.Source)]++
+	}
+	download.RecordRefresh(lists, snapshot.SavedAt)
+
+	logger.Info().Logf("restored %d entities from snapshot saved at %v", len(snapshot.Entities), snapshot.SavedAt)
+	return true
+}
+
+// refreshState is kept between each refresh
+type refreshState struct {
+	snapshotPath string
+
+	// store is optional and shares refreshes between Watchman instances
+	store    download.Store
+	host     string
+	interval time.Duration
+
+	// lastRefreshID is the stored refresh whose entities are being searched
+	lastRefreshID string
+
+	// versions is optional and records the history of each list
+	versions versions.Service
+
+	// scheduler is optional and chooses which lists are refreshed, otherwise every included list is
+	scheduler *download.Scheduler
+
+	// shrinkThreshold is the fraction of a list's entities a scheduled refresh can remove before it's held
+	shrinkThreshold float64
+
+	alerts []alertListener
+}
+
+const (
+	// refreshLockTTL is how long an instance can download the lists before another instance may start
+	refreshLockTTL = 15 * time.Minute
+)
+
+func instanceName() string {
+	if name := strings.TrimSpace(os.Getenv("INSTANCE_NAME")); name != "" {
+		return name
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		return hostname
+	}
+	return base.ID()
+}
+
+// restoreFromStore loads the entities of the latest stored refresh, returning false when there isn't one
+func (s *refreshState) restoreFromStore(ctx context.Context, logger log.Logger, searchService search.Service) bool {
+	if s.store == nil {
+		return false
+	}
+	latest, err := s.store.LatestRefresh(ctx)
+	if err != nil || latest == nil {
+		if err != nil {
+			logger.Warn().Logf("unable to read latest stored refresh: %v", err)
+		}
+		return false
+	}
+	entities, err := s.store.LoadEntities(ctx)
+	if err != nil {
+		logger.Warn().Logf("unable to load stored entities: %v", err)
+		return false
+	}
+	searchService.UpdateEntities(entities)
+	s.lastRefreshID = latest.RefreshID
+	download.RecordRefresh(latest.Lists, latest.EndedAt)
+
+	logger.Info().Logf("restored %d entities from refresh %s by %s", len(entities), latest.RefreshID, latest.Host)
+	return true
+}
+
+// latestEntities downloads the lists, or loads them from the store when another instance refreshed them within
+// the refresh interval. With a scheduler only lists are downloaded and the current entities of other enabled
+// lists are kept. False is returned when the searched entities are already up to date.
+func (s *refreshState) latestEntities(ctx context.Context, logger log.Logger, downloader download.Downloader, lists []pubsearch.SourceList, current []pubsearch.Entity[pubsearch.Value], initial bool) ([]pubsearch.Entity[pubsearch.Value], bool, error) {
+	if s.store != nil {
+		latest, err := s.store.LatestRefresh(ctx)
+		switch {
+		case err != nil:
+			logger.Warn().Logf("unable to read latest stored refresh: %v", err)
+
+		case latest != nil && latest.Host != s.host && time.Since(latest.EndedAt) < s.interval:
+			if latest.RefreshID == s.lastRefreshID {
+				return nil, false, nil
+			}
+			entities, err := s.store.LoadEntities(ctx)
+			if err == nil {
+				s.lastRefreshID = latest.RefreshID
+				download.RecordRefresh(latest.Lists, latest.EndedAt)
+				logger.Info().Logf("loaded %d entities from refresh %s by %s", len(entities), latest.RefreshID, latest.Host)
+				return s.withoutDisabled(entities), true, nil
+			}
+			logger.Warn().Logf("unable to load stored entities: %v", err)
+		}
+
+		acquired, err := s.store.AcquireRefreshLock(ctx, s.host, refreshLockTTL)
+		switch {
+		case err != nil:
+			logger.Warn().Logf("unable to acquire refresh lock: %v", err)
+		case acquired:
+			defer s.store.ReleaseRefreshLock(ctx, s.host)
+		case !initial:
+			// Pick up the other instance's refresh next time
+			logger.Info().Log("skipping refresh while another instance downloads the lists")
+			return nil, false, nil
+		}
+	}
+
+	entities, _, err := s.downloadEntities(ctx, logger, downloader, lists, current)
+	if err != nil {
+		return nil, false, err
+	}
+	return entities, true, nil
+}
+
+// downloadEntities downloads lists, then records and stores their entities. The versions recorded for
+// each list are returned when versions are kept. With a scheduler, lists which failed or shrunk beyond
+// the threshold are alerted and keep their current entities.
+func (s *refreshState) downloadEntities(ctx context.Context, logger log.Logger, downloader download.Downloader, lists []pubsearch.SourceList, current []pubsearch.Entity[pubsearch.Value]) ([]pubsearch.Entity[pubsearch.Value], []versions.Version, error) {
+	if s.scheduler == nil {
+		stats, err := downloader.RefreshAll(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		logRefresh(logger, stats)
+		return stats.Entities, s.record(ctx, logger, stats, stats.Entities, stats.Lists), nil
+	}
+
+	previous := countSources(current)
+	stats, err := downloader.RefreshLists(ctx, lists)
+	if err != nil {
+		for _, list := range lists {
+			if _, loaded := stats.Lists[string(list)]; !loaded {
+				// Each failed list is alerted with its own error when the downloader reports it
+				s.alert(ctx, logger, download.ListAlert{
+					List:     list,
+					Reason:   download.AlertRefreshFailed,
+					Error:    cmp.Or(stats.Failed[string(list)], err.Error()),
+					Previous: previous[list],
+				})
+			}
+		}
+		if len(stats.Lists) == 0 {
+			return nil, nil, err
+		}
 	}
-	logger.Info().Logf("data refreshed - %v entities from %v lists took %v",
-		len(stats.Entities), len(stats.Lists), stats.EndedAt.Sub(stats.StartedAt))
+	logRefresh(logger, stats)
 
-	// Replace in-mem entities for search.Service
-	searchService.UpdateEntities(stats.Entities)
+	stats = s.holdShrunkLists(ctx, logger, stats, previous)
+	entities, counts := s.mergeRefreshed(current, stats)
+	return entities, s.record(ctx, logger, stats, entities, counts), nil
+}
+
+func logRefresh(logger log.Logger, stats download.Stats) {
+	logger.Info().Logf("data refreshed - %v entities from %v lists (%d unchanged) took %v",
+		len(stats.Entities), len(stats.Lists), len(stats.Unchanged), stats.EndedAt.Sub(stats.StartedAt))
+}
+
+// record keeps a version of each refreshed list and stores the searched entities
+func (s *refreshState) record(ctx context.Context, logger log.Logger, stats download.Stats, entities []pubsearch.Entity[pubsearch.Value], counts map[string]int) []versions.Version {
+	var recorded []versions.Version
+	if s.versions != nil {
+		var err error
+		recorded, err = s.versions.Record(ctx, stats.EndedAt, stats.Lists, stats.Entities)
+		if err != nil {
+			logger.Error().LogErrorf("problem recording list versions: %v", err)
+		}
+	}
+	if s.store != nil {
+		refresh := download.Refresh{
+			RefreshID: base.ID(),
+			Host:      s.host,
+			Lists:     counts,
+			StartedAt: stats.StartedAt,
+			EndedAt:   stats.EndedAt,
+		}
+		if err := s.store.SaveRefresh(ctx, refresh, entities); err != nil {
+			logger.Error().LogErrorf("problem storing refresh: %v", err)
+		} else {
+			s.lastRefreshID = refresh.RefreshID
+		}
+	}
+	return recorded
+}
+
+// holdShrunkLists takes the lists which lost more than shrinkThreshold of their previous entities out of
+// stats, holding them until they're approved. Other refreshed lists discard any earlier hold.
+func (s *refreshState) holdShrunkLists(ctx context.Context, logger log.Logger, stats download.Stats, previous map[pubsearch.SourceList]int) download.Stats {
+	shrunk := make(map[pubsearch.SourceList][]pubsearch.Entity[pubsearch.Value])
+	for name, count := range stats.Lists {
+		list := pubsearch.SourceList(name)
+		if shrunkBeyond(previous[list], count, s.shrinkThreshold) {
+			shrunk[list] = nil
+		} else {
+			s.scheduler.Release(list)
+		}
+	}
+	if len(shrunk) == 0 {
+		return stats
+	}
+
+	out := stats
+	out.Lists = make(map[string]int, len(stats.Lists))
+	out.Entities = make([]pubsearch.Entity[pubsearch.Value], 0, len(stats.Entities))
+	for name, count := range stats.Lists {
+		if _, held := shrunk[pubsearch.SourceList(name)]; !held {
+			out.Lists[name] = count
+		}
+	}
+	for _, entity := range stats.Entities {
+		if held, exists := shrunk[entity.Source]; exists {
+			shrunk[entity.Source] = append(held, entity)
+		} else {
+			out.Entities = append(out.Entities, entity)
+		}
+	}
+
+	for list, entities := range shrunk {
+		s.scheduler.Hold(list, entities, previous[list], stats.EndedAt)
+		s.alert(ctx, logger, download.ListAlert{
+			List:     list,
+			Reason:   download.AlertListShrunk,
+			Entities: len(entities),
+			Previous: previous[list],
+		})
+	}
+	return out
+}
+
+// shrunkBeyond returns true when a list which had previous entities lost more than threshold of them
+func shrunkBeyond(previous, count int, threshold float64) bool {
+	if previous == 0 || count >= previous {
+		return false
+	}
+	return float64(previous-count)/float64(previous) > threshold
+}
+
+func countSources(entities []pubsearch.Entity[pubsearch.Value]) map[pubsearch.SourceList]int {
+	out := make(map[pubsearch.SourceList]int)
+	for _, entity := range entities {
+		out[entity.Source]++
+	}
+	return out
+}
+
+// alert logs, counts and sends each alertListener a refresh which wasn't applied
+func (s *refreshState) alert(ctx context.Context, logger log.Logger, alert download.ListAlert) {
+	alert.RaisedAt = time.Now().In(time.UTC)
+
+	logger.Error().With(log.Fields{
+		"list":     log.String(string(alert.List)),
+		"reason":   log.String(alert.Reason),
+		"entities": log.Int(alert.Entities),
+		"previous": log.Int(alert.Previous),
+	}).Logf("refresh of %s wasn't applied, searching its %d previous entities", alert.List, alert.Previous)
+
+	download.RecordAlert(alert)
+	for _, listener := range s.alerts {
+		go listener(ctx, alert)
+	}
+}
+
+// mergeRefreshed replaces the entities of the refreshed lists, keeping those of other enabled lists
+func (s *refreshState) mergeRefreshed(current []pubsearch.Entity[pubsearch.Value], stats download.Stats) ([]pubsearch.Entity[pubsearch.Value], map[string]int) {
+	out := make([]pubsearch.Entity[pubsearch.Value], 0, len(current)+len(stats.Entities))
+	counts := make(map[string]int, len(stats.Lists))
+	for _, entity := range s.withoutDisabled(current) {
+		if _, refreshed := stats.Lists[string(entity.Source)]; !refreshed {
+			out = append(out, entity)
+			counts[string(entity.Source)]++
+		}
+	}
+	for list, count := range stats.Lists {
+		counts[list] = count
+	}
+	return append(out, stats.Entities...), counts
+}
 
+// withoutDisabled drops the entities of lists which were disabled while Watchman is running
+func (s *refreshState) withoutDisabled(entities []pubsearch.Entity[pubsearch.Value]) []pubsearch.Entity[pubsearch.Value] {
+	if s.scheduler == nil {
+		return entities
+	}
+	out := make([]pubsearch.Entity[pubsearch.Value], 0, len(entities))
+	for _, entity := range entities {
+		if !s.scheduler.IsDisabled(entity.Source) {
+			out = append(out, entity)
+		}
+	}
+	return out
+}
+
+// refreshAllSources downloads every enabled list
+func refreshAllSources(ctx context.Context, logger log.Logger, downloader download.Downloader, searchService search.Service, initial bool, state *refreshState, listeners []refreshListener) error {
+	var lists []pubsearch.SourceList
+	if state.scheduler != nil {
+		lists = state.scheduler.Enabled()
+	}
+	return refreshSources(ctx, logger, downloader, searchService, initial, state, lists, listeners)
+}
+
+// refreshSources downloads lists and applies what changed to the searched entities
+func refreshSources(ctx context.Context, logger log.Logger, downloader download.Downloader, searchService search.Service, initial bool, state *refreshState, lists []pubsearch.SourceList, listeners []refreshListener) error {
+	if state.scheduler != nil {
+		// Failed refreshes are retried on the list's next schedule
+		state.scheduler.Refreshed(lists, time.Now())
+	}
+
+	entities, updated, err := state.latestEntities(ctx, logger, downloader, lists, searchService.Entities(), initial)
+	if err != nil || !updated {
+		return err
+	}
+	applyEntities(ctx, logger, searchService, initial, state, state.applyPins(ctx, logger, entities), listeners)
 	return nil
 }
+
+// refreshList downloads a list right away, such as after an emergency update, even when another instance
+// refreshed it recently. The changes to its searched entities and its recorded version are returned.
+func refreshList(ctx context.Context, logger log.Logger, downloader download.Downloader, searchService search.Service, state *refreshState, list pubsearch.SourceList, listeners []refreshListener) (download.ListRefresh, error) {
+	lists := []pubsearch.SourceList{list}
+	state.scheduler.Refreshed(lists, time.Now())
+
+	entities, recorded, err := state.downloadEntities(ctx, logger, downloader, lists, searchService.Entities())
+	if err != nil {
+		return download.ListRefresh{}, err
+	}
+	changes := applyEntities(ctx, logger, searchService, false, state, state.applyPins(ctx, logger, entities), listeners)
+
+	out := newListRefresh(list, changes, recorded, searchService.Entities())
+	_, out.Held = state.scheduler.Held(list)
+	logger.Info().Logf("refreshed %s on request with %d entities", list, out.Entities)
+	return out, nil
+}
+
+// approveList searches the held refresh of a list in place of its previous entities
+func approveList(ctx context.Context, logger log.Logger, searchService search.Service, state *refreshState, list pubsearch.SourceList, listeners []refreshListener) (download.ListRefresh, error) {
+	held, ok := state.scheduler.Release(list)
+	if !ok {
+		return download.ListRefresh{}, fmt.Errorf("%s: %w", list, download.ErrNoHeldRefresh)
+	}
+	now := time.Now().In(time.UTC)
+	stats := download.Stats{
+		Lists:     map[string]int{string(list): len(held)},
+		Entities:  held,
+		StartedAt: now,
+		EndedAt:   now,
+	}
+	entities, counts := state.mergeRefreshed(searchService.Entities(), stats)
+	recorded := state.record(ctx, logger, stats, entities, counts)
+	changes := applyEntities(ctx, logger, searchService, false, state, state.applyPins(ctx, logger, entities), listeners)
+
+	out := newListRefresh(list, changes, recorded, searchService.Entities())
+	logger.Info().Logf("approved held refresh of %s with %d entities", list, out.Entities)
+	return out, nil
+}
+
+func newListRefresh(list pubsearch.SourceList, changes *search.EntityChanges, recorded []versions.Version, current []pubsearch.Entity[pubsearch.Value]) download.ListRefresh {
+	out := download.ListRefresh{
+		List:        list,
+		ListChanges: download.CountChanges(*changes, current)[list],
+		RefreshedAt: time.Now().In(time.UTC),
+	}
+	for _, version := range recorded {
+		if version.List == string(list) {
+			out.VersionID = version.VersionID
+		}
+	}
+	return out
+}
+
+// applyPins keeps serving the entities of pinned lists from their pinned version
+func (s *refreshState) applyPins(ctx context.Context, logger log.Logger, entities []pubsearch.Entity[pubsearch.Value]) []pubsearch.Entity[pubsearch.Value] {
+	if s.versions == nil {
+		return entities
+	}
+	out, err := s.versions.ApplyPins(ctx, entities)
+	if err != nil {
+		logger.Error().LogErrorf("problem applying pinned list versions: %v", err)
+	}
+	return out
+}
+
+// removeDisabledLists stops searching the entities of lists disabled at runtime
+func removeDisabledLists(ctx context.Context, logger log.Logger, searchService search.Service, state *refreshState, listeners []refreshListener) {
+	current := searchService.Entities()
+	if next := state.withoutDisabled(current); len(next) < len(current) {
+		applyEntities(ctx, logger, searchService, false, state, next, listeners)
+	}
+}
+
+// applyEntities replaces the searched entities, saves a snapshot and calls each listener. The changes
+// to the searched entities are returned, which are nil after the initial refresh.
+func applyEntities(ctx context.Context, logger log.Logger, searchService search.Service, initial bool, state *refreshState, entities []pubsearch.Entity[pubsearch.Value], listeners []refreshListener) *search.EntityChanges {
+	var changes *search.EntityChanges
+	if initial {
+		// Replace in-mem entities for search.Service
+		searchService.UpdateEntities(entities)
+	} else {
+		// Only apply what changed, so unchanged entities aren't replaced in memory
+		diff := download.DiffEntities(searchService.Entities(), entities)
+		searchService.ApplyChanges(diff)
+		changes = &diff
+
+		logger.Info().Logf("applied %d added, %d modified and %d removed entities",
+			len(diff.Added), len(diff.Modified), len(diff.Removed))
+	}
+
+	current := searchService.Entities()
+	if state.snapshotPath != "" {
+		if err := download.WriteSnapshot(state.snapshotPath, current); err != nil {
+			logger.Error().LogErrorf("problem saving snapshot: %v", err)
+		}
+	}
+
+	for _, listener := range listeners {
+		go listener(ctx, changes, current)
+	}
+	return changes
+}
+
+// notifyWebhooks sends subscribers what changed since the last refresh
+func notifyWebhooks(logger log.Logger, webhookService webhooks.Service) refreshListener {
+	return func(ctx context.Context, changes *search.EntityChanges, current []pubsearch.Entity[pubsearch.Value]) {
+		if changes == nil {
+			return
+		}
+
+		err := webhookService.ListsRefreshed(ctx, download.CountChanges(*changes, current))
+		if err != nil {
+			logger.Error().LogErrorf("problem sending webhooks: %v", err)
+		}
+	}
+}
+
+// alertWebhooks sends subscribers each refresh which wasn't applied
+func alertWebhooks(logger log.Logger, webhookService webhooks.Service) alertListener {
+	return func(ctx context.Context, alert download.ListAlert) {
+		err := webhookService.ListAlert(ctx, alert)
+		if err != nil {
+			logger.Error().LogErrorf("problem sending alert webhooks: %v", err)
+		}
+	}
+}
+
+// publishRefreshEvents publishes each refreshed list along with the entities which changed
+func publishRefreshEvents(logger log.Logger, publisher events.Publisher) refreshListener {
+	return func(ctx context.Context, changes *search.EntityChanges, current []pubsearch.Entity[pubsearch.Value]) {
+		err := publisher.Publish(ctx, events.RefreshEvents(changes, current)...)
+		if err != nil {
+			logger.Error().LogErrorf("problem publishing refresh events: %v", err)
+		}
+	}
+}
+
+// rescreenWatches searches every watch against the latest entities
+func rescreenWatches(logger log.Logger, watchService watches.Service) refreshListener {
+	return func(ctx context.Context, _ *search.EntityChanges, _ []pubsearch.Entity[pubsearch.Value]) {
+		err := watchService.Rescreen(ctx)
+		if err != nil {
+			logger.Error().LogErrorf("problem rescreening watches: %v", err)
+		}
+	}
+}
+
+// customListPipeline returns the prepare pipeline configured for custom lists under Download.Prepare.custom,
+// which is nil when there isn't one
+func customListPipeline(conf download.Config) (*prepare.Pipeline, error) {
+	pipelineConf, exists := conf.Prepare[string(pubsearch.SourceCustomList)]
+	if !exists {
+		return nil, nil
+	}
+	return prepare.NewPipeline(pipelineConf)
+}
+
+// reloadCustomLists picks up custom lists changed by other instances sharing the database
+func reloadCustomLists(logger log.Logger, customListService customlists.Service) refreshListener {
+	return func(ctx context.Context, _ *search.EntityChanges, _ []pubsearch.Entity[pubsearch.Value]) {
+		err := customListService.Load(ctx)
+		if err != nil {
+			logger.Error().LogErrorf("problem reloading custom lists: %v", err)
+		}
+	}
+}
@@ -6,12 +6,19 @@ package main
 
 import (
 	"context"
+	"errors"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/moov-io/watchman/internal/database"
 	"github.com/moov-io/watchman/internal/download"
+	"github.com/moov-io/watchman/internal/prepare"
 	"github.com/moov-io/watchman/internal/search"
+	"github.com/moov-io/watchman/internal/versions"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
 
 	"github.com/moov-io/base/log"
 	"github.com/stretchr/testify/require"
@@ -30,6 +37,76 @@ func TestGetRefreshInterval(t *testing.T) {
 	require.Equal(t, 1*time.Hour, got)
 }
 
+func TestGetDownloadHTTPConfig(t *testing.T) {
+	conf := download.Config{
+		HTTP: download.HTTPConfig{
+			ProxyURL: "http://proxy:3128",
+			Timeouts: map[string]time.Duration{"us_csl": time.Minute},
+		},
+	}
+	got := getDownloadHTTPConfig(conf)
+	require.Equal(t, conf.HTTP, got)
+
+	t.Setenv("DOWNLOAD_PROXY_URL", "http://other:8080")
+	t.Setenv("DOWNLOAD_CA_BUNDLE", "/etc/ssl/corp.pem")
+	t.Setenv("DOWNLOAD_TIMEOUT", "2m")
+
+	got = getDownloadHTTPConfig(conf)
+	require.Equal(t, "http://other:8080", got.ProxyURL)
+	require.Equal(t, "/etc/ssl/corp.pem", got.CABundle)
+	require.Equal(t, 2*time.Minute, got.Timeout)
+	require.Equal(t, time.Minute, got.Timeouts["us_csl"])
+}
+
+func TestGetDownloadConcurrency(t *testing.T) {
+	require.Equal(t, 0, getDownloadConcurrency(download.Config{}))
+	require.Equal(t, 8, getDownloadConcurrency(download.Config{Concurrency: 8}))
+	require.Equal(t, time.Duration(0), getDownloadListTimeout(download.Config{}))
+
+	t.Setenv("DOWNLOAD_CONCURRENCY", "2")
+	t.Setenv("DOWNLOAD_LIST_TIMEOUT", "10m")
+	require.Equal(t, 2, getDownloadConcurrency(download.Config{Concurrency: 8}))
+	require.Equal(t, 10*time.Minute, getDownloadListTimeout(download.Config{ListTimeout: time.Minute}))
+}
+
+func TestGetDownloadCacheDirectory(t *testing.T) {
+	require.Equal(t, filepath.Join(os.TempDir(), "watchman-downloads"), getDownloadCacheDirectory(download.Config{}))
+	require.Equal(t, "/var/cache/watchman", getDownloadCacheDirectory(download.Config{CacheDirectory: "/var/cache/watchman"}))
+
+	t.Setenv("DOWNLOAD_CACHE_DIR", "off")
+	require.Equal(t, "", getDownloadCacheDirectory(download.Config{CacheDirectory: "/var/cache/watchman"}))
+}
+
+func TestGetDownloadChecksums(t *testing.T) {
+	sdn := strings.Repeat("ab", 32)
+	add := strings.Repeat("01", 32)
+
+	got, err := getDownloadChecksums(download.Config{Checksums: map[string]string{"sdn.csv": sdn}})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"sdn.csv": sdn}, got)
+
+	t.Setenv("DOWNLOAD_CHECKSUMS", " add.csv="+add+", sdn.csv="+add)
+	got, err = getDownloadChecksums(download.Config{Checksums: map[string]string{"sdn.csv": sdn}})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"sdn.csv": add, "add.csv": add}, got)
+
+	t.Setenv("DOWNLOAD_CHECKSUMS", "add.csv")
+	_, err = getDownloadChecksums(download.Config{})
+	require.ErrorContains(t, err, "invalid DOWNLOAD_CHECKSUMS entry")
+
+	t.Setenv("DOWNLOAD_CHECKSUMS", "add.csv=abc")
+	_, err = getDownloadChecksums(download.Config{})
+	require.ErrorContains(t, err, "checksum of add.csv isn't a hex encoded SHA-256")
+}
+
+func TestGetOFACFormat(t *testing.T) {
+	require.Equal(t, "csv", getOFACFormat(download.Config{}))
+	require.Equal(t, "advanced", getOFACFormat(download.Config{OFACFormat: "Advanced"}))
+
+	t.Setenv("OFAC_FORMAT", "csv")
+	require.Equal(t, "csv", getOFACFormat(download.Config{OFACFormat: "advanced"}))
+}
+
 func TestDownloader_setupPeriodicRefreshing(t *testing.T) {
 	ctx, cancelFunc := context.WithCancel(context.Background())
 	logger := log.NewTestLogger()
@@ -41,6 +118,9 @@ func TestDownloader_setupPeriodicRefreshing(t *testing.T) {
 	dl, err := download.NewDownloader(logger, conf)
 	require.NoError(t, err)
 
+	scheduler, err := download.NewScheduler(conf, time.Hour)
+	require.NoError(t, err)
+
 	searchService := search.NewService(logger)
 
 	go func() {
@@ -49,9 +129,324 @@ func TestDownloader_setupPeriodicRefreshing(t *testing.T) {
 	}()
 
 	errs := make(chan error, 1)
-	err = setupPeriodicRefreshing(ctx, logger, errs, conf, dl, searchService)
+	err = setupPeriodicRefreshing(ctx, logger, errs, conf, dl, scheduler, searchService, nil, nil, nil)
 	require.NoError(t, err)
 
 	cancelFunc()
 	require.NoError(t, <-errs)
 }
+
+func TestGetShrinkThreshold(t *testing.T) {
+	threshold := func(conf download.Config) float64 {
+		t.Helper()
+
+		out, err := getShrinkThreshold(conf)
+		require.NoError(t, err)
+		return out
+	}
+	half, zero, negative := 0.5, 0.0, -0.1
+
+	require.Equal(t, 0.2, threshold(download.Config{}))
+	require.Equal(t, 0.5, threshold(download.Config{ShrinkThreshold: &half}))
+	require.Equal(t, 0.0, threshold(download.Config{ShrinkThreshold: &zero}))
+
+	_, err := getShrinkThreshold(download.Config{ShrinkThreshold: &negative})
+	require.ErrorContains(t, err, "download shrink threshold of -0.1 must be between 0 and 1")
+
+	t.Setenv("DOWNLOAD_SHRINK_THRESHOLD", "1")
+	require.Equal(t, 1.0, threshold(download.Config{ShrinkThreshold: &half}))
+
+	t.Setenv("DOWNLOAD_SHRINK_THRESHOLD", "0")
+	require.Equal(t, 0.0, threshold(download.Config{ShrinkThreshold: &half}))
+
+	t.Setenv("DOWNLOAD_SHRINK_THRESHOLD", "half")
+	_, err = getShrinkThreshold(download.Config{})
+	require.ErrorContains(t, err, "invalid DOWNLOAD_SHRINK_THRESHOLD")
+
+	t.Setenv("DOWNLOAD_SHRINK_THRESHOLD", "-1")
+	_, err = getShrinkThreshold(download.Config{})
+	require.ErrorContains(t, err, "must be between 0 and 1")
+}
+
+func TestDownloader_restoreSnapshot(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	logger := log.NewTestLogger()
+
+	conf := download.Config{
+		InitialDataDirectory: filepath.Join("..", "..", "pkg", "ofac", "testdata"),
+		IncludedLists:        []pubsearch.SourceList{pubsearch.SourceUSOFAC},
+		SnapshotPath:         filepath.Join(t.TempDir(), "watchman.snapshot"),
+	}
+	dl, err := download.NewDownloader(logger, conf)
+	require.NoError(t, err)
+
+	// Nothing is restored before a snapshot is saved
+	searchService := search.NewService(logger)
+	require.False(t, restoreSnapshot(logger, conf.SnapshotPath, searchService))
+
+	err = refreshAllSources(ctx, logger, dl, searchService, true, &refreshState{snapshotPath: conf.SnapshotPath}, nil)
+	require.NoError(t, err)
+	expected := len(searchService.Entities())
+	require.Greater(t, expected, 0)
+
+	restored := search.NewService(logger)
+	require.True(t, restoreSnapshot(logger, conf.SnapshotPath, restored))
+	require.Len(t, restored.Entities(), expected)
+}
+
+type failingDownloader struct{}
+
+func (failingDownloader) RefreshAll(ctx context.Context) (download.Stats, error) {
+	return download.Stats{}, errors.New("unexpected download")
+}
+
+func (failingDownloader) RefreshLists(ctx context.Context, lists []pubsearch.SourceList) (download.Stats, error) {
+	return download.Stats{}, errors.New("unexpected download")
+}
+
+func (failingDownloader) UpdatePipelines(confs map[string]prepare.PipelineConfig) ([]pubsearch.SourceList, error) {
+	return nil, nil
+}
+
+func TestDownloader_sharedStore(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+
+	logger := log.NewTestLogger()
+	store := download.NewSQLStore(database.NewTestDB(t))
+
+	conf := download.Config{
+		InitialDataDirectory: filepath.Join("..", "..", "pkg", "ofac", "testdata"),
+		IncludedLists:        []pubsearch.SourceList{pubsearch.SourceUSOFAC},
+	}
+	dl, err := download.NewDownloader(logger, conf)
+	require.NoError(t, err)
+
+	// The first instance downloads the lists and stores them
+	first := &refreshState{store: store, host: "host-a", interval: time.Hour}
+	firstService := search.NewService(logger)
+	err = refreshAllSources(ctx, logger, dl, firstService, true, first, nil)
+	require.NoError(t, err)
+	require.NotEmpty(t, first.lastRefreshID)
+
+	expected := len(firstService.Entities())
+	require.Greater(t, expected, 0)
+
+	// Other instances load the stored refresh rather than downloading
+	second := &refreshState{store: store, host: "host-b", interval: time.Hour}
+	secondService := search.NewService(logger)
+	err = refreshAllSources(ctx, logger, failingDownloader{}, secondService, true, second, nil)
+	require.NoError(t, err)
+	require.Len(t, secondService.Entities(), expected)
+	require.Equal(t, first.lastRefreshID, second.lastRefreshID)
+
+	// Nothing is reloaded until there's another refresh
+	err = refreshAllSources(ctx, logger, failingDownloader{}, secondService, false, second, nil)
+	require.NoError(t, err)
+
+	// The stored refresh is restored on startup
+	restored := search.NewService(logger)
+	third := &refreshState{store: store, host: "host-c", interval: time.Hour}
+	require.True(t, third.restoreFromStore(ctx, logger, restored))
+	require.Len(t, restored.Entities(), expected)
+
+	// Stale refreshes are downloaded again
+	second.interval = time.Nanosecond
+	err = refreshAllSources(ctx, logger, failingDownloader{}, secondService, false, second, nil)
+	require.ErrorContains(t, err, "unexpected download")
+}
+
+// listDownloader returns one entity for each list it's asked to refresh
+type listDownloader struct {
+	refreshed []pubsearch.SourceList
+}
+
+func (dl *listDownloader) RefreshAll(ctx context.Context) (download.Stats, error) {
+	return download.Stats{}, errors.New("unexpected download")
+}
+
+func (dl *listDownloader) RefreshLists(ctx context.Context, lists []pubsearch.SourceList) (download.Stats, error) {
+	dl.refreshed = append(dl.refreshed, lists...)
+
+	stats := download.Stats{Lists: make(map[string]int)}
+	for _, list := range lists {
+		stats.Lists[string(list)] = 1
+		stats.Entities = append(stats.Entities, pubsearch.Entity[pubsearch.Value]{
+			Name:     "refreshed",
+			Source:   list,
+			SourceID: "1",
+		})
+	}
+	return stats, nil
+}
+
+func (dl *listDownloader) UpdatePipelines(confs map[string]prepare.PipelineConfig) ([]pubsearch.SourceList, error) {
+	return nil, nil
+}
+
+func TestDownloader_refreshSources(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+
+	scheduler, err := download.NewScheduler(download.Config{
+		IncludedLists: []pubsearch.SourceList{pubsearch.SourceUSOFAC, pubsearch.SourceUKCSL},
+	}, time.Hour)
+	require.NoError(t, err)
+
+	dl := &listDownloader{}
+	state := &refreshState{scheduler: scheduler}
+	searchService := search.NewService(logger)
+
+	err = refreshAllSources(ctx, logger, dl, searchService, true, state, nil)
+	require.NoError(t, err)
+	require.Len(t, searchService.Entities(), 2)
+
+	names := func() map[pubsearch.SourceList]string {
+		out := make(map[pubsearch.SourceList]string)
+		for _, entity := range searchService.Entities() {
+			out[entity.Source] = entity.Name
+		}
+		return out
+	}
+
+	// Only the due list is downloaded, while the other keeps its entities
+	searchService.UpdateEntities([]pubsearch.Entity[pubsearch.Value]{
+		{Name: "old", Source: pubsearch.SourceUSOFAC, SourceID: "1"},
+		{Name: "old", Source: pubsearch.SourceUKCSL, SourceID: "1"},
+	})
+	dl.refreshed = nil
+	err = refreshSources(ctx, logger, dl, searchService, false, state, []pubsearch.SourceList{pubsearch.SourceUSOFAC}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []pubsearch.SourceList{pubsearch.SourceUSOFAC}, dl.refreshed)
+	require.Equal(t, map[pubsearch.SourceList]string{
+		pubsearch.SourceUSOFAC: "refreshed",
+		pubsearch.SourceUKCSL:  "old",
+	}, names())
+
+	// Disabled lists are no longer searched
+	disabled := false
+	_, err = scheduler.Update(pubsearch.SourceUKCSL, download.ListScheduleUpdate{Enabled: &disabled})
+	require.NoError(t, err)
+
+	removeDisabledLists(ctx, logger, searchService, state, nil)
+	require.Equal(t, map[pubsearch.SourceList]string{
+		pubsearch.SourceUSOFAC: "refreshed",
+	}, names())
+}
+
+func TestDownloader_refreshList(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+
+	scheduler, err := download.NewScheduler(download.Config{
+		IncludedLists: []pubsearch.SourceList{pubsearch.SourceUSOFAC, pubsearch.SourceUKCSL},
+	}, time.Hour)
+	require.NoError(t, err)
+
+	dl := &listDownloader{}
+	state := &refreshState{
+		scheduler: scheduler,
+		versions:  versions.NewService(logger, versions.NewInMemoryRepository()),
+	}
+	searchService := search.NewService(logger)
+	searchService.UpdateEntities([]pubsearch.Entity[pubsearch.Value]{
+		{Name: "old", Source: pubsearch.SourceUKCSL, SourceID: "1"},
+	})
+
+	result, err := refreshList(ctx, logger, dl, searchService, state, pubsearch.SourceUSOFAC, nil)
+	require.NoError(t, err)
+	require.Equal(t, []pubsearch.SourceList{pubsearch.SourceUSOFAC}, dl.refreshed)
+	require.Equal(t, pubsearch.SourceUSOFAC, result.List)
+	require.NotEmpty(t, result.VersionID)
+	require.Equal(t, download.ListChanges{Entities: 1, Added: 1}, result.ListChanges)
+	require.Len(t, searchService.Entities(), 2)
+
+	// The list isn't due again until its next refresh
+	require.NotContains(t, scheduler.Due(time.Now()), pubsearch.SourceUSOFAC)
+}
+
+func TestDownloader_holdShrunkLists(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+
+	scheduler, err := download.NewScheduler(download.Config{
+		IncludedLists: []pubsearch.SourceList{pubsearch.SourceUSOFAC, pubsearch.SourceUKCSL},
+	}, time.Hour)
+	require.NoError(t, err)
+
+	alerts := make(chan download.ListAlert, 10)
+	state := &refreshState{
+		scheduler:       scheduler,
+		shrinkThreshold: 0.2,
+		alerts: []alertListener{
+			func(ctx context.Context, alert download.ListAlert) { alerts <- alert },
+		},
+	}
+	searchService := search.NewService(logger)
+
+	var current []pubsearch.Entity[pubsearch.Value]
+	for _, id := range []string{"1", "2", "3", "4", "5"} {
+		current = append(current, pubsearch.Entity[pubsearch.Value]{Name: "old", Source: pubsearch.SourceUSOFAC, SourceID: id})
+	}
+	current = append(current, pubsearch.Entity[pubsearch.Value]{Name: "old", Source: pubsearch.SourceUKCSL, SourceID: "1"})
+	searchService.UpdateEntities(current)
+
+	// OFAC lost four of its five entities, so it's held while UK CSL is refreshed
+	err = refreshAllSources(ctx, logger, &listDownloader{}, searchService, false, state, nil)
+	require.NoError(t, err)
+	require.Len(t, searchService.Entities(), 6)
+
+	alert := <-alerts
+	require.Equal(t, pubsearch.SourceUSOFAC, alert.List)
+	require.Equal(t, download.AlertListShrunk, alert.Reason)
+	require.Equal(t, 1, alert.Entities)
+	require.Equal(t, 5, alert.Previous)
+
+	hold, ok := scheduler.Held(pubsearch.SourceUSOFAC)
+	require.True(t, ok)
+	require.Equal(t, 5, hold.Previous)
+
+	// Approving the refresh searches its entities
+	result, err := approveList(ctx, logger, searchService, state, pubsearch.SourceUSOFAC, nil)
+	require.NoError(t, err)
+	require.Equal(t, download.ListChanges{Entities: 1, Removed: 4, Modified: 1}, result.ListChanges)
+	require.Len(t, searchService.Entities(), 2)
+
+	_, err = approveList(ctx, logger, searchService, state, pubsearch.SourceUSOFAC, nil)
+	require.ErrorIs(t, err, download.ErrNoHeldRefresh)
+}
+
+func TestDownloader_failedRefresh(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+
+	scheduler, err := download.NewScheduler(download.Config{
+		IncludedLists: []pubsearch.SourceList{pubsearch.SourceUSOFAC},
+	}, time.Hour)
+	require.NoError(t, err)
+
+	alerts := make(chan download.ListAlert, 10)
+	state := &refreshState{
+		scheduler: scheduler,
+		alerts: []alertListener{
+			func(ctx context.Context, alert download.ListAlert) { alerts <- alert },
+		},
+	}
+	searchService := search.NewService(logger)
+	searchService.UpdateEntities([]pubsearch.Entity[pubsearch.Value]{
+		{Name: "old", Source: pubsearch.SourceUSOFAC, SourceID: "1"},
+	})
+
+	// The previous entities are still searched
+	err = refreshAllSources(ctx, logger, failingDownloader{}, searchService, false, state, nil)
+	require.ErrorContains(t, err, "unexpected download")
+	require.Len(t, searchService.Entities(), 1)
+
+	alert := <-alerts
+	require.Equal(t, pubsearch.SourceUSOFAC, alert.List)
+	require.Equal(t, download.AlertRefreshFailed, alert.Reason)
+	require.Equal(t, "unexpected download", alert.Error)
+	require.Equal(t, 1, alert.Previous)
+}
@@ -0,0 +1,78 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/download"
+	"github.com/moov-io/watchman/internal/versions"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+)
+
+// runExport loads the lists and writes their prepared entities as CSV or JSON lines to w, or the file given
+// by -output (watchman export -format csv -output entities.csv)
+func runExport(ctx context.Context, logger log.Logger, dl download.Downloader, args []string, w io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	format := fs.String("format", versions.ExportJSON, "export format, csv or json")
+	lists := fs.String("lists", "", "comma separated lists to export, every list when empty")
+	output := fs.String("output", "", "file the entities are written to instead of stdout")
+
+	usage := errors.New("usage: watchman export [-format csv|json] [-lists us_ofac,uk_csl] [-output file]")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 0 {
+		return usage
+	}
+	if *format != versions.ExportCSV && *format != versions.ExportJSON {
+		return usage
+	}
+
+	stats, err := dl.RefreshAll(ctx)
+	if err != nil {
+		return fmt.Errorf("loading lists: %w", err)
+	}
+
+	entities := stats.Entities
+	if *lists != "" {
+		only := strings.Split(*lists, ",")
+		entities = slices.DeleteFunc(slices.Clone(entities), func(entity pubsearch.Entity[pubsearch.Value]) bool {
+			return !slices.Contains(only, string(entity.Source))
+		})
+	}
+
+	// Versions aren't kept between runs, so each list is exported with the hash of its entities
+	recorded, err := versions.NewService(logger, versions.NewInMemoryRepository()).Record(ctx, stats.EndedAt, stats.Lists, stats.Entities)
+	if err != nil {
+		return fmt.Errorf("hashing lists: %w", err)
+	}
+	current := make(map[string]versions.Version, len(recorded))
+	for _, version := range recorded {
+		version.VersionID = "" // only meaningful to the history it's recorded in
+		current[version.List] = version
+	}
+
+	if *output != "" {
+		fd, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("creating %s: %w", *output, err)
+		}
+		defer fd.Close()
+		w = fd
+	}
+	if err := versions.Export(w, *format, entities, current); err != nil {
+		return fmt.Errorf("exporting entities: %w", err)
+	}
+	logger.Info().Logf("exported %d entities", len(entities))
+	return nil
+}
@@ -0,0 +1,53 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/download"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunExport(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+
+	dl, err := download.NewDownloader(logger, download.Config{
+		InitialDataDirectory: filepath.Join("..", "..", "pkg", "ofac", "testdata"),
+		IncludedLists:        []pubsearch.SourceList{pubsearch.SourceUSOFAC},
+	})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.ErrorContains(t, runExport(ctx, logger, dl, []string{"-format", "xml"}, &buf), "usage: watchman export")
+	require.ErrorContains(t, runExport(ctx, logger, dl, []string{"extra"}, &buf), "usage: watchman export")
+
+	path := filepath.Join(t.TempDir(), "entities.csv")
+	require.NoError(t, runExport(ctx, logger, dl, []string{"-format", "csv", "-lists", "us_ofac", "-output", path}, &buf))
+	require.Empty(t, buf.String())
+
+	fd, err := os.Open(path)
+	require.NoError(t, err)
+	defer fd.Close()
+
+	rows, err := csv.NewReader(fd).ReadAll()
+	require.NoError(t, err)
+	require.Greater(t, len(rows), 1)
+	require.Equal(t, "us_ofac", rows[1][0])
+	require.Empty(t, rows[1][2])    // versionID
+	require.NotEmpty(t, rows[1][3]) // versionHash
+
+	require.NoError(t, runExport(ctx, logger, dl, []string{"-lists", "uk_csl"}, &buf))
+	require.Empty(t, buf.String())
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"crypto/tls"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -16,12 +17,32 @@ import (
 	"time"
 
 	"github.com/moov-io/watchman"
+	"github.com/moov-io/watchman/internal/ach"
+	"github.com/moov-io/watchman/internal/allowlist"
+	"github.com/moov-io/watchman/internal/audit"
+	"github.com/moov-io/watchman/internal/auth"
+	"github.com/moov-io/watchman/internal/cases"
+	"github.com/moov-io/watchman/internal/customlists"
+	"github.com/moov-io/watchman/internal/database"
 	"github.com/moov-io/watchman/internal/download"
+	"github.com/moov-io/watchman/internal/events"
+	"github.com/moov-io/watchman/internal/geography"
+	"github.com/moov-io/watchman/internal/graphql"
+	"github.com/moov-io/watchman/internal/jobs"
+	"github.com/moov-io/watchman/internal/payments"
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/internal/reports"
 	"github.com/moov-io/watchman/internal/search"
+	"github.com/moov-io/watchman/internal/tracing"
+	"github.com/moov-io/watchman/internal/versions"
+	"github.com/moov-io/watchman/internal/watches"
+	"github.com/moov-io/watchman/internal/webhooks"
 
 	"github.com/gorilla/mux"
 	"github.com/moov-io/base/admin"
 	"github.com/moov-io/base/log"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
 )
 
 func main() {
@@ -37,12 +58,97 @@ func main() {
 		os.Exit(1)
 	}
 
+	if path := os.Getenv("NICKNAMES_FILE"); path != "" {
+		if err := prepare.LoadNicknamesFile(path); err != nil {
+			logger.Fatal().LogErrorf("problem loading nicknames: %v", err)
+			os.Exit(1)
+		}
+	}
+	if path := os.Getenv("HONORIFICS_FILE"); path != "" {
+		if err := prepare.LoadHonorificsFile(path); err != nil {
+			logger.Fatal().LogErrorf("problem loading honorifics: %v", err)
+			os.Exit(1)
+		}
+	}
+	if path := os.Getenv("COMPANY_SUFFIXES_FILE"); path != "" {
+		if err := prepare.LoadCompanySuffixesFile(path); err != nil {
+			logger.Fatal().LogErrorf("problem loading company suffixes: %v", err)
+			os.Exit(1)
+		}
+	}
+
+	config.Download.MirrorURL = getMirrorURL(config.Download)
+	config.Download.Offline = getOffline(config.Download)
+	config.Download.OFACFormat = getOFACFormat(config.Download)
+	config.Download.HTTP = getDownloadHTTPConfig(config.Download)
+	config.Download.Concurrency = getDownloadConcurrency(config.Download)
+	config.Download.ListTimeout = getDownloadListTimeout(config.Download)
+	config.Download.CacheDirectory = getDownloadCacheDirectory(config.Download)
+	config.Download.Checksums, err = getDownloadChecksums(config.Download)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading download checksums: %v", err)
+		os.Exit(1)
+	}
+	shrinkThreshold, err := getShrinkThreshold(config.Download)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading download shrink threshold: %v", err)
+		os.Exit(1)
+	}
+	config.Download.ShrinkThreshold = &shrinkThreshold
+
+	// "watchman mirror" copies each list's files into a mirror, then exits
+	if len(os.Args) > 1 && os.Args[1] == "mirror" {
+		if err := runMirror(context.Background(), logger, config.Download, os.Args[2:]); err != nil {
+			logger.Fatal().LogErrorf("problem mirroring lists: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// "watchman bundle" writes a signed bundle of each list's prepared entities, then exits
+	if len(os.Args) > 1 && os.Args[1] == "bundle" {
+		if err := runBundle(context.Background(), logger, config.Download, os.Args[2:], os.Stdout); err != nil {
+			logger.Fatal().LogErrorf("problem building bundle: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+	config.Download.Bundle, err = getBundleConfig(config.Download)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading bundle config: %v", err)
+		os.Exit(1)
+	}
+
 	downloader, err := download.NewDownloader(logger, config.Download)
 	if err != nil {
 		logger.Fatal().LogErrorf("problem setting up downloader: %v", err)
 		os.Exit(1)
 	}
 
+	// "watchman export" writes the prepared entities of each list, then exits
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := runExport(context.Background(), logger, downloader, os.Args[2:], os.Stdout); err != nil {
+			logger.Fatal().LogErrorf("problem exporting entities: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// "watchman ach" screens the entries of a NACHA file, then exits
+	if len(os.Args) > 1 && os.Args[1] == "ach" {
+		if err := runACHScreening(context.Background(), logger, downloader, os.Args[2:], os.Stdout); err != nil {
+			logger.Fatal().LogErrorf("problem screening ACH file: %v", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	scheduler, err := download.NewScheduler(config.Download, getRefreshInterval(config.Download))
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading list schedules: %v", err)
+		os.Exit(1)
+	}
+
 	// Setup signal listener
 	ctx, cancelFunc := context.WithCancel(context.Background())
 	defer cancelFunc()
@@ -54,21 +160,285 @@ func main() {
 	// Listen for errors
 	errs := make(chan error, 1)
 
+	// Setup tracing, which is optional
+	tracingConfig, err := getTracingConfig(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading tracing config: %v", err)
+		os.Exit(1)
+	}
+	shutdownTracing, err := tracing.Setup(logger, tracingConfig)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem setting up tracing: %v", err)
+		os.Exit(1)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		if err := shutdownTracing(ctx); err != nil {
+			logger.Error().LogErrorf("problem sending spans: %v", err)
+		}
+	}()
+	if tracingConfig.Endpoint != "" {
+		logger.Info().Logf("sending traces to %s", tracingConfig.Endpoint)
+	}
+
+	// Setup storage, which is kept in memory unless a database is configured
+	allowlistRepo := allowlist.NewInMemoryRepository()
+	watchRepo := watches.NewInMemoryRepository()
+	versionRepo := versions.NewInMemoryRepository()
+	customListRepo := customlists.NewInMemoryRepository()
+	caseRepo := cases.NewInMemoryRepository()
+	webhookRepo := webhooks.NewInMemoryRepository()
+	var listStore download.Store
+	var db *database.DB
+
+	if dbConfig := getDatabaseConfig(config); dbConfig != nil {
+		var err error
+		db, err = database.Open(ctx, *dbConfig)
+		if err != nil {
+			logger.Fatal().LogErrorf("problem setting up database: %v", err)
+			os.Exit(1)
+		}
+		defer db.Close()
+
+		logger.Info().Logf("storing data in %s database", db.Dialect)
+
+		allowlistRepo = allowlist.NewSQLRepository(db)
+		watchRepo = watches.NewSQLRepository(db)
+		versionRepo = versions.NewSQLRepository(db)
+		customListRepo = customlists.NewSQLRepository(db)
+		caseRepo = cases.NewSQLRepository(db)
+		webhookRepo = webhooks.NewSQLRepository(db)
+		listStore = download.NewSQLStore(db)
+	}
+
+	// Setup event publishing, which is optional
+	publisher, err := events.NewPublisher(getEventsConfig(config))
+	if err != nil {
+		logger.Fatal().LogErrorf("problem setting up event publisher: %v", err)
+		os.Exit(1)
+	}
+	listeners := []refreshListener{}
+	if publisher != nil {
+		defer publisher.Close()
+
+		listeners = append(listeners, publishRefreshEvents(logger, publisher))
+	}
+
 	// Setup search service and endpoints
-	searchService := search.NewService(logger)
-	err = setupPeriodicRefreshing(ctx, logger, errs, config.Download, downloader, searchService)
+	allowlistService := allowlist.NewService(logger, allowlistRepo)
+	searchWeights, err := getSearchWeights(config)
 	if err != nil {
-		logger.Fatal().LogErrorf("problem during initial download: %v", err)
+		logger.Fatal().LogErrorf("problem reading search weights: %v", err)
+		os.Exit(1)
+	}
+	searchCalibration, err := getSearchCalibration(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading search calibration: %v", err)
+		os.Exit(1)
+	}
+	searchMinMatch, err := getSearchMinMatch(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading search minMatch: %v", err)
+		os.Exit(1)
+	}
+	searchAlgorithm, err := getSearchAlgorithm(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading search algorithm: %v", err)
+		os.Exit(1)
+	}
+	searchShards, err := getSearchShards(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading search shards: %v", err)
+		os.Exit(1)
+	}
+	searchTrigramOverlap, err := getSearchMinTrigramOverlap(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading search trigram overlap: %v", err)
+		os.Exit(1)
+	}
+	searchBudget, err := getSearchBudget(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading search budget: %v", err)
+		os.Exit(1)
+	}
+	searchBirthYearTolerance, err := getSearchBirthYearTolerance(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading birth year tolerance: %v", err)
+		os.Exit(1)
+	}
+	searchTypoTolerance, err := getSearchTypoTolerance(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading typo tolerance: %v", err)
+		os.Exit(1)
+	}
+	searchAltNameBoost, err := getSearchAltNameBoost(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading alt name boost: %v", err)
+		os.Exit(1)
+	}
+	searchConflictFactors, err := getSearchConflictFactors(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading search conflict factors: %v", err)
+		os.Exit(1)
+	}
+	searchWeakTerms, err := getSearchWeakTerms(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading search weak terms: %v", err)
+		os.Exit(1)
+	}
+	searchReranker, err := getSearchReranker(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading search rerank model: %v", err)
+		os.Exit(1)
+	}
+	searchFinancialIDs, err := getSearchFinancialIDs(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading search BIC/LEI mapping: %v", err)
+		os.Exit(1)
+	}
+	if searchFinancialIDs != nil {
+		logger.Info().Logf("read %d BIC/LEI pairs", searchFinancialIDs.Pairs())
+	}
+	searchProfiles, err := getSearchProfiles(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading search profiles: %v", err)
 		os.Exit(1)
 	}
+	searchConfig := search.ServiceConfig{
+		Weights:            searchWeights,
+		Calibration:        searchCalibration,
+		MinMatch:           searchMinMatch,
+		Algorithm:          searchAlgorithm,
+		Phonetic:           getSearchPhonetic(config),
+		Shards:             searchShards,
+		MinTrigramOverlap:  searchTrigramOverlap,
+		BirthYearTolerance: searchBirthYearTolerance,
+		TypoTolerance:      searchTypoTolerance,
+		Conflicts:          searchConflictFactors,
+		AltNameBoost:       searchAltNameBoost,
+		WeakTerms:          searchWeakTerms,
+		Budget:             searchBudget,
+		Reranker:           searchReranker,
+		FinancialIDs:       searchFinancialIDs,
+		Profiles:           searchProfiles,
+	}
+	searchService := search.NewServiceWithConfig(logger, searchConfig, allowlistService)
 
-	router := mux.NewRouter()
-	addPingRoute(router)
+	searchCacheConfig, err := getSearchCacheConfig(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading search cache config: %v", err)
+		os.Exit(1)
+	}
+	searchCache, err := search.NewCache(logger, searchCacheConfig)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem setting up search cache: %v", err)
+		os.Exit(1)
+	}
+	if searchCache != nil {
+		searchService = search.NewCachedService(logger, searchService, searchCache)
 
-	searchController := search.NewController(logger, searchService)
-	searchController.AppendRoutes(router)
+		// Cached results were adjusted by the allowlist entries when they were searched
+		allowlistService = allowlist.WithChangeHook(allowlistService, func() {
+			searchCache.Clear(context.Background())
+		})
+	}
+	versionService := versions.NewService(logger, versionRepo)
+	searchService = versions.NewSearchService(logger, searchService, versionService, func() search.Service {
+		return search.NewServiceWithConfig(logger, searchConfig, allowlistService)
+	})
+	webhookService := webhooks.NewService(logger, webhookRepo)
+	// Watch matches are kept for summary reports, along with being published to any broker
+	watchLog := reports.NewWatchLog()
+	watchService := watches.NewService(logger, watchRepo, searchService, events.Multi(publisher, watchLog))
+
+	// Setup the audit log of searches, which is optional
+	auditConfig, err := getAuditConfig(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading audit config: %v", err)
+		os.Exit(1)
+	}
+	auditSink, err := audit.NewSink(auditConfig, db)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem setting up audit log: %v", err)
+		os.Exit(1)
+	}
+	apiSearchService := searchService
+	if auditSink != nil {
+		defer auditSink.Close()
+
+		logger.Info().Log("recording searches to the audit log")
+		apiSearchService = audit.NewSearchService(logger, searchService, auditSink, versionService, auditConfig.TopResults)
+	}
+
+	// Hits of searches are recorded for review when enabled
+	casesConfig, err := getCasesConfig(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading cases config: %v", err)
+		os.Exit(1)
+	}
+	caseService := cases.NewService(logger, caseRepo, casesConfig)
+	if casesConfig.MinMatch > 0 {
+		logger.Info().Logf("recording search results scoring at least %.2f as hits", casesConfig.MinMatch)
+		apiSearchService = cases.NewSearchService(logger, apiSearchService, caseService)
+	}
+
+	// Summary reports read searches from the audit log when it's kept in the database
+	reportsConfig, err := getReportsConfig(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading reports config: %v", err)
+		os.Exit(1)
+	}
+	var auditReader audit.Reader
+	if auditConfig.Database {
+		auditReader = audit.NewSQLReader(db)
+	}
+	reportService := reports.NewService(logger, reportsConfig, auditReader, versionService, searchService, watchLog)
+	reportService.Start(ctx)
+
+	customListPipeline, err := customListPipeline(config.Download)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem setting up custom lists: %v", err)
+		os.Exit(1)
+	}
+	customListService := customlists.NewService(logger, customListRepo, searchService, customListPipeline)
+	if err := customListService.Load(ctx); err != nil {
+		logger.Fatal().LogErrorf("problem loading custom lists: %v", err)
+		os.Exit(1)
+	}
+
+	listeners = append(listeners,
+		notifyWebhooks(logger, webhookService),
+		rescreenWatches(logger, watchService),
+		reloadCustomLists(logger, customListService),
+	)
+	alerts := []alertListener{
+		alertWebhooks(logger, webhookService),
+	}
+
+	authConfig, err := getAuthConfig(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading auth config: %v", err)
+		os.Exit(1)
+	}
+	authMiddleware := auth.NewMiddleware(logger, authConfig, nil)
+
+	healthConfig, err := getHealthConfig(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading health config: %v", err)
+		os.Exit(1)
+	}
+	healthChecker := download.NewHealthChecker(healthConfig, scheduler, searchService)
+
+	// SIGHUP and the admin server reload enabled lists, schedules, prepare pipelines and minimum scores
+	reloader := newConfigReloader(logger, downloader, scheduler, searchService)
+	reloadSignals := make(chan os.Signal, 1)
+	signal.Notify(reloadSignals, syscall.SIGHUP)
+	defer signal.Stop(reloadSignals)
+	go reloader.listen(ctx, reloadSignals)
 
-	// Start Admin server (with Prometheus metrics)
+	// Start Admin server (with Prometheus metrics) before the initial download, so probes can see the lists loading
 	adminServer, err := admin.New(admin.Opts{
 		Addr: config.Servers.AdminAddress,
 	})
@@ -76,6 +446,40 @@ func main() {
 		errs <- fmt.Errorf("problem starting admin server: %v", err)
 	} else {
 		adminServer.AddVersionHandler(watchman.Version) // Setup 'GET /version'
+
+		// Watchman's admin routes need the admin scope, while /metrics and the health checks stay open
+		adminRouter := adminServer.Subrouter("")
+		if authMiddleware.Enabled() {
+			adminRouter.Use(authMiddleware.AdminHandler)
+		}
+
+		versionAdminController := versions.NewAdminController(logger, versionService, searchService)
+		versionAdminController.AppendRoutes(adminRouter)
+
+		downloadAdminController := download.NewAdminController(logger, scheduler)
+		downloadAdminController.AppendRoutes(adminRouter)
+
+		searchAdminController := search.NewAdminController(logger, searchService)
+		searchAdminController.AppendRoutes(adminRouter)
+
+		authAdminController := auth.NewAdminController(authMiddleware)
+		authAdminController.AppendRoutes(adminRouter)
+
+		// Recorded searches can be replayed at other thresholds when they're kept in the database.
+		// Replays are made without the audit log or cases, so they aren't recorded again.
+		if auditReader != nil {
+			auditAdminController := audit.NewAdminController(logger, auditReader, searchService)
+			auditAdminController.AppendRoutes(adminRouter)
+		}
+
+		reportAdminController := reports.NewAdminController(logger, reportService)
+		reportAdminController.AppendRoutes(adminRouter)
+
+		addReloadRoute(adminRouter, reloader)
+
+		// The health of each list is served next to /live and /ready, which stay open for probes
+		adminServer.AddHandler("/health", healthChecker.ServeHTTP)
+		adminServer.AddReadinessCheck("lists", healthChecker.ReadinessCheck)
 	}
 	go func() {
 		if adminServer == nil {
@@ -94,6 +498,87 @@ func main() {
 		}
 	}()
 
+	err = setupPeriodicRefreshing(ctx, logger, errs, config.Download, downloader, scheduler, searchService, listStore, versionService, alerts, listeners...)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem during initial download: %v", err)
+		os.Exit(1)
+	}
+
+	router := mux.NewRouter()
+	addPingRoute(router)
+	router.Use(tracing.Handler)
+	if authMiddleware.Enabled() {
+		logger.Info().Log("requiring api keys or bearer tokens")
+		router.Use(authMiddleware.Handler)
+	}
+
+	logRedaction, err := getLogRedaction(config)
+	if err != nil {
+		logger.Fatal().LogErrorf("problem reading log redaction: %v", err)
+		os.Exit(1)
+	}
+	searchController := search.NewControllerWithConfig(logger, apiSearchService, search.ControllerConfig{
+		LogRedaction: logRedaction,
+	})
+	searchController.AppendRoutes(router)
+
+	webhookController := webhooks.NewController(logger, webhookService)
+	webhookController.AppendRoutes(router)
+
+	watchController := watches.NewController(logger, watchService)
+	watchController.AppendRoutes(router)
+
+	allowlistController := allowlist.NewController(logger, allowlistService)
+	allowlistController.AppendRoutes(router)
+
+	caseController := cases.NewController(logger, caseService)
+	caseController.AppendRoutes(router)
+
+	versionController := versions.NewController(logger, versionService)
+	versionController.AppendRoutes(router)
+
+	customListController := customlists.NewController(logger, customListService)
+	customListController.AppendRoutes(router)
+
+	if getGraphQLEnabled(config) {
+		resolver := &graphqlResolver{
+			GraphQLSearch:   search.NewGraphQLSearch(apiSearchService),
+			GraphQLVersions: versions.NewGraphQLVersions(versionService),
+			GraphQLWatches:  watches.NewGraphQLWatches(watchService),
+		}
+		schema, err := graphql.NewSchema(resolver, search.GraphQLSchema, versions.GraphQLSchema, watches.GraphQLSchema)
+		if err != nil {
+			logger.Fatal().LogErrorf("problem creating GraphQL schema: %v", err)
+			os.Exit(1)
+		}
+		router.Name("GraphQL.v2").Methods("GET", "POST").Path("/v2/graphql").Handler(schema)
+	}
+
+	jurisdictions := geography.DefaultJurisdictions()
+	if path := os.Getenv("SANCTIONED_JURISDICTIONS_FILE"); path != "" {
+		jurisdictions, err = geography.LoadJurisdictionsFile(path)
+		if err != nil {
+			logger.Fatal().LogErrorf("problem loading sanctioned jurisdictions: %v", err)
+			os.Exit(1)
+		}
+	}
+	geographyController := geography.NewController(logger, geography.NewService(jurisdictions))
+	geographyController.AppendRoutes(router)
+
+	paymentsService := payments.NewService(logger, apiSearchService)
+	paymentsController := payments.NewController(logger, paymentsService)
+	paymentsController.AppendRoutes(router)
+
+	achController := ach.NewController(logger, ach.NewService(paymentsService))
+	achController.AppendRoutes(router)
+
+	// Screening jobs are kept in memory, as their results are only held until they expire
+	jobService := jobs.NewService(logger, jobs.NewInMemoryRepository(), apiSearchService)
+	jobService.Start(ctx)
+
+	jobController := jobs.NewController(logger, jobService)
+	jobController.AppendRoutes(router)
+
 	// Setup HTTP server
 	defaultTimeout := 20 * time.Second
 	serve := &http.Server{
@@ -129,6 +614,28 @@ func main() {
 		}
 	}()
 
+	// Start gRPC server
+	if config.Servers.GRPCAddress != "" {
+		listener, err := net.Listen("tcp", config.Servers.GRPCAddress)
+		if err != nil {
+			logger.Fatal().LogErrorf("problem binding gRPC server: %v", err)
+			os.Exit(1)
+		}
+		grpcServer := search.NewGRPCServer(logger, apiSearchService,
+			grpc.StatsHandler(otelgrpc.NewServerHandler()),
+			grpc.UnaryInterceptor(authMiddleware.UnaryServerInterceptor),
+			grpc.StreamInterceptor(authMiddleware.StreamServerInterceptor),
+		)
+		defer grpcServer.GracefulStop()
+
+		go func() {
+			logger.Logf("binding to %s for gRPC server", config.Servers.GRPCAddress)
+			if err := grpcServer.Serve(listener); err != nil {
+				errs <- logger.Error().LogErrorf("gRPC server shutdown: %v", err).Err()
+			}
+		}()
+	}
+
 	// Block/Wait for an error
 	if err := <-errs; err != nil {
 		shutdownServer()
@@ -136,6 +643,13 @@ func main() {
 	}
 }
 
+// graphqlResolver resolves the fields each package adds to the Query type of the GraphQL schema
+type graphqlResolver struct {
+	*search.GraphQLSearch
+	*versions.GraphQLVersions
+	*watches.GraphQLWatches
+}
+
 func addPingRoute(r *mux.Router) {
 	r.Methods("GET").Path("/ping").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/plain")
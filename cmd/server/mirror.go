@@ -0,0 +1,50 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/download"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/strx"
+)
+
+func getMirrorURL(conf download.Config) string {
+	return strings.TrimSpace(cmp.Or(os.Getenv("MIRROR_URL"), conf.MirrorURL))
+}
+
+func getOffline(conf download.Config) bool {
+	if v := os.Getenv("OFFLINE_MODE"); v != "" {
+		return strx.Yes(v)
+	}
+	return conf.Offline
+}
+
+// runMirror copies the current files of each included list into a mirror, which is given as an argument
+// (watchman mirror s3://bucket) or read from MirrorURL.
+func runMirror(ctx context.Context, logger log.Logger, conf download.Config, args []string) error {
+	mirrorURL := conf.MirrorURL
+	if len(args) > 0 {
+		mirrorURL = strings.TrimSpace(args[0])
+	}
+	if mirrorURL == "" {
+		return errors.New("usage: watchman mirror <s3://bucket | gs://bucket | directory>")
+	}
+
+	mirrored, err := download.MirrorSources(ctx, logger, conf, mirrorURL)
+	if err != nil {
+		return err
+	}
+	for list, files := range mirrored {
+		logger.Info().Logf("mirrored %s: %s", list, strings.Join(files, ", "))
+	}
+	return nil
+}
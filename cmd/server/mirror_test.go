@@ -0,0 +1,44 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/download"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetOffline(t *testing.T) {
+	require.False(t, getOffline(download.Config{}))
+	require.True(t, getOffline(download.Config{Offline: true}))
+
+	t.Setenv("OFFLINE_MODE", "no")
+	require.False(t, getOffline(download.Config{Offline: true}))
+}
+
+func TestRunMirror(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+
+	conf := download.Config{
+		InitialDataDirectory: filepath.Join("..", "..", "pkg", "ofac", "testdata"),
+		IncludedLists:        []pubsearch.SourceList{pubsearch.SourceUSOFAC},
+	}
+	require.ErrorContains(t, runMirror(ctx, logger, conf, nil), "usage: watchman mirror")
+
+	dir := t.TempDir()
+	require.NoError(t, runMirror(ctx, logger, conf, []string{dir}))
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 8) // each file and its .sha256
+}
@@ -0,0 +1,139 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/moov-io/watchman/internal/download"
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+)
+
+// configReloader applies the config file's enabled lists, schedules, prepare pipelines and minimum scores
+// while the server is running, so the searched entities are kept rather than downloaded again on a restart
+type configReloader struct {
+	logger log.Logger
+	load   func() (*Config, error)
+
+	downloader    download.Downloader
+	scheduler     *download.Scheduler
+	searchService search.Service
+
+	mu sync.Mutex // one reload at a time
+}
+
+// configReload describes the lists after a reload
+type configReload struct {
+	Lists    []download.ListSchedule          `json:"lists"`
+	MinMatch map[pubsearch.SourceList]float64 `json:"minMatch"`
+
+	// Reprepared lists had their prepare pipeline changed, so they're downloaded and prepared again right away
+	Reprepared []pubsearch.SourceList `json:"reprepared,omitempty"`
+
+	ReloadedAt time.Time `json:"reloadedAt"`
+}
+
+func newConfigReloader(logger log.Logger, downloader download.Downloader, scheduler *download.Scheduler, searchService search.Service) *configReloader {
+	return &configReloader{
+		logger: logger,
+		load: func() (*Config, error) {
+			return LoadConfig(logger)
+		},
+		downloader:    downloader,
+		scheduler:     scheduler,
+		searchService: searchService,
+	}
+}
+
+// Reload reads the config again and applies it. Every setting is validated before any is applied, so an
+// invalid config leaves the server as it was.
+func (r *configReloader) Reload() (configReload, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conf, err := r.load()
+	if err != nil {
+		return configReload{}, fmt.Errorf("loading config: %w", err)
+	}
+	minMatch, err := getSearchMinMatch(conf)
+	if err != nil {
+		return configReload{}, fmt.Errorf("reading search minMatch: %w", err)
+	}
+	for list, pipelineConf := range conf.Download.Prepare {
+		if _, err := prepare.NewPipeline(pipelineConf); err != nil {
+			return configReload{}, fmt.Errorf("%s prepare pipeline: %w", list, err)
+		}
+	}
+
+	// Schedules are validated by the scheduler before it changes any list
+	if err := r.scheduler.Reconfigure(conf.Download, getRefreshInterval(conf.Download)); err != nil {
+		return configReload{}, fmt.Errorf("reading list schedules: %w", err)
+	}
+	reprepared, err := r.downloader.UpdatePipelines(conf.Download.Prepare)
+	if err != nil {
+		return configReload{}, fmt.Errorf("updating prepare pipelines: %w", err)
+	}
+	r.scheduler.Expire(reprepared...)
+
+	if err := r.searchService.SetMinMatches(minMatch); err != nil {
+		return configReload{}, fmt.Errorf("updating search minMatch: %w", err)
+	}
+
+	return configReload{
+		Lists:      r.scheduler.Lists(),
+		MinMatch:   r.searchService.ListMinMatches(),
+		Reprepared: reprepared,
+		ReloadedAt: time.Now().In(time.UTC),
+	}, nil
+}
+
+// listen reloads the config each time a signal is received, until ctx is done
+func (r *configReloader) listen(ctx context.Context, signals <-chan os.Signal) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-signals:
+			r.reloaded(r.Reload())
+		}
+	}
+}
+
+func (r *configReloader) reloaded(result configReload, err error) {
+	if err != nil {
+		r.logger.Error().LogErrorf("problem reloading config: %v", err)
+		return
+	}
+	r.logger.Info().Logf("reloaded config with %d enabled lists, re-preparing %v", len(r.scheduler.Enabled()), result.Reprepared)
+}
+
+// addReloadRoute serves POST /config/reload, which is expected to be on the admin server
+func addReloadRoute(router *mux.Router, reloader *configReloader) {
+	router.Name("ReloadConfig").Methods("POST").Path("/config/reload").HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		result, err := reloader.Reload()
+		reloader.reloaded(result, err)
+
+		w.Header().Set("Content-Type", "application/json")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{
+				"error": err.Error(),
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(result)
+	})
+}
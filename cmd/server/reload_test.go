@@ -0,0 +1,95 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/download"
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigReloader(t *testing.T) {
+	logger := log.NewTestLogger()
+
+	startup := download.Config{
+		IncludedLists: []pubsearch.SourceList{pubsearch.SourceUSOFAC, pubsearch.SourceUKCSL},
+	}
+	downloader, err := download.NewDownloader(logger, startup)
+	require.NoError(t, err)
+	scheduler, err := download.NewScheduler(startup, time.Hour)
+	require.NoError(t, err)
+	scheduler.Refreshed(scheduler.Enabled(), time.Now())
+
+	searchService := search.NewService(logger)
+	reloader := newConfigReloader(logger, downloader, scheduler, searchService)
+
+	conf := &Config{
+		Download: download.Config{
+			IncludedLists: []pubsearch.SourceList{pubsearch.SourceUSOFAC, pubsearch.SourceEUCSL},
+			Schedules:     map[string]string{"eu_csl": "off"},
+			Prepare: map[string]prepare.PipelineConfig{
+				"us_ofac": {Individual: []prepare.Stage{prepare.StagePunctuation}},
+			},
+		},
+		SearchMinMatch: search.MinMatchConfig{
+			Sources: map[pubsearch.SourceList]float64{pubsearch.SourceUSCSL: 0.9},
+		},
+	}
+	reloader.load = func() (*Config, error) {
+		return conf, nil
+	}
+
+	result, err := reloader.Reload()
+	require.NoError(t, err)
+	require.Equal(t, []pubsearch.SourceList{pubsearch.SourceEUCSL, pubsearch.SourceUSOFAC}, scheduler.Enabled())
+	require.Equal(t, map[pubsearch.SourceList]float64{pubsearch.SourceUSCSL: 0.9}, result.MinMatch)
+	require.Equal(t, []pubsearch.SourceList{pubsearch.SourceUSOFAC}, result.Reprepared)
+
+	// Newly enabled lists and those with a new pipeline are downloaded right away
+	require.Equal(t, []pubsearch.SourceList{pubsearch.SourceEUCSL, pubsearch.SourceUSOFAC}, scheduler.Due(time.Now()))
+
+	// An invalid config changes nothing
+	conf.Download.IncludedLists = []pubsearch.SourceList{pubsearch.SourceUKCSL}
+	conf.SearchMinMatch.Sources[pubsearch.SourceUSCSL] = 0.5
+	conf.Download.Prepare["uk_csl"] = prepare.PipelineConfig{Entity: []prepare.Stage{"shout"}}
+
+	_, err = reloader.Reload()
+	require.ErrorContains(t, err, `uk_csl prepare pipeline: unknown prepare stage "shout"`)
+	require.Equal(t, []pubsearch.SourceList{pubsearch.SourceEUCSL, pubsearch.SourceUSOFAC}, scheduler.Enabled())
+	require.Equal(t, map[pubsearch.SourceList]float64{pubsearch.SourceUSCSL: 0.9}, searchService.ListMinMatches())
+
+	// Reload from the admin server
+	router := mux.NewRouter()
+	addReloadRoute(router, reloader)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/config/reload", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "unknown prepare stage")
+
+	delete(conf.Download.Prepare, "uk_csl")
+	delete(conf.SearchMinMatch.Sources, pubsearch.SourceUSCSL)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/config/reload", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var got configReload
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	require.Empty(t, got.MinMatch)
+	require.Empty(t, got.Reprepared)
+	require.Equal(t, []pubsearch.SourceList{pubsearch.SourceUKCSL}, scheduler.Enabled())
+}
@@ -0,0 +1,85 @@
+package ach
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/moov-io/watchman/internal/payments"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+)
+
+var (
+	// maxFileSize limits how much of a request body is read
+	maxFileSize int64 = 50 << 20 // 50MB
+)
+
+type Controller interface {
+	AppendRoutes(router *mux.Router) *mux.Router
+}
+
+func NewController(logger log.Logger, service Service) Controller {
+	return &controller{
+		logger:  logger,
+		service: service,
+	}
+}
+
+type controller struct {
+	logger  log.Logger
+	service Service
+}
+
+func (c *controller) AppendRoutes(router *mux.Router) *mux.Router {
+	router.
+		Name("ACH.screen").
+		Methods("POST").
+		Path("/v2/screen/ach").
+		HandlerFunc(c.screen)
+
+	return router
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (c *controller) writeError(w http.ResponseWriter, status int, err error) {
+	c.logger.Error().LogError(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error: err.Error(),
+	})
+}
+
+func (c *controller) screen(w http.ResponseWriter, r *http.Request) {
+	opts, err := payments.ReadScreenOpts(r)
+	if err != nil {
+		c.writeError(w, payments.ScreenOptsStatus(err), err)
+		return
+	}
+
+	file, err := ParseFile(http.MaxBytesReader(w, r.Body, maxFileSize))
+	if err != nil {
+		c.writeError(w, http.StatusBadRequest, fmt.Errorf("reading ACH file: %w", err))
+		return
+	}
+
+	report, err := c.service.Screen(r.Context(), file, opts)
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("problem screening ACH file: %w", err))
+		return
+	}
+
+	if r.URL.Query().Get("format") == "text" {
+		w.Header().Set("Content-Type", "text/plain")
+		report.WriteText(w)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,39 @@
+package ach
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestController(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	fd, err := os.Open(filepath.Join("testdata", "payroll.ach"))
+	require.NoError(t, err)
+	defer fd.Close()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/v2/screen/ach?minMatch=0.9", fd)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var report Report
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+	require.Equal(t, 4, report.Entries)
+	require.Len(t, report.Hits, 2)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/v2/screen/ach", strings.NewReader("not an ach file"))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
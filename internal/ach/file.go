@@ -0,0 +1,221 @@
+package ach
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+)
+
+// recordLength is the size of every record in a NACHA file
+const recordLength = 94
+
+// File is the batches and entries of a NACHA file, with only the fields needed to screen them
+type File struct {
+	DestinationName string  `json:"destinationName"`
+	OriginName      string  `json:"originName"`
+	Batches         []Batch `json:"batches"`
+}
+
+type Batch struct {
+	Number           string `json:"batchNumber"`
+	CompanyName      string `json:"companyName"`
+	CompanyID        string `json:"companyID"`
+	SECCode          string `json:"secCode"`
+	EntryDescription string `json:"entryDescription"`
+
+	Entries []Entry `json:"entries"`
+}
+
+type Entry struct {
+	TraceNumber     string `json:"traceNumber"`
+	TransactionCode string `json:"transactionCode"`
+	Amount          int    `json:"amount"` // in cents
+	IndividualID    string `json:"individualID,omitempty"`
+
+	// OriginatorName is the company name of the batch, except for international (IAT) entries
+	// whose originator is named in their addenda
+	OriginatorName    string             `json:"originatorName"`
+	OriginatorAddress *pubsearch.Address `json:"originatorAddress,omitempty"`
+
+	ReceiverName    string             `json:"receiverName"`
+	ReceiverAddress *pubsearch.Address `json:"receiverAddress,omitempty"`
+}
+
+// ParseFile reads the batches and entries of a NACHA file. Records can be on separate lines or
+// written one after the other, and the lines of blocks padded with 9s are skipped.
+func ParseFile(r io.Reader) (File, error) {
+	var file File
+	var batch *Batch
+
+	records, err := readRecords(r)
+	if err != nil {
+		return file, err
+	}
+	for i, record := range records {
+		n := i + 1
+
+		switch record[0] {
+		case '1':
+			file.DestinationName = field(record, 41, 63)
+			file.OriginName = field(record, 64, 86)
+
+		case '5':
+			if batch != nil {
+				return file, fmt.Errorf("record %d: batch %s isn't closed", n, batch.Number)
+			}
+			batch = &Batch{
+				CompanyName:      field(record, 5, 20),
+				CompanyID:        field(record, 41, 50),
+				SECCode:          field(record, 51, 53),
+				EntryDescription: field(record, 54, 63),
+				Number:           strings.TrimLeft(field(record, 88, 94), "0"),
+			}
+			if batch.SECCode == "IAT" {
+				batch.CompanyName = "" // IAT headers don't name the originator
+			}
+
+		case '6':
+			if batch == nil {
+				return file, fmt.Errorf("record %d: entry outside of a batch", n)
+			}
+			entry, err := readEntry(record, *batch)
+			if err != nil {
+				return file, fmt.Errorf("record %d: %w", n, err)
+			}
+			batch.Entries = append(batch.Entries, entry)
+
+		case '7':
+			if batch == nil || len(batch.Entries) == 0 {
+				return file, fmt.Errorf("record %d: addenda without an entry", n)
+			}
+			if batch.SECCode == "IAT" {
+				readIATAddenda(record, &batch.Entries[len(batch.Entries)-1])
+			}
+
+		case '8':
+			if batch == nil {
+				return file, fmt.Errorf("record %d: batch control outside of a batch", n)
+			}
+			file.Batches = append(file.Batches, *batch)
+			batch = nil
+
+		case '9':
+			// file control and padding
+
+		default:
+			return file, fmt.Errorf("record %d: unknown record type %q", n, record[0])
+		}
+	}
+	if batch != nil {
+		return file, fmt.Errorf("batch %s isn't closed", batch.Number)
+	}
+	if len(file.Batches) == 0 {
+		return file, errors.New("no batches found")
+	}
+	return file, nil
+}
+
+func readRecords(r io.Reader) ([]string, error) {
+	var out []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		// Records can be written without line breaks
+		text := strings.TrimRight(scanner.Text(), "\r")
+		for text != "" {
+			record := text
+			if len(record) > recordLength {
+				record = record[:recordLength]
+			}
+			text = text[len(record):]
+
+			if strings.TrimSpace(record) != "" {
+				out = append(out, record+strings.Repeat(" ", recordLength-len(record)))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading ACH file: %w", err)
+	}
+	return out, nil
+}
+
+// field returns the trimmed characters of a record between two positions, numbered from 1 as NACHA does
+func field(record string, start, end int) string {
+	return strings.TrimSpace(record[start-1 : end])
+}
+
+func readEntry(record string, batch Batch) (Entry, error) {
+	entry := Entry{
+		TransactionCode: field(record, 2, 3),
+		TraceNumber:     field(record, 80, 94),
+		OriginatorName:  batch.CompanyName,
+	}
+
+	amount := field(record, 30, 39)
+	n, err := strconv.Atoi(amount)
+	if err != nil {
+		return entry, fmt.Errorf("invalid amount %q", amount)
+	}
+	entry.Amount = n
+
+	// The receiver is written in different places depending on the kind of entry
+	switch batch.SECCode {
+	case "IAT":
+		// named in the addenda
+	case "CIE":
+		entry.ReceiverName = field(record, 40, 54)
+		entry.IndividualID = field(record, 55, 76)
+	case "CTX":
+		entry.IndividualID = field(record, 40, 54)
+		entry.ReceiverName = field(record, 59, 74)
+	default:
+		entry.IndividualID = field(record, 40, 54)
+		entry.ReceiverName = field(record, 55, 76)
+	}
+	return entry, nil
+}
+
+// readIATAddenda reads the names and addresses of the originator and receiver of an IAT entry,
+// which are spread across the addenda with type codes 10 through 16
+func readIATAddenda(record string, entry *Entry) {
+	iatAddress := func(addr *pubsearch.Address) *pubsearch.Address {
+		if addr == nil {
+			return &pubsearch.Address{}
+		}
+		return addr
+	}
+
+	switch field(record, 2, 3) {
+	case "10":
+		entry.ReceiverName = field(record, 47, 81)
+	case "11":
+		entry.OriginatorName = field(record, 4, 38)
+		entry.OriginatorAddress = iatAddress(entry.OriginatorAddress)
+		entry.OriginatorAddress.Line1 = field(record, 39, 73)
+	case "12":
+		entry.OriginatorAddress = iatAddress(entry.OriginatorAddress)
+		readIATLocation(record, entry.OriginatorAddress)
+	case "15":
+		entry.ReceiverAddress = iatAddress(entry.ReceiverAddress)
+		entry.ReceiverAddress.Line1 = field(record, 19, 53)
+	case "16":
+		entry.ReceiverAddress = iatAddress(entry.ReceiverAddress)
+		readIATLocation(record, entry.ReceiverAddress)
+	}
+}
+
+// readIATLocation reads the "City*State\" and "Country*Postal\" fields of an IAT addenda
+func readIATLocation(record string, addr *pubsearch.Address) {
+	split := func(value string) (string, string) {
+		a, b, _ := strings.Cut(strings.TrimSuffix(value, "\\"), "*")
+		return strings.TrimSpace(a), strings.TrimSpace(b)
+	}
+	addr.City, addr.State = split(field(record, 4, 38))
+	addr.Country, addr.PostalCode = split(field(record, 39, 73))
+}
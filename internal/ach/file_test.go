@@ -0,0 +1,80 @@
+package ach
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readFile(t *testing.T) File {
+	t.Helper()
+
+	fd, err := os.Open(filepath.Join("testdata", "payroll.ach"))
+	require.NoError(t, err)
+	t.Cleanup(func() { fd.Close() })
+
+	file, err := ParseFile(fd)
+	require.NoError(t, err)
+	return file
+}
+
+func TestParseFile(t *testing.T) {
+	file := readFile(t)
+	require.Equal(t, "CITADEL", file.DestinationName)
+	require.Equal(t, "WELLS FARGO", file.OriginName)
+	require.Len(t, file.Batches, 3)
+
+	payroll := file.Batches[0]
+	require.Equal(t, "1", payroll.Number)
+	require.Equal(t, "ACME PAYROLL", payroll.CompanyName)
+	require.Equal(t, "PPD", payroll.SECCode)
+	require.Equal(t, []Entry{
+		{TraceNumber: "121042880000001", TransactionCode: "22", Amount: 150000, IndividualID: "EMP001", OriginatorName: "ACME PAYROLL", ReceiverName: "NICOLAS MADURO MOROS"},
+		{TraceNumber: "121042880000002", TransactionCode: "22", Amount: 250055, IndividualID: "EMP002", OriginatorName: "ACME PAYROLL", ReceiverName: "JANE DOE"},
+	}, payroll.Entries)
+
+	// International entries name their parties in addenda
+	iat := file.Batches[2]
+	require.Equal(t, "IAT", iat.SECCode)
+	require.Empty(t, iat.CompanyName)
+	require.Len(t, iat.Entries, 1)
+
+	entry := iat.Entries[0]
+	require.Equal(t, "KOREA DAESONG BANK", entry.OriginatorName)
+	require.Equal(t, &pubsearch.Address{Line1: "SOSONG STREET", City: "PYONGYANG", Country: "KP"}, entry.OriginatorAddress)
+	require.Equal(t, "JOHN SMITH", entry.ReceiverName)
+	require.Equal(t, &pubsearch.Address{Line1: "1 MAIN ST", City: "NEW YORK", State: "NY", Country: "US", PostalCode: "10001"}, entry.ReceiverAddress)
+}
+
+func TestParseFile_WithoutLineBreaks(t *testing.T) {
+	bs, err := os.ReadFile(filepath.Join("testdata", "payroll.ach"))
+	require.NoError(t, err)
+
+	file, err := ParseFile(strings.NewReader(strings.ReplaceAll(string(bs), "\n", "")))
+	require.NoError(t, err)
+	require.Equal(t, readFile(t), file)
+}
+
+func TestParseFile_Errors(t *testing.T) {
+	_, err := ParseFile(strings.NewReader(""))
+	require.ErrorContains(t, err, "no batches found")
+
+	_, err = ParseFile(strings.NewReader("6220000000000000000000000000000000001"))
+	require.ErrorContains(t, err, "record 1: entry outside of a batch")
+
+	_, err = ParseFile(strings.NewReader("X"))
+	require.ErrorContains(t, err, `record 1: unknown record type 'X'`)
+
+	batch := "5200ACME PAYROLL                        1234567890PPDPAYROLL         240102   1121042880000001"
+	entry := "62223138010412345678         00001500X0EMP001         NICOLAS MADURO MOROS    0121042880000001"
+	_, err = ParseFile(strings.NewReader(batch + "\n" + entry))
+	require.ErrorContains(t, err, `record 2: invalid amount "00001500X0"`)
+
+	_, err = ParseFile(strings.NewReader(batch))
+	require.ErrorContains(t, err, "batch 1 isn't closed")
+}
@@ -0,0 +1,144 @@
+package ach
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/moov-io/watchman/internal/payments"
+)
+
+type Service interface {
+	// Screen searches for the originator and receiver of every entry in a file
+	Screen(ctx context.Context, file File, opts payments.ScreenOpts) (Report, error)
+}
+
+func NewService(paymentsService payments.Service) Service {
+	return &service{
+		payments: paymentsService,
+	}
+}
+
+type service struct {
+	payments payments.Service
+}
+
+// Report lists the entries of a file whose originator or receiver matched a listed entity
+type Report struct {
+	Batches  int     `json:"batches"`
+	Entries  int     `json:"entries"`
+	MinMatch float64 `json:"minMatch"`
+
+	Hits []EntryHit `json:"hits"`
+}
+
+type EntryHit struct {
+	BatchNumber string `json:"batchNumber"`
+	SECCode     string `json:"secCode"`
+
+	Entry
+
+	// Originator and Receiver are set when they matched
+	Originator *payments.PartyResult `json:"originator,omitempty"`
+	Receiver   *payments.PartyResult `json:"receiver,omitempty"`
+}
+
+func (s *service) Screen(ctx context.Context, file File, opts payments.ScreenOpts) (Report, error) {
+	out := Report{
+		MinMatch: opts.MinMatch,
+		Hits:     []EntryHit{},
+	}
+
+	// Every entry of a batch usually has the same originator, which is only searched once
+	originators := make(map[string]*payments.PartyResult)
+
+	for _, batch := range file.Batches {
+		out.Batches++
+
+		for _, entry := range batch.Entries {
+			out.Entries++
+
+			hit := EntryHit{
+				BatchNumber: batch.Number,
+				SECCode:     batch.SECCode,
+				Entry:       entry,
+			}
+
+			key := entry.OriginatorName
+			if entry.OriginatorAddress != nil {
+				key += "|" + entry.OriginatorAddress.Format()
+			}
+			originator, checked := originators[key]
+			if !checked {
+				result, err := s.screen(ctx, payments.RoleOriginator, entry.OriginatorName, entry, opts)
+				if err != nil {
+					return out, err
+				}
+				originator = result
+				originators[key] = originator
+			}
+			hit.Originator = originator
+
+			receiver, err := s.screen(ctx, payments.RoleReceiver, entry.ReceiverName, entry, opts)
+			if err != nil {
+				return out, err
+			}
+			hit.Receiver = receiver
+
+			if hit.Originator != nil || hit.Receiver != nil {
+				out.Hits = append(out.Hits, hit)
+			}
+		}
+	}
+	return out, nil
+}
+
+// screen returns the matches of a party, or nil when there aren't any
+func (s *service) screen(ctx context.Context, role payments.Role, name string, entry Entry, opts payments.ScreenOpts) (*payments.PartyResult, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, nil
+	}
+	party := payments.Party{
+		Role: role,
+		Name: name,
+	}
+	if role == payments.RoleOriginator {
+		party.Address = entry.OriginatorAddress
+	} else {
+		party.Address = entry.ReceiverAddress
+	}
+
+	result, err := s.payments.ScreenParty(ctx, party, opts)
+	if err != nil {
+		return nil, fmt.Errorf("trace number %s: %w", entry.TraceNumber, err)
+	}
+	if !result.Matched() {
+		return nil, nil
+	}
+	return &result, nil
+}
+
+// WriteText writes the report as a table with a row for each party which matched
+func (r Report) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "screened %d entries in %d batches, %d with hits above %.2f\n\n", r.Entries, r.Batches, len(r.Hits), r.MinMatch)
+	if len(r.Hits) == 0 {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "BATCH\tTRACE NUMBER\tAMOUNT\tPARTY\tNAME\tMATCH\tLIST\tSOURCE ID")
+	for _, hit := range r.Hits {
+		for _, party := range []*payments.PartyResult{hit.Originator, hit.Receiver} {
+			if party == nil || len(party.Entities) == 0 {
+				continue
+			}
+			best := party.Entities[0]
+			fmt.Fprintf(tw, "%s\t%s\t%d.%02d\t%s\t%s\t%.2f\t%s\t%s\n",
+				hit.BatchNumber, hit.TraceNumber, hit.Amount/100, hit.Amount%100,
+				party.Role, party.Name, best.Match, best.Source, best.SourceID)
+		}
+	}
+	return tw.Flush()
+}
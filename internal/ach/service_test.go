@@ -0,0 +1,63 @@
+package ach
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/payments"
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func testService(t *testing.T) Service {
+	t.Helper()
+
+	logger := log.NewTestLogger()
+	searchService := search.NewService(logger)
+	searchService.UpdateEntities([]pubsearch.Entity[pubsearch.Value]{
+		{
+			Name:     "Nicolas MADURO MOROS",
+			Type:     pubsearch.EntityPerson,
+			Source:   pubsearch.SourceUSOFAC,
+			SourceID: "22790",
+			Person:   &pubsearch.Person{Name: "Nicolas MADURO MOROS"},
+		},
+		{
+			Name:     "KOREA DAESONG BANK",
+			Type:     pubsearch.EntityBusiness,
+			Source:   pubsearch.SourceUSOFAC,
+			SourceID: "12312",
+			Business: &pubsearch.Business{Name: "KOREA DAESONG BANK"},
+		},
+	})
+	return NewService(payments.NewService(logger, searchService))
+}
+
+func TestService_Screen(t *testing.T) {
+	report, err := testService(t).Screen(context.Background(), readFile(t), payments.ScreenOpts{Limit: 5, MinMatch: 0.9})
+	require.NoError(t, err)
+	require.Equal(t, 3, report.Batches)
+	require.Equal(t, 4, report.Entries)
+	require.Len(t, report.Hits, 2)
+
+	receiver := report.Hits[0]
+	require.Equal(t, "121042880000001", receiver.TraceNumber)
+	require.Nil(t, receiver.Originator)
+	require.Equal(t, payments.RoleReceiver, receiver.Receiver.Role)
+	require.Equal(t, "22790", receiver.Receiver.Entities[0].SourceID)
+
+	originator := report.Hits[1]
+	require.Equal(t, "IAT", originator.SECCode)
+	require.Equal(t, "12312", originator.Originator.Entities[0].SourceID)
+	require.Nil(t, originator.Receiver)
+
+	var buf bytes.Buffer
+	require.NoError(t, report.WriteText(&buf))
+	require.Contains(t, buf.String(), "screened 4 entries in 3 batches, 2 with hits above 0.90")
+	require.Contains(t, buf.String(), "NICOLAS MADURO MOROS")
+	require.Contains(t, buf.String(), "1500.00")
+}
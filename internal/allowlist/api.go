@@ -0,0 +1,170 @@
+package allowlist
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/moov-io/watchman/internal/auth"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+)
+
+type Controller interface {
+	AppendRoutes(router *mux.Router) *mux.Router
+}
+
+func NewController(logger log.Logger, service Service) Controller {
+	return &controller{
+		logger:  logger,
+		service: service,
+	}
+}
+
+type controller struct {
+	logger  log.Logger
+	service Service
+}
+
+func (c *controller) AppendRoutes(router *mux.Router) *mux.Router {
+	router.
+		Name("Allowlist.create").
+		Methods("POST").
+		Path("/v2/allowlist").
+		HandlerFunc(c.createEntry)
+
+	router.
+		Name("Allowlist.list").
+		Methods("GET").
+		Path("/v2/allowlist").
+		HandlerFunc(c.listEntries)
+
+	router.
+		Name("Allowlist.audit").
+		Methods("GET").
+		Path("/v2/allowlist/audit").
+		HandlerFunc(c.listAuditEvents)
+
+	router.
+		Name("Allowlist.delete").
+		Methods("DELETE").
+		Path("/v2/allowlist/{entryID}").
+		HandlerFunc(c.deleteEntry)
+
+	return router
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (c *controller) writeError(w http.ResponseWriter, status int, err error) {
+	c.logger.Error().LogError(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error: err.Error(),
+	})
+}
+
+func (c *controller) createEntry(w http.ResponseWriter, r *http.Request) {
+	var req Entry
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.writeError(w, http.StatusBadRequest, fmt.Errorf("reading allowlist entry: %w", err))
+		return
+	}
+	actor, err := auth.RequestActor(r, req.CreatedBy)
+	if err != nil {
+		c.writeError(w, http.StatusForbidden, err)
+		return
+	}
+	req.CreatedBy = actor
+
+	tenantID, err := auth.RequestTenant(r)
+	if err != nil {
+		c.writeError(w, http.StatusForbidden, err)
+		return
+	}
+	req.TenantID = tenantID
+
+	entry, err := c.service.Create(req)
+	if err != nil {
+		c.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(entry)
+}
+
+type listEntriesResponse struct {
+	Entries []Entry `json:"entries"`
+}
+
+func (c *controller) listEntries(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := auth.RequestTenant(r)
+	if err != nil {
+		c.writeError(w, http.StatusForbidden, err)
+		return
+	}
+	entries, err := c.service.List(tenantID)
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("listing allowlist: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(listEntriesResponse{
+		Entries: entries,
+	})
+}
+
+type listAuditEventsResponse struct {
+	Events []AuditEvent `json:"events"`
+}
+
+func (c *controller) listAuditEvents(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := auth.RequestTenant(r)
+	if err != nil {
+		c.writeError(w, http.StatusForbidden, err)
+		return
+	}
+	events, err := c.service.AuditEvents(tenantID)
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("listing allowlist audit events: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(listAuditEventsResponse{
+		Events: events,
+	})
+}
+
+func (c *controller) deleteEntry(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := auth.RequestTenant(r)
+	if err != nil {
+		c.writeError(w, http.StatusForbidden, err)
+		return
+	}
+	q := r.URL.Query()
+	actor, err := auth.RequestActor(r, q.Get("actor"))
+	if err != nil {
+		c.writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	entry, err := c.service.Remove(tenantID, mux.Vars(r)["entryID"], actor, q.Get("reason"))
+	if err != nil {
+		c.writeError(w, http.StatusBadRequest, fmt.Errorf("removing allowlist entry: %w", err))
+		return
+	}
+	if entry == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,98 @@
+package allowlist
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/auth"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestController(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	// missing actor
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/v2/allowlist", strings.NewReader(`{"queryName": "Acme", "sourceList": "us_ofac", "sourceID": "123"}`))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// query name too long
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/v2/allowlist", strings.NewReader(`{"queryName": "`+strings.Repeat("a", 501)+`", "sourceList": "us_ofac", "sourceID": "123"}`))
+	req.Header.Set("X-User-ID", "jane")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// create
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/v2/allowlist", strings.NewReader(`{"queryName": "Acme", "sourceList": "us_ofac", "sourceID": "123", "reason": "different dob"}`))
+	req.Header.Set("X-User-ID", "jane")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var entry Entry
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&entry))
+	require.Equal(t, "jane", entry.CreatedBy)
+	require.NotEmpty(t, entry.EntryID)
+
+	// authenticated clients can't claim to be someone else
+	ctx := auth.WithClient(context.Background(), &auth.Client{Name: "payments"})
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/v2/allowlist", strings.NewReader(`{"queryName": "Acme", "sourceList": "us_ofac", "sourceID": "456", "createdBy": "jane"}`)).WithContext(ctx)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	// list
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/allowlist", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp listEntriesResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Entries, 1)
+
+	// other tenants can't see or remove the entry
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/allowlist?tenantID=acme", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Empty(t, resp.Entries)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/v2/allowlist/"+entry.EntryID+"?actor=john&tenantID=acme", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	// delete
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/v2/allowlist/"+entry.EntryID+"?actor=john", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/v2/allowlist/"+entry.EntryID+"?actor=john", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	// audit
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/allowlist/audit", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var audit listAuditEventsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&audit))
+	require.Len(t, audit.Events, 2)
+	require.Equal(t, "john", audit.Events[1].Actor)
+}
@@ -0,0 +1,50 @@
+package allowlist
+
+import (
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// Entry marks an entity as a confirmed false positive for a query name
+type Entry struct {
+	EntryID string `json:"entryID"`
+
+	// TenantID limits the entry to the searches of a tenant, see auth.RequestTenant
+	TenantID string `json:"tenantID,omitempty"`
+
+	QueryName  string            `json:"queryName"`
+	SourceList search.SourceList `json:"sourceList"`
+	SourceID   string            `json:"sourceID"`
+
+	Action Action `json:"action"`
+	Reason string `json:"reason"`
+
+	CreatedBy string    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type Action string
+
+var (
+	// ActionSuppress removes the entity from search results
+	ActionSuppress Action = "suppress"
+
+	// ActionDownrank lowers the entity's score in search results
+	ActionDownrank Action = "downrank"
+)
+
+// AuditEvent records each change made to the allowlist
+type AuditEvent struct {
+	EntryID string    `json:"entryID"`
+	Type    string    `json:"type"` // created, removed
+	Entry   Entry     `json:"entry"`
+	Actor   string    `json:"actor"`
+	Reason  string    `json:"reason,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+const (
+	AuditCreated = "created"
+	AuditRemoved = "removed"
+)
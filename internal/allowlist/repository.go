@@ -0,0 +1,118 @@
+package allowlist
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+)
+
+type Repository interface {
+	Save(entry Entry) error
+	List(tenantID string) ([]Entry, error)
+	Delete(tenantID, entryID string) (*Entry, error)
+
+	// Lookup returns the tenant's entries whose normalized query name matches
+	Lookup(tenantID, normalizedQuery string) ([]Entry, error)
+
+	RecordAudit(event AuditEvent) error
+	AuditEvents() ([]AuditEvent, error)
+}
+
+func NewInMemoryRepository() Repository {
+	return &inmemRepository{
+		entries: make(map[string]Entry),
+		byQuery: make(map[queryKey][]string),
+	}
+}
+
+type inmemRepository struct {
+	mu      sync.RWMutex
+	entries map[string]Entry
+	byQuery map[queryKey][]string
+	audit   []AuditEvent
+}
+
+// queryKey is the tenant and normalized query name of entries
+type queryKey struct {
+	tenantID string
+	query    string
+}
+
+func (r *inmemRepository) Save(entry Entry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries[entry.EntryID] = entry
+
+	key := queryKey{tenantID: entry.TenantID, query: normalizeQuery(entry.QueryName)}
+	if !slices.Contains(r.byQuery[key], entry.EntryID) {
+		r.byQuery[key] = append(r.byQuery[key], entry.EntryID)
+	}
+	return nil
+}
+
+func (r *inmemRepository) List(tenantID string) ([]Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]Entry, 0, len(r.entries))
+	for _, entry := range r.entries {
+		if entry.TenantID == tenantID {
+			out = append(out, entry)
+		}
+	}
+	slices.SortFunc(out, func(a, b Entry) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.EntryID, b.EntryID))
+	})
+	return out, nil
+}
+
+func (r *inmemRepository) Delete(tenantID, entryID string) (*Entry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, exists := r.entries[entryID]
+	if !exists || entry.TenantID != tenantID {
+		return nil, nil
+	}
+	delete(r.entries, entryID)
+
+	key := queryKey{tenantID: entry.TenantID, query: normalizeQuery(entry.QueryName)}
+	r.byQuery[key] = slices.DeleteFunc(r.byQuery[key], func(id string) bool {
+		return id == entryID
+	})
+	if len(r.byQuery[key]) == 0 {
+		delete(r.byQuery, key)
+	}
+	return &entry, nil
+}
+
+func (r *inmemRepository) Lookup(tenantID, normalizedQuery string) ([]Entry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := r.byQuery[queryKey{tenantID: tenantID, query: normalizedQuery}]
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	out := make([]Entry, 0, len(ids))
+	for _, id := range ids {
+		out = append(out, r.entries[id])
+	}
+	return out, nil
+}
+
+func (r *inmemRepository) RecordAudit(event AuditEvent) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.audit = append(r.audit, event)
+	return nil
+}
+
+func (r *inmemRepository) AuditEvents() ([]AuditEvent, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.audit), nil
+}
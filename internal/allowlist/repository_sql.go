@@ -0,0 +1,114 @@
+package allowlist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/moov-io/watchman/internal/database"
+
+	"github.com/moov-io/base"
+)
+
+// NewSQLRepository keeps allowlist entries and their audit trail in db, so they're shared between Watchman instances
+func NewSQLRepository(db *database.DB) Repository {
+	return &sqlRepository{db: db}
+}
+
+type sqlRepository struct {
+	db *database.DB
+}
+
+func (r *sqlRepository) Save(entry Entry) error {
+	bs, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	return r.db.InTx(ctx, func(tx *database.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM allowlist_entries WHERE entry_id = ?`, entry.EntryID)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `INSERT INTO allowlist_entries (entry_id, tenant_id, normalized_query, created_at, data) VALUES (?, ?, ?, ?, ?)`,
+			entry.EntryID, entry.TenantID, normalizeQuery(entry.QueryName), database.ToMillis(entry.CreatedAt), string(bs))
+		return err
+	})
+}
+
+func (r *sqlRepository) List(tenantID string) ([]Entry, error) {
+	return r.queryEntries(`SELECT data FROM allowlist_entries WHERE tenant_id = ? ORDER BY created_at, entry_id`, tenantID)
+}
+
+func (r *sqlRepository) Delete(tenantID, entryID string) (*Entry, error) {
+	entries, err := r.queryEntries(`SELECT data FROM allowlist_entries WHERE entry_id = ? AND tenant_id = ?`, entryID, tenantID)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+
+	_, err = r.db.ExecContext(context.Background(), `DELETE FROM allowlist_entries WHERE entry_id = ?`, entryID)
+	if err != nil {
+		return nil, err
+	}
+	return &entries[0], nil
+}
+
+func (r *sqlRepository) Lookup(tenantID, normalizedQuery string) ([]Entry, error) {
+	return r.queryEntries(`SELECT data FROM allowlist_entries WHERE tenant_id = ? AND normalized_query = ? ORDER BY created_at, entry_id`,
+		tenantID, normalizedQuery)
+}
+
+func (r *sqlRepository) queryEntries(query string, args ...any) ([]Entry, error) {
+	rows, err := r.db.QueryContext(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Entry
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var entry Entry
+		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+			return nil, fmt.Errorf("reading allowlist entry: %w", err)
+		}
+		out = append(out, entry)
+	}
+	return out, rows.Err()
+}
+
+func (r *sqlRepository) RecordAudit(event AuditEvent) error {
+	bs, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.ExecContext(context.Background(), `INSERT INTO allowlist_audit (event_id, entry_id, created_at, data) VALUES (?, ?, ?, ?)`,
+		base.ID(), event.EntryID, database.ToMillis(event.At), string(bs))
+	return err
+}
+
+func (r *sqlRepository) AuditEvents() ([]AuditEvent, error) {
+	rows, err := r.db.QueryContext(context.Background(), `SELECT data FROM allowlist_audit ORDER BY created_at, event_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []AuditEvent
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var event AuditEvent
+		if err := json.Unmarshal([]byte(data), &event); err != nil {
+			return nil, fmt.Errorf("reading allowlist audit event: %w", err)
+		}
+		out = append(out, event)
+	}
+	return out, rows.Err()
+}
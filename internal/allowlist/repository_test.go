@@ -0,0 +1,105 @@
+package allowlist
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/database"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository(t *testing.T) {
+	repos := map[string]Repository{
+		"inmem": NewInMemoryRepository(),
+		"sql":   NewSQLRepository(database.NewTestDB(t)),
+	}
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			testRepository(t, repo)
+		})
+	}
+}
+
+func testRepository(t *testing.T, repo Repository) {
+	t.Helper()
+
+	now := time.Now().In(time.UTC).Truncate(time.Millisecond)
+	first := Entry{
+		EntryID:    "a1",
+		QueryName:  "Acme Shipping",
+		SourceList: search.SourceUSOFAC,
+		SourceID:   "123",
+		Action:     ActionSuppress,
+		CreatedBy:  "jane",
+		CreatedAt:  now,
+	}
+	second := Entry{
+		EntryID:    "b2",
+		QueryName:  "ACME shipping.",
+		SourceList: search.SourceUSOFAC,
+		SourceID:   "456",
+		Action:     ActionDownrank,
+		CreatedBy:  "jane",
+		CreatedAt:  now.Add(time.Second),
+	}
+	require.NoError(t, repo.Save(second))
+	require.NoError(t, repo.Save(first))
+
+	entries, err := repo.List("")
+	require.NoError(t, err)
+	require.Equal(t, []Entry{first, second}, entries)
+
+	entries, err = repo.Lookup("", "acme shipping")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Entry{first, second}, entries)
+
+	entries, err = repo.Lookup("", "other")
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	// Entries are kept per tenant
+	tenant := Entry{
+		EntryID:    "c3",
+		TenantID:   "acme",
+		QueryName:  "Acme Shipping",
+		SourceList: search.SourceUSOFAC,
+		SourceID:   "789",
+		Action:     ActionSuppress,
+		CreatedBy:  "jane",
+		CreatedAt:  now.Add(2 * time.Second),
+	}
+	require.NoError(t, repo.Save(tenant))
+
+	entries, err = repo.List("acme")
+	require.NoError(t, err)
+	require.Equal(t, []Entry{tenant}, entries)
+
+	entries, err = repo.Lookup("acme", "acme shipping")
+	require.NoError(t, err)
+	require.Equal(t, []Entry{tenant}, entries)
+
+	removed, err := repo.Delete("", tenant.EntryID)
+	require.NoError(t, err)
+	require.Nil(t, removed)
+
+	removed, err = repo.Delete("", first.EntryID)
+	require.NoError(t, err)
+	require.Equal(t, &first, removed)
+
+	removed, err = repo.Delete("", first.EntryID)
+	require.NoError(t, err)
+	require.Nil(t, removed)
+
+	entries, err = repo.Lookup("", "acme shipping")
+	require.NoError(t, err)
+	require.Equal(t, []Entry{second}, entries)
+
+	event := AuditEvent{EntryID: first.EntryID, Type: AuditRemoved, Entry: first, Actor: "john", Reason: "mistake", At: now}
+	require.NoError(t, repo.RecordAudit(event))
+
+	events, err := repo.AuditEvents()
+	require.NoError(t, err)
+	require.Equal(t, []AuditEvent{event}, events)
+}
@@ -0,0 +1,210 @@
+package allowlist
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base"
+	"github.com/moov-io/base/log"
+)
+
+var (
+	// downrankFactor is multiplied against the score of downranked entities
+	downrankFactor = 0.5
+)
+
+const (
+	// maxQueryLength is the longest normalized query name stored, as limited by the allowlist_entries table
+	maxQueryLength = 500
+)
+
+type Service interface {
+	Create(entry Entry) (Entry, error)
+	List(tenantID string) ([]Entry, error)
+	Remove(tenantID, entryID, actor, reason string) (*Entry, error)
+
+	AuditEvents(tenantID string) ([]AuditEvent, error)
+
+	// Adjust is called by search.Service to suppress or downrank the entities allowlisted by the searching tenant
+	Adjust(tenantID string, query search.Entity[search.Value]) func(index search.Entity[search.Value], score float64) float64
+}
+
+func NewService(logger log.Logger, repo Repository) Service {
+	return &service{
+		logger: logger,
+		repo:   repo,
+	}
+}
+
+type service struct {
+	logger log.Logger
+	repo   Repository
+}
+
+// WithChangeHook calls changed after each entry service creates or removes, such as to clear the cached search
+// results the entry adjusts
+func WithChangeHook(service Service, changed func()) Service {
+	return &changeHook{
+		Service: service,
+		changed: changed,
+	}
+}
+
+type changeHook struct {
+	Service
+
+	changed func()
+}
+
+func (s *changeHook) Create(entry Entry) (Entry, error) {
+	entry, err := s.Service.Create(entry)
+	if err == nil {
+		s.changed()
+	}
+	return entry, err
+}
+
+func (s *changeHook) Remove(tenantID, entryID, actor, reason string) (*Entry, error) {
+	entry, err := s.Service.Remove(tenantID, entryID, actor, reason)
+	if err == nil && entry != nil {
+		s.changed()
+	}
+	return entry, err
+}
+
+func normalizeQuery(name string) string {
+	return strings.Join(strings.Fields(prepare.LowerAndRemovePunctuation(name)), " ")
+}
+
+func (s *service) Create(entry Entry) (Entry, error) {
+	entry.QueryName = strings.TrimSpace(entry.QueryName)
+	entry.SourceID = strings.TrimSpace(entry.SourceID)
+	entry.CreatedBy = strings.TrimSpace(entry.CreatedBy)
+
+	switch {
+	case normalizeQuery(entry.QueryName) == "":
+		return Entry{}, errors.New("missing queryName")
+	case utf8.RuneCountInString(normalizeQuery(entry.QueryName)) > maxQueryLength:
+		return Entry{}, fmt.Errorf("queryName is longer than %d characters", maxQueryLength)
+	case entry.SourceList == "":
+		return Entry{}, errors.New("missing sourceList")
+	case entry.SourceID == "":
+		return Entry{}, errors.New("missing sourceID")
+	case entry.CreatedBy == "":
+		return Entry{}, errors.New("missing createdBy")
+	}
+
+	switch entry.Action {
+	case "":
+		entry.Action = ActionSuppress
+	case ActionSuppress, ActionDownrank:
+	default:
+		return Entry{}, fmt.Errorf("unknown action %q", entry.Action)
+	}
+
+	entry.EntryID = base.ID()
+	entry.CreatedAt = time.Now().In(time.UTC)
+
+	err := s.repo.Save(entry)
+	if err != nil {
+		return Entry{}, fmt.Errorf("saving allowlist entry: %w", err)
+	}
+
+	err = s.repo.RecordAudit(AuditEvent{
+		EntryID: entry.EntryID,
+		Type:    AuditCreated,
+		Entry:   entry,
+		Actor:   entry.CreatedBy,
+		Reason:  entry.Reason,
+		At:      entry.CreatedAt,
+	})
+	if err != nil {
+		return Entry{}, fmt.Errorf("recording allowlist audit: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (s *service) List(tenantID string) ([]Entry, error) {
+	return s.repo.List(tenantID)
+}
+
+func (s *service) Remove(tenantID, entryID, actor, reason string) (*Entry, error) {
+	actor = strings.TrimSpace(actor)
+	if actor == "" {
+		return nil, errors.New("missing actor")
+	}
+
+	entry, err := s.repo.Delete(tenantID, entryID)
+	if err != nil {
+		return nil, fmt.Errorf("deleting allowlist entry: %w", err)
+	}
+	if entry == nil {
+		return nil, nil
+	}
+
+	err = s.repo.RecordAudit(AuditEvent{
+		EntryID: entry.EntryID,
+		Type:    AuditRemoved,
+		Entry:   *entry,
+		Actor:   actor,
+		Reason:  reason,
+		At:      time.Now().In(time.UTC),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("recording allowlist audit: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (s *service) AuditEvents(tenantID string) ([]AuditEvent, error) {
+	events, err := s.repo.AuditEvents()
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(events, func(event AuditEvent) bool {
+		return event.Entry.TenantID != tenantID
+	}), nil
+}
+
+func (s *service) Adjust(tenantID string, query search.Entity[search.Value]) func(index search.Entity[search.Value], score float64) float64 {
+	entries, err := s.repo.Lookup(tenantID, normalizeQuery(query.Name))
+	if err != nil {
+		s.logger.Error().LogErrorf("problem reading allowlist: %v", err)
+		return nil
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	type key struct {
+		source   search.SourceList
+		sourceID string
+	}
+	actions := make(map[key]Action, len(entries))
+	for _, entry := range entries {
+		k := key{entry.SourceList, entry.SourceID}
+		// Suppression takes priority over downranking
+		if actions[k] != ActionSuppress {
+			actions[k] = entry.Action
+		}
+	}
+
+	return func(index search.Entity[search.Value], score float64) float64 {
+		switch actions[key{index.Source, index.SourceID}] {
+		case ActionSuppress:
+			return 0.0
+		case ActionDownrank:
+			return score * downrankFactor
+		}
+		return score
+	}
+}
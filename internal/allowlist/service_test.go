@@ -0,0 +1,158 @@
+package allowlist
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func testService(t *testing.T) Service {
+	t.Helper()
+
+	return NewService(log.NewTestLogger(), NewInMemoryRepository())
+}
+
+func TestService_Adjust(t *testing.T) {
+	svc := testService(t)
+
+	suppressed := search.Entity[search.Value]{Source: search.SourceUSOFAC, SourceID: "123"}
+	downranked := search.Entity[search.Value]{Source: search.SourceUSOFAC, SourceID: "456"}
+	other := search.Entity[search.Value]{Source: search.SourceUSOFAC, SourceID: "789"}
+
+	_, err := svc.Create(Entry{
+		QueryName:  "Acme Shipping",
+		SourceList: search.SourceUSOFAC,
+		SourceID:   "123",
+		CreatedBy:  "jane",
+	})
+	require.NoError(t, err)
+
+	_, err = svc.Create(Entry{
+		QueryName:  "Acme Shipping",
+		SourceList: search.SourceUSOFAC,
+		SourceID:   "456",
+		Action:     ActionDownrank,
+		CreatedBy:  "jane",
+	})
+	require.NoError(t, err)
+
+	// Unrelated queries aren't adjusted
+	require.Nil(t, svc.Adjust("", search.Entity[search.Value]{Name: "Other Company"}))
+
+	// Query names are normalized
+	adjust := svc.Adjust("", search.Entity[search.Value]{Name: "ACME  shipping."})
+	require.NotNil(t, adjust)
+
+	require.InDelta(t, 0.0, adjust(suppressed, 0.95), 0.001)
+	require.InDelta(t, 0.475, adjust(downranked, 0.95), 0.001)
+	require.InDelta(t, 0.95, adjust(other, 0.95), 0.001)
+}
+
+func TestService_AdjustTenants(t *testing.T) {
+	svc := testService(t)
+
+	_, err := svc.Create(Entry{
+		TenantID:   "a",
+		QueryName:  "Acme Shipping",
+		SourceList: search.SourceUSOFAC,
+		SourceID:   "123",
+		CreatedBy:  "jane",
+	})
+	require.NoError(t, err)
+
+	query := search.Entity[search.Value]{Name: "Acme Shipping"}
+	index := search.Entity[search.Value]{Source: search.SourceUSOFAC, SourceID: "123"}
+
+	adjust := svc.Adjust("a", query)
+	require.NotNil(t, adjust)
+	require.InDelta(t, 0.0, adjust(index, 0.95), 0.001)
+
+	// Other tenants, and searches without one, aren't adjusted by tenant a's entry
+	require.Nil(t, svc.Adjust("b", query))
+	require.Nil(t, svc.Adjust("", query))
+
+	entries, err := svc.List("b")
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	events, err := svc.AuditEvents("b")
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
+
+func TestService_Audit(t *testing.T) {
+	svc := testService(t)
+
+	_, err := svc.Create(Entry{QueryName: "Acme"})
+	require.ErrorContains(t, err, "missing sourceList")
+
+	_, err = svc.Create(Entry{QueryName: "Acme", SourceList: search.SourceUSOFAC, SourceID: "123"})
+	require.ErrorContains(t, err, "missing createdBy")
+
+	_, err = svc.Create(Entry{QueryName: "Acme", SourceList: search.SourceUSOFAC, SourceID: "123", CreatedBy: "jane", Action: "hide"})
+	require.ErrorContains(t, err, "unknown action")
+
+	_, err = svc.Create(Entry{QueryName: strings.Repeat("Ä", 501), SourceList: search.SourceUSOFAC, SourceID: "123", CreatedBy: "jane"})
+	require.ErrorContains(t, err, "queryName is longer than 500 characters")
+
+	entry, err := svc.Create(Entry{
+		QueryName:  "Acme",
+		SourceList: search.SourceUSOFAC,
+		SourceID:   "123",
+		Reason:     "different country",
+		CreatedBy:  "jane",
+	})
+	require.NoError(t, err)
+	require.Equal(t, ActionSuppress, entry.Action)
+
+	_, err = svc.Remove("", entry.EntryID, "", "")
+	require.ErrorContains(t, err, "missing actor")
+
+	removed, err := svc.Remove("", entry.EntryID, "john", "reviewed again")
+	require.NoError(t, err)
+	require.NotNil(t, removed)
+
+	removed, err = svc.Remove("", entry.EntryID, "john", "")
+	require.NoError(t, err)
+	require.Nil(t, removed)
+
+	require.Nil(t, svc.Adjust("", search.Entity[search.Value]{Name: "Acme"}))
+
+	events, err := svc.AuditEvents("")
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	require.Equal(t, AuditCreated, events[0].Type)
+	require.Equal(t, "jane", events[0].Actor)
+	require.Equal(t, "different country", events[0].Reason)
+
+	require.Equal(t, AuditRemoved, events[1].Type)
+	require.Equal(t, "john", events[1].Actor)
+	require.Equal(t, "reviewed again", events[1].Reason)
+}
+
+func TestService_ChangeHook(t *testing.T) {
+	var changes int
+	svc := WithChangeHook(testService(t), func() { changes++ })
+
+	_, err := svc.Create(Entry{QueryName: "Acme"})
+	require.Error(t, err)
+	require.Equal(t, 0, changes)
+
+	entry, err := svc.Create(Entry{QueryName: "Acme", SourceList: search.SourceUSOFAC, SourceID: "123", CreatedBy: "jane"})
+	require.NoError(t, err)
+	require.Equal(t, 1, changes)
+
+	_, err = svc.Remove("", entry.EntryID, "john", "")
+	require.NoError(t, err)
+	require.Equal(t, 2, changes)
+
+	// Removing an entry which is already gone doesn't change anything
+	_, err = svc.Remove("", entry.EntryID, "john", "")
+	require.NoError(t, err)
+	require.Equal(t, 2, changes)
+}
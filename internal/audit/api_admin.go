@@ -0,0 +1,66 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/moov-io/watchman/internal/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+)
+
+// NewAdminController returns the route which replays recorded searches at other minimum scores, see Simulate.
+// It's expected to be served on the admin server.
+func NewAdminController(logger log.Logger, reader Reader, service search.Service) search.Controller {
+	return &adminController{
+		logger:  logger,
+		reader:  reader,
+		service: service,
+	}
+}
+
+type adminController struct {
+	logger  log.Logger
+	reader  Reader
+	service search.Service
+}
+
+func (c *adminController) AppendRoutes(router *mux.Router) *mux.Router {
+	router.
+		Name("SimulateThresholds").
+		Methods("POST").
+		Path("/audit/simulate").
+		HandlerFunc(c.simulate)
+
+	return router
+}
+
+func (c *adminController) simulate(w http.ResponseWriter, r *http.Request) {
+	var req SimulationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.writeError(w, fmt.Errorf("reading simulation request: %w", err))
+		return
+	}
+
+	simulation, err := Simulate(r.Context(), c.reader, c.service, req)
+	if err != nil {
+		c.writeError(w, fmt.Errorf("simulating thresholds: %w", err))
+		return
+	}
+	c.logger.Info().Logf("replayed %d searches at thresholds %v", simulation.Replayed, req.Thresholds)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(simulation)
+}
+
+func (c *adminController) writeError(w http.ResponseWriter, err error) {
+	c.logger.Error().LogError(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+type FileConfig struct {
+	// Path is appended to, and created when it doesn't exist
+	Path string
+}
+
+type fileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newFileSink(conf FileConfig) (*fileSink, error) {
+	if conf.Path == "" {
+		return nil, errors.New("audit: missing file path")
+	}
+	fd, err := os.OpenFile(conf.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("audit: opening %s: %w", conf.Path, err)
+	}
+	return &fileSink{file: fd}, nil
+}
+
+func (s *fileSink) Write(ctx context.Context, record Record) error {
+	bs, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("audit: encoding record: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Each record is written with one call, so lines aren't interleaved with other writers of the file
+	if _, err := s.file.Write(append(bs, '\n')); err != nil {
+		return fmt.Errorf("audit: writing record: %w", err)
+	}
+	return nil
+}
+
+func (s *fileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.file.Sync(); err != nil {
+		s.file.Close()
+		return err
+	}
+	return s.file.Close()
+}
@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type KafkaConfig struct {
+	Brokers []string
+	Topic   string
+}
+
+type kafkaSink struct {
+	writer *kafka.Writer
+}
+
+func newKafkaSink(conf KafkaConfig) (*kafkaSink, error) {
+	if len(conf.Brokers) == 0 {
+		return nil, errors.New("audit: kafka: missing brokers")
+	}
+	if conf.Topic == "" {
+		return nil, errors.New("audit: kafka: missing topic")
+	}
+
+	return &kafkaSink{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(conf.Brokers...),
+			Topic:        conf.Topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		},
+	}, nil
+}
+
+func (s *kafkaSink) Write(ctx context.Context, record Record) error {
+	bs, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("audit: kafka: encoding record: %w", err)
+	}
+
+	err = s.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(record.RecordID),
+		Value: bs,
+		Headers: []kafka.Header{
+			{Key: "type", Value: []byte(record.Type)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("audit: kafka: writing record: %w", err)
+	}
+	return nil
+}
+
+func (s *kafkaSink) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,90 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+)
+
+// Record evidences one search: who made it, what was searched for, which version of each list was searched
+// and the best results found
+type Record struct {
+	RecordID  string    `json:"recordID"`
+	Timestamp time.Time `json:"timestamp"`
+
+	// Client is the name of the API key or token client which searched, empty when requests aren't authenticated
+	Client    string `json:"client,omitempty"`
+	TenantID  string `json:"tenantID,omitempty"`
+	RequestID string `json:"requestID,omitempty"`
+
+	// Type is the kind of search made: entity, identifier, vessel, aircraft or crypto
+	Type  string          `json:"type"`
+	Query json.RawMessage `json:"query"`
+
+	// QueryID is a hash of Query, which is logged with the search in place of its details
+	QueryID string `json:"queryID"`
+
+	// Options are set for entity searches
+	Options *Options `json:"options,omitempty"`
+
+	// ListVersions holds the VersionID of each list searched, keyed by list
+	ListVersions map[string]string `json:"listVersions,omitempty"`
+
+	// Results are the best results returned, highest scoring first
+	Results []Result `json:"results"`
+
+	// Error is set when the search failed
+	Error string `json:"error,omitempty"`
+}
+
+const (
+	TypeEntity     = "entity"
+	TypeIdentifier = "identifier"
+	TypeVessel     = "vessel"
+	TypeAircraft   = "aircraft"
+	TypeCrypto     = "crypto"
+	TypeRemarks    = "remarks"
+)
+
+// Options are the search options which change which results are returned
+type Options struct {
+	Limit       int                  `json:"limit"`
+	MinMatch    float64              `json:"minMatch"`
+	Algorithm   string               `json:"algorithm,omitempty"`
+	ExactFirst  bool                 `json:"exactFirst,omitempty"`
+	Partial     bool                 `json:"partial,omitempty"`
+	Consolidate bool                 `json:"consolidate,omitempty"`
+	Prepare     []prepare.Stage      `json:"prepare,omitempty"`
+	Weights     pubsearch.Weights    `json:"weights"`
+	Filters     search.SearchFilters `json:"filters"`
+	AsOf        *time.Time           `json:"asOf,omitempty"`
+}
+
+func newOptions(opts search.SearchOpts) *Options {
+	out := &Options{
+		Limit:       opts.Limit,
+		MinMatch:    opts.MinMatch,
+		Algorithm:   opts.Algorithm,
+		ExactFirst:  opts.ExactFirst,
+		Partial:     opts.Partial,
+		Consolidate: opts.Consolidate,
+		Prepare:     opts.Prepare,
+		Weights:     opts.Weights,
+		Filters:     opts.Filters,
+	}
+	if !opts.AsOf.IsZero() {
+		out.AsOf = &opts.AsOf
+	}
+	return out
+}
+
+// Result is an entity returned by a search along with its score
+type Result struct {
+	Source   pubsearch.SourceList `json:"source"`
+	SourceID string               `json:"sourceID"`
+	Name     string               `json:"name"`
+	Match    float64              `json:"match"`
+}
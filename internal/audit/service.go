@@ -0,0 +1,244 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/moov-io/watchman/internal/auth"
+	"github.com/moov-io/watchman/internal/search"
+	"github.com/moov-io/watchman/internal/versions"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base"
+	"github.com/moov-io/base/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	recordsWritten = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "audit_records_total",
+		Help: "Count of searches recorded to the audit log by their result (written or failed)",
+	}, []string{"result"})
+)
+
+// NewSearchService records each search made through service to sink, along with the version of each list which
+// was searched. versions can be nil when list versions aren't kept, and topResults is how many results of each
+// search are recorded.
+//
+// Searches are answered even when they can't be recorded, which is logged and counted.
+func NewSearchService(logger log.Logger, service search.Service, sink Sink, versions versions.Service, topResults int) search.Service {
+	if topResults <= 0 {
+		topResults = defaultTopResults
+	}
+	return &auditedService{
+		Service:    service,
+		logger:     logger,
+		sink:       sink,
+		versions:   versions,
+		topResults: topResults,
+	}
+}
+
+type auditedService struct {
+	search.Service
+
+	logger     log.Logger
+	sink       Sink
+	versions   versions.Service
+	topResults int
+
+	// listVersions are read again each time the searched entities change
+	mu           sync.Mutex
+	versionsAt   time.Time
+	listVersions map[string]string
+}
+
+func (s *auditedService) Search(ctx context.Context, query pubsearch.Entity[pubsearch.Value], opts search.SearchOpts) ([]pubsearch.SearchedEntity[pubsearch.Value], error) {
+	results, err := s.Service.Search(ctx, query, opts)
+
+	record := s.newRecord(ctx, TypeEntity, query, err)
+	record.TenantID = opts.TenantID
+	record.RequestID = opts.RequestID
+	record.Options = newOptions(opts)
+	if !opts.AsOf.IsZero() {
+		record.ListVersions = s.versionsAsOf(ctx, opts.AsOf)
+	}
+	record.Results = topResults(results, s.topResults, func(r pubsearch.SearchedEntity[pubsearch.Value]) pubsearch.SearchedEntity[pubsearch.Value] {
+		return r
+	})
+	s.write(record)
+
+	return results, err
+}
+
+func (s *auditedService) SearchByIdentifier(ctx context.Context, query search.IdentifierQuery) ([]search.IdentifierMatch, error) {
+	results, err := s.Service.SearchByIdentifier(ctx, query)
+
+	record := s.newRecord(ctx, TypeIdentifier, query, err)
+	record.Results = topResults(results, s.topResults, func(r search.IdentifierMatch) pubsearch.SearchedEntity[pubsearch.Value] {
+		return r.SearchedEntity
+	})
+	s.write(record)
+
+	return results, err
+}
+
+func (s *auditedService) SearchVessels(ctx context.Context, query search.VesselQuery) ([]search.VehicleMatch, error) {
+	results, err := s.Service.SearchVessels(ctx, query)
+
+	record := s.newRecord(ctx, TypeVessel, query, err)
+	record.Results = topResults(results, s.topResults, vehicleEntity)
+	s.write(record)
+
+	return results, err
+}
+
+func (s *auditedService) SearchAircraft(ctx context.Context, query search.AircraftQuery) ([]search.VehicleMatch, error) {
+	results, err := s.Service.SearchAircraft(ctx, query)
+
+	record := s.newRecord(ctx, TypeAircraft, query, err)
+	record.Results = topResults(results, s.topResults, vehicleEntity)
+	s.write(record)
+
+	return results, err
+}
+
+func (s *auditedService) SearchCryptoAddress(ctx context.Context, query search.CryptoAddressQuery) ([]search.CryptoAddressMatch, error) {
+	results, err := s.Service.SearchCryptoAddress(ctx, query)
+
+	record := s.newRecord(ctx, TypeCrypto, query, err)
+	record.Results = topResults(results, s.topResults, func(r search.CryptoAddressMatch) pubsearch.SearchedEntity[pubsearch.Value] {
+		return r.SearchedEntity
+	})
+	s.write(record)
+
+	return results, err
+}
+
+func (s *auditedService) SearchRemarks(ctx context.Context, query search.RemarksQuery) ([]search.RemarksMatch, error) {
+	results, err := s.Service.SearchRemarks(ctx, query)
+
+	record := s.newRecord(ctx, TypeRemarks, query, err)
+	record.Results = topResults(results, s.topResults, func(r search.RemarksMatch) pubsearch.SearchedEntity[pubsearch.Value] {
+		return r.SearchedEntity
+	})
+	s.write(record)
+
+	return results, err
+}
+
+func vehicleEntity(r search.VehicleMatch) pubsearch.SearchedEntity[pubsearch.Value] {
+	return r.SearchedEntity
+}
+
+func (s *auditedService) newRecord(ctx context.Context, searchType string, query any, searchErr error) Record {
+	record := Record{
+		RecordID:     base.ID(),
+		Timestamp:    time.Now().In(time.UTC),
+		Type:         searchType,
+		ListVersions: s.currentVersions(ctx),
+	}
+	if client := auth.ClientFrom(ctx); client != nil {
+		record.Client = client.Name
+	}
+	if bs, err := json.Marshal(query); err == nil {
+		record.Query = bs
+		record.QueryID = search.QueryID(query)
+	}
+	if searchErr != nil {
+		record.Error = searchErr.Error()
+	}
+	return record
+}
+
+func (s *auditedService) write(record Record) {
+	// Searches are recorded even when the caller stops waiting for their results
+	if err := s.sink.Write(context.Background(), record); err != nil {
+		recordsWritten.WithLabelValues("failed").Inc()
+		s.logger.Error().With(log.Fields{
+			"record_id": log.String(record.RecordID),
+		}).LogErrorf("problem writing audit record: %v", err)
+		return
+	}
+	recordsWritten.WithLabelValues("written").Inc()
+}
+
+// currentVersions returns the VersionID of each list being searched, keyed by list
+func (s *auditedService) currentVersions(ctx context.Context) map[string]string {
+	if s.versions == nil {
+		return nil
+	}
+	info := s.Service.ListInfo()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listVersions != nil && s.versionsAt.Equal(info.UpdatedAt) {
+		return s.listVersions
+	}
+
+	lists := make([]string, 0, len(info.Lists))
+	for list := range info.Lists {
+		lists = append(lists, list)
+	}
+	slices.Sort(lists)
+
+	current, err := s.versions.Current(ctx, lists)
+	if err != nil {
+		// Versions are read again with the next search
+		s.logger.Error().LogErrorf("problem reading list versions for audit records: %v", err)
+		return nil
+	}
+
+	s.listVersions = make(map[string]string, len(current))
+	for list, version := range current {
+		s.listVersions[list] = version.VersionID
+	}
+	s.versionsAt = info.UpdatedAt
+	return s.listVersions
+}
+
+// versionsAsOf returns the VersionID of each list searched as of a time, keyed by list
+func (s *auditedService) versionsAsOf(ctx context.Context, at time.Time) map[string]string {
+	if s.versions == nil {
+		return nil
+	}
+	lists := make([]string, 0)
+	for list := range s.Service.ListInfo().Lists {
+		lists = append(lists, list)
+	}
+	slices.Sort(lists)
+
+	versions, err := s.versions.AsOf(ctx, lists, at)
+	if err != nil {
+		s.logger.Error().LogErrorf("problem reading list versions as of %v for audit records: %v", at, err)
+		return nil
+	}
+	out := make(map[string]string, len(versions))
+	for list, version := range versions {
+		out[list] = version.VersionID
+	}
+	return out
+}
+
+// topResults returns the first n results, which are sorted best first
+func topResults[T any](results []T, n int, entity func(T) pubsearch.SearchedEntity[pubsearch.Value]) []Result {
+	if len(results) > n {
+		results = results[:n]
+	}
+	out := make([]Result, 0, len(results))
+	for _, r := range results {
+		e := entity(r)
+		out = append(out, Result{
+			Source:   e.Source,
+			SourceID: e.SourceID,
+			Name:     e.Name,
+			Match:    e.Match,
+		})
+	}
+	return out
+}
@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/auth"
+	"github.com/moov-io/watchman/internal/search"
+	"github.com/moov-io/watchman/internal/versions"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func testEntity(sourceID, name string) pubsearch.Entity[pubsearch.Value] {
+	return pubsearch.Entity[pubsearch.Value]{
+		Name:     name,
+		Type:     pubsearch.EntityBusiness,
+		Source:   pubsearch.SourceUSOFAC,
+		SourceID: sourceID,
+		Business: &pubsearch.Business{
+			Name: name,
+			GovernmentIDs: []pubsearch.GovernmentID{
+				{Type: pubsearch.GovernmentIDTax, Identifier: "TAX-" + sourceID},
+			},
+		},
+	}
+}
+
+func TestSearchService(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+
+	entities := []pubsearch.Entity[pubsearch.Value]{
+		testEntity("1", "Acme Shipping"),
+		testEntity("2", "Acme Trading"),
+		testEntity("3", "Bravo Holdings"),
+	}
+	versionService := versions.NewService(logger, versions.NewInMemoryRepository())
+	recorded, err := versionService.Record(ctx, time.Now(), map[string]int{"us_ofac": 3}, entities)
+	require.NoError(t, err)
+
+	searchService := search.NewService(logger)
+	searchService.UpdateEntities(entities)
+
+	sink := NewInMemorySink()
+	svc := NewSearchService(logger, searchService, sink, versionService, 1)
+
+	ctx = auth.WithClient(ctx, &auth.Client{Name: "payments"})
+	results, err := svc.Search(ctx, pubsearch.Entity[pubsearch.Value]{
+		Name: "Acme",
+		Type: pubsearch.EntityBusiness,
+	}, search.SearchOpts{Limit: 5, MinMatch: 0.1, Algorithm: "token-sort", RequestID: "req-1"})
+	require.NoError(t, err)
+	require.Greater(t, len(results), 1)
+
+	_, err = svc.SearchByIdentifier(ctx, search.IdentifierQuery{Identifier: "TAX-3", Limit: 5})
+	require.NoError(t, err)
+
+	records := sink.Records()
+	require.Len(t, records, 2)
+
+	record := records[0]
+	require.NotEmpty(t, record.RecordID)
+	require.Equal(t, "payments", record.Client)
+	require.Equal(t, "req-1", record.RequestID)
+	require.Equal(t, TypeEntity, record.Type)
+	require.Contains(t, string(record.Query), `"Acme"`)
+	require.Equal(t, search.QueryID(pubsearch.Entity[pubsearch.Value]{Name: "Acme", Type: pubsearch.EntityBusiness}), record.QueryID)
+	require.Equal(t, "token-sort", record.Options.Algorithm)
+	require.Equal(t, map[string]string{"us_ofac": recorded[0].VersionID}, record.ListVersions)
+	require.Empty(t, record.Error)
+
+	// Only the top results are recorded
+	require.Len(t, record.Results, 1)
+	require.Equal(t, results[0].SourceID, record.Results[0].SourceID)
+	require.Equal(t, results[0].Match, record.Results[0].Match)
+
+	record = records[1]
+	require.Equal(t, TypeIdentifier, record.Type)
+	require.Nil(t, record.Options)
+	require.Len(t, record.Results, 1)
+	require.Equal(t, "3", record.Results[0].SourceID)
+
+	// Failed searches are recorded with their error
+	_, err = svc.Search(ctx, pubsearch.Entity[pubsearch.Value]{Name: "Acme"}, search.SearchOpts{Algorithm: "unknown"})
+	require.Error(t, err)
+
+	records = sink.Records()
+	require.Len(t, records, 3)
+	require.NotEmpty(t, records[2].Error)
+	require.Empty(t, records[2].Results)
+}
@@ -0,0 +1,196 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+)
+
+// SimulationRequest chooses the recorded searches which are replayed and the minimum scores they're replayed with
+type SimulationRequest struct {
+	// Thresholds are the minMatch values results are counted at
+	Thresholds []float64 `json:"thresholds"`
+
+	// Since is the earliest searches which are replayed, which is defaultSimulationWindow ago unless it's set
+	Since time.Time `json:"since"`
+
+	// Sample is how many of the most recent entity searches are replayed, defaultSimulationSample unless it's set
+	Sample int `json:"sample"`
+}
+
+const (
+	defaultSimulationWindow = 7 * 24 * time.Hour
+	defaultSimulationSample = 200
+	maxSimulationSample     = 2000
+	maxSimulationThresholds = 20
+)
+
+// Simulation reports how many hits the replayed searches would return at each threshold
+type Simulation struct {
+	// Replayed is how many searches were made again, while Skipped searches had failed or couldn't be replayed
+	Replayed int `json:"replayed"`
+	Skipped  int `json:"skipped"`
+
+	// Current is the hits of each search at the minMatch it was made with
+	Current ThresholdHits `json:"current"`
+
+	Thresholds []ThresholdHits `json:"thresholds"`
+
+	// From and To are when the first and last replayed searches were made
+	From time.Time `json:"from,omitempty"`
+	To   time.Time `json:"to,omitempty"`
+}
+
+// ThresholdHits counts the results of the replayed searches scoring at least MinMatch
+type ThresholdHits struct {
+	MinMatch float64 `json:"minMatch,omitempty"`
+
+	Hits int `json:"hits"`
+
+	// Searches is how many of the searches had any hits
+	Searches int `json:"searches"`
+
+	// Change is how many more hits there are than Current, which is negative for fewer
+	Change int `json:"change"`
+}
+
+func (r SimulationRequest) validate() error {
+	if len(r.Thresholds) == 0 {
+		return errors.New("missing thresholds")
+	}
+	if len(r.Thresholds) > maxSimulationThresholds {
+		return fmt.Errorf("at most %d thresholds can be simulated", maxSimulationThresholds)
+	}
+	for _, threshold := range r.Thresholds {
+		if threshold <= 0 || threshold > 1 {
+			return fmt.Errorf("threshold of %v must be above 0 and at most 1", threshold)
+		}
+	}
+	if r.Sample < 0 || r.Sample > maxSimulationSample {
+		return fmt.Errorf("sample of %d must be between 0 and %d", r.Sample, maxSimulationSample)
+	}
+	return nil
+}
+
+// Simulate replays the entity searches recorded in reader against service, counting the hits each would return at
+// every threshold of req. service shouldn't record searches, so replays aren't audited again.
+//
+// Searches are made against the entities searched now, rather than the list versions they were recorded with.
+// Each threshold replaces the search's minMatch and any minimum of a list, just as a search's minMatch does.
+func Simulate(ctx context.Context, reader Reader, service search.Service, req SimulationRequest) (Simulation, error) {
+	if err := req.validate(); err != nil {
+		return Simulation{}, err
+	}
+	since := req.Since
+	if since.IsZero() {
+		since = time.Now().Add(-defaultSimulationWindow)
+	}
+	sample := req.Sample
+	if sample == 0 {
+		sample = defaultSimulationSample
+	}
+
+	thresholds := slices.Clone(req.Thresholds)
+	slices.Sort(thresholds)
+	thresholds = slices.Compact(thresholds)
+
+	out := Simulation{
+		Thresholds: make([]ThresholdHits, len(thresholds)),
+	}
+	for i, threshold := range thresholds {
+		out.Thresholds[i].MinMatch = threshold
+	}
+
+	// Only entity searches have a minMatch to tune, so more records are read than are replayed
+	records, err := reader.Recent(ctx, since, sample*2)
+	if err != nil {
+		return Simulation{}, err
+	}
+	for _, record := range records {
+		if out.Replayed == sample {
+			break
+		}
+		if err := ctx.Err(); err != nil {
+			return Simulation{}, err
+		}
+		if record.Type != TypeEntity || record.Error != "" || record.Options == nil {
+			out.Skipped++
+			continue
+		}
+
+		var query pubsearch.Entity[pubsearch.Value]
+		if err := json.Unmarshal(record.Query, &query); err != nil {
+			out.Skipped++
+			continue
+		}
+		opts := record.Options.searchOpts(record.TenantID)
+
+		current, err := service.Search(ctx, query, opts)
+		if err != nil {
+			out.Skipped++
+			continue
+		}
+		opts.MinMatch = thresholds[0]
+		results, err := service.Search(ctx, query, opts)
+		if err != nil {
+			out.Skipped++
+			continue
+		}
+
+		out.Replayed++
+		out.Current.add(len(current))
+		for i := range out.Thresholds {
+			out.Thresholds[i].add(countAtLeast(results, out.Thresholds[i].MinMatch))
+		}
+
+		// Records are read newest first
+		if out.To.IsZero() {
+			out.To = record.Timestamp
+		}
+		out.From = record.Timestamp
+	}
+
+	for i := range out.Thresholds {
+		out.Thresholds[i].Change = out.Thresholds[i].Hits - out.Current.Hits
+	}
+	return out, nil
+}
+
+func (h *ThresholdHits) add(hits int) {
+	h.Hits += hits
+	if hits > 0 {
+		h.Searches++
+	}
+}
+
+func countAtLeast(results []pubsearch.SearchedEntity[pubsearch.Value], minMatch float64) int {
+	var n int
+	for _, result := range results {
+		if result.Match >= minMatch {
+			n++
+		}
+	}
+	return n
+}
+
+// searchOpts returns the options a search was recorded with, which is made against the current entities
+func (o *Options) searchOpts(tenantID string) search.SearchOpts {
+	return search.SearchOpts{
+		Limit:       o.Limit,
+		MinMatch:    o.MinMatch,
+		Algorithm:   o.Algorithm,
+		ExactFirst:  o.ExactFirst,
+		Partial:     o.Partial,
+		Consolidate: o.Consolidate,
+		Prepare:     o.Prepare,
+		Weights:     o.Weights,
+		Filters:     o.Filters,
+		TenantID:    tenantID,
+	}
+}
@@ -0,0 +1,101 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+
+	searchService := search.NewService(logger)
+	searchService.UpdateEntities([]pubsearch.Entity[pubsearch.Value]{
+		testEntity("1", "Acme Shipping"),
+		testEntity("2", "Acme Shipping Lines"),
+		testEntity("3", "Bravo Holdings"),
+		testEntity("4", "Acme Trading"),
+	})
+
+	sink := NewInMemorySink()
+	audited := NewSearchService(logger, searchService, sink, nil, 10)
+	for _, name := range []string{"Acme Shipping", "Bravo Holdings", "Charlie Farms"} {
+		_, err := audited.Search(ctx, pubsearch.Entity[pubsearch.Value]{
+			Name: name,
+			Type: pubsearch.EntityBusiness,
+		}, search.SearchOpts{Limit: 10, MinMatch: 0.9})
+		require.NoError(t, err)
+	}
+	_, err := audited.SearchByIdentifier(ctx, search.IdentifierQuery{Identifier: "TAX-3", Limit: 5})
+	require.NoError(t, err)
+
+	sim, err := Simulate(ctx, sink, searchService, SimulationRequest{Thresholds: []float64{0.99, 0.3, 0.99}})
+	require.NoError(t, err)
+	require.Equal(t, 3, sim.Replayed)
+	require.Equal(t, 1, sim.Skipped) // the identifier search
+	require.False(t, sim.From.After(sim.To))
+
+	require.Len(t, sim.Thresholds, 2)
+	low, high := sim.Thresholds[0], sim.Thresholds[1]
+	require.Equal(t, 0.3, low.MinMatch)
+	require.Greater(t, low.Hits, sim.Current.Hits)
+	require.Equal(t, low.Hits-sim.Current.Hits, low.Change)
+	require.Less(t, high.Hits, sim.Current.Hits)
+	require.Negative(t, high.Change)
+
+	// Replays aren't recorded
+	require.Len(t, sink.Records(), 4)
+
+	// Only the most recent searches are replayed
+	sim, err = Simulate(ctx, sink, searchService, SimulationRequest{Thresholds: []float64{0.9}, Sample: 1})
+	require.NoError(t, err)
+	require.Equal(t, 1, sim.Replayed)
+	require.Equal(t, sim.Current, ThresholdHits{})
+
+	_, err = Simulate(ctx, sink, searchService, SimulationRequest{})
+	require.ErrorContains(t, err, "missing thresholds")
+	_, err = Simulate(ctx, sink, searchService, SimulationRequest{Thresholds: []float64{1.5}})
+	require.ErrorContains(t, err, "must be above 0 and at most 1")
+}
+
+func TestAdminController_simulate(t *testing.T) {
+	logger := log.NewTestLogger()
+
+	searchService := search.NewService(logger)
+	searchService.UpdateEntities([]pubsearch.Entity[pubsearch.Value]{testEntity("1", "Acme Shipping")})
+
+	sink := NewInMemorySink()
+	_, err := NewSearchService(logger, searchService, sink, nil, 10).Search(context.Background(), pubsearch.Entity[pubsearch.Value]{
+		Name: "Acme Shipping",
+		Type: pubsearch.EntityBusiness,
+	}, search.SearchOpts{Limit: 10, MinMatch: 0.5})
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	NewAdminController(logger, sink, searchService).AppendRoutes(router)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/audit/simulate", strings.NewReader(`{"thresholds":[0.8]}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var sim Simulation
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&sim))
+	require.Equal(t, 1, sim.Replayed)
+	require.Equal(t, 1, sim.Thresholds[0].Hits)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/audit/simulate", strings.NewReader(`{"thresholds":[0.8],"sample":-1}`)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "sample of -1")
+}
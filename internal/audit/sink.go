@@ -0,0 +1,160 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/moov-io/watchman/internal/database"
+)
+
+// Sink appends audit records to where they're kept. Records are never updated or removed.
+type Sink interface {
+	Write(ctx context.Context, record Record) error
+	Close() error
+}
+
+// Reader reads back the records a Sink kept, which only sinks that can be queried support
+type Reader interface {
+	// Recent returns up to limit records written at or after since, newest first
+	Recent(ctx context.Context, since time.Time, limit int) ([]Record, error)
+
+	// Range calls fn with each record written at or after from and before to, oldest first, stopping at the
+	// first error fn returns. Records are read a page at a time, so any number of them can be ranged over.
+	Range(ctx context.Context, from, to time.Time, fn func(Record) error) error
+}
+
+// Config chooses where searches are recorded. Searches aren't recorded when no sink is set, and are
+// written to each sink which is.
+type Config struct {
+	// File appends each record as a line of JSON
+	File *FileConfig
+
+	// Database inserts each record into the search_audit table of the configured database
+	Database bool
+
+	Kafka *KafkaConfig
+
+	// TopResults is how many of each search's results are recorded, 10 when zero
+	TopResults int
+}
+
+const (
+	defaultTopResults = 10
+)
+
+// NewSink opens each configured sink, returning nil when auditing is disabled. db is required when
+// records are kept in the database.
+func NewSink(conf Config, db *database.DB) (Sink, error) {
+	var sinks multiSink
+	if conf.File != nil {
+		sink, err := newFileSink(*conf.File)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if conf.Database {
+		if db == nil {
+			sinks.Close()
+			return nil, errors.New("audit: records can't be kept in the database when none is configured")
+		}
+		sinks = append(sinks, NewSQLSink(db))
+	}
+	if conf.Kafka != nil {
+		sink, err := newKafkaSink(*conf.Kafka)
+		if err != nil {
+			sinks.Close()
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+
+	switch len(sinks) {
+	case 0:
+		return nil, nil
+	case 1:
+		return sinks[0], nil
+	}
+	return sinks, nil
+}
+
+// multiSink writes each record to every sink, so one failing sink doesn't stop the others being written
+type multiSink []Sink
+
+func (s multiSink) Write(ctx context.Context, record Record) error {
+	var errs []error
+	for _, sink := range s {
+		errs = append(errs, sink.Write(ctx, record))
+	}
+	return errors.Join(errs...)
+}
+
+func (s multiSink) Close() error {
+	var errs []error
+	for _, sink := range s {
+		errs = append(errs, sink.Close())
+	}
+	return errors.Join(errs...)
+}
+
+// NewInMemorySink holds each record it's given, which is useful for tests
+func NewInMemorySink() *InMemorySink {
+	return &InMemorySink{}
+}
+
+type InMemorySink struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+func (s *InMemorySink) Write(ctx context.Context, record Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records = append(s.records, record)
+	return nil
+}
+
+func (s *InMemorySink) Close() error {
+	return nil
+}
+
+// Records returns every record written, in order
+func (s *InMemorySink) Records() []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.records)
+}
+
+func (s *InMemorySink) Range(ctx context.Context, from, to time.Time, fn func(Record) error) error {
+	records := s.Records()
+	slices.SortStableFunc(records, func(a, b Record) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+	for _, record := range records {
+		if record.Timestamp.Before(from) || !record.Timestamp.Before(to) {
+			continue
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *InMemorySink) Recent(ctx context.Context, since time.Time, limit int) ([]Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []Record
+	for i := len(s.records) - 1; i >= 0 && len(out) < limit; i-- {
+		if !s.records[i].Timestamp.Before(since) {
+			out = append(out, s.records[i])
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,141 @@
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/database"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSink(t *testing.T) {
+	sink, err := NewSink(Config{}, nil)
+	require.NoError(t, err)
+	require.Nil(t, sink)
+
+	_, err = NewSink(Config{Database: true}, nil)
+	require.ErrorContains(t, err, "none is configured")
+
+	_, err = NewSink(Config{Kafka: &KafkaConfig{Topic: "audit"}}, nil)
+	require.ErrorContains(t, err, "missing brokers")
+}
+
+func TestSinks(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	db := database.NewTestDB(t)
+
+	sink, err := NewSink(Config{
+		File:     &FileConfig{Path: path},
+		Database: true,
+	}, db)
+	require.NoError(t, err)
+	require.IsType(t, multiSink{}, sink)
+
+	for _, id := range []string{"first", "second"} {
+		err := sink.Write(ctx, Record{
+			RecordID:  id,
+			Timestamp: time.Now(),
+			Client:    "payments",
+			Type:      TypeEntity,
+			Query:     json.RawMessage(`{"name":"Acme"}`),
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, sink.Close())
+
+	// Both records are appended to the file, one per line
+	fd, err := os.Open(path)
+	require.NoError(t, err)
+	defer fd.Close()
+
+	var ids []string
+	scanner := bufio.NewScanner(fd)
+	for scanner.Scan() {
+		var record Record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		ids = append(ids, record.RecordID)
+	}
+	require.Equal(t, []string{"first", "second"}, ids)
+
+	var count int
+	err = db.DB.QueryRowContext(ctx, db.Rebind(`SELECT COUNT(*) FROM search_audit WHERE client = ?`), "payments").Scan(&count)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	// Records are read back newest first
+	records, err := NewSQLReader(db).Recent(ctx, time.Now().Add(-time.Minute), 1)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	require.Equal(t, "second", records[0].RecordID)
+	require.JSONEq(t, `{"name":"Acme"}`, string(records[0].Query))
+
+	records, err = NewSQLReader(db).Recent(ctx, time.Now().Add(time.Minute), 10)
+	require.NoError(t, err)
+	require.Empty(t, records)
+}
+
+func TestReader_Range(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, time.June, 2, 0, 0, 0, 0, time.UTC)
+
+	readers := map[string]func(t *testing.T) (Sink, Reader){
+		"sql": func(t *testing.T) (Sink, Reader) {
+			db := database.NewTestDB(t)
+			return NewSQLSink(db), NewSQLReader(db)
+		},
+		"memory": func(t *testing.T) (Sink, Reader) {
+			sink := NewInMemorySink()
+			return sink, sink
+		},
+	}
+	for name, open := range readers {
+		t.Run(name, func(t *testing.T) {
+			sink, reader := open(t)
+
+			// More records than a page, several written in the same millisecond, and some outside of the range
+			from := start
+			to := start.Add(time.Duration(rangePageSize) * time.Millisecond)
+			var want []string
+			for i := 0; i < rangePageSize*3; i++ {
+				record := Record{
+					RecordID:  fmt.Sprintf("record-%05d", i),
+					Timestamp: start.Add(time.Duration(i/2-2) * time.Millisecond),
+					Type:      TypeEntity,
+				}
+				require.NoError(t, sink.Write(ctx, record))
+
+				if !record.Timestamp.Before(from) && record.Timestamp.Before(to) {
+					want = append(want, record.RecordID)
+				}
+			}
+
+			var ids []string
+			err := reader.Range(ctx, from, to, func(record Record) error {
+				ids = append(ids, record.RecordID)
+				return nil
+			})
+			require.NoError(t, err)
+			require.Len(t, want, rangePageSize*2)
+			require.Equal(t, want, ids)
+
+			// The first error stops ranging
+			calls := 0
+			stop := errors.New("stop")
+			err = reader.Range(ctx, from, to, func(Record) error {
+				calls++
+				return stop
+			})
+			require.ErrorIs(t, err, stop)
+			require.Equal(t, 1, calls)
+		})
+	}
+}
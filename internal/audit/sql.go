@@ -0,0 +1,123 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/moov-io/watchman/internal/database"
+)
+
+// NewSQLSink inserts records into the search_audit table of db
+func NewSQLSink(db *database.DB) Sink {
+	return &sqlSink{db: db}
+}
+
+// NewSQLReader reads the records NewSQLSink inserted into db
+func NewSQLReader(db *database.DB) Reader {
+	return &sqlSink{db: db}
+}
+
+const (
+	// rangePageSize is how many records Range reads at once
+	rangePageSize = 500
+)
+
+type sqlSink struct {
+	db *database.DB
+}
+
+func (s *sqlSink) Write(ctx context.Context, record Record) error {
+	bs, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("audit: encoding record: %w", err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `INSERT INTO search_audit (record_id, client, created_at, data) VALUES (?, ?, ?, ?)`,
+		record.RecordID, record.Client, database.ToMillis(record.Timestamp), string(bs))
+	if err != nil {
+		return fmt.Errorf("audit: inserting record: %w", err)
+	}
+	return nil
+}
+
+// Close leaves db open, which is shared with the rest of Watchman
+func (s *sqlSink) Close() error {
+	return nil
+}
+
+func (s *sqlSink) Recent(ctx context.Context, since time.Time, limit int) ([]Record, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT data FROM search_audit WHERE created_at >= ? ORDER BY created_at DESC, record_id DESC LIMIT ?`,
+		database.ToMillis(since), limit)
+	if err != nil {
+		return nil, fmt.Errorf("audit: reading records: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Record
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("audit: reading record: %w", err)
+		}
+		var record Record
+		if err := json.Unmarshal([]byte(data), &record); err != nil {
+			return nil, fmt.Errorf("audit: decoding record: %w", err)
+		}
+		out = append(out, record)
+	}
+	return out, rows.Err()
+}
+
+func (s *sqlSink) Range(ctx context.Context, from, to time.Time, fn func(Record) error) error {
+	// Pages continue after the (created_at, record_id) of the last record read, which starts just before from
+	createdAt, recordID := database.ToMillis(from), ""
+	for {
+		page, err := s.rangePage(ctx, createdAt, recordID, database.ToMillis(to))
+		if err != nil {
+			return err
+		}
+		for _, row := range page {
+			if err := fn(row.record); err != nil {
+				return err
+			}
+		}
+		if len(page) < rangePageSize {
+			return nil
+		}
+		last := page[len(page)-1]
+		createdAt, recordID = last.createdAt, last.recordID
+	}
+}
+
+type rangeRow struct {
+	recordID  string
+	createdAt int64
+	record    Record
+}
+
+// rangePage reads up to rangePageSize records after (createdAt, recordID) and before to. Each page is read in full
+// before Range calls fn, so no query is held open while records are summarized.
+func (s *sqlSink) rangePage(ctx context.Context, createdAt int64, recordID string, to int64) ([]rangeRow, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT record_id, created_at, data FROM search_audit WHERE created_at < ? AND (created_at > ? OR (created_at = ? AND record_id > ?)) ORDER BY created_at, record_id LIMIT ?`,
+		to, createdAt, createdAt, recordID, rangePageSize)
+	if err != nil {
+		return nil, fmt.Errorf("audit: reading records: %w", err)
+	}
+	defer rows.Close()
+
+	var out []rangeRow
+	for rows.Next() {
+		var row rangeRow
+		var data string
+		if err := rows.Scan(&row.recordID, &row.createdAt, &data); err != nil {
+			return nil, fmt.Errorf("audit: reading record: %w", err)
+		}
+		if err := json.Unmarshal([]byte(data), &row.record); err != nil {
+			return nil, fmt.Errorf("audit: decoding record: %w", err)
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
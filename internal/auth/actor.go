@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrOtherActor is returned for requests claiming to be made by someone other than their client
+var ErrOtherActor = errors.New("client can't act as")
+
+// RequestActor returns who made a request, such as for the audit trail of the allowlist. Authenticated requests
+// are made by their client, see Client.Actor, and claiming any other actor with the X-User-ID header or claimed
+// returns ErrOtherActor. Requests which aren't authenticated are made by the X-User-ID header set by an upstream
+// auth proxy, or else by claimed, which is usually read from the request's body.
+func RequestActor(r *http.Request, claimed string) (string, error) {
+	header := strings.TrimSpace(r.Header.Get("X-User-ID"))
+	claimed = strings.TrimSpace(claimed)
+
+	client := ClientFrom(r.Context())
+	if client == nil {
+		return cmp.Or(header, claimed), nil
+	}
+	actor := client.Actor()
+	for _, other := range []string{header, claimed} {
+		if other != "" && other != actor {
+			return "", fmt.Errorf("%w %q", ErrOtherActor, other)
+		}
+	}
+	return actor, nil
+}
@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestActor(t *testing.T) {
+	// Requests which aren't authenticated prefer the X-User-ID header to the claimed actor
+	req := httptest.NewRequest("POST", "/v2/allowlist", nil)
+	actor, err := RequestActor(req, "jane")
+	require.NoError(t, err)
+	require.Equal(t, "jane", actor)
+
+	req.Header.Set("X-User-ID", "john")
+	actor, err = RequestActor(req, "jane")
+	require.NoError(t, err)
+	require.Equal(t, "john", actor)
+
+	// Clients act as themselves, and can't claim to be anyone else
+	req = httptest.NewRequest("POST", "/v2/allowlist", nil)
+	req = req.WithContext(WithClient(req.Context(), &Client{Name: "payments"}))
+	for _, claimed := range []string{"", "payments"} {
+		actor, err = RequestActor(req, claimed)
+		require.NoError(t, err)
+		require.Equal(t, "payments", actor)
+	}
+	_, err = RequestActor(req, "jane")
+	require.ErrorIs(t, err, ErrOtherActor)
+
+	req.Header.Set("X-User-ID", "jane")
+	_, err = RequestActor(req, "")
+	require.ErrorContains(t, err, `client can't act as "jane"`)
+
+	// Tokens act as their subject
+	req = httptest.NewRequest("POST", "/v2/allowlist", nil)
+	req = req.WithContext(WithClient(req.Context(), &Client{Name: "case-manager", Subject: "jane@example.com"}))
+	actor, err = RequestActor(req, "jane@example.com")
+	require.NoError(t, err)
+	require.Equal(t, "jane@example.com", actor)
+}
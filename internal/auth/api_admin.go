@@ -0,0 +1,45 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+type Controller interface {
+	AppendRoutes(router *mux.Router) *mux.Router
+}
+
+// NewAdminController returns the routes which report each client's usage.
+// They're expected to be served on the admin server.
+func NewAdminController(middleware *Middleware) Controller {
+	return &adminController{
+		middleware: middleware,
+	}
+}
+
+type adminController struct {
+	middleware *Middleware
+}
+
+func (c *adminController) AppendRoutes(router *mux.Router) *mux.Router {
+	router.
+		Name("ClientUsage").
+		Methods("GET").
+		Path("/auth/usage").
+		HandlerFunc(c.clientUsage)
+
+	return router
+}
+
+type clientUsageResponse struct {
+	Clients []Usage `json:"clients"`
+}
+
+func (c *adminController) clientUsage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(clientUsageResponse{
+		Clients: c.middleware.Usage(),
+	})
+}
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor authenticates gRPC calls like Handler does HTTP requests, reading the
+// API key or bearer token from the x-api-key or authorization metadata. Every call needs the search scope.
+func (m *Middleware) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if !m.Enabled() {
+		return handler(ctx, req)
+	}
+	client, err := m.authorizeRPC(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(WithClient(ctx, client), req)
+}
+
+// StreamServerInterceptor authenticates gRPC streams once when they're opened, like UnaryServerInterceptor
+func (m *Middleware) StreamServerInterceptor(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if !m.Enabled() {
+		return handler(srv, stream)
+	}
+	client, err := m.authorizeRPC(stream.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &clientStream{ServerStream: stream, ctx: WithClient(stream.Context(), client)})
+}
+
+func (m *Middleware) authorizeRPC(ctx context.Context) (*Client, error) {
+	header := make(http.Header)
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, key := range []string{"Authorization", "X-API-Key"} {
+			if values := md.Get(key); len(values) > 0 {
+				header.Set(key, values[0])
+			}
+		}
+	}
+
+	client, retryAfter, err := m.authorize(ctx, header, ScopeSearch)
+	if err != nil {
+		code := codes.Internal
+		switch {
+		case errors.Is(err, ErrInvalidKey), errors.Is(err, ErrInvalidToken):
+			code = codes.Unauthenticated
+		case errors.Is(err, ErrMissingScope):
+			code = codes.PermissionDenied
+		case errors.Is(err, ErrRateLimited):
+			grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))))
+			code = codes.ResourceExhausted
+		}
+		return nil, status.Error(code, err.Error())
+	}
+	return client, nil
+}
+
+// clientStream overrides the context of a stream with one holding its client
+type clientStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *clientStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,87 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestMiddleware_GRPC(t *testing.T) {
+	conf := Config{
+		APIKeys: []APIKey{
+			{Name: "payments", Key: "secret-1", Tenant: "bank-a"},
+			{Name: "operations", Key: "secret-2", Scopes: []Scope{ScopeAdmin}},
+			{Name: "onboarding", Key: "secret-3", RateLimit: &RateLimit{Requests: 1, Interval: time.Hour}},
+		},
+	}
+	mw := NewMiddleware(log.NewTestLogger(), conf, nil)
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/watchman.Watchman/Search"}
+	handler := func(ctx context.Context, req any) (any, error) {
+		return ClientFrom(ctx), nil
+	}
+	call := func(key string) (*Client, error) {
+		ctx := context.Background()
+		if key != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-api-key", key))
+		}
+		resp, err := mw.UnaryServerInterceptor(ctx, nil, info, handler)
+		client, _ := resp.(*Client)
+		return client, err
+	}
+
+	_, err := call("")
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = call("wrong")
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	client, err := call("secret-1")
+	require.NoError(t, err)
+	require.Equal(t, "payments", client.Name)
+	require.Equal(t, "bank-a", client.Tenant)
+
+	_, err = call("secret-2")
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = call("secret-3")
+	require.NoError(t, err)
+	_, err = call("secret-3")
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Streams are authenticated when they're opened
+	stream := &testStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-api-key", "secret-1"))}
+	err = mw.StreamServerInterceptor(nil, stream, &grpc.StreamServerInfo{}, func(srv any, stream grpc.ServerStream) error {
+		require.Equal(t, "payments", ClientFrom(stream.Context()).Name)
+		return nil
+	})
+	require.NoError(t, err)
+
+	stream = &testStream{ctx: context.Background()}
+	err = mw.StreamServerInterceptor(nil, stream, &grpc.StreamServerInfo{}, func(srv any, stream grpc.ServerStream) error {
+		t.Fatal("unauthenticated stream was handled")
+		return nil
+	})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Calls aren't checked when auth isn't configured
+	mw = NewMiddleware(log.NewTestLogger(), Config{}, nil)
+	client, err = call("")
+	require.NoError(t, err)
+	require.Nil(t, client)
+}
+
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,230 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// jwksRefreshInterval limits how often unknown key IDs cause the signing keys to be fetched again
+	jwksRefreshInterval = time.Minute
+)
+
+// jwks fetches and caches the signing keys of an OIDC issuer. Keys are read without waiting on a fetch, which
+// is shared by every token with a key ID unknown when it started.
+type jwks struct {
+	logger log.Logger
+	client *http.Client
+	issuer string
+
+	fetches singleflight.Group
+
+	mu        sync.RWMutex
+	url       string
+	keys      map[string]crypto.PublicKey
+	fetchedAt time.Time // when the last fetch finished, successful or not
+}
+
+func newJWKS(logger log.Logger, client *http.Client, issuer, url string) *jwks {
+	return &jwks{
+		logger: logger,
+		client: client,
+		issuer: issuer,
+		url:    url,
+	}
+}
+
+// key returns the signing key with kid, fetching the keys again when it isn't known yet
+func (k *jwks) key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	key, fetchedAt := k.find(kid)
+	if key != nil {
+		return key, nil
+	}
+	// Unknown key IDs fetch the keys at most once an interval, so tokens with made up key IDs can't flood the issuer
+	if time.Since(fetchedAt) < jwksRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	_, err, _ := k.fetches.Do("keys", func() (interface{}, error) {
+		// The fetch is shared, so it isn't canceled along with the token which started it
+		return nil, k.refresh(context.WithoutCancel(ctx))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching signing keys: %w", err)
+	}
+	if key, _ := k.find(kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+// find returns the key with kid, and when the keys were last fetched
+func (k *jwks) find(kid string) (crypto.PublicKey, time.Time) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if kid == "" && len(k.keys) == 1 {
+		for _, key := range k.keys {
+			return key, k.fetchedAt
+		}
+	}
+	return k.keys[kid], k.fetchedAt
+}
+
+// refresh fetches the keys unless another fetch finished within the interval
+func (k *jwks) refresh(ctx context.Context) error {
+	k.mu.RLock()
+	url, fetchedAt := k.url, k.fetchedAt
+	k.mu.RUnlock()
+
+	if time.Since(fetchedAt) < jwksRefreshInterval {
+		return nil
+	}
+	url, keys, err := k.fetch(ctx, url)
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.fetchedAt = time.Now()
+	if err != nil {
+		return err
+	}
+	k.url, k.keys = url, keys
+	return nil
+}
+
+// fetch returns the signing keys at url, discovering it from the issuer when it's empty
+func (k *jwks) fetch(ctx context.Context, url string) (string, map[string]crypto.PublicKey, error) {
+	if url == "" {
+		var discovery struct {
+			JWKSURI string `json:"jwks_uri"`
+		}
+		err := k.get(ctx, strings.TrimSuffix(k.issuer, "/")+"/.well-known/openid-configuration", &discovery)
+		if err != nil {
+			return "", nil, fmt.Errorf("discovery: %w", err)
+		}
+		if discovery.JWKSURI == "" {
+			return "", nil, errors.New("discovery: missing jwks_uri")
+		}
+		url = discovery.JWKSURI
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := k.get(ctx, url, &set); err != nil {
+		return "", nil, err
+	}
+
+	// Keys which can't verify tokens are skipped so the issuer's other keys are still accepted
+	keys := make(map[string]crypto.PublicKey)
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			k.logger.Warn().Logf("skipping signing key %q from %s: %v", jwk.KeyID, url, err)
+			continue
+		}
+		if key == nil {
+			k.logger.Warn().Logf("skipping signing key %q from %s: unsupported key type %q", jwk.KeyID, url, jwk.KeyType)
+			continue
+		}
+		keys[jwk.KeyID] = key
+	}
+	return url, keys, nil
+}
+
+func (k *jwks) get(ctx context.Context, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: unexpected status %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+type jsonWebKey struct {
+	KeyID   string `json:"kid"`
+	KeyType string `json:"kty"`
+	Use     string `json:"use"`
+
+	// RSA keys
+	N string `json:"n"`
+	E string `json:"e"`
+
+	// EC keys
+	Curve string `json:"crv"`
+	X     string `json:"x"`
+	Y     string `json:"y"`
+}
+
+// publicKey returns nil for key types which can't verify tokens, and an error for unusable keys
+func (jwk jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch jwk.KeyType {
+	case "RSA":
+		n, err := decodeBigInt(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("n: %w", err)
+		}
+		e, err := decodeBigInt(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("e: %w", err)
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Curve)
+		}
+		x, err := decodeBigInt(jwk.X)
+		if err != nil {
+			return nil, fmt.Errorf("x: %w", err)
+		}
+		y, err := decodeBigInt(jwk.Y)
+		if err != nil {
+			return nil, fmt.Errorf("y: %w", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	}
+	return nil, nil
+}
+
+func decodeBigInt(v string) (*big.Int, error) {
+	bs, err := base64.RawURLEncoding.DecodeString(v)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(bs), nil
+}
@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"sync"
+	"time"
+)
+
+// limiter keeps a token bucket for each client, refilled at their rate limit
+type limiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+func newLimiter() *limiter {
+	return &limiter{
+		buckets: make(map[string]*bucket),
+	}
+}
+
+// allow takes a token from the client's bucket, otherwise returning how long until one is available
+func (l *limiter) allow(client string, limit RateLimit, now time.Time) (bool, time.Duration) {
+	if limit.empty() {
+		return true, 0
+	}
+	rate := float64(limit.Requests) / limit.Interval.Seconds() // tokens per second
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	b, exists := l.buckets[client]
+	if !exists {
+		b = &bucket{tokens: float64(limit.Requests), last: now}
+		l.buckets[client] = b
+	}
+
+	b.tokens += now.Sub(b.last).Seconds() * rate
+	if b.tokens > float64(limit.Requests) {
+		b.tokens = float64(limit.Requests)
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := (1 - b.tokens) / rate
+		return false, time.Duration(wait * float64(time.Second))
+	}
+	b.tokens--
+	return true, 0
+}
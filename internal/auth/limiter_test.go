@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	l := newLimiter()
+	limit := RateLimit{Requests: 2, Interval: time.Second}
+	now := time.Now()
+
+	ok, _ := l.allow("a", limit, now)
+	require.True(t, ok)
+	ok, _ = l.allow("a", limit, now)
+	require.True(t, ok)
+
+	ok, wait := l.allow("a", limit, now)
+	require.False(t, ok)
+	require.Equal(t, 500*time.Millisecond, wait)
+
+	// Other clients have their own bucket
+	ok, _ = l.allow("b", limit, now)
+	require.True(t, ok)
+
+	// Tokens are refilled over time
+	ok, _ = l.allow("a", limit, now.Add(500*time.Millisecond))
+	require.True(t, ok)
+
+	// Empty limits allow everything
+	for i := 0; i < 10; i++ {
+		ok, _ = l.allow("c", RateLimit{}, now)
+		require.True(t, ok)
+	}
+}
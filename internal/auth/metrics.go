@@ -0,0 +1,13 @@
+package auth
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	apiRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "api_requests_total",
+		Help: "Count of authenticated requests by client and status (allowed, rate_limited, forbidden or unauthorized)",
+	}, []string{"client", "status"})
+)
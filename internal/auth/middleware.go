@@ -0,0 +1,230 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moov-io/base/log"
+)
+
+// Middleware authenticates requests by their API key or bearer token, checks the client is allowed to
+// perform the operation and applies each client's rate limit
+type Middleware struct {
+	logger    log.Logger
+	validator Validator
+	tokens    TokenValidator
+	rateLimit RateLimit
+
+	limiter *limiter
+
+	mu    sync.Mutex
+	usage map[string]*Usage
+
+	now func() time.Time
+}
+
+// NewMiddleware returns a Middleware which validates keys with validator, or the configured
+// API keys when validator is nil. Bearer tokens are validated when OIDC is configured.
+func NewMiddleware(logger log.Logger, conf Config, validator Validator) *Middleware {
+	if validator == nil && len(conf.APIKeys) > 0 {
+		validator = NewStaticValidator(conf.APIKeys)
+	}
+	var tokens TokenValidator
+	if conf.OIDC != nil && conf.OIDC.Issuer != "" {
+		tokens = NewOIDCValidator(logger, *conf.OIDC, nil)
+	}
+	return &Middleware{
+		logger:    logger,
+		validator: validator,
+		tokens:    tokens,
+		rateLimit: conf.RateLimit,
+		limiter:   newLimiter(),
+		usage:     make(map[string]*Usage),
+		now:       time.Now,
+	}
+}
+
+// Enabled is false when there's nothing to validate keys or tokens with
+func (m *Middleware) Enabled() bool {
+	return m != nil && (m.validator != nil || m.tokens != nil)
+}
+
+// unauthenticatedPaths are served without an API key
+var unauthenticatedPaths = map[string]bool{
+	"/ping": true,
+}
+
+// listsPaths are the HTTP server routes which manage data rather than search it
+var listsPaths = []string{
+	"/v2/allowlist",
+	"/v2/cases",
+	"/v2/tenants/",
+	"/v2/watches",
+	"/v2/webhooks",
+}
+
+func requestScope(r *http.Request) Scope {
+	for _, prefix := range listsPaths {
+		if strings.HasPrefix(r.URL.Path, prefix) {
+			return ScopeLists
+		}
+	}
+	return ScopeSearch
+}
+
+// Handler authenticates requests to the HTTP server, which need the search or lists scope
+func (m *Middleware) Handler(next http.Handler) http.Handler {
+	return m.handler(next, requestScope)
+}
+
+// AdminHandler authenticates requests to the admin server, which need the admin scope
+func (m *Middleware) AdminHandler(next http.Handler) http.Handler {
+	return m.handler(next, func(_ *http.Request) Scope {
+		return ScopeAdmin
+	})
+}
+
+func (m *Middleware) handler(next http.Handler, scopeOf func(r *http.Request) Scope) http.Handler {
+	if !m.Enabled() {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if unauthenticatedPaths[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		client, retryAfter, err := m.authorize(r.Context(), r.Header, scopeOf(r))
+		if err != nil {
+			status := http.StatusInternalServerError
+			switch {
+			case errors.Is(err, ErrInvalidKey), errors.Is(err, ErrInvalidToken):
+				status = http.StatusUnauthorized
+			case errors.Is(err, ErrMissingScope):
+				status = http.StatusForbidden
+			case errors.Is(err, ErrRateLimited):
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				status = http.StatusTooManyRequests
+			}
+			writeError(w, status, err)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(WithClient(r.Context(), client)))
+	})
+}
+
+var (
+	ErrMissingScope = errors.New("missing scope")
+	ErrRateLimited  = errors.New("rate limit exceeded")
+)
+
+// authorize authenticates a request by its Authorization or X-API-Key header, checks the client has
+// the scope and applies its rate limit, returning how long to wait when it's exceeded
+func (m *Middleware) authorize(ctx context.Context, header http.Header, scope Scope) (*Client, time.Duration, error) {
+	client, err := m.authenticate(ctx, header)
+	if err != nil {
+		apiRequestsTotal.WithLabelValues("", "unauthorized").Inc()
+
+		if !errors.Is(err, ErrInvalidKey) && !errors.Is(err, ErrInvalidToken) {
+			m.logger.Error().LogErrorf("problem authenticating request: %v", err)
+		}
+		return nil, 0, err
+	}
+
+	if !client.Allowed(scope) {
+		apiRequestsTotal.WithLabelValues(client.Name, "forbidden").Inc()
+		return nil, 0, fmt.Errorf("%w: %s", ErrMissingScope, scope)
+	}
+
+	limit := m.rateLimit
+	if client.RateLimit != nil {
+		limit = *client.RateLimit
+	}
+	allowed, retryAfter := m.limiter.allow(client.Name, limit, m.now())
+	m.recordUsage(client.Name, allowed)
+
+	if !allowed {
+		apiRequestsTotal.WithLabelValues(client.Name, "rate_limited").Inc()
+		return nil, retryAfter, ErrRateLimited
+	}
+	apiRequestsTotal.WithLabelValues(client.Name, "allowed").Inc()
+
+	return client, 0, nil
+}
+
+// authenticate validates the request's bearer token when OIDC is configured, otherwise its API key
+func (m *Middleware) authenticate(ctx context.Context, header http.Header) (*Client, error) {
+	token, found := strings.CutPrefix(header.Get("Authorization"), "Bearer ")
+	if found && m.tokens != nil {
+		return m.tokens.ValidateToken(ctx, strings.TrimSpace(token))
+	}
+	if m.validator == nil {
+		return nil, fmt.Errorf("%w: missing bearer token", ErrInvalidToken)
+	}
+	return m.validator.Validate(ctx, header.Get("X-API-Key"))
+}
+
+func (m *Middleware) recordUsage(client string, allowed bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	u, exists := m.usage[client]
+	if !exists {
+		u = &Usage{Client: client}
+		m.usage[client] = u
+	}
+	u.Requests++
+	if !allowed {
+		u.RateLimited++
+	}
+	u.LastRequestAt = m.now().In(time.UTC)
+}
+
+// Usage returns the requests made by each client since startup, sorted by client
+func (m *Middleware) Usage() []Usage {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]Usage, 0, len(m.usage))
+	for _, u := range m.usage {
+		out = append(out, *u)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Client < out[j].Client
+	})
+	return out
+}
+
+type clientKey struct{}
+
+func WithClient(ctx context.Context, client *Client) context.Context {
+	return context.WithValue(ctx, clientKey{}, client)
+}
+
+// ClientFrom returns the client who made a request, which is nil when requests aren't authenticated
+func ClientFrom(ctx context.Context) *Client {
+	client, _ := ctx.Value(clientKey{}).(*Client)
+	return client
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error: err.Error(),
+	})
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	conf := Config{
+		APIKeys: []APIKey{
+			{Name: "payments", Key: "secret-1"},
+			{Name: "onboarding", Key: "secret-2", RateLimit: &RateLimit{Requests: 1, Interval: time.Hour}},
+		},
+		RateLimit: RateLimit{Requests: 2, Interval: time.Hour},
+	}
+	mw := NewMiddleware(log.NewTestLogger(), conf, nil)
+	require.True(t, mw.Enabled())
+
+	now := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+	mw.now = func() time.Time { return now }
+
+	router := mux.NewRouter()
+	router.Path("/ping").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	router.Path("/v2/search").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(ClientFrom(r.Context()).Name))
+	})
+	router.Use(mw.Handler)
+
+	do := func(path, key string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", path, nil)
+		if key != "" {
+			req.Header.Set("X-API-Key", key)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	require.Equal(t, http.StatusOK, do("/ping", "").Code)
+	require.Equal(t, http.StatusUnauthorized, do("/v2/search", "").Code)
+	require.Equal(t, http.StatusUnauthorized, do("/v2/search", "wrong").Code)
+
+	w := do("/v2/search", "secret-1")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "payments", w.Body.String())
+	require.Equal(t, http.StatusOK, do("/v2/search", "secret-1").Code)
+
+	w = do("/v2/search", "secret-1")
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "1800", w.Header().Get("Retry-After"))
+
+	// Keys can have their own limit
+	require.Equal(t, http.StatusOK, do("/v2/search", "secret-2").Code)
+	require.Equal(t, http.StatusTooManyRequests, do("/v2/search", "secret-2").Code)
+
+	// Usage is reported on the admin server
+	admin := mux.NewRouter()
+	NewAdminController(mw).AppendRoutes(admin)
+
+	w = httptest.NewRecorder()
+	admin.ServeHTTP(w, httptest.NewRequest("GET", "/auth/usage", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp clientUsageResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Equal(t, []Usage{
+		{Client: "onboarding", Requests: 2, RateLimited: 1, LastRequestAt: now},
+		{Client: "payments", Requests: 3, RateLimited: 1, LastRequestAt: now},
+	}, resp.Clients)
+}
+
+type failingValidator struct{}
+
+func (failingValidator) Validate(ctx context.Context, key string) (*Client, error) {
+	return nil, errors.New("validator unavailable")
+}
+
+func TestMiddleware_Validator(t *testing.T) {
+	mw := NewMiddleware(log.NewTestLogger(), Config{}, failingValidator{})
+
+	w := httptest.NewRecorder()
+	handler := mw.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/v2/search", nil))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// Requests aren't authenticated without keys or a validator
+	mw = NewMiddleware(log.NewTestLogger(), Config{}, nil)
+	require.False(t, mw.Enabled())
+}
@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+type Config struct {
+	// APIKeys are accepted in the X-API-Key header. Requests aren't authenticated when there are none
+	// and OIDC isn't configured.
+	APIKeys []APIKey
+
+	// OIDC accepts JWTs from an OpenID Connect issuer in the Authorization header
+	OIDC *OIDCConfig
+
+	// RateLimit applies to each client without their own limit. Clients aren't limited when it's empty.
+	RateLimit RateLimit
+}
+
+type APIKey struct {
+	// Name identifies the client in usage counters and metrics
+	Name string
+	Key  string
+
+	// Scopes are the operations a key can be used for, which are search and lists when empty
+	Scopes []Scope
+
+	// Tenant is the only tenant whose custom lists, jobs and hits the key can use, see TenantFor
+	Tenant string
+
+	RateLimit *RateLimit
+}
+
+type OIDCConfig struct {
+	// Issuer must match each token's iss claim. Its signing keys are discovered from
+	// {Issuer}/.well-known/openid-configuration unless JWKSURL is set.
+	Issuer   string
+	Audience string
+	JWKSURL  string
+
+	// Scopes are the token scopes which grant each operation
+	Scopes ScopeNames
+
+	// TenantClaim is the claim holding the tenant of a token's client, which is tenant_id when empty
+	TenantClaim string
+}
+
+// ScopeNames are the scopes an issuer grants for each operation, which default to
+// watchman:search, watchman:lists and watchman:admin
+type ScopeNames struct {
+	Search string
+	Lists  string
+	Admin  string
+}
+
+// Scope is an operation a client is allowed to perform
+type Scope string
+
+const (
+	// ScopeSearch allows searching and reading list information
+	ScopeSearch Scope = "search"
+
+	// ScopeLists allows managing custom lists, the allowlist, watches and webhooks
+	ScopeLists Scope = "lists"
+
+	// ScopeAdmin allows calling the admin server, such as pinning list versions
+	ScopeAdmin Scope = "admin"
+)
+
+// RateLimit allows a client to make Requests every Interval
+type RateLimit struct {
+	Requests int
+	Interval time.Duration
+}
+
+func (l RateLimit) empty() bool {
+	return l.Requests <= 0 || l.Interval <= 0
+}
+
+// Client is who made a request
+type Client struct {
+	Name string
+
+	// Subject is the sub claim of a token, which is who it was issued to
+	Subject string
+
+	Scopes []Scope
+
+	// Tenant is the tenant the client acts for, which is empty for clients of no tenant
+	Tenant string
+
+	// RateLimit overrides the default rate limit when set
+	RateLimit *RateLimit
+}
+
+// Actor is who made the client's requests: the subject of a token, or else the client's name
+func (c *Client) Actor() string {
+	return cmp.Or(c.Subject, c.Name)
+}
+
+func (c *Client) Allowed(scope Scope) bool {
+	return slices.Contains(c.Scopes, scope)
+}
+
+// Usage counts the requests made by a client
+type Usage struct {
+	Client        string    `json:"client"`
+	Requests      int64     `json:"requests"`
+	RateLimited   int64     `json:"rateLimited"`
+	LastRequestAt time.Time `json:"lastRequestAt"`
+}
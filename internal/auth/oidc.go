@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/moov-io/base/log"
+)
+
+var (
+	// signingMethods are the asymmetric algorithms accepted from an issuer
+	signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+)
+
+// NewOIDCValidator accepts JWTs signed by the issuer with the configured audience
+func NewOIDCValidator(logger log.Logger, conf OIDCConfig, client *http.Client) TokenValidator {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	conf.Scopes.Search = cmp.Or(conf.Scopes.Search, "watchman:search")
+	conf.Scopes.Lists = cmp.Or(conf.Scopes.Lists, "watchman:lists")
+	conf.Scopes.Admin = cmp.Or(conf.Scopes.Admin, "watchman:admin")
+	conf.TenantClaim = cmp.Or(conf.TenantClaim, "tenant_id")
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuer(conf.Issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(30 * time.Second),
+	}
+	if conf.Audience != "" {
+		opts = append(opts, jwt.WithAudience(conf.Audience))
+	}
+
+	return &oidcValidator{
+		conf:   conf,
+		keys:   newJWKS(logger, client, conf.Issuer, conf.JWKSURL),
+		parser: jwt.NewParser(opts...),
+	}
+}
+
+type oidcValidator struct {
+	conf   OIDCConfig
+	keys   *jwks
+	parser *jwt.Parser
+}
+
+type tokenClaims struct {
+	jwt.RegisteredClaims
+
+	// Scope is space separated, while some issuers send scp as a list
+	Scope string           `json:"scope"`
+	Scp   jwt.ClaimStrings `json:"scp"`
+
+	ClientID        string `json:"client_id"`
+	AuthorizedParty string `json:"azp"`
+
+	// all holds every claim, as the tenant's claim is named by the issuer's configuration
+	all map[string]interface{}
+}
+
+func (c *tokenClaims) UnmarshalJSON(data []byte) error {
+	type claims tokenClaims
+	if err := json.Unmarshal(data, (*claims)(c)); err != nil {
+		return err
+	}
+	return json.Unmarshal(data, &c.all)
+}
+
+func (v *oidcValidator) ValidateToken(ctx context.Context, token string) (*Client, error) {
+	var claims tokenClaims
+	_, err := v.parser.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.keys.key(ctx, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	names := map[string]Scope{
+		v.conf.Scopes.Search: ScopeSearch,
+		v.conf.Scopes.Lists:  ScopeLists,
+		v.conf.Scopes.Admin:  ScopeAdmin,
+	}
+	var scopes []Scope
+	for _, s := range append(strings.Fields(claims.Scope), claims.Scp...) {
+		if scope, exists := names[s]; exists {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	tenant, _ := claims.all[v.conf.TenantClaim].(string)
+
+	return &Client{
+		Name:    cmp.Or(claims.ClientID, claims.AuthorizedParty, claims.Subject),
+		Subject: claims.Subject,
+		Scopes:  scopes,
+		Tenant:  strings.TrimSpace(tenant),
+	}, nil
+}
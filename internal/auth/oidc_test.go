@@ -0,0 +1,291 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+type testIssuer struct {
+	*httptest.Server
+
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	encode := func(n *big.Int) string {
+		return base64.RawURLEncoding.EncodeToString(n.Bytes())
+	}
+
+	issuer := &testIssuer{rsaKey: rsaKey, ecKey: ecKey}
+	issuer.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			json.NewEncoder(w).Encode(map[string]string{"jwks_uri": issuer.URL + "/keys"})
+		case "/keys":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"keys": []jsonWebKey{
+					{KeyID: "rsa-1", KeyType: "RSA", Use: "sig", N: encode(rsaKey.N), E: encode(big.NewInt(int64(rsaKey.E)))},
+					{KeyID: "ec-1", KeyType: "EC", Curve: "P-256", X: encode(ecKey.X), Y: encode(ecKey.Y)},
+					{KeyID: "enc-1", KeyType: "RSA", Use: "enc"},
+					// Keys which can't verify tokens are skipped
+					{KeyID: "ec-2", KeyType: "EC", Curve: "P-192", X: encode(ecKey.X), Y: encode(ecKey.Y)},
+					{KeyID: "okp-1", KeyType: "OKP", Curve: "Ed25519", X: encode(ecKey.X)},
+					{KeyID: "rsa-2", KeyType: "RSA", N: "not base64!"},
+				},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(issuer.Close)
+
+	return issuer
+}
+
+func (i *testIssuer) sign(t *testing.T, method jwt.SigningMethod, kid string, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+
+	var key interface{} = i.rsaKey
+	if method == jwt.SigningMethodES256 {
+		key = i.ecKey
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestOIDCValidator(t *testing.T) {
+	ctx := context.Background()
+	issuer := newTestIssuer(t)
+
+	v := NewOIDCValidator(log.NewTestLogger(), OIDCConfig{
+		Issuer:   issuer.URL,
+		Audience: "watchman",
+	}, nil)
+
+	claims := func(overrides jwt.MapClaims) jwt.MapClaims {
+		out := jwt.MapClaims{
+			"iss":       issuer.URL,
+			"aud":       "watchman",
+			"sub":       "user-1",
+			"client_id": "payments",
+			"exp":       time.Now().Add(time.Hour).Unix(),
+			"scope":     "openid watchman:search watchman:admin",
+		}
+		for k, v := range overrides {
+			out[k] = v
+		}
+		return out
+	}
+
+	client, err := v.ValidateToken(ctx, issuer.sign(t, jwt.SigningMethodRS256, "rsa-1", claims(nil)))
+	require.NoError(t, err)
+	require.Equal(t, &Client{Name: "payments", Subject: "user-1", Scopes: []Scope{ScopeSearch, ScopeAdmin}}, client)
+
+	// EC keys and scp lists
+	client, err = v.ValidateToken(ctx, issuer.sign(t, jwt.SigningMethodES256, "ec-1", claims(jwt.MapClaims{
+		"scope": nil, "client_id": nil, "scp": []string{"watchman:lists"},
+	})))
+	require.NoError(t, err)
+	require.Equal(t, &Client{Name: "user-1", Subject: "user-1", Scopes: []Scope{ScopeLists}}, client)
+
+	// The tenant is read from the tenant_id claim
+	client, err = v.ValidateToken(ctx, issuer.sign(t, jwt.SigningMethodRS256, "rsa-1", claims(jwt.MapClaims{"tenant_id": "bank-a"})))
+	require.NoError(t, err)
+	require.Equal(t, "bank-a", client.Tenant)
+
+	// Or the claim named by the configuration
+	custom := NewOIDCValidator(log.NewTestLogger(), OIDCConfig{
+		Issuer:      issuer.URL,
+		Audience:    "watchman",
+		TenantClaim: "org",
+	}, nil)
+	client, err = custom.ValidateToken(ctx, issuer.sign(t, jwt.SigningMethodRS256, "rsa-1", claims(jwt.MapClaims{"org": "bank-b", "tenant_id": "bank-a"})))
+	require.NoError(t, err)
+	require.Equal(t, "bank-b", client.Tenant)
+
+	cases := map[string]string{
+		"wrong issuer":   issuer.sign(t, jwt.SigningMethodRS256, "rsa-1", claims(jwt.MapClaims{"iss": "https://other.example.com"})),
+		"wrong audience": issuer.sign(t, jwt.SigningMethodRS256, "rsa-1", claims(jwt.MapClaims{"aud": "other"})),
+		"expired":        issuer.sign(t, jwt.SigningMethodRS256, "rsa-1", claims(jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()})),
+		"no expiration":  issuer.sign(t, jwt.SigningMethodRS256, "rsa-1", claims(jwt.MapClaims{"exp": nil})),
+		"unknown key":    issuer.sign(t, jwt.SigningMethodRS256, "rsa-3", claims(nil)),
+		"unusable key":   issuer.sign(t, jwt.SigningMethodRS256, "rsa-2", claims(nil)),
+		"wrong key":      issuer.sign(t, jwt.SigningMethodRS256, "ec-1", claims(nil)),
+		"malformed":      "not-a-token",
+	}
+	for name, token := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := v.ValidateToken(ctx, token)
+			require.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+
+	// Symmetric tokens are rejected
+	hmac, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims(nil)).SignedString([]byte("secret"))
+	require.NoError(t, err)
+	_, err = v.ValidateToken(ctx, hmac)
+	require.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestJWKS_Fetch(t *testing.T) {
+	ctx := context.Background()
+	issuer := newTestIssuer(t)
+
+	// The keys are served from the issuer once release is closed
+	var fetches atomic.Int32
+	started, release := make(chan struct{}, 100), make(chan struct{})
+	keys := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		started <- struct{}{}
+		<-release
+
+		resp, err := http.Get(issuer.URL + "/keys")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+		io.Copy(w, resp.Body)
+	}))
+	t.Cleanup(keys.Close)
+
+	k := newJWKS(log.NewTestLogger(), keys.Client(), issuer.URL, keys.URL)
+
+	// Tokens arriving during a fetch share it
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := k.key(ctx, "rsa-1")
+			errs <- err
+		}()
+	}
+	<-started
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.Equal(t, int32(1), fetches.Load())
+
+	// Unknown key IDs don't fetch the keys again until the interval has passed
+	_, err := k.key(ctx, "rsa-3")
+	require.ErrorContains(t, err, `unknown signing key "rsa-3"`)
+	require.Equal(t, int32(1), fetches.Load())
+
+	// Known keys are read while the keys are fetched again
+	release = make(chan struct{})
+	k.mu.Lock()
+	k.fetchedAt = time.Now().Add(-jwksRefreshInterval)
+	k.mu.Unlock()
+	go k.key(ctx, "rsa-3")
+	<-started
+
+	found := make(chan error)
+	go func() {
+		_, err := k.key(ctx, "ec-1")
+		found <- err
+	}()
+	select {
+	case err := <-found:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("reading a known key waited on the fetch")
+	}
+	close(release)
+}
+
+func TestMiddleware_OIDC(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	conf := Config{
+		APIKeys: []APIKey{
+			{Name: "payments", Key: "secret-1"},
+		},
+		OIDC: &OIDCConfig{
+			Issuer: issuer.URL,
+			Scopes: ScopeNames{Search: "search", Lists: "lists", Admin: "admin"},
+		},
+	}
+	mw := NewMiddleware(log.NewTestLogger(), conf, nil)
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(ClientFrom(r.Context()).Name))
+	})
+	router := mux.NewRouter()
+	router.Path("/v2/search").Handler(ok)
+	router.Path("/v2/tenants/{tenantID}/lists").Handler(ok)
+	router.Use(mw.Handler)
+
+	admin := mux.NewRouter()
+	admin.Path("/lists/pins").Handler(ok)
+	admin.Use(mw.AdminHandler)
+
+	token := func(scope string) string {
+		return "Bearer " + issuer.sign(t, jwt.SigningMethodRS256, "rsa-1", jwt.MapClaims{
+			"iss":   issuer.URL,
+			"azp":   "onboarding",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"scope": scope,
+		})
+	}
+	do := func(router *mux.Router, path string, headers map[string]string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest("GET", path, nil)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do(router, "/v2/search", map[string]string{"Authorization": token("search")})
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "onboarding", w.Body.String())
+
+	require.Equal(t, http.StatusForbidden, do(router, "/v2/tenants/acme/lists", map[string]string{"Authorization": token("search")}).Code)
+	require.Equal(t, http.StatusOK, do(router, "/v2/tenants/acme/lists", map[string]string{"Authorization": token("lists")}).Code)
+	require.Equal(t, http.StatusUnauthorized, do(router, "/v2/search", map[string]string{"Authorization": "Bearer invalid"}).Code)
+
+	require.Equal(t, http.StatusForbidden, do(admin, "/lists/pins", map[string]string{"Authorization": token("search lists")}).Code)
+	require.Equal(t, http.StatusOK, do(admin, "/lists/pins", map[string]string{"Authorization": token("admin")}).Code)
+
+	// API keys are still accepted, but need an admin scope for the admin server
+	require.Equal(t, http.StatusOK, do(router, "/v2/tenants/acme/lists", map[string]string{"X-API-Key": "secret-1"}).Code)
+	require.Equal(t, http.StatusForbidden, do(admin, "/lists/pins", map[string]string{"X-API-Key": "secret-1"}).Code)
+	require.Equal(t, http.StatusUnauthorized, do(admin, "/lists/pins", nil).Code)
+}
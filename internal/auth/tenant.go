@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrOtherTenant is returned for requests made for a tenant other than their client's
+var ErrOtherTenant = errors.New("client can't act for tenant")
+
+// TenantFor returns the tenant a request acts for. Authenticated clients act for the tenant of their API key or
+// token, and requests for any other tenant, or for a tenant by clients of none, return ErrOtherTenant. The
+// requested tenant is used as given when requests aren't authenticated.
+func TenantFor(ctx context.Context, requested string) (string, error) {
+	client := ClientFrom(ctx)
+	if client == nil {
+		return requested, nil
+	}
+	if requested != "" && requested != client.Tenant {
+		return "", fmt.Errorf("%w %q", ErrOtherTenant, requested)
+	}
+	return client.Tenant, nil
+}
+
+// RequestTenant returns the tenant a request acts for, see TenantFor, as requested by its tenantID query
+// parameter or X-Tenant-ID header
+func RequestTenant(r *http.Request) (string, error) {
+	return TenantFor(r.Context(), strings.TrimSpace(cmp.Or(r.URL.Query().Get("tenantID"), r.Header.Get("X-Tenant-ID"))))
+}
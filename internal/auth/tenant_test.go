@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantFor(t *testing.T) {
+	ctx := context.Background()
+
+	// Requests which aren't authenticated act for the tenant they ask for
+	tenant, err := TenantFor(ctx, "bank-a")
+	require.NoError(t, err)
+	require.Equal(t, "bank-a", tenant)
+
+	// Clients act for their own tenant, and can't ask for another
+	ctx = WithClient(context.Background(), &Client{Name: "payments", Tenant: "bank-a"})
+	for _, requested := range []string{"", "bank-a"} {
+		tenant, err = TenantFor(ctx, requested)
+		require.NoError(t, err)
+		require.Equal(t, "bank-a", tenant)
+	}
+	_, err = TenantFor(ctx, "bank-b")
+	require.ErrorIs(t, err, ErrOtherTenant)
+
+	// Clients of no tenant can't act for one
+	ctx = WithClient(context.Background(), &Client{Name: "operations"})
+	tenant, err = TenantFor(ctx, "")
+	require.NoError(t, err)
+	require.Empty(t, tenant)
+
+	_, err = TenantFor(ctx, "bank-a")
+	require.ErrorIs(t, err, ErrOtherTenant)
+
+	req := httptest.NewRequest("GET", "/v2/search?name=acme", nil)
+	req.Header.Set("X-Tenant-ID", " bank-b ")
+	_, err = RequestTenant(req.WithContext(WithClient(req.Context(), &Client{Tenant: "bank-a"})))
+	require.ErrorContains(t, err, `client can't act for tenant "bank-b"`)
+}
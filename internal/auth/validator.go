@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+)
+
+var (
+	ErrInvalidKey   = errors.New("invalid api key")
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// Validator finds the client making a request from their API key
+type Validator interface {
+	Validate(ctx context.Context, key string) (*Client, error)
+}
+
+// TokenValidator finds the client making a request from their bearer token
+type TokenValidator interface {
+	ValidateToken(ctx context.Context, token string) (*Client, error)
+}
+
+// defaultKeyScopes are given to API keys without any scopes
+var defaultKeyScopes = []Scope{ScopeSearch, ScopeLists}
+
+// NewStaticValidator accepts a fixed set of API keys
+func NewStaticValidator(keys []APIKey) Validator {
+	return &staticValidator{
+		keys: keys,
+	}
+}
+
+type staticValidator struct {
+	keys []APIKey
+}
+
+func (v *staticValidator) Validate(_ context.Context, key string) (*Client, error) {
+	if key == "" {
+		return nil, ErrInvalidKey
+	}
+	for _, k := range v.keys {
+		if subtle.ConstantTimeCompare([]byte(k.Key), []byte(key)) == 1 {
+			scopes := k.Scopes
+			if len(scopes) == 0 {
+				scopes = defaultKeyScopes
+			}
+			return &Client{
+				Name:      k.Name,
+				Scopes:    scopes,
+				Tenant:    k.Tenant,
+				RateLimit: k.RateLimit,
+			}, nil
+		}
+	}
+	return nil, ErrInvalidKey
+}
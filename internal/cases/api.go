@@ -0,0 +1,155 @@
+package cases
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/auth"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+)
+
+type Controller interface {
+	AppendRoutes(router *mux.Router) *mux.Router
+}
+
+func NewController(logger log.Logger, service Service) Controller {
+	return &controller{
+		logger:  logger,
+		service: service,
+	}
+}
+
+type controller struct {
+	logger  log.Logger
+	service Service
+}
+
+func (c *controller) AppendRoutes(router *mux.Router) *mux.Router {
+	router.
+		Name("Cases.listHits").
+		Methods("GET").
+		Path("/v2/cases/hits").
+		HandlerFunc(c.listHits)
+
+	router.
+		Name("Cases.getHit").
+		Methods("GET").
+		Path("/v2/cases/hits/{hitID}").
+		HandlerFunc(c.getHit)
+
+	router.
+		Name("Cases.updateHit").
+		Methods("PUT").
+		Path("/v2/cases/hits/{hitID}").
+		HandlerFunc(c.updateHit)
+
+	return router
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (c *controller) writeError(w http.ResponseWriter, status int, err error) {
+	c.logger.Error().LogError(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error: err.Error(),
+	})
+}
+
+type listHitsResponse struct {
+	Hits []Hit `json:"hits"`
+}
+
+func (c *controller) listHits(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := auth.RequestTenant(r)
+	if err != nil {
+		c.writeError(w, http.StatusForbidden, err)
+		return
+	}
+	q := r.URL.Query()
+	filter := Filter{
+		TenantID:    tenantID,
+		Disposition: Disposition(strings.ToLower(strings.TrimSpace(q.Get("disposition")))),
+	}
+
+	hits, err := c.service.List(filter)
+	if err != nil {
+		c.writeError(w, http.StatusBadRequest, fmt.Errorf("listing hits: %w", err))
+		return
+	}
+	if hits == nil {
+		hits = []Hit{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(listHitsResponse{
+		Hits: hits,
+	})
+}
+
+// findHit returns the hit from the request's path, or nil when it doesn't exist or belongs to another tenant
+func (c *controller) findHit(w http.ResponseWriter, r *http.Request) *Hit {
+	tenantID, err := auth.RequestTenant(r)
+	if err != nil {
+		c.writeError(w, http.StatusForbidden, err)
+		return nil
+	}
+	hit, err := c.service.Get(mux.Vars(r)["hitID"])
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("reading hit: %w", err))
+		return nil
+	}
+	if hit == nil || (tenantID != "" && hit.TenantID != tenantID) {
+		w.WriteHeader(http.StatusNotFound)
+		return nil
+	}
+	return hit
+}
+
+func (c *controller) getHit(w http.ResponseWriter, r *http.Request) {
+	hit := c.findHit(w, r)
+	if hit == nil {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(hit)
+}
+
+func (c *controller) updateHit(w http.ResponseWriter, r *http.Request) {
+	var req Update
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.writeError(w, http.StatusBadRequest, fmt.Errorf("reading hit update: %w", err))
+		return
+	}
+	actor, err := auth.RequestActor(r, req.Actor)
+	if err != nil {
+		c.writeError(w, http.StatusForbidden, err)
+		return
+	}
+	req.Actor = actor
+
+	if c.findHit(w, r) == nil {
+		return
+	}
+	hit, err := c.service.Update(mux.Vars(r)["hitID"], req)
+	if err != nil {
+		c.writeError(w, http.StatusBadRequest, fmt.Errorf("updating hit: %w", err))
+		return
+	}
+	if hit == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(hit)
+}
@@ -0,0 +1,84 @@
+package cases
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestController(t *testing.T) {
+	svc := testService(t)
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), svc).AppendRoutes(router)
+
+	hits, err := svc.Record("", "Acme Shipping", []pubsearch.SearchedEntity[pubsearch.Value]{
+		searchedEntity("123", 0.95),
+	})
+	require.NoError(t, err)
+	hitID := hits[0].HitID
+
+	// list
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/v2/cases/hits?disposition=pending", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp listHitsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Hits, 1)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/cases/hits?disposition=ignored", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// missing actor
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("PUT", "/v2/cases/hits/"+hitID, strings.NewReader(`{"disposition": "cleared"}`))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// update
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("PUT", "/v2/cases/hits/"+hitID, strings.NewReader(`{"disposition": "cleared", "comment": "different dob"}`))
+	req.Header.Set("X-User-ID", "jane")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var hit Hit
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&hit))
+	require.Equal(t, DispositionCleared, hit.Disposition)
+	require.Equal(t, "jane", hit.Comments[0].Author)
+
+	// get
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/cases/hits/"+hitID, nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"disposition":"cleared"`)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/cases/hits/missing", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("PUT", "/v2/cases/hits/missing", strings.NewReader(`{"comment": "hello", "actor": "jane"}`))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	// Cleared hits aren't pending anymore
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/cases/hits?disposition=pending", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"hits":[]}`, w.Body.String())
+}
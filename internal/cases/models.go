@@ -0,0 +1,86 @@
+package cases
+
+import (
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// Hit is an entity which scored at least Config.MinMatch for a query name, kept until it's reviewed. The same
+// entity found again for the same query name and tenant updates its hit rather than creating another.
+type Hit struct {
+	HitID    string `json:"hitID"`
+	TenantID string `json:"tenantID,omitempty"`
+
+	QueryName  string            `json:"queryName"`
+	SourceList search.SourceList `json:"sourceList"`
+	SourceID   string            `json:"sourceID"`
+	EntityName string            `json:"entityName"`
+
+	// Score is from the latest search which found the entity, which has been found Searches times
+	Score    float64 `json:"score"`
+	Searches int     `json:"searches"`
+
+	Disposition Disposition `json:"disposition"`
+	Comments    []Comment   `json:"comments,omitempty"`
+
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+	LastFoundAt time.Time `json:"lastFoundAt"`
+}
+
+type Disposition string
+
+var (
+	// DispositionPending hits haven't been reviewed
+	DispositionPending Disposition = "pending"
+
+	// DispositionCleared hits are false positives
+	DispositionCleared Disposition = "cleared"
+
+	// DispositionEscalated hits need a second review
+	DispositionEscalated Disposition = "escalated"
+
+	// DispositionBlocked hits are true matches, whose transactions or customers are blocked
+	DispositionBlocked Disposition = "blocked"
+)
+
+func (d Disposition) valid() bool {
+	switch d {
+	case DispositionPending, DispositionCleared, DispositionEscalated, DispositionBlocked:
+		return true
+	}
+	return false
+}
+
+// Comment is left on a hit by a reviewer, along with the disposition it was changed to
+type Comment struct {
+	Author      string      `json:"author"`
+	Text        string      `json:"text,omitempty"`
+	Disposition Disposition `json:"disposition,omitempty"`
+	At          time.Time   `json:"at"`
+}
+
+// Update changes the disposition of a hit and leaves a comment, either of which can be empty
+type Update struct {
+	Disposition Disposition `json:"disposition"`
+	Comment     string      `json:"comment"`
+	Actor       string      `json:"actor"`
+}
+
+// Filter chooses which hits are listed, where empty fields match every hit
+type Filter struct {
+	TenantID    string
+	Disposition Disposition
+}
+
+func (f Filter) matches(hit Hit) bool {
+	return (f.TenantID == "" || f.TenantID == hit.TenantID) &&
+		(f.Disposition == "" || f.Disposition == hit.Disposition)
+}
+
+// Config records the hits of searches, which is off when MinMatch is zero
+type Config struct {
+	// MinMatch is the lowest score of results which are recorded as hits
+	MinMatch float64
+}
@@ -0,0 +1,62 @@
+package cases
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+)
+
+type Repository interface {
+	Save(hit Hit) error
+	Get(hitID string) (*Hit, error)
+	List(filter Filter) ([]Hit, error)
+}
+
+func NewInMemoryRepository() Repository {
+	return &inmemRepository{
+		hits: make(map[string]Hit),
+	}
+}
+
+type inmemRepository struct {
+	mu   sync.RWMutex
+	hits map[string]Hit
+}
+
+func (r *inmemRepository) Save(hit Hit) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	hit.Comments = slices.Clone(hit.Comments)
+	r.hits[hit.HitID] = hit
+	return nil
+}
+
+func (r *inmemRepository) Get(hitID string) (*Hit, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	hit, exists := r.hits[hitID]
+	if !exists {
+		return nil, nil
+	}
+	hit.Comments = slices.Clone(hit.Comments)
+	return &hit, nil
+}
+
+func (r *inmemRepository) List(filter Filter) ([]Hit, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var out []Hit
+	for _, hit := range r.hits {
+		if filter.matches(hit) {
+			hit.Comments = slices.Clone(hit.Comments)
+			out = append(out, hit)
+		}
+	}
+	slices.SortFunc(out, func(a, b Hit) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.HitID, b.HitID))
+	})
+	return out, nil
+}
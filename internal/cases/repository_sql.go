@@ -0,0 +1,86 @@
+package cases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/database"
+)
+
+// NewSQLRepository keeps hits in db, so they're shared between Watchman instances
+func NewSQLRepository(db *database.DB) Repository {
+	return &sqlRepository{db: db}
+}
+
+type sqlRepository struct {
+	db *database.DB
+}
+
+func (r *sqlRepository) Save(hit Hit) error {
+	bs, err := json.Marshal(hit)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	return r.db.InTx(ctx, func(tx *database.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM case_hits WHERE hit_id = ?`, hit.HitID)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `INSERT INTO case_hits (hit_id, tenant_id, disposition, created_at, data) VALUES (?, ?, ?, ?, ?)`,
+			hit.HitID, hit.TenantID, string(hit.Disposition), database.ToMillis(hit.CreatedAt), string(bs))
+		return err
+	})
+}
+
+func (r *sqlRepository) Get(hitID string) (*Hit, error) {
+	hits, err := r.queryHits(`SELECT data FROM case_hits WHERE hit_id = ?`, hitID)
+	if err != nil || len(hits) == 0 {
+		return nil, err
+	}
+	return &hits[0], nil
+}
+
+func (r *sqlRepository) List(filter Filter) ([]Hit, error) {
+	var where []string
+	var args []any
+	if filter.TenantID != "" {
+		where = append(where, "tenant_id = ?")
+		args = append(args, filter.TenantID)
+	}
+	if filter.Disposition != "" {
+		where = append(where, "disposition = ?")
+		args = append(args, string(filter.Disposition))
+	}
+
+	query := `SELECT data FROM case_hits`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, " AND ")
+	}
+	return r.queryHits(query+` ORDER BY created_at, hit_id`, args...)
+}
+
+func (r *sqlRepository) queryHits(query string, args ...any) ([]Hit, error) {
+	rows, err := r.db.QueryContext(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Hit
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var hit Hit
+		if err := json.Unmarshal([]byte(data), &hit); err != nil {
+			return nil, fmt.Errorf("reading case hit: %w", err)
+		}
+		out = append(out, hit)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,77 @@
+package cases
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/database"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository(t *testing.T) {
+	repos := map[string]Repository{
+		"inmem": NewInMemoryRepository(),
+		"sql":   NewSQLRepository(database.NewTestDB(t)),
+	}
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			testRepository(t, repo)
+		})
+	}
+}
+
+func testRepository(t *testing.T, repo Repository) {
+	t.Helper()
+
+	now := time.Now().In(time.UTC).Truncate(time.Millisecond)
+	first := Hit{
+		HitID:       "a1",
+		QueryName:   "Acme Shipping",
+		SourceList:  search.SourceUSOFAC,
+		SourceID:    "123",
+		EntityName:  "ACME SHIPPING LTD",
+		Score:       0.97,
+		Searches:    1,
+		Disposition: DispositionPending,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		LastFoundAt: now,
+	}
+	second := Hit{
+		HitID:       "b2",
+		TenantID:    "tenant-1",
+		QueryName:   "Acme Shipping",
+		SourceList:  search.SourceUSOFAC,
+		SourceID:    "456",
+		Disposition: DispositionPending,
+		CreatedAt:   now.Add(time.Second),
+	}
+	require.NoError(t, repo.Save(second))
+	require.NoError(t, repo.Save(first))
+
+	hits, err := repo.List(Filter{})
+	require.NoError(t, err)
+	require.Equal(t, []Hit{first, second}, hits)
+
+	hits, err = repo.List(Filter{TenantID: "tenant-1"})
+	require.NoError(t, err)
+	require.Equal(t, []Hit{second}, hits)
+
+	first.Disposition = DispositionCleared
+	first.Comments = []Comment{{Author: "jane", Text: "different dob", Disposition: DispositionCleared, At: now}}
+	require.NoError(t, repo.Save(first))
+
+	got, err := repo.Get(first.HitID)
+	require.NoError(t, err)
+	require.Equal(t, &first, got)
+
+	hits, err = repo.List(Filter{Disposition: DispositionPending})
+	require.NoError(t, err)
+	require.Equal(t, []Hit{second}, hits)
+
+	got, err = repo.Get("missing")
+	require.NoError(t, err)
+	require.Nil(t, got)
+}
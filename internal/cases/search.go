@@ -0,0 +1,40 @@
+package cases
+
+import (
+	"context"
+
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+)
+
+// NewSearchService records a hit of each entity search result made through service which scores at least
+// Config.MinMatch. Searches are answered even when their hits can't be recorded, which is logged.
+func NewSearchService(logger log.Logger, service search.Service, cases Service) search.Service {
+	return &casedService{
+		Service: service,
+		logger:  logger,
+		cases:   cases,
+	}
+}
+
+type casedService struct {
+	search.Service
+
+	logger log.Logger
+	cases  Service
+}
+
+func (s *casedService) Search(ctx context.Context, query pubsearch.Entity[pubsearch.Value], opts search.SearchOpts) ([]pubsearch.SearchedEntity[pubsearch.Value], error) {
+	results, err := s.Service.Search(ctx, query, opts)
+	if err != nil || !opts.AsOf.IsZero() {
+		// Lookbacks at previous list versions aren't hits of the lists being searched
+		return results, err
+	}
+
+	if _, err := s.cases.Record(opts.TenantID, query.Name, results); err != nil {
+		s.logger.Error().LogErrorf("problem recording hits: %v", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,162 @@
+package cases
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+)
+
+type Service interface {
+	// Record keeps a hit of each result scoring at least Config.MinMatch, returning the hits which were kept
+	Record(tenantID, queryName string, results []search.SearchedEntity[search.Value]) ([]Hit, error)
+
+	Get(hitID string) (*Hit, error)
+	List(filter Filter) ([]Hit, error)
+
+	// Update changes the disposition of a hit or comments on it, returning nil when the hit doesn't exist
+	Update(hitID string, update Update) (*Hit, error)
+}
+
+func NewService(logger log.Logger, repo Repository, conf Config) Service {
+	return &service{
+		logger:   logger,
+		repo:     repo,
+		minMatch: conf.MinMatch,
+	}
+}
+
+type service struct {
+	logger   log.Logger
+	repo     Repository
+	minMatch float64
+
+	// mu keeps concurrent changes to a hit from overwriting each other on this instance
+	mu sync.Mutex
+}
+
+// hitID is the same for an entity found by the same query name for the same tenant
+func hitID(tenantID, queryName string, source search.SourceList, sourceID string) string {
+	key := strings.Join([]string{tenantID, normalizeQuery(queryName), string(source), sourceID}, "\x00")
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:20])
+}
+
+func normalizeQuery(name string) string {
+	return strings.Join(strings.Fields(prepare.LowerAndRemovePunctuation(name)), " ")
+}
+
+func (s *service) Record(tenantID, queryName string, results []search.SearchedEntity[search.Value]) ([]Hit, error) {
+	if s.minMatch <= 0 || normalizeQuery(queryName) == "" {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []Hit
+	now := time.Now().In(time.UTC)
+	for _, result := range results {
+		if result.Match < s.minMatch {
+			continue
+		}
+
+		id := hitID(tenantID, queryName, result.Source, result.SourceID)
+		existing, err := s.repo.Get(id)
+		if err != nil {
+			return out, fmt.Errorf("reading hit: %w", err)
+		}
+
+		hit := Hit{
+			HitID:       id,
+			TenantID:    tenantID,
+			QueryName:   strings.TrimSpace(queryName),
+			SourceList:  result.Source,
+			SourceID:    result.SourceID,
+			Disposition: DispositionPending,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if existing != nil {
+			// Hits found again keep their disposition and comments
+			hit = *existing
+		}
+		hit.EntityName = result.Name
+		hit.Score = result.Match
+		hit.Searches++
+		hit.LastFoundAt = now
+
+		if err := s.repo.Save(hit); err != nil {
+			return out, fmt.Errorf("saving hit: %w", err)
+		}
+		out = append(out, hit)
+	}
+	return out, nil
+}
+
+func (s *service) Get(hitID string) (*Hit, error) {
+	return s.repo.Get(hitID)
+}
+
+func (s *service) List(filter Filter) ([]Hit, error) {
+	if filter.Disposition != "" && !filter.Disposition.valid() {
+		return nil, fmt.Errorf("unknown disposition %q", filter.Disposition)
+	}
+	return s.repo.List(filter)
+}
+
+func (s *service) Update(hitID string, update Update) (*Hit, error) {
+	update.Actor = strings.TrimSpace(update.Actor)
+	update.Comment = strings.TrimSpace(update.Comment)
+
+	switch {
+	case update.Actor == "":
+		return nil, errors.New("missing actor")
+	case update.Disposition == "" && update.Comment == "":
+		return nil, errors.New("missing disposition or comment")
+	case update.Disposition != "" && !update.Disposition.valid():
+		return nil, fmt.Errorf("unknown disposition %q", update.Disposition)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hit, err := s.repo.Get(hitID)
+	if err != nil {
+		return nil, fmt.Errorf("reading hit: %w", err)
+	}
+	if hit == nil {
+		return nil, nil
+	}
+
+	now := time.Now().In(time.UTC)
+	if update.Disposition != "" {
+		hit.Disposition = update.Disposition
+	}
+	hit.Comments = append(hit.Comments, Comment{
+		Author:      update.Actor,
+		Text:        update.Comment,
+		Disposition: update.Disposition,
+		At:          now,
+	})
+	hit.UpdatedAt = now
+
+	if err := s.repo.Save(*hit); err != nil {
+		return nil, fmt.Errorf("saving hit: %w", err)
+	}
+	s.logger.Info().With(log.Fields{
+		"hit_id":      log.String(hit.HitID),
+		"disposition": log.String(string(hit.Disposition)),
+		"actor":       log.String(update.Actor),
+	}).Log("updated hit")
+
+	return hit, nil
+}
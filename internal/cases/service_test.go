@@ -0,0 +1,147 @@
+package cases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func testService(t *testing.T) Service {
+	t.Helper()
+
+	return NewService(log.NewTestLogger(), NewInMemoryRepository(), Config{MinMatch: 0.9})
+}
+
+func searchedEntity(sourceID string, match float64) pubsearch.SearchedEntity[pubsearch.Value] {
+	return pubsearch.SearchedEntity[pubsearch.Value]{
+		Entity: pubsearch.Entity[pubsearch.Value]{
+			Name:     "ACME SHIPPING LTD",
+			Source:   pubsearch.SourceUSOFAC,
+			SourceID: sourceID,
+		},
+		Match: match,
+	}
+}
+
+func TestService_Record(t *testing.T) {
+	svc := testService(t)
+
+	// Only results scoring at least the minimum are recorded
+	hits, err := svc.Record("", "Acme Shipping", []pubsearch.SearchedEntity[pubsearch.Value]{
+		searchedEntity("123", 0.95),
+		searchedEntity("456", 0.80),
+	})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	require.Equal(t, DispositionPending, hits[0].Disposition)
+	require.Equal(t, 1, hits[0].Searches)
+
+	cleared, err := svc.Update(hits[0].HitID, Update{Disposition: DispositionCleared, Comment: "different dob", Actor: "jane"})
+	require.NoError(t, err)
+	require.Equal(t, DispositionCleared, cleared.Disposition)
+
+	// The same entity found for the same query name keeps its hit and disposition
+	again, err := svc.Record("", "ACME  shipping.", []pubsearch.SearchedEntity[pubsearch.Value]{
+		searchedEntity("123", 0.92),
+	})
+	require.NoError(t, err)
+	require.Len(t, again, 1)
+	require.Equal(t, hits[0].HitID, again[0].HitID)
+	require.Equal(t, DispositionCleared, again[0].Disposition)
+	require.Equal(t, 2, again[0].Searches)
+	require.Equal(t, 0.92, again[0].Score)
+	require.Len(t, again[0].Comments, 1)
+
+	// Other tenants have their own hits
+	other, err := svc.Record("tenant-1", "Acme Shipping", []pubsearch.SearchedEntity[pubsearch.Value]{
+		searchedEntity("123", 0.95),
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, hits[0].HitID, other[0].HitID)
+
+	pending, err := svc.List(Filter{Disposition: DispositionPending})
+	require.NoError(t, err)
+	require.Equal(t, other, pending)
+
+	// Nothing is recorded without a minimum
+	off := NewService(log.NewTestLogger(), NewInMemoryRepository(), Config{})
+	hits, err = off.Record("", "Acme Shipping", []pubsearch.SearchedEntity[pubsearch.Value]{
+		searchedEntity("123", 1),
+	})
+	require.NoError(t, err)
+	require.Empty(t, hits)
+}
+
+func TestService_Update(t *testing.T) {
+	svc := testService(t)
+
+	hits, err := svc.Record("", "Acme Shipping", []pubsearch.SearchedEntity[pubsearch.Value]{
+		searchedEntity("123", 0.95),
+	})
+	require.NoError(t, err)
+	hitID := hits[0].HitID
+
+	_, err = svc.Update(hitID, Update{Disposition: DispositionEscalated})
+	require.ErrorContains(t, err, "missing actor")
+
+	_, err = svc.Update(hitID, Update{Actor: "jane"})
+	require.ErrorContains(t, err, "missing disposition or comment")
+
+	_, err = svc.Update(hitID, Update{Disposition: "ignored", Actor: "jane"})
+	require.ErrorContains(t, err, `unknown disposition "ignored"`)
+
+	missing, err := svc.Update("missing", Update{Disposition: DispositionBlocked, Actor: "jane"})
+	require.NoError(t, err)
+	require.Nil(t, missing)
+
+	_, err = svc.Update(hitID, Update{Disposition: DispositionEscalated, Comment: "same dob", Actor: "jane"})
+	require.NoError(t, err)
+
+	// Comments without a disposition leave it unchanged
+	hit, err := svc.Update(hitID, Update{Comment: "asked compliance", Actor: "john"})
+	require.NoError(t, err)
+	require.Equal(t, DispositionEscalated, hit.Disposition)
+	require.Len(t, hit.Comments, 2)
+	require.Equal(t, "john", hit.Comments[1].Author)
+	require.Empty(t, hit.Comments[1].Disposition)
+
+	_, err = svc.List(Filter{Disposition: "ignored"})
+	require.ErrorContains(t, err, `unknown disposition "ignored"`)
+}
+
+func TestSearchService(t *testing.T) {
+	logger := log.NewTestLogger()
+
+	searchService := search.NewService(logger)
+	searchService.UpdateEntities([]pubsearch.Entity[pubsearch.Value]{
+		{
+			Name:     "Acme Shipping Ltd",
+			Type:     pubsearch.EntityBusiness,
+			Source:   pubsearch.SourceUSOFAC,
+			SourceID: "123",
+			Business: &pubsearch.Business{Name: "Acme Shipping Ltd"},
+		},
+	})
+	svc := testService(t)
+	cased := NewSearchService(logger, searchService, svc)
+
+	query := pubsearch.Entity[pubsearch.Value]{
+		Name:     "Acme Shipping Ltd",
+		Type:     pubsearch.EntityBusiness,
+		Business: &pubsearch.Business{Name: "Acme Shipping Ltd"},
+	}
+	results, err := cased.Search(context.Background(), query, search.SearchOpts{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	hits, err := svc.List(Filter{})
+	require.NoError(t, err)
+	require.Len(t, hits, 1)
+	require.Equal(t, "123", hits[0].SourceID)
+	require.Equal(t, "Acme Shipping Ltd", hits[0].QueryName)
+}
@@ -0,0 +1,252 @@
+package customlists
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+
+	"github.com/moov-io/watchman/internal/auth"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+)
+
+type Controller interface {
+	AppendRoutes(router *mux.Router) *mux.Router
+}
+
+func NewController(logger log.Logger, service Service) Controller {
+	return &controller{
+		logger:  logger,
+		service: service,
+	}
+}
+
+type controller struct {
+	logger  log.Logger
+	service Service
+}
+
+func (c *controller) AppendRoutes(router *mux.Router) *mux.Router {
+	router.
+		Name("CustomLists.list").
+		Methods("GET").
+		Path("/v2/tenants/{tenantID}/lists").
+		HandlerFunc(c.listLists)
+
+	router.
+		Name("CustomLists.get").
+		Methods("GET").
+		Path("/v2/tenants/{tenantID}/lists/{list}").
+		HandlerFunc(c.getList)
+
+	router.
+		Name("CustomLists.replace").
+		Methods("PUT").
+		Path("/v2/tenants/{tenantID}/lists/{list}").
+		HandlerFunc(c.replaceList)
+
+	router.
+		Name("CustomLists.delete").
+		Methods("DELETE").
+		Path("/v2/tenants/{tenantID}/lists/{list}").
+		HandlerFunc(c.deleteList)
+
+	router.
+		Name("CustomLists.addEntities").
+		Methods("POST").
+		Path("/v2/tenants/{tenantID}/lists/{list}/entities").
+		HandlerFunc(c.addEntities)
+
+	router.
+		Name("CustomLists.deleteEntity").
+		Methods("DELETE").
+		Path("/v2/tenants/{tenantID}/lists/{list}/entities/{sourceID}").
+		HandlerFunc(c.deleteEntity)
+
+	return router
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (c *controller) writeError(w http.ResponseWriter, err error) {
+	c.logger.Error().LogError(err)
+
+	status := http.StatusBadRequest
+	if errors.Is(err, ErrListNotFound) || errors.Is(err, ErrEntryNotFound) {
+		status = http.StatusNotFound
+	}
+	if errors.Is(err, auth.ErrOtherTenant) {
+		status = http.StatusForbidden
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error: err.Error(),
+	})
+}
+
+func (c *controller) writeList(w http.ResponseWriter, list *List) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(list)
+}
+
+// tenantID reads the tenant from the request's path, which must be the tenant of the authenticated client
+func tenantID(r *http.Request) (string, error) {
+	return auth.TenantFor(r.Context(), mux.Vars(r)["tenantID"])
+}
+
+type listListsResponse struct {
+	Lists []List `json:"lists"`
+}
+
+func (c *controller) listLists(w http.ResponseWriter, r *http.Request) {
+	tenant, err := tenantID(r)
+	if err != nil {
+		c.writeError(w, err)
+		return
+	}
+	lists, err := c.service.List(r.Context(), tenant)
+	if err != nil {
+		c.writeError(w, fmt.Errorf("listing custom lists: %w", err))
+		return
+	}
+	if lists == nil {
+		lists = []List{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(listListsResponse{
+		Lists: lists,
+	})
+}
+
+func (c *controller) getList(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	tenant, err := tenantID(r)
+	if err != nil {
+		c.writeError(w, err)
+		return
+	}
+	list, err := c.service.Get(r.Context(), tenant, vars["list"])
+	if err != nil {
+		c.writeError(w, fmt.Errorf("reading custom list: %w", err))
+		return
+	}
+	c.writeList(w, list)
+}
+
+type entitiesRequest struct {
+	Entities []pubsearch.Entity[pubsearch.Value] `json:"entities"`
+
+	// Records are a simpler form of entities, which is also read from CSV uploads
+	Records []Record `json:"records"`
+}
+
+// readEntities reads a JSON entitiesRequest, or CSV records when the Content-Type is text/csv
+func readEntities(r *http.Request) ([]pubsearch.Entity[pubsearch.Value], error) {
+	var req entitiesRequest
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "text/csv" {
+		records, err := ReadCSV(r.Body)
+		if err != nil {
+			return nil, fmt.Errorf("reading csv: %w", err)
+		}
+		req.Records = records
+	} else {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, fmt.Errorf("reading entities: %w", err)
+		}
+	}
+
+	entities := req.Entities
+	for i, record := range req.Records {
+		entity, err := record.Entity()
+		if err != nil {
+			return nil, fmt.Errorf("record %d: %w", i+1, err)
+		}
+		entities = append(entities, entity)
+	}
+	return entities, nil
+}
+
+func (c *controller) replaceList(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	tenant, err := tenantID(r)
+	if err != nil {
+		c.writeError(w, err)
+		return
+	}
+	entities, err := readEntities(r)
+	if err != nil {
+		c.writeError(w, err)
+		return
+	}
+	list, err := c.service.Replace(r.Context(), tenant, vars["list"], entities)
+	if err != nil {
+		c.writeError(w, fmt.Errorf("replacing custom list: %w", err))
+		return
+	}
+	c.writeList(w, list)
+}
+
+func (c *controller) addEntities(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	tenant, err := tenantID(r)
+	if err != nil {
+		c.writeError(w, err)
+		return
+	}
+	entities, err := readEntities(r)
+	if err != nil {
+		c.writeError(w, err)
+		return
+	}
+	list, err := c.service.Add(r.Context(), tenant, vars["list"], entities)
+	if err != nil {
+		c.writeError(w, fmt.Errorf("adding to custom list: %w", err))
+		return
+	}
+	c.writeList(w, list)
+}
+
+func (c *controller) deleteEntity(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	tenant, err := tenantID(r)
+	if err != nil {
+		c.writeError(w, err)
+		return
+	}
+	err = c.service.DeleteEntry(r.Context(), tenant, vars["list"], vars["sourceID"])
+	if err != nil {
+		c.writeError(w, fmt.Errorf("deleting custom list entity: %w", err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *controller) deleteList(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	tenant, err := tenantID(r)
+	if err != nil {
+		c.writeError(w, err)
+		return
+	}
+	if err := c.service.Delete(r.Context(), tenant, vars["list"]); err != nil {
+		c.writeError(w, fmt.Errorf("deleting custom list: %w", err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,145 @@
+package customlists
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/auth"
+	"github.com/moov-io/watchman/internal/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestController(t *testing.T) {
+	logger := log.NewTestLogger()
+	svc := NewService(logger, NewInMemoryRepository(), search.NewService(logger), nil)
+
+	router := mux.NewRouter()
+	NewController(logger, svc).AppendRoutes(router)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+	upload := func(method, path, contentType, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// replace
+	w := do("PUT", "/v2/tenants/acme/lists/deny", `{"entities": [{"name": "Northwind Traders", "entityType": "business", "sourceID": "1"}]}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var list List
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	require.Equal(t, 1, list.Size)
+
+	w = do("PUT", "/v2/tenants/acme/lists/deny", `{"entities": [{"entityType": "business"}]}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// add
+	w = do("POST", "/v2/tenants/acme/lists/deny/entities", `{"entities": [{"name": "Contoso", "entityType": "business", "sourceID": "2"}]}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// list and get
+	w = do("GET", "/v2/tenants/acme/lists", "")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var lists listListsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&lists))
+	require.Len(t, lists.Lists, 1)
+	require.Equal(t, 2, lists.Lists[0].Size)
+
+	w = do("GET", "/v2/tenants/acme/lists/deny", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	require.Len(t, list.Entities, 2)
+
+	w = do("GET", "/v2/tenants/acme/lists/missing", "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	// delete
+	w = do("DELETE", "/v2/tenants/acme/lists/deny/entities/2", "")
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	w = do("DELETE", "/v2/tenants/acme/lists/deny/entities/2", "")
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	// upload CSV and JSON records
+	w = upload("PUT", "/v2/tenants/acme/lists/deny", "text/csv; charset=utf-8", "sourceID,name,type,aliases\n3,Fabrikam,entity,Fabrikam Imports;Fabrikam Ltd\n")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	require.Equal(t, 1, list.Size)
+	require.Equal(t, "3", list.Entities[0].SourceID)
+	require.Equal(t, []string{"Fabrikam Imports", "Fabrikam Ltd"}, list.Entities[0].Business.AltNames)
+
+	w = upload("PUT", "/v2/tenants/acme/lists/deny", "text/csv", "name\nFabrikam\n")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = upload("POST", "/v2/tenants/acme/lists/deny/entities", "application/json", `{"records": [{"name": "Jane Doe", "type": "individual", "ids": ["passport:X1234567"]}]}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	require.Equal(t, 2, list.Size)
+
+	w = upload("POST", "/v2/tenants/acme/lists/deny/entities", "application/json", `{"records": [{"name": "Jane Doe", "type": "robot"}]}`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = do("DELETE", "/v2/tenants/acme/lists/deny", "")
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	w = do("GET", "/v2/tenants/acme/lists", "")
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&lists))
+	require.Empty(t, lists.Lists)
+}
+
+func TestController_Tenants(t *testing.T) {
+	logger := log.NewTestLogger()
+	svc := NewService(logger, NewInMemoryRepository(), search.NewService(logger), nil)
+
+	mw := auth.NewMiddleware(logger, auth.Config{
+		APIKeys: []auth.APIKey{
+			{Name: "acme", Key: "secret-1", Tenant: "acme"},
+			{Name: "globex", Key: "secret-2", Tenant: "globex"},
+		},
+	}, nil)
+
+	router := mux.NewRouter()
+	NewController(logger, svc).AppendRoutes(router)
+	router.Use(mw.Handler)
+
+	do := func(method, path, key, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("X-API-Key", key)
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := do("PUT", "/v2/tenants/acme/lists/deny", "secret-1", `{"entities": [{"name": "Northwind Traders", "entityType": "business", "sourceID": "1"}]}`)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// Another tenant's key can't read or change acme's lists
+	for _, method := range []string{"GET", "PUT", "DELETE"} {
+		w = do(method, "/v2/tenants/acme/lists/deny", "secret-2", `{"entities": []}`)
+		require.Equal(t, http.StatusForbidden, w.Code, method)
+	}
+	w = do("GET", "/v2/tenants/acme/lists", "secret-2", "")
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Contains(t, w.Body.String(), `client can't act for tenant \"acme\"`)
+
+	w = do("GET", "/v2/tenants/acme/lists/deny", "secret-1", "")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var list List
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	require.Equal(t, 1, list.Size)
+}
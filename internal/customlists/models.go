@@ -0,0 +1,25 @@
+package customlists
+
+import (
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// List is a named set of entities uploaded by a tenant, such as their internal deny list
+type List struct {
+	TenantID string `json:"tenantID"`
+	Name     string `json:"name"`
+
+	// Size is how many entities are in the list
+	Size     int                           `json:"size"`
+	Entities []search.Entity[search.Value] `json:"entities,omitempty"`
+
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// EntrySource is the SourceData of custom list entities when they're returned from a search
+type EntrySource struct {
+	TenantID string `json:"tenantID"`
+	List     string `json:"list"`
+}
@@ -0,0 +1,167 @@
+package customlists
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/moov-io/watchman/pkg/address"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+)
+
+// Record is the simplified form of an entity which is uploaded as CSV or JSON
+type Record struct {
+	SourceID string `json:"sourceID"`
+	Name     string `json:"name"`
+
+	// Type is an entityType (person, business, organization, aircraft or vessel). "individual" and "entity"
+	// are read as person and business.
+	Type string `json:"type"`
+
+	Aliases   []string `json:"aliases"`
+	Addresses []string `json:"addresses"`
+
+	// IDs are written as type:identifier or type:identifier:country, such as passport:X1234567:GB
+	IDs []string `json:"ids"`
+}
+
+func readEntityType(value string) (pubsearch.EntityType, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "person", "individual":
+		return pubsearch.EntityPerson, nil
+	case "business", "entity", "company":
+		return pubsearch.EntityBusiness, nil
+	case "organization":
+		return pubsearch.EntityOrganization, nil
+	case "aircraft":
+		return pubsearch.EntityAircraft, nil
+	case "vessel":
+		return pubsearch.EntityVessel, nil
+	}
+	return "", fmt.Errorf("unknown type %q", value)
+}
+
+func readGovernmentIDs(values []string) ([]pubsearch.GovernmentID, error) {
+	var out []pubsearch.GovernmentID
+	for _, value := range values {
+		parts := strings.Split(strings.TrimSpace(value), ":")
+		if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid id %q, expected type:identifier or type:identifier:country", value)
+		}
+		id := pubsearch.GovernmentID{
+			Type:       pubsearch.GovernmentIDType(strings.ToLower(parts[0])),
+			Identifier: parts[1],
+		}
+		if len(parts) == 3 {
+			id.Country = parts[2]
+		}
+		out = append(out, id)
+	}
+	return out, nil
+}
+
+// Entity converts a Record into the entity which is searched
+func (r Record) Entity() (pubsearch.Entity[pubsearch.Value], error) {
+	entityType, err := readEntityType(r.Type)
+	if err != nil {
+		return pubsearch.Entity[pubsearch.Value]{}, err
+	}
+	ids, err := readGovernmentIDs(r.IDs)
+	if err != nil {
+		return pubsearch.Entity[pubsearch.Value]{}, err
+	}
+
+	name := strings.TrimSpace(r.Name)
+	entity := pubsearch.Entity[pubsearch.Value]{
+		Name:     name,
+		Type:     entityType,
+		SourceID: strings.TrimSpace(r.SourceID),
+	}
+	for _, addr := range r.Addresses {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			entity.Addresses = append(entity.Addresses, address.ParseAddress(addr))
+		}
+	}
+
+	switch entityType {
+	case pubsearch.EntityPerson:
+		entity.Person = &pubsearch.Person{Name: name, AltNames: r.Aliases, GovernmentIDs: ids}
+	case pubsearch.EntityBusiness:
+		entity.Business = &pubsearch.Business{Name: name, AltNames: r.Aliases, GovernmentIDs: ids}
+	case pubsearch.EntityOrganization:
+		entity.Organization = &pubsearch.Organization{Name: name, AltNames: r.Aliases, GovernmentIDs: ids}
+	case pubsearch.EntityAircraft:
+		entity.Aircraft = &pubsearch.Aircraft{Name: name, AltNames: r.Aliases}
+	case pubsearch.EntityVessel:
+		entity.Vessel = &pubsearch.Vessel{Name: name, AltNames: r.Aliases}
+	}
+	return entity, nil
+}
+
+// csvSeparator splits the aliases, addresses and ids columns into their values
+const csvSeparator = ";"
+
+// ReadCSV reads Records from CSV with a header row. The name and type columns are required, while sourceID,
+// aliases, addresses and ids are optional. Columns with several values separate them with a semicolon.
+func ReadCSV(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("missing csv header")
+		}
+		return nil, fmt.Errorf("reading csv header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"name", "type"} {
+		if _, exists := columns[required]; !exists {
+			return nil, fmt.Errorf("missing %s column", required)
+		}
+	}
+
+	var out []Record
+	for line := 2; ; line++ {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading csv line %d: %w", line, err)
+		}
+
+		get := func(column string) string {
+			idx, exists := columns[column]
+			if !exists || idx >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[idx])
+		}
+		out = append(out, Record{
+			SourceID:  get("sourceid"),
+			Name:      get("name"),
+			Type:      get("type"),
+			Aliases:   splitValues(get("aliases")),
+			Addresses: splitValues(get("addresses")),
+			IDs:       splitValues(get("ids")),
+		})
+	}
+	return out, nil
+}
+
+func splitValues(value string) []string {
+	var out []string
+	for _, v := range strings.Split(value, csvSeparator) {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
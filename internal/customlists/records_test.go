@@ -0,0 +1,56 @@
+package customlists
+
+import (
+	"strings"
+	"testing"
+
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCSV(t *testing.T) {
+	input := `sourceID,name,type,aliases,addresses,ids
+cust-1,Northwind Traders,entity,Northwind Trading;NW Traders,"123 Main St, Springfield IL",tax-id:98-7654321:US
+,Jane Doe,individual,,,passport:X1234567
+`
+	records, err := ReadCSV(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	require.Equal(t, Record{
+		SourceID:  "cust-1",
+		Name:      "Northwind Traders",
+		Type:      "entity",
+		Aliases:   []string{"Northwind Trading", "NW Traders"},
+		Addresses: []string{"123 Main St, Springfield IL"},
+		IDs:       []string{"tax-id:98-7654321:US"},
+	}, records[0])
+
+	business, err := records[0].Entity()
+	require.NoError(t, err)
+	require.Equal(t, pubsearch.EntityBusiness, business.Type)
+	require.Equal(t, []string{"Northwind Trading", "NW Traders"}, business.Business.AltNames)
+	require.Equal(t, []pubsearch.GovernmentID{
+		{Type: pubsearch.GovernmentIDTax, Identifier: "98-7654321", Country: "US"},
+	}, business.Business.GovernmentIDs)
+	require.Len(t, business.Addresses, 1)
+
+	person, err := records[1].Entity()
+	require.NoError(t, err)
+	require.Equal(t, pubsearch.EntityPerson, person.Type)
+	require.Equal(t, "Jane Doe", person.Person.Name)
+	require.Equal(t, pubsearch.GovernmentIDPassport, person.Person.GovernmentIDs[0].Type)
+
+	_, err = ReadCSV(strings.NewReader("name,aliases\nJane,\n"))
+	require.ErrorContains(t, err, "missing type column")
+
+	_, err = ReadCSV(strings.NewReader(""))
+	require.ErrorContains(t, err, "missing csv header")
+
+	_, err = Record{Name: "Jane", Type: "robot"}.Entity()
+	require.ErrorContains(t, err, "unknown type")
+
+	_, err = Record{Name: "Jane", Type: "person", IDs: []string{"X1234567"}}.Entity()
+	require.ErrorContains(t, err, "invalid id")
+}
@@ -0,0 +1,83 @@
+package customlists
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+)
+
+type Repository interface {
+	// SaveList replaces a tenant's list along with its entities
+	SaveList(ctx context.Context, list List) error
+	GetList(ctx context.Context, tenantID, name string) (*List, error)
+	DeleteList(ctx context.Context, tenantID, name string) error
+
+	// ListLists returns the lists of a tenant, or every tenant when tenantID is empty, sorted by tenant and name
+	ListLists(ctx context.Context, tenantID string) ([]List, error)
+}
+
+func NewInMemoryRepository() Repository {
+	return &inmemRepository{
+		lists: make(map[listKey]List),
+	}
+}
+
+type listKey struct {
+	tenantID, name string
+}
+
+type inmemRepository struct {
+	mu    sync.RWMutex
+	lists map[listKey]List
+}
+
+func (r *inmemRepository) SaveList(ctx context.Context, list List) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	list.Entities = slices.Clone(list.Entities)
+	r.lists[listKey{tenantID: list.TenantID, name: list.Name}] = list
+	return nil
+}
+
+func (r *inmemRepository) GetList(ctx context.Context, tenantID, name string) (*List, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	list, exists := r.lists[listKey{tenantID: tenantID, name: name}]
+	if !exists {
+		return nil, nil
+	}
+	list.Entities = slices.Clone(list.Entities)
+	return &list, nil
+}
+
+func (r *inmemRepository) DeleteList(ctx context.Context, tenantID, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.lists, listKey{tenantID: tenantID, name: name})
+	return nil
+}
+
+func (r *inmemRepository) ListLists(ctx context.Context, tenantID string) ([]List, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var out []List
+	for key, list := range r.lists {
+		if tenantID != "" && key.tenantID != tenantID {
+			continue
+		}
+		list.Entities = slices.Clone(list.Entities)
+		out = append(out, list)
+	}
+	slices.SortFunc(out, func(a, b List) int {
+		if n := strings.Compare(a.TenantID, b.TenantID); n != 0 {
+			return n
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return out, nil
+}
@@ -0,0 +1,77 @@
+package customlists
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/moov-io/watchman/internal/database"
+)
+
+// NewSQLRepository keeps custom lists in db, so they're shared between Watchman instances
+func NewSQLRepository(db *database.DB) Repository {
+	return &sqlRepository{db: db}
+}
+
+type sqlRepository struct {
+	db *database.DB
+}
+
+func (r *sqlRepository) SaveList(ctx context.Context, list List) error {
+	bs, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+
+	return r.db.InTx(ctx, func(tx *database.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM custom_lists WHERE tenant_id = ? AND name = ?`, list.TenantID, list.Name)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `INSERT INTO custom_lists (tenant_id, name, updated_at, data) VALUES (?, ?, ?, ?)`,
+			list.TenantID, list.Name, database.ToMillis(list.UpdatedAt), string(bs))
+		return err
+	})
+}
+
+func (r *sqlRepository) GetList(ctx context.Context, tenantID, name string) (*List, error) {
+	lists, err := r.queryLists(ctx, `SELECT data FROM custom_lists WHERE tenant_id = ? AND name = ?`, tenantID, name)
+	if err != nil || len(lists) == 0 {
+		return nil, err
+	}
+	return &lists[0], nil
+}
+
+func (r *sqlRepository) DeleteList(ctx context.Context, tenantID, name string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM custom_lists WHERE tenant_id = ? AND name = ?`, tenantID, name)
+	return err
+}
+
+func (r *sqlRepository) ListLists(ctx context.Context, tenantID string) ([]List, error) {
+	if tenantID == "" {
+		return r.queryLists(ctx, `SELECT data FROM custom_lists ORDER BY tenant_id, name`)
+	}
+	return r.queryLists(ctx, `SELECT data FROM custom_lists WHERE tenant_id = ? ORDER BY name`, tenantID)
+}
+
+func (r *sqlRepository) queryLists(ctx context.Context, query string, args ...any) ([]List, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []List
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var list List
+		if err := json.Unmarshal([]byte(data), &list); err != nil {
+			return nil, fmt.Errorf("reading custom list: %w", err)
+		}
+		out = append(out, list)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,290 @@
+package customlists
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base"
+	"github.com/moov-io/base/log"
+)
+
+var (
+	ErrListNotFound  = errors.New("custom list not found")
+	ErrEntryNotFound = errors.New("custom list entry not found")
+
+	// validName limits tenant IDs and list names to characters which are safe in URL paths
+	validName = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+)
+
+type Service interface {
+	// Replace sets the entities of a list, creating it when needed
+	Replace(ctx context.Context, tenantID, name string, entities []pubsearch.Entity[pubsearch.Value]) (*List, error)
+
+	// Add appends entities to a list, replacing those with the same SourceID
+	Add(ctx context.Context, tenantID, name string, entities []pubsearch.Entity[pubsearch.Value]) (*List, error)
+
+	DeleteEntry(ctx context.Context, tenantID, name, sourceID string) error
+	Delete(ctx context.Context, tenantID, name string) error
+
+	Get(ctx context.Context, tenantID, name string) (*List, error)
+	List(ctx context.Context, tenantID string) ([]List, error)
+
+	// Load makes every stored list searchable, such as after a restart or when another instance changed them
+	Load(ctx context.Context) error
+}
+
+// NewService returns a Service which indexes custom lists in searchService. Entity names are run through
+// pipeline, which is optional, as they're indexed.
+func NewService(logger log.Logger, repo Repository, searchService search.Service, pipeline *prepare.Pipeline) Service {
+	return &service{
+		logger:        logger,
+		repo:          repo,
+		searchService: searchService,
+		pipeline:      pipeline,
+		tenants:       make(map[string]bool),
+	}
+}
+
+type service struct {
+	logger        log.Logger
+	repo          Repository
+	searchService search.Service
+	pipeline      *prepare.Pipeline
+
+	// mu serializes changes to lists, which are read and saved whole
+	mu sync.Mutex
+
+	// tenants have entities being searched
+	tenants map[string]bool
+}
+
+func validate(tenantID, name string) error {
+	if !validName.MatchString(tenantID) {
+		return fmt.Errorf("invalid tenantID %q", tenantID)
+	}
+	if !validName.MatchString(name) {
+		return fmt.Errorf("invalid list name %q", name)
+	}
+	return nil
+}
+
+// prepareEntities checks each entity can be searched and marks it as part of a custom list
+func prepareEntities(entities []pubsearch.Entity[pubsearch.Value]) ([]pubsearch.Entity[pubsearch.Value], error) {
+	out := make([]pubsearch.Entity[pubsearch.Value], 0, len(entities))
+	for i, entity := range entities {
+		if strings.TrimSpace(entity.Name) == "" {
+			return nil, fmt.Errorf("entity %d: missing name", i)
+		}
+		if entity.Type == "" {
+			return nil, fmt.Errorf("entity %d: missing entityType", i)
+		}
+		entity.Source = pubsearch.SourceCustomList
+		entity.SourceID = cmp.Or(strings.TrimSpace(entity.SourceID), base.ID())
+		entity.SourceData = nil
+		out = append(out, entity)
+	}
+	return out, nil
+}
+
+func (s *service) Replace(ctx context.Context, tenantID, name string, entities []pubsearch.Entity[pubsearch.Value]) (*List, error) {
+	if err := validate(tenantID, name); err != nil {
+		return nil, err
+	}
+	entities, err := prepareEntities(entities)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.save(ctx, List{
+		TenantID: tenantID,
+		Name:     name,
+		Entities: dedupe(entities),
+	})
+}
+
+func (s *service) Add(ctx context.Context, tenantID, name string, entities []pubsearch.Entity[pubsearch.Value]) (*List, error) {
+	if err := validate(tenantID, name); err != nil {
+		return nil, err
+	}
+	entities, err := prepareEntities(entities)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list, err := s.repo.GetList(ctx, tenantID, name)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		list = &List{TenantID: tenantID, Name: name}
+	}
+	list.Entities = dedupe(append(list.Entities, entities...))
+
+	return s.save(ctx, *list)
+}
+
+// dedupe keeps the last entity with each SourceID
+func dedupe(entities []pubsearch.Entity[pubsearch.Value]) []pubsearch.Entity[pubsearch.Value] {
+	positions := make(map[string]int, len(entities))
+	out := make([]pubsearch.Entity[pubsearch.Value], 0, len(entities))
+	for _, entity := range entities {
+		if idx, exists := positions[entity.SourceID]; exists {
+			out[idx] = entity
+			continue
+		}
+		positions[entity.SourceID] = len(out)
+		out = append(out, entity)
+	}
+	return out
+}
+
+func (s *service) DeleteEntry(ctx context.Context, tenantID, name, sourceID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list, err := s.repo.GetList(ctx, tenantID, name)
+	if err != nil {
+		return err
+	}
+	if list == nil {
+		return fmt.Errorf("%w: %s", ErrListNotFound, name)
+	}
+
+	idx := slices.IndexFunc(list.Entities, func(entity pubsearch.Entity[pubsearch.Value]) bool {
+		return entity.SourceID == sourceID
+	})
+	if idx < 0 {
+		return fmt.Errorf("%w: %s", ErrEntryNotFound, sourceID)
+	}
+	list.Entities = slices.Delete(list.Entities, idx, idx+1)
+
+	_, err = s.save(ctx, *list)
+	return err
+}
+
+func (s *service) Delete(ctx context.Context, tenantID, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.repo.DeleteList(ctx, tenantID, name); err != nil {
+		return fmt.Errorf("deleting %s custom list: %w", name, err)
+	}
+	s.logger.Info().Logf("deleted %s custom list of tenant %s", name, tenantID)
+
+	return s.updateTenant(ctx, tenantID)
+}
+
+func (s *service) save(ctx context.Context, list List) (*List, error) {
+	list.Size = len(list.Entities)
+	list.UpdatedAt = time.Now().In(time.UTC)
+
+	if err := s.repo.SaveList(ctx, list); err != nil {
+		return nil, fmt.Errorf("saving %s custom list: %w", list.Name, err)
+	}
+	s.logger.Info().Logf("saved %s custom list of tenant %s with %d entities", list.Name, list.TenantID, list.Size)
+
+	if err := s.updateTenant(ctx, list.TenantID); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// updateTenant replaces the searched entities of a tenant with those of each of their lists
+func (s *service) updateTenant(ctx context.Context, tenantID string) error {
+	lists, err := s.repo.ListLists(ctx, tenantID)
+	if err != nil {
+		return fmt.Errorf("reading custom lists of %s: %w", tenantID, err)
+	}
+	s.searchService.UpdateTenantEntities(tenantID, s.searchable(lists))
+	s.tenants[tenantID] = len(lists) > 0
+
+	return nil
+}
+
+// searchable returns the prepared entities of each list, with their SourceData showing which list they're from
+func (s *service) searchable(lists []List) []pubsearch.Entity[pubsearch.Value] {
+	var out []pubsearch.Entity[pubsearch.Value]
+	for _, list := range lists {
+		for _, entity := range list.Entities {
+			entity = search.PrepareEntity(s.pipeline, entity)
+			entity.SourceData = EntrySource{
+				TenantID: list.TenantID,
+				List:     list.Name,
+			}
+			out = append(out, entity)
+		}
+	}
+	return out
+}
+
+func (s *service) Get(ctx context.Context, tenantID, name string) (*List, error) {
+	list, err := s.repo.GetList(ctx, tenantID, name)
+	if err != nil {
+		return nil, err
+	}
+	if list == nil {
+		return nil, fmt.Errorf("%w: %s", ErrListNotFound, name)
+	}
+	return list, nil
+}
+
+func (s *service) List(ctx context.Context, tenantID string) ([]List, error) {
+	if tenantID == "" {
+		return nil, errors.New("missing tenantID")
+	}
+	lists, err := s.repo.ListLists(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range lists {
+		lists[i].Entities = nil
+	}
+	return lists, nil
+}
+
+func (s *service) Load(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lists, err := s.repo.ListLists(ctx, "")
+	if err != nil {
+		return fmt.Errorf("reading custom lists: %w", err)
+	}
+
+	byTenant := make(map[string][]List)
+	for _, list := range lists {
+		byTenant[list.TenantID] = append(byTenant[list.TenantID], list)
+	}
+	for tenantID, lists := range byTenant {
+		s.searchService.UpdateTenantEntities(tenantID, s.searchable(lists))
+	}
+
+	// Stop searching the lists of tenants which were deleted elsewhere
+	for tenantID := range s.tenants {
+		if _, exists := byTenant[tenantID]; !exists {
+			s.searchService.UpdateTenantEntities(tenantID, nil)
+		}
+	}
+	s.tenants = make(map[string]bool, len(byTenant))
+	for tenantID := range byTenant {
+		s.tenants[tenantID] = true
+	}
+	return nil
+}
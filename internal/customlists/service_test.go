@@ -0,0 +1,134 @@
+package customlists
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/database"
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func testEntity(sourceID, name string) pubsearch.Entity[pubsearch.Value] {
+	return pubsearch.Entity[pubsearch.Value]{
+		Name:     name,
+		Type:     pubsearch.EntityBusiness,
+		SourceID: sourceID,
+		Business: &pubsearch.Business{Name: name},
+	}
+}
+
+func TestService(t *testing.T) {
+	repos := map[string]Repository{
+		"inmem": NewInMemoryRepository(),
+		"sql":   NewSQLRepository(database.NewTestDB(t)),
+	}
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			testService(t, repo)
+		})
+	}
+}
+
+func testService(t *testing.T, repo Repository) {
+	t.Helper()
+
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+
+	searchService := search.NewService(logger)
+	searchService.UpdateEntities([]pubsearch.Entity[pubsearch.Value]{
+		{Name: "Global Shipping", Type: pubsearch.EntityBusiness, Source: pubsearch.SourceUSOFAC, SourceID: "100",
+			Business: &pubsearch.Business{Name: "Global Shipping"}},
+	})
+	svc := NewService(logger, repo, searchService, nil)
+
+	list, err := svc.Replace(ctx, "acme", "deny", []pubsearch.Entity[pubsearch.Value]{
+		testEntity("1", "Northwind Traders"),
+		testEntity("", "Contoso Holdings"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, list.Size)
+	require.NotEmpty(t, list.Entities[1].SourceID)
+	require.Equal(t, pubsearch.SourceCustomList, list.Entities[0].Source)
+
+	_, err = svc.Replace(ctx, "acme", "bad name", nil)
+	require.ErrorContains(t, err, "invalid list name")
+	_, err = svc.Add(ctx, "acme", "deny", []pubsearch.Entity[pubsearch.Value]{{Type: pubsearch.EntityPerson}})
+	require.ErrorContains(t, err, "missing name")
+
+	// Entities with the same SourceID are replaced
+	list, err = svc.Add(ctx, "acme", "deny", []pubsearch.Entity[pubsearch.Value]{
+		testEntity("1", "Northwind Trading"),
+		testEntity("3", "Fabrikam Imports"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, list.Size)
+	require.Equal(t, "Northwind Trading", list.Entities[0].Name)
+
+	// Only the tenant searches their lists
+	query := pubsearch.Entity[pubsearch.Value]{Name: "Northwind Trading", Type: pubsearch.EntityBusiness}
+	opts := search.SearchOpts{Limit: 10, MinMatch: 0.5}
+
+	results, err := searchService.Search(ctx, query, opts)
+	require.NoError(t, err)
+	for _, res := range results {
+		require.NotEqual(t, pubsearch.SourceCustomList, res.Source)
+	}
+
+	opts.TenantID = "acme"
+	results, err = searchService.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	require.Equal(t, "1", results[0].SourceID)
+	require.Equal(t, EntrySource{TenantID: "acme", List: "deny"}, results[0].SourceData)
+
+	opts.TenantID = "other"
+	results, err = searchService.Search(ctx, query, opts)
+	require.NoError(t, err)
+	for _, res := range results {
+		require.NotEqual(t, pubsearch.SourceCustomList, res.Source)
+	}
+
+	lists, err := svc.List(ctx, "acme")
+	require.NoError(t, err)
+	require.Len(t, lists, 1)
+	require.Equal(t, 3, lists[0].Size)
+	require.Empty(t, lists[0].Entities)
+
+	// Delete entries and lists
+	require.NoError(t, svc.DeleteEntry(ctx, "acme", "deny", "1"))
+	require.ErrorIs(t, svc.DeleteEntry(ctx, "acme", "deny", "1"), ErrEntryNotFound)
+	require.ErrorIs(t, svc.DeleteEntry(ctx, "acme", "missing", "1"), ErrListNotFound)
+
+	list, err = svc.Get(ctx, "acme", "deny")
+	require.NoError(t, err)
+	require.Equal(t, 2, list.Size)
+
+	require.NoError(t, svc.Delete(ctx, "acme", "deny"))
+	_, err = svc.Get(ctx, "acme", "deny")
+	require.ErrorIs(t, err, ErrListNotFound)
+
+	opts.TenantID = "acme"
+	results, err = searchService.Search(ctx, query, opts)
+	require.NoError(t, err)
+	for _, res := range results {
+		require.NotEqual(t, pubsearch.SourceCustomList, res.Source)
+	}
+
+	// Load picks up lists saved by other instances
+	_, err = NewService(logger, repo, search.NewService(logger), nil).Replace(ctx, "beta", "internal", []pubsearch.Entity[pubsearch.Value]{
+		testEntity("9", "Northwind Trading"),
+	})
+	require.NoError(t, err)
+	require.NoError(t, svc.Load(ctx))
+
+	opts.TenantID = "beta"
+	results, err = searchService.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	require.Equal(t, "9", results[0].SourceID)
+}
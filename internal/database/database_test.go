@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpen(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := Open(ctx, Config{Type: "oracle", DSN: "x"})
+	require.ErrorContains(t, err, "unknown database type")
+
+	_, err = Open(ctx, Config{Type: "sqlite"})
+	require.ErrorContains(t, err, "missing database DSN")
+
+	// Migrations are only applied once
+	conf := Config{Type: "sqlite", DSN: filepath.Join(t.TempDir(), "watchman.db")}
+	for i := 0; i < 2; i++ {
+		db, err := Open(ctx, conf)
+		require.NoError(t, err)
+
+		var version int
+		err = db.QueryRowContext(ctx, `SELECT MAX(version) FROM watchman_migrations`).Scan(&version)
+		require.NoError(t, err)
+		require.Equal(t, len(migrations), version)
+		require.NoError(t, db.Close())
+	}
+}
+
+func TestDB_Rebind(t *testing.T) {
+	query := `SELECT data FROM watches WHERE watch_id = ? AND created_at > ?`
+
+	db := &DB{Dialect: MySQL}
+	require.Equal(t, query, db.Rebind(query))
+
+	db.Dialect = Postgres
+	require.Equal(t, `SELECT data FROM watches WHERE watch_id = $1 AND created_at > $2`, db.Rebind(query))
+}
+
+func TestDB_Leases(t *testing.T) {
+	ctx := context.Background()
+	db := NewTestDB(t)
+
+	acquired, err := db.AcquireLease(ctx, "refresh", "host-a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// host-b waits while host-a renews its lease
+	acquired, err = db.AcquireLease(ctx, "refresh", "host-b", time.Minute)
+	require.NoError(t, err)
+	require.False(t, acquired)
+
+	acquired, err = db.AcquireLease(ctx, "refresh", "host-a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	require.NoError(t, db.ReleaseLease(ctx, "refresh", "host-a"))
+
+	acquired, err = db.AcquireLease(ctx, "refresh", "host-b", -time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// Expired leases can be taken over
+	acquired, err = db.AcquireLease(ctx, "refresh", "host-a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+}
+
+func TestMillis(t *testing.T) {
+	require.True(t, FromMillis(ToMillis(time.Time{})).IsZero())
+
+	when := time.Date(2024, time.March, 3, 10, 30, 0, 0, time.UTC)
+	require.Equal(t, when, FromMillis(ToMillis(when)))
+}
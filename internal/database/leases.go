@@ -0,0 +1,43 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// AcquireLease claims name for holder until ttl passes, so only one Watchman instance performs a task
+// (such as refreshing lists) at a time. False is returned while another holder's lease is unexpired.
+//
+// Holders can renew their lease by acquiring it again.
+func (db *DB) AcquireLease(ctx context.Context, name, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+
+	_, err := db.ExecContext(ctx, `DELETE FROM leases WHERE name = ? AND (expires_at < ? OR holder = ?)`, name, ToMillis(now), holder)
+	if err != nil {
+		return false, err
+	}
+
+	_, insertErr := db.ExecContext(ctx, `INSERT INTO leases (name, holder, expires_at) VALUES (?, ?, ?)`, name, holder, ToMillis(now.Add(ttl)))
+	if insertErr == nil {
+		return true, nil
+	}
+
+	// The insert fails when another holder's lease exists
+	var current string
+	err = db.DB.QueryRowContext(ctx, db.Rebind(`SELECT holder FROM leases WHERE name = ?`), name).Scan(&current)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, insertErr
+		}
+		return false, err
+	}
+	return current == holder, nil
+}
+
+// ReleaseLease gives up holder's lease on name, if it's still held
+func (db *DB) ReleaseLease(ctx context.Context, name, holder string) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM leases WHERE name = ? AND holder = ?`, name, holder)
+	return err
+}
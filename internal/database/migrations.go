@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// migrations are run in order, once each, and must never be edited after they're released.
+//
+// {{text}} is replaced with a column type large enough for JSON documents.
+var migrations = []string{
+	`CREATE TABLE allowlist_entries (
+		entry_id VARCHAR(64) NOT NULL PRIMARY KEY,
+		normalized_query VARCHAR(500) NOT NULL,
+		created_at BIGINT NOT NULL,
+		data {{text}} NOT NULL
+	)`,
+	`CREATE INDEX allowlist_entries_query ON allowlist_entries (normalized_query)`,
+	`CREATE TABLE allowlist_audit (
+		event_id VARCHAR(64) NOT NULL PRIMARY KEY,
+		entry_id VARCHAR(64) NOT NULL,
+		created_at BIGINT NOT NULL,
+		data {{text}} NOT NULL
+	)`,
+	`CREATE TABLE watches (
+		watch_id VARCHAR(64) NOT NULL PRIMARY KEY,
+		created_at BIGINT NOT NULL,
+		data {{text}} NOT NULL
+	)`,
+	`CREATE TABLE list_entities (
+		position BIGINT NOT NULL PRIMARY KEY,
+		source VARCHAR(64) NOT NULL,
+		source_id VARCHAR(255) NOT NULL,
+		data {{text}} NOT NULL
+	)`,
+	`CREATE TABLE list_refreshes (
+		refresh_id VARCHAR(64) NOT NULL PRIMARY KEY,
+		host VARCHAR(255) NOT NULL,
+		started_at BIGINT NOT NULL,
+		ended_at BIGINT NOT NULL,
+		data {{text}} NOT NULL
+	)`,
+	`CREATE INDEX list_refreshes_ended_at ON list_refreshes (ended_at)`,
+	`CREATE TABLE leases (
+		name VARCHAR(64) NOT NULL PRIMARY KEY,
+		holder VARCHAR(255) NOT NULL,
+		expires_at BIGINT NOT NULL
+	)`,
+	`CREATE TABLE list_versions (
+		version_id VARCHAR(64) NOT NULL PRIMARY KEY,
+		list VARCHAR(64) NOT NULL,
+		hash VARCHAR(64) NOT NULL,
+		downloaded_at BIGINT NOT NULL,
+		data {{text}} NOT NULL
+	)`,
+	`CREATE INDEX list_versions_downloaded_at ON list_versions (list, downloaded_at)`,
+	`CREATE TABLE list_version_members (
+		list VARCHAR(64) NOT NULL,
+		hash VARCHAR(64) NOT NULL,
+		source_id VARCHAR(255) NOT NULL,
+		fingerprint VARCHAR(64) NOT NULL,
+		PRIMARY KEY (list, hash, source_id)
+	)`,
+	`CREATE TABLE list_version_entities (
+		fingerprint VARCHAR(64) NOT NULL PRIMARY KEY,
+		data {{text}} NOT NULL
+	)`,
+	`CREATE TABLE list_version_pins (
+		list VARCHAR(64) NOT NULL PRIMARY KEY,
+		data {{text}} NOT NULL
+	)`,
+	`CREATE TABLE custom_lists (
+		tenant_id VARCHAR(64) NOT NULL,
+		name VARCHAR(64) NOT NULL,
+		updated_at BIGINT NOT NULL,
+		data {{text}} NOT NULL,
+		PRIMARY KEY (tenant_id, name)
+	)`,
+	`CREATE TABLE search_audit (
+		record_id VARCHAR(64) NOT NULL PRIMARY KEY,
+		client VARCHAR(255) NOT NULL,
+		created_at BIGINT NOT NULL,
+		data {{text}} NOT NULL
+	)`,
+	`CREATE INDEX search_audit_created_at ON search_audit (created_at)`,
+	`CREATE TABLE case_hits (
+		hit_id VARCHAR(64) NOT NULL PRIMARY KEY,
+		tenant_id VARCHAR(64) NOT NULL,
+		disposition VARCHAR(32) NOT NULL,
+		created_at BIGINT NOT NULL,
+		data {{text}} NOT NULL
+	)`,
+	`CREATE INDEX case_hits_disposition ON case_hits (disposition, created_at)`,
+	`ALTER TABLE allowlist_entries ADD COLUMN tenant_id VARCHAR(64) NOT NULL DEFAULT ''`,
+	`CREATE INDEX allowlist_entries_tenant_query ON allowlist_entries (tenant_id, normalized_query)`,
+	`CREATE TABLE webhook_subscriptions (
+		subscription_id VARCHAR(64) NOT NULL PRIMARY KEY,
+		created_at BIGINT NOT NULL,
+		data {{text}} NOT NULL
+	)`,
+}
+
+func (db *DB) migrate(ctx context.Context) error {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS watchman_migrations (version INT NOT NULL PRIMARY KEY, applied_at BIGINT NOT NULL)`)
+	if err != nil {
+		return err
+	}
+
+	var current int
+	err = db.DB.QueryRowContext(ctx, `SELECT COALESCE(MAX(version), 0) FROM watchman_migrations`).Scan(&current)
+	if err != nil {
+		return err
+	}
+
+	text := "TEXT"
+	if db.Dialect == MySQL {
+		text = "LONGTEXT"
+	}
+	for i := current; i < len(migrations); i++ {
+		version := i + 1
+		query := strings.ReplaceAll(migrations[i], "{{text}}", text)
+
+		err := db.InTx(ctx, func(tx *Tx) error {
+			if _, err := tx.ExecContext(ctx, query); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `INSERT INTO watchman_migrations (version, applied_at) VALUES (?, ?)`, version, ToMillis(time.Now()))
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("migration %d: %w", version, err)
+		}
+	}
+	return nil
+}
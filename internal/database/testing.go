@@ -0,0 +1,36 @@
+package database
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// NewTestDB opens a sqlite database which is removed after the test. DATABASE_TEST_TYPE and DATABASE_TEST_DSN
+// can choose a MySQL or Postgres database instead, whose tables are emptied before the test.
+func NewTestDB(tb testing.TB) *DB {
+	tb.Helper()
+
+	conf := Config{
+		Type: os.Getenv("DATABASE_TEST_TYPE"),
+		DSN:  os.Getenv("DATABASE_TEST_DSN"),
+	}
+	if conf.Type == "" {
+		conf.Type = string(SQLite)
+		conf.DSN = filepath.Join(tb.TempDir(), "watchman.db")
+	}
+
+	ctx := context.Background()
+	db, err := Open(ctx, conf)
+	require.NoError(tb, err)
+	tb.Cleanup(func() { db.Close() })
+
+	for _, table := range []string{"allowlist_entries", "allowlist_audit", "watches", "list_entities", "list_refreshes", "leases", "list_versions", "list_version_members", "list_version_entities", "list_version_pins", "custom_lists", "search_audit"} {
+		_, err := db.ExecContext(ctx, "DELETE FROM "+table)
+		require.NoError(tb, err)
+	}
+	return db
+}
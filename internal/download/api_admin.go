@@ -0,0 +1,143 @@
+package download
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+)
+
+type Controller interface {
+	AppendRoutes(router *mux.Router) *mux.Router
+}
+
+// NewAdminController returns the routes which enable, disable, reschedule, refresh and approve lists at runtime.
+// They're expected to be served on the admin server.
+func NewAdminController(logger log.Logger, scheduler *Scheduler) Controller {
+	return &adminController{
+		logger:    logger,
+		scheduler: scheduler,
+	}
+}
+
+type adminController struct {
+	logger    log.Logger
+	scheduler *Scheduler
+}
+
+func (c *adminController) AppendRoutes(router *mux.Router) *mux.Router {
+	router.
+		Name("ListSchedules").
+		Methods("GET").
+		Path("/lists/schedules").
+		HandlerFunc(c.listSchedules)
+
+	router.
+		Name("UpdateListSchedule").
+		Methods("PUT").
+		Path("/lists/{list}/schedule").
+		HandlerFunc(c.updateSchedule)
+
+	router.
+		Name("RefreshList").
+		Methods("POST").
+		Path("/lists/{list}/refresh").
+		HandlerFunc(c.refreshList)
+
+	router.
+		Name("ApproveList").
+		Methods("POST").
+		Path("/lists/{list}/approve").
+		HandlerFunc(c.approveList)
+
+	return router
+}
+
+type listSchedulesResponse struct {
+	Lists []ListSchedule `json:"lists"`
+}
+
+func (c *adminController) listSchedules(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(listSchedulesResponse{
+		Lists: c.scheduler.Lists(),
+	})
+}
+
+func (c *adminController) updateSchedule(w http.ResponseWriter, r *http.Request) {
+	var req ListScheduleUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.writeError(w, http.StatusBadRequest, fmt.Errorf("reading schedule request: %w", err))
+		return
+	}
+
+	list := pubsearch.SourceList(mux.Vars(r)["list"])
+	schedule, err := c.scheduler.Update(list, req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrListNotSupported) {
+			status = http.StatusNotFound
+		}
+		c.writeError(w, status, fmt.Errorf("updating %s schedule: %w", list, err))
+		return
+	}
+	c.logger.Info().Logf("%s is enabled=%v with schedule %s", list, schedule.Enabled, schedule.Schedule)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(schedule)
+}
+
+func (c *adminController) refreshList(w http.ResponseWriter, r *http.Request) {
+	list := pubsearch.SourceList(mux.Vars(r)["list"])
+	result, err := c.scheduler.Refresh(r.Context(), list)
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, ErrListNotSupported):
+			status = http.StatusNotFound
+		case errors.Is(err, ErrListDisabled):
+			status = http.StatusConflict
+		}
+		c.writeError(w, status, fmt.Errorf("refreshing %s: %w", list, err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func (c *adminController) approveList(w http.ResponseWriter, r *http.Request) {
+	list := pubsearch.SourceList(mux.Vars(r)["list"])
+	result, err := c.scheduler.Approve(r.Context(), list)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, ErrListNotSupported) || errors.Is(err, ErrNoHeldRefresh) {
+			status = http.StatusNotFound
+		}
+		c.writeError(w, status, fmt.Errorf("approving %s: %w", list, err))
+		return
+	}
+	c.logger.Info().Logf("approved held refresh of %s with %d entities", list, result.Entities)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (c *adminController) writeError(w http.ResponseWriter, status int, err error) {
+	c.logger.Error().LogError(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error: err.Error(),
+	})
+}
@@ -0,0 +1,112 @@
+package download
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminController(t *testing.T) {
+	scheduler, err := NewScheduler(Config{
+		IncludedLists: []search.SourceList{search.SourceUSOFAC},
+	}, time.Hour)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	NewAdminController(log.NewTestLogger(), scheduler).AppendRoutes(router)
+
+	// enable a list with its own schedule
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("PUT", "/lists/uk_csl/schedule", strings.NewReader(`{"enabled": true, "schedule": "30m"}`))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var schedule ListSchedule
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&schedule))
+	require.Equal(t, ListSchedule{List: search.SourceUKCSL, Enabled: true, Schedule: "30m"}, schedule)
+
+	// list schedules
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/lists/schedules", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp listSchedulesResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+
+	var enabled []search.SourceList
+	for _, list := range resp.Lists {
+		if list.Enabled {
+			enabled = append(enabled, list.List)
+		}
+	}
+	require.Equal(t, []search.SourceList{search.SourceUKCSL, search.SourceUSOFAC}, enabled)
+
+	// refresh a list right away
+	go func() {
+		req := <-scheduler.Requests()
+		req.Reply(ListRefresh{List: req.List, VersionID: "v1", ListChanges: ListChanges{Entities: 2, Added: 1}}, nil)
+	}()
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/lists/us_ofac/refresh", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var refresh ListRefresh
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&refresh))
+	require.Equal(t, "v1", refresh.VersionID)
+	require.Equal(t, 2, refresh.Entities)
+	require.Equal(t, 1, refresh.Added)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/lists/eu_csl/refresh", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusConflict, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/lists/other/refresh", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	// approve a held refresh
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/lists/us_ofac/approve", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	scheduler.Hold(search.SourceUSOFAC, []search.Entity[search.Value]{{Source: search.SourceUSOFAC}}, 10, time.Now())
+	go func() {
+		req := <-scheduler.Requests()
+		require.True(t, req.Approve)
+		req.Reply(ListRefresh{List: req.List, ListChanges: ListChanges{Entities: 1, Removed: 9}}, nil)
+	}()
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/lists/us_ofac/approve", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// errors
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("PUT", "/lists/other/schedule", strings.NewReader(`{"enabled": true}`))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("PUT", "/lists/us_ofac/schedule", strings.NewReader(`{"schedule": "daily"}`))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("PUT", "/lists/us_ofac/schedule", strings.NewReader(`{`))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
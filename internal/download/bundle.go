@@ -0,0 +1,309 @@
+package download
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+)
+
+// BundleConfig loads every list from a bundle built by "watchman bundle", rather than each Watchman instance
+// downloading and parsing the lists itself
+type BundleConfig struct {
+	// URL is an http(s) URL of a bundle or a bucket (s3://bucket?prefix=watchman/, gs://bucket) or local directory
+	// holding watchman.bundle
+	URL string
+
+	// PublicKey is the base64 encoded Ed25519 key which bundles must be signed with
+	PublicKey string
+
+	// MaxSize limits how many bytes of entities are read from a bundle, which is 1GB when zero
+	MaxSize int64
+}
+
+const (
+	// BundleFileName is the bundle's name in a bucket or directory
+	BundleFileName = "watchman.bundle"
+
+	bundleMagic   = "WATCHMAN-BUNDLE"
+	bundleVersion = 1
+
+	// maxBundleHeader limits the signature and manifest lines read before a bundle is verified
+	maxBundleHeader = 1 << 20
+
+	defaultMaxBundleSize = 1 << 30
+)
+
+// BundleManifest describes the entities of a bundle. It's signed along with the SHA-256 of the entities,
+// which are gzipped JSON following the manifest.
+type BundleManifest struct {
+	Version  int            `json:"version"`
+	BuiltAt  time.Time      `json:"builtAt"`
+	Lists    map[string]int `json:"lists"`
+	Entities int            `json:"entities"`
+	SHA256   string         `json:"sha256"`
+}
+
+// Bundle holds the prepared entities of each list, which are searched as they are
+type Bundle struct {
+	Manifest BundleManifest
+	Entities []pubsearch.Entity[pubsearch.Value]
+}
+
+// WriteBundle writes a bundle of entities signed with key. A bundle is the line "WATCHMAN-BUNDLE 1", the base64
+// signature of the manifest, the manifest as one line of JSON and then the entities as gzipped JSON.
+func WriteBundle(w io.Writer, key ed25519.PrivateKey, builtAt time.Time, entities []pubsearch.Entity[pubsearch.Value]) (BundleManifest, error) {
+	var body bytes.Buffer
+	gz := gzip.NewWriter(&body)
+	if err := json.NewEncoder(gz).Encode(entities); err != nil {
+		return BundleManifest{}, fmt.Errorf("encoding bundle entities: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return BundleManifest{}, fmt.Errorf("compressing bundle entities: %w", err)
+	}
+	sum := sha256.Sum256(body.Bytes())
+
+	manifest := BundleManifest{
+		Version:  bundleVersion,
+		BuiltAt:  builtAt.In(time.UTC),
+		Lists:    make(map[string]int),
+		Entities: len(entities),
+		SHA256:   hex.EncodeToString(sum[:]),
+	}
+	for _, entity := range entities {
+		manifest.Lists[string(entity.Source)]++
+	}
+	header, err := json.Marshal(manifest)
+	if err != nil {
+		return manifest, fmt.Errorf("encoding bundle manifest: %w", err)
+	}
+	signature := ed25519.Sign(key, header)
+
+	_, err = fmt.Fprintf(w, "%s %d\n%s\n%s\n", bundleMagic, bundleVersion, base64.StdEncoding.EncodeToString(signature), header)
+	if err != nil {
+		return manifest, err
+	}
+	if _, err := w.Write(body.Bytes()); err != nil {
+		return manifest, err
+	}
+	return manifest, nil
+}
+
+// ReadBundle reads a bundle written by WriteBundle, returning an error unless its manifest was signed with key
+// and its entities are those the manifest was signed with. Bundles with more than maxSize bytes of entities
+// are rejected, or 1GB when maxSize is zero.
+func ReadBundle(r io.Reader, key ed25519.PublicKey, maxSize int64) (*Bundle, error) {
+	if maxSize <= 0 {
+		maxSize = defaultMaxBundleSize
+	}
+
+	br := bufio.NewReader(io.LimitReader(r, maxBundleHeader))
+	readLine := func() ([]byte, error) {
+		line, err := br.ReadBytes('\n')
+		if err != nil {
+			return nil, errors.New("bundle header is incomplete")
+		}
+		return bytes.TrimSuffix(line, []byte("\n")), nil
+	}
+
+	magic, err := readLine()
+	if err != nil {
+		return nil, err
+	}
+	if string(magic) != fmt.Sprintf("%s %d", bundleMagic, bundleVersion) {
+		return nil, fmt.Errorf("unknown bundle format %q", truncate(string(magic), 40))
+	}
+	encoded, err := readLine()
+	if err != nil {
+		return nil, err
+	}
+	signature, err := base64.StdEncoding.DecodeString(string(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("decoding bundle signature: %w", err)
+	}
+	header, err := readLine()
+	if err != nil {
+		return nil, err
+	}
+	if !ed25519.Verify(key, header, signature) {
+		return nil, errors.New("bundle signature doesn't match its public key")
+	}
+
+	var manifest BundleManifest
+	if err := json.Unmarshal(header, &manifest); err != nil {
+		return nil, fmt.Errorf("decoding bundle manifest: %w", err)
+	}
+
+	// The rest of the bundle is its entities, which can be larger than the header was limited to
+	body, err := io.ReadAll(io.LimitReader(io.MultiReader(br, r), maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("reading bundle entities: %w", err)
+	}
+	if int64(len(body)) > maxSize {
+		return nil, fmt.Errorf("bundle entities are larger than %d bytes", maxSize)
+	}
+	sum := sha256.Sum256(body)
+	if hex.EncodeToString(sum[:]) != manifest.SHA256 {
+		return nil, errors.New("bundle entities don't match their signed checksum")
+	}
+
+	gz, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("decompressing bundle entities: %w", err)
+	}
+	defer gz.Close()
+
+	out := &Bundle{Manifest: manifest}
+	if err := json.NewDecoder(gz).Decode(&out.Entities); err != nil {
+		return nil, fmt.Errorf("decoding bundle entities: %w", err)
+	}
+	return out, nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+// GenerateBundleKeys returns a new base64 encoded Ed25519 public key and the signing key which goes with it
+func GenerateBundleKeys() (string, string, error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(public), base64.StdEncoding.EncodeToString(private.Seed()), nil
+}
+
+// ParseBundlePublicKey reads a base64 encoded Ed25519 public key
+func ParseBundlePublicKey(value string) (ed25519.PublicKey, error) {
+	bs, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil || len(bs) != ed25519.PublicKeySize {
+		return nil, errors.New("bundle public key isn't a base64 encoded Ed25519 public key")
+	}
+	return ed25519.PublicKey(bs), nil
+}
+
+// ParseBundleSigningKey reads a base64 encoded Ed25519 private key or its seed
+func ParseBundleSigningKey(value string) (ed25519.PrivateKey, error) {
+	bs, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	switch {
+	case err != nil:
+	case len(bs) == ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(bs), nil
+	case len(bs) == ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(bs), nil
+	}
+	return nil, errors.New("bundle signing key isn't a base64 encoded Ed25519 private key")
+}
+
+// PublishBundle writes a bundle of entities to a bucket or local directory as BundleFileName
+func PublishBundle(ctx context.Context, bucketURL string, key ed25519.PrivateKey, builtAt time.Time, entities []pubsearch.Entity[pubsearch.Value]) (BundleManifest, error) {
+	var buf bytes.Buffer
+	manifest, err := WriteBundle(&buf, key, builtAt, entities)
+	if err != nil {
+		return manifest, err
+	}
+
+	bucket, err := openMirror(ctx, bucketURL)
+	if err != nil {
+		return manifest, err
+	}
+	defer bucket.Close()
+
+	if err := bucket.WriteAll(ctx, BundleFileName, buf.Bytes(), nil); err != nil {
+		return manifest, fmt.Errorf("writing %s: %w", BundleFileName, err)
+	}
+	return manifest, nil
+}
+
+// fetchBundle reads and verifies the bundle at an http(s) URL or in a bucket
+func fetchBundle(ctx context.Context, client *http.Client, conf BundleConfig, key ed25519.PublicKey) (*Bundle, error) {
+	bundleURL := conf.URL
+	if strings.HasPrefix(bundleURL, "http://") || strings.HasPrefix(bundleURL, "https://") {
+		req, err := http.NewRequestWithContext(ctx, "GET", bundleURL, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("downloading bundle: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("downloading bundle: unexpected status %s", resp.Status)
+		}
+		return ReadBundle(resp.Body, key, conf.MaxSize)
+	}
+
+	bucket, err := openMirror(ctx, bundleURL)
+	if err != nil {
+		return nil, err
+	}
+	defer bucket.Close()
+
+	r, err := bucket.NewReader(ctx, BundleFileName, nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", BundleFileName, err)
+	}
+	defer r.Close()
+
+	return ReadBundle(r, key, conf.MaxSize)
+}
+
+// refreshFromBundle loads lists from the bundle, or every list in it when lists is empty
+func (dl *downloader) refreshFromBundle(ctx context.Context, stats Stats, lists []pubsearch.SourceList) (Stats, error) {
+	bundle, err := fetchBundle(ctx, dl.clients.fallback, dl.conf.Bundle, dl.bundleKey)
+	if err != nil {
+		return stats, err
+	}
+
+	// An older bundle could be replayed to load lists which have since changed, so it's rejected
+	dl.bundleMu.Lock()
+	loaded := dl.bundleBuiltAt
+	if bundle.Manifest.BuiltAt.Before(loaded) {
+		dl.bundleMu.Unlock()
+		return stats, fmt.Errorf("bundle built at %v is older than the loaded bundle built at %v", bundle.Manifest.BuiltAt, loaded)
+	}
+	dl.bundleBuiltAt = bundle.Manifest.BuiltAt
+	dl.bundleMu.Unlock()
+
+	for _, entity := range bundle.Entities {
+		if len(lists) == 0 || slices.Contains(lists, entity.Source) {
+			stats.Entities = append(stats.Entities, entity)
+			stats.Lists[string(entity.Source)]++
+		}
+	}
+	var missing []string
+	for _, list := range lists {
+		if _, exists := stats.Lists[string(list)]; !exists {
+			stats.Failed[string(list)] = "list isn't in the bundle"
+			missing = append(missing, string(list))
+		}
+	}
+	stats.EndedAt = time.Now().In(time.UTC)
+
+	dl.logger.Info().Logf("loaded %d entities from bundle built at %v", len(stats.Entities), bundle.Manifest.BuiltAt)
+
+	if len(missing) > 0 {
+		return stats, fmt.Errorf("bundle built at %v is missing %s", bundle.Manifest.BuiltAt, strings.Join(missing, ", "))
+	}
+	return stats, nil
+}
@@ -0,0 +1,178 @@
+package download
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func bundleEntities() []pubsearch.Entity[pubsearch.Value] {
+	return []pubsearch.Entity[pubsearch.Value]{
+		{Name: "acme shipping", Type: pubsearch.EntityBusiness, Source: pubsearch.SourceUSOFAC, SourceID: "1"},
+		{Name: "john smith", Type: pubsearch.EntityPerson, Source: pubsearch.SourceUSOFAC, SourceID: "2"},
+		{Name: "jane doe", Type: pubsearch.EntityPerson, Source: pubsearch.SourceUKCSL, SourceID: "UK-1"},
+	}
+}
+
+func bundleKeys(t *testing.T) (string, string) {
+	t.Helper()
+
+	public, signing, err := GenerateBundleKeys()
+	require.NoError(t, err)
+	return public, signing
+}
+
+func TestBundle(t *testing.T) {
+	public, signing := bundleKeys(t)
+	publicKey, err := ParseBundlePublicKey(public)
+	require.NoError(t, err)
+	signingKey, err := ParseBundleSigningKey(signing)
+	require.NoError(t, err)
+
+	builtAt := time.Date(2024, time.June, 1, 6, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	manifest, err := WriteBundle(&buf, signingKey, builtAt, bundleEntities())
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"us_ofac": 2, "uk_csl": 1}, manifest.Lists)
+	require.Equal(t, 3, manifest.Entities)
+
+	bundle, err := ReadBundle(bytes.NewReader(buf.Bytes()), publicKey, 0)
+	require.NoError(t, err)
+	require.Equal(t, manifest, bundle.Manifest)
+	require.Len(t, bundle.Entities, 3)
+	require.Equal(t, "jane doe", bundle.Entities[2].Name)
+
+	t.Run("other key", func(t *testing.T) {
+		other, _ := bundleKeys(t)
+		otherKey, err := ParseBundlePublicKey(other)
+		require.NoError(t, err)
+
+		_, err = ReadBundle(bytes.NewReader(buf.Bytes()), otherKey, 0)
+		require.ErrorContains(t, err, "signature doesn't match")
+	})
+
+	t.Run("modified entities", func(t *testing.T) {
+		bs := bytes.Clone(buf.Bytes())
+		bs[len(bs)-5] ^= 0xff
+
+		_, err := ReadBundle(bytes.NewReader(bs), publicKey, 0)
+		require.ErrorContains(t, err, "don't match their signed checksum")
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		_, err := ReadBundle(bytes.NewReader(buf.Bytes()), publicKey, 16)
+		require.ErrorContains(t, err, "larger than 16 bytes")
+	})
+
+	t.Run("not a bundle", func(t *testing.T) {
+		_, err := ReadBundle(bytes.NewReader([]byte("sdn.csv\n")), publicKey, 0)
+		require.ErrorContains(t, err, "unknown bundle format")
+
+		_, err = ReadBundle(bytes.NewReader(nil), publicKey, 0)
+		require.ErrorContains(t, err, "incomplete")
+	})
+}
+
+func TestBundleKeys(t *testing.T) {
+	_, err := ParseBundlePublicKey("")
+	require.Error(t, err)
+
+	_, err = ParseBundleSigningKey("bm90IGEga2V5")
+	require.Error(t, err)
+}
+
+func TestDownloader_Bundle(t *testing.T) {
+	ctx := context.Background()
+	public, signing := bundleKeys(t)
+	signingKey, err := ParseBundleSigningKey(signing)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	_, err = PublishBundle(ctx, dir, signingKey, time.Now(), bundleEntities())
+	require.NoError(t, err)
+
+	_, err = NewDownloader(log.NewTestLogger(), Config{Bundle: BundleConfig{URL: dir}})
+	require.ErrorContains(t, err, "public key")
+
+	dl, err := NewDownloader(log.NewTestLogger(), Config{
+		Bundle: BundleConfig{URL: dir, PublicKey: public},
+	})
+	require.NoError(t, err)
+
+	stats, err := dl.RefreshLists(ctx, []pubsearch.SourceList{pubsearch.SourceUSOFAC})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"us_ofac": 2}, stats.Lists)
+	require.Len(t, stats.Entities, 2)
+
+	// Lists the bundle doesn't have fail
+	stats, err = dl.RefreshLists(ctx, []pubsearch.SourceList{pubsearch.SourceUKCSL, pubsearch.SourceEUCSL})
+	require.ErrorContains(t, err, "missing eu_csl")
+	require.Equal(t, map[string]int{"uk_csl": 1}, stats.Lists)
+	require.Contains(t, stats.Failed, "eu_csl")
+
+	t.Run("older bundle", func(t *testing.T) {
+		dir := t.TempDir()
+		builtAt := time.Date(2024, time.June, 1, 6, 0, 0, 0, time.UTC)
+		_, err := PublishBundle(ctx, dir, signingKey, builtAt, bundleEntities())
+		require.NoError(t, err)
+
+		dl, err := NewDownloader(log.NewTestLogger(), Config{
+			Bundle: BundleConfig{URL: dir, PublicKey: public},
+		})
+		require.NoError(t, err)
+
+		_, err = dl.RefreshLists(ctx, nil)
+		require.NoError(t, err)
+
+		// The same bundle is loaded again, but not one built before it
+		_, err = dl.RefreshLists(ctx, nil)
+		require.NoError(t, err)
+
+		_, err = PublishBundle(ctx, dir, signingKey, builtAt.Add(-time.Hour), bundleEntities())
+		require.NoError(t, err)
+
+		stats, err := dl.RefreshLists(ctx, nil)
+		require.ErrorContains(t, err, "older than the loaded bundle built at 2024-06-01 06:00:00 +0000 UTC")
+		require.Empty(t, stats.Entities)
+	})
+
+	t.Run("http", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := WriteBundle(&buf, signingKey, time.Now(), bundleEntities())
+		require.NoError(t, err)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/"+BundleFileName {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write(buf.Bytes())
+		}))
+		defer server.Close()
+
+		dl, err := NewDownloader(log.NewTestLogger(), Config{
+			Bundle: BundleConfig{URL: server.URL + "/" + BundleFileName, PublicKey: public},
+		})
+		require.NoError(t, err)
+
+		stats, err := dl.RefreshLists(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, map[string]int{"us_ofac": 2, "uk_csl": 1}, stats.Lists)
+
+		dl, err = NewDownloader(log.NewTestLogger(), Config{
+			Bundle: BundleConfig{URL: server.URL + "/other.bundle", PublicKey: public},
+		})
+		require.NoError(t, err)
+
+		_, err = dl.RefreshLists(ctx, nil)
+		require.ErrorContains(t, err, "unexpected status 404")
+	})
+}
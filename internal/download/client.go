@@ -0,0 +1,54 @@
+package download
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	pubdownload "github.com/moov-io/watchman/pkg/download"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+)
+
+// httpClients holds the client each list's files are downloaded with
+type httpClients struct {
+	fallback *http.Client
+	lists    map[pubsearch.SourceList]*http.Client
+}
+
+func newHTTPClients(conf HTTPConfig) (*httpClients, error) {
+	clientConf := pubdownload.ClientConfig{
+		ProxyURL: conf.ProxyURL,
+		CABundle: conf.CABundle,
+		Timeout:  conf.Timeout,
+	}
+	fallback, err := pubdownload.NewHTTPClient(clientConf)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &httpClients{
+		fallback: fallback,
+		lists:    make(map[pubsearch.SourceList]*http.Client),
+	}
+	for list, timeout := range conf.Timeouts {
+		clientConf.Timeout = timeout
+
+		client, err := pubdownload.NewHTTPClient(clientConf)
+		if err != nil {
+			return nil, fmt.Errorf("%s http client: %w", list, err)
+		}
+		out.lists[pubsearch.SourceList(list)] = client
+	}
+	return out, nil
+}
+
+// with returns a context which downloads the files of list with its client
+func (c *httpClients) with(ctx context.Context, list pubsearch.SourceList) context.Context {
+	if c == nil {
+		return ctx
+	}
+	if client, exists := c.lists[list]; exists {
+		return pubdownload.WithHTTPClient(ctx, client)
+	}
+	return pubdownload.WithHTTPClient(ctx, c.fallback)
+}
@@ -0,0 +1,27 @@
+package download
+
+import (
+	"testing"
+	"time"
+
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPClients(t *testing.T) {
+	clients, err := newHTTPClients(HTTPConfig{
+		Timeout: time.Minute,
+		Timeouts: map[string]time.Duration{
+			"us_csl": 5 * time.Minute,
+		},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, time.Minute, clients.fallback.Timeout)
+	require.Equal(t, 5*time.Minute, clients.lists[pubsearch.SourceUSCSL].Timeout)
+	require.NotContains(t, clients.lists, pubsearch.SourceUSOFAC)
+
+	_, err = newHTTPClients(HTTPConfig{ProxyURL: "::"})
+	require.ErrorContains(t, err, "parsing proxy url")
+}
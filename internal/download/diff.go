@@ -0,0 +1,123 @@
+package download
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+)
+
+// ListChanges summarizes how a list changed between two refreshes
+type ListChanges struct {
+	Entities int `json:"entities"`
+
+	Added    int `json:"added"`
+	Removed  int `json:"removed"`
+	Modified int `json:"modified"`
+}
+
+func (c ListChanges) Changed() bool {
+	return c.Added > 0 || c.Removed > 0 || c.Modified > 0
+}
+
+// Diff compares the entities of two refreshes and counts the added, removed and modified entities
+// of each list. Entities are matched by their SourceID within a list.
+func Diff(previous, current []pubsearch.Entity[pubsearch.Value]) map[pubsearch.SourceList]ListChanges {
+	return CountChanges(DiffEntities(previous, current), current)
+}
+
+// DiffEntities compares the entities of two refreshes and returns the added, removed and modified entities.
+// Entities are matched by their SourceID within a list.
+func DiffEntities(previous, current []pubsearch.Entity[pubsearch.Value]) search.EntityChanges {
+	prev := fingerprintEntities(previous)
+
+	var out search.EntityChanges
+	seen := make(map[pubsearch.SourceList]map[string]bool)
+	for _, entity := range current {
+		if seen[entity.Source] == nil {
+			seen[entity.Source] = make(map[string]bool)
+		}
+		seen[entity.Source][entity.SourceID] = true
+
+		existing, found := prev[entity.Source][entity.SourceID]
+		switch {
+		case !found:
+			out.Added = append(out.Added, entity)
+		case existing != fingerprintEntity(entity):
+			out.Modified = append(out.Modified, entity)
+		}
+	}
+	for _, entity := range previous {
+		if !seen[entity.Source][entity.SourceID] {
+			out.Removed = append(out.Removed, entity)
+		}
+	}
+	return out
+}
+
+// CountChanges totals the changes of each list, along with how many entities each list has in current.
+func CountChanges(changes search.EntityChanges, current []pubsearch.Entity[pubsearch.Value]) map[pubsearch.SourceList]ListChanges {
+	out := make(map[pubsearch.SourceList]ListChanges)
+	count := func(entities []pubsearch.Entity[pubsearch.Value], fn func(*ListChanges)) {
+		for _, entity := range entities {
+			c := out[entity.Source]
+			fn(&c)
+			out[entity.Source] = c
+		}
+	}
+	count(current, func(c *ListChanges) { c.Entities++ })
+	count(changes.Added, func(c *ListChanges) { c.Added++ })
+	count(changes.Modified, func(c *ListChanges) { c.Modified++ })
+	count(changes.Removed, func(c *ListChanges) { c.Removed++ })
+	return out
+}
+
+type fingerprint [sha256.Size]byte
+
+// Fingerprint returns a hex encoded SHA-256 of entity, which changes whenever any of the entity's fields change.
+func Fingerprint(entity pubsearch.Entity[pubsearch.Value]) string {
+	fp := fingerprintEntity(entity)
+	return hex.EncodeToString(fp[:])
+}
+
+func fingerprintEntities(entities []pubsearch.Entity[pubsearch.Value]) map[pubsearch.SourceList]map[string]fingerprint {
+	out := make(map[pubsearch.SourceList]map[string]fingerprint)
+	for _, entity := range entities {
+		list, exists := out[entity.Source]
+		if !exists {
+			list = make(map[string]fingerprint)
+			out[entity.Source] = list
+		}
+		list[entity.SourceID] = fingerprintEntity(entity)
+	}
+	return out
+}
+
+func fingerprintEntity(entity pubsearch.Entity[pubsearch.Value]) fingerprint {
+	h := sha256.New()
+
+	// Entities read from a snapshot hold their SourceData as a map rather than the list's struct,
+	// so SourceData is fingerprinted as JSON with its object keys sorted.
+	sourceData := entity.SourceData
+	entity.SourceData = nil
+
+	bs, _ := json.Marshal(entity)
+	h.Write(bs)
+
+	if sourceData != nil {
+		bs, _ = json.Marshal(sourceData)
+		if _, isMap := sourceData.(map[string]interface{}); !isMap {
+			var canonical interface{}
+			if err := json.Unmarshal(bs, &canonical); err == nil {
+				bs, _ = json.Marshal(canonical)
+			}
+		}
+		h.Write(bs)
+	}
+
+	var out fingerprint
+	h.Sum(out[:0])
+	return out
+}
@@ -0,0 +1,67 @@
+package download
+
+import (
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	entity := func(source search.SourceList, id, name string) search.Entity[search.Value] {
+		return search.Entity[search.Value]{
+			Name:     name,
+			Source:   source,
+			SourceID: id,
+		}
+	}
+
+	previous := []search.Entity[search.Value]{
+		entity(search.SourceUSOFAC, "1", "John Doe"),
+		entity(search.SourceUSOFAC, "2", "Jane Doe"),
+		entity(search.SourceUSOFAC, "3", "Acme Corp"),
+		entity(search.SourceEUCSL, "10", "Hans Müller"),
+	}
+	current := []search.Entity[search.Value]{
+		entity(search.SourceUSOFAC, "1", "John Doe"),
+		entity(search.SourceUSOFAC, "2", "Jane Smith"),
+		entity(search.SourceUSOFAC, "4", "Widgets LLC"),
+		entity(search.SourceUSOFAC, "5", "Gadgets LLC"),
+		entity(search.SourceUKCSL, "20", "John Smith"),
+	}
+
+	changes := Diff(previous, current)
+	require.Len(t, changes, 3)
+
+	require.Equal(t, ListChanges{Entities: 4, Added: 2, Removed: 1, Modified: 1}, changes[search.SourceUSOFAC])
+	require.Equal(t, ListChanges{Entities: 0, Removed: 1}, changes[search.SourceEUCSL])
+	require.Equal(t, ListChanges{Entities: 1, Added: 1}, changes[search.SourceUKCSL])
+
+	t.Run("unchanged", func(t *testing.T) {
+		changes := Diff(current, current)
+		for _, c := range changes {
+			require.False(t, c.Changed())
+		}
+	})
+}
+
+func TestDiffEntities(t *testing.T) {
+	entity := func(id, name string) search.Entity[search.Value] {
+		return search.Entity[search.Value]{
+			Name:     name,
+			Source:   search.SourceUSOFAC,
+			SourceID: id,
+		}
+	}
+
+	previous := []search.Entity[search.Value]{entity("1", "John Doe"), entity("2", "Jane Doe"), entity("3", "Acme Corp")}
+	current := []search.Entity[search.Value]{entity("1", "John Doe"), entity("2", "Jane Smith"), entity("4", "Widgets LLC")}
+
+	changes := DiffEntities(previous, current)
+	require.Equal(t, []search.Entity[search.Value]{entity("4", "Widgets LLC")}, changes.Added)
+	require.Equal(t, []search.Entity[search.Value]{entity("2", "Jane Smith")}, changes.Modified)
+	require.Equal(t, []search.Entity[search.Value]{entity("3", "Acme Corp")}, changes.Removed)
+
+	require.True(t, DiffEntities(current, current).Empty())
+}
@@ -2,47 +2,210 @@ package download
 
 import (
 	"context"
+	"crypto/ed25519"
+	"errors"
 	"fmt"
 	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/internal/search"
+	"github.com/moov-io/watchman/internal/tracing"
+	"github.com/moov-io/watchman/pkg/csl_au"
+	"github.com/moov-io/watchman/pkg/csl_ca"
+	"github.com/moov-io/watchman/pkg/csl_ch"
+	"github.com/moov-io/watchman/pkg/csl_eu"
+	"github.com/moov-io/watchman/pkg/csl_uk"
+	"github.com/moov-io/watchman/pkg/csl_un"
 	"github.com/moov-io/watchman/pkg/csl_us"
+	pubdownload "github.com/moov-io/watchman/pkg/download"
+	"github.com/moov-io/watchman/pkg/fincen"
+	"github.com/moov-io/watchman/pkg/interpol"
 	"github.com/moov-io/watchman/pkg/ofac"
-	"github.com/moov-io/watchman/pkg/search"
+	"github.com/moov-io/watchman/pkg/opensanctions"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
 
 	"github.com/moov-io/base/log"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/sync/errgroup"
 )
 
 type Downloader interface {
 	RefreshAll(ctx context.Context) (Stats, error)
+
+	// RefreshLists downloads only the given lists, such as those due for a refresh. Lists which failed
+	// are missing from the returned Stats, which still hold every list that loaded along with the error
+	// of each failed list.
+	RefreshLists(ctx context.Context, lists []pubsearch.SourceList) (Stats, error)
+
+	// UpdatePipelines replaces the prepare pipeline of each list, returning the lists whose stages changed.
+	// Refreshes already underway finish with the previous pipelines, and nothing is replaced when any is invalid.
+	UpdatePipelines(confs map[string]prepare.PipelineConfig) ([]pubsearch.SourceList, error)
 }
 
 func NewDownloader(logger log.Logger, conf Config) (Downloader, error) {
-	return &downloader{
-		logger: logger,
-		conf:   conf,
-	}, nil
+	pipelines, err := newPipelines(conf.Prepare)
+	if err != nil {
+		return nil, err
+	}
+
+	clients, err := newHTTPClients(conf.HTTP)
+	if err != nil {
+		return nil, fmt.Errorf("download http client: %w", err)
+	}
+
+	dl := &downloader{
+		logger:  logger,
+		conf:    conf,
+		clients: clients,
+		cache:   newListCache(),
+		loaders: listLoaders,
+	}
+	if conf.Bundle.URL != "" {
+		dl.bundleKey, err = ParseBundlePublicKey(conf.Bundle.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	dl.pipelines.Store(&pipelines)
+	return dl, nil
 }
 
 type downloader struct {
 	logger log.Logger
 	conf   Config
+
+	clients   *httpClients
+	pipelines atomic.Pointer[listPipelines]
+
+	// cache holds the entities of each list from its last load
+	cache *listCache
+
+	// loaders download each kind of list, which tests replace
+	loaders []listLoader
+
+	// bundleKey verifies the bundle lists are loaded from, when there is one
+	bundleKey ed25519.PublicKey
+
+	// bundleBuiltAt is when the last bundle loaded was built, as older bundles aren't loaded
+	bundleMu      sync.Mutex
+	bundleBuiltAt time.Time
+}
+
+type listPipelines struct {
+	confs     map[string]prepare.PipelineConfig
+	pipelines map[pubsearch.SourceList]*prepare.Pipeline
+}
+
+func newPipelines(confs map[string]prepare.PipelineConfig) (listPipelines, error) {
+	out := listPipelines{
+		confs:     confs,
+		pipelines: make(map[pubsearch.SourceList]*prepare.Pipeline),
+	}
+	for list, pipelineConf := range confs {
+		pipeline, err := prepare.NewPipeline(pipelineConf)
+		if err != nil {
+			return out, fmt.Errorf("%s prepare pipeline: %w", list, err)
+		}
+		out.pipelines[pubsearch.SourceList(list)] = pipeline
+	}
+	return out, nil
+}
+
+func (dl *downloader) UpdatePipelines(confs map[string]prepare.PipelineConfig) ([]pubsearch.SourceList, error) {
+	next, err := newPipelines(confs)
+	if err != nil {
+		return nil, err
+	}
+	previous := dl.pipelines.Load()
+
+	var changed []pubsearch.SourceList
+	for _, name := range unionKeys(previous.confs, next.confs) {
+		before, after := previous.confs[name], next.confs[name]
+		if slices.Equal(before.Individual, after.Individual) && slices.Equal(before.Entity, after.Entity) {
+			// Unchanged pipelines are kept, so entities prepared by them are still reused
+			if pipeline, exists := previous.pipelines[pubsearch.SourceList(name)]; exists {
+				next.pipelines[pubsearch.SourceList(name)] = pipeline
+			}
+			continue
+		}
+		changed = append(changed, pubsearch.SourceList(name))
+	}
+	dl.pipelines.Store(&next)
+
+	// Entities prepared by the previous stages are parsed and prepared again on their next refresh
+	for _, list := range changed {
+		dl.cache.save(list, "", nil)
+	}
+	return changed, nil
+}
+
+func unionKeys(a, b map[string]prepare.PipelineConfig) []string {
+	var out []string
+	for name := range a {
+		out = append(out, name)
+	}
+	for name := range b {
+		if _, exists := a[name]; !exists {
+			out = append(out, name)
+		}
+	}
+	slices.Sort(out)
+	return out
 }
 
 func (dl *downloader) RefreshAll(ctx context.Context) (Stats, error) {
+	return dl.RefreshLists(ctx, dl.conf.IncludedLists)
+}
+
+func (dl *downloader) RefreshLists(ctx context.Context, lists []pubsearch.SourceList) (Stats, error) {
 	stats := Stats{
-		Lists:     make(map[string]int),
-		StartedAt: time.Now().In(time.UTC),
+		Lists:      make(map[string]int),
+		Failed:     make(map[string]string),
+		Duplicates: make(map[string]int),
+		StartedAt:  time.Now().In(time.UTC),
+	}
+	if dl.conf.Bundle.URL != "" {
+		return dl.refreshFromBundle(ctx, stats, lists)
 	}
+	conf := dl.conf
+	conf.IncludedLists = lists
+	if conf.MirrorURL != "" {
+		dir, cleanup, err := fetchMirror(ctx, dl.logger, conf.MirrorURL)
+		if err != nil {
+			return stats, err
+		}
+		defer cleanup()
+
+		conf.InitialDataDirectory = dir
+	}
+	if conf.Offline {
+		ctx = pubdownload.WithOffline(ctx)
+	}
+	if conf.CacheDirectory != "" {
+		ctx = pubdownload.WithCache(ctx, conf.CacheDirectory)
+	}
+	if len(conf.Checksums) > 0 {
+		ctx = pubdownload.WithChecksums(ctx, conf.Checksums)
+	}
+	ctx = withListCache(ctx, dl.cache)
+	pipelines := dl.pipelines.Load()
+
+	ctx, span := tracing.Start(ctx, "download.refresh", attribute.Int("lists", len(lists)))
+	defer span.End()
+
 	logger := dl.logger.Info().With(log.Fields{
-		"initial_data_directory": log.String(dl.conf.InitialDataDirectory),
+		"initial_data_directory": log.String(conf.InitialDataDirectory),
 	})
 	start := time.Now()
 	logger.Info().Log("starting list refresh")
 
-	g, ctx := errgroup.WithContext(ctx)
+	// A list which fails to load doesn't stop the others, so callers can keep serving whatever was loaded
+	var g errgroup.Group
+	g.SetLimit(conf.concurrency())
 	preparedLists := make(chan preparedList, 10)
 
 	// Start a goroutine to accumulate results
@@ -50,80 +213,176 @@ func (dl *downloader) RefreshAll(ctx context.Context) (Stats, error) {
 	go func() {
 		defer close(resultsDone)
 		for list := range preparedLists {
-			logger.Info().Logf("adding %d entities from %v", len(list.Entities), list.ListName)
+			if list.Unchanged {
+				cached, _ := dl.cache.lookup(list.ListName)
+				list.Entities = cached.entities
+				stats.Unchanged = append(stats.Unchanged, string(list.ListName))
+
+				logger.Info().Logf("reusing %d entities from %v whose files are unchanged", len(list.Entities), list.ListName)
+			} else {
+				logger.Info().Logf("adding %d entities from %v", len(list.Entities), list.ListName)
+
+				// Lists without a pipeline still have their countries normalized
+				pipeline := pipelines.pipelines[list.ListName]
+				search.PrepareEntities(ctx, pipeline, list.Entities)
+
+				// Entities prepared by a pipeline which was replaced during the refresh aren't reused
+				hash := list.Hash
+				if dl.pipelines.Load().pipelines[list.ListName] != pipeline {
+					hash = ""
+				}
+				dl.cache.save(list.ListName, hash, list.Entities)
+			}
 
 			stats.Lists[string(list.ListName)] = len(list.Entities)
+			if list.Duplicates > 0 {
+				stats.Duplicates[string(list.ListName)] = list.Duplicates
+			}
 			stats.Entities = append(stats.Entities, list.Entities...)
 		}
 	}()
 
-	// Create a WaitGroup to track all producers
-	var producerWg sync.WaitGroup
-
-	// OFAC Records
-	if slices.Contains(dl.conf.IncludedLists, search.SourceUSOFAC) {
-		producerWg.Add(1)
+	var mu sync.Mutex
+	var failures []error
+	for _, loader := range dl.loaders {
+		if !slices.Contains(conf.IncludedLists, loader.list) {
+			continue
+		}
+		loader := loader
 		g.Go(func() error {
-			defer producerWg.Done()
-			err := loadOFACRecords(ctx, logger, dl.conf, preparedLists)
+			err := dl.loadList(ctx, logger, conf, loader, preparedLists)
 			if err != nil {
-				return fmt.Errorf("loading OFAC records: %w", err)
-			}
-			return nil
-		})
-	}
+				mu.Lock()
+				defer mu.Unlock()
 
-	// CSL Records
-	if slices.Contains(dl.conf.IncludedLists, search.SourceUSCSL) {
-		producerWg.Add(1)
-		g.Go(func() error {
-			defer producerWg.Done()
-			err := loadCSLUSRecords(ctx, logger, dl.conf, preparedLists)
-			if err != nil {
-				return fmt.Errorf("loading US CSL records: %w", err)
+				stats.Failed[string(loader.list)] = err.Error()
+				failures = append(failures, err)
 			}
 			return nil
 		})
 	}
 
-	// Add a goroutine to close the channel when all producers are done
-	g.Go(func() error {
-		producerWg.Wait()
-		close(preparedLists)
-		return nil
-	})
-
-	// Wait for both producers and consumer to finish
-	err := g.Wait()
+	// Wait for the producers, then for the consumer to finish
+	g.Wait()
+	close(preparedLists)
 	<-resultsDone
 
+	err := errors.Join(failures...)
+	tracing.RecordError(span, err)
+	span.SetAttributes(attribute.Int("entities", len(stats.Entities)))
+
+	stats.EndedAt = time.Now().In(time.UTC)
+	RecordRefresh(stats.Lists, stats.EndedAt)
+
 	if err != nil {
-		return stats, fmt.Errorf("problem loading lists: %v", err)
+		return stats, fmt.Errorf("problem loading lists: %w", err)
 	}
-
 	logger.Info().Logf("finished all lists: %v", time.Since(start))
-	stats.EndedAt = time.Now().In(time.UTC)
 	return stats, nil
 }
 
+// listLoader downloads and parses the files of a list, sending its entities to responseCh
+type listLoader struct {
+	list pubsearch.SourceList
+	name string // of the list in errors, such as "US CSL"
+	load func(ctx context.Context, logger log.Logger, conf Config, responseCh chan preparedList) error
+}
+
+var listLoaders = []listLoader{
+	{list: pubsearch.SourceUSOFAC, name: "OFAC", load: loadOFACRecords},
+	{list: pubsearch.SourceUSCSL, name: "US CSL", load: loadCSLUSRecords},
+	{list: pubsearch.SourceUSBIS, name: "BIS", load: loadBISRecords},
+	{list: pubsearch.SourceUKCSL, name: "UK CSL", load: loadCSLUKRecords},
+	{list: pubsearch.SourceUNCSL, name: "UN CSL", load: loadCSLUNRecords},
+	{list: pubsearch.SourceEUCSL, name: "EU CSL", load: loadCSLEURecords},
+	{list: pubsearch.SourceAUCSL, name: "AU CSL", load: loadCSLAURecords},
+	{list: pubsearch.SourceCACSL, name: "CA CSL", load: loadCSLCARecords},
+	{list: pubsearch.SourceCHCSL, name: "CH CSL", load: loadCSLCHRecords},
+	{list: pubsearch.SourceUSFinCEN311, name: "FinCEN 311", load: loadFinCEN311Records},
+	{list: pubsearch.SourceOpenSanctions, name: "OpenSanctions", load: loadOpenSanctionsRecords},
+	{list: pubsearch.SourceInterpolRed, name: "Interpol Red Notices", load: loadInterpolRedRecords},
+}
+
+// loadList runs a list's loader, giving up on it once the list's timeout passes. A loader which is given up on
+// can't be interrupted while it parses, so it finishes in the background and its entities are discarded.
+func (dl *downloader) loadList(ctx context.Context, logger log.Logger, conf Config, loader listLoader, responseCh chan preparedList) error {
+	ctx, span := tracing.Start(ctx, "download.list", attribute.String("list", string(loader.list)))
+	defer span.End()
+
+	timeout := conf.listTimeout(loader.list)
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	loaded := make(chan preparedList, 1) // each loader sends its list once
+	done := make(chan error, 1)
+	go func() {
+		done <- loader.load(dl.clients.with(ctx, loader.list), logger, conf, loaded)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+		select {
+		case list := <-loaded:
+			responseCh <- list
+		default:
+		}
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		err = fmt.Errorf("timed out after %v: %w", timeout, err)
+	}
+	recordDownload(string(loader.list), start, err)
+	tracing.RecordError(span, err)
+	if err != nil {
+		return fmt.Errorf("loading %s records: %w", loader.name, err)
+	}
+	return nil
+}
+
 type preparedList struct {
-	ListName search.SourceList
-	Entities []search.Entity[search.Value]
+	ListName pubsearch.SourceList
+	Entities []pubsearch.Entity[pubsearch.Value]
+
+	// Hash of the files Entities were read from, which is empty when they couldn't be hashed
+	Hash string
+
+	// Unchanged lists have the same files as when they were last loaded, so their cached entities are used
+	Unchanged bool
+
+	// Duplicates is how many repeated rows were dropped while the list's files were merged
+	Duplicates int
 }
 
 func loadOFACRecords(ctx context.Context, logger log.Logger, conf Config, responseCh chan preparedList) error {
+	if strings.EqualFold(conf.OFACFormat, OFACFormatAdvanced) {
+		return loadOFACAdvancedRecords(ctx, logger, conf, responseCh)
+	}
+
 	start := time.Now()
 	files, err := ofac.Download(ctx, logger, conf.InitialDataDirectory)
 	if err != nil {
-		return fmt.Errorf("OFAC download: %v", err)
+		return fmt.Errorf("OFAC download: %w", err)
 	}
 	if len(files) == 0 {
 		return fmt.Errorf("unexpected %d OFAC files found", len(files))
 	}
+	hash, skip := skipUnchanged(ctx, pubsearch.SourceUSOFAC, files, responseCh)
+	if skip {
+		return nil
+	}
 
 	logger.Debug().Logf("finished OFAC download: %v", time.Since(start))
 	start = time.Now()
 
+	_, span := tracing.Start(ctx, "download.parse", attribute.String("list", string(pubsearch.SourceUSOFAC)))
+	defer span.End()
+
 	res, err := ofac.Read(files)
 	if err != nil {
 		return fmt.Errorf("parsing OFAC: %w", err)
@@ -131,10 +390,50 @@ func loadOFACRecords(ctx context.Context, logger log.Logger, conf Config, respon
 
 	entities := ofac.GroupIntoEntities(res.SDNs, res.Addresses, res.SDNComments, res.AlternateIdentities)
 	logger.Debug().Logf("finished OFAC preperation: %v", time.Since(start))
+	logger.Info().Logf("dropped %d duplicate OFAC alternate names and %d duplicate addresses",
+		res.Duplicates.AlternateIdentities, res.Duplicates.Addresses)
 
 	responseCh <- preparedList{
-		ListName: search.SourceUSOFAC,
+		ListName:   pubsearch.SourceUSOFAC,
+		Entities:   entities,
+		Hash:       hash,
+		Duplicates: res.Duplicates.Total(),
+	}
+	return nil
+}
+
+func loadOFACAdvancedRecords(ctx context.Context, logger log.Logger, conf Config, responseCh chan preparedList) error {
+	start := time.Now()
+	files, err := ofac.DownloadAdvanced(ctx, logger, conf.InitialDataDirectory)
+	if err != nil {
+		return fmt.Errorf("OFAC advanced download: %w", err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("unexpected %d OFAC advanced files found", len(files))
+	}
+	hash, skip := skipUnchanged(ctx, pubsearch.SourceUSOFAC, files, responseCh)
+	if skip {
+		return nil
+	}
+
+	logger.Debug().Logf("finished OFAC advanced download: %v", time.Since(start))
+	start = time.Now()
+
+	_, span := tracing.Start(ctx, "download.parse", attribute.String("list", string(pubsearch.SourceUSOFAC)))
+	defer span.End()
+
+	doc, err := ofac.ReadAdvanced(files)
+	if err != nil {
+		return fmt.Errorf("parsing OFAC advanced: %w", err)
+	}
+
+	entities := ofac.ConvertAdvanced(doc)
+	logger.Debug().Logf("finished OFAC advanced preperation: %v", time.Since(start))
+
+	responseCh <- preparedList{
+		ListName: pubsearch.SourceUSOFAC,
 		Entities: entities,
+		Hash:     hash,
 	}
 	return nil
 }
@@ -148,10 +447,17 @@ func loadCSLUSRecords(ctx context.Context, logger log.Logger, conf Config, respo
 	if len(files) == 0 {
 		return fmt.Errorf("unexpected %d US CSL files found", len(files))
 	}
+	hash, skip := skipUnchanged(ctx, pubsearch.SourceUSCSL, files, responseCh)
+	if skip {
+		return nil
+	}
 
 	logger.Debug().Logf("finished US CSL download: %v", time.Since(start))
 	start = time.Now()
 
+	_, span := tracing.Start(ctx, "download.parse", attribute.String("list", string(pubsearch.SourceUSCSL)))
+	defer span.End()
+
 	res, err := csl_us.Read(files)
 	if err != nil {
 		return fmt.Errorf("parsing US CSL: %w", err)
@@ -161,8 +467,387 @@ func loadCSLUSRecords(ctx context.Context, logger log.Logger, conf Config, respo
 	logger.Debug().Logf("finished US CSL preperation: %v", time.Since(start))
 
 	responseCh <- preparedList{
-		ListName: search.SourceUSCSL,
+		ListName: pubsearch.SourceUSCSL,
+		Entities: entities,
+		Hash:     hash,
+	}
+
+	return nil
+}
+
+func loadBISRecords(ctx context.Context, logger log.Logger, conf Config, responseCh chan preparedList) error {
+	start := time.Now()
+	files, err := csl_us.DownloadBIS(ctx, logger, conf.InitialDataDirectory)
+	if err != nil {
+		return fmt.Errorf("BIS download: %w", err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("unexpected %d BIS files found", len(files))
+	}
+	hash, skip := skipUnchanged(ctx, pubsearch.SourceUSBIS, files, responseCh)
+	if skip {
+		return nil
+	}
+
+	logger.Debug().Logf("finished BIS download: %v", time.Since(start))
+	start = time.Now()
+
+	_, span := tracing.Start(ctx, "download.parse", attribute.String("list", string(pubsearch.SourceUSBIS)))
+	defer span.End()
+
+	records, err := csl_us.ReadBIS(files)
+	if err != nil {
+		return fmt.Errorf("parsing BIS: %w", err)
+	}
+
+	entities := csl_us.ConvertBIS(records)
+	logger.Debug().Logf("finished BIS preperation: %v", time.Since(start))
+
+	responseCh <- preparedList{
+		ListName: pubsearch.SourceUSBIS,
+		Entities: entities,
+		Hash:     hash,
+	}
+
+	return nil
+}
+
+func loadCSLUKRecords(ctx context.Context, logger log.Logger, conf Config, responseCh chan preparedList) error {
+	start := time.Now()
+	files, err := csl_uk.DownloadCSL(ctx, logger, conf.InitialDataDirectory)
+	if err != nil {
+		return fmt.Errorf("UK CSL download: %w", err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("unexpected %d UK CSL files found", len(files))
+	}
+	hash, skip := skipUnchanged(ctx, pubsearch.SourceUKCSL, files, responseCh)
+	if skip {
+		return nil
+	}
+
+	logger.Debug().Logf("finished UK CSL download: %v", time.Since(start))
+	start = time.Now()
+
+	_, span := tracing.Start(ctx, "download.parse", attribute.String("list", string(pubsearch.SourceUKCSL)))
+	defer span.End()
+
+	var records []csl_uk.CSLRecord
+	for _, fd := range files {
+		rows, _, err := csl_uk.ReadCSLFile(fd)
+		if err != nil {
+			return fmt.Errorf("parsing UK CSL: %w", err)
+		}
+		records = append(records, rows...)
+	}
+
+	entities := csl_uk.ConvertSanctionsData(records)
+	logger.Debug().Logf("finished UK CSL preperation: %v", time.Since(start))
+
+	responseCh <- preparedList{
+		ListName: pubsearch.SourceUKCSL,
+		Entities: entities,
+		Hash:     hash,
+	}
+
+	return nil
+}
+
+func loadCSLUNRecords(ctx context.Context, logger log.Logger, conf Config, responseCh chan preparedList) error {
+	start := time.Now()
+	files, err := csl_un.Download(ctx, logger, conf.InitialDataDirectory)
+	if err != nil {
+		return fmt.Errorf("UN CSL download: %w", err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("unexpected %d UN CSL files found", len(files))
+	}
+	hash, skip := skipUnchanged(ctx, pubsearch.SourceUNCSL, files, responseCh)
+	if skip {
+		return nil
+	}
+
+	logger.Debug().Logf("finished UN CSL download: %v", time.Since(start))
+	start = time.Now()
+
+	_, span := tracing.Start(ctx, "download.parse", attribute.String("list", string(pubsearch.SourceUNCSL)))
+	defer span.End()
+
+	res, err := csl_un.Read(files)
+	if err != nil {
+		return fmt.Errorf("parsing UN CSL: %w", err)
+	}
+
+	entities := csl_un.ConvertSanctionsData(res)
+	logger.Debug().Logf("finished UN CSL preperation: %v", time.Since(start))
+
+	responseCh <- preparedList{
+		ListName: pubsearch.SourceUNCSL,
+		Entities: entities,
+		Hash:     hash,
+	}
+
+	return nil
+}
+
+func loadCSLAURecords(ctx context.Context, logger log.Logger, conf Config, responseCh chan preparedList) error {
+	start := time.Now()
+	files, err := csl_au.Download(ctx, logger, conf.InitialDataDirectory)
+	if err != nil {
+		return fmt.Errorf("AU CSL download: %w", err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("unexpected %d AU CSL files found", len(files))
+	}
+	hash, skip := skipUnchanged(ctx, pubsearch.SourceAUCSL, files, responseCh)
+	if skip {
+		return nil
+	}
+
+	logger.Debug().Logf("finished AU CSL download: %v", time.Since(start))
+	start = time.Now()
+
+	_, span := tracing.Start(ctx, "download.parse", attribute.String("list", string(pubsearch.SourceAUCSL)))
+	defer span.End()
+
+	res, err := csl_au.Read(files)
+	if err != nil {
+		return fmt.Errorf("parsing AU CSL: %w", err)
+	}
+
+	entities := csl_au.ConvertSanctionsData(res)
+	logger.Debug().Logf("finished AU CSL preperation: %v", time.Since(start))
+
+	responseCh <- preparedList{
+		ListName: pubsearch.SourceAUCSL,
+		Entities: entities,
+		Hash:     hash,
+	}
+
+	return nil
+}
+
+func loadCSLCARecords(ctx context.Context, logger log.Logger, conf Config, responseCh chan preparedList) error {
+	start := time.Now()
+	files, err := csl_ca.Download(ctx, logger, conf.InitialDataDirectory)
+	if err != nil {
+		return fmt.Errorf("CA CSL download: %w", err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("unexpected %d CA CSL files found", len(files))
+	}
+	hash, skip := skipUnchanged(ctx, pubsearch.SourceCACSL, files, responseCh)
+	if skip {
+		return nil
+	}
+
+	logger.Debug().Logf("finished CA CSL download: %v", time.Since(start))
+	start = time.Now()
+
+	_, span := tracing.Start(ctx, "download.parse", attribute.String("list", string(pubsearch.SourceCACSL)))
+	defer span.End()
+
+	res, err := csl_ca.Read(files)
+	if err != nil {
+		return fmt.Errorf("parsing CA CSL: %w", err)
+	}
+
+	entities := csl_ca.ConvertSanctionsData(res)
+	logger.Debug().Logf("finished CA CSL preperation: %v", time.Since(start))
+
+	responseCh <- preparedList{
+		ListName: pubsearch.SourceCACSL,
+		Entities: entities,
+		Hash:     hash,
+	}
+
+	return nil
+}
+
+func loadCSLCHRecords(ctx context.Context, logger log.Logger, conf Config, responseCh chan preparedList) error {
+	start := time.Now()
+	files, err := csl_ch.Download(ctx, logger, conf.InitialDataDirectory)
+	if err != nil {
+		return fmt.Errorf("CH CSL download: %w", err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("unexpected %d CH CSL files found", len(files))
+	}
+	hash, skip := skipUnchanged(ctx, pubsearch.SourceCHCSL, files, responseCh)
+	if skip {
+		return nil
+	}
+
+	logger.Debug().Logf("finished CH CSL download: %v", time.Since(start))
+	start = time.Now()
+
+	_, span := tracing.Start(ctx, "download.parse", attribute.String("list", string(pubsearch.SourceCHCSL)))
+	defer span.End()
+
+	res, err := csl_ch.Read(files)
+	if err != nil {
+		return fmt.Errorf("parsing CH CSL: %w", err)
+	}
+
+	entities := csl_ch.ConvertSanctionsData(res)
+	logger.Debug().Logf("finished CH CSL preperation: %v", time.Since(start))
+
+	responseCh <- preparedList{
+		ListName: pubsearch.SourceCHCSL,
+		Entities: entities,
+		Hash:     hash,
+	}
+
+	return nil
+}
+
+func loadFinCEN311Records(ctx context.Context, logger log.Logger, conf Config, responseCh chan preparedList) error {
+	start := time.Now()
+	files, err := fincen.Download(ctx, logger, conf.InitialDataDirectory)
+	if err != nil {
+		return fmt.Errorf("FinCEN 311 download: %w", err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("unexpected %d FinCEN 311 files found", len(files))
+	}
+	hash, skip := skipUnchanged(ctx, pubsearch.SourceUSFinCEN311, files, responseCh)
+	if skip {
+		return nil
+	}
+
+	logger.Debug().Logf("finished FinCEN 311 download: %v", time.Since(start))
+	start = time.Now()
+
+	_, span := tracing.Start(ctx, "download.parse", attribute.String("list", string(pubsearch.SourceUSFinCEN311)))
+	defer span.End()
+
+	res, err := fincen.Read(files)
+	if err != nil {
+		return fmt.Errorf("parsing FinCEN 311: %w", err)
+	}
+
+	entities := fincen.ConvertSpecialMeasures(res)
+	logger.Debug().Logf("finished FinCEN 311 preperation: %v", time.Since(start))
+
+	responseCh <- preparedList{
+		ListName: pubsearch.SourceUSFinCEN311,
+		Entities: entities,
+		Hash:     hash,
+	}
+
+	return nil
+}
+
+func loadCSLEURecords(ctx context.Context, logger log.Logger, conf Config, responseCh chan preparedList) error {
+	start := time.Now()
+	files, err := csl_eu.DownloadEU(ctx, logger, conf.InitialDataDirectory)
+	if err != nil {
+		return fmt.Errorf("EU CSL download: %w", err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("unexpected %d EU CSL files found", len(files))
+	}
+	hash, skip := skipUnchanged(ctx, pubsearch.SourceEUCSL, files, responseCh)
+	if skip {
+		return nil
+	}
+
+	logger.Debug().Logf("finished EU CSL download: %v", time.Since(start))
+	start = time.Now()
+
+	_, span := tracing.Start(ctx, "download.parse", attribute.String("list", string(pubsearch.SourceEUCSL)))
+	defer span.End()
+
+	var records []csl_eu.CSLRecord
+	for _, fd := range files {
+		rows, _, err := csl_eu.ParseEU(fd)
+		if err != nil {
+			return fmt.Errorf("parsing EU CSL: %w", err)
+		}
+		records = append(records, rows...)
+	}
+
+	entities := csl_eu.ConvertSanctionsData(records)
+	logger.Debug().Logf("finished EU CSL preperation: %v", time.Since(start))
+
+	responseCh <- preparedList{
+		ListName: pubsearch.SourceEUCSL,
+		Entities: entities,
+		Hash:     hash,
+	}
+
+	return nil
+}
+
+func loadOpenSanctionsRecords(ctx context.Context, logger log.Logger, conf Config, responseCh chan preparedList) error {
+	start := time.Now()
+	files, err := opensanctions.Download(ctx, logger, conf.InitialDataDirectory)
+	if err != nil {
+		return fmt.Errorf("OpenSanctions download: %w", err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("unexpected %d OpenSanctions files found", len(files))
+	}
+	hash, skip := skipUnchanged(ctx, pubsearch.SourceOpenSanctions, files, responseCh)
+	if skip {
+		return nil
+	}
+
+	logger.Debug().Logf("finished OpenSanctions download: %v", time.Since(start))
+	start = time.Now()
+
+	_, span := tracing.Start(ctx, "download.parse", attribute.String("list", string(pubsearch.SourceOpenSanctions)))
+	defer span.End()
+
+	res, err := opensanctions.Read(files)
+	if err != nil {
+		return fmt.Errorf("parsing OpenSanctions: %w", err)
+	}
+
+	entities := opensanctions.ConvertEntities(res)
+	logger.Debug().Logf("finished OpenSanctions preperation: %v", time.Since(start))
+
+	responseCh <- preparedList{
+		ListName: pubsearch.SourceOpenSanctions,
+		Entities: entities,
+		Hash:     hash,
+	}
+
+	return nil
+}
+
+func loadInterpolRedRecords(ctx context.Context, logger log.Logger, conf Config, responseCh chan preparedList) error {
+	start := time.Now()
+	files, err := interpol.Download(ctx, logger, conf.InitialDataDirectory)
+	if err != nil {
+		return fmt.Errorf("Interpol Red Notices download: %w", err)
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("unexpected %d Interpol Red Notices files found", len(files))
+	}
+	hash, skip := skipUnchanged(ctx, pubsearch.SourceInterpolRed, files, responseCh)
+	if skip {
+		return nil
+	}
+
+	logger.Debug().Logf("finished Interpol Red Notices download: %v", time.Since(start))
+	start = time.Now()
+
+	_, span := tracing.Start(ctx, "download.parse", attribute.String("list", string(pubsearch.SourceInterpolRed)))
+	defer span.End()
+
+	res, err := interpol.Read(files)
+	if err != nil {
+		return fmt.Errorf("parsing Interpol Red Notices: %w", err)
+	}
+
+	entities := interpol.ConvertNotices(res)
+	logger.Debug().Logf("finished Interpol Red Notices preperation: %v", time.Since(start))
+
+	responseCh <- preparedList{
+		ListName: pubsearch.SourceInterpolRed,
 		Entities: entities,
+		Hash:     hash,
 	}
 
 	return nil
@@ -0,0 +1,141 @@
+package download
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloader_RefreshListsPartial(t *testing.T) {
+	dl, err := NewDownloader(log.NewTestLogger(), Config{
+		InitialDataDirectory: filepath.Join("..", "..", "pkg", "ofac", "testdata"),
+		Offline:              true,
+	})
+	require.NoError(t, err)
+
+	// The CA list's files are missing, but OFAC is still loaded
+	stats, err := dl.RefreshLists(context.Background(), []search.SourceList{search.SourceUSOFAC, search.SourceCACSL})
+	require.ErrorContains(t, err, "files not found while offline")
+	require.Greater(t, stats.Lists[string(search.SourceUSOFAC)], 0)
+	require.NotContains(t, stats.Lists, string(search.SourceCACSL))
+	require.NotEmpty(t, stats.Entities)
+
+	require.Len(t, stats.Failed, 1)
+	require.Contains(t, stats.Failed[string(search.SourceCACSL)], "loading CA CSL records")
+}
+
+func TestDownloader_RefreshListsConcurrency(t *testing.T) {
+	ctx := context.Background()
+
+	d, err := NewDownloader(log.NewTestLogger(), Config{
+		Concurrency:  2,
+		ListTimeout:  time.Minute,
+		ListTimeouts: map[string]time.Duration{"uk_csl": 50 * time.Millisecond},
+	})
+	require.NoError(t, err)
+	dl := d.(*downloader)
+
+	var mu sync.Mutex
+	var running, most int
+	stuck := make(chan struct{})
+	defer close(stuck)
+
+	dl.loaders = nil
+	for _, list := range []search.SourceList{search.SourceUSOFAC, search.SourceUSCSL, search.SourceEUCSL, search.SourceUKCSL, search.SourceUNCSL} {
+		list := list
+		dl.loaders = append(dl.loaders, listLoader{
+			list: list,
+			name: string(list),
+			load: func(ctx context.Context, logger log.Logger, conf Config, responseCh chan preparedList) error {
+				mu.Lock()
+				running++
+				if running > most {
+					most = running
+				}
+				mu.Unlock()
+				defer func() {
+					mu.Lock()
+					running--
+					mu.Unlock()
+				}()
+
+				switch list {
+				case search.SourceUKCSL:
+					<-stuck // parses past its timeout
+				case search.SourceUNCSL:
+					return errors.New("bad file")
+				}
+				time.Sleep(10 * time.Millisecond)
+				responseCh <- preparedList{
+					ListName: list,
+					Entities: []search.Entity[search.Value]{{Name: "loaded", Source: list}},
+				}
+				return nil
+			},
+		})
+	}
+
+	stats, err := dl.RefreshLists(ctx, []search.SourceList{search.SourceUSOFAC, search.SourceUSCSL, search.SourceEUCSL, search.SourceUKCSL, search.SourceUNCSL})
+	require.ErrorContains(t, err, "timed out after 50ms")
+	require.ErrorContains(t, err, "bad file")
+	require.LessOrEqual(t, most, 2)
+
+	// Lists which loaded are still returned
+	require.Equal(t, map[string]int{"us_ofac": 1, "us_csl": 1, "eu_csl": 1}, stats.Lists)
+	require.Len(t, stats.Entities, 3)
+	require.Equal(t, map[string]string{
+		"uk_csl": "loading uk_csl records: timed out after 50ms: context deadline exceeded",
+		"un_csl": "loading un_csl records: bad file",
+	}, stats.Failed)
+}
+
+func TestDownloader_UpdatePipelines(t *testing.T) {
+	ctx := context.Background()
+
+	dl, err := NewDownloader(log.NewTestLogger(), Config{
+		InitialDataDirectory: filepath.Join("..", "..", "pkg", "csl_ca", "testdata"),
+		IncludedLists:        []search.SourceList{search.SourceCACSL},
+	})
+	require.NoError(t, err)
+
+	first, err := dl.RefreshAll(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "SCF PRIMORYE", first.Entities[2].Name)
+
+	// Invalid stages don't replace any pipeline
+	_, err = dl.UpdatePipelines(map[string]prepare.PipelineConfig{
+		"ca_csl": {Entity: []prepare.Stage{"shout"}},
+	})
+	require.ErrorContains(t, err, `unknown prepare stage "shout"`)
+
+	punctuation := map[string]prepare.PipelineConfig{
+		"ca_csl": {Entity: []prepare.Stage{prepare.StagePunctuation}},
+	}
+	changed, err := dl.UpdatePipelines(punctuation)
+	require.NoError(t, err)
+	require.Equal(t, []search.SourceList{search.SourceCACSL}, changed)
+
+	// Unchanged files are prepared again with the new stages
+	second, err := dl.RefreshAll(ctx)
+	require.NoError(t, err)
+	require.Empty(t, second.Unchanged)
+	require.Equal(t, "scf primorye", second.Entities[2].Name)
+
+	// The same stages aren't a change
+	changed, err = dl.UpdatePipelines(punctuation)
+	require.NoError(t, err)
+	require.Empty(t, changed)
+
+	third, err := dl.RefreshAll(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{string(search.SourceCACSL)}, third.Unchanged)
+}
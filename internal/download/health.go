@@ -0,0 +1,172 @@
+package download
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+)
+
+// HealthConfig chooses when a list's entities are too old to be trusted and what readiness checks report
+type HealthConfig struct {
+	// StaleAfter is how long after its last successful refresh a list is stale, which is never when zero
+	StaleAfter time.Duration
+
+	// Readiness is ReadinessLoaded, the default, to fail readiness checks until the lists are first loaded,
+	// or ReadinessFresh to also fail them while any enabled list is stale
+	Readiness string
+}
+
+const (
+	ReadinessLoaded = "loaded"
+	ReadinessFresh  = "fresh"
+)
+
+func (c HealthConfig) Validate() error {
+	switch c.Readiness {
+	case "", ReadinessLoaded, ReadinessFresh:
+	default:
+		return fmt.Errorf("unknown readiness %q, expected %s or %s", c.Readiness, ReadinessLoaded, ReadinessFresh)
+	}
+	if c.StaleAfter < 0 {
+		return fmt.Errorf("stale after of %v can't be negative", c.StaleAfter)
+	}
+	if c.Readiness == ReadinessFresh && c.StaleAfter == 0 {
+		return errors.New("fresh readiness needs a stale after duration")
+	}
+	return nil
+}
+
+// Overall statuses of a HealthReport
+const (
+	// HealthLoading is reported until the lists are first loaded, so nothing can be searched
+	HealthLoading = "loading"
+
+	HealthReady = "ready"
+
+	// HealthDegraded is reported while any enabled list is stale, which is still ready
+	HealthDegraded = "degraded"
+
+	// HealthUnhealthy is reported in place of HealthDegraded with ReadinessFresh, which isn't ready
+	HealthUnhealthy = "unhealthy"
+)
+
+// Statuses of each list in a HealthReport
+const (
+	ListFresh = "fresh"
+	ListStale = "stale"
+
+	// ListPending lists haven't been refreshed successfully yet, such as one enabled at runtime
+	ListPending = "pending"
+)
+
+// HealthReport describes if Watchman can be searched and how old the entities of each enabled list are
+type HealthReport struct {
+	Status   string       `json:"status"`
+	LoadedAt *time.Time   `json:"loadedAt,omitempty"`
+	Lists    []ListHealth `json:"lists"`
+}
+
+type ListHealth struct {
+	List     pubsearch.SourceList `json:"list"`
+	Status   string               `json:"status"`
+	Entities int                  `json:"entities"`
+
+	LastRefresh *time.Time `json:"lastRefresh,omitempty"`
+	AgeSeconds  int64      `json:"ageSeconds,omitempty"`
+}
+
+// Ready returns true when searches can be answered, which stale lists prevent with ReadinessFresh
+func (r HealthReport) Ready() bool {
+	return r.Status == HealthReady || r.Status == HealthDegraded
+}
+
+// HealthChecker reports the freshness of each enabled list, from when it was last refreshed successfully
+type HealthChecker struct {
+	conf      HealthConfig
+	scheduler *Scheduler
+	lists     interface{ ListInfo() search.ListInfo }
+
+	lastRefreshed func(source string) (time.Time, bool)
+}
+
+func NewHealthChecker(conf HealthConfig, scheduler *Scheduler, lists interface{ ListInfo() search.ListInfo }) *HealthChecker {
+	return &HealthChecker{
+		conf:          conf,
+		scheduler:     scheduler,
+		lists:         lists,
+		lastRefreshed: refreshAges.lastRefreshed,
+	}
+}
+
+// Check reports the health of every enabled list at now
+func (h *HealthChecker) Check(now time.Time) HealthReport {
+	info := h.lists.ListInfo()
+	if info.UpdatedAt.IsZero() {
+		return HealthReport{Status: HealthLoading}
+	}
+
+	loadedAt := info.UpdatedAt
+	out := HealthReport{
+		Status:   HealthReady,
+		LoadedAt: &loadedAt,
+	}
+	for _, list := range h.scheduler.Enabled() {
+		health := ListHealth{
+			List:     list,
+			Status:   ListPending,
+			Entities: info.Lists[string(list)],
+		}
+		if at, ok := h.lastRefreshed(string(list)); ok {
+			age := now.Sub(at)
+			health.LastRefresh = &at
+			health.AgeSeconds = int64(age.Seconds())
+			health.Status = ListFresh
+
+			if h.conf.StaleAfter > 0 && age > h.conf.StaleAfter {
+				health.Status = ListStale
+				out.Status = HealthDegraded
+				if h.conf.Readiness == ReadinessFresh {
+					out.Status = HealthUnhealthy
+				}
+			}
+		}
+		out.Lists = append(out.Lists, health)
+	}
+	return out
+}
+
+// ReadinessCheck returns an error when Watchman shouldn't receive searches, as chosen by HealthConfig.Readiness
+func (h *HealthChecker) ReadinessCheck() error {
+	report := h.Check(time.Now())
+	if report.Ready() {
+		return nil
+	}
+	if report.Status == HealthLoading {
+		return errors.New("lists are loading")
+	}
+
+	var stale []string
+	for _, list := range report.Lists {
+		if list.Status == ListStale {
+			stale = append(stale, string(list.List))
+		}
+	}
+	return fmt.Errorf("lists not refreshed within %v: %s", h.conf.StaleAfter, strings.Join(stale, ", "))
+}
+
+// ServeHTTP writes the HealthReport, answering with 503 Service Unavailable when it isn't ready
+func (h *HealthChecker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := h.Check(time.Now())
+
+	w.Header().Set("Content-Type", "application/json")
+	if !report.Ready() {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(report)
+}
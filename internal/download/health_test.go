@@ -0,0 +1,120 @@
+package download
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+type mockListInfo struct {
+	info search.ListInfo
+}
+
+func (m *mockListInfo) ListInfo() search.ListInfo {
+	return m.info
+}
+
+func TestHealthConfig_Validate(t *testing.T) {
+	require.NoError(t, HealthConfig{}.Validate())
+	require.NoError(t, HealthConfig{Readiness: ReadinessFresh, StaleAfter: 24 * time.Hour}.Validate())
+
+	require.ErrorContains(t, HealthConfig{Readiness: "always"}.Validate(), "unknown readiness")
+	require.ErrorContains(t, HealthConfig{Readiness: ReadinessFresh}.Validate(), "needs a stale after")
+	require.ErrorContains(t, HealthConfig{StaleAfter: -time.Hour}.Validate(), "can't be negative")
+}
+
+func TestHealthChecker(t *testing.T) {
+	scheduler, err := NewScheduler(Config{
+		IncludedLists: []pubsearch.SourceList{pubsearch.SourceUSOFAC, pubsearch.SourceUKCSL, pubsearch.SourceEUCSL},
+	}, 12*time.Hour)
+	require.NoError(t, err)
+
+	now := time.Date(2024, time.March, 8, 12, 0, 0, 0, time.UTC)
+	refreshed := map[string]time.Time{
+		"us_ofac": now.Add(-2 * time.Hour),
+		"uk_csl":  now.Add(-72 * time.Hour),
+	}
+	lists := &mockListInfo{}
+
+	checker := NewHealthChecker(HealthConfig{StaleAfter: 48 * time.Hour}, scheduler, lists)
+	checker.lastRefreshed = func(source string) (time.Time, bool) {
+		at, ok := refreshed[source]
+		return at, ok
+	}
+
+	// Nothing is ready before the lists are loaded
+	report := checker.Check(now)
+	require.Equal(t, HealthLoading, report.Status)
+	require.False(t, report.Ready())
+	require.ErrorContains(t, checker.ReadinessCheck(), "lists are loading")
+
+	lists.info = search.ListInfo{
+		Lists:     map[string]int{"us_ofac": 100, "uk_csl": 20},
+		UpdatedAt: now.Add(-time.Hour),
+	}
+	report = checker.Check(now)
+	require.Equal(t, HealthDegraded, report.Status)
+	require.True(t, report.Ready())
+	require.Len(t, report.Lists, 3)
+
+	require.Equal(t, ListHealth{List: pubsearch.SourceEUCSL, Status: ListPending}, report.Lists[0])
+
+	ukAt := refreshed["uk_csl"]
+	require.Equal(t, ListHealth{
+		List:        pubsearch.SourceUKCSL,
+		Status:      ListStale,
+		Entities:    20,
+		LastRefresh: &ukAt,
+		AgeSeconds:  72 * 60 * 60,
+	}, report.Lists[1])
+	require.Equal(t, ListFresh, report.Lists[2].Status)
+	require.Equal(t, 100, report.Lists[2].Entities)
+
+	// Stale lists fail readiness checks when they need to be fresh
+	checker.conf.Readiness = ReadinessFresh
+	report = checker.Check(now)
+	require.Equal(t, HealthUnhealthy, report.Status)
+	require.False(t, report.Ready())
+
+	refreshed["uk_csl"] = now.Add(-time.Hour)
+	require.Equal(t, HealthReady, checker.Check(now).Status)
+}
+
+func TestHealthChecker_ServeHTTP(t *testing.T) {
+	scheduler, err := NewScheduler(Config{
+		IncludedLists: []pubsearch.SourceList{pubsearch.SourceUSOFAC},
+	}, 12*time.Hour)
+	require.NoError(t, err)
+
+	lists := &mockListInfo{}
+	checker := NewHealthChecker(HealthConfig{}, scheduler, lists)
+	checker.lastRefreshed = func(source string) (time.Time, bool) {
+		return time.Now().Add(-time.Minute), true
+	}
+
+	w := httptest.NewRecorder()
+	checker.ServeHTTP(w, httptest.NewRequest("GET", "/health", nil))
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	lists.info = search.ListInfo{
+		Lists:     map[string]int{"us_ofac": 100},
+		UpdatedAt: time.Now(),
+	}
+	w = httptest.NewRecorder()
+	checker.ServeHTTP(w, httptest.NewRequest("GET", "/health", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var report HealthReport
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+	require.Equal(t, HealthReady, report.Status)
+	require.Len(t, report.Lists, 1)
+	require.Equal(t, ListFresh, report.Lists[0].Status)
+	require.NotNil(t, report.Lists[0].LastRefresh)
+}
@@ -0,0 +1,135 @@
+package download
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	pubdownload "github.com/moov-io/watchman/pkg/download"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	downloadDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "list_download_duration_seconds",
+		Help:    "Seconds taken to download and parse each list",
+		Buckets: []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+	}, []string{"source"})
+
+	downloadsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "list_downloads_total",
+		Help: "Count of list downloads by their status (success or failure)",
+	}, []string{"source", "status"})
+
+	checksumMismatches = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "list_download_checksum_mismatches_total",
+		Help: "Count of list downloads rejected for files which didn't match their expected checksum",
+	}, []string{"source"})
+
+	lastRefreshCount = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "last_data_refresh_count",
+		Help: "Count of records for a given sanction or entity list",
+	}, []string{"source"})
+
+	lastRefreshSuccess = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "last_data_refresh_success",
+		Help: "Unix timestamp of when data was last refreshed successfully",
+	}, []string{"source"})
+
+	lastRefreshFailure = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "last_data_refresh_failure",
+		Help: "Unix timestamp of the most recent failure to refresh data",
+	}, []string{"source"})
+
+	refreshAlerts = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "list_refresh_alerts_total",
+		Help: "Count of list refreshes which weren't applied by their reason (refresh-failed or list-shrunk)",
+	}, []string{"source", "reason"})
+
+	refreshAges = newRefreshAgeCollector()
+)
+
+func init() {
+	prometheus.MustRegister(refreshAges)
+}
+
+// recordDownload observes how long a list took to download and if it succeeded
+func recordDownload(source string, start time.Time, err error) {
+	downloadDuration.WithLabelValues(source).Observe(time.Since(start).Seconds())
+	if err != nil {
+		downloadsTotal.WithLabelValues(source, "failure").Inc()
+		lastRefreshFailure.WithLabelValues(source).Set(float64(time.Now().Unix()))
+		if errors.Is(err, pubdownload.ErrChecksumMismatch) {
+			checksumMismatches.WithLabelValues(source).Inc()
+		}
+		return
+	}
+	downloadsTotal.WithLabelValues(source, "success").Inc()
+}
+
+// RecordRefresh marks each list as refreshed at refreshedAt, which is reported by the
+// seconds_since_last_refresh metric. Lists are keyed by name with their count of entities.
+func RecordRefresh(lists map[string]int, refreshedAt time.Time) {
+	for source, count := range lists {
+		lastRefreshCount.WithLabelValues(source).Set(float64(count))
+		lastRefreshSuccess.WithLabelValues(source).Set(float64(refreshedAt.Unix()))
+		refreshAges.refreshed(source, refreshedAt)
+	}
+}
+
+// RecordAlert counts a refresh which wasn't applied, so the list's previous entities are still searched
+func RecordAlert(alert ListAlert) {
+	refreshAlerts.WithLabelValues(string(alert.List), alert.Reason).Inc()
+}
+
+// refreshAgeCollector reports the seconds since each list was refreshed when it's scraped
+type refreshAgeCollector struct {
+	desc *prometheus.Desc
+
+	mu   sync.Mutex
+	last map[string]time.Time
+}
+
+func newRefreshAgeCollector() *refreshAgeCollector {
+	return &refreshAgeCollector{
+		desc: prometheus.NewDesc(
+			"seconds_since_last_refresh",
+			"Seconds since each list was last refreshed successfully",
+			[]string{"source"}, nil,
+		),
+		last: make(map[string]time.Time),
+	}
+}
+
+func (c *refreshAgeCollector) refreshed(source string, at time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if at.After(c.last[source]) {
+		c.last[source] = at
+	}
+}
+
+// lastRefreshed returns when source was last refreshed successfully, which is false when it hasn't been
+func (c *refreshAgeCollector) lastRefreshed(source string) (time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	at, exists := c.last[source]
+	return at, exists
+}
+
+func (c *refreshAgeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *refreshAgeCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for source, at := range c.last {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, time.Since(at).Seconds(), source)
+	}
+}
@@ -0,0 +1,52 @@
+package download
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	pubdownload "github.com/moov-io/watchman/pkg/download"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	before := testutil.ToFloat64(downloadsTotal.WithLabelValues("test_list", "failure"))
+	recordDownload("test_list", time.Now(), errors.New("bad"))
+	require.Equal(t, before+1, testutil.ToFloat64(downloadsTotal.WithLabelValues("test_list", "failure")))
+
+	mismatches := testutil.ToFloat64(checksumMismatches.WithLabelValues("test_list"))
+	recordDownload("test_list", time.Now(), fmt.Errorf("OFAC download: %w", pubdownload.ErrChecksumMismatch))
+	require.Equal(t, mismatches+1, testutil.ToFloat64(checksumMismatches.WithLabelValues("test_list")))
+
+	refreshedAt := time.Now().Add(-1 * time.Hour)
+	RecordRefresh(map[string]int{"test_list": 12}, refreshedAt)
+
+	require.Equal(t, 12.0, testutil.ToFloat64(lastRefreshCount.WithLabelValues("test_list")))
+	require.Equal(t, float64(refreshedAt.Unix()), testutil.ToFloat64(lastRefreshSuccess.WithLabelValues("test_list")))
+
+	// An older refresh, such as one loaded from the store, doesn't reset the age
+	RecordRefresh(map[string]int{"test_list": 12}, refreshedAt.Add(-2*time.Hour))
+
+	ch := make(chan prometheus.Metric, 100)
+	refreshAges.Collect(ch)
+	close(ch)
+
+	var age float64
+	for metric := range ch {
+		var m dto.Metric
+		require.NoError(t, metric.Write(&m))
+		if m.GetLabel()[0].GetValue() == "test_list" {
+			age = m.GetGauge().GetValue()
+		}
+	}
+	require.InDelta(t, time.Hour.Seconds(), age, 5.0)
+
+	before = testutil.ToFloat64(refreshAlerts.WithLabelValues("test_list", AlertListShrunk))
+	RecordAlert(ListAlert{List: "test_list", Reason: AlertListShrunk})
+	require.Equal(t, before+1, testutil.ToFloat64(refreshAlerts.WithLabelValues("test_list", AlertListShrunk)))
+}
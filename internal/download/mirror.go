@@ -0,0 +1,210 @@
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/moov-io/watchman/pkg/csl_au"
+	"github.com/moov-io/watchman/pkg/csl_ca"
+	"github.com/moov-io/watchman/pkg/csl_ch"
+	"github.com/moov-io/watchman/pkg/csl_eu"
+	"github.com/moov-io/watchman/pkg/csl_uk"
+	"github.com/moov-io/watchman/pkg/csl_un"
+	"github.com/moov-io/watchman/pkg/csl_us"
+	pubdownload "github.com/moov-io/watchman/pkg/download"
+	"github.com/moov-io/watchman/pkg/fincen"
+	"github.com/moov-io/watchman/pkg/interpol"
+	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/opensanctions"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"gocloud.dev/blob"
+	_ "gocloud.dev/blob/fileblob"
+	_ "gocloud.dev/blob/gcsblob"
+	_ "gocloud.dev/blob/s3blob"
+)
+
+// sourceFiles returns the files of each list, read from initialDir when they're found there
+var sourceFiles = map[pubsearch.SourceList]func(ctx context.Context, logger log.Logger, initialDir string) (map[string]io.ReadCloser, error){
+	pubsearch.SourceUSOFAC:        ofac.Download,
+	pubsearch.SourceUSCSL:         csl_us.Download,
+	pubsearch.SourceUSBIS:         csl_us.DownloadBIS,
+	pubsearch.SourceAUCSL:         csl_au.Download,
+	pubsearch.SourceCACSL:         csl_ca.Download,
+	pubsearch.SourceCHCSL:         csl_ch.Download,
+	pubsearch.SourceEUCSL:         csl_eu.DownloadEU,
+	pubsearch.SourceUKCSL:         csl_uk.DownloadCSL,
+	pubsearch.SourceUNCSL:         csl_un.Download,
+	pubsearch.SourceUSFinCEN311:   fincen.Download,
+	pubsearch.SourceOpenSanctions: opensanctions.Download,
+	pubsearch.SourceInterpolRed:   interpol.Download,
+}
+
+// openMirror opens a bucket URL (s3://bucket?prefix=watchman/, gs://bucket) or local directory
+func openMirror(ctx context.Context, mirrorURL string) (*blob.Bucket, error) {
+	if !strings.Contains(mirrorURL, "://") {
+		dir, err := filepath.Abs(mirrorURL)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+		// Skip writing .attrs sidecar files, so the directory can also be used as an InitialDataDirectory
+		mirrorURL = "file://" + filepath.ToSlash(dir) + "?metadata=skip"
+	}
+
+	bucket, err := blob.OpenBucket(ctx, mirrorURL)
+	if err != nil {
+		return nil, fmt.Errorf("opening mirror %s: %w", mirrorURL, err)
+	}
+	return bucket, nil
+}
+
+// fetchMirror copies the files of a mirror into a temporary directory, which is used in place of
+// InitialDataDirectory. The returned func removes the directory.
+func fetchMirror(ctx context.Context, logger log.Logger, mirrorURL string) (string, func(), error) {
+	bucket, err := openMirror(ctx, mirrorURL)
+	if err != nil {
+		return "", nil, err
+	}
+	defer bucket.Close()
+
+	dir, err := os.MkdirTemp("", "watchman-mirror-*")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+
+	iter := bucket.List(&blob.ListOptions{Delimiter: "/"})
+	files := 0
+	for {
+		obj, err := iter.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			cleanup()
+			return "", nil, fmt.Errorf("listing mirror: %w", err)
+		}
+		if obj.IsDir || strings.HasSuffix(obj.Key, ".attrs") {
+			continue
+		}
+		if err := copyFromMirror(ctx, bucket, obj.Key, filepath.Join(dir, filepath.Base(obj.Key))); err != nil {
+			cleanup()
+			return "", nil, err
+		}
+		files++
+	}
+
+	logger.Info().Logf("read %d files from mirror", files)
+	return dir, cleanup, nil
+}
+
+func copyFromMirror(ctx context.Context, bucket *blob.Bucket, key, path string) error {
+	r, err := bucket.NewReader(ctx, key, nil)
+	if err != nil {
+		return fmt.Errorf("reading %s from mirror: %w", key, err)
+	}
+	defer r.Close()
+
+	fd, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(fd, r); err != nil {
+		fd.Close()
+		return fmt.Errorf("copying %s from mirror: %w", key, err)
+	}
+	return fd.Close()
+}
+
+// MirrorSources downloads the files of each included list and writes them into the bucket or directory
+// at mirrorURL, so Watchman instances without access to the public endpoints can read them with MirrorURL.
+// Files found in the InitialDataDirectory are mirrored rather than downloaded.
+//
+// The filenames written for each list are returned.
+func MirrorSources(ctx context.Context, logger log.Logger, conf Config, mirrorURL string) (map[pubsearch.SourceList][]string, error) {
+	if mirrorURL == "" {
+		return nil, errors.New("missing mirror URL")
+	}
+	bucket, err := openMirror(ctx, mirrorURL)
+	if err != nil {
+		return nil, err
+	}
+	defer bucket.Close()
+
+	clients, err := newHTTPClients(conf.HTTP)
+	if err != nil {
+		return nil, fmt.Errorf("download http client: %w", err)
+	}
+	if len(conf.Checksums) > 0 {
+		ctx = pubdownload.WithChecksums(ctx, conf.Checksums)
+	}
+
+	out := make(map[pubsearch.SourceList][]string)
+	for _, list := range conf.IncludedLists {
+		getFiles, exists := sourceFiles[list]
+		if !exists {
+			return out, fmt.Errorf("mirroring %s is not supported", list)
+		}
+		if list == pubsearch.SourceUSOFAC && strings.EqualFold(conf.OFACFormat, OFACFormatAdvanced) {
+			getFiles = ofac.DownloadAdvanced
+		}
+
+		files, err := getFiles(clients.with(ctx, list), logger, conf.InitialDataDirectory)
+		if err != nil {
+			return out, fmt.Errorf("downloading %s: %w", list, err)
+		}
+		if len(files) == 0 {
+			return out, fmt.Errorf("no %s files were downloaded", list)
+		}
+
+		for filename, fd := range files {
+			err := copyToMirror(ctx, bucket, filename, fd)
+			fd.Close()
+			if err != nil {
+				return out, fmt.Errorf("mirroring %s: %w", list, err)
+			}
+			out[list] = append(out[list], filename)
+		}
+		slices.Sort(out[list])
+
+		logger.Info().Logf("mirrored %d %s files", len(out[list]), list)
+	}
+	return out, nil
+}
+
+// copyToMirror writes a file into the mirror along with a checksum file, which instances reading the
+// mirror check the file against
+func copyToMirror(ctx context.Context, bucket *blob.Bucket, key string, r io.Reader) error {
+	h := sha256.New()
+	if err := writeToMirror(ctx, bucket, key, io.TeeReader(r, h)); err != nil {
+		return err
+	}
+	sum := fmt.Sprintf("%x  %s\n", h.Sum(nil), key)
+	return writeToMirror(ctx, bucket, key+pubdownload.ChecksumSuffix, strings.NewReader(sum))
+}
+
+func writeToMirror(ctx context.Context, bucket *blob.Bucket, key string, r io.Reader) error {
+	w, err := bucket.NewWriter(ctx, key, nil)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		w.Close()
+		return fmt.Errorf("writing %s: %w", key, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("writing %s: %w", key, err)
+	}
+	return nil
+}
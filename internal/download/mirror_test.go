@@ -0,0 +1,156 @@
+package download
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMirror(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+	mirrorDir := t.TempDir()
+
+	conf := Config{
+		InitialDataDirectory: filepath.Join("..", "..", "pkg", "ofac", "testdata"),
+		IncludedLists:        []search.SourceList{search.SourceUSOFAC},
+	}
+	mirrored, err := MirrorSources(ctx, logger, conf, mirrorDir)
+	require.NoError(t, err)
+	require.Equal(t, []string{"ADD.CSV", "ALT.CSV", "SDN.CSV", "SDN_COMMENTS.CSV"}, mirrored[search.SourceUSOFAC])
+
+	// Lists are read from the mirror without downloading anything
+	for _, mirrorURL := range []string{mirrorDir, "file://" + filepath.ToSlash(mirrorDir)} {
+		dl, err := NewDownloader(logger, Config{
+			IncludedLists: []search.SourceList{search.SourceUSOFAC},
+			MirrorURL:     mirrorURL,
+			Offline:       true,
+		})
+		require.NoError(t, err)
+
+		stats, err := dl.RefreshAll(ctx)
+		require.NoError(t, err)
+		require.Greater(t, stats.Lists[string(search.SourceUSOFAC)], 0)
+	}
+
+	// Each file's checksum is mirrored next to it, so changed files fail the refresh
+	checksum, err := os.ReadFile(filepath.Join(mirrorDir, "SDN.CSV.sha256"))
+	require.NoError(t, err)
+	require.Contains(t, string(checksum), "  SDN.CSV")
+
+	sdn, err := os.ReadFile(filepath.Join(mirrorDir, "SDN.CSV"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(mirrorDir, "SDN.CSV"), sdn[:len(sdn)/2], 0600))
+
+	dl, err := NewDownloader(logger, Config{
+		IncludedLists: []search.SourceList{search.SourceUSOFAC},
+		MirrorURL:     mirrorDir,
+		Offline:       true,
+	})
+	require.NoError(t, err)
+
+	_, err = dl.RefreshAll(ctx)
+	require.ErrorContains(t, err, "checksum mismatch: SDN.CSV has SHA-256")
+	require.NoError(t, os.WriteFile(filepath.Join(mirrorDir, "SDN.CSV"), sdn, 0600))
+
+	// Expected checksums are checked while mirroring
+	_, err = MirrorSources(ctx, logger, Config{
+		InitialDataDirectory: conf.InitialDataDirectory,
+		IncludedLists:        conf.IncludedLists,
+		Checksums:            map[string]string{"sdn.csv": strings.Repeat("0", 64)},
+	}, t.TempDir())
+	require.ErrorContains(t, err, "checksum mismatch")
+
+	// Files missing from the mirror aren't downloaded while offline
+	dl, err = NewDownloader(logger, Config{
+		IncludedLists: []search.SourceList{search.SourceUSOFAC},
+		MirrorURL:     t.TempDir(),
+		Offline:       true,
+	})
+	require.NoError(t, err)
+
+	_, err = dl.RefreshAll(ctx)
+	require.ErrorContains(t, err, "files not found while offline: ADD.CSV, ALT.CSV, SDN.CSV, SDN_COMMENTS.CSV")
+
+	_, err = MirrorSources(ctx, logger, Config{IncludedLists: []search.SourceList{"other"}}, mirrorDir)
+	require.ErrorContains(t, err, "not supported")
+}
+
+func TestMirror_OFACAdvanced(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+
+	conf := Config{
+		InitialDataDirectory: filepath.Join("..", "..", "pkg", "ofac", "testdata"),
+		IncludedLists:        []search.SourceList{search.SourceUSOFAC},
+		OFACFormat:           OFACFormatAdvanced,
+	}
+	mirrored, err := MirrorSources(ctx, logger, conf, t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, []string{"SDN_ADVANCED.XML"}, mirrored[search.SourceUSOFAC])
+
+	conf.Offline = true
+	dl, err := NewDownloader(logger, conf)
+	require.NoError(t, err)
+
+	stats, err := dl.RefreshAll(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 3, stats.Lists[string(search.SourceUSOFAC)])
+}
+
+func TestMirror_OpenSanctions(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+
+	conf := Config{
+		InitialDataDirectory: filepath.Join("..", "..", "pkg", "opensanctions", "testdata"),
+		IncludedLists:        []search.SourceList{search.SourceOpenSanctions},
+	}
+	mirrored, err := MirrorSources(ctx, logger, conf, t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, []string{"entities.ftm.json"}, mirrored[search.SourceOpenSanctions])
+
+	conf.Offline = true
+	dl, err := NewDownloader(logger, conf)
+	require.NoError(t, err)
+
+	stats, err := dl.RefreshAll(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 4, stats.Lists[string(search.SourceOpenSanctions)])
+}
+
+func TestMirror_AUCACHFinCENAndInterpol(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+
+	for list, dir := range map[search.SourceList]string{
+		search.SourceAUCSL:       "csl_au",
+		search.SourceCACSL:       "csl_ca",
+		search.SourceCHCSL:       "csl_ch",
+		search.SourceUSFinCEN311: "fincen",
+		search.SourceInterpolRed: "interpol",
+	} {
+		conf := Config{
+			InitialDataDirectory: filepath.Join("..", "..", "pkg", dir, "testdata"),
+			IncludedLists:        []search.SourceList{list},
+			Offline:              true,
+		}
+		mirrored, err := MirrorSources(ctx, logger, conf, t.TempDir())
+		require.NoError(t, err)
+		require.Len(t, mirrored[list], 1)
+
+		dl, err := NewDownloader(logger, conf)
+		require.NoError(t, err)
+
+		stats, err := dl.RefreshAll(ctx)
+		require.NoError(t, err)
+		require.Greater(t, stats.Lists[string(list)], 0)
+	}
+}
@@ -0,0 +1,161 @@
+package download
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule returns when a list is next refreshed after it was refreshed at a given time
+type Schedule interface {
+	Next(after time.Time) time.Time
+}
+
+// ParseSchedule reads a duration (e.g. 6h), a five field cron expression (e.g. "0 6 * * 1-5") or "off",
+// which stops a list from being refreshed after it's first downloaded.
+func ParseSchedule(value string) (Schedule, error) {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, "off") {
+		return offSchedule{}, nil
+	}
+	if dur, err := time.ParseDuration(value); err == nil {
+		if dur <= 0 {
+			return nil, fmt.Errorf("refresh interval %v must be positive", dur)
+		}
+		return intervalSchedule(dur), nil
+	}
+	return parseCron(value)
+}
+
+type offSchedule struct{}
+
+func (offSchedule) Next(time.Time) time.Time {
+	return time.Time{}
+}
+
+type intervalSchedule time.Duration
+
+func (s intervalSchedule) Next(after time.Time) time.Time {
+	return after.Add(time.Duration(s))
+}
+
+// cronSchedule holds the allowed values of each field as bits
+type cronSchedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// Standard cron matches either the day of month or week when both are restricted
+	anyDOM, anyDOW bool
+}
+
+var cronFields = []struct {
+	name            string
+	lowest, highest int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 6},
+}
+
+func parseCron(value string) (Schedule, error) {
+	fields := strings.Fields(value)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("schedule %q is not a duration or five field cron expression", value)
+	}
+
+	var bits [5]uint64
+	for i, field := range fields {
+		b, err := parseCronField(field, cronFields[i].lowest, cronFields[i].highest)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q %s: %w", value, cronFields[i].name, err)
+		}
+		bits[i] = b
+	}
+	return &cronSchedule{
+		minute: bits[0],
+		hour:   bits[1],
+		dom:    bits[2],
+		month:  bits[3],
+		dow:    bits[4],
+		anyDOM: fields[2] == "*",
+		anyDOW: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField reads comma separated values, ranges (1-5) and steps (*/15 or 0-30/10)
+func parseCronField(field string, lowest, highest int) (uint64, error) {
+	var out uint64
+	for _, part := range strings.Split(field, ",") {
+		expr, step := part, 1
+		if before, after, found := strings.Cut(part, "/"); found {
+			n, err := strconv.Atoi(after)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid step %q", after)
+			}
+			expr, step = before, n
+		}
+
+		start, end := lowest, highest
+		if expr != "*" {
+			lo, hi, isRange := strings.Cut(expr, "-")
+			var err error
+			if start, err = strconv.Atoi(lo); err != nil {
+				return 0, fmt.Errorf("invalid value %q", lo)
+			}
+			end = start
+			if isRange {
+				if end, err = strconv.Atoi(hi); err != nil {
+					return 0, fmt.Errorf("invalid value %q", hi)
+				}
+			} else if step > 1 {
+				end = highest
+			}
+		}
+		if start < lowest || end > highest || start > end {
+			return 0, fmt.Errorf("%q is outside of %d-%d", part, lowest, highest)
+		}
+		for v := start; v <= end; v += step {
+			out |= 1 << uint(v)
+		}
+	}
+	return out, nil
+}
+
+func (s *cronSchedule) matchesDay(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case s.anyDOM && s.anyDOW:
+		return true
+	case s.anyDOM:
+		return dow
+	case s.anyDOW:
+		return dom
+	}
+	return dom || dow
+}
+
+// Next returns the first matching minute after t, in t's location
+func (s *cronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+
+	// Every combination of fields repeats within a few years
+	limit := t.AddDate(5, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,39 @@
+package download
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSchedule(t *testing.T) {
+	at := time.Date(2024, time.March, 8, 14, 30, 0, 0, time.UTC) // Friday
+
+	cases := []struct {
+		value    string
+		expected time.Time
+	}{
+		{"6h", at.Add(6 * time.Hour)},
+		{"off", time.Time{}},
+		{"*/15 * * * *", time.Date(2024, time.March, 8, 14, 45, 0, 0, time.UTC)},
+		{"0 6 * * *", time.Date(2024, time.March, 9, 6, 0, 0, 0, time.UTC)},
+		{"0 6 * * 1-5", time.Date(2024, time.March, 11, 6, 0, 0, 0, time.UTC)},
+		{"30 14 * * *", time.Date(2024, time.March, 9, 14, 30, 0, 0, time.UTC)},
+		{"0 0,12 1 * *", time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 9 1 * 1", time.Date(2024, time.March, 11, 9, 0, 0, 0, time.UTC)}, // day of month or week
+	}
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			schedule, err := ParseSchedule(tc.value)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, schedule.Next(at))
+		})
+	}
+
+	for _, value := range []string{"", "-1h", "daily", "* * * *", "60 * * * *", "* * 0 * *", "*/0 * * * *", "5-1 * * * *"} {
+		_, err := ParseSchedule(value)
+		require.Error(t, err, value)
+	}
+}
@@ -0,0 +1,439 @@
+package download
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+)
+
+// ListSchedule is whether a list is downloaded and searched, along with when it's refreshed
+type ListSchedule struct {
+	List     pubsearch.SourceList `json:"list"`
+	Enabled  bool                 `json:"enabled"`
+	Schedule string               `json:"schedule"`
+
+	LastRefresh *time.Time `json:"lastRefresh,omitempty"`
+	NextRefresh *time.Time `json:"nextRefresh,omitempty"`
+
+	// Hold is a refresh which removed too many of the list's entities, so it isn't searched until it's approved
+	Hold *ListHold `json:"hold,omitempty"`
+}
+
+// ListHold is a refresh which is waiting to be approved, counting its entities and those still searched
+type ListHold struct {
+	Entities int       `json:"entities"`
+	Previous int       `json:"previous"`
+	HeldAt   time.Time `json:"heldAt"`
+}
+
+// ListScheduleUpdate enables or disables a list, or changes its schedule, leaving nil fields unchanged
+type ListScheduleUpdate struct {
+	Enabled  *bool   `json:"enabled"`
+	Schedule *string `json:"schedule"`
+}
+
+// ListRefresh is the outcome of downloading a list on demand, counting the changes to its searched entities
+type ListRefresh struct {
+	List      pubsearch.SourceList `json:"list"`
+	VersionID string               `json:"versionID,omitempty"`
+
+	ListChanges
+
+	// Held is true when the refresh removed too many entities, so the previous entities are still searched
+	Held bool `json:"held,omitempty"`
+
+	RefreshedAt time.Time `json:"refreshedAt"`
+}
+
+// RefreshRequest asks for a list to be downloaded right away, which is answered with Reply. Approve
+// requests search the list's held refresh instead of downloading it again.
+type RefreshRequest struct {
+	List    pubsearch.SourceList
+	Approve bool
+
+	reply chan refreshReply
+}
+
+type refreshReply struct {
+	result ListRefresh
+	err    error
+}
+
+func (r RefreshRequest) Reply(result ListRefresh, err error) {
+	r.reply <- refreshReply{result: result, err: err}
+}
+
+var (
+	ErrListNotSupported = errors.New("list not supported")
+	ErrListDisabled     = errors.New("list not enabled")
+	ErrNoHeldRefresh    = errors.New("no held refresh")
+)
+
+// Scheduler tracks which lists are enabled and when each is due for a refresh. Lists are scheduled
+// by Config.Schedules and otherwise refreshed every interval.
+type Scheduler struct {
+	mu       sync.Mutex
+	interval time.Duration
+	lists    map[pubsearch.SourceList]*scheduledList
+
+	changed  chan struct{}
+	requests chan RefreshRequest
+}
+
+type scheduledList struct {
+	enabled  bool
+	schedule string
+	parsed   Schedule
+
+	lastRefresh time.Time
+	nextRefresh time.Time // zero once an "off" list has been downloaded
+	pending     bool      // due regardless of nextRefresh, such as after being enabled
+
+	held *heldRefresh
+}
+
+type heldRefresh struct {
+	ListHold
+	entities []pubsearch.Entity[pubsearch.Value]
+}
+
+func NewScheduler(conf Config, interval time.Duration) (*Scheduler, error) {
+	s := &Scheduler{
+		interval: interval,
+		lists:    make(map[pubsearch.SourceList]*scheduledList),
+		changed:  make(chan struct{}, 1),
+		requests: make(chan RefreshRequest),
+	}
+	for list := range sourceFiles {
+		s.lists[list] = &scheduledList{
+			enabled:  slices.Contains(conf.IncludedLists, list),
+			schedule: interval.String(),
+			parsed:   intervalSchedule(interval),
+			pending:  true,
+		}
+	}
+	for name, value := range conf.Schedules {
+		list, exists := s.lists[pubsearch.SourceList(name)]
+		if !exists {
+			return nil, fmt.Errorf("%s schedule: %w", name, ErrListNotSupported)
+		}
+		parsed, err := ParseSchedule(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s schedule: %w", name, err)
+		}
+		list.schedule, list.parsed = value, parsed
+	}
+	return s, nil
+}
+
+// Enabled returns the lists which are downloaded and searched, sorted by name
+func (s *Scheduler) Enabled() []pubsearch.SourceList {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []pubsearch.SourceList
+	for name, list := range s.lists {
+		if list.enabled {
+			out = append(out, name)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// IsDisabled returns true for lists which could be downloaded but aren't enabled. Entities of other
+// sources, such as custom lists, are never disabled.
+func (s *Scheduler) IsDisabled(source pubsearch.SourceList) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list, exists := s.lists[source]
+	return exists && !list.enabled
+}
+
+// Due returns the enabled lists which should be refreshed at now, sorted by name
+func (s *Scheduler) Due(now time.Time) []pubsearch.SourceList {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []pubsearch.SourceList
+	for name, list := range s.lists {
+		if list.enabled && (list.pending || (!list.nextRefresh.IsZero() && !now.Before(list.nextRefresh))) {
+			out = append(out, name)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// Refreshed schedules the next refresh of lists, which were attempted at the given time
+func (s *Scheduler) Refreshed(lists []pubsearch.SourceList, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, name := range lists {
+		if list, exists := s.lists[name]; exists {
+			list.lastRefresh = at
+			list.nextRefresh = list.parsed.Next(at)
+			list.pending = false
+		}
+	}
+}
+
+// NextRefresh returns when the next enabled list is due, which is false when none are scheduled
+func (s *Scheduler) NextRefresh() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out time.Time
+	var found bool
+	for _, list := range s.lists {
+		if !list.enabled {
+			continue
+		}
+		next := list.nextRefresh
+		switch {
+		case list.pending:
+			next = list.lastRefresh // already due
+		case next.IsZero():
+			continue
+		}
+		if !found || next.Before(out) {
+			out, found = next, true
+		}
+	}
+	return out, found
+}
+
+// Changed receives after a list is enabled, disabled or rescheduled
+func (s *Scheduler) Changed() <-chan struct{} {
+	return s.changed
+}
+
+// Requests receives each list which should be downloaded right away
+func (s *Scheduler) Requests() <-chan RefreshRequest {
+	return s.requests
+}
+
+// Refresh downloads an enabled list right away and waits until its entities are searched
+func (s *Scheduler) Refresh(ctx context.Context, name pubsearch.SourceList) (ListRefresh, error) {
+	s.mu.Lock()
+	list, exists := s.lists[name]
+	enabled := exists && list.enabled
+	s.mu.Unlock()
+
+	switch {
+	case !exists:
+		return ListRefresh{}, fmt.Errorf("%s: %w", name, ErrListNotSupported)
+	case !enabled:
+		return ListRefresh{}, fmt.Errorf("%s: %w", name, ErrListDisabled)
+	}
+
+	return s.request(ctx, RefreshRequest{List: name})
+}
+
+// Approve searches the held refresh of a list in place of its previous entities and waits until it's applied
+func (s *Scheduler) Approve(ctx context.Context, name pubsearch.SourceList) (ListRefresh, error) {
+	s.mu.Lock()
+	list, exists := s.lists[name]
+	held := exists && list.held != nil
+	s.mu.Unlock()
+
+	switch {
+	case !exists:
+		return ListRefresh{}, fmt.Errorf("%s: %w", name, ErrListNotSupported)
+	case !held:
+		return ListRefresh{}, fmt.Errorf("%s: %w", name, ErrNoHeldRefresh)
+	}
+	return s.request(ctx, RefreshRequest{List: name, Approve: true})
+}
+
+func (s *Scheduler) request(ctx context.Context, req RefreshRequest) (ListRefresh, error) {
+	req.reply = make(chan refreshReply, 1)
+	select {
+	case s.requests <- req:
+	case <-ctx.Done():
+		return ListRefresh{}, ctx.Err()
+	}
+	select {
+	case reply := <-req.reply:
+		return reply.result, reply.err
+	case <-ctx.Done():
+		return ListRefresh{}, ctx.Err()
+	}
+}
+
+// Hold keeps the entities of a refresh which aren't searched until it's approved, replacing any earlier hold
+func (s *Scheduler) Hold(name pubsearch.SourceList, entities []pubsearch.Entity[pubsearch.Value], previous int, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if list, exists := s.lists[name]; exists {
+		list.held = &heldRefresh{
+			ListHold: ListHold{
+				Entities: len(entities),
+				Previous: previous,
+				HeldAt:   at,
+			},
+			entities: entities,
+		}
+	}
+}
+
+// Held returns the held refresh of a list, which is false when nothing is held
+func (s *Scheduler) Held(name pubsearch.SourceList) (ListHold, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list, exists := s.lists[name]
+	if !exists || list.held == nil {
+		return ListHold{}, false
+	}
+	return list.held.ListHold, true
+}
+
+// Release discards the held refresh of a list, returning its entities when one was held
+func (s *Scheduler) Release(name pubsearch.SourceList) ([]pubsearch.Entity[pubsearch.Value], bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list, exists := s.lists[name]
+	if !exists || list.held == nil {
+		return nil, false
+	}
+	entities := list.held.entities
+	list.held = nil
+	return entities, true
+}
+
+// Lists returns the schedule of every supported list, sorted by name
+func (s *Scheduler) Lists() []ListSchedule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]ListSchedule, 0, len(s.lists))
+	for name := range s.lists {
+		out = append(out, s.describe(name))
+	}
+	slices.SortFunc(out, func(a, b ListSchedule) int {
+		return cmp.Compare(a.List, b.List)
+	})
+	return out
+}
+
+// Update enables, disables or reschedules a list. Enabled lists are downloaded right away.
+func (s *Scheduler) Update(name pubsearch.SourceList, update ListScheduleUpdate) (ListSchedule, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list, exists := s.lists[name]
+	if !exists {
+		return ListSchedule{}, fmt.Errorf("%s: %w", name, ErrListNotSupported)
+	}
+	if update.Schedule != nil {
+		parsed, err := ParseSchedule(*update.Schedule)
+		if err != nil {
+			return ListSchedule{}, err
+		}
+		list.schedule, list.parsed = *update.Schedule, parsed
+		if !list.lastRefresh.IsZero() {
+			list.nextRefresh = parsed.Next(list.lastRefresh)
+		}
+	}
+	if update.Enabled != nil {
+		if *update.Enabled && !list.enabled {
+			list.pending = true
+		}
+		list.enabled = *update.Enabled
+	}
+	s.notify()
+
+	return s.describe(name), nil
+}
+
+// Reconfigure enables the lists of conf.IncludedLists, disabling the others, and schedules each by conf.Schedules
+// or every interval. Nothing is changed when a schedule is invalid. Lists which are newly enabled are downloaded
+// right away, while the others keep their last refresh.
+func (s *Scheduler) Reconfigure(conf Config, interval time.Duration) error {
+	schedules := make(map[pubsearch.SourceList]Schedule)
+	for name, value := range conf.Schedules {
+		if _, exists := s.lists[pubsearch.SourceList(name)]; !exists {
+			return fmt.Errorf("%s schedule: %w", name, ErrListNotSupported)
+		}
+		parsed, err := ParseSchedule(value)
+		if err != nil {
+			return fmt.Errorf("%s schedule: %w", name, err)
+		}
+		schedules[pubsearch.SourceList(name)] = parsed
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.interval = interval
+	for name, list := range s.lists {
+		list.schedule, list.parsed = interval.String(), intervalSchedule(interval)
+		if parsed, exists := schedules[name]; exists {
+			list.schedule, list.parsed = conf.Schedules[string(name)], parsed
+		}
+		if !list.lastRefresh.IsZero() {
+			list.nextRefresh = list.parsed.Next(list.lastRefresh)
+		}
+
+		enabled := slices.Contains(conf.IncludedLists, name)
+		if enabled && !list.enabled {
+			list.pending = true
+		}
+		list.enabled = enabled
+	}
+	s.notify()
+	return nil
+}
+
+// Expire makes enabled lists due right away, such as after the stages their names are prepared with changed
+func (s *Scheduler) Expire(lists ...pubsearch.SourceList) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, name := range lists {
+		if list, exists := s.lists[name]; exists && list.enabled {
+			list.pending = true
+		}
+	}
+	s.notify()
+}
+
+func (s *Scheduler) notify() {
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (s *Scheduler) describe(name pubsearch.SourceList) ListSchedule {
+	list := s.lists[name]
+	out := ListSchedule{
+		List:     name,
+		Enabled:  list.enabled,
+		Schedule: list.schedule,
+	}
+	if !list.lastRefresh.IsZero() {
+		at := list.lastRefresh
+		out.LastRefresh = &at
+	}
+	if list.enabled && !list.pending && !list.nextRefresh.IsZero() {
+		at := list.nextRefresh
+		out.NextRefresh = &at
+	}
+	if list.held != nil {
+		hold := list.held.ListHold
+		out.Hold = &hold
+	}
+	return out
+}
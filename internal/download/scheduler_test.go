@@ -0,0 +1,172 @@
+package download
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScheduler(t *testing.T) {
+	conf := Config{
+		IncludedLists: []search.SourceList{search.SourceUSOFAC, search.SourceUKCSL},
+		Schedules: map[string]string{
+			"us_ofac": "1h",
+		},
+	}
+	scheduler, err := NewScheduler(conf, 12*time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, []search.SourceList{search.SourceUKCSL, search.SourceUSOFAC}, scheduler.Enabled())
+
+	// Every enabled list is due before it's downloaded
+	now := time.Date(2024, time.March, 8, 12, 0, 0, 0, time.UTC)
+	require.Equal(t, scheduler.Enabled(), scheduler.Due(now))
+
+	scheduler.Refreshed(scheduler.Enabled(), now)
+	require.Empty(t, scheduler.Due(now))
+
+	next, ok := scheduler.NextRefresh()
+	require.True(t, ok)
+	require.Equal(t, now.Add(time.Hour), next)
+	require.Equal(t, []search.SourceList{search.SourceUSOFAC}, scheduler.Due(now.Add(time.Hour)))
+	require.Equal(t, []search.SourceList{search.SourceUKCSL, search.SourceUSOFAC}, scheduler.Due(now.Add(12*time.Hour)))
+
+	// Disable a list
+	require.False(t, scheduler.IsDisabled(search.SourceUKCSL))
+	disabled := false
+	got, err := scheduler.Update(search.SourceUKCSL, ListScheduleUpdate{Enabled: &disabled})
+	require.NoError(t, err)
+	require.False(t, got.Enabled)
+	require.Nil(t, got.NextRefresh)
+	require.True(t, scheduler.IsDisabled(search.SourceUKCSL))
+	require.False(t, scheduler.IsDisabled(search.SourceCustomList))
+	require.Equal(t, []search.SourceList{search.SourceUSOFAC}, scheduler.Due(now.Add(12*time.Hour)))
+	<-scheduler.Changed()
+
+	// Enabled lists are due right away
+	enabled := true
+	_, err = scheduler.Update(search.SourceEUCSL, ListScheduleUpdate{Enabled: &enabled})
+	require.NoError(t, err)
+	require.Equal(t, []search.SourceList{search.SourceEUCSL}, scheduler.Due(now))
+
+	// Reschedule a list
+	weekdays := "0 6 * * 1-5"
+	got, err = scheduler.Update(search.SourceUSOFAC, ListScheduleUpdate{Schedule: &weekdays})
+	require.NoError(t, err)
+	require.Equal(t, weekdays, got.Schedule)
+	require.Equal(t, time.Date(2024, time.March, 11, 6, 0, 0, 0, time.UTC), *got.NextRefresh)
+
+	lists := scheduler.Lists()
+	require.Len(t, lists, len(sourceFiles))
+	require.Equal(t, search.SourceAUCSL, lists[0].List)
+
+	// Errors
+	bad := "daily"
+	_, err = scheduler.Update(search.SourceUSOFAC, ListScheduleUpdate{Schedule: &bad})
+	require.Error(t, err)
+
+	_, err = scheduler.Update("other", ListScheduleUpdate{Enabled: &enabled})
+	require.ErrorIs(t, err, ErrListNotSupported)
+
+	_, err = NewScheduler(Config{Schedules: map[string]string{"other": "1h"}}, time.Hour)
+	require.ErrorIs(t, err, ErrListNotSupported)
+
+	_, err = NewScheduler(Config{Schedules: map[string]string{"us_ofac": "daily"}}, time.Hour)
+	require.Error(t, err)
+}
+
+func TestScheduler_Off(t *testing.T) {
+	scheduler, err := NewScheduler(Config{
+		IncludedLists: []search.SourceList{search.SourceUSOFAC},
+		Schedules:     map[string]string{"us_ofac": "off"},
+	}, time.Hour)
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.Len(t, scheduler.Due(now), 1)
+
+	scheduler.Refreshed(scheduler.Enabled(), now)
+	_, ok := scheduler.NextRefresh()
+	require.False(t, ok)
+	require.Empty(t, scheduler.Due(now.AddDate(1, 0, 0)))
+}
+
+func TestScheduler_Hold(t *testing.T) {
+	scheduler, err := NewScheduler(Config{
+		IncludedLists: []search.SourceList{search.SourceUSOFAC},
+	}, time.Hour)
+	require.NoError(t, err)
+
+	_, err = scheduler.Approve(context.Background(), search.SourceUSOFAC)
+	require.ErrorIs(t, err, ErrNoHeldRefresh)
+
+	now := time.Now()
+	entities := []search.Entity[search.Value]{{Name: "held", Source: search.SourceUSOFAC}}
+	scheduler.Hold(search.SourceUSOFAC, entities, 10, now)
+
+	hold, ok := scheduler.Held(search.SourceUSOFAC)
+	require.True(t, ok)
+	require.Equal(t, ListHold{Entities: 1, Previous: 10, HeldAt: now}, hold)
+	for _, list := range scheduler.Lists() {
+		if list.List == search.SourceUSOFAC {
+			require.Equal(t, &hold, list.Hold)
+		}
+	}
+
+	released, ok := scheduler.Release(search.SourceUSOFAC)
+	require.True(t, ok)
+	require.Equal(t, entities, released)
+
+	_, ok = scheduler.Held(search.SourceUSOFAC)
+	require.False(t, ok)
+}
+
+func TestScheduler_Reconfigure(t *testing.T) {
+	scheduler, err := NewScheduler(Config{
+		IncludedLists: []search.SourceList{search.SourceUSOFAC, search.SourceUKCSL},
+		Schedules:     map[string]string{"us_ofac": "1h"},
+	}, 12*time.Hour)
+	require.NoError(t, err)
+
+	now := time.Date(2024, time.March, 8, 12, 0, 0, 0, time.UTC)
+	scheduler.Refreshed(scheduler.Enabled(), now)
+
+	// Invalid schedules don't change anything
+	err = scheduler.Reconfigure(Config{
+		IncludedLists: []search.SourceList{search.SourceEUCSL},
+		Schedules:     map[string]string{"eu_csl": "sometimes"},
+	}, time.Hour)
+	require.Error(t, err)
+	require.Equal(t, []search.SourceList{search.SourceUKCSL, search.SourceUSOFAC}, scheduler.Enabled())
+
+	err = scheduler.Reconfigure(Config{
+		IncludedLists: []search.SourceList{search.SourceUSOFAC, search.SourceEUCSL},
+		Schedules:     map[string]string{"eu_csl": "off"},
+	}, 6*time.Hour)
+	require.NoError(t, err)
+	<-scheduler.Changed()
+
+	require.Equal(t, []search.SourceList{search.SourceEUCSL, search.SourceUSOFAC}, scheduler.Enabled())
+	require.True(t, scheduler.IsDisabled(search.SourceUKCSL))
+
+	// Newly enabled lists are due right away, while the others keep their last refresh
+	require.Equal(t, []search.SourceList{search.SourceEUCSL}, scheduler.Due(now))
+	require.Equal(t, []search.SourceList{search.SourceEUCSL, search.SourceUSOFAC}, scheduler.Due(now.Add(6*time.Hour)))
+
+	for _, list := range scheduler.Lists() {
+		switch list.List {
+		case search.SourceEUCSL:
+			require.Equal(t, "off", list.Schedule)
+		case search.SourceUSOFAC:
+			require.Equal(t, "6h0m0s", list.Schedule)
+		}
+	}
+
+	// Expired lists are due right away
+	scheduler.Refreshed(scheduler.Enabled(), now)
+	scheduler.Expire(search.SourceUSOFAC, search.SourceUKCSL)
+	require.Equal(t, []search.SourceList{search.SourceUSOFAC}, scheduler.Due(now))
+}
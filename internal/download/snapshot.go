@@ -0,0 +1,77 @@
+package download
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// Snapshot is a saved copy of the prepared entities, read on startup so searches can be served
+// before the lists are downloaded again.
+type Snapshot struct {
+	SavedAt  time.Time                     `json:"savedAt"`
+	Entities []search.Entity[search.Value] `json:"entities"`
+}
+
+// WriteSnapshot saves entities to path as gzipped JSON. The file is replaced atomically
+// so a failed write never leaves a partial snapshot behind.
+func WriteSnapshot(path string, entities []search.Entity[search.Value]) error {
+	dir, filename := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	fd, err := os.CreateTemp(dir, filename+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating snapshot: %w", err)
+	}
+	defer os.Remove(fd.Name())
+
+	gz := gzip.NewWriter(fd)
+	err = json.NewEncoder(gz).Encode(Snapshot{
+		SavedAt:  time.Now().In(time.UTC),
+		Entities: entities,
+	})
+	if err != nil {
+		fd.Close()
+		return fmt.Errorf("encoding snapshot: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		fd.Close()
+		return fmt.Errorf("compressing snapshot: %w", err)
+	}
+	if err := fd.Close(); err != nil {
+		return fmt.Errorf("closing snapshot: %w", err)
+	}
+
+	if err := os.Rename(fd.Name(), path); err != nil {
+		return fmt.Errorf("replacing snapshot: %w", err)
+	}
+	return nil
+}
+
+// ReadSnapshot reads a snapshot saved by WriteSnapshot.
+func ReadSnapshot(path string) (*Snapshot, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening snapshot: %w", err)
+	}
+	defer fd.Close()
+
+	gz, err := gzip.NewReader(fd)
+	if err != nil {
+		return nil, fmt.Errorf("decompressing snapshot: %w", err)
+	}
+	defer gz.Close()
+
+	var snapshot Snapshot
+	if err := json.NewDecoder(gz).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("decoding snapshot: %w", err)
+	}
+	return &snapshot, nil
+}
@@ -0,0 +1,65 @@
+package download
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/ofac"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshot(t *testing.T) {
+	files := make(map[string]io.ReadCloser)
+	for _, name := range []string{"sdn.csv", "alt.csv", "add.csv", "sdn_comments.csv"} {
+		fd, err := os.Open(filepath.Join("..", "..", "pkg", "ofac", "testdata", name))
+		require.NoError(t, err)
+		files[name] = fd
+	}
+	res, err := ofac.Read(files)
+	require.NoError(t, err)
+
+	entities := ofac.GroupIntoEntities(res.SDNs, res.Addresses, res.SDNComments, res.AlternateIdentities)
+	require.NotEmpty(t, entities)
+
+	path := filepath.Join(t.TempDir(), "watchman.snapshot")
+	require.NoError(t, WriteSnapshot(path, entities))
+
+	snapshot, err := ReadSnapshot(path)
+	require.NoError(t, err)
+	require.False(t, snapshot.SavedAt.IsZero())
+	require.Len(t, snapshot.Entities, len(entities))
+	require.Equal(t, entities[0].Name, snapshot.Entities[0].Name)
+
+	// Restored entities aren't seen as changed
+	require.True(t, DiffEntities(snapshot.Entities, entities).Empty())
+
+	t.Run("overwrite", func(t *testing.T) {
+		require.NoError(t, WriteSnapshot(path, entities[:1]))
+
+		snapshot, err := ReadSnapshot(path)
+		require.NoError(t, err)
+		require.Len(t, snapshot.Entities, 1)
+
+		// No temporary files are left behind
+		matches, err := filepath.Glob(filepath.Join(filepath.Dir(path), "*.tmp"))
+		require.NoError(t, err)
+		require.Empty(t, matches)
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := ReadSnapshot(filepath.Join(t.TempDir(), "missing"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		require.NoError(t, WriteSnapshot(path, []search.Entity[search.Value]{}))
+
+		snapshot, err := ReadSnapshot(path)
+		require.NoError(t, err)
+		require.Empty(t, snapshot.Entities)
+	})
+}
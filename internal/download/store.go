@@ -0,0 +1,41 @@
+package download
+
+import (
+	"context"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// Store shares the downloaded entities and the history of refreshes between Watchman instances,
+// so one instance can download the lists and the others load its results.
+type Store interface {
+	// SaveRefresh replaces the stored entities with those of a completed refresh
+	SaveRefresh(ctx context.Context, refresh Refresh, entities []search.Entity[search.Value]) error
+
+	// LatestRefresh returns the most recently completed refresh, or nil when there isn't one
+	LatestRefresh(ctx context.Context) (*Refresh, error)
+
+	// ListRefreshes returns up to limit refreshes, newest first
+	ListRefreshes(ctx context.Context, limit int) ([]Refresh, error)
+
+	// LoadEntities returns the entities saved by the latest refresh
+	LoadEntities(ctx context.Context) ([]search.Entity[search.Value], error)
+
+	// AcquireRefreshLock claims the next refresh for host, returning false while another instance holds it
+	AcquireRefreshLock(ctx context.Context, host string, ttl time.Duration) (bool, error)
+	ReleaseRefreshLock(ctx context.Context, host string) error
+}
+
+// Refresh records a completed download of the lists
+type Refresh struct {
+	RefreshID string `json:"refreshID"`
+
+	// Host is the instance which downloaded the lists
+	Host string `json:"host"`
+
+	Lists map[string]int `json:"lists"`
+
+	StartedAt time.Time `json:"startedAt"`
+	EndedAt   time.Time `json:"endedAt"`
+}
@@ -0,0 +1,131 @@
+package download
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/internal/database"
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// NewSQLStore keeps the downloaded entities and refresh history in db
+func NewSQLStore(db *database.DB) Store {
+	return &sqlStore{db: db}
+}
+
+type sqlStore struct {
+	db *database.DB
+}
+
+const (
+	refreshLease = "list-refresh"
+
+	// entityInsertBatch is how many entities are inserted with each statement
+	entityInsertBatch = 200
+)
+
+func (s *sqlStore) SaveRefresh(ctx context.Context, refresh Refresh, entities []search.Entity[search.Value]) error {
+	bs, err := json.Marshal(refresh)
+	if err != nil {
+		return err
+	}
+
+	return s.db.InTx(ctx, func(tx *database.Tx) error {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM list_entities`); err != nil {
+			return fmt.Errorf("clearing entities: %w", err)
+		}
+
+		for start := 0; start < len(entities); start += entityInsertBatch {
+			end := start + entityInsertBatch
+			if end > len(entities) {
+				end = len(entities)
+			}
+
+			values := make([]string, 0, end-start)
+			args := make([]any, 0, 4*(end-start))
+			for i := start; i < end; i++ {
+				bs, err := json.Marshal(entities[i])
+				if err != nil {
+					return fmt.Errorf("encoding %s entity %s: %w", entities[i].Source, entities[i].SourceID, err)
+				}
+				values = append(values, "(?, ?, ?, ?)")
+				args = append(args, i, string(entities[i].Source), entities[i].SourceID, string(bs))
+			}
+
+			query := `INSERT INTO list_entities (position, source, source_id, data) VALUES ` + strings.Join(values, ", ")
+			if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+				return fmt.Errorf("saving entities: %w", err)
+			}
+		}
+
+		_, err := tx.ExecContext(ctx, `INSERT INTO list_refreshes (refresh_id, host, started_at, ended_at, data) VALUES (?, ?, ?, ?, ?)`,
+			refresh.RefreshID, refresh.Host, database.ToMillis(refresh.StartedAt), database.ToMillis(refresh.EndedAt), string(bs))
+		if err != nil {
+			return fmt.Errorf("saving refresh: %w", err)
+		}
+		return nil
+	})
+}
+
+func (s *sqlStore) LatestRefresh(ctx context.Context) (*Refresh, error) {
+	refreshes, err := s.ListRefreshes(ctx, 1)
+	if err != nil || len(refreshes) == 0 {
+		return nil, err
+	}
+	return &refreshes[0], nil
+}
+
+func (s *sqlStore) ListRefreshes(ctx context.Context, limit int) ([]Refresh, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT data FROM list_refreshes ORDER BY ended_at DESC, refresh_id DESC LIMIT ?`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Refresh
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var refresh Refresh
+		if err := json.Unmarshal([]byte(data), &refresh); err != nil {
+			return nil, fmt.Errorf("reading refresh: %w", err)
+		}
+		out = append(out, refresh)
+	}
+	return out, rows.Err()
+}
+
+func (s *sqlStore) LoadEntities(ctx context.Context) ([]search.Entity[search.Value], error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT data FROM list_entities ORDER BY position`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []search.Entity[search.Value]
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var entity search.Entity[search.Value]
+		if err := json.Unmarshal([]byte(data), &entity); err != nil {
+			return nil, fmt.Errorf("reading entity: %w", err)
+		}
+		out = append(out, entity)
+	}
+	return out, rows.Err()
+}
+
+func (s *sqlStore) AcquireRefreshLock(ctx context.Context, host string, ttl time.Duration) (bool, error) {
+	return s.db.AcquireLease(ctx, refreshLease, host, ttl)
+}
+
+func (s *sqlStore) ReleaseRefreshLock(ctx context.Context, host string) error {
+	return s.db.ReleaseLease(ctx, refreshLease, host)
+}
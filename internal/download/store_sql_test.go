@@ -0,0 +1,83 @@
+package download
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/database"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLStore(t *testing.T) {
+	ctx := context.Background()
+	store := NewSQLStore(database.NewTestDB(t))
+
+	latest, err := store.LatestRefresh(ctx)
+	require.NoError(t, err)
+	require.Nil(t, latest)
+
+	entities := make([]search.Entity[search.Value], 0, 2*entityInsertBatch+5)
+	for i := 0; i < cap(entities); i++ {
+		entities = append(entities, search.Entity[search.Value]{
+			Name:     "Acme Shipping",
+			Type:     search.EntityBusiness,
+			Source:   search.SourceUSOFAC,
+			SourceID: strconv.Itoa(i),
+			Business: &search.Business{Name: "Acme Shipping"},
+		})
+	}
+
+	now := time.Now().In(time.UTC).Truncate(time.Millisecond)
+	first := Refresh{
+		RefreshID: "r1",
+		Host:      "host-a",
+		Lists:     map[string]int{"us_ofac": len(entities)},
+		StartedAt: now.Add(-time.Minute),
+		EndedAt:   now.Add(-30 * time.Second),
+	}
+	require.NoError(t, store.SaveRefresh(ctx, first, entities))
+
+	loaded, err := store.LoadEntities(ctx)
+	require.NoError(t, err)
+	require.Equal(t, entities, loaded)
+
+	// Each refresh replaces the stored entities
+	second := Refresh{
+		RefreshID: "r2",
+		Host:      "host-b",
+		Lists:     map[string]int{"us_ofac": 1},
+		StartedAt: now.Add(-10 * time.Second),
+		EndedAt:   now,
+	}
+	require.NoError(t, store.SaveRefresh(ctx, second, entities[:1]))
+
+	loaded, err = store.LoadEntities(ctx)
+	require.NoError(t, err)
+	require.Equal(t, entities[:1], loaded)
+
+	latest, err = store.LatestRefresh(ctx)
+	require.NoError(t, err)
+	require.Equal(t, &second, latest)
+
+	refreshes, err := store.ListRefreshes(ctx, 10)
+	require.NoError(t, err)
+	require.Equal(t, []Refresh{second, first}, refreshes)
+
+	acquired, err := store.AcquireRefreshLock(ctx, "host-a", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	acquired, err = store.AcquireRefreshLock(ctx, "host-b", time.Minute)
+	require.NoError(t, err)
+	require.False(t, acquired)
+
+	require.NoError(t, store.ReleaseRefreshLock(ctx, "host-a"))
+
+	acquired, err = store.AcquireRefreshLock(ctx, "host-b", time.Minute)
+	require.NoError(t, err)
+	require.True(t, acquired)
+}
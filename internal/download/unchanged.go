@@ -0,0 +1,104 @@
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"slices"
+	"sync"
+
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+)
+
+// listCache keeps the prepared entities of each list along with a hash of the files they were read from,
+// so lists whose files haven't changed aren't parsed and prepared again
+type listCache struct {
+	mu    sync.Mutex
+	lists map[pubsearch.SourceList]cachedList
+}
+
+type cachedList struct {
+	hash     string
+	entities []pubsearch.Entity[pubsearch.Value]
+}
+
+func newListCache() *listCache {
+	return &listCache{
+		lists: make(map[pubsearch.SourceList]cachedList),
+	}
+}
+
+func (c *listCache) save(list pubsearch.SourceList, hash string, entities []pubsearch.Entity[pubsearch.Value]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if hash == "" {
+		delete(c.lists, list)
+		return
+	}
+	c.lists[list] = cachedList{hash: hash, entities: entities}
+}
+
+func (c *listCache) lookup(list pubsearch.SourceList) (cachedList, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, exists := c.lists[list]
+	return cached, exists
+}
+
+type listCacheKey struct{}
+
+func withListCache(ctx context.Context, cache *listCache) context.Context {
+	return context.WithValue(ctx, listCacheKey{}, cache)
+}
+
+// skipUnchanged returns the hash of a list's files and whether they're the same as when the list was last
+// loaded, in which case the files are closed and the list is sent as unchanged.
+func skipUnchanged(ctx context.Context, list pubsearch.SourceList, files map[string]io.ReadCloser, responseCh chan preparedList) (string, bool) {
+	hash := hashFiles(files)
+
+	cache, _ := ctx.Value(listCacheKey{}).(*listCache)
+	if cache == nil || hash == "" {
+		return hash, false
+	}
+	if cached, exists := cache.lookup(list); !exists || cached.hash != hash {
+		return hash, false
+	}
+
+	for _, fd := range files {
+		fd.Close()
+	}
+	responseCh <- preparedList{
+		ListName:  list,
+		Unchanged: true,
+	}
+	return hash, true
+}
+
+// hashFiles returns a SHA-256 of each file's name and contents, which is empty when any file can't be
+// read again afterwards, such as a response body.
+func hashFiles(files map[string]io.ReadCloser) string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		fd, ok := files[name].(io.ReadSeeker)
+		if !ok {
+			return ""
+		}
+		h.Write([]byte(name))
+		if _, err := io.Copy(h, fd); err != nil {
+			return ""
+		}
+		if _, err := fd.Seek(0, io.SeekStart); err != nil {
+			return ""
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
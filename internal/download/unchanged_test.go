@@ -0,0 +1,74 @@
+package download
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloader_Unchanged(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	// Copy the CA list so it can be changed between refreshes
+	bs, err := os.ReadFile(filepath.Join("..", "..", "pkg", "csl_ca", "testdata", "sema-lmes.xml"))
+	require.NoError(t, err)
+	path := filepath.Join(dir, "sema-lmes.xml")
+	require.NoError(t, os.WriteFile(path, bs, 0600))
+
+	dl, err := NewDownloader(log.NewTestLogger(), Config{
+		InitialDataDirectory: dir,
+		IncludedLists:        []search.SourceList{search.SourceCACSL},
+	})
+	require.NoError(t, err)
+
+	first, err := dl.RefreshAll(ctx)
+	require.NoError(t, err)
+	require.Empty(t, first.Unchanged)
+	require.Len(t, first.Entities, 3)
+
+	// The same files aren't parsed again
+	second, err := dl.RefreshAll(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{string(search.SourceCACSL)}, second.Unchanged)
+	require.Equal(t, first.Entities, second.Entities)
+
+	// Changed files are
+	changed := strings.Replace(string(bs), "SCF PRIMORYE", "SCF PRIMORYE II", 1)
+	require.NoError(t, os.WriteFile(path, []byte(changed), 0600))
+
+	third, err := dl.RefreshAll(ctx)
+	require.NoError(t, err)
+	require.Empty(t, third.Unchanged)
+	require.Equal(t, "SCF PRIMORYE II", third.Entities[2].Name)
+}
+
+func TestHashFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.csv")
+	require.NoError(t, os.WriteFile(path, []byte("a,b,c"), 0600))
+
+	fd, err := os.Open(path)
+	require.NoError(t, err)
+	defer fd.Close()
+
+	files := map[string]io.ReadCloser{"a.csv": fd}
+	hash := hashFiles(files)
+	require.NotEmpty(t, hash)
+	require.Equal(t, hash, hashFiles(files))
+
+	// Files are read from the start after they're hashed
+	bs, err := io.ReadAll(fd)
+	require.NoError(t, err)
+	require.Equal(t, "a,b,c", string(bs))
+
+	// Response bodies can't be hashed
+	require.Empty(t, hashFiles(map[string]io.ReadCloser{"a.csv": io.NopCloser(strings.NewReader("a,b,c"))}))
+}
@@ -0,0 +1,63 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type KafkaConfig struct {
+	Brokers []string
+	Topic   string
+}
+
+type kafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+func newKafkaPublisher(conf KafkaConfig) (*kafkaPublisher, error) {
+	if len(conf.Brokers) == 0 {
+		return nil, errors.New("kafka: missing brokers")
+	}
+	if conf.Topic == "" {
+		return nil, errors.New("kafka: missing topic")
+	}
+
+	return &kafkaPublisher{
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(conf.Brokers...),
+			Topic:        conf.Topic,
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		},
+	}, nil
+}
+
+func (p *kafkaPublisher) Publish(ctx context.Context, events ...Event) error {
+	messages := make([]kafka.Message, 0, len(events))
+	for _, event := range events {
+		bs, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("kafka: encoding %s event: %w", event.Type, err)
+		}
+		messages = append(messages, kafka.Message{
+			Key:   []byte(event.Key()),
+			Value: bs,
+			Headers: []kafka.Header{
+				{Key: "type", Value: []byte(event.Type)},
+			},
+		})
+	}
+
+	if err := p.writer.WriteMessages(ctx, messages...); err != nil {
+		return fmt.Errorf("kafka: writing %d events: %w", len(messages), err)
+	}
+	return nil
+}
+
+func (p *kafkaPublisher) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,58 @@
+package events
+
+import (
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// Event is published to Kafka or NATS for downstream systems, such as case management
+type Event struct {
+	EventID   string    `json:"eventID"`
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+
+	// One of the following is set, depending on Type
+
+	List   *ListRefreshed               `json:"list,omitempty"`
+	Entity *search.Entity[search.Value] `json:"entity,omitempty"`
+	Watch  *WatchMatched                `json:"watch,omitempty"`
+}
+
+const (
+	EventListRefreshed  = "list.refreshed"
+	EventEntityAdded    = "entity.added"
+	EventEntityModified = "entity.modified"
+	EventEntityRemoved  = "entity.removed"
+	EventWatchMatched   = "watch.matched"
+)
+
+// ListRefreshed counts the entities of a list after it's refreshed, along with what changed
+type ListRefreshed struct {
+	Name     string `json:"name"`
+	Entities int    `json:"entities"`
+
+	Added    int `json:"added"`
+	Modified int `json:"modified"`
+	Removed  int `json:"removed"`
+}
+
+// WatchMatched holds the matches found when a watch was rescreened
+type WatchMatched struct {
+	WatchID string                                `json:"watchID"`
+	Name    string                                `json:"name"`
+	Matches []search.SearchedEntity[search.Value] `json:"matches"`
+}
+
+// Key groups related events, so they're delivered in order to the same Kafka partition
+func (e Event) Key() string {
+	switch {
+	case e.List != nil:
+		return e.List.Name
+	case e.Entity != nil:
+		return string(e.Entity.Source) + "/" + e.Entity.SourceID
+	case e.Watch != nil:
+		return e.Watch.WatchID
+	}
+	return e.EventID
+}
@@ -0,0 +1,62 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+)
+
+type NATSConfig struct {
+	URL string
+
+	// Subject is prefixed onto each event's type, such as "watchman.watch.matched"
+	Subject string
+}
+
+type natsPublisher struct {
+	conn    *nats.Conn
+	subject string
+}
+
+func newNATSPublisher(conf NATSConfig) (*natsPublisher, error) {
+	if conf.URL == "" {
+		return nil, errors.New("nats: missing url")
+	}
+	if conf.Subject == "" {
+		conf.Subject = "watchman"
+	}
+
+	conn, err := nats.Connect(conf.URL, nats.Name("watchman"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, fmt.Errorf("nats: connecting: %w", err)
+	}
+	return &natsPublisher{
+		conn:    conn,
+		subject: conf.Subject,
+	}, nil
+}
+
+func (p *natsPublisher) Publish(ctx context.Context, events ...Event) error {
+	for _, event := range events {
+		bs, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("nats: encoding %s event: %w", event.Type, err)
+		}
+
+		msg := nats.NewMsg(p.subject + "." + event.Type)
+		msg.Header.Set("Nats-Msg-Id", event.EventID)
+		msg.Data = bs
+
+		if err := p.conn.PublishMsg(msg); err != nil {
+			return fmt.Errorf("nats: publishing %s event: %w", event.Type, err)
+		}
+	}
+	return p.conn.FlushWithContext(ctx)
+}
+
+func (p *natsPublisher) Close() error {
+	return p.conn.Drain()
+}
@@ -0,0 +1,122 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/moov-io/base"
+)
+
+// Publisher sends events to a message broker
+type Publisher interface {
+	Publish(ctx context.Context, events ...Event) error
+	Close() error
+}
+
+// Config chooses where events are published. Events are not published when neither is set.
+type Config struct {
+	Kafka *KafkaConfig
+	NATS  *NATSConfig
+}
+
+// NewPublisher connects to the configured broker, returning nil when events are disabled
+func NewPublisher(conf Config) (Publisher, error) {
+	switch {
+	case conf.Kafka != nil && conf.NATS != nil:
+		return nil, errors.New("events can be published to Kafka or NATS, not both")
+	case conf.Kafka != nil:
+		pub, err := newKafkaPublisher(*conf.Kafka)
+		if err != nil {
+			return nil, err
+		}
+		return pub, nil
+	case conf.NATS != nil:
+		pub, err := newNATSPublisher(*conf.NATS)
+		if err != nil {
+			return nil, err
+		}
+		return pub, nil
+	}
+	return nil, nil
+}
+
+// Multi publishes each event to every publisher which isn't nil, returning nil when all of them are
+func Multi(publishers ...Publisher) Publisher {
+	var out multiPublisher
+	for _, pub := range publishers {
+		if pub != nil {
+			out = append(out, pub)
+		}
+	}
+	switch len(out) {
+	case 0:
+		return nil
+	case 1:
+		return out[0]
+	}
+	return out
+}
+
+type multiPublisher []Publisher
+
+func (m multiPublisher) Publish(ctx context.Context, events ...Event) error {
+	var errs []error
+	for _, pub := range m {
+		if err := pub.Publish(ctx, events...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m multiPublisher) Close() error {
+	var errs []error
+	for _, pub := range m {
+		if err := pub.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// New returns an Event of eventType with its EventID and Timestamp set
+func New(eventType string) Event {
+	return Event{
+		EventID:   base.ID(),
+		Type:      eventType,
+		Timestamp: time.Now().In(time.UTC),
+	}
+}
+
+// NewInMemoryPublisher holds each event it's given, which is useful for tests
+func NewInMemoryPublisher() *InMemoryPublisher {
+	return &InMemoryPublisher{}
+}
+
+type InMemoryPublisher struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+func (p *InMemoryPublisher) Publish(ctx context.Context, events ...Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.events = append(p.events, events...)
+	return nil
+}
+
+func (p *InMemoryPublisher) Close() error {
+	return nil
+}
+
+// Events returns every published event, in order
+func (p *InMemoryPublisher) Events() []Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return slices.Clone(p.events)
+}
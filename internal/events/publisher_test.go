@@ -0,0 +1,77 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPublisher(t *testing.T) {
+	pub, err := NewPublisher(Config{})
+	require.NoError(t, err)
+	require.Nil(t, pub)
+
+	_, err = NewPublisher(Config{Kafka: &KafkaConfig{}, NATS: &NATSConfig{}})
+	require.ErrorContains(t, err, "not both")
+
+	_, err = NewPublisher(Config{Kafka: &KafkaConfig{Topic: "watchman"}})
+	require.ErrorContains(t, err, "missing brokers")
+
+	_, err = NewPublisher(Config{Kafka: &KafkaConfig{Brokers: []string{"localhost:9092"}}})
+	require.ErrorContains(t, err, "missing topic")
+
+	// Kafka connections are opened when events are published
+	pub, err = NewPublisher(Config{Kafka: &KafkaConfig{Brokers: []string{"localhost:9092"}, Topic: "watchman"}})
+	require.NoError(t, err)
+	require.NoError(t, pub.Close())
+
+	_, err = NewPublisher(Config{NATS: &NATSConfig{}})
+	require.ErrorContains(t, err, "missing url")
+}
+
+func TestEvent_Key(t *testing.T) {
+	event := New(EventEntityAdded)
+	event.Entity = &search.Entity[search.Value]{Source: search.SourceUSOFAC, SourceID: "123"}
+	require.Equal(t, "us_ofac/123", event.Key())
+
+	event = New(EventWatchMatched)
+	event.Watch = &WatchMatched{WatchID: "w1"}
+	require.Equal(t, "w1", event.Key())
+
+	event = New(EventListRefreshed)
+	event.List = &ListRefreshed{Name: "us_ofac"}
+	require.Equal(t, "us_ofac", event.Key())
+
+	bs, err := json.Marshal(event)
+	require.NoError(t, err)
+	require.NotContains(t, string(bs), "entity")
+	require.Contains(t, string(bs), `"type":"list.refreshed"`)
+}
+
+func TestInMemoryPublisher(t *testing.T) {
+	pub := NewInMemoryPublisher()
+	require.NoError(t, pub.Publish(context.Background(), New(EventListRefreshed), New(EventEntityRemoved)))
+
+	published := pub.Events()
+	require.Len(t, published, 2)
+	require.Equal(t, EventEntityRemoved, published[1].Type)
+	require.NotEqual(t, published[0].EventID, published[1].EventID)
+}
+
+func TestMulti(t *testing.T) {
+	require.Nil(t, Multi(nil, nil))
+
+	first := NewInMemoryPublisher()
+	require.Equal(t, first, Multi(nil, first))
+
+	second := NewInMemoryPublisher()
+	pub := Multi(first, nil, second)
+	require.NoError(t, pub.Publish(context.Background(), New(EventWatchMatched)))
+	require.Len(t, first.Events(), 1)
+	require.Len(t, second.Events(), 1)
+	require.NoError(t, pub.Close())
+}
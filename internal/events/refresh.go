@@ -0,0 +1,57 @@
+package events
+
+import (
+	"slices"
+
+	"github.com/moov-io/watchman/internal/download"
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+)
+
+// RefreshEvents returns a list.refreshed event for each list followed by an event for each added, modified and
+// removed entity. changes is nil after the initial refresh, when only list.refreshed events are returned.
+func RefreshEvents(changes *search.EntityChanges, current []pubsearch.Entity[pubsearch.Value]) []Event {
+	var counts map[pubsearch.SourceList]download.ListChanges
+	if changes != nil {
+		counts = download.CountChanges(*changes, current)
+	} else {
+		counts = download.CountChanges(search.EntityChanges{}, current)
+	}
+
+	lists := make([]string, 0, len(counts))
+	for list := range counts {
+		lists = append(lists, string(list))
+	}
+	slices.Sort(lists)
+
+	var out []Event
+	for _, list := range lists {
+		c := counts[pubsearch.SourceList(list)]
+
+		event := New(EventListRefreshed)
+		event.List = &ListRefreshed{
+			Name:     list,
+			Entities: c.Entities,
+			Added:    c.Added,
+			Modified: c.Modified,
+			Removed:  c.Removed,
+		}
+		out = append(out, event)
+	}
+	if changes == nil {
+		return out
+	}
+
+	entityEvents := func(eventType string, entities []pubsearch.Entity[pubsearch.Value]) {
+		for i := range entities {
+			event := New(eventType)
+			event.Entity = &entities[i]
+			out = append(out, event)
+		}
+	}
+	entityEvents(EventEntityAdded, changes.Added)
+	entityEvents(EventEntityModified, changes.Modified)
+	entityEvents(EventEntityRemoved, changes.Removed)
+
+	return out
+}
@@ -0,0 +1,40 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefreshEvents(t *testing.T) {
+	acme := pubsearch.Entity[pubsearch.Value]{Name: "Acme", Source: pubsearch.SourceUSOFAC, SourceID: "1"}
+	bravo := pubsearch.Entity[pubsearch.Value]{Name: "Bravo", Source: pubsearch.SourceUSOFAC, SourceID: "2"}
+	charlie := pubsearch.Entity[pubsearch.Value]{Name: "Charlie", Source: pubsearch.SourceEUCSL, SourceID: "3"}
+
+	current := []pubsearch.Entity[pubsearch.Value]{acme, bravo, charlie}
+
+	// Initial refreshes only describe the lists
+	initial := RefreshEvents(nil, current)
+	require.Len(t, initial, 2)
+	require.Equal(t, "eu_csl", initial[0].List.Name)
+	require.Equal(t, "us_ofac", initial[1].List.Name)
+	require.Equal(t, 2, initial[1].List.Entities)
+
+	removed := pubsearch.Entity[pubsearch.Value]{Name: "Delta", Source: pubsearch.SourceUSOFAC, SourceID: "4"}
+	got := RefreshEvents(&search.EntityChanges{
+		Added:    []pubsearch.Entity[pubsearch.Value]{bravo},
+		Modified: []pubsearch.Entity[pubsearch.Value]{charlie},
+		Removed:  []pubsearch.Entity[pubsearch.Value]{removed},
+	}, current)
+	require.Len(t, got, 5)
+
+	require.Equal(t, ListRefreshed{Name: "us_ofac", Entities: 2, Added: 1, Removed: 1}, *got[1].List)
+	require.Equal(t, EventEntityAdded, got[2].Type)
+	require.Equal(t, "2", got[2].Entity.SourceID)
+	require.Equal(t, EventEntityModified, got[3].Type)
+	require.Equal(t, EventEntityRemoved, got[4].Type)
+	require.Equal(t, "Delta", got[4].Entity.Name)
+}
@@ -0,0 +1,103 @@
+package geography
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/moov-io/watchman/pkg/address"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+)
+
+type Controller interface {
+	AppendRoutes(router *mux.Router) *mux.Router
+}
+
+func NewController(logger log.Logger, service Service) Controller {
+	return &controller{
+		logger:  logger,
+		service: service,
+	}
+}
+
+type controller struct {
+	logger  log.Logger
+	service Service
+}
+
+func (c *controller) AppendRoutes(router *mux.Router) *mux.Router {
+	router.
+		Name("Geography.screen").
+		Methods("GET").
+		Path("/v2/screen/geography").
+		HandlerFunc(c.screen)
+
+	router.
+		Name("Geography.jurisdictions").
+		Methods("GET").
+		Path("/v2/screen/geography/jurisdictions").
+		HandlerFunc(c.listJurisdictions)
+
+	return router
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (c *controller) writeError(w http.ResponseWriter, status int, err error) {
+	c.logger.Error().LogError(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error: err.Error(),
+	})
+}
+
+func (c *controller) screen(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	// Fields which aren't given are read from the address, when it can be parsed
+	line := strings.TrimSpace(q.Get("address"))
+	parsed := address.ParseAddress(line)
+
+	addr := search.Address{
+		Line1:      line,
+		City:       firstNonEmpty(q.Get("city"), parsed.City),
+		PostalCode: firstNonEmpty(q.Get("zip"), q.Get("postalCode"), parsed.PostalCode),
+		State:      firstNonEmpty(q.Get("state"), q.Get("province"), q.Get("providence"), parsed.State),
+		Country:    firstNonEmpty(q.Get("country"), parsed.Country),
+	}
+	if addr.Line1 == "" && addr.City == "" && addr.State == "" && addr.Country == "" {
+		c.writeError(w, http.StatusBadRequest, errors.New("one of address, city, state or country is required"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c.service.Screen(addr))
+}
+
+type listJurisdictionsResponse struct {
+	Jurisdictions []Jurisdiction `json:"jurisdictions"`
+}
+
+func (c *controller) listJurisdictions(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(listJurisdictionsResponse{
+		Jurisdictions: c.service.Jurisdictions(),
+	})
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
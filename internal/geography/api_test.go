@@ -0,0 +1,49 @@
+package geography
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestController(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), NewService(DefaultJurisdictions())).AppendRoutes(router)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/v2/screen/geography?city=Yalta&country=UA", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var result Result
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+	require.True(t, result.Sanctioned)
+	require.Equal(t, "Crimea", result.Matches[0].Region)
+	require.Equal(t, "UKRAINE-EO13685", result.Matches[0].Program)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/screen/geography?country=DE", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"sanctioned": false, "matches": []}`, w.Body.String())
+
+	// missing address
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/screen/geography", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/screen/geography/jurisdictions", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp listJurisdictionsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.NotEmpty(t, resp.Jurisdictions)
+}
@@ -0,0 +1,126 @@
+package geography
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+//go:embed jurisdictions.csv
+var defaultJurisdictionsFile []byte
+
+// Jurisdiction is a comprehensively sanctioned country, or an embargoed region within a country
+type Jurisdiction struct {
+	Country string `json:"country"` // ISO-3166 alpha-2 code
+	Program string `json:"program"`
+
+	// Region is the name of an embargoed region, such as Crimea. It's empty when the whole country is sanctioned.
+	Region string `json:"region,omitempty"`
+
+	// Places are the names of the region and its cities which are matched against an address
+	Places []string `json:"places,omitempty"`
+}
+
+// DefaultJurisdictions returns the sanctioned jurisdictions screened against unless a custom file is loaded
+func DefaultJurisdictions() []Jurisdiction {
+	jurisdictions, err := ReadJurisdictions(bytes.NewReader(defaultJurisdictionsFile))
+	if err != nil {
+		panic(fmt.Sprintf("reading default jurisdictions: %v", err)) //nolint:forbidigo
+	}
+	return jurisdictions
+}
+
+// LoadJurisdictionsFile reads the sanctioned jurisdictions of a custom file, which replace the defaults.
+//
+// Each line of the file lists a country's ISO-3166 code (or name), its sanctions program and, for an
+// embargoed region, the region's name followed by the places within it, separated by commas
+// (e.g. "UA,UKRAINE-EO13685,Crimea,crimea,sevastopol"). Lines starting with # are skipped.
+func LoadJurisdictionsFile(path string) ([]Jurisdiction, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening jurisdictions file: %w", err)
+	}
+	defer fd.Close()
+
+	jurisdictions, err := ReadJurisdictions(fd)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return jurisdictions, nil
+}
+
+// ReadJurisdictions reads sanctioned jurisdictions in the format of LoadJurisdictionsFile
+func ReadJurisdictions(r io.Reader) ([]Jurisdiction, error) {
+	var out []Jurisdiction
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.Split(text, ",")
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("line %d: expected a country followed by its program", line)
+		}
+		country, found := search.CountryCode(fields[0])
+		if !found {
+			return nil, fmt.Errorf("line %d: unknown country %q", line, strings.TrimSpace(fields[0]))
+		}
+		program := strings.TrimSpace(fields[1])
+		if program == "" {
+			return nil, fmt.Errorf("line %d: missing program", line)
+		}
+
+		jurisdiction := Jurisdiction{
+			Country: country,
+			Program: program,
+		}
+		if len(fields) > 2 {
+			jurisdiction.Region = strings.TrimSpace(fields[2])
+		}
+		if jurisdiction.Region != "" {
+			for _, place := range append([]string{jurisdiction.Region}, fields[3:]...) {
+				if place = foldPlace(place); place != "" && !slices.Contains(jurisdiction.Places, place) {
+					jurisdiction.Places = append(jurisdiction.Places, place)
+				}
+			}
+		}
+		out = append(out, jurisdiction)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// foldPlace lowercases a place and removes its accents and punctuation, so "Sevastópol" and
+// "SEVASTOPOL," are compared as "sevastopol"
+func foldPlace(place string) string {
+	place = strings.Map(func(r rune) rune {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			return -1
+		case r == '\'' || r == '’':
+			return -1
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, norm.NFD.String(place))
+	return strings.Join(strings.Fields(place), " ")
+}
@@ -0,0 +1,99 @@
+package geography
+
+import (
+	"strings"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// Service screens addresses against sanctioned jurisdictions
+type Service interface {
+	// Screen returns the sanctioned jurisdictions an address is within
+	Screen(addr search.Address) Result
+
+	Jurisdictions() []Jurisdiction
+}
+
+func NewService(jurisdictions []Jurisdiction) Service {
+	return &service{
+		jurisdictions: jurisdictions,
+	}
+}
+
+type service struct {
+	jurisdictions []Jurisdiction
+}
+
+// Result of screening an address, which is sanctioned when it's within any of the jurisdictions matched
+type Result struct {
+	Sanctioned bool    `json:"sanctioned"`
+	Matches    []Match `json:"matches"`
+}
+
+type Match struct {
+	Jurisdiction
+
+	// MatchedOn is the field of the address which was matched: country, state, city or address
+	MatchedOn string `json:"matchedOn"`
+}
+
+func (s *service) Jurisdictions() []Jurisdiction {
+	return s.jurisdictions
+}
+
+func (s *service) Screen(addr search.Address) Result {
+	country := strings.ToUpper(search.NormalizeCountry(strings.TrimSpace(addr.Country)))
+
+	// Regions are matched by name, so an address of "Simferopol" or a country written as "Crimea" is caught
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{"country", foldPlace(addr.Country)},
+		{"state", foldPlace(addr.State)},
+		{"city", foldPlace(addr.City)},
+		{"address", foldPlace(addr.Line1 + " " + addr.Line2)},
+	}
+
+	out := Result{
+		Matches: []Match{},
+	}
+	for _, jurisdiction := range s.jurisdictions {
+		if jurisdiction.Region == "" {
+			if country != "" && country == jurisdiction.Country {
+				out.Matches = append(out.Matches, Match{Jurisdiction: jurisdiction, MatchedOn: "country"})
+			}
+			continue
+		}
+
+		// An address in another country isn't within the region, even when a city shares its name
+		if country != "" && country != jurisdiction.Country && !hasPlace(jurisdiction.Places, fields[0].value, true) {
+			continue
+		}
+		for _, field := range fields {
+			within := field.name == "country" || field.name == "address"
+			if hasPlace(jurisdiction.Places, field.value, within) {
+				out.Matches = append(out.Matches, Match{Jurisdiction: jurisdiction, MatchedOn: field.name})
+				break
+			}
+		}
+	}
+	out.Sanctioned = len(out.Matches) > 0
+	return out
+}
+
+// hasPlace returns true when value is one of places, or contains one when within is set
+func hasPlace(places []string, value string, within bool) bool {
+	if value == "" {
+		return false
+	}
+	for _, place := range places {
+		if value == place {
+			return true
+		}
+		if within && strings.Contains(" "+value+" ", " "+place+" ") {
+			return true
+		}
+	}
+	return false
+}
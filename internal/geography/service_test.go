@@ -0,0 +1,69 @@
+package geography
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadJurisdictions(t *testing.T) {
+	jurisdictions := DefaultJurisdictions()
+	require.NotEmpty(t, jurisdictions)
+
+	input := `# comment
+Cuba,CUBA
+UA,UKRAINE-EO13685,Crimea,Sevastópol,SIMFEROPOL
+`
+	jurisdictions, err := ReadJurisdictions(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Equal(t, []Jurisdiction{
+		{Country: "CU", Program: "CUBA"},
+		{Country: "UA", Program: "UKRAINE-EO13685", Region: "Crimea", Places: []string{"crimea", "sevastopol", "simferopol"}},
+	}, jurisdictions)
+
+	_, err = ReadJurisdictions(strings.NewReader("Atlantis,ATL"))
+	require.ErrorContains(t, err, `line 1: unknown country "Atlantis"`)
+
+	_, err = ReadJurisdictions(strings.NewReader("\nIR"))
+	require.ErrorContains(t, err, "line 2: expected a country followed by its program")
+}
+
+func TestService_Screen(t *testing.T) {
+	svc := NewService(DefaultJurisdictions())
+
+	cases := []struct {
+		addr      search.Address
+		program   string
+		matchedOn string
+	}{
+		{search.Address{City: "Tehran", Country: "Iran"}, "IRAN", "country"},
+		{search.Address{Country: "CU"}, "CUBA", "country"},
+		{search.Address{Country: "Korea, North"}, "DPRK", "country"},
+		{search.Address{City: "Sevastopol", Country: "Ukraine"}, "UKRAINE-EO13685", "city"},
+		{search.Address{City: "Simferopol"}, "UKRAINE-EO13685", "city"},
+		{search.Address{Country: "Ukraine (Crimea)"}, "UKRAINE-EO13685", "country"},
+		{search.Address{State: "Luhansk", Country: "UA"}, "UKRAINE-EO14065", "state"},
+		{search.Address{Line1: "12 Artema St, Donetsk", Country: "UA"}, "UKRAINE-EO14065", "address"},
+	}
+	for _, tc := range cases {
+		result := svc.Screen(tc.addr)
+		require.True(t, result.Sanctioned, tc.addr.Format())
+		require.Len(t, result.Matches, 1, tc.addr.Format())
+		require.Equal(t, tc.program, result.Matches[0].Program)
+		require.Equal(t, tc.matchedOn, result.Matches[0].MatchedOn)
+	}
+
+	// Places outside of a sanctioned jurisdiction
+	for _, addr := range []search.Address{
+		{City: "Kyiv", Country: "UA"},
+		{City: "Donetsk", Country: "Russia"},
+		{City: "Paris", Country: "FR"},
+	} {
+		result := svc.Screen(addr)
+		require.False(t, result.Sanctioned, addr.Format())
+		require.Empty(t, result.Matches)
+	}
+}
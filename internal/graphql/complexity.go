@@ -0,0 +1,136 @@
+package graphql
+
+import (
+	"github.com/graph-gophers/graphql-go/ast"
+	query "github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+// complexity is the cost of the query's operation: each field it selects costs one, and the fields selected
+// within a field which takes a limit argument cost that many times more, or its default number of times.
+// Counting stops once the cost is over maxComplexity. It's zero when the query or its operation can't be
+// read, which the schema reports when the query is executed.
+func (s *Schema) complexity(req Request) int {
+	doc, err := parser.ParseQuery(&query.Source{Input: req.Query})
+	if err != nil {
+		return 0
+	}
+
+	var op *query.OperationDefinition
+	switch {
+	case req.OperationName != "":
+		op = doc.Operations.ForName(req.OperationName)
+	case len(doc.Operations) == 1:
+		op = doc.Operations[0]
+	}
+	if op == nil || op.Operation != query.Query {
+		return 0
+	}
+
+	c := &costs{
+		schema:    s.schema.AST(),
+		fragments: doc.Fragments,
+		vars:      req.Variables,
+		spreading: make(map[string]bool),
+	}
+	return c.selections("Query", op.SelectionSet)
+}
+
+type costs struct {
+	schema    *ast.Schema
+	fragments query.FragmentDefinitionList
+	vars      map[string]any
+
+	// spreading holds the fragments being counted, so those which spread themselves aren't counted forever
+	spreading map[string]bool
+}
+
+func (c *costs) selections(typeName string, set query.SelectionSet) int {
+	var total int
+	for _, sel := range set {
+		switch sel := sel.(type) {
+		case *query.Field:
+			total += c.field(typeName, sel)
+
+		case *query.InlineFragment:
+			on := typeName
+			if sel.TypeCondition != "" {
+				on = sel.TypeCondition
+			}
+			total += c.selections(on, sel.SelectionSet)
+
+		case *query.FragmentSpread:
+			frag := c.fragments.ForName(sel.Name)
+			if frag == nil || c.spreading[sel.Name] {
+				continue
+			}
+			c.spreading[sel.Name] = true
+			total += c.selections(frag.TypeCondition, frag.SelectionSet)
+			delete(c.spreading, sel.Name)
+		}
+		if total > maxComplexity {
+			break
+		}
+	}
+	return total
+}
+
+func (c *costs) field(typeName string, field *query.Field) int {
+	object, ok := c.schema.Types[typeName].(*ast.ObjectTypeDefinition)
+	if !ok || len(field.SelectionSet) == 0 {
+		return 1
+	}
+	def := object.Fields.Get(field.Name)
+	if def == nil {
+		return 1
+	}
+	return 1 + c.limit(def, field)*c.selections(namedType(def.Type), field.SelectionSet)
+}
+
+// limit is the value of the field's limit argument, which is one for fields without one
+func (c *costs) limit(def *ast.FieldDefinition, field *query.Field) int {
+	arg := def.Arguments.Get("limit")
+	if arg == nil {
+		return 1
+	}
+
+	var value any
+	if v := field.Arguments.ForName("limit"); v != nil {
+		value, _ = v.Value.Value(c.vars)
+	} else if arg.Default != nil {
+		value = arg.Default.Deserialize(nil)
+	}
+
+	var n int
+	switch v := value.(type) {
+	case int32:
+		n = int(v)
+	case int64:
+		n = int(v)
+	case float64:
+		n = int(v)
+	}
+	switch {
+	case n < 1:
+		return 1
+	case n > maxComplexity:
+		return maxComplexity
+	}
+	return n
+}
+
+// namedType returns the name of a field's type, without its list and non-null wrappers
+func namedType(t ast.Type) string {
+	for {
+		switch wrapped := t.(type) {
+		case *ast.List:
+			t = wrapped.OfType
+		case *ast.NonNull:
+			t = wrapped.OfType
+		case ast.NamedType:
+			return wrapped.TypeName()
+		default:
+			return ""
+		}
+	}
+}
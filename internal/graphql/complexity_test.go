@@ -0,0 +1,47 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestComplexity(t *testing.T) {
+	schema := testSchema(t)
+
+	cases := []struct {
+		req      Request
+		expected int
+	}{
+		{Request{Query: `{ broken }`}, 1},
+		{Request{Query: `{ broken __typename }`}, 2},
+
+		// Fields with a limit count their selections that many times, or their default number of times
+		{Request{Query: `{ search(name: "a") { name } }`}, 11},
+		{Request{Query: `{ search(name: "a", limit: 2) { name person { name friend { name } } } }`}, 11},
+		{Request{Query: `{ search(name: "a", limit: 0) { name } }`}, 2},
+		{Request{Query: `{ a: search(name: "a", limit: 1) { name } b: search(name: "b", limit: 1) { name } }`}, 4},
+		{Request{
+			Query:     `query ($limit: Int) { search(name: "a", limit: $limit) { name } }`,
+			Variables: map[string]any{"limit": float64(3)},
+		}, 4},
+
+		// Fragments are counted where they're spread
+		{Request{Query: `{ search(name: "a", limit: 2) { ...F ... on Result { match } } } fragment F on Result { name person { name } }`}, 9},
+		{Request{Query: `{ search(name: "a", limit: 1) { ...F } } fragment F on Result { person { ...F } }`}, 2},
+
+		// Operations are chosen by name
+		{Request{Query: `query A { broken } query B { broken broken }`, OperationName: "B"}, 2},
+
+		// The schema reports queries whose cost can't be read
+		{Request{Query: `query A { broken } query B { broken }`}, 0},
+		{Request{Query: `{ broken`}, 0},
+		{Request{Query: `mutation { broken }`}, 0},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.expected, schema.complexity(tc.req), tc.req.Query)
+	}
+
+	// Counting stops once it's over the limit
+	require.Equal(t, maxComplexity+1, schema.complexity(Request{Query: `{ search(name: "a", limit: 1000000) { name } }`}))
+}
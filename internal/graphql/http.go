@@ -0,0 +1,67 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	maxRequestSize = 1 << 20
+)
+
+type requestKey struct{}
+
+// HTTPRequest returns the request a query was sent in, so resolvers can read its headers. It's nil when the
+// query wasn't executed by ServeHTTP.
+func HTTPRequest(ctx context.Context) *http.Request {
+	r, _ := ctx.Value(requestKey{}).(*http.Request)
+	return r
+}
+
+// ServeHTTP executes a query sent as a JSON Request in the body of a POST, or in the query, operationName and
+// variables parameters of a GET. Responses of valid queries are 200 OK, even when some of their fields failed.
+func (s *Schema) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	var err error
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if v := q.Get("variables"); v != "" {
+			if err = json.Unmarshal([]byte(v), &req.Variables); err != nil {
+				err = fmt.Errorf("reading variables: %w", err)
+			}
+		}
+
+	case http.MethodPost:
+		err = json.NewDecoder(io.LimitReader(r.Body, maxRequestSize)).Decode(&req)
+		if err != nil {
+			err = fmt.Errorf("reading GraphQL request: %w", err)
+		}
+
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if err == nil && req.Query == "" {
+		err = fmt.Errorf("missing query")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(requestError(err))
+		return
+	}
+
+	resp := s.Execute(context.WithValue(r.Context(), requestKey{}, r), req)
+	if len(resp.Data) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
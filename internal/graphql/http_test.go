@@ -0,0 +1,77 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type testTenant struct {
+	ID string
+}
+
+type tenantResolver struct{}
+
+func (*tenantResolver) Tenant(ctx context.Context) testTenant {
+	return testTenant{ID: HTTPRequest(ctx).Header.Get("X-Tenant-ID")}
+}
+
+func TestServeHTTP(t *testing.T) {
+	schema, err := NewSchema(&tenantResolver{}, `
+		type Tenant { id: String! }
+		extend type Query { tenant: Tenant! }
+	`)
+	require.NoError(t, err)
+
+	read := func(w *httptest.ResponseRecorder) (any, []*errors.QueryError) {
+		t.Helper()
+
+		var resp Response
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+
+		var data any
+		if len(resp.Data) > 0 {
+			require.NoError(t, json.Unmarshal(resp.Data, &data))
+		}
+		return data, resp.Errors
+	}
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/v2/graphql", strings.NewReader(`{"query": "query Tenant { tenant { id } }", "operationName": "Tenant"}`))
+	req.Header.Set("X-Tenant-ID", "acme")
+	schema.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	data, _ := read(w)
+	require.Equal(t, map[string]any{"tenant": map[string]any{"id": "acme"}}, data)
+
+	w = httptest.NewRecorder()
+	schema.ServeHTTP(w, httptest.NewRequest("GET", "/v2/graphql?query="+url.QueryEscape("{ tenant { id } }"), nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	data, _ = read(w)
+	require.Equal(t, map[string]any{"tenant": map[string]any{"id": ""}}, data)
+
+	// invalid queries
+	w = httptest.NewRecorder()
+	schema.ServeHTTP(w, httptest.NewRequest("POST", "/v2/graphql", strings.NewReader(`{"query": "{ other }"}`)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	_, errs := read(w)
+	require.Equal(t, `Cannot query field "other" on type "Query".`, errs[0].Message)
+
+	w = httptest.NewRecorder()
+	schema.ServeHTTP(w, httptest.NewRequest("POST", "/v2/graphql", strings.NewReader(`{}`)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	_, errs = read(w)
+	require.Equal(t, "missing query", errs[0].Message)
+
+	w = httptest.NewRecorder()
+	schema.ServeHTTP(w, httptest.NewRequest("DELETE", "/v2/graphql", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
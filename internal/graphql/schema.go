@@ -0,0 +1,136 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	graphqlgo "github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
+)
+
+const (
+	// maxDepth is how deeply fields can be nested in a query, counting the fields of its fragments
+	maxDepth = 10
+
+	// maxComplexity is the highest cost of a query which is executed, see complexity
+	maxComplexity = 10000
+
+	// maxQueryLength is the size of the largest query in bytes
+	maxQueryLength = 16 << 10
+)
+
+// baseSchema holds the Query type, which the schema of each package extends with its fields, and the scalars
+// shared between them
+const baseSchema = `
+schema {
+	query: Query
+}
+
+"""
+An RFC 3339 timestamp, such as 2024-03-03T12:00:00Z
+"""
+scalar Time
+
+"""
+Any JSON value, for fields without a fixed shape such as the sourceData of entities
+"""
+scalar JSON
+
+type Query {}
+`
+
+// Schema answers GraphQL queries of the fields packages add to the Query type. Mutations and subscriptions
+// aren't supported.
+type Schema struct {
+	schema *graphqlgo.Schema
+}
+
+// NewSchema parses schemas, which are the types of each package along with their fields of the Query type
+// added with "extend type Query". Fields of the Query type are resolved by the methods of resolver, and the
+// fields of other types by the methods or struct fields of the values those return, matched by name
+// ignoring case.
+func NewSchema(resolver any, schemas ...string) (*Schema, error) {
+	schema, err := graphqlgo.ParseSchema(baseSchema+strings.Join(schemas, "\n"), resolver,
+		graphqlgo.UseStringDescriptions(),
+		graphqlgo.UseFieldResolvers(),
+		graphqlgo.MaxDepth(maxDepth),
+		graphqlgo.MaxQueryLength(maxQueryLength),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("parsing GraphQL schema: %w", err)
+	}
+	return &Schema{schema: schema}, nil
+}
+
+// Request is a query along with the values of its variables, as sent to a GraphQL endpoint
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// Response holds the data selected by a query, along with the errors of any fields which couldn't be resolved.
+// Data is empty when the query itself is invalid.
+type Response = graphqlgo.Response
+
+// Execute runs the query's operation, unless it's more complex than the limit
+func (s *Schema) Execute(ctx context.Context, req Request) *Response {
+	if len(req.Query) <= maxQueryLength && s.complexity(req) > maxComplexity {
+		return requestError(fmt.Errorf("query is more complex than the limit of %d", maxComplexity))
+	}
+	return s.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+}
+
+func requestError(err error) *Response {
+	return &Response{
+		Errors: []*errors.QueryError{{Message: err.Error()}},
+	}
+}
+
+// Time is the Go type of the Time scalar
+type Time = graphqlgo.Time
+
+// JSON is the Go type of the JSON scalar, which holds the value as encoded
+type JSON json.RawMessage
+
+func (JSON) ImplementsGraphQLType(name string) bool {
+	return name == "JSON"
+}
+
+func (j *JSON) UnmarshalGraphQL(input any) error {
+	bs, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	*j = bs
+	return nil
+}
+
+func (j JSON) MarshalJSON() ([]byte, error) {
+	if len(j) == 0 {
+		return []byte("null"), nil
+	}
+	return j, nil
+}
+
+func (j *JSON) UnmarshalJSON(bs []byte) error {
+	*j = append((*j)[:0], bs...)
+	return nil
+}
+
+// Convert returns v as a T by way of its JSON, so the models of the REST API can be resolved by types whose
+// fields have the Go types of the schema, such as int32 for Int. Fields of T are matched to those in v's JSON
+// by name, ignoring case.
+func Convert[T any](v any) (T, error) {
+	var out T
+	bs, err := json.Marshal(v)
+	if err != nil {
+		return out, err
+	}
+	if err := json.Unmarshal(bs, &out); err != nil {
+		return out, fmt.Errorf("converting %T to %T: %w", v, out, err)
+	}
+	return out, nil
+}
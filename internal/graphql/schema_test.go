@@ -0,0 +1,213 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type testPerson struct {
+	Name      string
+	BirthDate *Time
+	Friend    *testPerson
+}
+
+type testResult struct {
+	Name   string
+	Match  float64
+	Person *testPerson
+	Data   *JSON
+}
+
+type testResolver struct{}
+
+func (*testResolver) Search(args struct {
+	Name  string
+	Limit int32
+}) []testResult {
+	data := JSON(`{"p":1}`)
+	out := []testResult{
+		{
+			Name:   args.Name,
+			Match:  0.95,
+			Person: &testPerson{Name: args.Name, BirthDate: &Time{Time: time.Date(1970, time.March, 4, 0, 0, 0, 0, time.UTC)}},
+			Data:   &data,
+		},
+		{
+			Name:  "Other",
+			Match: 0.5,
+		},
+	}
+	if int(args.Limit) < len(out) {
+		out = out[:args.Limit]
+	}
+	return out
+}
+
+func (*testResolver) Broken() (*string, error) {
+	return nil, errors.New("something went wrong")
+}
+
+const testSchemaSDL = `
+type Person {
+	name: String!
+	birthDate: Time
+	friend: Person
+}
+
+type Result {
+	name: String!
+	match: Float!
+	person: Person
+	data: JSON
+}
+
+extend type Query {
+	search(name: String!, limit: Int = 10): [Result!]!
+	broken: String
+}
+`
+
+func testSchema(t *testing.T) *Schema {
+	t.Helper()
+
+	schema, err := NewSchema(&testResolver{}, testSchemaSDL)
+	require.NoError(t, err)
+	return schema
+}
+
+func execute(t *testing.T, schema *Schema, req Request) (string, []string) {
+	t.Helper()
+
+	resp := schema.Execute(context.Background(), req)
+
+	var errs []string
+	for _, err := range resp.Errors {
+		errs = append(errs, err.Message)
+	}
+	return string(resp.Data), errs
+}
+
+func TestExecute(t *testing.T) {
+	schema := testSchema(t)
+
+	data, errs := execute(t, schema, Request{
+		Query: `query ($name: String!, $withPerson: Boolean = true) {
+			search(name: $name, limit: 1) {
+				fullName: name
+				...Match
+				person @include(if: $withPerson) { birthDate __typename }
+				data
+			}
+		}
+		fragment Match on Result { match }`,
+		Variables: map[string]any{"name": "John Smith"},
+	})
+	require.Empty(t, errs)
+	require.JSONEq(t, `{"search":[{"fullName":"John Smith","match":0.95,"person":{"birthDate":"1970-03-04T00:00:00Z","__typename":"Person"},"data":{"p":1}}]}`, data)
+
+	// Nil values are null
+	data, errs = execute(t, schema, Request{Query: `{ search(name: "") { name person { name } data } }`})
+	require.Empty(t, errs)
+	require.JSONEq(t, `{"search":[{"name":"","person":{"name":""},"data":{"p":1}},{"name":"Other","person":null,"data":null}]}`, data)
+
+	// Fields which fail are null
+	resp := schema.Execute(context.Background(), Request{Query: `{ broken search(name: "a", limit: 1) { name } }`})
+	require.JSONEq(t, `{"broken":null,"search":[{"name":"a"}]}`, string(resp.Data))
+	require.Len(t, resp.Errors, 1)
+	require.Equal(t, "something went wrong", resp.Errors[0].Message)
+	require.Equal(t, []any{"broken"}, resp.Errors[0].Path)
+
+	// The schema can be introspected
+	data, errs = execute(t, schema, Request{Query: `{ __type(name: "Result") { fields { name } } }`})
+	require.Empty(t, errs)
+	require.JSONEq(t, `{"__type":{"fields":[{"name":"name"},{"name":"match"},{"name":"person"},{"name":"data"}]}}`, data)
+}
+
+func TestExecute_Invalid(t *testing.T) {
+	schema := testSchema(t)
+
+	cases := map[string]Request{
+		`Cannot query field "entities" on type "Query".`:  {Query: `{ entities { name } }`},
+		`Unknown argument "type" on field "Query.search"`: {Query: `{ search(name: "a", type: person) { name } }`},
+		`Variable "name" has invalid value null.`:         {Query: `query ($name: String!) { search(name: $name) { name } }`},
+		`Unknown fragment "Names".`:                       {Query: `{ search(name: "a") { ...Names } }`},
+		"no mutations are offered by the schema":          {Query: `mutation { broken }`},
+	}
+	for expected, req := range cases {
+		data, errs := execute(t, schema, req)
+		require.Empty(t, data, req.Query)
+		require.NotEmpty(t, errs, req.Query)
+		require.Contains(t, errs[0], expected, req.Query)
+	}
+}
+
+func TestExecute_Limits(t *testing.T) {
+	schema := testSchema(t)
+
+	// Fields can be nested ten deep
+	friends := func(n int) string {
+		return strings.Repeat("friend { ", n) + "name" + strings.Repeat(" }", n)
+	}
+	data, errs := execute(t, schema, Request{Query: `{ search(name: "a", limit: 1) { person { ` + friends(7) + ` } } }`})
+	require.Empty(t, errs)
+	require.NotEmpty(t, data)
+
+	_, errs = execute(t, schema, Request{Query: `{ search(name: "a", limit: 1) { person { ` + friends(8) + ` } } }`})
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0], "exceeds max depth 10")
+
+	// Limits multiply the cost of the fields they select
+	_, errs = execute(t, schema, Request{Query: `{ search(name: "a", limit: 4999) { name match } }`})
+	require.Empty(t, errs)
+
+	_, errs = execute(t, schema, Request{Query: `{ search(name: "a", limit: 5000) { name match } }`})
+	require.Equal(t, []string{"query is more complex than the limit of 10000"}, errs)
+
+	_, errs = execute(t, schema, Request{
+		Query:     `query ($limit: Int) { a: search(name: "a", limit: $limit) { name } b: search(name: "b", limit: $limit) { name } }`,
+		Variables: map[string]any{"limit": float64(5000)},
+	})
+	require.Equal(t, []string{"query is more complex than the limit of 10000"}, errs)
+
+	_, errs = execute(t, schema, Request{Query: `{ broken }` + strings.Repeat(" ", maxQueryLength)})
+	require.Len(t, errs, 1)
+	require.Contains(t, errs[0], "exceeds the maximum allowed query length")
+}
+
+func TestConvert(t *testing.T) {
+	type model struct {
+		Name      string         `json:"name"`
+		Count     int            `json:"count"`
+		BirthDate *time.Time     `json:"birthDate"`
+		Lists     map[string]int `json:"lists"`
+	}
+	type view struct {
+		Name      string
+		Count     int32
+		BirthDate *Time
+		Lists     *JSON
+	}
+
+	birthDate := time.Date(1970, time.March, 4, 0, 0, 0, 0, time.UTC)
+	out, err := Convert[view](model{Name: "John", Count: 2, BirthDate: &birthDate, Lists: map[string]int{"us_ofac": 1}})
+	require.NoError(t, err)
+	require.Equal(t, "John", out.Name)
+	require.Equal(t, int32(2), out.Count)
+	require.True(t, birthDate.Equal(out.BirthDate.Time))
+	require.JSONEq(t, `{"us_ofac":1}`, string(*out.Lists))
+
+	out, err = Convert[view](model{})
+	require.NoError(t, err)
+	require.Nil(t, out.BirthDate)
+	require.Nil(t, out.Lists)
+
+	bs, err := json.Marshal(JSON(nil))
+	require.NoError(t, err)
+	require.Equal(t, "null", string(bs))
+}
@@ -0,0 +1,335 @@
+package jobs
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/auth"
+	"github.com/moov-io/watchman/internal/customlists"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+)
+
+var (
+	// maxRequestSize limits how much of a submitted job is read
+	maxRequestSize int64 = 256 << 20 // 256MB
+)
+
+type Controller interface {
+	AppendRoutes(router *mux.Router) *mux.Router
+}
+
+func NewController(logger log.Logger, service Service) Controller {
+	return &controller{
+		logger:  logger,
+		service: service,
+	}
+}
+
+type controller struct {
+	logger  log.Logger
+	service Service
+}
+
+func (c *controller) AppendRoutes(router *mux.Router) *mux.Router {
+	router.
+		Name("Jobs.submit").
+		Methods("POST").
+		Path("/v2/jobs").
+		HandlerFunc(c.submitJob)
+
+	router.
+		Name("Jobs.list").
+		Methods("GET").
+		Path("/v2/jobs").
+		HandlerFunc(c.listJobs)
+
+	router.
+		Name("Jobs.get").
+		Methods("GET").
+		Path("/v2/jobs/{jobID}").
+		HandlerFunc(c.getJob)
+
+	router.
+		Name("Jobs.results").
+		Methods("GET").
+		Path("/v2/jobs/{jobID}/results").
+		HandlerFunc(c.getResults)
+
+	router.
+		Name("Jobs.delete").
+		Methods("DELETE").
+		Path("/v2/jobs/{jobID}").
+		HandlerFunc(c.deleteJob)
+
+	return router
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (c *controller) writeError(w http.ResponseWriter, status int, err error) {
+	c.logger.Error().LogError(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error: err.Error(),
+	})
+}
+
+type submitRequest struct {
+	Limit    int     `json:"limit"`
+	MinMatch float64 `json:"minMatch"`
+
+	// Changed only screens against the entities changed by the latest list refresh
+	Changed bool `json:"changed"`
+
+	WebhookURL string `json:"webhookURL"`
+	Secret     string `json:"secret"`
+
+	Entities []pubsearch.Entity[pubsearch.Value] `json:"entities"`
+
+	// Records are a simpler form of entities, which is also read from CSV uploads
+	Records []customlists.Record `json:"records"`
+}
+
+// readRequest reads a JSON submitRequest, or CSV records when the Content-Type is text/csv. The options
+// of a CSV upload are read from query parameters.
+func readRequest(r *http.Request) (Request, error) {
+	var body submitRequest
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "text/csv" {
+		q := r.URL.Query()
+		if v := q.Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil {
+				return Request{}, fmt.Errorf("invalid limit %q", v)
+			}
+			body.Limit = n
+		}
+		if v := q.Get("minMatch"); v != "" {
+			n, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return Request{}, fmt.Errorf("invalid minMatch %q", v)
+			}
+			body.MinMatch = n
+		}
+		body.WebhookURL = q.Get("webhookURL")
+		body.Changed = strings.EqualFold(q.Get("changed"), "true")
+
+		records, err := customlists.ReadCSV(r.Body)
+		if err != nil {
+			return Request{}, fmt.Errorf("reading csv: %w", err)
+		}
+		body.Records = records
+	} else {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			return Request{}, fmt.Errorf("reading job: %w", err)
+		}
+	}
+
+	tenantID, err := auth.RequestTenant(r)
+	if err != nil {
+		return Request{}, err
+	}
+	req := Request{
+		Limit:      body.Limit,
+		MinMatch:   body.MinMatch,
+		TenantID:   tenantID,
+		Changed:    body.Changed,
+		WebhookURL: body.WebhookURL,
+		Secret:     body.Secret,
+		Queries:    body.Entities,
+	}
+	for i, record := range body.Records {
+		entity, err := record.Entity()
+		if err != nil {
+			return Request{}, fmt.Errorf("record %d: %w", i+1, err)
+		}
+		req.Queries = append(req.Queries, entity)
+	}
+	for i := range req.Queries {
+		req.Queries[i].Source = pubsearch.SourceAPIRequest
+	}
+	return req, nil
+}
+
+func (c *controller) submitJob(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+
+	req, err := readRequest(r)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, auth.ErrOtherTenant) {
+			status = http.StatusForbidden
+		}
+		c.writeError(w, status, err)
+		return
+	}
+
+	job, err := c.service.Submit(req)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrQueueFull) {
+			status = http.StatusServiceUnavailable
+		}
+		c.writeError(w, status, fmt.Errorf("submitting job: %w", err))
+		return
+	}
+
+	// The secret is only returned when the job is submitted
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(job)
+}
+
+type listJobsResponse struct {
+	Jobs []Job `json:"jobs"`
+}
+
+func (c *controller) listJobs(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := auth.RequestTenant(r)
+	if err != nil {
+		c.writeError(w, http.StatusForbidden, err)
+		return
+	}
+	jobs, err := c.service.List(tenantID)
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("listing jobs: %w", err))
+		return
+	}
+	for i := range jobs {
+		jobs[i].Secret = ""
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(listJobsResponse{
+		Jobs: jobs,
+	})
+}
+
+// findJob returns the job from the request's path, or nil when it doesn't exist or belongs to another tenant
+func (c *controller) findJob(w http.ResponseWriter, r *http.Request) *Job {
+	tenantID, err := auth.RequestTenant(r)
+	if err != nil {
+		c.writeError(w, http.StatusForbidden, err)
+		return nil
+	}
+	job, err := c.service.Get(mux.Vars(r)["jobID"])
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("getting job: %w", err))
+		return nil
+	}
+	if job == nil || job.TenantID != tenantID {
+		w.WriteHeader(http.StatusNotFound)
+		return nil
+	}
+	job.Secret = ""
+	return job
+}
+
+func (c *controller) getJob(w http.ResponseWriter, r *http.Request) {
+	job := c.findJob(w, r)
+	if job == nil {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(job)
+}
+
+type resultsResponse struct {
+	Job     Job      `json:"job"`
+	Results []Result `json:"results"`
+}
+
+func (c *controller) getResults(w http.ResponseWriter, r *http.Request) {
+	job := c.findJob(w, r)
+	if job == nil {
+		return
+	}
+	if job.Status != StatusCompleted {
+		c.writeError(w, http.StatusConflict, fmt.Errorf("job %s is %s", job.JobID, job.Status))
+		return
+	}
+
+	results, err := c.service.Results(job.JobID)
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("getting job results: %w", err))
+		return
+	}
+	if strings.EqualFold(r.URL.Query().Get("matched"), "true") {
+		matched := make([]Result, 0, job.Matched)
+		for _, result := range results {
+			if len(result.Entities) > 0 {
+				matched = append(matched, result)
+			}
+		}
+		results = matched
+	}
+
+	if strings.EqualFold(r.URL.Query().Get("format"), "csv") {
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, job.JobID))
+		if err := writeResultsCSV(w, results); err != nil {
+			c.logger.Error().LogErrorf("problem writing job results: %v", err)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resultsResponse{
+		Job:     *job,
+		Results: results,
+	})
+}
+
+// writeResultsCSV writes a row for each match, and a row without an entity for each query which had no matches
+func writeResultsCSV(w io.Writer, results []Result) error {
+	out := csv.NewWriter(w)
+	out.Write([]string{"index", "queryName", "querySourceID", "match", "entityName", "entityType", "source", "sourceID"})
+
+	for _, result := range results {
+		query := []string{strconv.Itoa(result.Index), result.Query.Name, result.Query.SourceID}
+		if len(result.Entities) == 0 {
+			out.Write(append(query[:3:3], "", "", "", "", ""))
+			continue
+		}
+		for _, entity := range result.Entities {
+			out.Write(append(query[:3:3],
+				strconv.FormatFloat(entity.Match, 'f', 4, 64),
+				entity.Name,
+				string(entity.Type),
+				string(entity.Source),
+				entity.SourceID,
+			))
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
+
+func (c *controller) deleteJob(w http.ResponseWriter, r *http.Request) {
+	job := c.findJob(w, r)
+	if job == nil {
+		return
+	}
+	err := c.service.Delete(job.JobID)
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("deleting job: %w", err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
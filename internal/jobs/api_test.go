@@ -0,0 +1,166 @@
+package jobs
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/auth"
+	"github.com/moov-io/watchman/internal/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestController(t *testing.T) {
+	svc := testService(t, nil)
+
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), svc).AppendRoutes(router)
+
+	// submit
+	w := httptest.NewRecorder()
+	body := `{"minMatch": 0.8, "records": [{"name": "Nicolas Maduro", "type": "person"}, {"name": "Jane Doe", "type": "person"}]}`
+	req := httptest.NewRequest("POST", "/v2/jobs", strings.NewReader(body))
+	req.Header.Set("X-Tenant-ID", "acme")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	var job Job
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&job))
+	require.Equal(t, 2, job.Total)
+	require.Equal(t, "acme", job.TenantID)
+	waitForJob(t, svc, job.JobID)
+
+	// get
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/jobs/"+job.JobID+"?tenantID=acme", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var found Job
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&found))
+	require.Equal(t, StatusCompleted, found.Status)
+	require.Equal(t, 1, found.Matched)
+
+	// jobs of other tenants aren't found
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/jobs/"+job.JobID, nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	// clients can't ask for the jobs of another tenant, and don't find them
+	ctx := auth.WithClient(context.Background(), &auth.Client{Name: "payments", Tenant: "globex"})
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/jobs/"+job.JobID+"?tenantID=acme", nil)
+	router.ServeHTTP(w, req.WithContext(ctx))
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/jobs/"+job.JobID, nil)
+	router.ServeHTTP(w, req.WithContext(ctx))
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	// list
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/jobs?tenantID=acme", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var list listJobsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&list))
+	require.Len(t, list.Jobs, 1)
+
+	// results
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/jobs/"+job.JobID+"/results?tenantID=acme&matched=true", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var results resultsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&results))
+	require.Len(t, results.Results, 1)
+	require.Equal(t, "22790", results.Results[0].Entities[0].SourceID)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/jobs/"+job.JobID+"/results?tenantID=acme&format=csv", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+
+	rows, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Equal(t, []string{"0", "Nicolas Maduro", ""}, rows[1][:3])
+	require.Equal(t, "22790", rows[1][7])
+	require.Equal(t, []string{"1", "Jane Doe", "", "", "", "", "", ""}, rows[2])
+
+	// delete
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/v2/jobs/"+job.JobID+"?tenantID=acme", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/jobs/"+job.JobID+"?tenantID=acme", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestController_CSV(t *testing.T) {
+	svc := testService(t, nil)
+
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), svc).AppendRoutes(router)
+
+	w := httptest.NewRecorder()
+	body := "name,type\nAcme Shipping,business\n"
+	req := httptest.NewRequest("POST", "/v2/jobs?limit=1&minMatch=0.5", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusAccepted, w.Code)
+
+	var job Job
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&job))
+	require.Equal(t, 1, job.Limit)
+	require.InDelta(t, 0.5, job.MinMatch, 0.001)
+
+	job = waitForJob(t, svc, job.JobID)
+	require.Equal(t, 1, job.Matched)
+
+	// invalid uploads
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/v2/jobs?minMatch=high", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv")
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "invalid minMatch")
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/v2/jobs", strings.NewReader(`{"records": [{"name": "Acme", "type": "ship"}]}`))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "record 1")
+}
+
+func TestController_ResultsPending(t *testing.T) {
+	// The service isn't started, so jobs stay pending
+	svc := NewService(log.NewTestLogger(), NewInMemoryRepository(), search.NewService(log.NewTestLogger()))
+
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), svc).AppendRoutes(router)
+
+	job, err := svc.Submit(Request{Queries: testQueries("John Doe")})
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/v2/jobs/"+job.JobID+"/results", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusConflict, w.Code)
+	require.Contains(t, w.Body.String(), "is pending")
+}
@@ -0,0 +1,91 @@
+package jobs
+
+import (
+	"time"
+
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+)
+
+// Status is where a job is in screening its queries
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// Done returns true once a job has completed or failed
+func (s Status) Done() bool {
+	return s == StatusCompleted || s == StatusFailed
+}
+
+// Job is a batch of queries which are screened in the background
+type Job struct {
+	JobID  string `json:"jobID"`
+	Status Status `json:"status"`
+
+	// Limit and MinMatch are applied to every query
+	Limit    int     `json:"limit"`
+	MinMatch float64 `json:"minMatch"`
+
+	TenantID string `json:"tenantID,omitempty"`
+
+	// Changed jobs only screen against the entities added or modified by the latest list refresh, which was
+	// applied at ChangesAppliedAt when the job started
+	Changed          bool       `json:"changed,omitempty"`
+	ChangesAppliedAt *time.Time `json:"changesAppliedAt,omitempty"`
+
+	// WebhookURL is optional and notified once the job is done
+	WebhookURL string `json:"webhookURL,omitempty"`
+	Secret     string `json:"secret,omitempty"`
+
+	// Total is the number of queries, Screened how many have been searched and Matched how many
+	// of those found at least one entity
+	Total    int `json:"total"`
+	Screened int `json:"screened"`
+	Matched  int `json:"matched"`
+
+	Error string `json:"error,omitempty"`
+
+	CreatedAt   time.Time  `json:"createdAt"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	CompletedAt *time.Time `json:"completedAt,omitempty"`
+}
+
+// Request is a job submitted for screening
+type Request struct {
+	Limit    int     `json:"limit"`
+	MinMatch float64 `json:"minMatch"`
+
+	TenantID string `json:"tenantID"`
+
+	// Changed only screens the queries against the entities changed by the latest list refresh
+	Changed bool `json:"changed"`
+
+	WebhookURL string `json:"webhookURL"`
+	Secret     string `json:"secret"`
+
+	Queries []pubsearch.Entity[pubsearch.Value] `json:"-"`
+}
+
+// Result holds the matches of one query, in the order queries were submitted
+type Result struct {
+	Index    int                                         `json:"index"`
+	Query    pubsearch.Entity[pubsearch.Value]           `json:"query"`
+	Entities []pubsearch.SearchedEntity[pubsearch.Value] `json:"entities"`
+}
+
+// Event is the body sent to a job's webhook once it's done
+type Event struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+
+	Job Job `json:"job"`
+}
+
+const (
+	EventJobCompleted = "job.completed"
+	EventJobFailed    = "job.failed"
+)
@@ -0,0 +1,110 @@
+package jobs
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Repository keeps jobs and their results. Jobs are kept in memory, as results are only
+// held until they're downloaded or expire.
+type Repository interface {
+	Save(job Job) error
+	Get(jobID string) (*Job, error)
+	List(tenantID string) ([]Job, error)
+	Delete(jobID string) error
+
+	SaveResults(jobID string, results []Result) error
+	GetResults(jobID string) ([]Result, error)
+
+	// Expire deletes jobs which completed before cutoff, along with their results
+	Expire(cutoff time.Time) (int, error)
+}
+
+func NewInMemoryRepository() Repository {
+	return &inmemRepository{
+		jobs:    make(map[string]Job),
+		results: make(map[string][]Result),
+	}
+}
+
+type inmemRepository struct {
+	mu      sync.RWMutex
+	jobs    map[string]Job
+	results map[string][]Result
+}
+
+func (r *inmemRepository) Save(job Job) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.jobs[job.JobID] = job
+	return nil
+}
+
+func (r *inmemRepository) Get(jobID string) (*Job, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	job, exists := r.jobs[jobID]
+	if !exists {
+		return nil, nil
+	}
+	return &job, nil
+}
+
+func (r *inmemRepository) List(tenantID string) ([]Job, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]Job, 0, len(r.jobs))
+	for _, job := range r.jobs {
+		if job.TenantID == tenantID {
+			out = append(out, job)
+		}
+	}
+	slices.SortFunc(out, func(a, b Job) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.JobID, b.JobID))
+	})
+	return out, nil
+}
+
+func (r *inmemRepository) Delete(jobID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.jobs, jobID)
+	delete(r.results, jobID)
+	return nil
+}
+
+func (r *inmemRepository) SaveResults(jobID string, results []Result) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.results[jobID] = results
+	return nil
+}
+
+func (r *inmemRepository) GetResults(jobID string) ([]Result, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.results[jobID], nil
+}
+
+func (r *inmemRepository) Expire(cutoff time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var expired int
+	for jobID, job := range r.jobs {
+		if job.CompletedAt != nil && job.CompletedAt.Before(cutoff) {
+			delete(r.jobs, jobID)
+			delete(r.results, jobID)
+			expired++
+		}
+	}
+	return expired, nil
+}
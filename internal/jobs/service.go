@@ -0,0 +1,320 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/moov-io/watchman/internal/search"
+	"github.com/moov-io/watchman/internal/webhooks"
+
+	"github.com/moov-io/base"
+	"github.com/moov-io/base/log"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	defaultLimit    = 10
+	maxLimit        = 100
+	defaultMinMatch = 0.85
+
+	// maxJobQueries is the most queries accepted in one job
+	maxJobQueries = 1_000_000
+
+	// maxQueuedJobs is how many jobs can wait for a worker before new jobs are refused
+	maxQueuedJobs = 100
+
+	// jobWorkers is how many jobs are screened at once, and searchConcurrency how many
+	// queries of each job are searched at once
+	jobWorkers        = 2
+	searchConcurrency = 4
+
+	// progressInterval is how many queries are screened between updates of a job's progress
+	progressInterval = 1000
+
+	// retention is how long a finished job and its results are kept
+	retention = 24 * time.Hour
+)
+
+var (
+	ErrQueueFull = errors.New("too many jobs are waiting to be screened")
+)
+
+type Service interface {
+	Submit(req Request) (Job, error)
+	Get(jobID string) (*Job, error)
+	List(tenantID string) ([]Job, error)
+	Results(jobID string) ([]Result, error)
+	Delete(jobID string) error
+
+	// Start screens submitted jobs in the background until ctx is cancelled
+	Start(ctx context.Context)
+}
+
+// NewService creates a Service which screens jobs with searchService and notifies each job's
+// webhook once it's done.
+func NewService(logger log.Logger, repo Repository, searchService search.Service) Service {
+	return &service{
+		logger:        logger,
+		repo:          repo,
+		searchService: searchService,
+		client:        webhooks.NewClient(),
+		queue:         make(chan queuedJob, maxQueuedJobs),
+	}
+}
+
+type service struct {
+	logger        log.Logger
+	repo          Repository
+	searchService search.Service
+	client        *webhooks.Client
+
+	queue chan queuedJob
+}
+
+type queuedJob struct {
+	job Job
+	req Request
+}
+
+func (s *service) Submit(req Request) (Job, error) {
+	if len(req.Queries) == 0 {
+		return Job{}, errors.New("no queries provided")
+	}
+	if len(req.Queries) > maxJobQueries {
+		return Job{}, fmt.Errorf("too many queries: %d (max %d)", len(req.Queries), maxJobQueries)
+	}
+	for i, query := range req.Queries {
+		if query.Name == "" {
+			return Job{}, fmt.Errorf("query[%d] is missing a name", i)
+		}
+	}
+	if req.MinMatch > 1.0 {
+		return Job{}, fmt.Errorf("minMatch of %.2f is above 1.00", req.MinMatch)
+	}
+
+	job := Job{
+		JobID:    base.ID(),
+		Status:   StatusPending,
+		Limit:    req.Limit,
+		MinMatch: req.MinMatch,
+		TenantID: req.TenantID,
+		Changed:  req.Changed,
+		Total:    len(req.Queries),
+	}
+	if job.Limit <= 0 {
+		job.Limit = defaultLimit
+	}
+	if job.Limit > maxLimit {
+		job.Limit = maxLimit
+	}
+	if job.MinMatch <= 0 {
+		job.MinMatch = defaultMinMatch
+	}
+
+	if req.WebhookURL != "" {
+		var err error
+		job.WebhookURL, err = webhooks.ValidateURL(req.WebhookURL)
+		if err != nil {
+			return Job{}, err
+		}
+		job.Secret = req.Secret
+		if job.Secret == "" {
+			job.Secret, err = webhooks.GenerateSecret()
+			if err != nil {
+				return Job{}, fmt.Errorf("generating job secret: %w", err)
+			}
+		}
+	}
+	job.CreatedAt = time.Now().In(time.UTC)
+
+	err := s.repo.Save(job)
+	if err != nil {
+		return Job{}, fmt.Errorf("saving job: %w", err)
+	}
+
+	select {
+	case s.queue <- queuedJob{job: job, req: req}:
+	default:
+		s.repo.Delete(job.JobID)
+		return Job{}, ErrQueueFull
+	}
+	return job, nil
+}
+
+func (s *service) Get(jobID string) (*Job, error) {
+	return s.repo.Get(jobID)
+}
+
+func (s *service) List(tenantID string) ([]Job, error) {
+	return s.repo.List(tenantID)
+}
+
+func (s *service) Results(jobID string) ([]Result, error) {
+	return s.repo.GetResults(jobID)
+}
+
+func (s *service) Delete(jobID string) error {
+	return s.repo.Delete(jobID)
+}
+
+func (s *service) Start(ctx context.Context) {
+	for i := 0; i < jobWorkers; i++ {
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case queued := <-s.queue:
+					s.run(ctx, queued.job, queued.req)
+				}
+			}
+		}()
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				expired, err := s.repo.Expire(time.Now().Add(-retention))
+				if err != nil {
+					s.logger.Error().LogErrorf("problem expiring jobs: %v", err)
+				} else if expired > 0 {
+					s.logger.Info().Logf("expired %d screening jobs", expired)
+				}
+			}
+		}
+	}()
+}
+
+func (s *service) run(ctx context.Context, job Job, req Request) {
+	logger := s.logger.With(log.Fields{
+		"job_id": log.String(job.JobID),
+	})
+
+	started := time.Now().In(time.UTC)
+	job.Status = StatusRunning
+	job.StartedAt = &started
+	if job.Changed {
+		// Records which refresh is screened, although one applied while the job runs is screened from then on
+		if applied := s.searchService.LastChanges().AppliedAt; !applied.IsZero() {
+			job.ChangesAppliedAt = &applied
+		}
+	}
+	if err := s.repo.Save(job); err != nil {
+		logger.Error().LogErrorf("problem saving job: %v", err)
+	}
+
+	results, err := s.screen(ctx, &job, req)
+
+	completed := time.Now().In(time.UTC)
+	job.CompletedAt = &completed
+	job.Status = StatusCompleted
+	if err != nil {
+		job.Status = StatusFailed
+		job.Error = err.Error()
+		logger.Error().LogErrorf("problem screening job: %v", err)
+	} else {
+		if err := s.repo.SaveResults(job.JobID, results); err != nil {
+			logger.Error().LogErrorf("problem saving job results: %v", err)
+		}
+	}
+	if err := s.repo.Save(job); err != nil {
+		logger.Error().LogErrorf("problem saving job: %v", err)
+	}
+
+	logger.Info().Logf("screened %d of %d queries in %v", job.Screened, job.Total, completed.Sub(started))
+
+	if job.WebhookURL != "" {
+		if err := s.notify(ctx, job); err != nil {
+			logger.Error().LogErrorf("problem sending job webhook: %v", err)
+		}
+	}
+}
+
+func (s *service) screen(ctx context.Context, job *Job, req Request) ([]Result, error) {
+	results := make([]Result, len(req.Queries))
+
+	var (
+		mu       sync.Mutex
+		screened atomic.Int64
+		matched  atomic.Int64
+	)
+	progress := func() {
+		mu.Lock()
+		defer mu.Unlock()
+
+		job.Screened = int(screened.Load())
+		job.Matched = int(matched.Load())
+		s.repo.Save(*job)
+	}
+
+	g, groupCtx := errgroup.WithContext(ctx)
+	g.SetLimit(searchConcurrency)
+
+	for i := range req.Queries {
+		i := i
+		g.Go(func() error {
+			entities, err := s.searchService.Search(groupCtx, req.Queries[i], search.SearchOpts{
+				Limit:     job.Limit,
+				MinMatch:  job.MinMatch,
+				TenantID:  job.TenantID,
+				RequestID: job.JobID,
+				Changed:   job.Changed,
+			})
+			if err != nil {
+				return fmt.Errorf("query[%d]: %w", i, err)
+			}
+			results[i] = Result{
+				Index:    i,
+				Query:    req.Queries[i],
+				Entities: entities,
+			}
+			if len(entities) > 0 {
+				matched.Add(1)
+			}
+			if n := screened.Add(1); n%int64(progressInterval) == 0 {
+				progress()
+			}
+			return nil
+		})
+	}
+	err := g.Wait()
+	if err == nil {
+		err = ctx.Err()
+	}
+
+	job.Screened = int(screened.Load())
+	job.Matched = int(matched.Load())
+
+	return results, err
+}
+
+func (s *service) notify(ctx context.Context, job Job) error {
+	eventType := EventJobCompleted
+	if job.Status == StatusFailed {
+		eventType = EventJobFailed
+	}
+	secret := job.Secret
+	job.Secret = ""
+
+	body, err := json.Marshal(Event{
+		Type:      eventType,
+		Timestamp: time.Now().In(time.UTC),
+		Job:       job,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding job event: %w", err)
+	}
+
+	return s.client.Send(ctx, job.WebhookURL, secret, body)
+}
@@ -0,0 +1,272 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/audit"
+	"github.com/moov-io/watchman/internal/search"
+	"github.com/moov-io/watchman/internal/webhooks"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func testService(t *testing.T, server *httptest.Server) *service {
+	t.Helper()
+
+	logger := log.NewTestLogger()
+
+	svc, ok := NewService(logger, NewInMemoryRepository(), testSearchService(logger)).(*service)
+	require.True(t, ok)
+
+	if server != nil {
+		svc.client.HTTP = server.Client()
+	}
+	svc.client.RetryDelay = time.Millisecond
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+	svc.Start(ctx)
+
+	return svc
+}
+
+func testSearchService(logger log.Logger) search.Service {
+	searchService := search.NewService(logger)
+	searchService.UpdateEntities([]pubsearch.Entity[pubsearch.Value]{
+		{
+			Name:     "Nicolas Maduro Moros",
+			Type:     pubsearch.EntityPerson,
+			Source:   pubsearch.SourceUSOFAC,
+			SourceID: "22790",
+			Person: &pubsearch.Person{
+				Name: "Nicolas Maduro Moros",
+			},
+		},
+		{
+			Name:     "Acme Shipping Limited",
+			Type:     pubsearch.EntityBusiness,
+			Source:   pubsearch.SourceUSOFAC,
+			SourceID: "1234",
+			Business: &pubsearch.Business{
+				Name: "Acme Shipping Limited",
+			},
+		},
+	})
+	return searchService
+}
+
+func testQueries(names ...string) []pubsearch.Entity[pubsearch.Value] {
+	out := make([]pubsearch.Entity[pubsearch.Value], len(names))
+	for i, name := range names {
+		out[i] = pubsearch.Entity[pubsearch.Value]{
+			Name:   name,
+			Type:   pubsearch.EntityPerson,
+			Source: pubsearch.SourceAPIRequest,
+			Person: &pubsearch.Person{Name: name},
+		}
+	}
+	return out
+}
+
+func waitForJob(t *testing.T, svc Service, jobID string) Job {
+	t.Helper()
+
+	var job *Job
+	require.Eventually(t, func() bool {
+		var err error
+		job, err = svc.Get(jobID)
+		require.NoError(t, err)
+		return job != nil && job.Status.Done()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	return *job
+}
+
+func TestService_Audited(t *testing.T) {
+	logger := log.NewTestLogger()
+
+	// Jobs are given the audited search service, so each of their searches is recorded
+	sink := audit.NewInMemorySink()
+	searchService := audit.NewSearchService(logger, testSearchService(logger), sink, nil, 1)
+
+	svc := NewService(logger, NewInMemoryRepository(), searchService)
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	t.Cleanup(cancelFunc)
+	svc.Start(ctx)
+
+	job, err := svc.Submit(Request{Queries: testQueries("Nicolas Maduro"), TenantID: "acme"})
+	require.NoError(t, err)
+	job = waitForJob(t, svc, job.JobID)
+	require.Equal(t, 1, job.Matched)
+
+	records := sink.Records()
+	require.Len(t, records, 1)
+	require.Equal(t, audit.TypeEntity, records[0].Type)
+	require.Equal(t, "acme", records[0].TenantID)
+	require.Equal(t, job.JobID, records[0].RequestID)
+	require.Contains(t, string(records[0].Query), "Nicolas Maduro")
+	require.Equal(t, "22790", records[0].Results[0].SourceID)
+}
+
+func TestService_Submit(t *testing.T) {
+	svc := testService(t, nil)
+
+	_, err := svc.Submit(Request{})
+	require.ErrorContains(t, err, "no queries provided")
+
+	_, err = svc.Submit(Request{Queries: testQueries("")})
+	require.ErrorContains(t, err, "query[0] is missing a name")
+
+	_, err = svc.Submit(Request{Queries: testQueries("John"), MinMatch: 1.5})
+	require.ErrorContains(t, err, "above 1.00")
+
+	_, err = svc.Submit(Request{Queries: testQueries("John"), WebhookURL: "http://example.com"})
+	require.ErrorContains(t, err, "https://")
+
+	job, err := svc.Submit(Request{Queries: testQueries("Nicolas Maduro", "John Doe"), Limit: 500})
+	require.NoError(t, err)
+	require.NotEmpty(t, job.JobID)
+	require.Equal(t, StatusPending, job.Status)
+	require.Equal(t, 2, job.Total)
+	require.Equal(t, maxLimit, job.Limit)
+	require.InDelta(t, defaultMinMatch, job.MinMatch, 0.001)
+	require.Empty(t, job.Secret)
+
+	job = waitForJob(t, svc, job.JobID)
+	require.Equal(t, StatusCompleted, job.Status)
+	require.Equal(t, 2, job.Screened)
+	require.Equal(t, 1, job.Matched)
+	require.NotNil(t, job.StartedAt)
+	require.NotNil(t, job.CompletedAt)
+
+	results, err := svc.Results(job.JobID)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, "Nicolas Maduro", results[0].Query.Name)
+	require.Equal(t, "22790", results[0].Entities[0].SourceID)
+	require.Equal(t, 1, results[1].Index)
+	require.Empty(t, results[1].Entities)
+}
+
+func TestService_SubmitChanged(t *testing.T) {
+	svc := testService(t, nil)
+
+	job, err := svc.Submit(Request{Queries: testQueries("Nicolas Maduro"), Changed: true})
+	require.NoError(t, err)
+	require.True(t, job.Changed)
+
+	// The lists haven't changed since they were loaded
+	job = waitForJob(t, svc, job.JobID)
+	require.Equal(t, StatusCompleted, job.Status)
+	require.Equal(t, 0, job.Matched)
+	require.Nil(t, job.ChangesAppliedAt)
+
+	svc.searchService.ApplyChanges(search.EntityChanges{
+		Added: []pubsearch.Entity[pubsearch.Value]{
+			{
+				Name:     "Nicolas Maduro Guerra",
+				Type:     pubsearch.EntityPerson,
+				Source:   pubsearch.SourceUSOFAC,
+				SourceID: "5678",
+				Person:   &pubsearch.Person{Name: "Nicolas Maduro Guerra"},
+			},
+		},
+	})
+
+	job, err = svc.Submit(Request{Queries: testQueries("Nicolas Maduro", "Acme Shipping"), Changed: true})
+	require.NoError(t, err)
+
+	job = waitForJob(t, svc, job.JobID)
+	require.Equal(t, 1, job.Matched)
+	require.NotNil(t, job.ChangesAppliedAt)
+
+	results, err := svc.Results(job.JobID)
+	require.NoError(t, err)
+	require.Len(t, results[0].Entities, 1)
+	require.Equal(t, "5678", results[0].Entities[0].SourceID)
+}
+
+func TestService_QueueFull(t *testing.T) {
+	svc, ok := NewService(log.NewTestLogger(), NewInMemoryRepository(), search.NewService(log.NewTestLogger())).(*service)
+	require.True(t, ok)
+	svc.queue = make(chan queuedJob, 1)
+
+	_, err := svc.Submit(Request{Queries: testQueries("John Doe")})
+	require.NoError(t, err)
+
+	_, err = svc.Submit(Request{Queries: testQueries("Jane Doe")})
+	require.ErrorIs(t, err, ErrQueueFull)
+
+	jobs, err := svc.List("")
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+}
+
+func TestService_Webhook(t *testing.T) {
+	events := make(chan Event, 1)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.True(t, webhooks.Verify("secret", r.Header.Get(webhooks.HeaderTimestamp), body, r.Header.Get(webhooks.HeaderSignature)))
+
+		var event Event
+		require.NoError(t, json.Unmarshal(body, &event))
+		events <- event
+	}))
+	defer server.Close()
+
+	svc := testService(t, server)
+
+	job, err := svc.Submit(Request{
+		Queries:    testQueries("Nicolas Maduro"),
+		MinMatch:   0.5,
+		WebhookURL: server.URL,
+		Secret:     "secret",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "secret", job.Secret)
+
+	select {
+	case event := <-events:
+		require.Equal(t, EventJobCompleted, event.Type)
+		require.Equal(t, job.JobID, event.Job.JobID)
+		require.Equal(t, StatusCompleted, event.Job.Status)
+		require.Equal(t, 1, event.Job.Matched)
+		require.Empty(t, event.Job.Secret)
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was not sent")
+	}
+}
+
+func TestRepository_Expire(t *testing.T) {
+	repo := NewInMemoryRepository()
+
+	completed := time.Now().Add(-2 * retention)
+	require.NoError(t, repo.Save(Job{JobID: "old", Status: StatusCompleted, CompletedAt: &completed}))
+	require.NoError(t, repo.SaveResults("old", []Result{{Index: 0}}))
+	require.NoError(t, repo.Save(Job{JobID: "running", Status: StatusRunning}))
+
+	expired, err := repo.Expire(time.Now().Add(-retention))
+	require.NoError(t, err)
+	require.Equal(t, 1, expired)
+
+	job, err := repo.Get("old")
+	require.NoError(t, err)
+	require.Nil(t, job)
+
+	results, err := repo.GetResults("old")
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	job, err = repo.Get("running")
+	require.NoError(t, err)
+	require.NotNil(t, job)
+}
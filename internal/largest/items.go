@@ -57,7 +57,7 @@ func (xs *Items) Add(it Item) {
 
 	// We are at capacity, so compare the new item to the smallest in our list
 	// (in descending order, the smallest is the last element).
-	if it.Weight <= xs.items[len(xs.items)-1].Weight {
+	if !heavier(it, xs.items[len(xs.items)-1]) {
 		// New item is not heavier than our smallest stored item
 		return
 	}
@@ -69,13 +69,25 @@ func (xs *Items) Add(it Item) {
 	xs.insertDescending(it)
 }
 
+// heavier orders Items by Weight, breaking ties by source list and ID so the same
+// items are kept in the same order however they were added.
+func heavier(a, b Item) bool {
+	if a.Weight != b.Weight {
+		return a.Weight > b.Weight
+	}
+	if a.Value.Source != b.Value.Source {
+		return a.Value.Source < b.Value.Source
+	}
+	return a.Value.SourceID < b.Value.SourceID
+}
+
 // insertDescending inserts an Item so that xs.items remains
 // sorted by Weight in descending order (index 0 is highest).
 func (xs *Items) insertDescending(it Item) {
 	// Find the position using binary search
-	// We want the first spot where items[i].Weight < it.Weight.
+	// We want the first spot where it is heavier than items[i].
 	i := sort.Search(len(xs.items), func(i int) bool {
-		return xs.items[i].Weight < it.Weight
+		return heavier(it, xs.items[i])
 	})
 	// Extend the slice by 1
 	xs.items = append(xs.items, it)
@@ -85,6 +97,23 @@ func (xs *Items) insertDescending(it Item) {
 	xs.items[i] = it
 }
 
+// Empty returns new Items with the same capacity and minMatch, such as to collect part of a search before
+// they're merged.
+func (xs *Items) Empty() *Items {
+	return &Items{
+		items:    make([]Item, 0, xs.capacity),
+		capacity: xs.capacity,
+		minMatch: xs.minMatch,
+	}
+}
+
+// Merge adds every item of other, keeping the top N items of both
+func (xs *Items) Merge(other *Items) {
+	for _, it := range other.Items() {
+		xs.Add(it)
+	}
+}
+
 // All returns a copy of all items in descending Weight order.
 func (xs *Items) Items() []Item {
 	xs.mu.Lock()
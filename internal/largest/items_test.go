@@ -101,3 +101,44 @@ func TestItems_MinMatch(t *testing.T) {
 		got[1].Value.Name,
 	})
 }
+
+func TestItems_Ties(t *testing.T) {
+	// Items with the same weight are kept and ordered by their source ID, whatever order they're added in
+	tie := func(sourceID string) largest.Item {
+		it := makeItem(sourceID, 0.5)
+		it.Value.SourceID = sourceID
+		return it
+	}
+
+	xs := largest.NewItems(2, 0.0)
+	xs.Add(tie("3"))
+	xs.Add(tie("1"))
+	xs.Add(tie("2"))
+
+	got := xs.Items()
+	require.Len(t, got, 2)
+	require.Equal(t, []string{"1", "2"}, []string{
+		got[0].Value.SourceID,
+		got[1].Value.SourceID,
+	})
+}
+
+func TestItems_Merge(t *testing.T) {
+	xs := largest.NewItems(3, 0.5)
+	xs.Add(makeItem("A", 0.9))
+	xs.Add(makeItem("B", 0.6))
+
+	shard := xs.Empty()
+	require.Empty(t, shard.Items())
+	shard.Add(makeItem("C", 0.8))
+	shard.Add(makeItem("D", 0.7))
+	shard.Add(makeItem("E", 0.4)) // below minMatch
+
+	xs.Merge(shard)
+
+	var names []string
+	for _, it := range xs.Items() {
+		names = append(names, it.Value.Name)
+	}
+	require.Equal(t, []string{"A", "C", "D"}, names)
+}
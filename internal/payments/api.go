@@ -0,0 +1,138 @@
+package payments
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/auth"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+)
+
+var (
+	// maxMessageSize limits how much of a request body is read
+	maxMessageSize int64 = 1 << 20 // 1MB
+
+	defaultLimit, maxLimit = 10, 100
+	defaultMinMatch        = 0.85
+)
+
+type Controller interface {
+	AppendRoutes(router *mux.Router) *mux.Router
+}
+
+func NewController(logger log.Logger, service Service) Controller {
+	return &controller{
+		logger:  logger,
+		service: service,
+	}
+}
+
+type controller struct {
+	logger  log.Logger
+	service Service
+}
+
+func (c *controller) AppendRoutes(router *mux.Router) *mux.Router {
+	router.
+		Name("Payments.screen").
+		Methods("POST").
+		Path("/v2/screen/payment").
+		HandlerFunc(c.screen)
+
+	return router
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (c *controller) writeError(w http.ResponseWriter, status int, err error) {
+	c.logger.Error().LogError(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error: err.Error(),
+	})
+}
+
+func (c *controller) screen(w http.ResponseWriter, r *http.Request) {
+	message, err := io.ReadAll(io.LimitReader(r.Body, maxMessageSize+1))
+	if err != nil {
+		c.writeError(w, http.StatusBadRequest, fmt.Errorf("reading payment message: %w", err))
+		return
+	}
+	if int64(len(message)) > maxMessageSize {
+		c.writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("payment message is larger than %d bytes", maxMessageSize))
+		return
+	}
+	if len(strings.TrimSpace(string(message))) == 0 {
+		c.writeError(w, http.StatusBadRequest, errors.New("missing payment message"))
+		return
+	}
+
+	opts, err := ReadScreenOpts(r)
+	if err != nil {
+		c.writeError(w, ScreenOptsStatus(err), err)
+		return
+	}
+
+	result, err := c.service.Screen(r.Context(), message, opts)
+	if err != nil {
+		c.writeError(w, http.StatusBadRequest, fmt.Errorf("problem screening payment message: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+// ReadScreenOpts reads the limit, minMatch, tenantID and requestID query parameters of a request
+func ReadScreenOpts(r *http.Request) (ScreenOpts, error) {
+	q := r.URL.Query()
+
+	tenantID, err := auth.RequestTenant(r)
+	if err != nil {
+		return ScreenOpts{}, err
+	}
+	opts := ScreenOpts{
+		Limit:     defaultLimit,
+		MinMatch:  defaultMinMatch,
+		TenantID:  tenantID,
+		RequestID: q.Get("requestID"),
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return opts, fmt.Errorf("invalid limit %q", v)
+		}
+		if n < maxLimit {
+			opts.Limit = n
+		} else {
+			opts.Limit = maxLimit
+		}
+	}
+	if v := q.Get("minMatch"); v != "" {
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil || n < 0 || n > 1 {
+			return opts, fmt.Errorf("invalid minMatch %q", v)
+		}
+		opts.MinMatch = n
+	}
+	return opts, nil
+}
+
+// ScreenOptsStatus is the HTTP status of an error from ReadScreenOpts
+func ScreenOptsStatus(err error) int {
+	if errors.Is(err, auth.ErrOtherTenant) {
+		return http.StatusForbidden
+	}
+	return http.StatusBadRequest
+}
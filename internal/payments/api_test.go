@@ -0,0 +1,45 @@
+package payments
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestController(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/v2/screen/payment?limit=2", strings.NewReader(string(readMessage(t, "pacs008.xml"))))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var result Result
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+	require.Equal(t, FormatPacs008, result.Format)
+	require.Len(t, result.Parties, 6)
+
+	creditor := result.Parties[5]
+	require.Equal(t, RoleCreditor, creditor.Role)
+	require.Len(t, creditor.BICMatches, 1)
+
+	// empty body
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/v2/screen/payment", strings.NewReader(" "))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// bad options
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/v2/screen/payment?minMatch=2", strings.NewReader(":59:JOHN SMITH"))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), `invalid minMatch \"2\"`)
+}
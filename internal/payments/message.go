@@ -0,0 +1,94 @@
+package payments
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+)
+
+// Format is the standard a payment message is written in
+type Format string
+
+const (
+	FormatPacs008 Format = "pacs.008"
+	FormatMT103   Format = "mt103"
+)
+
+// Role is a party's part in a payment, named after the ISO 20022 elements. The parties of an MT103 are
+// given the role of their pacs.008 equivalent, so the ordering customer (field 50) is the debtor.
+type Role string
+
+const (
+	RoleDebtor           Role = "debtor"
+	RoleUltimateDebtor   Role = "ultimate-debtor"
+	RoleInitiatingParty  Role = "initiating-party"
+	RoleCreditor         Role = "creditor"
+	RoleUltimateCreditor Role = "ultimate-creditor"
+
+	RoleDebtorAgent       Role = "debtor-agent"
+	RoleCreditorAgent     Role = "creditor-agent"
+	RoleIntermediaryAgent Role = "intermediary-agent"
+	RoleInstructingAgent  Role = "instructing-agent"
+	RoleInstructedAgent   Role = "instructed-agent"
+
+	// Reimbursement agents are the correspondents of MT103 fields 53, 54 and 55
+	RoleSendersCorrespondent     Role = "senders-correspondent"
+	RoleReceiversCorrespondent   Role = "receivers-correspondent"
+	RoleReimbursementInstitution Role = "reimbursement-institution"
+
+	// The originator and receiver of an ACH entry
+	RoleOriginator Role = "originator"
+	RoleReceiver   Role = "receiver"
+)
+
+// Party is a customer or bank named in a payment message
+type Party struct {
+	Role Role `json:"role"`
+
+	// Type is a person or business when the message says which, otherwise it's empty
+	Type pubsearch.EntityType `json:"type,omitempty"`
+
+	Name    string             `json:"name,omitempty"`
+	Address *pubsearch.Address `json:"address,omitempty"`
+	BIC     string             `json:"bic,omitempty"`
+}
+
+func (p Party) empty() bool {
+	return p.Name == "" && p.BIC == ""
+}
+
+var (
+	ErrUnknownFormat = errors.New("unknown payment message format, expected pacs.008 XML or MT103 text")
+)
+
+// ParseMessage reads the parties of a pacs.008 or MT103 message, detecting which it is
+func ParseMessage(data []byte) (Format, []Party, error) {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.HasPrefix(data, []byte("<")):
+		parties, err := ParsePacs008(bytes.NewReader(data))
+		return FormatPacs008, parties, err
+
+	case bytes.HasPrefix(data, []byte("{")) || bytes.HasPrefix(data, []byte(":")):
+		parties, err := ParseMT103(string(data))
+		return FormatMT103, parties, err
+	}
+	return "", nil, ErrUnknownFormat
+}
+
+// addParty appends party unless it doesn't name anyone or is a repeat of one already found
+func addParty(parties []Party, party Party) []Party {
+	party.Name = strings.Join(strings.Fields(party.Name), " ")
+	party.BIC = strings.ToUpper(strings.TrimSpace(party.BIC))
+	if party.empty() {
+		return parties
+	}
+	for _, p := range parties {
+		if p.Role == party.Role && p.Name == party.Name && p.BIC == party.BIC {
+			return parties
+		}
+	}
+	return append(parties, party)
+}
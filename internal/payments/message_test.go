@@ -0,0 +1,104 @@
+package payments
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func readMessage(t *testing.T, name string) []byte {
+	t.Helper()
+
+	bs, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	return bs
+}
+
+func TestParseMessage_Pacs008(t *testing.T) {
+	format, parties, err := ParseMessage(readMessage(t, "pacs008.xml"))
+	require.NoError(t, err)
+	require.Equal(t, FormatPacs008, format)
+
+	expected := []Party{
+		{Role: RoleInstructingAgent, Type: pubsearch.EntityBusiness, BIC: "DEUTDEFFXXX"},
+		{
+			Role: RoleDebtor,
+			Type: pubsearch.EntityPerson,
+			Name: "Maria Schmidt",
+			Address: &pubsearch.Address{
+				Line1:      "Hauptstrasse 12",
+				City:       "Berlin",
+				PostalCode: "10115",
+				Country:    "DE",
+			},
+		},
+		{Role: RoleDebtorAgent, Type: pubsearch.EntityBusiness, Name: "Deutsche Bank", BIC: "DEUTDEFFXXX"},
+		{Role: RoleIntermediaryAgent, Type: pubsearch.EntityBusiness, BIC: "HNRBBY2X"},
+		{Role: RoleCreditorAgent, Type: pubsearch.EntityBusiness, BIC: "KDBKKPPY"},
+		{
+			Role:    RoleCreditor,
+			Type:    pubsearch.EntityBusiness,
+			Name:    "Korea Daesong Trading Company",
+			Address: &pubsearch.Address{Line1: "Pyongyang", Line2: "North Korea"},
+			BIC:     "KDBKKPPY",
+		},
+	}
+	require.Equal(t, expected, parties)
+}
+
+func TestParseMessage_MT103(t *testing.T) {
+	format, parties, err := ParseMessage(readMessage(t, "mt103.txt"))
+	require.NoError(t, err)
+	require.Equal(t, FormatMT103, format)
+
+	expected := []Party{
+		{Role: RoleInstructingAgent, Type: pubsearch.EntityBusiness, BIC: "DEUTDEFFXXX"},
+		{Role: RoleInstructedAgent, Type: pubsearch.EntityBusiness, BIC: "KDBKKPPYXXX"},
+		{
+			Role:    RoleDebtor,
+			Name:    "MARIA SCHMIDT",
+			Address: &pubsearch.Address{Line1: "HAUPTSTRASSE 12", Line2: "10115 BERLIN"},
+		},
+		{Role: RoleDebtorAgent, Type: pubsearch.EntityBusiness, BIC: "DEUTDEFF"},
+		{Role: RoleIntermediaryAgent, Type: pubsearch.EntityBusiness, BIC: "HNRBBY2X"},
+		{
+			Role:    RoleCreditorAgent,
+			Type:    pubsearch.EntityBusiness,
+			Name:    "KOREA DAESONG BANK",
+			Address: &pubsearch.Address{Line1: "PYONGYANG"},
+		},
+		{
+			Role: RoleCreditor,
+			Name: "KOREA DAESONG TRADING COMPANY",
+			Address: &pubsearch.Address{
+				Line1:   "SOSONG STREET",
+				City:    "PYONGYANG",
+				Country: "KP",
+			},
+		},
+	}
+	require.Equal(t, expected, parties)
+
+	// Just the fields of a message
+	_, parties, err = ParseMessage([]byte(":20:REF\n:59:/12345\nJOHN SMITH\n:71A:OUR"))
+	require.NoError(t, err)
+	require.Equal(t, []Party{{Role: RoleCreditor, Name: "JOHN SMITH"}}, parties)
+}
+
+func TestParseMessage_Errors(t *testing.T) {
+	_, _, err := ParseMessage([]byte("hello"))
+	require.ErrorIs(t, err, ErrUnknownFormat)
+
+	_, _, err = ParseMessage([]byte("<Document><Other/></Document>"))
+	require.ErrorContains(t, err, "missing FIToFICstmrCdtTrf element")
+
+	_, _, err = ParseMessage([]byte("<Document><FIToFICstmrCdtTrf>"))
+	require.ErrorContains(t, err, "reading pacs.008")
+
+	_, _, err = ParseMessage([]byte("{1:F01DEUTDEFFAXXX0000000000}"))
+	require.ErrorContains(t, err, "no MT103 fields found")
+}
@@ -0,0 +1,167 @@
+package payments
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+)
+
+var (
+	// mtFieldRegex finds the tag of each field in the text block, such as ":50K:"
+	mtFieldRegex = regexp.MustCompile(`(?m)^:([0-9]{2}[A-Z]?):`)
+
+	bicRegex = regexp.MustCompile(`^[A-Z]{6}[A-Z0-9]{2}([A-Z0-9]{3})?$`)
+)
+
+// mtRoles are the roles of each party field in an MT103, keyed by the field's number
+var mtRoles = map[string]Role{
+	"50": RoleDebtor,
+	"52": RoleDebtorAgent,
+	"53": RoleSendersCorrespondent,
+	"54": RoleReceiversCorrespondent,
+	"55": RoleReimbursementInstitution,
+	"56": RoleIntermediaryAgent,
+	"57": RoleCreditorAgent,
+	"59": RoleCreditor,
+}
+
+// ParseMT103 reads the parties of an MT103 single customer credit transfer. The sender and receiver of
+// the basic and application header blocks are read as the instructing and instructed agents.
+func ParseMT103(message string) ([]Party, error) {
+	message = strings.ReplaceAll(message, "\r\n", "\n")
+
+	var out []Party
+	if header, found := mtBlock(message, "1"); found && len(header) >= 15 {
+		// F01 followed by the sender's logical terminal: a BIC8, terminal code and branch
+		out = addParty(out, Party{Role: RoleInstructingAgent, Type: pubsearch.EntityBusiness, BIC: mtTerminalBIC(header[3:15])})
+	}
+	if header, found := mtBlock(message, "2"); found && strings.HasPrefix(header, "I") && len(header) >= 16 {
+		out = addParty(out, Party{Role: RoleInstructedAgent, Type: pubsearch.EntityBusiness, BIC: mtTerminalBIC(header[4:16])})
+	}
+
+	text, found := mtBlock(message, "4")
+	if !found {
+		text = message // just the fields of the message
+	}
+	locations := mtFieldRegex.FindAllStringSubmatchIndex(text, -1)
+	if len(locations) == 0 {
+		return nil, errors.New("no MT103 fields found")
+	}
+	for i, loc := range locations {
+		end := len(text)
+		if i+1 < len(locations) {
+			end = locations[i+1][0]
+		}
+		tag := text[loc[2]:loc[3]]
+		value := strings.TrimSuffix(strings.TrimSpace(text[loc[1]:end]), "-")
+
+		role, exists := mtRoles[tag[:2]]
+		if !exists {
+			continue
+		}
+		out = addParty(out, parseMTParty(role, tag[2:], value))
+	}
+	return out, nil
+}
+
+// mtBlock returns the contents of a block, such as "{4:...-}"
+func mtBlock(message, id string) (string, bool) {
+	_, after, found := strings.Cut(message, "{"+id+":")
+	if !found {
+		return "", false
+	}
+	if id == "4" {
+		block, _, _ := strings.Cut(after, "\n-}")
+		return strings.TrimPrefix(block, "\n"), true
+	}
+	block, _, _ := strings.Cut(after, "}")
+	return block, true
+}
+
+func mtTerminalBIC(terminal string) string {
+	return terminal[:8] + terminal[9:12]
+}
+
+func parseMTParty(role Role, option, value string) Party {
+	party := Party{
+		Role: role,
+	}
+	if role != RoleDebtor && role != RoleCreditor {
+		party.Type = pubsearch.EntityBusiness
+	}
+
+	var lines []string
+	for _, line := range strings.Split(value, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	// The account or party identifier is optional, but always first
+	if len(lines) > 0 && strings.HasPrefix(lines[0], "/") {
+		lines = lines[1:]
+	}
+	if len(lines) == 0 {
+		return party
+	}
+
+	switch option {
+	case "A":
+		// Identifier code
+		if bic := strings.ToUpper(lines[0]); bicRegex.MatchString(bic) {
+			party.BIC = bic
+		}
+		if role == RoleDebtor || role == RoleCreditor {
+			party.Type = pubsearch.EntityBusiness
+		}
+
+	case "F":
+		parseMTStructuredParty(&party, lines)
+
+	case "", "D", "K":
+		// Name and address
+		party.Name = lines[0]
+		if len(lines) > 1 {
+			party.Address = &pubsearch.Address{
+				Line1: lines[1],
+				Line2: strings.Join(lines[2:], " "),
+			}
+		}
+	}
+	return party
+}
+
+// parseMTStructuredParty reads the numbered lines of option F, such as "1/JOHN SMITH" and "3/US/NEW YORK"
+func parseMTStructuredParty(party *Party, lines []string) {
+	var names, street []string
+	var addr pubsearch.Address
+	for _, line := range lines {
+		number, text, found := strings.Cut(line, "/")
+		if !found {
+			continue
+		}
+		switch number {
+		case "1":
+			names = append(names, text)
+		case "2":
+			street = append(street, text)
+		case "3":
+			country, town, _ := strings.Cut(text, "/")
+			addr.Country = strings.TrimSpace(country)
+			addr.City = strings.TrimSpace(town)
+		case "4", "5", "6", "7":
+			// Dates and places of birth and identifiers are only given for people
+			party.Type = pubsearch.EntityPerson
+		}
+	}
+	party.Name = strings.Join(names, " ")
+
+	if len(street) > 0 {
+		addr.Line1 = street[0]
+		addr.Line2 = strings.Join(street[1:], " ")
+	}
+	if addr != (pubsearch.Address{}) {
+		party.Address = &addr
+	}
+}
@@ -0,0 +1,180 @@
+package payments
+
+import (
+	"cmp"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+)
+
+// Elements are matched by their local names, so every version of pacs.008 is read along with messages
+// wrapped in an envelope or business application header.
+
+type pacs008 struct {
+	GroupHeader struct {
+		InstructingAgent *pacsAgent `xml:"InstgAgt"`
+		InstructedAgent  *pacsAgent `xml:"InstdAgt"`
+	} `xml:"GrpHdr"`
+
+	Transactions []pacsTransaction `xml:"CdtTrfTxInf"`
+}
+
+type pacsTransaction struct {
+	InstructingAgent *pacsAgent `xml:"InstgAgt"`
+	InstructedAgent  *pacsAgent `xml:"InstdAgt"`
+
+	IntermediaryAgent1 *pacsAgent `xml:"IntrmyAgt1"`
+	IntermediaryAgent2 *pacsAgent `xml:"IntrmyAgt2"`
+	IntermediaryAgent3 *pacsAgent `xml:"IntrmyAgt3"`
+
+	UltimateDebtor  *pacsParty `xml:"UltmtDbtr"`
+	InitiatingParty *pacsParty `xml:"InitgPty"`
+	Debtor          *pacsParty `xml:"Dbtr"`
+	DebtorAgent     *pacsAgent `xml:"DbtrAgt"`
+
+	CreditorAgent    *pacsAgent `xml:"CdtrAgt"`
+	Creditor         *pacsParty `xml:"Cdtr"`
+	UltimateCreditor *pacsParty `xml:"UltmtCdtr"`
+}
+
+type pacsParty struct {
+	Name    string       `xml:"Nm"`
+	Address *pacsAddress `xml:"PstlAdr"`
+	ID      struct {
+		Org *struct {
+			AnyBIC   string `xml:"AnyBIC"`
+			BICOrBEI string `xml:"BICOrBEI"`
+		} `xml:"OrgId"`
+		Private *struct{} `xml:"PrvtId"`
+	} `xml:"Id"`
+}
+
+type pacsAgent struct {
+	Institution struct {
+		BICFI   string       `xml:"BICFI"`
+		BIC     string       `xml:"BIC"`
+		Name    string       `xml:"Nm"`
+		Address *pacsAddress `xml:"PstlAdr"`
+	} `xml:"FinInstnId"`
+}
+
+type pacsAddress struct {
+	Street         string   `xml:"StrtNm"`
+	BuildingNumber string   `xml:"BldgNb"`
+	BuildingName   string   `xml:"BldgNm"`
+	PostalCode     string   `xml:"PstCd"`
+	Town           string   `xml:"TwnNm"`
+	Subdivision    string   `xml:"CtrySubDvsn"`
+	Country        string   `xml:"Ctry"`
+	Lines          []string `xml:"AdrLine"`
+}
+
+// ParsePacs008 reads the parties of a pacs.008 FI to FI customer credit transfer
+func ParsePacs008(r io.Reader) ([]Party, error) {
+	decoder := xml.NewDecoder(r)
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errors.New("missing FIToFICstmrCdtTrf element")
+			}
+			return nil, fmt.Errorf("reading pacs.008: %w", err)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "FIToFICstmrCdtTrf" {
+			continue
+		}
+
+		var msg pacs008
+		if err := decoder.DecodeElement(&msg, &start); err != nil {
+			return nil, fmt.Errorf("reading pacs.008: %w", err)
+		}
+		return msg.parties(), nil
+	}
+}
+
+func (msg pacs008) parties() []Party {
+	var out []Party
+	out = msg.GroupHeader.InstructingAgent.add(out, RoleInstructingAgent)
+	out = msg.GroupHeader.InstructedAgent.add(out, RoleInstructedAgent)
+
+	for _, tx := range msg.Transactions {
+		out = tx.InstructingAgent.add(out, RoleInstructingAgent)
+		out = tx.InstructedAgent.add(out, RoleInstructedAgent)
+
+		out = tx.UltimateDebtor.add(out, RoleUltimateDebtor)
+		out = tx.InitiatingParty.add(out, RoleInitiatingParty)
+		out = tx.Debtor.add(out, RoleDebtor)
+		out = tx.DebtorAgent.add(out, RoleDebtorAgent)
+
+		out = tx.IntermediaryAgent1.add(out, RoleIntermediaryAgent)
+		out = tx.IntermediaryAgent2.add(out, RoleIntermediaryAgent)
+		out = tx.IntermediaryAgent3.add(out, RoleIntermediaryAgent)
+
+		out = tx.CreditorAgent.add(out, RoleCreditorAgent)
+		out = tx.Creditor.add(out, RoleCreditor)
+		out = tx.UltimateCreditor.add(out, RoleUltimateCreditor)
+	}
+	return out
+}
+
+func (p *pacsParty) add(parties []Party, role Role) []Party {
+	if p == nil {
+		return parties
+	}
+	party := Party{
+		Role:    role,
+		Name:    p.Name,
+		Address: p.Address.address(),
+	}
+	if org := p.ID.Org; org != nil {
+		party.Type = pubsearch.EntityBusiness
+		party.BIC = cmp.Or(org.AnyBIC, org.BICOrBEI)
+	} else if p.ID.Private != nil {
+		party.Type = pubsearch.EntityPerson
+	}
+	return addParty(parties, party)
+}
+
+func (a *pacsAgent) add(parties []Party, role Role) []Party {
+	if a == nil {
+		return parties
+	}
+	return addParty(parties, Party{
+		Role:    role,
+		Type:    pubsearch.EntityBusiness,
+		Name:    a.Institution.Name,
+		Address: a.Institution.Address.address(),
+		BIC:     cmp.Or(a.Institution.BICFI, a.Institution.BIC),
+	})
+}
+
+func (a *pacsAddress) address() *pubsearch.Address {
+	if a == nil {
+		return nil
+	}
+	out := &pubsearch.Address{
+		Line1:      strings.Join(strings.Fields(a.Street+" "+a.BuildingNumber+" "+a.BuildingName), " "),
+		City:       strings.TrimSpace(a.Town),
+		PostalCode: strings.TrimSpace(a.PostalCode),
+		State:      strings.TrimSpace(a.Subdivision),
+		Country:    strings.TrimSpace(a.Country),
+	}
+	// Unstructured addresses are kept as lines
+	if len(a.Lines) > 0 {
+		if out.Line1 == "" {
+			out.Line1 = strings.TrimSpace(a.Lines[0])
+			out.Line2 = strings.TrimSpace(strings.Join(a.Lines[1:], " "))
+		} else {
+			out.Line2 = strings.TrimSpace(strings.Join(a.Lines, " "))
+		}
+	}
+	if *out == (pubsearch.Address{}) {
+		return nil
+	}
+	return out
+}
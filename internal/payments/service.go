@@ -0,0 +1,188 @@
+package payments
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+)
+
+type Service interface {
+	// Screen searches for each party of a payment message by its name, address and BIC
+	Screen(ctx context.Context, message []byte, opts ScreenOpts) (Result, error)
+
+	// ScreenParty searches for one party, such as those of an ACH file
+	ScreenParty(ctx context.Context, party Party, opts ScreenOpts) (PartyResult, error)
+}
+
+func NewService(logger log.Logger, searchService search.Service) Service {
+	return &service{
+		logger:        logger,
+		searchService: searchService,
+	}
+}
+
+type service struct {
+	logger        log.Logger
+	searchService search.Service
+}
+
+type ScreenOpts struct {
+	Limit    int
+	MinMatch float64
+
+	// TenantID includes the entities of a tenant's custom lists in the results
+	TenantID  string
+	RequestID string
+}
+
+// Result lists the parties of a payment message along with the entities each matched
+type Result struct {
+	Format  Format        `json:"format"`
+	Parties []PartyResult `json:"parties"`
+}
+
+type PartyResult struct {
+	Party
+
+	// Entities are the listed entities whose names and addresses match the party
+	Entities []pubsearch.SearchedEntity[pubsearch.Value] `json:"entities"`
+
+	// BICMatches are the listed entities with the party's BIC
+	BICMatches []search.IdentifierMatch `json:"bicMatches,omitempty"`
+}
+
+func (s *service) Screen(ctx context.Context, message []byte, opts ScreenOpts) (Result, error) {
+	format, parties, err := ParseMessage(message)
+	if err != nil {
+		return Result{}, err
+	}
+
+	out := Result{
+		Format:  format,
+		Parties: make([]PartyResult, 0, len(parties)),
+	}
+	for _, party := range parties {
+		result, err := s.ScreenParty(ctx, party, opts)
+		if err != nil {
+			return out, err
+		}
+		out.Parties = append(out.Parties, result)
+	}
+	return out, nil
+}
+
+func (s *service) ScreenParty(ctx context.Context, party Party, opts ScreenOpts) (PartyResult, error) {
+	out := PartyResult{
+		Party:    party,
+		Entities: []pubsearch.SearchedEntity[pubsearch.Value]{},
+	}
+
+	var err error
+	if party.Name != "" {
+		out.Entities, err = s.searchName(ctx, party, opts)
+		if err != nil {
+			return out, fmt.Errorf("screening %s: %w", party.Role, err)
+		}
+	}
+	if party.BIC != "" {
+		out.BICMatches, err = s.searchBIC(ctx, party.BIC, opts)
+		if err != nil {
+			return out, fmt.Errorf("screening %s BIC: %w", party.Role, err)
+		}
+	}
+	return out, nil
+}
+
+// Matched returns true when the party matched any entity
+func (r PartyResult) Matched() bool {
+	return len(r.Entities) > 0 || len(r.BICMatches) > 0
+}
+
+// searchName screens a party as a person and a business when the message doesn't say which it is
+func (s *service) searchName(ctx context.Context, party Party, opts ScreenOpts) ([]pubsearch.SearchedEntity[pubsearch.Value], error) {
+	types := []pubsearch.EntityType{party.Type}
+	if party.Type == "" {
+		types = []pubsearch.EntityType{pubsearch.EntityPerson, pubsearch.EntityBusiness}
+	}
+
+	out := []pubsearch.SearchedEntity[pubsearch.Value]{}
+	for _, entityType := range types {
+		found, err := s.searchService.Search(ctx, party.query(entityType), search.SearchOpts{
+			Limit:     opts.Limit,
+			MinMatch:  opts.MinMatch,
+			TenantID:  opts.TenantID,
+			RequestID: opts.RequestID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, found...)
+	}
+
+	slices.SortStableFunc(out, func(a, b pubsearch.SearchedEntity[pubsearch.Value]) int {
+		return cmp.Compare(b.Match, a.Match)
+	})
+	if opts.Limit > 0 && len(out) > opts.Limit {
+		out = out[:opts.Limit]
+	}
+	return out, nil
+}
+
+// searchBIC finds entities listed with a BIC. The BIC8 of a head office, written with or without
+// its "XXX" branch code, is searched both ways.
+func (s *service) searchBIC(ctx context.Context, bic string, opts ScreenOpts) ([]search.IdentifierMatch, error) {
+	codes := []string{bic}
+	switch {
+	case len(bic) == 8:
+		codes = append(codes, bic+"XXX")
+	case len(bic) == 11 && bic[8:] == "XXX":
+		codes = append(codes, bic[:8])
+	}
+
+	var out []search.IdentifierMatch
+	for _, code := range codes {
+		found, err := s.searchService.SearchByIdentifier(ctx, search.IdentifierQuery{
+			Identifier: code,
+			Type:       pubsearch.GovernmentIDSWIFT,
+			Limit:      opts.Limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		// A BIC which differs by a character is another bank
+		for _, match := range found {
+			if match.Exact {
+				out = append(out, match)
+			}
+		}
+	}
+	return out, nil
+}
+
+func (p Party) query(entityType pubsearch.EntityType) pubsearch.Entity[pubsearch.Value] {
+	query := pubsearch.Entity[pubsearch.Value]{
+		Name:   p.Name,
+		Type:   entityType,
+		Source: pubsearch.SourceAPIRequest,
+	}
+	if p.Address != nil {
+		query.Addresses = []pubsearch.Address{*p.Address}
+	}
+
+	switch entityType {
+	case pubsearch.EntityPerson:
+		query.Person = &pubsearch.Person{Name: p.Name}
+	case pubsearch.EntityBusiness:
+		query.Business = &pubsearch.Business{Name: p.Name}
+		if p.BIC != "" {
+			query.Business.GovernmentIDs = []pubsearch.GovernmentID{{Type: pubsearch.GovernmentIDSWIFT, Identifier: p.BIC}}
+		}
+	}
+	return query
+}
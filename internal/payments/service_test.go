@@ -0,0 +1,72 @@
+package payments
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func testService(t *testing.T) Service {
+	t.Helper()
+
+	logger := log.NewTestLogger()
+	searchService := search.NewService(logger)
+	searchService.UpdateEntities([]pubsearch.Entity[pubsearch.Value]{
+		{
+			Name:     "KOREA DAESONG BANK",
+			Type:     pubsearch.EntityBusiness,
+			Source:   pubsearch.SourceUSOFAC,
+			SourceID: "12312",
+			Business: &pubsearch.Business{
+				Name: "KOREA DAESONG BANK",
+				GovernmentIDs: []pubsearch.GovernmentID{
+					{Type: pubsearch.GovernmentIDSWIFT, Identifier: "KDBKKPPY"},
+				},
+			},
+		},
+		{
+			Name:     "Maria Schmitt",
+			Type:     pubsearch.EntityPerson,
+			Source:   pubsearch.SourceUSOFAC,
+			SourceID: "100",
+			Person:   &pubsearch.Person{Name: "Maria Schmitt"},
+		},
+	})
+	return NewService(logger, searchService)
+}
+
+func TestService_Screen(t *testing.T) {
+	svc := testService(t)
+	ctx := context.Background()
+
+	result, err := svc.Screen(ctx, readMessage(t, "mt103.txt"), ScreenOpts{Limit: 5, MinMatch: 0.85})
+	require.NoError(t, err)
+	require.Equal(t, FormatMT103, result.Format)
+	require.Len(t, result.Parties, 7)
+
+	byRole := make(map[Role]PartyResult)
+	for _, party := range result.Parties {
+		byRole[party.Role] = party
+	}
+
+	// The beneficiary's bank matches by name, while the receiver matches by the BIC8 of its head office
+	require.NotEmpty(t, byRole[RoleCreditorAgent].Entities)
+	require.Equal(t, "12312", byRole[RoleCreditorAgent].Entities[0].SourceID)
+	require.Len(t, byRole[RoleInstructedAgent].BICMatches, 1)
+	require.Equal(t, "12312", byRole[RoleInstructedAgent].BICMatches[0].SourceID)
+
+	// The ordering customer is screened as a person and a business
+	require.NotEmpty(t, byRole[RoleDebtor].Entities)
+	require.Equal(t, "100", byRole[RoleDebtor].Entities[0].SourceID)
+
+	require.Empty(t, byRole[RoleDebtorAgent].Entities)
+	require.Empty(t, byRole[RoleDebtorAgent].BICMatches)
+
+	_, err = svc.Screen(ctx, []byte("hello"), ScreenOpts{})
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
@@ -0,0 +1,138 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package prepare
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+var (
+	//go:embed nicknames.csv
+	defaultNicknamesFile []byte
+
+	// nicknames maps each nickname to the formal names it's short for
+	nicknames atomic.Pointer[map[string][]string]
+
+	// maxNicknameVariants caps how many expanded names are generated for one query
+	maxNicknameVariants = 8
+)
+
+func init() {
+	dict, err := readNicknames(bytes.NewReader(defaultNicknamesFile))
+	if err != nil {
+		panic(fmt.Sprintf("reading default nicknames: %v", err)) //nolint:forbidigo
+	}
+	nicknames.Store(&dict)
+}
+
+// LoadNicknamesFile adds the nicknames from a custom dictionary file to the default dictionary.
+//
+// Each line of the file lists a formal name followed by its nicknames, separated by commas
+// (e.g. "william,bill,billy"). Lines starting with # are skipped.
+func LoadNicknamesFile(path string) error {
+	fd, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening nicknames file: %w", err)
+	}
+	defer fd.Close()
+
+	custom, err := readNicknames(fd)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	dict := make(map[string][]string)
+	for nick, formal := range *nicknames.Load() {
+		dict[nick] = slices.Clone(formal)
+	}
+	for nick, formal := range custom {
+		for _, name := range formal {
+			if !slices.Contains(dict[nick], name) {
+				dict[nick] = append(dict[nick], name)
+			}
+		}
+	}
+	nicknames.Store(&dict)
+
+	return nil
+}
+
+func readNicknames(r io.Reader) (map[string][]string, error) {
+	dict := make(map[string][]string)
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		names := strings.Split(strings.ToLower(text), ",")
+		if len(names) < 2 {
+			return nil, fmt.Errorf("line %d: expected a formal name followed by nicknames", line)
+		}
+		formal := strings.TrimSpace(names[0])
+		if formal == "" || strings.Contains(formal, " ") {
+			return nil, fmt.Errorf("line %d: invalid formal name %q", line, formal)
+		}
+		for _, nick := range names[1:] {
+			nick = strings.TrimSpace(nick)
+			if nick == "" || strings.Contains(nick, " ") {
+				return nil, fmt.Errorf("line %d: invalid nickname %q", line, nick)
+			}
+			if !slices.Contains(dict[nick], formal) {
+				dict[nick] = append(dict[nick], formal)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return dict, nil
+}
+
+// FormalNames returns the formal names that a lowercase nickname is short for, such as "william" for "bill".
+func FormalNames(nickname string) []string {
+	return (*nicknames.Load())[nickname]
+}
+
+// NicknameVariants returns copies of the lowercase name terms with nicknames replaced by their formal names.
+// "bob smith" produces "robert smith". Nil is returned when no term is a known nickname.
+func NicknameVariants(terms []string) [][]string {
+	dict := *nicknames.Load()
+
+	var out [][]string
+	for i, term := range terms {
+		formal, exists := dict[term]
+		if !exists {
+			continue
+		}
+
+		// Expand each variant found so far along with the original terms
+		bases := append([][]string{terms}, out...)
+		for _, base := range bases {
+			for _, name := range formal {
+				if len(out) >= maxNicknameVariants {
+					return out
+				}
+				variant := slices.Clone(base)
+				variant[i] = name
+				out = append(out, variant)
+			}
+		}
+	}
+	return out
+}
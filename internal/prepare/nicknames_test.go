@@ -0,0 +1,57 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package prepare
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormalNames(t *testing.T) {
+	require.Equal(t, []string{"william"}, FormalNames("bill"))
+	require.ElementsMatch(t, []string{"alejandro", "alexander"}, FormalNames("alex"))
+	require.Empty(t, FormalNames("william"))
+}
+
+func TestNicknameVariants(t *testing.T) {
+	require.Equal(t, [][]string{{"robert", "smith"}}, NicknameVariants([]string{"bob", "smith"}))
+
+	got := NicknameVariants([]string{"bill", "ted", "jones"})
+	require.Contains(t, got, []string{"william", "ted", "jones"})
+	require.Contains(t, got, []string{"william", "edward", "jones"})
+	require.Contains(t, got, []string{"bill", "theodore", "jones"})
+
+	require.Nil(t, NicknameVariants([]string{"robert", "smith"}))
+	require.Nil(t, NicknameVariants(nil))
+}
+
+func TestLoadNicknamesFile(t *testing.T) {
+	original := nicknames.Load()
+	t.Cleanup(func() { nicknames.Store(original) })
+
+	path := filepath.Join(t.TempDir(), "nicknames.csv")
+	contents := strings.Join([]string{
+		"# custom names",
+		"Guadalupe,Lupe,Pita",
+		"william,bubba",
+	}, "\n")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+
+	require.NoError(t, LoadNicknamesFile(path))
+	require.Equal(t, []string{"guadalupe"}, FormalNames("lupe"))
+	require.Equal(t, []string{"william"}, FormalNames("bubba"))
+	require.Equal(t, []string{"william"}, FormalNames("bill")) // defaults are kept
+
+	t.Run("invalid", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("william\n"), 0600))
+		require.ErrorContains(t, LoadNicknamesFile(path), "line 1")
+
+		require.Error(t, LoadNicknamesFile(filepath.Join(t.TempDir(), "missing.csv")))
+	})
+}
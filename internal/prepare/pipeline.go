@@ -0,0 +1,193 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package prepare
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Stage is one named step of normalizing a name.
+type Stage string
+
+const (
+	// StageReorder turns "SURNAME, Given Names" into "Given Names SURNAME" for individuals
+	StageReorder Stage = "reorder"
+
+	// StageStopwords removes common words (e.g. "the", "of") in the language the name is written in
+	StageStopwords Stage = "stopwords"
+
+	// StagePunctuation lowercases names, removes punctuation and strips accents
+	StagePunctuation Stage = "punctuation"
+
+	// StageCompanyTitles removes company suffixes such as "LLC" and "LTD."
+	StageCompanyTitles Stage = "company-titles"
+
+	// StageTransliterate romanizes names written in non-Latin scripts
+	StageTransliterate Stage = "transliterate"
+
+	// StageConfusables replaces lookalike characters, such as a Cyrillic "а" or a zero, with the Latin letter
+	// they look like
+	StageConfusables Stage = "confusables"
+
+	// StageHonorifics removes honorifics and titles, such as "Dr." or "Sheikh", from the names of individuals
+	StageHonorifics Stage = "honorifics"
+
+	// StageCompanySuffixes writes legal entity suffixes, such as "Limited" or "S.A.", in one canonical form
+	StageCompanySuffixes Stage = "company-suffixes"
+
+	// StageRemoveCompanySuffixes removes the legal entity suffixes StageCompanySuffixes would canonicalize
+	StageRemoveCompanySuffixes Stage = "remove-company-suffixes"
+)
+
+// Stages returns each Stage a Pipeline can run.
+func Stages() []Stage {
+	return []Stage{
+		StageReorder, StageStopwords, StagePunctuation, StageCompanyTitles, StageTransliterate, StageConfusables,
+		StageHonorifics, StageCompanySuffixes, StageRemoveCompanySuffixes,
+	}
+}
+
+// ParseStages reads a comma separated list of stages, such as "reorder,stopwords".
+func ParseStages(raw string) ([]Stage, error) {
+	var out []Stage
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		stage := Stage(name)
+		if !slices.Contains(Stages(), stage) {
+			return nil, fmt.Errorf("unknown prepare stage %q", name)
+		}
+		out = append(out, stage)
+	}
+	return out, nil
+}
+
+// PipelineConfig chooses the ordered stages used to prepare individual and non-individual names.
+type PipelineConfig struct {
+	Individual []Stage
+
+	// Entity is used for businesses, organizations, vessels and aircraft
+	Entity []Stage
+}
+
+// Pipeline runs a PipelineConfig's stages over names.
+type Pipeline struct {
+	individual []Stage
+	entity     []Stage
+}
+
+// NewPipeline validates the stages of a PipelineConfig.
+func NewPipeline(conf PipelineConfig) (*Pipeline, error) {
+	for _, stage := range append(slices.Clone(conf.Individual), conf.Entity...) {
+		if !slices.Contains(Stages(), stage) {
+			return nil, fmt.Errorf("unknown prepare stage %q", stage)
+		}
+	}
+	return &Pipeline{
+		individual: conf.Individual,
+		entity:     conf.Entity,
+	}, nil
+}
+
+// PrepareOptions describe the name being prepared.
+type PrepareOptions struct {
+	Individual bool
+
+	// Country is used to pick the language of stopwords, when the name's language can't be detected
+	Country string
+
+	// Timings adds up how long each stage took, when it's set
+	Timings StageTimings
+}
+
+// StageTimings holds how long each stage of a Pipeline took over every name it prepared.
+type StageTimings map[Stage]time.Duration
+
+// Prepare runs each stage in order over name.
+func (p *Pipeline) Prepare(name string, opts PrepareOptions) string {
+	if p == nil {
+		return name
+	}
+
+	for _, stage := range p.stages(opts) {
+		var start time.Time
+		if opts.Timings != nil {
+			start = time.Now()
+		}
+
+		name = runStage(stage, name, opts)
+
+		if opts.Timings != nil {
+			opts.Timings[stage] += time.Since(start)
+		}
+	}
+	return strings.TrimSpace(name)
+}
+
+// StageOutput is a name as one stage of a Pipeline left it
+type StageOutput struct {
+	Stage Stage  `json:"stage"`
+	Name  string `json:"name"`
+}
+
+// Trace runs each stage in order over name like Prepare, returning the name after each of them.
+func (p *Pipeline) Trace(name string, opts PrepareOptions) []StageOutput {
+	if p == nil {
+		return nil
+	}
+
+	stages := p.stages(opts)
+	out := make([]StageOutput, 0, len(stages))
+	for _, stage := range stages {
+		name = runStage(stage, name, opts)
+		out = append(out, StageOutput{
+			Stage: stage,
+			Name:  strings.TrimSpace(name),
+		})
+	}
+	return out
+}
+
+func (p *Pipeline) stages(opts PrepareOptions) []Stage {
+	if opts.Individual {
+		return p.individual
+	}
+	return p.entity
+}
+
+func runStage(stage Stage, name string, opts PrepareOptions) string {
+	switch stage {
+	case StageReorder:
+		sdnType := ""
+		if opts.Individual {
+			sdnType = "individual"
+		}
+		return ReorderSDNName(name, sdnType)
+	case StageStopwords:
+		return RemoveStopwords(name, opts.Country)
+	case StagePunctuation:
+		return LowerAndRemovePunctuation(name)
+	case StageCompanyTitles:
+		return RemoveCompanyTitles(name)
+	case StageTransliterate:
+		return Transliterate(name)
+	case StageConfusables:
+		return ReplaceConfusables(name)
+	case StageHonorifics:
+		if opts.Individual {
+			return RemoveHonorifics(name)
+		}
+	case StageCompanySuffixes:
+		return CanonicalizeCompanySuffixes(name)
+	case StageRemoveCompanySuffixes:
+		return RemoveCompanySuffixes(name)
+	}
+	return name
+}
@@ -0,0 +1,174 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package prepare
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+var (
+	//go:embed company_suffixes.csv
+	defaultCompanySuffixesFile []byte
+
+	// companySuffixes maps each way a legal entity suffix is written to its canonical form
+	companySuffixes atomic.Pointer[suffixDictionary]
+)
+
+type suffixDictionary struct {
+	canonical map[string]string
+	maxWords  int
+
+	// digest identifies the suffixes and their canonical forms, see Pipeline.Version
+	digest string
+}
+
+func init() {
+	dict, err := readCompanySuffixes(bytes.NewReader(defaultCompanySuffixesFile))
+	if err != nil {
+		panic(fmt.Sprintf("reading default company suffixes: %v", err)) //nolint:forbidigo
+	}
+	companySuffixes.Store(newSuffixDictionary(dict))
+}
+
+// LoadCompanySuffixesFile adds the suffixes of a custom mapping file to the default mapping, replacing the
+// canonical form of any suffix which is already mapped.
+//
+// Each line of the file lists a canonical suffix followed by the ways it's written, separated by commas
+// (e.g. "kk,kk,kabushiki kaisha"). Lines starting with # are skipped.
+func LoadCompanySuffixesFile(path string) error {
+	fd, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening company suffixes file: %w", err)
+	}
+	defer fd.Close()
+
+	custom, err := readCompanySuffixes(fd)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	dict := make(map[string]string)
+	for variant, canonical := range companySuffixes.Load().canonical {
+		dict[variant] = canonical
+	}
+	for variant, canonical := range custom {
+		dict[variant] = canonical
+	}
+	companySuffixes.Store(newSuffixDictionary(dict))
+
+	return nil
+}
+
+func readCompanySuffixes(r io.Reader) (map[string]string, error) {
+	dict := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		names := strings.Split(text, ",")
+		if len(names) < 2 {
+			return nil, fmt.Errorf("line %d: expected a canonical suffix followed by the ways it's written", line)
+		}
+		canonical := strings.Join(suffixWords(names[0]), " ")
+		if canonical == "" {
+			return nil, fmt.Errorf("line %d: missing canonical suffix", line)
+		}
+		for _, variant := range names[1:] {
+			if words := suffixWords(variant); len(words) > 0 {
+				dict[strings.Join(words, " ")] = canonical
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return dict, nil
+}
+
+func newSuffixDictionary(canonical map[string]string) *suffixDictionary {
+	dict := &suffixDictionary{canonical: canonical, maxWords: 1}
+	entries := make([]string, 0, len(canonical))
+	for variant, suffix := range canonical {
+		if n := len(strings.Fields(variant)); n > dict.maxWords {
+			dict.maxWords = n
+		}
+		entries = append(entries, variant+"="+suffix)
+	}
+	dict.digest = digestOf(entries)
+	return dict
+}
+
+var suffixPunctuation = strings.NewReplacer(".", "", ",", " ", "/", "", "(", " ", ")", " ")
+
+// suffixWords lowercases the words of s and removes their periods, commas and slashes, so "S.A." and "A/S"
+// are read as "sa" and "as"
+func suffixWords(s string) []string {
+	return strings.Fields(suffixPunctuation.Replace(strings.ToLower(s)))
+}
+
+// CanonicalizeCompanySuffixes replaces the legal entity suffixes at the end of a name with their canonical form,
+// so "ACME Shipping Limited" and "ACME SHIPPING LTD." are both written "ACME Shipping ltd".
+func CanonicalizeCompanySuffixes(name string) string {
+	words, suffixes := splitCompanySuffixes(name)
+	if len(suffixes) == 0 {
+		return name
+	}
+	return strings.Join(append(words, suffixes...), " ")
+}
+
+// RemoveCompanySuffixes removes the legal entity suffixes at the end of a name, such as the "GmbH" of
+// "Siemens GmbH". Names made up only of suffixes are returned unchanged.
+func RemoveCompanySuffixes(name string) string {
+	words, suffixes := splitCompanySuffixes(name)
+	if len(suffixes) == 0 || len(words) == 0 {
+		return name
+	}
+	return strings.Join(words, " ")
+}
+
+// splitCompanySuffixes returns the words of name before its suffixes, along with the canonical form of each suffix
+func splitCompanySuffixes(name string) ([]string, []string) {
+	dict := companySuffixes.Load()
+	words := strings.Fields(name)
+
+	var suffixes []string
+	for len(words) > 0 {
+		matched := 0
+		n := dict.maxWords
+		if len(words) < n {
+			n = len(words)
+		}
+		for ; n > 0; n-- {
+			phrase := strings.Join(suffixWords(strings.Join(words[len(words)-n:], " ")), " ")
+			if canonical, found := dict.canonical[phrase]; found {
+				suffixes = append([]string{canonical}, suffixes...)
+				matched = n
+				break
+			}
+		}
+		if matched == 0 {
+			break
+		}
+		words = words[:len(words)-matched]
+	}
+	if len(words) > 0 {
+		words[len(words)-1] = strings.TrimRight(words[len(words)-1], ",")
+	}
+	return words, suffixes
+}
@@ -0,0 +1,75 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package prepare
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalizeCompanySuffixes(t *testing.T) {
+	tests := []struct {
+		input, expected string
+	}{
+		{"ACME Shipping Limited", "ACME Shipping ltd"},
+		{"ACME SHIPPING LTD.", "ACME SHIPPING ltd"},
+		{"Banco Nacional S.A.", "Banco Nacional sa"},
+		{"Grupo Industrial S.A. de C.V.", "Grupo Industrial sa de cv"},
+		{"Siemens GmbH", "Siemens gmbh"},
+		{"Rosneft OOO", "Rosneft ooo"},
+		{"Koc Holding A.Ş.", "Koc Holding as"},
+		{"Maersk A/S", "Maersk as"},
+		{"Jurong Pte. Ltd.", "Jurong pte ltd"},
+		{"China Ocean Shipping Co., Ltd.", "China Ocean Shipping ltd"},
+		{"ACME, Inc.", "ACME inc"},
+		{"Limited Brands Holdings", "Limited Brands Holdings"},
+		{"", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			require.Equal(t, tc.expected, CanonicalizeCompanySuffixes(tc.input))
+		})
+	}
+}
+
+func TestRemoveCompanySuffixes(t *testing.T) {
+	require.Equal(t, "Siemens", RemoveCompanySuffixes("Siemens GmbH"))
+	require.Equal(t, "China Ocean Shipping", RemoveCompanySuffixes("China Ocean Shipping Co., Ltd."))
+	require.Equal(t, "ACME", RemoveCompanySuffixes("ACME, Inc."))
+	require.Equal(t, "Limited", RemoveCompanySuffixes("Limited"))
+}
+
+func TestPipeline_CompanySuffixes(t *testing.T) {
+	pipeline, err := NewPipeline(PipelineConfig{
+		Entity: []Stage{StageCompanySuffixes},
+	})
+	require.NoError(t, err)
+	require.Equal(t, pipeline.Prepare("ACME Shipping Limited", PrepareOptions{}), pipeline.Prepare("ACME Shipping Ltd.", PrepareOptions{}))
+}
+
+func TestLoadCompanySuffixesFile(t *testing.T) {
+	original := companySuffixes.Load()
+	t.Cleanup(func() { companySuffixes.Store(original) })
+
+	path := filepath.Join(t.TempDir(), "company_suffixes.csv")
+	contents := strings.Join([]string{
+		"# custom suffixes",
+		"kk,K.K.,Kabushiki Kaisha",
+		"limited,ltd,limited",
+	}, "\n")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+
+	require.NoError(t, LoadCompanySuffixesFile(path))
+	require.Equal(t, "Toyota kk", CanonicalizeCompanySuffixes("Toyota Kabushiki Kaisha"))
+	require.Equal(t, "ACME limited", CanonicalizeCompanySuffixes("ACME Ltd"))
+	require.Equal(t, "Siemens gmbh", CanonicalizeCompanySuffixes("Siemens GmbH")) // defaults are kept
+
+	require.NoError(t, os.WriteFile(path, []byte("kk"), 0600))
+	require.ErrorContains(t, LoadCompanySuffixesFile(path), "line 1: expected a canonical suffix")
+}
@@ -0,0 +1,128 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package prepare
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// confusables maps letters of other scripts which look like Latin letters to the letter they look like
+var confusables = map[rune]rune{
+	// Cyrillic
+	'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H', 'О': 'O', 'Р': 'P', 'С': 'C', 'Т': 'T',
+	'У': 'Y', 'Х': 'X', 'І': 'I', 'Ј': 'J', 'Ѕ': 'S', 'Һ': 'H', 'Ԛ': 'Q', 'Ԝ': 'W', 'Ӏ': 'I',
+	'а': 'a', 'е': 'e', 'о': 'o', 'р': 'p', 'с': 'c', 'у': 'y', 'х': 'x', 'і': 'i', 'ј': 'j', 'ѕ': 's',
+	'һ': 'h', 'ԁ': 'd', 'ԛ': 'q', 'ԝ': 'w', 'ӏ': 'l',
+
+	// Greek
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K', 'Μ': 'M', 'Ν': 'N', 'Ο': 'O',
+	'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+	'α': 'a', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o', 'ρ': 'p', 'υ': 'u',
+
+	// Armenian
+	'օ': 'o', 'ս': 'u', 'հ': 'h', 'ց': 'g',
+}
+
+// confusableSymbols are digits and symbols which stand in for letters when they're written inside a word,
+// such as "H0SSEIN" or "PAYP@L"
+var confusableSymbols = map[rune]rune{
+	'0': 'o', '1': 'l', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b', '@': 'a', '$': 's',
+}
+
+// ReplaceConfusables replaces characters which look like Latin letters with those letters, so a name disguised
+// with lookalikes (a Cyrillic "а" in "Vlаdimir" or a zero in "H0SSEIN") is compared as it reads.
+//
+// Fullwidth and other compatibility forms are normalized and invisible characters, such as zero width spaces,
+// are removed. Words written entirely in another script are left for Transliterate, unless the rest of the name
+// is Latin and the word is only made of lookalikes.
+func ReplaceConfusables(s string) string {
+	s = norm.NFKC.String(s)
+	s = strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Cf, r) {
+			return -1
+		}
+		return r
+	}, s)
+
+	words := strings.Fields(s)
+	latinName := false
+	for _, word := range words {
+		if hasLatin(word) {
+			latinName = true
+			break
+		}
+	}
+	if !latinName {
+		return strings.Join(words, " ")
+	}
+
+	for i, word := range words {
+		if hasLatin(word) || onlyConfusables(word) {
+			words[i] = replaceConfusableRunes(word)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+func hasLatin(word string) bool {
+	for _, r := range word {
+		if unicode.Is(unicode.Latin, r) {
+			return true
+		}
+	}
+	return false
+}
+
+func onlyConfusables(word string) bool {
+	found := false
+	for _, r := range word {
+		if unicode.IsLetter(r) {
+			if _, ok := confusables[r]; !ok {
+				return false
+			}
+			found = true
+		}
+	}
+	return found
+}
+
+func replaceConfusableRunes(word string) string {
+	out := []rune(word)
+	for i, r := range out {
+		if c, ok := confusables[r]; ok {
+			out[i] = c
+		}
+	}
+
+	// Digits and symbols are only replaced between two letters, so "3M" and "A123456" are kept
+	for i := 1; i < len(out); i++ {
+		end := i
+		for end < len(out) && isConfusableSymbol(out[end]) {
+			end++
+		}
+		if end == i {
+			continue
+		}
+		if end < len(out) && unicode.IsLetter(out[i-1]) && unicode.IsLetter(out[end]) {
+			upper := unicode.IsUpper(out[i-1])
+			for j := i; j < end; j++ {
+				out[j] = confusableSymbols[out[j]]
+				if upper {
+					out[j] = unicode.ToUpper(out[j])
+				}
+			}
+		}
+		i = end
+	}
+	return string(out)
+}
+
+func isConfusableSymbol(r rune) bool {
+	_, ok := confusableSymbols[r]
+	return ok
+}
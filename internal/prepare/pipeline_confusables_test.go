@@ -0,0 +1,44 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package prepare
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceConfusables(t *testing.T) {
+	tests := []struct {
+		name, input, expected string
+	}{
+		{"latin", "Vladimir Putin", "Vladimir Putin"},
+		{"cyrillic a", "Vlаdimir Putin", "Vladimir Putin"},
+		{"cyrillic word", "Vladimir РUТIN", "Vladimir PUTIN"},
+		{"greek", "ΑLI ΗΑSSΑΝ", "ALI HASSAN"},
+		{"zero", "H0SSEIN", "HOSSEIN"},
+		{"zeros", "h00ssein", "hoossein"},
+		{"symbols", "P@YP$L", "PAYPSL"},
+		{"fullwidth", "ＡＣＭＥ Corp", "ACME Corp"},
+		{"zero width space", "Osa\u200bma", "Osama"},
+		{"leading digit", "3M Company", "3M Company"},
+		{"identifier", "A123456", "A123456"},
+		{"cyrillic name", "Владимир Путин", "Владимир Путин"},
+		{"empty", "", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, ReplaceConfusables(tc.input))
+		})
+	}
+}
+
+func TestPipeline_Confusables(t *testing.T) {
+	pipeline, err := NewPipeline(PipelineConfig{
+		Individual: []Stage{StageConfusables, StageTransliterate},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Vladimir Putin", pipeline.Prepare("Vlаdimir Рutin", PrepareOptions{Individual: true}))
+}
@@ -0,0 +1,139 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package prepare
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+var (
+	//go:embed honorifics.txt
+	defaultHonorificsFile []byte
+
+	// honorifics holds each honorific's lowercase words, joined by a space
+	honorifics atomic.Pointer[map[string]bool]
+
+	// maxHonorificWords is the most words an honorific can have
+	maxHonorificWords atomic.Int32
+
+	// honorificsDigest identifies the honorifics, see Pipeline.Version
+	honorificsDigest atomic.Pointer[string]
+)
+
+func init() {
+	dict, err := readHonorifics(bytes.NewReader(defaultHonorificsFile))
+	if err != nil {
+		panic(fmt.Sprintf("reading default honorifics: %v", err)) //nolint:forbidigo
+	}
+	storeHonorifics(dict)
+}
+
+// LoadHonorificsFile adds the honorifics of a custom file, one per line, to the default list.
+// Lines starting with # are skipped.
+func LoadHonorificsFile(path string) error {
+	fd, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("opening honorifics file: %w", err)
+	}
+	defer fd.Close()
+
+	custom, err := readHonorifics(fd)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	dict := make(map[string]bool)
+	for title := range *honorifics.Load() {
+		dict[title] = true
+	}
+	for title := range custom {
+		dict[title] = true
+	}
+	storeHonorifics(dict)
+
+	return nil
+}
+
+func readHonorifics(r io.Reader) (map[string]bool, error) {
+	dict := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if words := honorificWords(text); len(words) > 0 {
+			dict[strings.Join(words, " ")] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return dict, nil
+}
+
+func storeHonorifics(dict map[string]bool) {
+	longest := 1
+	titles := make([]string, 0, len(dict))
+	for title := range dict {
+		if n := len(strings.Fields(title)); n > longest {
+			longest = n
+		}
+		titles = append(titles, title)
+	}
+	digest := digestOf(titles)
+
+	honorifics.Store(&dict)
+	maxHonorificWords.Store(int32(longest))
+	honorificsDigest.Store(&digest)
+}
+
+// honorificWords lowercases the words of s, ignoring periods and splitting on hyphens so "Al-Haj" and "Dr."
+// match "al haj" and "dr"
+func honorificWords(s string) []string {
+	return strings.Fields(strings.NewReplacer(".", " ", "-", " ").Replace(strings.ToLower(s)))
+}
+
+// RemoveHonorifics removes honorifics and titles, such as "Dr.", "General" or "Sheikh", from a name. Names made up
+// only of honorifics are returned unchanged.
+func RemoveHonorifics(name string) string {
+	dict := *honorifics.Load()
+	longest := int(maxHonorificWords.Load())
+
+	words := strings.Fields(name)
+	out := make([]string, 0, len(words))
+	for i := 0; i < len(words); {
+		matched := 0
+		n := longest
+		if rest := len(words) - i; rest < n {
+			n = rest
+		}
+		for ; n > 0; n-- {
+			phrase := honorificWords(strings.Join(words[i:i+n], " "))
+			if len(phrase) > 0 && dict[strings.Join(phrase, " ")] {
+				matched = n
+				break
+			}
+		}
+		if matched == 0 {
+			out = append(out, words[i])
+			i++
+			continue
+		}
+		i += matched
+	}
+	if len(out) == 0 {
+		return name
+	}
+	return strings.Join(out, " ")
+}
@@ -0,0 +1,73 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package prepare
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveHonorifics(t *testing.T) {
+	tests := []struct {
+		input, expected string
+	}{
+		{"Dr. Ayman al-Zawahiri", "Ayman al-Zawahiri"},
+		{"General Qasem SOLEIMANI", "Qasem SOLEIMANI"},
+		{"Hajji Shibl AL-ZAYDI", "Shibl AL-ZAYDI"},
+		{"SHEIKH Ahmed Salim", "Ahmed Salim"},
+		{"Eng. Mohammad Hassan", "Mohammad Hassan"},
+		{"Lieutenant General Ali Hassan", "Ali Hassan"},
+		{"His Excellency John Smith", "John Smith"},
+		{"Al-Haj Abdul Rahman", "Abdul Rahman"},
+		{"John Major", "John Major"},
+		{"Sheikh", "Sheikh"},
+		{"", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			require.Equal(t, tc.expected, RemoveHonorifics(tc.input))
+		})
+	}
+}
+
+func TestPipeline_Honorifics(t *testing.T) {
+	pipeline, err := NewPipeline(PipelineConfig{
+		Individual: []Stage{StageHonorifics},
+		Entity:     []Stage{StageHonorifics},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Ali Hassan", pipeline.Prepare("General Ali Hassan", PrepareOptions{Individual: true}))
+
+	// Only the names of individuals have their titles removed
+	require.Equal(t, "General Dynamics", pipeline.Prepare("General Dynamics", PrepareOptions{}))
+}
+
+func TestLoadHonorificsFile(t *testing.T) {
+	original := honorifics.Load()
+	longest := maxHonorificWords.Load()
+	t.Cleanup(func() {
+		honorifics.Store(original)
+		maxHonorificWords.Store(longest)
+	})
+
+	path := filepath.Join(t.TempDir(), "honorifics.txt")
+	contents := strings.Join([]string{
+		"# custom titles",
+		"Comrade",
+		"Grand Ayatollah Emeritus",
+	}, "\n")
+	require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+
+	require.NoError(t, LoadHonorificsFile(path))
+	require.Equal(t, "Ali Hassan", RemoveHonorifics("Comrade Ali Hassan"))
+	require.Equal(t, "Ali Hassan", RemoveHonorifics("Grand Ayatollah Emeritus Ali Hassan"))
+	require.Equal(t, "Ali Hassan", RemoveHonorifics("Dr. Ali Hassan")) // defaults are kept
+
+	require.ErrorContains(t, LoadHonorificsFile(filepath.Join(t.TempDir(), "missing.txt")), "opening honorifics file")
+}
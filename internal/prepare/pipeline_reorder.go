@@ -5,23 +5,29 @@
 package prepare
 
 import (
-	"fmt"
 	"regexp"
 	"strings"
 )
 
-// This pattern looks for:
+// Names are split on their commas, where the first part is the surname and the rest are given names.
+// Each given name may only contain Unicode letters and diacritics (\p{L}\p{M}) plus allowed
+// punctuation/apostrophes/hyphens/spaces.
 //
-//	a comma, optional whitespace, then
-//	1+ Unicode letters and diacritics (\p{L}\p{M}), plus allowed punctuation/apostrophes/hyphens/spaces
-//	until the end of the string.
+// Generational and professional suffixes (Jr., III, M.D.) are moved to the end of the name.
 //
 // Examples that should match (and reorder):
 //
 //	"AL-ZAYDI, Shibl Muhsin 'Ubayd" --> "Shibl Muhsin 'Ubayd AL-ZAYDI"
 //	"MADURO MOROS, Nicolas"         --> "Nicolas MADURO MOROS"
+//	"DOE, John, Jr."                --> "John DOE Jr."
 var (
-	surnamePrecedes = regexp.MustCompile(`,(?:\s+)?([\p{L}\p{M}'’\-\.\s]+)$`)
+	givenNamePattern = regexp.MustCompile(`^[\p{L}\p{M}'’\-\.\s]+$`)
+
+	// nameSuffixes are compared after lowercasing and removing periods
+	nameSuffixes = map[string]bool{
+		"jr": true, "sr": true, "ii": true, "iii": true, "iv": true,
+		"md": true, "phd": true, "esq": true, "dds": true, "dmd": true, "cpa": true, "jd": true,
+	}
 )
 
 func ReorderSDNNames(names []string, sdnType string) []string {
@@ -38,28 +44,76 @@ func ReorderSDNNames(names []string, sdnType string) []string {
 
 // ReorderSDNName will take a given SDN name and, if it matches "Surname, FirstName(s)",
 // reorder it to "FirstName(s) Surname" (only for type == "individual").
+//
+// Suffixes are kept at the end, so "DOE, John, Jr." and "DOE Jr., John" become "John DOE Jr.".
 func ReorderSDNName(name, sdnType string) string {
 	// Only reorder for individuals
 	if !strings.EqualFold(sdnType, "individual") {
 		return name
 	}
-
-	// Try matching the pattern
-	match := surnamePrecedes.FindStringSubmatch(name)
-	if len(match) < 2 {
-		// No match => no reordering
+	if !strings.Contains(name, ",") {
 		return name
 	}
 
-	// match[1] is the part after the comma (the "first/given names" portion).
-	givenNames := strings.TrimSpace(match[1])
+	var parts []string
+	for _, part := range strings.Split(name, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	// Peel suffixes off the end, such as "DOE, John, Jr., M.D."
+	var suffixes []string
+	for len(parts) > 1 && isNameSuffix(parts[len(parts)-1], true) {
+		suffixes = append([]string{parts[len(parts)-1]}, suffixes...)
+		parts = parts[:len(parts)-1]
+	}
 
-	// match[0] is the entire substring matching the pattern, including the comma;
-	// remove it from the original to isolate the "surname" part.
-	surname := strings.TrimSuffix(name, match[0])
-	surname = strings.TrimSpace(surname)
+	if len(parts) < 2 {
+		// "John Doe, Jr." has nothing to reorder
+		return strings.Join(append(parts, suffixes...), " ")
+	}
+	for _, given := range parts[1:] {
+		if !givenNamePattern.MatchString(given) {
+			// No match => no reordering
+			return name
+		}
+	}
 
-	// Rebuild as "GivenName(s) Surname"
-	out := fmt.Sprintf("%s %s", givenNames, surname)
+	// Suffixes can also be written without a comma, as in "DOE Jr., John" or "DOE, John Jr."
+	surname, suffix := cutNameSuffix(parts[0])
+	if suffix != "" {
+		suffixes = append([]string{suffix}, suffixes...)
+	}
+	givenNames := strings.Join(parts[1:], " ")
+	if rest, suffix := cutNameSuffix(givenNames); suffix != "" && rest != "" {
+		givenNames = rest
+		suffixes = append([]string{suffix}, suffixes...)
+	}
+
+	// Rebuild as "GivenName(s) Surname Suffix(es)"
+	out := strings.Join(append([]string{givenNames, surname}, suffixes...), " ")
 	return strings.TrimSpace(out)
 }
+
+// isNameSuffix reports if term is a generational or professional suffix. "V" is only treated as
+// a suffix when it's been separated by a comma, since it's otherwise likely to be an initial.
+func isNameSuffix(term string, separated bool) bool {
+	term = strings.ToLower(strings.ReplaceAll(term, ".", ""))
+	if separated && term == "v" {
+		return true
+	}
+	return nameSuffixes[term]
+}
+
+// cutNameSuffix splits a trailing suffix from the end of name
+func cutNameSuffix(name string) (string, string) {
+	idx := strings.LastIndex(name, " ")
+	if idx < 0 {
+		return name, ""
+	}
+	if last := name[idx+1:]; isNameSuffix(last, false) {
+		return strings.TrimSpace(name[:idx]), last
+	}
+	return name, ""
+}
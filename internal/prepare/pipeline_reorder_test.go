@@ -30,6 +30,19 @@ func TestReorderSDNName(t *testing.T) {
 		// Issue 115
 		{"Bush, George W", "George W Bush"},
 		{"RIZO MORENO, Jorge Luis", "Jorge Luis RIZO MORENO"},
+		{"DE LA CRUZ, Maria del Carmen", "Maria del Carmen DE LA CRUZ"},
+		// Suffixes
+		{"DOE, John, Jr.", "John DOE Jr."},
+		{"DOE Jr., John", "John DOE Jr."},
+		{"DOE, John Jr.", "John DOE Jr."},
+		{"SMITH, John Paul, III", "John Paul SMITH III"},
+		{"SMITH, John, Jr., M.D.", "John SMITH Jr. M.D."},
+		{"KING, Martin Luther, V", "Martin Luther KING V"},
+		{"John Doe, Jr.", "John Doe Jr."},
+		// Multiple commas
+		{"DOE, John, Michael", "John Michael DOE"},
+		{"DOE,, John", "John DOE"},
+		{"DOE, John, 123", "DOE, John, 123"},
 	}
 	for i := range cases {
 		got := ReorderSDNName(cases[i].input, "individual")
@@ -4,85 +4,122 @@
 
 package prepare
 
-// import (
-// 	"testing"
-
-// 	"github.com/moov-io/base/log"
-// 	"github.com/moov-io/watchman/pkg/ofac"
-// )
-
-// var (
-// 	noopPipeliner = &Pipeliner{
-// 		logger: log.NewNopLogger(),
-// 		steps:  []step{},
-// 	}
-
-// 	noLogPipeliner = NewPipeliner(log.NewNopLogger(), false)
-// )
-
-// func TestPipelineNoop(t *testing.T) {
-// 	if err := noopPipeliner.Do(&Name{}); err != nil {
-// 		t.Fatal(err)
-// 	}
-// }
-
-// func TestFullPipeline(t *testing.T) {
-// 	individual := func(in string) *Name {
-// 		return &Name{
-// 			Processed: in,
-// 			sdn: &ofac.SDN{
-// 				SDNType: "individual",
-// 			},
-// 		}
-// 	}
-// 	company := func(in string) *Name {
-// 		return &Name{
-// 			Processed: in,
-// 			sdn: &ofac.SDN{
-// 				SDNType: "", // blank refers to a company
-// 			},
-// 		}
-// 	}
-
-// 	cases := []struct {
-// 		in       *Name
-// 		expected string
-// 	}{
-// 		// input edge cases
-// 		{individual(""), ""},
-// 		{individual(" "), ""},
-// 		{individual("  "), ""},
-// 		{company(""), ""},
-// 		{company(" "), ""},
-// 		{company("  "), ""},
-
-// 		// Re-order individual names
-// 		{individual("MADURO MOROS, Nicolas"), "nicolas maduro moros"},
-
-// 		// Remove Company Suffixes
-// 		{company("YAKIMA OIL TRADING, LLP"), "yakima oil trading"},                                                      // SDN 20259
-// 		{company("MKS INTERNATIONAL CO. LTD."), "mks international"},                                                    // SDN 21553
-// 		{company("SHANGHAI NORTH TRANSWAY INTERNATIONAL TRADING CO."), "shanghai north transway international trading"}, // SDN 22246
-
-// 		// Keep numbers
-// 		{company("11420 CORP."), "11420 corp"},
-// 		{company("11AA420 CORP."), "11aa420 corp"},
-// 		{company("11,420.2-1 CORP."), "114202 1 corp"},
-
-// 		// Remove stopwords
-// 		{company("INVERSIONES LA QUINTA Y CIA. LTDA."), "inversiones la quinta y cia"},
-
-// 		// Normalize ("-" -> " ")
-// 		{company("ANGLO-CARIBBEAN CO., LTD."), "anglo caribbean"},
-// 	}
-
-// 	for i := range cases {
-// 		if err := noLogPipeliner.Do(cases[i].in); err != nil {
-// 			t.Error(err)
-// 		} else {
-// 			if cases[i].in.Processed != cases[i].expected {
-// 				t.Errorf("%d# output=%q expected=%q", i, cases[i].in.Processed, cases[i].expected)
-// 			}
-// 		}
-// 	}
-// }
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineNoop(t *testing.T) {
+	var nilPipeline *Pipeline
+	require.Equal(t, "MADURO MOROS, Nicolas", nilPipeline.Prepare("MADURO MOROS, Nicolas", PrepareOptions{Individual: true}))
+
+	pipeline, err := NewPipeline(PipelineConfig{})
+	require.NoError(t, err)
+	require.Equal(t, "MADURO MOROS, Nicolas", pipeline.Prepare("MADURO MOROS, Nicolas", PrepareOptions{Individual: true}))
+}
+
+func TestFullPipeline(t *testing.T) {
+	stages := []Stage{StageReorder, StageCompanyTitles, StageStopwords, StagePunctuation}
+	pipeline, err := NewPipeline(PipelineConfig{
+		Individual: stages,
+		Entity:     stages,
+	})
+	require.NoError(t, err)
+
+	individual := PrepareOptions{Individual: true}
+	company := PrepareOptions{}
+
+	cases := []struct {
+		in       string
+		opts     PrepareOptions
+		expected string
+	}{
+		// Re-order individual names
+		{"MADURO MOROS, Nicolas", individual, "nicolas maduro moros"},
+
+		// Remove Company Suffixes
+		{"YAKIMA OIL TRADING, LLP", company, "yakima oil trading"},                                                      // SDN 20259
+		{"MKS INTERNATIONAL CO. LTD.", company, "mks international"},                                                    // SDN 21553
+		{"SHANGHAI NORTH TRANSWAY INTERNATIONAL TRADING CO.", company, "shanghai north transway international trading"}, // SDN 22246
+
+		// Keep numbers
+		{"11420 CORP.", company, "11420 corp"},
+		{"11AA420 CORP.", company, "11aa420 corp"},
+		{"11,420.2-1 CORP.", company, "114202 1 corp"},
+
+		// Remove stopwords
+		{"INVERSIONES LA QUINTA Y CIA. LTDA.", company, "inversiones la quinta y cia"},
+		{"The Bank of Shipping", company, "bank shipping"},
+
+		// Normalize ("-" -> " ")
+		{"ANGLO-CARIBBEAN CO., LTD.", company, "anglo caribbean"},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.expected, pipeline.Prepare(tc.in, tc.opts), tc.in)
+	}
+}
+
+func TestPipeline_Order(t *testing.T) {
+	name := "MADURO MOROS, Nicolas"
+
+	// Punctuation removes the comma reorder looks for
+	pipeline, err := NewPipeline(PipelineConfig{Individual: []Stage{StagePunctuation, StageReorder}})
+	require.NoError(t, err)
+	require.Equal(t, "maduro moros nicolas", pipeline.Prepare(name, PrepareOptions{Individual: true}))
+
+	pipeline, err = NewPipeline(PipelineConfig{Individual: []Stage{StageReorder, StagePunctuation}})
+	require.NoError(t, err)
+	require.Equal(t, "nicolas maduro moros", pipeline.Prepare(name, PrepareOptions{Individual: true}))
+
+	// Entities use their own stages
+	require.Equal(t, name, pipeline.Prepare(name, PrepareOptions{}))
+}
+
+func TestPipeline_Timings(t *testing.T) {
+	pipeline, err := NewPipeline(PipelineConfig{Individual: []Stage{StageReorder, StagePunctuation}})
+	require.NoError(t, err)
+
+	timings := make(StageTimings)
+	opts := PrepareOptions{Individual: true, Timings: timings}
+	require.Equal(t, "nicolas maduro moros", pipeline.Prepare("MADURO MOROS, Nicolas", opts))
+	pipeline.Prepare("SMITH, John", opts)
+
+	require.Len(t, timings, 2)
+	require.Contains(t, timings, StageReorder)
+	require.Contains(t, timings, StagePunctuation)
+}
+
+func TestPipeline_Trace(t *testing.T) {
+	pipeline, err := NewPipeline(PipelineConfig{Individual: []Stage{StageReorder, StageHonorifics, StagePunctuation}})
+	require.NoError(t, err)
+
+	name := "AL-ZAWAHIRI, Dr. Ayman"
+	opts := PrepareOptions{Individual: true}
+	trace := pipeline.Trace(name, opts)
+	require.Equal(t, []StageOutput{
+		{Stage: StageReorder, Name: "Dr. Ayman AL-ZAWAHIRI"},
+		{Stage: StageHonorifics, Name: "Ayman AL-ZAWAHIRI"},
+		{Stage: StagePunctuation, Name: "ayman al zawahiri"},
+	}, trace)
+	require.Equal(t, pipeline.Prepare(name, opts), trace[len(trace)-1].Name)
+
+	var none *Pipeline
+	require.Empty(t, none.Trace(name, opts))
+}
+
+func TestParseStages(t *testing.T) {
+	stages, err := ParseStages("reorder, Stopwords,,transliterate")
+	require.NoError(t, err)
+	require.Equal(t, []Stage{StageReorder, StageStopwords, StageTransliterate}, stages)
+
+	stages, err = ParseStages("")
+	require.NoError(t, err)
+	require.Empty(t, stages)
+
+	_, err = ParseStages("reorder,other")
+	require.ErrorContains(t, err, `unknown prepare stage "other"`)
+
+	_, err = NewPipeline(PipelineConfig{Entity: []Stage{"other"}})
+	require.ErrorContains(t, err, `unknown prepare stage "other"`)
+}
@@ -0,0 +1,268 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package prepare
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mozillazg/go-unidecode"
+)
+
+// Transliterate romanizes names written in non-Latin scripts (such as Cyrillic, Arabic, Chinese or Korean)
+// so they can be compared against the Latin-script names found on most sanctions lists.
+//
+// Strings that only contain Latin letters are returned unchanged.
+func Transliterate(s string) string {
+	if !HasNonLatin(s) {
+		return s
+	}
+
+	words := strings.Fields(s)
+	out := make([]string, 0, len(words))
+	for _, word := range words {
+		switch {
+		case containsScript(word, unicode.Arabic):
+			out = append(out, transliterateArabic(word))
+
+		case containsScript(word, unicode.Cyrillic):
+			out = append(out, transliterateCyrillic(word))
+
+		case containsScript(word, unicode.Hangul):
+			// Korean names are written without spaces, but romanized with one term per syllable
+			for _, r := range word {
+				out = append(out, unidecode.Unidecode(string(r)))
+			}
+
+		default:
+			out = append(out, unidecode.Unidecode(word))
+		}
+	}
+	return strings.Join(strings.Fields(strings.Join(out, " ")), " ")
+}
+
+// HasNonLatin reports if s contains any letters outside of the Latin script.
+func HasNonLatin(s string) bool {
+	for _, r := range s {
+		if r <= unicode.MaxASCII {
+			continue
+		}
+		if unicode.IsLetter(r) && !unicode.Is(unicode.Latin, r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Script is the writing system of a name, see DetectScript
+type Script string
+
+const (
+	ScriptLatin    Script = "latin"
+	ScriptCyrillic Script = "cyrillic"
+	ScriptArabic   Script = "arabic"
+	ScriptHan      Script = "han"
+	ScriptHangul   Script = "hangul"
+
+	// ScriptOther is any other script, such as Greek or the Japanese kana
+	ScriptOther Script = "other"
+)
+
+var scriptTables = []struct {
+	script Script
+	table  *unicode.RangeTable
+}{
+	{ScriptLatin, unicode.Latin},
+	{ScriptCyrillic, unicode.Cyrillic},
+	{ScriptArabic, unicode.Arabic},
+	{ScriptHan, unicode.Han},
+	{ScriptHangul, unicode.Hangul},
+}
+
+// DetectScript returns the script most of the letters of s are written in, or an empty Script when s has
+// no letters
+func DetectScript(s string) Script {
+	counts := make(map[Script]int)
+	for _, r := range s {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		script := ScriptOther
+		if r <= unicode.MaxASCII {
+			script = ScriptLatin
+		} else {
+			for _, t := range scriptTables {
+				if unicode.Is(t.table, r) {
+					script = t.script
+					break
+				}
+			}
+		}
+		counts[script] += 1
+	}
+
+	// Ties go to the first script listed, then ScriptOther
+	var best Script
+	for _, t := range scriptTables {
+		if counts[t.script] > counts[best] {
+			best = t.script
+		}
+	}
+	if counts[ScriptOther] > counts[best] {
+		best = ScriptOther
+	}
+	return best
+}
+
+// Comparable reports if names written in s and other can be compared once they're romanized. Han characters
+// are romanized by their Mandarin reading, which is unrelated to how the same name is written in Cyrillic, Arabic
+// or Hangul, so comparing those only produces noise scores. Names are otherwise compared across scripts, such
+// as an Arabic query and a Cyrillic alias which are both romanized by sound.
+func (s Script) Comparable(other Script) bool {
+	if s == other || s == "" || other == "" {
+		return true
+	}
+	if s == ScriptLatin || other == ScriptLatin || s == ScriptOther || other == ScriptOther {
+		return true
+	}
+	return s != ScriptHan && other != ScriptHan
+}
+
+// WithRomanizedNames returns names along with the romanized form of each non-Latin name,
+// skipping romanized forms which are already present.
+func WithRomanizedNames(names []string) []string {
+	out := names[:len(names):len(names)]
+	for _, name := range names {
+		if !HasNonLatin(name) {
+			continue
+		}
+		romanized := Transliterate(name)
+		if romanized == "" || containsFold(out, romanized) {
+			continue
+		}
+		out = append(out, romanized)
+	}
+	return out
+}
+
+func containsFold(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsScript(s string, script *unicode.RangeTable) bool {
+	for _, r := range s {
+		if unicode.Is(script, r) {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	// arabicNames are common name terms whose romanization can't be derived letter by letter,
+	// since short vowels are not written in Arabic.
+	arabicNames = map[string]string{
+		"محمد":    "muhammad",
+		"محمود":   "mahmud",
+		"أحمد":    "ahmad",
+		"احمد":    "ahmad",
+		"علي":     "ali",
+		"عبد":     "abd",
+		"الله":    "allah",
+		"عبدالله": "abdullah",
+		"حسن":     "hasan",
+		"حسين":    "husayn",
+		"عمر":     "umar",
+		"عثمان":   "uthman",
+		"خالد":    "khalid",
+		"إبراهيم": "ibrahim",
+		"ابراهيم": "ibrahim",
+		"يوسف":    "yusuf",
+		"مصطفى":   "mustafa",
+		"صالح":    "salih",
+		"سعيد":    "said",
+		"ناصر":    "nasir",
+		"جمال":    "jamal",
+		"كريم":    "karim",
+		"الرحمن":  "al rahman",
+		"عزيز":    "aziz",
+		"بن":      "bin",
+		"ابن":     "ibn",
+		"أبو":     "abu",
+		"ابو":     "abu",
+		"بنت":     "bint",
+	}
+
+	arabicLetters = map[rune]string{
+		'ا': "a", 'أ': "a", 'إ': "i", 'آ': "a", 'ٱ': "a", 'ى': "a", 'ة': "a",
+		'ب': "b", 'ت': "t", 'ث': "th", 'ج': "j", 'ح': "h", 'خ': "kh",
+		'د': "d", 'ذ': "dh", 'ر': "r", 'ز': "z", 'س': "s", 'ش': "sh",
+		'ص': "s", 'ض': "d", 'ط': "t", 'ظ': "z", 'غ': "gh", 'ف': "f",
+		'ق': "q", 'ك': "k", 'ل': "l", 'م': "m", 'ن': "n", 'ه': "h",
+		'و': "u", 'ي': "i",
+		// Persian and Urdu letters
+		'پ': "p", 'چ': "ch", 'ژ': "zh", 'گ': "g", 'ک': "k", 'ی': "i",
+		// ayn and hamza are usually dropped when names are romanized
+		'ع': "", 'ء': "", 'ئ': "", 'ؤ': "",
+	}
+)
+
+// transliterateArabic romanizes a single Arabic word, handling the "al-" article and common names.
+func transliterateArabic(word string) string {
+	word = strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) || r == 'ـ' { // harakat and tatweel
+			return -1
+		}
+		return r
+	}, word)
+
+	if name, exists := arabicNames[word]; exists {
+		return name
+	}
+	if rest, found := strings.CutPrefix(word, "ال"); found && len(rest) > 0 {
+		return "al " + transliterateArabic(rest)
+	}
+
+	var buf strings.Builder
+	for i, r := range []rune(word) {
+		switch {
+		case i == 0 && r == 'و':
+			buf.WriteString("w") // waw and yeh are consonants when they start a word
+		case i == 0 && (r == 'ي' || r == 'ی'):
+			buf.WriteString("y")
+		default:
+			if latin, exists := arabicLetters[r]; exists {
+				buf.WriteString(latin)
+			} else {
+				buf.WriteString(unidecode.Unidecode(string(r)))
+			}
+		}
+	}
+	return buf.String()
+}
+
+// cyrillicLetters override the romanizations of go-unidecode with those closer to the
+// BGN/PCGN style used by OFAC and other lists, such as "Yuryevich" rather than "Iur'evich"
+var cyrillicLetters = map[rune]string{
+	'ю': "yu", 'Ю': "Yu", 'я': "ya", 'Я': "Ya", 'ё': "yo", 'Ё': "Yo",
+	'й': "y", 'Й': "Y", 'ы': "y", 'Ы': "Y", 'ь': "", 'Ь': "", 'ъ': "", 'Ъ': "",
+}
+
+func transliterateCyrillic(word string) string {
+	var buf strings.Builder
+	for _, r := range word {
+		if latin, exists := cyrillicLetters[r]; exists {
+			buf.WriteString(latin)
+		} else {
+			buf.WriteString(unidecode.Unidecode(string(r)))
+		}
+	}
+	return buf.String()
+}
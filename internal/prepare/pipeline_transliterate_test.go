@@ -0,0 +1,78 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package prepare
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransliterate(t *testing.T) {
+	tests := []struct {
+		name, input, expected string
+	}{
+		{"latin", "Nicolás Maduro", "Nicolás Maduro"},
+		{"cyrillic", "Владимир Путин", "Vladimir Putin"},
+		{"cyrillic #2", "Дмитрий Хорошев", "Dmitriy Khoroshev"},
+		{"cyrillic soft sign", "Юрьевич", "Yurevich"},
+		{"arabic", "محمد علي", "muhammad ali"},
+		{"arabic article", "عبد المنان", "abd al mnan"},
+		{"arabic harakat", "مُحَمَّد", "muhammad"},
+		{"chinese", "金正恩", "Jin Zheng En"},
+		{"korean", "김정은", "gim jeong eun"},
+		{"mixed", "Putin Владимир", "Putin Vladimir"},
+		{"empty", "", ""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, Transliterate(tc.input))
+		})
+	}
+}
+
+func TestHasNonLatin(t *testing.T) {
+	require.False(t, HasNonLatin("John Smith"))
+	require.False(t, HasNonLatin("Raúl Castro, 123"))
+	require.True(t, HasNonLatin("Владимир"))
+	require.True(t, HasNonLatin("金正恩"))
+}
+
+func TestWithRomanizedNames(t *testing.T) {
+	names := []string{"Abdul Mannan Agha", "عبد المنان", "Vladimir Putin", "Владимир Путин"}
+	got := WithRomanizedNames(names)
+	require.Equal(t, []string{"Abdul Mannan Agha", "عبد المنان", "Vladimir Putin", "Владимир Путин", "abd al mnan"}, got)
+	require.Len(t, names, 4)
+
+	require.Nil(t, WithRomanizedNames(nil))
+}
+
+func TestDetectScript(t *testing.T) {
+	cases := map[string]Script{
+		"Vladimir Putin": ScriptLatin,
+		"José Müller":    ScriptLatin,
+		"Владимир Путин": ScriptCyrillic,
+		"محمد علي":       ScriptArabic,
+		"习近平":            ScriptHan,
+		"김정은":            ScriptHangul,
+		"Αλέξης Τσίπρας": ScriptOther,
+		"OOO Газпром":    ScriptCyrillic,
+		"12345 - ":       "",
+		"":               "",
+	}
+	for input, expected := range cases {
+		require.Equal(t, expected, DetectScript(input), input)
+	}
+}
+
+func TestScript_Comparable(t *testing.T) {
+	require.True(t, ScriptLatin.Comparable(ScriptArabic))
+	require.True(t, ScriptCyrillic.Comparable(ScriptCyrillic))
+	require.True(t, ScriptHan.Comparable(ScriptOther))
+	require.True(t, ScriptArabic.Comparable(""))
+	require.True(t, ScriptArabic.Comparable(ScriptCyrillic))
+	require.False(t, ScriptHan.Comparable(ScriptHangul))
+	require.False(t, ScriptCyrillic.Comparable(ScriptHan))
+}
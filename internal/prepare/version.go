@@ -0,0 +1,57 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package prepare
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// version is raised whenever a stage, or how names are normalized before they're compared, changes the names it
+// prepares. The dictionaries stages read are part of Pipeline.Version already, including any custom files.
+const version = 1
+
+// Version identifies how p prepares names: its stages and the dictionaries they read. Names prepared by pipelines
+// with the same version are the same, so a client system can check it prepares names as the server does.
+func (p *Pipeline) Version() string {
+	var individual, entity []Stage
+	if p != nil {
+		individual, entity = p.individual, p.entity
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "individual=%s\nentity=%s\n", joinStages(individual), joinStages(entity))
+	stages := append(slices.Clone(individual), entity...)
+	if slices.Contains(stages, StageCompanySuffixes) || slices.Contains(stages, StageRemoveCompanySuffixes) {
+		fmt.Fprintf(h, "company-suffixes=%s\n", companySuffixes.Load().digest)
+	}
+	if slices.Contains(individual, StageHonorifics) {
+		fmt.Fprintf(h, "honorifics=%s\n", *honorificsDigest.Load())
+	}
+	return fmt.Sprintf("%d-%s", version, hex.EncodeToString(h.Sum(nil))[:12])
+}
+
+func joinStages(stages []Stage) string {
+	names := make([]string, len(stages))
+	for i, stage := range stages {
+		names[i] = string(stage)
+	}
+	return strings.Join(names, ",")
+}
+
+// digestOf returns a hash of entries, in any order
+func digestOf(entries []string) string {
+	entries = slices.Clone(entries)
+	slices.Sort(entries)
+
+	h := sha256.New()
+	for _, entry := range entries {
+		fmt.Fprintln(h, entry)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,36 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package prepare
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline_Version(t *testing.T) {
+	pipeline := func(stages ...Stage) *Pipeline {
+		p, err := NewPipeline(PipelineConfig{Individual: stages, Entity: stages})
+		require.NoError(t, err)
+		return p
+	}
+
+	version := pipeline(StageReorder, StageHonorifics).Version()
+	require.Regexp(t, `^1-[0-9a-f]{12}$`, version)
+	require.Equal(t, version, pipeline(StageReorder, StageHonorifics).Version())
+
+	// The stages and their order are part of the version
+	require.NotEqual(t, version, pipeline(StageHonorifics, StageReorder).Version())
+	require.NotEqual(t, version, pipeline(StageReorder).Version())
+	require.NotEqual(t, pipeline(StageReorder).Version(), (&Pipeline{entity: []Stage{StageReorder}}).Version())
+
+	var empty *Pipeline
+	require.Equal(t, pipeline().Version(), empty.Version())
+}
+
+func TestDigestOf(t *testing.T) {
+	require.Equal(t, digestOf([]string{"dr", "sheikh"}), digestOf([]string{"sheikh", "dr"}))
+	require.NotEqual(t, digestOf([]string{"dr"}), digestOf([]string{"dr", "sheikh"}))
+}
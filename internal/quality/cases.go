@@ -0,0 +1,189 @@
+package quality
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+//go:embed corpus.csv
+var defaultCorpusFile []byte
+
+// Expectation is whether a case's query should return its entity
+type Expectation string
+
+const (
+	ExpectHit  Expectation = "hit"
+	ExpectMiss Expectation = "miss"
+)
+
+// Case is a labeled query along with the entity it should, or shouldn't, match
+type Case struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Type      string `json:"type,omitempty"`
+	BirthDate string `json:"birthDate,omitempty"`
+	Country   string `json:"country,omitempty"`
+
+	// Source and SourceID are the entity the query is labeled against. A miss without a SourceID expects no results.
+	Source   search.SourceList `json:"sourceList,omitempty"`
+	SourceID string            `json:"sourceID,omitempty"`
+
+	Expect Expectation `json:"expect"`
+	Note   string      `json:"note,omitempty"`
+}
+
+var (
+	birthDateFormats = []string{"2006-01-02", "2006-01", "2006"}
+)
+
+// Query is the entity searched for a case
+func (c Case) Query() search.Entity[search.Value] {
+	out := search.Entity[search.Value]{
+		Name:   c.Name,
+		Type:   search.EntityType(c.Type),
+		Source: search.SourceAPIRequest,
+	}
+
+	switch out.Type {
+	case search.EntityPerson:
+		out.Person = &search.Person{Name: c.Name}
+		for _, format := range birthDateFormats {
+			if tt, err := time.Parse(format, c.BirthDate); err == nil {
+				out.Person.BirthDate = &tt
+				out.Person.BirthDates = []search.DateRange{search.DateRangeFor(tt, format)}
+				break
+			}
+		}
+	case search.EntityBusiness:
+		out.Business = &search.Business{Name: c.Name}
+	case search.EntityOrganization:
+		out.Organization = &search.Organization{Name: c.Name}
+	case search.EntityAircraft:
+		out.Aircraft = &search.Aircraft{Name: c.Name}
+	case search.EntityVessel:
+		out.Vessel = &search.Vessel{Name: c.Name}
+	}
+
+	if c.Country != "" {
+		out.Addresses = []search.Address{
+			{Country: c.Country},
+		}
+	}
+	return out
+}
+
+// DefaultCases returns the curated corpus, which is labeled against the OFAC list in pkg/ofac/testdata
+func DefaultCases() []Case {
+	cases, err := ReadCases(bytes.NewReader(defaultCorpusFile))
+	if err != nil {
+		panic(fmt.Sprintf("reading default corpus: %v", err)) //nolint:forbidigo
+	}
+	return cases
+}
+
+// LoadCasesFile reads the labeled cases of a CSV file, in the format of ReadCases
+func LoadCasesFile(path string) ([]Case, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening cases file: %w", err)
+	}
+	defer fd.Close()
+
+	cases, err := ReadCases(fd)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return cases, nil
+}
+
+// columns are the headers of a cases file, compared without case
+var columns = []string{"id", "name", "type", "birthdate", "country", "sourcelist", "sourceid", "expect", "note"}
+
+// ReadCases reads labeled cases from a CSV with a header row. The name and expect columns are required, and expect
+// is either hit or miss. The id, type, birthDate, country, sourceList, sourceID and note columns are optional.
+// Hits need a sourceList and sourceID, and cases without an id are numbered by their line.
+func ReadCases(r io.Reader) ([]Case, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.Comment = '#'
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("missing header row")
+		}
+		return nil, err
+	}
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+
+	index := make(map[string]int)
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		for _, column := range columns {
+			if name == column {
+				index[column] = i
+			}
+		}
+	}
+	for _, column := range []string{"name", "expect"} {
+		if _, ok := index[column]; !ok {
+			return nil, fmt.Errorf("missing %s column", column)
+		}
+	}
+
+	var out []Case
+	for {
+		values, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+		get := func(column string) string {
+			if i, ok := index[column]; ok && i < len(values) {
+				return strings.TrimSpace(values[i])
+			}
+			return ""
+		}
+
+		c := Case{
+			ID:        get("id"),
+			Name:      get("name"),
+			Type:      strings.ToLower(get("type")),
+			BirthDate: get("birthdate"),
+			Country:   get("country"),
+			Source:    search.SourceList(strings.ToLower(get("sourcelist"))),
+			SourceID:  get("sourceid"),
+			Expect:    Expectation(strings.ToLower(get("expect"))),
+			Note:      get("note"),
+		}
+		if c.ID == "" {
+			c.ID = fmt.Sprintf("line-%d", line)
+		}
+		if c.Name == "" {
+			return nil, fmt.Errorf("line %d: missing name", line)
+		}
+		switch c.Expect {
+		case ExpectHit:
+			if c.Source == "" || c.SourceID == "" {
+				return nil, fmt.Errorf("line %d: a hit needs a sourceList and sourceID", line)
+			}
+		case ExpectMiss:
+		default:
+			return nil, fmt.Errorf("line %d: expect of %q must be hit or miss", line, c.Expect)
+		}
+		out = append(out, c)
+	}
+	return out, nil
+}
@@ -0,0 +1,61 @@
+package quality
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCases(t *testing.T) {
+	input := "\ufeffName,Type,birthDate,sourceList,SourceID,Expect\n" +
+		"# comments are skipped\n" +
+		"Nicolas Maduro,Person,1962-11-23,US_OFAC,22790,HIT\n" +
+		"Margaret Thompson,person,,,,miss\n"
+
+	cases, err := ReadCases(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, cases, 2)
+
+	require.Equal(t, Case{
+		ID:        "line-3",
+		Name:      "Nicolas Maduro",
+		Type:      "person",
+		BirthDate: "1962-11-23",
+		Source:    search.SourceUSOFAC,
+		SourceID:  "22790",
+		Expect:    ExpectHit,
+	}, cases[0])
+	require.Equal(t, ExpectMiss, cases[1].Expect)
+	require.Empty(t, cases[1].SourceID)
+
+	_, err = ReadCases(strings.NewReader("name\nJohn Smith\n"))
+	require.ErrorContains(t, err, "missing expect column")
+
+	_, err = ReadCases(strings.NewReader("name,expect\nJohn Smith,hit\n"))
+	require.ErrorContains(t, err, "line 2: a hit needs a sourceList and sourceID")
+
+	_, err = ReadCases(strings.NewReader("name,expect\nJohn Smith,maybe\n"))
+	require.ErrorContains(t, err, `line 2: expect of "maybe" must be hit or miss`)
+}
+
+func TestCase_Query(t *testing.T) {
+	query := Case{Name: "Nicolas Maduro", Type: "person", BirthDate: "1962-11", Country: "VE"}.Query()
+	require.Equal(t, search.EntityPerson, query.Type)
+	require.NotNil(t, query.Person)
+	require.Equal(t, "1962-11-01", query.Person.BirthDate.Format("2006-01-02"))
+	require.Equal(t, "VE", query.Addresses[0].Country)
+}
+
+func TestDefaultCases(t *testing.T) {
+	cases := DefaultCases()
+	require.NotEmpty(t, cases)
+
+	ids := make(map[string]bool)
+	for _, c := range cases {
+		require.False(t, ids[c.ID], "duplicate case %s", c.ID)
+		ids[c.ID] = true
+	}
+}
@@ -0,0 +1,127 @@
+package quality
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Comparison is how a run changed from a baseline, such as a golden report written before a change to scoring
+type Comparison struct {
+	Baseline Metrics `json:"baseline"`
+	Current  Metrics `json:"current"`
+
+	// Regressions are cases which were right in the baseline and are wrong now, and Fixes are the reverse
+	Regressions []Change `json:"regressions"`
+	Fixes       []Change `json:"fixes"`
+
+	// Added are cases which aren't in the baseline
+	Added []string `json:"added,omitempty"`
+}
+
+// Change is a case whose outcome differs from the baseline
+type Change struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+
+	Before      Outcome `json:"before"`
+	After       Outcome `json:"after"`
+	BeforeScore float64 `json:"beforeScore"`
+	AfterScore  float64 `json:"afterScore"`
+}
+
+// Changed returns true when any case has a different outcome than in the baseline
+func (c Comparison) Changed() bool {
+	return len(c.Regressions) > 0 || len(c.Fixes) > 0
+}
+
+// Compare returns how current changed from baseline, matching their cases by ID
+func Compare(baseline, current Report) Comparison {
+	out := Comparison{
+		Baseline: baseline.Metrics,
+		Current:  current.Metrics,
+	}
+
+	before := make(map[string]CaseResult, len(baseline.Results))
+	for _, res := range baseline.Results {
+		before[res.ID] = res
+	}
+	for _, res := range current.Results {
+		prev, found := before[res.ID]
+		if !found {
+			out.Added = append(out.Added, res.ID)
+			continue
+		}
+		if prev.Outcome == res.Outcome {
+			continue
+		}
+		change := Change{
+			ID:          res.ID,
+			Name:        res.Name,
+			Before:      prev.Outcome,
+			After:       res.Outcome,
+			BeforeScore: prev.Score,
+			AfterScore:  res.Score,
+		}
+		if prev.Outcome.Correct() {
+			out.Regressions = append(out.Regressions, change)
+		} else {
+			out.Fixes = append(out.Fixes, change)
+		}
+	}
+	return out
+}
+
+// WriteSummary writes the metrics of a report along with each case it got wrong
+func WriteSummary(w io.Writer, report Report) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "cases\t%d\n", report.Cases)
+	fmt.Fprintf(tw, "precision\t%.4f\n", report.Precision)
+	fmt.Fprintf(tw, "recall\t%.4f\n", report.Recall)
+	fmt.Fprintf(tw, "true positives\t%d\n", report.TruePositives)
+	fmt.Fprintf(tw, "false positives\t%d\n", report.FalsePositives)
+	fmt.Fprintf(tw, "true negatives\t%d\n", report.TrueNegatives)
+	fmt.Fprintf(tw, "false negatives\t%d\n", report.FalseNegatives)
+
+	var wrong []CaseResult
+	for _, res := range report.Results {
+		if !res.Outcome.Correct() {
+			wrong = append(wrong, res)
+		}
+	}
+	if len(wrong) > 0 {
+		fmt.Fprintf(tw, "\nid\tname\toutcome\tscore\ttop match\n")
+		for _, res := range wrong {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%.4f\t%s\n", res.ID, res.Name, res.Outcome, res.Score, res.TopMatch)
+		}
+	}
+	return tw.Flush()
+}
+
+// WriteText writes the change in precision and recall along with every regressed and fixed case
+func (c Comparison) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintf(tw, "\tbaseline\tcurrent\tchange\n")
+	fmt.Fprintf(tw, "precision\t%.4f\t%.4f\t%+.4f\n", c.Baseline.Precision, c.Current.Precision, c.Current.Precision-c.Baseline.Precision)
+	fmt.Fprintf(tw, "recall\t%.4f\t%.4f\t%+.4f\n", c.Baseline.Recall, c.Current.Recall, c.Current.Recall-c.Baseline.Recall)
+
+	write := func(title string, changes []Change) {
+		if len(changes) == 0 {
+			return
+		}
+		fmt.Fprintf(tw, "\n%s\nid\tname\tbefore\tafter\n", title)
+		for _, change := range changes {
+			fmt.Fprintf(tw, "%s\t%s\t%s (%.4f)\t%s (%.4f)\n", change.ID, change.Name,
+				change.Before, change.BeforeScore, change.After, change.AfterScore)
+		}
+	}
+	write("regressions", c.Regressions)
+	write("fixes", c.Fixes)
+
+	if len(c.Added) > 0 {
+		fmt.Fprintf(tw, "\n%d cases aren't in the baseline\n", len(c.Added))
+	}
+	return tw.Flush()
+}
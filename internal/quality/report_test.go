@@ -0,0 +1,61 @@
+package quality
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	baseline := Report{
+		Results: []CaseResult{
+			{Case: Case{ID: "a", Name: "Nicolas Maduro"}, Outcome: TruePositive, Score: 0.97},
+			{Case: Case{ID: "b", Name: "Maxim Yakubets"}, Outcome: FalseNegative, Score: 0.72},
+			{Case: Case{ID: "c", Name: "Li Wei"}, Outcome: TrueNegative, Score: 0.50},
+		},
+	}
+	baseline.Metrics = metricsOf(baseline.Results)
+	require.Equal(t, 0.5, baseline.Recall)
+
+	current := Report{
+		Results: []CaseResult{
+			{Case: Case{ID: "a", Name: "Nicolas Maduro"}, Outcome: TruePositive, Score: 0.95},
+			{Case: Case{ID: "b", Name: "Maxim Yakubets"}, Outcome: TruePositive, Score: 0.88},
+			{Case: Case{ID: "c", Name: "Li Wei"}, Outcome: FalsePositive, Score: 0.91},
+			{Case: Case{ID: "d", Name: "Evil Corp"}, Outcome: TruePositive, Score: 0.98},
+		},
+	}
+	current.Metrics = metricsOf(current.Results)
+
+	comparison := Compare(baseline, current)
+	require.True(t, comparison.Changed())
+	require.Equal(t, []Change{
+		{ID: "c", Name: "Li Wei", Before: TrueNegative, After: FalsePositive, BeforeScore: 0.50, AfterScore: 0.91},
+	}, comparison.Regressions)
+	require.Len(t, comparison.Fixes, 1)
+	require.Equal(t, "b", comparison.Fixes[0].ID)
+	require.Equal(t, []string{"d"}, comparison.Added)
+
+	var buf strings.Builder
+	require.NoError(t, comparison.WriteText(&buf))
+	require.Contains(t, buf.String(), "regressions")
+	require.Contains(t, buf.String(), "+0.5000")
+
+	require.False(t, Compare(current, current).Changed())
+}
+
+func TestWriteSummary(t *testing.T) {
+	report := Report{
+		Results: []CaseResult{
+			{Case: Case{ID: "a", Name: "Nicolas Maduro"}, Outcome: TruePositive, Score: 0.97},
+			{Case: Case{ID: "b", Name: "Maxim Yakubets"}, Outcome: FalseNegative, Score: 0.72, TopMatch: "us_ofac/26663 Maksim Viktorovich YAKUBETS"},
+		},
+	}
+	report.Metrics = metricsOf(report.Results)
+
+	var buf strings.Builder
+	require.NoError(t, WriteSummary(&buf, report))
+	require.Contains(t, buf.String(), "falseNegative")
+	require.NotContains(t, buf.String(), "Nicolas Maduro")
+}
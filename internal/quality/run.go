@@ -0,0 +1,185 @@
+package quality
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/internal/search"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Outcome is how the results of a case compared to its label
+type Outcome string
+
+const (
+	TruePositive  Outcome = "truePositive"  // a hit whose entity was returned
+	FalseNegative Outcome = "falseNegative" // a hit whose entity wasn't returned
+	TrueNegative  Outcome = "trueNegative"  // a miss whose entity, or any entity, wasn't returned
+	FalsePositive Outcome = "falsePositive" // a miss whose entity, or any entity, was returned
+)
+
+// Correct returns true when the results agreed with the case's label
+func (o Outcome) Correct() bool {
+	return o == TruePositive || o == TrueNegative
+}
+
+// Options are how each case is searched, which match the options of /v2/search
+type Options struct {
+	Limit     int
+	MinMatch  float64
+	Algorithm string
+	Prepare   []prepare.Stage
+}
+
+const (
+	defaultLimit    = 10
+	defaultMinMatch = 0.85
+)
+
+// CaseResult is the outcome of one case
+type CaseResult struct {
+	Case
+
+	Outcome Outcome `json:"outcome"`
+
+	// Score is the score of the case's entity, or of the top result for a miss without one. It's set even when
+	// below MinMatch, so near misses can be seen.
+	Score float64 `json:"score"`
+
+	// Rank is the position of the case's entity in the results, starting at 1
+	Rank int `json:"rank,omitempty"`
+
+	// TopMatch is the best result, written as sourceList/sourceID name
+	TopMatch string `json:"topMatch,omitempty"`
+}
+
+// Metrics summarize the outcomes of a run
+type Metrics struct {
+	Cases          int `json:"cases"`
+	TruePositives  int `json:"truePositives"`
+	FalsePositives int `json:"falsePositives"`
+	TrueNegatives  int `json:"trueNegatives"`
+	FalseNegatives int `json:"falseNegatives"`
+
+	// Precision is the share of returned labeled entities which should have been, and Recall the share of hits
+	// which were returned
+	Precision float64 `json:"precision"`
+	Recall    float64 `json:"recall"`
+}
+
+// Report is the result of running cases against a search service
+type Report struct {
+	Limit     int     `json:"limit"`
+	MinMatch  float64 `json:"minMatch"`
+	Algorithm string  `json:"algorithm,omitempty"`
+
+	Metrics
+	Results []CaseResult `json:"results"`
+}
+
+// Run searches every case and labels its outcome. The service should hold the entities the cases are labeled against.
+func Run(ctx context.Context, service search.Service, cases []Case, opts Options) (Report, error) {
+	opts.Limit = cmp.Or(opts.Limit, defaultLimit)
+	opts.MinMatch = cmp.Or(opts.MinMatch, defaultMinMatch)
+
+	out := Report{
+		Limit:     opts.Limit,
+		MinMatch:  opts.MinMatch,
+		Algorithm: opts.Algorithm,
+		Results:   make([]CaseResult, len(cases)),
+	}
+
+	g, groupCtx := errgroup.WithContext(ctx)
+	g.SetLimit(runtime.NumCPU())
+
+	for i := range cases {
+		i := i
+		g.Go(func() error {
+			// Results below MinMatch are kept to report how close the case's entity came
+			entities, err := service.Search(groupCtx, cases[i].Query(), search.SearchOpts{
+				Limit:     opts.Limit,
+				Algorithm: opts.Algorithm,
+				Prepare:   opts.Prepare,
+			})
+			if err != nil {
+				return fmt.Errorf("case %s: %w", cases[i].ID, err)
+			}
+
+			c := cases[i]
+			res := CaseResult{Case: c}
+			returned := false
+			for rank, entity := range entities {
+				if rank == 0 {
+					res.TopMatch = fmt.Sprintf("%s/%s %s", entity.Source, entity.SourceID, entity.Name)
+				}
+				if c.SourceID == "" {
+					if rank == 0 {
+						res.Score = round(entity.Match)
+						returned = entity.Match >= opts.MinMatch
+					}
+					continue
+				}
+				if entity.Source == c.Source && entity.SourceID == c.SourceID {
+					res.Score = round(entity.Match)
+					res.Rank = rank + 1
+					returned = entity.Match >= opts.MinMatch
+					break
+				}
+			}
+
+			switch {
+			case c.Expect == ExpectHit && returned:
+				res.Outcome = TruePositive
+			case c.Expect == ExpectHit:
+				res.Outcome = FalseNegative
+			case returned:
+				res.Outcome = FalsePositive
+			default:
+				res.Outcome = TrueNegative
+			}
+			out.Results[i] = res
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return out, err
+	}
+
+	out.Metrics = metricsOf(out.Results)
+	return out, nil
+}
+
+func metricsOf(results []CaseResult) Metrics {
+	out := Metrics{
+		Cases: len(results),
+	}
+	for _, res := range results {
+		switch res.Outcome {
+		case TruePositive:
+			out.TruePositives++
+		case FalsePositive:
+			out.FalsePositives++
+		case TrueNegative:
+			out.TrueNegatives++
+		case FalseNegative:
+			out.FalseNegatives++
+		}
+	}
+	if n := out.TruePositives + out.FalsePositives; n > 0 {
+		out.Precision = round(float64(out.TruePositives) / float64(n))
+	}
+	if n := out.TruePositives + out.FalseNegatives; n > 0 {
+		out.Recall = round(float64(out.TruePositives) / float64(n))
+	}
+	return out
+}
+
+// round keeps reports readable, and stable across tiny floating point differences
+func round(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
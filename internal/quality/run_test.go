@@ -0,0 +1,60 @@
+package quality
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/download"
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite testdata/golden.json with the current results")
+
+func TestGolden(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+
+	conf := download.Config{
+		InitialDataDirectory: filepath.Join("..", "..", "pkg", "ofac", "testdata"),
+		IncludedLists:        []pubsearch.SourceList{pubsearch.SourceUSOFAC},
+	}
+	dl, err := download.NewDownloader(logger, conf)
+	require.NoError(t, err)
+	stats, err := dl.RefreshAll(ctx)
+	require.NoError(t, err)
+
+	service := search.NewService(logger)
+	service.UpdateEntities(stats.Entities)
+
+	report, err := Run(ctx, service, DefaultCases(), Options{})
+	require.NoError(t, err)
+
+	path := filepath.Join("testdata", "golden.json")
+	if *updateGolden {
+		bs, err := json.MarshalIndent(report, "", "  ")
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(path, append(bs, '\n'), 0644))
+	}
+
+	bs, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var golden Report
+	require.NoError(t, json.Unmarshal(bs, &golden))
+
+	comparison := Compare(golden, report)
+	if comparison.Changed() || len(comparison.Added) > 0 {
+		var buf strings.Builder
+		comparison.WriteText(&buf)
+		t.Fatalf("results changed from testdata/golden.json, run go test ./internal/quality -run TestGolden -update "+
+			"if the change is expected\n%s", buf.String())
+	}
+}
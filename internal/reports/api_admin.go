@@ -0,0 +1,107 @@
+package reports
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/internal/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+)
+
+// NewAdminController returns the routes which generate and deliver summary reports, which are expected to be
+// served on the admin server
+func NewAdminController(logger log.Logger, service Service) search.Controller {
+	return &adminController{
+		logger:  logger,
+		service: service,
+	}
+}
+
+type adminController struct {
+	logger  log.Logger
+	service Service
+}
+
+func (c *adminController) AppendRoutes(router *mux.Router) *mux.Router {
+	router.
+		Name("GenerateReport").
+		Methods("GET").
+		Path("/reports/summary").
+		HandlerFunc(c.generate)
+
+	router.
+		Name("DeliverReport").
+		Methods("POST").
+		Path("/reports/summary/deliver").
+		HandlerFunc(c.deliver)
+
+	return router
+}
+
+// readReport generates the report of the period and end parameters. Without an end, the report covers the
+// period before the last midnight, and an end date (2024-06-01) covers the period before that day.
+func (c *adminController) readReport(r *http.Request) (Report, error) {
+	q := r.URL.Query()
+	period, err := ParsePeriod(q.Get("period"))
+	if err != nil {
+		return Report{}, err
+	}
+	end := time.Now()
+	if v := strings.TrimSpace(q.Get("end")); v != "" {
+		end, err = time.Parse(time.DateOnly, v)
+		if err != nil {
+			return Report{}, fmt.Errorf("end: expected a date (2006-01-02): %q", v)
+		}
+	}
+	return c.service.Generate(r.Context(), period, end)
+}
+
+func (c *adminController) generate(w http.ResponseWriter, r *http.Request) {
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	if format != "" && format != FormatJSON && format != FormatHTML {
+		c.writeError(w, fmt.Errorf("unknown report format %q", format))
+		return
+	}
+	report, err := c.readReport(r)
+	if err != nil {
+		c.writeError(w, fmt.Errorf("generating report: %w", err))
+		return
+	}
+
+	if format == FormatHTML {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	Render(w, format, report)
+}
+
+func (c *adminController) deliver(w http.ResponseWriter, r *http.Request) {
+	report, err := c.readReport(r)
+	if err != nil {
+		c.writeError(w, fmt.Errorf("generating report: %w", err))
+		return
+	}
+	if err := c.service.Deliver(r.Context(), report); err != nil {
+		c.writeError(w, fmt.Errorf("delivering report: %w", err))
+		return
+	}
+	c.logger.Info().Logf("delivered %s report from %s", report.Period, report.From.Format(time.DateOnly))
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (c *adminController) writeError(w http.ResponseWriter, err error) {
+	c.logger.Error().LogError(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error": err.Error(),
+	})
+}
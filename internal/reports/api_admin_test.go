@@ -0,0 +1,58 @@
+package reports
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/search"
+	"github.com/moov-io/watchman/internal/versions"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminController(t *testing.T) {
+	logger := log.NewTestLogger()
+	dir := t.TempDir()
+	svc := NewService(logger, Config{BucketURL: dir}, nil, versions.NewService(logger, versions.NewInMemoryRepository()), search.NewService(logger), NewWatchLog())
+
+	router := mux.NewRouter()
+	NewAdminController(logger, svc).AppendRoutes(router)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/reports/summary?period=weekly&end=2024-06-03", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var report Report
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&report))
+	require.Equal(t, PeriodWeekly, report.Period)
+	require.Equal(t, "2024-05-27", report.From.Format("2006-01-02"))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/reports/summary?end=2024-06-03&format=html", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.True(t, strings.HasPrefix(w.Header().Get("Content-Type"), "text/html"))
+	require.Contains(t, w.Body.String(), "Watchman daily report 2024-06-02")
+
+	// bad parameters
+	for _, query := range []string{"period=monthly", "end=yesterday", "format=pdf"} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/reports/summary?"+query, nil))
+		require.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	// deliver
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/reports/summary/deliver?end=2024-06-03", nil))
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	_, err := os.Stat(filepath.Join(dir, "daily", "2024-06-02.json"))
+	require.NoError(t, err)
+}
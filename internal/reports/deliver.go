@@ -0,0 +1,109 @@
+package reports
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gocloud.dev/blob"
+	_ "gocloud.dev/blob/fileblob"
+	_ "gocloud.dev/blob/gcsblob"
+	_ "gocloud.dev/blob/s3blob"
+)
+
+// Event is the body sent to the reports webhook
+type Event struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+
+	Report Report `json:"report"`
+
+	// HTML is the report rendered as a page, which can be sent as the body of an email
+	HTML string `json:"html"`
+}
+
+const (
+	EventReportGenerated = "report.generated"
+)
+
+func (s *service) Deliver(ctx context.Context, report Report) error {
+	if s.conf.Webhook == nil && s.conf.BucketURL == "" {
+		return errors.New("no webhook or bucket to deliver reports to")
+	}
+	var errs []error
+	if s.conf.Webhook != nil {
+		if err := s.sendWebhook(ctx, report); err != nil {
+			errs = append(errs, fmt.Errorf("sending webhook: %w", err))
+		}
+	}
+	if s.conf.BucketURL != "" {
+		if err := writeBucket(ctx, s.conf.BucketURL, report); err != nil {
+			errs = append(errs, fmt.Errorf("writing to bucket: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *service) sendWebhook(ctx context.Context, report Report) error {
+	var html bytes.Buffer
+	if err := Render(&html, FormatHTML, report); err != nil {
+		return err
+	}
+	body, err := json.Marshal(Event{
+		Type:      EventReportGenerated,
+		Timestamp: time.Now().In(time.UTC),
+		Report:    report,
+		HTML:      html.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("encoding report event: %w", err)
+	}
+	return s.client.Send(ctx, s.conf.Webhook.URL, s.conf.Webhook.Secret, body)
+}
+
+// writeBucket writes the report as JSON and HTML, see FileName
+func writeBucket(ctx context.Context, bucketURL string, report Report) error {
+	bucket, err := openBucket(ctx, bucketURL)
+	if err != nil {
+		return err
+	}
+	defer bucket.Close()
+
+	for _, format := range []string{FormatJSON, FormatHTML} {
+		var buf bytes.Buffer
+		if err := Render(&buf, format, report); err != nil {
+			return err
+		}
+		key := FileName(report, format)
+		if err := bucket.WriteAll(ctx, key, buf.Bytes(), nil); err != nil {
+			return fmt.Errorf("writing %s: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// openBucket opens a bucket URL (s3://bucket?prefix=reports/, gs://bucket) or local directory
+func openBucket(ctx context.Context, bucketURL string) (*blob.Bucket, error) {
+	if !strings.Contains(bucketURL, "://") {
+		dir, err := filepath.Abs(bucketURL)
+		if err != nil {
+			return nil, err
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+		bucketURL = "file://" + filepath.ToSlash(dir) + "?metadata=skip"
+	}
+
+	bucket, err := blob.OpenBucket(ctx, bucketURL)
+	if err != nil {
+		return nil, fmt.Errorf("opening bucket %s: %w", bucketURL, err)
+	}
+	return bucket, nil
+}
@@ -0,0 +1,88 @@
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/webhooks"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func testReport() Report {
+	from := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+	return Report{
+		Period:      PeriodDaily,
+		From:        from,
+		To:          from.Add(24 * time.Hour),
+		GeneratedAt: from.Add(30 * time.Hour),
+		Searches: &SearchSummary{
+			Total:    10,
+			Types:    map[string]int{"entity": 10},
+			MinMatch: 0.85,
+			Hits:     3,
+			WithHits: 2,
+		},
+		Watches: WatchSummary{Triggered: 1, Watches: 1, Matches: 2},
+		Lists: []ListSummary{
+			{
+				List:      "us_ofac",
+				Hits:      3,
+				Programs:  map[string]int{"SDGT": 3},
+				Versions:  []VersionUsage{{VersionID: "v1", Searches: 10, Entities: 100}},
+				Refreshes: 1,
+			},
+		},
+	}
+}
+
+func TestService_Deliver(t *testing.T) {
+	var event Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !webhooks.Verify("secret", r.Header.Get(webhooks.HeaderTimestamp), body, r.Header.Get(webhooks.HeaderSignature)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.Unmarshal(body, &event)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "reports")
+	svc := NewService(log.NewTestLogger(), Config{
+		Webhook:   &WebhookConfig{URL: server.URL, Secret: "secret"},
+		BucketURL: dir,
+	}, nil, nil, nil, nil).(*service)
+	svc.client.RetryDelay = time.Millisecond
+
+	report := testReport()
+	require.NoError(t, svc.Deliver(context.Background(), report))
+
+	require.Equal(t, EventReportGenerated, event.Type)
+	require.Equal(t, report.Searches, event.Report.Searches)
+	require.Contains(t, event.HTML, "Watchman daily screening report")
+
+	bs, err := os.ReadFile(filepath.Join(dir, "daily", "2024-06-01.json"))
+	require.NoError(t, err)
+	var written Report
+	require.NoError(t, json.Unmarshal(bs, &written))
+	require.Equal(t, report.Lists, written.Lists)
+
+	bs, err = os.ReadFile(filepath.Join(dir, "daily", "2024-06-01.html"))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(bs), "<!DOCTYPE html>"))
+
+	// Nowhere to deliver reports
+	svc = NewService(log.NewTestLogger(), Config{}, nil, nil, nil, nil).(*service)
+	require.ErrorContains(t, svc.Deliver(context.Background(), report), "no webhook or bucket")
+}
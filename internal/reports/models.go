@@ -0,0 +1,95 @@
+package reports
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Period is how much time a summary report covers
+type Period string
+
+const (
+	PeriodDaily  Period = "daily"
+	PeriodWeekly Period = "weekly"
+)
+
+// ParsePeriod reads a Period, which is PeriodDaily when empty
+func ParsePeriod(value string) (Period, error) {
+	switch period := Period(strings.ToLower(strings.TrimSpace(value))); period {
+	case "":
+		return PeriodDaily, nil
+	case PeriodDaily, PeriodWeekly:
+		return period, nil
+	}
+	return "", fmt.Errorf("unknown report period %q", value)
+}
+
+// Window returns the times a report of the period covers which ends at the last UTC midnight at or before end:
+// the day before it or the seven days before it
+func (p Period) Window(end time.Time) (time.Time, time.Time) {
+	to := end.In(time.UTC).Truncate(24 * time.Hour)
+	if p == PeriodWeekly {
+		return to.AddDate(0, 0, -7), to
+	}
+	return to.AddDate(0, 0, -1), to
+}
+
+// Report summarizes the screening done over a period, as compliance officers review it
+type Report struct {
+	Period      Period    `json:"period"`
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	GeneratedAt time.Time `json:"generatedAt"`
+
+	// Searches are read from the audit log, and are nil when searches aren't kept in the database
+	Searches *SearchSummary `json:"searches,omitempty"`
+
+	Watches WatchSummary `json:"watches"`
+
+	// Lists holds the hits and versions of each list which was searched or refreshed, ordered by list
+	Lists []ListSummary `json:"lists"`
+}
+
+// SearchSummary counts the searches made over the period
+type SearchSummary struct {
+	Total  int `json:"total"`
+	Failed int `json:"failed"`
+
+	// Types counts the searches of each type: entity, identifier, vessel, aircraft, crypto or remarks
+	Types map[string]int `json:"types"`
+
+	// Hits are the results which scored at least MinMatch, and WithHits how many searches had any
+	MinMatch float64 `json:"minMatch"`
+	Hits     int     `json:"hits"`
+	WithHits int     `json:"withHits"`
+}
+
+// WatchSummary counts the rescreens of watches which found matches over the period
+type WatchSummary struct {
+	// Triggered is how many rescreens notified a watch's webhook, for Watches distinct watches
+	Triggered int `json:"triggered"`
+	Watches   int `json:"watches"`
+	Matches   int `json:"matches"`
+}
+
+// ListSummary holds the hits of one list and the versions of it which were searched over the period
+type ListSummary struct {
+	List string `json:"list"`
+	Hits int    `json:"hits"`
+
+	// Programs counts the hits of each sanctions program, for entities still on the list
+	Programs map[string]int `json:"programs,omitempty"`
+
+	// Versions are those searches were made against, and Refreshes is how many versions were downloaded
+	Versions  []VersionUsage `json:"versions,omitempty"`
+	Refreshes int            `json:"refreshes"`
+}
+
+// VersionUsage is a list version and how many searches were made against it
+type VersionUsage struct {
+	VersionID    string    `json:"versionID"`
+	Searches     int       `json:"searches"`
+	Entities     int       `json:"entities,omitempty"`
+	DownloadedAt time.Time `json:"downloadedAt,omitempty"`
+}
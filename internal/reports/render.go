@@ -0,0 +1,119 @@
+package reports
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	FormatJSON = "json"
+	FormatHTML = "html"
+)
+
+// Render writes report as JSON or as an HTML page, which prints to PDF from a browser
+func Render(w io.Writer, format string, report Report) error {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "", FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	case FormatHTML:
+		return reportTemplate.Execute(w, report)
+	}
+	return fmt.Errorf("unknown report format %q", format)
+}
+
+// FileName is where a report is written in a bucket, such as daily/2024-06-01.html
+func FileName(report Report, format string) string {
+	return fmt.Sprintf("%s/%s.%s", report.Period, report.From.Format(time.DateOnly), format)
+}
+
+type count struct {
+	Name  string
+	Count int
+}
+
+// sortedCounts orders counts by the most first, then by name
+func sortedCounts(counts map[string]int) []count {
+	out := make([]count, 0, len(counts))
+	for name, n := range counts {
+		out = append(out, count{Name: name, Count: n})
+	}
+	slices.SortFunc(out, func(a, b count) int {
+		if a.Count != b.Count {
+			return b.Count - a.Count
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return out
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"date": func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format("2006-01-02 15:04 MST")
+	},
+	"day": func(t time.Time) string {
+		return t.Format(time.DateOnly)
+	},
+	"sorted": sortedCounts,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Watchman {{ .Period }} report {{ day .From }}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.8em; text-align: left; }
+td.n { text-align: right; }
+@media print { body { margin: 0; } }
+</style>
+</head>
+<body>
+<h1>Watchman {{ .Period }} screening report</h1>
+<p>From {{ date .From }} to {{ date .To }}, generated {{ date .GeneratedAt }}</p>
+
+<h2>Searches</h2>
+{{ with .Searches }}
+<table>
+<tr><th>Searches</th><td class="n">{{ .Total }}</td></tr>
+<tr><th>Failed</th><td class="n">{{ .Failed }}</td></tr>
+<tr><th>With hits (score of at least {{ .MinMatch }})</th><td class="n">{{ .WithHits }}</td></tr>
+<tr><th>Hits</th><td class="n">{{ .Hits }}</td></tr>
+{{ range sorted .Types }}<tr><th>{{ .Name }} searches</th><td class="n">{{ .Count }}</td></tr>
+{{ end }}</table>
+{{ else }}
+<p>Searches aren't recorded in the database, so they aren't summarized.</p>
+{{ end }}
+
+<h2>Watches</h2>
+<table>
+<tr><th>Rescreens with matches</th><td class="n">{{ .Watches.Triggered }}</td></tr>
+<tr><th>Watches matched</th><td class="n">{{ .Watches.Watches }}</td></tr>
+<tr><th>Matches</th><td class="n">{{ .Watches.Matches }}</td></tr>
+</table>
+
+<h2>Lists</h2>
+<table>
+<tr><th>List</th><th>Hits</th><th>Refreshes</th><th>Versions searched</th></tr>
+{{ range .Lists }}<tr><td>{{ .List }}</td><td class="n">{{ .Hits }}</td><td class="n">{{ .Refreshes }}</td><td>{{ range .Versions }}{{ .VersionID }}{{ if .Entities }} ({{ .Entities }} entities{{ if not .DownloadedAt.IsZero }}, downloaded {{ date .DownloadedAt }}{{ end }}){{ end }}: {{ .Searches }} searches<br>{{ end }}</td></tr>
+{{ end }}</table>
+
+{{ range .Lists }}{{ if .Programs }}
+<h3>Hits of {{ .List }} by program</h3>
+<table>
+<tr><th>Program</th><th>Hits</th></tr>
+{{ range sorted .Programs }}<tr><td>{{ .Name }}</td><td class="n">{{ .Count }}</td></tr>
+{{ end }}</table>
+{{ end }}{{ end }}
+</body>
+</html>
+`))
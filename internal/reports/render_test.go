@@ -0,0 +1,39 @@
+package reports
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	report := testReport()
+
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, FormatJSON, report))
+
+	var decoded Report
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(t, report.Watches, decoded.Watches)
+
+	buf.Reset()
+	require.NoError(t, Render(&buf, FormatHTML, report))
+	html := buf.String()
+	require.Contains(t, html, "<title>Watchman daily report 2024-06-01</title>")
+	require.Contains(t, html, "Hits of us_ofac by program")
+	require.Contains(t, html, "v1 (100 entities): 10 searches")
+
+	// Searches aren't summarized without the audit log
+	report.Searches = nil
+	buf.Reset()
+	require.NoError(t, Render(&buf, FormatHTML, report))
+	require.Contains(t, buf.String(), "Searches aren't recorded in the database")
+
+	require.ErrorContains(t, Render(&buf, "pdf", report), `unknown report format "pdf"`)
+}
+
+func TestFileName(t *testing.T) {
+	require.Equal(t, "daily/2024-06-01.json", FileName(testReport(), FormatJSON))
+}
@@ -0,0 +1,298 @@
+package reports
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/internal/audit"
+	"github.com/moov-io/watchman/internal/download"
+	"github.com/moov-io/watchman/internal/search"
+	"github.com/moov-io/watchman/internal/versions"
+	"github.com/moov-io/watchman/internal/webhooks"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+)
+
+// Config chooses when summary reports are generated and where they're delivered. Reports are only delivered
+// when Schedule is set, while they can always be generated from the admin server.
+type Config struct {
+	// Schedule is a five field cron expression in UTC (e.g. "0 6 * * *") or a duration of when reports are
+	// delivered, each covering the Period before it
+	Schedule string
+
+	// Period is daily unless it's set
+	Period Period
+
+	// MinMatch is the lowest score of a result counted as a hit, defaultMinMatch unless it's set
+	MinMatch float64
+
+	// Webhook is sent the report as JSON along with its HTML, such as to a service which emails it
+	Webhook *WebhookConfig
+
+	// BucketURL is a bucket (s3://bucket?prefix=reports/ or gs://bucket) or local directory (file:///var/reports)
+	// each report is written to as JSON and HTML
+	BucketURL string
+}
+
+type WebhookConfig struct {
+	URL    string
+	Secret string
+}
+
+const (
+	defaultMinMatch = 0.85
+
+	// maxListVersions is the most versions of each list a report reads, newest first
+	maxListVersions = 500
+)
+
+// Validate returns an error when the schedule, period, minimum score or webhook URL can't be used
+func (c Config) Validate() error {
+	if c.Schedule != "" {
+		if _, err := download.ParseSchedule(c.Schedule); err != nil {
+			return fmt.Errorf("reports schedule: %w", err)
+		}
+		if c.Webhook == nil && c.BucketURL == "" {
+			return errors.New("reports are scheduled without a webhook or bucket to deliver them to")
+		}
+	}
+	if _, err := ParsePeriod(string(c.Period)); err != nil {
+		return err
+	}
+	if math.IsNaN(c.MinMatch) || c.MinMatch < 0 || c.MinMatch > 1 {
+		return fmt.Errorf("reports minMatch of %v must be between 0 and 1", c.MinMatch)
+	}
+	if c.Webhook != nil {
+		if _, err := webhooks.ValidateURL(c.Webhook.URL); err != nil {
+			return fmt.Errorf("reports webhook: %w", err)
+		}
+	}
+	return nil
+}
+
+type Service interface {
+	// Generate summarizes the period which ended at the last UTC midnight at or before end
+	Generate(ctx context.Context, period Period, end time.Time) (Report, error)
+
+	// Deliver sends a report to the webhook and bucket of the Config
+	Deliver(ctx context.Context, report Report) error
+
+	// Start delivers a report on each run of the schedule until ctx is done, doing nothing without a schedule
+	Start(ctx context.Context)
+}
+
+// NewService returns a Service which reads searches from auditReader, when they're kept in the database, and the
+// matches of watches from watchLog. Hits are attributed to programs with the entities of searchService.
+func NewService(logger log.Logger, conf Config, auditReader audit.Reader, versionService versions.Service, searchService search.Service, watchLog *WatchLog) Service {
+	return &service{
+		logger:         logger,
+		conf:           conf,
+		auditReader:    auditReader,
+		versionService: versionService,
+		searchService:  searchService,
+		watchLog:       watchLog,
+		client:         webhooks.NewClient(),
+	}
+}
+
+type service struct {
+	logger         log.Logger
+	conf           Config
+	auditReader    audit.Reader
+	versionService versions.Service
+	searchService  search.Service
+	watchLog       *WatchLog
+	client         *webhooks.Client
+}
+
+func (s *service) Generate(ctx context.Context, period Period, end time.Time) (Report, error) {
+	from, to := period.Window(end)
+	out := Report{
+		Period:      period,
+		From:        from,
+		To:          to,
+		GeneratedAt: time.Now().In(time.UTC),
+		Watches:     s.watchLog.summarize(from, to),
+	}
+	lists := make(map[string]*ListSummary)
+	list := func(name string) *ListSummary {
+		if lists[name] == nil {
+			lists[name] = &ListSummary{List: name}
+		}
+		return lists[name]
+	}
+
+	if s.auditReader != nil {
+		searches := s.newSearchSummary()
+		programs := entityPrograms(s.searchService)
+		err := s.auditReader.Range(ctx, from, to, func(record audit.Record) error {
+			searches.add(record, programs, list)
+			return nil
+		})
+		if err != nil {
+			return out, fmt.Errorf("reading searches: %w", err)
+		}
+		out.Searches = searches
+	}
+
+	// Versions downloaded over the period count as refreshes, and the latest describe the versions which were searched
+	recorded := make(map[string]versions.Version)
+	names := searchedLists(s.searchService)
+	for name := range lists {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	for _, name := range names {
+		latest, err := s.versionService.List(ctx, name, versions.Filter{To: to, Limit: maxListVersions})
+		if err != nil {
+			return out, fmt.Errorf("reading versions of %s: %w", name, err)
+		}
+		for _, version := range latest {
+			recorded[version.VersionID] = version
+			if !version.DownloadedAt.Before(from) {
+				list(name).Refreshes++
+			}
+		}
+	}
+	for _, summary := range lists {
+		for i, usage := range summary.Versions {
+			if version, exists := recorded[usage.VersionID]; exists {
+				summary.Versions[i].Entities = version.Entities
+				summary.Versions[i].DownloadedAt = version.DownloadedAt
+			}
+		}
+		out.Lists = append(out.Lists, *summary)
+	}
+	slices.SortFunc(out.Lists, func(a, b ListSummary) int {
+		return strings.Compare(a.List, b.List)
+	})
+	return out, nil
+}
+
+func (s *service) newSearchSummary() *SearchSummary {
+	return &SearchSummary{
+		Types:    make(map[string]int),
+		MinMatch: s.minMatch(),
+	}
+}
+
+// add counts a search in the running totals of the summary, adding its hits and the versions it searched to
+// each list
+func (out *SearchSummary) add(record audit.Record, programs map[entityRef][]string, list func(string) *ListSummary) {
+	out.Total++
+	out.Types[record.Type]++
+	if record.Error != "" {
+		out.Failed++
+		return
+	}
+
+	hits := 0
+	for _, result := range record.Results {
+		if result.Match < out.MinMatch {
+			continue
+		}
+		hits++
+
+		summary := list(string(result.Source))
+		summary.Hits++
+		for _, program := range programs[entityRef{result.Source, result.SourceID}] {
+			if summary.Programs == nil {
+				summary.Programs = make(map[string]int)
+			}
+			summary.Programs[program]++
+		}
+	}
+	out.Hits += hits
+	if hits > 0 {
+		out.WithHits++
+	}
+
+	for name, versionID := range record.ListVersions {
+		summary := list(name)
+		idx := slices.IndexFunc(summary.Versions, func(v VersionUsage) bool {
+			return v.VersionID == versionID
+		})
+		if idx < 0 {
+			idx = len(summary.Versions)
+			summary.Versions = append(summary.Versions, VersionUsage{VersionID: versionID})
+		}
+		summary.Versions[idx].Searches++
+	}
+}
+
+func (s *service) minMatch() float64 {
+	if s.conf.MinMatch > 0 {
+		return s.conf.MinMatch
+	}
+	return defaultMinMatch
+}
+
+type entityRef struct {
+	source   pubsearch.SourceList
+	sourceID string
+}
+
+// entityPrograms returns the sanctions programs of each entity being searched
+func entityPrograms(searchService search.Service) map[entityRef][]string {
+	out := make(map[entityRef][]string)
+	for _, entity := range searchService.Entities() {
+		if entity.SanctionsInfo != nil && len(entity.SanctionsInfo.Programs) > 0 {
+			out[entityRef{entity.Source, entity.SourceID}] = entity.SanctionsInfo.Programs
+		}
+	}
+	return out
+}
+
+// searchedLists returns the lists with entities being searched
+func searchedLists(searchService search.Service) []string {
+	var out []string
+	for name := range searchService.ListInfo().Lists {
+		out = append(out, name)
+	}
+	slices.Sort(out)
+	return out
+}
+
+func (s *service) Start(ctx context.Context) {
+	if s.conf.Schedule == "" {
+		return
+	}
+	schedule, err := download.ParseSchedule(s.conf.Schedule)
+	if err != nil {
+		s.logger.Error().LogErrorf("problem reading reports schedule: %v", err)
+		return
+	}
+	period, _ := ParsePeriod(string(s.conf.Period)) // checked by Config.Validate
+
+	go func() {
+		for {
+			next := schedule.Next(time.Now())
+			if next.IsZero() {
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Until(next)):
+			}
+
+			report, err := s.Generate(ctx, period, time.Now())
+			if err != nil {
+				s.logger.Error().LogErrorf("problem generating %s report: %v", period, err)
+				continue
+			}
+			if err := s.Deliver(ctx, report); err != nil {
+				s.logger.Error().LogErrorf("problem delivering %s report: %v", period, err)
+				continue
+			}
+			s.logger.Info().Logf("delivered %s report from %s", period, report.From.Format(time.DateOnly))
+		}
+	}()
+}
@@ -0,0 +1,222 @@
+package reports
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/audit"
+	"github.com/moov-io/watchman/internal/database"
+	"github.com/moov-io/watchman/internal/events"
+	"github.com/moov-io/watchman/internal/search"
+	"github.com/moov-io/watchman/internal/versions"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+// recordedSearches is an audit.Reader of the records it holds, newest first
+type recordedSearches []audit.Record
+
+func (r recordedSearches) Recent(ctx context.Context, since time.Time, limit int) ([]audit.Record, error) {
+	var out []audit.Record
+	for _, record := range r {
+		if !record.Timestamp.Before(since) && len(out) < limit {
+			out = append(out, record)
+		}
+	}
+	return out, nil
+}
+
+func (r recordedSearches) Range(ctx context.Context, from, to time.Time, fn func(audit.Record) error) error {
+	for i := len(r) - 1; i >= 0; i-- {
+		record := r[i]
+		if record.Timestamp.Before(from) || !record.Timestamp.Before(to) {
+			continue
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestPeriod_Window(t *testing.T) {
+	end := time.Date(2024, time.June, 3, 6, 30, 0, 0, time.UTC)
+
+	from, to := PeriodDaily.Window(end)
+	require.Equal(t, time.Date(2024, time.June, 2, 0, 0, 0, 0, time.UTC), from)
+	require.Equal(t, time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC), to)
+
+	from, to = PeriodWeekly.Window(end)
+	require.Equal(t, time.Date(2024, time.May, 27, 0, 0, 0, 0, time.UTC), from)
+	require.Equal(t, time.Date(2024, time.June, 3, 0, 0, 0, 0, time.UTC), to)
+
+	period, err := ParsePeriod("")
+	require.NoError(t, err)
+	require.Equal(t, PeriodDaily, period)
+
+	_, err = ParsePeriod("monthly")
+	require.ErrorContains(t, err, `unknown report period "monthly"`)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	require.NoError(t, Config{}.Validate())
+	require.NoError(t, Config{Schedule: "0 6 * * *", BucketURL: "reports"}.Validate())
+
+	require.ErrorContains(t, Config{Schedule: "0 6 * * *"}.Validate(), "without a webhook or bucket")
+	require.ErrorContains(t, Config{Schedule: "often", BucketURL: "reports"}.Validate(), "reports schedule")
+	require.Error(t, Config{Period: "monthly"}.Validate())
+	require.Error(t, Config{MinMatch: 1.5}.Validate())
+
+	require.NoError(t, Config{Webhook: &WebhookConfig{URL: "https://reports.example.com/watchman"}}.Validate())
+	require.ErrorContains(t, Config{Webhook: &WebhookConfig{URL: "http://reports.example.com/watchman"}}.Validate(), "reports webhook: webhook url must be an absolute https:// address")
+	require.ErrorContains(t, Config{Webhook: &WebhookConfig{URL: "/watchman"}}.Validate(), "reports webhook")
+}
+
+func TestService_Generate(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+	day := time.Now().In(time.UTC).Truncate(24 * time.Hour).Add(-48 * time.Hour) // watch matches are kept for 8 days
+
+	entities := []pubsearch.Entity[pubsearch.Value]{
+		{
+			Name:          "Acme Trading",
+			Type:          pubsearch.EntityBusiness,
+			Source:        pubsearch.SourceUSOFAC,
+			SourceID:      "1001",
+			Business:      &pubsearch.Business{Name: "Acme Trading"},
+			SanctionsInfo: &pubsearch.SanctionsInfo{Programs: []string{"SDGT", "IRAN"}},
+		},
+		{
+			Name:     "Acme Shipping",
+			Type:     pubsearch.EntityBusiness,
+			Source:   pubsearch.SourceUKCSL,
+			SourceID: "UK-1",
+			Business: &pubsearch.Business{Name: "Acme Shipping"},
+		},
+	}
+	searchService := search.NewService(logger)
+	searchService.UpdateEntities(entities)
+
+	versionService := versions.NewService(logger, versions.NewInMemoryRepository())
+	recorded, err := versionService.Record(ctx, day.Add(2*time.Hour), map[string]int{"us_ofac": 1, "uk_csl": 1}, entities)
+	require.NoError(t, err)
+	versionIDs := make(map[string]string)
+	for _, version := range recorded {
+		versionIDs[version.List] = version.VersionID
+	}
+
+	records := recordedSearches{
+		{
+			Timestamp:    day.Add(26 * time.Hour), // after the report's period
+			Type:         audit.TypeEntity,
+			ListVersions: versionIDs,
+			Results:      []audit.Result{{Source: pubsearch.SourceUSOFAC, SourceID: "1001", Match: 0.99}},
+		},
+		{
+			Timestamp:    day.Add(12 * time.Hour),
+			Type:         audit.TypeEntity,
+			ListVersions: versionIDs,
+			Results: []audit.Result{
+				{Source: pubsearch.SourceUSOFAC, SourceID: "1001", Match: 0.95},
+				{Source: pubsearch.SourceUKCSL, SourceID: "UK-1", Match: 0.70},
+			},
+		},
+		{
+			Timestamp:    day.Add(8 * time.Hour),
+			Type:         audit.TypeEntity,
+			ListVersions: versionIDs,
+			Results:      []audit.Result{{Source: pubsearch.SourceUKCSL, SourceID: "UK-1", Match: 0.90}},
+		},
+		{
+			Timestamp: day.Add(4 * time.Hour),
+			Type:      audit.TypeIdentifier,
+			Error:     "missing identifier",
+		},
+	}
+
+	watchLog := NewWatchLog()
+	watchLog.Publish(ctx, watchMatched("w1", 2, day.Add(3*time.Hour)), watchMatched("w1", 1, day.Add(9*time.Hour)), watchMatched("w2", 1, day.Add(30*time.Hour)))
+
+	svc := NewService(logger, Config{}, records, versionService, searchService, watchLog)
+	report, err := svc.Generate(ctx, PeriodDaily, day.Add(30*time.Hour))
+	require.NoError(t, err)
+
+	require.Equal(t, day, report.From)
+	require.Equal(t, day.Add(24*time.Hour), report.To)
+
+	require.Equal(t, &SearchSummary{
+		Total:    3,
+		Failed:   1,
+		Types:    map[string]int{"entity": 2, "identifier": 1},
+		MinMatch: 0.85,
+		Hits:     2,
+		WithHits: 2,
+	}, report.Searches)
+	require.Equal(t, WatchSummary{Triggered: 2, Watches: 1, Matches: 3}, report.Watches)
+
+	require.Len(t, report.Lists, 2)
+	uk, ofac := report.Lists[0], report.Lists[1]
+
+	require.Equal(t, "uk_csl", uk.List)
+	require.Equal(t, 1, uk.Hits)
+	require.Empty(t, uk.Programs)
+	require.Equal(t, 1, uk.Refreshes)
+
+	require.Equal(t, "us_ofac", ofac.List)
+	require.Equal(t, 1, ofac.Hits)
+	require.Equal(t, map[string]int{"SDGT": 1, "IRAN": 1}, ofac.Programs)
+	require.Equal(t, []VersionUsage{
+		{VersionID: versionIDs["us_ofac"], Searches: 2, Entities: 1, DownloadedAt: day.Add(2 * time.Hour)},
+	}, ofac.Versions)
+
+	// Searches aren't summarized without the audit log
+	svc = NewService(logger, Config{MinMatch: 0.9}, nil, versionService, searchService, nil)
+	report, err = svc.Generate(ctx, PeriodWeekly, day.Add(30*time.Hour))
+	require.NoError(t, err)
+	require.Nil(t, report.Searches)
+	require.Equal(t, WatchSummary{}, report.Watches)
+	require.Equal(t, day.Add(-6*24*time.Hour), report.From)
+}
+
+func TestService_GenerateEverySearch(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+	day := time.Date(2024, time.June, 2, 0, 0, 0, 0, time.UTC)
+
+	// Searches are counted a page at a time, however many there were
+	db := database.NewTestDB(t)
+	sink := audit.NewSQLSink(db)
+	for i := 0; i < 1200; i++ {
+		err := sink.Write(ctx, audit.Record{
+			RecordID:  fmt.Sprintf("record-%d", i),
+			Timestamp: day.Add(time.Duration(i) * time.Second),
+			Type:      audit.TypeEntity,
+			Results:   []audit.Result{{Source: pubsearch.SourceUSOFAC, SourceID: "1001", Match: 0.9}},
+		})
+		require.NoError(t, err)
+	}
+
+	versionService := versions.NewService(logger, versions.NewInMemoryRepository())
+	svc := NewService(logger, Config{}, audit.NewSQLReader(db), versionService, search.NewService(logger), nil)
+	report, err := svc.Generate(ctx, PeriodDaily, day.Add(24*time.Hour))
+	require.NoError(t, err)
+
+	require.Equal(t, 1200, report.Searches.Total)
+	require.Equal(t, 1200, report.Searches.WithHits)
+	require.Len(t, report.Lists, 1)
+	require.Equal(t, 1200, report.Lists[0].Hits)
+}
+
+func watchMatched(watchID string, matches int, at time.Time) events.Event {
+	event := events.New(events.EventWatchMatched)
+	event.Timestamp = at
+	event.Watch = &events.WatchMatched{
+		WatchID: watchID,
+		Matches: make([]pubsearch.SearchedEntity[pubsearch.Value], matches),
+	}
+	return event
+}
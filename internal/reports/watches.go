@@ -0,0 +1,80 @@
+package reports
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/moov-io/watchman/internal/events"
+)
+
+// watchRetention is how long watch matches are kept, which covers a weekly report delivered a day late
+const watchRetention = 8 * 24 * time.Hour
+
+// WatchLog keeps when each watch matched for the reports covering it. It's an events.Publisher, which the
+// watches service publishes its matches to along with any broker. Matches are only kept in memory, so a report
+// doesn't count those found before the server started.
+type WatchLog struct {
+	mu      sync.Mutex
+	matches []watchMatch
+}
+
+type watchMatch struct {
+	watchID string
+	matches int
+	at      time.Time
+}
+
+func NewWatchLog() *WatchLog {
+	return &WatchLog{}
+}
+
+func (l *WatchLog) Publish(ctx context.Context, evts ...events.Event) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, event := range evts {
+		if event.Type != events.EventWatchMatched || event.Watch == nil {
+			continue
+		}
+		l.matches = append(l.matches, watchMatch{
+			watchID: event.Watch.WatchID,
+			matches: len(event.Watch.Matches),
+			at:      event.Timestamp,
+		})
+	}
+
+	// Matches older than any report reads are dropped
+	cutoff := time.Now().Add(-watchRetention)
+	l.matches = slices.DeleteFunc(l.matches, func(m watchMatch) bool {
+		return m.at.Before(cutoff)
+	})
+	return nil
+}
+
+func (l *WatchLog) Close() error {
+	return nil
+}
+
+// summarize counts the matches found at or after from and before to
+func (l *WatchLog) summarize(from, to time.Time) WatchSummary {
+	var out WatchSummary
+	if l == nil {
+		return out
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	watches := make(map[string]bool)
+	for _, m := range l.matches {
+		if m.at.Before(from) || !m.at.Before(to) {
+			continue
+		}
+		out.Triggered++
+		out.Matches += m.matches
+		watches[m.watchID] = true
+	}
+	out.Watches = len(watches)
+	return out
+}
@@ -0,0 +1,119 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// AircraftQuery screens an aircraft by its tail number and manufacturer's serial number, falling back to its name
+type AircraftQuery struct {
+	Name         string
+	TailNumber   string
+	SerialNumber string
+
+	// Model narrows the results to aircraft of a model, e.g. "A340" includes each "Airbus A340-642".
+	// Aircraft without a listed model aren't excluded.
+	Model string
+
+	Limit    int
+	MinMatch float64
+}
+
+func (q AircraftQuery) empty() bool {
+	return strings.TrimSpace(q.Name) == "" && normalizeIdentifier(q.TailNumber) == "" &&
+		normalizeIdentifier(q.SerialNumber) == ""
+}
+
+const (
+	matchedTailNumber   = "tailNumber"
+	matchedSerialNumber = "serialNumber"
+)
+
+// aircraftIndex maps the normalized identifiers of each aircraft to their positions in service.entities
+type aircraftIndex struct {
+	// tailNumber includes previous registrations
+	tailNumber   map[string][]int
+	serialNumber map[string][]int
+}
+
+func newAircraftIndex(entities []search.Entity[search.Value]) aircraftIndex {
+	return aircraftIndex{
+		tailNumber:   buildPostings(entities, positionOf, tailNumberEntries),
+		serialNumber: buildPostings(entities, positionOf, serialNumberEntries),
+	}
+}
+
+func (x aircraftIndex) update(changes positionChanges) aircraftIndex {
+	return aircraftIndex{
+		tailNumber:   updatePostings(x.tailNumber, changes, positionOf, tailNumberEntries),
+		serialNumber: updatePostings(x.serialNumber, changes, positionOf, serialNumberEntries),
+	}
+}
+
+// tailNumberEntries adds each of an aircraft's current and previous tail numbers once
+func tailNumberEntries(idx int, entity search.Entity[search.Value], fn func(string, int)) {
+	if entity.Aircraft == nil {
+		return
+	}
+	keys := []string{normalizeIdentifier(entity.Aircraft.TailNumber)}
+	for _, previous := range entity.Aircraft.PreviousTailNumbers {
+		keys = append(keys, normalizeIdentifier(previous))
+	}
+	for i, key := range keys {
+		if key != "" && !slices.Contains(keys[:i], key) {
+			fn(key, idx)
+		}
+	}
+}
+
+func serialNumberEntries(idx int, entity search.Entity[search.Value], fn func(string, int)) {
+	if entity.Aircraft == nil {
+		return
+	}
+	if key := normalizeIdentifier(entity.Aircraft.SerialNumber); key != "" {
+		fn(key, idx)
+	}
+}
+
+// matchesModel reports if an aircraft's listed model includes the queried model, ignoring case and punctuation
+func matchesModel(query string, aircraft *search.Aircraft) bool {
+	if aircraft == nil || normalizeIdentifier(aircraft.Model) == "" {
+		return true
+	}
+	return strings.Contains(normalizeIdentifier(aircraft.Model), query)
+}
+
+func (s *service) SearchAircraft(ctx context.Context, query AircraftQuery) ([]VehicleMatch, error) {
+	if query.empty() {
+		return nil, errors.New("missing aircraft name or identifier")
+	}
+
+	current := s.current.Load()
+
+	var found identifierLookup
+	found.add(current.aircraft.tailNumber, normalizeIdentifier(query.TailNumber), matchedTailNumber)
+	found.add(current.aircraft.serialNumber, normalizeIdentifier(query.SerialNumber), matchedSerialNumber)
+
+	var keep func(search.Entity[search.Value]) bool
+	if model := normalizeIdentifier(query.Model); model != "" {
+		keep = func(entity search.Entity[search.Value]) bool {
+			return matchesModel(model, entity.Aircraft)
+		}
+	}
+
+	entity := search.Entity[search.Value]{
+		Name: query.Name,
+		Type: search.EntityAircraft,
+		Aircraft: &search.Aircraft{
+			Name:         query.Name,
+			TailNumber:   query.TailNumber,
+			SerialNumber: query.SerialNumber,
+			Model:        query.Model,
+		},
+	}
+	return s.searchVehicles(ctx, current, found, entity, query.Limit, query.MinMatch, keep)
+}
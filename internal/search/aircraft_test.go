@@ -0,0 +1,94 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_SearchAircraft(t *testing.T) {
+	ctx := context.Background()
+	svc := testService(t)
+
+	t.Run("tail number", func(t *testing.T) {
+		// 18150 MSN 391 lists "Aircraft Tail Number EP-MMH"
+		results, err := svc.SearchAircraft(ctx, AircraftQuery{TailNumber: "epmmh"})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, "18150", results[0].SourceID)
+		require.True(t, results[0].Exact)
+		require.Equal(t, []string{"tailNumber"}, results[0].MatchedOn)
+		require.InDelta(t, 1.0, results[0].Match, 0.001)
+	})
+
+	t.Run("previous tail number", func(t *testing.T) {
+		// 18151 MSN 449 was registered as YI-NAE
+		results, err := svc.SearchAircraft(ctx, AircraftQuery{TailNumber: "YI-NAE", SerialNumber: "449"})
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		require.Equal(t, "18151", results[0].SourceID)
+		require.Equal(t, []string{"tailNumber", "serialNumber"}, results[0].MatchedOn)
+	})
+
+	t.Run("listed under its tail number", func(t *testing.T) {
+		results, err := svc.SearchAircraft(ctx, AircraftQuery{TailNumber: "EP-GOL"})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, "15432", results[0].SourceID)
+	})
+
+	t.Run("model", func(t *testing.T) {
+		results, err := svc.SearchAircraft(ctx, AircraftQuery{SerialNumber: "1013409297", Model: "il-76"})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, "15432", results[0].SourceID)
+
+		results, err = svc.SearchAircraft(ctx, AircraftQuery{SerialNumber: "1013409297", Model: "A340"})
+		require.NoError(t, err)
+		require.Empty(t, results)
+	})
+
+	t.Run("names", func(t *testing.T) {
+		results, err := svc.SearchAircraft(ctx, AircraftQuery{Name: "EP-GOQ", Limit: 3})
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		for _, res := range results {
+			require.Equal(t, search.EntityAircraft, res.Type)
+			require.False(t, res.Exact)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := svc.SearchAircraft(ctx, AircraftQuery{Model: "A340"})
+		require.ErrorContains(t, err, "missing aircraft name or identifier")
+	})
+}
+
+func TestAPI_searchAircraft(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	req := httptest.NewRequest("GET", "/v2/search/aircraft?serialNumber=391&model=A340", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp vehicleSearchResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Entities, 1)
+	require.Equal(t, "18150", resp.Entities[0].SourceID)
+	require.Equal(t, "EP-MMH", resp.Entities[0].Aircraft.TailNumber)
+
+	req = httptest.NewRequest("GET", "/v2/search/aircraft", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
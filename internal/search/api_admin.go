@@ -0,0 +1,101 @@
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+)
+
+// NewAdminController returns the routes which change the minimum score of each list at runtime.
+// They're expected to be served on the admin server.
+func NewAdminController(logger log.Logger, service Service) Controller {
+	return &adminController{
+		logger:  logger,
+		service: service,
+	}
+}
+
+type adminController struct {
+	logger  log.Logger
+	service Service
+}
+
+func (c *adminController) AppendRoutes(router *mux.Router) *mux.Router {
+	router.
+		Name("ListMinMatches").
+		Methods("GET").
+		Path("/search/min-match").
+		HandlerFunc(c.listMinMatches)
+
+	router.
+		Name("UpdateListMinMatch").
+		Methods("PUT").
+		Path("/search/min-match/{list}").
+		HandlerFunc(c.updateMinMatch)
+
+	router.
+		Name("RemoveListMinMatch").
+		Methods("DELETE").
+		Path("/search/min-match/{list}").
+		HandlerFunc(c.removeMinMatch)
+
+	return router
+}
+
+type listMinMatchesResponse struct {
+	Lists map[search.SourceList]float64 `json:"lists"`
+}
+
+func (c *adminController) listMinMatches(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(listMinMatchesResponse{
+		Lists: c.service.ListMinMatches(),
+	})
+}
+
+type minMatchUpdate struct {
+	MinMatch float64 `json:"minMatch"`
+}
+
+func (c *adminController) updateMinMatch(w http.ResponseWriter, r *http.Request) {
+	var req minMatchUpdate
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.writeError(w, http.StatusBadRequest, fmt.Errorf("reading minMatch request: %w", err))
+		return
+	}
+	c.setMinMatch(w, r, req.MinMatch)
+}
+
+func (c *adminController) removeMinMatch(w http.ResponseWriter, r *http.Request) {
+	c.setMinMatch(w, r, 0)
+}
+
+func (c *adminController) setMinMatch(w http.ResponseWriter, r *http.Request, minMatch float64) {
+	list := search.SourceList(strings.ToLower(mux.Vars(r)["list"]))
+	if err := c.service.SetListMinMatch(list, minMatch); err != nil {
+		c.writeError(w, http.StatusBadRequest, fmt.Errorf("updating %s minMatch: %w", list, err))
+		return
+	}
+	c.logger.Info().Logf("%s minMatch is %v", list, minMatch)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(listMinMatchesResponse{
+		Lists: c.service.ListMinMatches(),
+	})
+}
+
+func (c *adminController) writeError(w http.ResponseWriter, status int, err error) {
+	c.logger.Error().LogError(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error: err.Error(),
+	})
+}
@@ -0,0 +1,55 @@
+package search
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminController_MinMatch(t *testing.T) {
+	svc := NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{
+		MinMatch: MinMatchConfig{
+			Sources: map[search.SourceList]float64{search.SourceUSOFAC: 0.8},
+		},
+	})
+
+	router := mux.NewRouter()
+	NewAdminController(log.NewTestLogger(), svc).AppendRoutes(router)
+
+	read := func(w *httptest.ResponseRecorder) map[search.SourceList]float64 {
+		t.Helper()
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp listMinMatchesResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		return resp.Lists
+	}
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/search/min-match", nil))
+	require.Equal(t, map[search.SourceList]float64{search.SourceUSOFAC: 0.8}, read(w))
+
+	// set a stricter minimum for one list
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PUT", "/search/min-match/US_CSL", strings.NewReader(`{"minMatch": 0.95}`)))
+	require.Equal(t, map[search.SourceList]float64{search.SourceUSOFAC: 0.8, search.SourceUSCSL: 0.95}, read(w))
+
+	// out of range
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("PUT", "/search/min-match/us_csl", strings.NewReader(`{"minMatch": 1.5}`)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "must be between 0 and 1")
+
+	// remove a minimum
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("DELETE", "/search/min-match/us_ofac", nil))
+	require.Equal(t, map[search.SourceList]float64{search.SourceUSCSL: 0.95}, read(w))
+}
@@ -0,0 +1,193 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/moov-io/watchman/internal/auth"
+	"github.com/moov-io/watchman/pkg/watchmanpb"
+
+	"github.com/moov-io/base/log"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// bulkSearchConcurrency is how many BulkSearch queries are screened at once per stream
+	bulkSearchConcurrency = 8
+)
+
+// NewGRPCServer returns a gRPC server with the Watchman service registered
+func NewGRPCServer(logger log.Logger, service Service, opts ...grpc.ServerOption) *grpc.Server {
+	server := grpc.NewServer(opts...)
+	watchmanpb.RegisterWatchmanServer(server, &grpcServer{
+		logger:  logger,
+		service: service,
+	})
+	return server
+}
+
+type grpcServer struct {
+	watchmanpb.UnimplementedWatchmanServer
+
+	logger  log.Logger
+	service Service
+}
+
+func (s *grpcServer) Search(ctx context.Context, req *watchmanpb.SearchRequest) (*watchmanpb.SearchResponse, error) {
+	resp, err := s.search(ctx, req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return resp, nil
+}
+
+func (s *grpcServer) BulkSearch(stream watchmanpb.Watchman_BulkSearchServer) error {
+	ctx := stream.Context()
+
+	var sendMu sync.Mutex
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(bulkSearchConcurrency)
+
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			g.Wait()
+			return err
+		}
+
+		g.Go(func() error {
+			resp, err := s.search(ctx, req)
+			if err != nil {
+				resp = &watchmanpb.SearchResponse{
+					CorrelationId: req.GetCorrelationId(),
+					Error:         err.Error(),
+				}
+			}
+
+			sendMu.Lock()
+			defer sendMu.Unlock()
+
+			return stream.Send(resp)
+		})
+	}
+
+	return g.Wait()
+}
+
+func (s *grpcServer) ListInfo(ctx context.Context, req *watchmanpb.ListInfoRequest) (*watchmanpb.ListInfoResponse, error) {
+	info := s.service.ListInfo()
+
+	resp := &watchmanpb.ListInfoResponse{
+		Lists: make(map[string]int64, len(info.Lists)),
+	}
+	for name, count := range info.Lists {
+		resp.Lists[name] = int64(count)
+	}
+	if !info.UpdatedAt.IsZero() {
+		resp.UpdatedAt = info.UpdatedAt.Format(time.RFC3339)
+	}
+	return resp, nil
+}
+
+func (s *grpcServer) search(ctx context.Context, req *watchmanpb.SearchRequest) (*watchmanpb.SearchResponse, error) {
+	query, err := readSearchQuery(grpcSearchQuery(req))
+	if err != nil {
+		return nil, fmt.Errorf("problem reading gRPC search request: %w", err)
+	}
+
+	// Calls search the custom lists of their client's tenant
+	tenantID, err := auth.TenantFor(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	opts := SearchOpts{
+		Limit:     batchSearchLimit(int(req.GetLimit())),
+		MinMatch:  req.GetMinMatch(),
+		TenantID:  tenantID,
+		RequestID: req.GetRequestId(),
+	}
+	entities, err := s.service.Search(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &watchmanpb.SearchResponse{
+		CorrelationId: req.GetCorrelationId(),
+		Entities:      make([]*watchmanpb.SearchedEntity, 0, len(entities)),
+	}
+	for _, entity := range entities {
+		bs, err := json.Marshal(entity.Entity)
+		if err != nil {
+			return nil, fmt.Errorf("encoding %s entity %s: %w", entity.Source, entity.SourceID, err)
+		}
+		resp.Entities = append(resp.Entities, &watchmanpb.SearchedEntity{
+			Name:       entity.Name,
+			EntityType: string(entity.Type),
+			SourceList: string(entity.Source),
+			SourceId:   entity.SourceID,
+			Match:      entity.Match,
+			Entity:     bs,
+		})
+	}
+	return resp, nil
+}
+
+// grpcSearchQuery converts a gRPC request into the query parameters of GET /v2/search
+// so both APIs share the same parsing.
+func grpcSearchQuery(req *watchmanpb.SearchRequest) url.Values {
+	q := make(url.Values)
+	set := func(key, value string) {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	add := func(key string, values []string) {
+		for _, v := range values {
+			q.Add(key, v)
+		}
+	}
+
+	set("name", req.GetName())
+	set("type", req.GetType())
+	add("altNames", req.GetAltNames())
+	set("gender", req.GetGender())
+	set("birthDate", req.GetBirthDate())
+	set("deathDate", req.GetDeathDate())
+	add("titles", req.GetTitles())
+
+	set("created", req.GetCreated())
+	set("dissolved", req.GetDissolved())
+
+	set("aircraftType", req.GetAircraftType())
+	set("vesselType", req.GetVesselType())
+	set("flag", req.GetFlag())
+	set("built", req.GetBuilt())
+	set("icaoCode", req.GetIcaoCode())
+	set("model", req.GetModel())
+	set("serialNumber", req.GetSerialNumber())
+	set("imoNumber", req.GetImoNumber())
+	set("mmsi", req.GetMmsi())
+	set("callSign", req.GetCallSign())
+	set("owner", req.GetOwner())
+
+	add("email", req.GetEmailAddresses())
+	add("phone", req.GetPhoneNumbers())
+	add("fax", req.GetFaxNumbers())
+	add("website", req.GetWebsites())
+	add("address", req.GetAddresses())
+	add("cryptoAddress", req.GetCryptoAddresses())
+
+	return q
+}
@@ -0,0 +1,121 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+	"github.com/moov-io/watchman/pkg/watchmanpb"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func testGRPCClient(t *testing.T) watchmanpb.WatchmanClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := NewGRPCServer(log.NewTestLogger(), testService(t))
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return watchmanpb.NewWatchmanClient(conn)
+}
+
+func TestGRPC_Search(t *testing.T) {
+	client := testGRPCClient(t)
+	ctx := context.Background()
+
+	resp, err := client.Search(ctx, &watchmanpb.SearchRequest{
+		Name:  "SHIPPING LIMITED",
+		Type:  "business",
+		Limit: 3,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Entities, 3)
+
+	first := resp.Entities[0]
+	require.Equal(t, "us_ofac", first.SourceList)
+	require.NotEmpty(t, first.SourceId)
+	require.Greater(t, first.Match, 0.5)
+
+	var entity search.Entity[search.Value]
+	require.NoError(t, json.Unmarshal(first.Entity, &entity))
+	require.Equal(t, first.SourceId, entity.SourceID)
+}
+
+func TestGRPC_BulkSearch(t *testing.T) {
+	client := testGRPCClient(t)
+
+	stream, err := client.BulkSearch(context.Background())
+	require.NoError(t, err)
+
+	queries := map[string][]string{
+		"1": {"SHIPPING LIMITED", "business"},
+		"2": {"Nicolas Maduro", "person"},
+		"3": {"AEROCARIBBEAN AIRLINES", "business"},
+	}
+	for id, query := range queries {
+		err := stream.Send(&watchmanpb.SearchRequest{
+			Name:          query[0],
+			Type:          query[1],
+			Limit:         1,
+			CorrelationId: id,
+		})
+		require.NoError(t, err)
+	}
+	require.NoError(t, stream.CloseSend())
+
+	found := make(map[string]int)
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		require.Empty(t, resp.Error)
+
+		found[resp.CorrelationId] = len(resp.Entities)
+	}
+	require.Equal(t, map[string]int{"1": 1, "2": 1, "3": 1}, found)
+}
+
+func TestGRPC_ListInfo(t *testing.T) {
+	client := testGRPCClient(t)
+
+	resp, err := client.ListInfo(context.Background(), &watchmanpb.ListInfoRequest{})
+	require.NoError(t, err)
+	require.Greater(t, resp.Lists["us_ofac"], int64(0))
+	require.NotEmpty(t, resp.UpdatedAt)
+}
+
+func TestGRPC_searchQuery(t *testing.T) {
+	q := grpcSearchQuery(&watchmanpb.SearchRequest{
+		Name:            "Adam",
+		Type:            "person",
+		BirthDate:       "2025-01-02",
+		CryptoAddresses: []string{"XBT:12345"},
+	})
+	query, err := readSearchQuery(q)
+	require.NoError(t, err)
+
+	require.Equal(t, "Adam", query.Name)
+	require.Equal(t, search.EntityPerson, query.Type)
+	require.Equal(t, "2025-01-02", query.Person.BirthDate.Format("2006-01-02"))
+	require.Equal(t, []search.CryptoAddress{{Currency: "XBT", Address: "12345"}}, query.CryptoAddresses)
+}
@@ -2,14 +2,18 @@ package search
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/moov-io/watchman/internal/auth"
 	"github.com/moov-io/watchman/internal/prepare"
 	"github.com/moov-io/watchman/pkg/address"
+	"github.com/moov-io/watchman/pkg/normalize"
 	"github.com/moov-io/watchman/pkg/search"
 
 	"github.com/gorilla/mux"
@@ -22,15 +26,42 @@ type Controller interface {
 }
 
 func NewController(logger log.Logger, service Service) Controller {
+	return NewControllerWithConfig(logger, service, ControllerConfig{})
+}
+
+// ControllerConfig changes how searches are logged
+type ControllerConfig struct {
+	// LogRedaction hashes or removes the personal details of searches written to logs
+	LogRedaction LogRedaction
+}
+
+func NewControllerWithConfig(logger log.Logger, service Service, conf ControllerConfig) Controller {
 	return &controller{
-		logger:  logger,
-		service: service,
+		logger:    logger,
+		service:   service,
+		redaction: conf.LogRedaction,
 	}
 }
 
 type controller struct {
-	logger  log.Logger
-	service Service
+	logger    log.Logger
+	service   Service
+	redaction LogRedaction
+}
+
+// logError logs err along with the ID of the query it's about, when set. The personal details of the
+// search found in err are replaced when logs are redacted, along with any other details given.
+func (c *controller) logError(r *http.Request, queryID string, err error, details ...string) {
+	logger := c.logger.Error()
+	if queryID != "" {
+		logger = logger.With(log.Fields{
+			"query_id": log.String(queryID),
+		})
+	}
+	if c.redaction.enabled() {
+		err = errors.New(c.redaction.Scrub(err.Error(), append(detailValues(r.URL.Query()), details...)...))
+	}
+	logger.LogError(err)
 }
 
 func (c *controller) AppendRoutes(router *mux.Router) *mux.Router {
@@ -40,11 +71,83 @@ func (c *controller) AppendRoutes(router *mux.Router) *mux.Router {
 		Path("/v2/search").
 		HandlerFunc(c.search)
 
+	router.
+		Name("SearchBatch.v2").
+		Methods("POST").
+		Path("/v2/search/batch").
+		HandlerFunc(c.searchBatch)
+
+	router.
+		Name("SearchIdentifier.v2").
+		Methods("GET").
+		Path("/v2/search/id").
+		HandlerFunc(c.searchIdentifier)
+
+	router.
+		Name("SearchVessel.v2").
+		Methods("GET").
+		Path("/v2/search/vessel").
+		HandlerFunc(c.searchVessel)
+
+	router.
+		Name("SearchAircraft.v2").
+		Methods("GET").
+		Path("/v2/search/aircraft").
+		HandlerFunc(c.searchAircraft)
+
+	router.
+		Name("SearchCrypto.v2").
+		Methods("GET").
+		Path("/v2/search/crypto").
+		HandlerFunc(c.searchCryptoAddress)
+
+	router.
+		Name("SearchRemarks.v2").
+		Methods("GET").
+		Path("/v2/search/remarks").
+		HandlerFunc(c.searchRemarks)
+
+	router.
+		Name("PrepareName.v2").
+		Methods("GET").
+		Path("/v2/prepare").
+		HandlerFunc(c.prepareName)
+
+	router.
+		Name("NormalizeNames.v2").
+		Methods("GET").
+		Path("/v2/normalize").
+		HandlerFunc(c.normalizeNames)
+
+	router.
+		Name("ListInfo.v2").
+		Methods("GET").
+		Path("/v2/listinfo").
+		HandlerFunc(c.listInfo)
+
+	router.
+		Name("Programs.v2").
+		Methods("GET").
+		Path("/v2/programs").
+		HandlerFunc(c.programs)
+
+	router.
+		Name("ListChanges.v2").
+		Methods("GET").
+		Path("/v2/listinfo/changes").
+		HandlerFunc(c.listChanges)
+
 	return router
 }
 
 type searchResponse struct {
 	Entities []search.SearchedEntity[search.Value] `json:"entities"`
+
+	// NextCursor is set when there are more results, which are returned by passing it as the cursor parameter
+	NextCursor string `json:"nextCursor,omitempty"`
+
+	// Truncated is set when the search ran out of time or candidates, so Entities are the best of those it scored
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 type errorResponse struct {
@@ -54,10 +157,19 @@ type errorResponse struct {
 func (c *controller) search(w http.ResponseWriter, r *http.Request) {
 	debug := strx.Yes(r.URL.Query().Get("debug"))
 
-	req, err := readSearchRequest(r)
+	// The parameters a q query string is short for are read along with the others
+	values, err := expandQuery(r.URL.Query())
+	if err == nil {
+		r.URL.RawQuery = values.Encode()
+	}
+
+	var req search.Entity[search.Value]
+	if err == nil {
+		req, err = readSearchRequest(r)
+	}
 	if err != nil {
 		err = fmt.Errorf("problem reading v2 search request: %w", err)
-		c.logger.Error().LogError(err)
+		c.logError(r, "", err)
 
 		w.WriteHeader(http.StatusBadRequest)
 		w.Header().Set("Content-Type", "application/json")
@@ -67,38 +179,489 @@ func (c *controller) search(w http.ResponseWriter, r *http.Request) {
 
 		return
 	}
+	queryID := QueryID(req)
 	if debug {
-		c.logger.Debug().Logf("request: %#v", req)
+		if c.redaction.enabled() {
+			c.logger.Debug().With(log.Fields{
+				"query_id": log.String(queryID),
+			}).Logf("request: %s", c.redaction.Params(r.URL.Query()))
+		} else {
+			c.logger.Debug().Logf("request: %#v", req)
+		}
 	}
 
-	q := r.URL.Query()
-	opts := SearchOpts{
-		Limit:          extractSearchLimit(r),
-		MinMatch:       extractSearchMinMatch(r),
-		RequestID:      q.Get("requestID"),
-		DebugSourceIDs: strings.Split(q.Get("debugSourceIDs"), ","),
-	}
+	opts, err := readSearchOpts(r)
 	if debug {
 		c.logger.Debug().Logf("opts: %#v", opts)
 	}
+	if err != nil {
+		c.logError(r, queryID, fmt.Errorf("problem reading v2 search request: %w", err))
 
-	entities, err := c.service.Search(r.Context(), req, opts)
+		status := http.StatusBadRequest
+		if errors.Is(err, auth.ErrOtherTenant) {
+			status = http.StatusForbidden
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	// Search for one extra result to know if there's another page
+	limit := opts.Limit
+	opts.Limit += 1
+
+	ctx, info := WithSearchInfo(r.Context())
+	entities, err := c.service.Search(ctx, req, opts)
 	if err != nil {
-		c.logger.Error().LogErrorf("problem with v2 search: %v", err)
+		err = fmt.Errorf("problem with v2 search: %w", err)
+		c.logError(r, queryID, err)
 
+		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(errorResponse{
+			Error: err.Error(),
+		})
 		return
 	}
 	if debug {
 		c.logger.Debug().Logf("found %d entities\n", len(entities))
 	}
 
+	var resp searchResponse
+	entities, resp.NextCursor = nextPage(opts.Sort, entities, limit)
+	resp.Entities = entities
+	resp.Truncated = info.Truncated
+
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(searchResponse{
+	json.NewEncoder(w).Encode(resp)
+}
+
+// readSearchOpts reads the options of a search from its query parameters
+func readSearchOpts(r *http.Request) (SearchOpts, error) {
+	q := r.URL.Query()
+	tenantID, err := auth.RequestTenant(r)
+	if err != nil {
+		return SearchOpts{}, err
+	}
+	opts := SearchOpts{
+		Limit:                 extractSearchLimit(r),
+		MinMatch:              extractSearchMinMatch(r),
+		Algorithm:             q.Get("algorithm"),
+		Explain:               strx.Yes(q.Get("explain")),
+		Highlight:             strx.Yes(q.Get("highlight")),
+		Consolidate:           strx.Yes(q.Get("consolidate")),
+		ExactFirst:            strx.Yes(q.Get("exactFirst")),
+		Partial:               strx.Yes(q.Get("partial")),
+		TenantID:              tenantID,
+		Profile:               strings.TrimSpace(q.Get("profile")),
+		RequireAddressCountry: strx.Yes(q.Get("requireAddressCountry")),
+		RequestID:             q.Get("requestID"),
+		DebugSourceIDs:        strings.Split(q.Get("debugSourceIDs"), ","),
+	}
+	opts.Prepare, err = prepare.ParseStages(q.Get("prepare"))
+	if err == nil {
+		_, err = search.NameScorerFor(opts.Algorithm)
+	}
+	if err == nil {
+		opts.Weights, err = search.ParseWeights(q.Get("weights"))
+	}
+	if err == nil {
+		opts.Sort, opts.After, err = readSearchPage(q)
+	}
+	if err == nil {
+		opts.Filters, err = readSearchFilters(q)
+	}
+	if err == nil {
+		opts.AsOf, err = readAsOf(q.Get("asOf"))
+	}
+	if err == nil {
+		opts.Timeout, opts.MaxCandidates, err = readSearchBudget(q)
+	}
+	return opts, err
+}
+
+// readSearchBudget reads the timeout (such as 250ms) and maxCandidates parameters, which lower the limits of the
+// service for one search
+func readSearchBudget(q url.Values) (time.Duration, int, error) {
+	var timeout time.Duration
+	if v := strings.TrimSpace(q.Get("timeout")); v != "" {
+		dur, err := time.ParseDuration(v)
+		if err != nil || dur < 0 {
+			return 0, 0, fmt.Errorf("invalid timeout %q", v)
+		}
+		timeout = dur
+	}
+	var maxCandidates int
+	if v := strings.TrimSpace(q.Get("maxCandidates")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, fmt.Errorf("invalid maxCandidates %q", v)
+		}
+		maxCandidates = n
+	}
+	return timeout, maxCandidates, nil
+}
+
+// readAsOf parses an RFC3339 timestamp or a date, which includes every list version downloaded that day
+func readAsOf(input string) (time.Time, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, input); err == nil {
+		return t, nil
+	}
+	day, err := time.Parse(time.DateOnly, input)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("asOf: expected a date (2006-01-02) or RFC3339 timestamp: %q", input)
+	}
+	return day.Add(24*time.Hour - time.Millisecond), nil
+}
+
+// split returns each of the comma separated values of a repeated parameter
+func split(values []string) []string {
+	var out []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
+
+// readSearchFilters reads the program, country, entityType, list and source parameters, each of which can be repeated
+// or comma separated, along with the sectoral and pep parameters
+func readSearchFilters(q url.Values) (SearchFilters, error) {
+	types, err := ParseEntityTypes(split(q["entityType"]))
+	if err != nil {
+		return SearchFilters{}, err
+	}
+	filters := SearchFilters{
+		Programs:  split(q["program"]),
+		Countries: split(q["country"]),
+		Types:     types,
+		Lists:     split(q["list"]),
+		Sources:   ParseSourceLists(split(q["source"])),
+	}
+	if filters.Sectoral, err = readBoolFilter(q, "sectoral"); err != nil {
+		return SearchFilters{}, err
+	}
+	if filters.PEP, err = readBoolFilter(q, "pep"); err != nil {
+		return SearchFilters{}, err
+	}
+	if filters.Wanted, err = readBoolFilter(q, "wanted"); err != nil {
+		return SearchFilters{}, err
+	}
+	return filters, nil
+}
+
+// readBoolFilter returns nil when param isn't included
+func readBoolFilter(q url.Values, param string) (*bool, error) {
+	v := q.Get(param)
+	if v == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", param, v, err)
+	}
+	return &b, nil
+}
+
+// readSearchPage reads the sort and cursor parameters. The sort of a cursor is used when sort isn't included.
+func readSearchPage(q url.Values) (SortOrder, *Cursor, error) {
+	order, err := ParseSortOrder(q.Get("sort"))
+	if err != nil {
+		return order, nil, err
+	}
+	if q.Get("cursor") == "" {
+		return order, nil, nil
+	}
+
+	after, err := ParseCursor(q.Get("cursor"))
+	if err != nil {
+		return order, nil, err
+	}
+	if q.Get("sort") != "" && after.Sort != order {
+		return order, nil, fmt.Errorf("cursor is for sort=%s", after.Sort)
+	}
+	return after.Sort, after, nil
+}
+
+type identifierSearchResponse struct {
+	Entities []IdentifierMatch `json:"entities"`
+}
+
+func (c *controller) searchIdentifier(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	query := IdentifierQuery{
+		Identifier: strings.TrimSpace(q.Get("identifier")),
+		Type:       search.GovernmentIDType(strings.TrimSpace(strings.ToLower(q.Get("type")))),
+		Country:    strings.TrimSpace(q.Get("country")),
+		Limit:      extractSearchLimit(r),
+	}
+
+	entities, err := c.service.SearchByIdentifier(r.Context(), query)
+	if err != nil {
+		err = fmt.Errorf("problem with v2 identifier search: %w", err)
+		c.logError(r, QueryID(query), err)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(identifierSearchResponse{
 		Entities: entities,
 	})
 }
 
+type vehicleSearchResponse struct {
+	Entities []VehicleMatch `json:"entities"`
+}
+
+func (c *controller) searchVessel(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	query := VesselQuery{
+		Name:      strings.TrimSpace(q.Get("name")),
+		IMONumber: strings.TrimSpace(q.Get("imoNumber")),
+		CallSign:  strings.TrimSpace(q.Get("callSign")),
+		MMSI:      strings.TrimSpace(q.Get("mmsi")),
+		Flag:      strings.TrimSpace(q.Get("flag")),
+		Limit:     extractSearchLimit(r),
+		MinMatch:  extractSearchMinMatch(r),
+	}
+
+	entities, err := c.service.SearchVessels(r.Context(), query)
+	if err != nil {
+		err = fmt.Errorf("problem with v2 vessel search: %w", err)
+		c.logError(r, QueryID(query), err)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(vehicleSearchResponse{
+		Entities: entities,
+	})
+}
+
+func (c *controller) searchAircraft(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	query := AircraftQuery{
+		Name:         strings.TrimSpace(q.Get("name")),
+		TailNumber:   strings.TrimSpace(q.Get("tailNumber")),
+		SerialNumber: strings.TrimSpace(q.Get("serialNumber")),
+		Model:        strings.TrimSpace(q.Get("model")),
+		Limit:        extractSearchLimit(r),
+		MinMatch:     extractSearchMinMatch(r),
+	}
+
+	entities, err := c.service.SearchAircraft(r.Context(), query)
+	if err != nil {
+		err = fmt.Errorf("problem with v2 aircraft search: %w", err)
+		c.logError(r, QueryID(query), err)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(vehicleSearchResponse{
+		Entities: entities,
+	})
+}
+
+type cryptoSearchResponse struct {
+	Entities []CryptoAddressMatch `json:"entities"`
+}
+
+func (c *controller) searchCryptoAddress(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	query := CryptoAddressQuery{
+		Address:  strings.TrimSpace(q.Get("address")),
+		Currency: strings.TrimSpace(q.Get("currency")),
+		Limit:    extractSearchLimit(r),
+	}
+
+	entities, err := c.service.SearchCryptoAddress(r.Context(), query)
+	if err != nil {
+		err = fmt.Errorf("problem with v2 crypto address search: %w", err)
+		c.logError(r, QueryID(query), err)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(cryptoSearchResponse{
+		Entities: entities,
+	})
+}
+
+type remarksSearchResponse struct {
+	Entities []RemarksMatch `json:"entities"`
+}
+
+func (c *controller) searchRemarks(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	query := RemarksQuery{
+		Text:     strings.TrimSpace(q.Get("text")),
+		Mode:     RemarksMode(q.Get("mode")),
+		MinMatch: extractSearchMinMatch(r),
+		Limit:    extractSearchLimit(r),
+	}
+
+	entities, err := c.service.SearchRemarks(r.Context(), query)
+	if err != nil {
+		err = fmt.Errorf("problem with v2 remarks search: %w", err)
+		c.logError(r, QueryID(query), err)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(remarksSearchResponse{
+		Entities: entities,
+	})
+}
+
+func (c *controller) prepareName(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	stages, err := prepare.ParseStages(q.Get("prepare"))
+	query := PrepareQuery{
+		Name:    strings.TrimSpace(q.Get("name")),
+		Type:    search.EntityType(strings.TrimSpace(strings.ToLower(q.Get("type")))),
+		Country: strings.TrimSpace(q.Get("country")),
+		Stages:  stages,
+	}
+
+	var prepared NamePreparation
+	if err == nil {
+		prepared, err = c.service.PrepareName(r.Context(), query)
+	}
+	if err != nil {
+		err = fmt.Errorf("problem preparing v2 name: %w", err)
+		c.logError(r, QueryID(query), err)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(prepared)
+}
+
+// maxNormalizeNames is the most names normalized by one request
+const maxNormalizeNames = 100
+
+type normalizeResponse struct {
+	// Version identifies how the names were normalized, see normalize.Normalizer.Version
+	Version string           `json:"version"`
+	Names   []normalize.Name `json:"names"`
+}
+
+func (c *controller) normalizeNames(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	normalizer, err := normalize.New(normalize.Options{
+		Type:    search.EntityType(strings.TrimSpace(strings.ToLower(q.Get("type")))),
+		Country: strings.TrimSpace(q.Get("country")),
+		Stages:  strings.Split(q.Get("prepare"), ","),
+	})
+	names := q["name"]
+	if err == nil {
+		switch {
+		case len(names) == 0:
+			err = errors.New("missing name")
+		case len(names) > maxNormalizeNames:
+			err = fmt.Errorf("%d names exceeds the limit of %d", len(names), maxNormalizeNames)
+		}
+	}
+	if err != nil {
+		err = fmt.Errorf("problem normalizing v2 names: %w", err)
+		c.logError(r, "", err)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	out := normalizeResponse{
+		Version: normalizer.Version(),
+		Names:   make([]normalize.Name, len(names)),
+	}
+	for i, name := range names {
+		out.Names[i] = normalizer.Normalize(name)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(out)
+}
+
+func (c *controller) listInfo(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c.service.ListInfo())
+}
+
+type programsResponse struct {
+	Programs []Program `json:"programs"`
+}
+
+func (c *controller) programs(w http.ResponseWriter, r *http.Request) {
+	sources := ParseSourceLists(split(r.URL.Query()["source"]))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(programsResponse{
+		Programs: c.service.Programs(sources),
+	})
+}
+
+func (c *controller) listChanges(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c.service.LastChanges())
+}
+
 var (
 	softResultsLimit, hardResultsLimit = 10, 100
 )
@@ -126,8 +689,10 @@ func extractSearchMinMatch(r *http.Request) float64 {
 }
 
 func readSearchRequest(r *http.Request) (search.Entity[search.Value], error) {
-	q := r.URL.Query()
+	return readSearchQuery(r.URL.Query())
+}
 
+func readSearchQuery(q url.Values) (search.Entity[search.Value], error) {
 	var err error
 	var req search.Entity[search.Value]
 
@@ -144,6 +709,9 @@ func readSearchRequest(r *http.Request) (search.Entity[search.Value], error) {
 			BirthDate: readDate(q.Get("birthDate")),
 			DeathDate: readDate(q.Get("deathDate")),
 			Titles:    q["titles"],
+
+			BirthDates:    readDateRanges(q.Get("birthDate")),
+			Nationalities: q["nationality"],
 			// GovernmentIDs []GovernmentID `json:"governmentIDs"`
 		}
 
@@ -154,6 +722,10 @@ func readSearchRequest(r *http.Request) (search.Entity[search.Value], error) {
 			Dissolved: readDate(q.Get("dissolved")),
 			// Identifier []Identifier `json:"identifier"`
 		}
+		req.Business.GovernmentIDs, err = readFinancialIDs(q["bic"], q["lei"])
+		if err != nil {
+			return req, err
+		}
 
 	case search.EntityOrganization:
 		req.Organization = &search.Organization{
@@ -162,16 +734,21 @@ func readSearchRequest(r *http.Request) (search.Entity[search.Value], error) {
 			Dissolved: readDate(q.Get("dissolved")),
 			// Identifier []Identifier `json:"identifier"`
 		}
+		req.Organization.GovernmentIDs, err = readFinancialIDs(q["bic"], q["lei"])
+		if err != nil {
+			return req, err
+		}
 
 	case search.EntityAircraft:
 		req.Aircraft = &search.Aircraft{
 			Name:         req.Name,
 			Type:         search.AircraftType(q.Get("aircraftType")),
 			Flag:         q.Get("flag"),
-			Built:        readDate("built"),
+			Built:        readDate(q.Get("built")),
 			ICAOCode:     q.Get("icaoCode"),
 			Model:        q.Get("model"),
 			SerialNumber: q.Get("serialNumber"),
+			TailNumber:   q.Get("tailNumber"),
 		}
 
 	case search.EntityVessel:
@@ -203,6 +780,9 @@ func readSearchRequest(r *http.Request) (search.Entity[search.Value], error) {
 	req.Contact.Websites = readStrings(q["website"])
 
 	req.Addresses = readAddresses(q["address"])
+	if addr, ok := readStructuredAddress(q); ok {
+		req.Addresses = append(req.Addresses, addr)
+	}
 	req.CryptoAddresses = readCryptoCurrencyAddresses(q["cryptoAddress"])
 
 	// TODO(adam):
@@ -231,7 +811,25 @@ func readDate(input string) *time.Time {
 	return nil
 }
 
+// readDateRanges reads a partial date into the days it covers, so "2025-01" matches any day in January 2025
+func readDateRanges(input string) []search.DateRange {
+	if input == "" {
+		return nil
+	}
+
+	for _, format := range allowedDateFormats {
+		tt, err := time.Parse(format, input)
+		if err == nil {
+			return []search.DateRange{search.DateRangeFor(tt, format)}
+		}
+	}
+	return nil
+}
+
 func readInt(input string) (int, error) {
+	if input == "" {
+		return 0, nil
+	}
 	n, err := strconv.ParseInt(input, 10, 32)
 	return int(n), err
 }
@@ -254,6 +852,19 @@ func readAddresses(inputs []string) []search.Address {
 	return out
 }
 
+// readStructuredAddress reads an address written as its components, such as addressLine1 and addressCity
+func readStructuredAddress(q url.Values) (search.Address, bool) {
+	addr := search.Address{
+		Line1:      strings.TrimSpace(q.Get("addressLine1")),
+		Line2:      strings.TrimSpace(q.Get("addressLine2")),
+		City:       strings.TrimSpace(q.Get("addressCity")),
+		PostalCode: strings.TrimSpace(q.Get("addressPostalCode")),
+		State:      strings.TrimSpace(q.Get("addressState")),
+		Country:    strings.TrimSpace(q.Get("addressCountry")),
+	}
+	return addr, addr != search.Address{}
+}
+
 func readCryptoCurrencyAddresses(inputs []string) []search.CryptoAddress {
 	var out []search.CryptoAddress
 	for _, input := range inputs {
@@ -268,3 +879,23 @@ func readCryptoCurrencyAddresses(inputs []string) []search.CryptoAddress {
 	}
 	return out
 }
+
+// readFinancialIDs reads the BICs and LEIs of a bank, returning an error when any are malformed
+func readFinancialIDs(bics, leis []string) ([]search.GovernmentID, error) {
+	var out []search.GovernmentID
+	for _, input := range bics {
+		bic, err := search.NormalizeBIC(input)
+		if err != nil {
+			return nil, fmt.Errorf("reading bic: %w", err)
+		}
+		out = append(out, search.GovernmentID{Type: search.GovernmentIDSWIFT, Identifier: bic})
+	}
+	for _, input := range leis {
+		lei, err := search.NormalizeLEI(input)
+		if err != nil {
+			return nil, fmt.Errorf("reading lei: %w", err)
+		}
+		out = append(out, search.GovernmentID{Type: search.GovernmentIDLEI, Identifier: lei})
+	}
+	return out, nil
+}
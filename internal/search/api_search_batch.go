@@ -0,0 +1,321 @@
+package search
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/auth"
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	maxBatchQueries = 10000
+
+	// maxBatchRequestSize limits how much of a batch search request is read
+	maxBatchRequestSize int64 = 32 << 20 // 32MB
+
+	// batchSearchConcurrency is how many queries of a batch search are screened at once
+	batchSearchConcurrency = 8
+)
+
+type batchSearchRequest struct {
+	// Limit and MinMatch are applied to each query which doesn't specify their own
+	Limit    int     `json:"limit"`
+	MinMatch float64 `json:"minMatch"`
+
+	// Algorithm chooses how names are compared for every query
+	Algorithm string `json:"algorithm"`
+
+	// Prepare lists the stages run over every query's name, in order
+	Prepare []prepare.Stage `json:"prepare"`
+
+	// Weights override how much each field counts towards the score of every query
+	Weights search.Weights `json:"weights"`
+
+	// Consolidate merges the records of the same party on different lists into one result, for every query
+	Consolidate bool `json:"consolidate"`
+
+	// ExactFirst answers each query from the entities with exactly its name, when there are any
+	ExactFirst bool `json:"exactFirst"`
+
+	// Partial only returns the entities with a name containing each query's name
+	Partial bool `json:"partial"`
+
+	// Highlight includes the words of each result's names which matched its query
+	Highlight bool `json:"highlight"`
+
+	// RequireAddressCountry only returns the entities with an address in the country of each query's address
+	RequireAddressCountry bool `json:"requireAddressCountry"`
+
+	// MaxCandidates lowers the most entities each query scores, see BudgetConfig
+	MaxCandidates int `json:"maxCandidates"`
+
+	// Profile names the scoring profile of every query, see ProfilesConfig
+	Profile string `json:"profile"`
+
+	// Programs, Countries, EntityTypes, Lists, Sources, Sectoral, PEP and Wanted restrict the results of every query
+	Programs    []string `json:"programs"`
+	Countries   []string `json:"countries"`
+	EntityTypes []string `json:"entityTypes"`
+	Lists       []string `json:"lists"`
+	Sources     []string `json:"sources"`
+	Sectoral    *bool    `json:"sectoral"`
+	PEP         *bool    `json:"pep"`
+	Wanted      *bool    `json:"wanted"`
+
+	Queries []batchSearchQuery `json:"queries"`
+}
+
+type batchSearchQuery struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Country string `json:"country"`
+
+	// BirthDate of a person, written as 2006-01-02, 2006-01 or 2006
+	BirthDate string `json:"birthDate,omitempty"`
+
+	// Address is compared by its components, and is in Country unless it has its own
+	Address *search.Address `json:"address,omitempty"`
+
+	// BIC and LEI identify a business or organization which is a bank
+	BIC string `json:"bic,omitempty"`
+	LEI string `json:"lei,omitempty"`
+
+	Limit    int     `json:"limit"`
+	MinMatch float64 `json:"minMatch"`
+}
+
+type batchSearchResponse struct {
+	Results []batchSearchResult `json:"results"`
+}
+
+type batchSearchResult struct {
+	Query    batchSearchQuery                      `json:"query"`
+	Entities []search.SearchedEntity[search.Value] `json:"entities"`
+
+	// Truncated is set when the query ran out of time or candidates, so Entities are the best of those it scored
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Error is set when the query couldn't be searched, which leaves the results of the other queries
+	Error string `json:"error,omitempty"`
+}
+
+func (c *controller) searchBatch(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxBatchRequestSize)
+
+	req, err := readBatchSearchRequest(r)
+	if err != nil {
+		err = fmt.Errorf("problem reading v2 batch search request: %w", err)
+		c.logError(r, "", err)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+
+	requestID := r.URL.Query().Get("requestID")
+	tenantID, err := auth.RequestTenant(r)
+	if err != nil {
+		c.logError(r, "", fmt.Errorf("problem with v2 batch search: %w", err))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		json.NewEncoder(w).Encode(errorResponse{
+			Error: err.Error(),
+		})
+		return
+	}
+	filters := SearchFilters{
+		Programs:  req.Programs,
+		Countries: req.Countries,
+		Lists:     req.Lists,
+		Sources:   ParseSourceLists(req.Sources),
+		Sectoral:  req.Sectoral,
+		PEP:       req.PEP,
+		Wanted:    req.Wanted,
+	}
+	filters.Types, _ = ParseEntityTypes(req.EntityTypes) // checked by readBatchSearchRequest
+
+	resp := batchSearchResponse{
+		Results: make([]batchSearchResult, len(req.Queries)),
+	}
+	var g errgroup.Group
+	g.SetLimit(batchSearchConcurrency)
+
+	for i, q := range req.Queries {
+		i, q := i, q
+		opts := SearchOpts{
+			Limit:       batchSearchLimit(q.Limit, req.Limit),
+			MinMatch:    q.MinMatch,
+			Algorithm:   req.Algorithm,
+			Prepare:     req.Prepare,
+			Weights:     req.Weights,
+			Consolidate: req.Consolidate,
+			ExactFirst:  req.ExactFirst,
+			Partial:     req.Partial,
+			Highlight:   req.Highlight,
+			Filters:     filters,
+			TenantID:    tenantID,
+			Profile:     req.Profile,
+			RequestID:   requestID,
+
+			RequireAddressCountry: req.RequireAddressCountry,
+			MaxCandidates:         req.MaxCandidates,
+		}
+		if opts.MinMatch <= 0 {
+			opts.MinMatch = req.MinMatch
+		}
+
+		g.Go(func() error {
+			query := q.entity()
+			ctx, info := WithSearchInfo(r.Context())
+			entities, err := c.service.Search(ctx, query, opts)
+			if err != nil {
+				err = fmt.Errorf("problem with v2 batch search of query[%d]: %w", i, err)
+				c.logError(r, QueryID(query), err, q.Name, q.BirthDate)
+
+				resp.Results[i] = batchSearchResult{
+					Query: q,
+					Error: err.Error(),
+				}
+				return nil
+			}
+
+			resp.Results[i] = batchSearchResult{
+				Query:     q,
+				Entities:  entities,
+				Truncated: info.Truncated,
+			}
+			return nil
+		})
+	}
+	g.Wait()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func readBatchSearchRequest(r *http.Request) (batchSearchRequest, error) {
+	var req batchSearchRequest
+	if r.Body == nil {
+		return req, errors.New("missing request body")
+	}
+	defer r.Body.Close()
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		return req, fmt.Errorf("decoding request: %w", err)
+	}
+	if len(req.Queries) == 0 {
+		return req, errors.New("no queries provided")
+	}
+	if len(req.Queries) > maxBatchQueries {
+		return req, fmt.Errorf("too many queries: %d (max %d)", len(req.Queries), maxBatchQueries)
+	}
+	if _, err := search.NameScorerFor(req.Algorithm); err != nil {
+		return req, err
+	}
+	if err := req.Weights.Validate(); err != nil {
+		return req, err
+	}
+	if _, err := prepare.NewPipeline(prepare.PipelineConfig{Individual: req.Prepare}); err != nil {
+		return req, err
+	}
+	if _, err := ParseEntityTypes(req.EntityTypes); err != nil {
+		return req, err
+	}
+	if req.MaxCandidates < 0 {
+		return req, fmt.Errorf("invalid maxCandidates %d", req.MaxCandidates)
+	}
+	for i := range req.Queries {
+		req.Queries[i].Name = strings.TrimSpace(req.Queries[i].Name)
+		req.Queries[i].Type = strings.TrimSpace(strings.ToLower(req.Queries[i].Type))
+		req.Queries[i].Country = strings.TrimSpace(req.Queries[i].Country)
+		req.Queries[i].BirthDate = strings.TrimSpace(req.Queries[i].BirthDate)
+
+		if req.Queries[i].Name == "" {
+			return req, fmt.Errorf("query[%d] is missing a name", i)
+		}
+		if req.Queries[i].BirthDate != "" && readDate(req.Queries[i].BirthDate) == nil {
+			return req, fmt.Errorf("query[%d] has an invalid birthDate %q", i, req.Queries[i].BirthDate)
+		}
+		if _, err := readFinancialIDs(nonEmpty(req.Queries[i].BIC), nonEmpty(req.Queries[i].LEI)); err != nil {
+			return req, fmt.Errorf("query[%d]: %w", i, err)
+		}
+	}
+	return req, nil
+}
+
+// financialIDs returns the query's BIC and LEI, which readBatchSearchRequest checked
+func (q batchSearchQuery) financialIDs() []search.GovernmentID {
+	ids, _ := readFinancialIDs(nonEmpty(q.BIC), nonEmpty(q.LEI))
+	return ids
+}
+
+func nonEmpty(value string) []string {
+	if value = strings.TrimSpace(value); value != "" {
+		return []string{value}
+	}
+	return nil
+}
+
+func batchSearchLimit(limits ...int) int {
+	limit := softResultsLimit
+	for _, n := range limits {
+		if n > 0 {
+			limit = n
+			break
+		}
+	}
+	if limit > hardResultsLimit {
+		limit = hardResultsLimit
+	}
+	return limit
+}
+
+func (q batchSearchQuery) entity() search.Entity[search.Value] {
+	out := search.Entity[search.Value]{
+		Name:   q.Name,
+		Type:   search.EntityType(q.Type),
+		Source: search.SourceAPIRequest,
+	}
+
+	switch out.Type {
+	case search.EntityPerson:
+		out.Person = &search.Person{
+			Name:       q.Name,
+			BirthDate:  readDate(q.BirthDate),
+			BirthDates: readDateRanges(q.BirthDate),
+		}
+	case search.EntityBusiness:
+		out.Business = &search.Business{Name: q.Name, GovernmentIDs: q.financialIDs()}
+	case search.EntityOrganization:
+		out.Organization = &search.Organization{Name: q.Name, GovernmentIDs: q.financialIDs()}
+	case search.EntityAircraft:
+		out.Aircraft = &search.Aircraft{Name: q.Name}
+	case search.EntityVessel:
+		out.Vessel = &search.Vessel{Name: q.Name}
+	}
+
+	if q.Address != nil {
+		addr := *q.Address
+		addr.Country = cmp.Or(addr.Country, q.Country)
+		out.Addresses = []search.Address{addr}
+	} else if q.Country != "" {
+		out.Addresses = []search.Address{
+			{Country: q.Country},
+		}
+	}
+
+	return out
+}
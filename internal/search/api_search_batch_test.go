@@ -0,0 +1,134 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPI_searchBatch(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	body := `{"limit": 2, "queries": [
+  {"name": "SHIPPING LIMITED", "type": "business"},
+  {"name": "Nicolas Maduro", "type": "person", "country": "Venezuela", "limit": 1, "minMatch": 0.5}
+]}`
+	req := httptest.NewRequest("POST", "/v2/search/batch", strings.NewReader(body))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp batchSearchResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Results, 2)
+
+	require.Equal(t, "SHIPPING LIMITED", resp.Results[0].Query.Name)
+	require.Len(t, resp.Results[0].Entities, 2)
+
+	require.Equal(t, "Venezuela", resp.Results[1].Query.Country)
+	require.Len(t, resp.Results[1].Entities, 1)
+	require.Greater(t, resp.Results[1].Entities[0].Match, 0.5)
+}
+
+func TestAPI_searchBatchError(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), failingQuery{Service: testService(t), name: "Nicolas Maduro"}).AppendRoutes(router)
+
+	body := `{"queries": [
+  {"name": "SHIPPING LIMITED", "type": "business"},
+  {"name": "Nicolas Maduro", "type": "person"}
+]}`
+	req := httptest.NewRequest("POST", "/v2/search/batch", strings.NewReader(body))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// The failed query doesn't fail the others
+	var resp batchSearchResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Results, 2)
+
+	require.NotEmpty(t, resp.Results[0].Entities)
+	require.Empty(t, resp.Results[0].Error)
+
+	require.Equal(t, "Nicolas Maduro", resp.Results[1].Query.Name)
+	require.Empty(t, resp.Results[1].Entities)
+	require.Equal(t, `problem with v2 batch search of query[1]: search failed`, resp.Results[1].Error)
+
+	// Bodies are limited in size
+	defer func(size int64) { maxBatchRequestSize = size }(maxBatchRequestSize)
+	maxBatchRequestSize = int64(len(body) - 1)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("POST", "/v2/search/batch", strings.NewReader(body)))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "request body too large")
+}
+
+type failingQuery struct {
+	Service
+	name string
+}
+
+func (s failingQuery) Search(ctx context.Context, query search.Entity[search.Value], opts SearchOpts) ([]search.SearchedEntity[search.Value], error) {
+	if query.Name == s.name {
+		return nil, errors.New("search failed")
+	}
+	return s.Service.Search(ctx, query, opts)
+}
+
+func TestAPI_readBatchSearchRequest(t *testing.T) {
+	cases := []struct {
+		body     string
+		expected string
+	}{
+		{body: `{`, expected: "decoding request"},
+		{body: `{"queries": []}`, expected: "no queries provided"},
+		{body: `{"queries": [{"type": "person"}]}`, expected: "query[0] is missing a name"},
+		{body: `{"algorithm": "other", "queries": [{"name": "adam"}]}`, expected: `unknown algorithm "other"`},
+		{body: `{"queries": [{"name": "adam", "birthDate": "June 1970"}]}`, expected: `query[0] has an invalid birthDate "June 1970"`},
+		{body: `{"queries": [{"name": "Deutsche Bank", "type": "business", "lei": "7LTWFZYICNSX8D621K87"}]}`, expected: `query[0]: reading lei: LEI "7LTWFZYICNSX8D621K87" has invalid check digits`},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest("POST", "/v2/search/batch", strings.NewReader(tc.body))
+
+		_, err := readBatchSearchRequest(req)
+		require.ErrorContains(t, err, tc.expected)
+	}
+
+	t.Run("normalize", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/v2/search/batch", strings.NewReader(`{"queries": [{"name": " Adam ", "type": "PERSON", "country": " US ", "birthDate": "1970-06"}]}`))
+
+		found, err := readBatchSearchRequest(req)
+		require.NoError(t, err)
+		require.Len(t, found.Queries, 1)
+
+		query := found.Queries[0].entity()
+		require.Equal(t, "Adam", query.Name)
+		require.Equal(t, search.EntityPerson, query.Type)
+		require.Equal(t, "Adam", query.Person.Name)
+		require.Equal(t, "US", query.Addresses[0].Country)
+		require.Equal(t, "1970-06-01", query.Person.BirthDate.Format("2006-01-02"))
+		require.Len(t, query.Person.BirthDates, 1)
+	})
+}
+
+func TestAPI_batchSearchLimit(t *testing.T) {
+	require.Equal(t, softResultsLimit, batchSearchLimit(0, 0))
+	require.Equal(t, 5, batchSearchLimit(5, 20))
+	require.Equal(t, 20, batchSearchLimit(0, 20))
+	require.Equal(t, hardResultsLimit, batchSearchLimit(1000))
+}
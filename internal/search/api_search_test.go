@@ -1,12 +1,19 @@
 package search
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/moov-io/watchman/internal/auth"
 	"github.com/moov-io/watchman/pkg/search"
 
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,7 +29,27 @@ func TestAPI_readSearchRequest(t *testing.T) {
 
 		require.NotNil(t, query.Person)
 		require.Equal(t, "2025-01-02T00:00:00Z", query.Person.BirthDate.Format(time.RFC3339))
+	})
+
+	t.Run("partial birth date", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/v2/search?name=adam&type=person&birthDate=2025-01", nil)
+
+		query, err := readSearchRequest(req)
+		require.NoError(t, err)
+
+		require.Len(t, query.Person.BirthDates, 1)
+		require.Equal(t, "2025-01-01", query.Person.BirthDates[0].Start.Format(time.DateOnly))
+		require.Equal(t, "2025-01-31", query.Person.BirthDates[0].End.Format(time.DateOnly))
+	})
+
+	t.Run("gender and nationality", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/v2/search?name=adam&type=person&gender=F&nationality=VE&nationality=Cuba", nil)
+
+		query, err := readSearchRequest(req)
+		require.NoError(t, err)
 
+		require.Equal(t, search.GenderFemale, query.Person.Gender)
+		require.Equal(t, []string{"VE", "Cuba"}, query.Person.Nationalities)
 	})
 
 	t.Run("contact info", func(t *testing.T) {
@@ -63,4 +90,223 @@ func TestAPI_readSearchRequest(t *testing.T) {
 		}
 		require.ElementsMatch(t, expected, query.CryptoAddresses)
 	})
+
+	t.Run("addresses", func(t *testing.T) {
+		address := "/v2/search?type=business&address=Skorepka+1058/8,+Prague+110+00,+Czech+Republic"
+		address += "&addressLine1=1600+Pennsylvania+Ave&addressCity=Washington&addressState=DC"
+		address += "&addressPostalCode=20500&addressCountry=US"
+
+		req := httptest.NewRequest("GET", address, nil)
+
+		query, err := readSearchRequest(req)
+		require.NoError(t, err)
+
+		expected := []search.Address{
+			{Line1: "Skorepka 1058/8", City: "Prague", PostalCode: "110 00", Country: "Czech Republic"},
+			{Line1: "1600 Pennsylvania Ave", City: "Washington", State: "DC", PostalCode: "20500", Country: "US"},
+		}
+		require.Equal(t, expected, query.Addresses)
+	})
+}
+
+func TestAPI_listInfo(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	req := httptest.NewRequest("GET", "/v2/listinfo", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var info ListInfo
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&info))
+	require.Greater(t, info.Lists["us_ofac"], 0)
+	require.False(t, info.UpdatedAt.IsZero())
+}
+
+func TestAPI_searchAlgorithm(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	for _, algorithm := range search.Algorithms() {
+		req := httptest.NewRequest("GET", "/v2/search?name=shipping+limited&type=business&algorithm="+algorithm, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code, algorithm)
+	}
+
+	req := httptest.NewRequest("GET", "/v2/search?name=shipping+limited&type=business&algorithm=other", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), `unknown algorithm \"other\"`)
+}
+
+func TestAPI_searchAsOf(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	req := httptest.NewRequest("GET", "/v2/search?name=shipping+limited&type=business&asOf=June+1", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), `asOf: expected a date (2006-01-02) or RFC3339 timestamp`)
+
+	// Dates include the whole day
+	asOf, err := readAsOf("2024-06-01")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, time.June, 1, 23, 59, 59, 999000000, time.UTC), asOf)
+
+	asOf, err = readAsOf("2024-06-01T12:00:00Z")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, time.June, 1, 12, 0, 0, 0, time.UTC), asOf)
+}
+
+func TestAPI_searchBudget(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	req := httptest.NewRequest("GET", "/v2/search?name=shipping+limited&type=business&maxCandidates=5&timeout=5s", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp searchResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.True(t, resp.Truncated)
+	require.LessOrEqual(t, len(resp.Entities), 5)
+
+	for _, param := range []string{"maxCandidates=-1", "maxCandidates=many", "timeout=soon"} {
+		req := httptest.NewRequest("GET", "/v2/search?name=shipping+limited&type=business&"+param, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code, param)
+	}
+}
+
+func TestAPI_searchPrepare(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	req := httptest.NewRequest("GET", "/v2/search?name=shipping+limited+LLC&type=business&prepare=company-titles,stopwords", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest("GET", "/v2/search?name=shipping+limited&type=business&prepare=reorder,other", nil)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), `unknown prepare stage \"other\"`)
+}
+
+func TestAPI_searchTenant(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	ctx := auth.WithClient(context.Background(), &auth.Client{Name: "payments", Tenant: "bank-a"})
+
+	req := httptest.NewRequest("GET", "/v2/search?name=acme&type=business&tenantID=bank-a", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req.WithContext(ctx))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	// A client can't search the custom lists of another tenant
+	req = httptest.NewRequest("GET", "/v2/search?name=acme&type=business", nil)
+	req.Header.Set("X-Tenant-ID", "bank-b")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req.WithContext(ctx))
+	require.Equal(t, http.StatusForbidden, w.Code)
+	require.Contains(t, w.Body.String(), `client can't act for tenant \"bank-b\"`)
+
+	req = httptest.NewRequest("POST", "/v2/search/batch?tenantID=bank-b", strings.NewReader(`{"queries": [{"name": "acme", "entityType": "business"}]}`))
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req.WithContext(ctx))
+	require.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestAPI_searchHighlight(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	req := httptest.NewRequest("GET", "/v2/search?name=nicolas+maduro&type=person&limit=1&highlight=true", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp searchResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Entities, 1)
+
+	highlights := resp.Entities[0].Highlights
+	require.NotEmpty(t, highlights)
+	for _, span := range highlights[0].Spans {
+		require.Equal(t, span.Text, string([]rune(highlights[0].Name)[span.Start:span.End]))
+	}
+}
+
+func TestAPI_searchWeights(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	req := httptest.NewRequest("GET", "/v2/search?name=shipping+limited&type=business&weights=name:50,address:5", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest("GET", "/v2/search?name=shipping+limited&type=business&weights=address:500", nil)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "address weight of 500 must be between 0 and 100")
+}
+
+func TestAPI_searchPages(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	get := func(url string) (*httptest.ResponseRecorder, searchResponse) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", url, nil))
+
+		var resp searchResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		}
+		return w, resp
+	}
+
+	w, first := get("/v2/search?name=shipping&type=business&limit=5&sort=name")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, first.Entities, 5)
+	require.NotEmpty(t, first.NextCursor)
+
+	// The cursor carries its sort
+	w, second := get("/v2/search?name=shipping&type=business&limit=5&cursor=" + first.NextCursor)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Len(t, second.Entities, 5)
+	require.NotEqual(t, first.Entities[0].SourceID, second.Entities[0].SourceID)
+
+	w, _ = get("/v2/search?name=shipping&type=business&limit=5&sort=score&cursor=" + first.NextCursor)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "cursor is for sort=name")
+
+	w, _ = get("/v2/search?name=shipping&type=business&sort=date")
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// No cursor is returned on the last page
+	w, last := get("/v2/search?name=shipping&type=business&minMatch=0.99&limit=100")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Empty(t, last.NextCursor)
 }
@@ -0,0 +1,104 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// BudgetConfig limits the work of each search, so a query matching most of the index, such as a single common
+// word, returns the best results found so far rather than holding up the request
+type BudgetConfig struct {
+	// Timeout is how long each search scores entities for, unless its context ends sooner. Searches have no
+	// limit besides their context when zero.
+	Timeout time.Duration
+
+	// MaxCandidates is the most entities each search scores, scoring every candidate when zero
+	MaxCandidates int
+}
+
+// Validate returns an error when either limit is negative
+func (c BudgetConfig) Validate() error {
+	if c.Timeout < 0 {
+		return fmt.Errorf("search timeout of %v must not be negative", c.Timeout)
+	}
+	if c.MaxCandidates < 0 {
+		return fmt.Errorf("search max candidates of %d must not be negative", c.MaxCandidates)
+	}
+	return nil
+}
+
+// For returns the limits of a search, which can lower but not raise those of the service
+func (c BudgetConfig) For(opts SearchOpts) BudgetConfig {
+	return BudgetConfig{
+		Timeout:       lowestLimit(c.Timeout, opts.Timeout),
+		MaxCandidates: lowestLimit(c.MaxCandidates, opts.MaxCandidates),
+	}
+}
+
+// lowestLimit returns the smallest limit which is set, where zero is no limit
+func lowestLimit[T int | time.Duration](limits ...T) T {
+	var out T
+	for _, limit := range limits {
+		if limit > 0 && (out == 0 || limit < out) {
+			out = limit
+		}
+	}
+	return out
+}
+
+// searchBudget stops a search from scoring more entities once its context is done or it has scored its most
+// candidates. It's shared by the shards of a search.
+type searchBudget struct {
+	done      <-chan struct{}
+	limited   bool
+	remaining atomic.Int64
+	truncated atomic.Bool
+}
+
+func newSearchBudget(ctx context.Context, maxCandidates int) *searchBudget {
+	b := &searchBudget{
+		done:    ctx.Done(),
+		limited: maxCandidates > 0,
+	}
+	b.remaining.Store(int64(maxCandidates))
+	return b
+}
+
+// spend reports if another entity can be scored, marking the search as truncated when it can't
+func (b *searchBudget) spend() bool {
+	select {
+	case <-b.done:
+		b.truncated.Store(true)
+		return false
+	default:
+	}
+	if b.limited && b.remaining.Add(-1) < 0 {
+		b.truncated.Store(true)
+		return false
+	}
+	return true
+}
+
+// SearchInfo describes how a search ended, for callers which pass a context from WithSearchInfo
+type SearchInfo struct {
+	// Truncated searches ran out of time or candidates before scoring every entity, so their results are the
+	// best of those which were scored
+	Truncated bool
+}
+
+type searchInfoKey struct{}
+
+// WithSearchInfo returns a context which searches record their SearchInfo into. It's read once Search returns,
+// and each search needs its own.
+func WithSearchInfo(ctx context.Context) (context.Context, *SearchInfo) {
+	info := &SearchInfo{}
+	return context.WithValue(ctx, searchInfoKey{}, info), info
+}
+
+func recordTruncated(ctx context.Context) {
+	if info, ok := ctx.Value(searchInfoKey{}).(*SearchInfo); ok {
+		info.Truncated = true
+	}
+}
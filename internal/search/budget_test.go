@@ -0,0 +1,117 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudgetConfig_For(t *testing.T) {
+	conf := BudgetConfig{Timeout: time.Second, MaxCandidates: 1000}
+
+	require.Equal(t, conf, conf.For(SearchOpts{}))
+	require.Equal(t, BudgetConfig{Timeout: 100 * time.Millisecond, MaxCandidates: 10}, conf.For(SearchOpts{
+		Timeout:       100 * time.Millisecond,
+		MaxCandidates: 10,
+	}))
+
+	// Searches can't raise the limits of the service
+	require.Equal(t, conf, conf.For(SearchOpts{Timeout: time.Minute, MaxCandidates: 5000}))
+	require.Equal(t, BudgetConfig{MaxCandidates: 5000}, BudgetConfig{}.For(SearchOpts{MaxCandidates: 5000}))
+
+	require.Error(t, BudgetConfig{MaxCandidates: -1}.Validate())
+	require.Error(t, BudgetConfig{Timeout: -time.Second}.Validate())
+}
+
+func TestService_Budget(t *testing.T) {
+	var entities []search.Entity[search.Value]
+	for i := 0; i < 100; i++ {
+		name := fmt.Sprintf("Trading Company %d", i)
+		entities = append(entities, search.Entity[search.Value]{
+			Name:     name,
+			Type:     search.EntityBusiness,
+			Source:   search.SourceUSOFAC,
+			SourceID: fmt.Sprintf("%d", i),
+			Business: &search.Business{Name: name},
+		})
+	}
+	svc := NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{Shards: 1})
+	svc.UpdateEntities(entities)
+
+	query := search.Entity[search.Value]{
+		Name:     "Trading Company 99",
+		Type:     search.EntityBusiness,
+		Business: &search.Business{Name: "Trading Company 99"},
+	}
+	opts := SearchOpts{Limit: 200, MinMatch: 0.5}
+
+	ctx, info := WithSearchInfo(context.Background())
+	results, err := svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Len(t, results, 100)
+	require.False(t, info.Truncated)
+
+	// Only the first candidates are scored, so the best match isn't among them
+	opts.MaxCandidates = 10
+	ctx, info = WithSearchInfo(context.Background())
+	results, err = svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Len(t, results, 10)
+	require.NotEqual(t, "99", results[0].SourceID)
+	require.True(t, info.Truncated)
+
+	// Searches past their deadline return what was scored in time
+	opts.MaxCandidates = 0
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+
+	ctx, info = WithSearchInfo(ctx)
+	results, err = svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Empty(t, results)
+	require.True(t, info.Truncated)
+
+	// Cancelled searches have nobody waiting for their results
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = svc.Search(ctx, query, opts)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCachedService_Truncated(t *testing.T) {
+	svc := NewService(log.NewTestLogger())
+	svc.UpdateEntities([]search.Entity[search.Value]{
+		{Name: "Acme Trading", Type: search.EntityBusiness, Source: search.SourceUSOFAC, SourceID: "1", Business: &search.Business{Name: "Acme Trading"}},
+		{Name: "Acme Shipping", Type: search.EntityBusiness, Source: search.SourceUSOFAC, SourceID: "2", Business: &search.Business{Name: "Acme Shipping"}},
+	})
+	cache := NewMemoryCache(10, time.Minute)
+	cached := NewCachedService(log.NewTestLogger(), svc, cache)
+
+	query := search.Entity[search.Value]{
+		Name:     "Acme Shipping",
+		Type:     search.EntityBusiness,
+		Business: &search.Business{Name: "Acme Shipping"},
+	}
+	opts := SearchOpts{Limit: 5, MinMatch: 0.01, MaxCandidates: 1}
+
+	ctx, info := WithSearchInfo(context.Background())
+	results, err := cached.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.True(t, info.Truncated)
+
+	// The truncated results weren't kept, so the search is scored again
+	opts.MaxCandidates = 0
+	ctx, info = WithSearchInfo(context.Background())
+	results, err = cached.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.False(t, info.Truncated)
+}
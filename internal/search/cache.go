@@ -0,0 +1,278 @@
+package search
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+)
+
+// CacheConfig keeps the results of repeated searches, such as those retried by upstream systems
+type CacheConfig struct {
+	// Size is how many searches are kept in memory. Results aren't cached when it's zero and RedisURL is empty.
+	Size int
+
+	// TTL is how long results are kept. It's 5m by default.
+	TTL time.Duration
+
+	// RedisURL shares cached results between instances, such as redis://:password@localhost:6379/0
+	RedisURL string
+}
+
+const (
+	defaultCacheTTL = 5 * time.Minute
+)
+
+// ResultCache keeps search results by a key of the normalized query and its options
+type ResultCache interface {
+	Get(ctx context.Context, key string) ([]search.SearchedEntity[search.Value], bool)
+	Set(ctx context.Context, key string, results []search.SearchedEntity[search.Value])
+
+	// Clear removes every result, which is called after the indexed entities change
+	Clear(ctx context.Context)
+}
+
+// NewCache returns the memory or Redis cache configured by conf, which is nil when caching is disabled
+func NewCache(logger log.Logger, conf CacheConfig) (ResultCache, error) {
+	ttl := conf.TTL
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+	switch {
+	case conf.RedisURL != "":
+		return newRedisCache(logger, conf.RedisURL, ttl)
+	case conf.Size > 0:
+		return NewMemoryCache(conf.Size, ttl), nil
+	}
+	return nil, nil
+}
+
+// NewCachedService answers repeated searches from cache, which is cleared each time the indexed entities change
+func NewCachedService(logger log.Logger, service Service, cache ResultCache) Service {
+	return &cachedService{
+		Service: service,
+		logger:  logger,
+		cache:   cache,
+	}
+}
+
+type cachedService struct {
+	Service
+
+	logger log.Logger
+	cache  ResultCache
+}
+
+func (s *cachedService) UpdateEntities(entities []search.Entity[search.Value]) {
+	s.Service.UpdateEntities(entities)
+	s.cache.Clear(context.Background())
+}
+
+func (s *cachedService) ApplyChanges(changes EntityChanges) {
+	s.Service.ApplyChanges(changes)
+	s.cache.Clear(context.Background())
+}
+
+func (s *cachedService) UpdateTenantEntities(tenantID string, entities []search.Entity[search.Value]) {
+	s.Service.UpdateTenantEntities(tenantID, entities)
+	s.cache.Clear(context.Background())
+}
+
+func (s *cachedService) SetListMinMatch(list search.SourceList, minMatch float64) error {
+	if err := s.Service.SetListMinMatch(list, minMatch); err != nil {
+		return err
+	}
+	s.cache.Clear(context.Background())
+	return nil
+}
+
+func (s *cachedService) SetMinMatches(conf MinMatchConfig) error {
+	if err := s.Service.SetMinMatches(conf); err != nil {
+		return err
+	}
+	s.cache.Clear(context.Background())
+	return nil
+}
+
+func (s *cachedService) Search(ctx context.Context, query search.Entity[search.Value], opts SearchOpts) ([]search.SearchedEntity[search.Value], error) {
+	// Searches with the same options but different API keys or tenants can use different profiles
+	profile, err := s.Service.SearchProfile(ctx, opts)
+	if err != nil {
+		return s.Service.Search(ctx, query, opts)
+	}
+	opts.Profile = profile
+
+	key, err := cacheKey(query, opts)
+	if err != nil {
+		// Invalid options are reported by the search
+		return s.Service.Search(ctx, query, opts)
+	}
+
+	if results, found := s.cache.Get(ctx, key); found {
+		recordCacheLookup(true)
+		return results, nil
+	}
+	recordCacheLookup(false)
+
+	// Truncated results are only the best of the entities scored in time, so they aren't kept
+	searchCtx, info := WithSearchInfo(ctx)
+	results, err := s.Service.Search(searchCtx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	if info.Truncated {
+		recordTruncated(ctx)
+		return results, nil
+	}
+	s.cache.Set(ctx, key, results)
+	return results, nil
+}
+
+// cacheKey identifies a search by its prepared query, whose name is normalized, along with every option
+// which changes its results
+func cacheKey(query search.Entity[search.Value], opts SearchOpts) (string, error) {
+	query, err := prepareQuery(query, opts.Prepare)
+	if err != nil {
+		return "", err
+	}
+	query.Name = strings.Join(strings.Fields(strings.ToLower(query.Name)), " ")
+
+	key := struct {
+		Query       search.Entity[search.Value]
+		Limit       int
+		MinMatch    float64
+		Algorithm   string
+		Explain     bool
+		Highlight   bool
+		Consolidate bool
+		ExactFirst  bool
+		Partial     bool
+		Prepare     []prepare.Stage
+		Weights     search.Weights
+		Filters     SearchFilters
+		TenantID    string
+		Profile     string
+		Sort        SortOrder
+		After       *Cursor
+		AsOf        time.Time
+		Changed     bool
+		Phonetic    *bool
+		Typos       search.TypoTolerance
+
+		RequireAddressCountry bool
+	}{
+		Query:       query,
+		Limit:       opts.Limit,
+		MinMatch:    opts.MinMatch,
+		Algorithm:   strings.ToLower(opts.Algorithm),
+		Explain:     opts.Explain,
+		Highlight:   opts.Highlight,
+		Consolidate: opts.Consolidate,
+		ExactFirst:  opts.ExactFirst,
+		Partial:     opts.Partial,
+		Prepare:     opts.Prepare,
+		Weights:     opts.Weights,
+		Filters:     opts.Filters,
+		TenantID:    opts.TenantID,
+		Profile:     opts.Profile,
+		Sort:        opts.Sort,
+		After:       opts.After,
+		AsOf:        opts.AsOf,
+		Changed:     opts.Changed,
+		Phonetic:    opts.Phonetic,
+		Typos:       opts.TypoTolerance,
+
+		RequireAddressCountry: opts.RequireAddressCountry,
+	}
+	bs, err := json.Marshal(key)
+	if err != nil {
+		return "", fmt.Errorf("encoding search cache key: %w", err)
+	}
+	sum := sha256.Sum256(bs)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// NewMemoryCache keeps the results of up to size searches, discarding the least recently used
+func NewMemoryCache(size int, ttl time.Duration) ResultCache {
+	return &memoryCache{
+		size:    size,
+		ttl:     ttl,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+type memoryCache struct {
+	size int
+	ttl  time.Duration
+
+	mu      sync.Mutex
+	order   *list.List // most recently used first
+	entries map[string]*list.Element
+}
+
+type memoryEntry struct {
+	key       string
+	results   []search.SearchedEntity[search.Value]
+	expiresAt time.Time
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) ([]search.SearchedEntity[search.Value], bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elm, found := c.entries[key]
+	if !found {
+		return nil, false
+	}
+	entry := elm.Value.(*memoryEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.order.Remove(elm)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(elm)
+	return slices.Clone(entry.results), true
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, results []search.SearchedEntity[search.Value]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &memoryEntry{
+		key:       key,
+		results:   slices.Clone(results),
+		expiresAt: time.Now().Add(c.ttl),
+	}
+	if elm, found := c.entries[key]; found {
+		elm.Value = entry
+		c.order.MoveToFront(elm)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*memoryEntry).key)
+	}
+}
+
+func (c *memoryCache) Clear(_ context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.entries = make(map[string]*list.Element)
+}
@@ -0,0 +1,272 @@
+package search
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+)
+
+const (
+	redisKeyPrefix = "watchman:search:"
+	redisTimeout   = 2 * time.Second
+	redisIdleConns = 10
+)
+
+var (
+	errRedisNil = errors.New("redis: nil")
+)
+
+// redisCache keeps results in Redis, which is spoken to over RESP with a small pool of connections.
+// Failures are logged and treated as misses, so searches never fail because of the cache.
+type redisCache struct {
+	logger log.Logger
+	ttl    time.Duration
+
+	addr     string
+	password string
+	db       int
+
+	idle chan *redisConn
+}
+
+type redisConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func newRedisCache(logger log.Logger, rawURL string, ttl time.Duration) (*redisCache, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("parsing redis url: %w", err)
+	}
+	if u.Scheme != "redis" || u.Host == "" {
+		return nil, fmt.Errorf("redis url %q must be written as redis://host:port/db", u.Redacted())
+	}
+
+	c := &redisCache{
+		logger: logger,
+		ttl:    ttl,
+		addr:   u.Host,
+		idle:   make(chan *redisConn, redisIdleConns),
+	}
+	if u.Port() == "" {
+		c.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		c.password, _ = u.User.Password()
+	}
+	if db := strings.Trim(u.Path, "/"); db != "" {
+		c.db, err = strconv.Atoi(db)
+		if err != nil {
+			return nil, fmt.Errorf("redis db %q: %w", db, err)
+		}
+	}
+	return c, nil
+}
+
+func (c *redisCache) Get(ctx context.Context, key string) ([]search.SearchedEntity[search.Value], bool) {
+	reply, err := c.do(ctx, "GET", redisKeyPrefix+key)
+	if err != nil {
+		if !errors.Is(err, errRedisNil) {
+			c.logger.Warn().Logf("problem reading cached search: %v", err)
+		}
+		return nil, false
+	}
+	bs, _ := reply.([]byte)
+
+	var results []search.SearchedEntity[search.Value]
+	if err := json.Unmarshal(bs, &results); err != nil {
+		c.logger.Warn().Logf("problem decoding cached search: %v", err)
+		return nil, false
+	}
+	return results, true
+}
+
+func (c *redisCache) Set(ctx context.Context, key string, results []search.SearchedEntity[search.Value]) {
+	bs, err := json.Marshal(results)
+	if err != nil {
+		c.logger.Warn().Logf("problem encoding search results: %v", err)
+		return
+	}
+	_, err = c.do(ctx, "SET", redisKeyPrefix+key, string(bs), "PX", strconv.FormatInt(c.ttl.Milliseconds(), 10))
+	if err != nil {
+		c.logger.Warn().Logf("problem caching search: %v", err)
+	}
+}
+
+// Clear deletes every cached search, including those cached by other instances
+func (c *redisCache) Clear(ctx context.Context) {
+	cursor := "0"
+	for {
+		reply, err := c.do(ctx, "SCAN", cursor, "MATCH", redisKeyPrefix+"*", "COUNT", "1000")
+		if err != nil {
+			c.logger.Warn().Logf("problem clearing cached searches: %v", err)
+			return
+		}
+		page, _ := reply.([]any)
+		if len(page) != 2 {
+			c.logger.Warn().Logf("unexpected redis SCAN reply: %v", reply)
+			return
+		}
+		next, _ := page[0].([]byte)
+		keys, _ := page[1].([]any)
+
+		if len(keys) > 0 {
+			args := []string{"DEL"}
+			for _, k := range keys {
+				if bs, ok := k.([]byte); ok {
+					args = append(args, string(bs))
+				}
+			}
+			if _, err := c.do(ctx, args...); err != nil {
+				c.logger.Warn().Logf("problem clearing cached searches: %v", err)
+				return
+			}
+		}
+
+		cursor = string(next)
+		if cursor == "0" || cursor == "" {
+			return
+		}
+	}
+}
+
+// do sends a command and reads its reply, which is a []byte, int64, string, []any or errRedisNil
+func (c *redisCache) do(ctx context.Context, args ...string) (any, error) {
+	conn, err := c.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := conn.do(ctx, args...)
+	var redisErr redisError
+	if err != nil && !errors.Is(err, errRedisNil) && !errors.As(err, &redisErr) {
+		// The connection is left in an unknown state
+		conn.conn.Close()
+		return nil, err
+	}
+
+	select {
+	case c.idle <- conn:
+	default:
+		conn.conn.Close()
+	}
+	return reply, err
+}
+
+func (c *redisCache) conn(ctx context.Context) (*redisConn, error) {
+	select {
+	case conn := <-c.idle:
+		return conn, nil
+	default:
+	}
+
+	dialer := net.Dialer{Timeout: redisTimeout}
+	nc, err := dialer.DialContext(ctx, "tcp", c.addr)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to redis: %w", err)
+	}
+	conn := &redisConn{conn: nc, r: bufio.NewReader(nc)}
+
+	if c.password != "" {
+		if _, err := conn.do(ctx, "AUTH", c.password); err != nil {
+			nc.Close()
+			return nil, fmt.Errorf("redis AUTH: %w", err)
+		}
+	}
+	if c.db != 0 {
+		if _, err := conn.do(ctx, "SELECT", strconv.Itoa(c.db)); err != nil {
+			nc.Close()
+			return nil, fmt.Errorf("redis SELECT: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+func (c *redisConn) do(ctx context.Context, args ...string) (any, error) {
+	deadline := time.Now().Add(redisTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	c.conn.SetDeadline(deadline)
+
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&buf, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(c.conn, buf.String()); err != nil {
+		return nil, err
+	}
+	return readRESP(c.r)
+}
+
+// readRESP reads one reply written in the Redis serialization protocol
+func readRESP(r *bufio.Reader) (any, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, errRedisNil
+		}
+		bs := make([]byte, n+2) // with the trailing \r\n
+		if _, err := io.ReadFull(r, bs); err != nil {
+			return nil, err
+		}
+		return bs[:n], nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, err
+		}
+		if n < 0 {
+			return nil, errRedisNil
+		}
+		out := make([]any, 0, n)
+		for i := 0; i < n; i++ {
+			elm, err := readRESP(r)
+			if err != nil && !errors.Is(err, errRedisNil) {
+				return nil, err
+			}
+			out = append(out, elm)
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("redis: unexpected reply %q", line)
+}
@@ -0,0 +1,144 @@
+package search
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisCache(t *testing.T) {
+	ctx := context.Background()
+	server := newFakeRedis(t)
+
+	cache, err := newRedisCache(log.NewTestLogger(), "redis://:secret@"+server.addr+"/2", time.Minute)
+	require.NoError(t, err)
+
+	_, found := cache.Get(ctx, "a")
+	require.False(t, found)
+
+	results := []search.SearchedEntity[search.Value]{
+		{Entity: search.Entity[search.Value]{Name: "Acme Shipping", SourceID: "1"}, Match: 0.9},
+	}
+	cache.Set(ctx, "a", results)
+	cache.Set(ctx, "b", results)
+
+	got, found := cache.Get(ctx, "a")
+	require.True(t, found)
+	require.Equal(t, "Acme Shipping", got[0].Name)
+	require.InDelta(t, 0.9, got[0].Match, 0.001)
+
+	cache.Clear(ctx)
+	_, found = cache.Get(ctx, "a")
+	require.False(t, found)
+	_, found = cache.Get(ctx, "b")
+	require.False(t, found)
+
+	require.Equal(t, "secret", server.password)
+	require.Equal(t, "2", server.db)
+}
+
+func TestRedisCache_Unavailable(t *testing.T) {
+	cache, err := newRedisCache(log.NewTestLogger(), "redis://127.0.0.1:1", time.Minute)
+	require.NoError(t, err)
+
+	// Searches are missed rather than failing
+	_, found := cache.Get(context.Background(), "a")
+	require.False(t, found)
+	cache.Set(context.Background(), "a", nil)
+	cache.Clear(context.Background())
+}
+
+// fakeRedis answers the commands used by redisCache
+type fakeRedis struct {
+	addr string
+
+	mu       sync.Mutex
+	values   map[string]string
+	password string
+	db       string
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { ln.Close() })
+
+	server := &fakeRedis{addr: ln.Addr().String(), values: make(map[string]string)}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (s *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	for {
+		reply, err := readRESP(r)
+		if err != nil {
+			return
+		}
+		var args []string
+		for _, arg := range reply.([]any) {
+			args = append(args, string(arg.([]byte)))
+		}
+		fmt.Fprint(conn, s.handle(args))
+	}
+}
+
+func (s *fakeRedis) handle(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "AUTH":
+		s.password = args[1]
+		return "+OK\r\n"
+	case "SELECT":
+		s.db = args[1]
+		return "+OK\r\n"
+	case "GET":
+		value, found := s.values[args[1]]
+		if !found {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "SET":
+		s.values[args[1]] = args[2]
+		return "+OK\r\n"
+	case "SCAN":
+		prefix := strings.TrimSuffix(args[3], "*")
+		var keys []string
+		for key := range s.values {
+			if strings.HasPrefix(key, prefix) {
+				keys = append(keys, fmt.Sprintf("$%d\r\n%s\r\n", len(key), key))
+			}
+		}
+		return fmt.Sprintf("*2\r\n$1\r\n0\r\n*%d\r\n%s", len(keys), strings.Join(keys, ""))
+	case "DEL":
+		for _, key := range args[1:] {
+			delete(s.values, key)
+		}
+		return fmt.Sprintf(":%d\r\n", len(args)-1)
+	}
+	return "-ERR unknown command\r\n"
+}
@@ -0,0 +1,143 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheKey(t *testing.T) {
+	opts := SearchOpts{Limit: 10, MinMatch: 0.8}
+	query := search.Entity[search.Value]{Name: "Acme  Shipping", Type: search.EntityBusiness}
+
+	key, err := cacheKey(query, opts)
+	require.NoError(t, err)
+
+	// Names differing by case and spacing share a key, as do request IDs
+	other, err := cacheKey(search.Entity[search.Value]{Name: " ACME shipping ", Type: search.EntityBusiness}, SearchOpts{Limit: 10, MinMatch: 0.8, RequestID: "abc"})
+	require.NoError(t, err)
+	require.Equal(t, key, other)
+
+	// Options which change the results don't
+	other, err = cacheKey(query, SearchOpts{Limit: 20, MinMatch: 0.8})
+	require.NoError(t, err)
+	require.NotEqual(t, key, other)
+
+	other, err = cacheKey(query, SearchOpts{Limit: 10, MinMatch: 0.8, TenantID: "tenant-a"})
+	require.NoError(t, err)
+	require.NotEqual(t, key, other)
+
+	other, err = cacheKey(query, SearchOpts{Limit: 10, MinMatch: 0.8, Weights: search.Weights{Address: 5}})
+	require.NoError(t, err)
+	require.NotEqual(t, key, other)
+
+	other, err = cacheKey(query, SearchOpts{Limit: 10, MinMatch: 0.8, Profile: "retail"})
+	require.NoError(t, err)
+	require.NotEqual(t, key, other)
+
+	other, err = cacheKey(query, SearchOpts{Limit: 10, MinMatch: 0.8, RequireAddressCountry: true})
+	require.NoError(t, err)
+	require.NotEqual(t, key, other)
+
+	phonetic := true
+	other, err = cacheKey(query, SearchOpts{Limit: 10, MinMatch: 0.8, Phonetic: &phonetic})
+	require.NoError(t, err)
+	require.NotEqual(t, key, other)
+
+	other, err = cacheKey(query, SearchOpts{Limit: 10, MinMatch: 0.8, TypoTolerance: search.TypoTolerance{{MinLength: 4, Edits: 1}}})
+	require.NoError(t, err)
+	require.NotEqual(t, key, other)
+}
+
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+	cache := NewMemoryCache(2, time.Minute)
+
+	results := []search.SearchedEntity[search.Value]{{Match: 0.9}}
+	cache.Set(ctx, "a", results)
+	cache.Set(ctx, "b", results)
+
+	got, found := cache.Get(ctx, "a")
+	require.True(t, found)
+	require.Equal(t, results, got)
+
+	// The least recently used search is discarded
+	cache.Set(ctx, "c", results)
+	_, found = cache.Get(ctx, "b")
+	require.False(t, found)
+	_, found = cache.Get(ctx, "a")
+	require.True(t, found)
+
+	cache.Clear(ctx)
+	_, found = cache.Get(ctx, "a")
+	require.False(t, found)
+
+	// Expired searches are missed
+	cache = NewMemoryCache(2, time.Nanosecond)
+	cache.Set(ctx, "a", results)
+	time.Sleep(time.Millisecond)
+	_, found = cache.Get(ctx, "a")
+	require.False(t, found)
+}
+
+func TestCachedService(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+
+	svc := NewCachedService(logger, NewService(logger), NewMemoryCache(10, time.Minute))
+	svc.UpdateEntities([]search.Entity[search.Value]{
+		{Name: "Acme Shipping", Type: search.EntityBusiness, Source: search.SourceUSOFAC, SourceID: "1"},
+	})
+
+	query := search.Entity[search.Value]{Name: "acme shipping", Type: search.EntityBusiness}
+	opts := SearchOpts{Limit: 10, MinMatch: 0.5}
+
+	results, err := svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	cached, err := svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Equal(t, results, cached)
+
+	// Changing the minimum of a list clears cached searches
+	near := search.Entity[search.Value]{Name: "acme shipin", Type: search.EntityBusiness}
+	results, err = svc.Search(ctx, near, SearchOpts{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	require.NoError(t, svc.SetListMinMatch(search.SourceUSOFAC, 0.99))
+	results, err = svc.Search(ctx, near, SearchOpts{Limit: 10})
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	// Refreshing the entities clears cached searches
+	svc.UpdateEntities(nil)
+	results, err = svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	// Invalid options aren't cached
+	_, err = svc.Search(ctx, query, SearchOpts{Limit: 10, Algorithm: "other"})
+	require.Error(t, err)
+}
+
+func TestNewCache(t *testing.T) {
+	logger := log.NewTestLogger()
+
+	cache, err := NewCache(logger, CacheConfig{})
+	require.NoError(t, err)
+	require.Nil(t, cache)
+
+	cache, err = NewCache(logger, CacheConfig{Size: 10})
+	require.NoError(t, err)
+	require.IsType(t, &memoryCache{}, cache)
+
+	_, err = NewCache(logger, CacheConfig{RedisURL: "http://localhost"})
+	require.ErrorContains(t, err, "redis://")
+}
@@ -0,0 +1,23 @@
+package search
+
+import (
+	"fmt"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// CalibrationConfig moves the scores of each source list onto a common scale, accounting for how much each list
+// holds about its entities. Lists without a calibration keep their raw scores.
+type CalibrationConfig struct {
+	Sources map[search.SourceList]search.Calibration
+}
+
+// Validate returns an error when the calibration of any list is out of range or decreasing
+func (c CalibrationConfig) Validate() error {
+	for source, calibration := range c.Sources {
+		if err := calibration.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package search
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/moov-io/watchman/internal/tracing"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// EntityChanges are the entities added, modified or removed between two refreshes of the lists.
+type EntityChanges struct {
+	Added    []search.Entity[search.Value]
+	Modified []search.Entity[search.Value]
+
+	// Removed holds the previous version of each removed entity
+	Removed []search.Entity[search.Value]
+}
+
+func (c EntityChanges) Empty() bool {
+	return len(c.Added) == 0 && len(c.Modified) == 0 && len(c.Removed) == 0
+}
+
+// AppliedChanges describes the latest EntityChanges applied to the index
+type AppliedChanges struct {
+	AppliedAt time.Time `json:"appliedAt"`
+
+	Added    []EntityRef `json:"added"`
+	Modified []EntityRef `json:"modified"`
+	Removed  []EntityRef `json:"removed"`
+}
+
+// EntityRef identifies an entity on one of the lists
+type EntityRef struct {
+	Source   search.SourceList `json:"source"`
+	SourceID string            `json:"sourceID"`
+	Name     string            `json:"name"`
+}
+
+func entityRefs(entities []search.Entity[search.Value]) []EntityRef {
+	out := make([]EntityRef, len(entities))
+	for i, entity := range entities {
+		out[i] = EntityRef{
+			Source:   entity.Source,
+			SourceID: entity.SourceID,
+			Name:     entity.Name,
+		}
+	}
+	return out
+}
+
+type entityKey struct {
+	source   search.SourceList
+	sourceID string
+}
+
+func keyOf(entity search.Entity[search.Value]) entityKey {
+	return entityKey{source: entity.Source, sourceID: entity.SourceID}
+}
+
+func (s *service) ApplyChanges(changes EntityChanges) {
+	_, span := tracing.Start(context.Background(), "search.index.changes",
+		attribute.Int("added", len(changes.Added)),
+		attribute.Int("modified", len(changes.Modified)),
+		attribute.Int("removed", len(changes.Removed)),
+	)
+	defer span.End()
+
+	s.updates.Lock()
+	defer s.updates.Unlock()
+
+	// Searches may be reading the current entities and positions, so the changes are applied to copies of them
+	start := time.Now()
+	current := s.current.Load()
+	next := current.with()
+	next.entities = slices.Clone(current.entities)
+	next.positions = make(map[entityKey]int, len(current.positions)+len(changes.Added))
+	for key, idx := range current.positions {
+		next.positions[key] = idx
+	}
+
+	// touched holds the positions whose entity is removed, replaced or moved, which the lookups are updated for
+	touched := make(map[int]bool)
+	for _, entity := range changes.Removed {
+		key := keyOf(entity)
+		idx, exists := next.positions[key]
+		if !exists {
+			continue
+		}
+		// Move the last entity into the removed entity's spot
+		last := len(next.entities) - 1
+		touched[idx], touched[last] = true, true
+		if idx != last {
+			next.entities[idx] = next.entities[last]
+			next.positions[keyOf(next.entities[idx])] = idx
+		}
+		next.entities[last] = search.Entity[search.Value]{}
+		next.entities = next.entities[:last]
+		delete(next.positions, key)
+	}
+
+	interned := newInterner()
+	upsert := func(entity search.Entity[search.Value]) {
+		entity = s.withPhoneticCodes(next.financialIDs.enrich(interned.entity(entity)))
+
+		key := keyOf(entity)
+		if idx, exists := next.positions[key]; exists {
+			next.entities[idx] = entity
+			touched[idx] = true
+			return
+		}
+		next.positions[key] = len(next.entities)
+		touched[len(next.entities)] = true
+		next.entities = append(next.entities, entity)
+	}
+	for _, entity := range changes.Modified {
+		upsert(entity)
+	}
+	for _, entity := range changes.Added {
+		upsert(entity)
+	}
+
+	// The lookups refer to positions in next.entities, so the entries of the entities which left a position are
+	// dropped and those of the entities now at it are added. Links are built again, as a change to one entity can
+	// join or split the group of entities on other lists it's linked with.
+	if len(touched) > 0 {
+		moved := positionChanges{}
+		for idx := range touched {
+			if idx < len(current.entities) {
+				moved.removed = append(moved.removed, positioned{idx: idx, entity: current.entities[idx]})
+			}
+			if idx < len(next.entities) {
+				moved.added = append(moved.added, positioned{idx: idx, entity: next.entities[idx]})
+			}
+		}
+		next.identifiers = current.identifiers.update(moved)
+		next.filters = current.filters.update(moved)
+		next.vessels = current.vessels.update(moved)
+		next.aircraft = current.aircraft.update(moved)
+		next.crypto = current.crypto.update(moved)
+		next.remarks = current.remarks.update(moved, len(next.entities))
+		next.exact = current.exact.update(moved)
+		next.trigrams = current.trigrams.update(moved)
+		next.phonetic = current.phonetic.update(moved)
+		next.links = newLinkIndex(next.entities)
+	}
+
+	now := time.Now().In(time.UTC)
+	next.listInfo = ListInfo{
+		Lists:     countLists(next.entities),
+		UpdatedAt: now,
+	}
+	next.programs = countPrograms(next.entities)
+	next.changed = changedPositions(next.positions, changes)
+	next.lastChanges = AppliedChanges{
+		AppliedAt: now,
+		Added:     entityRefs(changes.Added),
+		Modified:  entityRefs(changes.Modified),
+		Removed:   entityRefs(changes.Removed),
+	}
+
+	if err := s.swap(next, start); err != nil {
+		tracing.RecordError(span, err)
+	}
+}
+
+// changedPositions returns the positions of the entities added or modified by changes, in ascending order
+func changedPositions(positions map[entityKey]int, changes EntityChanges) []int {
+	out := make([]int, 0, len(changes.Added)+len(changes.Modified))
+	for _, entities := range [][]search.Entity[search.Value]{changes.Added, changes.Modified} {
+		for _, entity := range entities {
+			if idx, exists := positions[keyOf(entity)]; exists {
+				out = append(out, idx)
+			}
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+func (s *service) LastChanges() AppliedChanges {
+	return s.current.Load().lastChanges
+}
+
+func (s *service) Entities() []search.Entity[search.Value] {
+	entities := s.current.Load().entities
+
+	out := make([]search.Entity[search.Value], len(entities))
+	copy(out, entities)
+	return out
+}
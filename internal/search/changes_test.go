@@ -0,0 +1,208 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_ApplyChanges(t *testing.T) {
+	entity := func(id, name, passport string) search.Entity[search.Value] {
+		return search.Entity[search.Value]{
+			Name:     name,
+			Type:     search.EntityPerson,
+			Source:   search.SourceUSOFAC,
+			SourceID: id,
+			Person: &search.Person{
+				Name: name,
+				GovernmentIDs: []search.GovernmentID{
+					{Type: search.GovernmentIDPassport, Identifier: passport},
+				},
+			},
+		}
+	}
+
+	svc := NewService(log.NewTestLogger())
+
+	initial := []search.Entity[search.Value]{
+		entity("1", "John Doe", "A1111111"),
+		entity("2", "Jane Doe", "B2222222"),
+		entity("3", "Jim Doe", "C3333333"),
+	}
+	svc.UpdateEntities(initial)
+	require.Len(t, svc.Entities(), 3)
+
+	svc.ApplyChanges(EntityChanges{
+		Added:    []search.Entity[search.Value]{entity("4", "Jack Doe", "D4444444")},
+		Modified: []search.Entity[search.Value]{entity("3", "James Doe", "C3333333")},
+		Removed:  []search.Entity[search.Value]{initial[0]},
+	})
+
+	names := make(map[string]string)
+	for _, e := range svc.Entities() {
+		names[e.SourceID] = e.Name
+	}
+	require.Equal(t, map[string]string{"2": "Jane Doe", "3": "James Doe", "4": "Jack Doe"}, names)
+
+	// The slice given to UpdateEntities is unchanged
+	require.Equal(t, "John Doe", initial[0].Name)
+
+	require.Equal(t, 3, svc.ListInfo().Lists["us_ofac"])
+
+	changes := svc.LastChanges()
+	require.Equal(t, []EntityRef{{Source: search.SourceUSOFAC, SourceID: "4", Name: "Jack Doe"}}, changes.Added)
+	require.Len(t, changes.Modified, 1)
+	require.Len(t, changes.Removed, 1)
+	require.False(t, changes.AppliedAt.IsZero())
+
+	// Document numbers follow the entities as they move
+	ctx := context.Background()
+	found, err := svc.SearchByIdentifier(ctx, IdentifierQuery{Identifier: "D4444444"})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	require.Equal(t, "4", found[0].SourceID)
+
+	found, err = svc.SearchByIdentifier(ctx, IdentifierQuery{Identifier: "A1111111"})
+	require.NoError(t, err)
+	require.Empty(t, found)
+
+	results, err := svc.Search(ctx, search.Entity[search.Value]{Name: "James Doe", Type: search.EntityPerson}, SearchOpts{Limit: 1, MinMatch: 0.01})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "3", results[0].SourceID)
+}
+
+func TestService_ApplyChangesLookups(t *testing.T) {
+	svc := NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{Phonetic: true}).(*service)
+	entities := testEntities(t)
+	svc.UpdateEntities(entities)
+
+	requireLookups := func(t *testing.T, want, got *index) {
+		t.Helper()
+
+		require.Equal(t, want.identifiers, got.identifiers)
+		require.Equal(t, want.filters, got.filters)
+		require.Equal(t, want.vessels, got.vessels)
+		require.Equal(t, want.aircraft, got.aircraft)
+		require.Equal(t, want.crypto, got.crypto)
+		require.Equal(t, want.remarks, got.remarks)
+		require.Equal(t, want.links, got.links)
+		require.Equal(t, want.exact, got.exact)
+		require.Equal(t, want.trigrams, got.trigrams)
+		require.Equal(t, want.phonetic, got.phonetic)
+	}
+	before := svc.current.Load()
+	requireLookups(t, newIndex(before.entities, time.Time{}), before)
+
+	modified := entities[5]
+	modified.Name = "Kalashnikov Concern"
+	modified.Remarks = "Linked To: ROSTEC; Vessel Registration Identification IMO 9187629"
+	modified.CryptoAddresses = []search.CryptoAddress{{Currency: "XBT", Address: "1BVp3aUe9bWxVLo7jHDnzXpVXPbBjBGzVf"}}
+
+	last := entities[len(entities)-1]
+	last.SourceID += "-renamed"
+
+	added := search.Entity[search.Value]{
+		Name:     "Ocean Carrier",
+		Type:     search.EntityVessel,
+		Source:   search.SourceUSOFAC,
+		SourceID: "999999",
+		Vessel: &search.Vessel{
+			Name:      "Ocean Carrier",
+			IMONumber: "IMO 9187629",
+			MMSI:      "123456789",
+			Flag:      "Panama",
+		},
+		Remarks: "Vessel Registration Identification IMO 9187629",
+	}
+
+	svc.ApplyChanges(EntityChanges{
+		Added:    []search.Entity[search.Value]{added, last},
+		Modified: []search.Entity[search.Value]{modified},
+		Removed:  []search.Entity[search.Value]{entities[0], entities[3], entities[len(entities)-1]},
+	})
+
+	// The lookups are updated to match the entities, while the index searches may still be reading is unchanged
+	after := svc.current.Load()
+	require.Len(t, after.entities, len(entities)-1)
+	requireLookups(t, newIndex(after.entities, time.Time{}), after)
+	requireLookups(t, newIndex(before.entities, time.Time{}), before)
+}
+
+func TestService_SearchChanged(t *testing.T) {
+	person := func(id, name string) search.Entity[search.Value] {
+		return search.Entity[search.Value]{
+			Name:     name,
+			Type:     search.EntityPerson,
+			Source:   search.SourceUSOFAC,
+			SourceID: id,
+			Person:   &search.Person{Name: name},
+		}
+	}
+
+	ctx := context.Background()
+	query := search.Entity[search.Value]{Name: "John Doe", Type: search.EntityPerson}
+	opts := SearchOpts{Limit: 10, MinMatch: 0.8, Changed: true, ExactFirst: true}
+
+	svc := NewService(log.NewTestLogger())
+	svc.UpdateEntities([]search.Entity[search.Value]{person("1", "John Doe"), person("2", "Jane Smith")})
+	svc.UpdateTenantEntities("tenant", []search.Entity[search.Value]{person("t1", "John Doe")})
+
+	// Nothing has changed since the entities were loaded
+	results, err := svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	svc.ApplyChanges(EntityChanges{
+		Added:    []search.Entity[search.Value]{person("3", "Jon Doe")},
+		Modified: []search.Entity[search.Value]{person("2", "John Doe")},
+	})
+
+	opts.TenantID = "tenant"
+	results, err = svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, res := range results {
+		ids = append(ids, res.SourceID)
+	}
+	require.ElementsMatch(t, []string{"2", "3"}, ids)
+
+	// The unchanged entity is still found by other searches
+	opts.Changed = false
+	results, err = svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Equal(t, "1", results[0].SourceID)
+}
+
+func TestAPI_listChanges(t *testing.T) {
+	svc := testService(t)
+	removed := svc.Entities()[0]
+	svc.ApplyChanges(EntityChanges{
+		Removed: []search.Entity[search.Value]{removed},
+	})
+
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), svc).AppendRoutes(router)
+
+	req := httptest.NewRequest("GET", "/v2/listinfo/changes", nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var changes AppliedChanges
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&changes))
+	require.Len(t, changes.Removed, 1)
+	require.Equal(t, removed.SourceID, changes.Removed[0].SourceID)
+	require.Empty(t, changes.Added)
+}
@@ -0,0 +1,119 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// CryptoAddressQuery finds the entities which a digital currency address is listed under
+type CryptoAddressQuery struct {
+	Address string
+
+	// Currency optionally narrows which addresses are matched, e.g. XBT or ETH
+	Currency string
+
+	Limit int
+}
+
+// CryptoAddressMatch is an entity listing an address matching a CryptoAddressQuery
+type CryptoAddressMatch struct {
+	search.SearchedEntity[search.Value]
+
+	CryptoAddress search.CryptoAddress `json:"cryptoAddress"`
+}
+
+// cryptoIndex maps normalized addresses to the entities listing them
+type cryptoIndex map[string][]cryptoRef
+
+type cryptoRef struct {
+	entity  int // index into service.entities
+	address search.CryptoAddress
+}
+
+func newCryptoIndex(entities []search.Entity[search.Value]) cryptoIndex {
+	return buildPostings(entities, cryptoRef.position, cryptoEntries)
+}
+
+func (x cryptoIndex) update(changes positionChanges) cryptoIndex {
+	return updatePostings(x, changes, cryptoRef.position, cryptoEntries)
+}
+
+func cryptoEntries(idx int, entity search.Entity[search.Value], fn func(string, cryptoRef)) {
+	for _, addr := range entity.CryptoAddresses {
+		if key := normalizeCryptoAddress(addr.Address); key != "" {
+			fn(key, cryptoRef{entity: idx, address: addr})
+		}
+	}
+}
+
+func (ref cryptoRef) position() int {
+	return ref.entity
+}
+
+// normalizeCryptoAddress trims an address and lowercases the formats which aren't case sensitive. Hex addresses
+// (0x...) are written with mixed case checksums and bech32 (bc1...) or cashaddr addresses can be written in
+// either case, but base58 addresses such as legacy Bitcoin addresses are compared exactly.
+func normalizeCryptoAddress(address string) string {
+	address = strings.TrimSpace(address)
+
+	lower := strings.ToLower(address)
+	if strings.HasPrefix(lower, "0x") {
+		return lower
+	}
+	for _, prefix := range caseInsensitivePrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return lower
+		}
+	}
+	return address
+}
+
+var caseInsensitivePrefixes = []string{
+	"bc1",          // Bitcoin
+	"ltc1",         // Litecoin
+	"bitcoincash:", // Bitcoin Cash
+}
+
+func (s *service) SearchCryptoAddress(ctx context.Context, query CryptoAddressQuery) ([]CryptoAddressMatch, error) {
+	key := normalizeCryptoAddress(query.Address)
+	if key == "" {
+		return nil, errors.New("missing address")
+	}
+
+	current := s.current.Load()
+
+	var out []CryptoAddressMatch
+	seen := make(map[int]bool)
+	for _, ref := range current.crypto[key] {
+		if seen[ref.entity] {
+			continue // entities can list an address under multiple currencies
+		}
+		if query.Currency != "" && !strings.EqualFold(query.Currency, ref.address.Currency) {
+			continue
+		}
+		seen[ref.entity] = true
+
+		match := CryptoAddressMatch{
+			CryptoAddress: ref.address,
+		}
+		match.Entity = current.entities[ref.entity]
+		match.Match = exactIdentifierMatch
+		out = append(out, match)
+	}
+
+	slices.SortFunc(out, func(a, b CryptoAddressMatch) int {
+		if c := strings.Compare(string(a.Source), string(b.Source)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.SourceID, b.SourceID)
+	})
+
+	if query.Limit > 0 && len(out) > query.Limit {
+		out = out[:query.Limit]
+	}
+	return out, nil
+}
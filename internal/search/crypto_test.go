@@ -0,0 +1,72 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeCryptoAddress(t *testing.T) {
+	require.Equal(t, "0x098b716b8aaf21512996dc57eb0615e2383e2f96", normalizeCryptoAddress(" 0x098B716B8Aaf21512996dC57EB0615e2383E2f96"))
+	require.Equal(t, "bc1qsxwkhm3jm9tyvkpgwqfx7tcsxxrt7ll8pyk3jk", normalizeCryptoAddress("BC1QSXWKHM3JM9TYVKPGWQFX7TCSXXRT7LL8PYK3JK"))
+
+	// base58 addresses are case sensitive
+	require.Equal(t, "12QtD5BFwRsdNsAZY76UVE1xyCGNTojH9h", normalizeCryptoAddress("12QtD5BFwRsdNsAZY76UVE1xyCGNTojH9h"))
+}
+
+func TestService_SearchCryptoAddress(t *testing.T) {
+	ctx := context.Background()
+	svc := testService(t)
+
+	// 25308 YAN, Xiaobing lists "Digital Currency Address - XBT 12QtD5BFwRsdNsAZY76UVE1xyCGNTojH9h"
+	results, err := svc.SearchCryptoAddress(ctx, CryptoAddressQuery{Address: "12QtD5BFwRsdNsAZY76UVE1xyCGNTojH9h"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "25308", results[0].SourceID)
+	require.Equal(t, "XBT", results[0].CryptoAddress.Currency)
+	require.InDelta(t, 1.0, results[0].Match, 0.001)
+
+	results, err = svc.SearchCryptoAddress(ctx, CryptoAddressQuery{Address: "12qtd5bfwrsdnsazy76uve1xycgntojh9h"})
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	results, err = svc.SearchCryptoAddress(ctx, CryptoAddressQuery{Address: "12QtD5BFwRsdNsAZY76UVE1xyCGNTojH9h", Currency: "eth"})
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	// 27307 LAZARUS GROUP, whose Ethereum address is searched without its checksum
+	results, err = svc.SearchCryptoAddress(ctx, CryptoAddressQuery{Address: "0x098b716b8aaf21512996dc57eb0615e2383e2f96", Currency: "ETH"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "27307", results[0].SourceID)
+
+	_, err = svc.SearchCryptoAddress(ctx, CryptoAddressQuery{})
+	require.ErrorContains(t, err, "missing address")
+}
+
+func TestAPI_searchCryptoAddress(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	req := httptest.NewRequest("GET", "/v2/search/crypto?address=12QtD5BFwRsdNsAZY76UVE1xyCGNTojH9h&currency=XBT", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp cryptoSearchResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Entities, 1)
+	require.Equal(t, "25308", resp.Entities[0].SourceID)
+	require.Equal(t, "Xiaobing YAN", resp.Entities[0].Name)
+
+	req = httptest.NewRequest("GET", "/v2/search/crypto", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,149 @@
+package search
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// SortOrder is how search results are ordered
+type SortOrder string
+
+const (
+	// SortByScore orders results by their match, highest first. It's the default.
+	SortByScore SortOrder = "score"
+
+	// SortByName orders results alphabetically by name
+	SortByName SortOrder = "name"
+
+	// SortByList groups results by their source list, ordered by match within each list
+	SortByList SortOrder = "list"
+)
+
+const (
+	// maxSortedResults is how many of the highest scoring results are ordered by name or list
+	maxSortedResults = 1000
+)
+
+func ParseSortOrder(v string) (SortOrder, error) {
+	switch order := SortOrder(strings.ToLower(strings.TrimSpace(v))); order {
+	case "":
+		return SortByScore, nil
+	case SortByScore, SortByName, SortByList:
+		return order, nil
+	}
+	return "", fmt.Errorf("unknown sort %q", v)
+}
+
+// Cursor marks the last result of a page, which the next page of results starts after.
+// Cursors stay valid while the lists are refreshed since they don't rely on a result's position.
+type Cursor struct {
+	Sort SortOrder `json:"sort"`
+
+	Match    float64 `json:"match"`
+	Name     string  `json:"name"`
+	Source   string  `json:"source"`
+	SourceID string  `json:"sourceID"`
+}
+
+// CursorFor returns the cursor which pages after result
+func CursorFor(order SortOrder, result search.SearchedEntity[search.Value]) Cursor {
+	return cursorOf(order, result.Entity, result.Match)
+}
+
+func cursorOf(order SortOrder, entity search.Entity[search.Value], match float64) Cursor {
+	return Cursor{
+		Sort:     order,
+		Match:    match,
+		Name:     strings.ToLower(entity.Name),
+		Source:   string(entity.Source),
+		SourceID: entity.SourceID,
+	}
+}
+
+func (c Cursor) Encode() string {
+	bs, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(bs)
+}
+
+func ParseCursor(v string) (*Cursor, error) {
+	bs, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(v))
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	var out Cursor
+	if err := json.Unmarshal(bs, &out); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	if _, err := ParseSortOrder(string(out.Sort)); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	return &out, nil
+}
+
+// nextPage returns the first limit of results and the encoded cursor of the next page, which is empty when there
+// are no more results. Re-ranked results aren't in the order cursors page through, so the result left out is the
+// last of them in that order, and the cursor marks the last of the page in that order.
+func nextPage(order SortOrder, results []search.SearchedEntity[search.Value], limit int) ([]search.SearchedEntity[search.Value], string) {
+	if len(results) <= limit {
+		return results, ""
+	}
+	last := func(results []search.SearchedEntity[search.Value]) int {
+		idx := 0
+		for i := range results {
+			if order.before(CursorFor(order, results[idx]), CursorFor(order, results[i])) {
+				idx = i
+			}
+		}
+		return idx
+	}
+	page := slices.Clone(results)
+	for len(page) > limit {
+		idx := last(page)
+		page = slices.Delete(page, idx, idx+1)
+	}
+	return page, CursorFor(order, page[last(page)]).Encode()
+}
+
+// before reports if a is ordered ahead of b. Ties are broken by source list and ID so results have one order.
+func (order SortOrder) before(a, b Cursor) bool {
+	switch order {
+	case SortByName:
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+	case SortByList:
+		if a.Source != b.Source {
+			return a.Source < b.Source
+		}
+	}
+	if a.Match != b.Match {
+		return a.Match > b.Match
+	}
+	if a.Source != b.Source {
+		return a.Source < b.Source
+	}
+	return a.SourceID < b.SourceID
+}
+
+// sortResults orders results, returning up to limit of those after the cursor
+func sortResults(order SortOrder, results []search.SearchedEntity[search.Value], after *Cursor, limit int) []search.SearchedEntity[search.Value] {
+	sort.Slice(results, func(i, j int) bool {
+		return order.before(CursorFor(order, results[i]), CursorFor(order, results[j]))
+	})
+	if after != nil {
+		start := sort.Search(len(results), func(i int) bool {
+			return order.before(*after, CursorFor(order, results[i]))
+		})
+		results = results[start:]
+	}
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
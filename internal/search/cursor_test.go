@@ -0,0 +1,69 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_SearchPages(t *testing.T) {
+	ctx := context.Background()
+	svc := testService(t)
+
+	query := search.Entity[search.Value]{
+		Name: "SHIPPING",
+		Type: search.EntityBusiness,
+	}
+
+	for _, order := range []SortOrder{SortByScore, SortByName, SortByList} {
+		t.Run(string(order), func(t *testing.T) {
+			opts := SearchOpts{Limit: 30, MinMatch: 0.5, Sort: order}
+			all, err := svc.Search(ctx, query, opts)
+			require.NoError(t, err)
+			require.Len(t, all, 30)
+
+			// Paging through the results returns the same results in the same order
+			var paged []search.SearchedEntity[search.Value]
+			opts.Limit = 7
+			for len(paged) < len(all) {
+				page, err := svc.Search(ctx, query, opts)
+				require.NoError(t, err)
+				require.NotEmpty(t, page)
+
+				paged = append(paged, page...)
+
+				after := CursorFor(order, page[len(page)-1])
+				opts.After = &after
+			}
+			require.Equal(t, all, paged[:len(all)])
+
+			for i := 1; i < len(all); i++ {
+				require.True(t, order.before(CursorFor(order, all[i-1]), CursorFor(order, all[i])))
+			}
+		})
+	}
+}
+
+func TestCursor(t *testing.T) {
+	cursor := Cursor{Sort: SortByName, Match: 0.8731, Name: "acme corp", Source: "us_ofac", SourceID: "123"}
+
+	parsed, err := ParseCursor(cursor.Encode())
+	require.NoError(t, err)
+	require.Equal(t, cursor, *parsed)
+
+	_, err = ParseCursor("not a cursor")
+	require.ErrorContains(t, err, "invalid cursor")
+
+	_, err = ParseCursor(Cursor{Sort: "other"}.Encode())
+	require.ErrorContains(t, err, "unknown sort")
+
+	order, err := ParseSortOrder("")
+	require.NoError(t, err)
+	require.Equal(t, SortByScore, order)
+
+	_, err = ParseSortOrder("date")
+	require.ErrorContains(t, err, `unknown sort "date"`)
+}
@@ -0,0 +1,87 @@
+package search
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// exactIndex maps the normalized names and alternate names of entities to their positions, so a query written
+// the same way as an entity's name only scores the entities with that name
+type exactIndex struct {
+	names map[string][]int // index into service.entities
+}
+
+func newExactIndex(entities []search.Entity[search.Value]) exactIndex {
+	return exactIndex{
+		names: buildPostings(entities, positionOf, exactEntries),
+	}
+}
+
+func (x exactIndex) update(changes positionChanges) exactIndex {
+	return exactIndex{
+		names: updatePostings(x.names, changes, positionOf, exactEntries),
+	}
+}
+
+func exactEntries(idx int, entity search.Entity[search.Value], fn func(string, int)) {
+	for _, key := range entityNameKeys(entity) {
+		fn(key, idx)
+	}
+}
+
+// candidates returns the entities whose name or an alternate name has key, see nameKey
+func (x exactIndex) candidates(key string) []int {
+	return x.names[key]
+}
+
+// nameKey normalizes a name so names differing only by case, punctuation, accents or the order of their
+// words ("MADURO MOROS, Nicolás" and "Nicolas Maduro Moros") are the same
+func nameKey(name string) string {
+	words := strings.Fields(prepare.LowerAndRemovePunctuation(name))
+	slices.Sort(words)
+	return strings.Join(words, " ")
+}
+
+// entityNames returns an entity's name followed by the alternate names of its type
+func entityNames(entity search.Entity[search.Value]) []string {
+	names := []string{entity.Name}
+	switch {
+	case entity.Person != nil:
+		names = append(names, entity.Person.AltNames...)
+	case entity.Business != nil:
+		names = append(names, entity.Business.AltNames...)
+	case entity.Organization != nil:
+		names = append(names, entity.Organization.AltNames...)
+	case entity.Aircraft != nil:
+		names = append(names, entity.Aircraft.AltNames...)
+	case entity.Vessel != nil:
+		names = append(names, entity.Vessel.AltNames...)
+	}
+	return names
+}
+
+// NormalizedNames returns an entity's name and alternate names as they're compared by exact and partial
+// matches, lowercased and transliterated without punctuation
+func NormalizedNames(entity search.Entity[search.Value]) []string {
+	var out []string
+	for _, name := range entityNames(entity) {
+		if normalized := normalizeName(name); normalized != "" && !slices.Contains(out, normalized) {
+			out = append(out, normalized)
+		}
+	}
+	return out
+}
+
+// entityNameKeys returns the keys of an entity's name and alternate names
+func entityNameKeys(entity search.Entity[search.Value]) []string {
+	var out []string
+	for _, name := range entityNames(entity) {
+		if key := nameKey(name); key != "" && !slices.Contains(out, key) {
+			out = append(out, key)
+		}
+	}
+	return out
+}
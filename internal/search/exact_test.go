@@ -0,0 +1,82 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNameKey(t *testing.T) {
+	require.Equal(t, "maduro moros nicolas", nameKey("MADURO MOROS, Nicolás"))
+	require.Equal(t, nameKey("Nicolas Maduro Moros"), nameKey("  nicolas   MADURO moros "))
+	require.NotEqual(t, nameKey("Nicolas Maduro"), nameKey("Nicolas Maduro Moros"))
+	require.Empty(t, nameKey(" , "))
+}
+
+func TestExactIndex(t *testing.T) {
+	entities := linkedEntities()
+	exact := newExactIndex(entities)
+
+	found := exact.candidates(nameKey("moros nicolas maduro"))
+	require.Len(t, found, 5)
+	for _, idx := range found {
+		require.Equal(t, nameKey("Nicolas Maduro Moros"), nameKey(entities[idx].Name))
+	}
+	require.Empty(t, exact.candidates(nameKey("Nicolas Maduro")))
+}
+
+func TestService_SearchExactFirst(t *testing.T) {
+	ctx := context.Background()
+
+	svc := NewService(log.NewTestLogger())
+	svc.UpdateEntities(linkedEntities())
+
+	query := search.Entity[search.Value]{
+		Name: "STAR OCEAN",
+		Type: search.EntityVessel,
+	}
+	results, err := svc.Search(ctx, query, SearchOpts{Limit: 10, MinMatch: 0.01})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	// Only the vessel written the same way is scored
+	query.Name = "ocean star"
+	results, err = svc.Search(ctx, query, SearchOpts{Limit: 10, MinMatch: 0.01, ExactFirst: true})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "v-1", results[0].SourceID)
+
+	// Every entity is scored when none has exactly the query's name
+	query = search.Entity[search.Value]{
+		Name:   "Acme Shipping",
+		Type:   search.EntityBusiness,
+		Source: search.SourceAPIRequest,
+	}
+	results, err = svc.Search(ctx, query, SearchOpts{Limit: 10, MinMatch: 0.5, ExactFirst: true})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	// Filters apply to the exact matches
+	query = search.Entity[search.Value]{
+		Name: "Nicolas Maduro Moros",
+		Type: search.EntityPerson,
+	}
+	results, err = svc.Search(ctx, query, SearchOpts{Limit: 10, MinMatch: 0.01, ExactFirst: true})
+	require.NoError(t, err)
+	require.Len(t, results, 5)
+
+	results, err = svc.Search(ctx, query, SearchOpts{
+		Limit:      10,
+		MinMatch:   0.01,
+		ExactFirst: true,
+		Filters:    SearchFilters{Types: []search.EntityType{search.EntityBusiness}},
+	})
+	require.NoError(t, err)
+	for _, res := range results {
+		require.Equal(t, search.EntityBusiness, res.Type)
+	}
+}
@@ -0,0 +1,344 @@
+package search
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// SearchFilters restricts a search to entities on any of the Programs, in any of the Countries, of any of
+// the Types, on any of the Lists (e.g. "SSI" or "NS-CMIC") and from any of the Sources (e.g. us_ofac or uk_csl).
+// Empty filters match every entity.
+//
+// Sectoral, when set, restricts a search to entities which are (or aren't) only on the Sectoral Sanctions
+// Identifications (SSI) list. PEP, when set, restricts a search to politically exposed persons (or everyone else),
+// and Wanted to persons wanted by law enforcement, such as with an Interpol Red Notice (or everyone else).
+type SearchFilters struct {
+	Programs  []string
+	Countries []string
+	Types     []search.EntityType
+	Lists     []string
+	Sources   []search.SourceList
+	Sectoral  *bool
+	PEP       *bool
+	Wanted    *bool
+}
+
+func (f SearchFilters) Empty() bool {
+	return len(f.Programs) == 0 && len(f.Countries) == 0 && len(f.Types) == 0 && len(f.Lists) == 0 && len(f.Sources) == 0 && f.Sectoral == nil && f.PEP == nil && f.Wanted == nil
+}
+
+// ParseEntityTypes reads entity types along with the names lists use for them, such as individual and entity
+func ParseEntityTypes(values []string) ([]search.EntityType, error) {
+	var out []search.EntityType
+	for _, v := range values {
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "":
+			continue
+		case "person", "individual":
+			out = append(out, search.EntityPerson)
+		case "business", "company":
+			out = append(out, search.EntityBusiness)
+		case "entity":
+			out = append(out, search.EntityBusiness, search.EntityOrganization)
+		case "organization":
+			out = append(out, search.EntityOrganization)
+		case "aircraft":
+			out = append(out, search.EntityAircraft)
+		case "vessel":
+			out = append(out, search.EntityVessel)
+		default:
+			return nil, fmt.Errorf("unknown entity type %q", v)
+		}
+	}
+	return out, nil
+}
+
+// filterIndex maps each program, country, entity type, list, source and flag to the entities which have it
+type filterIndex struct {
+	programs  map[string][]int // index into service.entities
+	countries map[string][]int
+	types     map[search.EntityType][]int
+	lists     map[string][]int
+	sources   map[search.SourceList][]int
+	sectoral  map[bool][]int
+	pep       map[bool][]int
+	wanted    map[bool][]int
+}
+
+func newFilterIndex(entities []search.Entity[search.Value]) filterIndex {
+	return filterIndex{
+		programs:  buildPostings(entities, positionOf, eachValue(entityPrograms)),
+		countries: buildPostings(entities, positionOf, eachValue(entityCountries)),
+		types:     buildPostings(entities, positionOf, oneValue(entityType)),
+		lists:     buildPostings(entities, positionOf, eachValue(entityLists)),
+		sources:   buildPostings(entities, positionOf, oneValue(entitySource)),
+		sectoral:  buildPostings(entities, positionOf, oneValue(isSectoral)),
+		pep:       buildPostings(entities, positionOf, oneValue(isPEP)),
+		wanted:    buildPostings(entities, positionOf, oneValue(isWanted)),
+	}
+}
+
+func (x filterIndex) update(changes positionChanges) filterIndex {
+	return filterIndex{
+		programs:  updatePostings(x.programs, changes, positionOf, eachValue(entityPrograms)),
+		countries: updatePostings(x.countries, changes, positionOf, eachValue(entityCountries)),
+		types:     updatePostings(x.types, changes, positionOf, oneValue(entityType)),
+		lists:     updatePostings(x.lists, changes, positionOf, eachValue(entityLists)),
+		sources:   updatePostings(x.sources, changes, positionOf, oneValue(entitySource)),
+		sectoral:  updatePostings(x.sectoral, changes, positionOf, oneValue(isSectoral)),
+		pep:       updatePostings(x.pep, changes, positionOf, oneValue(isPEP)),
+		wanted:    updatePostings(x.wanted, changes, positionOf, oneValue(isWanted)),
+	}
+}
+
+// eachValue returns the entries of a filter with values, which an entity can have several of
+func eachValue[K comparable](values func(search.Entity[search.Value]) []K) entriesFunc[K, int] {
+	return func(idx int, entity search.Entity[search.Value], fn func(K, int)) {
+		for _, value := range values(entity) {
+			fn(value, idx)
+		}
+	}
+}
+
+// oneValue returns the entries of a filter with the one value of each entity
+func oneValue[K comparable](value func(search.Entity[search.Value]) K) entriesFunc[K, int] {
+	return func(idx int, entity search.Entity[search.Value], fn func(K, int)) {
+		fn(value(entity), idx)
+	}
+}
+
+func entityType(entity search.Entity[search.Value]) search.EntityType {
+	return entity.Type
+}
+
+func entitySource(entity search.Entity[search.Value]) search.SourceList {
+	return entity.Source
+}
+
+func isPEP(entity search.Entity[search.Value]) bool {
+	return entity.PEP != nil
+}
+
+func isWanted(entity search.Entity[search.Value]) bool {
+	return entity.Wanted != nil
+}
+
+// candidates returns the positions of entities matching filters, in ascending order
+func (idx filterIndex) candidates(filters SearchFilters) []int {
+	var out []int
+	var narrowed bool
+	narrow := func(positions []int) {
+		if !narrowed {
+			out, narrowed = positions, true
+			return
+		}
+		out = intersect(out, positions)
+	}
+
+	if len(filters.Programs) > 0 {
+		narrow(lookup(idx.programs, filters.Programs, normalizeProgram))
+	}
+	if len(filters.Countries) > 0 {
+		narrow(lookup(idx.countries, filters.Countries, normalizeCountry))
+	}
+	if len(filters.Types) > 0 {
+		narrow(lookup(idx.types, filters.Types, func(t search.EntityType) search.EntityType { return t }))
+	}
+	if len(filters.Lists) > 0 {
+		narrow(lookup(idx.lists, filters.Lists, normalizeProgram))
+	}
+	if len(filters.Sources) > 0 {
+		narrow(lookup(idx.sources, filters.Sources, normalizeSource))
+	}
+	if filters.Sectoral != nil {
+		narrow(idx.sectoral[*filters.Sectoral])
+	}
+	if filters.PEP != nil {
+		narrow(idx.pep[*filters.PEP])
+	}
+	if filters.Wanted != nil {
+		narrow(idx.wanted[*filters.Wanted])
+	}
+	if out == nil {
+		out = []int{}
+	}
+	return out
+}
+
+// matches checks an entity which isn't indexed, such as those of a tenant, against filters
+func (f SearchFilters) matches(entity search.Entity[search.Value]) bool {
+	if len(f.Programs) > 0 && !overlaps(entityPrograms(entity), f.Programs, normalizeProgram) {
+		return false
+	}
+	if len(f.Countries) > 0 && !overlaps(entityCountries(entity), f.Countries, normalizeCountry) {
+		return false
+	}
+	if len(f.Types) > 0 && !slices.Contains(f.Types, entity.Type) {
+		return false
+	}
+	if len(f.Lists) > 0 && !overlaps(entityLists(entity), f.Lists, normalizeProgram) {
+		return false
+	}
+	if len(f.Sources) > 0 && !slices.ContainsFunc(f.Sources, func(source search.SourceList) bool {
+		return normalizeSource(source) == entity.Source
+	}) {
+		return false
+	}
+	if f.Sectoral != nil && *f.Sectoral != isSectoral(entity) {
+		return false
+	}
+	if f.PEP != nil && *f.PEP != (entity.PEP != nil) {
+		return false
+	}
+	if f.Wanted != nil && *f.Wanted != (entity.Wanted != nil) {
+		return false
+	}
+	return true
+}
+
+// ParseSourceLists reads the source lists of entities, such as us_ofac. Names are case insensitive.
+func ParseSourceLists(values []string) []search.SourceList {
+	var out []search.SourceList
+	for _, v := range values {
+		if source := normalizeSource(search.SourceList(v)); source != "" && !slices.Contains(out, source) {
+			out = append(out, source)
+		}
+	}
+	return out
+}
+
+func normalizeSource(source search.SourceList) search.SourceList {
+	return search.SourceList(strings.ToLower(strings.TrimSpace(string(source))))
+}
+
+// lookup returns the sorted union of positions for each value
+func lookup[K comparable](index map[K][]int, values []K, normalize func(K) K) []int {
+	var out []int
+	for _, v := range values {
+		out = append(out, index[normalize(v)]...)
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+func intersect(a, b []int) []int {
+	out := make([]int, 0, len(a))
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	return out
+}
+
+// union returns the positions in either a or b, which are both in ascending order
+func union(a, b []int) []int {
+	if len(b) == 0 {
+		return a
+	}
+	out := make([]int, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] < b[j]:
+			out = append(out, a[i])
+			i++
+		case a[i] > b[j]:
+			out = append(out, b[j])
+			j++
+		default:
+			out = append(out, a[i])
+			i++
+			j++
+		}
+	}
+	out = append(out, a[i:]...)
+	return append(out, b[j:]...)
+}
+
+func overlaps(indexed, values []string, normalize func(string) string) bool {
+	for _, v := range values {
+		if slices.Contains(indexed, normalize(v)) {
+			return true
+		}
+	}
+	return false
+}
+
+func entityPrograms(entity search.Entity[search.Value]) []string {
+	if entity.SanctionsInfo == nil {
+		return nil
+	}
+	var out []string
+	for _, program := range entity.SanctionsInfo.Programs {
+		if program = normalizeProgram(program); program != "" && !slices.Contains(out, program) {
+			out = append(out, program)
+		}
+	}
+	return out
+}
+
+// entityLists returns the lists of an entity, which are compared like programs
+func entityLists(entity search.Entity[search.Value]) []string {
+	if entity.SanctionsInfo == nil {
+		return nil
+	}
+	var out []string
+	for _, list := range entity.SanctionsInfo.Lists {
+		if list = normalizeProgram(list); list != "" && !slices.Contains(out, list) {
+			out = append(out, list)
+		}
+	}
+	return out
+}
+
+func isSectoral(entity search.Entity[search.Value]) bool {
+	return entity.SanctionsInfo != nil && entity.SanctionsInfo.Sectoral
+}
+
+// entityCountries returns the countries of an entity's addresses, government IDs and flag
+func entityCountries(entity search.Entity[search.Value]) []string {
+	var out []string
+	add := func(country string) {
+		if country = normalizeCountry(country); country != "" && !slices.Contains(out, country) {
+			out = append(out, country)
+		}
+	}
+	for _, addr := range entity.Addresses {
+		add(addr.Country)
+	}
+	for _, id := range governmentIDs(entity) {
+		add(id.Country)
+	}
+	if entity.Vessel != nil {
+		add(entity.Vessel.Flag)
+	}
+	if entity.Aircraft != nil {
+		add(entity.Aircraft.Flag)
+	}
+	return out
+}
+
+func normalizeProgram(program string) string {
+	return strings.ToUpper(strings.TrimSpace(program))
+}
+
+// normalizeCountry returns the ISO-3166 alpha-2 code of a country's name or code, otherwise
+// the uppercased input
+func normalizeCountry(country string) string {
+	country = strings.TrimSpace(country)
+	if code, found := search.CountryCode(country); found {
+		return code
+	}
+	return strings.ToUpper(country)
+}
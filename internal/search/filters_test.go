@@ -0,0 +1,231 @@
+package search
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_SearchFilters(t *testing.T) {
+	ctx := context.Background()
+	svc := testService(t)
+
+	query := search.Entity[search.Value]{
+		Name: "SHIPPING",
+		Type: search.EntityBusiness,
+	}
+	find := func(filters SearchFilters) []search.SearchedEntity[search.Value] {
+		t.Helper()
+
+		results, err := svc.Search(ctx, query, SearchOpts{Limit: 25, MinMatch: 0.01, Filters: filters})
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		return results
+	}
+
+	for _, res := range find(SearchFilters{Programs: []string{"iran", "SDGT"}}) {
+		programs := entityPrograms(res.Entity)
+		require.True(t, slices.Contains(programs, "IRAN") || slices.Contains(programs, "SDGT"), programs)
+	}
+	for _, res := range find(SearchFilters{Countries: []string{"Iran"}}) {
+		require.Contains(t, entityCountries(res.Entity), "IR")
+	}
+	for _, res := range find(SearchFilters{Programs: []string{"IRAN"}, Types: []search.EntityType{search.EntityBusiness}}) {
+		require.Equal(t, search.EntityBusiness, res.Entity.Type)
+		require.Contains(t, entityPrograms(res.Entity), "IRAN")
+	}
+
+	results, err := svc.Search(ctx, query, SearchOpts{Limit: 25, Filters: SearchFilters{Programs: []string{"OTHER"}}})
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	// Changed entities are filtered
+	svc.ApplyChanges(EntityChanges{
+		Added: []search.Entity[search.Value]{
+			{
+				Name: "Shipping Example", Type: search.EntityBusiness, Source: search.SourceUSCSL, SourceID: "csl-1",
+				Business:      &search.Business{Name: "Shipping Example"},
+				Addresses:     []search.Address{{Country: "United Kingdom"}},
+				SanctionsInfo: &search.SanctionsInfo{Programs: []string{"OTHER"}},
+			},
+		},
+	})
+	results = find(SearchFilters{Programs: []string{"other"}, Countries: []string{"GB"}})
+	require.Len(t, results, 1)
+	require.Equal(t, "csl-1", results[0].SourceID)
+
+	// Only the entities of some source lists are searched
+	results = find(SearchFilters{Sources: []search.SourceList{"US_CSL"}})
+	require.Len(t, results, 1)
+	require.Equal(t, "csl-1", results[0].SourceID)
+
+	for _, res := range find(SearchFilters{Sources: []search.SourceList{search.SourceUSOFAC, search.SourceUKCSL}}) {
+		require.Equal(t, search.SourceUSOFAC, res.Source)
+	}
+}
+
+func TestService_SearchFilters_Sectoral(t *testing.T) {
+	ctx := context.Background()
+	svc := testService(t)
+
+	query := search.Entity[search.Value]{
+		Name: "SHIPPING",
+		Type: search.EntityBusiness,
+	}
+	sectoral, blocked := true, false
+
+	// No SSI entities are in the test data
+	results, err := svc.Search(ctx, query, SearchOpts{Limit: 25, MinMatch: 0.01, Filters: SearchFilters{Sectoral: &sectoral}})
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	svc.ApplyChanges(EntityChanges{
+		Added: []search.Entity[search.Value]{
+			{
+				Name: "Shipping Example", Type: search.EntityBusiness, Source: search.SourceUSCSL, SourceID: "csl-1",
+				Business:      &search.Business{Name: "Shipping Example"},
+				SanctionsInfo: &search.SanctionsInfo{Programs: []string{"UKRAINE-EO13662"}, Lists: []string{"SSI"}, Sectoral: true},
+			},
+		},
+	})
+	results, err = svc.Search(ctx, query, SearchOpts{Limit: 25, MinMatch: 0.01, Filters: SearchFilters{Sectoral: &sectoral}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "csl-1", results[0].SourceID)
+
+	results, err = svc.Search(ctx, query, SearchOpts{Limit: 25, MinMatch: 0.01, Filters: SearchFilters{Lists: []string{"ssi", "NS-CMIC"}}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "csl-1", results[0].SourceID)
+
+	results, err = svc.Search(ctx, query, SearchOpts{Limit: 25, MinMatch: 0.01, Filters: SearchFilters{Sectoral: &blocked}})
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	for _, res := range results {
+		require.False(t, isSectoral(res.Entity))
+	}
+}
+
+func TestService_SearchFilters_PEP(t *testing.T) {
+	ctx := context.Background()
+	svc := testService(t)
+
+	svc.ApplyChanges(EntityChanges{
+		Added: []search.Entity[search.Value]{
+			{
+				Name: "Nicolas Maduro", Type: search.EntityPerson, Source: search.SourceOpenSanctions, SourceID: "Q57413",
+				Person: &search.Person{Name: "Nicolas Maduro"},
+				PEP:    &search.PEPInfo{Positions: []string{"President of Venezuela"}},
+			},
+		},
+	})
+	query := search.Entity[search.Value]{
+		Name: "Nicolas Maduro",
+		Type: search.EntityPerson,
+	}
+	pep, others := true, false
+
+	results, err := svc.Search(ctx, query, SearchOpts{Limit: 25, MinMatch: 0.01, Filters: SearchFilters{PEP: &pep}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "Q57413", results[0].SourceID)
+
+	results, err = svc.Search(ctx, query, SearchOpts{Limit: 25, MinMatch: 0.01, Filters: SearchFilters{PEP: &others}})
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	for _, res := range results {
+		require.Nil(t, res.Entity.PEP)
+	}
+}
+
+func TestService_SearchFilters_Wanted(t *testing.T) {
+	ctx := context.Background()
+	svc := testService(t)
+
+	svc.ApplyChanges(EntityChanges{
+		Added: []search.Entity[search.Value]{
+			{
+				Name: "Jose Adan Perez", Type: search.EntityPerson, Source: search.SourceInterpolRed, SourceID: "2010/32437",
+				Person: &search.Person{Name: "Jose Adan Perez"},
+				Wanted: &search.WantedInfo{Authority: "Interpol", Notice: "Red Notice"},
+			},
+		},
+	})
+	query := search.Entity[search.Value]{
+		Name: "Jose Adan Perez",
+		Type: search.EntityPerson,
+	}
+	wanted, others := true, false
+
+	results, err := svc.Search(ctx, query, SearchOpts{Limit: 25, MinMatch: 0.01, Filters: SearchFilters{Wanted: &wanted}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "2010/32437", results[0].SourceID)
+
+	results, err = svc.Search(ctx, query, SearchOpts{Limit: 25, MinMatch: 0.01, Filters: SearchFilters{Wanted: &others}})
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	for _, res := range results {
+		require.Nil(t, res.Entity.Wanted)
+	}
+}
+
+func TestParseEntityTypes(t *testing.T) {
+	types, err := ParseEntityTypes([]string{"individual", "entity", "vessel"})
+	require.NoError(t, err)
+	require.Equal(t, []search.EntityType{
+		search.EntityPerson, search.EntityBusiness, search.EntityOrganization, search.EntityVessel,
+	}, types)
+
+	_, err = ParseEntityTypes([]string{"robot"})
+	require.ErrorContains(t, err, `unknown entity type "robot"`)
+}
+
+func TestParseSourceLists(t *testing.T) {
+	require.Equal(t, []search.SourceList{search.SourceUSOFAC, search.SourceUKCSL}, ParseSourceLists([]string{"US_OFAC", " uk_csl", "us_ofac", ""}))
+	require.Empty(t, ParseSourceLists(nil))
+}
+
+func TestAPI_searchFilters(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	req := httptest.NewRequest("GET", "/v2/search?name=shipping&type=business&program=SDGT,IRAN&country=IR&entityType=entity", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest("GET", "/v2/search?name=shipping&type=business&entityType=robot", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest("GET", "/v2/search?name=shipping&type=business&list=SDN,NS-CMIC&sectoral=false", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	req = httptest.NewRequest("GET", "/v2/search?name=shipping&type=business&source=us_csl,eu_csl", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"entities":null}`, w.Body.String())
+
+	req = httptest.NewRequest("GET", "/v2/search?name=shipping&type=business&pep=maybe", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	req = httptest.NewRequest("GET", "/v2/search?name=shipping&type=business&sectoral=maybe", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
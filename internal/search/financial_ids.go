@@ -0,0 +1,235 @@
+package search
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// FinancialIDMapping pairs the BICs of financial institutions with their LEIs, such as GLEIF's BIC-to-LEI
+// relationship files. Indexed entities and queries with one of them get the other as well, so a search with a
+// bank's LEI matches its listing by BIC.
+//
+// It's read from a CSV file with LEI and BIC columns, in any order and along with others, and read again when
+// the lists are refreshed after the file changes.
+type FinancialIDMapping struct {
+	path    string
+	modTime time.Time
+
+	leis map[string][]string // by BIC of 11 characters
+	bics map[string][]string // by LEI
+}
+
+// LoadFinancialIDMapping reads the mapping of a CSV file, see FinancialIDMapping
+func LoadFinancialIDMapping(path string) (*FinancialIDMapping, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading BIC/LEI mapping: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("reading BIC/LEI mapping: %w", err)
+	}
+	out, err := ReadFinancialIDMapping(f)
+	if err != nil {
+		return nil, fmt.Errorf("reading BIC/LEI mapping %s: %w", path, err)
+	}
+	out.path, out.modTime = path, info.ModTime()
+	return out, nil
+}
+
+// ReadFinancialIDMapping reads the pairs of BICs and LEIs of a CSV file. Rows without a valid BIC and LEI are skipped.
+func ReadFinancialIDMapping(r io.Reader) (*FinancialIDMapping, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	leiColumn, bicColumn := -1, -1
+	for i, name := range header {
+		switch strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))) {
+		case "LEI":
+			leiColumn = i
+		case "BIC":
+			bicColumn = i
+		}
+	}
+	if leiColumn < 0 || bicColumn < 0 {
+		return nil, errors.New("missing LEI or BIC column")
+	}
+
+	out := &FinancialIDMapping{
+		leis: make(map[string][]string),
+		bics: make(map[string][]string),
+	}
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if leiColumn >= len(record) || bicColumn >= len(record) {
+			continue
+		}
+		lei, err := search.NormalizeLEI(record[leiColumn])
+		if err != nil {
+			continue
+		}
+		bic, err := search.NormalizeBIC(record[bicColumn])
+		if err != nil {
+			continue
+		}
+		bic = bicWithBranch(bic)
+		if !slices.Contains(out.leis[bic], lei) {
+			out.leis[bic] = append(out.leis[bic], lei)
+		}
+		if !slices.Contains(out.bics[lei], bic) {
+			out.bics[lei] = append(out.bics[lei], bic)
+		}
+	}
+	if len(out.bics) == 0 {
+		return nil, errors.New("no BICs and LEIs found")
+	}
+	return out, nil
+}
+
+// bicWithBranch returns a BIC of 8 characters with the head office's branch code, "XXX"
+func bicWithBranch(bic string) string {
+	if len(bic) == 8 {
+		return bic + "XXX"
+	}
+	return bic
+}
+
+// Pairs returns how many BICs and LEIs are paired
+func (m *FinancialIDMapping) Pairs() int {
+	if m == nil {
+		return 0
+	}
+	n := 0
+	for _, bics := range m.bics {
+		n += len(bics)
+	}
+	return n
+}
+
+// reload returns the mapping read again from its file when it's been modified since it was read, or m
+func (m *FinancialIDMapping) reload() (*FinancialIDMapping, error) {
+	if m == nil || m.path == "" {
+		return m, nil
+	}
+	info, err := os.Stat(m.path)
+	if err != nil {
+		return m, fmt.Errorf("reading BIC/LEI mapping: %w", err)
+	}
+	if !info.ModTime().After(m.modTime) {
+		return m, nil
+	}
+	return LoadFinancialIDMapping(m.path)
+}
+
+// enrich adds the LEIs paired with the BICs of a business or organization, and the BICs paired with its LEIs
+func (m *FinancialIDMapping) enrich(entity search.Entity[search.Value]) search.Entity[search.Value] {
+	if m == nil {
+		return entity
+	}
+	switch {
+	case entity.Business != nil:
+		if ids, added := m.paired(entity.Business.GovernmentIDs); added {
+			business := *entity.Business
+			business.GovernmentIDs = ids
+			entity.Business = &business
+		}
+	case entity.Organization != nil:
+		if ids, added := m.paired(entity.Organization.GovernmentIDs); added {
+			org := *entity.Organization
+			org.GovernmentIDs = ids
+			entity.Organization = &org
+		}
+	}
+	return entity
+}
+
+// paired returns ids along with the BICs and LEIs paired with those in ids, and if any were added
+func (m *FinancialIDMapping) paired(ids []search.GovernmentID) ([]search.GovernmentID, bool) {
+	var found []search.GovernmentID
+	add := func(idType search.GovernmentIDType, identifier string) {
+		id := search.GovernmentID{Type: idType, Identifier: identifier}
+		if !slices.ContainsFunc(ids, func(other search.GovernmentID) bool {
+			return other.Type == idType && strings.EqualFold(bicWithBranch(other.Identifier), bicWithBranch(identifier))
+		}) && !slices.Contains(found, id) {
+			found = append(found, id)
+		}
+	}
+	for _, id := range ids {
+		switch id.Type {
+		case search.GovernmentIDSWIFT:
+			bic, err := search.NormalizeBIC(id.Identifier)
+			if err != nil {
+				continue
+			}
+			// Branches without their own LEI are part of their head office
+			leis := m.leis[bicWithBranch(bic)]
+			if len(leis) == 0 {
+				leis = m.leis[bicWithBranch(bic[:8])]
+			}
+			for _, lei := range leis {
+				add(search.GovernmentIDLEI, lei)
+			}
+		case search.GovernmentIDLEI:
+			lei, err := search.NormalizeLEI(id.Identifier)
+			if err != nil {
+				continue
+			}
+			for _, bic := range m.bics[lei] {
+				add(search.GovernmentIDSWIFT, bic)
+			}
+		}
+	}
+	if len(found) == 0 {
+		return ids, false
+	}
+	return append(slices.Clone(ids), found...), true
+}
+
+// financialCandidates returns the positions of entities listed with one of the query's BICs, or the head office
+// of one, or LEIs in ascending order. They're scored however different their names are.
+func (s *service) financialCandidates(current *index, query search.Entity[search.Value]) []int {
+	var out []int
+	for _, id := range governmentIDs(query) {
+		var keys []string
+		switch id.Type {
+		case search.GovernmentIDSWIFT:
+			if bic, err := search.NormalizeBIC(id.Identifier); err == nil {
+				keys = []string{bic, bic[:8], bic[:8] + "XXX"}
+			}
+		case search.GovernmentIDLEI:
+			if lei, err := search.NormalizeLEI(id.Identifier); err == nil {
+				keys = []string{lei}
+			}
+		}
+		for _, key := range keys {
+			for _, ref := range current.identifiers[normalizeIdentifier(key)] {
+				if ref.id.Type == id.Type {
+					out = append(out, ref.entity)
+				}
+			}
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
@@ -0,0 +1,135 @@
+package search
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadFinancialIDMapping(t *testing.T) {
+	mapping, err := ReadFinancialIDMapping(strings.NewReader("\ufeffBIC,Name,LEI\n" +
+		"fnbkus33,First National Bank of Omaha,5493001KJTIIGC8Y1R12\n" +
+		"FNBKUS33XXX,First National Bank of Omaha,5493001KJTIIGC8Y1R12\n" +
+		"DEUTDEFF,Deutsche Bank,7LTWFZYICNSX8D621K86\n" +
+		"DEUTDEFF500,Deutsche Bank,7LTWFZYICNSX8D621K86\n" +
+		"NOTABIC,Invalid,7LTWFZYICNSX8D621K86\n" +
+		"DEUTDEFF,Invalid,7LTWFZYICNSX8D621K87\n"))
+	require.NoError(t, err)
+	require.Equal(t, 3, mapping.Pairs())
+
+	ids, added := mapping.paired([]search.GovernmentID{
+		{Type: search.GovernmentIDSWIFT, Identifier: "FNBKUS33"},
+	})
+	require.True(t, added)
+	require.Equal(t, []search.GovernmentID{
+		{Type: search.GovernmentIDSWIFT, Identifier: "FNBKUS33"},
+		{Type: search.GovernmentIDLEI, Identifier: "5493001KJTIIGC8Y1R12"},
+	}, ids)
+
+	// Branches without their own pair are part of their head office
+	ids, _ = mapping.paired([]search.GovernmentID{{Type: search.GovernmentIDSWIFT, Identifier: "FNBKUS33NYC"}})
+	require.Contains(t, ids, search.GovernmentID{Type: search.GovernmentIDLEI, Identifier: "5493001KJTIIGC8Y1R12"})
+
+	ids, _ = mapping.paired([]search.GovernmentID{{Type: search.GovernmentIDLEI, Identifier: "7LTWFZYICNSX8D621K86"}})
+	require.Equal(t, []search.GovernmentID{
+		{Type: search.GovernmentIDLEI, Identifier: "7LTWFZYICNSX8D621K86"},
+		{Type: search.GovernmentIDSWIFT, Identifier: "DEUTDEFFXXX"},
+		{Type: search.GovernmentIDSWIFT, Identifier: "DEUTDEFF500"},
+	}, ids)
+
+	_, added = mapping.paired([]search.GovernmentID{{Type: search.GovernmentIDTax, Identifier: "FNBKUS33"}})
+	require.False(t, added)
+
+	_, err = ReadFinancialIDMapping(strings.NewReader("LEI,Name\n"))
+	require.ErrorContains(t, err, "missing LEI or BIC column")
+
+	_, err = ReadFinancialIDMapping(strings.NewReader("LEI,BIC\nNOTALEI,NOTABIC\n"))
+	require.ErrorContains(t, err, "no BICs and LEIs found")
+}
+
+func financialIDEntities() []search.Entity[search.Value] {
+	bank := func(sourceID, name, bic string) search.Entity[search.Value] {
+		return search.Entity[search.Value]{
+			Name:     name,
+			Type:     search.EntityBusiness,
+			Source:   search.SourceUSOFAC,
+			SourceID: sourceID,
+			Business: &search.Business{
+				Name:          name,
+				GovernmentIDs: []search.GovernmentID{{Type: search.GovernmentIDSWIFT, Identifier: bic}},
+			},
+		}
+	}
+	return []search.Entity[search.Value]{
+		bank("1", "FNB OMAHA", "FNBKUS33"),
+		bank("2", "FIRST NATIONAL BANK", "FNBAZAJJ"),
+		bank("3", "COMMERCIAL BANK OF SYRIA", "CBSYSYDA"),
+	}
+}
+
+func TestService_FinancialIDs(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "bic-lei.csv")
+	require.NoError(t, os.WriteFile(path, []byte("LEI,BIC\n5493001KJTIIGC8Y1R12,FNBKUS33XXX\n"), 0600))
+
+	mapping, err := LoadFinancialIDMapping(path)
+	require.NoError(t, err)
+
+	svc := NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{
+		MinTrigramOverlap: 0.5,
+		FinancialIDs:      mapping,
+	})
+	svc.UpdateEntities(financialIDEntities())
+
+	query, err := readSearchQuery(url.Values{
+		"name": []string{"First National Bank"},
+		"type": []string{"business"},
+		"lei":  []string{"5493001kjtiigc8y1r12"},
+	})
+	require.NoError(t, err)
+
+	// The bank listed by the BIC paired with the LEI is found, however its name is written, while the bank of
+	// the same name with another BIC is demoted below it
+	results, err := svc.Search(ctx, query, SearchOpts{Limit: 10, MinMatch: 0.01})
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	require.Equal(t, "1", results[0].SourceID)
+	require.InDelta(t, 1.0, results[0].Match, 0.001)
+	for _, result := range results[1:] {
+		require.Less(t, result.Match, 0.5, result.SourceID)
+	}
+
+	// Changes to the mapping are read when the lists are indexed again
+	require.NoError(t, os.WriteFile(path, []byte("LEI,BIC\n5493001KJTIIGC8Y1R12,FNBAZAJJXXX\n"), 0600))
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(path, later, later))
+	svc.UpdateEntities(financialIDEntities())
+
+	results, err = svc.Search(ctx, query, SearchOpts{Limit: 10, MinMatch: 0.01})
+	require.NoError(t, err)
+	require.Equal(t, "2", results[0].SourceID)
+	require.InDelta(t, 1.0, results[0].Match, 0.001)
+
+	// A malformed identifier is rejected
+	_, err = readSearchQuery(url.Values{
+		"name": []string{"First National Bank"},
+		"type": []string{"organization"},
+		"bic":  []string{"FNB"},
+	})
+	require.ErrorContains(t, err, "reading bic: BIC \"FNB\" must be 8 or 11 characters")
+}
+
+func TestUnion(t *testing.T) {
+	require.Equal(t, []int{1, 2, 3, 5, 8}, union([]int{1, 3, 5}, []int{2, 3, 8}))
+	require.Equal(t, []int{1, 3}, union([]int{1, 3}, nil))
+	require.Equal(t, []int{4}, union([]int{}, []int{4}))
+}
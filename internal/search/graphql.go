@@ -0,0 +1,649 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/graphql"
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// GraphQLSchema holds the entity types along with the search, entities and listInfo fields of the GraphQL
+// endpoint. Types have the fields of the REST API's JSON.
+const GraphQLSchema = `
+enum EntityType {
+	person
+	business
+	organization
+	aircraft
+	vessel
+}
+
+"""
+An entity of a list, as returned by /v2/search
+"""
+type Entity {
+	name: String!
+	entityType: String!
+	sourceList: String!
+	sourceID: String!
+	person: Person
+	business: Business
+	organization: Organization
+	aircraft: Aircraft
+	vessel: Vessel
+	contact: ContactInfo!
+	addresses: [Address!]!
+	cryptoAddresses: [CryptoAddress!]!
+	affiliations: [Affiliation!]!
+	sanctionsInfo: SanctionsInfo
+	historicalInfo: [HistoricalInfo!]!
+	weakAltNames: [String!]!
+	pep: PEPInfo
+	wanted: WantedInfo
+	remarks: String!
+	sourceData: JSON
+}
+
+"""
+An entity found by a search, with how well it matched
+"""
+type SearchedEntity {
+	name: String!
+	entityType: String!
+	sourceList: String!
+	sourceID: String!
+	person: Person
+	business: Business
+	organization: Organization
+	aircraft: Aircraft
+	vessel: Vessel
+	contact: ContactInfo!
+	addresses: [Address!]!
+	cryptoAddresses: [CryptoAddress!]!
+	affiliations: [Affiliation!]!
+	sanctionsInfo: SanctionsInfo
+	historicalInfo: [HistoricalInfo!]!
+	weakAltNames: [String!]!
+	pep: PEPInfo
+	wanted: WantedInfo
+	remarks: String!
+	sourceData: JSON
+
+	match: Float!
+	birthDateMatch: String!
+	altNameMatch: String!
+	nameScript: String!
+	weakAltNameMatch: String!
+	explanation: JSON
+	highlights: JSON
+	calibration: JSON
+	rerank: JSON
+	related: [RelatedEntity!]!
+}
+
+type RelatedEntity {
+	name: String!
+	sourceList: String!
+	sourceID: String!
+	match: Float!
+	linkedBy: String!
+}
+
+type Person {
+	name: String!
+	altNames: [String!]!
+	gender: String!
+	birthDate: Time
+	deathDate: Time
+	titles: [String!]!
+	birthDates: [DateRange!]!
+	placeOfBirth: String!
+	nationalities: [String!]!
+	governmentIDs: [GovernmentID!]!
+}
+
+type DateRange {
+	start: Time!
+	end: Time!
+}
+
+type GovernmentID {
+	type: String!
+	country: String!
+	identifier: String!
+}
+
+type Business {
+	name: String!
+	altNames: [String!]!
+	created: Time
+	dissolved: Time
+	governmentIDs: [GovernmentID!]!
+}
+
+type Organization {
+	name: String!
+	altNames: [String!]!
+	created: Time
+	dissolved: Time
+	governmentIDs: [GovernmentID!]!
+}
+
+type Aircraft {
+	name: String!
+	altNames: [String!]!
+	type: String!
+	flag: String!
+	built: Time
+	icaoCode: String!
+	model: String!
+	serialNumber: String!
+	tailNumber: String!
+	previousTailNumbers: [String!]!
+}
+
+type Vessel {
+	name: String!
+	altNames: [String!]!
+	imoNumber: String!
+	type: String!
+	flag: String!
+	built: Time
+	model: String!
+	tonnage: Int!
+	mmsi: String!
+	callSign: String!
+	grossRegisteredTonnage: Int!
+	owner: String!
+}
+
+type ContactInfo {
+	emailAddresses: [String!]!
+	phoneNumbers: [String!]!
+	faxNumbers: [String!]!
+	websites: [String!]!
+}
+
+type Address {
+	line1: String!
+	line2: String!
+	city: String!
+	postalCode: String!
+	state: String!
+	country: String!
+	latitude: Float!
+	longitude: Float!
+}
+
+type CryptoAddress {
+	currency: String!
+	address: String!
+}
+
+type Affiliation {
+	entityName: String!
+	type: String!
+	details: String!
+}
+
+type SanctionsInfo {
+	programs: [String!]!
+	secondary: Boolean!
+	description: String!
+	regulations: [String!]!
+	lists: [String!]!
+	programDates: JSON
+	sectoral: Boolean!
+	directives: [String!]!
+	licenseRequirement: String!
+	licensePolicy: String!
+}
+
+type PEPInfo {
+	positions: [String!]!
+	relative: Boolean!
+}
+
+type WantedInfo {
+	authority: String!
+	notice: String!
+	url: String!
+}
+
+type HistoricalInfo {
+	type: String!
+	value: String!
+	date: Time!
+}
+
+type SearchResponse {
+	entities: [SearchedEntity!]!
+	nextCursor: String
+	truncated: Boolean!
+}
+
+type ListInfo {
+	lists: JSON!
+	updatedAt: Time!
+}
+
+extend type Query {
+	"""
+	Searches the lists with the parameters of /v2/search
+	"""
+	search(
+		q: String
+		name: String
+		type: EntityType
+		altNames: [String!]
+		gender: String
+		birthDate: String
+		deathDate: String
+		titles: [String!]
+		nationality: [String!]
+		created: String
+		dissolved: String
+		aircraftType: String
+		flag: String
+		built: String
+		icaoCode: String
+		model: String
+		serialNumber: String
+		tailNumber: String
+		imoNumber: String
+		vesselType: String
+		mmsi: String
+		callSign: String
+		owner: String
+		tonnage: Int
+		grossRegisteredTonnage: Int
+		email: [String!]
+		emailAddress: [String!]
+		phone: [String!]
+		phoneNumber: [String!]
+		fax: [String!]
+		faxNumber: [String!]
+		website: [String!]
+		address: [String!]
+		cryptoAddress: [String!]
+		bic: [String!]
+		lei: [String!]
+
+		limit: Int = 10
+		minMatch: Float
+		algorithm: String
+		explain: Boolean
+		highlight: Boolean
+		consolidate: Boolean
+		exactFirst: Boolean
+		partial: Boolean
+		tenantID: String
+		profile: String
+		requestID: String
+		prepare: String
+		weights: String
+		sort: String
+		cursor: String
+		asOf: String
+
+		program: [String!]
+		country: [String!]
+		entityType: [EntityType!]
+		list: [String!]
+		source: [String!]
+		sectoral: Boolean
+		pep: Boolean
+		wanted: Boolean
+	): SearchResponse
+
+	"""
+	Returns the searched entities of some lists, or with some source IDs
+	"""
+	entities(list: [String!], sourceID: [String!], limit: Int = 10): [Entity!]
+
+	"""
+	Returns how many entities are searched from each list
+	"""
+	listInfo: ListInfo
+}
+`
+
+// GraphQLSearch resolves the search, entities and listInfo fields of GraphQLSchema
+type GraphQLSearch struct {
+	service Service
+}
+
+func NewGraphQLSearch(service Service) *GraphQLSearch {
+	return &GraphQLSearch{
+		service: service,
+	}
+}
+
+// graphqlSearchArgs are the arguments of the search field, which are encoded as the query parameters of
+// /v2/search by their JSON names
+type graphqlSearchArgs struct {
+	// query
+	Q                      *string   `json:"q,omitempty"`
+	Name                   *string   `json:"name,omitempty"`
+	Type                   *string   `json:"type,omitempty"`
+	AltNames               *[]string `json:"altNames,omitempty"`
+	Gender                 *string   `json:"gender,omitempty"`
+	BirthDate              *string   `json:"birthDate,omitempty"`
+	DeathDate              *string   `json:"deathDate,omitempty"`
+	Titles                 *[]string `json:"titles,omitempty"`
+	Nationality            *[]string `json:"nationality,omitempty"`
+	Created                *string   `json:"created,omitempty"`
+	Dissolved              *string   `json:"dissolved,omitempty"`
+	AircraftType           *string   `json:"aircraftType,omitempty"`
+	Flag                   *string   `json:"flag,omitempty"`
+	Built                  *string   `json:"built,omitempty"`
+	ICAOCode               *string   `json:"icaoCode,omitempty"`
+	Model                  *string   `json:"model,omitempty"`
+	SerialNumber           *string   `json:"serialNumber,omitempty"`
+	TailNumber             *string   `json:"tailNumber,omitempty"`
+	IMONumber              *string   `json:"imoNumber,omitempty"`
+	VesselType             *string   `json:"vesselType,omitempty"`
+	MMSI                   *string   `json:"mmsi,omitempty"`
+	CallSign               *string   `json:"callSign,omitempty"`
+	Owner                  *string   `json:"owner,omitempty"`
+	Tonnage                *int32    `json:"tonnage,omitempty"`
+	GrossRegisteredTonnage *int32    `json:"grossRegisteredTonnage,omitempty"`
+	Email                  *[]string `json:"email,omitempty"`
+	EmailAddress           *[]string `json:"emailAddress,omitempty"`
+	Phone                  *[]string `json:"phone,omitempty"`
+	PhoneNumber            *[]string `json:"phoneNumber,omitempty"`
+	Fax                    *[]string `json:"fax,omitempty"`
+	FaxNumber              *[]string `json:"faxNumber,omitempty"`
+	Website                *[]string `json:"website,omitempty"`
+	Address                *[]string `json:"address,omitempty"`
+	CryptoAddress          *[]string `json:"cryptoAddress,omitempty"`
+	BIC                    *[]string `json:"bic,omitempty"`
+	LEI                    *[]string `json:"lei,omitempty"`
+
+	// options
+	Limit       int32    `json:"limit"`
+	MinMatch    *float64 `json:"minMatch,omitempty"`
+	Algorithm   *string  `json:"algorithm,omitempty"`
+	Explain     *bool    `json:"explain,omitempty"`
+	Highlight   *bool    `json:"highlight,omitempty"`
+	Consolidate *bool    `json:"consolidate,omitempty"`
+	ExactFirst  *bool    `json:"exactFirst,omitempty"`
+	Partial     *bool    `json:"partial,omitempty"`
+	TenantID    *string  `json:"tenantID,omitempty"`
+	Profile     *string  `json:"profile,omitempty"`
+	RequestID   *string  `json:"requestID,omitempty"`
+	Prepare     *string  `json:"prepare,omitempty"`
+	Weights     *string  `json:"weights,omitempty"`
+	Sort        *string  `json:"sort,omitempty"`
+	Cursor      *string  `json:"cursor,omitempty"`
+	AsOf        *string  `json:"asOf,omitempty"`
+
+	// filters
+	Program    *[]string `json:"program,omitempty"`
+	Country    *[]string `json:"country,omitempty"`
+	EntityType *[]string `json:"entityType,omitempty"`
+	List       *[]string `json:"list,omitempty"`
+	Source     *[]string `json:"source,omitempty"`
+	Sectoral   *bool     `json:"sectoral,omitempty"`
+	PEP        *bool     `json:"pep,omitempty"`
+	Wanted     *bool     `json:"wanted,omitempty"`
+}
+
+func (args graphqlSearchArgs) values() (url.Values, error) {
+	bs, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(bs))
+	dec.UseNumber()
+
+	var fields map[string]any
+	if err := dec.Decode(&fields); err != nil {
+		return nil, err
+	}
+	values := make(url.Values, len(fields))
+	for key, v := range fields {
+		if vs, ok := v.([]any); ok {
+			for _, v := range vs {
+				values.Add(key, fmt.Sprint(v))
+			}
+			continue
+		}
+		values.Set(key, fmt.Sprint(v))
+	}
+	return values, nil
+}
+
+type graphqlSearchResponse struct {
+	Entities   []graphqlSearchedEntity
+	NextCursor *string
+	Truncated  bool
+}
+
+type graphqlEntity struct {
+	Name            string
+	EntityType      string
+	SourceList      string
+	SourceID        string
+	Person          *graphqlPerson
+	Business        *graphqlBusiness
+	Organization    *graphqlBusiness
+	Aircraft        *graphqlAircraft
+	Vessel          *graphqlVessel
+	Contact         search.ContactInfo
+	Addresses       []search.Address
+	CryptoAddresses []search.CryptoAddress
+	Affiliations    []search.Affiliation
+	SanctionsInfo   *graphqlSanctionsInfo
+	HistoricalInfo  []graphqlHistoricalInfo
+	WeakAltNames    []string
+	PEP             *search.PEPInfo
+	Wanted          *search.WantedInfo
+	Remarks         string
+	SourceData      *graphql.JSON
+}
+
+type graphqlSearchedEntity struct {
+	graphqlEntity
+
+	Match            float64
+	BirthDateMatch   string
+	AltNameMatch     string
+	NameScript       string
+	WeakAltNameMatch string
+	Explanation      *graphql.JSON
+	Highlights       *graphql.JSON
+	Calibration      *graphql.JSON
+	Rerank           *graphql.JSON
+	Related          []graphqlRelatedEntity
+}
+
+type graphqlRelatedEntity struct {
+	Name       string
+	SourceList string
+	SourceID   string
+	Match      float64
+	LinkedBy   string
+}
+
+type graphqlPerson struct {
+	Name          string
+	AltNames      []string
+	Gender        string
+	BirthDate     *graphql.Time
+	DeathDate     *graphql.Time
+	Titles        []string
+	BirthDates    []graphqlDateRange
+	PlaceOfBirth  string
+	Nationalities []string
+	GovernmentIDs []graphqlGovernmentID
+}
+
+type graphqlDateRange struct {
+	Start graphql.Time
+	End   graphql.Time
+}
+
+type graphqlGovernmentID struct {
+	Type       string
+	Country    string
+	Identifier string
+}
+
+// graphqlBusiness holds businesses and organizations, which have the same fields
+type graphqlBusiness struct {
+	Name          string
+	AltNames      []string
+	Created       *graphql.Time
+	Dissolved     *graphql.Time
+	GovernmentIDs []graphqlGovernmentID
+}
+
+type graphqlAircraft struct {
+	Name                string
+	AltNames            []string
+	Type                string
+	Flag                string
+	Built               *graphql.Time
+	ICAOCode            string
+	Model               string
+	SerialNumber        string
+	TailNumber          string
+	PreviousTailNumbers []string
+}
+
+type graphqlVessel struct {
+	Name                   string
+	AltNames               []string
+	IMONumber              string
+	Type                   string
+	Flag                   string
+	Built                  *graphql.Time
+	Model                  string
+	Tonnage                int32
+	MMSI                   string
+	CallSign               string
+	GrossRegisteredTonnage int32
+	Owner                  string
+}
+
+type graphqlSanctionsInfo struct {
+	Programs           []string
+	Secondary          bool
+	Description        string
+	Regulations        []string
+	Lists              []string
+	ProgramDates       *graphql.JSON
+	Sectoral           bool
+	Directives         []string
+	LicenseRequirement string
+	LicensePolicy      string
+}
+
+type graphqlHistoricalInfo struct {
+	Type  string
+	Value string
+	Date  graphql.Time
+}
+
+type graphqlListInfo struct {
+	Lists     graphql.JSON
+	UpdatedAt graphql.Time
+}
+
+func (g *GraphQLSearch) Search(ctx context.Context, args graphqlSearchArgs) (*graphqlSearchResponse, error) {
+	values, err := args.values()
+	if err != nil {
+		return nil, err
+	}
+	values, err = expandQuery(values)
+	if err != nil {
+		return nil, err
+	}
+	query, err := readSearchQuery(values)
+	if err != nil {
+		return nil, err
+	}
+
+	// Options are read as they are for /v2/search, including the X-Tenant-ID header of the request
+	r, err := http.NewRequestWithContext(ctx, "GET", "/v2/search?"+values.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if req := graphql.HTTPRequest(ctx); req != nil {
+		r.Header = req.Header
+	}
+	opts, err := readSearchOpts(r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Search for one extra result to know if there's another page
+	limit := opts.Limit
+	opts.Limit += 1
+
+	entities, err := g.service.Search(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	var resp searchResponse
+	entities, resp.NextCursor = nextPage(opts.Sort, entities, limit)
+	resp.Entities = entities
+
+	out, err := graphql.Convert[graphqlSearchResponse](resp)
+	return &out, err
+}
+
+func (g *GraphQLSearch) Entities(args struct {
+	List     *[]string
+	SourceID *[]string
+	Limit    int32
+}) (*[]graphqlEntity, error) {
+	limit := int(args.Limit)
+	if limit <= 0 {
+		limit = softResultsLimit
+	}
+	if limit > hardResultsLimit {
+		limit = hardResultsLimit
+	}
+
+	lists := make(map[string]bool)
+	if args.List != nil {
+		for _, list := range *args.List {
+			lists[strings.ToLower(list)] = true
+		}
+	}
+	sourceIDs := make(map[string]bool)
+	if args.SourceID != nil {
+		for _, id := range *args.SourceID {
+			sourceIDs[id] = true
+		}
+	}
+
+	out := []search.Entity[search.Value]{}
+	for _, entity := range g.service.Entities() {
+		if len(lists) > 0 && !lists[strings.ToLower(string(entity.Source))] {
+			continue
+		}
+		if len(sourceIDs) > 0 && !sourceIDs[entity.SourceID] {
+			continue
+		}
+		out = append(out, entity)
+		if len(out) >= limit {
+			break
+		}
+	}
+	entities, err := graphql.Convert[[]graphqlEntity](out)
+	return &entities, err
+}
+
+func (g *GraphQLSearch) ListInfo() (*graphqlListInfo, error) {
+	out, err := graphql.Convert[graphqlListInfo](g.service.ListInfo())
+	return &out, err
+}
@@ -0,0 +1,88 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/graphql"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQLSearch(t *testing.T) {
+	svc := NewService(log.NewTestLogger())
+	svc.UpdateEntities([]search.Entity[search.Value]{
+		{
+			Name:     "Nicolas Maduro Moros",
+			Type:     search.EntityPerson,
+			Source:   search.SourceUSOFAC,
+			SourceID: "22790",
+			Person:   &search.Person{Name: "Nicolas Maduro Moros"},
+			SanctionsInfo: &search.SanctionsInfo{
+				Programs: []string{"VENEZUELA-EO13692"},
+			},
+		},
+		{
+			Name:     "Acme Shipping Limited",
+			Type:     search.EntityBusiness,
+			Source:   search.SourceUSCSL,
+			SourceID: "1234",
+			Business: &search.Business{Name: "Acme Shipping Limited"},
+		},
+	})
+
+	schema, err := graphql.NewSchema(NewGraphQLSearch(svc), GraphQLSchema)
+	require.NoError(t, err)
+
+	resp := schema.Execute(context.Background(), graphql.Request{
+		Query: `query ($name: String!) {
+			search(name: $name, type: person, limit: 1, minMatch: 0.9) {
+				entities { name match sanctionsInfo { programs } }
+				nextCursor
+			}
+			entities(list: ["us_csl"]) { sourceID }
+			listInfo { lists }
+		}`,
+		Variables: map[string]any{"name": "Nicolas Maduro"},
+	})
+	require.Empty(t, resp.Errors)
+
+	var data struct {
+		Search struct {
+			Entities []map[string]any `json:"entities"`
+		} `json:"search"`
+		Entities []map[string]any `json:"entities"`
+		ListInfo struct {
+			Lists map[string]int `json:"lists"`
+		} `json:"listInfo"`
+	}
+	require.NoError(t, json.Unmarshal(resp.Data, &data))
+
+	require.Len(t, data.Search.Entities, 1)
+	result := data.Search.Entities[0]
+	require.Len(t, result, 3)
+	require.Equal(t, "Nicolas Maduro Moros", result["name"])
+	require.Greater(t, result["match"], 0.9)
+	require.Equal(t, map[string]any{"programs": []any{"VENEZUELA-EO13692"}}, result["sanctionsInfo"])
+
+	require.Equal(t, []map[string]any{{"sourceID": "1234"}}, data.Entities)
+	require.Equal(t, map[string]int{"us_ofac": 1, "us_csl": 1}, data.ListInfo.Lists)
+
+	// Entities are typed
+	resp = schema.Execute(context.Background(), graphql.Request{
+		Query: `{ entities(sourceID: ["22790"]) { __typename person { name birthDate } sourceData } }`,
+	})
+	require.Empty(t, resp.Errors)
+	require.JSONEq(t, `{"entities":[{"__typename":"Entity","person":{"name":"Nicolas Maduro Moros","birthDate":null},"sourceData":null}]}`, string(resp.Data))
+
+	// Invalid search parameters are reported by the field
+	resp = schema.Execute(context.Background(), graphql.Request{
+		Query: `{ search(name: "Maduro", sort: "sideways") { nextCursor } listInfo { lists } }`,
+	})
+	require.Len(t, resp.Errors, 1)
+	require.Equal(t, []any{"search"}, resp.Errors[0].Path)
+	require.JSONEq(t, `{"search":null,"listInfo":{"lists":{"us_ofac":1,"us_csl":1}}}`, string(resp.Data))
+}
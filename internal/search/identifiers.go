@@ -0,0 +1,182 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// IdentifierQuery finds entities by a government issued document number, regardless of their name
+type IdentifierQuery struct {
+	Identifier string
+
+	// Type and Country optionally narrow which documents are matched
+	Type    search.GovernmentIDType
+	Country string
+
+	Limit int
+}
+
+// IdentifierMatch is an entity with a document number matching an IdentifierQuery
+type IdentifierMatch struct {
+	search.SearchedEntity[search.Value]
+
+	GovernmentID search.GovernmentID `json:"governmentID"`
+
+	// Exact is false when the document number differs by leading zeros or a single character
+	Exact bool `json:"exact"`
+}
+
+const (
+	exactIdentifierMatch = 1.0
+	nearIdentifierMatch  = 0.9
+
+	// minNearIdentifierLength prevents short numbers from matching many documents
+	minNearIdentifierLength = 5
+)
+
+// identifierIndex maps normalized document numbers to where they were listed
+type identifierIndex map[string][]identifierRef
+
+type identifierRef struct {
+	entity int // index into service.entities
+	id     search.GovernmentID
+}
+
+func newIdentifierIndex(entities []search.Entity[search.Value]) identifierIndex {
+	return buildPostings(entities, identifierRef.position, identifierEntries)
+}
+
+func (x identifierIndex) update(changes positionChanges) identifierIndex {
+	return updatePostings(x, changes, identifierRef.position, identifierEntries)
+}
+
+func identifierEntries(idx int, entity search.Entity[search.Value], fn func(string, identifierRef)) {
+	for _, id := range governmentIDs(entity) {
+		if key := normalizeIdentifier(id.Identifier); key != "" {
+			fn(key, identifierRef{entity: idx, id: id})
+		}
+	}
+}
+
+func (ref identifierRef) position() int {
+	return ref.entity
+}
+
+func governmentIDs(entity search.Entity[search.Value]) []search.GovernmentID {
+	switch {
+	case entity.Person != nil:
+		return entity.Person.GovernmentIDs
+	case entity.Business != nil:
+		return entity.Business.GovernmentIDs
+	case entity.Organization != nil:
+		return entity.Organization.GovernmentIDs
+	}
+	return nil
+}
+
+// normalizeIdentifier uppercases a document number and removes everything besides letters and digits
+func normalizeIdentifier(id string) string {
+	var buf strings.Builder
+	for _, r := range id {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			buf.WriteRune(unicode.ToUpper(r))
+		}
+	}
+	return buf.String()
+}
+
+// nearIdentifier reports if two normalized document numbers differ only by leading zeros or a single edit,
+// which covers common typos and transcription differences.
+func nearIdentifier(a, b string) bool {
+	if len(a) < minNearIdentifierLength || len(b) < minNearIdentifierLength {
+		return false
+	}
+	if strings.TrimLeft(a, "0") == strings.TrimLeft(b, "0") {
+		return true
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	if len(b)-len(a) > 1 {
+		return false
+	}
+
+	// Find the first difference, then require the rest to match after one substitution or insertion
+	i := 0
+	for i < len(a) && a[i] == b[i] {
+		i++
+	}
+	if len(a) == len(b) {
+		return a[i+1:] == b[i+1:]
+	}
+	return a[i:] == b[i+1:]
+}
+
+func (s *service) SearchByIdentifier(ctx context.Context, query IdentifierQuery) ([]IdentifierMatch, error) {
+	key := normalizeIdentifier(query.Identifier)
+	if key == "" {
+		return nil, errors.New("missing identifier")
+	}
+
+	current := s.current.Load()
+
+	var out []IdentifierMatch
+	seen := make(map[int]bool)
+	add := func(refs []identifierRef, exact bool) {
+		for _, ref := range refs {
+			if seen[ref.entity] {
+				continue // entities can list the same document more than once
+			}
+			if query.Type != "" && ref.id.Type != query.Type {
+				continue
+			}
+			if query.Country != "" && ref.id.Country != "" && !strings.EqualFold(query.Country, ref.id.Country) {
+				continue
+			}
+
+			seen[ref.entity] = true
+
+			match := IdentifierMatch{
+				GovernmentID: ref.id,
+				Exact:        exact,
+			}
+			match.Entity = current.entities[ref.entity]
+			match.Match = nearIdentifierMatch
+			if exact {
+				match.Match = exactIdentifierMatch
+			}
+			out = append(out, match)
+		}
+	}
+
+	add(current.identifiers[key], true)
+	for indexed, refs := range current.identifiers {
+		if indexed != key && nearIdentifier(key, indexed) {
+			add(refs, false)
+		}
+	}
+
+	// Exact matches first, then by list and ID for consistent ordering
+	slices.SortFunc(out, func(a, b IdentifierMatch) int {
+		if a.Exact != b.Exact {
+			if a.Exact {
+				return -1
+			}
+			return 1
+		}
+		if c := strings.Compare(string(a.Source), string(b.Source)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.SourceID, b.SourceID)
+	})
+
+	if query.Limit > 0 && len(out) > query.Limit {
+		out = out[:query.Limit]
+	}
+	return out, nil
+}
@@ -0,0 +1,87 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeIdentifier(t *testing.T) {
+	require.Equal(t, "D0001203", normalizeIdentifier("d 0001203"))
+	require.Equal(t, "12345678", normalizeIdentifier("123-456.78"))
+	require.Equal(t, "", normalizeIdentifier(" - "))
+}
+
+func TestNearIdentifier(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"1084010", "01084010", true}, // leading zero
+		{"1084010", "1084011", true},  // substitution
+		{"1084010", "108400", true},   // deletion
+		{"1084010", "10840100", true}, // insertion
+		{"1084010", "1840010", false}, // two edits
+		{"12345", "12345678", false},
+		{"1234", "1235", false}, // too short
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.expected, nearIdentifier(tc.a, tc.b), "%s vs %s", tc.a, tc.b)
+		require.Equal(t, tc.expected, nearIdentifier(tc.b, tc.a), "%s vs %s", tc.b, tc.a)
+	}
+}
+
+func TestService_SearchByIdentifier(t *testing.T) {
+	ctx := context.Background()
+	svc := testService(t)
+
+	// 2676 AL ZAWAHIRI lists "Passport 1084010 (Egypt)"
+	results, err := svc.SearchByIdentifier(ctx, IdentifierQuery{Identifier: "1084010"})
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	require.Equal(t, "2676", results[0].SourceID)
+	require.True(t, results[0].Exact)
+	require.InDelta(t, 1.0, results[0].Match, 0.001)
+	require.Equal(t, search.GovernmentIDPassport, results[0].GovernmentID.Type)
+
+	results, err = svc.SearchByIdentifier(ctx, IdentifierQuery{Identifier: "01084010"})
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	require.Equal(t, "2676", results[0].SourceID)
+	require.False(t, results[0].Exact)
+
+	results, err = svc.SearchByIdentifier(ctx, IdentifierQuery{Identifier: "1084010", Type: search.GovernmentIDTax})
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	_, err = svc.SearchByIdentifier(ctx, IdentifierQuery{})
+	require.ErrorContains(t, err, "missing identifier")
+}
+
+func TestAPI_searchIdentifier(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	req := httptest.NewRequest("GET", "/v2/search/id?identifier=1084010&type=passport", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp identifierSearchResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.NotEmpty(t, resp.Entities)
+	require.Equal(t, "2676", resp.Entities[0].SourceID)
+
+	req = httptest.NewRequest("GET", "/v2/search/id", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
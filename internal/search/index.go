@@ -0,0 +1,249 @@
+package search
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// index is everything a search reads, built in full before it's swapped in (see service.swap) and never modified
+// after. Searches load the current index once, so they keep reading the one they started with while a newer one
+// is built or swapped in, without waiting on either.
+type index struct {
+	entities    []search.Entity[search.Value]
+	positions   map[entityKey]int // index into entities
+	identifiers identifierIndex
+	filters     filterIndex
+	vessels     vesselIndex
+	aircraft    aircraftIndex
+	crypto      cryptoIndex
+	remarks     remarksIndex
+	links       linkIndex
+	exact       exactIndex
+	trigrams    trigramIndex
+	phonetic    phoneticIndex
+	listInfo    ListInfo
+	programs    []Program
+	lastChanges AppliedChanges
+	changed     []int // positions of the entities added or modified by lastChanges, in ascending order
+	minMatches  map[search.SourceList]float64
+
+	// financialIDs were added to the entities as they were indexed, and are added to queries
+	financialIDs *FinancialIDMapping
+
+	// tenants holds the entities searched alongside the others for each tenant
+	tenants map[string][]search.Entity[search.Value]
+}
+
+// newIndex builds the lookups over entities, which it keeps rather than copies
+func newIndex(entities []search.Entity[search.Value], updatedAt time.Time) *index {
+	positions := make(map[entityKey]int, len(entities))
+	for i, entity := range entities {
+		positions[keyOf(entity)] = i
+	}
+	return &index{
+		entities:    entities,
+		positions:   positions,
+		identifiers: newIdentifierIndex(entities),
+		filters:     newFilterIndex(entities),
+		vessels:     newVesselIndex(entities),
+		aircraft:    newAircraftIndex(entities),
+		crypto:      newCryptoIndex(entities),
+		remarks:     newRemarksIndex(entities),
+		links:       newLinkIndex(entities),
+		exact:       newExactIndex(entities),
+		trigrams:    newTrigramIndex(entities),
+		phonetic:    newPhoneticIndex(entities),
+		listInfo: ListInfo{
+			Lists:     countLists(entities),
+			UpdatedAt: updatedAt,
+		},
+		programs: countPrograms(entities),
+	}
+}
+
+// with returns a copy of x, for an update to replace some of its fields before it's swapped in. Maps and slices
+// are shared with x, so they're replaced rather than modified.
+func (x *index) with() *index {
+	out := *x
+	return &out
+}
+
+// positionChanges are the entities leaving and taking positions in the entities of an index, which its lookups are
+// updated for rather than built again
+type positionChanges struct {
+	removed []positioned // entities as they were indexed, before the update
+	added   []positioned
+}
+
+type positioned struct {
+	idx    int
+	entity search.Entity[search.Value]
+}
+
+// postings are the lists of positions, or of refs to positions, held by a lookup under each of its keys. Each
+// list is in ascending order of position, with the refs to one position in the order they were added.
+type postings[K comparable, R any] struct {
+	lists    map[K][]R
+	position func(R) int // index into the entities of the index
+
+	// copied is set for an update of an index, whose lists are shared with the index searches may be reading
+	// until the update first changes them
+	copied map[K]bool
+}
+
+// entriesFunc calls fn with the key and ref of each entry of the entity at idx in a lookup
+type entriesFunc[K comparable, R any] func(idx int, entity search.Entity[search.Value], fn func(K, R))
+
+// buildPostings returns the lists of every entry of entities
+func buildPostings[K comparable, R any](entities []search.Entity[search.Value], position func(R) int, entries entriesFunc[K, R]) map[K][]R {
+	p := &postings[K, R]{
+		lists:    make(map[K][]R),
+		position: position,
+	}
+	for i, entity := range entities {
+		entries(i, entity, p.insert)
+	}
+	return p.lists
+}
+
+// updatePostings returns a copy of lists with the entries of the removed entities dropped and those of the added
+// entities inserted, leaving lists unchanged
+func updatePostings[K comparable, R any](lists map[K][]R, changes positionChanges, position func(R) int, entries entriesFunc[K, R]) map[K][]R {
+	p := editPostings(lists, position)
+	p.removeEntries(changes.removed, entries)
+	p.insertEntries(changes.added, entries)
+	return p.lists
+}
+
+// editPostings returns postings for an update of lists, which are left unchanged
+func editPostings[K comparable, R any](lists map[K][]R, position func(R) int) *postings[K, R] {
+	return &postings[K, R]{
+		lists:    maps.Clone(lists),
+		position: position,
+		copied:   make(map[K]bool),
+	}
+}
+
+func (p *postings[K, R]) removeEntries(removed []positioned, entries entriesFunc[K, R]) {
+	for _, c := range removed {
+		entries(c.idx, c.entity, func(key K, _ R) {
+			p.remove(key, c.idx)
+		})
+	}
+}
+
+func (p *postings[K, R]) insertEntries(added []positioned, entries entriesFunc[K, R]) {
+	for _, c := range added {
+		entries(c.idx, c.entity, p.insert)
+	}
+}
+
+// positionOf is the position func of lists of positions
+func positionOf(idx int) int {
+	return idx
+}
+
+// remove drops every ref to idx under key
+func (p *postings[K, R]) remove(key K, idx int) {
+	if _, exists := p.lists[key]; !exists {
+		return
+	}
+	refs := p.own(key)
+	start, end := p.span(refs, idx)
+	if refs = slices.Delete(refs, start, end); len(refs) == 0 {
+		delete(p.lists, key)
+		return
+	}
+	p.lists[key] = refs
+}
+
+// insert adds ref after the refs under key to the same or earlier positions
+func (p *postings[K, R]) insert(key K, ref R) {
+	refs := p.own(key)
+	if n := len(refs); n == 0 || p.position(refs[n-1]) <= p.position(ref) {
+		p.lists[key] = append(refs, ref)
+		return
+	}
+	_, end := p.span(refs, p.position(ref))
+	p.lists[key] = slices.Insert(refs, end, ref)
+}
+
+// span returns the range of refs to idx
+func (p *postings[K, R]) span(refs []R, idx int) (int, int) {
+	start, _ := slices.BinarySearchFunc(refs, idx, func(ref R, idx int) int {
+		return cmp.Compare(p.position(ref), idx)
+	})
+	end := start
+	for end < len(refs) && p.position(refs[end]) == idx {
+		end++
+	}
+	return start, end
+}
+
+// own returns the list under key, copying it first when it's shared
+func (p *postings[K, R]) own(key K) []R {
+	if p.copied != nil && !p.copied[key] {
+		p.copied[key] = true
+		p.lists[key] = slices.Clone(p.lists[key])
+	}
+	return p.lists[key]
+}
+
+// validate returns an error when the lookups of the index don't describe its entities, which would return the
+// wrong entities, or panic, once it's searched
+func (x *index) validate() error {
+	n := len(x.entities)
+	for key, idx := range x.positions {
+		if idx < 0 || idx >= n || keyOf(x.entities[idx]) != key {
+			return fmt.Errorf("%s/%s isn't at its position %d", key.source, key.sourceID, idx)
+		}
+	}
+	for _, entity := range x.entities {
+		if _, exists := x.positions[keyOf(entity)]; !exists {
+			return fmt.Errorf("%s/%s has no position", entity.Source, entity.SourceID)
+		}
+	}
+	if len(x.remarks.remarks) != n {
+		return fmt.Errorf("remarks of %d entities for %d entities", len(x.remarks.remarks), n)
+	}
+	if err := inRange("changed", x.changed, n); err != nil {
+		return err
+	}
+	for _, positions := range x.trigrams.grams {
+		if err := inRange("trigram", positions, n); err != nil {
+			return err
+		}
+	}
+	for _, positions := range x.phonetic.codes {
+		if err := inRange("phonetic", positions, n); err != nil {
+			return err
+		}
+	}
+	for _, positions := range x.exact.names {
+		if err := inRange("exact name", positions, n); err != nil {
+			return err
+		}
+	}
+	for _, refs := range x.identifiers {
+		for _, ref := range refs {
+			if ref.entity < 0 || ref.entity >= n {
+				return fmt.Errorf("identifier position %d is outside of %d entities", ref.entity, n)
+			}
+		}
+	}
+	return nil
+}
+
+func inRange(name string, positions []int, n int) error {
+	for _, idx := range positions {
+		if idx < 0 || idx >= n {
+			return fmt.Errorf("%s position %d is outside of %d entities", name, idx, n)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func indexEntities(n int, name string) []search.Entity[search.Value] {
+	out := make([]search.Entity[search.Value], n)
+	for i := range out {
+		out[i] = search.Entity[search.Value]{
+			Name:     fmt.Sprintf("%s %d", name, i),
+			Type:     search.EntityBusiness,
+			Source:   search.SourceUSOFAC,
+			SourceID: fmt.Sprintf("%d", i),
+			Business: &search.Business{Name: fmt.Sprintf("%s %d", name, i)},
+		}
+	}
+	return out
+}
+
+func TestIndex_Validate(t *testing.T) {
+	entities := indexEntities(10, "Acme Trading")
+
+	idx := newIndex(entities, time.Now())
+	require.NoError(t, idx.validate())
+	require.NoError(t, newIndex(nil, time.Now()).validate())
+
+	broken := idx.with()
+	broken.positions = map[entityKey]int{keyOf(entities[0]): 1}
+	require.ErrorContains(t, broken.validate(), "isn't at its position 1")
+
+	broken = idx.with()
+	broken.entities = entities[:5]
+	require.Error(t, broken.validate())
+
+	broken = idx.with()
+	broken.changed = []int{10}
+	require.ErrorContains(t, broken.validate(), "changed position 10 is outside of 10 entities")
+}
+
+func TestService_RejectedIndex(t *testing.T) {
+	svc := NewService(log.NewTestLogger()).(*service)
+	svc.UpdateEntities(indexEntities(10, "Acme Trading"))
+	before := svc.current.Load()
+
+	next := newIndex(indexEntities(20, "Acme Shipping"), time.Now())
+	next.remarks = newRemarksIndex(nil)
+
+	svc.updates.Lock()
+	err := svc.swap(next, time.Now())
+	svc.updates.Unlock()
+
+	// Searches keep reading the index from before
+	require.ErrorContains(t, err, "remarks of 0 entities for 20 entities")
+	require.Same(t, before, svc.current.Load())
+	require.Len(t, svc.Entities(), 10)
+}
+
+func TestService_SearchDuringUpdates(t *testing.T) {
+	ctx := context.Background()
+	svc := NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{MinTrigramOverlap: 0.3})
+	svc.UpdateEntities(indexEntities(200, "Acme Trading"))
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			query := search.Entity[search.Value]{Name: "Acme Trading 42", Type: search.EntityBusiness}
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				// Each search reads one whole index, whichever of them it's given
+				results, err := svc.Search(ctx, query, SearchOpts{Limit: 5, MinMatch: 0.01, ExactFirst: true})
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				if len(results) == 0 || results[0].SourceID != "42" {
+					t.Errorf("unexpected results: %v", results)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 20; i++ {
+		svc.UpdateEntities(indexEntities(200+i, "Acme Trading"))
+		svc.ApplyChanges(EntityChanges{
+			Added:   indexEntities(300, "Acme Trading")[250:],
+			Removed: indexEntities(200, "Acme Trading")[100:110],
+		})
+		svc.UpdateTenantEntities("tenant", indexEntities(i%3, "Tenant"))
+		require.NoError(t, svc.SetListMinMatch(search.SourceUSOFAC, 0.001*float64(i%2)))
+	}
+	close(done)
+	wg.Wait()
+
+	require.Len(t, svc.Entities(), 219-10+50)
+}
@@ -0,0 +1,200 @@
+package search
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// interner keeps one copy of each repeated string and list of strings, such as the countries, programs and cities
+// shared by thousands of entities or the names repeated between an entity and its person or business.
+//
+// Interned lists and sanctions are shared between entities, so they must not be modified in place. Entities
+// are only read once they're indexed, and PrepareEntity and NormalizeCountries copy what they change.
+type interner struct {
+	seen      map[string]string
+	lists     map[string][]string
+	sanctions map[string]*search.SanctionsInfo
+}
+
+func newInterner() *interner {
+	return &interner{
+		seen:      make(map[string]string),
+		lists:     make(map[string][]string),
+		sanctions: make(map[string]*search.SanctionsInfo),
+	}
+}
+
+func (in *interner) string(s string) string {
+	if s == "" {
+		return s
+	}
+	if out, exists := in.seen[s]; exists {
+		return out
+	}
+	in.seen[s] = s
+	return s
+}
+
+// listSeparator joins the strings of a list into its key, as it isn't written in any list's data
+const listSeparator = "\x00"
+
+// strings returns the shared copy of a list of strings with each string interned
+func (in *interner) strings(xs []string) []string {
+	if len(xs) == 0 {
+		return xs
+	}
+	key := strings.Join(xs, listSeparator)
+	if out, exists := in.lists[key]; exists && len(out) == len(xs) {
+		return out
+	}
+	out := make([]string, len(xs))
+	for i := range xs {
+		out[i] = in.string(xs[i])
+	}
+	in.lists[key] = out
+	return out
+}
+
+// sanctionsInfo returns the shared copy of info, as most entities of a list are sanctioned under one of a
+// few programs with nothing else set
+func (in *interner) sanctionsInfo(info *search.SanctionsInfo) *search.SanctionsInfo {
+	key := fmt.Sprintf("%q|%v|%q|%q|%q|%v|%q|%q|%q", info.Programs, info.Secondary, info.Description,
+		info.Regulations, info.Lists, info.Sectoral, info.Directives, info.LicenseRequirement, info.LicensePolicy)
+	if out, exists := in.sanctions[key]; exists {
+		return out
+	}
+	out := *info
+	out.Programs = in.strings(out.Programs)
+	out.Description = in.string(out.Description)
+	out.Regulations = in.strings(out.Regulations)
+	out.Lists = in.strings(out.Lists)
+	out.Directives = in.strings(out.Directives)
+	out.LicenseRequirement = in.string(out.LicenseRequirement)
+	out.LicensePolicy = in.string(out.LicensePolicy)
+	in.sanctions[key] = &out
+	return &out
+}
+
+func (in *interner) governmentIDs(ids []search.GovernmentID) []search.GovernmentID {
+	if len(ids) == 0 {
+		return ids
+	}
+	out := slices.Clone(ids)
+	for i := range out {
+		out[i].Type = search.GovernmentIDType(in.string(string(out[i].Type)))
+		out[i].Country = in.string(out[i].Country)
+	}
+	return out
+}
+
+// entity returns entity with its strings interned. Structs and slices are copied before they're modified, so
+// entity itself is left unchanged. Its SourceData is kept as it is.
+func (in *interner) entity(entity search.Entity[search.Value]) search.Entity[search.Value] {
+	entity.Name = in.string(entity.Name)
+	entity.WeakAltNames = in.strings(entity.WeakAltNames)
+
+	if entity.Person != nil {
+		person := *entity.Person
+		person.Name = in.string(person.Name)
+		person.AltNames = in.strings(person.AltNames)
+		person.Titles = in.strings(person.Titles)
+		person.PlaceOfBirth = in.string(person.PlaceOfBirth)
+		person.Nationalities = in.strings(person.Nationalities)
+		person.GovernmentIDs = in.governmentIDs(person.GovernmentIDs)
+		entity.Person = &person
+	}
+	if entity.Business != nil {
+		business := *entity.Business
+		business.Name = in.string(business.Name)
+		business.AltNames = in.strings(business.AltNames)
+		business.GovernmentIDs = in.governmentIDs(business.GovernmentIDs)
+		entity.Business = &business
+	}
+	if entity.Organization != nil {
+		org := *entity.Organization
+		org.Name = in.string(org.Name)
+		org.AltNames = in.strings(org.AltNames)
+		org.GovernmentIDs = in.governmentIDs(org.GovernmentIDs)
+		entity.Organization = &org
+	}
+	if entity.Aircraft != nil {
+		aircraft := *entity.Aircraft
+		aircraft.Name = in.string(aircraft.Name)
+		aircraft.AltNames = in.strings(aircraft.AltNames)
+		aircraft.Flag = in.string(aircraft.Flag)
+		aircraft.ICAOCode = in.string(aircraft.ICAOCode)
+		aircraft.Model = in.string(aircraft.Model)
+		entity.Aircraft = &aircraft
+	}
+	if entity.Vessel != nil {
+		vessel := *entity.Vessel
+		vessel.Name = in.string(vessel.Name)
+		vessel.AltNames = in.strings(vessel.AltNames)
+		vessel.Flag = in.string(vessel.Flag)
+		vessel.Model = in.string(vessel.Model)
+		vessel.Owner = in.string(vessel.Owner)
+		entity.Vessel = &vessel
+	}
+
+	if len(entity.Addresses) > 0 {
+		addresses := slices.Clone(entity.Addresses)
+		for i := range addresses {
+			addresses[i].City = in.string(addresses[i].City)
+			addresses[i].PostalCode = in.string(addresses[i].PostalCode)
+			addresses[i].State = in.string(addresses[i].State)
+			addresses[i].Country = in.string(addresses[i].Country)
+		}
+		entity.Addresses = addresses
+	}
+	if len(entity.CryptoAddresses) > 0 {
+		crypto := slices.Clone(entity.CryptoAddresses)
+		for i := range crypto {
+			crypto[i].Currency = in.string(crypto[i].Currency)
+		}
+		entity.CryptoAddresses = crypto
+	}
+	if len(entity.Affiliations) > 0 {
+		affiliations := slices.Clone(entity.Affiliations)
+		for i := range affiliations {
+			affiliations[i].EntityName = in.string(affiliations[i].EntityName)
+			affiliations[i].Type = in.string(affiliations[i].Type)
+		}
+		entity.Affiliations = affiliations
+	}
+	if entity.SanctionsInfo != nil {
+		entity.SanctionsInfo = in.sanctionsInfo(entity.SanctionsInfo)
+	}
+	if len(entity.HistoricalInfo) > 0 {
+		history := slices.Clone(entity.HistoricalInfo)
+		for i := range history {
+			history[i].Type = in.string(history[i].Type)
+		}
+		entity.HistoricalInfo = history
+	}
+	if entity.PEP != nil {
+		pep := *entity.PEP
+		pep.Positions = in.strings(pep.Positions)
+		entity.PEP = &pep
+	}
+	if entity.Wanted != nil {
+		wanted := *entity.Wanted
+		wanted.Authority = in.string(wanted.Authority)
+		wanted.Notice = in.string(wanted.Notice)
+		entity.Wanted = &wanted
+	}
+	return entity
+}
+
+// internEntities returns entities with the strings of every entity interned together
+func internEntities(entities []search.Entity[search.Value]) []search.Entity[search.Value] {
+	in := newInterner()
+
+	out := make([]search.Entity[search.Value], len(entities))
+	for i := range entities {
+		out[i] = in.entity(entities[i])
+	}
+	return out
+}
@@ -0,0 +1,95 @@
+package search
+
+import (
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+// addressOf is where a string's bytes are held, which are the same for shared strings
+func addressOf(s string) uintptr {
+	return uintptr(unsafe.Pointer(unsafe.StringData(s)))
+}
+
+func TestInterner(t *testing.T) {
+	in := newInterner()
+
+	program := in.string(strings.Clone("SDGT"))
+	again := in.string(strings.Clone("SDGT"))
+	require.Equal(t, addressOf(program), addressOf(again))
+	require.Empty(t, in.string(""))
+
+	// Identical lists share one copy
+	programs := in.strings([]string{strings.Clone("SDGT"), strings.Clone("IRGC")})
+	other := in.strings([]string{strings.Clone("SDGT"), strings.Clone("IRGC")})
+	require.Equal(t, []string{"SDGT", "IRGC"}, other)
+	require.Same(t, &programs[0], &other[0])
+	require.Equal(t, addressOf(program), addressOf(programs[0]))
+
+	different := in.strings([]string{"SDGT"})
+	require.NotSame(t, &programs[0], &different[0])
+	require.Nil(t, in.strings(nil))
+
+	info := in.sanctionsInfo(&search.SanctionsInfo{Programs: []string{"SDGT", "IRGC"}, Secondary: true})
+	require.Same(t, info, in.sanctionsInfo(&search.SanctionsInfo{Programs: []string{"SDGT", "IRGC"}, Secondary: true}))
+	require.NotSame(t, info, in.sanctionsInfo(&search.SanctionsInfo{Programs: []string{"SDGT", "IRGC"}}))
+	require.Same(t, &programs[0], &info.Programs[0])
+}
+
+func TestService_InternsEntities(t *testing.T) {
+	newEntity := func(id string) search.Entity[search.Value] {
+		return search.Entity[search.Value]{
+			Name:     strings.Clone("Acme Trading"),
+			Type:     search.EntityBusiness,
+			Source:   search.SourceUSOFAC,
+			SourceID: id,
+			Business: &search.Business{
+				Name:     strings.Clone("Acme Trading"),
+				AltNames: []string{strings.Clone("Acme Trading Co")},
+			},
+			Addresses: []search.Address{
+				{Line1: "1 Main St", City: strings.Clone("Tehran"), Country: strings.Clone("IR")},
+			},
+			SanctionsInfo: &search.SanctionsInfo{
+				Programs: []string{strings.Clone("SDGT"), strings.Clone("IRGC")},
+			},
+		}
+	}
+	entities := []search.Entity[search.Value]{newEntity("1"), newEntity("2")}
+	business := entities[0].Business
+
+	svc := NewService(log.NewTestLogger())
+	svc.UpdateEntities(entities)
+
+	held := svc.(*service).current.Load().entities
+	require.Len(t, held, 2)
+
+	same := func(a, b string) {
+		t.Helper()
+		require.Equal(t, a, b)
+		require.Equal(t, addressOf(a), addressOf(b), "%q isn't shared", a)
+	}
+	same(held[0].Name, held[0].Business.Name)
+	same(held[0].Name, held[1].Name)
+	same(held[0].Addresses[0].City, held[1].Addresses[0].City)
+	same(held[0].Addresses[0].Country, held[1].Addresses[0].Country)
+	require.Same(t, held[0].SanctionsInfo, held[1].SanctionsInfo)
+
+	// The caller's entities aren't modified
+	require.Same(t, business, entities[0].Business)
+	require.NotSame(t, business, held[0].Business)
+	require.NotEqual(t, addressOf(entities[0].Name), addressOf(entities[1].Name))
+
+	// Entities added by changes are interned too
+	svc.(*service).ApplyChanges(EntityChanges{
+		Added: []search.Entity[search.Value]{newEntity("3"), newEntity("4")},
+	})
+	held = svc.(*service).current.Load().entities
+	require.Len(t, held, 4)
+	same(held[2].Addresses[0].Country, held[3].Addresses[0].Country)
+}
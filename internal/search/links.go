@@ -0,0 +1,240 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// What linked records share, see search.RelatedEntity
+const (
+	linkedByGovernmentID  = "governmentID"
+	linkedByCryptoAddress = "cryptoAddress"
+	linkedByIMONumber     = "imoNumber"
+	linkedByMMSI          = "mmsi"
+	linkedByName          = "nameAndDetails"
+)
+
+// minLinkedIdentifierLength keeps short document numbers, which are often reused, from linking records
+const minLinkedIdentifierLength = 6
+
+// consolidateFactor is how many more results are scored when consolidating, as linked records
+// are merged into one result
+const consolidateFactor = 4
+
+// linkIndex groups the records of different lists which are the same party. Records are linked by a
+// shared document number, crypto address or vessel number, or by the same name along with the same
+// birth date (people), country (businesses and organizations) or flag (vessels and aircraft).
+type linkIndex struct {
+	groups  map[entityKey]int
+	members map[int][]linkMember
+}
+
+type linkMember struct {
+	entity   int // index into service.entities
+	linkedBy string
+}
+
+type linkRef struct {
+	entity int
+	id     search.GovernmentID
+}
+
+func newLinkIndex(entities []search.Entity[search.Value]) linkIndex {
+	parent := make([]int, len(entities))
+	for i := range parent {
+		parent[i] = i
+	}
+	reasons := make([]string, len(entities))
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+	union := func(a, b int, reason string) {
+		if entities[a].Source == entities[b].Source || entities[a].Type != entities[b].Type {
+			return
+		}
+		if reasons[a] == "" {
+			reasons[a] = reason
+		}
+		if reasons[b] == "" {
+			reasons[b] = reason
+		}
+		if ra, rb := find(a), find(b); ra != rb {
+			parent[rb] = ra
+		}
+	}
+
+	identifiers := make(map[string][]linkRef)
+	exact := make(map[string][]int)
+	names := make(map[string][]int)
+
+	for i, entity := range entities {
+		for _, id := range governmentIDs(entity) {
+			if key := normalizeIdentifier(id.Identifier); len(key) >= minLinkedIdentifierLength {
+				identifiers[key] = append(identifiers[key], linkRef{entity: i, id: id})
+			}
+		}
+		for _, addr := range entity.CryptoAddresses {
+			if addr.Address != "" {
+				key := linkedByCryptoAddress + ":" + strings.ToUpper(addr.Currency) + ":" + addr.Address
+				exact[key] = append(exact[key], i)
+			}
+		}
+		if entity.Vessel != nil {
+			if imo := digitsOf(entity.Vessel.IMONumber); len(imo) == 7 {
+				exact[linkedByIMONumber+":"+imo] = append(exact[linkedByIMONumber+":"+imo], i)
+			}
+			if mmsi := digitsOf(entity.Vessel.MMSI); len(mmsi) == 9 {
+				exact[linkedByMMSI+":"+mmsi] = append(exact[linkedByMMSI+":"+mmsi], i)
+			}
+		}
+		for _, name := range linkNames(entity) {
+			key := string(entity.Type) + ":" + name
+			if n := len(names[key]); n == 0 || names[key][n-1] != i {
+				names[key] = append(names[key], i)
+			}
+		}
+	}
+
+	for _, refs := range identifiers {
+		for i := range refs {
+			for j := i + 1; j < len(refs); j++ {
+				if sameDocument(refs[i].id, refs[j].id) {
+					union(refs[i].entity, refs[j].entity, linkedByGovernmentID)
+				}
+			}
+		}
+	}
+	for key, idxs := range exact {
+		reason, _, _ := strings.Cut(key, ":")
+		for _, idx := range idxs[1:] {
+			union(idxs[0], idx, reason)
+		}
+	}
+	for _, idxs := range names {
+		for i := range idxs {
+			for j := i + 1; j < len(idxs); j++ {
+				if sameDetails(entities[idxs[i]], entities[idxs[j]]) {
+					union(idxs[i], idxs[j], linkedByName)
+				}
+			}
+		}
+	}
+
+	out := linkIndex{
+		groups:  make(map[entityKey]int),
+		members: make(map[int][]linkMember),
+	}
+	for i := range entities {
+		root := find(i)
+		out.members[root] = append(out.members[root], linkMember{entity: i, linkedBy: reasons[i]})
+	}
+	for root, members := range out.members {
+		if len(members) < 2 {
+			delete(out.members, root)
+			continue
+		}
+		for _, member := range members {
+			out.groups[keyOf(entities[member.entity])] = root
+		}
+	}
+	return out
+}
+
+// sameDocument returns true when two records of a document number could be the same document
+func sameDocument(a, b search.GovernmentID) bool {
+	if a.Type != "" && b.Type != "" && a.Type != b.Type {
+		return false
+	}
+	return a.Country == "" || b.Country == "" || strings.EqualFold(a.Country, b.Country)
+}
+
+// sameDetails returns true when two records of the same name share the detail they're linked by
+func sameDetails(a, b search.Entity[search.Value]) bool {
+	switch {
+	case a.Person != nil && b.Person != nil:
+		return a.Person.BirthDate != nil && b.Person.BirthDate != nil &&
+			a.Person.BirthDate.Format("2006-01-02") == b.Person.BirthDate.Format("2006-01-02")
+
+	case a.Vessel != nil && b.Vessel != nil:
+		return a.Vessel.Flag != "" && strings.EqualFold(a.Vessel.Flag, b.Vessel.Flag)
+
+	case a.Aircraft != nil && b.Aircraft != nil:
+		return a.Aircraft.Flag != "" && strings.EqualFold(a.Aircraft.Flag, b.Aircraft.Flag)
+
+	case a.Business != nil || a.Organization != nil:
+		for _, addr := range a.Addresses {
+			if addr.Country == "" {
+				continue
+			}
+			for _, other := range b.Addresses {
+				if strings.EqualFold(addr.Country, other.Country) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// linkNames returns the keys of an entity's names with at least two words, see nameKey
+func linkNames(entity search.Entity[search.Value]) []string {
+	var out []string
+	for _, key := range entityNameKeys(entity) {
+		if strings.Contains(key, " ") {
+			out = append(out, key) // single words are too common to link records by
+		}
+	}
+	return out
+}
+
+// consolidate merges results which are linked as the same party into the highest scoring of them, listing
+// the others as its related records, and returns up to limit results
+func (l linkIndex) consolidate(results []search.SearchedEntity[search.Value], entities []search.Entity[search.Value], limit int) []search.SearchedEntity[search.Value] {
+	out := make([]search.SearchedEntity[search.Value], 0, len(results))
+	merged := make(map[int]int) // group to index in out
+
+	for _, result := range results {
+		key := keyOf(result.Entity)
+		group, linked := l.groups[key]
+		if !linked {
+			out = append(out, result)
+			continue
+		}
+
+		if idx, exists := merged[group]; exists {
+			for i := range out[idx].Related {
+				related := &out[idx].Related[i]
+				if related.Source == key.source && related.SourceID == key.sourceID && related.Match == 0 {
+					related.Match = result.Match
+				}
+			}
+			continue
+		}
+
+		for _, member := range l.members[group] {
+			entity := entities[member.entity]
+			if keyOf(entity) == key {
+				continue
+			}
+			result.Related = append(result.Related, search.RelatedEntity{
+				Name:     entity.Name,
+				Source:   entity.Source,
+				SourceID: entity.SourceID,
+				LinkedBy: member.linkedBy,
+			})
+		}
+		merged[group] = len(out)
+		out = append(out, result)
+	}
+
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
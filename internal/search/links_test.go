@@ -0,0 +1,170 @@
+package search
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func linkedEntities() []search.Entity[search.Value] {
+	birthDate := time.Date(1962, time.November, 23, 0, 0, 0, 0, time.UTC)
+	person := func(source search.SourceList, sourceID, name string, ids ...search.GovernmentID) search.Entity[search.Value] {
+		return search.Entity[search.Value]{
+			Name:     name,
+			Type:     search.EntityPerson,
+			Source:   source,
+			SourceID: sourceID,
+			Person: &search.Person{
+				Name:          name,
+				BirthDate:     &birthDate,
+				GovernmentIDs: ids,
+			},
+		}
+	}
+	passport := search.GovernmentID{Type: search.GovernmentIDPassport, Country: "VE", Identifier: "D-0001832"}
+
+	return []search.Entity[search.Value]{
+		person(search.SourceUSOFAC, "22790", "Nicolas MADURO MOROS", passport),
+		person(search.SourceEUCSL, "eu-5764", "Nicolás MADURO MOROS"),
+		person(search.SourceUKCSL, "VEN0001", "MADURO MOROS Nicolas", search.GovernmentID{Identifier: "d0001832"}),
+		person(search.SourceUSOFAC, "99999", "Nicolas Maduro Moros"), // same list and name, but a different record
+
+		// The same name without a birth date isn't linked
+		{
+			Name:     "Nicolas Maduro Moros",
+			Type:     search.EntityPerson,
+			Source:   search.SourceUNCSL,
+			SourceID: "un-1",
+			Person:   &search.Person{Name: "Nicolas Maduro Moros"},
+		},
+
+		// Businesses are linked by name and country
+		{
+			Name:      "Acme Shipping Limited",
+			Type:      search.EntityBusiness,
+			Source:    search.SourceUSOFAC,
+			SourceID:  "1234",
+			Business:  &search.Business{Name: "Acme Shipping Limited"},
+			Addresses: []search.Address{{Country: "CY"}},
+		},
+		{
+			Name:      "ACME SHIPPING LIMITED",
+			Type:      search.EntityBusiness,
+			Source:    search.SourceEUCSL,
+			SourceID:  "eu-1",
+			Business:  &search.Business{Name: "ACME SHIPPING LIMITED"},
+			Addresses: []search.Address{{Country: "GR"}, {Country: "CY"}},
+		},
+		{
+			Name:      "Acme Shipping Limited",
+			Type:      search.EntityBusiness,
+			Source:    search.SourceUKCSL,
+			SourceID:  "uk-1",
+			Business:  &search.Business{Name: "Acme Shipping Limited"},
+			Addresses: []search.Address{{Country: "PA"}},
+		},
+
+		// Vessels are linked by IMO number
+		{
+			Name:     "OCEAN STAR",
+			Type:     search.EntityVessel,
+			Source:   search.SourceUSOFAC,
+			SourceID: "v-1",
+			Vessel:   &search.Vessel{Name: "OCEAN STAR", IMONumber: "IMO 9187629"},
+		},
+		{
+			Name:     "STAR OF THE OCEAN",
+			Type:     search.EntityVessel,
+			Source:   search.SourceUKCSL,
+			SourceID: "v-2",
+			Vessel:   &search.Vessel{Name: "STAR OF THE OCEAN", IMONumber: "9187629"},
+		},
+	}
+}
+
+func TestLinkIndex(t *testing.T) {
+	entities := linkedEntities()
+	links := newLinkIndex(entities)
+
+	group := func(source search.SourceList, sourceID string) (int, bool) {
+		g, ok := links.groups[entityKey{source: source, sourceID: sourceID}]
+		return g, ok
+	}
+
+	maduro, ok := group(search.SourceUSOFAC, "22790")
+	require.True(t, ok)
+	for _, key := range []entityKey{{search.SourceEUCSL, "eu-5764"}, {search.SourceUKCSL, "VEN0001"}} {
+		g, ok := group(key.source, key.sourceID)
+		require.True(t, ok, key)
+		require.Equal(t, maduro, g, key)
+	}
+
+	// The second OFAC record shares a name and birth date with the EU record, so it's linked
+	// through it while records of one list aren't linked to each other directly
+	_, ok = group(search.SourceUNCSL, "un-1")
+	require.False(t, ok)
+
+	acme, ok := group(search.SourceUSOFAC, "1234")
+	require.True(t, ok)
+	g, _ := group(search.SourceEUCSL, "eu-1")
+	require.Equal(t, acme, g)
+	_, ok = group(search.SourceUKCSL, "uk-1")
+	require.False(t, ok)
+
+	vessel, ok := group(search.SourceUSOFAC, "v-1")
+	require.True(t, ok)
+	g, _ = group(search.SourceUKCSL, "v-2")
+	require.Equal(t, vessel, g)
+
+	reasons := make(map[entityKey]string)
+	for _, member := range links.members[maduro] {
+		reasons[keyOf(entities[member.entity])] = member.linkedBy
+	}
+	require.Equal(t, linkedByGovernmentID, reasons[entityKey{search.SourceUKCSL, "VEN0001"}])
+	require.Equal(t, linkedByName, reasons[entityKey{search.SourceEUCSL, "eu-5764"}])
+}
+
+func TestService_SearchConsolidate(t *testing.T) {
+	ctx := context.Background()
+
+	svc := NewService(log.NewTestLogger())
+	svc.UpdateEntities(linkedEntities())
+
+	query := search.Entity[search.Value]{
+		Name:   "Nicolas Maduro Moros",
+		Type:   search.EntityPerson,
+		Person: &search.Person{Name: "Nicolas Maduro Moros"},
+	}
+	results, err := svc.Search(ctx, query, SearchOpts{Limit: 10, MinMatch: 0.9})
+	require.NoError(t, err)
+	require.Len(t, results, 5)
+
+	results, err = svc.Search(ctx, query, SearchOpts{Limit: 10, MinMatch: 0.9, Consolidate: true})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		if result.Source == search.SourceUNCSL {
+			require.Empty(t, result.Related)
+			continue
+		}
+		require.Len(t, result.Related, 3)
+		for _, related := range result.Related {
+			require.Greater(t, related.Match, 0.9)
+			require.NotEmpty(t, related.LinkedBy)
+		}
+	}
+
+	// Records which were removed are no longer linked
+	svc.ApplyChanges(EntityChanges{Removed: linkedEntities()[1:4]})
+	results, err = svc.Search(ctx, query, SearchOpts{Limit: 10, MinMatch: 0.9, Consolidate: true})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		require.Empty(t, result.Related)
+	}
+}
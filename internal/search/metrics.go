@@ -0,0 +1,100 @@
+package search
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	searchLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "search_latency_seconds",
+		Help:    "Seconds taken to search the indexed entities",
+		Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+	})
+
+	searchResults = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "search_results_total",
+		Help: "Count of search results returned from each list",
+	}, []string{"source"})
+
+	searchHits = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "search_hits_total",
+		Help: "Count of search results from each list scoring at or above SEARCH_HIT_THRESHOLD",
+	}, []string{"source"})
+
+	exactMatches = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "search_exact_matches_total",
+		Help: "Count of searches answered by entities with the same name as the query, without scoring every entity",
+	})
+
+	truncatedSearches = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "search_truncated_total",
+		Help: "Count of searches which ran out of time or candidates before scoring every entity",
+	})
+
+	rerankErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "search_rerank_errors_total",
+		Help: "Count of searches whose results kept their deterministic order as the re-ranker failed",
+	})
+
+	indexBuildDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "search_index_build_seconds",
+		Help:    "Seconds taken to build and validate each search index before it's swapped in",
+		Buckets: []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	})
+
+	indexSwaps = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "search_index_swaps_total",
+		Help: "Count of search indexes built by their result (swapped in, or rejected keeping the current index)",
+	}, []string{"result"})
+
+	cacheLookups = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "search_cache_lookups_total",
+		Help: "Count of searches looked up in the result cache by their result (hit or miss)",
+	}, []string{"result"})
+)
+
+const (
+	defaultHitThreshold = 0.95
+)
+
+// hitThreshold is the score a result needs to be counted as a hit
+var hitThreshold = func(fromEnv string) float64 {
+	if fromEnv == "" {
+		return defaultHitThreshold
+	}
+	n, err := strconv.ParseFloat(fromEnv, 64)
+	if err != nil {
+		panic(fmt.Sprintf("ERROR: parsing SEARCH_HIT_THRESHOLD=%q failed: %v", fromEnv, err)) //nolint:forbidigo
+	}
+	return n
+}(strings.TrimSpace(os.Getenv("SEARCH_HIT_THRESHOLD")))
+
+func recordSearch(start time.Time, results []search.SearchedEntity[search.Value]) {
+	searchLatency.Observe(time.Since(start).Seconds())
+
+	for _, res := range results {
+		source := string(res.Source)
+
+		searchResults.WithLabelValues(source).Inc()
+		if res.Match >= hitThreshold {
+			searchHits.WithLabelValues(source).Inc()
+		}
+	}
+}
+
+func recordCacheLookup(hit bool) {
+	if hit {
+		cacheLookups.WithLabelValues("hit").Inc()
+	} else {
+		cacheLookups.WithLabelValues("miss").Inc()
+	}
+}
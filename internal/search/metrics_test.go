@@ -0,0 +1,31 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Metrics(t *testing.T) {
+	ctx := context.Background()
+	svc := testService(t)
+
+	source := string(search.SourceUSOFAC)
+	results := testutil.ToFloat64(searchResults.WithLabelValues(source))
+	hits := testutil.ToFloat64(searchHits.WithLabelValues(source))
+
+	found, err := svc.Search(ctx, search.Entity[search.Value]{
+		CryptoAddresses: []search.CryptoAddress{
+			{Currency: "XBT", Address: "12VrYZgS1nmf9KHHped24xBb1aLLRpV2cT"},
+		},
+	}, SearchOpts{Limit: 10, MinMatch: 0.01})
+	require.NoError(t, err)
+	require.NotEmpty(t, found)
+
+	require.Equal(t, results+float64(len(found)), testutil.ToFloat64(searchResults.WithLabelValues(source)))
+	require.Greater(t, testutil.ToFloat64(searchHits.WithLabelValues(source)), hits)
+}
@@ -0,0 +1,80 @@
+package search
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// MinMatchConfig sets the lowest score of results from each source list, such as a stricter minimum for the
+// Denied Persons List than for the SDN list. It applies to searches which don't set their own MinMatch.
+type MinMatchConfig struct {
+	Sources map[search.SourceList]float64
+}
+
+// Validate returns an error when the minimum of any list is out of range
+func (c MinMatchConfig) Validate() error {
+	for source, minMatch := range c.Sources {
+		if err := validateMinMatch(minMatch); err != nil {
+			return fmt.Errorf("%s: %w", source, err)
+		}
+	}
+	return nil
+}
+
+func validateMinMatch(minMatch float64) error {
+	if math.IsNaN(minMatch) || minMatch < 0 || minMatch > 1 {
+		return fmt.Errorf("minMatch of %v must be between 0 and 1", minMatch)
+	}
+	return nil
+}
+
+func copyMinMatches(sources map[search.SourceList]float64) map[search.SourceList]float64 {
+	out := make(map[search.SourceList]float64, len(sources))
+	for source, minMatch := range sources {
+		if minMatch > 0 {
+			out[source] = minMatch
+		}
+	}
+	return out
+}
+
+func (s *service) ListMinMatches() map[search.SourceList]float64 {
+	return copyMinMatches(s.current.Load().minMatches)
+}
+
+func (s *service) SetListMinMatch(list search.SourceList, minMatch float64) error {
+	if err := validateMinMatch(minMatch); err != nil {
+		return err
+	}
+
+	s.updates.Lock()
+	defer s.updates.Unlock()
+
+	// Searches may be reading the current minimums, so the next index gets a copy
+	next := s.current.Load().with()
+	next.minMatches = copyMinMatches(next.minMatches)
+	if minMatch == 0 {
+		delete(next.minMatches, list)
+	} else {
+		next.minMatches[list] = minMatch
+	}
+	s.current.Store(next)
+	return nil
+}
+
+func (s *service) SetMinMatches(conf MinMatchConfig) error {
+	if err := conf.Validate(); err != nil {
+		return err
+	}
+	minMatches := copyMinMatches(conf.Sources)
+
+	s.updates.Lock()
+	defer s.updates.Unlock()
+
+	next := s.current.Load().with()
+	next.minMatches = minMatches
+	s.current.Store(next)
+	return nil
+}
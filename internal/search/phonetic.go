@@ -0,0 +1,67 @@
+package search
+
+import (
+	"math"
+	"slices"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// phoneticIndex maps the Soundex code of each term of entity names to the entities with them, so names spelled
+// differently but sounding alike ("Mohammed" and "Muhamad") are scored when too few of their trigrams are shared.
+//
+// Codes are read from the PhoneticCodes of entities, which are only stored when the service indexes them.
+type phoneticIndex struct {
+	codes map[string][]int // index into service.entities, in order
+}
+
+func newPhoneticIndex(entities []search.Entity[search.Value]) phoneticIndex {
+	return phoneticIndex{
+		codes: buildPostings(entities, positionOf, phoneticEntries),
+	}
+}
+
+func (x phoneticIndex) update(changes positionChanges) phoneticIndex {
+	return phoneticIndex{
+		codes: updatePostings(x.codes, changes, positionOf, phoneticEntries),
+	}
+}
+
+func phoneticEntries(idx int, entity search.Entity[search.Value], fn func(string, int)) {
+	for _, code := range distinctCodes(entity.PhoneticCodes) {
+		fn(code, idx)
+	}
+}
+
+// similar returns the entities sharing at least overlap (between 0 and 1) of the codes of the query's terms
+func (x phoneticIndex) similar(query search.Entity[search.Value], overlap float64, entities int) []int {
+	codes := distinctCodes(query.PhoneticCodes)
+	if len(codes) == 0 || len(x.codes) == 0 {
+		return nil
+	}
+	need := int32(math.Ceil(overlap * float64(len(codes))))
+
+	counts := make([]int32, entities)
+	for _, code := range codes {
+		for _, idx := range x.codes[code] {
+			counts[idx]++
+		}
+	}
+	out := []int{}
+	for idx, count := range counts {
+		if count > 0 && count >= need {
+			out = append(out, idx)
+		}
+	}
+	return out
+}
+
+// distinctCodes returns each code of terms once, in sorted order
+func distinctCodes(terms map[string]string) []string {
+	out := make([]string, 0, len(terms))
+	for _, code := range terms {
+		out = append(out, code)
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
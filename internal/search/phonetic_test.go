@@ -0,0 +1,92 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func phoneticEntities() []search.Entity[search.Value] {
+	person := func(sourceID, name string) search.Entity[search.Value] {
+		return search.Entity[search.Value]{
+			Name:     name,
+			Type:     search.EntityPerson,
+			Source:   search.SourceUSOFAC,
+			SourceID: sourceID,
+			Person:   &search.Person{Name: name},
+		}
+	}
+	return []search.Entity[search.Value]{
+		person("1", "Muhamad Ali Hasan"),
+		person("2", "Ahmad Karimi"),
+	}
+}
+
+func TestPhoneticIndex(t *testing.T) {
+	entities := phoneticEntities()
+	for i := range entities {
+		entities[i] = search.WithPhoneticCodes(entities[i])
+	}
+	phonetic := newPhoneticIndex(entities)
+
+	query := search.WithPhoneticCodes(search.Entity[search.Value]{Name: "Mohammed Ali Hassan"})
+	require.Equal(t, []int{0}, phonetic.similar(query, 0.9, len(entities)))
+	require.Empty(t, phonetic.similar(search.WithPhoneticCodes(search.Entity[search.Value]{Name: "Zed"}), 0.5, len(entities)))
+
+	// Entities without codes aren't indexed
+	require.Empty(t, newPhoneticIndex(phoneticEntities()).codes)
+	require.Nil(t, newPhoneticIndex(phoneticEntities()).similar(query, 0.5, len(entities)))
+}
+
+func TestService_SearchPhonetic(t *testing.T) {
+	ctx := context.Background()
+	query := search.Entity[search.Value]{
+		Name:   "Mohammed Aly Hassan",
+		Type:   search.EntityPerson,
+		Person: &search.Person{Name: "Mohammed Aly Hassan"},
+	}
+	sourceIDs := func(svc Service, opts SearchOpts) []string {
+		t.Helper()
+
+		opts.Limit, opts.MinMatch = 10, 0.5
+		results, err := svc.Search(ctx, query, opts)
+		require.NoError(t, err)
+
+		var out []string
+		for _, result := range results {
+			out = append(out, result.SourceID)
+		}
+		return out
+	}
+
+	// The name is spelled too differently to share enough trigrams with the query
+	svc := NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{MinTrigramOverlap: 0.6})
+	svc.UpdateEntities(phoneticEntities())
+	require.Empty(t, sourceIDs(svc, SearchOpts{}))
+
+	// Names which sound alike are scored
+	svc = NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{MinTrigramOverlap: 0.6, Phonetic: true})
+	svc.UpdateEntities(phoneticEntities())
+	require.Equal(t, []string{"1"}, sourceIDs(svc, SearchOpts{}))
+
+	disabled := false
+	require.Empty(t, sourceIDs(svc, SearchOpts{Phonetic: &disabled}))
+
+	// A profile can match names which sound alike, which indexes their codes
+	enabled := true
+	svc = NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{
+		MinTrigramOverlap: 0.6,
+		Profiles: ProfilesConfig{
+			Profiles: map[string]Profile{
+				"phonetic": {Phonetic: &enabled},
+			},
+		},
+	})
+	svc.UpdateEntities(phoneticEntities())
+	require.Equal(t, []string{"1"}, sourceIDs(svc, SearchOpts{Profile: "phonetic"}))
+	require.Empty(t, sourceIDs(svc, SearchOpts{}))
+}
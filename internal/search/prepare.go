@@ -0,0 +1,208 @@
+package search
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/internal/tracing"
+	"github.com/moov-io/watchman/pkg/address"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// PrepareEntity splits the addresses of an entity written on one line into their components, normalizes
+// its countries to ISO-3166 codes and runs each of its names
+// through pipeline. The entity's fields are copied before they're modified, so entity itself is left unchanged.
+func PrepareEntity(pipeline *prepare.Pipeline, entity search.Entity[search.Value]) search.Entity[search.Value] {
+	return prepareEntity(pipeline, entity, nil)
+}
+
+// PrepareEntities runs PrepareEntity over each entity in place, recording a span of how long each stage of
+// pipeline took over all of them
+func PrepareEntities(ctx context.Context, pipeline *prepare.Pipeline, entities []search.Entity[search.Value]) {
+	_, span := tracing.Start(ctx, "prepare", attribute.Int("entities", len(entities)))
+	defer span.End()
+
+	var timings prepare.StageTimings
+	if span.IsRecording() {
+		if len(entities) > 0 {
+			span.SetAttributes(attribute.String("list", string(entities[0].Source)))
+		}
+		if pipeline != nil {
+			timings = make(prepare.StageTimings)
+		}
+	}
+	for i := range entities {
+		entities[i] = prepareEntity(pipeline, entities[i], timings)
+	}
+	for _, stage := range prepare.Stages() {
+		if took, ok := timings[stage]; ok {
+			span.SetAttributes(attribute.Float64("prepare.stage."+string(stage)+".seconds", took.Seconds()))
+		}
+	}
+}
+
+func prepareEntity(pipeline *prepare.Pipeline, entity search.Entity[search.Value], timings prepare.StageTimings) search.Entity[search.Value] {
+	entity = search.NormalizeCountries(splitAddresses(entity))
+	if pipeline == nil {
+		return entity
+	}
+
+	opts := prepare.PrepareOptions{
+		Individual: entity.Type == search.EntityPerson,
+		Timings:    timings,
+	}
+	if len(entity.Addresses) > 0 {
+		opts.Country = entity.Addresses[0].Country
+	}
+
+	run := func(name string) string {
+		if name == "" {
+			return name
+		}
+		return pipeline.Prepare(name, opts)
+	}
+	runAll := func(names []string) []string {
+		if len(names) == 0 {
+			return names
+		}
+		out := make([]string, len(names))
+		for i := range names {
+			out[i] = run(names[i])
+		}
+		return out
+	}
+
+	entity.Name = run(entity.Name)
+	entity.WeakAltNames = runAll(entity.WeakAltNames)
+
+	if entity.Person != nil {
+		person := *entity.Person
+		person.Name = run(person.Name)
+		person.AltNames = runAll(person.AltNames)
+		entity.Person = &person
+	}
+	if entity.Business != nil {
+		business := *entity.Business
+		business.Name = run(business.Name)
+		business.AltNames = runAll(business.AltNames)
+		entity.Business = &business
+	}
+	if entity.Organization != nil {
+		org := *entity.Organization
+		org.Name = run(org.Name)
+		org.AltNames = runAll(org.AltNames)
+		entity.Organization = &org
+	}
+	if entity.Aircraft != nil {
+		aircraft := *entity.Aircraft
+		aircraft.Name = run(aircraft.Name)
+		aircraft.AltNames = runAll(aircraft.AltNames)
+		entity.Aircraft = &aircraft
+	}
+	if entity.Vessel != nil {
+		vessel := *entity.Vessel
+		vessel.Name = run(vessel.Name)
+		vessel.AltNames = runAll(vessel.AltNames)
+		entity.Vessel = &vessel
+	}
+
+	return entity
+}
+
+// PrepareQuery is a name to run through preparation stages without searching, see Service.PrepareName
+type PrepareQuery struct {
+	Name string
+	Type search.EntityType
+
+	// Country picks the language of stopwords when the name's language can't be detected
+	Country string
+
+	// Stages are run in order over the name, as they are with SearchOpts.Prepare. Each stage is run on its
+	// own over the name when it's empty.
+	Stages []prepare.Stage
+}
+
+// NamePreparation is the output of each stage run over a name, along with the terms it's compared with
+type NamePreparation struct {
+	Name string            `json:"name"`
+	Type search.EntityType `json:"type,omitempty"`
+
+	Stages []prepare.StageOutput `json:"stages"`
+
+	// Prepared is the name a search would compare, which is the name itself when no stages are chosen
+	Prepared string `json:"prepared"`
+
+	search.NameTerms
+}
+
+func (s *service) PrepareName(ctx context.Context, query PrepareQuery) (NamePreparation, error) {
+	if query.Name == "" {
+		return NamePreparation{}, errors.New("missing name")
+	}
+	opts := prepare.PrepareOptions{
+		Individual: query.Type == search.EntityPerson,
+		Country:    search.NormalizeCountry(query.Country),
+	}
+	out := NamePreparation{
+		Name:     query.Name,
+		Type:     query.Type,
+		Prepared: query.Name,
+	}
+
+	if len(query.Stages) == 0 {
+		for _, stage := range prepare.Stages() {
+			pipeline, err := prepare.NewPipeline(prepare.PipelineConfig{
+				Individual: []prepare.Stage{stage},
+				Entity:     []prepare.Stage{stage},
+			})
+			if err != nil {
+				return NamePreparation{}, err
+			}
+			out.Stages = append(out.Stages, pipeline.Trace(query.Name, opts)...)
+		}
+	} else {
+		pipeline, err := prepare.NewPipeline(prepare.PipelineConfig{
+			Individual: query.Stages,
+			Entity:     query.Stages,
+		})
+		if err != nil {
+			return NamePreparation{}, fmt.Errorf("prepare stages: %w", err)
+		}
+		out.Stages = pipeline.Trace(query.Name, opts)
+		out.Prepared = pipeline.Prepare(query.Name, opts)
+	}
+
+	out.NameTerms = search.ExplainNameTerms(out.Prepared, search.SimilarityConfig{
+		WeakTerms: s.weakTerms,
+	})
+	return out, nil
+}
+
+// splitAddresses parses the addresses which a list wrote on one line, such as "Skorepka 1058/8, Prague 110 00",
+// into their components so they're compared component by component
+func splitAddresses(entity search.Entity[search.Value]) search.Entity[search.Value] {
+	var addresses []search.Address
+	for i, addr := range entity.Addresses {
+		if addr.Line2 != "" || addr.City != "" || addr.State != "" || addr.PostalCode != "" || !strings.Contains(addr.Line1, ",") {
+			continue
+		}
+		if addresses == nil {
+			addresses = slices.Clone(entity.Addresses)
+		}
+		parsed := address.ParseAddress(addr.Line1)
+		parsed.Country = cmp.Or(parsed.Country, addr.Country)
+		parsed.Latitude, parsed.Longitude = addr.Latitude, addr.Longitude
+		addresses[i] = parsed
+	}
+	if addresses != nil {
+		entity.Addresses = addresses
+	}
+	return entity
+}
@@ -0,0 +1,161 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/normalize"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrepareEntity(t *testing.T) {
+	pipeline, err := prepare.NewPipeline(prepare.PipelineConfig{
+		Individual: []prepare.Stage{prepare.StageReorder, prepare.StagePunctuation},
+		Entity:     []prepare.Stage{prepare.StageCompanyTitles},
+	})
+	require.NoError(t, err)
+
+	person := search.Entity[search.Value]{
+		Name: "MADURO MOROS, Nicolas",
+		Type: search.EntityPerson,
+		Person: &search.Person{
+			Name:     "MADURO MOROS, Nicolas",
+			AltNames: []string{"MADURO, Nico"},
+		},
+	}
+	got := PrepareEntity(pipeline, person)
+	require.Equal(t, "nicolas maduro moros", got.Name)
+	require.Equal(t, "nicolas maduro moros", got.Person.Name)
+	require.Equal(t, []string{"nico maduro"}, got.Person.AltNames)
+
+	// The original entity is unchanged
+	require.Equal(t, "MADURO MOROS, Nicolas", person.Person.Name)
+	require.Equal(t, []string{"MADURO, Nico"}, person.Person.AltNames)
+
+	business := search.Entity[search.Value]{
+		Name: "MKS INTERNATIONAL CO. LTD.",
+		Type: search.EntityBusiness,
+		Business: &search.Business{
+			Name: "MKS INTERNATIONAL CO. LTD.",
+		},
+	}
+	got = PrepareEntity(pipeline, business)
+	require.Equal(t, "MKS INTERNATIONAL", got.Name)
+	require.Equal(t, "MKS INTERNATIONAL", got.Business.Name)
+
+	require.Equal(t, business, PrepareEntity(nil, business))
+
+	// Countries are normalized without a pipeline
+	person.Addresses = []search.Address{{City: "Caracas", Country: "Venezuela"}}
+	person.Person.Nationalities = []string{"Venezuela", "VEN"}
+	got = PrepareEntity(nil, person)
+	require.Equal(t, "VE", got.Addresses[0].Country)
+	require.Equal(t, []string{"VE"}, got.Person.Nationalities)
+	require.Equal(t, "Venezuela", person.Addresses[0].Country)
+
+	// Addresses written on one line are split into their components
+	business.Addresses = []search.Address{
+		{Line1: "Skorepka 1058/8, Prague 110 00, Czech Republic"},
+		{Line1: "Ul. Tverskaya 7", City: "Moscow", Country: "Russia"},
+	}
+	got = PrepareEntity(nil, business)
+	require.Equal(t, []search.Address{
+		{Line1: "Skorepka 1058/8", City: "Prague", PostalCode: "110 00", Country: "CZ"},
+		{Line1: "Ul. Tverskaya 7", City: "Moscow", Country: "RU"},
+	}, got.Addresses)
+	require.Equal(t, "Skorepka 1058/8, Prague 110 00, Czech Republic", business.Addresses[0].Line1)
+}
+
+func TestService_PrepareName(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(log.NewTestLogger())
+
+	got, err := svc.PrepareName(ctx, PrepareQuery{
+		Name:   "AL-ZAWAHIRI, Dr. Ayman",
+		Type:   search.EntityPerson,
+		Stages: []prepare.Stage{prepare.StageReorder, prepare.StageHonorifics},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []prepare.StageOutput{
+		{Stage: prepare.StageReorder, Name: "Dr. Ayman AL-ZAWAHIRI"},
+		{Stage: prepare.StageHonorifics, Name: "Ayman AL-ZAWAHIRI"},
+	}, got.Stages)
+	require.Equal(t, "Ayman AL-ZAWAHIRI", got.Prepared)
+	require.Equal(t, "ayman al zawahiri", got.Normalized)
+	require.Equal(t, []string{"al"}, got.Dropped)
+	require.Len(t, got.Terms, 2)
+	require.Equal(t, search.NameTerm{Term: "ayman", Soundex: "A550", Weight: 1}, got.Terms[0])
+
+	// Without stages each is run on its own, and the name is compared as it's written
+	got, err = svc.PrepareName(ctx, PrepareQuery{Name: "Global Trading LLC", Type: search.EntityBusiness})
+	require.NoError(t, err)
+	require.Len(t, got.Stages, len(prepare.Stages()))
+	require.Equal(t, "Global Trading LLC", got.Prepared)
+	for _, stage := range got.Stages {
+		if stage.Stage == prepare.StageCompanyTitles {
+			require.Equal(t, "Global Trading", stage.Name)
+		}
+	}
+	require.True(t, got.Terms[1].Weak)
+	require.Less(t, got.Terms[1].Weight, 1.0)
+
+	_, err = svc.PrepareName(ctx, PrepareQuery{})
+	require.ErrorContains(t, err, "missing name")
+}
+
+func TestAPI_prepareName(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), NewService(log.NewTestLogger())).AppendRoutes(router)
+
+	req := httptest.NewRequest("GET", "/v2/prepare?name=Bob+SMITH&type=person&prepare=punctuation", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var got NamePreparation
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	require.Equal(t, "bob smith", got.Prepared)
+	require.Equal(t, [][]string{{"robert", "smith"}}, got.Nicknames)
+
+	req = httptest.NewRequest("GET", "/v2/prepare?name=Bob&prepare=shout", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "unknown prepare stage")
+}
+
+func TestAPI_normalizeNames(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), NewService(log.NewTestLogger())).AppendRoutes(router)
+
+	req := httptest.NewRequest("GET", "/v2/normalize?name=MADURO+MOROS,+Nicolas&name=Dr.+Ayman+AL-ZAWAHIRI&type=person&prepare=reorder,honorifics", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var got normalizeResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	require.Len(t, got.Names, 2)
+	require.Equal(t, "nicolas maduro moros", got.Names[0].Normalized)
+	require.Equal(t, "ayman al zawahiri", got.Names[1].Normalized)
+
+	// Clients normalizing names the same way get the same version
+	normalizer, err := normalize.New(normalize.Options{Type: search.EntityPerson, Stages: []string{"reorder", "honorifics"}})
+	require.NoError(t, err)
+	require.Equal(t, normalizer.Version(), got.Version)
+
+	for _, path := range []string{"/v2/normalize?type=person", "/v2/normalize?name=Bob&prepare=shout"} {
+		req = httptest.NewRequest("GET", path, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusBadRequest, w.Code, path)
+	}
+}
@@ -0,0 +1,166 @@
+package search
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/auth"
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// Profile is a named set of scoring options, such as the weights and minimum score of one of several flows screened
+// by the same deployment. Each option applies to the searches using the profile which don't set it themselves.
+type Profile struct {
+	// Weights take precedence over the service's weights, overall or by entity type
+	Weights WeightsConfig
+
+	// MinMatch is the lowest score of results, which overrides the minimum of each list when it's set
+	MinMatch float64
+
+	// Prepare runs these stages, in order, over the query's names before they're compared
+	Prepare []prepare.Stage
+
+	// TypoTolerance replaces the service's tolerance of typos in name terms, see search.TypoTolerance
+	TypoTolerance search.TypoTolerance
+
+	// Phonetic replaces whether the service matches name terms which sound alike, see ServiceConfig.Phonetic
+	Phonetic *bool
+
+	// Sources only searches the entities of these lists
+	Sources []search.SourceList
+}
+
+// Validate returns an error when any of the profile's options is invalid
+func (p Profile) Validate() error {
+	if err := p.Weights.Validate(); err != nil {
+		return fmt.Errorf("weights: %w", err)
+	}
+	if err := validateMinMatch(p.MinMatch); err != nil {
+		return err
+	}
+	if _, err := prepare.NewPipeline(prepare.PipelineConfig{Individual: p.Prepare}); err != nil {
+		return err
+	}
+	if err := p.TypoTolerance.Validate(); err != nil {
+		return fmt.Errorf("typo tolerance: %w", err)
+	}
+	return nil
+}
+
+// apply returns opts with the options of p which opts doesn't set, for a search of entityType
+func (p Profile) apply(opts SearchOpts, entityType search.EntityType) SearchOpts {
+	opts.Weights = p.Weights.For(entityType, opts.Weights)
+	if opts.MinMatch <= 0 {
+		opts.MinMatch = p.MinMatch
+	}
+	if len(opts.Prepare) == 0 {
+		opts.Prepare = p.Prepare
+	}
+	if len(opts.TypoTolerance) == 0 {
+		opts.TypoTolerance = p.TypoTolerance
+	}
+	if opts.Phonetic == nil {
+		opts.Phonetic = p.Phonetic
+	}
+	if len(opts.Filters.Sources) == 0 {
+		opts.Filters.Sources = p.Sources
+	}
+	return opts
+}
+
+// ErrUnknownProfile is returned by searches using a profile which isn't configured
+var ErrUnknownProfile = errors.New("unknown search profile")
+
+// ProfilesConfig names scoring profiles and chooses which one each search uses. A search naming a profile
+// (SearchOpts.Profile) uses it, otherwise it uses the profile of the API key it was made with, then that of its
+// tenant, then Default. Searches without a profile use the service's options.
+type ProfilesConfig struct {
+	Profiles map[string]Profile
+
+	// Keys are the profile of each API key or OIDC client, by its name
+	Keys map[string]string
+
+	// Tenants are the profile of each tenant's searches, by their tenant ID
+	Tenants map[string]string
+
+	// Default is the profile of the other searches
+	Default string
+}
+
+// Validate returns an error when a profile is invalid, or when a profile is chosen which isn't configured
+func (c ProfilesConfig) Validate() error {
+	for name, profile := range c.Profiles {
+		if err := profile.Validate(); err != nil {
+			return fmt.Errorf("profile %s: %w", name, err)
+		}
+	}
+	for key, name := range c.Keys {
+		if _, err := c.choose(context.Background(), SearchOpts{Profile: name}); err != nil {
+			return fmt.Errorf("API key %s: %w", key, err)
+		}
+	}
+	for tenantID, name := range c.Tenants {
+		if _, err := c.choose(context.Background(), SearchOpts{Profile: name}); err != nil {
+			return fmt.Errorf("tenant %s: %w", tenantID, err)
+		}
+	}
+	if _, err := c.choose(context.Background(), SearchOpts{}); err != nil {
+		return fmt.Errorf("default: %w", err)
+	}
+	return nil
+}
+
+// phonetic returns if any profile matches name terms which sound alike, so their codes need to be indexed
+func (c ProfilesConfig) phonetic() bool {
+	for _, profile := range c.Profiles {
+		if profile.Phonetic != nil && *profile.Phonetic {
+			return true
+		}
+	}
+	return false
+}
+
+// choose returns the name of the profile a search uses, which is empty when it doesn't use one. Names, API keys
+// and tenants are matched ignoring case, as config files are read with lowercase keys.
+func (c ProfilesConfig) choose(ctx context.Context, opts SearchOpts) (string, error) {
+	name := opts.Profile
+	if name == "" {
+		if client := auth.ClientFrom(ctx); client != nil {
+			name = lookupFold(c.Keys, client.Name)
+		}
+	}
+	if name == "" && opts.TenantID != "" {
+		name = lookupFold(c.Tenants, opts.TenantID)
+	}
+	name = cmp.Or(name, c.Default)
+	if name == "" {
+		return "", nil
+	}
+
+	for configured := range c.Profiles {
+		if strings.EqualFold(configured, name) {
+			return configured, nil
+		}
+	}
+	return "", fmt.Errorf("%w %q", ErrUnknownProfile, name)
+}
+
+func lookupFold(m map[string]string, key string) string {
+	if value, exists := m[key]; exists {
+		return value
+	}
+	for k, value := range m {
+		if strings.EqualFold(k, key) {
+			return value
+		}
+	}
+	return ""
+}
+
+func (s *service) SearchProfile(ctx context.Context, opts SearchOpts) (string, error) {
+	return s.profiles.choose(ctx, opts)
+}
@@ -0,0 +1,160 @@
+package search
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/auth"
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func profileEntities() []search.Entity[search.Value] {
+	entity := func(source search.SourceList, sourceID, name string) search.Entity[search.Value] {
+		return search.Entity[search.Value]{
+			Name:     name,
+			Type:     search.EntityBusiness,
+			Source:   source,
+			SourceID: sourceID,
+			Business: &search.Business{Name: name},
+		}
+	}
+	return []search.Entity[search.Value]{
+		entity(search.SourceUSOFAC, "1", "ACME SHIPPING LIMITED"),
+		entity(search.SourceUSCSL, "2", "ACME SHIPPING LIMITED"),
+		entity(search.SourceUSOFAC, "3", "ACME SHIPPERS"),
+	}
+}
+
+func testProfiles() ProfilesConfig {
+	return ProfilesConfig{
+		Profiles: map[string]Profile{
+			"retail": {
+				MinMatch: 0.9,
+			},
+			"correspondent": {
+				MinMatch: 0.5,
+				Sources:  []search.SourceList{search.SourceUSOFAC},
+			},
+		},
+		Keys:    map[string]string{"Payments": "correspondent"},
+		Tenants: map[string]string{"bank-a": "correspondent"},
+		Default: "retail",
+	}
+}
+
+func TestProfilesConfig_Validate(t *testing.T) {
+	require.NoError(t, testProfiles().Validate())
+	require.NoError(t, ProfilesConfig{}.Validate())
+
+	conf := testProfiles()
+	conf.Keys["onboarding"] = "other"
+	require.ErrorContains(t, conf.Validate(), `API key onboarding: unknown search profile "other"`)
+
+	conf = testProfiles()
+	conf.Default = "other"
+	require.ErrorIs(t, conf.Validate(), ErrUnknownProfile)
+
+	conf = testProfiles()
+	conf.Profiles["retail"] = Profile{MinMatch: 1.5}
+	require.ErrorContains(t, conf.Validate(), "profile retail: minMatch of 1.5 must be between 0 and 1")
+
+	conf = testProfiles()
+	conf.Profiles["retail"] = Profile{Prepare: []prepare.Stage{"shout"}}
+	require.ErrorContains(t, conf.Validate(), `profile retail: unknown prepare stage "shout"`)
+
+	conf = testProfiles()
+	conf.Profiles["retail"] = Profile{TypoTolerance: search.TypoTolerance{{MinLength: 2, Edits: 2}}}
+	require.ErrorContains(t, conf.Validate(), "profile retail: typo tolerance: 2 edits would match every term of 2 characters")
+}
+
+func TestService_ProfileTypoTolerance(t *testing.T) {
+	ctx := context.Background()
+	profiles := ProfilesConfig{
+		Profiles: map[string]Profile{
+			"typos": {TypoTolerance: search.TypoTolerance{{MinLength: 4, Edits: 1}}},
+		},
+	}
+	svc := NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{Profiles: profiles})
+	svc.UpdateEntities(profileEntities())
+
+	query := search.Entity[search.Value]{Name: "Acme Sihpping Limited", Type: search.EntityBusiness}
+	score := func(opts SearchOpts) float64 {
+		t.Helper()
+
+		opts.Limit = 1
+		opts.Filters.Sources = []search.SourceList{search.SourceUSCSL}
+		results, err := svc.Search(ctx, query, opts)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		return results[0].Match
+	}
+	require.Greater(t, score(SearchOpts{Profile: "typos"}), score(SearchOpts{}))
+}
+
+func TestService_Profiles(t *testing.T) {
+	ctx := context.Background()
+	svc := NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{Profiles: testProfiles()})
+	svc.UpdateEntities(profileEntities())
+
+	query := search.Entity[search.Value]{Name: "Acme Shipping", Type: search.EntityBusiness}
+	sourceIDs := func(ctx context.Context, opts SearchOpts) []string {
+		t.Helper()
+
+		results, err := svc.Search(ctx, query, opts)
+		require.NoError(t, err)
+
+		var out []string
+		for _, result := range results {
+			out = append(out, result.SourceID)
+		}
+		return out
+	}
+
+	// The default profile only returns the closest matches
+	require.ElementsMatch(t, []string{"1", "2"}, sourceIDs(ctx, SearchOpts{Limit: 10}))
+
+	// A search's own minMatch takes precedence over its profile's
+	require.ElementsMatch(t, []string{"1", "2", "3"}, sourceIDs(ctx, SearchOpts{Limit: 10, MinMatch: 0.5}))
+
+	// The profile named by a search, or chosen by its API key or tenant, returns weaker matches of the SDN list only
+	require.Equal(t, []string{"1", "3"}, sourceIDs(ctx, SearchOpts{Limit: 10, Profile: "Correspondent"}))
+	require.Equal(t, []string{"1", "3"}, sourceIDs(ctx, SearchOpts{Limit: 10, TenantID: "bank-a"}))
+
+	keyCtx := auth.WithClient(ctx, &auth.Client{Name: "payments"})
+	require.Equal(t, []string{"1", "3"}, sourceIDs(keyCtx, SearchOpts{Limit: 10}))
+
+	profile, err := svc.SearchProfile(keyCtx, SearchOpts{Profile: "retail"})
+	require.NoError(t, err)
+	require.Equal(t, "retail", profile)
+
+	_, err = svc.Search(ctx, query, SearchOpts{Limit: 10, Profile: "other"})
+	require.ErrorIs(t, err, ErrUnknownProfile)
+}
+
+func TestAPI_searchProfile(t *testing.T) {
+	svc := NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{Profiles: testProfiles()})
+	svc.UpdateEntities(profileEntities())
+
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), svc).AppendRoutes(router)
+
+	req := httptest.NewRequest("GET", "/v2/search?name=acme+shipping&type=business&profile=correspondent", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"sourceID":"1"`)
+	require.NotContains(t, w.Body.String(), `"sourceID":"2"`)
+
+	req = httptest.NewRequest("GET", "/v2/search?name=acme+shipping&type=business&profile=other", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), `"error":"problem with v2 search: v2 search: unknown search profile \"other\""`)
+}
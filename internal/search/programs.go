@@ -0,0 +1,84 @@
+package search
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// Program describes a sanctions program of a source list, such as OFAC's SDGT, for building program filters
+type Program struct {
+	// Name is the program as it's passed to the program filter, e.g. SDGT
+	Name   string            `json:"name"`
+	Source search.SourceList `json:"sourceList"`
+
+	// Entities is how many entities of the source are listed under the program
+	Entities int `json:"entities"`
+
+	// FirstEffective and LastEffective are the earliest and latest days a listing under the program took effect,
+	// which are only set for lists publishing them
+	FirstEffective *time.Time `json:"firstEffective,omitempty"`
+	LastEffective  *time.Time `json:"lastEffective,omitempty"`
+}
+
+// countPrograms returns the programs of entities, ordered by source and then name
+func countPrograms(entities []search.Entity[search.Value]) []Program {
+	type programKey struct {
+		source search.SourceList
+		name   string
+	}
+	programs := make(map[programKey]*Program)
+
+	for _, entity := range entities {
+		if entity.SanctionsInfo == nil {
+			continue
+		}
+		for _, name := range entityPrograms(entity) {
+			key := programKey{source: entity.Source, name: name}
+			program, exists := programs[key]
+			if !exists {
+				program = &Program{Name: name, Source: entity.Source}
+				programs[key] = program
+			}
+			program.Entities += 1
+
+			for listed, effective := range entity.SanctionsInfo.ProgramDates {
+				if normalizeProgram(listed) != name {
+					continue
+				}
+				if program.FirstEffective == nil || effective.Before(*program.FirstEffective) {
+					program.FirstEffective = &effective
+				}
+				if program.LastEffective == nil || effective.After(*program.LastEffective) {
+					program.LastEffective = &effective
+				}
+			}
+		}
+	}
+
+	out := make([]Program, 0, len(programs))
+	for _, program := range programs {
+		out = append(out, *program)
+	}
+	slices.SortFunc(out, func(a, b Program) int {
+		if c := strings.Compare(string(a.Source), string(b.Source)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return out
+}
+
+func (s *service) Programs(sources []search.SourceList) []Program {
+	current := s.current.Load()
+
+	out := make([]Program, 0, len(current.programs))
+	for _, program := range current.programs {
+		if len(sources) == 0 || slices.Contains(sources, program.Source) {
+			out = append(out, program)
+		}
+	}
+	return out
+}
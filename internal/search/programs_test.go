@@ -0,0 +1,93 @@
+package search
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Programs(t *testing.T) {
+	listed := func(year int) time.Time {
+		return time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+	svc := NewService(log.NewTestLogger())
+	svc.UpdateEntities([]search.Entity[search.Value]{
+		{
+			Name: "a", Source: search.SourceEUCSL, SourceID: "1",
+			SanctionsInfo: &search.SanctionsInfo{
+				Programs:     []string{"IRQ", "LBY"},
+				ProgramDates: map[string]time.Time{"IRQ": listed(2003)},
+			},
+		},
+		{
+			Name: "b", Source: search.SourceEUCSL, SourceID: "2",
+			SanctionsInfo: &search.SanctionsInfo{
+				Programs:     []string{"irq"},
+				ProgramDates: map[string]time.Time{"irq": listed(2011)},
+			},
+		},
+		{
+			Name: "c", Source: search.SourceUSOFAC, SourceID: "3",
+			SanctionsInfo: &search.SanctionsInfo{Programs: []string{"IRQ2", "SDGT"}},
+		},
+		{Name: "d", Source: search.SourceUSOFAC, SourceID: "4"},
+	})
+
+	programs := svc.Programs(nil)
+	require.Len(t, programs, 4)
+
+	first, last := listed(2003), listed(2011)
+	require.Equal(t, Program{Name: "IRQ", Source: search.SourceEUCSL, Entities: 2, FirstEffective: &first, LastEffective: &last}, programs[0])
+	require.Equal(t, Program{Name: "LBY", Source: search.SourceEUCSL, Entities: 1}, programs[1])
+	require.Equal(t, Program{Name: "IRQ2", Source: search.SourceUSOFAC, Entities: 1}, programs[2])
+	require.Equal(t, Program{Name: "SDGT", Source: search.SourceUSOFAC, Entities: 1}, programs[3])
+
+	programs = svc.Programs([]search.SourceList{search.SourceUSOFAC})
+	require.Len(t, programs, 2)
+	require.Equal(t, "IRQ2", programs[0].Name)
+
+	// Programs are counted again as entities change
+	svc.ApplyChanges(EntityChanges{
+		Removed: []search.Entity[search.Value]{{Source: search.SourceUSOFAC, SourceID: "3"}},
+	})
+	require.Empty(t, svc.Programs([]search.SourceList{search.SourceUSOFAC}))
+}
+
+func TestAPI_programs(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	req := httptest.NewRequest("GET", "/v2/programs?source=US_OFAC", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp programsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.NotEmpty(t, resp.Programs)
+
+	var sdgt *Program
+	for i := range resp.Programs {
+		require.Equal(t, search.SourceUSOFAC, resp.Programs[i].Source)
+		if resp.Programs[i].Name == "SDGT" {
+			sdgt = &resp.Programs[i]
+		}
+	}
+	require.NotNil(t, sdgt)
+	require.Greater(t, sdgt.Entities, 0)
+	require.Nil(t, sdgt.FirstEffective)
+
+	req = httptest.NewRequest("GET", "/v2/programs?source=eu_csl", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"programs":[]}`, w.Body.String())
+}
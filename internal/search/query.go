@@ -0,0 +1,194 @@
+package search
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// queryFields maps each field of a query string, lowercased, to the /v2/search parameter it sets.
+// Filters (country, type, program, list, sectoral, pep and wanted) must match, while other fields are scored.
+var queryFields = map[string]string{
+	"country":    "country",
+	"countries":  "country",
+	"type":       "entityType",
+	"entitytype": "entityType",
+	"program":    "program",
+	"programs":   "program",
+	"list":       "list",
+	"lists":      "list",
+	"sectoral":   "sectoral",
+	"pep":        "pep",
+	"wanted":     "wanted",
+
+	"name":                   "name",
+	"altname":                "altNames",
+	"altnames":               "altNames",
+	"gender":                 "gender",
+	"nationality":            "nationality",
+	"nationalities":          "nationality",
+	"birthdate":              "birthDate",
+	"deathdate":              "deathDate",
+	"title":                  "titles",
+	"titles":                 "titles",
+	"created":                "created",
+	"dissolved":              "dissolved",
+	"address":                "address",
+	"email":                  "email",
+	"phone":                  "phone",
+	"fax":                    "fax",
+	"website":                "website",
+	"cryptoaddress":          "cryptoAddress",
+	"bic":                    "bic",
+	"lei":                    "lei",
+	"aircrafttype":           "aircraftType",
+	"icaocode":               "icaoCode",
+	"serialnumber":           "serialNumber",
+	"tailnumber":             "tailNumber",
+	"model":                  "model",
+	"flag":                   "flag",
+	"built":                  "built",
+	"imonumber":              "imoNumber",
+	"vesseltype":             "vesselType",
+	"mmsi":                   "mmsi",
+	"callsign":               "callSign",
+	"owner":                  "owner",
+	"tonnage":                "tonnage",
+	"grossregisteredtonnage": "grossRegisteredTonnage",
+}
+
+// repeatableParams can be set by both a query string and its own parameter
+var repeatableParams = []string{"country", "entityType", "program", "list", "altNames", "titles", "nationality", "address", "email", "phone", "fax", "website", "cryptoAddress", "bic", "lei"}
+
+// ParseQuery reads a query string such as name:"nicolas maduro" AND country:VE AND type:individual into
+// the /v2/search parameters it's short for. Terms are joined by AND or spaces, values with spaces are quoted,
+// and filters take comma separated values which match any of them (country:VE,CU). Words without a field
+// are part of the name.
+func ParseQuery(input string) (url.Values, error) {
+	terms, err := splitQuery(input)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(url.Values)
+	var names []string
+	for _, term := range terms {
+		if term.field == "" && !term.quoted {
+			switch strings.ToUpper(term.value) {
+			case "AND":
+				continue
+			case "OR", "NOT":
+				return nil, fmt.Errorf("%s isn't supported, match any of several values with commas such as country:VE,CU", term.value)
+			}
+		}
+
+		if term.field == "" {
+			names = append(names, term.value)
+			continue
+		}
+		param, exists := queryFields[strings.ToLower(term.field)]
+		if !exists {
+			return nil, fmt.Errorf("unknown query field %q", term.field)
+		}
+		if term.value == "" {
+			return nil, fmt.Errorf("missing value for %s", term.field)
+		}
+		if param == "name" {
+			names = append(names, term.value)
+			continue
+		}
+		out.Add(param, term.value)
+	}
+	if len(names) > 0 {
+		out.Set("name", strings.Join(names, " "))
+	}
+
+	// Score the query as the type it's filtered to
+	if types, err := ParseEntityTypes(out["entityType"]); err == nil && len(types) == 1 {
+		out.Set("type", string(types[0]))
+	}
+	return out, nil
+}
+
+type queryTerm struct {
+	field  string
+	value  string
+	quoted bool
+}
+
+func splitQuery(input string) ([]queryTerm, error) {
+	var out []queryTerm
+
+	runes := []rune(input)
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		var term queryTerm
+		start := i
+		for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != ':' && runes[i] != '"' {
+			i++
+		}
+		if i < len(runes) && runes[i] == ':' {
+			term.field = string(runes[start:i])
+			i++
+			start = i
+		} else {
+			i = start
+		}
+
+		if i < len(runes) && runes[i] == '"' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("missing closing quote after %q", string(runes[i:]))
+			}
+			term.value = strings.TrimSpace(string(runes[i+1 : end]))
+			term.quoted = true
+			i = end + 1
+		} else {
+			for i < len(runes) && !unicode.IsSpace(runes[i]) {
+				i++
+			}
+			term.value = string(runes[start:i])
+		}
+		out = append(out, term)
+	}
+	return out, nil
+}
+
+// expandQuery replaces the q parameter with the parameters its query string is short for. Parameters
+// which are only read once, such as name, can't be set both ways.
+func expandQuery(values url.Values) (url.Values, error) {
+	input := strings.TrimSpace(values.Get("q"))
+	if input == "" {
+		return values, nil
+	}
+	parsed, err := ParseQuery(input)
+	if err != nil {
+		return nil, fmt.Errorf("q: %w", err)
+	}
+
+	out := make(url.Values, len(values)+len(parsed))
+	for key, vs := range values {
+		if key != "q" {
+			out[key] = vs
+		}
+	}
+	for key, vs := range parsed {
+		if key == "type" && out.Get("type") != "" {
+			continue // the type parameter chooses how the query is scored
+		}
+		if _, exists := out[key]; exists && !slices.Contains(repeatableParams, key) {
+			return nil, fmt.Errorf("q: %s is also set by the %s parameter", key, key)
+		}
+		out[key] = append(out[key], vs...)
+	}
+	return out, nil
+}
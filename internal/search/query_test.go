@@ -0,0 +1,97 @@
+package search
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	values, err := ParseQuery(`name:"nicolas maduro" AND country:VE AND type:individual`)
+	require.NoError(t, err)
+	require.Equal(t, url.Values{
+		"name":       {"nicolas maduro"},
+		"country":    {"VE"},
+		"entityType": {"individual"},
+		"type":       {"person"},
+	}, values)
+
+	// Words without a field are part of the name, and fields are case insensitive
+	values, err = ParseQuery(`nicolas maduro Program:VENEZUELA-EO13850,SDGT pep:true birthDate:1962-11-23`)
+	require.NoError(t, err)
+	require.Equal(t, url.Values{
+		"name":      {"nicolas maduro"},
+		"program":   {"VENEZUELA-EO13850,SDGT"},
+		"pep":       {"true"},
+		"birthDate": {"1962-11-23"},
+	}, values)
+
+	values, err = ParseQuery(`name:"ali hassan" gender:male nationality:IQ`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"IQ"}, values["nationality"])
+
+	// Entities match any of several types, so the query isn't scored as one
+	values, err = ParseQuery(`name:acme type:entity`)
+	require.NoError(t, err)
+	require.Empty(t, values.Get("type"))
+
+	for input, expected := range map[string]string{
+		`name:maduro OR name:chavez`: "OR isn't supported",
+		`name:"maduro`:               "missing closing quote",
+		`height:180`:                 `unknown query field "height"`,
+		`name: country:VE`:           "missing value for name",
+	} {
+		_, err := ParseQuery(input)
+		require.ErrorContains(t, err, expected, input)
+	}
+}
+
+func TestExpandQuery(t *testing.T) {
+	values, err := expandQuery(url.Values{
+		"q":       {`maduro country:VE`},
+		"country": {"CU"},
+		"limit":   {"5"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, url.Values{
+		"name":    {"maduro"},
+		"country": {"CU", "VE"},
+		"limit":   {"5"},
+	}, values)
+
+	_, err = expandQuery(url.Values{"q": {"maduro"}, "name": {"chavez"}})
+	require.ErrorContains(t, err, "name is also set by the name parameter")
+}
+
+func TestAPI_searchQuery(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	get := func(q string) (*httptest.ResponseRecorder, searchResponse) {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/v2/search?limit=25&q="+url.QueryEscape(q), nil))
+
+		var resp searchResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		}
+		return w, resp
+	}
+
+	w, resp := get(`name:"shipping" AND type:business`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NotEmpty(t, resp.Entities)
+	for _, entity := range resp.Entities {
+		require.Equal(t, "business", string(entity.Type))
+	}
+
+	w, _ = get(`name:shipping OR type:business`)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "OR isn't supported")
+}
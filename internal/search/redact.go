@@ -0,0 +1,137 @@
+package search
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// LogRedaction chooses how the personal details of searches, such as names, dates of birth and ID numbers, are
+// written to logs. Details are logged as they were sent when it's empty.
+type LogRedaction string
+
+const (
+	RedactionNone LogRedaction = ""
+
+	// RedactionHash replaces each detail with a short hash of it, so the same value can be followed between log lines
+	RedactionHash LogRedaction = "hash"
+
+	// RedactionRemove replaces each detail with [redacted]
+	RedactionRemove LogRedaction = "redact"
+)
+
+// ParseLogRedaction reads a redaction of hash or redact, or none when value is empty, off or none
+func ParseLogRedaction(value string) (LogRedaction, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "off", "none":
+		return RedactionNone, nil
+	case string(RedactionHash):
+		return RedactionHash, nil
+	case string(RedactionRemove), "remove":
+		return RedactionRemove, nil
+	}
+	return RedactionNone, fmt.Errorf("unknown log redaction %q, expected hash or redact", value)
+}
+
+// QueryID identifies a query by a hash of it. It's logged along with each search, and kept with audit records,
+// so log lines can be matched to the search which wrote them without logging the query.
+func QueryID(query any) string {
+	bs, _ := json.Marshal(query)
+	sum := sha256.Sum256(bs)
+	return hex.EncodeToString(sum[:8])
+}
+
+func (r LogRedaction) enabled() bool {
+	return r == RedactionHash || r == RedactionRemove
+}
+
+// Value returns how a detail is logged
+func (r LogRedaction) Value(value string) string {
+	if value == "" {
+		return value
+	}
+	switch r {
+	case RedactionHash:
+		sum := sha256.Sum256([]byte(value))
+		return "sha256:" + hex.EncodeToString(sum[:6])
+	case RedactionRemove:
+		return "[redacted]"
+	}
+	return value
+}
+
+const (
+	minScrubLength = 3
+)
+
+var (
+	quotedValue = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+)
+
+// Scrub replaces each of values in message, along with anything quoted in it such as invalid values in errors,
+// with how they're logged
+func (r LogRedaction) Scrub(message string, values ...string) string {
+	if !r.enabled() {
+		return message
+	}
+
+	// Longer values are replaced first, so a name isn't left partly replaced by one of its words
+	values = slices.Clone(values)
+	slices.SortFunc(values, func(a, b string) int { return len(b) - len(a) })
+	for _, v := range values {
+		// Shorter values would replace parts of other words
+		if v = strings.TrimSpace(v); len(v) >= minScrubLength {
+			message = strings.ReplaceAll(message, v, r.Value(v))
+		}
+	}
+	return quotedValue.ReplaceAllStringFunc(message, func(quoted string) string {
+		if quoted == `""` || strings.HasPrefix(quoted, `"sha256:`) || quoted == `"[redacted]"` {
+			return quoted
+		}
+		return `"` + r.Value(strings.Trim(quoted, `"`)) + `"`
+	})
+}
+
+// detailParams are the query parameters of searches which hold personal details
+var detailParams = []string{
+	"q", "name", "altNames", "birthDate", "deathDate",
+	"identifier", "imoNumber", "callSign", "mmsi", "tailNumber", "serialNumber", "owner",
+	"email", "emailAddress", "phone", "phoneNumber", "fax", "faxNumber", "website",
+	"address", "cryptoAddress", "text",
+}
+
+// Params returns the parameters of a search with each personal detail replaced with how it's logged, written as
+// key=value pairs sorted by key
+func (r LogRedaction) Params(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for key := range q {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var out []string
+	for _, key := range keys {
+		detail := slices.Contains(detailParams, key)
+		for _, v := range q[key] {
+			if detail {
+				v = r.Value(v)
+			}
+			out = append(out, key+"="+v)
+		}
+	}
+	return strings.Join(out, " ")
+}
+
+// detailValues returns the personal details written in the parameters of a search
+func detailValues(q url.Values) []string {
+	var out []string
+	for _, key := range detailParams {
+		out = append(out, q[key]...)
+	}
+	return out
+}
@@ -0,0 +1,91 @@
+package search
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLogRedaction(t *testing.T) {
+	for input, expected := range map[string]LogRedaction{
+		"":       RedactionNone,
+		"off":    RedactionNone,
+		"HASH":   RedactionHash,
+		"redact": RedactionRemove,
+	} {
+		got, err := ParseLogRedaction(input)
+		require.NoError(t, err, input)
+		require.Equal(t, expected, got, input)
+	}
+
+	_, err := ParseLogRedaction("encrypt")
+	require.ErrorContains(t, err, `unknown log redaction "encrypt"`)
+}
+
+func TestLogRedaction_Scrub(t *testing.T) {
+	message := `problem reading v2 search request: q: missing closing quote after "Nicolas Maduro" for Nicolas Maduro`
+
+	require.Equal(t, message, RedactionNone.Scrub(message, "Nicolas Maduro"))
+
+	got := RedactionRemove.Scrub(message, "Nicolas Maduro")
+	require.Equal(t, `problem reading v2 search request: q: missing closing quote after "[redacted]" for [redacted]`, got)
+
+	// The same value is always hashed the same
+	got = RedactionHash.Scrub(message, "Nicolas Maduro")
+	hashed := RedactionHash.Value("Nicolas Maduro")
+	require.Regexp(t, `^sha256:[0-9a-f]{12}$`, hashed)
+	require.Equal(t, `problem reading v2 search request: q: missing closing quote after "`+hashed+`" for `+hashed, got)
+}
+
+func TestLogRedaction_Params(t *testing.T) {
+	q := url.Values{
+		"name":      []string{"Nicolas Maduro"},
+		"birthDate": []string{"1962-11-23"},
+		"type":      []string{"person"},
+		"limit":     []string{"5"},
+	}
+	require.Equal(t, "birthDate=[redacted] limit=5 name=[redacted] type=person", RedactionRemove.Params(q))
+}
+
+func TestQueryID(t *testing.T) {
+	first := QueryID(IdentifierQuery{Identifier: "A1234567"})
+	require.Len(t, first, 16)
+	require.Equal(t, first, QueryID(IdentifierQuery{Identifier: "A1234567"}))
+	require.NotEqual(t, first, QueryID(IdentifierQuery{Identifier: "A1234568"}))
+}
+
+func TestAPI_searchLogRedaction(t *testing.T) {
+	buf, logger := log.NewBufferLogger()
+
+	router := mux.NewRouter()
+	NewControllerWithConfig(logger, testService(t), ControllerConfig{
+		LogRedaction: RedactionRemove,
+	}).AppendRoutes(router)
+
+	req := httptest.NewRequest("GET", "/v2/search?type=person&debug=yes&name=Nicolas+Maduro&birthDate=1962-11-23&algorithm=other", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	logs := buf.String()
+	require.Contains(t, logs, "query_id=")
+	require.Contains(t, logs, "name=[redacted]")
+	require.Contains(t, logs, "unknown algorithm")
+	require.NotContains(t, logs, "Maduro")
+	require.NotContains(t, logs, "1962")
+
+	buf.Reset()
+	req = httptest.NewRequest("GET", `/v2/search?q=name:"Nicolas+Maduro`, nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	logs = buf.String()
+	require.Contains(t, logs, "missing closing quote")
+	require.NotContains(t, logs, "Maduro")
+}
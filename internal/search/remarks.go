@@ -0,0 +1,282 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/moov-io/watchman/internal/stringscore"
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// RemarksQuery finds entities by the free text of their remarks and sanctions descriptions, such as a place
+// of birth or document number which the older list formats only write there
+type RemarksQuery struct {
+	Text string
+
+	// Mode is RemarksPhrase unless it's set
+	Mode RemarksMode
+
+	// MinMatch is the lowest score of RemarksFuzzy results, which is defaultRemarksMinMatch unless it's set
+	MinMatch float64
+
+	Limit int
+}
+
+// RemarksMode chooses how the text of a RemarksQuery is matched
+type RemarksMode string
+
+const (
+	// RemarksPhrase matches remarks containing the words of the query in order, ignoring case and punctuation
+	RemarksPhrase RemarksMode = "phrase"
+
+	// RemarksFuzzy matches remarks with words similar to each of the query's, in any order
+	RemarksFuzzy RemarksMode = "fuzzy"
+)
+
+// ParseRemarksMode reads a RemarksMode, which is RemarksPhrase when empty
+func ParseRemarksMode(value string) (RemarksMode, error) {
+	switch mode := RemarksMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return RemarksPhrase, nil
+	case RemarksPhrase, RemarksFuzzy:
+		return mode, nil
+	}
+	return "", fmt.Errorf("unknown remarks mode %q", value)
+}
+
+// RemarksMatch is an entity with remarks matching a RemarksQuery
+type RemarksMatch struct {
+	search.SearchedEntity[search.Value]
+
+	// Remark is the part of the entity's remarks which matched, as remarks are separated by semicolons
+	Remark string `json:"remark"`
+}
+
+const (
+	defaultRemarksMinMatch = 0.85
+
+	// remarksWordThreshold is how similar the words of a fuzzy query and a remark must be to count towards its score
+	remarksWordThreshold = 0.85
+)
+
+// remarksIndex maps the words of each entity's remarks to the entities and remarks they're written in
+type remarksIndex struct {
+	words   map[string][]remarkRef
+	remarks [][]remark // by index into service.entities
+}
+
+type remarkRef struct {
+	entity int // index into service.entities
+	remark int // index into remarksIndex.remarks[entity]
+}
+
+// remark is one part of an entity's remarks, as written and as its normalized words
+type remark struct {
+	text  string
+	words []string
+}
+
+func newRemarksIndex(entities []search.Entity[search.Value]) remarksIndex {
+	out := remarksIndex{
+		remarks: make([][]remark, len(entities)),
+	}
+	for i, entity := range entities {
+		out.remarks[i] = remarksOf(entity)
+	}
+	out.words = buildPostings(entities, remarkRef.position, out.entries)
+	return out
+}
+
+// update applies changes to the words of x and resizes its remarks to the given number of entities
+func (x remarksIndex) update(changes positionChanges, entities int) remarksIndex {
+	words := editPostings(x.words, remarkRef.position)
+	words.removeEntries(changes.removed, x.entries)
+
+	out := remarksIndex{
+		remarks: slices.Clone(x.remarks),
+	}
+	for _, c := range changes.removed {
+		out.remarks[c.idx] = nil
+	}
+	if entities < len(out.remarks) {
+		out.remarks = out.remarks[:entities]
+	}
+	for len(out.remarks) < entities {
+		out.remarks = append(out.remarks, nil)
+	}
+	for _, c := range changes.added {
+		out.remarks[c.idx] = remarksOf(c.entity)
+	}
+	words.insertEntries(changes.added, out.entries)
+	out.words = words.lists
+
+	return out
+}
+
+// entries adds each word of the remarks of the entity at idx once per remark
+func (x remarksIndex) entries(idx int, _ search.Entity[search.Value], fn func(string, remarkRef)) {
+	for i, r := range x.remarks[idx] {
+		for j, word := range r.words {
+			if !slices.Contains(r.words[:j], word) {
+				fn(word, remarkRef{entity: idx, remark: i})
+			}
+		}
+	}
+}
+
+func (ref remarkRef) position() int {
+	return ref.entity
+}
+
+// remarksOf returns the remarks of an entity which have words
+func remarksOf(entity search.Entity[search.Value]) []remark {
+	var out []remark
+	for _, text := range entityRemarks(entity) {
+		r := remark{
+			text:  text,
+			words: remarkWords(text),
+		}
+		if len(r.words) > 0 {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// entityRemarks splits the remarks and sanctions description of an entity on semicolons
+func entityRemarks(entity search.Entity[search.Value]) []string {
+	texts := []string{entity.Remarks}
+	if entity.SanctionsInfo != nil && entity.SanctionsInfo.Description != entity.Remarks {
+		texts = append(texts, entity.SanctionsInfo.Description)
+	}
+
+	var out []string
+	for _, text := range texts {
+		for _, part := range strings.Split(text, ";") {
+			if part = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(part), ".")); part != "" && !slices.Contains(out, part) {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
+
+// remarkWords lowercases text and splits it into words of letters and digits
+func remarkWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func (s *service) SearchRemarks(ctx context.Context, query RemarksQuery) ([]RemarksMatch, error) {
+	words := remarkWords(query.Text)
+	if len(words) == 0 {
+		return nil, errors.New("missing remarks text")
+	}
+	mode, err := ParseRemarksMode(string(query.Mode))
+	if err != nil {
+		return nil, err
+	}
+	minMatch := query.MinMatch
+	if minMatch <= 0 {
+		minMatch = defaultRemarksMinMatch
+	}
+
+	current := s.current.Load()
+
+	var out []RemarksMatch
+	best := make(map[int]int) // entity to its position in out
+	add := func(ref remarkRef, score float64) {
+		match := RemarksMatch{
+			Remark: current.remarks.remarks[ref.entity][ref.remark].text,
+		}
+		match.Entity = current.entities[ref.entity]
+		match.Match = score
+
+		if idx, exists := best[ref.entity]; exists {
+			if score > out[idx].Match {
+				out[idx] = match
+			}
+			return
+		}
+		best[ref.entity] = len(out)
+		out = append(out, match)
+	}
+
+	switch mode {
+	case RemarksPhrase:
+		for _, ref := range current.remarks.words[words[0]] {
+			if containsPhrase(current.remarks.remarks[ref.entity][ref.remark].words, words) {
+				add(ref, exactIdentifierMatch)
+			}
+		}
+
+	case RemarksFuzzy:
+		for ref, scores := range current.remarks.similarWords(words) {
+			var total float64
+			for _, score := range scores {
+				total += score
+			}
+			if score := total / float64(len(words)); score >= minMatch {
+				add(ref, score)
+			}
+		}
+	}
+
+	slices.SortFunc(out, func(a, b RemarksMatch) int {
+		if a.Match != b.Match {
+			if a.Match > b.Match {
+				return -1
+			}
+			return 1
+		}
+		if c := strings.Compare(string(a.Source), string(b.Source)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.SourceID, b.SourceID)
+	})
+
+	if query.Limit > 0 && len(out) > query.Limit {
+		out = out[:query.Limit]
+	}
+	return out, nil
+}
+
+// containsPhrase reports if words contains each word of phrase, in order and next to each other
+func containsPhrase(words, phrase []string) bool {
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		if slices.Equal(words[i:i+len(phrase)], phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// similarWords returns the remarks with words similar to those of a query, along with the best score of each
+// query word by its position. Each indexed word is compared once, rather than each word of every remark.
+func (idx remarksIndex) similarWords(query []string) map[remarkRef][]float64 {
+	out := make(map[remarkRef][]float64)
+	for word, refs := range idx.words {
+		for i, q := range query {
+			score := stringscore.JaroWinkler(q, word)
+			if score < remarksWordThreshold {
+				continue
+			}
+			for _, ref := range refs {
+				scores, exists := out[ref]
+				if !exists {
+					scores = make([]float64, len(query))
+					out[ref] = scores
+				}
+				if score > scores[i] {
+					scores[i] = score
+				}
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,99 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntityRemarks(t *testing.T) {
+	entity := search.Entity[search.Value]{
+		Remarks:       "DOB 23 Nov 1962; POB Caracas, Venezuela; Cedula No. 5892464 (Venezuela).",
+		SanctionsInfo: &search.SanctionsInfo{Description: "Subject to Secondary Sanctions; POB Caracas, Venezuela"},
+	}
+	require.Equal(t, []string{
+		"DOB 23 Nov 1962", "POB Caracas, Venezuela", "Cedula No. 5892464 (Venezuela)", "Subject to Secondary Sanctions",
+	}, entityRemarks(entity))
+
+	require.Equal(t, []string{"cedula", "no", "5892464", "venezuela"}, remarkWords("Cedula No. 5892464 (Venezuela)"))
+}
+
+func TestService_SearchRemarks(t *testing.T) {
+	ctx := context.Background()
+	svc := testService(t)
+
+	// 22790 MADURO MOROS, Nicolas lists "Cedula No. 5892464 (Venezuela)" in his remarks
+	results, err := svc.SearchRemarks(ctx, RemarksQuery{Text: "cedula no. 5892464"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "22790", results[0].SourceID)
+	require.Equal(t, "Cedula No. 5892464 (Venezuela)", results[0].Remark)
+	require.InDelta(t, 1.0, results[0].Match, 0.001)
+
+	// Phrases are matched in order, within one remark
+	results, err = svc.SearchRemarks(ctx, RemarksQuery{Text: "5892464 cedula"})
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	results, err = svc.SearchRemarks(ctx, RemarksQuery{Text: "1962 POB Caracas"})
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	// Fuzzy searches match similar words in any order
+	results, err = svc.SearchRemarks(ctx, RemarksQuery{Text: "5892464 cedulla", Mode: RemarksFuzzy})
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	require.Equal(t, "22790", results[0].SourceID)
+	require.Less(t, results[0].Match, 1.0)
+	require.Greater(t, results[0].Match, defaultRemarksMinMatch)
+
+	results, err = svc.SearchRemarks(ctx, RemarksQuery{Text: "POB Caracas", Mode: RemarksFuzzy, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	_, err = svc.SearchRemarks(ctx, RemarksQuery{Text: " ; "})
+	require.ErrorContains(t, err, "missing remarks text")
+
+	_, err = svc.SearchRemarks(ctx, RemarksQuery{Text: "caracas", Mode: "regex"})
+	require.ErrorContains(t, err, `unknown remarks mode "regex"`)
+
+	// Changed entities are searched
+	svc.ApplyChanges(EntityChanges{
+		Added: []search.Entity[search.Value]{
+			{Name: "Example", Type: search.EntityPerson, Source: search.SourceUSCSL, SourceID: "csl-1", Remarks: "Passport 5892464"},
+		},
+	})
+	results, err = svc.SearchRemarks(ctx, RemarksQuery{Text: "Passport 5892464"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "csl-1", results[0].SourceID)
+}
+
+func TestAPI_searchRemarks(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	req := httptest.NewRequest("GET", "/v2/search/remarks?text=Cedula+No.+5892464", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp remarksSearchResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Entities, 1)
+	require.Equal(t, "22790", resp.Entities[0].SourceID)
+
+	req = httptest.NewRequest("GET", "/v2/search/remarks?text=caracas&mode=other", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "unknown remarks mode")
+}
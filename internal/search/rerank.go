@@ -0,0 +1,206 @@
+package search
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// Reranker reorders the results of a search once they're scored, such as with a model trained on how analysts
+// resolved earlier matches. Rerank returns a score between 0 and 1 for each candidate, in the same order, which the
+// results are sorted by instead of their Match.
+//
+// The candidates are the results the search returns, so limits, minMatch and pages of results are chosen by Match
+// as they are without a Reranker.
+type Reranker interface {
+	Name() string
+	Rerank(ctx context.Context, query search.Entity[search.Value], candidates []RerankCandidate) ([]float64, error)
+}
+
+// RerankCandidate is a scored result along with the features of how it compared to the query
+type RerankCandidate struct {
+	Result   search.SearchedEntity[search.Value]
+	Features Features
+}
+
+// Features are named values describing how a result compared to the query:
+//
+//   - match is the result's score, and score is the same before calibration and adjustments
+//   - baseScore, coverage and criticalCoverage are those of search.SimilarityExplanation
+//   - field.<name> is the score of each group of fields compared, and field.<name>.exact is 1 when they matched exactly
+//   - conflicts counts the attributes which differ from the query's, and conflict.<name> is the factor of each
+//   - birthDate.<match> is 1 for how the dates of birth compared, such as birthDate.exact or birthDate.mismatch
+//   - decidedBy.<name> is 1 when one exact identifier decided the score
+//   - altNameMatch and weakAltNameMatch are 1 when an alternate name or weak alias matched best
+//   - sameType is 1 when the result is of the query's entity type
+//
+// Features which don't apply to a result are left out, so they count as zero.
+type Features map[string]float64
+
+// candidateFeatures returns the Features of result, which was scored against query with cfg
+func candidateFeatures(query search.Entity[search.Value], result search.SearchedEntity[search.Value], cfg search.SimilarityConfig) Features {
+	score, explain := search.ExplainSimilarity(query, result.Entity, cfg)
+
+	out := Features{
+		"match":            result.Match,
+		"score":            score,
+		"baseScore":        explain.BaseScore,
+		"coverage":         explain.Coverage,
+		"criticalCoverage": explain.CriticalCoverage,
+		"conflicts":        float64(len(explain.Conflicts)),
+	}
+	for _, field := range explain.Fields {
+		if field.FieldsCompared == 0 {
+			continue
+		}
+		out["field."+field.Field] = field.Score
+		if field.Exact {
+			out["field."+field.Field+".exact"] = 1
+		}
+	}
+	for _, conflict := range explain.Conflicts {
+		out["conflict."+conflict.Field] = conflict.Factor
+	}
+	if result.BirthDateMatch != "" {
+		out["birthDate."+string(result.BirthDateMatch)] = 1
+	}
+	if explain.DecidedBy != "" {
+		out["decidedBy."+explain.DecidedBy] = 1
+	}
+	if result.AltNameMatch != "" {
+		out["altNameMatch"] = 1
+	}
+	if result.WeakAltNameMatch != "" {
+		out["weakAltNameMatch"] = 1
+	}
+	if query.Type != "" && query.Type == result.Type {
+		out["sameType"] = 1
+	}
+	return out
+}
+
+// rerank orders results by the scores of the Reranker, leaving them in the order of their Match when it fails
+func (s *service) rerank(ctx context.Context, query search.Entity[search.Value], cfg search.SimilarityConfig, results []search.SearchedEntity[search.Value]) {
+	if len(results) == 0 {
+		return
+	}
+	candidates := make([]RerankCandidate, len(results))
+	for i := range results {
+		candidates[i] = RerankCandidate{
+			Result:   results[i],
+			Features: candidateFeatures(query, results[i], cfg),
+		}
+	}
+
+	name := s.reranker.Name()
+	scores, err := s.reranker.Rerank(ctx, query, candidates)
+	if err == nil {
+		err = validateRerankScores(scores, len(results))
+	}
+	if err != nil {
+		rerankErrors.Inc()
+		s.logger.Warn().Logf("problem re-ranking results with %s: %v", name, err)
+		return
+	}
+	for i := range results {
+		results[i].Rerank = &search.RerankScore{
+			Model: name,
+			Score: scores[i],
+		}
+	}
+	// Ties keep the order of their Match
+	slices.SortStableFunc(results, func(a, b search.SearchedEntity[search.Value]) int {
+		return cmp.Compare(b.Rerank.Score, a.Rerank.Score)
+	})
+}
+
+func validateRerankScores(scores []float64, expected int) error {
+	if len(scores) != expected {
+		return fmt.Errorf("returned %d scores for %d results", len(scores), expected)
+	}
+	for _, score := range scores {
+		if math.IsNaN(score) || score < 0 || score > 1 {
+			return fmt.Errorf("score of %v must be between 0 and 1", score)
+		}
+	}
+	return nil
+}
+
+// LogisticModel is a Reranker which scores each result with logistic regression over its Features. It's
+// written as JSON, such as:
+//
+//	{"name": "analyst-2024-06", "bias": -6.2, "weights": {"match": 7.5, "field.name": 1.1, "conflicts": -0.9}}
+//
+// Features without a weight are ignored.
+type LogisticModel struct {
+	ModelName string             `json:"name"`
+	Bias      float64            `json:"bias"`
+	Weights   map[string]float64 `json:"weights"`
+}
+
+// LoadLogisticModel reads a LogisticModel from a JSON file
+func LoadLogisticModel(path string) (*LogisticModel, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading rerank model: %w", err)
+	}
+	var model LogisticModel
+	if err := json.Unmarshal(data, &model); err != nil {
+		return nil, fmt.Errorf("reading rerank model %s: %w", path, err)
+	}
+	if err := model.Validate(); err != nil {
+		return nil, fmt.Errorf("rerank model %s: %w", path, err)
+	}
+	return &model, nil
+}
+
+// Validate returns an error when the model has no weights or any isn't a finite number
+func (m *LogisticModel) Validate() error {
+	if len(m.Weights) == 0 {
+		return errors.New("no feature weights")
+	}
+	if math.IsNaN(m.Bias) || math.IsInf(m.Bias, 0) {
+		return fmt.Errorf("bias of %v must be a finite number", m.Bias)
+	}
+	for feature, weight := range m.Weights {
+		if math.IsNaN(weight) || math.IsInf(weight, 0) {
+			return fmt.Errorf("weight of %s of %v must be a finite number", feature, weight)
+		}
+	}
+	return nil
+}
+
+func (m *LogisticModel) Name() string {
+	return cmp.Or(m.ModelName, "logistic")
+}
+
+func (m *LogisticModel) Rerank(ctx context.Context, query search.Entity[search.Value], candidates []RerankCandidate) ([]float64, error) {
+	out := make([]float64, len(candidates))
+	for i := range candidates {
+		out[i] = m.Score(candidates[i].Features)
+	}
+	return out, nil
+}
+
+// Score returns the probability the model gives a result with features
+func (m *LogisticModel) Score(features Features) float64 {
+	// Weights are summed in the same order each time, so equal features always score the same
+	names := make([]string, 0, len(m.Weights))
+	for name := range m.Weights {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	z := m.Bias
+	for _, name := range names {
+		z += m.Weights[name] * features[name]
+	}
+	return 1 / (1 + math.Exp(-z))
+}
@@ -0,0 +1,197 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+// reverseReranker scores results in the reverse order of their Match
+type reverseReranker struct {
+	err error
+}
+
+func (r reverseReranker) Name() string {
+	return "reverse"
+}
+
+func (r reverseReranker) Rerank(ctx context.Context, query search.Entity[search.Value], candidates []RerankCandidate) ([]float64, error) {
+	out := make([]float64, len(candidates))
+	for i, candidate := range candidates {
+		out[i] = 1 - candidate.Features["match"]
+	}
+	return out, r.err
+}
+
+func TestService_Rerank(t *testing.T) {
+	ctx := context.Background()
+	query := search.Entity[search.Value]{
+		Name: "SHIPPING",
+		Type: search.EntityBusiness,
+	}
+	opts := SearchOpts{Limit: 10, MinMatch: 0.5}
+
+	deterministic, err := testService(t).Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Len(t, deterministic, 10)
+
+	svc := NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{Reranker: reverseReranker{}})
+	svc.UpdateEntities(testEntities(t))
+
+	reranked, err := svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Len(t, reranked, 10)
+
+	// The same results are returned in the model's order, keeping their Match
+	matches := make(map[string]float64)
+	for _, result := range deterministic {
+		matches[result.SourceID] = result.Match
+	}
+	for i, result := range reranked {
+		require.Contains(t, matches, result.SourceID)
+		require.InDelta(t, matches[result.SourceID], result.Match, 0.0001)
+		require.Equal(t, "reverse", result.Rerank.Model)
+		require.InDelta(t, 1-result.Match, result.Rerank.Score, 0.0001)
+		if i > 0 {
+			require.LessOrEqual(t, reranked[i-1].Match, result.Match)
+		}
+	}
+	require.InDelta(t, deterministic[len(deterministic)-1].Match, reranked[0].Match, 0.0001)
+
+	t.Run("failing", func(t *testing.T) {
+		svc := NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{
+			Reranker: reverseReranker{err: errors.New("model unavailable")},
+		})
+		svc.UpdateEntities(testEntities(t))
+
+		results, err := svc.Search(ctx, query, opts)
+		require.NoError(t, err)
+		require.Equal(t, deterministic, results)
+	})
+
+	t.Run("sorted by name", func(t *testing.T) {
+		opts := opts
+		opts.Sort = SortByName
+
+		expected, err := testService(t).Search(ctx, query, opts)
+		require.NoError(t, err)
+
+		results, err := svc.Search(ctx, query, opts)
+		require.NoError(t, err)
+		require.Equal(t, expected, results)
+	})
+}
+
+func TestNextPage(t *testing.T) {
+	ctx := context.Background()
+	query := search.Entity[search.Value]{
+		Name: "SHIPPING",
+		Type: search.EntityBusiness,
+	}
+
+	all, err := testService(t).Search(ctx, query, SearchOpts{Limit: 30, MinMatch: 0.5})
+	require.NoError(t, err)
+	require.Len(t, all, 30)
+
+	svc := NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{Reranker: reverseReranker{}})
+	svc.UpdateEntities(testEntities(t))
+
+	// Paging through re-ranked results returns each result once
+	seen := make(map[string]bool)
+	opts := SearchOpts{Limit: 8, MinMatch: 0.5}
+	for len(seen) < len(all) {
+		results, err := svc.Search(ctx, query, opts)
+		require.NoError(t, err)
+
+		page, next := nextPage(SortByScore, results, 7)
+		require.NotEmpty(t, page)
+		for _, result := range page {
+			require.False(t, seen[result.SourceID], result.SourceID)
+			seen[result.SourceID] = true
+		}
+		require.NotEmpty(t, next)
+
+		opts.After, err = ParseCursor(next)
+		require.NoError(t, err)
+	}
+	for _, result := range all {
+		require.True(t, seen[result.SourceID], result.SourceID)
+	}
+
+	page, next := nextPage(SortByScore, all[:5], 7)
+	require.Equal(t, all[:5], page)
+	require.Empty(t, next)
+}
+
+func TestCandidateFeatures(t *testing.T) {
+	query := search.Entity[search.Value]{
+		Name:   "Mohamed Ali Hasan",
+		Type:   search.EntityPerson,
+		Person: &search.Person{Name: "Mohamed Ali Hasan", Gender: search.GenderMale},
+	}
+	result := search.SearchedEntity[search.Value]{
+		Entity: search.Entity[search.Value]{
+			Name:     "Mohammed Ali Hassan",
+			Type:     search.EntityPerson,
+			Source:   search.SourceUSOFAC,
+			SourceID: "1",
+			Person:   &search.Person{Name: "Mohammed Ali Hassan", Gender: search.GenderFemale},
+		},
+		Match:        0.81,
+		AltNameMatch: "M. A. Hassan",
+	}
+	cfg := search.SimilarityConfig{
+		Conflicts: search.ConflictFactors{Gender: 0.5},
+	}
+
+	features := candidateFeatures(query, result, cfg)
+	require.InDelta(t, 0.81, features["match"], 0.0001)
+	require.Greater(t, features["score"], 0.0)
+	require.Greater(t, features["field.name"], 0.0)
+	require.Equal(t, 1.0, features["conflicts"])
+	require.Equal(t, 0.5, features["conflict.gender"])
+	require.Equal(t, 1.0, features["altNameMatch"])
+	require.Equal(t, 1.0, features["sameType"])
+	require.NotContains(t, features, "weakAltNameMatch")
+}
+
+func TestLogisticModel(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "model.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"name": "analyst", "bias": -4, "weights": {"match": 5, "conflicts": -2}}`), 0600))
+
+	model, err := LoadLogisticModel(path)
+	require.NoError(t, err)
+	require.Equal(t, "analyst", model.Name())
+
+	require.InDelta(t, 0.5, model.Score(Features{"match": 0.8}), 0.0001)
+	require.InDelta(t, 0.1192, model.Score(Features{"match": 0.8, "conflicts": 1}), 0.0001)
+	require.InDelta(t, 0.0180, model.Score(Features{"unweighted": 1}), 0.0001)
+
+	scores, err := model.Rerank(context.Background(), search.Entity[search.Value]{}, []RerankCandidate{
+		{Features: Features{"match": 0.8}},
+		{Features: Features{"match": 1}},
+	})
+	require.NoError(t, err)
+	require.Len(t, scores, 2)
+	require.Greater(t, scores[1], scores[0])
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"bias": 1}`), 0600))
+	_, err = LoadLogisticModel(path)
+	require.ErrorContains(t, err, "no feature weights")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"weights": {"match": 1}}`), 0600))
+	model, err = LoadLogisticModel(path)
+	require.NoError(t, err)
+	require.Equal(t, "logistic", model.Name())
+
+	_, err = LoadLogisticModel(filepath.Join(dir, "missing.json"))
+	require.ErrorContains(t, err, "reading rerank model")
+}
@@ -1,72 +1,518 @@
 package search
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
-	"os"
 	"slices"
-	"strconv"
-	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
-	"github.com/moov-io/watchman/internal/indices"
 	"github.com/moov-io/watchman/internal/largest"
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/internal/tracing"
 	"github.com/moov-io/watchman/pkg/search"
 
 	"github.com/moov-io/base/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// ErrAsOfUnsupported is returned by searches as of a time which are made without list versions
+var ErrAsOfUnsupported = errors.New("searching as of a time needs list versions")
+
 type Service interface {
+	// UpdateEntities replaces every indexed entity
 	UpdateEntities(entities []search.Entity[search.Value])
 
+	// ApplyChanges adds, replaces and removes indexed entities without rebuilding the index
+	ApplyChanges(changes EntityChanges)
+
+	// UpdateTenantEntities replaces the entities only searched for a tenant, such as their private deny list
+	UpdateTenantEntities(tenantID string, entities []search.Entity[search.Value])
+
+	// Entities returns a copy of the indexed entities, excluding those of any tenant
+	Entities() []search.Entity[search.Value]
+	LastChanges() AppliedChanges
+
 	Search(ctx context.Context, query search.Entity[search.Value], opts SearchOpts) ([]search.SearchedEntity[search.Value], error)
+	SearchByIdentifier(ctx context.Context, query IdentifierQuery) ([]IdentifierMatch, error)
+	SearchVessels(ctx context.Context, query VesselQuery) ([]VehicleMatch, error)
+	SearchAircraft(ctx context.Context, query AircraftQuery) ([]VehicleMatch, error)
+	SearchCryptoAddress(ctx context.Context, query CryptoAddressQuery) ([]CryptoAddressMatch, error)
+	SearchRemarks(ctx context.Context, query RemarksQuery) ([]RemarksMatch, error)
+
+	// PrepareName runs a name through preparation stages and splits it into the terms it's compared with,
+	// without searching
+	PrepareName(ctx context.Context, query PrepareQuery) (NamePreparation, error)
+
+	ListInfo() ListInfo
+
+	// Programs returns the sanctions programs of the entities from sources, or every source when it's empty
+	Programs(sources []search.SourceList) []Program
+
+	// ListMinMatches returns the lowest score of results from each list with a minimum, see MinMatchConfig
+	ListMinMatches() map[search.SourceList]float64
+
+	// SetListMinMatch changes the lowest score of results from list, removing its minimum when minMatch is zero
+	SetListMinMatch(list search.SourceList, minMatch float64) error
+
+	// SetMinMatches replaces the minimum of every list at once, so no search sees only some of them changed
+	SetMinMatches(conf MinMatchConfig) error
+
+	// SearchProfile returns the name of the scoring profile a search with opts uses, which is empty without one.
+	// See ProfilesConfig.
+	SearchProfile(ctx context.Context, opts SearchOpts) (string, error)
+}
+
+// ScoreAdjuster modifies the scores of indexed entities for a query, such as suppressing known false positives.
+//
+// Adjust returns nil when no indexed entity needs adjusting for the query searched by the tenant. The query is as
+// it was searched, before SearchOpts.Prepare runs over its names.
+type ScoreAdjuster interface {
+	Adjust(tenantID string, query search.Entity[search.Value]) func(index search.Entity[search.Value], score float64) float64
+}
+
+func NewService(logger log.Logger, adjusters ...ScoreAdjuster) Service {
+	return NewServiceWithWeights(logger, WeightsConfig{}, adjusters...)
+}
+
+// NewServiceWithWeights returns a Service which weighs each field of an entity by weights, unless a search
+// sets its own.
+func NewServiceWithWeights(logger log.Logger, weights WeightsConfig, adjusters ...ScoreAdjuster) Service {
+	return NewServiceWithConfig(logger, ServiceConfig{Weights: weights}, adjusters...)
+}
+
+// ServiceConfig changes how a Service scores entities
+type ServiceConfig struct {
+	Weights     WeightsConfig
+	Calibration CalibrationConfig
+	MinMatch    MinMatchConfig
+
+	// Shards is how many parts the entities are split into and searched concurrently, one per core when zero
+	Shards int
+
+	// Algorithm chooses how the names of searches which don't choose an algorithm are compared, which is
+	// Jaro-Winkler when it's empty. See search.Algorithms()
+	Algorithm string
+
+	// MinTrigramOverlap skips scoring the entities whose names share less than this fraction of the trigrams
+	// in a query's name, see trigramIndex. Every entity is scored when it's zero.
+	MinTrigramOverlap float64
+
+	// BirthYearTolerance is how many years apart dates of birth can be and still count a little towards the score,
+	// see search.SimilarityConfig
+	BirthYearTolerance int
+
+	// TypoTolerance lets name terms with a few typos score as the term they're a typo of, see
+	// search.TypoTolerance. Names aren't corrected when it's empty.
+	TypoTolerance search.TypoTolerance
+
+	// Phonetic boosts name terms which sound alike (Soundex), such as "Mohammed" and "Muhamad", and scores the
+	// entities with names which sound like the query's when MinTrigramOverlap would skip them. The codes of names
+	// are computed as they're indexed when it's set, or when a profile sets it.
+	Phonetic bool
+
+	// Conflicts lower the score of a person whose gender or nationality differs from the query's, or of a bank
+	// with other BICs or LEIs
+	Conflicts search.ConflictFactors
+
+	// AltNameBoost raises the score of entities with several names matching the query, see search.SimilarityConfig
+	AltNameBoost float64
+
+	// WeakTerms are the generic words of names, which count for less than others. The defaults of
+	// search.WeakTerms are used when nil.
+	WeakTerms *search.WeakTermSet
+
+	// Budget limits how long each search scores entities for and how many it scores
+	Budget BudgetConfig
+
+	// Reranker reorders the best results of searches sorted by score, which keep their deterministic order when
+	// it's nil
+	Reranker Reranker
+
+	// FinancialIDs pairs the BICs and LEIs of banks, which are added to indexed entities and queries with either
+	FinancialIDs *FinancialIDMapping
+
+	// Profiles are named sets of scoring options chosen by each search, its API key or its tenant
+	Profiles ProfilesConfig
 }
 
-func NewService(logger log.Logger) Service {
-	return &service{
-		logger: logger,
+// NewServiceWithConfig returns a Service which weighs fields by conf.Weights and moves the scores of each list
+// onto a common scale with conf.Calibration.
+func NewServiceWithConfig(logger log.Logger, conf ServiceConfig, adjusters ...ScoreAdjuster) Service {
+	s := &service{
+		logger:      logger,
+		adjusters:   adjusters,
+		weights:     conf.Weights,
+		calibration: conf.Calibration,
+		shards:      cmp.Or(conf.Shards, defaultShards()),
+		algorithm:   conf.Algorithm,
+		overlap:     conf.MinTrigramOverlap,
+		tolerance:   conf.BirthYearTolerance,
+		typos:       conf.TypoTolerance,
+		phonetic:    conf.Phonetic,
+		coded:       conf.Phonetic || conf.Profiles.phonetic(),
+		conflicts:   conf.Conflicts,
+		altBoost:    conf.AltNameBoost,
+		weakTerms:   conf.WeakTerms,
+		budget:      conf.Budget,
+		reranker:    conf.Reranker,
+		profiles:    conf.Profiles,
 	}
+	s.current.Store(&index{
+		financialIDs: conf.FinancialIDs,
+		minMatches:   copyMinMatches(conf.MinMatch.Sources),
+	})
+	return s
+}
+
+// WeightsConfig changes how much each field counts towards the score of a search, which uses
+// search.DefaultWeights for any weight left at zero.
+type WeightsConfig struct {
+	// Default applies to searches for every type of entity
+	Default search.Weights
+
+	// Types override Default for searches of one type of entity, such as a lower address weight for people
+	Types map[search.EntityType]search.Weights
+}
+
+// Validate returns an error when any weight is out of range
+func (c WeightsConfig) Validate() error {
+	if err := c.Default.Validate(); err != nil {
+		return err
+	}
+	for entityType, weights := range c.Types {
+		if err := weights.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", entityType, err)
+		}
+	}
+	return nil
+}
+
+// For returns the weights of a search for entityType, with any set by the search taking precedence
+func (c WeightsConfig) For(entityType search.EntityType, override search.Weights) search.Weights {
+	return override.Or(c.Types[entityType]).Or(c.Default)
 }
 
 type service struct {
-	logger   log.Logger
-	entities []search.Entity[search.Value]
+	logger      log.Logger
+	adjusters   []ScoreAdjuster
+	weights     WeightsConfig
+	calibration CalibrationConfig
+	shards      int
+	algorithm   string
+	overlap     float64
+	tolerance   int
+	typos       search.TypoTolerance
+	phonetic    bool
+	coded       bool // the phonetic codes of names are indexed
+	conflicts   search.ConflictFactors
+	altBoost    float64
+	weakTerms   *search.WeakTermSet
+	budget      BudgetConfig
+	reranker    Reranker
+	profiles    ProfilesConfig
+
+	// current is what searches read. Updates build and validate a new index off to the side, then swap it in, so
+	// searches never wait on them or see an index partway through being built.
+	current atomic.Pointer[index]
 
-	sync.RWMutex // protects entities
+	// updates lets one update at a time build the next index from the current one
+	updates sync.Mutex
 }
 
 func (s *service) UpdateEntities(entities []search.Entity[search.Value]) {
-	s.Lock()
-	defer s.Unlock()
+	// Index builds aren't part of a request, so they're traced on their own
+	_, span := tracing.Start(context.Background(), "search.index", attribute.Int("entities", len(entities)))
+	defer span.End()
+
+	s.updates.Lock()
+	defer s.updates.Unlock()
+
+	start := time.Now()
+	current := s.current.Load()
+
+	// The BIC/LEI mapping is read again when its file has changed since the last time lists were indexed
+	financialIDs := current.financialIDs
+	if reloaded, err := financialIDs.reload(); err != nil {
+		s.logger.Warn().Logf("problem reloading BIC/LEI mapping: %v", err)
+	} else {
+		financialIDs = reloaded
+	}
 
-	s.entities = entities
+	// Copy entities so ApplyChanges doesn't modify the caller's slice, sharing the strings repeated between them
+	entities = internEntities(entities)
+	for i := range entities {
+		entities[i] = s.withPhoneticCodes(financialIDs.enrich(entities[i]))
+	}
+
+	// Every entity was replaced, rather than changed, so none of them are in next.changed
+	next := newIndex(entities, time.Now().In(time.UTC))
+	next.lastChanges = current.lastChanges
+	next.minMatches = current.minMatches
+	next.financialIDs = financialIDs
+	next.tenants = current.tenants
+
+	if err := s.swap(next, start); err != nil {
+		tracing.RecordError(span, err)
+	}
+}
+
+// swap replaces the current index with next once it's validated, and otherwise keeps searching the current index.
+// The caller must hold s.updates.
+func (s *service) swap(next *index, start time.Time) error {
+	if err := next.validate(); err != nil {
+		indexSwaps.WithLabelValues("rejected").Inc()
+		s.logger.Error().LogErrorf("keeping the current search index, rejected an index of %d entities: %v", len(next.entities), err)
+		return err
+	}
+	s.current.Store(next)
+
+	indexBuildDuration.Observe(time.Since(start).Seconds())
+	indexSwaps.WithLabelValues("swapped").Inc()
+	return nil
+}
+
+func (s *service) UpdateTenantEntities(tenantID string, entities []search.Entity[search.Value]) {
+	entities = internEntities(entities)
+	for i := range entities {
+		entities[i] = s.withPhoneticCodes(entities[i])
+	}
+
+	s.updates.Lock()
+	defer s.updates.Unlock()
+
+	// Searches may be reading the current tenants, so the next index gets a copy
+	next := s.current.Load().with()
+	tenants := make(map[string][]search.Entity[search.Value], len(next.tenants)+1)
+	for id, entities := range next.tenants {
+		tenants[id] = entities
+	}
+	if len(entities) == 0 {
+		delete(tenants, tenantID)
+	} else {
+		tenants[tenantID] = entities
+	}
+	next.tenants = tenants
+	s.current.Store(next)
+}
+
+func countLists(entities []search.Entity[search.Value]) map[string]int {
+	lists := make(map[string]int)
+	for _, entity := range entities {
+		lists[string(entity.Source)] += 1
+	}
+	return lists
+}
+
+// ListInfo describes the entities currently held for searching
+type ListInfo struct {
+	Lists     map[string]int `json:"lists"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+func (s *service) ListInfo() ListInfo {
+	return s.current.Load().listInfo
 }
 
 func (s *service) Search(ctx context.Context, query search.Entity[search.Value], opts SearchOpts) ([]search.SearchedEntity[search.Value], error) {
-	// Grab a read-lock over our data
-	s.RLock()
-	defer s.RUnlock()
+	if timeout := s.budget.For(opts).Timeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	profile, err := s.profiles.choose(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("v2 search: %w", err)
+	}
+	if profile != "" {
+		opts = s.profiles.Profiles[profile].apply(opts, query.Type)
+	}
+
+	// The whole search reads the same index, even when a newer one is swapped in before it's done
+	current := s.current.Load()
+
+	ctx, span := tracing.Start(ctx, "search",
+		attribute.String("search.profile", profile),
+		attribute.String("search.algorithm", cmp.Or(opts.Algorithm, "default")),
+		attribute.String("search.entity_type", string(query.Type)),
+		attribute.Int("search.limit", opts.Limit),
+		attribute.Int("search.entities", len(current.entities)),
+	)
+	defer span.End()
 
-	out, err := s.performSearch(ctx, query, opts)
+	if !opts.AsOf.IsZero() {
+		return nil, fmt.Errorf("v2 search: %w", ErrAsOfUnsupported)
+	}
+
+	start := time.Now()
+	out, err := s.performSearch(ctx, current, query, opts)
+	if err == nil && errors.Is(ctx.Err(), context.Canceled) {
+		// Nobody is waiting for the results, while a deadline returns those found before it
+		err = ctx.Err()
+	}
 	if err != nil {
+		tracing.RecordError(span, err)
 		return nil, fmt.Errorf("v2 search: %w", err)
 	}
+	recordSearch(start, out)
+	span.SetAttributes(attribute.Int("search.results", len(out)))
+
 	return out, nil
 }
 
 type SearchOpts struct {
-	Limit    int
+	Limit int
+
+	// MinMatch is the lowest score of a result, which overrides the minimum of each list when it's set
 	MinMatch float64
 
+	// Algorithm chooses how names are compared, see search.Algorithms(). The service's algorithm is used when
+	// it's empty.
+	Algorithm string
+
+	// Explain includes how each result's score was computed
+	Explain bool
+
+	// Highlight includes the words of each result's names which matched the query's name
+	Highlight bool
+
+	// ExactFirst only scores the entities with a name written the same as the query's name, ignoring case,
+	// punctuation, accents and word order, when any of them score above MinMatch. Every entity is scored otherwise.
+	ExactFirst bool
+
+	// Partial only returns the entities with a name containing the query's name, such as "Maduro" for
+	// "Nicolas MADURO MOROS", ignoring case, accents and punctuation
+	Partial bool
+
+	// Consolidate merges the records of the same party on different lists into one result, see linkIndex
+	Consolidate bool
+
+	// Prepare runs these stages, in order, over the query's names before they're compared
+	Prepare []prepare.Stage
+
+	// TypoTolerance replaces the service's tolerance of typos in name terms when it's set
+	TypoTolerance search.TypoTolerance
+
+	// Phonetic replaces whether the service matches name terms which sound alike when it's set, see
+	// ServiceConfig.Phonetic
+	Phonetic *bool
+
+	// Weights override how much each field counts towards the score, for those which are set
+	Weights search.Weights
+
+	// Filters limits which entities are compared against the query
+	Filters SearchFilters
+
+	// TenantID includes the entities of a tenant's custom lists in the results
+	TenantID string
+
+	// Profile names the scoring profile of the search, which otherwise depends on its API key and tenant. See
+	// ProfilesConfig.
+	Profile string
+
+	// Sort orders the results, which is by score when empty
+	Sort SortOrder
+
+	// After returns the results ordered after this cursor, which is the last result of the previous page
+	After *Cursor
+
+	// AsOf searches the entities of each list as they were at this time, rather than the current entities.
+	// It needs the list versions searched by versions.NewSearchService.
+	AsOf time.Time
+
+	// RequireAddressCountry only returns the entities with an address in a country of the query's addresses,
+	// along with those without the country of any address
+	RequireAddressCountry bool
+
+	// Changed only compares the query against the entities added or modified by the last changes applied to the
+	// index (see LastChanges), so names cleared before a refresh can be rescreened against what it changed.
+	// Tenant entities aren't compared, as they aren't part of the lists' changes.
+	Changed bool
+
+	// Timeout and MaxCandidates lower the service's BudgetConfig for this search, returning the best results
+	// scored before either runs out
+	Timeout       time.Duration
+	MaxCandidates int
+
 	RequestID      string
 	DebugSourceIDs []string
 }
 
-func (s *service) performSearch(ctx context.Context, query search.Entity[search.Value], opts SearchOpts) ([]search.SearchedEntity[search.Value], error) {
-	items := largest.NewItems(opts.Limit, opts.MinMatch)
+func (s *service) performSearch(ctx context.Context, current *index, query search.Entity[search.Value], opts SearchOpts) ([]search.SearchedEntity[search.Value], error) {
+	scorer, err := search.NameScorerFor(cmp.Or(opts.Algorithm, s.algorithm))
+	if err != nil {
+		return nil, err
+	}
+	if err := opts.Weights.Validate(); err != nil {
+		return nil, err
+	}
+	cfg := search.SimilarityConfig{
+		NameScorer:            scorer,
+		Weights:               s.weights.For(query.Type, opts.Weights),
+		BirthYearTolerance:    s.tolerance,
+		TypoTolerance:         s.typos,
+		Phonetic:              s.phoneticFor(opts),
+		Conflicts:             s.conflicts,
+		AltNameBoost:          s.altBoost,
+		WeakTerms:             s.weakTerms,
+		RequireAddressCountry: opts.RequireAddressCountry,
+	}
+	if len(opts.TypoTolerance) > 0 {
+		cfg.TypoTolerance = opts.TypoTolerance
+	}
+
+	// Adjusters match the name as it was searched, such as the query of an allowlist entry, rather than as it's prepared
+	searched := query
+	query, err = prepareQuery(query, opts.Prepare)
+	if err != nil {
+		return nil, err
+	}
+	query = current.financialIDs.enrich(query)
+	if cfg.Phonetic {
+		query = search.WithPhoneticCodes(query)
+	}
+
+	order := cmp.Or(opts.Sort, SortByScore)
+	capacity := opts.Limit
+	if order != SortByScore {
+		// Name and list sorts order the highest scoring results, then page through those
+		capacity = maxSortedResults
+	} else if opts.Consolidate {
+		capacity = opts.Limit * consolidateFactor
+	}
+	items := largest.NewItems(capacity, opts.MinMatch)
 
-	indices.ProcessSliceFn(s.entities, getGroupCount(opts), func(index search.Entity[search.Value]) {
-		score := search.DebugSimilarity(nil, query, index) // TODO(adam): add proper debug functionality?
+	var adjustments []func(index search.Entity[search.Value], score float64) float64
+	for _, adjuster := range s.adjusters {
+		if fn := adjuster.Adjust(opts.TenantID, searched); fn != nil {
+			adjustments = append(adjustments, fn)
+		}
+	}
+
+	rawScore := func(index search.Entity[search.Value]) float64 {
+		score := search.SimilarityWithConfig(query, index, cfg)
+		for _, adjust := range adjustments {
+			score = adjust(index, score)
+		}
+		return score
+	}
+	budget := newSearchBudget(ctx, s.budget.For(opts).MaxCandidates)
+	compare := func(items *largest.Items, index search.Entity[search.Value]) {
+		if !budget.spend() {
+			return // out of time or candidates
+		}
+		score := s.calibration.Sources[index.Source].Apply(rawScore(index))
+		if opts.MinMatch <= 0 && score < current.minMatches[index.Source] {
+			return // below the minimum of its list
+		}
+		if order == SortByScore && opts.After != nil && !order.before(*opts.After, cursorOf(order, index, score)) {
+			return // on an earlier page
+		}
 
 		if slices.Contains(opts.DebugSourceIDs, index.SourceID) {
 			// fmt.Printf("%#v\n", index)
@@ -80,7 +526,38 @@ func (s *service) performSearch(ctx context.Context, query search.Entity[search.
 			Value:  index,
 			Weight: score,
 		})
-	})
+	}
+	span := trace.SpanFromContext(ctx)
+	if opts.ExactFirst && !opts.Changed && s.searchExact(current, query, opts, items, compare) {
+		exactMatches.Inc()
+		span.SetAttributes(attribute.Bool("search.exact", true))
+	} else {
+		if candidates, narrowed := s.candidates(current, query, opts); narrowed {
+			// Only entities matching the filters, or with enough of the query's name, are scored
+			searchShards(items, candidates, s.shards, func(items *largest.Items, idx int) {
+				compare(items, current.entities[idx])
+			})
+			span.SetAttributes(attribute.Int("search.candidates", len(candidates)))
+		} else {
+			searchShards(items, current.entities, s.shards, compare)
+			span.SetAttributes(attribute.Int("search.candidates", len(current.entities)))
+		}
+		if opts.TenantID != "" && !opts.Changed {
+			span.SetAttributes(attribute.Int("search.tenant_entities", len(current.tenants[opts.TenantID])))
+			part := normalizeName(query.Name)
+			searchShards(items, current.tenants[opts.TenantID], s.shards, func(items *largest.Items, index search.Entity[search.Value]) {
+				if opts.Filters.matches(index) && (!opts.Partial || part == "" || nameContains(index, part)) {
+					compare(items, index)
+				}
+			})
+		}
+	}
+
+	if budget.truncated.Load() {
+		truncatedSearches.Inc()
+		span.SetAttributes(attribute.Bool("search.truncated", true))
+		recordTruncated(ctx)
+	}
 
 	results := items.Items()
 	var out []search.SearchedEntity[search.Value]
@@ -90,27 +567,141 @@ func (s *service) performSearch(ctx context.Context, query search.Entity[search.
 			continue
 		}
 
-		out = append(out, search.SearchedEntity[search.Value]{
+		entity := search.SearchedEntity[search.Value]{
 			Entity: res.Value,
 			Match:  res.Weight,
-		})
+
+			BirthDateMatch:   search.CompareBirthDatesWithConfig(query, res.Value, cfg),
+			AltNameMatch:     search.MatchAltName(query, res.Value, cfg),
+			WeakAltNameMatch: search.MatchWeakAltName(query, res.Value),
+		}
+		entity.NameScript = search.NameScript(cmp.Or(entity.AltNameMatch, res.Value.Name))
+		if points := s.calibration.Sources[res.Value.Source]; len(points) > 0 {
+			entity.Calibration = &search.ScoreCalibration{
+				RawMatch: rawScore(res.Value),
+				Points:   points,
+			}
+		}
+		out = append(out, entity)
+	}
+	if opts.Consolidate {
+		limit := opts.Limit
+		if order != SortByScore {
+			limit = 0 // sortResults pages through the consolidated results
+		}
+		out = current.links.consolidate(out, current.entities, limit)
+	}
+	if order != SortByScore {
+		out = sortResults(order, out, opts.After, opts.Limit)
+	}
+	if s.reranker != nil && order == SortByScore {
+		s.rerank(ctx, query, cfg, out)
+		span.SetAttributes(attribute.String("search.reranker", s.reranker.Name()))
 	}
 
+	if opts.Explain {
+		for i := range out {
+			_, out[i].Explanation = search.ExplainSimilarity(query, out[i].Entity, cfg)
+		}
+	}
+	if opts.Highlight {
+		for i := range out {
+			out[i].Highlights = search.HighlightNames(query, out[i].Entity, cfg)
+		}
+	}
 	return out, nil
 }
 
-const (
-	defaultGroupCount = 20 // rough estimate from local testing // TODO(adam): more benchmarks
-)
+// phoneticFor returns if a search matches name terms which sound alike
+func (s *service) phoneticFor(opts SearchOpts) bool {
+	if opts.Phonetic != nil {
+		return *opts.Phonetic
+	}
+	return s.phonetic
+}
+
+// withPhoneticCodes stores the phonetic codes of an entity's names as it's indexed, when any search can use them
+func (s *service) withPhoneticCodes(entity search.Entity[search.Value]) search.Entity[search.Value] {
+	if !s.coded {
+		return entity
+	}
+	return search.WithPhoneticCodes(entity)
+}
 
-func getGroupCount(opts SearchOpts) int {
-	fromEnv := strings.TrimSpace(os.Getenv("SEARCH_GROUP_COUNT")) // not exported, used for initial testing
-	if fromEnv != "" {
-		n, err := strconv.ParseUint(fromEnv, 10, 8)
-		if err != nil {
-			panic(fmt.Sprintf("ERROR: parsing SEARCH_GROUP_COUNT=%q failed: %v", fromEnv, err)) //nolint:forbidigo
+// candidates returns the positions of the entities to score for a search, and false when every entity is scored
+func (s *service) candidates(current *index, query search.Entity[search.Value], opts SearchOpts) ([]int, bool) {
+	var out []int
+	narrowed := false
+	narrow := func(positions []int) {
+		if !narrowed {
+			out, narrowed = positions, true
+			return
 		}
-		return int(n)
+		out = intersect(out, positions)
+	}
+
+	if opts.Changed {
+		narrow(current.changed)
+	}
+	if !opts.Filters.Empty() {
+		narrow(current.filters.candidates(opts.Filters))
+	}
+	if query.Name != "" {
+		if opts.Partial {
+			narrow(current.trigrams.containing(current.entities, query.Name))
+		} else if s.overlap > 0 {
+			if similar := current.trigrams.similar(query.Name, s.overlap, len(current.entities)); similar != nil {
+				// Names which sound like the query's are scored however they're spelled
+				if s.phoneticFor(opts) {
+					similar = union(similar, current.phonetic.similar(query, s.overlap, len(current.entities)))
+				}
+				// Banks with the query's BIC or LEI are scored however their names are written
+				narrow(union(similar, s.financialCandidates(current, query)))
+			}
+		}
+	}
+	return out, narrowed
+}
+
+// searchExact scores the entities, and those of the tenant, whose name is written the same as the query's name.
+// It returns false when none of them scored above MinMatch, and every entity needs to be scored.
+func (s *service) searchExact(current *index, query search.Entity[search.Value], opts SearchOpts, items *largest.Items, compare func(*largest.Items, search.Entity[search.Value])) bool {
+	key := nameKey(query.Name)
+	if key == "" {
+		return false
+	}
+
+	found := items.Empty()
+	for _, idx := range current.exact.candidates(key) {
+		if opts.Filters.matches(current.entities[idx]) {
+			compare(found, current.entities[idx])
+		}
+	}
+	if opts.TenantID != "" {
+		for _, index := range current.tenants[opts.TenantID] {
+			if opts.Filters.matches(index) && slices.Contains(entityNameKeys(index), key) {
+				compare(found, index)
+			}
+		}
+	}
+	if len(found.Items()) == 0 {
+		return false
+	}
+	items.Merge(found)
+	return true
+}
+
+// prepareQuery normalizes the query's countries, as indexed entities are, and runs its names through stages
+func prepareQuery(query search.Entity[search.Value], stages []prepare.Stage) (search.Entity[search.Value], error) {
+	if len(stages) == 0 {
+		return search.NormalizeCountries(query), nil
+	}
+	pipeline, err := prepare.NewPipeline(prepare.PipelineConfig{
+		Individual: stages,
+		Entity:     stages,
+	})
+	if err != nil {
+		return query, err
 	}
-	return defaultGroupCount
+	return PrepareEntity(pipeline, query), nil
 }
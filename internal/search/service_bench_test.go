@@ -3,16 +3,16 @@ package search
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"testing"
 
 	"github.com/moov-io/watchman/pkg/search"
 
+	"github.com/moov-io/base/log"
 	"github.com/stretchr/testify/require"
 )
 
 func Benchmark_Search(b *testing.B) {
-	svc := testService(b)
+	entities := testEntities(b)
 	ctx := context.Background()
 
 	query := search.Entity[search.Value]{
@@ -24,24 +24,46 @@ func Benchmark_Search(b *testing.B) {
 		MinMatch: 0.1,
 	}
 
-	b.ResetTimer()
+	shards := []int{1, 2, 4, 8, 16, 32, 64}
+	for _, n := range shards {
+		b.Run(fmt.Sprintf("%d shards", n), func(b *testing.B) {
+			svc := NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{Shards: n})
+			svc.UpdateEntities(entities)
 
-	search := func(b *testing.B, groups int) {
-		b.Helper()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				results, err := svc.Search(ctx, query, opts)
+				require.NoError(b, err)
+				require.Greater(b, len(results), 0)
+			}
+		})
+	}
+}
 
-		for i := 0; i < b.N; i++ {
-			results, err := svc.Search(ctx, query, opts)
-			require.NoError(b, err)
-			require.Greater(b, len(results), 0)
-		}
+func Benchmark_SearchTrigramOverlap(b *testing.B) {
+	entities := testEntities(b)
+	ctx := context.Background()
+
+	query := search.Entity[search.Value]{
+		Name: "Nicolas Maduro",
+		Type: search.EntityPerson,
+	}
+	opts := SearchOpts{
+		Limit:    20,
+		MinMatch: 0.1,
 	}
 
-	groups := []int{1, 3, 5, 10, 20, 25, 50, 100, 150, 200, 250}
-	for _, g := range groups {
-		b.Run(fmt.Sprintf("%d group", g), func(b *testing.B) {
-			b.Setenv("SEARCH_GROUP_COUNT", strconv.Itoa(g))
+	for _, overlap := range []float64{0, 0.2, 0.4, 0.6} {
+		b.Run(fmt.Sprintf("%.1f overlap", overlap), func(b *testing.B) {
+			svc := NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{MinTrigramOverlap: overlap})
+			svc.UpdateEntities(entities)
 
-			search(b, g)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				results, err := svc.Search(ctx, query, opts)
+				require.NoError(b, err)
+				require.Greater(b, len(results), 0)
+			}
 		})
 	}
 }
@@ -3,10 +3,14 @@ package search
 import (
 	"context"
 	"io"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
+	"github.com/moov-io/watchman/internal/allowlist"
+	"github.com/moov-io/watchman/internal/prepare"
 	"github.com/moov-io/watchman/pkg/ofac"
 	"github.com/moov-io/watchman/pkg/search"
 
@@ -56,6 +60,15 @@ func TestService_Search(t *testing.T) {
 }
 
 func testService(tb testing.TB) Service {
+	svc := NewService(log.NewTestLogger())
+	svc.UpdateEntities(testEntities(tb))
+
+	return svc
+}
+
+func testEntities(tb testing.TB) []search.Entity[search.Value] {
+	tb.Helper()
+
 	files := testInputs(tb,
 		filepath.Join("..", "..", "pkg", "ofac", "testdata", "sdn.csv"),
 		filepath.Join("..", "..", "pkg", "ofac", "testdata", "alt.csv"),
@@ -65,14 +78,7 @@ func testService(tb testing.TB) Service {
 	ofacRecords, err := ofac.Read(files)
 	require.NoError(tb, err)
 
-	entities := ofac.GroupIntoEntities(ofacRecords.SDNs, ofacRecords.Addresses, ofacRecords.SDNComments, ofacRecords.AlternateIdentities)
-
-	logger := log.NewTestLogger()
-
-	svc := NewService(logger)
-	svc.UpdateEntities(entities)
-
-	return svc
+	return ofac.GroupIntoEntities(ofacRecords.SDNs, ofacRecords.Addresses, ofacRecords.SDNComments, ofacRecords.AlternateIdentities)
 }
 
 func testInputs(tb testing.TB, paths ...string) map[string]io.ReadCloser {
@@ -89,3 +95,517 @@ func testInputs(tb testing.TB, paths ...string) map[string]io.ReadCloser {
 	}
 	return input
 }
+
+type suppressAll struct {
+	sourceID string
+}
+
+func (s suppressAll) Adjust(tenantID string, query search.Entity[search.Value]) func(index search.Entity[search.Value], score float64) float64 {
+	return func(index search.Entity[search.Value], score float64) float64 {
+		if index.SourceID == s.sourceID {
+			return 0.0
+		}
+		return score
+	}
+}
+
+func TestService_ScoreAdjuster(t *testing.T) {
+	ctx := context.Background()
+	opts := SearchOpts{Limit: 10, MinMatch: 0.01}
+
+	query := search.Entity[search.Value]{
+		Name: "SHIPPING LIMITED",
+		Type: search.EntityBusiness,
+	}
+
+	results, err := testService(t).Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	top := results[0].SourceID
+
+	svc := NewService(log.NewTestLogger(), suppressAll{sourceID: top})
+	svc.UpdateEntities(testService(t).(*service).current.Load().entities)
+
+	results, err = svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	for _, res := range results {
+		require.NotEqual(t, top, res.SourceID)
+	}
+}
+
+func TestService_AllowlistTenants(t *testing.T) {
+	ctx := context.Background()
+	opts := SearchOpts{Limit: 10, MinMatch: 0.01}
+
+	query := search.Entity[search.Value]{
+		Name: "SHIPPING LIMITED",
+		Type: search.EntityBusiness,
+	}
+
+	results, err := testService(t).Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	top := results[0]
+
+	allowlistService := allowlist.NewService(log.NewTestLogger(), allowlist.NewInMemoryRepository())
+	_, err = allowlistService.Create(allowlist.Entry{
+		TenantID:   "a",
+		QueryName:  query.Name,
+		SourceList: top.Source,
+		SourceID:   top.SourceID,
+		CreatedBy:  "jane",
+	})
+	require.NoError(t, err)
+
+	svc := NewService(log.NewTestLogger(), allowlistService)
+	svc.UpdateEntities(testService(t).(*service).current.Load().entities)
+
+	found := func(tenantID string) bool {
+		opts := opts
+		opts.TenantID = tenantID
+
+		results, err := svc.Search(ctx, query, opts)
+		require.NoError(t, err)
+		return slices.ContainsFunc(results, func(res search.SearchedEntity[search.Value]) bool {
+			return res.SourceID == top.SourceID
+		})
+	}
+
+	// Tenant a's entry only suppresses the entity from tenant a's searches
+	require.False(t, found("a"))
+	require.True(t, found("b"))
+	require.True(t, found(""))
+}
+
+func TestService_AllowlistPreparedQuery(t *testing.T) {
+	ctx := context.Background()
+	entities := []search.Entity[search.Value]{
+		{
+			Name:     "Nicolas Maduro Moros",
+			Type:     search.EntityPerson,
+			Source:   search.SourceUSOFAC,
+			SourceID: "22790",
+			Person:   &search.Person{Name: "Nicolas Maduro Moros"},
+		},
+	}
+
+	allowlistService := allowlist.NewService(log.NewTestLogger(), allowlist.NewInMemoryRepository())
+	_, err := allowlistService.Create(allowlist.Entry{
+		QueryName:  "MADURO MOROS, Nicolas",
+		SourceList: search.SourceUSOFAC,
+		SourceID:   "22790",
+		CreatedBy:  "jane",
+	})
+	require.NoError(t, err)
+
+	svc := NewService(log.NewTestLogger(), allowlistService)
+	svc.UpdateEntities(entities)
+
+	// The entry matches the name as it was searched, which the reorder stage turns into "Nicolas MADURO MOROS"
+	query := search.Entity[search.Value]{
+		Name:   "Maduro Moros,  NICOLAS",
+		Type:   search.EntityPerson,
+		Person: &search.Person{Name: "Maduro Moros,  NICOLAS"},
+	}
+	results, err := svc.Search(ctx, query, SearchOpts{Limit: 10, MinMatch: 0.01, Prepare: []prepare.Stage{prepare.StageReorder}})
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	// Other names aren't suppressed
+	query.Name = "Nicolas Maduro"
+	query.Person = &search.Person{Name: query.Name}
+	results, err = svc.Search(ctx, query, SearchOpts{Limit: 10, MinMatch: 0.01, Prepare: []prepare.Stage{prepare.StageReorder}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+}
+
+func TestService_Weights(t *testing.T) {
+	ctx := context.Background()
+	opts := SearchOpts{Limit: 1, MinMatch: 0.01}
+
+	entities := []search.Entity[search.Value]{
+		{
+			Name:     "Orient Shipping Limited",
+			Type:     search.EntityBusiness,
+			Source:   search.SourceUSOFAC,
+			SourceID: "8393",
+			Business: &search.Business{Name: "Orient Shipping Limited"},
+			Addresses: []search.Address{
+				{Line1: "Lot 18, Bay Street", City: "Kingstown", Country: "Saint Vincent and the Grenadines"},
+			},
+		},
+	}
+	query := search.Entity[search.Value]{
+		Name:     "Orient Shipping Limited",
+		Type:     search.EntityBusiness,
+		Business: &search.Business{Name: "Orient Shipping Limited"},
+		Addresses: []search.Address{
+			{Line1: "Lot 18, Bay Street", City: "Paris", Country: "France"},
+		},
+	}
+
+	svc := NewService(log.NewTestLogger())
+	svc.UpdateEntities(entities)
+
+	defaults, err := svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Len(t, defaults, 1)
+
+	svc = NewServiceWithWeights(log.NewTestLogger(), WeightsConfig{
+		Types: map[search.EntityType]search.Weights{
+			search.EntityBusiness: {Address: 1},
+		},
+	})
+	svc.UpdateEntities(entities)
+
+	weighted, err := svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Len(t, weighted, 1)
+	require.Greater(t, weighted[0].Match, defaults[0].Match)
+
+	// Weights set by a search take precedence
+	opts.Weights = search.Weights{Address: 15}
+	overridden, err := svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.InDelta(t, defaults[0].Match, overridden[0].Match, 0.001)
+
+	opts.Weights = search.Weights{Name: -1}
+	_, err = svc.Search(ctx, query, opts)
+	require.ErrorContains(t, err, "name weight of -1")
+}
+
+func TestService_RequireAddressCountry(t *testing.T) {
+	ctx := context.Background()
+	opts := SearchOpts{Limit: 5, MinMatch: 0.5}
+
+	entities := []search.Entity[search.Value]{
+		{
+			Name:      "Orient Shipping Limited",
+			Type:      search.EntityBusiness,
+			Source:    search.SourceUSOFAC,
+			SourceID:  "8393",
+			Business:  &search.Business{Name: "Orient Shipping Limited"},
+			Addresses: []search.Address{{Line1: "Lot 18, Bay Street, Kingstown, Saint Vincent and the Grenadines"}},
+		},
+		{
+			Name:      "Orient Shipping Ltd",
+			Type:      search.EntityBusiness,
+			Source:    search.SourceUSOFAC,
+			SourceID:  "9104",
+			Business:  &search.Business{Name: "Orient Shipping Ltd"},
+			Addresses: []search.Address{{City: "Panama City", Country: "Panama"}},
+		},
+	}
+	// Lists are prepared as they're downloaded, which splits the address written on one line into components
+	for i := range entities {
+		entities[i] = PrepareEntity(nil, entities[i])
+	}
+	svc := NewService(log.NewTestLogger())
+	svc.UpdateEntities(entities)
+	query := search.Entity[search.Value]{
+		Name:      "Orient Shipping Limited",
+		Type:      search.EntityBusiness,
+		Business:  &search.Business{Name: "Orient Shipping Limited"},
+		Addresses: []search.Address{{Line1: "Lot 18 Bay St.", City: "Kingstown", Country: "VC"}},
+	}
+
+	results, err := svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Equal(t, "8393", results[0].SourceID)
+	require.Equal(t, "Kingstown", results[0].Addresses[0].City)
+
+	opts.RequireAddressCountry = true
+	results, err = svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "8393", results[0].SourceID)
+}
+
+func TestService_Algorithm(t *testing.T) {
+	ctx := context.Background()
+	entities := []search.Entity[search.Value]{
+		{
+			Name:     "Maduro Moros Nicolas",
+			Type:     search.EntityPerson,
+			Source:   search.SourceUSOFAC,
+			SourceID: "22790",
+			Person:   &search.Person{Name: "Maduro Moros Nicolas"},
+		},
+	}
+	query := search.Entity[search.Value]{
+		Name:   "Nikolas Madura Moros",
+		Type:   search.EntityPerson,
+		Person: &search.Person{Name: "Nikolas Madura Moros"},
+	}
+	match := func(svc Service, opts SearchOpts) float64 {
+		t.Helper()
+
+		opts.Limit = 1
+		results, err := svc.Search(ctx, query, opts)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		return results[0].Match
+	}
+
+	// Searches which don't choose an algorithm use the service's
+	svc := NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{Algorithm: search.AlgorithmTokenSort})
+	svc.UpdateEntities(entities)
+
+	plain := NewService(log.NewTestLogger())
+	plain.UpdateEntities(entities)
+
+	require.Equal(t, match(plain, SearchOpts{Algorithm: search.AlgorithmTokenSort}), match(svc, SearchOpts{}))
+	require.Equal(t, match(plain, SearchOpts{}), match(svc, SearchOpts{Algorithm: search.AlgorithmJaroWinkler}))
+	require.NotEqual(t, match(plain, SearchOpts{}), match(svc, SearchOpts{}))
+}
+
+func TestService_Calibration(t *testing.T) {
+	ctx := context.Background()
+
+	entities := []search.Entity[search.Value]{
+		{
+			Name:     "Mohammed Ali Hassan",
+			Type:     search.EntityPerson,
+			Source:   search.SourceUNCSL,
+			SourceID: "un-1",
+			Person:   &search.Person{Name: "Mohammed Ali Hassan"},
+		},
+		{
+			Name:     "Mohammed Ali Hassan",
+			Type:     search.EntityPerson,
+			Source:   search.SourceUSCSL,
+			SourceID: "dpl-1",
+			Person:   &search.Person{Name: "Mohammed Ali Hassan"},
+		},
+	}
+	query := search.Entity[search.Value]{
+		Name:   "Mohamed Ali Hasan",
+		Type:   search.EntityPerson,
+		Person: &search.Person{Name: "Mohamed Ali Hasan"},
+	}
+	opts := SearchOpts{Limit: 10, MinMatch: 0.01}
+
+	svc := NewService(log.NewTestLogger())
+	svc.UpdateEntities(entities)
+
+	raw, err := svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Len(t, raw, 2)
+	require.InDelta(t, raw[0].Match, raw[1].Match, 0.0001)
+	require.Nil(t, raw[0].Calibration)
+
+	calibration := search.Calibration{{Raw: 0.8, Calibrated: 0.7}}
+	svc = NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{
+		Calibration: CalibrationConfig{
+			Sources: map[search.SourceList]search.Calibration{
+				search.SourceUSCSL: calibration,
+			},
+		},
+	})
+	svc.UpdateEntities(entities)
+
+	calibrated, err := svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Len(t, calibrated, 2)
+
+	require.Equal(t, "un-1", calibrated[0].SourceID)
+	require.Nil(t, calibrated[0].Calibration)
+	require.InDelta(t, raw[0].Match, calibrated[0].Match, 0.0001)
+
+	require.Equal(t, "dpl-1", calibrated[1].SourceID)
+	require.Less(t, calibrated[1].Match, calibrated[0].Match)
+	require.NotNil(t, calibrated[1].Calibration)
+	require.InDelta(t, raw[0].Match, calibrated[1].Calibration.RawMatch, 0.0001)
+	require.Equal(t, calibration, calibrated[1].Calibration.Points)
+	require.InDelta(t, calibration.Apply(raw[0].Match), calibrated[1].Match, 0.0001)
+
+	// MinMatch applies to calibrated scores
+	opts.MinMatch = (calibrated[0].Match + calibrated[1].Match) / 2
+	calibrated, err = svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Len(t, calibrated, 1)
+	require.Equal(t, "un-1", calibrated[0].SourceID)
+}
+
+func TestService_ListMinMatch(t *testing.T) {
+	ctx := context.Background()
+
+	svc := NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{
+		MinMatch: MinMatchConfig{
+			Sources: map[search.SourceList]float64{search.SourceUSCSL: 0.99},
+		},
+	})
+	svc.UpdateEntities([]search.Entity[search.Value]{
+		{
+			Name:     "Mohammed Ali Hassan",
+			Type:     search.EntityPerson,
+			Source:   search.SourceUNCSL,
+			SourceID: "un-1",
+			Person:   &search.Person{Name: "Mohammed Ali Hassan"},
+		},
+		{
+			Name:     "Mohammed Ali Hassan",
+			Type:     search.EntityPerson,
+			Source:   search.SourceUSCSL,
+			SourceID: "dpl-1",
+			Person:   &search.Person{Name: "Mohammed Ali Hassan"},
+		},
+	})
+	query := search.Entity[search.Value]{
+		Name:   "Mohamed Ali Hasan",
+		Type:   search.EntityPerson,
+		Person: &search.Person{Name: "Mohamed Ali Hasan"},
+	}
+
+	// The stricter minimum of the CSL drops its near match
+	results, err := svc.Search(ctx, query, SearchOpts{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "un-1", results[0].SourceID)
+
+	// A search's own minMatch overrides the minimum of every list
+	results, err = svc.Search(ctx, query, SearchOpts{Limit: 10, MinMatch: 0.5})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	// Minimums can be changed while searches are served
+	require.NoError(t, svc.SetListMinMatch(search.SourceUSCSL, 0))
+	require.NoError(t, svc.SetListMinMatch(search.SourceUNCSL, 0.99))
+	require.Equal(t, map[search.SourceList]float64{search.SourceUNCSL: 0.99}, svc.ListMinMatches())
+
+	results, err = svc.Search(ctx, query, SearchOpts{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, "dpl-1", results[0].SourceID)
+
+	require.ErrorContains(t, svc.SetListMinMatch(search.SourceUNCSL, 1.2), "must be between 0 and 1")
+
+	// Every minimum is replaced at once, or none are when any is invalid
+	err = svc.SetMinMatches(MinMatchConfig{
+		Sources: map[search.SourceList]float64{search.SourceUSCSL: 0.99, search.SourceUNCSL: 2},
+	})
+	require.ErrorContains(t, err, "must be between 0 and 1")
+	require.Equal(t, map[search.SourceList]float64{search.SourceUNCSL: 0.99}, svc.ListMinMatches())
+
+	err = svc.SetMinMatches(MinMatchConfig{
+		Sources: map[search.SourceList]float64{search.SourceUSCSL: 0.99},
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[search.SourceList]float64{search.SourceUSCSL: 0.99}, svc.ListMinMatches())
+}
+
+func TestService_WeakAltNames(t *testing.T) {
+	svc := NewService(log.NewTestLogger())
+	svc.UpdateEntities([]search.Entity[search.Value]{
+		{
+			Name:         "Dmitry Yuryevich KHOROSHEV",
+			Type:         search.EntityPerson,
+			Source:       search.SourceUSOFAC,
+			SourceID:     "48603",
+			Person:       &search.Person{Name: "Dmitry Yuryevich KHOROSHEV"},
+			WeakAltNames: []string{"LOCKBITSUPP"},
+		},
+	})
+
+	query := search.Entity[search.Value]{
+		Name:   "LockBitSupp",
+		Type:   search.EntityPerson,
+		Person: &search.Person{Name: "LockBitSupp"},
+	}
+	results, err := svc.Search(context.Background(), query, SearchOpts{Limit: 1, MinMatch: 0.01})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Less(t, results[0].Match, 0.6)
+	require.Equal(t, "LOCKBITSUPP", results[0].WeakAltNameMatch)
+}
+
+func TestService_AltNameMatch(t *testing.T) {
+	svc := NewService(log.NewTestLogger())
+	svc.UpdateEntities([]search.Entity[search.Value]{
+		{
+			Name:     "Islamic Revolutionary Guard Corps",
+			Type:     search.EntityBusiness,
+			Source:   search.SourceUSOFAC,
+			SourceID: "1",
+			Business: &search.Business{
+				Name:     "Islamic Revolutionary Guard Corps",
+				AltNames: []string{"IRGC", "Sepah Pasdaran"},
+			},
+		},
+	})
+
+	query := search.Entity[search.Value]{
+		Name:     "Sepah Pasdaran",
+		Type:     search.EntityBusiness,
+		Business: &search.Business{Name: "Sepah Pasdaran"},
+	}
+	results, err := svc.Search(context.Background(), query, SearchOpts{Limit: 1, MinMatch: 0.01})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Greater(t, results[0].Match, 0.9)
+	require.Equal(t, "Sepah Pasdaran", results[0].AltNameMatch)
+}
+
+func TestService_Explain(t *testing.T) {
+	ctx := context.Background()
+	svc := testService(t)
+
+	query := search.Entity[search.Value]{
+		Name: "SHIPPING LIMITED",
+		Type: search.EntityBusiness,
+	}
+
+	results, err := svc.Search(ctx, query, SearchOpts{Limit: 2, MinMatch: 0.01})
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	require.Nil(t, results[0].Explanation)
+
+	results, err = svc.Search(ctx, query, SearchOpts{Limit: 2, MinMatch: 0.01, Explain: true})
+	require.NoError(t, err)
+	require.NotEmpty(t, results)
+	for _, res := range results {
+		require.NotNil(t, res.Explanation)
+		require.InDelta(t, res.Match, res.Explanation.Score, 0.001)
+		require.NotEmpty(t, res.Explanation.Fields)
+	}
+}
+
+func TestService_BirthDateMatch(t *testing.T) {
+	ctx := context.Background()
+	svc := testService(t)
+
+	find := func(t *testing.T, birthDate string) search.SearchedEntity[search.Value] {
+		t.Helper()
+
+		query, err := readSearchQuery(url.Values{
+			"name":      []string{"Mahmoud Mohammad Ahmed Bahaziq"},
+			"type":      []string{"person"},
+			"birthDate": []string{birthDate},
+		})
+		require.NoError(t, err)
+
+		results, err := svc.Search(ctx, query, SearchOpts{Limit: 10, MinMatch: 0.01})
+		require.NoError(t, err)
+		for _, res := range results {
+			if res.SourceID == "10692" {
+				return res
+			}
+		}
+		t.Fatalf("10692 not found in %d results", len(results))
+		return search.SearchedEntity[search.Value]{}
+	}
+
+	exact := find(t, "1943-08-17")
+	require.Equal(t, search.DateMatchExact, exact.BirthDateMatch)
+
+	// Matches "alt. DOB 1943"
+	within := find(t, "1943-11")
+	require.Equal(t, search.DateMatchWithin, within.BirthDateMatch)
+
+	mismatch := find(t, "1980-01-01")
+	require.Equal(t, search.DateMatchMismatch, mismatch.BirthDateMatch)
+
+	require.Greater(t, exact.Match, within.Match)
+	require.Greater(t, within.Match, mismatch.Match)
+}
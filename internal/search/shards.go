@@ -0,0 +1,54 @@
+package search
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/moov-io/watchman/internal/indices"
+	"github.com/moov-io/watchman/internal/largest"
+)
+
+// minShardSize keeps small lists, such as a tenant's or those left by filters, from being spread over more
+// goroutines than they're worth
+const minShardSize = 512
+
+// defaultShards searches one shard on each core
+func defaultShards() int {
+	return runtime.GOMAXPROCS(0)
+}
+
+// searchShards splits in into at most shards contiguous parts which are scored concurrently. Each shard keeps its
+// own top results, so they don't contend over one lock, and those are merged into items by score.
+func searchShards[T any](items *largest.Items, in []T, shards int, compare func(shard *largest.Items, item T)) {
+	if limit := len(in) / minShardSize; shards > limit {
+		shards = limit
+	}
+	if shards <= 1 {
+		for _, item := range in {
+			compare(items, item)
+		}
+		return
+	}
+
+	bounds := indices.New(len(in), shards)
+	results := make([]*largest.Items, len(bounds)-1)
+
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			shard := items.Empty()
+			for _, item := range in[bounds[i]:bounds[i+1]] {
+				compare(shard, item)
+			}
+			results[i] = shard
+		}(i)
+	}
+	wg.Wait()
+
+	for _, shard := range results {
+		items.Merge(shard)
+	}
+}
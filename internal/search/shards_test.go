@@ -0,0 +1,72 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/largest"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchShards(t *testing.T) {
+	var in []int
+	for i := 0; i < 10*minShardSize; i++ {
+		in = append(in, i)
+	}
+	score := func(items *largest.Items, i int) {
+		items.Add(largest.Item{
+			Value:  search.Entity[search.Value]{SourceID: fmt.Sprintf("%d", i)},
+			Weight: float64(i) / float64(len(in)),
+		})
+	}
+
+	for _, shards := range []int{0, 1, 3, 8, 100} {
+		items := largest.NewItems(5, 0.5)
+		searchShards(items, in, shards, score)
+
+		found := items.Items()
+		require.Len(t, found, 5)
+		for i, item := range found {
+			require.Equal(t, fmt.Sprintf("%d", len(in)-1-i), item.Value.SourceID, "%d shards", shards)
+		}
+	}
+
+	items := largest.NewItems(5, 0.5)
+	searchShards(items, []int{}, 4, score)
+	require.Empty(t, items.Items())
+}
+
+func TestService_Shards(t *testing.T) {
+	entities := testEntities(t)
+	ctx := context.Background()
+
+	query := search.Entity[search.Value]{
+		Name:   "Nicolas Maduro",
+		Type:   search.EntityPerson,
+		Person: &search.Person{Name: "Nicolas Maduro"},
+	}
+	opts := SearchOpts{Limit: 25, MinMatch: 0.5}
+
+	var expected []search.SearchedEntity[search.Value]
+	for _, shards := range []int{1, 4, 16} {
+		svc := NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{Shards: shards})
+		svc.UpdateEntities(entities)
+
+		results, err := svc.Search(ctx, query, opts)
+		require.NoError(t, err)
+		require.Len(t, results, 25)
+
+		if expected == nil {
+			expected = results
+			continue
+		}
+		for i := range results {
+			require.Equal(t, expected[i].SourceID, results[i].SourceID, "%d shards", shards)
+			require.InDelta(t, expected[i].Match, results[i].Match, 0.0001)
+		}
+	}
+}
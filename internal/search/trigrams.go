@@ -0,0 +1,135 @@
+package search
+
+import (
+	"math"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// trigramIndex maps each sequence of three characters in the words of entity names to the entities with them,
+// to find the names containing part of a name and to skip entities whose names share too little with a query
+// to match it.
+//
+// Words are padded with a space on each side, so " ma" is only found at the start of a word.
+type trigramIndex struct {
+	grams map[string][]int // index into service.entities, in order
+}
+
+func newTrigramIndex(entities []search.Entity[search.Value]) trigramIndex {
+	return trigramIndex{
+		grams: buildPostings(entities, positionOf, trigramEntries),
+	}
+}
+
+func (x trigramIndex) update(changes positionChanges) trigramIndex {
+	return trigramIndex{
+		grams: updatePostings(x.grams, changes, positionOf, trigramEntries),
+	}
+}
+
+func trigramEntries(idx int, entity search.Entity[search.Value], fn func(string, int)) {
+	// Each entity is only added once, even when several of its names have the trigram
+	seen := make(map[string]bool)
+	for _, name := range entityNames(entity) {
+		for _, gram := range nameTrigrams(name, true) {
+			if !seen[gram] {
+				seen[gram] = true
+				fn(gram, idx)
+			}
+		}
+	}
+}
+
+// containing returns the entities with a name containing part, such as "Maduro" in "Nicolas MADURO MOROS".
+// Names are compared after romanizing them and removing their case, accents and punctuation.
+func (x trigramIndex) containing(entities []search.Entity[search.Value], part string) []int {
+	part = normalizeName(part)
+	if part == "" {
+		return []int{}
+	}
+
+	// Every trigram of part has to be in a name which contains it, though part may start or end inside a word
+	var positions []int
+	if grams := nameTrigrams(part, false); len(grams) == 0 {
+		positions = make([]int, len(entities))
+		for i := range positions {
+			positions[i] = i
+		}
+	} else {
+		positions = x.grams[grams[0]]
+		for _, gram := range grams[1:] {
+			positions = intersect(positions, x.grams[gram])
+		}
+	}
+
+	out := []int{}
+	for _, idx := range positions {
+		if nameContains(entities[idx], part) {
+			out = append(out, idx)
+		}
+	}
+	return out
+}
+
+// similar returns the entities sharing at least overlap (between 0 and 1) of the trigrams in name
+func (x trigramIndex) similar(name string, overlap float64, entities int) []int {
+	grams := nameTrigrams(name, true)
+	if len(grams) == 0 {
+		return nil
+	}
+	need := int32(math.Ceil(overlap * float64(len(grams))))
+
+	counts := make([]int32, entities)
+	for _, gram := range grams {
+		for _, idx := range x.grams[gram] {
+			counts[idx]++
+		}
+	}
+	out := []int{}
+	for idx, count := range counts {
+		if count > 0 && count >= need {
+			out = append(out, idx)
+		}
+	}
+	return out
+}
+
+// normalizeName romanizes name, as it's scored, then lowercases it and removes its accents and punctuation,
+// keeping the order of its words
+func normalizeName(name string) string {
+	return strings.Join(nameWords(name), " ")
+}
+
+func nameWords(name string) []string {
+	return strings.Fields(prepare.LowerAndRemovePunctuation(prepare.Transliterate(name)))
+}
+
+// nameContains returns true when any name of entity contains part, which is already normalized
+func nameContains(entity search.Entity[search.Value], part string) bool {
+	for _, name := range entityNames(entity) {
+		if strings.Contains(normalizeName(name), part) {
+			return true
+		}
+	}
+	return false
+}
+
+// nameTrigrams returns the distinct trigrams of each word in name. Words are padded with spaces when pad is set.
+func nameTrigrams(name string, pad bool) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, word := range nameWords(name) {
+		if pad {
+			word = " " + word + " "
+		}
+		for i := 0; i+3 <= len(word); i++ {
+			if gram := word[i : i+3]; !seen[gram] {
+				seen[gram] = true
+				out = append(out, gram)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,95 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNameTrigrams(t *testing.T) {
+	require.Equal(t, []string{" ab", "abc", "bc ", " a "}, nameTrigrams("ABC, a", true))
+	require.Equal(t, []string{"abc"}, nameTrigrams("ABC, a", false))
+	require.Empty(t, nameTrigrams("", true))
+}
+
+func TestTrigramIndex(t *testing.T) {
+	entities := linkedEntities()
+	trigrams := newTrigramIndex(entities)
+
+	names := func(positions []int) []string {
+		var out []string
+		for _, idx := range positions {
+			out = append(out, entities[idx].SourceID)
+		}
+		return out
+	}
+
+	require.ElementsMatch(t, []string{"22790", "eu-5764", "VEN0001", "99999", "un-1"}, names(trigrams.containing(entities, "maduro")))
+	require.ElementsMatch(t, []string{"v-1", "v-2"}, names(trigrams.containing(entities, "Ocean")))
+	require.ElementsMatch(t, []string{"v-1"}, names(trigrams.containing(entities, "EAN ST")))
+	require.Empty(t, trigrams.containing(entities, "Maduro Nicolas"))
+
+	// Parts too short for a trigram are found by comparing every name
+	require.ElementsMatch(t, []string{"v-2"}, names(trigrams.containing(entities, "of")))
+
+	require.ElementsMatch(t, []string{"v-1", "v-2"}, names(trigrams.similar("Ocean Star", 0.9, len(entities))))
+	require.ElementsMatch(t, []string{"v-1", "v-2"}, names(trigrams.similar("Ocean Stars", 0.5, len(entities))))
+	require.Empty(t, trigrams.similar("Ocean Stars", 0.95, len(entities)))
+
+	// Names in other scripts are romanized, as they are when scored
+	require.Len(t, trigrams.similar("Николас Мадуро", 0.5, len(entities)), 5)
+}
+
+func TestService_SearchPartial(t *testing.T) {
+	ctx := context.Background()
+
+	svc := NewService(log.NewTestLogger())
+	svc.UpdateEntities(linkedEntities())
+
+	query := search.Entity[search.Value]{
+		Name: "Shipping",
+		Type: search.EntityBusiness,
+	}
+	results, err := svc.Search(ctx, query, SearchOpts{Limit: 10, MinMatch: 0.01, Partial: true})
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	for _, res := range results {
+		require.Contains(t, normalizeName(res.Name), "shipping")
+	}
+
+	// Tenant entities are also only returned when their name contains the query's
+	svc.UpdateTenantEntities("acme", []search.Entity[search.Value]{
+		{Name: "Shipping Partners", Type: search.EntityBusiness, Source: search.SourceList("custom"), SourceID: "c-1"},
+		{Name: "Other Partners", Type: search.EntityBusiness, Source: search.SourceList("custom"), SourceID: "c-2"},
+	})
+	results, err = svc.Search(ctx, query, SearchOpts{Limit: 10, MinMatch: 0.01, Partial: true, TenantID: "acme"})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+}
+
+func TestService_SearchTrigramOverlap(t *testing.T) {
+	ctx := context.Background()
+
+	svc := NewServiceWithConfig(log.NewTestLogger(), ServiceConfig{MinTrigramOverlap: 0.5})
+	svc.UpdateEntities(linkedEntities())
+
+	query := search.Entity[search.Value]{
+		Name: "Ocean Stars",
+		Type: search.EntityVessel,
+	}
+	results, err := svc.Search(ctx, query, SearchOpts{Limit: 10, MinMatch: 0.01})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	// Entities sharing too little of the name aren't scored
+	query.Name = "Acme"
+	results, err = svc.Search(ctx, query, SearchOpts{Limit: 10, MinMatch: 0.01})
+	require.NoError(t, err)
+	for _, res := range results {
+		require.Contains(t, normalizeName(res.Name), "acme")
+	}
+}
@@ -0,0 +1,117 @@
+package search
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// VehicleMatch is a vessel or aircraft matching a VesselQuery or AircraftQuery
+type VehicleMatch struct {
+	search.SearchedEntity[search.Value]
+
+	// MatchedOn lists the identifiers (e.g. imoNumber or tailNumber) which matched, and is empty for name matches
+	MatchedOn []string `json:"matchedOn,omitempty"`
+
+	// Exact is true when an identifier matched, which are returned ahead of name matches
+	Exact bool `json:"exact"`
+}
+
+// identifierLookup collects the entities found by each of a query's identifiers
+type identifierLookup struct {
+	matched   map[int][]string // index into service.entities
+	positions []int
+}
+
+func (l *identifierLookup) add(index map[string][]int, key, field string) {
+	if key == "" {
+		return
+	}
+	if l.matched == nil {
+		l.matched = make(map[int][]string)
+	}
+	for _, idx := range index[key] {
+		if _, exists := l.matched[idx]; !exists {
+			l.positions = append(l.positions, idx)
+		}
+		l.matched[idx] = append(l.matched[idx], field)
+	}
+}
+
+// searchVehicles returns the entities found by identifiers, ordered by how many identifiers matched, then fills
+// the remaining spots with entities whose names are similar to the query. keep is optional and excludes results.
+func (s *service) searchVehicles(ctx context.Context, current *index, found identifierLookup, query search.Entity[search.Value], limit int, minMatch float64, keep func(search.Entity[search.Value]) bool) ([]VehicleMatch, error) {
+	limit = cmp.Or(limit, softResultsLimit)
+
+	positions := found.positions
+	slices.SortFunc(positions, func(a, b int) int {
+		if c := len(found.matched[b]) - len(found.matched[a]); c != 0 {
+			return c
+		}
+		ea, eb := current.entities[a], current.entities[b]
+		if c := strings.Compare(string(ea.Source), string(eb.Source)); c != 0 {
+			return c
+		}
+		return strings.Compare(ea.SourceID, eb.SourceID)
+	})
+
+	out := make([]VehicleMatch, 0, len(positions))
+	seen := make(map[entityKey]bool)
+	for _, idx := range positions {
+		if len(out) >= limit {
+			break
+		}
+		if keep != nil && !keep(current.entities[idx]) {
+			continue
+		}
+		match := VehicleMatch{
+			MatchedOn: found.matched[idx],
+			Exact:     true,
+		}
+		match.Entity = current.entities[idx]
+		match.Match = exactIdentifierMatch
+		out = append(out, match)
+		seen[keyOf(match.Entity)] = true
+	}
+
+	// Fill the remaining spots with entities whose names are similar
+	if strings.TrimSpace(query.Name) == "" || len(out) >= limit {
+		return out, nil
+	}
+	opts := SearchOpts{
+		// Search for extra results in case the identifier matches are also found by name
+		Limit:    limit + len(out),
+		MinMatch: minMatch,
+		Filters: SearchFilters{
+			Types: []search.EntityType{query.Type},
+		},
+	}
+	results, err := s.performSearch(ctx, current, query, opts)
+	if err != nil {
+		return nil, err
+	}
+	for _, res := range results {
+		if len(out) >= limit {
+			break
+		}
+		if seen[keyOf(res.Entity)] || (keep != nil && !keep(res.Entity)) {
+			continue
+		}
+		out = append(out, VehicleMatch{SearchedEntity: res})
+	}
+	return out, nil
+}
+
+func digitsOf(value string) string {
+	var buf strings.Builder
+	for _, r := range value {
+		if unicode.IsDigit(r) {
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,116 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// VesselQuery screens a vessel by its IMO number, call sign and MMSI, falling back to its name
+type VesselQuery struct {
+	Name      string
+	IMONumber string
+	CallSign  string
+	MMSI      string
+
+	// Flag is compared when scoring name matches, but doesn't exclude vessels which have been reflagged
+	Flag string
+
+	Limit    int
+	MinMatch float64
+}
+
+func (q VesselQuery) empty() bool {
+	return strings.TrimSpace(q.Name) == "" && normalizeIMO(q.IMONumber) == "" &&
+		normalizeIdentifier(q.CallSign) == "" && normalizeMMSI(q.MMSI) == ""
+}
+
+const (
+	matchedIMO      = "imoNumber"
+	matchedCallSign = "callSign"
+	matchedMMSI     = "mmsi"
+)
+
+// vesselIndex maps the normalized identifiers of each vessel to their positions in service.entities
+type vesselIndex struct {
+	imo      map[string][]int
+	callSign map[string][]int
+	mmsi     map[string][]int
+}
+
+func newVesselIndex(entities []search.Entity[search.Value]) vesselIndex {
+	return vesselIndex{
+		imo:      buildPostings(entities, positionOf, vesselEntries(vesselIMO)),
+		callSign: buildPostings(entities, positionOf, vesselEntries(vesselCallSign)),
+		mmsi:     buildPostings(entities, positionOf, vesselEntries(vesselMMSI)),
+	}
+}
+
+func (x vesselIndex) update(changes positionChanges) vesselIndex {
+	return vesselIndex{
+		imo:      updatePostings(x.imo, changes, positionOf, vesselEntries(vesselIMO)),
+		callSign: updatePostings(x.callSign, changes, positionOf, vesselEntries(vesselCallSign)),
+		mmsi:     updatePostings(x.mmsi, changes, positionOf, vesselEntries(vesselMMSI)),
+	}
+}
+
+// vesselEntries returns the entries of vessels by one of their normalized identifiers
+func vesselEntries(identifier func(*search.Vessel) string) entriesFunc[string, int] {
+	return func(idx int, entity search.Entity[search.Value], fn func(string, int)) {
+		if entity.Vessel == nil {
+			return
+		}
+		if key := identifier(entity.Vessel); key != "" {
+			fn(key, idx)
+		}
+	}
+}
+
+func vesselIMO(vessel *search.Vessel) string {
+	return normalizeIMO(vessel.IMONumber)
+}
+
+func vesselCallSign(vessel *search.Vessel) string {
+	return normalizeIdentifier(vessel.CallSign)
+}
+
+func vesselMMSI(vessel *search.Vessel) string {
+	return normalizeMMSI(vessel.MMSI)
+}
+
+// normalizeIMO keeps the seven digits of an IMO number, which are often written as "IMO 9187629"
+func normalizeIMO(imo string) string {
+	return digitsOf(imo)
+}
+
+func normalizeMMSI(mmsi string) string {
+	return digitsOf(mmsi)
+}
+
+func (s *service) SearchVessels(ctx context.Context, query VesselQuery) ([]VehicleMatch, error) {
+	if query.empty() {
+		return nil, errors.New("missing vessel name or identifier")
+	}
+
+	current := s.current.Load()
+
+	var found identifierLookup
+	found.add(current.vessels.imo, normalizeIMO(query.IMONumber), matchedIMO)
+	found.add(current.vessels.callSign, normalizeIdentifier(query.CallSign), matchedCallSign)
+	found.add(current.vessels.mmsi, normalizeMMSI(query.MMSI), matchedMMSI)
+
+	entity := search.Entity[search.Value]{
+		Name: query.Name,
+		Type: search.EntityVessel,
+		Vessel: &search.Vessel{
+			Name:      query.Name,
+			IMONumber: query.IMONumber,
+			CallSign:  query.CallSign,
+			MMSI:      query.MMSI,
+			Flag:      query.Flag,
+		},
+	}
+	return s.searchVehicles(ctx, current, found, entity, query.Limit, query.MinMatch, nil)
+}
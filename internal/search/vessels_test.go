@@ -0,0 +1,95 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeIMO(t *testing.T) {
+	require.Equal(t, "9187629", normalizeIMO("IMO 9187629"))
+	require.Equal(t, "9187629", normalizeIMO("9187629"))
+	require.Equal(t, "", normalizeIMO("IMO"))
+}
+
+func TestService_SearchVessels(t *testing.T) {
+	ctx := context.Background()
+	svc := testService(t)
+
+	// 15036 ARTAVIL is listed with "IMO 9187629; MMSI 572469210" and call sign T2EU4
+	t.Run("imo", func(t *testing.T) {
+		results, err := svc.SearchVessels(ctx, VesselQuery{IMONumber: "IMO 9187629"})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, "15036", results[0].SourceID)
+		require.True(t, results[0].Exact)
+		require.Equal(t, []string{"imoNumber"}, results[0].MatchedOn)
+		require.InDelta(t, 1.0, results[0].Match, 0.001)
+	})
+
+	t.Run("identifiers trump names", func(t *testing.T) {
+		// The name belongs to another tanker, but the call sign is ARTAVIL's
+		results, err := svc.SearchVessels(ctx, VesselQuery{
+			Name:     "ARK III",
+			CallSign: "t2eu4",
+			MMSI:     "572469210",
+			Limit:    5,
+		})
+		require.NoError(t, err)
+		require.NotEmpty(t, results)
+		require.Equal(t, "15036", results[0].SourceID)
+		require.Equal(t, []string{"callSign", "mmsi"}, results[0].MatchedOn)
+
+		require.Greater(t, len(results), 1)
+		require.Equal(t, "15037", results[1].SourceID)
+		require.False(t, results[1].Exact)
+		require.Empty(t, results[1].MatchedOn)
+
+		for _, res := range results {
+			require.Equal(t, search.EntityVessel, res.Type)
+		}
+	})
+
+	t.Run("no duplicates", func(t *testing.T) {
+		results, err := svc.SearchVessels(ctx, VesselQuery{Name: "ARTAVIL", IMONumber: "9187629"})
+		require.NoError(t, err)
+		require.Equal(t, "15036", results[0].SourceID)
+		for _, res := range results[1:] {
+			require.NotEqual(t, "15036", res.SourceID)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := svc.SearchVessels(ctx, VesselQuery{Flag: "Iran"})
+		require.ErrorContains(t, err, "missing vessel name or identifier")
+	})
+}
+
+func TestAPI_searchVessel(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t)).AppendRoutes(router)
+
+	req := httptest.NewRequest("GET", "/v2/search/vessel?imoNumber=9187655", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp vehicleSearchResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Entities, 1)
+	require.Equal(t, "15037", resp.Entities[0].SourceID)
+	require.Equal(t, search.VesselTypeTanker, resp.Entities[0].Vessel.Type)
+
+	req = httptest.NewRequest("GET", "/v2/search/vessel", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
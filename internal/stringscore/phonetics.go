@@ -37,3 +37,66 @@ func firstCharacterSoundexMatch(s1, s2 string) bool {
 	}
 	return getPhoneticClass(s1) == getPhoneticClass(s2)
 }
+
+var soundexDigits = [26]byte{
+	// a b c d e f g h i j k l m n o p q r s t u v w x y z
+	0, '1', '2', '3', 0, '1', '2', 0, 0, '2', '2', '4', '5', '5', 0, '1', '2', '6', '2', '3', 0, '1', 0, '2', 0, '2',
+}
+
+// Soundex returns the American Soundex code of s, such as "M530" for both "Mohammed" and "Muhamad".
+// Characters outside of a-z are skipped. An empty code is returned when s has no letters.
+func Soundex(s string) string {
+	code, ok := soundex(s)
+	if !ok {
+		return ""
+	}
+	return string(code[:])
+}
+
+func soundex(s string) ([4]byte, bool) {
+	var code [4]byte
+	n := 0
+
+	var last byte
+	for i := 0; i < len(s) && n < len(code); i++ {
+		c := s[i]
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		if c < 'a' || c > 'z' {
+			continue
+		}
+		digit := soundexDigits[c-'a']
+
+		if n == 0 {
+			code[0] = c - ('a' - 'A')
+			n++
+			last = digit
+			continue
+		}
+		switch {
+		case c == 'h' || c == 'w':
+			// h and w don't separate letters with the same code
+		case digit == 0:
+			last = 0 // vowels separate letters with the same code
+		case digit != last:
+			code[n] = digit
+			n++
+			last = digit
+		}
+	}
+	if n == 0 {
+		return code, false
+	}
+	for ; n < len(code); n++ {
+		code[n] = '0'
+	}
+	return code, true
+}
+
+// SoundexMatch reports if s1 and s2 have the same Soundex code.
+func SoundexMatch(s1, s2 string) bool {
+	c1, ok1 := soundex(s1)
+	c2, ok2 := soundex(s2)
+	return ok1 && ok2 && c1 == c2
+}
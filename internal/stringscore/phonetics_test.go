@@ -33,3 +33,28 @@ func TestDisablePhoneticFiltering(t *testing.T) {
 	score = BestPairsJaroWinkler(search, indexed)
 	require.InDelta(t, 0.544, score, 0.01)
 }
+
+func TestSoundex(t *testing.T) {
+	cases := map[string]string{
+		"Robert":   "R163",
+		"Rupert":   "R163",
+		"Rubin":    "R150",
+		"Ashcraft": "A261",
+		"Tymczak":  "T522",
+		"Pfister":  "P236",
+		"Honeyman": "H555",
+		"Mohammed": "M530",
+		"Muhamad":  "M530",
+		"Lee":      "L000",
+		"":         "",
+		"123":      "",
+	}
+	for input, expected := range cases {
+		require.Equal(t, expected, Soundex(input), input)
+	}
+
+	require.True(t, SoundexMatch("mohammed", "MUHAMAD"))
+	require.True(t, SoundexMatch("Catherine", "Cathryn"))
+	require.False(t, SoundexMatch("Catherine", "Katherine"))
+	require.False(t, SoundexMatch("", ""))
+}
@@ -0,0 +1,128 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+
+	"github.com/moov-io/base/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Config chooses where spans are sent. Spans aren't recorded when Endpoint is empty.
+type Config struct {
+	// Endpoint is an OpenTelemetry collector which accepts OTLP over HTTP, such as http://localhost:4318
+	Endpoint string
+
+	// Headers are sent with each export, such as the API key of a hosted collector
+	Headers map[string]string
+
+	// ServiceName is reported as each span's service.name, which is watchman when empty
+	ServiceName string
+
+	// SampleRatio is the fraction of new traces which are recorded, every trace when zero. Traces continued
+	// from a caller are recorded when the caller recorded them.
+	SampleRatio float64
+}
+
+const (
+	defaultServiceName = "watchman"
+
+	// instrumentationName is the scope of Watchman's own spans
+	instrumentationName = "github.com/moov-io/watchman"
+)
+
+func (c Config) Validate() error {
+	if math.IsNaN(c.SampleRatio) || c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return errors.New("trace sample ratio must be between 0 and 1")
+	}
+	if c.Endpoint != "" {
+		u, err := url.Parse(c.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("trace endpoint %q isn't an http(s) URL", c.Endpoint)
+		}
+	}
+	return nil
+}
+
+// Setup records spans and sends them to conf's collector, which replaces any earlier Setup. Spans are continued
+// from the W3C traceparent of callers. The returned func sends the spans which are left and stops recording,
+// and it does nothing when tracing isn't configured.
+func Setup(logger log.Logger, conf Config) (func(ctx context.Context) error, error) {
+	if conf.Endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(), exporterOptions(conf)...)
+	if err != nil {
+		return nil, fmt.Errorf("creating trace exporter: %w", err)
+	}
+
+	ratio := conf.SampleRatio
+	if ratio == 0 {
+		ratio = 1
+	}
+	serviceName := conf.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", serviceName))),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		logger.Error().LogErrorf("problem sending spans: %v", err)
+	}))
+
+	return provider.Shutdown, nil
+}
+
+// exporterOptions sends spans to the /v1/traces path of conf's endpoint, unless it already ends with it
+func exporterOptions(conf Config) []otlptracehttp.Option {
+	u, _ := url.Parse(conf.Endpoint)
+
+	path := strings.TrimSuffix(u.Path, "/")
+	if !strings.HasSuffix(path, "/v1/traces") {
+		path += "/v1/traces"
+	}
+	opts := []otlptracehttp.Option{
+		otlptracehttp.WithEndpoint(u.Host),
+		otlptracehttp.WithURLPath(path),
+		otlptracehttp.WithHeaders(conf.Headers),
+	}
+	if u.Scheme == "http" {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	return opts
+}
+
+// Start begins a span as a child of the span in ctx, or a new trace when there's none. The returned context
+// holds the new span, which must be ended.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// RecordError marks the span as failed when err is set
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
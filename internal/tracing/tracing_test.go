@@ -0,0 +1,207 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// collector receives the spans sent to it
+type collector struct {
+	mu       sync.Mutex
+	spans    []*tracepb.Span
+	services []string
+	headers  http.Header
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bs, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req coltracepb.ExportTraceServiceRequest
+	if err := proto.Unmarshal(bs, &req); err != nil || r.URL.Path != "/v1/traces" {
+		http.Error(w, "unexpected export", http.StatusBadRequest)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.headers = r.Header.Clone()
+	for _, rs := range req.ResourceSpans {
+		for _, attr := range rs.GetResource().GetAttributes() {
+			if attr.Key == "service.name" {
+				c.services = append(c.services, attr.Value.GetStringValue())
+			}
+		}
+		for _, ss := range rs.ScopeSpans {
+			c.spans = append(c.spans, ss.Spans...)
+		}
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+}
+
+func (c *collector) span(t *testing.T, name string) *tracepb.Span {
+	t.Helper()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, span := range c.spans {
+		if span.Name == name {
+			return span
+		}
+	}
+	t.Fatalf("no %q span in %d spans", name, len(c.spans))
+	return nil
+}
+
+func setupCollector(t *testing.T, conf Config) (*collector, func()) {
+	t.Helper()
+
+	c := &collector{}
+	server := httptest.NewServer(c)
+	t.Cleanup(server.Close)
+
+	conf.Endpoint = server.URL
+	shutdown, err := Setup(log.NewTestLogger(), conf)
+	require.NoError(t, err)
+
+	return c, func() {
+		require.NoError(t, shutdown(context.Background()))
+	}
+}
+
+func value(span *tracepb.Span, key string) *commonpb.AnyValue {
+	for _, attr := range span.Attributes {
+		if attr.Key == key {
+			return attr.Value
+		}
+	}
+	return nil
+}
+
+func TestConfig_Validate(t *testing.T) {
+	require.NoError(t, Config{}.Validate())
+	require.NoError(t, Config{SampleRatio: 0.25}.Validate())
+	require.NoError(t, Config{Endpoint: "https://otlp.example.com/v1/traces"}.Validate())
+	require.Error(t, Config{SampleRatio: 1.5}.Validate())
+	require.Error(t, Config{SampleRatio: -0.1}.Validate())
+	require.Error(t, Config{Endpoint: "localhost:4318"}.Validate())
+}
+
+func TestStart_Disabled(t *testing.T) {
+	_, span := Start(context.Background(), "unused")
+	require.False(t, span.IsRecording())
+
+	// Spans which aren't recorded can still be used
+	span.SetAttributes(attribute.Int("entities", 10))
+	RecordError(span, errors.New("bad thing"))
+	span.End()
+}
+
+func TestStart(t *testing.T) {
+	c, shutdown := setupCollector(t, Config{
+		Headers:     map[string]string{"X-Api-Key": "secret"},
+		ServiceName: "watchman-test",
+	})
+
+	ctx, parent := Start(context.Background(), "download.refresh", attribute.Int("lists", 2))
+	require.True(t, parent.IsRecording())
+
+	_, child := Start(ctx, "download.list", attribute.String("list", "us_ofac"))
+	RecordError(child, errors.New("connection refused"))
+	child.End()
+
+	parent.SetAttributes(attribute.Float64("took", 1.5), attribute.Bool("partial", true))
+	RecordError(parent, nil)
+	parent.End()
+	shutdown()
+
+	require.Len(t, c.spans, 2)
+	require.Equal(t, "secret", c.headers.Get("X-Api-Key"))
+	require.Contains(t, c.services, "watchman-test")
+
+	refresh := c.span(t, "download.refresh")
+	list := c.span(t, "download.list")
+	require.Equal(t, refresh.TraceId, list.TraceId)
+	require.Equal(t, refresh.SpanId, list.ParentSpanId)
+	require.Empty(t, refresh.ParentSpanId)
+
+	require.Equal(t, int64(2), value(refresh, "lists").GetIntValue())
+	require.Equal(t, 1.5, value(refresh, "took").GetDoubleValue())
+	require.True(t, value(refresh, "partial").GetBoolValue())
+	require.Equal(t, "us_ofac", value(list, "list").GetStringValue())
+
+	require.Equal(t, tracepb.Status_STATUS_CODE_UNSET, refresh.GetStatus().GetCode())
+	require.Equal(t, tracepb.Status_STATUS_CODE_ERROR, list.GetStatus().GetCode())
+	require.Equal(t, "connection refused", list.GetStatus().GetMessage())
+}
+
+func TestStart_Sampling(t *testing.T) {
+	c, shutdown := setupCollector(t, Config{SampleRatio: 0.000001})
+
+	var sampled int
+	for i := 0; i < 100; i++ {
+		ctx, span := Start(context.Background(), "search")
+		if span.IsRecording() {
+			sampled++
+		}
+		// Children follow the decision of their trace
+		_, child := Start(ctx, "child")
+		require.Equal(t, span.IsRecording(), child.IsRecording())
+		child.End()
+		span.End()
+	}
+	shutdown()
+
+	require.Less(t, sampled, 5)
+	require.Len(t, c.spans, sampled*2)
+}
+
+func TestHandler(t *testing.T) {
+	c, shutdown := setupCollector(t, Config{})
+
+	router := mux.NewRouter()
+	router.Use(Handler)
+	router.Methods("GET").Path("/v2/search/{id}").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, span := Start(r.Context(), "search")
+		span.End()
+
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	req := httptest.NewRequest("GET", "/v2/search/123", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusServiceUnavailable, w.Code)
+	shutdown()
+
+	server := c.span(t, "GET /v2/search/{id}")
+	require.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", hex.EncodeToString(server.TraceId))
+	require.Equal(t, "00f067aa0ba902b7", hex.EncodeToString(server.ParentSpanId))
+	require.Equal(t, tracepb.Span_SPAN_KIND_SERVER, server.Kind)
+	require.Equal(t, "/v2/search/{id}", value(server, "http.route").GetStringValue())
+	require.Equal(t, int64(503), value(server, "http.status_code").GetIntValue())
+	require.Equal(t, tracepb.Status_STATUS_CODE_ERROR, server.GetStatus().GetCode())
+
+	search := c.span(t, "search")
+	require.Equal(t, server.TraceId, search.TraceId)
+	require.Equal(t, server.SpanId, search.ParentSpanId)
+}
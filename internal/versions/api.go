@@ -0,0 +1,149 @@
+package versions
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+)
+
+type Controller interface {
+	AppendRoutes(router *mux.Router) *mux.Router
+}
+
+func NewController(logger log.Logger, service Service) Controller {
+	return &controller{
+		logger:  logger,
+		service: service,
+	}
+}
+
+type controller struct {
+	logger  log.Logger
+	service Service
+}
+
+func (c *controller) AppendRoutes(router *mux.Router) *mux.Router {
+	router.
+		Name("ListVersions.v2").
+		Methods("GET").
+		Path("/v2/listinfo/{list}/versions").
+		HandlerFunc(c.listVersions)
+
+	router.
+		Name("ListVersionsDiff.v2").
+		Methods("GET").
+		Path("/v2/listinfo/{list}/versions/diff").
+		HandlerFunc(c.diffVersions)
+
+	router.
+		Name("Changes.v2").
+		Methods("GET").
+		Path("/v2/changes").
+		HandlerFunc(c.changes)
+
+	return router
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (c *controller) writeError(w http.ResponseWriter, status int, err error) {
+	c.logger.Error().LogError(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error: err.Error(),
+	})
+}
+
+type listVersionsResponse struct {
+	Versions []Version `json:"versions"`
+}
+
+func (c *controller) listVersions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	var filter Filter
+	var err error
+	if filter.From, err = readTime(q.Get("from"), false); err != nil {
+		c.writeError(w, http.StatusBadRequest, fmt.Errorf("reading from: %w", err))
+		return
+	}
+	if filter.To, err = readTime(q.Get("to"), true); err != nil {
+		c.writeError(w, http.StatusBadRequest, fmt.Errorf("reading to: %w", err))
+		return
+	}
+	if v := q.Get("limit"); v != "" {
+		filter.Limit, _ = strconv.Atoi(v)
+	}
+
+	versions, err := c.service.List(r.Context(), mux.Vars(r)["list"], filter)
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("listing versions: %w", err))
+		return
+	}
+	if versions == nil {
+		versions = []Version{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(listVersionsResponse{
+		Versions: versions,
+	})
+}
+
+func (c *controller) diffVersions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	diff, err := c.service.Diff(r.Context(), mux.Vars(r)["list"], q.Get("from"), q.Get("to"))
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, ErrVersionNotFound) {
+			status = http.StatusNotFound
+		}
+		c.writeError(w, status, fmt.Errorf("diffing versions: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(diff)
+}
+
+func (c *controller) changes(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	query := ChangeQuery{
+		Since:  q.Get("since"),
+		Cursor: strings.TrimSpace(q.Get("cursor")),
+	}
+	if v := strings.TrimSpace(q.Get("lists")); v != "" {
+		for _, list := range strings.Split(v, ",") {
+			query.Lists = append(query.Lists, strings.TrimSpace(list))
+		}
+	}
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			c.writeError(w, http.StatusBadRequest, fmt.Errorf("reading limit: %q", v))
+			return
+		}
+		query.Limit = n
+	}
+
+	feed, err := c.service.Changes(r.Context(), query)
+	if err != nil {
+		c.writeError(w, http.StatusBadRequest, fmt.Errorf("reading changes: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(feed)
+}
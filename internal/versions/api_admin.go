@@ -0,0 +1,218 @@
+package versions
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/download"
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+)
+
+// NewAdminController returns the routes which pin and roll back the list versions being searched, and export
+// the searched entities.
+// They're expected to be served on the admin server.
+func NewAdminController(logger log.Logger, service Service, searchService search.Service) Controller {
+	return &adminController{
+		controller: controller{
+			logger:  logger,
+			service: service,
+		},
+		searchService: searchService,
+	}
+}
+
+type adminController struct {
+	controller
+
+	searchService search.Service
+}
+
+func (c *adminController) AppendRoutes(router *mux.Router) *mux.Router {
+	router.
+		Name("ListPins").
+		Methods("GET").
+		Path("/lists/pins").
+		HandlerFunc(c.listPins)
+
+	router.
+		Name("PinList").
+		Methods("PUT").
+		Path("/lists/{list}/pin").
+		HandlerFunc(c.pinList)
+
+	router.
+		Name("UnpinList").
+		Methods("DELETE").
+		Path("/lists/{list}/pin").
+		HandlerFunc(c.unpinList)
+
+	router.
+		Name("RollbackList").
+		Methods("POST").
+		Path("/lists/{list}/rollback").
+		HandlerFunc(c.rollbackList)
+
+	router.
+		Name("ExportEntities").
+		Methods("GET").
+		Path("/entities/export").
+		HandlerFunc(c.exportEntities)
+
+	return router
+}
+
+type listPinsResponse struct {
+	Pins []Pin `json:"pins"`
+}
+
+func (c *adminController) listPins(w http.ResponseWriter, r *http.Request) {
+	pins, err := c.service.Pins(r.Context())
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("listing pins: %w", err))
+		return
+	}
+	if pins == nil {
+		pins = []Pin{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(listPinsResponse{
+		Pins: pins,
+	})
+}
+
+type pinRequest struct {
+	// Version is a VersionID, or a time which chooses the last version downloaded at or before it
+	Version string `json:"version"`
+}
+
+func (c *adminController) pinList(w http.ResponseWriter, r *http.Request) {
+	var req pinRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.writeError(w, http.StatusBadRequest, fmt.Errorf("reading pin request: %w", err))
+		return
+	}
+
+	list := mux.Vars(r)["list"]
+	pin, err := c.service.Pin(r.Context(), list, req.Version)
+	if err != nil {
+		c.writeServiceError(w, fmt.Errorf("pinning %s: %w", list, err))
+		return
+	}
+	c.serve(w, r, list, pin)
+}
+
+func (c *adminController) rollbackList(w http.ResponseWriter, r *http.Request) {
+	list := mux.Vars(r)["list"]
+	pin, err := c.service.Rollback(r.Context(), list)
+	if err != nil {
+		c.writeServiceError(w, fmt.Errorf("rolling back %s: %w", list, err))
+		return
+	}
+	c.serve(w, r, list, pin)
+}
+
+func (c *adminController) unpinList(w http.ResponseWriter, r *http.Request) {
+	list := mux.Vars(r)["list"]
+	if err := c.service.Unpin(r.Context(), list); err != nil {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("unpinning %s: %w", list, err))
+		return
+	}
+	c.serve(w, r, list, nil)
+}
+
+// serve replaces the searched entities of list with those of its pinned or latest version
+func (c *adminController) serve(w http.ResponseWriter, r *http.Request, list string, pin *Pin) {
+	entities, err := c.service.Serving(r.Context(), list)
+	if err != nil && !errors.Is(err, ErrVersionNotFound) {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("reading %s entities: %w", list, err))
+		return
+	}
+	if err == nil {
+		current := c.searchService.Entities()
+
+		next := make([]pubsearch.Entity[pubsearch.Value], 0, len(current))
+		for _, entity := range current {
+			if string(entity.Source) != list {
+				next = append(next, entity)
+			}
+		}
+		next = append(next, entities...)
+
+		diff := download.DiffEntities(current, next)
+		c.searchService.ApplyChanges(diff)
+
+		c.logger.Info().Logf("serving %d %s entities after %d added, %d modified and %d removed",
+			len(entities), list, len(diff.Added), len(diff.Modified), len(diff.Removed))
+	}
+
+	if pin == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(pin)
+}
+
+// exportEntities writes the searched entities as CSV or JSON lines (?format=csv|json), optionally of only
+// some lists (?lists=us_ofac,uk_csl)
+func (c *adminController) exportEntities(w http.ResponseWriter, r *http.Request) {
+	format := cmp.Or(strings.ToLower(r.URL.Query().Get("format")), ExportJSON)
+	if format != ExportCSV && format != ExportJSON {
+		c.writeError(w, http.StatusBadRequest, fmt.Errorf("unknown export format %q", format))
+		return
+	}
+
+	var only []string
+	if v := strings.TrimSpace(r.URL.Query().Get("lists")); v != "" {
+		for _, list := range strings.Split(v, ",") {
+			only = append(only, strings.TrimSpace(list))
+		}
+	}
+
+	var entities []pubsearch.Entity[pubsearch.Value]
+	var lists []string
+	for _, entity := range c.searchService.Entities() {
+		list := string(entity.Source)
+		if len(only) > 0 && !slices.Contains(only, list) {
+			continue
+		}
+		if !slices.Contains(lists, list) {
+			lists = append(lists, list)
+		}
+		entities = append(entities, entity)
+	}
+	current, err := c.service.Current(r.Context(), lists)
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("reading list versions: %w", err))
+		return
+	}
+
+	if format == ExportCSV {
+		w.Header().Set("Content-Type", "text/csv")
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=watchman-entities.%s", format))
+
+	if err := Export(w, format, entities, current); err != nil {
+		c.logger.Error().LogErrorf("problem exporting entities: %v", err)
+	}
+}
+
+func (c *adminController) writeServiceError(w http.ResponseWriter, err error) {
+	status := http.StatusBadRequest
+	if errors.Is(err, ErrVersionNotFound) {
+		status = http.StatusNotFound
+	}
+	c.writeError(w, status, err)
+}
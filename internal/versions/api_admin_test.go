@@ -0,0 +1,99 @@
+package versions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminController(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+	svc := NewService(logger, NewInMemoryRepository())
+
+	lists := map[string]int{"us_ofac": 1}
+	good, err := svc.Record(ctx, time.Date(2024, time.March, 3, 12, 0, 0, 0, time.UTC), lists, []pubsearch.Entity[pubsearch.Value]{
+		testEntity("1", "Acme Shipping"),
+	})
+	require.NoError(t, err)
+
+	// A bad download removed every entity
+	_, err = svc.Record(ctx, time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC), lists, nil)
+	require.NoError(t, err)
+
+	searchService := search.NewService(logger)
+	other := pubsearch.Entity[pubsearch.Value]{Name: "Other", Source: pubsearch.SourceUSCSL, SourceID: "5"}
+	searchService.UpdateEntities([]pubsearch.Entity[pubsearch.Value]{other})
+
+	router := mux.NewRouter()
+	NewAdminController(logger, svc, searchService).AppendRoutes(router)
+
+	sourceIDs := func() []string {
+		var out []string
+		for _, entity := range searchService.Entities() {
+			out = append(out, entity.SourceID)
+		}
+		return out
+	}
+
+	// rollback
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/lists/us_ofac/rollback", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var pin Pin
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&pin))
+	require.Equal(t, good[0].VersionID, pin.Version.VersionID)
+	require.ElementsMatch(t, []string{"1", "5"}, sourceIDs())
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/lists/us_ofac/rollback", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	// list pins
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/lists/pins", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var pins listPinsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&pins))
+	require.Len(t, pins.Pins, 1)
+
+	// unpin serves the latest version
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/lists/us_ofac/pin", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.ElementsMatch(t, []string{"5"}, sourceIDs())
+
+	// pin
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("PUT", "/lists/us_ofac/pin", strings.NewReader(`{"version": "2024-03-05"}`))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.ElementsMatch(t, []string{"1", "5"}, sourceIDs())
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("PUT", "/lists/us_ofac/pin", strings.NewReader(`{"version": "2024-01-01"}`))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("PUT", "/lists/us_ofac/pin", strings.NewReader(`{`))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,67 @@
+package versions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestController(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(log.NewTestLogger(), NewInMemoryRepository())
+
+	lists := map[string]int{"us_ofac": 1}
+	_, err := svc.Record(ctx, time.Date(2024, time.March, 3, 12, 0, 0, 0, time.UTC), lists, []search.Entity[search.Value]{
+		testEntity("1", "Acme Shipping"),
+	})
+	require.NoError(t, err)
+	_, err = svc.Record(ctx, time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC), lists, []search.Entity[search.Value]{
+		testEntity("2", "Bravo Trading"),
+	})
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), svc).AppendRoutes(router)
+
+	// list
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/v2/listinfo/us_ofac/versions?from=2024-03-01&limit=5", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp listVersionsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Versions, 2)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/listinfo/us_ofac/versions?to=yesterday", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// diff
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/listinfo/us_ofac/versions/diff?from=2024-03-03&to=2024-03-10", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var diff Diff
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&diff))
+	require.Len(t, diff.Added, 1)
+	require.Equal(t, "2", diff.Added[0].SourceID)
+	require.Len(t, diff.Removed, 1)
+	require.Equal(t, "1", diff.Removed[0].SourceID)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/listinfo/us_ofac/versions/diff?from=2024-01-01&to=2024-03-10", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,245 @@
+package versions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+const (
+	defaultChangesLimit = 100
+	maxChangesLimit     = 1000
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid changes cursor")
+)
+
+// Changes reads the versions of each list in the order they were recorded, comparing each against the list's
+// previous version. A cursor is the VersionID a page stopped in along with how many of its changes were returned.
+func (s *service) Changes(ctx context.Context, query ChangeQuery) (*ChangeFeed, error) {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultChangesLimit
+	}
+	limit = minInt(limit, maxChangesLimit)
+
+	lists := query.Lists
+	if len(lists) == 0 {
+		var err error
+		if lists, err = s.repo.ListNames(ctx); err != nil {
+			return nil, fmt.Errorf("reading lists: %w", err)
+		}
+	}
+
+	out := &ChangeFeed{
+		Changes: []Change{},
+		Latest:  strings.TrimSpace(query.Since),
+	}
+
+	// include chooses the versions after where the feed starts, and skip is how many changes of the first to leave out
+	var include func(Version) bool
+	var from time.Time
+	skip := 0
+	switch {
+	case query.Cursor != "":
+		out.Latest = ""
+		versionID, offset, found := strings.Cut(query.Cursor, ":")
+		n, err := strconv.Atoi(offset)
+		if !found || err != nil || n < 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidCursor, query.Cursor)
+		}
+		start, err := s.findVersion(ctx, lists, versionID)
+		if err != nil {
+			return nil, err
+		}
+		if start == nil {
+			return nil, fmt.Errorf("%w: version %s not found", ErrInvalidCursor, versionID)
+		}
+		include = func(v Version) bool { return compareVersions(v, *start) >= 0 }
+		from, skip = start.DownloadedAt, n
+
+	case out.Latest != "":
+		start, err := s.findVersion(ctx, lists, out.Latest)
+		if err != nil {
+			return nil, err
+		}
+		if start != nil {
+			include = func(v Version) bool { return compareVersions(v, *start) > 0 }
+			from = start.DownloadedAt
+		} else {
+			at, err := readTime(out.Latest, false)
+			if err != nil {
+				return nil, fmt.Errorf("since: %w", err)
+			}
+			include = func(v Version) bool { return v.DownloadedAt.After(at) }
+			from = at
+		}
+
+	default:
+		return nil, errors.New("missing since")
+	}
+
+	versions, err := s.versionsFrom(ctx, lists, from)
+	if err != nil {
+		return nil, err
+	}
+	for _, version := range versions {
+		if !include(version.Version) {
+			continue
+		}
+		changes, err := s.versionChanges(ctx, version.previous, version.Version)
+		if err != nil {
+			return nil, err
+		}
+		offset := minInt(skip, len(changes))
+		changes, skip = changes[offset:], 0
+
+		if room := limit - len(out.Changes); len(changes) > room {
+			out.Changes = append(out.Changes, changes[:room]...)
+			out.Next = fmt.Sprintf("%s:%d", version.VersionID, offset+room)
+			return out, nil
+		}
+		out.Changes = append(out.Changes, changes...)
+		out.Latest = version.VersionID
+	}
+	return out, nil
+}
+
+// compareVersions orders versions by when they were downloaded, then by list and VersionID
+func compareVersions(a, b Version) int {
+	if c := a.DownloadedAt.Compare(b.DownloadedAt); c != 0 {
+		return c
+	}
+	if c := strings.Compare(a.List, b.List); c != 0 {
+		return c
+	}
+	return strings.Compare(a.VersionID, b.VersionID)
+}
+
+// findVersion returns the version of any of lists with a VersionID, or nil when there isn't one
+func (s *service) findVersion(ctx context.Context, lists []string, versionID string) (*Version, error) {
+	for _, list := range lists {
+		version, err := s.repo.GetVersion(ctx, list, versionID)
+		if err != nil || version != nil {
+			return version, err
+		}
+	}
+	return nil, nil
+}
+
+type feedVersion struct {
+	Version
+
+	// previous is the version of the list before this one, which is nil for a list's first version
+	previous *Version
+}
+
+// versionsFrom returns the versions of lists downloaded at or after from, ordered by compareVersions
+func (s *service) versionsFrom(ctx context.Context, lists []string, from time.Time) ([]feedVersion, error) {
+	var out []feedVersion
+	for _, list := range lists {
+		versions, err := s.repo.ListVersions(ctx, list, Filter{From: from})
+		if err != nil {
+			return nil, fmt.Errorf("reading %s versions: %w", list, err)
+		}
+		if len(versions) == 0 {
+			continue
+		}
+		slices.Reverse(versions) // oldest first
+
+		// The version before the oldest is what its changes are compared against
+		var previous *Version
+		earlier, err := s.repo.ListVersions(ctx, list, Filter{To: versions[0].DownloadedAt, Limit: 2})
+		if err != nil {
+			return nil, fmt.Errorf("reading %s versions: %w", list, err)
+		}
+		for _, version := range earlier {
+			if version.VersionID != versions[0].VersionID {
+				version := version
+				previous = &version
+				break
+			}
+		}
+
+		for i := range versions {
+			out = append(out, feedVersion{Version: versions[i], previous: previous})
+			previous = &versions[i]
+		}
+	}
+	slices.SortFunc(out, func(a, b feedVersion) int {
+		return compareVersions(a.Version, b.Version)
+	})
+	return out, nil
+}
+
+// versionChanges compares the entities of a version to its previous version, ordered by SourceID
+func (s *service) versionChanges(ctx context.Context, previous *Version, version Version) ([]Change, error) {
+	if previous != nil && previous.Hash == version.Hash {
+		return nil, nil
+	}
+	before := make(map[string]string)
+	if previous != nil {
+		var err error
+		if before, err = s.repo.Members(ctx, version.List, previous.Hash); err != nil {
+			return nil, fmt.Errorf("reading %s members: %w", previous.VersionID, err)
+		}
+	}
+	after, err := s.repo.Members(ctx, version.List, version.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s members: %w", version.VersionID, err)
+	}
+
+	var out []Change
+	var fingerprints []string
+	for sourceID, fp := range after {
+		existing, found := before[sourceID]
+		switch {
+		case !found:
+			out = append(out, Change{Type: ChangeAdded, SourceID: sourceID})
+			fingerprints = append(fingerprints, fp)
+		case existing != fp:
+			out = append(out, Change{Type: ChangeModified, SourceID: sourceID})
+			fingerprints = append(fingerprints, existing, fp)
+		}
+	}
+	for sourceID, fp := range before {
+		if _, found := after[sourceID]; !found {
+			out = append(out, Change{Type: ChangeRemoved, SourceID: sourceID})
+			fingerprints = append(fingerprints, fp)
+		}
+	}
+
+	entities, err := s.repo.Entities(ctx, fingerprints)
+	if err != nil {
+		return nil, fmt.Errorf("reading changed entities: %w", err)
+	}
+	snapshot := func(members map[string]string, sourceID string) *search.Entity[search.Value] {
+		entity, exists := entities[members[sourceID]]
+		if !exists {
+			return nil
+		}
+		return &entity
+	}
+	for i := range out {
+		out[i].List = version.List
+		out[i].VersionID = version.VersionID
+		out[i].ChangedAt = version.DownloadedAt
+		if out[i].Type != ChangeAdded {
+			out[i].Before = snapshot(before, out[i].SourceID)
+		}
+		if out[i].Type != ChangeRemoved {
+			out[i].After = snapshot(after, out[i].SourceID)
+		}
+	}
+	slices.SortFunc(out, func(a, b Change) int {
+		return strings.Compare(a.SourceID, b.SourceID)
+	})
+	return out, nil
+}
@@ -0,0 +1,163 @@
+package versions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_Changes(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(log.NewTestLogger(), NewInMemoryRepository())
+
+	ukEntity := func(sourceID, name string) search.Entity[search.Value] {
+		entity := testEntity(sourceID, name)
+		entity.Source = search.SourceUKCSL
+		return entity
+	}
+
+	first := time.Date(2024, time.March, 3, 12, 0, 0, 0, time.UTC)
+	initial, err := svc.Record(ctx, first, map[string]int{"us_ofac": 2, "uk_csl": 1}, []search.Entity[search.Value]{
+		testEntity("1", "Acme Shipping"),
+		testEntity("2", "Bravo Trading"),
+		ukEntity("UK-1", "Charlie Holdings"),
+	})
+	require.NoError(t, err)
+
+	second := first.Add(24 * time.Hour)
+	_, err = svc.Record(ctx, second, map[string]int{"us_ofac": 2, "uk_csl": 1}, []search.Entity[search.Value]{
+		testEntity("1", "Acme Shipping Ltd"), // modified
+		testEntity("3", "Delta Mining"),      // added, while 2 was removed
+		ukEntity("UK-1", "Charlie Holdings"), // unchanged
+	})
+	require.NoError(t, err)
+
+	third := second.Add(24 * time.Hour)
+	latest, err := svc.Record(ctx, third, map[string]int{"us_ofac": 2}, []search.Entity[search.Value]{
+		testEntity("1", "Acme Shipping Ltd"),
+		testEntity("4", "Echo Metals"),
+	})
+	require.NoError(t, err)
+
+	// Changes since the initial download
+	feed, err := svc.Changes(ctx, ChangeQuery{Since: first.Format(time.RFC3339)})
+	require.NoError(t, err)
+	require.Empty(t, feed.Next)
+	require.Equal(t, latest[0].VersionID, feed.Latest)
+	require.Len(t, feed.Changes, 5)
+
+	modified := feed.Changes[0]
+	require.Equal(t, ChangeModified, modified.Type)
+	require.Equal(t, "1", modified.SourceID)
+	require.Equal(t, second, modified.ChangedAt)
+	require.Equal(t, "Acme Shipping", modified.Before.Name)
+	require.Equal(t, "Acme Shipping Ltd", modified.After.Name)
+
+	removed := feed.Changes[1]
+	require.Equal(t, ChangeRemoved, removed.Type)
+	require.Equal(t, "2", removed.SourceID)
+	require.Equal(t, "Bravo Trading", removed.Before.Name)
+	require.Nil(t, removed.After)
+
+	added := feed.Changes[2]
+	require.Equal(t, ChangeAdded, added.Type)
+	require.Nil(t, added.Before)
+	require.Equal(t, "Delta Mining", added.After.Name)
+
+	require.Equal(t, "3", feed.Changes[3].SourceID)
+	require.Equal(t, ChangeRemoved, feed.Changes[3].Type)
+	require.Equal(t, "4", feed.Changes[4].SourceID)
+	require.Equal(t, latest[0].VersionID, feed.Changes[4].VersionID)
+
+	// Paging through the same changes
+	var paged []Change
+	query := ChangeQuery{Since: initial[1].VersionID, Limit: 2}
+	for page := 0; page < 5; page++ {
+		feed, err := svc.Changes(ctx, query)
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(feed.Changes), 2)
+		paged = append(paged, feed.Changes...)
+		if feed.Next == "" {
+			require.Equal(t, latest[0].VersionID, feed.Latest)
+			break
+		}
+		query = ChangeQuery{Cursor: feed.Next, Limit: 2}
+	}
+	require.Len(t, paged, 5)
+	for i := range paged {
+		require.Equal(t, feed.Changes[i].SourceID, paged[i].SourceID)
+		require.Equal(t, feed.Changes[i].Type, paged[i].Type)
+	}
+
+	// Caught up
+	feed, err = svc.Changes(ctx, ChangeQuery{Since: latest[0].VersionID})
+	require.NoError(t, err)
+	require.Empty(t, feed.Changes)
+	require.Equal(t, latest[0].VersionID, feed.Latest)
+
+	// Every entity of a list's first version is added
+	feed, err = svc.Changes(ctx, ChangeQuery{Since: "2024-03-01", Lists: []string{"uk_csl"}})
+	require.NoError(t, err)
+	require.Len(t, feed.Changes, 1)
+	require.Equal(t, ChangeAdded, feed.Changes[0].Type)
+	require.Equal(t, "UK-1", feed.Changes[0].SourceID)
+
+	_, err = svc.Changes(ctx, ChangeQuery{})
+	require.ErrorContains(t, err, "missing since")
+
+	_, err = svc.Changes(ctx, ChangeQuery{Cursor: "missing:0"})
+	require.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestController_Changes(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(log.NewTestLogger(), NewInMemoryRepository())
+
+	lists := map[string]int{"us_ofac": 1}
+	_, err := svc.Record(ctx, time.Date(2024, time.March, 3, 12, 0, 0, 0, time.UTC), lists, []search.Entity[search.Value]{
+		testEntity("1", "Acme Shipping"),
+	})
+	require.NoError(t, err)
+	_, err = svc.Record(ctx, time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC), lists, []search.Entity[search.Value]{
+		testEntity("2", "Bravo Trading"),
+	})
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), svc).AppendRoutes(router)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/v2/changes?since=2024-03-04&lists=us_ofac&limit=1", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var feed ChangeFeed
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&feed))
+	require.Len(t, feed.Changes, 1)
+	require.Equal(t, ChangeRemoved, feed.Changes[0].Type)
+	require.Equal(t, "1", feed.Changes[0].SourceID)
+	require.NotEmpty(t, feed.Next)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/v2/changes?cursor="+feed.Next, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var next ChangeFeed
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&next))
+	require.Len(t, next.Changes, 1)
+	require.Equal(t, ChangeAdded, next.Changes[0].Type)
+	require.Empty(t, next.Next)
+
+	for _, query := range []string{"", "since=yesterday", "since=2024-03-04&limit=x", "cursor=abc"} {
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/v2/changes?"+query, nil))
+		require.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+}
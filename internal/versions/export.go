@@ -0,0 +1,113 @@
+package versions
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+)
+
+// Formats entities are exported in
+const (
+	ExportCSV  = "csv"
+	ExportJSON = "json"
+)
+
+// ExportedEntity is an entity being searched along with the version of its list
+type ExportedEntity struct {
+	SourceList  pubsearch.SourceList `json:"sourceList"`
+	SourceID    string               `json:"sourceID"`
+	VersionID   string               `json:"versionID,omitempty"`
+	VersionHash string               `json:"versionHash,omitempty"`
+
+	// NormalizedNames are the entity's names as they're compared by exact matches
+	NormalizedNames []string `json:"normalizedNames"`
+
+	Entity pubsearch.Entity[pubsearch.Value] `json:"entity"`
+}
+
+var exportColumns = []string{
+	"sourceList", "sourceID", "versionID", "versionHash", "entityType", "name", "altNames", "normalizedNames",
+}
+
+// Export writes entities, sorted by list and SourceID, as CSV or JSON lines. Each is written with the version of
+// its list from versions, which is keyed by list.
+func Export(w io.Writer, format string, entities []pubsearch.Entity[pubsearch.Value], versions map[string]Version) error {
+	if format != ExportCSV && format != ExportJSON {
+		return fmt.Errorf("unknown export format %q, expected %s or %s", format, ExportCSV, ExportJSON)
+	}
+
+	entities = slices.Clone(entities)
+	slices.SortFunc(entities, func(a, b pubsearch.Entity[pubsearch.Value]) int {
+		return cmp.Or(cmp.Compare(a.Source, b.Source), cmp.Compare(a.SourceID, b.SourceID))
+	})
+
+	if format == ExportJSON {
+		enc := json.NewEncoder(w)
+		for _, entity := range entities {
+			if err := enc.Encode(exportEntity(entity, versions)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportColumns); err != nil {
+		return err
+	}
+	for _, entity := range entities {
+		exported := exportEntity(entity, versions)
+		err := cw.Write([]string{
+			string(exported.SourceList),
+			exported.SourceID,
+			exported.VersionID,
+			exported.VersionHash,
+			string(entity.Type),
+			entity.Name,
+			strings.Join(altNames(entity), "; "),
+			strings.Join(exported.NormalizedNames, "; "),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func exportEntity(entity pubsearch.Entity[pubsearch.Value], versions map[string]Version) ExportedEntity {
+	out := ExportedEntity{
+		SourceList:      entity.Source,
+		SourceID:        entity.SourceID,
+		NormalizedNames: search.NormalizedNames(entity),
+		Entity:          entity,
+	}
+	if version, exists := versions[string(entity.Source)]; exists {
+		out.VersionID = version.VersionID
+		out.VersionHash = version.Hash
+	}
+	return out
+}
+
+func altNames(entity pubsearch.Entity[pubsearch.Value]) []string {
+	switch {
+	case entity.Person != nil:
+		return entity.Person.AltNames
+	case entity.Business != nil:
+		return entity.Business.AltNames
+	case entity.Organization != nil:
+		return entity.Organization.AltNames
+	case entity.Aircraft != nil:
+		return entity.Aircraft.AltNames
+	case entity.Vessel != nil:
+		return entity.Vessel.AltNames
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package versions
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport(t *testing.T) {
+	acme := testEntity("2", "Acme Shipping, Ltd.")
+	acme.Business.AltNames = []string{"Acme Freight"}
+	entities := []pubsearch.Entity[pubsearch.Value]{
+		acme,
+		{Name: "Other", Type: pubsearch.EntityPerson, Source: pubsearch.SourceUSCSL, SourceID: "5"},
+		testEntity("1", "Bolt Trading"),
+	}
+	versions := map[string]Version{
+		"us_ofac": {VersionID: "v1", List: "us_ofac", Hash: "abc123"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Export(&buf, ExportCSV, entities, versions))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, [][]string{
+		exportColumns,
+		{"us_csl", "5", "", "", "person", "Other", "", "other"},
+		{"us_ofac", "1", "v1", "abc123", "business", "Bolt Trading", "", "bolt trading"},
+		{"us_ofac", "2", "v1", "abc123", "business", "Acme Shipping, Ltd.", "Acme Freight", "acme shipping ltd; acme freight"},
+	}, rows)
+
+	buf.Reset()
+	require.NoError(t, Export(&buf, ExportJSON, entities, versions))
+
+	var exported []ExportedEntity
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var entity ExportedEntity
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entity))
+		exported = append(exported, entity)
+	}
+	require.Len(t, exported, 3)
+	require.Equal(t, "v1", exported[1].VersionID)
+	require.Equal(t, "Bolt Trading", exported[1].Entity.Name)
+	require.Equal(t, []string{"acme shipping ltd", "acme freight"}, exported[2].NormalizedNames)
+
+	require.ErrorContains(t, Export(&buf, "xml", entities, versions), "unknown export format")
+}
+
+func TestAdminController_Export(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+	svc := NewService(logger, NewInMemoryRepository())
+
+	entities := []pubsearch.Entity[pubsearch.Value]{
+		testEntity("1", "Acme Shipping"),
+		{Name: "Other", Source: pubsearch.SourceUSCSL, SourceID: "5"},
+	}
+	recorded, err := svc.Record(ctx, time.Date(2024, time.March, 3, 12, 0, 0, 0, time.UTC), map[string]int{"us_ofac": 1}, entities[:1])
+	require.NoError(t, err)
+
+	searchService := search.NewService(logger)
+	searchService.UpdateEntities(entities)
+
+	router := mux.NewRouter()
+	NewAdminController(logger, svc, searchService).AppendRoutes(router)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/entities/export?format=csv&lists=us_ofac", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/csv", w.Header().Get("Content-Type"))
+
+	rows, err := csv.NewReader(w.Body).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	require.Equal(t, []string{"us_ofac", "1", recorded[0].VersionID, recorded[0].Hash}, rows[1][:4])
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/entities/export", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	require.Equal(t, 2, bytes.Count(w.Body.Bytes(), []byte("\n")))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/entities/export?format=xml", nil))
+	require.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,86 @@
+package versions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/moov-io/watchman/internal/graphql"
+)
+
+// GraphQLSchema holds the listVersions field of the GraphQL endpoint
+const GraphQLSchema = `
+type Version {
+	versionID: String!
+	list: String!
+	hash: String!
+	entities: Int!
+	added: Int!
+	modified: Int!
+	removed: Int!
+	downloadedAt: Time!
+}
+
+extend type Query {
+	"""
+	Returns the versions of a list, newest first, with the parameters of /v2/listinfo/{list}/versions
+	"""
+	listVersions(list: String!, from: String, to: String, limit: Int): [Version!]
+}
+`
+
+// GraphQLVersions resolves the listVersions field of GraphQLSchema
+type GraphQLVersions struct {
+	service Service
+}
+
+func NewGraphQLVersions(service Service) *GraphQLVersions {
+	return &GraphQLVersions{
+		service: service,
+	}
+}
+
+type graphqlVersion struct {
+	VersionID    string
+	List         string
+	Hash         string
+	Entities     int32
+	Added        int32
+	Modified     int32
+	Removed      int32
+	DownloadedAt graphql.Time
+}
+
+func (g *GraphQLVersions) ListVersions(ctx context.Context, args struct {
+	List  string
+	From  *string
+	To    *string
+	Limit *int32
+}) (*[]graphqlVersion, error) {
+	if args.List == "" {
+		return nil, errors.New("missing list")
+	}
+
+	var filter Filter
+	var err error
+	if args.From != nil {
+		if filter.From, err = readTime(*args.From, false); err != nil {
+			return nil, fmt.Errorf("reading from: %w", err)
+		}
+	}
+	if args.To != nil {
+		if filter.To, err = readTime(*args.To, true); err != nil {
+			return nil, fmt.Errorf("reading to: %w", err)
+		}
+	}
+	if args.Limit != nil {
+		filter.Limit = int(*args.Limit)
+	}
+
+	versions, err := g.service.List(ctx, args.List, filter)
+	if err != nil {
+		return nil, fmt.Errorf("listing versions: %w", err)
+	}
+	out, err := graphql.Convert[[]graphqlVersion](versions)
+	return &out, err
+}
@@ -0,0 +1,46 @@
+package versions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/graphql"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQLVersions(t *testing.T) {
+	ctx := context.Background()
+	svc := NewService(log.NewTestLogger(), NewInMemoryRepository())
+
+	march3 := time.Date(2024, time.March, 3, 12, 0, 0, 0, time.UTC)
+	_, err := svc.Record(ctx, march3, map[string]int{"us_ofac": 1}, []search.Entity[search.Value]{
+		testEntity("1", "Acme Shipping"),
+	})
+	require.NoError(t, err)
+
+	schema, err := graphql.NewSchema(NewGraphQLVersions(svc), GraphQLSchema)
+	require.NoError(t, err)
+
+	resp := schema.Execute(ctx, graphql.Request{
+		Query: `{ listVersions(list: "us_ofac", from: "2024-03-01") { list entities downloadedAt } }`,
+	})
+	require.Empty(t, resp.Errors)
+
+	require.JSONEq(t, `{"listVersions":[{"list":"us_ofac","entities":1,"downloadedAt":"2024-03-03T12:00:00Z"}]}`, string(resp.Data))
+
+	resp = schema.Execute(ctx, graphql.Request{
+		Query: `{ listVersions(list: "us_csl") { list } }`,
+	})
+	require.Empty(t, resp.Errors)
+	require.JSONEq(t, `{"listVersions":[]}`, string(resp.Data))
+
+	resp = schema.Execute(ctx, graphql.Request{
+		Query: `{ listVersions(list: "us_ofac", from: "March") { list } }`,
+	})
+	require.Len(t, resp.Errors, 1)
+	require.Contains(t, resp.Errors[0].Message, "reading from")
+}
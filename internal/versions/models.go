@@ -0,0 +1,104 @@
+package versions
+
+import (
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// Version records one successful download of a list
+type Version struct {
+	VersionID string `json:"versionID"`
+	List      string `json:"list"`
+
+	// Hash is a SHA-256 of the list's entities. Versions with the same hash hold identical entities.
+	Hash     string `json:"hash"`
+	Entities int    `json:"entities"`
+
+	// Added, Modified and Removed count the changes from the list's previous version
+	Added    int `json:"added"`
+	Modified int `json:"modified"`
+	Removed  int `json:"removed"`
+
+	DownloadedAt time.Time `json:"downloadedAt"`
+}
+
+// Contents are the entities of a list version
+type Contents struct {
+	// Members holds the fingerprint of each entity, keyed by SourceID
+	Members map[string]string
+
+	// Entities are keyed by their fingerprint
+	Entities map[string]search.Entity[search.Value]
+}
+
+// Diff holds the entities which changed between two versions of a list
+type Diff struct {
+	From Version `json:"from"`
+	To   Version `json:"to"`
+
+	Added    []search.Entity[search.Value] `json:"added"`
+	Modified []search.Entity[search.Value] `json:"modified"`
+	Removed  []search.Entity[search.Value] `json:"removed"`
+}
+
+const (
+	ChangeAdded    = "added"
+	ChangeModified = "modified"
+	ChangeRemoved  = "removed"
+)
+
+// Change is one entity added, modified or removed by a list version. Before is the entity in the list's
+// previous version and After is the entity in this version, so additions only have After and removals only Before.
+type Change struct {
+	Type      string    `json:"type"`
+	List      string    `json:"list"`
+	SourceID  string    `json:"sourceID"`
+	VersionID string    `json:"versionID"`
+	ChangedAt time.Time `json:"changedAt"`
+
+	Before *search.Entity[search.Value] `json:"before,omitempty"`
+	After  *search.Entity[search.Value] `json:"after,omitempty"`
+}
+
+// ChangeQuery chooses the changes of a ChangeFeed
+type ChangeQuery struct {
+	// Since is a VersionID or time, and changes are those of versions recorded after it
+	Since string
+
+	// Cursor continues from the Next of a previous feed, in place of Since
+	Cursor string
+
+	// Lists only includes the changes of some lists, rather than every list with a recorded version
+	Lists []string
+
+	Limit int
+}
+
+// ChangeFeed is a page of changes, oldest first
+type ChangeFeed struct {
+	Changes []Change `json:"changes"`
+
+	// Next is the Cursor of the following page, which is empty once every change has been returned
+	Next string `json:"next,omitempty"`
+
+	// Latest is the newest version whose changes have all been returned, which is the Since to poll with later
+	Latest string `json:"latest,omitempty"`
+}
+
+// Pin keeps a list serving the entities of one version, rather than those of each refresh
+type Pin struct {
+	List    string  `json:"list"`
+	Version Version `json:"version"`
+
+	PinnedAt time.Time `json:"pinnedAt"`
+}
+
+// Filter narrows the versions returned by a Repository
+type Filter struct {
+	// From and To only include versions downloaded within their times, when set
+	From time.Time
+	To   time.Time
+
+	Limit int
+}
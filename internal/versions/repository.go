@@ -0,0 +1,181 @@
+package versions
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+type Repository interface {
+	// SaveVersion records version along with its entities. Contents are shared by versions with the same hash.
+	SaveVersion(ctx context.Context, version Version, contents Contents) error
+
+	// ListVersions returns the versions of a list, newest first
+	ListVersions(ctx context.Context, list string, filter Filter) ([]Version, error)
+	GetVersion(ctx context.Context, list, versionID string) (*Version, error)
+
+	// ListNames returns each list with a recorded version, sorted by name
+	ListNames(ctx context.Context) ([]string, error)
+
+	// Members returns the fingerprint of each entity in a list version, keyed by SourceID
+	Members(ctx context.Context, list, hash string) (map[string]string, error)
+
+	// Entities returns the entities with the given fingerprints
+	Entities(ctx context.Context, fingerprints []string) (map[string]search.Entity[search.Value], error)
+
+	// SavePin replaces the pin of a list
+	SavePin(ctx context.Context, pin Pin) error
+	DeletePin(ctx context.Context, list string) error
+
+	// ListPins returns every pinned list, sorted by list name
+	ListPins(ctx context.Context) ([]Pin, error)
+}
+
+func NewInMemoryRepository() Repository {
+	return &inmemRepository{
+		versions: make(map[string][]Version),
+		members:  make(map[contentsKey]map[string]string),
+		entities: make(map[string]search.Entity[search.Value]),
+		pins:     make(map[string]Pin),
+	}
+}
+
+type contentsKey struct {
+	list, hash string
+}
+
+type inmemRepository struct {
+	mu       sync.RWMutex
+	versions map[string][]Version // oldest first
+	members  map[contentsKey]map[string]string
+	entities map[string]search.Entity[search.Value]
+	pins     map[string]Pin
+}
+
+func (r *inmemRepository) SaveVersion(ctx context.Context, version Version, contents Contents) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	versions := append(r.versions[version.List], version)
+	slices.SortStableFunc(versions, func(a, b Version) int {
+		return a.DownloadedAt.Compare(b.DownloadedAt)
+	})
+	r.versions[version.List] = versions
+
+	key := contentsKey{list: version.List, hash: version.Hash}
+	if _, exists := r.members[key]; !exists {
+		r.members[key] = contents.Members
+	}
+	for fp, entity := range contents.Entities {
+		if _, exists := r.entities[fp]; !exists {
+			r.entities[fp] = entity
+		}
+	}
+	return nil
+}
+
+func (r *inmemRepository) ListVersions(ctx context.Context, list string, filter Filter) ([]Version, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var out []Version
+	versions := r.versions[list]
+	for i := len(versions) - 1; i >= 0; i-- {
+		v := versions[i]
+		if !filter.From.IsZero() && v.DownloadedAt.Before(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && v.DownloadedAt.After(filter.To) {
+			continue
+		}
+		out = append(out, v)
+		if filter.Limit > 0 && len(out) >= filter.Limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (r *inmemRepository) ListNames(ctx context.Context) ([]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]string, 0, len(r.versions))
+	for list := range r.versions {
+		out = append(out, list)
+	}
+	slices.Sort(out)
+	return out, nil
+}
+
+func (r *inmemRepository) GetVersion(ctx context.Context, list, versionID string) (*Version, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	idx := slices.IndexFunc(r.versions[list], func(v Version) bool {
+		return v.VersionID == versionID
+	})
+	if idx < 0 {
+		return nil, nil
+	}
+	v := r.versions[list][idx]
+	return &v, nil
+}
+
+func (r *inmemRepository) Members(ctx context.Context, list, hash string) (map[string]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	members := r.members[contentsKey{list: list, hash: hash}]
+	out := make(map[string]string, len(members))
+	for sourceID, fp := range members {
+		out[sourceID] = fp
+	}
+	return out, nil
+}
+
+func (r *inmemRepository) Entities(ctx context.Context, fingerprints []string) (map[string]search.Entity[search.Value], error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make(map[string]search.Entity[search.Value], len(fingerprints))
+	for _, fp := range fingerprints {
+		if entity, exists := r.entities[fp]; exists {
+			out[fp] = entity
+		}
+	}
+	return out, nil
+}
+
+func (r *inmemRepository) SavePin(ctx context.Context, pin Pin) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pins[pin.List] = pin
+	return nil
+}
+
+func (r *inmemRepository) DeletePin(ctx context.Context, list string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.pins, list)
+	return nil
+}
+
+func (r *inmemRepository) ListPins(ctx context.Context) ([]Pin, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]Pin, 0, len(r.pins))
+	for _, pin := range r.pins {
+		out = append(out, pin)
+	}
+	slices.SortFunc(out, func(a, b Pin) int {
+		return strings.Compare(a.List, b.List)
+	})
+	return out, nil
+}
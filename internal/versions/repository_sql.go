@@ -0,0 +1,301 @@
+package versions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/database"
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// NewSQLRepository keeps list versions in db, so their history outlives restarts and is shared between instances
+func NewSQLRepository(db *database.DB) Repository {
+	return &sqlRepository{db: db}
+}
+
+type sqlRepository struct {
+	db *database.DB
+}
+
+const (
+	// sqlBatchSize is how many rows are read or written with each statement
+	sqlBatchSize = 200
+)
+
+func (r *sqlRepository) SaveVersion(ctx context.Context, version Version, contents Contents) error {
+	bs, err := json.Marshal(version)
+	if err != nil {
+		return err
+	}
+
+	return r.db.InTx(ctx, func(tx *database.Tx) error {
+		_, err := tx.ExecContext(ctx, `INSERT INTO list_versions (version_id, list, hash, downloaded_at, data) VALUES (?, ?, ?, ?, ?)`,
+			version.VersionID, version.List, version.Hash, database.ToMillis(version.DownloadedAt), string(bs))
+		if err != nil {
+			return fmt.Errorf("saving version: %w", err)
+		}
+
+		if err := r.saveMembers(ctx, tx, version, contents.Members); err != nil {
+			return fmt.Errorf("saving version members: %w", err)
+		}
+		if err := r.saveEntities(ctx, tx, contents.Entities); err != nil {
+			return fmt.Errorf("saving version entities: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *sqlRepository) saveMembers(ctx context.Context, tx *database.Tx, version Version, members map[string]string) error {
+	var count int
+	err := tx.QueryRowContext(ctx, r.db.Rebind(`SELECT COUNT(*) FROM list_version_members WHERE list = ? AND hash = ?`), version.List, version.Hash).Scan(&count)
+	if err != nil || count > 0 {
+		return err // an earlier version already saved these members
+	}
+
+	rows := make([][]any, 0, len(members))
+	for sourceID, fp := range members {
+		rows = append(rows, []any{version.List, version.Hash, sourceID, fp})
+	}
+	return insertBatches(ctx, tx, `INSERT INTO list_version_members (list, hash, source_id, fingerprint) VALUES `, "(?, ?, ?, ?)", rows)
+}
+
+func (r *sqlRepository) saveEntities(ctx context.Context, tx *database.Tx, entities map[string]search.Entity[search.Value]) error {
+	fingerprints := make([]string, 0, len(entities))
+	for fp := range entities {
+		fingerprints = append(fingerprints, fp)
+	}
+
+	// Entities are shared by versions, so only those unchanged since every earlier version are inserted
+	existing := make(map[string]bool)
+	for start := 0; start < len(fingerprints); start += sqlBatchSize {
+		batch := fingerprints[start:minInt(start+sqlBatchSize, len(fingerprints))]
+
+		query := `SELECT fingerprint FROM list_version_entities WHERE fingerprint IN (` + placeholders(len(batch)) + `)`
+		result, err := tx.QueryContext(ctx, query, stringArgs(batch)...)
+		if err != nil {
+			return err
+		}
+		for result.Next() {
+			var fp string
+			if err := result.Scan(&fp); err != nil {
+				result.Close()
+				return err
+			}
+			existing[fp] = true
+		}
+		result.Close()
+		if err := result.Err(); err != nil {
+			return err
+		}
+	}
+
+	var rows [][]any
+	for _, fp := range fingerprints {
+		if existing[fp] {
+			continue
+		}
+		bs, err := json.Marshal(entities[fp])
+		if err != nil {
+			return err
+		}
+		rows = append(rows, []any{fp, string(bs)})
+	}
+	return insertBatches(ctx, tx, `INSERT INTO list_version_entities (fingerprint, data) VALUES `, "(?, ?)", rows)
+}
+
+func insertBatches(ctx context.Context, tx *database.Tx, prefix, row string, rows [][]any) error {
+	for start := 0; start < len(rows); start += sqlBatchSize {
+		batch := rows[start:minInt(start+sqlBatchSize, len(rows))]
+
+		values := make([]string, len(batch))
+		var args []any
+		for i := range batch {
+			values[i] = row
+			args = append(args, batch[i]...)
+		}
+		if _, err := tx.ExecContext(ctx, prefix+strings.Join(values, ", "), args...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *sqlRepository) ListVersions(ctx context.Context, list string, filter Filter) ([]Version, error) {
+	query := `SELECT data FROM list_versions WHERE list = ?`
+	args := []any{list}
+	if !filter.From.IsZero() {
+		query += ` AND downloaded_at >= ?`
+		args = append(args, database.ToMillis(filter.From))
+	}
+	if !filter.To.IsZero() {
+		query += ` AND downloaded_at <= ?`
+		args = append(args, database.ToMillis(filter.To))
+	}
+	query += ` ORDER BY downloaded_at DESC, version_id DESC`
+	if filter.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, filter.Limit)
+	}
+	return r.queryVersions(ctx, query, args...)
+}
+
+func (r *sqlRepository) GetVersion(ctx context.Context, list, versionID string) (*Version, error) {
+	versions, err := r.queryVersions(ctx, `SELECT data FROM list_versions WHERE list = ? AND version_id = ?`, list, versionID)
+	if err != nil || len(versions) == 0 {
+		return nil, err
+	}
+	return &versions[0], nil
+}
+
+func (r *sqlRepository) ListNames(ctx context.Context) ([]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT DISTINCT list FROM list_versions ORDER BY list`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var list string
+		if err := rows.Scan(&list); err != nil {
+			return nil, err
+		}
+		out = append(out, list)
+	}
+	return out, rows.Err()
+}
+
+func (r *sqlRepository) queryVersions(ctx context.Context, query string, args ...any) ([]Version, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Version
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var version Version
+		if err := json.Unmarshal([]byte(data), &version); err != nil {
+			return nil, fmt.Errorf("reading list version: %w", err)
+		}
+		out = append(out, version)
+	}
+	return out, rows.Err()
+}
+
+func (r *sqlRepository) Members(ctx context.Context, list, hash string) (map[string]string, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT source_id, fingerprint FROM list_version_members WHERE list = ? AND hash = ?`, list, hash)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make(map[string]string)
+	for rows.Next() {
+		var sourceID, fp string
+		if err := rows.Scan(&sourceID, &fp); err != nil {
+			return nil, err
+		}
+		out[sourceID] = fp
+	}
+	return out, rows.Err()
+}
+
+func (r *sqlRepository) Entities(ctx context.Context, fingerprints []string) (map[string]search.Entity[search.Value], error) {
+	out := make(map[string]search.Entity[search.Value], len(fingerprints))
+	for start := 0; start < len(fingerprints); start += sqlBatchSize {
+		batch := fingerprints[start:minInt(start+sqlBatchSize, len(fingerprints))]
+
+		query := `SELECT fingerprint, data FROM list_version_entities WHERE fingerprint IN (` + placeholders(len(batch)) + `)`
+		rows, err := r.db.QueryContext(ctx, query, stringArgs(batch)...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var fp, data string
+			if err := rows.Scan(&fp, &data); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			var entity search.Entity[search.Value]
+			if err := json.Unmarshal([]byte(data), &entity); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("reading list version entity: %w", err)
+			}
+			out[fp] = entity
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (r *sqlRepository) SavePin(ctx context.Context, pin Pin) error {
+	bs, err := json.Marshal(pin)
+	if err != nil {
+		return err
+	}
+
+	return r.db.InTx(ctx, func(tx *database.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM list_version_pins WHERE list = ?`, pin.List)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `INSERT INTO list_version_pins (list, data) VALUES (?, ?)`, pin.List, string(bs))
+		return err
+	})
+}
+
+func (r *sqlRepository) DeletePin(ctx context.Context, list string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM list_version_pins WHERE list = ?`, list)
+	return err
+}
+
+func (r *sqlRepository) ListPins(ctx context.Context) ([]Pin, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT data FROM list_version_pins ORDER BY list`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Pin
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var pin Pin
+		if err := json.Unmarshal([]byte(data), &pin); err != nil {
+			return nil, fmt.Errorf("reading list pin: %w", err)
+		}
+		out = append(out, pin)
+	}
+	return out, rows.Err()
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func stringArgs(values []string) []any {
+	out := make([]any, len(values))
+	for i := range values {
+		out[i] = values[i]
+	}
+	return out
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
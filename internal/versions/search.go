@@ -0,0 +1,162 @@
+package versions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+)
+
+// historicalIndexes is how many sets of list versions are kept indexed for searches as of a time
+const historicalIndexes = 3
+
+// ErrTenantAsOf is returned by searches as of a time made for a tenant, as their custom lists aren't versioned
+var ErrTenantAsOf = errors.New("tenant custom lists can't be searched as of a time")
+
+// NewSearchService answers searches made as of a time (search.SearchOpts.AsOf) from the entities of each list's
+// version downloaded last at or before it. Every other search is made through service.
+//
+// newIndex returns an empty search.Service scoring entities like service, which is filled with the entities of
+// those versions. The indexes of the last few sets of versions searched are kept, since lookbacks tend to search
+// one date many times.
+func NewSearchService(logger log.Logger, service search.Service, versions Service, newIndex func() search.Service) search.Service {
+	return &historicalService{
+		Service:  service,
+		logger:   logger,
+		versions: versions,
+		newIndex: newIndex,
+	}
+}
+
+type historicalService struct {
+	search.Service
+
+	logger   log.Logger
+	versions Service
+	newIndex func() search.Service
+
+	mu      sync.Mutex // one index is built at a time
+	indexes []historicalIndex
+}
+
+// historicalIndex holds the entities of a set of list versions, newest first in historicalService.indexes
+type historicalIndex struct {
+	key     string
+	service search.Service
+}
+
+func (s *historicalService) Search(ctx context.Context, query pubsearch.Entity[pubsearch.Value], opts search.SearchOpts) ([]pubsearch.SearchedEntity[pubsearch.Value], error) {
+	if opts.AsOf.IsZero() {
+		return s.Service.Search(ctx, query, opts)
+	}
+	if opts.TenantID != "" {
+		return nil, fmt.Errorf("v2 search as of %v: %w", opts.AsOf, ErrTenantAsOf)
+	}
+
+	index, err := s.indexAsOf(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("v2 search as of %v: %w", opts.AsOf, err)
+	}
+	opts.AsOf = time.Time{}
+	return index.Search(ctx, query, opts)
+}
+
+func (s *historicalService) SetListMinMatch(list pubsearch.SourceList, minMatch float64) error {
+	if err := s.Service.SetListMinMatch(list, minMatch); err != nil {
+		return err
+	}
+	return s.applyMinMatches()
+}
+
+func (s *historicalService) SetMinMatches(conf search.MinMatchConfig) error {
+	if err := s.Service.SetMinMatches(conf); err != nil {
+		return err
+	}
+	return s.applyMinMatches()
+}
+
+// applyMinMatches gives the kept indexes the minimums of the current lists
+func (s *historicalService) applyMinMatches() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	conf := search.MinMatchConfig{Sources: s.Service.ListMinMatches()}
+	for _, index := range s.indexes {
+		if err := index.service.SetMinMatches(conf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indexAsOf returns an index of the entities searched at opts.AsOf, building it unless it's one of the last few
+func (s *historicalService) indexAsOf(ctx context.Context, opts search.SearchOpts) (search.Service, error) {
+	lists := make([]string, 0)
+	for list := range s.Service.ListInfo().Lists {
+		lists = append(lists, list)
+	}
+	slices.Sort(lists)
+
+	versions, err := s.versions.AsOf(ctx, lists, opts.AsOf)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w: no list versions downloaded by %v", ErrVersionNotFound, opts.AsOf)
+	}
+
+	ids := make([]string, 0, len(versions))
+	for _, list := range lists {
+		if version, exists := versions[list]; exists {
+			ids = append(ids, version.VersionID)
+		}
+	}
+	key := strings.Join(ids, ",")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, index := range s.indexes {
+		if index.key == key {
+			s.indexes = slices.Insert(slices.Delete(s.indexes, i, i+1), 0, index)
+			return index.service, nil
+		}
+	}
+
+	var entities []pubsearch.Entity[pubsearch.Value]
+	for _, list := range lists {
+		version, exists := versions[list]
+		if !exists {
+			continue
+		}
+		listEntities, err := s.versions.Entities(ctx, version)
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, listEntities...)
+	}
+
+	index := historicalIndex{
+		key:     key,
+		service: s.newIndex(),
+	}
+	index.service.UpdateEntities(entities)
+	if err := index.service.SetMinMatches(search.MinMatchConfig{Sources: s.Service.ListMinMatches()}); err != nil {
+		return nil, err
+	}
+	s.logger.Info().Logf("indexed %d entities of %d list versions for searches as of %v", len(entities), len(versions), opts.AsOf)
+
+	s.indexes = slices.Insert(s.indexes, 0, index)
+	if len(s.indexes) > historicalIndexes {
+		s.indexes = s.indexes[:historicalIndexes]
+	}
+	return index.service, nil
+}
@@ -0,0 +1,108 @@
+package versions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/search"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchService_AsOf(t *testing.T) {
+	ctx := context.Background()
+	logger := log.NewTestLogger()
+
+	versions := NewService(logger, NewInMemoryRepository())
+	june1 := time.Date(2024, time.June, 1, 6, 0, 0, 0, time.UTC)
+	june8 := time.Date(2024, time.June, 8, 6, 0, 0, 0, time.UTC)
+
+	lists := map[string]int{"us_ofac": 1}
+	_, err := versions.Record(ctx, june1, lists, []pubsearch.Entity[pubsearch.Value]{testEntity("1", "Acme Shipping")})
+	require.NoError(t, err)
+
+	current := []pubsearch.Entity[pubsearch.Value]{testEntity("2", "Bravo Trading")}
+	_, err = versions.Record(ctx, june8, lists, current)
+	require.NoError(t, err)
+
+	inner := search.NewService(logger)
+	inner.UpdateEntities(current)
+
+	indexes := 0
+	svc := NewSearchService(logger, inner, versions, func() search.Service {
+		indexes++
+		return search.NewService(logger)
+	})
+
+	query := pubsearch.Entity[pubsearch.Value]{
+		Name:     "Acme Shipping",
+		Type:     pubsearch.EntityBusiness,
+		Business: &pubsearch.Business{Name: "Acme Shipping"},
+	}
+	opts := search.SearchOpts{Limit: 1, MinMatch: 0.9}
+
+	// Current entities don't include the removed entity
+	results, err := svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	// It was on the list June 1, including versions downloaded anytime that day
+	opts.AsOf = time.Date(2024, time.June, 1, 23, 59, 59, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		results, err = svc.Search(ctx, query, opts)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		require.Equal(t, "1", results[0].SourceID)
+	}
+	require.Equal(t, 1, indexes)
+
+	// Minimums of the lists apply to the kept indexes once they're changed
+	misspelled := query
+	misspelled.Name, misspelled.Business = "Acme Shiping", &pubsearch.Business{Name: "Acme Shiping"}
+	listOpts := opts
+	listOpts.MinMatch = 0
+	results, err = svc.Search(ctx, misspelled, listOpts)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	require.NoError(t, svc.SetListMinMatch(pubsearch.SourceUSOFAC, 0.999))
+	results, err = svc.Search(ctx, misspelled, listOpts)
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	// Tenant custom lists aren't versioned
+	tenantOpts := opts
+	tenantOpts.TenantID = "bank-a"
+	_, err = svc.Search(ctx, query, tenantOpts)
+	require.ErrorIs(t, err, ErrTenantAsOf)
+
+	opts.AsOf = june8
+	results, err = svc.Search(ctx, query, opts)
+	require.NoError(t, err)
+	require.Empty(t, results)
+	require.Equal(t, 2, indexes)
+
+	// And to indexes built after they're changed
+	misspelled.Name, misspelled.Business = "Bravo Tradng", &pubsearch.Business{Name: "Bravo Tradng"}
+	listOpts.AsOf = june8
+	results, err = svc.Search(ctx, misspelled, listOpts)
+	require.NoError(t, err)
+	require.Empty(t, results)
+
+	require.NoError(t, svc.SetListMinMatch(pubsearch.SourceUSOFAC, 0))
+	results, err = svc.Search(ctx, misspelled, listOpts)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	// Nothing was downloaded yet
+	opts.AsOf = june1.Add(-time.Hour)
+	_, err = svc.Search(ctx, query, opts)
+	require.ErrorIs(t, err, ErrVersionNotFound)
+
+	// Searches as of a time need list versions
+	_, err = inner.Search(ctx, query, opts)
+	require.ErrorIs(t, err, search.ErrAsOfUnsupported)
+}
@@ -0,0 +1,454 @@
+package versions
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/internal/download"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base"
+	"github.com/moov-io/base/log"
+)
+
+var (
+	ErrVersionNotFound = errors.New("list version not found")
+)
+
+type Service interface {
+	// Record saves a version of each list from a successful download
+	Record(ctx context.Context, downloadedAt time.Time, lists map[string]int, entities []search.Entity[search.Value]) ([]Version, error)
+
+	// List returns the versions of a list, newest first
+	List(ctx context.Context, list string, filter Filter) ([]Version, error)
+
+	// Diff compares two versions of a list. Each version is referenced by its VersionID, or a time
+	// which chooses the last version downloaded at or before it.
+	Diff(ctx context.Context, list, from, to string) (*Diff, error)
+
+	// Pin serves the entities of a list version, referenced like Diff, until the list is unpinned
+	Pin(ctx context.Context, list, ref string) (*Pin, error)
+
+	// Rollback pins a list to the newest version whose entities differ from the one being served
+	Rollback(ctx context.Context, list string) (*Pin, error)
+
+	Unpin(ctx context.Context, list string) error
+	Pins(ctx context.Context) ([]Pin, error)
+
+	// Serving returns the entities of a list which should be searched, either from its pinned or latest version
+	Serving(ctx context.Context, list string) ([]search.Entity[search.Value], error)
+
+	// Current returns the version of each list being searched, its pinned or latest version, keyed by list.
+	// Lists without a recorded version are left out.
+	Current(ctx context.Context, lists []string) (map[string]Version, error)
+
+	// ApplyPins replaces the entities of each pinned list with those of its pinned version
+	ApplyPins(ctx context.Context, entities []search.Entity[search.Value]) ([]search.Entity[search.Value], error)
+
+	// AsOf returns the last version of each list downloaded at or before a time, keyed by list. Lists without
+	// a version downloaded by then are left out.
+	AsOf(ctx context.Context, lists []string, at time.Time) (map[string]Version, error)
+
+	// Entities returns the entities of a list version
+	Entities(ctx context.Context, version Version) ([]search.Entity[search.Value], error)
+
+	// Changes returns a page of the entities added, modified and removed by the versions recorded after a
+	// version or time
+	Changes(ctx context.Context, query ChangeQuery) (*ChangeFeed, error)
+}
+
+func NewService(logger log.Logger, repo Repository) Service {
+	return &service{
+		logger: logger,
+		repo:   repo,
+	}
+}
+
+type service struct {
+	logger log.Logger
+	repo   Repository
+}
+
+func (s *service) Record(ctx context.Context, downloadedAt time.Time, lists map[string]int, entities []search.Entity[search.Value]) ([]Version, error) {
+	byList := make(map[string][]search.Entity[search.Value])
+	for list := range lists {
+		byList[list] = nil
+	}
+	for _, entity := range entities {
+		byList[string(entity.Source)] = append(byList[string(entity.Source)], entity)
+	}
+
+	names := make([]string, 0, len(byList))
+	for list := range byList {
+		names = append(names, list)
+	}
+	slices.Sort(names)
+
+	out := make([]Version, 0, len(names))
+	for _, list := range names {
+		version, err := s.record(ctx, list, downloadedAt, byList[list])
+		if err != nil {
+			return out, fmt.Errorf("recording %s version: %w", list, err)
+		}
+		out = append(out, version)
+	}
+	return out, nil
+}
+
+func (s *service) record(ctx context.Context, list string, downloadedAt time.Time, entities []search.Entity[search.Value]) (Version, error) {
+	contents := Contents{
+		Members:  make(map[string]string, len(entities)),
+		Entities: make(map[string]search.Entity[search.Value], len(entities)),
+	}
+	for _, entity := range entities {
+		fp := download.Fingerprint(entity)
+		contents.Members[entity.SourceID] = fp
+		contents.Entities[fp] = entity
+	}
+
+	version := Version{
+		VersionID:    base.ID(),
+		List:         list,
+		Hash:         hashMembers(contents.Members),
+		Entities:     len(contents.Members),
+		DownloadedAt: downloadedAt,
+	}
+
+	previous, err := s.repo.ListVersions(ctx, list, Filter{Limit: 1})
+	if err != nil {
+		return version, fmt.Errorf("reading previous version: %w", err)
+	}
+	switch {
+	case len(previous) == 0:
+		version.Added = version.Entities
+
+	case previous[0].Hash != version.Hash:
+		members, err := s.repo.Members(ctx, list, previous[0].Hash)
+		if err != nil {
+			return version, fmt.Errorf("reading previous version: %w", err)
+		}
+		added, modified, removed := compareMembers(members, contents.Members)
+		version.Added, version.Modified, version.Removed = len(added), len(modified), len(removed)
+	}
+
+	if err := s.repo.SaveVersion(ctx, version, contents); err != nil {
+		return version, err
+	}
+	return version, nil
+}
+
+// hashMembers combines the fingerprint of each entity, sorted by SourceID
+func hashMembers(members map[string]string) string {
+	sourceIDs := make([]string, 0, len(members))
+	for sourceID := range members {
+		sourceIDs = append(sourceIDs, sourceID)
+	}
+	slices.Sort(sourceIDs)
+
+	h := sha256.New()
+	for _, sourceID := range sourceIDs {
+		h.Write([]byte(sourceID))
+		h.Write([]byte{0})
+		h.Write([]byte(members[sourceID]))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// compareMembers returns the fingerprints of entities added, modified and removed from previous to current
+func compareMembers(previous, current map[string]string) (added, modified, removed []string) {
+	for sourceID, fp := range current {
+		existing, found := previous[sourceID]
+		switch {
+		case !found:
+			added = append(added, fp)
+		case existing != fp:
+			modified = append(modified, fp)
+		}
+	}
+	for sourceID, fp := range previous {
+		if _, found := current[sourceID]; !found {
+			removed = append(removed, fp)
+		}
+	}
+	return
+}
+
+func (s *service) List(ctx context.Context, list string, filter Filter) ([]Version, error) {
+	return s.repo.ListVersions(ctx, list, filter)
+}
+
+func (s *service) Diff(ctx context.Context, list, from, to string) (*Diff, error) {
+	fromVersion, err := s.resolve(ctx, list, from)
+	if err != nil {
+		return nil, fmt.Errorf("from: %w", err)
+	}
+	toVersion, err := s.resolve(ctx, list, to)
+	if err != nil {
+		return nil, fmt.Errorf("to: %w", err)
+	}
+
+	out := &Diff{
+		From: *fromVersion,
+		To:   *toVersion,
+	}
+	if fromVersion.Hash == toVersion.Hash {
+		return out, nil
+	}
+
+	fromMembers, err := s.repo.Members(ctx, list, fromVersion.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s members: %w", fromVersion.VersionID, err)
+	}
+	toMembers, err := s.repo.Members(ctx, list, toVersion.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s members: %w", toVersion.VersionID, err)
+	}
+	added, modified, removed := compareMembers(fromMembers, toMembers)
+
+	entities, err := s.repo.Entities(ctx, append(append(slices.Clone(added), modified...), removed...))
+	if err != nil {
+		return nil, fmt.Errorf("reading changed entities: %w", err)
+	}
+	out.Added = pickEntities(entities, added)
+	out.Modified = pickEntities(entities, modified)
+	out.Removed = pickEntities(entities, removed)
+
+	return out, nil
+}
+
+func (s *service) Pin(ctx context.Context, list, ref string) (*Pin, error) {
+	version, err := s.resolve(ctx, list, ref)
+	if err != nil {
+		return nil, err
+	}
+	return s.pin(ctx, *version)
+}
+
+func (s *service) pin(ctx context.Context, version Version) (*Pin, error) {
+	pin := Pin{
+		List:     version.List,
+		Version:  version,
+		PinnedAt: time.Now().In(time.UTC),
+	}
+	if err := s.repo.SavePin(ctx, pin); err != nil {
+		return nil, fmt.Errorf("saving %s pin: %w", version.List, err)
+	}
+	s.logger.Info().Logf("pinned %s to version %s downloaded at %v", version.List, version.VersionID, version.DownloadedAt)
+
+	return &pin, nil
+}
+
+func (s *service) Rollback(ctx context.Context, list string) (*Pin, error) {
+	current, err := s.serving(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := s.repo.ListVersions(ctx, list, Filter{To: current.DownloadedAt})
+	if err != nil {
+		return nil, err
+	}
+	for _, version := range previous {
+		if version.Hash != current.Hash {
+			return s.pin(ctx, version)
+		}
+	}
+	return nil, fmt.Errorf("%w: no %s version before %s", ErrVersionNotFound, list, current.VersionID)
+}
+
+// serving returns the pinned version of a list, or its latest version
+func (s *service) serving(ctx context.Context, list string) (*Version, error) {
+	pin, err := s.findPin(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+	if pin != nil {
+		return &pin.Version, nil
+	}
+
+	latest, err := s.repo.ListVersions(ctx, list, Filter{Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(latest) == 0 {
+		return nil, fmt.Errorf("%w: %s has no versions", ErrVersionNotFound, list)
+	}
+	return &latest[0], nil
+}
+
+func (s *service) findPin(ctx context.Context, list string) (*Pin, error) {
+	pins, err := s.repo.ListPins(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("reading pins: %w", err)
+	}
+	for _, pin := range pins {
+		if pin.List == list {
+			return &pin, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *service) Unpin(ctx context.Context, list string) error {
+	if err := s.repo.DeletePin(ctx, list); err != nil {
+		return fmt.Errorf("deleting %s pin: %w", list, err)
+	}
+	s.logger.Info().Logf("unpinned %s", list)
+	return nil
+}
+
+func (s *service) Pins(ctx context.Context) ([]Pin, error) {
+	return s.repo.ListPins(ctx)
+}
+
+func (s *service) Serving(ctx context.Context, list string) ([]search.Entity[search.Value], error) {
+	version, err := s.serving(ctx, list)
+	if err != nil {
+		return nil, err
+	}
+	return s.versionEntities(ctx, *version)
+}
+
+func (s *service) Current(ctx context.Context, lists []string) (map[string]Version, error) {
+	out := make(map[string]Version, len(lists))
+	for _, list := range lists {
+		version, err := s.serving(ctx, list)
+		if errors.Is(err, ErrVersionNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s version: %w", list, err)
+		}
+		out[list] = *version
+	}
+	return out, nil
+}
+
+func (s *service) AsOf(ctx context.Context, lists []string, at time.Time) (map[string]Version, error) {
+	out := make(map[string]Version, len(lists))
+	for _, list := range lists {
+		versions, err := s.repo.ListVersions(ctx, list, Filter{To: at, Limit: 1})
+		if err != nil {
+			return nil, fmt.Errorf("reading %s versions: %w", list, err)
+		}
+		if len(versions) > 0 {
+			out[list] = versions[0]
+		}
+	}
+	return out, nil
+}
+
+func (s *service) Entities(ctx context.Context, version Version) ([]search.Entity[search.Value], error) {
+	return s.versionEntities(ctx, version)
+}
+
+func (s *service) versionEntities(ctx context.Context, version Version) ([]search.Entity[search.Value], error) {
+	members, err := s.repo.Members(ctx, version.List, version.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s members: %w", version.VersionID, err)
+	}
+	fingerprints := make([]string, 0, len(members))
+	for _, fp := range members {
+		fingerprints = append(fingerprints, fp)
+	}
+
+	entities, err := s.repo.Entities(ctx, fingerprints)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s entities: %w", version.VersionID, err)
+	}
+	return pickEntities(entities, fingerprints), nil
+}
+
+func (s *service) ApplyPins(ctx context.Context, entities []search.Entity[search.Value]) ([]search.Entity[search.Value], error) {
+	pins, err := s.repo.ListPins(ctx)
+	if err != nil || len(pins) == 0 {
+		return entities, err
+	}
+
+	pinned := make(map[search.SourceList]bool, len(pins))
+	for _, pin := range pins {
+		pinned[search.SourceList(pin.List)] = true
+	}
+
+	out := make([]search.Entity[search.Value], 0, len(entities))
+	for _, entity := range entities {
+		if !pinned[entity.Source] {
+			out = append(out, entity)
+		}
+	}
+	for _, pin := range pins {
+		pinnedEntities, err := s.versionEntities(ctx, pin.Version)
+		if err != nil {
+			return entities, err
+		}
+		out = append(out, pinnedEntities...)
+	}
+	return out, nil
+}
+
+// resolve finds a version by its VersionID, or the last version downloaded at or before a time. Dates
+// (such as 2024-03-10) include every version downloaded that day.
+func (s *service) resolve(ctx context.Context, list, ref string) (*Version, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, errors.New("missing version")
+	}
+
+	version, err := s.repo.GetVersion(ctx, list, ref)
+	if err != nil || version != nil {
+		return version, err
+	}
+
+	at, err := readTime(ref, true)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrVersionNotFound, ref)
+	}
+
+	versions, err := s.repo.ListVersions(ctx, list, Filter{To: at, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("%w: no %s version downloaded by %s", ErrVersionNotFound, list, ref)
+	}
+	return &versions[0], nil
+}
+
+// readTime parses RFC3339 timestamps or dates. Dates used as the end of a range include that entire day.
+func readTime(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	day, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected a date (2006-01-02) or RFC3339 timestamp: %q", value)
+	}
+	if endOfDay {
+		day = day.Add(24*time.Hour - time.Millisecond)
+	}
+	return day, nil
+}
+
+func pickEntities(entities map[string]search.Entity[search.Value], fingerprints []string) []search.Entity[search.Value] {
+	out := make([]search.Entity[search.Value], 0, len(fingerprints))
+	for _, fp := range fingerprints {
+		if entity, exists := entities[fp]; exists {
+			out = append(out, entity)
+		}
+	}
+	slices.SortFunc(out, func(a, b search.Entity[search.Value]) int {
+		return cmp.Compare(a.SourceID, b.SourceID)
+	})
+	return out
+}
@@ -0,0 +1,177 @@
+package versions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/database"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func testEntity(sourceID, name string) search.Entity[search.Value] {
+	return search.Entity[search.Value]{
+		Name:     name,
+		Type:     search.EntityBusiness,
+		Source:   search.SourceUSOFAC,
+		SourceID: sourceID,
+		Business: &search.Business{Name: name},
+	}
+}
+
+func TestService(t *testing.T) {
+	repos := map[string]Repository{
+		"inmem": NewInMemoryRepository(),
+		"sql":   NewSQLRepository(database.NewTestDB(t)),
+	}
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			testService(t, NewService(log.NewTestLogger(), repo))
+		})
+	}
+}
+
+func testService(t *testing.T, svc Service) {
+	t.Helper()
+
+	ctx := context.Background()
+	march3 := time.Date(2024, time.March, 3, 12, 0, 0, 0, time.UTC)
+	march10 := time.Date(2024, time.March, 10, 12, 0, 0, 0, time.UTC)
+	lists := map[string]int{"us_ofac": 2, "eu_csl": 0}
+
+	first, err := svc.Record(ctx, march3, lists, []search.Entity[search.Value]{
+		testEntity("1", "Acme Shipping"),
+		testEntity("2", "Bravo Trading"),
+	})
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+	require.Equal(t, "eu_csl", first[0].List)
+	require.Equal(t, 0, first[0].Entities)
+
+	ofac := first[1]
+	require.Equal(t, "us_ofac", ofac.List)
+	require.Equal(t, 2, ofac.Entities)
+	require.Equal(t, 2, ofac.Added)
+	require.Len(t, ofac.Hash, 64)
+
+	// Downloading identical entities keeps the hash
+	again, err := svc.Record(ctx, march3.Add(12*time.Hour), lists, []search.Entity[search.Value]{
+		testEntity("2", "Bravo Trading"),
+		testEntity("1", "Acme Shipping"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, ofac.Hash, again[1].Hash)
+	require.Zero(t, again[1].Added+again[1].Modified+again[1].Removed)
+
+	latest, err := svc.Record(ctx, march10, lists, []search.Entity[search.Value]{
+		testEntity("1", "Acme Shipping Limited"),
+		testEntity("3", "Charlie Holdings"),
+	})
+	require.NoError(t, err)
+	require.NotEqual(t, ofac.Hash, latest[1].Hash)
+	require.Equal(t, 1, latest[1].Added)
+	require.Equal(t, 1, latest[1].Modified)
+	require.Equal(t, 1, latest[1].Removed)
+
+	versions, err := svc.List(ctx, "us_ofac", Filter{})
+	require.NoError(t, err)
+	require.Len(t, versions, 3)
+	require.Equal(t, latest[1].VersionID, versions[0].VersionID)
+	require.Equal(t, ofac.VersionID, versions[2].VersionID)
+
+	versions, err = svc.List(ctx, "us_ofac", Filter{From: march3.Add(time.Hour), To: march10.Add(-time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, versions, 1)
+	require.Equal(t, again[1].VersionID, versions[0].VersionID)
+
+	// Versions are found by their ID or date
+	diff, err := svc.Diff(ctx, "us_ofac", ofac.VersionID, "2024-03-10")
+	require.NoError(t, err)
+	require.Equal(t, ofac.VersionID, diff.From.VersionID)
+	require.Equal(t, latest[1].VersionID, diff.To.VersionID)
+	require.Equal(t, []search.Entity[search.Value]{testEntity("3", "Charlie Holdings")}, diff.Added)
+	require.Equal(t, []search.Entity[search.Value]{testEntity("1", "Acme Shipping Limited")}, diff.Modified)
+	require.Equal(t, []search.Entity[search.Value]{testEntity("2", "Bravo Trading")}, diff.Removed)
+
+	diff, err = svc.Diff(ctx, "us_ofac", "2024-03-03", "2024-03-09T00:00:00Z")
+	require.NoError(t, err)
+	require.Equal(t, ofac.VersionID, diff.From.VersionID)
+	require.Equal(t, again[1].VersionID, diff.To.VersionID)
+	require.Empty(t, diff.Added)
+	require.Empty(t, diff.Removed)
+
+	_, err = svc.Diff(ctx, "us_ofac", "2024-03-01", latest[1].VersionID)
+	require.ErrorIs(t, err, ErrVersionNotFound)
+
+	_, err = svc.Diff(ctx, "us_ofac", "", latest[1].VersionID)
+	require.ErrorContains(t, err, "missing version")
+
+	// Versions downloaded by a time are found for each list
+	asOf, err := svc.AsOf(ctx, []string{"us_ofac", "uk_csl"}, march10.Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, asOf, 1)
+	require.Equal(t, again[1].VersionID, asOf["us_ofac"].VersionID)
+
+	entities, err := svc.Entities(ctx, asOf["us_ofac"])
+	require.NoError(t, err)
+	require.Len(t, entities, 2)
+
+	// Roll back to the previous entities, skipping versions with the same hash
+	pin, err := svc.Rollback(ctx, "us_ofac")
+	require.NoError(t, err)
+	require.Equal(t, again[1].VersionID, pin.Version.VersionID)
+
+	_, err = svc.Rollback(ctx, "us_ofac")
+	require.ErrorIs(t, err, ErrVersionNotFound)
+
+	// Pinned lists are searched at their pinned version
+	current, err := svc.Current(ctx, []string{"us_ofac", "eu_csl", "uk_csl"})
+	require.NoError(t, err)
+	require.Len(t, current, 2)
+	require.Equal(t, again[1].VersionID, current["us_ofac"].VersionID)
+	require.Equal(t, latest[0].VersionID, current["eu_csl"].VersionID)
+
+	serving, err := svc.Serving(ctx, "us_ofac")
+	require.NoError(t, err)
+	require.Equal(t, []search.Entity[search.Value]{
+		testEntity("1", "Acme Shipping"),
+		testEntity("2", "Bravo Trading"),
+	}, serving)
+
+	// Refreshed entities of pinned lists are replaced
+	pinned, err := svc.ApplyPins(ctx, []search.Entity[search.Value]{
+		testEntity("4", "Delta Freight"),
+		{Name: "Other", Source: search.SourceUSCSL, SourceID: "5"},
+	})
+	require.NoError(t, err)
+	require.Len(t, pinned, 3)
+	require.Equal(t, "5", pinned[0].SourceID)
+
+	pin, err = svc.Pin(ctx, "us_ofac", latest[1].VersionID)
+	require.NoError(t, err)
+	require.Equal(t, latest[1].VersionID, pin.Version.VersionID)
+
+	pins, err := svc.Pins(ctx)
+	require.NoError(t, err)
+	require.Len(t, pins, 1)
+	require.Equal(t, "us_ofac", pins[0].List)
+
+	_, err = svc.Pin(ctx, "us_ofac", "2024-01-01")
+	require.ErrorIs(t, err, ErrVersionNotFound)
+
+	require.NoError(t, svc.Unpin(ctx, "us_ofac"))
+	pins, err = svc.Pins(ctx)
+	require.NoError(t, err)
+	require.Empty(t, pins)
+
+	current, err = svc.Current(ctx, []string{"us_ofac"})
+	require.NoError(t, err)
+	require.Equal(t, latest[1].VersionID, current["us_ofac"].VersionID)
+
+	unpinned, err := svc.ApplyPins(ctx, []search.Entity[search.Value]{testEntity("4", "Delta Freight")})
+	require.NoError(t, err)
+	require.Len(t, unpinned, 1)
+}
@@ -0,0 +1,163 @@
+package watches
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/moov-io/watchman/internal/auth"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+)
+
+type Controller interface {
+	AppendRoutes(router *mux.Router) *mux.Router
+}
+
+func NewController(logger log.Logger, service Service) Controller {
+	return &controller{
+		logger:  logger,
+		service: service,
+	}
+}
+
+type controller struct {
+	logger  log.Logger
+	service Service
+}
+
+func (c *controller) AppendRoutes(router *mux.Router) *mux.Router {
+	router.
+		Name("Watches.create").
+		Methods("POST").
+		Path("/v2/watches").
+		HandlerFunc(c.createWatch)
+
+	router.
+		Name("Watches.list").
+		Methods("GET").
+		Path("/v2/watches").
+		HandlerFunc(c.listWatches)
+
+	router.
+		Name("Watches.get").
+		Methods("GET").
+		Path("/v2/watches/{watchID}").
+		HandlerFunc(c.getWatch)
+
+	router.
+		Name("Watches.delete").
+		Methods("DELETE").
+		Path("/v2/watches/{watchID}").
+		HandlerFunc(c.deleteWatch)
+
+	return router
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (c *controller) writeError(w http.ResponseWriter, status int, err error) {
+	c.logger.Error().LogError(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error: err.Error(),
+	})
+}
+
+func (c *controller) createWatch(w http.ResponseWriter, r *http.Request) {
+	var req Watch
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.writeError(w, http.StatusBadRequest, fmt.Errorf("reading watch: %w", err))
+		return
+	}
+	tenantID, err := auth.RequestTenant(r)
+	if err != nil {
+		c.writeError(w, http.StatusForbidden, err)
+		return
+	}
+	req.TenantID = tenantID
+
+	watch, err := c.service.Create(req)
+	if err != nil {
+		c.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// The secret is only returned when the watch is created
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(watch)
+}
+
+type listWatchesResponse struct {
+	Watches []Watch `json:"watches"`
+}
+
+func (c *controller) listWatches(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := auth.RequestTenant(r)
+	if err != nil {
+		c.writeError(w, http.StatusForbidden, err)
+		return
+	}
+	watches, err := c.service.List(tenantID)
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("listing watches: %w", err))
+		return
+	}
+	for i := range watches {
+		watches[i].Secret = ""
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(listWatchesResponse{
+		Watches: watches,
+	})
+}
+
+// findWatch returns the watch from the request's path, or nil when it doesn't exist or belongs to another tenant
+func (c *controller) findWatch(w http.ResponseWriter, r *http.Request) *Watch {
+	tenantID, err := auth.RequestTenant(r)
+	if err != nil {
+		c.writeError(w, http.StatusForbidden, err)
+		return nil
+	}
+	watch, err := c.service.Get(mux.Vars(r)["watchID"])
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("getting watch: %w", err))
+		return nil
+	}
+	if watch == nil || watch.TenantID != tenantID {
+		w.WriteHeader(http.StatusNotFound)
+		return nil
+	}
+	watch.Secret = ""
+	return watch
+}
+
+func (c *controller) getWatch(w http.ResponseWriter, r *http.Request) {
+	watch := c.findWatch(w, r)
+	if watch == nil {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(watch)
+}
+
+func (c *controller) deleteWatch(w http.ResponseWriter, r *http.Request) {
+	watch := c.findWatch(w, r)
+	if watch == nil {
+		return
+	}
+	err := c.service.Delete(watch.WatchID)
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("deleting watch: %w", err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
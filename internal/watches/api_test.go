@@ -0,0 +1,83 @@
+package watches
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/auth"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestController(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t, nil)).AppendRoutes(router)
+
+	// create
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/v2/watches", strings.NewReader(`{"name": "Acme Shipping", "type": "business", "webhookURL": "https://example.com/hook"}`))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var watch Watch
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&watch))
+	require.Equal(t, "Acme Shipping", watch.Name)
+	require.NotEmpty(t, watch.Secret)
+
+	// get
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/watches/"+watch.WatchID, nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var found Watch
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&found))
+	require.Equal(t, watch.WatchID, found.WatchID)
+	require.Empty(t, found.Secret)
+
+	// list
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/watches", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp listWatchesResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Watches, 1)
+
+	// other tenants can't see or delete the watch
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/watches?tenantID=globex", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Empty(t, resp.Watches)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/v2/watches/"+watch.WatchID+"?tenantID=globex", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	ctx := auth.WithClient(context.Background(), &auth.Client{Name: "payments", Tenant: "globex"})
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/watches/"+watch.WatchID+"?tenantID=acme", nil).WithContext(ctx)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusForbidden, w.Code)
+
+	// delete
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/v2/watches/"+watch.WatchID, nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/watches/"+watch.WatchID, nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
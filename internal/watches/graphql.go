@@ -0,0 +1,72 @@
+package watches
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moov-io/watchman/internal/auth"
+	"github.com/moov-io/watchman/internal/graphql"
+)
+
+// GraphQLSchema holds the watches field of the GraphQL endpoint, which needs the lists scope when requests
+// are authenticated and returns the watches of the request's tenant. Webhook secrets aren't part of the schema.
+const GraphQLSchema = `
+type Watch {
+	watchID: String!
+	name: String!
+	type: String!
+	country: String!
+	minMatch: Float!
+	webhookURL: String!
+	createdAt: Time!
+}
+
+extend type Query {
+	"""
+	Returns the watches of the request's tenant, without their webhook secrets
+	"""
+	watches: [Watch!]
+}
+`
+
+// GraphQLWatches resolves the watches field of GraphQLSchema
+type GraphQLWatches struct {
+	service Service
+}
+
+func NewGraphQLWatches(service Service) *GraphQLWatches {
+	return &GraphQLWatches{
+		service: service,
+	}
+}
+
+type graphqlWatch struct {
+	WatchID    string
+	Name       string
+	Type       string
+	Country    string
+	MinMatch   float64
+	WebhookURL string
+	CreatedAt  graphql.Time
+}
+
+func (g *GraphQLWatches) Watches(ctx context.Context) (*[]graphqlWatch, error) {
+	if client := auth.ClientFrom(ctx); client != nil && !client.Allowed(auth.ScopeLists) {
+		return nil, fmt.Errorf("missing %s scope", auth.ScopeLists)
+	}
+
+	tenantID, err := auth.TenantFor(ctx, "")
+	if r := graphql.HTTPRequest(ctx); r != nil {
+		tenantID, err = auth.RequestTenant(r)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	watches, err := g.service.List(tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("listing watches: %w", err)
+	}
+	out, err := graphql.Convert[[]graphqlWatch](watches)
+	return &out, err
+}
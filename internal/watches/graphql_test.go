@@ -0,0 +1,55 @@
+package watches
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/auth"
+	"github.com/moov-io/watchman/internal/graphql"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQLWatches(t *testing.T) {
+	svc := testService(t, nil)
+
+	_, err := svc.Create(Watch{
+		Name:       "Nicolas Maduro",
+		Type:       "person",
+		WebhookURL: "https://example.com/webhook",
+	})
+	require.NoError(t, err)
+
+	schema, err := graphql.NewSchema(NewGraphQLWatches(svc), GraphQLSchema)
+	require.NoError(t, err)
+
+	resp := schema.Execute(context.Background(), graphql.Request{
+		Query: `{ watches { name minMatch } }`,
+	})
+	require.Empty(t, resp.Errors)
+
+	require.JSONEq(t, `{"watches":[{"name":"Nicolas Maduro","minMatch":0.85}]}`, string(resp.Data))
+
+	// Secrets aren't returned
+	resp = schema.Execute(context.Background(), graphql.Request{
+		Query: `{ watches { secret } }`,
+	})
+	require.Len(t, resp.Errors, 1)
+	require.Equal(t, `Cannot query field "secret" on type "Watch".`, resp.Errors[0].Message)
+
+	// Clients need the lists scope
+	ctx := auth.WithClient(context.Background(), &auth.Client{Name: "search-only", Scopes: []auth.Scope{auth.ScopeSearch}})
+	resp = schema.Execute(ctx, graphql.Request{
+		Query: `{ watches { name } }`,
+	})
+	require.Len(t, resp.Errors, 1)
+	require.Equal(t, "missing lists scope", resp.Errors[0].Message)
+
+	// Clients only see the watches of their tenant
+	ctx = auth.WithClient(context.Background(), &auth.Client{Name: "payments", Tenant: "globex", Scopes: []auth.Scope{auth.ScopeLists}})
+	resp = schema.Execute(ctx, graphql.Request{
+		Query: `{ watches { name } }`,
+	})
+	require.Empty(t, resp.Errors)
+	require.JSONEq(t, `{"watches":[]}`, string(resp.Data))
+}
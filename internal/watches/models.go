@@ -0,0 +1,71 @@
+package watches
+
+import (
+	"strings"
+	"time"
+
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+)
+
+// Watch is a name which is rescreened against every list refresh
+type Watch struct {
+	WatchID string `json:"watchID"`
+
+	// TenantID is who created the watch, see auth.RequestTenant. Its rescreens search as the tenant.
+	TenantID string `json:"tenantID,omitempty"`
+
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Country string `json:"country,omitempty"`
+
+	// MinMatch is the lowest score which is sent to the webhook
+	MinMatch float64 `json:"minMatch"`
+
+	WebhookURL string `json:"webhookURL"`
+	Secret     string `json:"secret,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (w Watch) query() pubsearch.Entity[pubsearch.Value] {
+	out := pubsearch.Entity[pubsearch.Value]{
+		Name:   w.Name,
+		Type:   pubsearch.EntityType(strings.ToLower(w.Type)),
+		Source: pubsearch.SourceAPIRequest,
+	}
+
+	switch out.Type {
+	case pubsearch.EntityPerson:
+		out.Person = &pubsearch.Person{Name: w.Name}
+	case pubsearch.EntityBusiness:
+		out.Business = &pubsearch.Business{Name: w.Name}
+	case pubsearch.EntityOrganization:
+		out.Organization = &pubsearch.Organization{Name: w.Name}
+	case pubsearch.EntityAircraft:
+		out.Aircraft = &pubsearch.Aircraft{Name: w.Name}
+	case pubsearch.EntityVessel:
+		out.Vessel = &pubsearch.Vessel{Name: w.Name}
+	}
+
+	if w.Country != "" {
+		out.Addresses = []pubsearch.Address{
+			{Country: w.Country},
+		}
+	}
+
+	return out
+}
+
+// Event is the body sent to a watch's webhook when matches are found
+type Event struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+
+	WatchID string                                      `json:"watchID"`
+	Name    string                                      `json:"name"`
+	Matches []pubsearch.SearchedEntity[pubsearch.Value] `json:"matches"`
+}
+
+const (
+	EventWatchMatched = "watch.matched"
+)
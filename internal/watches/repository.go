@@ -0,0 +1,66 @@
+package watches
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+)
+
+type Repository interface {
+	Save(watch Watch) error
+	Get(watchID string) (*Watch, error)
+	List() ([]Watch, error)
+	Delete(watchID string) error
+}
+
+func NewInMemoryRepository() Repository {
+	return &inmemRepository{
+		watches: make(map[string]Watch),
+	}
+}
+
+type inmemRepository struct {
+	mu      sync.RWMutex
+	watches map[string]Watch
+}
+
+func (r *inmemRepository) Save(watch Watch) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.watches[watch.WatchID] = watch
+	return nil
+}
+
+func (r *inmemRepository) Get(watchID string) (*Watch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	watch, exists := r.watches[watchID]
+	if !exists {
+		return nil, nil
+	}
+	return &watch, nil
+}
+
+func (r *inmemRepository) List() ([]Watch, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]Watch, 0, len(r.watches))
+	for _, watch := range r.watches {
+		out = append(out, watch)
+	}
+	slices.SortFunc(out, func(a, b Watch) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.WatchID, b.WatchID))
+	})
+	return out, nil
+}
+
+func (r *inmemRepository) Delete(watchID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.watches, watchID)
+	return nil
+}
@@ -0,0 +1,75 @@
+package watches
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/moov-io/watchman/internal/database"
+)
+
+// NewSQLRepository keeps watches in db, so they're shared between Watchman instances
+func NewSQLRepository(db *database.DB) Repository {
+	return &sqlRepository{db: db}
+}
+
+type sqlRepository struct {
+	db *database.DB
+}
+
+func (r *sqlRepository) Save(watch Watch) error {
+	bs, err := json.Marshal(watch)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	return r.db.InTx(ctx, func(tx *database.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM watches WHERE watch_id = ?`, watch.WatchID)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `INSERT INTO watches (watch_id, created_at, data) VALUES (?, ?, ?)`,
+			watch.WatchID, database.ToMillis(watch.CreatedAt), string(bs))
+		return err
+	})
+}
+
+func (r *sqlRepository) Get(watchID string) (*Watch, error) {
+	watches, err := r.queryWatches(`SELECT data FROM watches WHERE watch_id = ?`, watchID)
+	if err != nil || len(watches) == 0 {
+		return nil, err
+	}
+	return &watches[0], nil
+}
+
+func (r *sqlRepository) List() ([]Watch, error) {
+	return r.queryWatches(`SELECT data FROM watches ORDER BY created_at, watch_id`)
+}
+
+func (r *sqlRepository) Delete(watchID string) error {
+	_, err := r.db.ExecContext(context.Background(), `DELETE FROM watches WHERE watch_id = ?`, watchID)
+	return err
+}
+
+func (r *sqlRepository) queryWatches(query string, args ...any) ([]Watch, error) {
+	rows, err := r.db.QueryContext(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Watch
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var watch Watch
+		if err := json.Unmarshal([]byte(data), &watch); err != nil {
+			return nil, fmt.Errorf("reading watch: %w", err)
+		}
+		out = append(out, watch)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,55 @@
+package watches
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/database"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository(t *testing.T) {
+	repos := map[string]Repository{
+		"inmem": NewInMemoryRepository(),
+		"sql":   NewSQLRepository(database.NewTestDB(t)),
+	}
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			testRepository(t, repo)
+		})
+	}
+}
+
+func testRepository(t *testing.T, repo Repository) {
+	t.Helper()
+
+	now := time.Now().In(time.UTC).Truncate(time.Millisecond)
+	first := Watch{WatchID: "a1", Name: "Acme Shipping", Type: "business", MinMatch: 0.9, CreatedAt: now}
+	second := Watch{WatchID: "b2", Name: "John Doe", Type: "person", Country: "US", CreatedAt: now.Add(time.Second)}
+
+	require.NoError(t, repo.Save(second))
+	require.NoError(t, repo.Save(first))
+
+	watch, err := repo.Get(first.WatchID)
+	require.NoError(t, err)
+	require.Equal(t, &first, watch)
+
+	watch, err = repo.Get("missing")
+	require.NoError(t, err)
+	require.Nil(t, watch)
+
+	// Saving again replaces the watch
+	first.MinMatch = 0.95
+	require.NoError(t, repo.Save(first))
+
+	watches, err := repo.List()
+	require.NoError(t, err)
+	require.Equal(t, []Watch{first, second}, watches)
+
+	require.NoError(t, repo.Delete(first.WatchID))
+
+	watches, err = repo.List()
+	require.NoError(t, err)
+	require.Equal(t, []Watch{second}, watches)
+}
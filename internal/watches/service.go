@@ -0,0 +1,192 @@
+package watches
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/internal/events"
+	"github.com/moov-io/watchman/internal/search"
+	"github.com/moov-io/watchman/internal/webhooks"
+
+	"github.com/moov-io/base"
+	"github.com/moov-io/base/log"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	defaultMinMatch = 0.85
+
+	// rescreenConcurrency is how many watches are searched at once
+	rescreenConcurrency = 4
+
+	// rescreenLimit is the most matches sent for each watch
+	rescreenLimit = 10
+)
+
+type Service interface {
+	Create(watch Watch) (Watch, error)
+	Get(watchID string) (*Watch, error)
+
+	// List returns the watches of a tenant
+	List(tenantID string) ([]Watch, error)
+	Delete(watchID string) error
+
+	// Rescreen searches every watch and notifies the webhook of each watch with matches.
+	Rescreen(ctx context.Context) error
+}
+
+// NewService creates a Service which notifies each watch's webhook of its matches. Matches are also
+// published as events when publisher is non-nil.
+func NewService(logger log.Logger, repo Repository, searchService search.Service, publisher events.Publisher) Service {
+	return &service{
+		logger:        logger,
+		repo:          repo,
+		searchService: searchService,
+		client:        webhooks.NewClient(),
+		publisher:     publisher,
+	}
+}
+
+type service struct {
+	logger        log.Logger
+	repo          Repository
+	searchService search.Service
+	client        *webhooks.Client
+	publisher     events.Publisher
+}
+
+func (s *service) Create(watch Watch) (Watch, error) {
+	watch.Name = strings.TrimSpace(watch.Name)
+	if watch.Name == "" {
+		return Watch{}, errors.New("watch is missing a name")
+	}
+	watch.Type = strings.TrimSpace(strings.ToLower(watch.Type))
+	watch.Country = strings.TrimSpace(watch.Country)
+
+	if watch.MinMatch <= 0 {
+		watch.MinMatch = defaultMinMatch
+	}
+	if watch.MinMatch > 1.0 {
+		return Watch{}, fmt.Errorf("minMatch of %.2f is above 1.00", watch.MinMatch)
+	}
+
+	var err error
+	watch.WebhookURL, err = webhooks.ValidateURL(watch.WebhookURL)
+	if err != nil {
+		return Watch{}, err
+	}
+	if watch.Secret == "" {
+		watch.Secret, err = webhooks.GenerateSecret()
+		if err != nil {
+			return Watch{}, fmt.Errorf("generating watch secret: %w", err)
+		}
+	}
+
+	watch.WatchID = base.ID()
+	watch.CreatedAt = time.Now().In(time.UTC)
+
+	err = s.repo.Save(watch)
+	if err != nil {
+		return Watch{}, fmt.Errorf("saving watch: %w", err)
+	}
+	return watch, nil
+}
+
+func (s *service) Get(watchID string) (*Watch, error) {
+	return s.repo.Get(watchID)
+}
+
+func (s *service) List(tenantID string) ([]Watch, error) {
+	watches, err := s.repo.List()
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(watches, func(watch Watch) bool {
+		return watch.TenantID != tenantID
+	}), nil
+}
+
+func (s *service) Delete(watchID string) error {
+	return s.repo.Delete(watchID)
+}
+
+func (s *service) Rescreen(ctx context.Context) error {
+	watches, err := s.repo.List()
+	if err != nil {
+		return fmt.Errorf("listing watches: %w", err)
+	}
+	if len(watches) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(rescreenConcurrency)
+
+	for _, watch := range watches {
+		watch := watch
+		g.Go(func() error {
+			logger := s.logger.With(log.Fields{
+				"watch_id": log.String(watch.WatchID),
+			})
+			if err := s.rescreen(ctx, watch); err != nil {
+				logger.Error().LogErrorf("problem rescreening watch: %v", err)
+			}
+			return nil
+		})
+	}
+	g.Wait()
+
+	s.logger.Info().Logf("rescreened %d watches in %v", len(watches), time.Since(start))
+
+	return nil
+}
+
+func (s *service) rescreen(ctx context.Context, watch Watch) error {
+	matches, err := s.searchService.Search(ctx, watch.query(), search.SearchOpts{
+		Limit:    rescreenLimit,
+		MinMatch: watch.MinMatch,
+		TenantID: watch.TenantID,
+	})
+	if err != nil {
+		return fmt.Errorf("searching: %w", err)
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	if s.publisher != nil {
+		event := events.New(events.EventWatchMatched)
+		event.Watch = &events.WatchMatched{
+			WatchID: watch.WatchID,
+			Name:    watch.Name,
+			Matches: matches,
+		}
+		if err := s.publisher.Publish(ctx, event); err != nil {
+			s.logger.Error().LogErrorf("problem publishing watch event: %v", err)
+		}
+	}
+
+	body, err := json.Marshal(Event{
+		Type:      EventWatchMatched,
+		Timestamp: time.Now().In(time.UTC),
+		WatchID:   watch.WatchID,
+		Name:      watch.Name,
+		Matches:   matches,
+	})
+	if err != nil {
+		return fmt.Errorf("encoding watch event: %w", err)
+	}
+
+	err = s.client.Send(ctx, watch.WebhookURL, watch.Secret, body)
+	if err != nil {
+		return fmt.Errorf("sending webhook: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package watches
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/events"
+	"github.com/moov-io/watchman/internal/search"
+	"github.com/moov-io/watchman/internal/webhooks"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func testService(t *testing.T, server *httptest.Server) *service {
+	t.Helper()
+
+	logger := log.NewTestLogger()
+
+	searchService := search.NewService(logger)
+	searchService.UpdateEntities([]pubsearch.Entity[pubsearch.Value]{
+		{
+			Name:     "Nicolas Maduro Moros",
+			Type:     pubsearch.EntityPerson,
+			Source:   pubsearch.SourceUSOFAC,
+			SourceID: "22790",
+			Person: &pubsearch.Person{
+				Name: "Nicolas Maduro Moros",
+			},
+		},
+		{
+			Name:     "Acme Shipping Limited",
+			Type:     pubsearch.EntityBusiness,
+			Source:   pubsearch.SourceUSOFAC,
+			SourceID: "1234",
+			Business: &pubsearch.Business{
+				Name: "Acme Shipping Limited",
+			},
+		},
+	})
+
+	svc, ok := NewService(logger, NewInMemoryRepository(), searchService, nil).(*service)
+	require.True(t, ok)
+
+	if server != nil {
+		svc.client.HTTP = server.Client()
+	}
+	svc.client.RetryDelay = time.Millisecond
+
+	return svc
+}
+
+func TestService_Create(t *testing.T) {
+	svc := testService(t, nil)
+
+	_, err := svc.Create(Watch{WebhookURL: "https://example.com"})
+	require.ErrorContains(t, err, "missing a name")
+
+	_, err = svc.Create(Watch{Name: "John", WebhookURL: "http://example.com"})
+	require.ErrorContains(t, err, "https://")
+
+	_, err = svc.Create(Watch{Name: "John", MinMatch: 1.5, WebhookURL: "https://example.com"})
+	require.ErrorContains(t, err, "above 1.00")
+
+	watch, err := svc.Create(Watch{Name: " John Doe ", Type: "Person", WebhookURL: "https://example.com"})
+	require.NoError(t, err)
+	require.NotEmpty(t, watch.WatchID)
+	require.NotEmpty(t, watch.Secret)
+	require.Equal(t, "John Doe", watch.Name)
+	require.Equal(t, "person", watch.Type)
+	require.InDelta(t, defaultMinMatch, watch.MinMatch, 0.001)
+
+	found, err := svc.Get(watch.WatchID)
+	require.NoError(t, err)
+	require.Equal(t, watch.WatchID, found.WatchID)
+
+	require.NoError(t, svc.Delete(watch.WatchID))
+
+	found, err = svc.Get(watch.WatchID)
+	require.NoError(t, err)
+	require.Nil(t, found)
+}
+
+func TestService_Rescreen(t *testing.T) {
+	delivered := make(chan Event, 10)
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if !webhooks.Verify("secret", r.Header.Get(webhooks.HeaderTimestamp), body, r.Header.Get(webhooks.HeaderSignature)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var event Event
+		json.Unmarshal(body, &event)
+		delivered <- event
+	}))
+	defer server.Close()
+
+	svc := testService(t, server)
+	publisher := events.NewInMemoryPublisher()
+	svc.publisher = publisher
+
+	maduro, err := svc.Create(Watch{
+		Name:       "Nicolas Maduro",
+		Type:       "person",
+		MinMatch:   0.80,
+		WebhookURL: server.URL,
+		Secret:     "secret",
+	})
+	require.NoError(t, err)
+
+	// No matches are expected, so no webhook is sent
+	_, err = svc.Create(Watch{
+		Name:       "Jane Unrelated",
+		Type:       "person",
+		WebhookURL: server.URL,
+		Secret:     "secret",
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, svc.Rescreen(context.Background()))
+	close(delivered)
+
+	var received []Event
+	for event := range delivered {
+		received = append(received, event)
+	}
+	require.Len(t, received, 1)
+
+	event := received[0]
+	require.Equal(t, EventWatchMatched, event.Type)
+	require.Equal(t, maduro.WatchID, event.WatchID)
+	require.Len(t, event.Matches, 1)
+	require.Equal(t, "22790", event.Matches[0].SourceID)
+	require.Greater(t, event.Matches[0].Match, 0.80)
+
+	published := publisher.Events()
+	require.Len(t, published, 1)
+	require.Equal(t, events.EventWatchMatched, published[0].Type)
+	require.Equal(t, maduro.WatchID, published[0].Watch.WatchID)
+	require.Len(t, published[0].Watch.Matches, 1)
+}
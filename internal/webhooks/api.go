@@ -0,0 +1,147 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/moov-io/watchman/internal/auth"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+)
+
+type Controller interface {
+	AppendRoutes(router *mux.Router) *mux.Router
+}
+
+func NewController(logger log.Logger, service Service) Controller {
+	return &controller{
+		logger:  logger,
+		service: service,
+	}
+}
+
+type controller struct {
+	logger  log.Logger
+	service Service
+}
+
+func (c *controller) AppendRoutes(router *mux.Router) *mux.Router {
+	router.
+		Name("Webhooks.create").
+		Methods("POST").
+		Path("/v2/webhooks").
+		HandlerFunc(c.createSubscription)
+
+	router.
+		Name("Webhooks.list").
+		Methods("GET").
+		Path("/v2/webhooks").
+		HandlerFunc(c.listSubscriptions)
+
+	router.
+		Name("Webhooks.delete").
+		Methods("DELETE").
+		Path("/v2/webhooks/{subscriptionID}").
+		HandlerFunc(c.deleteSubscription)
+
+	return router
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func (c *controller) writeError(w http.ResponseWriter, status int, err error) {
+	c.logger.Error().LogError(err)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{
+		Error: err.Error(),
+	})
+}
+
+type createSubscriptionRequest struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret"`
+}
+
+func (c *controller) createSubscription(w http.ResponseWriter, r *http.Request) {
+	var req createSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.writeError(w, http.StatusBadRequest, fmt.Errorf("reading webhook subscription: %w", err))
+		return
+	}
+
+	tenantID, err := auth.RequestTenant(r)
+	if err != nil {
+		c.writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	sub, err := c.service.Subscribe(tenantID, req.URL, req.Secret)
+	if err != nil {
+		c.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// The secret is only returned when the subscription is created
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(sub)
+}
+
+type listSubscriptionsResponse struct {
+	Subscriptions []Subscription `json:"subscriptions"`
+}
+
+func (c *controller) listSubscriptions(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := auth.RequestTenant(r)
+	if err != nil {
+		c.writeError(w, http.StatusForbidden, err)
+		return
+	}
+	subs, err := c.service.Subscriptions(tenantID)
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("listing webhook subscriptions: %w", err))
+		return
+	}
+	for i := range subs {
+		subs[i].Secret = ""
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(listSubscriptionsResponse{
+		Subscriptions: subs,
+	})
+}
+
+func (c *controller) deleteSubscription(w http.ResponseWriter, r *http.Request) {
+	tenantID, err := auth.RequestTenant(r)
+	if err != nil {
+		c.writeError(w, http.StatusForbidden, err)
+		return
+	}
+	subscriptionID := mux.Vars(r)["subscriptionID"]
+
+	// Only the tenant's own subscriptions can be deleted
+	subs, err := c.service.Subscriptions(tenantID)
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("listing webhook subscriptions: %w", err))
+		return
+	}
+	if !slices.ContainsFunc(subs, func(sub Subscription) bool { return sub.SubscriptionID == subscriptionID }) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	err = c.service.Unsubscribe(subscriptionID)
+	if err != nil {
+		c.writeError(w, http.StatusInternalServerError, fmt.Errorf("deleting webhook subscription: %w", err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,74 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/internal/auth"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestController(t *testing.T) {
+	router := mux.NewRouter()
+	NewController(log.NewTestLogger(), testService(t, nil)).AppendRoutes(router)
+
+	// create
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("POST", "/v2/webhooks", strings.NewReader(`{"url": "https://example.com/hook", "secret": "s3cr3t"}`))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	var sub Subscription
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&sub))
+	require.Equal(t, "https://example.com/hook", sub.URL)
+	require.Equal(t, "s3cr3t", sub.Secret)
+
+	// invalid
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("POST", "/v2/webhooks", strings.NewReader(`{"url": "ftp://example.com"}`))
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	// list
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/webhooks", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp listSubscriptionsResponse
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Len(t, resp.Subscriptions, 1)
+	require.Empty(t, resp.Subscriptions[0].Secret)
+
+	// other tenants can't see or delete the subscription
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("GET", "/v2/webhooks?tenantID=globex", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+	require.Empty(t, resp.Subscriptions)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/v2/webhooks/"+sub.SubscriptionID+"?tenantID=globex", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	ctx := auth.WithClient(context.Background(), &auth.Client{Name: "payments", Tenant: "globex"})
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/v2/webhooks/"+sub.SubscriptionID, nil).WithContext(ctx)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNotFound, w.Code)
+
+	// delete
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest("DELETE", "/v2/webhooks/"+sub.SubscriptionID, nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusNoContent, w.Code)
+}
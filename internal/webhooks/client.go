@@ -0,0 +1,100 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client delivers signed payloads to webhook URLs
+type Client struct {
+	HTTP *http.Client
+
+	MaxAttempts int
+	RetryDelay  time.Duration
+}
+
+func NewClient() *Client {
+	return &Client{
+		HTTP: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		MaxAttempts: 3,
+		RetryDelay:  time.Second,
+	}
+}
+
+// Send POSTs body to the webhook URL, signed with secret, and retries failed deliveries.
+func (c *Client) Send(ctx context.Context, webhookURL, secret string, body []byte) error {
+	var err error
+	for attempt := 1; attempt <= c.MaxAttempts; attempt++ {
+		err = c.send(ctx, webhookURL, secret, body)
+		if err == nil {
+			return nil
+		}
+		if attempt < c.MaxAttempts {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(c.RetryDelay * time.Duration(attempt)):
+			}
+		}
+	}
+	return fmt.Errorf("after %d attempts: %w", c.MaxAttempts, err)
+}
+
+func (c *Client) send(ctx context.Context, webhookURL, secret string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(secret, timestamp, body))
+
+	resp, err := c.HTTP.Do(req)
+	if resp != nil && resp.Body != nil {
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+	}
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected %s", resp.Status)
+	}
+	return nil
+}
+
+// ValidateURL ensures webhook URLs are absolute https:// addresses
+func ValidateURL(webhookURL string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(webhookURL))
+	if err != nil {
+		return "", fmt.Errorf("invalid webhook url: %w", err)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return "", errors.New("webhook url must be an absolute https:// address")
+	}
+	return u.String(), nil
+}
+
+// GenerateSecret returns a random secret used to sign webhooks
+func GenerateSecret() (string, error) {
+	bs := make([]byte, 32)
+	if _, err := rand.Read(bs); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bs), nil
+}
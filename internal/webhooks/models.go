@@ -0,0 +1,38 @@
+package webhooks
+
+import (
+	"time"
+
+	"github.com/moov-io/watchman/internal/download"
+)
+
+type Subscription struct {
+	SubscriptionID string    `json:"subscriptionID"`
+	TenantID       string    `json:"tenantID,omitempty"`
+	URL            string    `json:"url"`
+	Secret         string    `json:"secret,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// Event is the body sent to each subscription
+type Event struct {
+	Type      string    `json:"type"`
+	Timestamp time.Time `json:"timestamp"`
+
+	Lists []ListEvent `json:"lists,omitempty"`
+
+	// Alert is sent with lists.alert events when a refresh wasn't applied
+	Alert *download.ListAlert `json:"alert,omitempty"`
+}
+
+const (
+	EventListsRefreshed = "lists.refreshed"
+	EventListAlert      = "lists.alert"
+)
+
+type ListEvent struct {
+	Name    string `json:"name"`
+	Changed bool   `json:"changed"`
+
+	download.ListChanges
+}
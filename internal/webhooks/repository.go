@@ -0,0 +1,54 @@
+package webhooks
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+)
+
+type Repository interface {
+	Save(sub Subscription) error
+	List() ([]Subscription, error)
+	Delete(subscriptionID string) error
+}
+
+func NewInMemoryRepository() Repository {
+	return &inmemRepository{
+		subscriptions: make(map[string]Subscription),
+	}
+}
+
+type inmemRepository struct {
+	mu            sync.RWMutex
+	subscriptions map[string]Subscription
+}
+
+func (r *inmemRepository) Save(sub Subscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.subscriptions[sub.SubscriptionID] = sub
+	return nil
+}
+
+func (r *inmemRepository) List() ([]Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]Subscription, 0, len(r.subscriptions))
+	for _, sub := range r.subscriptions {
+		out = append(out, sub)
+	}
+	slices.SortFunc(out, func(a, b Subscription) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.SubscriptionID, b.SubscriptionID))
+	})
+	return out, nil
+}
+
+func (r *inmemRepository) Delete(subscriptionID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.subscriptions, subscriptionID)
+	return nil
+}
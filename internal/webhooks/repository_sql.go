@@ -0,0 +1,63 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/moov-io/watchman/internal/database"
+)
+
+// NewSQLRepository keeps subscriptions in db, so they're shared between Watchman instances
+func NewSQLRepository(db *database.DB) Repository {
+	return &sqlRepository{db: db}
+}
+
+type sqlRepository struct {
+	db *database.DB
+}
+
+func (r *sqlRepository) Save(sub Subscription) error {
+	bs, err := json.Marshal(sub)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	return r.db.InTx(ctx, func(tx *database.Tx) error {
+		_, err := tx.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE subscription_id = ?`, sub.SubscriptionID)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `INSERT INTO webhook_subscriptions (subscription_id, created_at, data) VALUES (?, ?, ?)`,
+			sub.SubscriptionID, database.ToMillis(sub.CreatedAt), string(bs))
+		return err
+	})
+}
+
+func (r *sqlRepository) List() ([]Subscription, error) {
+	rows, err := r.db.QueryContext(context.Background(), `SELECT data FROM webhook_subscriptions ORDER BY created_at, subscription_id`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []Subscription
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var sub Subscription
+		if err := json.Unmarshal([]byte(data), &sub); err != nil {
+			return nil, fmt.Errorf("reading webhook subscription: %w", err)
+		}
+		out = append(out, sub)
+	}
+	return out, rows.Err()
+}
+
+func (r *sqlRepository) Delete(subscriptionID string) error {
+	_, err := r.db.ExecContext(context.Background(), `DELETE FROM webhook_subscriptions WHERE subscription_id = ?`, subscriptionID)
+	return err
+}
@@ -0,0 +1,47 @@
+package webhooks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/database"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRepository(t *testing.T) {
+	repos := map[string]Repository{
+		"inmem": NewInMemoryRepository(),
+		"sql":   NewSQLRepository(database.NewTestDB(t)),
+	}
+	for name, repo := range repos {
+		t.Run(name, func(t *testing.T) {
+			testRepository(t, repo)
+		})
+	}
+}
+
+func testRepository(t *testing.T, repo Repository) {
+	t.Helper()
+
+	now := time.Now().In(time.UTC).Truncate(time.Millisecond)
+	first := Subscription{SubscriptionID: "a1", URL: "https://example.com/hook", Secret: "s3cr3t", CreatedAt: now}
+	second := Subscription{SubscriptionID: "b2", TenantID: "acme", URL: "https://acme.example.com/hook", CreatedAt: now.Add(time.Second)}
+
+	require.NoError(t, repo.Save(second))
+	require.NoError(t, repo.Save(first))
+
+	// Saving again replaces the subscription
+	first.URL = "https://example.com/hook2"
+	require.NoError(t, repo.Save(first))
+
+	subs, err := repo.List()
+	require.NoError(t, err)
+	require.Equal(t, []Subscription{first, second}, subs)
+
+	require.NoError(t, repo.Delete(first.SubscriptionID))
+
+	subs, err = repo.List()
+	require.NoError(t, err)
+	require.Equal(t, []Subscription{second}, subs)
+}
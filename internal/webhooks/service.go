@@ -0,0 +1,150 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moov-io/watchman/internal/download"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base"
+	"github.com/moov-io/base/log"
+)
+
+type Service interface {
+	// Subscribe adds a subscription for the tenant, see auth.RequestTenant. Every subscription is sent each
+	// Event, as list refreshes aren't specific to a tenant.
+	Subscribe(tenantID, webhookURL, secret string) (Subscription, error)
+
+	// Subscriptions returns the subscriptions of a tenant
+	Subscriptions(tenantID string) ([]Subscription, error)
+	Unsubscribe(subscriptionID string) error
+
+	// ListsRefreshed sends an Event to every subscription and blocks until each delivery has completed or failed.
+	ListsRefreshed(ctx context.Context, changes map[search.SourceList]download.ListChanges) error
+
+	// ListAlert sends an Event to every subscription when a refresh of a list wasn't applied
+	ListAlert(ctx context.Context, alert download.ListAlert) error
+}
+
+func NewService(logger log.Logger, repo Repository) Service {
+	return &service{
+		logger: logger,
+		repo:   repo,
+		client: NewClient(),
+	}
+}
+
+type service struct {
+	logger log.Logger
+	repo   Repository
+	client *Client
+}
+
+func (s *service) Subscribe(tenantID, webhookURL, secret string) (Subscription, error) {
+	webhookURL, err := ValidateURL(webhookURL)
+	if err != nil {
+		return Subscription{}, err
+	}
+
+	if secret == "" {
+		secret, err = GenerateSecret()
+		if err != nil {
+			return Subscription{}, fmt.Errorf("generating webhook secret: %w", err)
+		}
+	}
+
+	sub := Subscription{
+		SubscriptionID: base.ID(),
+		TenantID:       tenantID,
+		URL:            webhookURL,
+		Secret:         secret,
+		CreatedAt:      time.Now().In(time.UTC),
+	}
+	err = s.repo.Save(sub)
+	if err != nil {
+		return Subscription{}, fmt.Errorf("saving webhook subscription: %w", err)
+	}
+	return sub, nil
+}
+
+func (s *service) Subscriptions(tenantID string) ([]Subscription, error) {
+	subs, err := s.repo.List()
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(subs, func(sub Subscription) bool {
+		return sub.TenantID != tenantID
+	}), nil
+}
+
+func (s *service) Unsubscribe(subscriptionID string) error {
+	return s.repo.Delete(subscriptionID)
+}
+
+func (s *service) ListsRefreshed(ctx context.Context, changes map[search.SourceList]download.ListChanges) error {
+	event := Event{
+		Type:      EventListsRefreshed,
+		Timestamp: time.Now().In(time.UTC),
+	}
+	for list, c := range changes {
+		event.Lists = append(event.Lists, ListEvent{
+			Name:        string(list),
+			Changed:     c.Changed(),
+			ListChanges: c,
+		})
+	}
+	slices.SortFunc(event.Lists, func(a, b ListEvent) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return s.send(ctx, event)
+}
+
+func (s *service) ListAlert(ctx context.Context, alert download.ListAlert) error {
+	return s.send(ctx, Event{
+		Type:      EventListAlert,
+		Timestamp: time.Now().In(time.UTC),
+		Alert:     &alert,
+	})
+}
+
+// send delivers event to every subscription
+func (s *service) send(ctx context.Context, event Event) error {
+	subs, err := s.repo.List()
+	if err != nil {
+		return fmt.Errorf("listing webhook subscriptions: %w", err)
+	}
+	if len(subs) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding webhook event: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	for _, sub := range subs {
+		wg.Add(1)
+		go func(sub Subscription) {
+			defer wg.Done()
+
+			logger := s.logger.With(log.Fields{
+				"subscription_id": log.String(sub.SubscriptionID),
+			})
+			if err := s.client.Send(ctx, sub.URL, sub.Secret, body); err != nil {
+				logger.Error().LogErrorf("webhook delivery failed: %v", err)
+			} else {
+				logger.Info().Log("webhook delivered")
+			}
+		}(sub)
+	}
+	wg.Wait()
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/download"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func testService(t *testing.T, server *httptest.Server) *service {
+	t.Helper()
+
+	svc, ok := NewService(log.NewTestLogger(), NewInMemoryRepository()).(*service)
+	require.True(t, ok)
+
+	if server != nil {
+		svc.client.HTTP = server.Client()
+	}
+	svc.client.RetryDelay = time.Millisecond
+
+	return svc
+}
+
+func TestService_Subscribe(t *testing.T) {
+	svc := testService(t, nil)
+
+	_, err := svc.Subscribe("", "http://example.com/webhook", "")
+	require.ErrorContains(t, err, "must be an absolute https:// address")
+
+	_, err = svc.Subscribe("", "/webhook", "")
+	require.ErrorContains(t, err, "must be an absolute https:// address")
+
+	sub, err := svc.Subscribe("", "https://example.com/webhook", "")
+	require.NoError(t, err)
+	require.NotEmpty(t, sub.SubscriptionID)
+	require.Len(t, sub.Secret, 64)
+
+	subs, err := svc.Subscriptions("")
+	require.NoError(t, err)
+	require.Len(t, subs, 1)
+
+	require.NoError(t, svc.Unsubscribe(sub.SubscriptionID))
+
+	subs, err = svc.Subscriptions("")
+	require.NoError(t, err)
+	require.Empty(t, subs)
+}
+
+func TestService_ListsRefreshed(t *testing.T) {
+	var received atomic.Int32
+	var event Event
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		timestamp := r.Header.Get(HeaderTimestamp)
+		if !Verify("secret", timestamp, body, r.Header.Get(HeaderSignature)) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		// Fail the first delivery to exercise retries
+		if received.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.Unmarshal(body, &event)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	svc := testService(t, server)
+
+	_, err := svc.Subscribe("", server.URL+"/webhook", "secret")
+	require.NoError(t, err)
+
+	err = svc.ListsRefreshed(context.Background(), map[search.SourceList]download.ListChanges{
+		search.SourceUSOFAC: {Entities: 10, Added: 1, Removed: 2, Modified: 3},
+		search.SourceUSCSL:  {Entities: 5},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), received.Load())
+
+	require.Equal(t, EventListsRefreshed, event.Type)
+	require.Len(t, event.Lists, 2)
+
+	require.Equal(t, "us_csl", event.Lists[0].Name)
+	require.False(t, event.Lists[0].Changed)
+
+	require.Equal(t, "us_ofac", event.Lists[1].Name)
+	require.True(t, event.Lists[1].Changed)
+	require.Equal(t, 10, event.Lists[1].Entities)
+	require.Equal(t, 1, event.Lists[1].Added)
+	require.Equal(t, 2, event.Lists[1].Removed)
+	require.Equal(t, 3, event.Lists[1].Modified)
+}
+
+func TestService_ListAlert(t *testing.T) {
+	var event Event
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&event)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	svc := testService(t, server)
+
+	_, err := svc.Subscribe("", server.URL+"/webhook", "secret")
+	require.NoError(t, err)
+
+	err = svc.ListAlert(context.Background(), download.ListAlert{
+		List:     search.SourceUSOFAC,
+		Reason:   download.AlertListShrunk,
+		Entities: 10,
+		Previous: 100,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, EventListAlert, event.Type)
+	require.Empty(t, event.Lists)
+	require.NotNil(t, event.Alert)
+	require.Equal(t, search.SourceUSOFAC, event.Alert.List)
+	require.Equal(t, download.AlertListShrunk, event.Alert.Reason)
+	require.Equal(t, 100, event.Alert.Previous)
+}
+
+func TestSignature(t *testing.T) {
+	body := []byte(`{"type":"lists.refreshed"}`)
+
+	sig := Sign("secret", "1700000000", body)
+	require.Equal(t, "sha256=", sig[:7])
+
+	require.True(t, Verify("secret", "1700000000", body, sig))
+	require.False(t, Verify("other", "1700000000", body, sig))
+	require.False(t, Verify("secret", "1700000001", body, sig))
+}
@@ -3,11 +3,104 @@
 package address
 
 import (
+	"strings"
+	"unicode"
+
 	"github.com/moov-io/watchman/pkg/search"
 )
 
+// ParseAddress splits an address written on one line, such as "Skorepka 1058/8, Prague 110 00, Czech Republic",
+// into its components. Without libpostal the components are read from the parts of the address between commas:
+// the first is the street, a trailing country is recognized by name or code, the part before it holds the city
+// along with any postal code and state or province code, and any parts in between are the second line.
 func ParseAddress(input string) search.Address {
 	var out search.Address
 
+	parts := splitParts(input)
+	if len(parts) == 0 {
+		return out
+	}
+	if _, ok := search.CountryCode(parts[len(parts)-1]); ok {
+		out.Country = parts[len(parts)-1]
+		parts = parts[:len(parts)-1]
+	}
+	switch len(parts) {
+	case 0:
+		return out
+	case 1:
+		// A lone part is a street unless it's only a city, such as "Tehran, Iran"
+		if out.Country != "" && !strings.ContainsFunc(parts[0], unicode.IsDigit) {
+			out.City = parts[0]
+		} else {
+			out.Line1 = parts[0]
+		}
+		return out
+	}
+
+	out.Line1 = parts[0]
+	rest := parts[1:]
+
+	// The last part is the city, postal code and state, which can also be written on their own
+	last := len(rest) - 1
+	out.City, out.State, out.PostalCode = parseLocality(rest[last])
+	if out.City == "" && last > 0 && (out.PostalCode != "" || out.State != "") {
+		last--
+		out.City = rest[last]
+	}
+	out.Line2 = strings.Join(rest[:last], ", ")
+
 	return out
 }
+
+func splitParts(input string) []string {
+	var out []string
+	for _, part := range strings.FieldsFunc(input, func(r rune) bool {
+		return r == ',' || r == ';' || r == '\n'
+	}) {
+		if part = strings.Join(strings.Fields(part), " "); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+// parseLocality reads the city, state and postal code from a part of an address, such as "Moscow 123317",
+// "Springfield IL 62701" or "10115 Berlin". Postal codes are the words with digits at the start or end of the
+// part, and a state is a short uppercase code before a trailing postal code.
+func parseLocality(part string) (city, state, postalCode string) {
+	words := strings.Fields(part)
+
+	start, end := 0, len(words)
+	for end > start && end > len(words)-2 && isPostalWord(words[end-1]) {
+		end--
+	}
+	if end == len(words) {
+		for start < end && start < 2 && isPostalWord(words[start]) {
+			start++
+		}
+	}
+	postal := append(append([]string{}, words[:start]...), words[end:]...)
+
+	if len(postal) > 0 && end-start > 0 && isRegionCode(words[end-1]) {
+		state = words[end-1]
+		end--
+	}
+	return strings.Join(words[start:end], " "), state, strings.Join(postal, " ")
+}
+
+func isPostalWord(word string) bool {
+	return len(word) <= 10 && strings.ContainsFunc(word, unicode.IsDigit)
+}
+
+// isRegionCode returns true for state and province codes, such as "IL" or "NSW"
+func isRegionCode(word string) bool {
+	if len(word) < 2 || len(word) > 3 {
+		return false
+	}
+	for _, r := range word {
+		if !unicode.IsUpper(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -1,3 +1,80 @@
 //go:build !libpostal
 
 package address
+
+import (
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAddress(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected search.Address
+	}{
+		{
+			input:    "",
+			expected: search.Address{},
+		},
+		{
+			input: "Skorepka 1058/8 Stare Mesto, Prague 110 00, Czech Republic",
+			expected: search.Address{
+				Line1:      "Skorepka 1058/8 Stare Mesto",
+				City:       "Prague",
+				PostalCode: "110 00",
+				Country:    "Czech Republic",
+			},
+		},
+		{
+			input: "Presnenskaya Embankment, 12, Federation East Tower, Floor 31, Suite Q, Moscow 123317, Russia",
+			expected: search.Address{
+				Line1:      "Presnenskaya Embankment",
+				Line2:      "12, Federation East Tower, Floor 31, Suite Q",
+				City:       "Moscow",
+				PostalCode: "123317",
+				Country:    "Russia",
+			},
+		},
+		{
+			input: "101 Maple Street, Apt 202, Springfield, IL 62701, US",
+			expected: search.Address{
+				Line1:      "101 Maple Street",
+				Line2:      "Apt 202",
+				City:       "Springfield",
+				State:      "IL",
+				PostalCode: "62701",
+				Country:    "US",
+			},
+		},
+		{
+			input: "Unter den Linden 77\n10117 Berlin\nGermany",
+			expected: search.Address{
+				Line1:      "Unter den Linden 77",
+				City:       "Berlin",
+				PostalCode: "10117",
+				Country:    "Germany",
+			},
+		},
+		{
+			input: "Tehran, Iran",
+			expected: search.Address{
+				City:    "Tehran",
+				Country: "Iran",
+			},
+		},
+		{
+			input: "123 Main St",
+			expected: search.Address{
+				Line1: "123 Main St",
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			require.Equal(t, tc.expected, ParseAddress(tc.input))
+		})
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package client calls the Watchman HTTP API, retrying requests which were refused or failed in transit.
+//
+//	wc := client.NewClient(client.Config{BaseAddress: "http://localhost:8084", APIKey: "..."})
+//	resp, err := wc.Search(ctx, query, client.SearchOpts{Limit: 5, MinMatch: 0.9})
+package client
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	// BaseAddress of Watchman, http://localhost:8084 by default
+	BaseAddress string
+
+	// HTTPClient sends each request, with a 30s timeout by default
+	HTTPClient *http.Client
+
+	// APIKey is sent as the X-API-Key header and BearerToken as the Authorization header, when configured
+	APIKey      string
+	BearerToken string
+
+	// TenantID is sent as the X-Tenant-ID header, so the tenant's custom lists are searched
+	TenantID string
+
+	// MaxAttempts is how many times a request is sent before its error is returned, 3 by default.
+	// RetryDelay is how long the first retry waits, 500ms by default, and grows with each attempt.
+	MaxAttempts int
+	RetryDelay  time.Duration
+}
+
+// Client calls the Watchman API. It's safe for concurrent use.
+type Client struct {
+	conf       Config
+	httpClient *http.Client
+}
+
+func NewClient(conf Config) *Client {
+	conf.BaseAddress = strings.TrimSuffix(cmp.Or(conf.BaseAddress, "http://localhost:8084"), "/")
+	conf.MaxAttempts = cmp.Or(conf.MaxAttempts, 3)
+	conf.RetryDelay = cmp.Or(conf.RetryDelay, 500*time.Millisecond)
+
+	httpClient := conf.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{
+			Timeout: 30 * time.Second,
+		}
+	}
+
+	return &Client{
+		conf:       conf,
+		httpClient: httpClient,
+	}
+}
+
+// Error is returned when Watchman responds with an unsuccessful status
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("watchman: unexpected %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("watchman: unexpected %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// retryable returns true for responses which mean the request wasn't handled and can be sent again
+func retryable(status int, idempotent bool) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// do sends a request, decoding the response into out when it's non-nil. Requests which aren't idempotent,
+// such as creating a watch, are only retried when Watchman refused them.
+func (c *Client) do(ctx context.Context, method, path string, body, out any, idempotent bool) error {
+	var bs []byte
+	if body != nil {
+		var err error
+		bs, err = json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding %s %s request: %w", method, path, err)
+		}
+	}
+
+	var err error
+	for attempt := 1; attempt <= c.conf.MaxAttempts; attempt++ {
+		var retry bool
+		var wait time.Duration
+		retry, wait, err = c.send(ctx, method, path, bs, out, idempotent)
+		if err == nil || !retry || attempt == c.conf.MaxAttempts {
+			break
+		}
+
+		wait = cmp.Or(wait, c.conf.RetryDelay*time.Duration(attempt))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+	return err
+}
+
+func (c *Client) send(ctx context.Context, method, path string, body []byte, out any, idempotent bool) (bool, time.Duration, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.conf.BaseAddress+path, reader)
+	if err != nil {
+		return false, 0, fmt.Errorf("creating %s %s request: %w", method, path, err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.conf.APIKey != "" {
+		req.Header.Set("X-API-Key", c.conf.APIKey)
+	}
+	if c.conf.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.conf.BearerToken)
+	}
+	if c.conf.TenantID != "" {
+		req.Header.Set("X-Tenant-ID", c.conf.TenantID)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		// Requests which failed in transit may have been handled, so only idempotent ones are retried
+		return idempotent && ctx.Err() == nil, 0, fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		var problem struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&problem)
+
+		var wait time.Duration
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			wait = time.Duration(seconds) * time.Second
+		}
+		return retryable(resp.StatusCode, idempotent), wait, &Error{
+			StatusCode: resp.StatusCode,
+			Message:    problem.Error,
+		}
+	}
+
+	if out != nil && resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return false, 0, fmt.Errorf("decoding %s %s response: %w", method, path, err)
+		}
+	}
+	return false, 0, nil
+}
+
+// isNotFound returns true when err is a 404 from Watchman
+func isNotFound(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.StatusCode == http.StatusNotFound
+}
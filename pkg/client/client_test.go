@@ -0,0 +1,178 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/search"
+	"github.com/moov-io/watchman/internal/watches"
+	"github.com/moov-io/watchman/pkg/normalize"
+	pubsearch "github.com/moov-io/watchman/pkg/search"
+
+	"github.com/gorilla/mux"
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func testServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	logger := log.NewTestLogger()
+
+	searchService := search.NewService(logger)
+	searchService.UpdateEntities([]pubsearch.Entity[pubsearch.Value]{
+		{
+			Name:     "Nicolas Maduro Moros",
+			Type:     pubsearch.EntityPerson,
+			Source:   pubsearch.SourceUSOFAC,
+			SourceID: "22790",
+			Person: &pubsearch.Person{
+				Name: "Nicolas Maduro Moros",
+			},
+		},
+	})
+
+	router := mux.NewRouter()
+	search.NewController(logger, searchService).AppendRoutes(router)
+	watchService := watches.NewService(logger, watches.NewInMemoryRepository(), searchService, nil)
+	watches.NewController(logger, watchService).AppendRoutes(router)
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestClient_Search(t *testing.T) {
+	ctx := context.Background()
+	wc := NewClient(Config{BaseAddress: testServer(t).URL + "/"})
+
+	query := pubsearch.Entity[pubsearch.Value]{
+		Name:   "Nicolas Maduro",
+		Type:   pubsearch.EntityPerson,
+		Person: &pubsearch.Person{Name: "Nicolas Maduro"},
+	}
+	resp, err := wc.Search(ctx, query, SearchOpts{Limit: 1, MinMatch: 0.5})
+	require.NoError(t, err)
+	require.Len(t, resp.Entities, 1)
+	require.Equal(t, "22790", resp.Entities[0].SourceID)
+
+	batch, err := wc.SearchBatch(ctx, pubsearch.BatchSearchRequest{
+		MinMatch: 0.9,
+		Queries: []pubsearch.BatchSearchQuery{
+			{Name: "Nicolas Maduro Moros", Type: "person"},
+			{Name: "Jane Doe", Type: "person"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, batch.Results, 2)
+	require.Len(t, batch.Results[0].Entities, 1)
+	require.Empty(t, batch.Results[1].Entities)
+
+	_, err = wc.SearchBatch(ctx, pubsearch.BatchSearchRequest{})
+	var e *Error
+	require.ErrorAs(t, err, &e)
+	require.Equal(t, http.StatusBadRequest, e.StatusCode)
+	require.Contains(t, e.Message, "no queries provided")
+
+	info, err := wc.ListInfo(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 1, info.Lists["us_ofac"])
+}
+
+func TestClient_Normalize(t *testing.T) {
+	ctx := context.Background()
+	wc := NewClient(Config{BaseAddress: testServer(t).URL + "/"})
+
+	opts := normalize.Options{Type: pubsearch.EntityPerson, Stages: []string{"reorder"}}
+	resp, err := wc.Normalize(ctx, []string{"MADURO MOROS, Nicolas"}, opts)
+	require.NoError(t, err)
+
+	normalizer, err := normalize.New(opts)
+	require.NoError(t, err)
+	require.Equal(t, normalizer.Version(), resp.Version)
+	require.Equal(t, []normalize.Name{normalizer.Normalize("MADURO MOROS, Nicolas")}, resp.Names)
+}
+
+func TestClient_Watches(t *testing.T) {
+	ctx := context.Background()
+	wc := NewClient(Config{BaseAddress: testServer(t).URL})
+
+	_, err := wc.CreateWatch(ctx, Watch{Name: "Acme", WebhookURL: "http://example.com"})
+	require.ErrorContains(t, err, "https://")
+
+	watch, err := wc.CreateWatch(ctx, Watch{Name: "Acme Shipping", Type: "business", WebhookURL: "https://example.com/hook"})
+	require.NoError(t, err)
+	require.NotEmpty(t, watch.WatchID)
+	require.NotEmpty(t, watch.Secret)
+
+	found, err := wc.GetWatch(ctx, watch.WatchID)
+	require.NoError(t, err)
+	require.Equal(t, "Acme Shipping", found.Name)
+	require.Empty(t, found.Secret)
+
+	list, err := wc.ListWatches(ctx)
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	require.NoError(t, wc.DeleteWatch(ctx, watch.WatchID))
+
+	found, err = wc.GetWatch(ctx, watch.WatchID)
+	require.NoError(t, err)
+	require.Nil(t, found)
+}
+
+func TestClient_Retries(t *testing.T) {
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "key", r.Header.Get("X-API-Key"))
+		require.Equal(t, "acme", r.Header.Get("X-Tenant-ID"))
+
+		switch attempts.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.Write([]byte(`{"lists": {"us_ofac": 10}}`))
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	wc := NewClient(Config{
+		BaseAddress: server.URL,
+		APIKey:      "key",
+		TenantID:    "acme",
+		RetryDelay:  time.Millisecond,
+	})
+
+	info, err := wc.ListInfo(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 10, info.Lists["us_ofac"])
+	require.Equal(t, int32(3), attempts.Load())
+
+	// Requests which aren't idempotent aren't retried after a bad gateway
+	attempts.Store(1)
+	_, err = wc.CreateWatch(ctx, Watch{Name: "Acme"})
+	var e *Error
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, http.StatusBadGateway, e.StatusCode)
+	require.Equal(t, int32(2), attempts.Load())
+
+	// Cancelled contexts stop retries
+	attempts.Store(0)
+	ctx, cancelFunc := context.WithCancel(ctx)
+	cancelFunc()
+	_, err = wc.ListInfo(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,258 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/normalize"
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+type SearchOpts struct {
+	Limit    int
+	MinMatch float64
+
+	// Algorithm chooses how names are compared, such as jaro-winkler
+	Algorithm string
+
+	// Explain asks Watchman to include how each result was scored
+	Explain bool
+
+	// Highlight asks Watchman to include the words of each result's names which matched the query
+	Highlight bool
+
+	// Consolidate merges the records of the same party on different lists into one result,
+	// listing the others in its Related records
+	Consolidate bool
+
+	// ExactFirst only scores the entities with exactly the query's name (in any order or case),
+	// unless none of them match
+	ExactFirst bool
+
+	// Partial only returns the entities with a name containing the query's name, such as "Maduro"
+	Partial bool
+
+	// Sort orders results by score (the default), name or list
+	Sort string
+
+	// Cursor returns the next page of results, from a previous SearchResponse.NextCursor
+	Cursor string
+
+	// Programs, Countries, EntityTypes and Lists (such as SSI or NS-CMIC) only return entities matching
+	// one of their values
+	Programs    []string
+	Countries   []string
+	EntityTypes []string
+	Lists       []string
+
+	// Timeout and MaxCandidates lower how long the search scores entities for and how many it scores,
+	// returning the best results found before either runs out
+	Timeout       time.Duration
+	MaxCandidates int
+
+	// Profile names the scoring profile of the search, such as its weights and minimum score, which otherwise
+	// depends on the API key and tenant
+	Profile string
+
+	// RequestID is logged with the search, to find it in Watchman's logs
+	RequestID string
+}
+
+type SearchResponse struct {
+	Entities []search.SearchedEntity[search.Value] `json:"entities"`
+
+	// NextCursor is set when there are more results, see SearchOpts.Cursor
+	NextCursor string `json:"nextCursor,omitempty"`
+
+	// Truncated is set when the search ran out of time or candidates, so Entities are the best of those it scored
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// Search finds the entities most like query with /v2/search
+func (c *Client) Search(ctx context.Context, query search.Entity[search.Value], opts SearchOpts) (SearchResponse, error) {
+	q := queryValues(query)
+	if opts.Limit > 0 {
+		q.Set("limit", strconv.Itoa(opts.Limit))
+	}
+	if opts.MinMatch > 0 {
+		q.Set("minMatch", strconv.FormatFloat(opts.MinMatch, 'f', -1, 64))
+	}
+	setValue(q, "algorithm", opts.Algorithm)
+	if opts.Explain {
+		q.Set("explain", "true")
+	}
+	if opts.Highlight {
+		q.Set("highlight", "true")
+	}
+	if opts.Consolidate {
+		q.Set("consolidate", "true")
+	}
+	if opts.ExactFirst {
+		q.Set("exactFirst", "true")
+	}
+	if opts.Partial {
+		q.Set("partial", "true")
+	}
+	setValue(q, "sort", opts.Sort)
+	setValue(q, "cursor", opts.Cursor)
+	setValue(q, "profile", opts.Profile)
+	setValue(q, "requestID", opts.RequestID)
+	if opts.Timeout > 0 {
+		q.Set("timeout", opts.Timeout.String())
+	}
+	if opts.MaxCandidates > 0 {
+		q.Set("maxCandidates", strconv.Itoa(opts.MaxCandidates))
+	}
+	addValues(q, "program", opts.Programs)
+	addValues(q, "country", opts.Countries)
+	addValues(q, "entityType", opts.EntityTypes)
+	addValues(q, "list", opts.Lists)
+
+	var out SearchResponse
+	err := c.do(ctx, "GET", "/v2/search?"+q.Encode(), nil, &out, true)
+	return out, err
+}
+
+// SearchBatch screens several queries in one request to /v2/search/batch
+func (c *Client) SearchBatch(ctx context.Context, req search.BatchSearchRequest) (search.BatchSearchResponse, error) {
+	var out search.BatchSearchResponse
+	err := c.do(ctx, "POST", "/v2/search/batch", req, &out, true)
+	return out, err
+}
+
+func setValue(q url.Values, key, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		q.Set(key, value)
+	}
+}
+
+func addValues(q url.Values, key string, values []string) {
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value != "" {
+			q.Add(key, value)
+		}
+	}
+}
+
+func setDate(q url.Values, key string, t *time.Time) {
+	if t != nil && !t.IsZero() {
+		q.Set(key, t.Format("2006-01-02"))
+	}
+}
+
+// queryValues writes the fields of query which /v2/search reads as query parameters
+func queryValues(query search.Entity[search.Value]) url.Values {
+	q := make(url.Values)
+	setValue(q, "name", query.Name)
+	setValue(q, "type", string(query.Type))
+
+	if p := query.Person; p != nil {
+		setValue(q, "name", p.Name)
+		addValues(q, "altNames", p.AltNames)
+		setValue(q, "gender", string(p.Gender))
+		setDate(q, "birthDate", p.BirthDate)
+		setDate(q, "deathDate", p.DeathDate)
+		addValues(q, "titles", p.Titles)
+		addValues(q, "nationality", p.Nationalities)
+	}
+	if b := query.Business; b != nil {
+		setValue(q, "name", b.Name)
+		setDate(q, "created", b.Created)
+		setDate(q, "dissolved", b.Dissolved)
+	}
+	if o := query.Organization; o != nil {
+		setValue(q, "name", o.Name)
+		setDate(q, "created", o.Created)
+		setDate(q, "dissolved", o.Dissolved)
+	}
+	if a := query.Aircraft; a != nil {
+		setValue(q, "name", a.Name)
+		setValue(q, "aircraftType", string(a.Type))
+		setValue(q, "flag", a.Flag)
+		setDate(q, "built", a.Built)
+		setValue(q, "icaoCode", a.ICAOCode)
+		setValue(q, "model", a.Model)
+		setValue(q, "serialNumber", a.SerialNumber)
+		setValue(q, "tailNumber", a.TailNumber)
+	}
+	if v := query.Vessel; v != nil {
+		setValue(q, "name", v.Name)
+		setValue(q, "imoNumber", v.IMONumber)
+		setValue(q, "vesselType", string(v.Type))
+		setValue(q, "flag", v.Flag)
+		setValue(q, "model", v.Model)
+		setValue(q, "mmsi", v.MMSI)
+		setValue(q, "callSign", v.CallSign)
+		setValue(q, "owner", v.Owner)
+		if v.Tonnage > 0 {
+			q.Set("tonnage", strconv.Itoa(v.Tonnage))
+		}
+		if v.GrossRegisteredTonnage > 0 {
+			q.Set("grossRegisteredTonnage", strconv.Itoa(v.GrossRegisteredTonnage))
+		}
+	}
+
+	addValues(q, "email", query.Contact.EmailAddresses)
+	addValues(q, "phone", query.Contact.PhoneNumbers)
+	addValues(q, "fax", query.Contact.FaxNumbers)
+	addValues(q, "website", query.Contact.Websites)
+
+	for _, addr := range query.Addresses {
+		parts := []string{addr.Line1, addr.Line2, addr.City, strings.TrimSpace(addr.State + " " + addr.PostalCode), addr.Country}
+		var nonEmpty []string
+		for _, part := range parts {
+			if part = strings.TrimSpace(part); part != "" {
+				nonEmpty = append(nonEmpty, part)
+			}
+		}
+		if len(nonEmpty) > 0 {
+			q.Add("address", strings.Join(nonEmpty, ", "))
+		}
+	}
+	for _, addr := range query.CryptoAddresses {
+		if addr.Currency != "" && addr.Address != "" {
+			q.Add("cryptoAddress", addr.Currency+":"+addr.Address)
+		}
+	}
+	return q
+}
+
+// ListInfo is how many entities were loaded from each list, and when they were last refreshed
+type ListInfo struct {
+	Lists     map[string]int `json:"lists"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+// ListInfo returns the lists Watchman has loaded, from /v2/listinfo
+func (c *Client) ListInfo(ctx context.Context) (ListInfo, error) {
+	var out ListInfo
+	err := c.do(ctx, "GET", "/v2/listinfo", nil, &out, true)
+	return out, err
+}
+
+// NormalizeResponse holds names as Watchman prepared and normalized them
+type NormalizeResponse struct {
+	// Version is the same as a normalize.Normalizer's when it normalizes names the same way as Watchman
+	Version string           `json:"version"`
+	Names   []normalize.Name `json:"names"`
+}
+
+// Normalize prepares and normalizes names the same way as a search with opts, from /v2/normalize
+func (c *Client) Normalize(ctx context.Context, names []string, opts normalize.Options) (NormalizeResponse, error) {
+	q := make(url.Values)
+	addValues(q, "name", names)
+	setValue(q, "type", string(opts.Type))
+	setValue(q, "country", opts.Country)
+	setValue(q, "prepare", strings.Join(opts.Stages, ","))
+
+	var out NormalizeResponse
+	err := c.do(ctx, "GET", "/v2/normalize?"+q.Encode(), nil, &out, true)
+	return out, err
+}
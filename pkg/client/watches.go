@@ -0,0 +1,64 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package client
+
+import (
+	"context"
+	"net/url"
+	"time"
+)
+
+// Watch is a name which Watchman rescreens after every list refresh, notifying WebhookURL of its matches
+type Watch struct {
+	WatchID string `json:"watchID,omitempty"`
+
+	Name    string `json:"name"`
+	Type    string `json:"type,omitempty"`
+	Country string `json:"country,omitempty"`
+
+	// MinMatch is the lowest score which is sent to the webhook, 0.85 when empty
+	MinMatch float64 `json:"minMatch,omitempty"`
+
+	// WebhookURL must be an https:// address. Webhooks are signed with Secret, which is generated
+	// when empty and only returned by CreateWatch.
+	WebhookURL string `json:"webhookURL"`
+	Secret     string `json:"secret,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// CreateWatch saves watch and returns it with its ID and secret
+func (c *Client) CreateWatch(ctx context.Context, watch Watch) (Watch, error) {
+	var out Watch
+	err := c.do(ctx, "POST", "/v2/watches", watch, &out, false)
+	return out, err
+}
+
+type listWatchesResponse struct {
+	Watches []Watch `json:"watches"`
+}
+
+func (c *Client) ListWatches(ctx context.Context) ([]Watch, error) {
+	var out listWatchesResponse
+	err := c.do(ctx, "GET", "/v2/watches", nil, &out, true)
+	return out.Watches, err
+}
+
+// GetWatch returns nil when the watch doesn't exist
+func (c *Client) GetWatch(ctx context.Context, watchID string) (*Watch, error) {
+	var out Watch
+	err := c.do(ctx, "GET", "/v2/watches/"+url.PathEscape(watchID), nil, &out, true)
+	if isNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) DeleteWatch(ctx context.Context, watchID string) error {
+	return c.do(ctx, "DELETE", "/v2/watches/"+url.PathEscape(watchID), nil, nil, true)
+}
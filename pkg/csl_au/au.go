@@ -0,0 +1,43 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_au
+
+// Record is a listed individual, entity or vessel of the Australian Department of Foreign Affairs and
+// Trade (DFAT) Consolidated List. Each row of the list is a name, so aliases are collected into their
+// primary name's record.
+//
+// https://www.dfat.gov.au/international-relations/security/sanctions/consolidated-list
+type Record struct {
+	Reference string   `json:"reference"`
+	Name      string   `json:"name"`
+	Type      string   `json:"type"` // Individual, Entity or Vessel
+	Aliases   []string `json:"aliases"`
+
+	DatesOfBirth  []string `json:"datesOfBirth"`
+	PlacesOfBirth []string `json:"placesOfBirth"`
+	Citizenships  []string `json:"citizenships"`
+	Addresses     []string `json:"addresses"`
+
+	AdditionalInformation string `json:"additionalInformation"`
+	ListingInformation    string `json:"listingInformation"`
+	Committees            string `json:"committees"`
+	ControlDate           string `json:"controlDate"`
+}
+
+// Columns of the list, by their header
+const (
+	columnReference   = "Reference"
+	columnName        = "Name of Individual or Entity"
+	columnType        = "Type"
+	columnNameType    = "Name Type"
+	columnDateOfBirth = "Date of Birth"
+	columnBirthPlace  = "Place of Birth"
+	columnCitizenship = "Citizenship"
+	columnAddress     = "Address"
+	columnAdditional  = "Additional Information"
+	columnListing     = "Listing Information"
+	columnCommittees  = "Committees"
+	columnControlDate = "Control Date"
+)
@@ -0,0 +1,31 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_au
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/strx"
+	"github.com/moov-io/watchman/pkg/download"
+)
+
+var (
+	publicAUDownloadURL = "https://www.dfat.gov.au/sites/default/files/regulation8_consolidated.xlsx"
+	auDownloadURL       = strx.Or(os.Getenv("AU_CSL_DOWNLOAD_URL"), publicAUDownloadURL)
+)
+
+const filename = "regulation8_consolidated.xlsx"
+
+func Download(ctx context.Context, logger log.Logger, initialDir string) (map[string]io.ReadCloser, error) {
+	dl := download.New(logger, download.HTTPClient)
+
+	auNameAndSource := make(map[string]string)
+	auNameAndSource[filename] = auDownloadURL
+
+	return dl.GetFiles(ctx, initialDir, auNameAndSource)
+}
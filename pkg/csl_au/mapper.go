@@ -0,0 +1,104 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_au
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+func ConvertSanctionsData(records []Record) []search.Entity[search.Value] {
+	out := make([]search.Entity[search.Value], 0, len(records))
+	for _, record := range records {
+		out = append(out, ToEntity(record))
+	}
+	return out
+}
+
+// ToEntity converts a DFAT Consolidated List record into a search Entity
+func ToEntity(record Record) search.Entity[search.Value] {
+	out := search.Entity[search.Value]{
+		Name:       record.Name,
+		Source:     search.SourceAUCSL,
+		SourceID:   record.Reference,
+		SourceData: record,
+	}
+	altNames := prepare.WithRomanizedNames(record.Aliases)
+
+	switch strings.ToLower(record.Type) {
+	case "individual":
+		out.Type = search.EntityPerson
+		out.Person = &search.Person{
+			Name:          out.Name,
+			AltNames:      altNames,
+			Nationalities: record.Citizenships,
+		}
+		if len(record.PlacesOfBirth) > 0 {
+			out.Person.PlaceOfBirth = record.PlacesOfBirth[0]
+		}
+		for _, dob := range record.DatesOfBirth {
+			if dates, ok := parseDate(dob); ok {
+				out.Person.BirthDates = append(out.Person.BirthDates, dates)
+				if out.Person.BirthDate == nil {
+					out.Person.BirthDate = &dates.Start
+				}
+			}
+		}
+
+	case "vessel":
+		out.Type = search.EntityVessel
+		out.Vessel = &search.Vessel{
+			Name:     out.Name,
+			AltNames: altNames,
+		}
+		if m := imoNumberRegex.FindStringSubmatch(record.AdditionalInformation); m != nil {
+			out.Vessel.IMONumber = m[1]
+		}
+
+	default:
+		out.Type = search.EntityBusiness
+		out.Business = &search.Business{
+			Name:     out.Name,
+			AltNames: altNames,
+		}
+	}
+
+	for _, addr := range record.Addresses {
+		out.Addresses = append(out.Addresses, search.Address{Line1: addr})
+	}
+
+	out.SanctionsInfo = &search.SanctionsInfo{
+		Description: record.AdditionalInformation,
+	}
+	if record.Committees != "" {
+		out.SanctionsInfo.Programs = []string{record.Committees}
+	}
+
+	return out
+}
+
+var imoNumberRegex = regexp.MustCompile(`(?i)IMO(?:\s+number)?[:\s]+(\d{7})`)
+
+var dateLayouts = []string{"02/01/2006", "Jan 2006", "2006"}
+
+// parseDate reads the dates of birth DFAT lists, such as "18/08/1956", "1956" or "circa 1956"
+func parseDate(value string) (search.DateRange, bool) {
+	value = strings.TrimSpace(value)
+	for _, prefix := range []string{"circa", "approximately", "approx.", "c."} {
+		if len(value) > len(prefix) && strings.EqualFold(value[:len(prefix)], prefix) {
+			value = strings.TrimSpace(value[len(prefix):])
+		}
+	}
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return search.DateRangeFor(t, layout), true
+		}
+	}
+	return search.DateRange{}, false
+}
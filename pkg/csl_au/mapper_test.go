@@ -0,0 +1,50 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_au
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertSanctionsData(t *testing.T) {
+	files, err := Download(context.Background(), log.NewNopLogger(), "testdata")
+	require.NoError(t, err)
+
+	records, err := Read(files)
+	require.NoError(t, err)
+
+	entities := ConvertSanctionsData(records)
+	require.Len(t, entities, 4)
+
+	person := entities[0]
+	require.Equal(t, search.SourceAUCSL, person.Source)
+	require.Equal(t, "1", person.SourceID)
+	require.Equal(t, search.EntityPerson, person.Type)
+	require.Equal(t, []string{"Abdal Al-Hadi Al-Iraqi", "Abu Abdallah"}, person.Person.AltNames)
+	require.Equal(t, "Mosul, Iraq", person.Person.PlaceOfBirth)
+	require.Equal(t, []search.DateRange{
+		{Start: time.Date(1961, time.January, 1, 0, 0, 0, 0, time.UTC), End: time.Date(1961, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		{Start: time.Date(1962, time.January, 1, 0, 0, 0, 0, time.UTC), End: time.Date(1962, time.December, 31, 0, 0, 0, 0, time.UTC)},
+	}, person.Person.BirthDates)
+	require.Equal(t, []string{"1267/1989/2253 (ISIL (Da'esh) and Al-Qaida)"}, person.SanctionsInfo.Programs)
+
+	deripaska := entities[1]
+	require.Equal(t, time.Date(1968, time.January, 2, 0, 0, 0, 0, time.UTC), *deripaska.Person.BirthDate)
+
+	business := entities[2]
+	require.Equal(t, search.EntityBusiness, business.Type)
+	require.Equal(t, []search.Address{{Line1: "Moscow, Russia"}}, business.Addresses)
+
+	vessel := entities[3]
+	require.Equal(t, search.EntityVessel, vessel.Type)
+	require.Equal(t, "8660313", vessel.Vessel.IMONumber)
+}
@@ -0,0 +1,108 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_au
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+func Read(files map[string]io.ReadCloser) ([]Record, error) {
+	for name, contents := range files {
+		switch strings.ToLower(name) {
+		case filename:
+			defer contents.Close()
+
+			rows, err := readSheet(contents)
+			if err != nil {
+				return nil, fmt.Errorf("reading %s: %w", name, err)
+			}
+			return parseRows(rows)
+		default:
+			return nil, fmt.Errorf("unknown file %s", name)
+		}
+	}
+	return nil, errors.New("no files provided")
+}
+
+// parseRows collects the rows of each reference into a Record. Aliases are listed with a reference of their
+// primary name followed by a letter, such as "12a".
+func parseRows(rows [][]string) ([]Record, error) {
+	if len(rows) == 0 {
+		return nil, errors.New("missing header row")
+	}
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	for _, required := range []string{columnReference, columnName, columnNameType} {
+		if _, exists := columns[required]; !exists {
+			return nil, fmt.Errorf("missing %q column", required)
+		}
+	}
+
+	var out []Record
+	positions := make(map[string]int) // reference to index into out
+	for _, row := range rows[1:] {
+		get := func(column string) string {
+			idx, exists := columns[column]
+			if !exists || idx >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[idx])
+		}
+
+		reference := strings.TrimRightFunc(get(columnReference), unicode.IsLetter)
+		name := get(columnName)
+		if reference == "" || name == "" {
+			continue
+		}
+
+		idx, exists := positions[reference]
+		if !exists {
+			idx = len(out)
+			positions[reference] = idx
+			out = append(out, Record{Reference: reference})
+		}
+		record := &out[idx]
+
+		if strings.EqualFold(get(columnNameType), "Primary Name") && record.Name == "" {
+			record.Name = name
+		} else if !slices.Contains(record.Aliases, name) {
+			record.Aliases = append(record.Aliases, name)
+		}
+
+		record.Type = cmp.Or(record.Type, get(columnType))
+		record.DatesOfBirth = appendUnique(record.DatesOfBirth, get(columnDateOfBirth))
+		record.PlacesOfBirth = appendUnique(record.PlacesOfBirth, get(columnBirthPlace))
+		record.Citizenships = appendUnique(record.Citizenships, get(columnCitizenship))
+		record.Addresses = appendUnique(record.Addresses, get(columnAddress))
+		record.AdditionalInformation = cmp.Or(record.AdditionalInformation, get(columnAdditional))
+		record.ListingInformation = cmp.Or(record.ListingInformation, get(columnListing))
+		record.Committees = cmp.Or(record.Committees, get(columnCommittees))
+		record.ControlDate = cmp.Or(record.ControlDate, get(columnControlDate))
+	}
+
+	// References which only have aliases use their first alias
+	for i := range out {
+		if out[i].Name == "" && len(out[i].Aliases) > 0 {
+			out[i].Name = out[i].Aliases[0]
+			out[i].Aliases = out[i].Aliases[1:]
+		}
+	}
+	return out, nil
+}
+
+func appendUnique(values []string, value string) []string {
+	if value == "" || slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
@@ -0,0 +1,53 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_au
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moov-io/base/log"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	files, err := Download(context.Background(), log.NewNopLogger(), "testdata")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	records, err := Read(files)
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+
+	require.Equal(t, Record{
+		Reference:             "1",
+		Name:                  "Abd Al-Baqi Nashwan Abd Al-Razzaq Abd Al-Rahman",
+		Type:                  "Individual",
+		Aliases:               []string{"Abdal Al-Hadi Al-Iraqi", "Abu Abdallah"},
+		DatesOfBirth:          []string{"1961", "circa 1962"},
+		PlacesOfBirth:         []string{"Mosul, Iraq"},
+		Citizenships:          []string{"Iraqi"},
+		AdditionalInformation: "Member of Al-Qaida senior leadership.",
+		ListingInformation:    "Listed on 6 Oct 2001",
+		Committees:            "1267/1989/2253 (ISIL (Da'esh) and Al-Qaida)",
+		ControlDate:           "43512",
+	}, records[0])
+}
+
+func TestParseRows(t *testing.T) {
+	_, err := parseRows(nil)
+	require.ErrorContains(t, err, "missing header row")
+
+	_, err = parseRows([][]string{{"Reference", "Name"}})
+	require.ErrorContains(t, err, `missing "Name of Individual or Entity" column`)
+}
+
+func TestColumnIndex(t *testing.T) {
+	require.Equal(t, 0, columnIndex("A1"))
+	require.Equal(t, 2, columnIndex("C12"))
+	require.Equal(t, 27, columnIndex("AB3"))
+	require.Equal(t, -1, columnIndex(""))
+}
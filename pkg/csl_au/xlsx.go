@@ -0,0 +1,139 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_au
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// readSheet returns the rows of the first worksheet of an XLSX (Office Open XML) workbook. Only shared,
+// inline and plain cell values are read, which is everything the DFAT list uses.
+func readSheet(r io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("opening workbook: %w", err)
+	}
+
+	var strs sharedStrings
+	if err := decodeZipFile(zr, "xl/sharedStrings.xml", &strs); err != nil && !errors.Is(err, errZipFileMissing) {
+		return nil, err
+	}
+	var sheet worksheet
+	if err := decodeZipFile(zr, "xl/worksheets/sheet1.xml", &sheet); err != nil {
+		return nil, err
+	}
+
+	out := make([][]string, 0, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		var values []string
+		for i, cell := range row.Cells {
+			col := i
+			if ref := columnIndex(cell.Ref); ref >= 0 {
+				col = ref
+			}
+			for len(values) <= col {
+				values = append(values, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				idx, err := strconv.Atoi(strings.TrimSpace(cell.Value))
+				if err != nil || idx < 0 || idx >= len(strs.Items) {
+					return nil, fmt.Errorf("cell %s: invalid shared string %q", cell.Ref, cell.Value)
+				}
+				values[col] = strs.Items[idx].String()
+			case "inlineStr":
+				values[col] = cell.Inline.String()
+			default:
+				values[col] = cell.Value
+			}
+		}
+		out = append(out, values)
+	}
+	return out, nil
+}
+
+var errZipFileMissing = errors.New("file missing from workbook")
+
+func decodeZipFile(zr *zip.Reader, name string, v interface{}) error {
+	for _, f := range zr.File {
+		if !strings.EqualFold(f.Name, name) {
+			continue
+		}
+		fd, err := f.Open()
+		if err != nil {
+			return fmt.Errorf("opening %s: %w", name, err)
+		}
+		defer fd.Close()
+
+		if err := xml.NewDecoder(fd).Decode(v); err != nil {
+			return fmt.Errorf("parsing %s: %w", name, err)
+		}
+		return nil
+	}
+	return errZipFileMissing
+}
+
+// columnIndex returns the zero based column of a cell reference such as "C12", or -1
+func columnIndex(ref string) int {
+	col := 0
+	var letters int
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A'+1)
+		letters++
+	}
+	if letters == 0 {
+		return -1
+	}
+	return col - 1
+}
+
+type sharedStrings struct {
+	Items []richText `xml:"si"`
+}
+
+// richText is a cell's text, which is split into runs when it's formatted
+type richText struct {
+	Text string `xml:"t"`
+	Runs []struct {
+		Text string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t richText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var buf strings.Builder
+	for _, run := range t.Runs {
+		buf.WriteString(run.Text)
+	}
+	return buf.String()
+}
+
+type worksheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string   `xml:"r,attr"`
+			Type   string   `xml:"t,attr"`
+			Value  string   `xml:"v"`
+			Inline richText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
@@ -0,0 +1,38 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_ca
+
+import (
+	"encoding/xml"
+)
+
+// ConsolidatedList is Canada's Consolidated Canadian Autonomous Sanctions List of individuals, entities
+// and ships listed under the Special Economic Measures Act (SEMA) and the Justice for Victims of Corrupt
+// Foreign Officials Act.
+//
+// https://www.international.gc.ca/world-monde/international_relations-relations_internationales/sanctions/consolidated-consolide.aspx
+type ConsolidatedList struct {
+	XMLName xml.Name `xml:"data-set"`
+	Records []Record `xml:"record"`
+}
+
+type Record struct {
+	Country       string `xml:"Country" json:"country"`
+	Schedule      string `xml:"Schedule" json:"schedule"`
+	Item          string `xml:"Item" json:"item"`
+	DateOfListing string `xml:"DateOfListing" json:"dateOfListing"`
+
+	LastName  string `xml:"LastName" json:"lastName"`
+	GivenName string `xml:"GivenName" json:"givenName"`
+	Aliases   string `xml:"Aliases" json:"aliases"`
+
+	DateOfBirthOrShipBuildDate string `xml:"DateOfBirthOrShipBuildDate" json:"dateOfBirthOrShipBuildDate"`
+
+	// EntityOrShip is the name of a listed entity or ship, and TitleOrShip is an individual's title or a
+	// ship's name
+	EntityOrShip  string `xml:"EntityOrShip" json:"entityOrShip"`
+	TitleOrShip   string `xml:"TitleOrShip" json:"titleOrShip"`
+	ShipIMONumber string `xml:"ShipIMONumber" json:"shipIMONumber"`
+}
@@ -0,0 +1,29 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_ca
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/strx"
+	"github.com/moov-io/watchman/pkg/download"
+)
+
+var (
+	publicCADownloadURL = "https://www.international.gc.ca/world-monde/assets/office_docs/international_relations-relations_internationales/sanctions/sema-lmes.xml"
+	caDownloadURL       = strx.Or(os.Getenv("CA_CSL_DOWNLOAD_URL"), publicCADownloadURL)
+)
+
+func Download(ctx context.Context, logger log.Logger, initialDir string) (map[string]io.ReadCloser, error) {
+	dl := download.New(logger, download.HTTPClient)
+
+	caNameAndSource := make(map[string]string)
+	caNameAndSource["sema-lmes.xml"] = caDownloadURL
+
+	return dl.GetFiles(ctx, initialDir, caNameAndSource)
+}
@@ -0,0 +1,113 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_ca
+
+import (
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+func ConvertSanctionsData(data *ConsolidatedList) []search.Entity[search.Value] {
+	if data == nil {
+		return nil
+	}
+
+	out := make([]search.Entity[search.Value], 0, len(data.Records))
+	for _, record := range data.Records {
+		out = append(out, ToEntity(record))
+	}
+	return out
+}
+
+// ToEntity converts a record of Canada's Consolidated Autonomous Sanctions List into a search Entity.
+// Items are numbered within each country's schedule, so all three identify a record.
+func ToEntity(src Record) search.Entity[search.Value] {
+	out := search.Entity[search.Value]{
+		Source:     search.SourceCACSL,
+		SourceID:   strings.Join([]string{strings.TrimSpace(src.Country), strings.TrimSpace(src.Schedule), strings.TrimSpace(src.Item)}, "/"),
+		SourceData: src,
+	}
+	altNames := prepare.WithRomanizedNames(splitAliases(src.Aliases))
+
+	switch {
+	case strings.TrimSpace(src.LastName) != "" || strings.TrimSpace(src.GivenName) != "":
+		out.Name = strings.TrimSpace(strings.TrimSpace(src.GivenName) + " " + strings.TrimSpace(src.LastName))
+		out.Type = search.EntityPerson
+		out.Person = &search.Person{
+			Name:     out.Name,
+			AltNames: altNames,
+		}
+		if title := strings.TrimSpace(src.TitleOrShip); title != "" {
+			out.Person.Titles = []string{title}
+		}
+		if dates, ok := parseDate(src.DateOfBirthOrShipBuildDate); ok {
+			out.Person.BirthDates = []search.DateRange{dates}
+			out.Person.BirthDate = &dates.Start
+		}
+
+	case strings.TrimSpace(src.ShipIMONumber) != "":
+		out.Name = firstNonEmpty(src.TitleOrShip, src.EntityOrShip)
+		out.Type = search.EntityVessel
+		out.Vessel = &search.Vessel{
+			Name:      out.Name,
+			AltNames:  altNames,
+			IMONumber: strings.TrimSpace(src.ShipIMONumber),
+		}
+		if dates, ok := parseDate(src.DateOfBirthOrShipBuildDate); ok {
+			out.Vessel.Built = &dates.Start
+		}
+
+	default:
+		out.Name = firstNonEmpty(src.EntityOrShip, src.TitleOrShip)
+		out.Type = search.EntityBusiness
+		out.Business = &search.Business{
+			Name:     out.Name,
+			AltNames: altNames,
+		}
+	}
+
+	// The regime of a record is its country, e.g. "Russia"
+	if country := strings.TrimSpace(src.Country); country != "" {
+		out.SanctionsInfo = &search.SanctionsInfo{
+			Programs: []string{country},
+		}
+	}
+
+	return out
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func splitAliases(aliases string) []string {
+	var out []string
+	for _, alias := range strings.FieldsFunc(aliases, func(r rune) bool { return r == ';' || r == ',' }) {
+		if alias = strings.TrimSpace(alias); alias != "" {
+			out = append(out, alias)
+		}
+	}
+	return out
+}
+
+var dateLayouts = []string{"2006-01-02", "02-01-2006", "2006"}
+
+func parseDate(value string) (search.DateRange, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return search.DateRangeFor(t, layout), true
+		}
+	}
+	return search.DateRange{}, false
+}
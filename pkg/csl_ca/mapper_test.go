@@ -0,0 +1,47 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_ca
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertSanctionsData(t *testing.T) {
+	files, err := Download(context.Background(), log.NewNopLogger(), "testdata")
+	require.NoError(t, err)
+
+	data, err := Read(files)
+	require.NoError(t, err)
+
+	entities := ConvertSanctionsData(data)
+	require.Len(t, entities, 3)
+
+	person := entities[0]
+	require.Equal(t, search.SourceCACSL, person.Source)
+	require.Equal(t, "Belarus/1, Part 1/1", person.SourceID)
+	require.Equal(t, search.EntityPerson, person.Type)
+	require.Equal(t, "Aleksandr Grigorievich Lukashenko", person.Person.Name)
+	require.Equal(t, []string{"Aliaksandr Ryhoravich LUKASHENKA", "Alexander LUKASHENKO"}, person.Person.AltNames)
+	require.Equal(t, []string{"President"}, person.Person.Titles)
+	require.Equal(t, time.Date(1954, time.August, 30, 0, 0, 0, 0, time.UTC), *person.Person.BirthDate)
+	require.Equal(t, []string{"Belarus"}, person.SanctionsInfo.Programs)
+
+	business := entities[1]
+	require.Equal(t, search.EntityBusiness, business.Type)
+	require.Equal(t, "Joint Stock Company Concern Avtomatika", business.Business.Name)
+
+	vessel := entities[2]
+	require.Equal(t, search.EntityVessel, vessel.Type)
+	require.Equal(t, "SCF PRIMORYE", vessel.Vessel.Name)
+	require.Equal(t, "9305568", vessel.Vessel.IMONumber)
+	require.Equal(t, 2005, vessel.Vessel.Built.Year())
+}
@@ -0,0 +1,36 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_ca
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moov-io/base/log"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	files, err := Download(context.Background(), log.NewNopLogger(), "testdata")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	data, err := Read(files)
+	require.NoError(t, err)
+	require.Len(t, data.Records, 3)
+
+	require.Equal(t, Record{
+		Country:                    "Belarus",
+		Schedule:                   "1, Part 1",
+		Item:                       "1",
+		DateOfListing:              "2020-09-29",
+		LastName:                   "Lukashenko",
+		GivenName:                  "Aleksandr Grigorievich",
+		Aliases:                    "Aliaksandr Ryhoravich LUKASHENKA; Alexander LUKASHENKO",
+		DateOfBirthOrShipBuildDate: "1954-08-30",
+		TitleOrShip:                "President",
+	}, data.Records[0])
+}
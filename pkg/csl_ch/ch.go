@@ -0,0 +1,144 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_ch
+
+import (
+	"encoding/xml"
+)
+
+// SanctionsList is the State Secretariat for Economic Affairs (SECO) list of individuals, entities and
+// objects subject to Swiss sanctions.
+//
+// https://www.seco.admin.ch/seco/en/home/Aussenwirtschaftspolitik_Wirtschaftliche_Zusammenarbeit/Wirtschaftsbeziehungen/exportkontrollen-und-sanktionen/sanktionen-embargos.html
+type SanctionsList struct {
+	XMLName  xml.Name  `xml:"swiss-sanctions-list"`
+	Date     string    `xml:"date,attr"`
+	Programs []Program `xml:"sanctions-program"`
+	Places   []Place   `xml:"place"`
+	Targets  []Target  `xml:"target"`
+}
+
+type Program struct {
+	SSID string `xml:"ssid,attr"`
+
+	Names []LangValue    `xml:"program-name"`
+	Keys  []LangValue    `xml:"program-key"`
+	Sets  []SanctionsSet `xml:"sanctions-set"`
+}
+
+type SanctionsSet struct {
+	SSID  string `xml:"ssid,attr"`
+	Lang  string `xml:"lang,attr"`
+	Value string `xml:",chardata"`
+}
+
+type LangValue struct {
+	Lang  string `xml:"lang,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Place is a location targets refer to for their addresses and places of birth
+type Place struct {
+	SSID     string  `xml:"ssid,attr"`
+	Location string  `xml:"location"`
+	Area     string  `xml:"area"`
+	Country  Country `xml:"country"`
+}
+
+type Country struct {
+	ISOCode string `xml:"iso-code,attr"`
+	Name    string `xml:",chardata"`
+}
+
+type Target struct {
+	SSID           string `xml:"ssid,attr"`
+	SanctionsSetID string `xml:"sanctions-set-id,attr"`
+
+	Individual *Individual `xml:"individual"`
+	Entity     *Party      `xml:"entity"`
+	Object     *Object     `xml:"object"`
+
+	Modifications []Modification `xml:"modification"`
+}
+
+type Individual struct {
+	Sex string `xml:"sex,attr"`
+	Party
+}
+
+// Party holds what individuals, entities and objects have in common
+type Party struct {
+	Identities       []Identity `xml:"identity"`
+	Justifications   []string   `xml:"justification"`
+	Relations        []Relation `xml:"relation"`
+	OtherInformation []string   `xml:"other-information"`
+}
+
+type Object struct {
+	ObjectType string `xml:"object-type,attr"` // e.g. vessel or aircraft
+	Party
+}
+
+type Identity struct {
+	SSID string `xml:"ssid,attr"`
+	Main bool   `xml:"main,attr"`
+
+	Names         []Name                   `xml:"name"`
+	DatesOfBirth  []DayMonthYear           `xml:"day-month-year"`
+	PlacesOfBirth []PlaceRef               `xml:"place-of-birth"`
+	Nationalities []Nationality            `xml:"nationality"`
+	Addresses     []Address                `xml:"address"`
+	Documents     []IdentificationDocument `xml:"identification-document"`
+}
+
+type Name struct {
+	SSID     string     `xml:"ssid,attr"`
+	NameType string     `xml:"name-type,attr"` // primary-name, alias, formerly-known-as, etc
+	Quality  string     `xml:"quality,attr"`
+	Parts    []NamePart `xml:"name-part"`
+}
+
+type NamePart struct {
+	Order    int    `xml:"order,attr"`
+	PartType string `xml:"name-part-type,attr"` // given-name, further-given-name, father-name, family-name, whole-name, etc
+	Value    string `xml:"value"`
+}
+
+type DayMonthYear struct {
+	Day   int `xml:"day,attr"`
+	Month int `xml:"month,attr"`
+	Year  int `xml:"year,attr"`
+}
+
+type PlaceRef struct {
+	PlaceID string `xml:"place-id,attr"`
+}
+
+type Nationality struct {
+	Country Country `xml:"country"`
+}
+
+type Address struct {
+	PlaceID string `xml:"place-id,attr"`
+	Details string `xml:"address-details"`
+	ZipCode string `xml:"zip-code"`
+	POBox   string `xml:"p-o-box"`
+}
+
+type IdentificationDocument struct {
+	DocumentType string  `xml:"document-type,attr"` // passport, id-card, etc
+	Number       string  `xml:"number"`
+	Issuer       Country `xml:"issuer"`
+}
+
+type Relation struct {
+	TargetID     string `xml:"target-id,attr"`
+	RelationType string `xml:"relation-type,attr"`
+}
+
+type Modification struct {
+	ModificationType string `xml:"modification-type,attr"` // listed, amended, de-listed
+	EffectiveDate    string `xml:"effective-date,attr"`
+}
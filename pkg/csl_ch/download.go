@@ -0,0 +1,29 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_ch
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/strx"
+	"github.com/moov-io/watchman/pkg/download"
+)
+
+var (
+	publicCHDownloadURL = "https://www.sesam.search.admin.ch/sesam-search-web/pages/downloadXmlGesamtliste.xhtml?lang=en&action=downloadXmlGesamtlisteAction"
+	chDownloadURL       = strx.Or(os.Getenv("CH_CSL_DOWNLOAD_URL"), publicCHDownloadURL)
+)
+
+func Download(ctx context.Context, logger log.Logger, initialDir string) (map[string]io.ReadCloser, error) {
+	dl := download.New(logger, download.HTTPClient)
+
+	chNameAndSource := make(map[string]string)
+	chNameAndSource["consolidated-list.xml"] = chDownloadURL
+
+	return dl.GetFiles(ctx, initialDir, chNameAndSource)
+}
@@ -0,0 +1,313 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_ch
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+func ConvertSanctionsData(data *SanctionsList) []search.Entity[search.Value] {
+	if data == nil {
+		return nil
+	}
+
+	places := make(map[string]Place, len(data.Places))
+	for _, place := range data.Places {
+		places[place.SSID] = place
+	}
+	programs := make(map[string]string)
+	for _, program := range data.Programs {
+		key := englishValue(program.Keys)
+		for _, set := range program.Sets {
+			programs[set.SSID] = key
+		}
+	}
+
+	out := make([]search.Entity[search.Value], 0, len(data.Targets))
+	for _, target := range data.Targets {
+		if delisted(target) {
+			continue
+		}
+		entity, ok := ToEntity(target, places, programs)
+		if ok {
+			out = append(out, entity)
+		}
+	}
+	return out
+}
+
+// ToEntity converts a SECO target into a search Entity. Targets refer to places and sanctions programs
+// by their ssid, which are read from the top of the list.
+func ToEntity(src Target, places map[string]Place, programs map[string]string) (search.Entity[search.Value], bool) {
+	out := search.Entity[search.Value]{
+		Source:     search.SourceCHCSL,
+		SourceID:   strings.TrimSpace(src.SSID),
+		SourceData: src,
+	}
+
+	var party Party
+	switch {
+	case src.Individual != nil:
+		party = src.Individual.Party
+		out.Type = search.EntityPerson
+	case src.Entity != nil:
+		party = *src.Entity
+		out.Type = search.EntityBusiness
+	case src.Object != nil:
+		party = src.Object.Party
+		switch strings.ToLower(strings.TrimSpace(src.Object.ObjectType)) {
+		case "vessel":
+			out.Type = search.EntityVessel
+		case "aircraft":
+			out.Type = search.EntityAircraft
+		default:
+			return out, false
+		}
+	default:
+		return out, false
+	}
+
+	names := mapNames(party.Identities)
+	if len(names) == 0 {
+		return out, false
+	}
+	out.Name = names[0]
+	altNames := prepare.WithRomanizedNames(names[1:])
+
+	identities := party.Identities
+	out.Addresses = mapAddresses(identities, places)
+
+	switch out.Type {
+	case search.EntityPerson:
+		out.Person = &search.Person{
+			Name:          out.Name,
+			AltNames:      altNames,
+			Gender:        mapGender(src.Individual.Sex),
+			Nationalities: mapNationalities(identities),
+			GovernmentIDs: mapDocuments(identities),
+		}
+		for _, identity := range identities {
+			for _, dob := range identity.DatesOfBirth {
+				if dates, ok := dob.dateRange(); ok {
+					out.Person.BirthDates = append(out.Person.BirthDates, dates)
+				}
+			}
+			for _, pob := range identity.PlacesOfBirth {
+				if out.Person.PlaceOfBirth == "" {
+					out.Person.PlaceOfBirth = places[pob.PlaceID].format()
+				}
+			}
+		}
+		if len(out.Person.BirthDates) > 0 {
+			out.Person.BirthDate = &out.Person.BirthDates[0].Start
+		}
+
+	case search.EntityBusiness:
+		out.Business = &search.Business{
+			Name:          out.Name,
+			AltNames:      altNames,
+			GovernmentIDs: mapDocuments(identities),
+		}
+
+	case search.EntityVessel:
+		out.Vessel = &search.Vessel{
+			Name:     out.Name,
+			AltNames: altNames,
+		}
+		for _, doc := range identityDocuments(identities) {
+			if strings.Contains(strings.ToLower(doc.DocumentType), "imo") {
+				out.Vessel.IMONumber = strings.TrimSpace(doc.Number)
+			}
+		}
+
+	case search.EntityAircraft:
+		out.Aircraft = &search.Aircraft{
+			Name:     out.Name,
+			AltNames: altNames,
+		}
+	}
+
+	out.SanctionsInfo = &search.SanctionsInfo{
+		Description: strings.TrimSpace(strings.Join(party.Justifications, " ")),
+	}
+	if program := programs[src.SanctionsSetID]; program != "" {
+		out.SanctionsInfo.Programs = []string{program}
+	}
+
+	return out, true
+}
+
+// delisted returns true when the latest modification of a target removed it from the list
+func delisted(target Target) bool {
+	if len(target.Modifications) == 0 {
+		return false
+	}
+	return strings.EqualFold(target.Modifications[len(target.Modifications)-1].ModificationType, "de-listed")
+}
+
+// mapNames returns the primary name of the main identity first, followed by every other name
+func mapNames(identities []Identity) []string {
+	var out []string
+	add := func(name string) {
+		if name != "" && !slices.ContainsFunc(out, func(n string) bool { return strings.EqualFold(n, name) }) {
+			out = append(out, name)
+		}
+	}
+
+	for _, primary := range []bool{true, false} {
+		for _, identity := range identities {
+			for _, name := range identity.Names {
+				isPrimary := identity.Main && name.NameType == "primary-name"
+				if isPrimary == primary {
+					add(name.format())
+				}
+			}
+		}
+	}
+	return out
+}
+
+// format joins the parts of a name in their listed order
+func (n Name) format() string {
+	parts := slices.Clone(n.Parts)
+	slices.SortStableFunc(parts, func(a, b NamePart) int {
+		return cmp.Compare(a.Order, b.Order)
+	})
+
+	var values []string
+	for _, part := range parts {
+		if v := strings.TrimSpace(part.Value); v != "" {
+			values = append(values, v)
+		}
+	}
+	return strings.Join(values, " ")
+}
+
+func mapGender(sex string) search.Gender {
+	switch strings.ToLower(strings.TrimSpace(sex)) {
+	case "male":
+		return search.GenderMale
+	case "female":
+		return search.GenderFemale
+	}
+	return search.GenderUnknown
+}
+
+func mapNationalities(identities []Identity) []string {
+	var out []string
+	for _, identity := range identities {
+		for _, nationality := range identity.Nationalities {
+			country := cmp.Or(strings.TrimSpace(nationality.Country.ISOCode), strings.TrimSpace(nationality.Country.Name))
+			if country != "" && !slices.Contains(out, country) {
+				out = append(out, country)
+			}
+		}
+	}
+	return out
+}
+
+func identityDocuments(identities []Identity) []IdentificationDocument {
+	var out []IdentificationDocument
+	for _, identity := range identities {
+		out = append(out, identity.Documents...)
+	}
+	return out
+}
+
+func mapDocuments(identities []Identity) []search.GovernmentID {
+	var out []search.GovernmentID
+	for _, doc := range identityDocuments(identities) {
+		number := strings.TrimSpace(doc.Number)
+		if number == "" {
+			continue
+		}
+		out = append(out, search.GovernmentID{
+			Type:       mapDocumentType(doc.DocumentType),
+			Country:    cmp.Or(strings.TrimSpace(doc.Issuer.ISOCode), strings.TrimSpace(doc.Issuer.Name)),
+			Identifier: number,
+		})
+	}
+	return out
+}
+
+func mapDocumentType(documentType string) search.GovernmentIDType {
+	switch strings.ToLower(strings.TrimSpace(documentType)) {
+	case "passport":
+		return search.GovernmentIDPassport
+	case "diplomatic-passport":
+		return search.GovernmentIDDiplomaticPass
+	case "id-card":
+		return search.GovernmentIDNational
+	case "driving-license":
+		return search.GovernmentIDDriversLicense
+	case "tax-id":
+		return search.GovernmentIDTax
+	case "registration-number":
+		return search.GovernmentIDBusinessRegisration
+	}
+	return search.GovernmentIDPersonalID
+}
+
+func mapAddresses(identities []Identity, places map[string]Place) []search.Address {
+	var out []search.Address
+	for _, identity := range identities {
+		for _, addr := range identity.Addresses {
+			place := places[addr.PlaceID]
+			address := search.Address{
+				Line1:      strings.TrimSpace(addr.Details),
+				Line2:      strings.TrimSpace(addr.POBox),
+				City:       strings.TrimSpace(place.Location),
+				PostalCode: strings.TrimSpace(addr.ZipCode),
+				State:      strings.TrimSpace(place.Area),
+				Country:    cmp.Or(strings.TrimSpace(place.Country.ISOCode), strings.TrimSpace(place.Country.Name)),
+			}
+			if address != (search.Address{}) {
+				out = append(out, address)
+			}
+		}
+	}
+	return out
+}
+
+func (p Place) format() string {
+	var parts []string
+	for _, v := range []string{p.Location, p.Area, p.Country.Name} {
+		if v = strings.TrimSpace(v); v != "" {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// dateRange returns the period of a date of birth, which can be missing its day or month
+func (d DayMonthYear) dateRange() (search.DateRange, bool) {
+	switch {
+	case d.Year == 0:
+		return search.DateRange{}, false
+	case d.Month == 0:
+		return search.DateRangeFor(time.Date(d.Year, time.January, 1, 0, 0, 0, 0, time.UTC), "2006"), true
+	case d.Day == 0:
+		return search.DateRangeFor(time.Date(d.Year, time.Month(d.Month), 1, 0, 0, 0, 0, time.UTC), "2006-01"), true
+	}
+	return search.DateRangeFor(time.Date(d.Year, time.Month(d.Month), d.Day, 0, 0, 0, 0, time.UTC), "2006-01-02"), true
+}
+
+func englishValue(values []LangValue) string {
+	for _, v := range values {
+		if strings.EqualFold(v.Lang, "eng") {
+			return strings.TrimSpace(v.Value)
+		}
+	}
+	if len(values) > 0 {
+		return strings.TrimSpace(values[0].Value)
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_ch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertSanctionsData(t *testing.T) {
+	files, err := Download(context.Background(), log.NewNopLogger(), "testdata")
+	require.NoError(t, err)
+
+	data, err := Read(files)
+	require.NoError(t, err)
+
+	// The de-listed target is skipped
+	entities := ConvertSanctionsData(data)
+	require.Len(t, entities, 3)
+
+	person := entities[0]
+	require.Equal(t, search.SourceCHCSL, person.Source)
+	require.Equal(t, "40001", person.SourceID)
+	require.Equal(t, search.EntityPerson, person.Type)
+	require.Equal(t, "Sergei Borisovich Ivanov", person.Name)
+	require.Equal(t, []string{"Sergey Ivanov"}, person.Person.AltNames)
+	require.Equal(t, search.GenderMale, person.Person.Gender)
+	require.Equal(t, time.Date(1953, time.January, 31, 0, 0, 0, 0, time.UTC), *person.Person.BirthDate)
+	require.Equal(t, "Leningrad, Leningrad Oblast, Russian Federation", person.Person.PlaceOfBirth)
+	require.Equal(t, []string{"RU"}, person.Person.Nationalities)
+	require.Equal(t, []search.GovernmentID{
+		{Type: search.GovernmentIDPassport, Country: "RU", Identifier: "100123456"},
+	}, person.Person.GovernmentIDs)
+	require.Equal(t, []search.Address{
+		{Line1: "Staraya Square 4", City: "Moscow", PostalCode: "103132", Country: "RU"},
+	}, person.Addresses)
+	require.Equal(t, []string{"Ukraine"}, person.SanctionsInfo.Programs)
+	require.Contains(t, person.SanctionsInfo.Description, "Special Presidential Representative")
+
+	business := entities[1]
+	require.Equal(t, search.EntityBusiness, business.Type)
+	require.Equal(t, "Korea Mining Development Trading Corporation", business.Business.Name)
+	require.Equal(t, []string{"KOMID"}, business.Business.AltNames)
+	require.Equal(t, "KP", business.Addresses[0].Country)
+	require.Equal(t, []string{"North Korea"}, business.SanctionsInfo.Programs)
+
+	vessel := entities[2]
+	require.Equal(t, search.EntityVessel, vessel.Type)
+	require.Equal(t, "CHON MA SAN", vessel.Vessel.Name)
+	require.Equal(t, "8660313", vessel.Vessel.IMONumber)
+}
+
+func TestDayMonthYear(t *testing.T) {
+	_, ok := DayMonthYear{}.dateRange()
+	require.False(t, ok)
+
+	dates, ok := DayMonthYear{Year: 1970}.dateRange()
+	require.True(t, ok)
+	require.Equal(t, time.Date(1970, time.December, 31, 0, 0, 0, 0, time.UTC), dates.End)
+
+	dates, ok = DayMonthYear{Month: 2, Year: 1970}.dateRange()
+	require.True(t, ok)
+	require.Equal(t, time.Date(1970, time.February, 28, 0, 0, 0, 0, time.UTC), dates.End)
+}
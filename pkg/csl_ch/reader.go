@@ -0,0 +1,39 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_ch
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func Read(files map[string]io.ReadCloser) (*SanctionsList, error) {
+	for filename, contents := range files {
+		switch strings.ToLower(filename) {
+		case "consolidated-list.xml":
+			return parseXML(filename, contents)
+		default:
+			return nil, fmt.Errorf("unknown file %s", filename)
+		}
+	}
+	return nil, errors.New("no files provided")
+}
+
+func parseXML(filename string, contents io.ReadCloser) (*SanctionsList, error) {
+	if contents == nil {
+		return nil, fmt.Errorf("%s is empty or missing", filename)
+	}
+	defer contents.Close()
+
+	var doc SanctionsList
+	err := xml.NewDecoder(contents).Decode(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	return &doc, nil
+}
@@ -0,0 +1,42 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_ch
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moov-io/base/log"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	files, err := Download(context.Background(), log.NewNopLogger(), "testdata")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	data, err := Read(files)
+	require.NoError(t, err)
+	require.Len(t, data.Programs, 2)
+	require.Len(t, data.Places, 3)
+	require.Len(t, data.Targets, 4)
+
+	target := data.Targets[0]
+	require.Equal(t, "40001", target.SSID)
+	require.Equal(t, "11", target.SanctionsSetID)
+	require.NotNil(t, target.Individual)
+	require.Equal(t, "male", target.Individual.Sex)
+
+	identity := target.Individual.Identities[0]
+	require.True(t, identity.Main)
+	require.Len(t, identity.Names, 2)
+	require.Equal(t, DayMonthYear{Day: 31, Month: 1, Year: 1953}, identity.DatesOfBirth[0])
+	require.Equal(t, Address{PlaceID: "101", Details: "Staraya Square 4", ZipCode: "103132"}, identity.Addresses[0])
+	require.Equal(t, "passport", identity.Documents[0].DocumentType)
+
+	require.NotNil(t, data.Targets[2].Object)
+	require.Equal(t, "vessel", data.Targets[2].Object.ObjectType)
+}
@@ -10,35 +10,61 @@ package csl_eu
 type CSL map[int]*CSLRecord
 
 type CSLRecord struct {
-	FileGenerationDate         string            `json:"fileGenerationDate"`
-	EntityLogicalID            int               `json:"entityLogicalId"`
-	EntityRemark               string            `json:"entityRemark"`
-	EntitySubjectType          string            `json:"entitySubjectType"`
-	EntityPublicationURL       string            `json:"entityPublicationURL"`
-	EntityReferenceNumber      string            `json:"entityReferenceNumber"`
-	NameAliasWholeNames        []string          `json:"nameAliasWholeNames"`
-	NameAliasTitles            []string          `json:"nameAliasTitles"`
-	AddressCities              []string          `json:"addressCities"`
-	AddressStreets             []string          `json:"addressStreets"`
-	AddressPoBoxes             []string          `json:"addressPoBoxs"`
-	AddressZipCodes            []string          `json:"addressZipCodes"`
-	AddressCountryDescriptions []string          `json:"addressCountryDescriptions"`
-	BirthDates                 []string          `json:"birthDates"`
-	BirthCities                []string          `json:"birthCities"`
-	BirthCountries             []string          `json:"birthCountries"`
-	ValidFromTo                map[string]string `json:"validFromTo"`
+	FileGenerationDate         string             `json:"fileGenerationDate"`
+	EntityLogicalID            int                `json:"entityLogicalId"`
+	EntityRemark               string             `json:"entityRemark"`
+	EntitySubjectType          string             `json:"entitySubjectType"`
+	EntityPublicationURL       string             `json:"entityPublicationURL"`
+	EntityReferenceNumber      string             `json:"entityReferenceNumber"`
+	EntityUnitedNationsID      string             `json:"entityUnitedNationsId,omitempty"`
+	EntityDesignationDate      string             `json:"entityDesignationDate,omitempty"`
+	EntityRegulations          []RegulationRecord `json:"entityRegulations,omitempty"`
+	NameAliasWholeNames        []string           `json:"nameAliasWholeNames"`
+	NameAliasTitles            []string           `json:"nameAliasTitles"`
+	NameAliasGenders           []string           `json:"nameAliasGenders,omitempty"`
+	Addresses                  []Address          `json:"addresses,omitempty"`
+	AddressCities              []string           `json:"addressCities"`
+	AddressStreets             []string           `json:"addressStreets"`
+	AddressPoBoxes             []string           `json:"addressPoBoxs"`
+	AddressZipCodes            []string           `json:"addressZipCodes"`
+	AddressCountryDescriptions []string           `json:"addressCountryDescriptions"`
+	BirthDates                 []string           `json:"birthDates"`
+	BirthCities                []string           `json:"birthCities"`
+	BirthCountries             []string           `json:"birthCountries"`
+	BirthDateDetails           []BirthDate        `json:"birthDateDetails,omitempty"`
+	Citizenships               []string           `json:"citizenships,omitempty"`
+	Identifications            []Identification   `json:"identifications"`
+	ValidFromTo                map[string]string  `json:"validFromTo"`
+}
+
+// RegulationRecord is an EU legal act listing or amending an entity, which is cited when reporting a match
+type RegulationRecord struct {
+	Type               string `json:"type"`
+	PublicationDate    string `json:"publicationDate"`
+	EntryIntoForceDate string `json:"entryIntoForceDate"`
+	NumberTitle        string `json:"numberTitle"` // e.g. 1210/2003 (OJ L169)
+	Programme          string `json:"programme"`   // e.g. IRQ
+	PublicationURL     string `json:"publicationURL"`
 }
 
 // header indicies
 const (
-	FileGenerationDateIdx             = 0
-	EntityLogicalIdx                  = 1
-	ReferenceNumberIdx                = 2
-	EntityRemarkIdx                   = 6
-	EntitySubjectTypeIdx              = 8
-	EntityRegulationPublicationURLIdx = 15
+	FileGenerationDateIdx                 = 0
+	EntityLogicalIdx                      = 1
+	ReferenceNumberIdx                    = 2
+	EntityUnitedNationsIdx                = 3
+	EntityDesignationDateIdx              = 4
+	EntityRemarkIdx                       = 6
+	EntitySubjectTypeIdx                  = 8
+	EntityRegulationTypeIdx               = 9
+	EntityRegulationPublicationDateIdx    = 11
+	EntityRegulationEntryIntoForceDateIdx = 12
+	EntityRegulationNumberTitleIdx        = 13
+	EntityRegulationProgrammeIdx          = 14
+	EntityRegulationPublicationURLIdx     = 15
 
 	NameAliasWholeNameIdx = 19
+	NameAliasGenderIdx    = 21
 	NameAliasTitleIdx     = 22
 
 	AddressCityIdx               = 34
@@ -47,12 +73,23 @@ const (
 	AddressZipCodeIdx            = 37
 	AddressCountryDescriptionIdx = 43
 
-	BirthDateIdx        = 54
-	BirthDateCityIdx    = 65
-	BirthDateCountryIdx = 67
+	BirthDateIdx              = 54
+	BirthDateYearIdx          = 57
+	BirthDateYearRangeFromIdx = 58
+	BirthDateYearRangeToIdx   = 59
+	BirthDateCircaIdx         = 60
+	BirthDateCityIdx          = 65
+	BirthDateCountryIdx       = 67
 
-	IdentificationValidFromIdx = 86
-	IdentificationValidToIdx   = 87
+	IdentificationNumberIdx          = 78
+	IdentificationValidFromIdx       = 86
+	IdentificationValidToIdx         = 87
+	IdentificationTypeCodeIdx        = 90
+	IdentificationTypeDescriptionIdx = 91
+	IdentificationCountryIso2CodeIdx = 93
+
+	CitizenshipCountryIso2CodeIdx    = 106
+	CitizenshipCountryDescriptionIdx = 107
 )
 
 // below is the original struct used to parse the document
@@ -123,13 +160,13 @@ type Address struct { // addresses
 	// Regulation         *Regulation
 }
 type BirthDate struct {
-	BirthDate string // keep
+	BirthDate string `json:"birthDate"`
 	// Day                int64
 	// Month              int64
-	// Year               int64
-	// YearRangeFrom      string
-	// YearRangeTo        string
-	// Circa              string
+	Year          string `json:"year"`
+	YearRangeFrom string `json:"yearRangeFrom"`
+	YearRangeTo   string `json:"yearRangeTo"`
+	Circa         bool   `json:"circa"`
 	// CaldendarType      string
 	// ZipCode            string
 	// Region             string
@@ -143,7 +180,7 @@ type BirthDate struct {
 
 type Identification struct {
 	// Regulation         *Regulation
-	// Number             int64
+	Number string `json:"number"`
 	// KnownExpired       bool
 	// KnownFalse         bool
 	// ReportedLost       bool
@@ -155,10 +192,10 @@ type Identification struct {
 	ValidFrom string `json:"validFrom"`
 	ValidTo   string `json:"validTo"`
 	// NameOnDocument     string
-	// TypeCode           string
-	// TypeDescription    string
+	TypeCode        string `json:"typeCode"`
+	TypeDescription string `json:"typeDescription"`
 	// Region             string
-	// CountryIso2code    string
+	CountryIso2Code string `json:"countryIso2Code"`
 	// CountryDescription string
 	// RegulationLanguage string
 	// Remark             string
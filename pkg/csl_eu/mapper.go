@@ -5,6 +5,8 @@
 package csl_eu
 
 import (
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -18,20 +20,57 @@ func PtrToEntity(record *CSLRecord) search.Entity[CSLRecord] {
 	return search.Entity[CSLRecord]{}
 }
 
+// ConvertSanctionsData converts the EU CSL records into search Entities
+func ConvertSanctionsData(records []CSLRecord) []search.Entity[search.Value] {
+	out := make([]search.Entity[search.Value], 0, len(records))
+	for _, record := range records {
+		entity := ToEntity(record)
+		out = append(out, search.Entity[search.Value]{
+			Name:          entity.Name,
+			Type:          entity.Type,
+			Source:        entity.Source,
+			SourceID:      entity.SourceID,
+			Person:        entity.Person,
+			Business:      entity.Business,
+			Addresses:     entity.Addresses,
+			SanctionsInfo: entity.SanctionsInfo,
+			SourceData:    record,
+		})
+	}
+	return out
+}
+
 func ToEntity(record CSLRecord) search.Entity[CSLRecord] {
 	out := search.Entity[CSLRecord]{
 		Source:     search.SourceEUCSL,
+		SourceID:   strconv.Itoa(record.EntityLogicalID),
 		SourceData: record,
 	}
 
-	if strings.EqualFold(record.EntitySubjectType, "person") {
-		out.Type = search.EntityPerson
-		out.Person = &search.Person{}
+	var name string
+	var altNames []string
+	if len(record.NameAliasWholeNames) > 0 {
+		name, altNames = record.NameAliasWholeNames[0], record.NameAliasWholeNames[1:]
+	}
+	out.Name = name
+
+	var governmentIDs []search.GovernmentID
+	for _, id := range record.Identifications {
+		if gid := mapIdentification(id); gid != nil {
+			governmentIDs = append(governmentIDs, *gid)
+		}
+	}
 
-		if len(record.NameAliasWholeNames) > 0 {
-			out.Name = record.NameAliasWholeNames[0]
-			out.Person.Name = record.NameAliasWholeNames[0]
-			out.Person.AltNames = record.NameAliasWholeNames[1:]
+	switch strings.ToLower(record.EntitySubjectType) {
+	case "person":
+		out.Type = search.EntityPerson
+		out.Person = &search.Person{
+			Name:          name,
+			AltNames:      altNames,
+			Gender:        mapGender(record.NameAliasGenders),
+			Titles:        record.NameAliasTitles,
+			Nationalities: record.Citizenships,
+			GovernmentIDs: governmentIDs,
 		}
 		if len(record.BirthDates) > 0 {
 			tt, err := time.Parse("2006-01-02", record.BirthDates[0])
@@ -39,7 +78,183 @@ func ToEntity(record CSLRecord) search.Entity[CSLRecord] {
 				out.Person.BirthDate = &tt
 			}
 		}
+		for _, birth := range record.BirthDateDetails {
+			if rng, ok := mapBirthDate(birth); ok {
+				out.Person.BirthDates = append(out.Person.BirthDates, rng)
+			}
+			if out.Person.PlaceOfBirth == "" {
+				out.Person.PlaceOfBirth = mapPlaceOfBirth(birth)
+			}
+		}
+
+	case "enterprise":
+		out.Type = search.EntityBusiness
+		out.Business = &search.Business{
+			Name:          name,
+			AltNames:      altNames,
+			GovernmentIDs: governmentIDs,
+		}
+	}
+
+	out.Addresses = mapAddresses(record.Addresses)
+	out.SanctionsInfo = mapSanctionsInfo(record)
+
+	return out
+}
+
+func mapGender(genders []string) search.Gender {
+	for _, g := range genders {
+		switch strings.ToUpper(strings.TrimSpace(g)) {
+		case "M":
+			return search.GenderMale
+		case "F":
+			return search.GenderFemale
+		}
+	}
+	return ""
+}
+
+// mapBirthDate reads a full date, a year or a range of years. Approximate (circa) dates are widened by a
+// year on each side.
+func mapBirthDate(birth BirthDate) (search.DateRange, bool) {
+	var out search.DateRange
+	switch {
+	case birth.BirthDate != "":
+		tt, err := time.Parse("2006-01-02", birth.BirthDate)
+		if err != nil {
+			return out, false
+		}
+		out = search.DateRangeFor(tt, "2006-01-02")
+
+	case birth.Year != "":
+		tt, err := time.Parse("2006", birth.Year)
+		if err != nil {
+			return out, false
+		}
+		out = search.DateRangeFor(tt, "2006")
+
+	case birth.YearRangeFrom != "":
+		from, err := time.Parse("2006", birth.YearRangeFrom)
+		if err != nil {
+			return out, false
+		}
+		out = search.DateRangeFor(from, "2006")
+		if to, err := time.Parse("2006", birth.YearRangeTo); err == nil && to.After(from) {
+			out.End = search.DateRangeFor(to, "2006").End
+		}
+
+	default:
+		return out, false
 	}
 
+	if birth.Circa {
+		out.Start = out.Start.AddDate(-1, 0, 0)
+		out.End = out.End.AddDate(1, 0, 0)
+	}
+	return out, true
+}
+
+func mapPlaceOfBirth(birth BirthDate) string {
+	var parts []string
+	for _, v := range []string{birth.City, birth.CountryDescription} {
+		if v = strings.TrimSpace(v); v != "" && !strings.EqualFold(v, "UNKNOWN") {
+			parts = append(parts, v)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func mapAddresses(addresses []Address) []search.Address {
+	var out []search.Address
+	for _, addr := range addresses {
+		line1 := strings.TrimSpace(addr.Street)
+		if addr.PoBox != "" {
+			line1 = strings.TrimSpace(line1 + " PO Box " + addr.PoBox)
+		}
+		a := search.Address{
+			Line1:      line1,
+			City:       strings.TrimSpace(addr.City),
+			PostalCode: strings.TrimSpace(addr.ZipCode),
+		}
+		if country := strings.TrimSpace(addr.CountryDescription); !strings.EqualFold(country, "UNKNOWN") {
+			a.Country = country
+		}
+		if a.Line1 == "" && a.City == "" && a.PostalCode == "" && a.Country == "" {
+			continue
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+// mapSanctionsInfo returns the programmes and regulations an entity is listed under, which EU-regulated
+// users cite when reporting a match.
+func mapSanctionsInfo(record CSLRecord) *search.SanctionsInfo {
+	info := &search.SanctionsInfo{
+		Description: strings.TrimSpace(record.EntityRemark),
+	}
+	for _, regulation := range record.EntityRegulations {
+		programme := strings.TrimSpace(regulation.Programme)
+		if programme != "" && !slices.Contains(info.Programs, programme) {
+			info.Programs = append(info.Programs, programme)
+		}
+		// A programme took effect for the entity with the first regulation listing them under it
+		if tt, err := time.Parse("2006-01-02", strings.TrimSpace(regulation.EntryIntoForceDate)); err == nil && programme != "" {
+			if info.ProgramDates == nil {
+				info.ProgramDates = make(map[string]time.Time)
+			}
+			if since, exists := info.ProgramDates[programme]; !exists || tt.Before(since) {
+				info.ProgramDates[programme] = tt
+			}
+		}
+		if number := strings.TrimSpace(regulation.NumberTitle); number != "" && !slices.Contains(info.Regulations, number) {
+			info.Regulations = append(info.Regulations, number)
+		}
+	}
+	if info.Description == "" && len(info.Programs) == 0 && len(info.Regulations) == 0 {
+		return nil
+	}
+	return info
+}
+
+func mapIdentification(id Identification) *search.GovernmentID {
+	// Numbers can include their type and issue dates, e.g. "34409/129 (other-Other identification number) (july 1997)"
+	number, _, _ := strings.Cut(id.Number, " (")
+	number = strings.TrimSpace(number)
+	if number == "" {
+		return nil
+	}
+
+	out := &search.GovernmentID{
+		Identifier: number,
+	}
+	if country := strings.TrimSpace(id.CountryIso2Code); country != "00" {
+		out.Country = country
+	}
+
+	switch strings.ToLower(id.TypeCode) {
+	case "passport":
+		out.Type = search.GovernmentIDPassport
+	case "id":
+		out.Type = search.GovernmentIDNational
+	case "fiscalcode":
+		out.Type = search.GovernmentIDTax
+	case "ssn":
+		out.Type = search.GovernmentIDSSN
+	case "drivinglicence":
+		out.Type = search.GovernmentIDDriversLicense
+	case "birthcert":
+		out.Type = search.GovernmentIDBirthCert
+	case "electionid":
+		out.Type = search.GovernmentIDElectoral
+	case "regnumber":
+		out.Type = search.GovernmentIDBusinessRegisration
+	case "swiftbic":
+		out.Type = search.GovernmentIDSWIFT
+	case "imo":
+		return nil // not a government issued ID
+	default:
+		out.Type = search.GovernmentIDPersonalID
+	}
 	return out
 }
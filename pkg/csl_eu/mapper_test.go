@@ -0,0 +1,93 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_eu
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertSanctionsData(t *testing.T) {
+	fd, err := os.Open(filepath.Join("..", "..", "test", "testdata", "eu_csl.csv"))
+	require.NoError(t, err)
+
+	records, euCSLMap, err := ParseEU(fd)
+	require.NoError(t, err)
+
+	entities := ConvertSanctionsData(records)
+	require.Len(t, entities, len(records))
+
+	t.Run("person", func(t *testing.T) {
+		e := ToEntity(*euCSLMap[25])
+		require.Equal(t, "25", e.SourceID)
+		require.Equal(t, search.EntityPerson, e.Type)
+		require.Equal(t, "Abid Hamid Mahmud Al-Tikriti", e.Name)
+
+		p := e.Person
+		require.Equal(t, search.GenderMale, p.Gender)
+		require.Equal(t, []string{"Col"}, p.Titles)
+		require.Equal(t, "al-Awja, near Tikrit", p.PlaceOfBirth)
+		require.Equal(t, []string{"IQ"}, p.Nationalities)
+
+		// Listed as born circa 1957
+		require.Len(t, p.BirthDates, 1)
+		require.Equal(t, "1956-01-01", p.BirthDates[0].Start.Format(time.DateOnly))
+		require.Equal(t, "1958-12-31", p.BirthDates[0].End.Format(time.DateOnly))
+
+		require.Equal(t, &search.SanctionsInfo{
+			Programs:    []string{"IRQ"},
+			Regulations: []string{"1210/2003 (OJ L169)"},
+			ProgramDates: map[string]time.Time{
+				"IRQ": time.Date(2003, time.July, 7, 0, 0, 0, 0, time.UTC),
+			},
+		}, e.SanctionsInfo)
+	})
+
+	t.Run("business", func(t *testing.T) {
+		e := ToEntity(*euCSLMap[6260])
+		require.Equal(t, search.EntityBusiness, e.Type)
+		require.Equal(t, "Ashton Global Investments Limited", e.Business.Name)
+
+		require.Equal(t, []search.GovernmentID{
+			{Type: search.GovernmentIDBusinessRegisration, Country: "VG", Identifier: "1510484"},
+		}, e.Business.GovernmentIDs)
+
+		require.Len(t, e.Addresses, 1)
+		require.Equal(t, "Woodbourne Hall PO Box 3162", e.Addresses[0].Line1)
+		require.Equal(t, "Road Town, Tortola", e.Addresses[0].City)
+		require.Equal(t, "VIRGIN ISLANDS (BRITISH)", e.Addresses[0].Country)
+
+		require.Equal(t, []string{"LBY"}, e.SanctionsInfo.Programs)
+		require.Equal(t, []string{"2016/44 (OJ L12)"}, e.SanctionsInfo.Regulations)
+		require.Equal(t, "amendment", e.SourceData.EntityRegulations[0].Type)
+	})
+}
+
+func TestMapBirthDate(t *testing.T) {
+	cases := []struct {
+		birth      BirthDate
+		start, end string
+	}{
+		{BirthDate{BirthDate: "1937-04-28"}, "1937-04-28", "1937-04-28"},
+		{BirthDate{Year: "1965"}, "1965-01-01", "1965-12-31"},
+		{BirthDate{YearRangeFrom: "1960", YearRangeTo: "1963"}, "1960-01-01", "1963-12-31"},
+		{BirthDate{Year: "1957", Circa: true}, "1956-01-01", "1958-12-31"},
+	}
+	for _, tc := range cases {
+		got, ok := mapBirthDate(tc.birth)
+		require.True(t, ok)
+		require.Equal(t, tc.start, got.Start.Format(time.DateOnly))
+		require.Equal(t, tc.end, got.End.Format(time.DateOnly))
+	}
+
+	_, ok := mapBirthDate(BirthDate{City: "Baghdad"})
+	require.False(t, ok)
+}
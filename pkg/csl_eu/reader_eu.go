@@ -9,7 +9,9 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"strconv"
+	"strings"
 )
 
 func ParseEU(r io.ReadCloser) ([]CSLRecord, CSL, error) {
@@ -91,6 +93,25 @@ func unmarshalRecord(csvRecord []string, euCSLRecord *CSLRecord) {
 	if csvRecord[EntityRegulationPublicationURLIdx] != "" {
 		euCSLRecord.EntityPublicationURL = csvRecord[EntityRegulationPublicationURLIdx]
 	}
+	if csvRecord[EntityUnitedNationsIdx] != "" {
+		euCSLRecord.EntityUnitedNationsID = csvRecord[EntityUnitedNationsIdx]
+	}
+	if csvRecord[EntityDesignationDateIdx] != "" {
+		euCSLRecord.EntityDesignationDate = csvRecord[EntityDesignationDateIdx]
+	}
+	if csvRecord[EntityRegulationNumberTitleIdx] != "" {
+		regulation := RegulationRecord{
+			Type:               csvRecord[EntityRegulationTypeIdx],
+			PublicationDate:    csvRecord[EntityRegulationPublicationDateIdx],
+			EntryIntoForceDate: csvRecord[EntityRegulationEntryIntoForceDateIdx],
+			NumberTitle:        csvRecord[EntityRegulationNumberTitleIdx],
+			Programme:          csvRecord[EntityRegulationProgrammeIdx],
+			PublicationURL:     csvRecord[EntityRegulationPublicationURLIdx],
+		}
+		if !slices.Contains(euCSLRecord.EntityRegulations, regulation) {
+			euCSLRecord.EntityRegulations = append(euCSLRecord.EntityRegulations, regulation)
+		}
+	}
 
 	// name alias
 	if csvRecord[NameAliasWholeNameIdx] != "" {
@@ -103,7 +124,23 @@ func unmarshalRecord(csvRecord []string, euCSLRecord *CSLRecord) {
 			euCSLRecord.NameAliasTitles = append(euCSLRecord.NameAliasTitles, csvRecord[NameAliasTitleIdx])
 		}
 	}
+	if csvRecord[NameAliasGenderIdx] != "" {
+		if !arrayContains(euCSLRecord.NameAliasGenders, csvRecord[NameAliasGenderIdx]) {
+			euCSLRecord.NameAliasGenders = append(euCSLRecord.NameAliasGenders, csvRecord[NameAliasGenderIdx])
+		}
+	}
+
 	// address
+	addr := Address{
+		City:               csvRecord[AddressCityIdx],
+		Street:             csvRecord[AddressStreetIdx],
+		PoBox:              csvRecord[AddressPoBoxIdx],
+		ZipCode:            csvRecord[AddressZipCodeIdx],
+		CountryDescription: csvRecord[AddressCountryDescriptionIdx],
+	}
+	if addr != (Address{}) && !slices.Contains(euCSLRecord.Addresses, addr) {
+		euCSLRecord.Addresses = append(euCSLRecord.Addresses, addr)
+	}
 	if csvRecord[AddressCityIdx] != "" {
 		if !arrayContains(euCSLRecord.AddressCities, csvRecord[AddressCityIdx]) {
 			euCSLRecord.AddressCities = append(euCSLRecord.AddressCities, csvRecord[AddressCityIdx])
@@ -147,7 +184,46 @@ func unmarshalRecord(csvRecord []string, euCSLRecord *CSLRecord) {
 		}
 	}
 
+	birth := BirthDate{
+		BirthDate:          csvRecord[BirthDateIdx],
+		Year:               csvRecord[BirthDateYearIdx],
+		YearRangeFrom:      csvRecord[BirthDateYearRangeFromIdx],
+		YearRangeTo:        csvRecord[BirthDateYearRangeToIdx],
+		Circa:              strings.EqualFold(csvRecord[BirthDateCircaIdx], "YES"),
+		City:               csvRecord[BirthDateCityIdx],
+		CountryDescription: csvRecord[BirthDateCountryIdx],
+	}
+	if birth.BirthDate != "" || birth.Year != "" || birth.YearRangeFrom != "" || birth.City != "" {
+		if !slices.Contains(euCSLRecord.BirthDateDetails, birth) {
+			euCSLRecord.BirthDateDetails = append(euCSLRecord.BirthDateDetails, birth)
+		}
+	}
+
+	// citizenships
+	if len(csvRecord) > CitizenshipCountryDescriptionIdx {
+		country := csvRecord[CitizenshipCountryDescriptionIdx]
+		if country == "" || strings.EqualFold(country, "UNKNOWN") {
+			country = csvRecord[CitizenshipCountryIso2CodeIdx]
+		}
+		if country != "" && country != "00" && !arrayContains(euCSLRecord.Citizenships, country) {
+			euCSLRecord.Citizenships = append(euCSLRecord.Citizenships, country)
+		}
+	}
+
 	// identifications
+	if len(csvRecord) > IdentificationCountryIso2CodeIdx && csvRecord[IdentificationNumberIdx] != "" {
+		id := Identification{
+			Number:          csvRecord[IdentificationNumberIdx],
+			ValidFrom:       csvRecord[IdentificationValidFromIdx],
+			ValidTo:         csvRecord[IdentificationValidToIdx],
+			TypeCode:        csvRecord[IdentificationTypeCodeIdx],
+			TypeDescription: csvRecord[IdentificationTypeDescriptionIdx],
+			CountryIso2Code: csvRecord[IdentificationCountryIso2CodeIdx],
+		}
+		if !slices.Contains(euCSLRecord.Identifications, id) {
+			euCSLRecord.Identifications = append(euCSLRecord.Identifications, id)
+		}
+	}
 	if csvRecord[IdentificationValidFromIdx] != "" {
 		euCSLRecord.ValidFromTo = make(map[string]string)
 		euCSLRecord.ValidFromTo[csvRecord[IdentificationValidFromIdx]] = csvRecord[IdentificationValidToIdx]
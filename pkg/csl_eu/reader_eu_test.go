@@ -9,6 +9,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/moov-io/watchman/pkg/search"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -72,3 +74,32 @@ func TestReadEU(t *testing.T) {
 	assert.Equal(t, expectedBirthCity, euCSLMap[testLogicalID].BirthCities[0])
 	assert.Equal(t, expectedBirthCountryDescription, euCSLMap[testLogicalID].BirthCountries[0])
 }
+
+func TestReadEU_Identifications(t *testing.T) {
+	fd, err := os.Open(filepath.Join("..", "..", "test", "testdata", "eu_csl.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, euCSLMap, err := ParseEU(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	record := euCSLMap[505]
+	if record == nil {
+		t.Fatal("expected a record at 505")
+	}
+	assert.Contains(t, record.Identifications, Identification{
+		Number:          "D 0001203 (passport-National passport) ((afghan passport))",
+		TypeCode:        "passport",
+		TypeDescription: "National passport",
+		CountryIso2Code: "AF",
+	})
+
+	entity := ToEntity(*record)
+	assert.Contains(t, entity.Person.GovernmentIDs, search.GovernmentID{
+		Type:       search.GovernmentIDPassport,
+		Country:    "AF",
+		Identifier: "D 0001203",
+	})
+}
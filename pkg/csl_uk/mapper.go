@@ -0,0 +1,154 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_uk
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+func ConvertSanctionsData(records []CSLRecord) []search.Entity[search.Value] {
+	out := make([]search.Entity[search.Value], 0, len(records))
+	for _, record := range records {
+		out = append(out, ToEntity(record))
+	}
+	return out
+}
+
+// ToEntity converts a UK OFSI Consolidated List record into a search Entity
+func ToEntity(record CSLRecord) search.Entity[search.Value] {
+	out := search.Entity[search.Value]{
+		Source:     search.SourceUKCSL,
+		SourceID:   strconv.Itoa(record.GroupID),
+		SourceData: record,
+	}
+
+	var altNames []string
+	if len(record.Names) > 0 {
+		out.Name = record.Names[0]
+		altNames = record.Names[1:]
+	}
+	altNames = prepare.WithRomanizedNames(altNames)
+
+	switch strings.ToLower(record.GroupType) {
+	case "individual":
+		out.Type = search.EntityPerson
+		out.Person = &search.Person{
+			Name:     out.Name,
+			AltNames: altNames,
+			Titles:   record.Titles,
+		}
+		for _, dob := range record.DatesOfBirth {
+			if tt := parseDate(dob); tt != nil {
+				out.Person.BirthDate = tt
+				break
+			}
+		}
+
+	case "ship":
+		out.Type = search.EntityVessel
+		out.Vessel = &search.Vessel{
+			Name:     out.Name,
+			AltNames: altNames,
+		}
+		for _, info := range record.OtherInfos {
+			mapVesselDetails(out.Vessel, info)
+		}
+
+	default:
+		out.Type = search.EntityBusiness
+		out.Business = &search.Business{
+			Name:     out.Name,
+			AltNames: altNames,
+		}
+	}
+
+	out.Addresses = mapAddresses(record)
+
+	if len(record.Regimes) > 0 || len(record.OtherInfos) > 0 {
+		out.SanctionsInfo = &search.SanctionsInfo{
+			Programs:    record.Regimes,
+			Description: strings.Join(record.OtherInfos, " "),
+		}
+	}
+
+	return out
+}
+
+// vesselDetailRegex finds the "(IMO number):7408873" style details OFSI writes in a ship's other information
+var vesselDetailRegex = regexp.MustCompile(`\(([^()]+)\):\s*([^(]*)`)
+
+func mapVesselDetails(vessel *search.Vessel, info string) {
+	for _, m := range vesselDetailRegex.FindAllStringSubmatch(info, -1) {
+		value := strings.TrimSpace(m[2])
+		if value == "" {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(m[1])) {
+		case "imo number":
+			vessel.IMONumber = value
+		case "current owners":
+			vessel.Owner = value
+		case "flag of ship":
+			vessel.Flag = value
+		case "type of ship":
+			vessel.Type = search.ParseVesselType(value)
+		case "tonnage of ship":
+			vessel.Tonnage, _ = strconv.Atoi(strings.ReplaceAll(value, ",", ""))
+		case "year built":
+			if tt, err := time.Parse("2006", value); err == nil {
+				vessel.Built = &tt
+			}
+		}
+	}
+}
+
+// parseDate reads dates in the "dd/mm/yyyy" format used by OFSI. Unknown parts of a date are
+// written as zeros, e.g. "00/00/1961", and are treated as January or the first of the month.
+func parseDate(value string) *time.Time {
+	parts := strings.Split(strings.TrimSpace(value), "/")
+	if len(parts) != 3 {
+		return nil
+	}
+	for i := 0; i < 2; i++ {
+		if parts[i] == "00" || parts[i] == "0" {
+			parts[i] = "01"
+		}
+	}
+	tt, err := time.Parse("02/01/2006", strings.Join(parts, "/"))
+	if err != nil {
+		return nil
+	}
+	return &tt
+}
+
+func mapAddresses(record CSLRecord) []search.Address {
+	var country string
+	if len(record.Countries) == 1 {
+		country = record.Countries[0]
+	}
+
+	var out []search.Address
+	for _, addr := range record.Addresses {
+		if addr == "" {
+			continue
+		}
+		out = append(out, search.Address{
+			Line1:   addr,
+			Country: country,
+		})
+	}
+	if len(out) == 0 && country != "" {
+		out = append(out, search.Address{
+			Country: country,
+		})
+	}
+	return out
+}
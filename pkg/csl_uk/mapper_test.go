@@ -0,0 +1,93 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_uk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapper(t *testing.T) {
+	fd, err := os.Open(filepath.Join("..", "..", "test", "testdata", "ConList.csv"))
+	require.NoError(t, err)
+
+	records, recordsMap, err := ReadCSLFile(fd)
+	require.NoError(t, err)
+
+	entities := ConvertSanctionsData(records)
+	require.Len(t, entities, len(records))
+
+	t.Run("entity", func(t *testing.T) {
+		found := ToEntity(*recordsMap[12431])
+
+		require.Equal(t, "(GENERAL) ORGANIZATION FOR ENGINEERING INDUSTRIES", found.Name)
+		require.Equal(t, search.EntityBusiness, found.Type)
+		require.Equal(t, search.SourceUKCSL, found.Source)
+		require.Equal(t, "12431", found.SourceID)
+		require.NotNil(t, found.Business)
+
+		require.Len(t, found.Addresses, 3)
+		require.Equal(t, "PO Box 21120, Baramkeh, Damascus", found.Addresses[0].Line1)
+		require.Equal(t, "Syria", found.Addresses[0].Country)
+
+		require.Equal(t, []string{"Syria"}, found.SanctionsInfo.Programs)
+	})
+
+	t.Run("individual", func(t *testing.T) {
+		found := ToEntity(*recordsMap[12205])
+
+		require.Equal(t, "ABDOLLAHI Hamed", found.Name)
+		require.Equal(t, search.EntityPerson, found.Type)
+		require.NotNil(t, found.Person)
+		require.Equal(t, []string{"General"}, found.Person.Titles)
+
+		expectedBirthDate := time.Date(1960, time.August, 11, 0, 0, 0, 0, time.UTC)
+		require.Equal(t, expectedBirthDate, *found.Person.BirthDate)
+
+		require.Equal(t, []string{"Counter-Terrorism (International)"}, found.SanctionsInfo.Programs)
+	})
+
+	t.Run("ship", func(t *testing.T) {
+		found := ToEntity(*recordsMap[13651])
+
+		require.Equal(t, "AKTIVA", found.Name)
+		require.Equal(t, search.EntityVessel, found.Type)
+		require.NotNil(t, found.Vessel)
+
+		require.Equal(t, "7408873", found.Vessel.IMONumber)
+		require.Equal(t, "Korea Samjong Shipping", found.Vessel.Owner)
+		require.Equal(t, "North Korea", found.Vessel.Flag)
+		require.Equal(t, search.VesselTypeTanker, found.Vessel.Type)
+		require.Equal(t, 1676, found.Vessel.Tonnage)
+		require.Equal(t, 1975, found.Vessel.Built.Year())
+	})
+}
+
+func TestMapper_parseDate(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected time.Time
+	}{
+		{"11/08/1960", time.Date(1960, time.August, 11, 0, 0, 0, 0, time.UTC)},
+		{"00/00/1961", time.Date(1961, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"00/04/1972", time.Date(1972, time.April, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			got := parseDate(tc.input)
+			require.NotNil(t, got)
+			require.Equal(t, tc.expected, *got)
+		})
+	}
+
+	require.Nil(t, parseDate(""))
+	require.Nil(t, parseDate("1961"))
+}
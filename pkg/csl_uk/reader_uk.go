@@ -177,6 +177,11 @@ func unmarshalCSLRecord(csvRecord []string, ukCSLRecord *CSLRecord) {
 	if csvRecord[GroupTypeIdx] != "" && ukCSLRecord.GroupType == "" {
 		ukCSLRecord.GroupType = csvRecord[GroupTypeIdx]
 	}
+	if csvRecord[RegimeIdx] != "" {
+		if !arrayContains(ukCSLRecord.Regimes, csvRecord[RegimeIdx]) {
+			ukCSLRecord.Regimes = append(ukCSLRecord.Regimes, csvRecord[RegimeIdx])
+		}
+	}
 
 	if csvRecord[ListedDateIdx] != "" {
 		if !arrayContains(ukCSLRecord.ListedDates, csvRecord[ListedDateIdx]) {
@@ -31,6 +31,8 @@ const (
 	CountryIdx        = 26
 	OtherInfoIdx      = 27
 	GroupTypeIdx      = 28
+	AliasTypeIdx      = 29
+	RegimeIdx         = 31
 	ListedDateIdx     = 32
 	UKSancListDateIdx = 33
 	LastUpdatedIdx    = 34
@@ -50,6 +52,7 @@ type CSLRecord struct {
 	Countries         []string `json:"countries"`
 	OtherInfos        []string `json:"otherInfo"`
 	GroupType         string   `json:"groupType"`
+	Regimes           []string `json:"regimes"`
 	ListedDates       []string `json:"listedDate"`
 	SanctionListDates []string `json:"sanctionListDate"`
 	LastUpdates       []string `json:"lastUpdated"`
@@ -0,0 +1,29 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_un
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/strx"
+	"github.com/moov-io/watchman/pkg/download"
+)
+
+var (
+	publicUNDownloadURL = "https://scsanctions.un.org/resources/xml/en/consolidated.xml"
+	unDownloadURL       = strx.Or(os.Getenv("UN_CSL_DOWNLOAD_URL"), publicUNDownloadURL)
+)
+
+func Download(ctx context.Context, logger log.Logger, initialDir string) (map[string]io.ReadCloser, error) {
+	dl := download.New(logger, download.HTTPClient)
+
+	unNameAndSource := make(map[string]string)
+	unNameAndSource["consolidated.xml"] = unDownloadURL
+
+	return dl.GetFiles(ctx, initialDir, unNameAndSource)
+}
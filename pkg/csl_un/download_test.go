@@ -0,0 +1,39 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_un
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moov-io/base/log"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownload(t *testing.T) {
+	if testing.Short() {
+		return
+	}
+
+	files, err := Download(context.Background(), log.NewNopLogger(), "")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	file, found := files["consolidated.xml"]
+	require.True(t, found)
+	require.NotNil(t, file)
+	require.NoError(t, file.Close())
+}
+
+func TestDownload_initialDir(t *testing.T) {
+	files, err := Download(context.Background(), log.NewNopLogger(), "testdata")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	file, found := files["consolidated.xml"]
+	require.True(t, found)
+	require.NoError(t, file.Close())
+}
@@ -0,0 +1,219 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_un
+
+import (
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+func ConvertSanctionsData(data *ConsolidatedList) []search.Entity[search.Value] {
+	if data == nil {
+		return nil
+	}
+
+	out := make([]search.Entity[search.Value], 0, len(data.Individuals)+len(data.Entities))
+	for _, individual := range data.Individuals {
+		out = append(out, IndividualToEntity(individual))
+	}
+	for _, entity := range data.Entities {
+		out = append(out, EntityToEntity(entity))
+	}
+	return out
+}
+
+// IndividualToEntity converts a UN CSL individual into a search Entity
+func IndividualToEntity(src Individual) search.Entity[search.Value] {
+	out := search.Entity[search.Value]{
+		Name:       joinNames(src.FirstName, src.SecondName, src.ThirdName, src.FourthName),
+		Type:       search.EntityPerson,
+		Source:     search.SourceUNCSL,
+		SourceID:   src.ReferenceNumber,
+		SourceData: src,
+	}
+
+	out.Person = &search.Person{
+		Name:     out.Name,
+		AltNames: mapAliases(src.Aliases, src.NameOriginal),
+		Gender:   mapGender(src.Gender),
+		Titles:   mapTitles(src.Titles),
+	}
+	for _, dob := range src.DatesOfBirth {
+		if tt := parseDateOfBirth(dob); tt != nil {
+			out.Person.BirthDate = tt
+			break
+		}
+	}
+	for _, doc := range src.Documents {
+		if id := mapDocument(doc); id != nil {
+			out.Person.GovernmentIDs = append(out.Person.GovernmentIDs, *id)
+		}
+	}
+
+	out.Addresses = mapAddresses(src.Addresses)
+	out.SanctionsInfo = mapSanctionsInfo(src.UNListType, src.ListedOn, src.Comments)
+
+	return out
+}
+
+// EntityToEntity converts a UN CSL entity (businesses, groups and organizations) into a search Entity
+func EntityToEntity(src Entity) search.Entity[search.Value] {
+	out := search.Entity[search.Value]{
+		Name:       strings.TrimSpace(src.FirstName),
+		Type:       search.EntityBusiness,
+		Source:     search.SourceUNCSL,
+		SourceID:   src.ReferenceNumber,
+		SourceData: src,
+	}
+
+	out.Business = &search.Business{
+		Name:     out.Name,
+		AltNames: mapAliases(src.Aliases, src.NameOriginal),
+	}
+
+	out.Addresses = mapAddresses(src.Addresses)
+	out.SanctionsInfo = mapSanctionsInfo(src.UNListType, src.ListedOn, src.Comments)
+
+	return out
+}
+
+func joinNames(names ...string) string {
+	var parts []string
+	for _, n := range names {
+		n = strings.TrimSpace(n)
+		if n != "" {
+			parts = append(parts, n)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+func mapAliases(aliases []Alias, originalScript string) []string {
+	var out []string
+	for _, alias := range aliases {
+		name := strings.TrimSpace(alias.Name)
+		if name == "" {
+			continue
+		}
+		// UN marks some aliases as "Low" quality, which are often too short or generic to screen against
+		if strings.EqualFold(alias.Quality, "low") {
+			continue
+		}
+		out = append(out, name)
+	}
+	if originalScript = strings.TrimSpace(originalScript); originalScript != "" {
+		out = append(out, originalScript)
+	}
+	return prepare.WithRomanizedNames(out)
+}
+
+func mapGender(gender string) search.Gender {
+	switch strings.ToLower(strings.TrimSpace(gender)) {
+	case "male":
+		return search.GenderMale
+	case "female":
+		return search.GenderFemale
+	}
+	return search.GenderUnknown
+}
+
+func mapTitles(titles []string) []string {
+	var out []string
+	for _, t := range titles {
+		if t = strings.TrimSpace(t); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func parseDateOfBirth(dob DateOfBirth) *time.Time {
+	if dob.Date != "" {
+		// Dates are typically "2006-01-02" but some records include a timezone offset
+		for _, layout := range []string{"2006-01-02", "2006-01-02-07:00", "2006-01-02Z07:00"} {
+			if tt, err := time.Parse(layout, dob.Date); err == nil {
+				return &tt
+			}
+		}
+	}
+	if dob.Year != "" {
+		if tt, err := time.Parse("2006", dob.Year); err == nil {
+			return &tt
+		}
+	}
+	return nil
+}
+
+func mapDocument(doc Document) *search.GovernmentID {
+	number := strings.TrimSpace(doc.Number)
+	if number == "" {
+		return nil
+	}
+
+	id := &search.GovernmentID{
+		Identifier: number,
+		Country:    strings.TrimSpace(doc.IssuingCountry),
+	}
+	if id.Country == "" {
+		id.Country = strings.TrimSpace(doc.CountryOfIssue)
+	}
+
+	kind := strings.ToLower(doc.Type)
+	switch {
+	case strings.Contains(kind, "diplomatic"):
+		id.Type = search.GovernmentIDDiplomaticPass
+	case strings.Contains(kind, "passport"):
+		id.Type = search.GovernmentIDPassport
+	case strings.Contains(kind, "national identification"):
+		id.Type = search.GovernmentIDNational
+	case strings.Contains(kind, "driving"), strings.Contains(kind, "driver"):
+		id.Type = search.GovernmentIDDriversLicense
+	case strings.Contains(kind, "tax"):
+		id.Type = search.GovernmentIDTax
+	default:
+		id.Type = search.GovernmentIDPersonalID
+	}
+	return id
+}
+
+func mapAddresses(addresses []Address) []search.Address {
+	var out []search.Address
+	for _, addr := range addresses {
+		a := search.Address{
+			Line1:      strings.TrimSpace(addr.Street),
+			City:       strings.TrimSpace(addr.City),
+			PostalCode: strings.TrimSpace(addr.ZipCode),
+			State:      strings.TrimSpace(addr.StateProvince),
+			Country:    strings.TrimSpace(addr.Country),
+		}
+		if a.Line1 == "" && a.City == "" && a.PostalCode == "" && a.State == "" && a.Country == "" {
+			continue
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+func mapSanctionsInfo(listType, listedOn, comments string) *search.SanctionsInfo {
+	listType = strings.TrimSpace(listType)
+	if listType == "" && comments == "" {
+		return nil
+	}
+
+	info := &search.SanctionsInfo{
+		Description: strings.TrimSpace(comments),
+	}
+	if listType != "" {
+		info.Programs = []string{listType}
+
+		if tt, err := time.Parse("2006-01-02", strings.TrimSpace(listedOn)); err == nil {
+			info.ProgramDates = map[string]time.Time{listType: tt}
+		}
+	}
+	return info
+}
@@ -0,0 +1,119 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_un
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertSanctionsData(t *testing.T) {
+	files, err := Download(context.Background(), log.NewTestLogger(), "testdata")
+	require.NoError(t, err)
+
+	data, err := Read(files)
+	require.NoError(t, err)
+
+	entities := ConvertSanctionsData(data)
+	require.Len(t, entities, 4)
+
+	for _, e := range entities {
+		require.Equal(t, search.SourceUNCSL, e.Source)
+		require.NotEmpty(t, e.SourceID)
+		require.NotEmpty(t, e.Name)
+	}
+}
+
+func TestIndividualToEntity(t *testing.T) {
+	files, err := Download(context.Background(), log.NewTestLogger(), "testdata")
+	require.NoError(t, err)
+
+	data, err := Read(files)
+	require.NoError(t, err)
+
+	t.Run("KPi.033", func(t *testing.T) {
+		found := IndividualToEntity(data.Individuals[0])
+
+		require.Equal(t, "RI WON HO", found.Name)
+		require.Equal(t, search.EntityPerson, found.Type)
+		require.Equal(t, "KPi.033", found.SourceID)
+
+		require.NotNil(t, found.Person)
+		require.Equal(t, search.GenderMale, found.Person.Gender)
+		require.Empty(t, found.Person.AltNames)
+
+		expectedBirthDate := time.Date(1964, time.July, 17, 0, 0, 0, 0, time.UTC)
+		require.Equal(t, expectedBirthDate, *found.Person.BirthDate)
+
+		expectedIDs := []search.GovernmentID{
+			{
+				Type:       search.GovernmentIDPassport,
+				Country:    "Democratic People's Republic of Korea",
+				Identifier: "381310014",
+			},
+		}
+		require.Equal(t, expectedIDs, found.Person.GovernmentIDs)
+
+		require.Len(t, found.Addresses, 1)
+		require.Equal(t, "Syrian Arab Republic", found.Addresses[0].Country)
+
+		require.Equal(t, []string{"DPRK"}, found.SanctionsInfo.Programs)
+		require.Equal(t, map[string]time.Time{
+			"DPRK": time.Date(2016, time.November, 30, 0, 0, 0, 0, time.UTC),
+		}, found.SanctionsInfo.ProgramDates)
+	})
+
+	t.Run("QDi.088", func(t *testing.T) {
+		found := IndividualToEntity(data.Individuals[1])
+
+		require.Equal(t, "ABDUL MANAN", found.Name)
+		require.Equal(t, search.GenderUnknown, found.Person.Gender)
+		require.Equal(t, []string{"Mullah"}, found.Person.Titles)
+
+		// Low quality aliases are skipped
+		require.Equal(t, []string{"Abdul Mannan Agha", "عبد المنان", "abd al mnan"}, found.Person.AltNames)
+
+		expectedBirthDate := time.Date(1965, time.January, 1, 0, 0, 0, 0, time.UTC)
+		require.Equal(t, expectedBirthDate, *found.Person.BirthDate)
+
+		require.Empty(t, found.Addresses)
+		require.Equal(t, []string{"Al-Qaida"}, found.SanctionsInfo.Programs)
+	})
+}
+
+func TestEntityToEntity(t *testing.T) {
+	files, err := Download(context.Background(), log.NewTestLogger(), "testdata")
+	require.NoError(t, err)
+
+	data, err := Read(files)
+	require.NoError(t, err)
+
+	found := EntityToEntity(data.Entities[0])
+
+	require.Equal(t, "KOREA MINING DEVELOPMENT TRADING CORPORATION", found.Name)
+	require.Equal(t, search.EntityBusiness, found.Type)
+	require.Equal(t, "KPe.001", found.SourceID)
+
+	require.NotNil(t, found.Business)
+	require.Equal(t, []string{"CHANGGWANG SINYONG CORPORATION", "KOMID"}, found.Business.AltNames)
+
+	expectedAddresses := []search.Address{
+		{
+			Line1:   "Central District",
+			City:    "Pyongyang",
+			Country: "Democratic People's Republic of Korea",
+		},
+	}
+	require.Equal(t, expectedAddresses, found.Addresses)
+
+	require.Equal(t, []string{"DPRK"}, found.SanctionsInfo.Programs)
+	require.Contains(t, found.SanctionsInfo.Description, "Primary arms dealer")
+}
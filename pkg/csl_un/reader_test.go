@@ -0,0 +1,55 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_un
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base/log"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader(t *testing.T) {
+	files, err := Download(context.Background(), log.NewTestLogger(), "testdata")
+	require.NoError(t, err)
+
+	data, err := Read(files)
+	require.NoError(t, err)
+
+	require.Equal(t, "2024-12-18T18:00:03.226Z", data.DateGenerated)
+	require.Len(t, data.Individuals, 2)
+	require.Len(t, data.Entities, 2)
+
+	ri := data.Individuals[0]
+	require.Equal(t, "KPi.033", ri.ReferenceNumber)
+	require.Equal(t, "RI", ri.FirstName)
+	require.Equal(t, "WON HO", ri.SecondName)
+	require.Len(t, ri.Documents, 1)
+	require.Equal(t, "381310014", ri.Documents[0].Number)
+
+	komid := data.Entities[0]
+	require.Equal(t, "KPe.001", komid.ReferenceNumber)
+	require.Len(t, komid.Aliases, 2)
+	require.Equal(t, "Pyongyang", komid.Addresses[0].City)
+}
+
+func TestReader_Errors(t *testing.T) {
+	_, err := Read(nil)
+	require.ErrorContains(t, err, "no files provided")
+
+	_, err = Read(map[string]io.ReadCloser{
+		"other.csv": io.NopCloser(strings.NewReader("")),
+	})
+	require.ErrorContains(t, err, "unknown file other.csv")
+
+	_, err = Read(map[string]io.ReadCloser{
+		"consolidated.xml": io.NopCloser(strings.NewReader("<CONSOLIDATED_LIST")),
+	})
+	require.ErrorContains(t, err, "failed to parse consolidated.xml")
+}
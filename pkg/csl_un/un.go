@@ -0,0 +1,110 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_un
+
+import (
+	"encoding/xml"
+)
+
+// ConsolidatedList is the UN Security Council Consolidated List
+//
+// https://main.un.org/securitycouncil/en/content/un-sc-consolidated-list
+type ConsolidatedList struct {
+	XMLName       xml.Name     `xml:"CONSOLIDATED_LIST"`
+	DateGenerated string       `xml:"dateGenerated,attr"`
+	Individuals   []Individual `xml:"INDIVIDUALS>INDIVIDUAL"`
+	Entities      []Entity     `xml:"ENTITIES>ENTITY"`
+}
+
+type Individual struct {
+	DataID          string `xml:"DATAID" json:"dataID"`
+	VersionNum      string `xml:"VERSIONNUM" json:"versionNum"`
+	FirstName       string `xml:"FIRST_NAME" json:"firstName"`
+	SecondName      string `xml:"SECOND_NAME" json:"secondName"`
+	ThirdName       string `xml:"THIRD_NAME" json:"thirdName"`
+	FourthName      string `xml:"FOURTH_NAME" json:"fourthName"`
+	UNListType      string `xml:"UN_LIST_TYPE" json:"unListType"`
+	ReferenceNumber string `xml:"REFERENCE_NUMBER" json:"referenceNumber"`
+	ListedOn        string `xml:"LISTED_ON" json:"listedOn"`
+	Gender          string `xml:"GENDER" json:"gender"`
+	NameOriginal    string `xml:"NAME_ORIGINAL_SCRIPT" json:"nameOriginalScript"`
+	Comments        string `xml:"COMMENTS1" json:"comments"`
+
+	Titles        []string `xml:"TITLE>VALUE" json:"titles"`
+	Designations  []string `xml:"DESIGNATION>VALUE" json:"designations"`
+	Nationalities []string `xml:"NATIONALITY>VALUE" json:"nationalities"`
+	ListTypes     []string `xml:"LIST_TYPE>VALUE" json:"listTypes"`
+	LastUpdated   []string `xml:"LAST_DAY_UPDATED>VALUE" json:"lastUpdated"`
+
+	Aliases        []Alias        `xml:"INDIVIDUAL_ALIAS" json:"aliases"`
+	Addresses      []Address      `xml:"INDIVIDUAL_ADDRESS" json:"addresses"`
+	DatesOfBirth   []DateOfBirth  `xml:"INDIVIDUAL_DATE_OF_BIRTH" json:"datesOfBirth"`
+	PlacesOfBirth  []PlaceOfBirth `xml:"INDIVIDUAL_PLACE_OF_BIRTH" json:"placesOfBirth"`
+	Documents      []Document     `xml:"INDIVIDUAL_DOCUMENT" json:"documents"`
+	SortKey        string         `xml:"SORT_KEY" json:"-"`
+	SortKeyLastMod string         `xml:"SORT_KEY_LAST_MOD" json:"-"`
+}
+
+type Entity struct {
+	DataID          string `xml:"DATAID" json:"dataID"`
+	VersionNum      string `xml:"VERSIONNUM" json:"versionNum"`
+	FirstName       string `xml:"FIRST_NAME" json:"firstName"`
+	UNListType      string `xml:"UN_LIST_TYPE" json:"unListType"`
+	ReferenceNumber string `xml:"REFERENCE_NUMBER" json:"referenceNumber"`
+	ListedOn        string `xml:"LISTED_ON" json:"listedOn"`
+	NameOriginal    string `xml:"NAME_ORIGINAL_SCRIPT" json:"nameOriginalScript"`
+	Comments        string `xml:"COMMENTS1" json:"comments"`
+
+	ListTypes   []string `xml:"LIST_TYPE>VALUE" json:"listTypes"`
+	LastUpdated []string `xml:"LAST_DAY_UPDATED>VALUE" json:"lastUpdated"`
+
+	Aliases        []Alias   `xml:"ENTITY_ALIAS" json:"aliases"`
+	Addresses      []Address `xml:"ENTITY_ADDRESS" json:"addresses"`
+	SortKey        string    `xml:"SORT_KEY" json:"-"`
+	SortKeyLastMod string    `xml:"SORT_KEY_LAST_MOD" json:"-"`
+}
+
+type Alias struct {
+	Quality     string `xml:"QUALITY" json:"quality"` // Good, Low, a.k.a.
+	Name        string `xml:"ALIAS_NAME" json:"name"`
+	DateOfBirth string `xml:"DATE_OF_BIRTH" json:"dateOfBirth"`
+	CityOfBirth string `xml:"CITY_OF_BIRTH" json:"cityOfBirth"`
+	Note        string `xml:"NOTE" json:"note"`
+}
+
+type Address struct {
+	Street        string `xml:"STREET" json:"street"`
+	City          string `xml:"CITY" json:"city"`
+	StateProvince string `xml:"STATE_PROVINCE" json:"stateProvince"`
+	ZipCode       string `xml:"ZIP_CODE" json:"zipCode"`
+	Country       string `xml:"COUNTRY" json:"country"`
+	Note          string `xml:"NOTE" json:"note"`
+}
+
+type DateOfBirth struct {
+	TypeOfDate string `xml:"TYPE_OF_DATE" json:"typeOfDate"` // EXACT, APPROXIMATELY, BETWEEN
+	Date       string `xml:"DATE" json:"date"`
+	Year       string `xml:"YEAR" json:"year"`
+	FromYear   string `xml:"FROM_YEAR" json:"fromYear"`
+	ToYear     string `xml:"TO_YEAR" json:"toYear"`
+	Note       string `xml:"NOTE" json:"note"`
+}
+
+type PlaceOfBirth struct {
+	City          string `xml:"CITY" json:"city"`
+	StateProvince string `xml:"STATE_PROVINCE" json:"stateProvince"`
+	Country       string `xml:"COUNTRY" json:"country"`
+}
+
+type Document struct {
+	Type           string `xml:"TYPE_OF_DOCUMENT" json:"type"`
+	Type2          string `xml:"TYPE_OF_DOCUMENT2" json:"type2"`
+	Number         string `xml:"NUMBER" json:"number"`
+	IssuingCountry string `xml:"ISSUING_COUNTRY" json:"issuingCountry"`
+	DateOfIssue    string `xml:"DATE_OF_ISSUE" json:"dateOfIssue"`
+	CityOfIssue    string `xml:"CITY_OF_ISSUE" json:"cityOfIssue"`
+	CountryOfIssue string `xml:"COUNTRY_OF_ISSUE" json:"countryOfIssue"`
+	Note           string `xml:"NOTE" json:"note"`
+}
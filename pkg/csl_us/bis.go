@@ -0,0 +1,155 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_us
+
+import (
+	"compress/gzip"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// BISRecord is an entry of the Commerce Department's Bureau of Industry and Security (BIS) lists, as published
+// in the trade.gov Consolidated Screening List (consolidated.csv)
+type BISRecord struct {
+	EntityID string `json:"entityID"`
+
+	// List is the BIS list of the record: DPL, EL or UVL
+	List string `json:"list"`
+
+	Type           string   `json:"type"`
+	Programs       []string `json:"programs"`
+	Name           string   `json:"name"`
+	Title          string   `json:"title"`
+	AlternateNames []string `json:"alternateNames"`
+	Addresses      []string `json:"addresses"`
+
+	FRNotice      string `json:"frNotice"`
+	StartDate     string `json:"startDate"`
+	EndDate       string `json:"endDate"`
+	StandardOrder string `json:"standardOrder"`
+
+	LicenseRequirement string `json:"licenseRequirement"`
+	LicensePolicy      string `json:"licensePolicy"`
+
+	Remarks       string `json:"remarks"`
+	SourceListURL string `json:"sourceListURL"`
+	SourceInfoURL string `json:"sourceInfoURL"`
+}
+
+const (
+	BISDeniedPersons = "DPL"
+	BISEntityList    = "EL"
+	BISUnverified    = "UVL"
+)
+
+// bisSources are the values of the source column for each BIS list
+var bisSources = map[string]string{
+	"Denied Persons List (DPL) - Bureau of Industry and Security": BISDeniedPersons,
+	"Entity List (EL) - Bureau of Industry and Security":          BISEntityList,
+	"Unverified List (UVL) - Bureau of Industry and Security":     BISUnverified,
+}
+
+// ReadBIS parses the BIS records of consolidated.csv, which can be gzipped (consolidated.csv.gz).
+// Records of other agencies are skipped.
+func ReadBIS(files map[string]io.ReadCloser) ([]BISRecord, error) {
+	for filename, file := range files {
+		name := strings.ToLower(filepath.Base(filename))
+		if name != bisFilename && name != bisFilename+".gz" {
+			continue
+		}
+		defer file.Close()
+
+		var r io.Reader = file
+		if strings.HasSuffix(name, ".gz") {
+			gz, err := gzip.NewReader(file)
+			if err != nil {
+				return nil, fmt.Errorf("opening %s: %w", filename, err)
+			}
+			defer gz.Close()
+			r = gz
+		}
+
+		records, err := parseBIS(r)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", filename, err)
+		}
+		return records, nil
+	}
+	return nil, errors.New("missing " + bisFilename)
+}
+
+func parseBIS(r io.Reader) ([]BISRecord, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("reading header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.TrimSpace(name)] = i
+	}
+
+	var out []BISRecord
+	for {
+		row, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		get := func(column string) string {
+			idx, exists := columns[column]
+			if !exists || idx >= len(row) {
+				return ""
+			}
+			return strings.TrimSpace(row[idx])
+		}
+
+		list, exists := bisSources[get("source")]
+		if !exists {
+			continue
+		}
+		out = append(out, BISRecord{
+			EntityID:           get("_id"),
+			List:               list,
+			Type:               get("type"),
+			Programs:           splitBIS(get("programs")),
+			Name:               get("name"),
+			Title:              get("title"),
+			AlternateNames:     splitBIS(get("alt_names")),
+			Addresses:          splitBIS(get("addresses")),
+			FRNotice:           get("federal_register_notice"),
+			StartDate:          get("start_date"),
+			EndDate:            get("end_date"),
+			StandardOrder:      get("standard_order"),
+			LicenseRequirement: get("license_requirement"),
+			LicensePolicy:      get("license_policy"),
+			Remarks:            get("remarks"),
+			SourceListURL:      get("source_list_url"),
+			SourceInfoURL:      get("source_information_url"),
+		})
+	}
+	return out, nil
+}
+
+// splitBIS reads the semicolon separated values of a column
+func splitBIS(value string) []string {
+	var out []string
+	for _, v := range strings.Split(value, ";") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
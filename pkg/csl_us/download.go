@@ -19,8 +19,13 @@ import (
 var (
 	publicUSDownloadURL = "https://sanctionslistservice.ofac.treas.gov/api/PublicationPreview/exports/%s"
 	usDownloadURL       = strx.Or(os.Getenv("US_CSL_DOWNLOAD_TEMPLATE"), os.Getenv("US_CSL_DOWNLOAD_URL"), publicUSDownloadURL)
+
+	publicBISDownloadURL = "https://data.trade.gov/downloadable_consolidated_screening_list/v1/consolidated.csv"
+	bisDownloadURL       = strx.Or(os.Getenv("US_BIS_DOWNLOAD_URL"), publicBISDownloadURL)
 )
 
+const bisFilename = "consolidated.csv"
+
 func Download(ctx context.Context, logger log.Logger, initialDir string) (map[string]io.ReadCloser, error) {
 	dl := download.New(logger, download.HTTPClient)
 
@@ -42,3 +47,14 @@ func buildDownloadURL(urlStr string) (string, error) {
 	}
 	return cslURL.String(), nil
 }
+
+// DownloadBIS returns the trade.gov Consolidated Screening List (consolidated.csv), which ReadBIS reads
+// the Bureau of Industry and Security lists from
+func DownloadBIS(ctx context.Context, logger log.Logger, initialDir string) (map[string]io.ReadCloser, error) {
+	dl := download.New(logger, download.HTTPClient)
+
+	bisNameAndSource := make(map[string]string)
+	bisNameAndSource[bisFilename] = bisDownloadURL
+
+	return dl.GetFiles(ctx, initialDir, bisNameAndSource)
+}
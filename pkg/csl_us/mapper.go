@@ -6,12 +6,12 @@ package csl_us
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/moov-io/watchman/internal/prepare"
-	"github.com/moov-io/watchman/pkg/address"
 	"github.com/moov-io/watchman/pkg/csl_us/gen/ENHANCED_XML"
 	"github.com/moov-io/watchman/pkg/search"
 )
@@ -57,6 +57,7 @@ func ToEntity(src ENHANCED_XML.EntitiesEntity) search.Entity[search.Value] {
 	entity.Addresses = mapAddresses(src.Addresses)
 	entity.Affiliations = mapAffiliations(src.Relationships)
 	entity.SanctionsInfo = mapSanctionsInfo(src)
+	entity.CryptoAddresses = mapCryptoAddresses(src.Features)
 
 	return entity
 }
@@ -166,8 +167,12 @@ func mapPersonFeatures(features *ENHANCED_XML.EntityFeatures, person *search.Per
 					person.BirthDate = &date
 				}
 			}
-		// case 9: // Place of Birth
-		// 	person.PlaceOfBirth = feature.Value
+		case 9: // Place of Birth
+			person.PlaceOfBirth = feature.Value
+		case 10, 11: // Nationality Country, Citizenship Country
+			if feature.Value != "" && !slices.Contains(person.Nationalities, feature.Value) {
+				person.Nationalities = append(person.Nationalities, feature.Value)
+			}
 		case 91526: // Gender - Male
 			person.Gender = search.GenderMale
 		case 91527: // Gender - Female
@@ -194,6 +199,14 @@ func mapBusiness(src ENHANCED_XML.EntitiesEntity) *search.Business {
 				if date := mapDate(feature.ValueDate); date != nil {
 					business.Dissolved = date
 				}
+			case "Issuer Name": // NS-CMIC securities issuers
+				name := strings.TrimSpace(feature.Value)
+				listed := slices.ContainsFunc(append([]string{business.Name}, business.AltNames...), func(n string) bool {
+					return strings.EqualFold(n, name)
+				})
+				if name != "" && !listed {
+					business.AltNames = append(business.AltNames, name)
+				}
 			}
 		}
 	}
@@ -202,6 +215,17 @@ func mapBusiness(src ENHANCED_XML.EntitiesEntity) *search.Business {
 	if src.IdentityDocuments != nil {
 		business.GovernmentIDs = mapGovernmentIDs(src.IdentityDocuments)
 	}
+	if src.Features != nil {
+		for _, feature := range src.Features.Feature {
+			// The country prefix of an ISIN is where the security was issued, so it's left off
+			if feature.Type.Text == "ISIN" && feature.Value != "" {
+				business.GovernmentIDs = append(business.GovernmentIDs, search.GovernmentID{
+					Type:       search.GovernmentIDISIN,
+					Identifier: strings.TrimSpace(feature.Value),
+				})
+			}
+		}
+	}
 
 	return business
 }
@@ -253,8 +277,12 @@ func mapAircraft(src ENHANCED_XML.EntitiesEntity) *search.Aircraft {
 				}
 			case "Aircraft Model":
 				aircraft.Model = feature.Value
-			case "Serial Number":
+			case "Serial Number", "Aircraft Serial Identification", "Aircraft Manufacturer's Serial Number (MSN)":
 				aircraft.SerialNumber = feature.Value
+			case "Aircraft Tail Number":
+				aircraft.TailNumber = feature.Value
+			case "Previous Aircraft Tail Number":
+				aircraft.PreviousTailNumbers = append(aircraft.PreviousTailNumbers, feature.Value)
 			case "Registration Country":
 				aircraft.Flag = feature.Value // Assuming country code is stored in value
 			case "ICAO Number":
@@ -324,6 +352,26 @@ func mapContactInfo(src ENHANCED_XML.EntitiesEntity) search.ContactInfo {
 	return info
 }
 
+// mapCryptoAddresses reads "Digital Currency Address - XBT" features, where the currency follows the dash
+func mapCryptoAddresses(features *ENHANCED_XML.EntityFeatures) []search.CryptoAddress {
+	if features == nil {
+		return nil
+	}
+
+	var out []search.CryptoAddress
+	for _, feature := range features.Feature {
+		currency, found := strings.CutPrefix(feature.Type.Text, "Digital Currency Address - ")
+		if !found || strings.TrimSpace(feature.Value) == "" {
+			continue
+		}
+		out = append(out, search.CryptoAddress{
+			Currency: strings.TrimSpace(currency),
+			Address:  strings.TrimSpace(feature.Value),
+		})
+	}
+	return out
+}
+
 func mapAddresses(addresses *ENHANCED_XML.EntityAddresses) []search.Address {
 	if addresses == nil {
 		return nil
@@ -361,9 +409,9 @@ func mapAddresses(addresses *ENHANCED_XML.EntityAddresses) []search.Address {
 			mappedAddr.Country = addr.Country.Text
 		}
 
-		res := address.ParseAddress(mappedAddr.Format())
-		if res.Line1 != "" && res.Country != "" {
-			result = append(result, res)
+		// The list's parts are kept as they're written, rather than joined and parsed apart again
+		if mappedAddr.Line1 != "" || mappedAddr.City != "" || mappedAddr.Country != "" {
+			result = append(result, mappedAddr)
 		}
 	}
 
@@ -399,18 +447,78 @@ func mapSanctionsInfo(src ENHANCED_XML.EntitiesEntity) *search.SanctionsInfo {
 		info.Programs = append(info.Programs, program.Text)
 	}
 
-	// Check for secondary sanctions in features
+	// Check for secondary sanctions and sectoral directives in features
 	if src.Features != nil {
 		for _, feature := range src.Features.Feature {
 			if feature.Type.Text == "Secondary Sanctions Risk" {
 				info.Secondary = strings.ToLower(feature.Value) == "yes"
 			}
+			if directive := mapDirective(feature); directive != "" && !slices.Contains(info.Directives, directive) {
+				info.Directives = append(info.Directives, directive)
+			}
+		}
+	}
+
+	// Entities on the SSI list are only sectorally restricted unless they're also on the SDN list
+	var ssi, sdn bool
+	for _, list := range src.SanctionsLists.SanctionsList {
+		switch list.Text {
+		case ssiListName:
+			ssi = true
+		case sdnListName:
+			sdn = true
+		}
+		if code := listCode(list.Text); code != "" && !slices.Contains(info.Lists, code) {
+			info.Lists = append(info.Lists, code)
 		}
 	}
+	info.Sectoral = ssi && !sdn
 
 	return info
 }
 
+const (
+	sdnListName = "SDN List"
+	ssiListName = "Sectoral Sanctions Identifications List"
+)
+
+// listCodes are the abbreviations OFAC uses for each of its lists
+var listCodes = map[string]string{
+	sdnListName:                         "SDN",
+	ssiListName:                         "SSI",
+	"CAPTA List":                        "CAPTA",
+	"FSE List":                          "FSE",
+	"Non-SDN CMIC List":                 "NS-CMIC",
+	"Non-SDN Menu-Based Sanctions List": "NS-MBS",
+	"Non-SDN Palestinian Legislative Council List": "NS-PLC",
+}
+
+// listCode returns the abbreviation of a list. Every entity is on the "Consolidated List", so it's skipped.
+func listCode(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" || name == "Consolidated List" {
+		return ""
+	}
+	if code, exists := listCodes[name]; exists {
+		return code
+	}
+	return name
+}
+
+// mapDirective returns the directive of features such as "Executive Order 13662 Directive Determination -",
+// which read "Subject to Directive 2" optionally followed by a description of the directive.
+func mapDirective(feature ENHANCED_XML.FeaturesFeature) string {
+	if !strings.Contains(feature.Type.Text, "Directive") {
+		return ""
+	}
+	value := strings.TrimSpace(feature.Value)
+	if !strings.HasPrefix(value, "Subject to Directive") {
+		return ""
+	}
+	directive, _, _ := strings.Cut(value, " - ")
+	return strings.TrimSpace(directive)
+}
+
 func mapTitles(src ENHANCED_XML.EntitiesEntity) []string {
 	var titles []string
 	if src.GeneralInfo.Title != "" {
@@ -523,12 +631,7 @@ func mapAircraftType(value string) search.AircraftType {
 }
 
 func mapVesselType(value string) search.VesselType {
-	switch strings.ToLower(value) {
-	case "cargo":
-		return search.VesselTypeCargo
-	default:
-		return search.VesselTypeUnknown
-	}
+	return search.ParseVesselType(value)
 }
 
 func getCountryCode(country *ENHANCED_XML.ReferenceValueReferenceType) string {
@@ -0,0 +1,111 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_us
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+func ConvertBIS(records []BISRecord) []search.Entity[search.Value] {
+	out := make([]search.Entity[search.Value], 0, len(records))
+	for _, record := range records {
+		out = append(out, BISToEntity(record))
+	}
+	return out
+}
+
+// BISToEntity converts a Denied Persons, Entity or Unverified List record into a search Entity
+func BISToEntity(record BISRecord) search.Entity[search.Value] {
+	out := search.Entity[search.Value]{
+		Source:     search.SourceUSBIS,
+		SourceID:   record.EntityID,
+		SourceData: record,
+	}
+
+	altNames := prepare.WithRomanizedNames(record.AlternateNames)
+
+	if isBISPerson(record) {
+		out.Name = prepare.ReorderSDNName(record.Name, "individual")
+		out.Type = search.EntityPerson
+		out.Person = &search.Person{
+			Name:     out.Name,
+			AltNames: altNames,
+		}
+		if record.Title != "" {
+			out.Person.Titles = []string{record.Title}
+		}
+	} else {
+		out.Name = record.Name
+		out.Type = search.EntityBusiness
+		out.Business = &search.Business{
+			Name:     out.Name,
+			AltNames: altNames,
+		}
+	}
+
+	for _, addr := range record.Addresses {
+		out.Addresses = append(out.Addresses, mapBISAddress(addr))
+	}
+
+	out.SanctionsInfo = &search.SanctionsInfo{
+		Programs:           record.Programs,
+		Lists:              []string{record.List},
+		Description:        record.Remarks,
+		LicenseRequirement: record.LicenseRequirement,
+		LicensePolicy:      record.LicensePolicy,
+	}
+	if record.FRNotice != "" {
+		out.SanctionsInfo.Regulations = []string{record.FRNotice}
+	}
+
+	return out
+}
+
+// isBISPerson reports if a record is an individual. Most records have no type, so Denied Persons List
+// records are individuals unless their name has a business term (e.g. "LLC" or "AIRLINES").
+func isBISPerson(record BISRecord) bool {
+	switch strings.ToLower(record.Type) {
+	case "individual":
+		return true
+	case "":
+		if record.List != BISDeniedPersons {
+			return false
+		}
+		words := strings.FieldsFunc(strings.ToLower(record.Name), func(r rune) bool {
+			return r == ' ' || r == ',' || r == '(' || r == ')'
+		})
+		for _, word := range words {
+			if slices.Contains(bisBusinessTerms, strings.TrimSuffix(word, ".")) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+var bisBusinessTerms = []string{
+	"air", "airlines", "airways", "co", "company", "corp", "corporation", "electronics", "enterprises", "gmbh",
+	"group", "inc", "industrial", "industries", "international", "llc", "ltd", "s.a", "services", "solutions",
+	"systems", "technik", "technology", "trade", "trading",
+}
+
+// mapBISAddress reads addresses such as "No. 34 Mansour Street, Tehran, IR" which end with a country code
+func mapBISAddress(addr string) search.Address {
+	idx := strings.LastIndex(addr, ",")
+	if idx > 0 {
+		if country := strings.TrimSpace(addr[idx+1:]); len(country) == 2 {
+			return search.Address{
+				Line1:   strings.TrimSpace(addr[:idx]),
+				Country: strings.ToUpper(country),
+			}
+		}
+	}
+	return search.Address{Line1: addr}
+}
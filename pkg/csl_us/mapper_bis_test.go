@@ -0,0 +1,85 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package csl_us
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadBIS(t *testing.T) {
+	fd, err := os.Open(filepath.Join("testdata", "consolidated.csv.gz"))
+	require.NoError(t, err)
+
+	records, err := ReadBIS(map[string]io.ReadCloser{"consolidated.csv.gz": fd})
+	require.NoError(t, err)
+
+	lists := make(map[string]int)
+	for _, record := range records {
+		lists[record.List]++
+	}
+	require.Equal(t, map[string]int{BISDeniedPersons: 462, BISEntityList: 2001, BISUnverified: 201}, lists)
+
+	_, err = ReadBIS(map[string]io.ReadCloser{"other.csv": io.NopCloser(strings.NewReader(""))})
+	require.ErrorContains(t, err, "missing consolidated.csv")
+}
+
+func TestConvertBIS(t *testing.T) {
+	input := strings.NewReader(strings.TrimSpace(`
+_id,source,entity_number,type,programs,name,title,addresses,federal_register_notice,start_date,end_date,standard_order,license_requirement,license_policy,call_sign,vessel_type,gross_tonnage,gross_registered_tonnage,vessel_flag,vessel_owner,remarks,source_list_url,alt_names,citizenships,dates_of_birth,nationalities,places_of_birth,source_information_url,ids
+4398d33e9e93c7477af39f1e0bfffc13ddd47bcc,Entity List (EL) - Bureau of Industry and Security,,,,140 Repair Plant JSC,,"19 Luysi Chalovskoy St., Borisov, 222512, BY",87 FR 20299,2022-04-07,,,"For all items subject to the EAR. (See §§734.9(g),3 746.8(a)(3), and 744.21(b) of the EAR.)",Policy of denial. ,,,,,,,,http://bit.ly/1L47xrV,Open Joint Stock Company 140 Repair Plant; and JSC 140 Repair Plant,,,,,http://bit.ly/1L47xrV,
+0dbd98f1969cb28bbe39669ea97c5ca9d0cac0f4,Denied Persons List (DPL) - Bureau of Industry and Security,,,,ABDIEL PADRON MADRID,,"INMATE NUMBER: 42167-480, FCI LA TUNA FEDERAL CORRECTIONAL INSTITUTION, P.O. BOX 3000, ANTHONY, NM, 88201",87 F.R. 9030 2/17/2022,2022-02-10,2030-06-17,,,,,,,,,,F.R. NOTICE ADDED,http://bit.ly/1Qi5heF,,,,,,http://bit.ly/1iwxiF0,
+a1b2,Denied Persons List (DPL) - Bureau of Industry and Security,,,,"AL NASER WINGS AIRLINES",,"P.O. BOX 28360, DUBAI, AE; P.O. BOX 911399, AMMAN, 11191, JO",,,,,,,,,,,,,,,,,,,,,
+1ef5e3e12d352fe44c823c7af18d382680c493e0,Unverified List (UVL) - Bureau of Industry and Security,,,,AECC South Industry Co. Ltd,,"Dongjiaduan, Lusong District, Zhuzhou, Hunan Province, China, CN",,,,,,,,,,,,,,http://bit.ly/1iwwTSJ,,,,,,http://bit.ly/1Qi4R7Z,
+21944,Specially Designated Nationals (SDN) - Treasury Department,21944,Individual,SYRIA,"'ABBAS, Rim",,SY,,,,,,,,,,,,,,http://bit.ly/1I7ipyR,"","",1973-03-25,SY,"",http://bit.ly/1MLgpye,""`))
+
+	records, err := parseBIS(input)
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+
+	entities := ConvertBIS(records)
+	require.Len(t, entities, 4)
+
+	el := entities[0]
+	require.Equal(t, search.SourceUSBIS, el.Source)
+	require.Equal(t, "4398d33e9e93c7477af39f1e0bfffc13ddd47bcc", el.SourceID)
+	require.Equal(t, search.EntityBusiness, el.Type)
+	require.Equal(t, "140 Repair Plant JSC", el.Business.Name)
+	require.Equal(t, []string{"Open Joint Stock Company 140 Repair Plant", "and JSC 140 Repair Plant"}, el.Business.AltNames)
+	require.Equal(t, []search.Address{{Line1: "19 Luysi Chalovskoy St., Borisov, 222512", Country: "BY"}}, el.Addresses)
+	require.Equal(t, &search.SanctionsInfo{
+		Lists:              []string{"EL"},
+		Regulations:        []string{"87 FR 20299"},
+		LicenseRequirement: "For all items subject to the EAR. (See §§734.9(g),3 746.8(a)(3), and 744.21(b) of the EAR.)",
+		LicensePolicy:      "Policy of denial.",
+	}, el.SanctionsInfo)
+
+	dpl := entities[1]
+	require.Equal(t, search.EntityPerson, dpl.Type)
+	require.Equal(t, "ABDIEL PADRON MADRID", dpl.Person.Name)
+	require.Equal(t, "F.R. NOTICE ADDED", dpl.SanctionsInfo.Description)
+	require.Equal(t, []string{"DPL"}, dpl.SanctionsInfo.Lists)
+	require.Equal(t, []search.Address{
+		{Line1: "INMATE NUMBER: 42167-480, FCI LA TUNA FEDERAL CORRECTIONAL INSTITUTION, P.O. BOX 3000, ANTHONY, NM, 88201"},
+	}, dpl.Addresses) // no country code
+
+	airline := entities[2]
+	require.Equal(t, search.EntityBusiness, airline.Type)
+	require.Equal(t, []search.Address{
+		{Line1: "P.O. BOX 28360, DUBAI", Country: "AE"},
+		{Line1: "P.O. BOX 911399, AMMAN, 11191", Country: "JO"},
+	}, airline.Addresses)
+
+	uvl := entities[3]
+	require.Equal(t, search.EntityBusiness, uvl.Type)
+	require.Equal(t, []string{"UVL"}, uvl.SanctionsInfo.Lists)
+}
@@ -55,11 +55,11 @@ func TestMapPersonFeaturesFromXML(t *testing.T) {
 		t.Errorf("BirthDate.Year() = %d, want %d", person.BirthDate.Year(), wantYear)
 	}
 
-	// // Test place of birth
-	// wantPlace := "Umm Tuba"
-	// if person.PlaceOfBirth != wantPlace {
-	// 	t.Errorf("PlaceOfBirth = %s, want %s", person.PlaceOfBirth, wantPlace)
-	// }
+	// Test place of birth
+	wantPlace := "Umm Tuba"
+	if person.PlaceOfBirth != wantPlace {
+		t.Errorf("PlaceOfBirth = %s, want %s", person.PlaceOfBirth, wantPlace)
+	}
 }
 
 func TestMapPersonFeaturesEdgeCases(t *testing.T) {
@@ -146,10 +146,10 @@ func TestMapPersonFeaturesEdgeCases(t *testing.T) {
 				t.Errorf("BirthDate = %v, want %v", person.BirthDate, tt.wantDate)
 			}
 
-			// // Check place
-			// if person.PlaceOfBirth != tt.wantPlace {
-			// 	t.Errorf("PlaceOfBirth = %v, want %v", person.PlaceOfBirth, tt.wantPlace)
-			// }
+			// Check place
+			if person.PlaceOfBirth != tt.wantPlace {
+				t.Errorf("PlaceOfBirth = %v, want %v", person.PlaceOfBirth, tt.wantPlace)
+			}
 
 			// Check gender
 			if person.Gender != tt.wantGender {
@@ -5,12 +5,14 @@
 package csl_us
 
 import (
+	"context"
 	"encoding/xml"
 	"testing"
 
 	"github.com/moov-io/watchman/pkg/csl_us/gen/ENHANCED_XML"
 	"github.com/moov-io/watchman/pkg/search"
 
+	"github.com/moov-io/base/log"
 	"github.com/stretchr/testify/require"
 )
 
@@ -199,6 +201,80 @@ func TestMapSanctionsProgramsFromXML(t *testing.T) {
 	require.Equal(t, "NS-PLC", got.Programs[0])
 }
 
+func TestMapSectoralSanctionsFromXML(t *testing.T) {
+	entityXML := `
+    <entity id="17014">
+      <features>
+        <feature id="16141">
+          <type featureTypeId="204">Executive Order 13662 Directive Determination -</type>
+          <value>Subject to Directive 1</value>
+          <isPrimary>true</isPrimary>
+        </feature>
+        <feature id="46690">
+          <type featureTypeId="947">Executive Order 14024 Directive Information -</type>
+          <value>For more information on directives, please visit the following link: https://home.treasury.gov/</value>
+          <isPrimary>true</isPrimary>
+        </feature>
+        <feature id="46708">
+          <type featureTypeId="948">Executive Order 14024 Directive Information</type>
+          <value>Subject to Directive 3 - All transactions in, provision of financing for, and other dealings in new debt of longer than 14 days maturity or new equity are prohibited.</value>
+          <isPrimary>true</isPrimary>
+        </feature>
+      </features>
+      <sanctionsLists>
+        <sanctionsList refId="91507" id="39387" datePublished="2014-07-29">Sectoral Sanctions Identifications List</sanctionsList>
+        <sanctionsList refId="91512" id="39389" datePublished="2014-10-10">Consolidated List</sanctionsList>
+      </sanctionsLists>
+      <sanctionsPrograms>
+        <sanctionsProgram refId="91502" id="17792">UKRAINE-EO13662</sanctionsProgram>
+        <sanctionsProgram refId="92042" id="37820">RUSSIA-EO14024</sanctionsProgram>
+      </sanctionsPrograms>
+    </entity>`
+
+	var entity ENHANCED_XML.EntitiesEntity
+	if err := xml.Unmarshal([]byte(entityXML), &entity); err != nil {
+		t.Fatalf("failed to unmarshal entity XML: %v", err)
+	}
+
+	got := mapSanctionsInfo(entity)
+	require.True(t, got.Sectoral)
+	require.Equal(t, []string{"Subject to Directive 1", "Subject to Directive 3"}, got.Directives)
+	require.Equal(t, []string{"UKRAINE-EO13662", "RUSSIA-EO14024"}, got.Programs)
+
+	// Entities also on the SDN list are blocked
+	entity.SanctionsLists.SanctionsList = append(entity.SanctionsLists.SanctionsList, ENHANCED_XML.SanctionsListsSanctionsList{
+		Text: "SDN List",
+	})
+	got = mapSanctionsInfo(entity)
+	require.False(t, got.Sectoral)
+}
+
+func TestMapCMIC(t *testing.T) {
+	files, err := Download(context.Background(), log.NewTestLogger(), "testdata")
+	require.NoError(t, err)
+
+	data, err := Read(files)
+	require.NoError(t, err)
+
+	var found *search.Entity[search.Value]
+	for _, entity := range ConvertSanctionsData(data) {
+		if entity.SourceID == "30882" {
+			found = &entity
+			break
+		}
+	}
+	require.NotNil(t, found)
+
+	require.Equal(t, []string{"NS-CMIC"}, found.SanctionsInfo.Lists)
+	require.Equal(t, []string{"CMIC-EO13959"}, found.SanctionsInfo.Programs)
+	require.Contains(t, found.Business.AltNames, "China Telecom Corporation Limited.Co.Ltd")
+	require.NotContains(t, found.Business.AltNames, "China Telecom Corp Ltd") // listed as an alternate name
+	require.Contains(t, found.Business.GovernmentIDs, search.GovernmentID{
+		Type:       search.GovernmentIDISIN,
+		Identifier: "US1694261033",
+	})
+}
+
 func TestMapIDTypeFromXML(t *testing.T) {
 	idDocXML := `
       <identityDocuments>
@@ -224,3 +300,38 @@ func TestMapIDTypeFromXML(t *testing.T) {
 	}
 	require.Equal(t, want, ids[0])
 }
+
+func TestMapCryptoAddressesFromXML(t *testing.T) {
+	featuresXML := `
+      <features>
+        <feature id="52714">
+          <type featureTypeId="344">Digital Currency Address - XBT</type>
+          <versionId>41195</versionId>
+          <value>12QtD5BFwRsdNsAZY76UVE1xyCGNTojH9h</value>
+          <isPrimary>false</isPrimary>
+        </feature>
+        <feature id="52715">
+          <type featureTypeId="345">Digital Currency Address - ETH</type>
+          <versionId>41196</versionId>
+          <value>0x098B716B8Aaf21512996dC57EB0615e2383E2f96</value>
+          <isPrimary>false</isPrimary>
+        </feature>
+        <feature id="4699">
+          <type featureTypeId="9">Place of Birth</type>
+          <versionId>1613</versionId>
+          <value>Umm Tuba</value>
+          <isPrimary>true</isPrimary>
+        </feature>
+      </features>`
+
+	var features ENHANCED_XML.EntityFeatures
+	require.NoError(t, xml.Unmarshal([]byte(featuresXML), &features))
+
+	got := mapCryptoAddresses(&features)
+	require.Equal(t, []search.CryptoAddress{
+		{Currency: "XBT", Address: "12QtD5BFwRsdNsAZY76UVE1xyCGNTojH9h"},
+		{Currency: "ETH", Address: "0x098B716B8Aaf21512996dC57EB0615e2383E2f96"},
+	}, got)
+
+	require.Nil(t, mapCryptoAddresses(nil))
+}
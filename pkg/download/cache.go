@@ -0,0 +1,122 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package download
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/moov-io/base/log"
+)
+
+type cacheKey struct{}
+
+// WithCache keeps the files downloaded by calls to GetFiles with the returned context in dir, along with
+// their ETag and Last-Modified headers. Later downloads of each file are conditional requests, so files
+// which haven't changed are read from dir rather than downloaded again.
+func WithCache(ctx context.Context, dir string) context.Context {
+	return context.WithValue(ctx, cacheKey{}, &fileCache{dir: dir})
+}
+
+func cacheFrom(ctx context.Context) *fileCache {
+	cache, _ := ctx.Value(cacheKey{}).(*fileCache)
+	return cache
+}
+
+type fileCache struct {
+	dir string
+}
+
+// cachedFile describes the validators of a downloaded file
+type cachedFile struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+func (c *fileCache) paths(downloadURL string) (string, string) {
+	sum := sha256.Sum256([]byte(downloadURL))
+	name := hex.EncodeToString(sum[:16])
+	return filepath.Join(c.dir, name+".data"), filepath.Join(c.dir, name+".json")
+}
+
+// addValidators makes req conditional when a previous download of its file is kept
+func (c *fileCache) addValidators(req *http.Request) {
+	dataPath, metaPath := c.paths(req.URL.String())
+	if _, err := os.Stat(dataPath); err != nil {
+		return
+	}
+	bs, err := os.ReadFile(metaPath)
+	if err != nil {
+		return
+	}
+	var meta cachedFile
+	if json.Unmarshal(bs, &meta) != nil {
+		return
+	}
+	if meta.ETag != "" {
+		req.Header.Set("If-None-Match", meta.ETag)
+	}
+	if meta.LastModified != "" {
+		req.Header.Set("If-Modified-Since", meta.LastModified)
+	}
+}
+
+// open returns the kept file of a 304 Not Modified response
+func (c *fileCache) open(downloadURL string) (io.ReadCloser, error) {
+	dataPath, _ := c.paths(downloadURL)
+	return os.Open(dataPath)
+}
+
+// save copies a successful response into the cache and returns the kept file. The response is read
+// directly when the cache can't be written to.
+func (c *fileCache) save(logger log.Logger, downloadURL string, resp *http.Response) (io.ReadCloser, error) {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		logger.Warn().Logf("not caching download: %v", err)
+		return resp.Body, nil
+	}
+	dataPath, metaPath := c.paths(downloadURL)
+
+	// Write to a temporary file so concurrent reads never see a partial download
+	tmp, err := os.CreateTemp(c.dir, "download-*")
+	if err != nil {
+		logger.Warn().Logf("not caching download: %v", err)
+		return resp.Body, nil
+	}
+	defer resp.Body.Close()
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, resp.Body); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("writing cached file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), dataPath); err != nil {
+		return nil, fmt.Errorf("saving cached file: %w", err)
+	}
+
+	meta, err := json.Marshal(cachedFile{
+		URL:          downloadURL,
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	})
+	if err == nil {
+		err = os.WriteFile(metaPath, meta, 0644)
+	}
+	if err != nil {
+		// Without validators the next download isn't conditional
+		os.Remove(metaPath)
+	}
+	return os.Open(dataPath)
+}
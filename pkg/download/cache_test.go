@@ -0,0 +1,61 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package download
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloader_WithCache(t *testing.T) {
+	var downloads, notModified atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloads.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("sdn data"))
+	}))
+	defer srv.Close()
+
+	dl := New(log.NewTestLogger(), srv.Client())
+	ctx := WithCache(context.Background(), t.TempDir())
+
+	read := func() string {
+		files, err := dl.GetFiles(ctx, "", map[string]string{
+			"sdn.csv": srv.URL + "/sdn.csv",
+		})
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		defer files["sdn.csv"].Close()
+
+		bs, err := io.ReadAll(files["sdn.csv"])
+		require.NoError(t, err)
+		return string(bs)
+	}
+
+	require.Equal(t, "sdn data", read())
+	require.Equal(t, "sdn data", read())
+	require.Equal(t, int32(1), downloads.Load())
+	require.Equal(t, int32(1), notModified.Load())
+
+	// Without the cache every request downloads the file
+	files, err := dl.GetFiles(context.Background(), "", map[string]string{
+		"sdn.csv": srv.URL + "/sdn.csv",
+	})
+	require.NoError(t, err)
+	files["sdn.csv"].Close()
+	require.Equal(t, int32(2), downloads.Load())
+}
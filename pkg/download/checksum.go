@@ -0,0 +1,108 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package download
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrChecksumMismatch is returned by GetFiles when a file's contents don't match its expected SHA-256
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// ChecksumSuffix is added to a file's name for the file holding its SHA-256, in the format written by sha256sum.
+// Files read from the initial directory are checked against the checksum file next to them.
+const ChecksumSuffix = ".sha256"
+
+type checksumsKey struct{}
+
+// WithChecksums makes calls to GetFiles with the returned context check each file against the SHA-256 in checksums,
+// keyed by file name (e.g. sdn.csv), whether it's downloaded or read from the initial directory
+func WithChecksums(ctx context.Context, checksums map[string]string) context.Context {
+	normalized := make(map[string]string, len(checksums))
+	for name, sum := range checksums {
+		normalized[strings.ToLower(name)] = strings.TrimSpace(sum)
+	}
+	return context.WithValue(ctx, checksumsKey{}, normalized)
+}
+
+func expectedChecksum(ctx context.Context, name string) string {
+	checksums, _ := ctx.Value(checksumsKey{}).(map[string]string)
+	return checksums[strings.ToLower(name)]
+}
+
+// Checksum returns the hex encoded SHA-256 of r
+func Checksum(r io.Reader) (string, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// readChecksumFile returns the SHA-256 in path, which is empty when there's no such file
+func readChecksumFile(path string) (string, error) {
+	bs, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	fields := strings.Fields(string(bs))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty checksum file %s", path)
+	}
+	return fields[0], nil
+}
+
+// verifyChecksum reads the whole of content, returning a reader of the same bytes when its SHA-256 is expected.
+// Seekable content is rewound rather than held in memory.
+func verifyChecksum(name string, content io.ReadCloser, expected string) (io.ReadCloser, error) {
+	var r io.Reader = content
+
+	seeker, seekable := content.(io.ReadSeeker)
+	var buf *bytes.Buffer
+	if !seekable {
+		buf = new(bytes.Buffer)
+		r = io.TeeReader(content, buf)
+	}
+
+	sum, err := Checksum(r)
+	if err != nil {
+		content.Close()
+		return nil, fmt.Errorf("reading %s: %w", name, err)
+	}
+	if !strings.EqualFold(sum, expected) {
+		content.Close()
+		return nil, fmt.Errorf("%w: %s has SHA-256 %s rather than %s", ErrChecksumMismatch, name, sum, expected)
+	}
+
+	if seekable {
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			content.Close()
+			return nil, fmt.Errorf("rewinding %s: %w", name, err)
+		}
+		return content, nil
+	}
+	content.Close()
+	return memoryFile{bytes.NewReader(buf.Bytes())}, nil
+}
+
+// memoryFile is a downloaded file held in memory, which can still be read again
+type memoryFile struct {
+	*bytes.Reader
+}
+
+func (memoryFile) Close() error {
+	return nil
+}
@@ -0,0 +1,91 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package download
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	// SHA-256 of "hello, world"
+	helloChecksum = "09ca7e4eaa6e8ae9c7d261167129184883644d07dfba7cbfbc4c8a2e08360d5b"
+)
+
+func TestDownloader_Checksums(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("hello, world"))
+	}))
+	defer server.Close()
+
+	dl := New(log.NewTestLogger(), server.Client())
+	sources := map[string]string{
+		"sdn.csv": server.URL + "/sdn.csv",
+	}
+
+	ctx := WithChecksums(context.Background(), map[string]string{"SDN.CSV": strings.ToUpper(helloChecksum)})
+	files, err := dl.GetFiles(ctx, "", sources)
+	require.NoError(t, err)
+
+	// Checked files can still be read and read again
+	bs, err := io.ReadAll(files["sdn.csv"])
+	require.NoError(t, err)
+	require.Equal(t, "hello, world", string(bs))
+	_, ok := files["sdn.csv"].(io.ReadSeeker)
+	require.True(t, ok)
+
+	// Tampered or truncated downloads are rejected
+	ctx = WithChecksums(context.Background(), map[string]string{"sdn.csv": strings.Repeat("0", 64)})
+	_, err = dl.GetFiles(ctx, "", sources)
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+	require.ErrorContains(t, err, "sdn.csv has SHA-256 "+helloChecksum)
+}
+
+func TestDownloader_ChecksumFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sdn.csv"), []byte("hello, world"), 0600))
+
+	dl := New(log.NewTestLogger(), nil)
+	ctx := WithOffline(context.Background())
+	sources := map[string]string{
+		"sdn.csv": "http://lists.example.com/sdn.csv",
+	}
+
+	// Files without a checksum file aren't checked
+	files, err := dl.GetFiles(ctx, dir, sources)
+	require.NoError(t, err)
+	files["sdn.csv"].Close()
+
+	// sha256sum's format is read
+	checksumFile := filepath.Join(dir, "sdn.csv"+ChecksumSuffix)
+	require.NoError(t, os.WriteFile(checksumFile, []byte(helloChecksum+"  sdn.csv\n"), 0600))
+
+	files, err = dl.GetFiles(ctx, dir, sources)
+	require.NoError(t, err)
+	bs, err := io.ReadAll(files["sdn.csv"])
+	require.NoError(t, err)
+	require.Equal(t, "hello, world", string(bs))
+	files["sdn.csv"].Close()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sdn.csv"), []byte("hello"), 0600))
+	_, err = dl.GetFiles(ctx, dir, sources)
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+
+	// Expected checksums are used over checksum files
+	sum, err := Checksum(strings.NewReader("hello"))
+	require.NoError(t, err)
+	files, err = dl.GetFiles(WithChecksums(ctx, map[string]string{"sdn.csv": sum}), dir, sources)
+	require.NoError(t, err)
+	files["sdn.csv"].Close()
+}
@@ -0,0 +1,175 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package download
+
+import (
+	"context"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewHTTPClient(t *testing.T) {
+	client, err := NewHTTPClient(ClientConfig{})
+	require.NoError(t, err)
+	require.Equal(t, 45*time.Second, client.Timeout)
+
+	client, err = NewHTTPClient(ClientConfig{Timeout: time.Minute})
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, client.Timeout)
+
+	_, err = NewHTTPClient(ClientConfig{ProxyURL: "proxy:3128"})
+	require.ErrorContains(t, err, "invalid proxy url")
+
+	_, err = NewHTTPClient(ClientConfig{CABundle: filepath.Join(t.TempDir(), "missing.pem")})
+	require.ErrorContains(t, err, "reading CA bundle")
+
+	empty := filepath.Join(t.TempDir(), "empty.pem")
+	require.NoError(t, os.WriteFile(empty, nil, 0600))
+	_, err = NewHTTPClient(ClientConfig{CABundle: empty})
+	require.ErrorContains(t, err, "no certificates found")
+}
+
+func TestNewHTTPClient_CABundle(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("sdn data"))
+	}))
+	defer srv.Close()
+
+	// The test server's certificate isn't trusted by default
+	client, err := NewHTTPClient(ClientConfig{})
+	require.NoError(t, err)
+	_, err = client.Get(srv.URL)
+	require.Error(t, err)
+
+	bundle := filepath.Join(t.TempDir(), "ca.pem")
+	cert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	require.NoError(t, os.WriteFile(bundle, cert, 0600))
+
+	client, err = NewHTTPClient(ClientConfig{CABundle: bundle})
+	require.NoError(t, err)
+
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestDownloader_WithHTTPClient(t *testing.T) {
+	var proxied atomic.Int32
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Proxied requests carry the absolute URL and the credentials from the proxy URL
+		user, pass, _ := parseProxyAuth(r)
+		if user != "watchman" || pass != "secret" {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+			return
+		}
+		proxied.Add(1)
+		w.Write([]byte(r.URL.String()))
+	}))
+	defer proxy.Close()
+
+	client, err := NewHTTPClient(ClientConfig{
+		ProxyURL: "http://watchman:secret@" + proxy.Listener.Addr().String(),
+	})
+	require.NoError(t, err)
+
+	dl := New(log.NewTestLogger(), &http.Client{})
+	ctx := WithHTTPClient(context.Background(), client)
+
+	files, err := dl.GetFiles(ctx, "", map[string]string{
+		"sdn.csv": "http://lists.example.com/sdn.csv",
+	})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	bs, err := io.ReadAll(files["sdn.csv"])
+	require.NoError(t, err)
+	require.Equal(t, "http://lists.example.com/sdn.csv", string(bs))
+	require.Equal(t, int32(1), proxied.Load())
+}
+
+func parseProxyAuth(r *http.Request) (string, string, bool) {
+	req := &http.Request{Header: http.Header{"Authorization": r.Header.Values("Proxy-Authorization")}}
+	return req.BasicAuth()
+}
+
+func TestDownloader_Retry(t *testing.T) {
+	defer func(backoff time.Duration) { retryBackoff = backoff }(retryBackoff)
+	retryBackoff = time.Millisecond
+
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch attempts.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.Write([]byte("sdn data"))
+		}
+	}))
+	defer srv.Close()
+
+	dl := New(log.NewTestLogger(), srv.Client())
+	body, err := dl.retryDownload(context.Background(), srv.Client(), srv.URL)
+	require.NoError(t, err)
+	defer body.Close()
+
+	bs, err := io.ReadAll(body)
+	require.NoError(t, err)
+	require.Equal(t, "sdn data", string(bs))
+	require.Equal(t, int32(3), attempts.Load())
+
+	// Client errors aren't retried
+	attempts.Store(0)
+	notFound := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer notFound.Close()
+
+	_, err = dl.retryDownload(context.Background(), notFound.Client(), notFound.URL)
+	require.ErrorContains(t, err, "404")
+	require.Equal(t, int32(1), attempts.Load())
+
+	// Failures give up after maxAttempts
+	attempts.Store(0)
+	unavailable := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unavailable.Close()
+
+	_, err = dl.retryDownload(context.Background(), unavailable.Client(), unavailable.URL)
+	require.ErrorContains(t, err, "max retries reached")
+	require.Equal(t, int32(maxAttempts), attempts.Load())
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := 1; attempt < 10; attempt++ {
+		wait := backoff(attempt, 0)
+		require.Greater(t, wait, time.Duration(0))
+		require.LessOrEqual(t, wait, maxRetryBackoff)
+	}
+	require.GreaterOrEqual(t, backoff(1, 500*time.Millisecond), 500*time.Millisecond)
+
+	// Retry-After is honored up to maxRetryBackoff
+	require.Equal(t, 10*time.Second, backoff(1, 10*time.Second))
+	require.Equal(t, maxRetryBackoff, backoff(1, time.Hour))
+
+	require.Equal(t, 5*time.Second, parseRetryAfter("5"))
+	require.Equal(t, time.Duration(0), parseRetryAfter("soon"))
+	require.Greater(t, parseRetryAfter(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)), 50*time.Second)
+}
@@ -0,0 +1,30 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package fincen
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/strx"
+	"github.com/moov-io/watchman/pkg/download"
+)
+
+var (
+	publicSpecialMeasuresURL = "https://www.fincen.gov/resources/statutes-and-regulations/311-and-9714-special-measures"
+	specialMeasuresURL       = strx.Or(os.Getenv("FINCEN_311_DOWNLOAD_URL"), publicSpecialMeasuresURL)
+)
+
+// Download fetches FinCEN's page of special measures, which publishes them as a table rather than a data file
+func Download(ctx context.Context, logger log.Logger, initialDir string) (map[string]io.ReadCloser, error) {
+	dl := download.New(logger, download.HTTPClient)
+
+	namesAndSources := make(map[string]string)
+	namesAndSources["311-special-measures.html"] = specialMeasuresURL
+
+	return dl.GetFiles(ctx, initialDir, namesAndSources)
+}
@@ -0,0 +1,38 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package fincen
+
+// SpecialMeasures are the foreign financial institutions and jurisdictions FinCEN has found to be of primary money
+// laundering concern under Section 311 of the USA PATRIOT Act, or under Section 9714 of the Combating Russian Money
+// Laundering Act, along with the special measures imposed on them. US banks can't hold correspondent accounts for
+// most of them.
+//
+// https://www.fincen.gov/resources/statutes-and-regulations/311-and-9714-special-measures
+type SpecialMeasures struct {
+	Designations []Designation
+}
+
+const (
+	Section311  = "311"
+	Section9714 = "9714"
+)
+
+// Designation is one row of FinCEN's table of special measures. Each date is written as it's published,
+// such as "05/01/2024", and is empty when that step wasn't taken.
+type Designation struct {
+	Name string `json:"name"`
+
+	// Section is the law the designation was made under, Section311 or Section9714
+	Section string `json:"section"`
+
+	// Finding is when FinCEN found the institution to be of primary money laundering concern, or issued its
+	// order for those designated under Section 9714
+	Finding      string `json:"finding,omitempty"`
+	ProposedRule string `json:"proposedRule,omitempty"`
+	FinalRule    string `json:"finalRule,omitempty"`
+
+	// Rescinded is set when the special measures were withdrawn
+	Rescinded string `json:"rescinded,omitempty"`
+}
@@ -0,0 +1,170 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package fincen
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+func ConvertSpecialMeasures(data *SpecialMeasures) []search.Entity[search.Value] {
+	if data == nil {
+		return nil
+	}
+
+	out := make([]search.Entity[search.Value], 0, len(data.Designations))
+	for _, designation := range data.Designations {
+		if designation.Rescinded != "" {
+			continue
+		}
+		entity, ok := ToEntity(designation)
+		if ok {
+			out = append(out, entity)
+		}
+	}
+	return out
+}
+
+// ToEntity converts a designation into a search Entity. Jurisdictions, such as Burma or Iran, are organizations
+// in the country they name, and every other designation is a business.
+func ToEntity(src Designation) (search.Entity[search.Value], bool) {
+	name, altNames := splitName(src.Name)
+	if name == "" {
+		return search.Entity[search.Value]{}, false
+	}
+
+	out := search.Entity[search.Value]{
+		Name:       name,
+		Source:     search.SourceUSFinCEN311,
+		SourceID:   sourceID(name),
+		SourceData: src,
+	}
+
+	concern := "Financial institution"
+	if code, ok := search.CountryCode(name); ok {
+		concern = "Jurisdiction"
+		out.Type = search.EntityOrganization
+		out.Organization = &search.Organization{
+			Name:     name,
+			AltNames: altNames,
+		}
+		out.Addresses = []search.Address{{Country: code}}
+	} else {
+		out.Type = search.EntityBusiness
+		out.Business = &search.Business{
+			Name:     name,
+			AltNames: altNames,
+		}
+	}
+
+	program := "Section " + src.Section
+	out.SanctionsInfo = &search.SanctionsInfo{
+		Programs:    []string{program},
+		Description: description(src, concern),
+	}
+	if since, ok := parseDate(src.FinalRule); ok {
+		out.SanctionsInfo.ProgramDates = map[string]time.Time{program: since}
+	} else if since, ok := parseDate(src.Finding); ok {
+		out.SanctionsInfo.ProgramDates = map[string]time.Time{program: since}
+	}
+	out.Remarks = out.SanctionsInfo.Description
+
+	return out, true
+}
+
+// description says what FinCEN found and which of its rules have been published
+func description(src Designation, concern string) string {
+	law := "Section 311 of the USA PATRIOT Act"
+	if src.Section == Section9714 {
+		law = "Section 9714 of the Combating Russian Money Laundering Act"
+	}
+	parts := []string{fmt.Sprintf("%s of primary money laundering concern under %s", concern, law)}
+	if src.Finding != "" {
+		parts = append(parts, "finding "+src.Finding)
+	}
+	if src.ProposedRule != "" {
+		parts = append(parts, "proposed rule "+src.ProposedRule)
+	}
+	if src.FinalRule != "" {
+		parts = append(parts, "final rule imposing special measures "+src.FinalRule)
+	}
+	return strings.Join(parts, "; ")
+}
+
+var akaPrefixes = []string{"a.k.a.", "aka", "also known as", "formerly"}
+
+// splitName separates the parentheticals of a designated name, such as "Commercial Bank of Syria (CBS)",
+// into its alternate names. Other parentheticals, such as the subsidiaries a designation includes, are dropped.
+func splitName(value string) (string, []string) {
+	var altNames []string
+	var name strings.Builder
+	for value != "" {
+		open := strings.Index(value, "(")
+		if open < 0 {
+			name.WriteString(value)
+			break
+		}
+		end := strings.Index(value[open:], ")")
+		if end < 0 {
+			name.WriteString(value[:open])
+			break
+		}
+		name.WriteString(value[:open])
+		altNames = append(altNames, parentheticalNames(value[open+1:open+end])...)
+		value = value[open+end+1:]
+	}
+	return strings.Join(strings.Fields(name.String()), " "), altNames
+}
+
+func parentheticalNames(value string) []string {
+	value = strings.TrimSpace(value)
+	aka := isAcronym(value)
+	for _, prefix := range akaPrefixes {
+		if len(value) > len(prefix) && strings.EqualFold(value[:len(prefix)], prefix) {
+			value = strings.TrimSpace(value[len(prefix):])
+			aka = true
+		}
+	}
+	if !aka {
+		return nil
+	}
+
+	var out []string
+	for _, name := range strings.Split(value, ";") {
+		if name = strings.TrimSpace(name); name != "" {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// isAcronym returns true for names written without lowercase letters, such as "CBS" or "PM2BTC"
+func isAcronym(value string) bool {
+	return value != "" && !strings.ContainsFunc(value, unicode.IsLower) && !strings.ContainsFunc(value, unicode.IsSpace)
+}
+
+// sourceID is the name of a designation in lowercase with dashes, since FinCEN doesn't number them
+func sourceID(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}), "-")
+}
+
+var dateRegex = regexp.MustCompile(`\d{1,2}/\d{1,2}/\d{4}`)
+
+// parseDate reads the first date of a cell, which are written like "05/01/2024" and often alongside the
+// Federal Register citation of the rule
+func parseDate(value string) (time.Time, bool) {
+	t, err := time.Parse("1/2/2006", dateRegex.FindString(value))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
@@ -0,0 +1,77 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package fincen
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertSpecialMeasures(t *testing.T) {
+	files, err := Download(context.Background(), log.NewNopLogger(), "testdata")
+	require.NoError(t, err)
+
+	data, err := Read(files)
+	require.NoError(t, err)
+
+	// The rescinded designation is skipped
+	entities := ConvertSpecialMeasures(data)
+	require.Len(t, entities, 5)
+
+	burma := entities[0]
+	require.Equal(t, search.SourceUSFinCEN311, burma.Source)
+	require.Equal(t, "burma", burma.SourceID)
+	require.Equal(t, search.EntityOrganization, burma.Type)
+	require.Equal(t, "Burma", burma.Organization.Name)
+	require.Equal(t, []search.Address{{Country: "MM"}}, burma.Addresses)
+	require.Equal(t, []string{"Section 311"}, burma.SanctionsInfo.Programs)
+	require.Equal(t, map[string]time.Time{
+		"Section 311": time.Date(2004, time.April, 12, 0, 0, 0, 0, time.UTC),
+	}, burma.SanctionsInfo.ProgramDates)
+	require.Contains(t, burma.SanctionsInfo.Description, "Jurisdiction of primary money laundering concern under Section 311")
+
+	bank := entities[1]
+	require.Equal(t, search.EntityBusiness, bank.Type)
+	require.Equal(t, "commercial-bank-of-syria", bank.SourceID)
+	require.Equal(t, "Commercial Bank of Syria", bank.Business.Name)
+	require.Equal(t, []string{"CBS"}, bank.Business.AltNames)
+
+	// Without a final rule the program dates from the finding
+	huione := entities[2]
+	require.Equal(t, time.Date(2025, time.May, 1, 0, 0, 0, 0, time.UTC), huione.SanctionsInfo.ProgramDates["Section 311"])
+
+	bitzlato := entities[3]
+	require.Equal(t, "Bitzlato Limited", bitzlato.Name)
+	require.Equal(t, []string{"Bitzlato"}, bitzlato.Business.AltNames)
+	require.Equal(t, []string{"Section 9714"}, bitzlato.SanctionsInfo.Programs)
+	require.Contains(t, bitzlato.SanctionsInfo.Description, "Combating Russian Money Laundering Act")
+
+	require.Equal(t, "PM2BTC", entities[4].Name)
+}
+
+func TestSplitName(t *testing.T) {
+	cases := []struct {
+		input    string
+		name     string
+		altNames []string
+	}{
+		{"Huione Group", "Huione Group", nil},
+		{"Banco Delta Asia (BDA)", "Banco Delta Asia", []string{"BDA"}},
+		{"ABLV Bank, AS (formerly Aizkraukles Banka)", "ABLV Bank, AS", []string{"Aizkraukles Banka"}},
+		{"Al Huda Bank (including its branches)", "Al Huda Bank", nil},
+		{"Bank (unclosed", "Bank", nil},
+	}
+	for _, tc := range cases {
+		name, altNames := splitName(tc.input)
+		require.Equal(t, tc.name, name, tc.input)
+		require.Equal(t, tc.altNames, altNames, tc.input)
+	}
+}
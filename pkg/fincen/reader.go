@@ -0,0 +1,137 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package fincen
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/antchfx/htmlquery"
+	"golang.org/x/net/html"
+)
+
+func Read(files map[string]io.ReadCloser) (*SpecialMeasures, error) {
+	for filename, contents := range files {
+		switch strings.ToLower(filename) {
+		case "311-special-measures.html":
+			return parseHTML(filename, contents)
+		default:
+			return nil, fmt.Errorf("unknown file %s", filename)
+		}
+	}
+	return nil, errors.New("no files provided")
+}
+
+// column is what a cell of the table holds, read from the table's header
+type column int
+
+const (
+	columnUnknown column = iota
+	columnName
+	columnFinding
+	columnProposedRule
+	columnFinalRule
+	columnRescinded
+)
+
+func parseHTML(filename string, contents io.ReadCloser) (*SpecialMeasures, error) {
+	if contents == nil {
+		return nil, fmt.Errorf("%s is empty or missing", filename)
+	}
+	defer contents.Close()
+
+	doc, err := htmlquery.Parse(contents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	tables, err := htmlquery.QueryAll(doc, `//table`)
+	if err != nil {
+		return nil, fmt.Errorf("finding tables of %s: %w", filename, err)
+	}
+
+	var out SpecialMeasures
+	for _, table := range tables {
+		columns := readColumns(table)
+		if len(columns) == 0 || columns[0] != columnName || !slices.Contains(columns, columnFinding) {
+			continue // not a table of special measures
+		}
+
+		// Section 9714 designations are published under their own heading
+		section := Section311
+		if heading := htmlquery.FindOne(table, `preceding::*[self::h2 or self::h3 or self::h4][1]`); heading != nil {
+			if strings.Contains(htmlquery.InnerText(heading), Section9714) {
+				section = Section9714
+			}
+		}
+
+		for _, row := range htmlquery.Find(table, `.//tr[td]`) {
+			d := Designation{Section: section}
+			for i, cell := range htmlquery.Find(row, `./td`) {
+				if i >= len(columns) {
+					break
+				}
+				text := cellText(cell)
+				switch columns[i] {
+				case columnName:
+					d.Name = text
+				case columnFinding:
+					d.Finding = text
+				case columnProposedRule:
+					d.ProposedRule = text
+				case columnFinalRule:
+					d.FinalRule = text
+				case columnRescinded:
+					d.Rescinded = text
+				}
+			}
+			if strings.Contains(d.Name, Section9714) {
+				d.Section = Section9714
+			}
+			if d.Name != "" {
+				out.Designations = append(out.Designations, d)
+			}
+		}
+	}
+	if len(out.Designations) == 0 {
+		return nil, fmt.Errorf("no special measures found in %s", filename)
+	}
+	return &out, nil
+}
+
+// readColumns reads what each column of a table holds from its header cells
+func readColumns(table *html.Node) []column {
+	var out []column
+	for _, cell := range htmlquery.Find(table, `(.//tr[th])[1]/th`) {
+		header := strings.ToLower(cellText(cell))
+		switch {
+		case strings.Contains(header, "rescind"):
+			out = append(out, columnRescinded)
+		case strings.Contains(header, "final"):
+			out = append(out, columnFinalRule)
+		case strings.Contains(header, "proposed") || strings.Contains(header, "nprm"):
+			out = append(out, columnProposedRule)
+		case strings.Contains(header, "finding") || strings.Contains(header, "order"):
+			out = append(out, columnFinding)
+		case len(out) == 0:
+			out = append(out, columnName) // institution, jurisdiction or entity
+		default:
+			out = append(out, columnUnknown)
+		}
+	}
+	return out
+}
+
+// cellText returns the text of a cell with its whitespace collapsed, and empty for placeholders such as "N/A"
+func cellText(cell *html.Node) string {
+	text := strings.Join(strings.Fields(htmlquery.InnerText(cell)), " ")
+	switch strings.ToLower(text) {
+	case "-", "n/a", "none":
+		return ""
+	}
+	return text
+}
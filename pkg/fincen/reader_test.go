@@ -0,0 +1,54 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package fincen
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base/log"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	files, err := Download(context.Background(), log.NewNopLogger(), "testdata")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	data, err := Read(files)
+	require.NoError(t, err)
+	require.Len(t, data.Designations, 6)
+
+	require.Equal(t, Designation{
+		Name:         "Burma",
+		Section:      Section311,
+		Finding:      "11/25/2003",
+		ProposedRule: "11/25/2003 68 FR 66305",
+		FinalRule:    "04/12/2004 69 FR 19093",
+	}, data.Designations[0])
+
+	require.Equal(t, "Commercial Bank of Syria (CBS) (including Syrian Lebanese Commercial Bank)", data.Designations[1].Name)
+	require.Empty(t, data.Designations[2].FinalRule)
+	require.Equal(t, "08/09/2020", data.Designations[3].Rescinded)
+
+	require.Equal(t, Designation{
+		Name:      "Bitzlato Limited (a.k.a. Bitzlato)",
+		Section:   Section9714,
+		Finding:   "01/18/2023",
+		FinalRule: "01/18/2023",
+	}, data.Designations[4])
+	require.Equal(t, Section9714, data.Designations[5].Section)
+}
+
+func TestRead_NoSpecialMeasures(t *testing.T) {
+	files := map[string]io.ReadCloser{
+		"311-special-measures.html": io.NopCloser(strings.NewReader("<html><body><p>Page not found</p></body></html>")),
+	}
+	_, err := Read(files)
+	require.ErrorContains(t, err, "no special measures found")
+}
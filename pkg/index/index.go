@@ -0,0 +1,208 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package index embeds Watchman's screening in another Go application. An Index downloads and prepares the
+// lists, then searches them in process the same way as Watchman's /v2/search endpoint.
+//
+//	idx, err := index.New(index.Config{Lists: []search.SourceList{search.SourceUSOFAC}})
+//	stats, err := idx.Refresh(ctx)
+//	matches, err := idx.Search(ctx, query, index.SearchOpts{Limit: 5, MinMatch: 0.9})
+//
+// Lists are only downloaded when Refresh is called, so the application chooses when they're updated.
+package index
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/moov-io/watchman/internal/download"
+	internalsearch "github.com/moov-io/watchman/internal/search"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+)
+
+type Config struct {
+	// Logger records each refresh, nothing is logged by default
+	Logger log.Logger
+
+	// Lists are downloaded by Refresh, us_ofac and us_csl by default
+	Lists []search.SourceList
+
+	// DataDirectory holds list files which are read in place of downloading them. With Offline set
+	// every list must be found there.
+	DataDirectory string
+	Offline       bool
+
+	// Weights change how much each field counts towards scores, see search.ParseWeights
+	Weights search.Weights
+}
+
+// Index holds the entities of the lists and searches them
+type Index struct {
+	logger     log.Logger
+	downloader download.Downloader
+	service    internalsearch.Service
+}
+
+func New(conf Config) (*Index, error) {
+	logger := conf.Logger
+	if logger == nil {
+		logger = log.NewNopLogger()
+	}
+	if len(conf.Lists) == 0 {
+		conf.Lists = []search.SourceList{search.SourceUSOFAC, search.SourceUSCSL}
+	}
+	if err := conf.Weights.Validate(); err != nil {
+		return nil, err
+	}
+
+	dl, err := download.NewDownloader(logger, download.Config{
+		IncludedLists:        conf.Lists,
+		InitialDataDirectory: conf.DataDirectory,
+		Offline:              conf.Offline,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("setting up downloader: %w", err)
+	}
+
+	weights := internalsearch.WeightsConfig{
+		Default: conf.Weights,
+	}
+	return &Index{
+		logger:     logger,
+		downloader: dl,
+		service:    internalsearch.NewServiceWithWeights(logger, weights),
+	}, nil
+}
+
+// Stats describe a refresh of the lists
+type Stats struct {
+	// Lists is how many entities were loaded from each list
+	Lists map[string]int `json:"lists"`
+
+	StartedAt time.Time `json:"startedAt"`
+	EndedAt   time.Time `json:"endedAt"`
+}
+
+// Refresh downloads and prepares the lists, then replaces the searched entities with them.
+// The previous entities are kept, and searched, when an error is returned.
+func (idx *Index) Refresh(ctx context.Context) (Stats, error) {
+	stats, err := idx.downloader.RefreshAll(ctx)
+	if err != nil {
+		return Stats{}, fmt.Errorf("refreshing lists: %w", err)
+	}
+	idx.service.UpdateEntities(stats.Entities)
+
+	idx.logger.Info().Logf("loaded %d entities in %v", len(stats.Entities), stats.EndedAt.Sub(stats.StartedAt))
+
+	return Stats{
+		Lists:     stats.Lists,
+		StartedAt: stats.StartedAt,
+		EndedAt:   stats.EndedAt,
+	}, nil
+}
+
+// Update replaces the searched entities, such as with lists the application loads itself. Their countries
+// are normalized to ISO-3166 codes, as the countries of downloaded lists are.
+func (idx *Index) Update(entities []search.Entity[search.Value]) {
+	prepared := make([]search.Entity[search.Value], len(entities))
+	for i := range entities {
+		prepared[i] = internalsearch.PrepareEntity(nil, entities[i])
+	}
+	idx.service.UpdateEntities(prepared)
+}
+
+// Entities returns every searched entity
+func (idx *Index) Entities() []search.Entity[search.Value] {
+	return idx.service.Entities()
+}
+
+// ListInfo is how many entities are searched from each list, and when they were loaded
+type ListInfo struct {
+	Lists     map[string]int `json:"lists"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
+
+func (idx *Index) ListInfo() ListInfo {
+	info := idx.service.ListInfo()
+	return ListInfo{
+		Lists:     info.Lists,
+		UpdatedAt: info.UpdatedAt,
+	}
+}
+
+var (
+	defaultLimit = 10
+	maxLimit     = 1000
+)
+
+type SearchOpts struct {
+	// Limit is the most results returned, 10 by default and at most 1000. MinMatch is the lowest score returned.
+	Limit    int
+	MinMatch float64
+
+	// Algorithm chooses how names are compared, see search.Algorithms()
+	Algorithm string
+
+	// Explain includes how each result's score was computed
+	Explain bool
+
+	// Highlight includes the words of each result's names which matched the query's name
+	Highlight bool
+
+	// Consolidate merges the records of the same party on different lists into one result,
+	// listing the others in its Related records
+	Consolidate bool
+
+	// ExactFirst only scores the entities with exactly the query's name (in any order or case),
+	// unless none of them match
+	ExactFirst bool
+
+	// Partial only returns the entities with a name containing the query's name, such as "Maduro"
+	Partial bool
+
+	// Weights override the index's weights, for those which are set
+	Weights search.Weights
+
+	// Programs, Countries, EntityTypes and Lists (such as SSI or NS-CMIC) only return entities matching
+	// one of their values
+	Programs    []string
+	Countries   []string
+	EntityTypes []search.EntityType
+	Lists       []string
+}
+
+// Search returns the entities most like query, highest score first
+func (idx *Index) Search(ctx context.Context, query search.Entity[search.Value], opts SearchOpts) ([]search.SearchedEntity[search.Value], error) {
+	query.Source = cmp.Or(query.Source, search.SourceAPIRequest)
+
+	limit := opts.Limit
+	switch {
+	case limit <= 0:
+		limit = defaultLimit
+	case limit > maxLimit:
+		limit = maxLimit
+	}
+
+	return idx.service.Search(ctx, query, internalsearch.SearchOpts{
+		Limit:       limit,
+		MinMatch:    opts.MinMatch,
+		Algorithm:   opts.Algorithm,
+		Explain:     opts.Explain,
+		Highlight:   opts.Highlight,
+		Consolidate: opts.Consolidate,
+		ExactFirst:  opts.ExactFirst,
+		Partial:     opts.Partial,
+		Weights:     opts.Weights,
+		Filters: internalsearch.SearchFilters{
+			Programs:  opts.Programs,
+			Countries: opts.Countries,
+			Types:     opts.EntityTypes,
+			Lists:     opts.Lists,
+		},
+	})
+}
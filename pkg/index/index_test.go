@@ -0,0 +1,100 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package index
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_Refresh(t *testing.T) {
+	ctx := context.Background()
+
+	idx, err := New(Config{
+		Lists:         []search.SourceList{search.SourceUSOFAC},
+		DataDirectory: filepath.Join("..", "ofac", "testdata"),
+		Offline:       true,
+	})
+	require.NoError(t, err)
+
+	stats, err := idx.Refresh(ctx)
+	require.NoError(t, err)
+	require.Greater(t, stats.Lists["us_ofac"], 0)
+	require.Equal(t, stats.Lists, idx.ListInfo().Lists)
+
+	query := search.Entity[search.Value]{
+		Name:   "Nicolas Maduro Moros",
+		Type:   search.EntityPerson,
+		Person: &search.Person{Name: "Nicolas Maduro Moros"},
+	}
+	matches, err := idx.Search(ctx, query, SearchOpts{Limit: 1, MinMatch: 0.9})
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	require.Equal(t, search.SourceUSOFAC, matches[0].Source)
+	require.Greater(t, matches[0].Match, 0.9)
+}
+
+func TestIndex_Update(t *testing.T) {
+	ctx := context.Background()
+
+	idx, err := New(Config{})
+	require.NoError(t, err)
+
+	idx.Update([]search.Entity[search.Value]{
+		{
+			Name:      "Acme Shipping Limited",
+			Type:      search.EntityBusiness,
+			Source:    search.SourceAPIRequest,
+			SourceID:  "acme-1",
+			Business:  &search.Business{Name: "Acme Shipping Limited"},
+			Addresses: []search.Address{{City: "Caracas", Country: "Venezuela"}},
+		},
+		{
+			Name:     "John Smith",
+			Type:     search.EntityPerson,
+			Source:   search.SourceAPIRequest,
+			SourceID: "john-1",
+			Person:   &search.Person{Name: "John Smith"},
+		},
+	})
+	require.Len(t, idx.Entities(), 2)
+	require.Equal(t, "VE", idx.Entities()[0].Addresses[0].Country)
+
+	query := search.Entity[search.Value]{
+		Name:     "Acme Shipping",
+		Type:     search.EntityBusiness,
+		Business: &search.Business{Name: "Acme Shipping"},
+	}
+	matches, err := idx.Search(ctx, query, SearchOpts{MinMatch: 0.5})
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	require.Equal(t, "acme-1", matches[0].SourceID)
+
+	matches, err = idx.Search(ctx, query, SearchOpts{EntityTypes: []search.EntityType{search.EntityPerson}})
+	require.NoError(t, err)
+	for _, match := range matches {
+		require.Equal(t, search.EntityPerson, match.Type)
+	}
+
+	// Limits outside of 1 to 1000 are clamped
+	matches, err = idx.Search(ctx, query, SearchOpts{Limit: -1})
+	require.NoError(t, err)
+	require.Equal(t, "acme-1", matches[0].SourceID)
+
+	matches, err = idx.Search(ctx, query, SearchOpts{Limit: 1_000_000_000})
+	require.NoError(t, err)
+	require.Equal(t, "acme-1", matches[0].SourceID)
+
+	_, err = idx.Search(ctx, query, SearchOpts{Algorithm: "other"})
+	require.ErrorContains(t, err, `unknown algorithm "other"`)
+
+	_, err = New(Config{Weights: search.Weights{Name: 500}})
+	require.ErrorContains(t, err, "must be between 0 and 100")
+}
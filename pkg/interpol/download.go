@@ -0,0 +1,183 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package interpol
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/strx"
+	"github.com/moov-io/watchman/pkg/download"
+	"github.com/pariz/gountries"
+)
+
+var (
+	publicRedNoticesURL = "https://ws-public.interpol.int/notices/v1/red?resultPerPage=160"
+	redNoticesURL       = strx.Or(os.Getenv("INTERPOL_RED_NOTICES_DOWNLOAD_URL"), publicRedNoticesURL)
+)
+
+const (
+	redNoticesFile = "red-notices.json"
+
+	// maxPages is the most pages of one query which are read, in case the API keeps linking to a next page
+	maxPages = 100
+)
+
+// ageRanges split the notices of a nationality with more than one query returns
+var ageRanges = [][2]int{
+	{0, 20}, {21, 25}, {26, 30}, {31, 35}, {36, 40}, {41, 45}, {46, 50}, {51, 55}, {56, 60}, {61, 65},
+	{66, 70}, {71, 80}, {81, 120},
+}
+
+// Download returns the public Red Notices as red-notices.json, which is read from initialDir instead when it's
+// there. Interpol's API only returns the first notices of each query, so when there are more they're queried by
+// nationality, and those nationalities with too many by age, until every notice is found.
+func Download(ctx context.Context, logger log.Logger, initialDir string) (map[string]io.ReadCloser, error) {
+	dl := download.New(logger, download.HTTPClient)
+
+	if initialDir != "" {
+		if _, err := os.Stat(filepath.Join(initialDir, redNoticesFile)); err == nil {
+			return dl.GetFiles(ctx, initialDir, map[string]string{redNoticesFile: redNoticesURL})
+		}
+	}
+
+	f := &fetcher{dl: dl, found: make(map[string]bool)}
+	total, found, err := f.query(ctx, redNoticesURL)
+	if err != nil {
+		return nil, err
+	}
+	if found < total {
+		for _, nationality := range nationalities() {
+			nationalityURL := withParams(redNoticesURL, "nationality", nationality)
+			total, found, err := f.query(ctx, nationalityURL)
+			if err != nil {
+				return nil, err
+			}
+			if found >= total {
+				continue
+			}
+			for _, ages := range ageRanges {
+				ageURL := withParams(nationalityURL, "ageMin", strconv.Itoa(ages[0]), "ageMax", strconv.Itoa(ages[1]))
+				if _, _, err := f.query(ctx, ageURL); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	if len(f.notices) < total {
+		logger.Warn().Logf("found %d of the %d public red notices", len(f.notices), total)
+	}
+
+	var out page
+	out.Total = len(f.notices)
+	out.Embedded.Notices = f.notices
+	bs, err := json.Marshal(out)
+	if err != nil {
+		return nil, fmt.Errorf("encoding %s: %w", redNoticesFile, err)
+	}
+	return map[string]io.ReadCloser{
+		redNoticesFile: io.NopCloser(bytes.NewReader(bs)),
+	}, nil
+}
+
+// fetcher collects the notices of each query, keeping one of each notice
+type fetcher struct {
+	dl      *download.Downloader
+	notices []Notice
+	found   map[string]bool // by EntityID
+}
+
+// query reads every page of queryURL, returning the total Interpol reported and how many notices it returned
+func (f *fetcher) query(ctx context.Context, queryURL string) (int, int, error) {
+	var total, found int
+	next := queryURL
+	for pages := 0; next != "" && pages < maxPages; pages++ {
+		p, err := f.page(ctx, next)
+		if err != nil {
+			return total, found, err
+		}
+		total = p.Total
+		found += len(p.Embedded.Notices)
+		for _, notice := range p.Embedded.Notices {
+			if !f.found[notice.EntityID] {
+				f.found[notice.EntityID] = true
+				f.notices = append(f.notices, notice)
+			}
+		}
+
+		if len(p.Embedded.Notices) == 0 || p.Links.Next == nil || p.Links.Next.Href == "" {
+			break
+		}
+		next, err = resolve(next, p.Links.Next.Href)
+		if err != nil {
+			return total, found, err
+		}
+	}
+	return total, found, nil
+}
+
+func (f *fetcher) page(ctx context.Context, pageURL string) (page, error) {
+	var out page
+
+	files, err := f.dl.GetFiles(ctx, "", map[string]string{redNoticesFile: pageURL})
+	if err != nil {
+		return out, err
+	}
+	contents, exists := files[redNoticesFile]
+	if !exists {
+		return out, fmt.Errorf("downloading red notices from %s failed", pageURL)
+	}
+	defer contents.Close()
+
+	if err := json.NewDecoder(contents).Decode(&out); err != nil {
+		return out, fmt.Errorf("reading red notices from %s: %w", pageURL, err)
+	}
+	return out, nil
+}
+
+// nationalities returns the ISO-3166 alpha-2 code of every country, which notices are queried by
+func nationalities() []string {
+	var out []string
+	for _, country := range gountries.New().FindAllCountries() {
+		out = append(out, country.Alpha2)
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// withParams returns rawURL with each pair of params set in its query
+func withParams(rawURL string, params ...string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	q := u.Query()
+	for i := 0; i+1 < len(params); i += 2 {
+		q.Set(params[i], params[i+1])
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+func resolve(base, ref string) (string, error) {
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return "", fmt.Errorf("reading next page of red notices: %w", err)
+	}
+	return b.ResolveReference(r).String(), nil
+}
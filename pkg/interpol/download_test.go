@@ -0,0 +1,107 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package interpol
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/moov-io/base/log"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownload_initialDir(t *testing.T) {
+	files, err := Download(context.Background(), log.NewNopLogger(), "testdata")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	file, found := files[redNoticesFile]
+	require.True(t, found)
+	require.NoError(t, file.Close())
+}
+
+func TestDownload_pages(t *testing.T) {
+	// Each query returns up to two notices a page and four in total, as Interpol only returns the first of them
+	notices := []Notice{
+		{EntityID: "1", Name: "A", Nationalities: []string{"US"}},
+		{EntityID: "2", Name: "B", Nationalities: []string{"US"}},
+		{EntityID: "3", Name: "C", Nationalities: []string{"US"}},
+		{EntityID: "4", Name: "D", Nationalities: []string{"US"}},
+		{EntityID: "5", Name: "E", Nationalities: []string{"US"}, DateOfBirth: "1950"},
+		{EntityID: "6", Name: "F", Nationalities: []string{"FR"}},
+	}
+	ages := map[string]int{"5": 74}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		var matched []Notice
+		for _, notice := range notices {
+			if v := q.Get("nationality"); v != "" && notice.Nationalities[0] != v {
+				continue
+			}
+			if v := q.Get("ageMin"); v != "" {
+				from, _ := strconv.Atoi(v)
+				to, _ := strconv.Atoi(q.Get("ageMax"))
+				age := ages[notice.EntityID]
+				if age == 0 {
+					age = 30
+				}
+				if age < from || age > to {
+					continue
+				}
+			}
+			matched = append(matched, notice)
+		}
+		total := len(matched)
+		if len(matched) > 4 {
+			matched = matched[:4]
+		}
+
+		pageNum, _ := strconv.Atoi(q.Get("page"))
+		if pageNum < 1 {
+			pageNum = 1
+		}
+		start, end := (pageNum-1)*2, pageNum*2
+		if end > len(matched) {
+			end = len(matched)
+		}
+		var p page
+		p.Total = total
+		if start < end {
+			p.Embedded.Notices = matched[start:end]
+		}
+		if start+2 < len(matched) {
+			q.Set("page", strconv.Itoa(pageNum+1))
+			p.Links.Next = &struct {
+				Href string `json:"href"`
+			}{Href: fmt.Sprintf("%s?%s", r.URL.Path, q.Encode())}
+		}
+		json.NewEncoder(w).Encode(p)
+	}))
+	defer server.Close()
+
+	redNoticesURL = server.URL + "/notices/v1/red?resultPerPage=2"
+	t.Cleanup(func() {
+		redNoticesURL = publicRedNoticesURL
+	})
+
+	files, err := Download(context.Background(), log.NewNopLogger(), t.TempDir())
+	require.NoError(t, err)
+
+	data, err := Read(files)
+	require.NoError(t, err)
+
+	var found []string
+	for _, notice := range data.Notices {
+		found = append(found, notice.EntityID)
+	}
+	require.ElementsMatch(t, []string{"1", "2", "3", "4", "5", "6"}, found)
+}
@@ -0,0 +1,31 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package interpol
+
+// RedNotices are Interpol's public Red Notices, each a request to law enforcement worldwide to locate and
+// provisionally arrest a person pending extradition or a similar legal action. A Red Notice isn't an international
+// arrest warrant or a sanction, and most are restricted to law enforcement, so only those Interpol publishes are
+// included.
+//
+// https://www.interpol.int/How-we-work/Notices/Red-Notices
+type RedNotices struct {
+	Notices []Notice
+}
+
+// Notice is one public Red Notice as it's listed by Interpol's API
+type Notice struct {
+	// EntityID identifies the notice, such as "2010/32437"
+	EntityID string `json:"entity_id"`
+
+	// Forename holds the person's given names and Name their family name, both as Interpol writes them
+	Forename string `json:"forename"`
+	Name     string `json:"name"`
+
+	// DateOfBirth is written as "1969/04/17", or with only the year when the day isn't known
+	DateOfBirth string `json:"date_of_birth,omitempty"`
+
+	// Nationalities are ISO-3166 alpha-2 codes, such as "SV"
+	Nationalities []string `json:"nationalities,omitempty"`
+}
@@ -0,0 +1,81 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package interpol
+
+import (
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+func ConvertNotices(data *RedNotices) []search.Entity[search.Value] {
+	if data == nil {
+		return nil
+	}
+
+	out := make([]search.Entity[search.Value], 0, len(data.Notices))
+	for _, notice := range data.Notices {
+		entity, ok := ToEntity(notice)
+		if ok {
+			out = append(out, entity)
+		}
+	}
+	return out
+}
+
+// ToEntity converts a Red Notice into a person who is flagged as wanted, rather than sanctioned
+func ToEntity(src Notice) (search.Entity[search.Value], bool) {
+	name := strings.Join(strings.Fields(src.Forename+" "+src.Name), " ")
+	if name == "" || src.EntityID == "" {
+		return search.Entity[search.Value]{}, false
+	}
+
+	out := search.Entity[search.Value]{
+		Name:       name,
+		Type:       search.EntityPerson,
+		Source:     search.SourceInterpolRed,
+		SourceID:   src.EntityID,
+		SourceData: src,
+	}
+	out.Person = &search.Person{
+		Name: name,
+	}
+	for _, nationality := range src.Nationalities {
+		if nationality = strings.TrimSpace(nationality); nationality != "" {
+			out.Person.Nationalities = append(out.Person.Nationalities, search.NormalizeCountry(nationality))
+		}
+	}
+	if dates, ok := parseDate(src.DateOfBirth); ok {
+		out.Person.BirthDates = []search.DateRange{dates}
+		out.Person.BirthDate = &dates.Start
+	}
+
+	out.Wanted = &search.WantedInfo{
+		Authority: "Interpol",
+		Notice:    "Red Notice",
+		URL:       noticeURL(src.EntityID),
+	}
+	out.Remarks = "Interpol Red Notice " + src.EntityID + ", a request to locate and provisionally arrest a wanted person, which isn't a sanctions designation"
+
+	return out, true
+}
+
+var dateLayouts = []string{"2006/01/02", "2006/01", "2006"}
+
+func parseDate(value string) (search.DateRange, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return search.DateRangeFor(t, layout), true
+		}
+	}
+	return search.DateRange{}, false
+}
+
+// noticeURL is the public page of a notice, which Interpol addresses as 2010-32437 rather than 2010/32437
+func noticeURL(entityID string) string {
+	return "https://www.interpol.int/How-we-work/Notices/Red-Notices/View-Red-Notices#" + strings.ReplaceAll(entityID, "/", "-")
+}
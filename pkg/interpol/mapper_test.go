@@ -0,0 +1,63 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package interpol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertNotices(t *testing.T) {
+	files, err := Download(context.Background(), log.NewNopLogger(), "testdata")
+	require.NoError(t, err)
+
+	data, err := Read(files)
+	require.NoError(t, err)
+
+	// The notice without a name is skipped
+	entities := ConvertNotices(data)
+	require.Len(t, entities, 3)
+
+	perez := entities[0]
+	require.Equal(t, "JOSE ADAN PEREZ", perez.Name)
+	require.Equal(t, search.EntityPerson, perez.Type)
+	require.Equal(t, search.SourceInterpolRed, perez.Source)
+	require.Equal(t, "2010/32437", perez.SourceID)
+	require.Equal(t, "JOSE ADAN PEREZ", perez.Person.Name)
+	require.Equal(t, []string{"SV"}, perez.Person.Nationalities)
+
+	born := time.Date(1969, time.April, 17, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, &born, perez.Person.BirthDate)
+	require.Equal(t, []search.DateRange{{Start: born, End: born}}, perez.Person.BirthDates)
+
+	require.Equal(t, &search.WantedInfo{
+		Authority: "Interpol",
+		Notice:    "Red Notice",
+		URL:       "https://www.interpol.int/How-we-work/Notices/Red-Notices/View-Red-Notices#2010-32437",
+	}, perez.Wanted)
+	require.Nil(t, perez.SanctionsInfo)
+	require.Contains(t, perez.Remarks, "isn't a sanctions designation")
+
+	// Only the year of birth is known
+	ivanov := entities[1]
+	require.Equal(t, []string{"RU", "UA"}, ivanov.Person.Nationalities)
+	require.Equal(t, []search.DateRange{{
+		Start: time.Date(1985, time.January, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(1985, time.December, 31, 0, 0, 0, 0, time.UTC),
+	}}, ivanov.Person.BirthDates)
+
+	rashid := entities[2]
+	require.Equal(t, "AL-RASHID", rashid.Name)
+	require.Nil(t, rashid.Person.BirthDate)
+	require.Empty(t, rashid.Person.Nationalities)
+
+	require.Nil(t, ConvertNotices(nil))
+}
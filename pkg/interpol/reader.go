@@ -0,0 +1,51 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package interpol
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// page is a response of Interpol's notices API, which red-notices.json is also written as
+type page struct {
+	Total    int `json:"total"`
+	Embedded struct {
+		Notices []Notice `json:"notices"`
+	} `json:"_embedded"`
+	Links struct {
+		Next *struct {
+			Href string `json:"href"`
+		} `json:"next,omitempty"`
+	} `json:"_links"`
+}
+
+func Read(files map[string]io.ReadCloser) (*RedNotices, error) {
+	for filename, contents := range files {
+		switch strings.ToLower(filename) {
+		case redNoticesFile:
+			return readNotices(filename, contents)
+		default:
+			return nil, fmt.Errorf("unknown file %s", filename)
+		}
+	}
+	return nil, errors.New("no files provided")
+}
+
+func readNotices(filename string, contents io.ReadCloser) (*RedNotices, error) {
+	if contents == nil {
+		return nil, fmt.Errorf("%s is empty or missing", filename)
+	}
+	defer contents.Close()
+
+	var p page
+	if err := json.NewDecoder(contents).Decode(&p); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+	}
+	return &RedNotices{Notices: p.Embedded.Notices}, nil
+}
@@ -0,0 +1,46 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package interpol
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/moov-io/base/log"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRead(t *testing.T) {
+	files, err := Download(context.Background(), log.NewNopLogger(), "testdata")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	data, err := Read(files)
+	require.NoError(t, err)
+	require.Len(t, data.Notices, 4)
+
+	require.Equal(t, Notice{
+		EntityID:      "2010/32437",
+		Forename:      "JOSE ADAN",
+		Name:          "PEREZ",
+		DateOfBirth:   "1969/04/17",
+		Nationalities: []string{"SV"},
+	}, data.Notices[0])
+	require.Equal(t, Notice{EntityID: "2022/11804", Name: "AL-RASHID"}, data.Notices[2])
+}
+
+func TestRead_Errors(t *testing.T) {
+	_, err := Read(nil)
+	require.ErrorContains(t, err, "no files provided")
+
+	_, err = Read(map[string]io.ReadCloser{"notices.csv": io.NopCloser(strings.NewReader(""))})
+	require.ErrorContains(t, err, "unknown file notices.csv")
+
+	_, err = Read(map[string]io.ReadCloser{redNoticesFile: io.NopCloser(strings.NewReader("<html>"))})
+	require.ErrorContains(t, err, "failed to parse red-notices.json")
+}
@@ -0,0 +1,96 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Package normalize prepares names the same way Watchman does before comparing them, so client systems can
+// normalize names on their side, such as to cache or deduplicate searches, and get the names the server would.
+//
+//	normalizer, err := normalize.New(normalize.Options{Type: search.EntityPerson, Stages: []string{"reorder"}})
+//	name := normalizer.Normalize("AL-ZAWAHIRI, Ayman")
+//
+// Version identifies the preparation, which Watchman's /v2/normalize endpoint also returns, so a client can detect
+// when its names are prepared differently than the server's, such as after either of them is upgraded or the
+// server loads custom dictionaries.
+package normalize
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// Options choose how names are prepared, as they are by the parameters of a search
+type Options struct {
+	// Type of the entity named, whose names are prepared as individuals' for search.EntityPerson
+	Type search.EntityType
+
+	// Country picks the language of stopwords when the name's language can't be detected
+	Country string
+
+	// Stages are run in order over each name, as the prepare parameter of /v2/search runs them, such as "reorder"
+	// and "stopwords". Names are only normalized when it's empty.
+	Stages []string
+}
+
+// Name is a name as it was given, prepared and normalized
+type Name struct {
+	Name string `json:"name"`
+
+	// Prepared is the name after each of the stages
+	Prepared string `json:"prepared"`
+
+	// Normalized is the prepared name romanized, lowercased and without punctuation, as it's compared with the
+	// names of indexed entities
+	Normalized string `json:"normalized"`
+}
+
+// Normalizer prepares names with one set of Options
+type Normalizer struct {
+	pipeline *prepare.Pipeline
+	opts     prepare.PrepareOptions
+	version  string
+}
+
+// New returns a Normalizer, or an error when any of the stages isn't known
+func New(opts Options) (*Normalizer, error) {
+	stages, err := prepare.ParseStages(strings.Join(opts.Stages, ","))
+	if err != nil {
+		return nil, err
+	}
+	pipeline, err := prepare.NewPipeline(prepare.PipelineConfig{
+		Individual: stages,
+		Entity:     stages,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("prepare stages: %w", err)
+	}
+	return &Normalizer{
+		pipeline: pipeline,
+		opts: prepare.PrepareOptions{
+			Individual: opts.Type == search.EntityPerson,
+			Country:    search.NormalizeCountry(opts.Country),
+		},
+		version: pipeline.Version(),
+	}, nil
+}
+
+// Normalize prepares and normalizes name
+func (n *Normalizer) Normalize(name string) Name {
+	prepared := strings.TrimSpace(name)
+	if prepared != "" {
+		prepared = n.pipeline.Prepare(prepared, n.opts)
+	}
+	return Name{
+		Name:       name,
+		Prepared:   prepared,
+		Normalized: search.NormalizeName(prepared),
+	}
+}
+
+// Version identifies how names are prepared and normalized. Names normalized with the same version are the same
+// wherever they're normalized.
+func (n *Normalizer) Version() string {
+	return n.version
+}
@@ -0,0 +1,67 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package normalize_test
+
+import (
+	"testing"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	internalsearch "github.com/moov-io/watchman/internal/search"
+	"github.com/moov-io/watchman/pkg/normalize"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizer(t *testing.T) {
+	normalizer, err := normalize.New(normalize.Options{
+		Type:   search.EntityPerson,
+		Stages: []string{"reorder", "honorifics"},
+	})
+	require.NoError(t, err)
+
+	name := normalizer.Normalize("AL-ZAWAHIRI, Dr. Ayman")
+	require.Equal(t, normalize.Name{
+		Name:       "AL-ZAWAHIRI, Dr. Ayman",
+		Prepared:   "Ayman AL-ZAWAHIRI",
+		Normalized: "ayman al zawahiri",
+	}, name)
+	require.Regexp(t, `^1-[0-9a-f]{12}$`, normalizer.Version())
+
+	// Without stages names are only normalized
+	normalizer, err = normalize.New(normalize.Options{Type: search.EntityBusiness})
+	require.NoError(t, err)
+	require.Equal(t, "acme shipping co", normalizer.Normalize("  ACME Shipping, Co. ").Normalized)
+	require.Empty(t, normalizer.Normalize("").Normalized)
+
+	_, err = normalize.New(normalize.Options{Stages: []string{"shout"}})
+	require.ErrorContains(t, err, `unknown prepare stage "shout"`)
+}
+
+func TestNormalizer_MatchesServer(t *testing.T) {
+	stages := []prepare.Stage{prepare.StageReorder, prepare.StageHonorifics, prepare.StageCompanySuffixes}
+	pipeline, err := prepare.NewPipeline(prepare.PipelineConfig{Individual: stages, Entity: stages})
+	require.NoError(t, err)
+
+	for _, entity := range []search.Entity[search.Value]{
+		{Name: "MADURO MOROS, Nicolas", Type: search.EntityPerson},
+		{Name: "Sheikh Mohammed BIN RASHID", Type: search.EntityPerson},
+		{Name: "Владимир Путин", Type: search.EntityPerson},
+		{Name: "ACME Shipping Limited", Type: search.EntityBusiness},
+	} {
+		normalizer, err := normalize.New(normalize.Options{
+			Type:   entity.Type,
+			Stages: []string{"reorder", "honorifics", "company-suffixes"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, pipeline.Version(), normalizer.Version())
+
+		// Names are prepared as a search prepares its query, then normalized as they're compared
+		prepared := internalsearch.PrepareEntity(pipeline, entity).Name
+		name := normalizer.Normalize(entity.Name)
+		require.Equal(t, prepared, name.Prepared, entity.Name)
+		require.Equal(t, search.ExplainNameTerms(prepared, search.SimilarityConfig{}).Normalized, name.Normalized, entity.Name)
+	}
+}
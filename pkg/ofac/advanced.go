@@ -0,0 +1,228 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ofac
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// AdvancedSanctions is OFAC's Advanced XML (SDN_ADVANCED.XML) publication of the SDN list, which holds each
+// party's features (birth dates, nationalities, vessel details, etc) as structured values rather than remarks.
+//
+// Most values refer to the ReferenceValueSets by ID, e.g. a Feature's FeatureTypeID is a FeatureType.
+type AdvancedSanctions struct {
+	XMLName xml.Name `xml:"Sanctions"`
+
+	DateOfIssue          AdvancedDate                  `xml:"DateOfIssue"`
+	ReferenceValueSets   AdvancedReferenceValueSets    `xml:"ReferenceValueSets"`
+	Locations            []AdvancedLocation            `xml:"Locations>Location"`
+	IDRegDocuments       []AdvancedIDRegDocument       `xml:"IDRegDocuments>IDRegDocument"`
+	DistinctParties      []AdvancedDistinctParty       `xml:"DistinctParties>DistinctParty"`
+	ProfileRelationships []AdvancedProfileRelationship `xml:"ProfileRelationships>ProfileRelationship"`
+	SanctionsEntries     []AdvancedSanctionsEntry      `xml:"SanctionsEntries>SanctionsEntry"`
+}
+
+// AdvancedReferenceValue is a named value which other elements refer to by ID
+type AdvancedReferenceValue struct {
+	ID    int    `xml:"ID,attr"`
+	Value string `xml:",chardata"`
+
+	// ISO2 is set on countries
+	ISO2 string `xml:"ISO2,attr"`
+
+	// PartyTypeID is set on party sub types
+	PartyTypeID int `xml:"PartyTypeID,attr"`
+}
+
+type AdvancedReferenceValueSets struct {
+	AliasTypes        []AdvancedReferenceValue `xml:"AliasTypeValues>AliasType"`
+	Countries         []AdvancedReferenceValue `xml:"CountryValues>Country"`
+	DetailReferences  []AdvancedReferenceValue `xml:"DetailReferenceValues>DetailReference"`
+	FeatureTypes      []AdvancedReferenceValue `xml:"FeatureTypeValues>FeatureType"`
+	IDRegDocTypes     []AdvancedReferenceValue `xml:"IDRegDocTypeValues>IDRegDocType"`
+	LocPartTypes      []AdvancedReferenceValue `xml:"LocPartTypeValues>LocPartType"`
+	NamePartTypes     []AdvancedReferenceValue `xml:"NamePartTypeValues>NamePartType"`
+	PartySubTypes     []AdvancedReferenceValue `xml:"PartySubTypeValues>PartySubType"`
+	PartyTypes        []AdvancedReferenceValue `xml:"PartyTypeValues>PartyType"`
+	RelationTypes     []AdvancedReferenceValue `xml:"RelationTypeValues>RelationType"`
+	SanctionsPrograms []AdvancedReferenceValue `xml:"SanctionsProgramValues>SanctionsProgram"`
+	SanctionsTypes    []AdvancedReferenceValue `xml:"SanctionsTypeValues>SanctionsType"`
+}
+
+type AdvancedDate struct {
+	Year  int `xml:"Year"`
+	Month int `xml:"Month"`
+	Day   int `xml:"Day"`
+}
+
+// Time returns the date, which is the zero time when Year is missing
+func (d AdvancedDate) Time() time.Time {
+	if d.Year == 0 {
+		return time.Time{}
+	}
+	return time.Date(d.Year, time.Month(atLeastOne(d.Month)), atLeastOne(d.Day), 0, 0, 0, 0, time.UTC)
+}
+
+func atLeastOne(v int) int {
+	if v < 1 {
+		return 1
+	}
+	return v
+}
+
+// AdvancedDatePeriod is a date or range of dates, where the period starts between Start.From and Start.To
+// and ends between End.From and End.To
+type AdvancedDatePeriod struct {
+	Start AdvancedDateBoundary `xml:"Start"`
+	End   AdvancedDateBoundary `xml:"End"`
+}
+
+type AdvancedDateBoundary struct {
+	Approximate bool         `xml:"Approximate,attr"`
+	From        AdvancedDate `xml:"From"`
+	To          AdvancedDate `xml:"To"`
+}
+
+type AdvancedLocation struct {
+	ID        int                    `xml:"ID,attr"`
+	Countries []AdvancedLocationRef  `xml:"LocationCountry"`
+	Parts     []AdvancedLocationPart `xml:"LocationPart"`
+}
+
+type AdvancedLocationRef struct {
+	CountryID int `xml:"CountryID,attr"`
+}
+
+type AdvancedLocationPart struct {
+	LocPartTypeID int                         `xml:"LocPartTypeID,attr"`
+	Values        []AdvancedLocationPartValue `xml:"LocationPartValue"`
+}
+
+type AdvancedLocationPartValue struct {
+	Primary bool   `xml:"Primary,attr"`
+	Value   string `xml:"Value"`
+}
+
+type AdvancedIDRegDocument struct {
+	ID                int    `xml:"ID,attr"`
+	IDRegDocTypeID    int    `xml:"IDRegDocTypeID,attr"`
+	IdentityID        int    `xml:"IdentityID,attr"`
+	IssuedByCountryID int    `xml:"IssuedBy-CountryID,attr"`
+	IDRegistrationNo  string `xml:"IDRegistrationNo"`
+	IssuingAuthority  string `xml:"IssuingAuthority"`
+	DocumentedNameID  int    `xml:"DocumentedNameID,attr"`
+}
+
+// AdvancedDistinctParty is a listed person, entity, vessel or aircraft. FixedRef is the ent_num of the CSV files.
+type AdvancedDistinctParty struct {
+	FixedRef string            `xml:"FixedRef,attr"`
+	Comment  string            `xml:"Comment"`
+	Profiles []AdvancedProfile `xml:"Profile"`
+}
+
+type AdvancedProfile struct {
+	ID             int                `xml:"ID,attr"`
+	PartySubTypeID int                `xml:"PartySubTypeID,attr"`
+	Identities     []AdvancedIdentity `xml:"Identity"`
+	Features       []AdvancedFeature  `xml:"Feature"`
+}
+
+type AdvancedIdentity struct {
+	ID             int                        `xml:"ID,attr"`
+	Primary        bool                       `xml:"Primary,attr"`
+	Aliases        []AdvancedAlias            `xml:"Alias"`
+	NamePartGroups []AdvancedNamePartGroupRef `xml:"NamePartGroups>MasterNamePartGroup>NamePartGroup"`
+}
+
+type AdvancedNamePartGroupRef struct {
+	ID             int `xml:"ID,attr"`
+	NamePartTypeID int `xml:"NamePartTypeID,attr"`
+}
+
+type AdvancedAlias struct {
+	AliasTypeID    int                      `xml:"AliasTypeID,attr"`
+	Primary        bool                     `xml:"Primary,attr"`
+	LowQuality     bool                     `xml:"LowQuality,attr"`
+	DocumentedName []AdvancedDocumentedName `xml:"DocumentedName"`
+}
+
+type AdvancedDocumentedName struct {
+	ID    int                     `xml:"ID,attr"`
+	Parts []AdvancedNamePartValue `xml:"DocumentedNamePart>NamePartValue"`
+}
+
+type AdvancedNamePartValue struct {
+	NamePartGroupID int    `xml:"NamePartGroupID,attr"`
+	ScriptID        int    `xml:"ScriptID,attr"`
+	Value           string `xml:",chardata"`
+}
+
+type AdvancedFeature struct {
+	ID            int                      `xml:"ID,attr"`
+	FeatureTypeID int                      `xml:"FeatureTypeID,attr"`
+	Versions      []AdvancedFeatureVersion `xml:"FeatureVersion"`
+}
+
+type AdvancedFeatureVersion struct {
+	ID          int                     `xml:"ID,attr"`
+	DatePeriods []AdvancedDatePeriod    `xml:"DatePeriod"`
+	Details     []AdvancedVersionDetail `xml:"VersionDetail"`
+	Locations   []AdvancedLocationLink  `xml:"VersionLocation"`
+}
+
+// AdvancedVersionDetail is a feature's text, or refers to a DetailReference such as "Male" or "Crude Oil Tanker"
+type AdvancedVersionDetail struct {
+	DetailTypeID      int    `xml:"DetailTypeID,attr"`
+	DetailReferenceID int    `xml:"DetailReferenceID,attr"`
+	Value             string `xml:",chardata"`
+}
+
+type AdvancedLocationLink struct {
+	LocationID int `xml:"LocationID,attr"`
+}
+
+type AdvancedProfileRelationship struct {
+	ID             int  `xml:"ID,attr"`
+	FromProfileID  int  `xml:"From-ProfileID,attr"`
+	ToProfileID    int  `xml:"To-ProfileID,attr"`
+	RelationTypeID int  `xml:"RelationTypeID,attr"`
+	Former         bool `xml:"Former,attr"`
+}
+
+type AdvancedSanctionsEntry struct {
+	ID        int                        `xml:"ID,attr"`
+	ProfileID int                        `xml:"ProfileID,attr"`
+	ListID    int                        `xml:"ListID,attr"`
+	Measures  []AdvancedSanctionsMeasure `xml:"SanctionsMeasure"`
+}
+
+type AdvancedSanctionsMeasure struct {
+	ID              int    `xml:"ID,attr"`
+	SanctionsTypeID int    `xml:"SanctionsTypeID,attr"`
+	Comment         string `xml:"Comment"`
+}
+
+// ReadAdvanced parses the SDN_ADVANCED.XML file
+func ReadAdvanced(files map[string]io.ReadCloser) (*AdvancedSanctions, error) {
+	for filename, file := range files {
+		if !strings.EqualFold(filepath.Base(filename), advancedFilename) {
+			continue
+		}
+		defer file.Close()
+
+		var doc AdvancedSanctions
+		if err := xml.NewDecoder(file).Decode(&doc); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filename, err)
+		}
+		return &doc, nil
+	}
+	return nil, errors.New("missing " + advancedFilename)
+}
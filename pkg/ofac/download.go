@@ -40,3 +40,16 @@ func Download(ctx context.Context, logger log.Logger, initialDir string) (map[st
 
 	return dl.GetFiles(ctx, initialDir, addrs)
 }
+
+// advancedFilename is OFAC's Advanced XML publication of the SDN list, see ReadAdvanced
+const advancedFilename = "SDN_ADVANCED.XML"
+
+// DownloadAdvanced retrieves the Advanced XML publication of the SDN list instead of the CSV files
+func DownloadAdvanced(ctx context.Context, logger log.Logger, initialDir string) (map[string]io.ReadCloser, error) {
+	dl := download.New(logger, download.HTTPClient)
+
+	addrs := map[string]string{
+		advancedFilename: fmt.Sprintf(ofacURLTemplate, advancedFilename),
+	}
+	return dl.GetFiles(ctx, initialDir, addrs)
+}
@@ -6,9 +6,9 @@ package ofac
 
 import (
 	"cmp"
-	"fmt"
 	"regexp"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -76,6 +76,44 @@ func parseTime(acceptedLayouts []string, value string) (time.Time, error) {
 	return time.Time{}, nil
 }
 
+// parseDateRange reads partial dates ("Jan 1970"), ranges ("1958 to 1962") and approximate dates ("circa 1960")
+// into the span of days they cover. Approximate dates are widened by a year on each side.
+func parseDateRange(acceptedLayouts []string, value string) (search.DateRange, bool) {
+	circa := strings.Contains(value, "circa")
+	value = strings.TrimSpace(strings.ReplaceAll(value, "circa", ""))
+
+	parts := strings.SplitN(value, " to ", 2)
+	if len(parts) == 1 {
+		parts = strings.SplitN(value, "-", 2)
+	}
+
+	parse := func(value string) (search.DateRange, bool) {
+		value = strings.TrimSpace(value)
+		for _, layout := range acceptedLayouts {
+			tt, err := time.Parse(layout, value)
+			if err == nil && !invalidDate(tt) {
+				return search.DateRangeFor(tt, layout), true
+			}
+		}
+		return search.DateRange{}, false
+	}
+
+	out, ok := parse(parts[0])
+	if !ok {
+		return out, false
+	}
+	if len(parts) > 1 {
+		if end, ok := parse(parts[1]); ok && end.End.After(out.End) {
+			out.End = end.End
+		}
+	}
+	if circa {
+		out.Start = out.Start.AddDate(-1, 0, 0)
+		out.End = out.End.AddDate(1, 0, 0)
+	}
+	return out, true
+}
+
 func extractCountry(remark string) string {
 	matches := countryParenRegex.FindStringSubmatch(remark)
 	if len(matches) > 1 {
@@ -99,10 +137,12 @@ func ToEntity(sdn SDN, addresses []Address, comments []SDNComments, altIds []Alt
 		Source:     search.SourceUSOFAC,
 		SourceData: sdn,
 		SourceID:   sdn.EntityID,
+		Remarks:    strings.TrimSpace(sdn.Remarks),
 	}
 
 	remarks := splitRemarks(sdn.Remarks)
 	affiliations, sanctionsInfo, historicalInfo, titles := parseRemarks(remarks)
+	sanctionsInfo = withPrograms(sanctionsInfo, sdn.Programs)
 
 	out.Affiliations = affiliations
 	out.SanctionsInfo = sanctionsInfo
@@ -113,13 +153,13 @@ func ToEntity(sdn SDN, addresses []Address, comments []SDNComments, altIds []Alt
 	out.Contact = parseContactInfo(remarks)
 	out.Addresses = parseAddresses(addresses)
 
-	// Get all alternate names from both remarks and AlternateIdentity entries
-	var altNames []string
-	altNames = append(altNames, parseAltNames(remarks)...)
-	altNames = append(altNames, parseAltIdentities(altIds)...)
-	altNames = deduplicateStrings(altNames)
+	// Alternate names are read from AlternateIdentity entries, while the remarks list weak aliases
+	altNames := deduplicateStrings(parseAltIdentities(altIds))
 	altNames = prepare.ReorderSDNNames(altNames, sdn.SDNType)
 
+	weakAltNames := prepare.ReorderSDNNames(deduplicateStrings(parseWeakAltNames(remarks)), sdn.SDNType)
+	out.WeakAltNames = excludeStrings(weakAltNames, altNames)
+
 	switch strings.ToLower(strings.TrimSpace(sdn.SDNType)) {
 	case "-0-", "":
 		out.Type = search.EntityBusiness
@@ -186,6 +226,15 @@ func ToEntity(sdn SDN, addresses []Address, comments []SDNComments, altIds []Alt
 			return &t
 		})
 
+		for _, dob := range findMatchingRemarks(remarks, "DOB") {
+			if r, ok := parseDateRange(dobPatterns, dob.value); ok {
+				out.Person.BirthDates = append(out.Person.BirthDates, r)
+			}
+		}
+
+		out.Person.PlaceOfBirth = parsePlaceOfBirth(remarks)
+		out.Person.Nationalities = parseNationalities(remarks)
+
 		// Parse government IDs
 		out.Person.GovernmentIDs = parseGovernmentIDs(remarks)
 
@@ -221,25 +270,61 @@ func ToEntity(sdn SDN, addresses []Address, comments []SDNComments, altIds []Alt
 			Model:        firstValue(findMatchingRemarks(remarks, "Aircraft Model")),
 			SerialNumber: parseSerialNumber(remarks),
 			ICAOCode:     firstValue(findMatchingRemarks(remarks, "ICAO Code")),
+
+			TailNumber:          parseTailNumber(sdn.SDNName, remarks),
+			PreviousTailNumbers: findRemarkValues(remarks, "Previous Aircraft Tail Number"),
 		}
 	}
 
 	return out
 }
 
+// parseAddresses splits each address into its components. The columns are joined by commas, so the
+// street, city and country are parsed apart even if a column is empty.
 func parseAddresses(inputs []Address) []search.Address {
 	var out []search.Address
 	for i := range inputs {
-		input := fmt.Sprintf("%s %s %s", inputs[i].Address, inputs[i].CityStateProvincePostalCode, inputs[i].Country)
-		addr := address.ParseAddress(input)
-		if addr.Line1 != "" {
+		var parts []string
+		for _, part := range []string{inputs[i].Address, inputs[i].CityStateProvincePostalCode, inputs[i].Country} {
+			if part = strings.TrimSpace(part); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		addr := address.ParseAddress(strings.Join(parts, ", "))
+		if addr.Line1 != "" || addr.City != "" || addr.Country != "" {
 			out = append(out, addr)
 		}
 	}
 	return out
 }
 
-func parseAltNames(remarks []string) []string {
+// parsePlaceOfBirth returns the first "POB" remark, ignoring alternates
+func parsePlaceOfBirth(remarks []string) string {
+	for _, r := range remarks {
+		m := pobRegex.FindStringSubmatch(r)
+		if len(m) > 1 && !strings.Contains(strings.ToLower(r), "alt.") {
+			return strings.TrimSpace(m[1])
+		}
+	}
+	return ""
+}
+
+// parseNationalities reads the "nationality" and "citizen" remarks
+func parseNationalities(remarks []string) []string {
+	var out []string
+	for _, r := range remarks {
+		for _, m := range citizenshipRegex.FindAllStringSubmatch(r, -1) {
+			if country := strings.TrimSpace(m[2]); country != "" {
+				out = append(out, country)
+			}
+		}
+	}
+	return deduplicateStrings(out)
+}
+
+// parseWeakAltNames returns the weak aliases of an SDN, which are quoted in its remarks (a.k.a. 'ABU ALI')
+// rather than listed in alt.csv
+func parseWeakAltNames(remarks []string) []string {
 	var names []string
 	for _, r := range remarks {
 		matches := akaRegex.FindAllStringSubmatch(r, -1)
@@ -263,13 +348,24 @@ func parseAltIdentities(altIds []AlternateIdentity) []string {
 	return names
 }
 
+// excludeStrings returns the values of input which aren't in exclude
+func excludeStrings(input, exclude []string) []string {
+	var out []string
+	for _, v := range input {
+		if !slices.Contains(exclude, v) {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
 func deduplicateStrings(input []string) []string {
 	seen := make(map[string]bool)
 	var result []string
 
 	for _, str := range input {
-		// Normalize the string for comparison
-		normalized := strings.ToLower(strings.TrimSpace(str))
+		// Normalize the string for comparison, ignoring case and punctuation
+		normalized := normalizeRow(str)
 		if !seen[normalized] {
 			seen[normalized] = true
 			result = append(result, str) // Keep original string formatting
@@ -315,6 +411,9 @@ var (
 
 	// Refugee Documents
 	governmentIDRefugeeRegex = regexp.MustCompile(`(?i)Refugee\s+ID\s+(?:Card)?\s*([A-Z0-9]+)`)
+
+	// Bank identifier codes
+	governmentIDSWIFTRegex = regexp.MustCompile(`(?i)SWIFT/BIC\s+([A-Z0-9]{8,11})\b`)
 )
 
 func parseGovernmentIDs(remarks []string) []search.GovernmentID {
@@ -341,6 +440,7 @@ func parseGovernmentIDs(remarks []string) []search.GovernmentID {
 		governmentIDCommercialRegistryRegex:   search.GovernmentIDCommercialRegistry,
 		governmentIDBirthCertRegex:            search.GovernmentIDBirthCert,
 		governmentIDRefugeeRegex:              search.GovernmentIDRefugee,
+		governmentIDSWIFTRegex:                search.GovernmentIDSWIFT,
 	}
 
 	for _, r := range remarks {
@@ -358,6 +458,14 @@ func parseGovernmentIDs(remarks []string) []search.GovernmentID {
 			if matches := re.FindStringSubmatch(remarkWithoutCountry); len(matches) > 1 {
 				identifier := strings.TrimRight(matches[1], ".;,")
 
+				// Most Legal Entity Numbers are the LEIs of companies, rather than a registration number
+				idType := idType
+				if re == governmentIDLegalEntityNumberRegex {
+					if lei, err := search.NormalizeLEI(identifier); err == nil {
+						idType, identifier = search.GovernmentIDLEI, lei
+					}
+				}
+
 				ids = append(ids, search.GovernmentID{
 					Type:       idType,
 					Country:    country, // Use the extracted and normalized country
@@ -408,12 +516,7 @@ func normalizeCountryCode(country string) string {
 }
 
 func normalizeVesselType(vesselType string) search.VesselType {
-	switch strings.ToLower(strings.TrimSpace(vesselType)) {
-	case "cargo":
-		return search.VesselTypeCargo
-	default:
-		return search.VesselTypeUnknown
-	}
+	return search.ParseVesselType(vesselType)
 }
 
 func normalizeAircraftType(aircraftType string) search.AircraftType {
@@ -444,6 +547,23 @@ func parseSerialNumber(remarks []string) string {
 	return ""
 }
 
+// tailNumberRegex matches registration marks such as EP-GOL, EX-35011 or N200VR
+var tailNumberRegex = regexp.MustCompile(`^(?:[A-Z0-9]{1,2}-[A-Z0-9]{2,6}|N[0-9][0-9A-Z]{0,4})$`)
+
+// parseTailNumber reads the "Aircraft Tail Number" remark, otherwise OFAC often lists aircraft under their
+// tail number and those which aren't registered under their serial number, e.g. "MSN 550".
+func parseTailNumber(name string, remarks []string) string {
+	for _, r := range findMatchingRemarks(remarks, "Aircraft Tail Number") {
+		if !strings.Contains(r.fullName, "Previous") {
+			return r.value
+		}
+	}
+	if name = strings.TrimSpace(name); tailNumberRegex.MatchString(name) {
+		return name
+	}
+	return ""
+}
+
 var (
 	// Regular expressions for parsing relationships and sanctions
 	linkedToRegex   = regexp.MustCompile(`(?i)Linked\s+To:\s+([^;]+)`)
@@ -547,6 +667,24 @@ func sanitizeSanctionsInfo(info *search.SanctionsInfo) *search.SanctionsInfo {
 	return info
 }
 
+// withPrograms adds the SDN's sanctions programs, such as SDGT, to info
+func withPrograms(info *search.SanctionsInfo, programs []string) *search.SanctionsInfo {
+	var cleaned []string
+	for _, program := range programs {
+		if program = strings.TrimSpace(program); program != "" {
+			cleaned = append(cleaned, program)
+		}
+	}
+	if len(cleaned) == 0 {
+		return info
+	}
+	if info == nil {
+		info = &search.SanctionsInfo{}
+	}
+	info.Programs = cleaned
+	return info
+}
+
 func deduplicateHistoricalInfo(info []search.HistoricalInfo) []search.HistoricalInfo {
 	seen := make(map[string]bool)
 	var result []search.HistoricalInfo
@@ -0,0 +1,505 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ofac
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+// advancedRefs resolves the IDs used throughout an AdvancedSanctions document. Reference values are matched by
+// their names since OFAC doesn't promise their IDs are stable between publications.
+type advancedRefs struct {
+	values map[string]map[int]string // reference set -> ID -> value
+
+	countries     map[int]string
+	partySubTypes map[int]AdvancedReferenceValue
+	locations     map[int]AdvancedLocation
+
+	documents     map[int][]AdvancedIDRegDocument  // keyed by IdentityID
+	entries       map[int][]AdvancedSanctionsEntry // keyed by ProfileID
+	relationships map[int][]AdvancedProfileRelationship
+	profileNames  map[int]string
+}
+
+func newAdvancedRefs(doc *AdvancedSanctions) *advancedRefs {
+	sets := doc.ReferenceValueSets
+	refs := &advancedRefs{
+		values:        make(map[string]map[int]string),
+		countries:     make(map[int]string),
+		partySubTypes: make(map[int]AdvancedReferenceValue),
+		locations:     make(map[int]AdvancedLocation),
+		documents:     make(map[int][]AdvancedIDRegDocument),
+		entries:       make(map[int][]AdvancedSanctionsEntry),
+		relationships: make(map[int][]AdvancedProfileRelationship),
+		profileNames:  make(map[int]string),
+	}
+	for name, values := range map[string][]AdvancedReferenceValue{
+		"AliasType":       sets.AliasTypes,
+		"DetailReference": sets.DetailReferences,
+		"FeatureType":     sets.FeatureTypes,
+		"IDRegDocType":    sets.IDRegDocTypes,
+		"LocPartType":     sets.LocPartTypes,
+		"NamePartType":    sets.NamePartTypes,
+		"PartyType":       sets.PartyTypes,
+		"RelationType":    sets.RelationTypes,
+		"SanctionsType":   sets.SanctionsTypes,
+	} {
+		refs.values[name] = make(map[int]string)
+		for _, v := range values {
+			refs.values[name][v.ID] = strings.TrimSpace(v.Value)
+		}
+	}
+	for _, v := range sets.Countries {
+		refs.countries[v.ID] = strings.TrimSpace(v.Value)
+	}
+	for _, v := range sets.PartySubTypes {
+		refs.partySubTypes[v.ID] = v
+	}
+	for _, loc := range doc.Locations {
+		refs.locations[loc.ID] = loc
+	}
+	for _, d := range doc.IDRegDocuments {
+		refs.documents[d.IdentityID] = append(refs.documents[d.IdentityID], d)
+	}
+	for _, e := range doc.SanctionsEntries {
+		refs.entries[e.ProfileID] = append(refs.entries[e.ProfileID], e)
+	}
+	for _, r := range doc.ProfileRelationships {
+		refs.relationships[r.FromProfileID] = append(refs.relationships[r.FromProfileID], r)
+	}
+	return refs
+}
+
+func (r *advancedRefs) value(set string, id int) string {
+	return r.values[set][id]
+}
+
+// ConvertAdvanced maps each profile of OFAC's Advanced XML into an Entity. The features which the CSV files
+// only hold as remarks are read into their typed fields.
+func ConvertAdvanced(doc *AdvancedSanctions) []search.Entity[search.Value] {
+	if doc == nil {
+		return nil
+	}
+	refs := newAdvancedRefs(doc)
+
+	type profile struct {
+		party   AdvancedDistinctParty
+		profile AdvancedProfile
+		kind    string
+	}
+	var profiles []profile
+	for _, party := range doc.DistinctParties {
+		for _, p := range party.Profiles {
+			kind := refs.entityKind(p.PartySubTypeID)
+			profiles = append(profiles, profile{party: party, profile: p, kind: kind})
+
+			// Names are needed before relationships can be described
+			if names := refs.names(p, kind); len(names) > 0 {
+				refs.profileNames[p.ID] = names[0]
+			}
+		}
+	}
+
+	out := make([]search.Entity[search.Value], 0, len(profiles))
+	for _, p := range profiles {
+		out = append(out, refs.toEntity(p.party, p.profile, p.kind))
+	}
+	return out
+}
+
+// entityKind returns "individual", "entity", "vessel" or "aircraft" from a profile's PartySubType
+func (r *advancedRefs) entityKind(partySubTypeID int) string {
+	sub := r.partySubTypes[partySubTypeID]
+	switch strings.ToLower(strings.TrimSpace(sub.Value)) {
+	case "vessel":
+		return "vessel"
+	case "aircraft":
+		return "aircraft"
+	}
+	if strings.EqualFold(r.value("PartyType", sub.PartyTypeID), "Individual") {
+		return "individual"
+	}
+	return "entity"
+}
+
+// names returns the primary name of a profile followed by its aliases, excluding weak aliases. The given names of
+// individuals are placed before their last names, matching how ReorderSDNName orders the CSV names.
+func (r *advancedRefs) names(p AdvancedProfile, kind string) []string {
+	var primary string
+	var others []string
+	r.eachName(p, kind, func(alias AdvancedAlias, full string) {
+		if alias.LowQuality {
+			return // see weakNames
+		}
+		if alias.Primary && primary == "" {
+			primary = full
+		} else {
+			others = append(others, full)
+		}
+	})
+	if primary == "" && len(others) > 0 {
+		primary, others = others[0], others[1:]
+	}
+	if primary == "" {
+		return nil
+	}
+	return append([]string{primary}, deduplicateStrings(others)...)
+}
+
+// weakNames returns the aliases of a profile OFAC marks as low quality, which it publishes as weak a.k.a.s
+func (r *advancedRefs) weakNames(p AdvancedProfile, kind string) []string {
+	var out []string
+	r.eachName(p, kind, func(alias AdvancedAlias, full string) {
+		if alias.LowQuality {
+			out = append(out, full)
+		}
+	})
+	return deduplicateStrings(out)
+}
+
+// eachName calls fn with the full name of each alias of a profile
+func (r *advancedRefs) eachName(p AdvancedProfile, kind string, fn func(alias AdvancedAlias, full string)) {
+	for _, identity := range p.Identities {
+		groupTypes := make(map[int]string)
+		for _, g := range identity.NamePartGroups {
+			groupTypes[g.ID] = r.value("NamePartType", g.NamePartTypeID)
+		}
+
+		for _, alias := range identity.Aliases {
+			for _, name := range alias.DocumentedName {
+				var given, last []string
+				for _, part := range name.Parts {
+					value := strings.TrimSpace(part.Value)
+					if value == "" {
+						continue
+					}
+					if kind == "individual" && strings.EqualFold(groupTypes[part.NamePartGroupID], "Last Name") {
+						last = append(last, value)
+					} else {
+						given = append(given, value)
+					}
+				}
+				if full := strings.Join(append(given, last...), " "); full != "" {
+					fn(alias, full)
+				}
+			}
+		}
+	}
+}
+
+// featureValue returns the text of a feature version, which is held as text, a DetailReference or a location
+func (r *advancedRefs) featureValue(version AdvancedFeatureVersion) string {
+	for _, d := range version.Details {
+		if v := strings.TrimSpace(d.Value); v != "" {
+			return v
+		}
+		if v := r.value("DetailReference", d.DetailReferenceID); v != "" {
+			return v
+		}
+	}
+	for _, link := range version.Locations {
+		if v := r.formatLocation(r.locations[link.LocationID]); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// featureCountry returns the country of a feature version's location, such as a nationality
+func (r *advancedRefs) featureCountry(version AdvancedFeatureVersion) string {
+	for _, link := range version.Locations {
+		for _, c := range r.locations[link.LocationID].Countries {
+			if name := r.countries[c.CountryID]; name != "" {
+				return name
+			}
+		}
+	}
+	return r.featureValue(version)
+}
+
+func (r *advancedRefs) locationParts(loc AdvancedLocation) map[string]string {
+	out := make(map[string]string)
+	for _, part := range loc.Parts {
+		typ := strings.ToUpper(r.value("LocPartType", part.LocPartTypeID))
+		for _, v := range part.Values {
+			if v.Primary || out[typ] == "" {
+				out[typ] = strings.TrimSpace(v.Value)
+			}
+		}
+	}
+	return out
+}
+
+func (r *advancedRefs) locationCountry(loc AdvancedLocation) string {
+	for _, c := range loc.Countries {
+		if name := r.countries[c.CountryID]; name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+func (r *advancedRefs) toAddress(loc AdvancedLocation) search.Address {
+	parts := r.locationParts(loc)
+	return search.Address{
+		Line1:      parts["ADDRESS1"],
+		Line2:      strings.TrimSpace(parts["ADDRESS2"] + " " + parts["ADDRESS3"]),
+		City:       parts["CITY"],
+		PostalCode: parts["POSTAL CODE"],
+		State:      parts["STATE/PROVINCE"],
+		Country:    r.locationCountry(loc),
+	}
+}
+
+// formatLocation writes a location as "city, state, country", such as a place of birth
+func (r *advancedRefs) formatLocation(loc AdvancedLocation) string {
+	parts := r.locationParts(loc)
+	var out []string
+	for _, key := range []string{"ADDRESS1", "ADDRESS2", "ADDRESS3", "CITY", "STATE/PROVINCE", "REGION", "UNKNOWN"} {
+		if v := parts[key]; v != "" {
+			out = append(out, v)
+		}
+	}
+	if country := r.locationCountry(loc); country != "" {
+		out = append(out, country)
+	}
+	return strings.Join(out, ", ")
+}
+
+func (r *advancedRefs) toEntity(party AdvancedDistinctParty, p AdvancedProfile, kind string) search.Entity[search.Value] {
+	names := r.names(p, kind)
+	var name string
+	var altNames []string
+	if len(names) > 0 {
+		name, altNames = names[0], names[1:]
+	}
+
+	out := search.Entity[search.Value]{
+		Name:         name,
+		Source:       search.SourceUSOFAC,
+		SourceData:   party,
+		SourceID:     strings.TrimSpace(party.FixedRef),
+		WeakAltNames: r.weakNames(p, kind),
+	}
+
+	var (
+		titles       []string
+		gender       search.Gender
+		birthDate    *time.Time
+		birthDates   []search.DateRange
+		placeOfBirth string
+		nationality  []string
+		established  *time.Time
+	)
+	vessel := &search.Vessel{Name: name, AltNames: altNames}
+	aircraft := &search.Aircraft{Name: name, AltNames: altNames}
+
+	for _, feature := range p.Features {
+		featureType := r.value("FeatureType", feature.FeatureTypeID)
+		for _, version := range feature.Versions {
+			value := r.featureValue(version)
+
+			switch {
+			case featureType == "Birthdate":
+				for _, period := range version.DatePeriods {
+					if birthDate == nil {
+						if t := period.Start.From.Time(); !t.IsZero() {
+							birthDate = &t
+						}
+					}
+					if rng, ok := advancedDateRange(period); ok {
+						birthDates = append(birthDates, rng)
+					}
+				}
+			case featureType == "Place of Birth":
+				if placeOfBirth == "" {
+					placeOfBirth = value
+				}
+			case featureType == "Nationality Country", featureType == "Citizenship Country":
+				if country := r.featureCountry(version); country != "" {
+					nationality = append(nationality, country)
+				}
+			case featureType == "Gender":
+				gender = search.Gender(strings.ToLower(value))
+			case featureType == "Title":
+				titles = append(titles, value)
+			case featureType == "Location":
+				for _, link := range version.Locations {
+					if addr := r.toAddress(r.locations[link.LocationID]); addr.Line1 != "" || addr.City != "" {
+						out.Addresses = append(out.Addresses, addr)
+					}
+				}
+			case strings.HasPrefix(featureType, "Digital Currency Address - "):
+				if value != "" {
+					out.CryptoAddresses = append(out.CryptoAddresses, search.CryptoAddress{
+						Currency: strings.TrimSpace(strings.TrimPrefix(featureType, "Digital Currency Address - ")),
+						Address:  value,
+					})
+				}
+			case featureType == "Email Address":
+				out.Contact.EmailAddresses = append(out.Contact.EmailAddresses, value)
+			case featureType == "Phone Number":
+				out.Contact.PhoneNumbers = append(out.Contact.PhoneNumbers, value)
+			case featureType == "Fax Number":
+				out.Contact.FaxNumbers = append(out.Contact.FaxNumbers, value)
+			case featureType == "Website":
+				out.Contact.Websites = append(out.Contact.Websites, value)
+			case strings.HasPrefix(featureType, "Additional Sanctions Information"):
+				if out.SanctionsInfo == nil {
+					out.SanctionsInfo = &search.SanctionsInfo{}
+				}
+				out.SanctionsInfo.Description = value
+				if strings.Contains(strings.ToLower(value), "secondary sanctions") {
+					out.SanctionsInfo.Secondary = true
+				}
+			case featureType == "Organization Established Date":
+				for _, period := range version.DatePeriods {
+					if t := period.Start.From.Time(); !t.IsZero() && established == nil {
+						established = &t
+					}
+				}
+
+			// Vessels
+			case featureType == "Vessel Call Sign":
+				vessel.CallSign = value
+			case featureType == "Vessel Type":
+				vessel.Type = normalizeVesselType(value)
+			case featureType == "Vessel Flag":
+				vessel.Flag = normalizeCountryCode(r.featureCountry(version))
+			case featureType == "Vessel Owner":
+				vessel.Owner = value
+			case featureType == "Vessel Tonnage":
+				vessel.Tonnage = parseTonnage(value)
+			case featureType == "Vessel Gross Registered Tonnage":
+				vessel.GrossRegisteredTonnage = parseTonnage(value)
+			case strings.EqualFold(featureType, "Vessel Year of Build"):
+				if year, err := strconv.Atoi(strings.TrimSpace(value)); err == nil {
+					built := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+					vessel.Built = &built
+				}
+
+			// Aircraft
+			case featureType == "Aircraft Model":
+				aircraft.Model = value
+			case featureType == "Aircraft Tail Number":
+				aircraft.TailNumber = value
+			case featureType == "Previous Aircraft Tail Number":
+				aircraft.PreviousTailNumbers = append(aircraft.PreviousTailNumbers, value)
+			case strings.HasPrefix(featureType, "Aircraft Manufacturer") && strings.Contains(featureType, "Serial Number"):
+				aircraft.SerialNumber = value
+			case featureType == "Aircraft Manufacture Date":
+				for _, period := range version.DatePeriods {
+					if t := period.Start.From.Time(); !t.IsZero() && aircraft.Built == nil {
+						aircraft.Built = &t
+					}
+				}
+			}
+		}
+	}
+
+	// Identity documents
+	var governmentIDs []search.GovernmentID
+	for _, identity := range p.Identities {
+		for _, d := range r.documents[identity.ID] {
+			docType := r.value("IDRegDocType", d.IDRegDocTypeID)
+			number := strings.TrimSpace(d.IDRegistrationNo)
+			if number == "" {
+				continue
+			}
+			switch docType {
+			case "Vessel Registration Identification":
+				vessel.IMONumber = strings.TrimSpace(strings.TrimPrefix(number, "IMO"))
+				continue
+			case "MMSI":
+				vessel.MMSI = number
+				continue
+			}
+			for _, id := range parseGovernmentIDs([]string{docType + " " + number}) {
+				id.Country = normalizeCountry(r.countries[d.IssuedByCountryID])
+				governmentIDs = append(governmentIDs, id)
+			}
+		}
+	}
+
+	// Sanctions programs and relationships
+	var programs []string
+	for _, entry := range r.entries[p.ID] {
+		for _, measure := range entry.Measures {
+			if strings.EqualFold(r.value("SanctionsType", measure.SanctionsTypeID), "Program") {
+				programs = append(programs, measure.Comment)
+			}
+		}
+	}
+	out.SanctionsInfo = withPrograms(out.SanctionsInfo, programs)
+
+	for _, rel := range r.relationships[p.ID] {
+		if entityName := r.profileNames[rel.ToProfileID]; entityName != "" {
+			out.Affiliations = append(out.Affiliations, search.Affiliation{
+				EntityName: entityName,
+				Type:       r.value("RelationType", rel.RelationTypeID),
+			})
+		}
+	}
+	out.Affiliations = deduplicateAffiliations(out.Affiliations)
+
+	switch kind {
+	case "individual":
+		out.Type = search.EntityPerson
+		out.Person = &search.Person{
+			Name:          name,
+			AltNames:      altNames,
+			Gender:        gender,
+			BirthDate:     birthDate,
+			BirthDates:    birthDates,
+			Titles:        deduplicateTitles(titles),
+			PlaceOfBirth:  placeOfBirth,
+			Nationalities: deduplicateStrings(nationality),
+			GovernmentIDs: governmentIDs,
+		}
+
+	case "vessel":
+		out.Type = search.EntityVessel
+		out.Vessel = vessel
+
+	case "aircraft":
+		out.Type = search.EntityAircraft
+		if aircraft.TailNumber == "" && tailNumberRegex.MatchString(name) {
+			aircraft.TailNumber = name
+		}
+		out.Aircraft = aircraft
+
+	default:
+		out.Type = search.EntityBusiness
+		out.Business = &search.Business{
+			Name:          prepare.RemoveCompanyTitles(name),
+			AltNames:      altNames,
+			Created:       established,
+			GovernmentIDs: governmentIDs,
+		}
+	}
+
+	return out
+}
+
+// advancedDateRange returns the days a DatePeriod covers, from the earliest start to the latest end
+func advancedDateRange(period AdvancedDatePeriod) (search.DateRange, bool) {
+	start := period.Start.From.Time()
+	if start.IsZero() {
+		return search.DateRange{}, false
+	}
+	end := period.End.To.Time()
+	if end.IsZero() || end.Before(start) {
+		end = start
+	}
+	if invalidDate(start) {
+		return search.DateRange{}, false
+	}
+	return search.DateRange{Start: start, End: end}, true
+}
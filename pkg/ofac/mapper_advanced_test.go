@@ -0,0 +1,102 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package ofac
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertAdvanced(t *testing.T) {
+	doc, err := ReadAdvanced(testInputs(t, filepath.Join("testdata", "sdn_advanced.xml")))
+	require.NoError(t, err)
+	require.Equal(t, 2024, doc.DateOfIssue.Year)
+
+	entities := ConvertAdvanced(doc)
+	require.Len(t, entities, 3)
+
+	find := func(sourceID string) search.Entity[search.Value] {
+		for _, e := range entities {
+			if e.SourceID == sourceID {
+				return e
+			}
+		}
+		t.Fatalf("entity %s not found", sourceID)
+		return search.Entity[search.Value]{}
+	}
+
+	t.Run("person", func(t *testing.T) {
+		e := find("15102")
+		require.Equal(t, "Daniel MORENO", e.Name)
+		require.Equal(t, search.EntityPerson, e.Type)
+		require.Equal(t, search.SourceUSOFAC, e.Source)
+
+		p := e.Person
+		require.NotNil(t, p)
+		require.Equal(t, []string{"Daniel MORENO CHAN"}, p.AltNames)
+		require.Equal(t, []string{"EL CHINO"}, e.WeakAltNames)
+		require.Equal(t, search.GenderMale, p.Gender)
+		require.Equal(t, "1972-10-12", p.BirthDate.Format(time.DateOnly))
+		require.Len(t, p.BirthDates, 2)
+		require.Equal(t, "1970-12-31", p.BirthDates[1].End.Format(time.DateOnly))
+		require.Equal(t, "Corozal, Belize", p.PlaceOfBirth)
+		require.Equal(t, []string{"Belize"}, p.Nationalities)
+
+		require.Len(t, p.GovernmentIDs, 1)
+		require.Equal(t, search.GovernmentIDPassport, p.GovernmentIDs[0].Type)
+		require.Equal(t, "Belize", p.GovernmentIDs[0].Country)
+		require.Equal(t, "0291622", p.GovernmentIDs[0].Identifier)
+
+		require.Len(t, e.Addresses, 1)
+		require.Equal(t, "Calle 20 de Noviembre 12", e.Addresses[0].Line1)
+		require.Equal(t, "Chetumal", e.Addresses[0].City)
+		require.Equal(t, "77000", e.Addresses[0].PostalCode)
+		require.Equal(t, "Mexico", e.Addresses[0].Country)
+
+		require.Equal(t, []search.CryptoAddress{
+			{Currency: "XBT", Address: "1AjZPMsnmpdK2Rv9KQNfMurTXinscVro9V"},
+		}, e.CryptoAddresses)
+		require.Equal(t, []string{"SDNTK"}, e.SanctionsInfo.Programs)
+	})
+
+	t.Run("vessel", func(t *testing.T) {
+		e := find("15036")
+		require.Equal(t, search.EntityVessel, e.Type)
+
+		v := e.Vessel
+		require.NotNil(t, v)
+		require.Equal(t, "ARTAVIL", v.Name)
+		require.Equal(t, "9187629", v.IMONumber)
+		require.Equal(t, "572469210", v.MMSI)
+		require.Equal(t, "T2EU4", v.CallSign)
+		require.Equal(t, search.VesselTypeTanker, v.Type)
+		require.Equal(t, "Iran", v.Flag)
+		require.Equal(t, 99144, v.Tonnage)
+		require.Equal(t, 56068, v.GrossRegisteredTonnage)
+
+		require.True(t, e.SanctionsInfo.Secondary)
+		require.Equal(t, []string{"IRAN"}, e.SanctionsInfo.Programs)
+		require.Equal(t, []search.Affiliation{
+			{EntityName: "NATIONAL IRANIAN TANKER COMPANY", Type: "Linked To"},
+		}, e.Affiliations)
+	})
+
+	t.Run("business", func(t *testing.T) {
+		e := find("7002")
+		require.Equal(t, search.EntityBusiness, e.Type)
+		require.Equal(t, "NATIONAL IRANIAN TANKER COMPANY", e.Name)
+		require.NotNil(t, e.Business)
+	})
+}
+
+func TestReadAdvanced_Missing(t *testing.T) {
+	_, err := ReadAdvanced(testInputs(t, filepath.Join("testdata", "sdn.csv")))
+	require.ErrorContains(t, err, "missing SDN_ADVANCED.XML")
+}
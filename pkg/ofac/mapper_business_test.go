@@ -28,15 +28,16 @@ func TestMapperBusiness__FromSource(t *testing.T) {
 
 		business := found.Business
 		require.Equal(t, "SUEX OTC, S.R.O.", business.Name)
-		require.ElementsMatch(t, []string{"SUCCESSFUL EXCHANGE"}, business.AltNames)
+		require.Empty(t, business.AltNames)
+		require.Equal(t, []string{"SUCCESSFUL EXCHANGE"}, found.WeakAltNames)
 
 		createdAt := time.Date(2018, time.September, 25, 0, 0, 0, 0, time.UTC)
 		require.Equal(t, createdAt.Format(time.RFC3339), business.Created.Format(time.RFC3339))
 		require.Nil(t, business.Dissolved)
 
 		expectedGovernmentIDs := []search.GovernmentID{
-			{Type: search.GovernmentIDBusinessRegisration, Country: "Czech Republic", Identifier: "07486049"},
-			{Type: search.GovernmentIDBusinessRegisration, Country: "Czech Republic", Identifier: "5299007NTWCC3U23WM81"},
+			{Type: search.GovernmentIDBusinessRegisration, Country: "CZ", Identifier: "07486049"},
+			{Type: search.GovernmentIDLEI, Country: "CZ", Identifier: "5299007NTWCC3U23WM81"},
 		}
 		require.ElementsMatch(t, expectedGovernmentIDs, business.GovernmentIDs)
 
@@ -44,7 +45,23 @@ func TestMapperBusiness__FromSource(t *testing.T) {
 			Websites: []string{"suex.io"},
 		}
 		require.Equal(t, expectedContact, found.Contact)
-		require.Empty(t, found.Addresses)
+
+		expectedAddresses := []search.Address{
+			{
+				Line1:      "Presnenskaya Embankment",
+				Line2:      "12, Federation East Tower, Floor 31, Suite Q",
+				City:       "Moscow",
+				PostalCode: "123317",
+				Country:    "RU",
+			},
+			{
+				Line1:      "Skorepka 1058/8 Stare Mesto",
+				City:       "Prague",
+				PostalCode: "110 00",
+				Country:    "CZ",
+			},
+		}
+		require.Equal(t, expectedAddresses, found.Addresses)
 
 		expectedCryptoAddresses := []search.CryptoAddress{
 			{Currency: "XBT", Address: "12HQDsicffSBaYdJ6BhnE22sfjTESmmzKx"},
@@ -76,7 +93,7 @@ func TestMapperBusiness__FromSource(t *testing.T) {
 		require.ElementsMatch(t, expectedCryptoAddresses, found.CryptoAddresses)
 
 		require.Empty(t, found.Affiliations)
-		require.Nil(t, found.SanctionsInfo)
+		require.Equal(t, &search.SanctionsInfo{Programs: []string{"CYBER2"}}, found.SanctionsInfo)
 		require.Empty(t, found.HistoricalInfo)
 
 		sdn, ok := found.SourceData.(ofac.SDN)
@@ -105,7 +122,7 @@ func TestMapperBusiness__FromSource(t *testing.T) {
 		t.Logf("%#v", found.Business)
 
 		expectedGovernmentIDs := []search.GovernmentID{
-			{Type: "tax-id", Country: "United States", Identifier: "52-2083095"},
+			{Type: "tax-id", Country: "US", Identifier: "52-2083095"},
 			{Type: "business-registration", Country: "Connecticut", Identifier: "0582030"},
 			{Type: "business-registration", Country: "Florida", Identifier: "M99000000961"},
 		}
@@ -129,10 +146,9 @@ func TestMapperBusiness__FromSource(t *testing.T) {
 		business := found.Business
 		require.Equal(t, "AUTONOMOUS NON-PROFIT ORGANIZATION DIALOG REGIONS", business.Name)
 
+		require.Equal(t, []string{"DIALOGUE REGIONS", "DIALOG REGIONY", "DIALOGUE"}, found.WeakAltNames)
+
 		expectedAltNames := []string{
-			"DIALOGUE REGIONS",
-			"DIALOG REGIONY",
-			"DIALOGUE",
 			"AUTONOMOUS NON-PROFIT ORGANIZATION FOR THE DEVELOPMENT OF DIGITAL PROJECTS IN THE FIELD OF PUBLIC RELATIONS AND COMMUNICATIONS DIALOG REGIONS",
 			"ANO DIALOG REGIONS",
 			"AVTONOMNAYA NEKOMMERCHESKAYA ORGANIZATSIYA PO RAZVITIYU TSIFROVYKH PROEKTOV V SFERE OBSHCHESTEVENNYKH SVYAZEI I KOMMUNIKATSII DIALOG REGIONY",
@@ -144,8 +160,8 @@ func TestMapperBusiness__FromSource(t *testing.T) {
 		require.Nil(t, business.Dissolved)
 
 		expectedGovernmentIDs := []search.GovernmentID{
-			{Type: search.GovernmentIDBusinessRegisration, Country: "Russia", Identifier: "1207700248030"},
-			{Type: search.GovernmentIDTax, Country: "Russia", Identifier: "9709063550"},
+			{Type: search.GovernmentIDBusinessRegisration, Country: "RU", Identifier: "1207700248030"},
+			{Type: search.GovernmentIDTax, Country: "RU", Identifier: "9709063550"},
 		}
 		require.ElementsMatch(t, expectedGovernmentIDs, business.GovernmentIDs)
 
@@ -153,7 +169,15 @@ func TestMapperBusiness__FromSource(t *testing.T) {
 			Websites: []string{"www.dialog.info", "www.dialog-regions.ru"},
 		}
 		require.Equal(t, expectedContact, found.Contact)
-		require.Empty(t, found.Addresses)
+		require.Equal(t, []search.Address{
+			{
+				Line1:      "Ul. Timura Frunze",
+				Line2:      "D. 11, Str. 1, Floor 1, Pomeshch. I, Kom. 2",
+				City:       "Moscow",
+				PostalCode: "119021",
+				Country:    "RU",
+			},
+		}, found.Addresses)
 		require.Empty(t, found.CryptoAddresses)
 
 		expectedAffiliations := []search.Affiliation{
@@ -161,7 +185,7 @@ func TestMapperBusiness__FromSource(t *testing.T) {
 		}
 		require.ElementsMatch(t, expectedAffiliations, found.Affiliations)
 
-		require.Nil(t, found.SanctionsInfo)
+		require.Equal(t, &search.SanctionsInfo{Programs: []string{"RUSSIA-EO14024"}}, found.SanctionsInfo)
 		require.Empty(t, found.HistoricalInfo)
 
 		sdn, ok := found.SourceData.(ofac.SDN)
@@ -6,7 +6,7 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestParseAltNames(t *testing.T) {
+func TestParseWeakAltNames(t *testing.T) {
 	tests := []struct {
 		remarks  []string
 		expected []string
@@ -30,7 +30,7 @@ func TestParseAltNames(t *testing.T) {
 	}
 
 	for _, tt := range tests {
-		result := parseAltNames(tt.remarks)
+		result := parseWeakAltNames(tt.remarks)
 		require.Equal(t, tt.expected, result)
 	}
 }
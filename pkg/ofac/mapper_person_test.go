@@ -30,10 +30,10 @@ func TestMapperPerson__FromSource(t *testing.T) {
 		require.Equal(t, "Dmitry Yuryevich KHOROSHEV", person.Name)
 
 		expectedAltNames := []string{
-			"LOCKBITSUPP", "Dmitriy Yurevich KHOROSHEV",
-			"Dmitry YURIEVICH", "Dmitrii Yuryevich KHOROSHEV",
+			"Dmitriy Yurevich KHOROSHEV", "Dmitry YURIEVICH", "Dmitrii Yuryevich KHOROSHEV",
 		}
 		require.ElementsMatch(t, expectedAltNames, person.AltNames)
+		require.Equal(t, []string{"LOCKBITSUPP"}, found.WeakAltNames)
 
 		require.Equal(t, search.GenderMale, person.Gender)
 
@@ -46,17 +46,17 @@ func TestMapperPerson__FromSource(t *testing.T) {
 		expectedGovernmentIDs := []search.GovernmentID{
 			{
 				Type:       search.GovernmentIDPassport,
-				Country:    "Russia",
+				Country:    "RU",
 				Identifier: "2018278055",
 			},
 			{
 				Type:       search.GovernmentIDPassport,
-				Country:    "Russia",
+				Country:    "RU",
 				Identifier: "2006801524",
 			},
 			{
 				Type:       search.GovernmentIDTax,
-				Country:    "Russia",
+				Country:    "RU",
 				Identifier: "366110340670",
 			},
 		}
@@ -71,7 +71,7 @@ func TestMapperPerson__FromSource(t *testing.T) {
 			EmailAddresses: []string{"khoroshev1@icloud.com", "sitedev5@yandex.ru"},
 		}
 		require.Equal(t, expectedContact, found.Contact)
-		require.Empty(t, found.Addresses)
+		require.Equal(t, []search.Address{{Country: "RU"}}, found.Addresses)
 
 		expectedCryptoAddresses := []search.CryptoAddress{
 			{Currency: "XBT", Address: "bc1qvhnfknw852ephxyc5hm4q520zmvf9maphetc9z"},
@@ -79,7 +79,7 @@ func TestMapperPerson__FromSource(t *testing.T) {
 		require.ElementsMatch(t, expectedCryptoAddresses, found.CryptoAddresses)
 
 		require.Empty(t, found.Affiliations)
-		require.Nil(t, found.SanctionsInfo)
+		require.Equal(t, &search.SanctionsInfo{Programs: []string{"CYBER2"}}, found.SanctionsInfo)
 		require.Empty(t, found.HistoricalInfo)
 
 		sdn, ok := found.SourceData.(ofac.SDN)
@@ -105,7 +105,7 @@ func TestMapper__Person(t *testing.T) {
 
 	passport := e.Person.GovernmentIDs[0]
 	require.Equal(t, search.GovernmentIDPassport, passport.Type)
-	require.Equal(t, "Belize", passport.Country)
+	require.Equal(t, "BZ", passport.Country)
 	require.Equal(t, "0291622", passport.Identifier)
 
 	require.Nil(t, e.Business)
@@ -137,10 +137,12 @@ func TestMapper__CompletePerson(t *testing.T) {
 	require.Equal(t, search.GenderMale, e.Person.Gender)
 	require.Equal(t, "1968-10-28T00:00:00Z", e.Person.BirthDate.Format(time.RFC3339))
 	require.Nil(t, e.Person.DeathDate)
+	require.Equal(t, "Baghdad, Iraq", e.Person.PlaceOfBirth)
+	require.Equal(t, []string{"Iran"}, e.Person.Nationalities)
 
-	// Test alt names
-	require.Len(t, e.Person.AltNames, 1)
-	require.Equal(t, "Hajji SHIBL", e.Person.AltNames[0])
+	// Aliases quoted in the remarks are weak
+	require.Empty(t, e.Person.AltNames)
+	require.Equal(t, []string{"Hajji SHIBL"}, e.WeakAltNames)
 
 	// Test government IDs
 	require.Len(t, e.Person.GovernmentIDs, 2)
@@ -68,6 +68,27 @@ func TestParseTime(t *testing.T) {
 	})
 }
 
+func TestParseDateRange(t *testing.T) {
+	cases := map[string][2]string{
+		"01 Apr 1950":                {"1950-04-01", "1950-04-01"},
+		"01 Feb 1958 to 28 Feb 1958": {"1958-02-01", "1958-02-28"},
+		"1928":                       {"1928-01-01", "1928-12-31"},
+		"1928 to 1930":               {"1928-01-01", "1930-12-31"},
+		"Sep 1958":                   {"1958-09-01", "1958-09-30"},
+		"circa 01 Jan 1961":          {"1960-01-01", "1962-01-01"},
+		"circa 1979-1982":            {"1978-01-01", "1983-12-31"},
+	}
+	for input, expected := range cases {
+		r, ok := parseDateRange(dobPatterns, input)
+		require.True(t, ok, input)
+		require.Equal(t, expected[0], r.Start.Format(time.DateOnly), input)
+		require.Equal(t, expected[1], r.End.Format(time.DateOnly), input)
+	}
+
+	_, ok := parseDateRange(dobPatterns, "unknown")
+	require.False(t, ok)
+}
+
 func TestParseGovernmentIDs(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -144,6 +165,37 @@ func TestParseGovernmentIDs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "swift bic",
+			remarks: []string{
+				"SWIFT/BIC HNRBBY2X",
+			},
+			want: []search.GovernmentID{
+				{
+					Type:       search.GovernmentIDSWIFT,
+					Identifier: "HNRBBY2X",
+				},
+			},
+		},
+		{
+			name: "legal entity numbers",
+			remarks: []string{
+				"Legal Entity Number 253400V1H6ART1UQ0N98 (Russia)",
+				"Legal Entity Number 851683897 (Netherlands)",
+			},
+			want: []search.GovernmentID{
+				{
+					Type:       search.GovernmentIDLEI,
+					Country:    "Russia",
+					Identifier: "253400V1H6ART1UQ0N98",
+				},
+				{
+					Type:       search.GovernmentIDBusinessRegisration,
+					Country:    "Netherlands",
+					Identifier: "851683897",
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -70,6 +70,8 @@ func TestMapper__Aircraft(t *testing.T) {
 	require.Equal(t, "1995-01-01", e.Aircraft.Built.Format(time.DateOnly))
 	require.Equal(t, "Airbus A321-131", e.Aircraft.Model)
 	require.Equal(t, "550", e.Aircraft.SerialNumber)
+	require.Empty(t, e.Aircraft.TailNumber)
+	require.Equal(t, []string{"2-WGLP"}, e.Aircraft.PreviousTailNumbers)
 
 	sourceData, ok := e.SourceData.(SDN)
 	require.True(t, ok)
@@ -141,6 +143,15 @@ func TestNormalizeVesselType(t *testing.T) {
 		{"cargo", search.VesselTypeCargo},
 		{"Cargo", search.VesselTypeCargo},
 		{"CARGO", search.VesselTypeCargo},
+		{"General Cargo", search.VesselTypeCargo},
+		{"Crude Oil Tanker", search.VesselTypeTanker},
+		{"Chemical/Products Tanker", search.VesselTypeTanker},
+		{"Bulk Carrier", search.VesselTypeBulkCarrier},
+		{"Container Ship", search.VesselTypeContainer},
+		{"Tug", search.VesselTypeTug},
+		{"Passenger Ferry", search.VesselTypePassenger},
+		{"Fishing Vessel", search.VesselTypeFishing},
+		{"Yacht", search.VesselTypeYacht},
 		{"unknown", search.VesselTypeUnknown},
 		{"", search.VesselTypeUnknown},
 	}
@@ -151,6 +162,17 @@ func TestNormalizeVesselType(t *testing.T) {
 	}
 }
 
+func TestParseTailNumber(t *testing.T) {
+	require.Equal(t, "EP-MMH", parseTailNumber("MSN 391", []string{"Aircraft Model Airbus A340-642", "Aircraft Tail Number EP-MMH"}))
+	require.Equal(t, "", parseTailNumber("MSN 449", []string{"Previous Aircraft Tail Number YI-NAE"}))
+
+	// Aircraft listed under their registration
+	require.Equal(t, "EP-GOL", parseTailNumber("EP-GOL", nil))
+	require.Equal(t, "EX-35011", parseTailNumber("EX-35011", nil))
+	require.Equal(t, "N200VR", parseTailNumber("N200VR", nil))
+	require.Equal(t, "", parseTailNumber("MSN 550", nil))
+}
+
 func TestNormalizeAircraftType(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -11,6 +11,7 @@ import (
 	"io"
 	"path/filepath"
 	"strings"
+	"unicode"
 )
 
 // Read will consume the file at path and attempt to parse it was a CSV OFAC file.
@@ -59,6 +60,9 @@ func Read(files map[string]io.ReadCloser) (*Results, error) {
 	// Merge extended comments into SDN
 	res.SDNs = mergeSpilloverRecords(res.SDNs, res.SDNComments)
 
+	// Drop alternate names and addresses which repeat another of their entity
+	res.Duplicates = res.deduplicate()
+
 	return res, nil
 }
 
@@ -74,6 +78,66 @@ type Results struct {
 
 	// SDNComments returns an array of OFAC Specially Designated National Comments
 	SDNComments map[string][]SDNComments `json:"sdnComments"`
+
+	// Duplicates counts the rows of alt.csv and add.csv which were dropped for repeating another of their entity
+	Duplicates Duplicates `json:"duplicates"`
+}
+
+// Duplicates counts rows which only differ from an earlier row of the same entity by case, punctuation or spacing
+type Duplicates struct {
+	AlternateIdentities int `json:"alternateIdentities"`
+	Addresses           int `json:"addresses"`
+}
+
+func (d Duplicates) Total() int {
+	return d.AlternateIdentities + d.Addresses
+}
+
+// deduplicate keeps the first of each entity's alternate names and addresses which are the same once normalized,
+// since repeated aliases grow the index and count more than once towards a match.
+func (r *Results) deduplicate() Duplicates {
+	var out Duplicates
+	for entityID, alts := range r.AlternateIdentities {
+		kept := alts[:0]
+		seen := make(map[string]bool)
+		for _, alt := range alts {
+			key := normalizeRow(alt.AlternateName)
+			if seen[key] {
+				out.AlternateIdentities++
+				continue
+			}
+			seen[key] = true
+			kept = append(kept, alt)
+		}
+		r.AlternateIdentities[entityID] = kept
+	}
+	for entityID, addresses := range r.Addresses {
+		kept := addresses[:0]
+		seen := make(map[string]bool)
+		for _, addr := range addresses {
+			key := normalizeRow(addr.Address, addr.CityStateProvincePostalCode, addr.Country)
+			if seen[key] {
+				out.Addresses++
+				continue
+			}
+			seen[key] = true
+			kept = append(kept, addr)
+		}
+		r.Addresses[entityID] = kept
+	}
+	return out
+}
+
+// normalizeRow lowercases fields and reduces them to their words of letters and digits, so "ACME, INC." and
+// "Acme Inc" are the same row
+func normalizeRow(fields ...string) string {
+	words := make([]string, 0, len(fields))
+	for _, field := range fields {
+		words = append(words, strings.Join(strings.FieldsFunc(strings.ToLower(field), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		}), " "))
+	}
+	return strings.Join(words, "|")
 }
 
 func (r *Results) append(rr *Results, err error) error {
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/moov-io/base/log"
@@ -54,6 +55,36 @@ func TestRead(t *testing.T) {
 	require.Len(t, res.SDNComments, 13)
 }
 
+func TestRead_Duplicates(t *testing.T) {
+	alt := `306,220,"aka","BANCO NACIONAL DE CUBA","-0-"
+306,221,"aka","Banco Nacional de Cuba.","-0-"
+306,222,"fka","NATIONAL BANK OF CUBA","-0-"
+307,223,"aka","BANCO NACIONAL DE CUBA","-0-"
+`
+	add := `306,200,"Dai-Ichi Bldg. 6th Floor, 10-2 Nihombashi","Tokyo 103","Japan","-0-"
+306,201,"Dai Ichi Bldg 6th Floor 10-2 Nihombashi","TOKYO 103","Japan","-0-"
+306,202,"Avenida de Concha Espina 8","Madrid E-28036","Spain","-0-"
+`
+	res, err := Read(map[string]io.ReadCloser{
+		"alt.csv": io.NopCloser(strings.NewReader(alt)),
+		"add.csv": io.NopCloser(strings.NewReader(add)),
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, Duplicates{AlternateIdentities: 1, Addresses: 1}, res.Duplicates)
+	require.Equal(t, 2, res.Duplicates.Total())
+
+	// The first of each row is kept, and rows of other entities aren't compared
+	require.Len(t, res.AlternateIdentities["306"], 2)
+	require.Equal(t, "220", res.AlternateIdentities["306"][0].AlternateID)
+	require.Equal(t, "222", res.AlternateIdentities["306"][1].AlternateID)
+	require.Len(t, res.AlternateIdentities["307"], 1)
+
+	require.Len(t, res.Addresses["306"], 2)
+	require.Equal(t, "200", res.Addresses["306"][0].AddressID)
+	require.Equal(t, "202", res.Addresses["306"][1].AddressID)
+}
+
 func TestReplaceNull(t *testing.T) {
 	ans := replaceNull(nil)
 	if ans != nil {
@@ -0,0 +1,33 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package opensanctions
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/moov-io/base/log"
+	"github.com/moov-io/base/strx"
+	"github.com/moov-io/watchman/pkg/download"
+)
+
+var (
+	publicDownloadURL = "https://data.opensanctions.org/datasets/latest/peps/entities.ftm.json"
+	downloadURL       = strx.Or(os.Getenv("OPENSANCTIONS_DOWNLOAD_URL"), publicDownloadURL)
+)
+
+const filename = "entities.ftm.json"
+
+// Download returns the entities of an OpenSanctions dataset, which is their PEPs unless
+// OPENSANCTIONS_DOWNLOAD_URL is set.
+func Download(ctx context.Context, logger log.Logger, initialDir string) (map[string]io.ReadCloser, error) {
+	dl := download.New(logger, download.HTTPClient)
+
+	nameAndSource := make(map[string]string)
+	nameAndSource[filename] = downloadURL
+
+	return dl.GetFiles(ctx, initialDir, nameAndSource)
+}
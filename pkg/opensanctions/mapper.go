@@ -0,0 +1,197 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package opensanctions
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/pkg/search"
+)
+
+func ConvertEntities(entities []Entity) []search.Entity[search.Value] {
+	out := make([]search.Entity[search.Value], 0, len(entities))
+	for _, entity := range entities {
+		if converted, ok := ToEntity(entity); ok {
+			out = append(out, converted)
+		}
+	}
+	return out
+}
+
+// ToEntity converts an OpenSanctions entity into a search Entity. Only persons, companies and
+// organizations are converted.
+func ToEntity(src Entity) (search.Entity[search.Value], bool) {
+	out := search.Entity[search.Value]{
+		Name:       strings.TrimSpace(src.Caption),
+		Source:     search.SourceOpenSanctions,
+		SourceID:   src.ID,
+		SourceData: src,
+	}
+	if out.Name == "" {
+		out.Name = src.First("name")
+	}
+	altNames := mapAltNames(src, out.Name)
+	out.WeakAltNames = mapWeakAltNames(src, out.Name, altNames)
+
+	switch src.Schema {
+	case "Person":
+		out.Type = search.EntityPerson
+		out.Person = &search.Person{
+			Name:          out.Name,
+			AltNames:      altNames,
+			Gender:        search.Gender(prepare.NormalizeGender(src.First("gender"))),
+			Titles:        src.Property("title"),
+			PlaceOfBirth:  src.First("birthPlace"),
+			Nationalities: mapCountries(append(src.Property("nationality"), src.Property("citizenship")...)),
+			GovernmentIDs: mapGovernmentIDs(src),
+		}
+		for _, value := range src.Property("birthDate") {
+			if dates, ok := parseDate(value); ok {
+				out.Person.BirthDates = append(out.Person.BirthDates, dates)
+				if out.Person.BirthDate == nil {
+					out.Person.BirthDate = &dates.Start
+				}
+			}
+		}
+		if dates, ok := parseDate(src.First("deathDate")); ok {
+			out.Person.DeathDate = &dates.Start
+		}
+
+	case "Company", "LegalEntity":
+		out.Type = search.EntityBusiness
+		out.Business = &search.Business{
+			Name:          out.Name,
+			AltNames:      altNames,
+			GovernmentIDs: mapGovernmentIDs(src),
+		}
+
+	case "Organization":
+		out.Type = search.EntityOrganization
+		out.Organization = &search.Organization{
+			Name:          out.Name,
+			AltNames:      altNames,
+			GovernmentIDs: mapGovernmentIDs(src),
+		}
+
+	default:
+		return out, false
+	}
+
+	out.Addresses = mapAddresses(src)
+	out.Contact = search.ContactInfo{
+		EmailAddresses: src.Property("email"),
+		PhoneNumbers:   src.Property("phone"),
+		Websites:       src.Property("website"),
+	}
+
+	topics := src.Property("topics")
+	if slices.Contains(topics, TopicPEP) || slices.Contains(topics, TopicRCA) {
+		out.PEP = &search.PEPInfo{
+			Positions: src.Property("position"),
+			Relative:  !slices.Contains(topics, TopicPEP),
+		}
+	}
+	if slices.Contains(topics, TopicSanction) {
+		out.SanctionsInfo = &search.SanctionsInfo{
+			Programs: src.Property("program"),
+			Lists:    src.Datasets,
+		}
+	}
+
+	return out, true
+}
+
+func mapAltNames(src Entity, name string) []string {
+	var out []string
+	for _, prop := range []string{"name", "alias", "previousName"} {
+		for _, alt := range src.Property(prop) {
+			if alt = strings.TrimSpace(alt); alt != "" && alt != name && !slices.Contains(out, alt) {
+				out = append(out, alt)
+			}
+		}
+	}
+	return prepare.WithRomanizedNames(out)
+}
+
+// mapWeakAltNames returns the weakAlias names of src, which are kept apart from its other names
+func mapWeakAltNames(src Entity, name string, altNames []string) []string {
+	var out []string
+	for _, alt := range src.Property("weakAlias") {
+		if alt = strings.TrimSpace(alt); alt != "" && alt != name && !slices.Contains(altNames, alt) && !slices.Contains(out, alt) {
+			out = append(out, alt)
+		}
+	}
+	return out
+}
+
+// mapCountries uppercases the ISO-3166 codes OpenSanctions uses
+func mapCountries(codes []string) []string {
+	var out []string
+	for _, code := range codes {
+		if code = strings.ToUpper(strings.TrimSpace(code)); code != "" && !slices.Contains(out, code) {
+			out = append(out, code)
+		}
+	}
+	return out
+}
+
+func mapGovernmentIDs(src Entity) []search.GovernmentID {
+	var country string
+	if countries := mapCountries(src.Property("country")); len(countries) == 1 {
+		country = countries[0]
+	}
+
+	var out []search.GovernmentID
+	add := func(prop string, idType search.GovernmentIDType) {
+		for _, number := range src.Property(prop) {
+			if number = strings.TrimSpace(number); number != "" {
+				out = append(out, search.GovernmentID{Type: idType, Country: country, Identifier: number})
+			}
+		}
+	}
+	add("passportNumber", search.GovernmentIDPassport)
+	add("idNumber", search.GovernmentIDNational)
+	add("taxNumber", search.GovernmentIDTax)
+	add("registrationNumber", search.GovernmentIDBusinessRegisration)
+	return out
+}
+
+func mapAddresses(src Entity) []search.Address {
+	countries := mapCountries(src.Property("country"))
+
+	var out []search.Address
+	for _, addr := range src.Property("address") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			out = append(out, search.Address{Line1: addr})
+		}
+	}
+	if len(countries) == 1 {
+		for i := range out {
+			out[i].Country = countries[0]
+		}
+	}
+	if len(out) == 0 {
+		for _, country := range countries {
+			out = append(out, search.Address{Country: country})
+		}
+	}
+	return out
+}
+
+var dateLayouts = []string{"2006-01-02", "2006-01", "2006"}
+
+// parseDate reads the full and partial dates of FtM, such as "1952-10-07", "1952-10" or "1952"
+func parseDate(value string) (search.DateRange, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return search.DateRangeFor(t, layout), true
+		}
+	}
+	return search.DateRange{}, false
+}
@@ -0,0 +1,66 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package opensanctions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/moov-io/base/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertEntities(t *testing.T) {
+	files, err := Download(context.Background(), log.NewNopLogger(), "testdata")
+	require.NoError(t, err)
+
+	records, err := Read(files)
+	require.NoError(t, err)
+	require.Len(t, records, 5) // the position isn't a target
+
+	entities := ConvertEntities(records)
+	require.Len(t, entities, 4) // vessels aren't converted
+
+	putin := entities[0]
+	require.Equal(t, search.SourceOpenSanctions, putin.Source)
+	require.Equal(t, "Q7747", putin.SourceID)
+	require.Equal(t, search.EntityPerson, putin.Type)
+	require.Equal(t, "Vladimir Putin", putin.Person.Name)
+	require.Contains(t, putin.Person.AltNames, "Vladimir Vladimirovich Putin")
+	require.NotContains(t, putin.Person.AltNames, "Vova")
+	require.Equal(t, []string{"Vova"}, putin.WeakAltNames)
+	require.Equal(t, search.GenderMale, putin.Person.Gender)
+	require.Equal(t, time.Date(1952, time.October, 7, 0, 0, 0, 0, time.UTC), *putin.Person.BirthDate)
+	require.Equal(t, "Leningrad", putin.Person.PlaceOfBirth)
+	require.Equal(t, []string{"RU"}, putin.Person.Nationalities)
+	require.Equal(t, &search.PEPInfo{Positions: []string{"President of Russia"}}, putin.PEP)
+	require.Equal(t, &search.SanctionsInfo{
+		Programs: []string{"RUSSIA-EO14024"},
+		Lists:    []string{"wikidata", "us_ofac_sdn", "eu_fsf"},
+	}, putin.SanctionsInfo)
+
+	// PEPs who aren't sanctioned have no SanctionsInfo
+	legislator := entities[1]
+	require.NotNil(t, legislator.PEP)
+	require.Nil(t, legislator.SanctionsInfo)
+	require.Equal(t, []search.DateRange{{
+		Start: time.Date(1961, time.January, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(1961, time.December, 31, 0, 0, 0, 0, time.UTC),
+	}}, legislator.Person.BirthDates)
+	require.Equal(t, []search.Address{{Country: "US"}}, legislator.Addresses)
+
+	relative := entities[2]
+	require.Equal(t, &search.PEPInfo{Relative: true}, relative.PEP)
+	require.Equal(t, []string{"Lyudmila Putina"}, relative.Person.AltNames)
+
+	company := entities[3]
+	require.Equal(t, search.EntityBusiness, company.Type)
+	require.Equal(t, []search.GovernmentID{{
+		Type: search.GovernmentIDBusinessRegisration, Country: "RU", Identifier: "1025501701686",
+	}}, company.Business.GovernmentIDs)
+}
@@ -0,0 +1,38 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package opensanctions
+
+// Entity is a record of an OpenSanctions dataset in their FollowTheMoney (FtM) format, one per line
+// of entities.ftm.json. See https://www.opensanctions.org/docs/entities/
+type Entity struct {
+	ID         string              `json:"id"`
+	Schema     string              `json:"schema"`
+	Caption    string              `json:"caption"`
+	Properties map[string][]string `json:"properties"`
+	Datasets   []string            `json:"datasets"`
+	Target     bool                `json:"target"`
+	FirstSeen  string              `json:"first_seen"`
+	LastSeen   string              `json:"last_seen"`
+}
+
+// Property returns every value of a property, e.g. "name" or "birthDate"
+func (e Entity) Property(name string) []string {
+	return e.Properties[name]
+}
+
+// First returns the first value of a property
+func (e Entity) First(name string) string {
+	if values := e.Properties[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Topics OpenSanctions tags entities with
+const (
+	TopicPEP      = "role.pep"
+	TopicRCA      = "role.rca" // relative or close associate of a PEP
+	TopicSanction = "sanction"
+)
@@ -0,0 +1,59 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+package opensanctions
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Read parses the target entities of entities.ftm.json. Entities which only describe targets, such as
+// their addresses and positions, are skipped.
+func Read(files map[string]io.ReadCloser) ([]Entity, error) {
+	for name, contents := range files {
+		switch strings.ToLower(name) {
+		case filename:
+			return parseEntities(name, contents)
+		default:
+			return nil, fmt.Errorf("unknown file %s", name)
+		}
+	}
+	return nil, errors.New("no files provided")
+}
+
+func parseEntities(name string, contents io.ReadCloser) ([]Entity, error) {
+	if contents == nil {
+		return nil, fmt.Errorf("%s is empty or missing", name)
+	}
+	defer contents.Close()
+
+	var out []Entity
+
+	// Entities can be large, so lines aren't limited to bufio's default size
+	r := bufio.NewReader(contents)
+	for line := 1; ; line++ {
+		row, err := r.ReadBytes('\n')
+		if len(strings.TrimSpace(string(row))) > 0 {
+			var entity Entity
+			if err := json.Unmarshal(row, &entity); err != nil {
+				return nil, fmt.Errorf("parsing %s line %d: %w", name, line, err)
+			}
+			if entity.Target {
+				out = append(out, entity)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", name, err)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,96 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Calibration maps the raw scores of one source list onto the scale shared by every list, so a 0.90 means about
+// the same match quality whether it came from a list with many aliases per entry or one with only a name.
+//
+// Points are joined by straight lines, with (0, 0) and (1, 1) as the ends, so scores between two points are
+// moved by a share of each.
+type Calibration []CalibrationPoint
+
+// CalibrationPoint maps one raw score to its calibrated score
+type CalibrationPoint struct {
+	Raw        float64 `json:"raw"`
+	Calibrated float64 `json:"calibrated"`
+}
+
+// Validate returns an error when a point is outside of 0 and 1, the raw scores aren't increasing or a higher raw
+// score would be calibrated lower than a lesser one.
+func (c Calibration) Validate() error {
+	for i, point := range c {
+		if math.IsNaN(point.Raw) || point.Raw < 0 || point.Raw > 1 {
+			return fmt.Errorf("raw score of %v must be between 0 and 1", point.Raw)
+		}
+		if math.IsNaN(point.Calibrated) || point.Calibrated < 0 || point.Calibrated > 1 {
+			return fmt.Errorf("calibrated score of %v must be between 0 and 1", point.Calibrated)
+		}
+		if i > 0 {
+			if point.Raw <= c[i-1].Raw {
+				return fmt.Errorf("raw score of %v must be above %v", point.Raw, c[i-1].Raw)
+			}
+			if point.Calibrated < c[i-1].Calibrated {
+				return fmt.Errorf("calibrated score of %v must not be below %v", point.Calibrated, c[i-1].Calibrated)
+			}
+		}
+	}
+	return nil
+}
+
+// Apply returns score on the shared scale. Scores are unchanged when there are no points.
+func (c Calibration) Apply(score float64) float64 {
+	if len(c) == 0 {
+		return score
+	}
+	lower := CalibrationPoint{Raw: 0, Calibrated: 0}
+	for i := 0; i <= len(c); i++ {
+		upper := CalibrationPoint{Raw: 1, Calibrated: 1}
+		if i < len(c) {
+			upper = c[i]
+		}
+		if score <= upper.Raw {
+			if upper.Raw == lower.Raw {
+				return upper.Calibrated
+			}
+			share := (score - lower.Raw) / (upper.Raw - lower.Raw)
+			return lower.Calibrated + share*(upper.Calibrated-lower.Calibrated)
+		}
+		lower = upper
+	}
+	return score
+}
+
+// ParseCalibration reads points written as comma separated raw:calibrated pairs, such as "0.85:0.80,0.95:0.93".
+// Points are sorted by their raw score.
+func ParseCalibration(input string) (Calibration, error) {
+	var out Calibration
+	for _, pair := range strings.Split(input, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		raw, calibrated, found := strings.Cut(pair, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid calibration point %q, expected raw:calibrated", pair)
+		}
+		var point CalibrationPoint
+		var err error
+		if point.Raw, err = strconv.ParseFloat(strings.TrimSpace(raw), 64); err != nil {
+			return nil, fmt.Errorf("invalid raw score %q: %w", raw, err)
+		}
+		if point.Calibrated, err = strconv.ParseFloat(strings.TrimSpace(calibrated), 64); err != nil {
+			return nil, fmt.Errorf("invalid calibrated score %q: %w", calibrated, err)
+		}
+		out = append(out, point)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Raw < out[j].Raw
+	})
+	return out, out.Validate()
+}
@@ -0,0 +1,54 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalibration_Apply(t *testing.T) {
+	var none Calibration
+	require.InDelta(t, 0.91, none.Apply(0.91), 0.0001)
+
+	// Lower the top of a list whose entries have many aliases
+	calibration := Calibration{
+		{Raw: 0.80, Calibrated: 0.80},
+		{Raw: 0.95, Calibrated: 0.90},
+	}
+	require.NoError(t, calibration.Validate())
+
+	require.InDelta(t, 0.40, calibration.Apply(0.40), 0.0001)
+	require.InDelta(t, 0.80, calibration.Apply(0.80), 0.0001)
+	require.InDelta(t, 0.85, calibration.Apply(0.875), 0.0001)
+	require.InDelta(t, 0.90, calibration.Apply(0.95), 0.0001)
+	require.InDelta(t, 0.95, calibration.Apply(0.975), 0.0001)
+	require.InDelta(t, 1.00, calibration.Apply(1.0), 0.0001)
+}
+
+func TestParseCalibration(t *testing.T) {
+	calibration, err := ParseCalibration("0.95:0.90, 0.8:0.8")
+	require.NoError(t, err)
+	require.Equal(t, Calibration{
+		{Raw: 0.80, Calibrated: 0.80},
+		{Raw: 0.95, Calibrated: 0.90},
+	}, calibration)
+
+	calibration, err = ParseCalibration("")
+	require.NoError(t, err)
+	require.Empty(t, calibration)
+
+	_, err = ParseCalibration("0.9")
+	require.ErrorContains(t, err, `invalid calibration point "0.9"`)
+
+	_, err = ParseCalibration("high:0.9")
+	require.ErrorContains(t, err, "invalid raw score")
+
+	_, err = ParseCalibration("0.9:1.2")
+	require.ErrorContains(t, err, "calibrated score of 1.2 must be between 0 and 1")
+
+	_, err = ParseCalibration("0.8:0.9,0.9:0.85")
+	require.ErrorContains(t, err, "calibrated score of 0.85 must not be below 0.9")
+
+	_, err = ParseCalibration("0.8:0.8,0.8:0.85")
+	require.ErrorContains(t, err, "raw score of 0.8 must be above 0.8")
+}
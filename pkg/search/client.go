@@ -1,16 +1,21 @@
 package search
 
 import (
+	"bytes"
 	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 )
 
 type Client interface {
 	SearchByEntity(ctx context.Context, entity Entity[Value], opts SearchOpts) (SearchResponse, error)
+
+	// SearchBatch screens several queries in one request to /v2/search/batch
+	SearchBatch(ctx context.Context, req BatchSearchRequest) (BatchSearchResponse, error)
 }
 
 func NewClient(httpClient *http.Client, baseAddress string) Client {
@@ -31,11 +36,34 @@ type client struct {
 
 type SearchResponse struct {
 	Entities []SearchedEntity[Value] `json:"entities"`
+
+	// NextCursor is set when there are more results, see SearchOpts.Cursor
+	NextCursor string `json:"nextCursor,omitempty"`
+
+	// Truncated is set when the search ran out of time or candidates, so Entities are the best of those it scored
+	Truncated bool `json:"truncated,omitempty"`
 }
 
 type SearchOpts struct {
 	Limit    int
 	MinMatch float64
+
+	// Explain asks Watchman to include how each result was scored
+	Explain bool
+
+	// Highlight asks Watchman to include the words of each result's names which matched the query
+	Highlight bool
+
+	// Sort orders results by score (the default), name or list
+	Sort string
+
+	// Cursor returns the next page of results, from a previous SearchResponse.NextCursor
+	Cursor string
+
+	// Programs, Countries and EntityTypes only return entities matching one of their values
+	Programs    []string
+	Countries   []string
+	EntityTypes []string
 }
 
 func (c *client) SearchByEntity(ctx context.Context, entity Entity[Value], opts SearchOpts) (SearchResponse, error) {
@@ -45,6 +73,27 @@ func (c *client) SearchByEntity(ctx context.Context, entity Entity[Value], opts
 	if opts.Limit > 0 {
 		addr += fmt.Sprintf("&limit=%d", opts.Limit)
 	}
+	if opts.Explain {
+		addr += "&explain=true"
+	}
+	if opts.Highlight {
+		addr += "&highlight=true"
+	}
+	if opts.Sort != "" {
+		addr += "&sort=" + url.QueryEscape(opts.Sort)
+	}
+	if opts.Cursor != "" {
+		addr += "&cursor=" + url.QueryEscape(opts.Cursor)
+	}
+	for _, program := range opts.Programs {
+		addr += "&program=" + url.QueryEscape(program)
+	}
+	for _, country := range opts.Countries {
+		addr += "&country=" + url.QueryEscape(country)
+	}
+	for _, entityType := range opts.EntityTypes {
+		addr += "&entityType=" + url.QueryEscape(entityType)
+	}
 
 	var out SearchResponse
 
@@ -67,3 +116,98 @@ func (c *client) SearchByEntity(ctx context.Context, entity Entity[Value], opts
 	}
 	return out, nil
 }
+
+type BatchSearchRequest struct {
+	// Limit and MinMatch are applied to each query which doesn't specify their own
+	Limit    int     `json:"limit,omitempty"`
+	MinMatch float64 `json:"minMatch,omitempty"`
+
+	// Consolidate merges the records of the same party on different lists into one result
+	Consolidate bool `json:"consolidate,omitempty"`
+
+	// ExactFirst answers each query from the entities with exactly its name, when there are any
+	ExactFirst bool `json:"exactFirst,omitempty"`
+
+	// Partial only returns the entities with a name containing each query's name
+	Partial bool `json:"partial,omitempty"`
+
+	// Highlight includes the words of each result's names which matched its query
+	Highlight bool `json:"highlight,omitempty"`
+
+	// RequireAddressCountry only returns the entities with an address in the country of each query's address
+	RequireAddressCountry bool `json:"requireAddressCountry,omitempty"`
+
+	// MaxCandidates lowers the most entities each query scores
+	MaxCandidates int `json:"maxCandidates,omitempty"`
+
+	// Profile names the scoring profile of every query, such as its weights and minimum score
+	Profile string `json:"profile,omitempty"`
+
+	Queries []BatchSearchQuery `json:"queries"`
+}
+
+type BatchSearchQuery struct {
+	Name    string `json:"name"`
+	Type    string `json:"type,omitempty"`
+	Country string `json:"country,omitempty"`
+
+	// BirthDate of a person, written as 2006-01-02, 2006-01 or 2006
+	BirthDate string `json:"birthDate,omitempty"`
+
+	// Address is compared by its components, and is in Country unless it has its own
+	Address *Address `json:"address,omitempty"`
+
+	Limit    int     `json:"limit,omitempty"`
+	MinMatch float64 `json:"minMatch,omitempty"`
+}
+
+type BatchSearchResponse struct {
+	Results []BatchSearchResult `json:"results"`
+}
+
+// BatchSearchResult holds the matches of one query, in the order queries were sent
+type BatchSearchResult struct {
+	Query    BatchSearchQuery        `json:"query"`
+	Entities []SearchedEntity[Value] `json:"entities"`
+
+	// Truncated is set when the query ran out of time or candidates, so Entities are the best of those it scored
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Error is set when the query couldn't be searched, while the other queries of the batch still were
+	Error string `json:"error,omitempty"`
+}
+
+func (c *client) SearchBatch(ctx context.Context, req BatchSearchRequest) (BatchSearchResponse, error) {
+	var out BatchSearchResponse
+
+	body, err := json.Marshal(req)
+	if err != nil {
+		return out, fmt.Errorf("encoding batch search request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.baseAddress+"/v2/search/batch", bytes.NewReader(body))
+	if err != nil {
+		return out, fmt.Errorf("creating batch search request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return out, fmt.Errorf("batch search: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var problem struct {
+			Error string `json:"error"`
+		}
+		json.NewDecoder(resp.Body).Decode(&problem)
+		return out, fmt.Errorf("batch search: unexpected %s: %s", resp.Status, problem.Error)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(&out)
+	if err != nil {
+		return out, fmt.Errorf("decoding batch search response: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,57 @@
+package search_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_SearchBatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "POST", r.Method)
+		require.Equal(t, "/v2/search/batch", r.URL.Path)
+
+		var req search.BatchSearchRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if len(req.Queries) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "no queries provided"})
+			return
+		}
+
+		resp := search.BatchSearchResponse{}
+		for _, q := range req.Queries {
+			resp.Results = append(resp.Results, search.BatchSearchResult{
+				Query: q,
+				Entities: []search.SearchedEntity[search.Value]{
+					{Entity: search.Entity[search.Value]{Name: q.Name}, Match: req.MinMatch},
+				},
+			})
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	client := search.NewClient(server.Client(), server.URL)
+
+	resp, err := client.SearchBatch(ctx, search.BatchSearchRequest{
+		MinMatch: 0.9,
+		Queries: []search.BatchSearchQuery{
+			{Name: "Jane Doe", Type: "person", BirthDate: "1970-06"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 1)
+	require.Equal(t, "1970-06", resp.Results[0].Query.BirthDate)
+	require.InDelta(t, 0.9, resp.Results[0].Entities[0].Match, 0.001)
+
+	_, err = client.SearchBatch(ctx, search.BatchSearchRequest{})
+	require.ErrorContains(t, err, "400 Bad Request: no queries provided")
+}
@@ -0,0 +1,199 @@
+package search
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/pariz/gountries"
+	"golang.org/x/text/unicode/norm"
+)
+
+// countryAliases are spellings found on lists which aren't a country's name or code
+var countryAliases = map[string]string{
+	"britain":                                "GB",
+	"great britain":                          "GB",
+	"england":                                "GB",
+	"scotland":                               "GB",
+	"wales":                                  "GB",
+	"northern ireland":                       "GB",
+	"uk":                                     "GB",
+	"burma":                                  "MM",
+	"cabo verde":                             "CV",
+	"congo, democratic republic of the":      "CD",
+	"congo, the democratic republic of the":  "CD",
+	"congo (kinshasa)":                       "CD",
+	"drc":                                    "CD",
+	"congo, republic of the":                 "CG",
+	"congo (brazzaville)":                    "CG",
+	"cote d'ivoire":                          "CI",
+	"czechia":                                "CZ",
+	"dprk":                                   "KP",
+	"east timor":                             "TL",
+	"eswatini":                               "SZ",
+	"gaza":                                   "PS",
+	"gaza strip":                             "PS",
+	"holland":                                "NL",
+	"holy see":                               "VA",
+	"iran, islamic republic of":              "IR",
+	"korea, democratic people's republic of": "KP",
+	"korea, republic of":                     "KR",
+	"kosovo":                                 "XK",
+	"north macedonia":                        "MK",
+	"palestine":                              "PS",
+	"palestinian":                            "PS",
+	"prc":                                    "CN",
+	"roc":                                    "TW",
+	"rok":                                    "KR",
+	"turkiye":                                "TR",
+	"uae":                                    "AE",
+	"viet nam":                               "VN",
+	"west bank":                              "PS",
+}
+
+var (
+	countryQuery     *gountries.Query
+	countryQueryOnce sync.Once
+)
+
+// CountryCode returns the ISO-3166 alpha-2 code of a country from its name, native name or its
+// alpha-2 or alpha-3 code. Spellings common on lists, such as "Korea, North" or "Burma", are also
+// recognized. False is returned for anything else.
+func CountryCode(country string) (string, bool) {
+	country = foldCountryName(country)
+	if country == "" {
+		return "", false
+	}
+	countryQueryOnce.Do(func() {
+		countryQuery = gountries.New()
+	})
+
+	lookup := func(name string) (string, bool) {
+		if code, exists := countryAliases[name]; exists {
+			return code, true
+		}
+		if len(name) == 2 || len(name) == 3 {
+			if found, err := countryQuery.FindCountryByAlpha(name); err == nil {
+				return found.Alpha2, true
+			}
+		}
+		if found, err := countryQuery.FindCountryByName(name); err == nil {
+			return found.Alpha2, true
+		}
+		if found, err := countryQuery.FindCountryByNativeName(name); err == nil {
+			return found.Alpha2, true
+		}
+		return "", false
+	}
+
+	if code, found := lookup(country); found {
+		return code, true
+	}
+	// Lists often invert names, as in "Korea, North" or "Bahamas, The"
+	if before, after, found := strings.Cut(country, ", "); found {
+		if code, found := lookup(strings.TrimPrefix(after+" "+before, "the ")); found {
+			return code, true
+		}
+	}
+	if name, found := strings.CutPrefix(country, "the "); found {
+		return lookup(name)
+	}
+	return "", false
+}
+
+// NormalizeCountry returns the ISO-3166 alpha-2 code of country, or country unchanged when it isn't recognized
+func NormalizeCountry(country string) string {
+	if code, found := CountryCode(country); found {
+		return code
+	}
+	return country
+}
+
+// sameCountry returns true when both countries are the same, written either as names or codes
+func sameCountry(a, b string) bool {
+	if strings.EqualFold(a, b) {
+		return true
+	}
+	codeA, foundA := CountryCode(a)
+	codeB, foundB := CountryCode(b)
+	return foundA && foundB && codeA == codeB
+}
+
+// foldCountryName lowercases a country and removes its accents, periods and extra spaces,
+// so "Côte d'Ivoire" and "U.S.A." are looked up as "cote d'ivoire" and "usa"
+func foldCountryName(country string) string {
+	country = strings.Map(func(r rune) rune {
+		if r == '.' || unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		if r == '’' {
+			return '\''
+		}
+		return unicode.ToLower(r)
+	}, norm.NFD.String(country))
+	return strings.Join(strings.Fields(country), " ")
+}
+
+// NormalizeCountries returns entity with the countries of its addresses, government IDs, nationalities
+// and flags replaced by their ISO-3166 alpha-2 codes. Fields are copied before they're modified, so
+// entity itself is left unchanged.
+func NormalizeCountries[T Value](entity Entity[T]) Entity[T] {
+	if len(entity.Addresses) > 0 {
+		addresses := make([]Address, len(entity.Addresses))
+		for i, addr := range entity.Addresses {
+			addr.Country = NormalizeCountry(addr.Country)
+			addresses[i] = addr
+		}
+		entity.Addresses = addresses
+	}
+
+	if entity.Person != nil {
+		person := *entity.Person
+		person.GovernmentIDs = normalizeIDCountries(person.GovernmentIDs)
+		if len(person.Nationalities) > 0 {
+			var nationalities []string
+			for _, nationality := range person.Nationalities {
+				nationality = NormalizeCountry(nationality)
+				if !slices.Contains(nationalities, nationality) {
+					nationalities = append(nationalities, nationality)
+				}
+			}
+			person.Nationalities = nationalities
+		}
+		entity.Person = &person
+	}
+	if entity.Business != nil {
+		business := *entity.Business
+		business.GovernmentIDs = normalizeIDCountries(business.GovernmentIDs)
+		entity.Business = &business
+	}
+	if entity.Organization != nil {
+		org := *entity.Organization
+		org.GovernmentIDs = normalizeIDCountries(org.GovernmentIDs)
+		entity.Organization = &org
+	}
+	if entity.Aircraft != nil && entity.Aircraft.Flag != "" {
+		aircraft := *entity.Aircraft
+		aircraft.Flag = NormalizeCountry(aircraft.Flag)
+		entity.Aircraft = &aircraft
+	}
+	if entity.Vessel != nil && entity.Vessel.Flag != "" {
+		vessel := *entity.Vessel
+		vessel.Flag = NormalizeCountry(vessel.Flag)
+		entity.Vessel = &vessel
+	}
+	return entity
+}
+
+func normalizeIDCountries(ids []GovernmentID) []GovernmentID {
+	if len(ids) == 0 {
+		return ids
+	}
+	out := make([]GovernmentID, len(ids))
+	for i, id := range ids {
+		id.Country = NormalizeCountry(id.Country)
+		out[i] = id
+	}
+	return out
+}
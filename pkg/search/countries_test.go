@@ -0,0 +1,66 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountryCode(t *testing.T) {
+	cases := map[string]string{
+		"Russia":             "RU",
+		"Russian Federation": "RU",
+		"RUS":                "RU",
+		"ru":                 "RU",
+		"U.S.A.":             "US",
+		"United Kingdom":     "GB",
+		"UK":                 "GB",
+		"Korea, North":       "KP",
+		"Korea, South":       "KR",
+		"Bahamas, The":       "BS",
+		"Burma":              "MM",
+		"Côte d'Ivoire":      "CI",
+		"Россия":             "RU",
+		"  czech   republic": "CZ",
+	}
+	for input, expected := range cases {
+		code, found := CountryCode(input)
+		require.True(t, found, input)
+		require.Equal(t, expected, code, input)
+	}
+
+	for _, input := range []string{"", "Florida", "Atlantis", "XX"} {
+		_, found := CountryCode(input)
+		require.False(t, found, input)
+	}
+	require.Equal(t, "Florida", NormalizeCountry("Florida"))
+}
+
+func TestNormalizeCountries(t *testing.T) {
+	entity := Entity[any]{
+		Type: EntityPerson,
+		Person: &Person{
+			Nationalities: []string{"Russia", "RU", "Russian Federation"},
+			GovernmentIDs: []GovernmentID{{Type: GovernmentIDPassport, Country: "Russian Federation", Identifier: "123"}},
+		},
+		Addresses: []Address{{City: "Moscow", Country: "Russia"}},
+	}
+	got := NormalizeCountries(entity)
+	require.Equal(t, []string{"RU"}, got.Person.Nationalities)
+	require.Equal(t, "RU", got.Person.GovernmentIDs[0].Country)
+	require.Equal(t, "RU", got.Addresses[0].Country)
+
+	// The original entity is unchanged
+	require.Equal(t, "Russia", entity.Addresses[0].Country)
+	require.Equal(t, "Russian Federation", entity.Person.GovernmentIDs[0].Country)
+
+	vessel := NormalizeCountries(Entity[any]{Type: EntityVessel, Vessel: &Vessel{Flag: "Panama"}})
+	require.Equal(t, "PA", vessel.Vessel.Flag)
+}
+
+func TestSameCountry(t *testing.T) {
+	require.True(t, sameCountry("Russia", "RU"))
+	require.True(t, sameCountry("Florida", "florida"))
+	require.False(t, sameCountry("Russia", "Ukraine"))
+	require.False(t, sameCountry("Atlantis", "Lemuria"))
+}
@@ -47,3 +47,34 @@ func (c *MockClient) SearchByEntity(ctx context.Context, query Entity[Value], op
 
 	return resp, nil
 }
+
+func (c *MockClient) SearchBatch(ctx context.Context, req BatchSearchRequest) (BatchSearchResponse, error) {
+	if c.Err != nil {
+		return BatchSearchResponse{}, c.Err
+	}
+
+	var out BatchSearchResponse
+	for _, q := range req.Queries {
+		query := Entity[Value]{
+			Name: q.Name,
+			Type: EntityType(q.Type),
+		}
+		opts := SearchOpts{
+			Limit: cmp.Or(q.Limit, req.Limit, 10),
+		}
+		resp, err := c.SearchByEntity(ctx, query, opts)
+		if err != nil {
+			return out, err
+		}
+
+		minMatch := cmp.Or(q.MinMatch, req.MinMatch)
+		result := BatchSearchResult{Query: q}
+		for _, entity := range resp.Entities {
+			if entity.Match >= minMatch {
+				result.Entities = append(result.Entities, entity)
+			}
+		}
+		out.Results = append(out.Results, result)
+	}
+	return out, nil
+}
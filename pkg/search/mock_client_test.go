@@ -41,3 +41,26 @@ func TestMockClient(t *testing.T) {
 	require.Equal(t, "Jane Doe", first.Name)
 	require.InDelta(t, 0.6667, first.Match, 0.001)
 }
+
+func TestMockClient_SearchBatch(t *testing.T) {
+	ctx := context.Background()
+	mc := search.NewMockClient()
+
+	mc.Index = append(mc.Index, search.Entity[search.Value]{
+		Name: "Jane Doe",
+		Type: search.EntityPerson,
+	})
+
+	resp, err := mc.SearchBatch(ctx, search.BatchSearchRequest{
+		MinMatch: 0.9,
+		Queries: []search.BatchSearchQuery{
+			{Name: "Jane Doe", Type: "person"},
+			{Name: "Acme Corp", Type: "business"},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Results, 2)
+	require.Len(t, resp.Results[0].Entities, 1)
+	require.Empty(t, resp.Results[1].Entities)
+	require.Len(t, mc.Searches, 2)
+}
@@ -4,4 +4,60 @@ type SearchedEntity[T any] struct {
 	Entity[T]
 
 	Match float64 `json:"match"`
+
+	// BirthDateMatch is set when both the query and entity have a date of birth
+	BirthDateMatch DateMatch `json:"birthDateMatch,omitempty"`
+
+	// AltNameMatch is the alternate or former name which best matched the query's name, which is empty when
+	// the entity's primary name matched best
+	AltNameMatch string `json:"altNameMatch,omitempty"`
+
+	// NameScript is the script of the name which best matched the query's name when it isn't written in Latin
+	// letters, such as cyrillic, arabic or han
+	NameScript string `json:"nameScript,omitempty"`
+
+	// WeakAltNameMatch is the weak alias the query's name matched, which wasn't counted towards Match
+	WeakAltNameMatch string `json:"weakAltNameMatch,omitempty"`
+
+	// Explanation is included when requested and details how Match was computed
+	Explanation *SimilarityExplanation `json:"explanation,omitempty"`
+
+	// Highlights are included when requested and mark the words of each name which matched the query
+	Highlights []NameHighlight `json:"highlights,omitempty"`
+
+	// Calibration is set when the scores of the entity's list are calibrated, and holds its raw score and how it
+	// was mapped to Match
+	Calibration *ScoreCalibration `json:"calibration,omitempty"`
+
+	// Rerank is set when the results of a search were reordered by a re-ranking model, which leaves Match as is
+	Rerank *RerankScore `json:"rerank,omitempty"`
+
+	// Related are the records of the same party on other lists, which are set when results are consolidated
+	Related []RelatedEntity `json:"related,omitempty"`
+}
+
+// RelatedEntity is a record on another list which was linked to a result as the same party
+type RelatedEntity struct {
+	Name     string     `json:"name"`
+	Source   SourceList `json:"sourceList"`
+	SourceID string     `json:"sourceID"`
+
+	// Match is the record's own score, which is empty when it scored below the result's limit or minMatch
+	Match float64 `json:"match,omitempty"`
+
+	// LinkedBy is what the records share: governmentID, cryptoAddress, imoNumber, mmsi or nameAndDetails,
+	// the same name along with a birth date, country or flag
+	LinkedBy string `json:"linkedBy"`
+}
+
+// ScoreCalibration is how a score was moved onto the scale shared by every list
+type ScoreCalibration struct {
+	RawMatch float64     `json:"rawMatch"`
+	Points   Calibration `json:"points"`
+}
+
+// RerankScore is what a re-ranking model scored a result, which results were sorted by
+type RerankScore struct {
+	Model string  `json:"model"`
+	Score float64 `json:"score"`
 }
@@ -2,6 +2,7 @@ package search
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -33,6 +34,25 @@ type Entity[T Value] struct {
 	SanctionsInfo  *SanctionsInfo   `json:"sanctionsInfo"`
 	HistoricalInfo []HistoricalInfo `json:"historicalInfo"`
 
+	// WeakAltNames are aliases a list marks as weak or low quality, such as a single given name. They aren't
+	// compared when scoring, but a result is flagged with WeakAltNameMatch when the query matched one.
+	WeakAltNames []string `json:"weakAltNames,omitempty"`
+
+	// PEP is set on politically exposed persons, who aren't sanctioned unless SanctionsInfo is also set
+	PEP *PEPInfo `json:"pep,omitempty"`
+
+	// Wanted is set on persons sought by law enforcement, such as with an Interpol Red Notice, who aren't
+	// sanctioned unless SanctionsInfo is also set
+	Wanted *WantedInfo `json:"wanted,omitempty"`
+
+	// Remarks are a list's free text notes about the entity, such as OFAC's "DOB 23 Nov 1962; POB Caracas,
+	// Venezuela; Cedula No. 5892464", which can hold details the other fields don't
+	Remarks string `json:"remarks,omitempty"`
+
+	// PhoneticCodes are the Soundex codes of the terms of the entity's names, stored by WithPhoneticCodes as the
+	// entity is indexed so they're compared rather than computed for every search
+	PhoneticCodes map[string]string `json:"-"`
+
 	SourceData T `json:"sourceData"` // Contains all original list data with source list naming
 }
 
@@ -51,10 +71,26 @@ type SourceList string
 var (
 	SourceAPIRequest SourceList = "api-request"
 
-	SourceEUCSL  SourceList = "eu_csl"
-	SourceUKCSL  SourceList = "uk_csl"
-	SourceUSCSL  SourceList = "us_csl"
-	SourceUSOFAC SourceList = "us_ofac"
+	// SourceCustomList entities are uploaded by a tenant and only searched for them
+	SourceCustomList SourceList = "custom"
+
+	// SourceOpenSanctions entities are read from an OpenSanctions dataset, such as their PEPs
+	SourceOpenSanctions SourceList = "opensanctions"
+
+	// SourceInterpolRed entities are persons wanted by law enforcement with an Interpol Red Notice, who aren't
+	// sanctioned
+	SourceInterpolRed SourceList = "interpol_red"
+
+	SourceAUCSL       SourceList = "au_csl"
+	SourceCACSL       SourceList = "ca_csl"
+	SourceCHCSL       SourceList = "ch_csl"
+	SourceEUCSL       SourceList = "eu_csl"
+	SourceUKCSL       SourceList = "uk_csl"
+	SourceUNCSL       SourceList = "un_csl"
+	SourceUSBIS       SourceList = "us_bis"
+	SourceUSCSL       SourceList = "us_csl"
+	SourceUSFinCEN311 SourceList = "us_fincen_311"
+	SourceUSOFAC      SourceList = "us_ofac"
 )
 
 type Person struct {
@@ -65,9 +101,36 @@ type Person struct {
 	DeathDate *time.Time `json:"deathDate"`
 	Titles    []string   `json:"titles"`
 
+	// BirthDates holds every listed date of birth, including partial dates and ranges.
+	// BirthDate is compared as a single day when BirthDates is empty.
+	BirthDates []DateRange `json:"birthDates,omitempty"`
+
+	PlaceOfBirth  string   `json:"placeOfBirth,omitempty"`
+	Nationalities []string `json:"nationalities,omitempty"`
+
 	GovernmentIDs []GovernmentID `json:"governmentIDs"`
 }
 
+// DateRange is an inclusive span of days. Partial dates, such as only a year, cover their whole period.
+type DateRange struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+}
+
+// DateRangeFor returns the period a date parsed with layout represents, such as the whole year for "2006"
+// or the whole month for "Jan 2006".
+func DateRangeFor(t time.Time, layout string) DateRange {
+	start := t.Truncate(24 * time.Hour)
+
+	switch {
+	case strings.Contains(layout, "02") || strings.Contains(layout, "_2"):
+		return DateRange{Start: start, End: start}
+	case strings.Contains(layout, "01") || strings.Contains(layout, "Jan"):
+		return DateRange{Start: start, End: start.AddDate(0, 1, -1)}
+	}
+	return DateRange{Start: start, End: start.AddDate(1, 0, -1)}
+}
+
 type Gender string
 
 var (
@@ -116,6 +179,15 @@ var (
 	GovernmentIDRefugee             GovernmentIDType = "refugee-id"
 	GovernmentIDDiplomaticPass      GovernmentIDType = "diplomatic-passport"
 	GovernmentIDPersonalID          GovernmentIDType = "personal-id"
+
+	// GovernmentIDISIN is the International Securities Identification Number of a company's securities
+	GovernmentIDISIN GovernmentIDType = "isin"
+
+	// GovernmentIDSWIFT is the SWIFT business identifier code (BIC) of a bank
+	GovernmentIDSWIFT GovernmentIDType = "swift-bic"
+
+	// GovernmentIDLEI is the ISO 17442 Legal Entity Identifier of a company
+	GovernmentIDLEI GovernmentIDType = "lei"
 )
 
 type Business struct {
@@ -145,7 +217,11 @@ type Aircraft struct {
 	Built        *time.Time   `json:"built"`
 	ICAOCode     string       `json:"icaoCode"` // ICAO aircraft type designator
 	Model        string       `json:"model"`
-	SerialNumber string       `json:"serialNumber"`
+	SerialNumber string       `json:"serialNumber"` // Manufacturer's serial number (MSN)
+
+	// TailNumber is the aircraft's current registration mark, e.g. EP-MMH
+	TailNumber          string   `json:"tailNumber"`
+	PreviousTailNumbers []string `json:"previousTailNumbers"`
 }
 
 type AircraftType string
@@ -176,10 +252,52 @@ type Vessel struct {
 type VesselType string
 
 var (
-	VesselTypeUnknown VesselType = "unknown"
-	VesselTypeCargo   VesselType = "cargo"
+	VesselTypeUnknown     VesselType = "unknown"
+	VesselTypeCargo       VesselType = "cargo"
+	VesselTypeTanker      VesselType = "tanker"
+	VesselTypeBulkCarrier VesselType = "bulk-carrier"
+	VesselTypeContainer   VesselType = "container"
+	VesselTypeTug         VesselType = "tug"
+	VesselTypePassenger   VesselType = "passenger"
+	VesselTypeFishing     VesselType = "fishing"
+	VesselTypeYacht       VesselType = "yacht"
 )
 
+// vesselTypeKeywords are checked in order, so "Bulk Carrier" isn't read as cargo and "Chemical Carrier" is a tanker
+var vesselTypeKeywords = []struct {
+	keyword string
+	vtype   VesselType
+}{
+	{"tanker", VesselTypeTanker},
+	{"chemical", VesselTypeTanker},
+	{"oil", VesselTypeTanker},
+	{"gas carrier", VesselTypeTanker},
+	{"bulk", VesselTypeBulkCarrier},
+	{"container", VesselTypeContainer},
+	{"cargo", VesselTypeCargo},
+	{"tug", VesselTypeTug},
+	{"passenger", VesselTypePassenger},
+	{"ferry", VesselTypePassenger},
+	{"fishing", VesselTypeFishing},
+	{"trawler", VesselTypeFishing},
+	{"yacht", VesselTypeYacht},
+}
+
+// ParseVesselType reads the vessel types written by sanctions lists, such as "Crude Oil Tanker" or
+// "General Cargo / Multi Purpose"
+func ParseVesselType(value string) VesselType {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return VesselTypeUnknown
+	}
+	for _, kw := range vesselTypeKeywords {
+		if strings.Contains(value, kw.keyword) {
+			return kw.vtype
+		}
+	}
+	return VesselTypeUnknown
+}
+
 // CryptoAddress
 //
 // &cryptoAddress=XBT:x123456
@@ -221,6 +339,43 @@ type SanctionsInfo struct {
 	Programs    []string `json:"programs"`    // e.g., "SDGT", "IRGC"
 	Secondary   bool     `json:"secondary"`   // Subject to secondary sanctions
 	Description string   `json:"description"` // Additional details
+
+	// Regulations are the legal acts the entity is listed under, e.g. EU regulation "1210/2003 (OJ L169)"
+	Regulations []string `json:"regulations,omitempty"`
+
+	// Lists are the lists of a source the entity is on, e.g. "SDN", "SSI" or "NS-CMIC" of the US CSL
+	Lists []string `json:"lists,omitempty"`
+
+	// ProgramDates are the days the entity's listing under each of Programs took effect, for lists which publish
+	// them such as the EU's regulations and the UN's listed on dates
+	ProgramDates map[string]time.Time `json:"programDates,omitempty"`
+
+	// Sectoral is set on entities only restricted by OFAC's Sectoral Sanctions Identifications (SSI) list,
+	// rather than blocked by the SDN list. Directives are the restrictions which apply, e.g. "Subject to Directive 2"
+	Sectoral   bool     `json:"sectoral,omitempty"`
+	Directives []string `json:"directives,omitempty"`
+
+	// LicenseRequirement and LicensePolicy are the export licenses an entity on the BIS Entity List needs,
+	// and how applications for them are reviewed
+	LicenseRequirement string `json:"licenseRequirement,omitempty"`
+	LicensePolicy      string `json:"licensePolicy,omitempty"`
+}
+
+// PEPInfo describes a politically exposed person (PEP), or a relative or close associate (RCA) of one
+type PEPInfo struct {
+	Positions []string `json:"positions"` // e.g. "Member of the State Duma"
+
+	// Relative is set on relatives and close associates rather than PEPs themselves
+	Relative bool `json:"relative,omitempty"`
+}
+
+// WantedInfo describes a request from law enforcement to locate and arrest a person
+type WantedInfo struct {
+	Authority string `json:"authority"` // e.g. "Interpol"
+	Notice    string `json:"notice"`    // e.g. "Red Notice"
+
+	// URL is the notice's public page
+	URL string `json:"url,omitempty"`
 }
 
 type HistoricalInfo struct {
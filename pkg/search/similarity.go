@@ -25,25 +25,130 @@ func Similarity[Q any, I any](query Entity[Q], index Entity[I]) float64 {
 
 // DebugSimilarity does the same as Similarity, but logs debug info to w.
 func DebugSimilarity[Q any, I any](w io.Writer, query Entity[Q], index Entity[I]) float64 {
+	return similarity(w, query, index, SimilarityConfig{}, nil)
+}
+
+// SimilarityConfig changes how Similarity compares entities. The zero value uses the defaults.
+type SimilarityConfig struct {
+	// NameScorer compares name terms, Jaro-Winkler is used when nil.
+	NameScorer NameScorer
+
+	// Weights change how much each field counts towards the score, see DefaultWeights for those left at zero.
+	Weights Weights
+
+	// BirthYearTolerance is how many years apart dates of birth can be and still count a little towards the score.
+	// Dates of birth more than a year apart are a mismatch when it's zero or one.
+	BirthYearTolerance int
+
+	// Conflicts lower the score of a person whose gender or nationality differs from the query's, or of a
+	// financial institution with other BICs or LEIs, see DefaultConflictFactors for those left at zero.
+	Conflicts ConflictFactors
+
+	// AltNameBoost moves a name's score this fraction closer to a perfect match for each other distinct name or
+	// alias which also matched the query well, up to three of them. Zero only counts the best matching name.
+	AltNameBoost float64
+
+	// WeakTerms are the generic words of names, which count for less than others. The default WeakTerms
+	// are used when nil.
+	WeakTerms *WeakTermSet
+
+	// RequireAddressCountry scores entities zero when none of their addresses are in a country of the query's
+	// addresses. Entities without the country of any address are still scored.
+	RequireAddressCountry bool
+
+	// Phonetic boosts name terms which sound alike (Soundex), such as "Mohammed" and "Muhamad", when NameScorer is
+	// Jaro-Winkler or hybrid. The codes of entities given to WithPhoneticCodes aren't computed again.
+	Phonetic bool
+
+	// TypoTolerance lets name terms with a few typos score as the term they're a typo of, see TypoTolerance.
+	// It applies to every NameScorer, and is empty (no tolerance) by default.
+	TypoTolerance TypoTolerance
+}
+
+func (cfg SimilarityConfig) nameScorer() NameScorer {
+	if cfg.NameScorer != nil {
+		return cfg.NameScorer
+	}
+	return defaultNameScorer
+}
+
+// termScorer returns the scorer comparing the name terms of query and index, which boosts terms that sound alike
+// and tolerates the typos of cfg
+func termScorer[Q any, I any](cfg SimilarityConfig, query Entity[Q], index Entity[I]) NameScorer {
+	return withTypoTolerance(phoneticScorer(cfg.Phonetic, cfg.nameScorer(), query, index), cfg.TypoTolerance)
+}
+
+func (cfg SimilarityConfig) weakTerms() *WeakTermSet {
+	if cfg.WeakTerms != nil {
+		return cfg.WeakTerms
+	}
+	return defaultWeakTerms
+}
+
+func (cfg SimilarityConfig) weights() Weights {
+	return cfg.Weights.Or(DefaultWeights())
+}
+
+// SimilarityWithConfig does the same as Similarity, but with cfg changing how entities are compared.
+func SimilarityWithConfig[Q any, I any](query Entity[Q], index Entity[I], cfg SimilarityConfig) float64 {
+	return similarity(nil, query, index, cfg, nil)
+}
+
+// ExplainSimilarity does the same as SimilarityWithConfig, but also returns how each field contributed to the score.
+func ExplainSimilarity[Q any, I any](query Entity[Q], index Entity[I], cfg SimilarityConfig) (float64, *SimilarityExplanation) {
+	explain := &SimilarityExplanation{}
+	score := similarity(nil, query, index, cfg, explain)
+	explain.Score = score
+	return score, explain
+}
+
+func similarity[Q any, I any](w io.Writer, query Entity[Q], index Entity[I], cfg SimilarityConfig, explain *SimilarityExplanation) float64 {
+	if cfg.RequireAddressCountry {
+		if conflict, found := compareAddressCountries(query, index); found {
+			if explain != nil {
+				explain.Conflicts = []AttributeConflict{conflict}
+			}
+			return 0.0
+		}
+	}
+
 	var pieces []scorePiece
+	weights := cfg.weights()
 
 	// Critical identifiers (highest weight)
-	exactIdentifiers := compareExactIdentifiers(w, query, index, criticalIdWeight)
+	exactIdentifiers := compareExactIdentifiers(w, query, index, weights.Identifiers)
 	if exactIdentifiers.matched && exactIdentifiers.fieldsCompared > 0 {
+		if explain != nil {
+			explain.decidedBy(exactIdentifiers)
+		}
 		if math.IsNaN(exactIdentifiers.score) {
 			return 0.0
 		}
 		return exactIdentifiers.score
 	}
-	exactCryptoAddresses := compareExactCryptoAddresses(w, query, index, criticalIdWeight)
+	exactCryptoAddresses := compareExactCryptoAddresses(w, query, index, weights.Identifiers)
 	if exactCryptoAddresses.matched && exactCryptoAddresses.fieldsCompared > 0 {
+		if explain != nil {
+			explain.decidedBy(exactCryptoAddresses)
+		}
 		if math.IsNaN(exactCryptoAddresses.score) {
 			return 0.0
 		}
 		return exactCryptoAddresses.score
 	}
-	exactGovernmentIDs := compareExactGovernmentIDs(w, query, index, criticalIdWeight)
+	// Banks share so many words of their names that a BIC or LEI decides whether they're the same institution
+	exactFinancialIDs := compareFinancialIDs(w, query, index, weights.Identifiers)
+	if exactFinancialIDs.matched && exactFinancialIDs.fieldsCompared > 0 {
+		if explain != nil {
+			explain.decidedBy(exactFinancialIDs)
+		}
+		return exactFinancialIDs.score
+	}
+	exactGovernmentIDs := compareExactGovernmentIDs(w, query, index, weights.Identifiers)
 	if exactGovernmentIDs.matched && exactGovernmentIDs.fieldsCompared > 0 {
+		if explain != nil {
+			explain.decidedBy(exactGovernmentIDs)
+		}
 		if math.IsNaN(exactGovernmentIDs.score) {
 			return 0.0
 		}
@@ -60,8 +165,8 @@ func DebugSimilarity[Q any, I any](w io.Writer, query Entity[Q], index Entity[I]
 
 	// Name comparison (second highest weight)
 	pieces = append(pieces,
-		compareName(w, query, index, nameWeight),
-		compareEntityTitlesFuzzy(w, query, index, nameWeight),
+		compareWeightedName(w, query, index, weights, cfg),
+		compareEntityTitlesFuzzy(w, query, index, weights.Name),
 	)
 	if w != nil {
 		debug(w, "name comparison")
@@ -71,8 +176,8 @@ func DebugSimilarity[Q any, I any](w io.Writer, query Entity[Q], index Entity[I]
 
 	// Supporting information (lower weight)
 	pieces = append(pieces,
-		compareEntityDates(w, query, index, supportingInfoWeight),
-		compareAddresses(w, query, index, supportingInfoWeight),
+		compareEntityDates(w, query, index, weights.Dates, cfg.BirthYearTolerance),
+		compareAddresses(w, query, index, weights.Address),
 		compareSupportingInfo(w, query, index, supportingInfoWeight),
 	)
 	if w != nil {
@@ -83,6 +188,15 @@ func DebugSimilarity[Q any, I any](w io.Writer, query Entity[Q], index Entity[I]
 	}
 
 	finalScore := calculateFinalScore(w, pieces, query, index)
+
+	// Attributes which conflict with the query's demote the entity, however well it otherwise matched
+	conflicts := compareConflicts(query, index, cfg.Conflicts.Or(DefaultConflictFactors()))
+	finalScore *= conflictFactor(conflicts)
+
+	if explain != nil {
+		explainFinalScore(explain, pieces, query, index, cfg)
+		explain.Conflicts = conflicts
+	}
 	if math.IsNaN(finalScore) {
 		return 0.0
 	}
@@ -447,6 +561,9 @@ func countAircraftFields(a *Aircraft) int {
 	if a.SerialNumber != "" {
 		count++
 	}
+	if a.TailNumber != "" {
+		count++
+	}
 
 	return count
 }
@@ -2,15 +2,19 @@ package search
 
 import (
 	"io"
+	"slices"
 	"strings"
+	"unicode"
 
 	"github.com/moov-io/watchman/internal/stringscore"
+
+	"golang.org/x/text/unicode/norm"
 )
 
 const (
 	// Field weights for addresses
-	line1Weight   = 3.0 // Primary address line - most important
-	line2Weight   = 1.0 // Secondary address info - less important
+	line1Weight   = 3.0 // Street - most important
+	line2Weight   = 1.0 // House and building numbers - less important
 	cityWeight    = 2.0 // City - moderately important
 	stateWeight   = 1.0 // State - helps confirm location
 	postalWeight  = 1.5 // Postal code - good verification
@@ -60,52 +64,47 @@ func findBestAddressMatch(queryAddrs, indexAddrs []Address) float64 {
 	return bestScore
 }
 
+// compareAddress scores the components of two addresses which are both set. Lines one and two are compared
+// as a house number and the words of a street, so "12 Main Street" and "Main St., 12" agree.
 func compareAddress(query, index Address) float64 {
 	var totalScore, totalWeight float64
+	add := func(score, weight float64) {
+		totalScore += score * weight
+		totalWeight += weight
+	}
+	q, i := newAddressParts(query), newAddressParts(index)
 
-	// Compare line1 (highest weight)
-	if query.Line1 != "" && index.Line1 != "" {
-		similarity := stringscore.JaroWinkler(query.Line1, index.Line1)
-		totalScore += similarity * line1Weight
-		totalWeight += line1Weight
+	// Compare the street (highest weight)
+	if len(q.street) > 0 && len(i.street) > 0 {
+		add(compareStreetWords(q.street, i.street), line1Weight)
 	}
 
-	// Compare line2
-	if query.Line2 != "" && index.Line2 != "" {
-		similarity := stringscore.JaroWinkler(query.Line2, index.Line2)
-		totalScore += similarity * line2Weight
-		totalWeight += line2Weight
+	// Compare house and building numbers (exact match). Numbers are only in line two of many addresses,
+	// such as a floor or suite, so any number in common counts.
+	if len(q.numbers) > 0 && len(i.numbers) > 0 {
+		add(boolToScore(slices.ContainsFunc(q.numbers, func(n string) bool {
+			return slices.Contains(i.numbers, n)
+		})), line2Weight)
 	}
 
 	// Compare city
-	if query.City != "" && index.City != "" {
-		similarity := stringscore.JaroWinkler(query.City, index.City)
-		totalScore += similarity * cityWeight
-		totalWeight += cityWeight
+	if q.city != "" && i.city != "" {
+		add(stringscore.JaroWinkler(q.city, i.city), cityWeight)
 	}
 
 	// Compare state (exact match)
-	if query.State != "" && index.State != "" {
-		if strings.EqualFold(query.State, index.State) {
-			totalScore += stateWeight
-		}
-		totalWeight += stateWeight
+	if q.state != "" && i.state != "" {
+		add(boolToScore(q.state == i.state), stateWeight)
 	}
 
-	// Compare postal code (exact match)
-	if query.PostalCode != "" && index.PostalCode != "" {
-		if strings.EqualFold(query.PostalCode, index.PostalCode) {
-			totalScore += postalWeight
-		}
-		totalWeight += postalWeight
+	// Compare postal code (exact match), where a ZIP+4 code matches its ZIP code
+	if q.postalCode != "" && i.postalCode != "" {
+		add(boolToScore(samePostalCode(q.postalCode, i.postalCode)), postalWeight)
 	}
 
 	// Compare country (exact match)
 	if query.Country != "" && index.Country != "" {
-		if strings.EqualFold(query.Country, index.Country) {
-			totalScore += countryWeight
-		}
-		totalWeight += countryWeight
+		add(boolToScore(sameCountry(query.Country, index.Country)), countryWeight)
 	}
 
 	if totalWeight == 0 {
@@ -114,3 +113,129 @@ func compareAddress(query, index Address) float64 {
 
 	return totalScore / totalWeight
 }
+
+// compareAddressCountries returns a conflict when the query's addresses and the entity's are in different countries
+func compareAddressCountries[Q any, I any](query Entity[Q], index Entity[I]) (AttributeConflict, bool) {
+	qCountries, iCountries := addressCountries(query.Addresses), addressCountries(index.Addresses)
+	if len(qCountries) == 0 || len(iCountries) == 0 {
+		return AttributeConflict{}, false
+	}
+	if slices.ContainsFunc(qCountries, func(c string) bool {
+		return slices.ContainsFunc(iCountries, func(other string) bool { return sameCountry(c, other) })
+	}) {
+		return AttributeConflict{}, false
+	}
+	return AttributeConflict{
+		Field:   "addressCountry",
+		Query:   qCountries,
+		Indexed: iCountries,
+		Factor:  0,
+	}, true
+}
+
+func addressCountries(addresses []Address) []string {
+	var out []string
+	for _, addr := range addresses {
+		if addr.Country != "" && !slices.Contains(out, addr.Country) {
+			out = append(out, addr.Country)
+		}
+	}
+	return out
+}
+
+// addressParts are the normalized components of an address
+type addressParts struct {
+	numbers    []string // of lines one and two, such as house, building and suite numbers
+	street     []string // the other words of lines one and two, with street types abbreviated
+	city       string
+	state      string
+	postalCode string // without spaces or dashes
+}
+
+func newAddressParts(addr Address) addressParts {
+	var out addressParts
+	for _, word := range addressWords(addr.Line1 + " " + addr.Line2) {
+		if strings.ContainsFunc(word, unicode.IsDigit) {
+			out.numbers = append(out.numbers, word)
+		} else if abbr, ok := streetTypes[word]; ok {
+			out.street = append(out.street, abbr)
+		} else if !addressUnitWords[word] {
+			out.street = append(out.street, word)
+		}
+	}
+	out.city = strings.Join(addressWords(addr.City), " ")
+	out.state = strings.Join(addressWords(addr.State), " ")
+	out.postalCode = strings.ToLower(strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, addr.PostalCode))
+	return out
+}
+
+// addressWords lowercases text, removes its accents and splits it into words of letters and digits
+func addressWords(text string) []string {
+	text = strings.Map(func(r rune) rune {
+		if unicode.Is(unicode.Mn, r) {
+			return -1
+		}
+		return r
+	}, norm.NFD.String(strings.ToLower(text)))
+
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// compareStreetWords averages the best Jaro-Winkler score of each word of the shorter street against the
+// other's words, so words in a different order or missing from one address count less against a match
+func compareStreetWords(query, index []string) float64 {
+	if len(query) > len(index) {
+		query, index = index, query
+	}
+	var total float64
+	for _, q := range query {
+		var best float64
+		for _, w := range index {
+			if score := stringscore.JaroWinkler(q, w); score > best {
+				best = score
+			}
+		}
+		total += best
+	}
+	return total / float64(len(query))
+}
+
+func samePostalCode(a, b string) bool {
+	if a == b {
+		return true
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return len(a) == 5 && len(b) == 9 && strings.HasPrefix(b, a) // US ZIP+4
+}
+
+// streetTypes maps the words of street types to one abbreviation, so addresses written either way agree
+var streetTypes = map[string]string{
+	"street": "st", "st": "st", "str": "st", "strasse": "st", "straße": "st",
+	"avenue": "ave", "ave": "ave", "av": "ave", "avenida": "ave",
+	"road": "rd", "rd": "rd",
+	"boulevard": "blvd", "blvd": "blvd",
+	"drive": "dr", "dr": "dr",
+	"lane": "ln", "ln": "ln",
+	"court": "ct", "ct": "ct",
+	"place": "pl", "pl": "pl",
+	"square": "sq", "sq": "sq",
+	"highway": "hwy", "hwy": "hwy",
+	"ulitsa": "ul", "ul": "ul",
+	"prospekt": "pr", "prospect": "pr", "pr": "pr", "prosp": "pr",
+}
+
+// addressUnitWords are the words of a line which only describe its numbers, such as "Floor 31" or "Suite Q"
+var addressUnitWords = map[string]bool{
+	"apt": true, "apartment": true, "suite": true, "ste": true, "unit": true, "floor": true, "fl": true,
+	"room": true, "office": true, "building": true, "bldg": true, "no": true, "number": true,
+	"d": true, "dom": true, "kom": true, "korpus": true, "pomeshch": true,
+}
@@ -0,0 +1,89 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareAddress(t *testing.T) {
+	index := Address{
+		Line1:      "1600 Pennsylvania Avenue NW",
+		City:       "Washington",
+		State:      "DC",
+		PostalCode: "20500-0003",
+		Country:    "US",
+	}
+
+	// Street types, word order, ZIP+4 codes and country names don't count against a match
+	score := compareAddress(Address{
+		Line1:      "Pennsylvania Ave. NW 1600",
+		City:       "washington",
+		State:      "dc",
+		PostalCode: "20500",
+		Country:    "United States",
+	}, index)
+	require.InDelta(t, 1.0, score, 0.001)
+
+	// Another house number on the same street
+	score = compareAddress(Address{Line1: "1500 Pennsylvania Avenue NW", City: "Washington"}, index)
+	require.InDelta(t, 0.833, score, 0.001)
+
+	// Another street in the same city
+	score = compareAddress(Address{Line1: "350 Fifth Avenue", City: "Washington", PostalCode: "10118"}, index)
+	require.Less(t, score, 0.6)
+
+	// Only the components of both addresses are compared
+	require.InDelta(t, 1.0, compareAddress(Address{Country: "us"}, index), 0.001)
+	require.Zero(t, compareAddress(Address{}, index))
+}
+
+func TestNewAddressParts(t *testing.T) {
+	parts := newAddressParts(Address{
+		Line1:      "Presnenskaya Embankment",
+		Line2:      "12, Federation East Tower, Floor 31, Suite Q",
+		City:       "Moskva",
+		PostalCode: "123 317",
+	})
+	require.Equal(t, []string{"12", "31"}, parts.numbers)
+	require.Equal(t, []string{"presnenskaya", "embankment", "federation", "east", "tower", "q"}, parts.street)
+	require.Equal(t, "moskva", parts.city)
+	require.Equal(t, "123317", parts.postalCode)
+
+	parts = newAddressParts(Address{Line1: "Unter den Linden Straße 77", City: "Zürich"})
+	require.Equal(t, []string{"unter", "den", "linden", "st"}, parts.street)
+	require.Equal(t, "zurich", parts.city)
+}
+
+func TestSimilarity_RequireAddressCountry(t *testing.T) {
+	query := Entity[any]{
+		Name:      "Acme Trading",
+		Type:      EntityBusiness,
+		Business:  &Business{Name: "Acme Trading"},
+		Addresses: []Address{{City: "Tehran", Country: "Iran"}},
+	}
+	index := Entity[any]{
+		Name:      "Acme Trading",
+		Type:      EntityBusiness,
+		Business:  &Business{Name: "Acme Trading"},
+		Addresses: []Address{{City: "Dubai", Country: "AE"}},
+	}
+
+	// Only lowered by the address when the country isn't required
+	require.Greater(t, SimilarityWithConfig(query, index, SimilarityConfig{}), 0.5)
+
+	cfg := SimilarityConfig{RequireAddressCountry: true}
+	score, explain := ExplainSimilarity(query, index, cfg)
+	require.Zero(t, score)
+	require.Equal(t, []AttributeConflict{
+		{Field: "addressCountry", Query: []string{"Iran"}, Indexed: []string{"AE"}},
+	}, explain.Conflicts)
+
+	// Any address in one of the query's countries matches
+	index.Addresses = append(index.Addresses, Address{Country: "IR"})
+	require.Greater(t, SimilarityWithConfig(query, index, cfg), 0.9)
+
+	// Entities without the country of an address aren't filtered
+	index.Addresses = []Address{{City: "Dubai"}}
+	require.Greater(t, SimilarityWithConfig(query, index, cfg), 0.5)
+}
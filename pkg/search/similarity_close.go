@@ -15,8 +15,9 @@ const (
 	distant    = 365 // Within a year
 )
 
-// compareEntityDates performs date comparisons based on entity type
-func compareEntityDates[Q any, I any](w io.Writer, query Entity[Q], index Entity[I], weight float64) scorePiece {
+// compareEntityDates performs date comparisons based on entity type. Dates of birth up to tolerance years apart
+// still count a little towards the score, see SimilarityConfig.BirthYearTolerance.
+func compareEntityDates[Q any, I any](w io.Writer, query Entity[Q], index Entity[I], weight float64, tolerance int) scorePiece {
 	if query.Type != index.Type {
 		return scorePiece{score: 0, weight: weight, pieceType: "dates", fieldsCompared: 0}
 	}
@@ -27,7 +28,7 @@ func compareEntityDates[Q any, I any](w io.Writer, query Entity[Q], index Entity
 
 	switch query.Type {
 	case EntityPerson:
-		dateScore, matched, fieldsCompared = comparePersonDates(query.Person, index.Person)
+		dateScore, matched, fieldsCompared = comparePersonDates(query.Person, index.Person, tolerance)
 	case EntityBusiness:
 		dateScore, matched, fieldsCompared = compareBusinessDates(query.Business, index.Business)
 	case EntityOrganization:
@@ -48,7 +49,7 @@ func compareEntityDates[Q any, I any](w io.Writer, query Entity[Q], index Entity
 }
 
 // comparePersonDates handles birth and death dates
-func comparePersonDates(query *Person, index *Person) (float64, bool, int) {
+func comparePersonDates(query *Person, index *Person, tolerance int) (float64, bool, int) {
 	if query == nil || index == nil {
 		return 0, false, 0
 	}
@@ -56,10 +57,10 @@ func comparePersonDates(query *Person, index *Person) (float64, bool, int) {
 	fieldsCompared := 0
 	var scores []float64
 
-	// Birth date comparison
-	if query.BirthDate != nil && index.BirthDate != nil {
+	// Birth date comparison, including partial dates and ranges
+	if score, status := comparePersonBirthDates(query, index, tolerance); status != "" {
 		fieldsCompared++
-		scores = append(scores, compareDates(query.BirthDate, index.BirthDate))
+		scores = append(scores, score)
 	}
 
 	// Death date comparison
@@ -177,6 +178,11 @@ func compareDates(date1, date2 *time.Time) float64 {
 	// Calculate difference in days
 	diffDays := math.Abs(d1.Sub(d2).Hours() / 24)
 
+	return dateDifferenceScore(diffDays)
+}
+
+// dateDifferenceScore scores how close two dates are, given how many days apart they are
+func dateDifferenceScore(diffDays float64) float64 {
 	switch {
 	case diffDays <= float64(exactMatch):
 		return 1.0
@@ -193,6 +199,138 @@ func compareDates(date1, date2 *time.Time) float64 {
 	}
 }
 
+// DateMatch describes how a query's date compared against an indexed entity's date
+type DateMatch string
+
+var (
+	// DateMatchExact is when both dates are the same day
+	DateMatchExact DateMatch = "exact"
+
+	// DateMatchWithin is when the dates overlap, but at least one is a partial date or range
+	DateMatchWithin DateMatch = "within"
+
+	// DateMatchClose is when the dates don't overlap, but are less than a year apart or within the
+	// SimilarityConfig.BirthYearTolerance
+	DateMatchClose DateMatch = "close"
+
+	// DateMatchTransposed is when the dates are the same once the day and month of one are swapped,
+	// such as 1962-03-11 written as 1962-11-03
+	DateMatchTransposed DateMatch = "transposed"
+
+	DateMatchMismatch DateMatch = "mismatch"
+)
+
+// CompareBirthDates returns how the query's date of birth matched the indexed person's. An empty DateMatch is
+// returned when either lacks a date of birth.
+func CompareBirthDates[Q any, I any](query Entity[Q], index Entity[I]) DateMatch {
+	return CompareBirthDatesWithConfig(query, index, SimilarityConfig{})
+}
+
+// CompareBirthDatesWithConfig does the same as CompareBirthDates, with the BirthYearTolerance of cfg
+func CompareBirthDatesWithConfig[Q any, I any](query Entity[Q], index Entity[I], cfg SimilarityConfig) DateMatch {
+	_, status := comparePersonBirthDates(query.Person, index.Person, cfg.BirthYearTolerance)
+	return status
+}
+
+func comparePersonBirthDates(query *Person, index *Person, tolerance int) (float64, DateMatch) {
+	if query == nil || index == nil {
+		return 0, ""
+	}
+
+	queryDates, indexDates := birthDateRanges(query), birthDateRanges(index)
+	if len(queryDates) == 0 || len(indexDates) == 0 {
+		return 0, ""
+	}
+
+	var bestScore float64
+	bestStatus := DateMatchMismatch
+	for _, q := range queryDates {
+		for _, i := range indexDates {
+			score, status := compareDateRanges(q, i, tolerance)
+			if score > bestScore {
+				bestScore, bestStatus = score, status
+			}
+		}
+	}
+	return bestScore, bestStatus
+}
+
+func birthDateRanges(p *Person) []DateRange {
+	if len(p.BirthDates) > 0 {
+		return p.BirthDates
+	}
+	if p.BirthDate != nil {
+		day := p.BirthDate.Truncate(24 * time.Hour)
+		return []DateRange{{Start: day, End: day}}
+	}
+	return nil
+}
+
+// compareDateRanges scores overlapping ranges by how precise the wider range is, otherwise by the gap between them.
+// Days whose day and month were swapped, and gaps of up to tolerance years, score lower than dates which are close.
+func compareDateRanges(r1, r2 DateRange, tolerance int) (float64, DateMatch) {
+	start1, end1 := r1.Start.Truncate(24*time.Hour), r1.End.Truncate(24*time.Hour)
+	start2, end2 := r2.Start.Truncate(24*time.Hour), r2.End.Truncate(24*time.Hour)
+
+	if !start1.After(end2) && !start2.After(end1) {
+		span := math.Max(end1.Sub(start1).Hours(), end2.Sub(start2).Hours()) / 24
+		switch {
+		case span <= float64(exactMatch):
+			return 1.0, DateMatchExact
+		case span <= 31:
+			return 0.95, DateMatchWithin
+		case span <= 366:
+			return 0.9, DateMatchWithin
+		default:
+			return 0.85, DateMatchWithin
+		}
+	}
+
+	if start1.Equal(end1) && start2.Equal(end2) && isTransposed(start1, start2) {
+		return transposedDateScore, DateMatchTransposed
+	}
+
+	gap := start1.Sub(end2)
+	if start2.After(end1) {
+		gap = start2.Sub(end1)
+	}
+	diffDays := gap.Hours() / 24
+	if score := dateDifferenceScore(diffDays); score > 0 {
+		return score, DateMatchClose
+	}
+	if score := yearToleranceScore(diffDays, tolerance); score > 0 {
+		return score, DateMatchClose
+	}
+	return 0, DateMatchMismatch
+}
+
+const (
+	// transposedDateScore is given to dates whose day and month are swapped, which is a common data entry mistake
+	transposedDateScore = 0.8
+
+	// daysPerYear counts leap years, so dates on the same day of years apart are whole years apart
+	daysPerYear = 365.25
+)
+
+// isTransposed returns true when d1 and d2 are the same date once the day and month of d1 are swapped
+func isTransposed(d1, d2 time.Time) bool {
+	if d1.Day() > 12 || d1.Day() == int(d1.Month()) {
+		return false
+	}
+	swapped := time.Date(d1.Year(), time.Month(d1.Day()), int(d1.Month()), 0, 0, 0, 0, d1.Location())
+	return swapped.Equal(d2)
+}
+
+// yearToleranceScore grades dates which are more than a year but at most tolerance years apart, from the 0.3
+// dateDifferenceScore gives dates a year apart down to 0.1 at tolerance years
+func yearToleranceScore(diffDays float64, tolerance int) float64 {
+	years := diffDays / daysPerYear
+	if tolerance <= 1 || years > float64(tolerance) {
+		return 0.0
+	}
+	return 0.3 - 0.2*(years-1)/float64(tolerance-1)
+}
+
 // areDatesLogical checks if dates make temporal sense
 func areDatesLogical(person *Person, index *Person) bool {
 	if person.BirthDate != nil && person.DeathDate != nil &&
@@ -0,0 +1,98 @@
+package search
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareDateRanges(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+	single := func(t time.Time) DateRange {
+		return DateRange{Start: t, End: t}
+	}
+
+	cases := []struct {
+		name      string
+		r1, r2    DateRange
+		tolerance int
+		score     float64
+		expected  DateMatch
+	}{
+		{"same day", single(day(1993, 4, 17)), single(day(1993, 4, 17)), 0, 1.0, DateMatchExact},
+		{"within month", single(day(1993, 4, 17)), DateRangeFor(day(1993, 4, 1), "Jan 2006"), 0, 0.95, DateMatchWithin},
+		{"within year", single(day(1993, 4, 17)), DateRangeFor(day(1993, 1, 1), "2006"), 0, 0.9, DateMatchWithin},
+		{"within range", single(day(1960, 6, 1)), DateRange{Start: day(1958, 1, 1), End: day(1962, 12, 31)}, 0, 0.85, DateMatchWithin},
+		{"a day apart", single(day(1993, 4, 17)), single(day(1993, 4, 18)), 0, 0.925, DateMatchClose},
+		{"after range", single(day(1994, 1, 1)), DateRangeFor(day(1993, 1, 1), "2006"), 0, 0.925, DateMatchClose},
+		{"years apart", single(day(1970, 1, 1)), single(day(1993, 4, 17)), 0, 0.0, DateMatchMismatch},
+		{"transposed", single(day(1962, 3, 11)), single(day(1962, 11, 3)), 0, 0.8, DateMatchTransposed},
+		{"transposed other year", single(day(1962, 3, 11)), single(day(1963, 11, 3)), 0, 0.0, DateMatchMismatch},
+		{"within tolerance", single(day(1990, 4, 17)), single(day(1992, 4, 17)), 3, 0.2, DateMatchClose},
+		{"at tolerance", single(day(1990, 1, 2)), DateRangeFor(day(1993, 1, 1), "2006"), 3, 0.1, DateMatchClose},
+		{"beyond tolerance", single(day(1970, 1, 1)), single(day(1993, 4, 17)), 3, 0.0, DateMatchMismatch},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			score, status := compareDateRanges(tc.r1, tc.r2, tc.tolerance)
+			require.InDelta(t, tc.score, score, 0.001)
+			require.Equal(t, tc.expected, status)
+
+			// Order doesn't matter
+			score, status = compareDateRanges(tc.r2, tc.r1, tc.tolerance)
+			require.InDelta(t, tc.score, score, 0.001)
+			require.Equal(t, tc.expected, status)
+		})
+	}
+}
+
+func TestCompareBirthDates(t *testing.T) {
+	dob := time.Date(1993, time.April, 17, 0, 0, 0, 0, time.UTC)
+	index := Entity[any]{
+		Person: &Person{
+			BirthDate: &dob,
+			BirthDates: []DateRange{
+				{Start: dob, End: dob},
+				DateRangeFor(time.Date(1991, time.January, 1, 0, 0, 0, 0, time.UTC), "2006"),
+			},
+		},
+	}
+
+	require.Equal(t, DateMatch(""), CompareBirthDates(Entity[any]{}, index))
+	require.Equal(t, DateMatch(""), CompareBirthDates(Entity[any]{Person: &Person{}}, index))
+
+	query := func(t time.Time) Entity[any] {
+		return Entity[any]{Person: &Person{BirthDate: &t}}
+	}
+	require.Equal(t, DateMatchExact, CompareBirthDates(query(dob), index))
+	require.Equal(t, DateMatchWithin, CompareBirthDates(query(time.Date(1991, time.July, 4, 0, 0, 0, 0, time.UTC)), index))
+	require.Equal(t, DateMatchMismatch, CompareBirthDates(query(time.Date(1970, time.July, 4, 0, 0, 0, 0, time.UTC)), index))
+
+	twoYears := query(time.Date(1995, time.April, 17, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, DateMatchMismatch, CompareBirthDates(twoYears, index))
+	require.Equal(t, DateMatchClose, CompareBirthDatesWithConfig(twoYears, index, SimilarityConfig{BirthYearTolerance: 3}))
+}
+
+func TestSimilarity_BirthYearTolerance(t *testing.T) {
+	dob := time.Date(1962, time.November, 3, 0, 0, 0, 0, time.UTC)
+	index := Entity[any]{
+		Name:   "Nicolas Maduro Moros",
+		Type:   EntityPerson,
+		Person: &Person{Name: "Nicolas Maduro Moros", BirthDate: &dob},
+	}
+	query := func(t time.Time) Entity[any] {
+		return Entity[any]{Name: "Nicolas Maduro Moros", Type: EntityPerson, Person: &Person{Name: "Nicolas Maduro Moros", BirthDate: &t}}
+	}
+
+	transposed := Similarity(query(time.Date(1962, time.March, 11, 0, 0, 0, 0, time.UTC)), index)
+	mismatch := Similarity(query(time.Date(1968, time.March, 11, 0, 0, 0, 0, time.UTC)), index)
+	require.Greater(t, transposed, mismatch)
+
+	twoYears := query(time.Date(1964, time.November, 3, 0, 0, 0, 0, time.UTC))
+	tolerant := SimilarityWithConfig(twoYears, index, SimilarityConfig{BirthYearTolerance: 5})
+	require.Greater(t, tolerant, Similarity(twoYears, index))
+	require.Greater(t, Similarity(query(dob), index), tolerant)
+}
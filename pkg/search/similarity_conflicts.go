@@ -0,0 +1,161 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ConflictFactors multiply the score of an entity whose attributes conflict with the query's, so a strong name
+// match of the wrong nationality can rank below a weaker match which agrees with the query.
+// Factors left at zero use the default, and a factor of one ignores the conflict.
+type ConflictFactors struct {
+	// Gender applies when the query and a person have different genders, neither of them unknown
+	Gender float64 `json:"gender,omitempty"`
+
+	// Nationality applies when a person has none of the query's nationalities
+	Nationality float64 `json:"nationality,omitempty"`
+
+	// FinancialID applies when the query and a business or organization both have BICs, or both have LEIs, and
+	// none of them are the same institution's
+	FinancialID float64 `json:"financialID,omitempty"`
+}
+
+const (
+	genderConflictFactor      = 0.8
+	nationalityConflictFactor = 0.8
+	financialIDConflictFactor = 0.5
+)
+
+// DefaultConflictFactors returns the factors used when a factor isn't set
+func DefaultConflictFactors() ConflictFactors {
+	return ConflictFactors{
+		Gender:      genderConflictFactor,
+		Nationality: nationalityConflictFactor,
+		FinancialID: financialIDConflictFactor,
+	}
+}
+
+// Or returns c with any factors left at zero taken from other
+func (c ConflictFactors) Or(other ConflictFactors) ConflictFactors {
+	if c.Gender == 0 {
+		c.Gender = other.Gender
+	}
+	if c.Nationality == 0 {
+		c.Nationality = other.Nationality
+	}
+	if c.FinancialID == 0 {
+		c.FinancialID = other.FinancialID
+	}
+	return c
+}
+
+// Validate returns an error when a factor is negative, above one or not a number
+func (c ConflictFactors) Validate() error {
+	for _, field := range c.fields() {
+		v := *field.value
+		if math.IsNaN(v) || v < 0 || v > 1 {
+			return fmt.Errorf("%s conflict factor of %v must be between 0 and 1", field.name, v)
+		}
+	}
+	return nil
+}
+
+// ParseConflictFactors reads factors written as comma separated field:factor pairs, such as "nationality:0.7".
+// Fields are gender, nationality and financialID.
+func ParseConflictFactors(input string) (ConflictFactors, error) {
+	var out ConflictFactors
+	fields := out.fields()
+
+	for _, pair := range strings.Split(input, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, found := strings.Cut(pair, ":")
+		if !found {
+			return out, fmt.Errorf("invalid conflict factor %q, expected field:factor", pair)
+		}
+		idx := slices.IndexFunc(fields, func(f weightField) bool {
+			return strings.EqualFold(f.name, strings.TrimSpace(name))
+		})
+		if idx < 0 {
+			return out, fmt.Errorf("unknown conflict field %q", name)
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return out, fmt.Errorf("invalid %s conflict factor %q: %w", fields[idx].name, value, err)
+		}
+		*fields[idx].value = v
+	}
+	return out, out.Validate()
+}
+
+func (c *ConflictFactors) fields() []weightField {
+	return []weightField{
+		{"gender", &c.Gender},
+		{"nationality", &c.Nationality},
+		{"financialID", &c.FinancialID},
+	}
+}
+
+// AttributeConflict is an attribute of the query which differs from the indexed entity's
+type AttributeConflict struct {
+	Field   string   `json:"field"`
+	Query   []string `json:"query"`
+	Indexed []string `json:"indexed"`
+
+	// Factor is what the score was multiplied by
+	Factor float64 `json:"factor"`
+}
+
+// compareConflicts returns the attributes of a person, or the identifiers of a financial institution, which
+// conflict with the query's
+func compareConflicts[Q any, I any](query Entity[Q], index Entity[I], factors ConflictFactors) []AttributeConflict {
+	out := compareFinancialIDConflicts(query, index, factors)
+	if query.Person == nil || index.Person == nil {
+		return out
+	}
+
+	qGender, iGender := knownGender(query.Person.Gender), knownGender(index.Person.Gender)
+	if qGender != "" && iGender != "" && qGender != iGender {
+		out = append(out, AttributeConflict{
+			Field:   "gender",
+			Query:   []string{string(qGender)},
+			Indexed: []string{string(iGender)},
+			Factor:  factors.Gender,
+		})
+	}
+
+	qNations, iNations := query.Person.Nationalities, index.Person.Nationalities
+	if len(qNations) > 0 && len(iNations) > 0 && !slices.ContainsFunc(qNations, func(n string) bool {
+		return slices.ContainsFunc(iNations, func(other string) bool { return strings.EqualFold(n, other) })
+	}) {
+		out = append(out, AttributeConflict{
+			Field:   "nationality",
+			Query:   qNations,
+			Indexed: iNations,
+			Factor:  factors.Nationality,
+		})
+	}
+	return out
+}
+
+func knownGender(g Gender) Gender {
+	switch g := Gender(strings.ToLower(string(g))); g {
+	case GenderMale, GenderFemale:
+		return g
+	}
+	return ""
+}
+
+// conflictFactor multiplies the factors of every conflict
+func conflictFactor(conflicts []AttributeConflict) float64 {
+	factor := 1.0
+	for _, c := range conflicts {
+		factor *= c.Factor
+	}
+	return factor
+}
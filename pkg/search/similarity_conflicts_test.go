@@ -0,0 +1,77 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConflictFactors(t *testing.T) {
+	factors, err := ParseConflictFactors("Gender:0.9, nationality:0.5, financialID:0.3")
+	require.NoError(t, err)
+	require.Equal(t, ConflictFactors{Gender: 0.9, Nationality: 0.5, FinancialID: 0.3}, factors)
+
+	factors, err = ParseConflictFactors("")
+	require.NoError(t, err)
+	require.Equal(t, DefaultConflictFactors(), factors.Or(DefaultConflictFactors()))
+
+	_, err = ParseConflictFactors("gender")
+	require.ErrorContains(t, err, `invalid conflict factor "gender"`)
+
+	_, err = ParseConflictFactors("age:0.5")
+	require.ErrorContains(t, err, `unknown conflict field "age"`)
+
+	_, err = ParseConflictFactors("nationality:1.5")
+	require.ErrorContains(t, err, "nationality conflict factor of 1.5 must be between 0 and 1")
+}
+
+func TestCompareConflicts(t *testing.T) {
+	person := func(gender Gender, nationalities ...string) Entity[any] {
+		return Entity[any]{
+			Type:   EntityPerson,
+			Person: &Person{Gender: gender, Nationalities: nationalities},
+		}
+	}
+	factors := DefaultConflictFactors()
+
+	require.Empty(t, compareConflicts(person(GenderMale, "VE"), person(GenderMale, "CU", "VE"), factors))
+	require.Empty(t, compareConflicts(person(GenderUnknown), person(GenderFemale), factors))
+	require.Empty(t, compareConflicts(person(""), person(GenderFemale, "VE"), factors))
+	require.Empty(t, compareConflicts(Entity[any]{}, person(GenderFemale), factors))
+
+	conflicts := compareConflicts(person(GenderMale, "VE"), person(GenderFemale, "CU"), factors)
+	require.Len(t, conflicts, 2)
+	require.Equal(t, "gender", conflicts[0].Field)
+	require.Equal(t, "nationality", conflicts[1].Field)
+	require.Equal(t, []string{"CU"}, conflicts[1].Indexed)
+	require.InDelta(t, genderConflictFactor*nationalityConflictFactor, conflictFactor(conflicts), 0.001)
+}
+
+func TestSimilarity_Conflicts(t *testing.T) {
+	query := Entity[any]{
+		Name:   "Ali Hassan Ahmed",
+		Type:   EntityPerson,
+		Person: &Person{Name: "Ali Hassan Ahmed", Gender: GenderMale, Nationalities: []string{"IQ"}},
+	}
+	strong := Entity[any]{
+		Name:   "Ali Hassan Ahmed",
+		Type:   EntityPerson,
+		Person: &Person{Name: "Ali Hassan Ahmed", Gender: GenderMale, Nationalities: []string{"SY"}},
+	}
+	weaker := Entity[any]{
+		Name:   "Ali Hasan Ahmad",
+		Type:   EntityPerson,
+		Person: &Person{Name: "Ali Hasan Ahmad", Gender: GenderMale, Nationalities: []string{"IQ"}},
+	}
+
+	// The conflicting nationality ranks the exact name below a close spelling of it
+	require.Less(t, Similarity(query, strong), Similarity(query, weaker))
+
+	// A factor of one ignores the conflict
+	ignored := SimilarityWithConfig(query, strong, SimilarityConfig{Conflicts: ConflictFactors{Nationality: 1}})
+	require.Greater(t, ignored, Similarity(query, weaker))
+
+	_, explain := ExplainSimilarity(query, strong, SimilarityConfig{})
+	require.Len(t, explain.Conflicts, 1)
+	require.Equal(t, "nationality", explain.Conflicts[0].Field)
+}
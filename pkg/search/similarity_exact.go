@@ -60,7 +60,7 @@ func comparePersonExactIDs(w io.Writer, query *Person, index *Person, weight flo
 		for _, qID := range query.GovernmentIDs {
 			for _, iID := range index.GovernmentIDs {
 				if strings.EqualFold(string(qID.Type), string(iID.Type)) &&
-					strings.EqualFold(qID.Country, iID.Country) &&
+					sameCountry(qID.Country, iID.Country) &&
 					strings.EqualFold(normalizeIdentifier(qID.Identifier), normalizeIdentifier(iID.Identifier)) {
 					score += 15.0
 					hasMatch = true
@@ -106,7 +106,7 @@ func compareBusinessExactIDs(w io.Writer, query *Business, index *Business, weig
 			for _, iID := range index.GovernmentIDs {
 				// Exact match on all identifier fields
 				if strings.EqualFold(string(qID.Type), string(iID.Type)) &&
-					strings.EqualFold(qID.Country, iID.Country) &&
+					sameCountry(qID.Country, iID.Country) &&
 					strings.EqualFold(normalizeIdentifier(qID.Identifier), normalizeIdentifier(iID.Identifier)) {
 					score += 15.0
 					hasMatch = true
@@ -152,7 +152,7 @@ func compareOrgExactIDs(w io.Writer, query *Organization, index *Organization, w
 			for _, iID := range index.GovernmentIDs {
 				// Exact match on all identifier fields
 				if strings.EqualFold(string(qID.Type), string(iID.Type)) &&
-					strings.EqualFold(qID.Country, iID.Country) &&
+					sameCountry(qID.Country, iID.Country) &&
 					strings.EqualFold(normalizeIdentifier(qID.Identifier), normalizeIdentifier(iID.Identifier)) {
 					score += 15.0
 					hasMatch = true
@@ -257,6 +257,16 @@ func compareAircraftExactIDs(w io.Writer, query *Aircraft, index *Aircraft, weig
 		}
 	}
 
+	// Tail Number, which can match a previous registration
+	if query.TailNumber != "" {
+		fieldsCompared++
+		totalWeight += 15.0
+		if matchesTailNumber(query.TailNumber, index) {
+			score += 15.0
+			hasMatch = true
+		}
+	}
+
 	// ICAO Code
 	if query.ICAOCode != "" {
 		fieldsCompared++
@@ -283,6 +293,26 @@ func compareAircraftExactIDs(w io.Writer, query *Aircraft, index *Aircraft, weig
 	}
 }
 
+// matchesTailNumber compares registration marks without dashes or spaces, as EP-MMH is also written EPMMH
+func matchesTailNumber(tailNumber string, index *Aircraft) bool {
+	normalize := func(s string) string {
+		return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(strings.TrimSpace(s)))
+	}
+	tailNumber = normalize(tailNumber)
+	if tailNumber == "" {
+		return false
+	}
+	if tailNumber == normalize(index.TailNumber) {
+		return true
+	}
+	for _, previous := range index.PreviousTailNumbers {
+		if tailNumber == normalize(previous) {
+			return true
+		}
+	}
+	return false
+}
+
 func compareExactCryptoAddresses[Q any, I any](w io.Writer, query Entity[Q], index Entity[I], weight float64) scorePiece {
 	fieldsCompared := 0
 	hasMatch := false
@@ -413,7 +443,7 @@ func compareIdentifiers(queryID, indexID string, queryCountry, indexCountry stri
 	}
 
 	// Both have country - check if they match
-	if strings.EqualFold(queryCountry, indexCountry) {
+	if sameCountry(queryCountry, indexCountry) {
 		return idMatch{score: 1.0, found: true, exact: true, hasCountry: true}
 	}
 
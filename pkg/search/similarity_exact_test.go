@@ -41,3 +41,23 @@ func TestCompareBusinessExactIDs(t *testing.T) {
 		})
 	}
 }
+
+func TestCompareAircraftExactIDs_TailNumber(t *testing.T) {
+	index := &Aircraft{
+		SerialNumber:        "449",
+		TailNumber:          "EP-MMH",
+		PreviousTailNumbers: []string{"YI-NAE"},
+	}
+
+	got := compareAircraftExactIDs(nil, &Aircraft{TailNumber: "EPMMH"}, index, criticalIdWeight)
+	require.True(t, got.matched)
+	require.True(t, got.exact)
+
+	got = compareAircraftExactIDs(nil, &Aircraft{TailNumber: "yi-nae"}, index, criticalIdWeight)
+	require.True(t, got.matched)
+
+	got = compareAircraftExactIDs(nil, &Aircraft{TailNumber: "EP-MMA", SerialNumber: "449"}, index, criticalIdWeight)
+	require.True(t, got.matched)
+	require.False(t, got.exact)
+	require.Equal(t, 2, got.fieldsCompared)
+}
@@ -0,0 +1,198 @@
+package search
+
+import (
+	"math"
+	"strings"
+
+	"github.com/moov-io/watchman/internal/prepare"
+)
+
+// SimilarityExplanation describes how Similarity arrived at a score so the result can be reviewed.
+type SimilarityExplanation struct {
+	Score float64 `json:"score"`
+
+	// DecidedBy is set when one exact identifier comparison determined the whole score
+	DecidedBy string `json:"decidedBy,omitempty"`
+
+	Fields []FieldScore `json:"fields"`
+
+	// BaseScore is the weighted average of Fields before coverage penalties and bonuses are applied
+	BaseScore float64 `json:"baseScore"`
+
+	Coverage         float64 `json:"coverage"`
+	CriticalCoverage float64 `json:"criticalCoverage"`
+
+	Name *NameExplanation `json:"name,omitempty"`
+
+	// Conflicts are the attributes which differ from the query's, each multiplying the score by its factor
+	Conflicts []AttributeConflict `json:"conflicts,omitempty"`
+}
+
+// FieldScore is the result of comparing one group of fields on the query and index entities
+type FieldScore struct {
+	Field string  `json:"field"`
+	Score float64 `json:"score"`
+
+	// Weight is how much the score counts towards the overall score, including any multiplier for required fields
+	Weight float64 `json:"weight"`
+
+	Matched        bool `json:"matched"`
+	Required       bool `json:"required"`
+	Exact          bool `json:"exact"`
+	FieldsCompared int  `json:"fieldsCompared"`
+}
+
+// NameExplanation shows which indexed name matched the query best and how each query term aligned to it
+type NameExplanation struct {
+	Query   string `json:"query"`
+	Matched string `json:"matched"`
+
+	// Kind is one of "primary", "alt" or "historical"
+	Kind string `json:"kind"`
+
+	Terms []TermAlignment `json:"terms"`
+}
+
+// TermAlignment pairs a query term with the indexed term that it scored highest against
+type TermAlignment struct {
+	Query   string  `json:"query"`
+	Indexed string  `json:"indexed"`
+	Score   float64 `json:"score"`
+}
+
+// explainedFields names each scorePiece in the order similarity computes them
+var explainedFields = []string{
+	"identifiers",
+	"cryptoAddresses",
+	"governmentIDs",
+	"name",
+	"titles",
+	"dates",
+	"addresses",
+	"supportingInfo",
+}
+
+func explainPiece(field string, piece scorePiece) FieldScore {
+	weight := piece.weight
+	if piece.required {
+		weight *= criticalFieldMultiplier
+	}
+	if piece.fieldsCompared == 0 {
+		weight = 0
+	}
+	return FieldScore{
+		Field:          field,
+		Score:          zeroNaN(piece.score),
+		Weight:         weight,
+		Matched:        piece.matched,
+		Required:       piece.required,
+		Exact:          piece.exact,
+		FieldsCompared: piece.fieldsCompared,
+	}
+}
+
+func zeroNaN(n float64) float64 {
+	if math.IsNaN(n) {
+		return 0.0
+	}
+	return n
+}
+
+func (e *SimilarityExplanation) decidedBy(piece scorePiece) {
+	field := piece.pieceType
+	switch field {
+	case "crypto-exact":
+		field = "cryptoAddresses"
+	case "gov-ids-exact":
+		field = "governmentIDs"
+	case "financial-ids-exact":
+		field = "financialIDs"
+	}
+	e.DecidedBy = field
+	e.Fields = append(e.Fields, explainPiece(field, piece))
+	e.BaseScore = zeroNaN(piece.score)
+	e.Coverage = 1.0
+	e.CriticalCoverage = 1.0
+}
+
+func explainFinalScore[Q any, I any](e *SimilarityExplanation, pieces []scorePiece, query Entity[Q], index Entity[I], cfg SimilarityConfig) {
+	for i, piece := range pieces {
+		field := piece.pieceType
+		if i < len(explainedFields) {
+			field = explainedFields[i]
+		}
+		e.Fields = append(e.Fields, explainPiece(field, piece))
+	}
+
+	cov := calculateCoverage(pieces, index)
+	e.Coverage = zeroNaN(cov.ratio)
+	e.CriticalCoverage = zeroNaN(cov.criticalRatio)
+	e.BaseScore = zeroNaN(calculateBaseScore(pieces, countFieldsByImportance(pieces)))
+
+	e.Name = explainName(query, index, termScorer(cfg, query, index), cfg.weakTerms())
+}
+
+// explainName repeats the term comparisons of compareName, keeping which indexed terms each query term matched.
+func explainName[Q any, I any](query Entity[Q], index Entity[I], scorer NameScorer, weak *WeakTermSet) *NameExplanation {
+	qFields := strings.Fields(normalizeName(query.Name))
+	qTerms := filterSignificantTerms(qFields)
+	if len(qTerms) == 0 {
+		return nil
+	}
+
+	var best *NameExplanation
+	var bestScore float64
+
+	check := func(qTerms []string, kind, name string, penalty float64) {
+		terms, score := alignNameTerms(scorer, weak, qTerms, normalizeName(name))
+		score *= penalty
+		if best == nil || score > bestScore {
+			best = &NameExplanation{
+				Query:   query.Name,
+				Matched: name,
+				Kind:    kind,
+				Terms:   terms,
+			}
+			bestScore = score
+		}
+	}
+	checkAll := func(qTerms []string, penalty float64) {
+		check(qTerms, "primary", index.Name, penalty)
+		for _, altName := range indexAltNames(index) {
+			check(qTerms, "alt", altName, penalty)
+		}
+		for _, hist := range index.HistoricalInfo {
+			if strings.EqualFold(hist.Type, "Former Name") {
+				check(qTerms, "historical", hist.Value, penalty*0.95)
+			}
+		}
+	}
+
+	checkAll(qTerms, 1.0)
+	for _, variant := range prepare.NicknameVariants(qFields) {
+		if terms := filterSignificantTerms(variant); len(terms) > 0 {
+			checkAll(terms, nicknamePenalty)
+		}
+	}
+	return best
+}
+
+func alignNameTerms(scorer NameScorer, weak *WeakTermSet, queryTerms []string, indexName string) ([]TermAlignment, float64) {
+	indexTerms := filterSignificantTerms(strings.Fields(indexName))
+	if len(indexTerms) == 0 {
+		return nil, 0.0
+	}
+
+	out := make([]TermAlignment, len(queryTerms))
+	for i, qTerm := range queryTerms {
+		out[i].Query = qTerm
+		for _, iTerm := range indexTerms {
+			if score := scorer.ScoreTerm(qTerm, iTerm); score > out[i].Score {
+				out[i].Indexed = iTerm
+				out[i].Score = score
+			}
+		}
+	}
+	score, _ := weak.scoreTerms(scorer, queryTerms, indexTerms)
+	return out, score
+}
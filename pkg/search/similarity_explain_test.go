@@ -0,0 +1,62 @@
+package search_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moov-io/watchman/internal/ofactest"
+	"github.com/moov-io/watchman/pkg/search"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainSimilarity(t *testing.T) {
+	indexEntity := ofactest.FindEntity(t, "48603")
+
+	t.Run("name and dates", func(t *testing.T) {
+		birthDate := time.Date(1993, time.April, 17, 0, 0, 0, 0, time.UTC)
+		query := search.Entity[any]{
+			Name: "Dmitri Yuryevich KHOROSHEV",
+			Type: search.EntityPerson,
+			Person: &search.Person{
+				Name:      "Dmitri Yuryevich KHOROSHEV",
+				BirthDate: &birthDate,
+			},
+		}
+
+		score, explain := search.ExplainSimilarity(query, indexEntity, search.SimilarityConfig{})
+		require.InDelta(t, search.Similarity(query, indexEntity), score, 0.001)
+		require.InDelta(t, score, explain.Score, 0.001)
+		require.Empty(t, explain.DecidedBy)
+
+		fields := make(map[string]search.FieldScore)
+		for _, f := range explain.Fields {
+			fields[f.Field] = f
+		}
+		require.True(t, fields["name"].Matched)
+		require.Greater(t, fields["name"].Weight, 0.0)
+		require.True(t, fields["dates"].Matched)
+		require.Equal(t, 0.0, fields["addresses"].Weight)
+
+		require.NotNil(t, explain.Name)
+		require.NotEmpty(t, explain.Name.Matched)
+		require.Len(t, explain.Name.Terms, 3)
+		require.Equal(t, "dmitri", explain.Name.Terms[0].Query)
+		require.Equal(t, "khoroshev", explain.Name.Terms[2].Indexed)
+		require.InDelta(t, 1.0, explain.Name.Terms[2].Score, 0.001)
+	})
+
+	t.Run("exact crypto address", func(t *testing.T) {
+		query := search.Entity[any]{
+			Type: search.EntityPerson,
+			CryptoAddresses: []search.CryptoAddress{
+				{Currency: "XBT", Address: "bc1qvhnfknw852ephxyc5hm4q520zmvf9maphetc9z"},
+			},
+		}
+
+		score, explain := search.ExplainSimilarity(query, indexEntity, search.SimilarityConfig{})
+		require.InDelta(t, 1.0, score, 0.001)
+		require.Equal(t, "cryptoAddresses", explain.DecidedBy)
+		require.Nil(t, explain.Name)
+	})
+}
@@ -0,0 +1,161 @@
+package search
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// NormalizeBIC returns an ISO 9362 business identifier code uppercased without spaces, or an error when it isn't
+// an institution code, country and location optionally followed by a branch code.
+func NormalizeBIC(bic string) (string, error) {
+	bic = strings.ToUpper(strings.Join(strings.Fields(bic), ""))
+	if len(bic) != 8 && len(bic) != 11 {
+		return "", fmt.Errorf("BIC %q must be 8 or 11 characters", bic)
+	}
+	for i, r := range bic {
+		letter := r >= 'A' && r <= 'Z'
+		if !letter && (i == 4 || i == 5) {
+			return "", fmt.Errorf("BIC %q doesn't have a country code", bic)
+		}
+		if !letter && (r < '0' || r > '9') {
+			return "", fmt.Errorf("BIC %q must be letters and digits", bic)
+		}
+	}
+	return bic, nil
+}
+
+// NormalizeLEI returns an ISO 17442 Legal Entity Identifier uppercased without spaces, or an error when it isn't
+// 20 letters and digits with valid check digits.
+func NormalizeLEI(lei string) (string, error) {
+	lei = strings.ToUpper(strings.Join(strings.Fields(lei), ""))
+	if len(lei) != 20 {
+		return "", fmt.Errorf("LEI %q must be 20 characters", lei)
+	}
+	// ISO 7064 MOD 97-10, with letters counting as 10 through 35
+	remainder := 0
+	for _, r := range lei {
+		switch {
+		case r >= '0' && r <= '9':
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			remainder = (remainder*100 + int(r-'A') + 10) % 97
+		default:
+			return "", fmt.Errorf("LEI %q must be letters and digits", lei)
+		}
+	}
+	if remainder != 1 {
+		return "", fmt.Errorf("LEI %q has invalid check digits", lei)
+	}
+	return lei, nil
+}
+
+// financialIDs are the valid BICs and LEIs of a business or organization, normalized
+type financialIDs struct {
+	bics []string
+	leis []string
+}
+
+func financialIDsOf[T any](entity Entity[T]) financialIDs {
+	var ids []GovernmentID
+	switch {
+	case entity.Business != nil:
+		ids = entity.Business.GovernmentIDs
+	case entity.Organization != nil:
+		ids = entity.Organization.GovernmentIDs
+	}
+
+	var out financialIDs
+	for _, id := range ids {
+		switch id.Type {
+		case GovernmentIDSWIFT:
+			if bic, err := NormalizeBIC(id.Identifier); err == nil {
+				out.bics = append(out.bics, bic)
+			}
+		case GovernmentIDLEI:
+			if lei, err := NormalizeLEI(id.Identifier); err == nil {
+				out.leis = append(out.leis, lei)
+			}
+		}
+	}
+	return out
+}
+
+const (
+	sameBranchScore  = 1.0
+	otherBranchScore = 0.95
+)
+
+// compare returns how well ids identify the same institution as other: one for the same LEI or BIC, whose
+// branch defaults to the head office's "XXX", and a little less for another branch of the same BIC.
+func (ids financialIDs) compare(other financialIDs) float64 {
+	for _, lei := range ids.leis {
+		if slices.Contains(other.leis, lei) {
+			return sameBranchScore
+		}
+	}
+	best := 0.0
+	for _, bic := range ids.bics {
+		for _, o := range other.bics {
+			if bic[:8] != o[:8] {
+				continue
+			}
+			if bicBranch(bic) == bicBranch(o) {
+				return sameBranchScore
+			}
+			best = otherBranchScore
+		}
+	}
+	return best
+}
+
+// comparable reports if ids and other both have BICs, or both have LEIs
+func (ids financialIDs) comparable(other financialIDs) bool {
+	return (len(ids.bics) > 0 && len(other.bics) > 0) || (len(ids.leis) > 0 && len(other.leis) > 0)
+}
+
+func bicBranch(bic string) string {
+	if len(bic) == 11 {
+		return bic[8:]
+	}
+	return "XXX"
+}
+
+// compareFinancialIDs matches financial institutions by their BICs and LEIs, which confirm the query and index
+// are the same institution however differently their names are written, such as "First National Bank".
+func compareFinancialIDs[Q any, I any](w io.Writer, query Entity[Q], index Entity[I], weight float64) scorePiece {
+	qIDs, iIDs := financialIDsOf(query), financialIDsOf(index)
+	if !qIDs.comparable(iIDs) {
+		return scorePiece{score: 0, weight: weight, fieldsCompared: 0, pieceType: "financial-ids-exact"}
+	}
+
+	score := qIDs.compare(iIDs)
+	if w != nil {
+		debug(w, "financial IDs: query=%v index=%v score=%.2f\n", qIDs, iIDs, score)
+	}
+	return scorePiece{
+		score:          score,
+		weight:         weight,
+		matched:        score > 0,
+		required:       false,
+		exact:          score >= sameBranchScore,
+		fieldsCompared: 1,
+		pieceType:      "financial-ids-exact",
+	}
+}
+
+// compareFinancialIDConflicts returns a conflict when the query and index have BICs or LEIs to compare, but none
+// of them are the same institution's
+func compareFinancialIDConflicts[Q any, I any](query Entity[Q], index Entity[I], factors ConflictFactors) []AttributeConflict {
+	qIDs, iIDs := financialIDsOf(query), financialIDsOf(index)
+	if !qIDs.comparable(iIDs) || qIDs.compare(iIDs) > 0 {
+		return nil
+	}
+	return []AttributeConflict{{
+		Field:   "financialID",
+		Query:   append(slices.Clone(qIDs.bics), qIDs.leis...),
+		Indexed: append(slices.Clone(iIDs.bics), iIDs.leis...),
+		Factor:  factors.FinancialID,
+	}}
+}
@@ -0,0 +1,92 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeBIC(t *testing.T) {
+	bic, err := NormalizeBIC(" deut de ff ")
+	require.NoError(t, err)
+	require.Equal(t, "DEUTDEFF", bic)
+
+	bic, err = NormalizeBIC("DEUTDEFF500")
+	require.NoError(t, err)
+	require.Equal(t, "DEUTDEFF500", bic)
+
+	_, err = NormalizeBIC("DEUTDEF")
+	require.ErrorContains(t, err, "must be 8 or 11 characters")
+
+	_, err = NormalizeBIC("DEUT12FF")
+	require.ErrorContains(t, err, "doesn't have a country code")
+
+	_, err = NormalizeBIC("DEUTDE-F")
+	require.ErrorContains(t, err, "must be letters and digits")
+}
+
+func TestNormalizeLEI(t *testing.T) {
+	lei, err := NormalizeLEI("7ltwfzyicnsx8d621k86")
+	require.NoError(t, err)
+	require.Equal(t, "7LTWFZYICNSX8D621K86", lei)
+
+	_, err = NormalizeLEI("7LTWFZYICNSX8D621K87")
+	require.ErrorContains(t, err, "invalid check digits")
+
+	_, err = NormalizeLEI("7LTWFZYICNSX8D621K8")
+	require.ErrorContains(t, err, "must be 20 characters")
+
+	_, err = NormalizeLEI("7LTWFZYICNSX8D621K8-")
+	require.ErrorContains(t, err, "must be letters and digits")
+}
+
+func TestSimilarity_FinancialIDs(t *testing.T) {
+	bank := func(name string, ids ...GovernmentID) Entity[any] {
+		return Entity[any]{
+			Name:     name,
+			Type:     EntityBusiness,
+			Business: &Business{Name: name, GovernmentIDs: ids},
+		}
+	}
+	bic := func(code string) GovernmentID {
+		return GovernmentID{Type: GovernmentIDSWIFT, Identifier: code}
+	}
+	lei := func(code string) GovernmentID {
+		return GovernmentID{Type: GovernmentIDLEI, Identifier: code}
+	}
+
+	query := bank("First National Bank", bic("FNBKUS33"))
+
+	// The same BIC confirms a weak name match, with or without the head office's branch code
+	indexed := bank("First Natl. Bank of Omaha", bic("FNBKUS33XXX"))
+	require.InDelta(t, 1.0, Similarity(query, indexed), 0.001)
+
+	score, explain := ExplainSimilarity(query, indexed, SimilarityConfig{})
+	require.InDelta(t, 1.0, score, 0.001)
+	require.Equal(t, "financialIDs", explain.DecidedBy)
+
+	// Another branch of the institution is still a match
+	require.InDelta(t, otherBranchScore, Similarity(query, bank("FNB", bic("FNBKUS33NYC"))), 0.001)
+
+	// Another institution's BIC demotes the same name
+	other := bank("First National Bank", bic("FNBAZAJJ"))
+	without := bank("First National Bank")
+	ignored := SimilarityWithConfig(query, other, SimilarityConfig{Conflicts: ConflictFactors{FinancialID: 1}})
+	require.InDelta(t, ignored*financialIDConflictFactor, Similarity(query, other), 0.001)
+	require.Less(t, Similarity(query, other), Similarity(query, without))
+
+	_, explain = ExplainSimilarity(query, other, SimilarityConfig{})
+	require.Len(t, explain.Conflicts, 1)
+	require.Equal(t, "financialID", explain.Conflicts[0].Field)
+	require.Equal(t, []string{"FNBAZAJJ"}, explain.Conflicts[0].Indexed)
+
+	// LEIs are compared the same way
+	query = bank("Deutsche Bank", lei("7LTWFZYICNSX8D621K86"))
+	require.InDelta(t, 1.0, Similarity(query, bank("DB AG", lei("7ltwfzyicnsx8d621k86"))), 0.001)
+
+	other = bank("Deutsche Bank", lei("529900T8BM49AURSDO55"))
+	require.Less(t, Similarity(query, other), Similarity(query, bank("Deutsche Bank")))
+
+	// A BIC isn't compared to an LEI
+	require.Empty(t, compareFinancialIDConflicts(query, bank("Deutsche Bank", bic("DEUTDEFF")), DefaultConflictFactors()))
+}
@@ -4,6 +4,7 @@ import (
 	"io"
 	"math"
 	"regexp"
+	"slices"
 	"strings"
 	"unicode"
 
@@ -21,6 +22,15 @@ const (
 	// Score thresholds for term matching
 	termMatchThreshold = 0.90 // Individual term match threshold
 	nameMatchThreshold = 0.85 // Overall name match threshold
+
+	// How far phonetically similar terms are moved towards a perfect score
+	phoneticMatchBoost = 0.5
+
+	// Penalty for matches found by replacing a nickname in the query with a formal name
+	nicknamePenalty = 0.95
+
+	// maxAltNameBoosts is how many names beyond the best match are counted by SimilarityConfig.AltNameBoost
+	maxAltNameBoosts = 3
 )
 
 // nameMatch tracks detailed matching information
@@ -30,9 +40,21 @@ type nameMatch struct {
 	totalTerms    int
 	isExact       bool
 	isHistorical  bool
+	isAlt         bool   // matched an alternate or former name
+	altName       string // the alternate or former name matched, when isAlt
+
+	// strongMatches counts the distinct names of the index which scored above nameMatchThreshold
+	strongMatches int
 }
 
 func compareName[Q any, I any](w io.Writer, query Entity[Q], index Entity[I], weight float64) scorePiece {
+	return compareWeightedName(w, query, index, Weights{Name: weight, AltName: weight}, SimilarityConfig{})
+}
+
+// compareWeightedName compares the names of query and index, which is weighted by weights.AltName instead of
+// weights.Name when the best match was an alternate or former name
+func compareWeightedName[Q any, I any](w io.Writer, query Entity[Q], index Entity[I], weights Weights, cfg SimilarityConfig) scorePiece {
+	weight := weights.Name
 	qName := normalizeName(query.Name)
 	iName := normalizeName(index.Name)
 
@@ -54,39 +76,17 @@ func compareName[Q any, I any](w io.Writer, query Entity[Q], index Entity[I], we
 		}
 	}
 
-	// Get query terms and filter out insignificant ones
-	qTerms := filterSignificantTerms(strings.Fields(qName))
+	bestMatch, qTerms := matchNames(cfg, query, qName, iName, index)
 	if len(qTerms) == 0 {
 		return scorePiece{score: 0, weight: 0, fieldsCompared: 0, pieceType: "name"}
 	}
 
-	// Check primary name
-	bestMatch := compareNameTerms(qTerms, iName)
-
-	// Check alternate names for persons
-	if query.Person != nil && index.Person != nil {
-		for _, altName := range index.Person.AltNames {
-			altMatch := compareNameTerms(qTerms, normalizeName(altName))
-			if altMatch.score > bestMatch.score {
-				bestMatch = altMatch
-			}
-		}
-	}
-
-	// Check historical names with penalty
-	for _, hist := range index.HistoricalInfo {
-		if strings.EqualFold(hist.Type, "Former Name") {
-			histMatch := compareNameTerms(qTerms, normalizeName(hist.Value))
-			histMatch.score *= 0.95 // Apply penalty for historical names
-			histMatch.isHistorical = true
-			if histMatch.score > bestMatch.score {
-				bestMatch = histMatch
-			}
-		}
-	}
-
 	// Apply additional criteria for match quality
 	finalScore := adjustScoreBasedOnQuality(bestMatch, len(qTerms))
+	if bestMatch.isAlt {
+		weight = weights.AltName
+	}
+	finalScore = boostAltNameMatches(finalScore, bestMatch.strongMatches, cfg.AltNameBoost)
 
 	return scorePiece{
 		score:          finalScore,
@@ -99,8 +99,201 @@ func compareName[Q any, I any](w io.Writer, query Entity[Q], index Entity[I], we
 	}
 }
 
+// matchNames finds the best match of the query's normalized name against the names of index, along with the
+// query terms which produced it. No terms are returned when the query's name has no significant terms.
+//
+// The query's name as it was written chooses how names of each script are compared, see queryName.
+func matchNames[Q any, I any](cfg SimilarityConfig, query Entity[Q], qName, iName string, index Entity[I]) (nameMatch, []string) {
+	scorer := termScorer(cfg, query, index)
+
+	// Get query terms and filter out insignificant ones
+	qFields := strings.Fields(qName)
+	qTerms := filterSignificantTerms(qFields)
+	if len(qTerms) == 0 {
+		return nameMatch{}, nil
+	}
+
+	q := newQueryName(query.Name, qTerms, cfg.weakTerms())
+	bestMatch := bestNameMatch(scorer, q, iName, index)
+
+	// Replace nicknames with formal names, so "bob smith" matches "robert smith"
+	for _, variant := range prepare.NicknameVariants(qFields) {
+		terms := filterSignificantTerms(variant)
+		if len(terms) == 0 {
+			continue
+		}
+		variantMatch := bestNameMatch(scorer, queryName{script: q.script, terms: terms, weak: q.weak}, iName, index)
+		variantMatch.score *= nicknamePenalty
+		if variantMatch.score > bestMatch.score {
+			bestMatch = variantMatch
+			qTerms = terms
+		}
+	}
+	return bestMatch, qTerms
+}
+
+// queryName is the query's name as it's compared against the names of each script
+type queryName struct {
+	script prepare.Script
+	terms  []string // romanized, for Latin names and names in scripts other than the query's, see prepare.Script.Comparable
+
+	// native terms are as written, for names in the query's own non-Latin script. Romanizing both names
+	// would lose the letters which are romanized alike, or the vowels guessed for Arabic.
+	native []string
+
+	weak *WeakTermSet
+}
+
+func newQueryName(name string, terms []string, weak *WeakTermSet) queryName {
+	out := queryName{
+		script: prepare.DetectScript(name),
+		terms:  terms,
+		weak:   weak,
+	}
+	if out.routed() {
+		out.native = filterSignificantTerms(strings.Fields(normalizeNativeName(name)))
+	}
+	return out
+}
+
+// routed reports if the query's non-Latin script chooses how each name is compared. Latin queries, and those
+// of unrecognized scripts, are compared with the romanized form of every name.
+func (q queryName) routed() bool {
+	return q.script != "" && q.script != prepare.ScriptLatin && q.script != prepare.ScriptOther
+}
+
+// compare scores the query against name, whose normalized (romanized) form is normalized. Names in a script whose
+// romanization is unrelated to the query's aren't compared.
+func (q queryName) compare(scorer NameScorer, name, normalized string) nameMatch {
+	if !q.routed() {
+		return compareNameTerms(scorer, q.weak, q.terms, normalized)
+	}
+	script := prepare.DetectScript(name)
+	if !q.script.Comparable(script) {
+		return nameMatch{}
+	}
+	if script == q.script && len(q.native) > 0 {
+		return compareNameTerms(scorer, q.weak, q.native, normalizeNativeName(name))
+	}
+	return compareNameTerms(scorer, q.weak, q.terms, normalized)
+}
+
+// bestNameMatch compares the query against the primary, alternate and historical names of index, counting
+// how many distinct names scored above nameMatchThreshold
+func bestNameMatch[I any](scorer NameScorer, query queryName, iName string, index Entity[I]) nameMatch {
+	// Check primary name
+	bestMatch := query.compare(scorer, index.Name, iName)
+
+	compared := []string{iName}
+	strong := 0
+	if bestMatch.score >= nameMatchThreshold {
+		strong++
+	}
+	compare := func(name string, penalty float64, historical bool) {
+		normalized := normalizeName(name)
+		if normalized == "" || slices.Contains(compared, normalized) {
+			return // repeats of a name aren't independent matches
+		}
+		compared = append(compared, normalized)
+
+		altMatch := query.compare(scorer, name, normalized)
+		altMatch.score *= penalty
+		altMatch.isHistorical = historical
+		altMatch.isAlt = true
+		altMatch.altName = name
+		if altMatch.score >= nameMatchThreshold {
+			strong++
+		}
+		if altMatch.score > bestMatch.score {
+			bestMatch = altMatch
+		}
+	}
+
+	// Check alternate names
+	for _, altName := range indexAltNames(index) {
+		compare(altName, 1.0, false)
+	}
+
+	// Check historical names with penalty
+	for _, hist := range index.HistoricalInfo {
+		if strings.EqualFold(hist.Type, "Former Name") {
+			compare(hist.Value, 0.95, true)
+		}
+	}
+
+	bestMatch.strongMatches = strong
+	return bestMatch
+}
+
+// boostAltNameMatches moves score towards a perfect match by boost for each name beyond the first which
+// matched the query well, up to maxAltNameBoosts of them
+func boostAltNameMatches(score float64, strongMatches int, boost float64) float64 {
+	extra := strongMatches - 1
+	if boost <= 0 || extra <= 0 {
+		return score
+	}
+	if extra > maxAltNameBoosts {
+		extra = maxAltNameBoosts
+	}
+	return score + (1-score)*(1-math.Pow(1-boost, float64(extra)))
+}
+
+// MatchAltName returns the alternate or former name of index which best matched the query's name, or an
+// empty string when its primary name matched best.
+func MatchAltName[Q any, I any](query Entity[Q], index Entity[I], cfg SimilarityConfig) string {
+	qName := normalizeName(query.Name)
+	iName := normalizeName(index.Name)
+	if qName == "" || qName == iName {
+		return ""
+	}
+	bestMatch, _ := matchNames(cfg, query, qName, iName, index)
+	if !bestMatch.isAlt || bestMatch.score <= 0 {
+		return ""
+	}
+	return bestMatch.altName
+}
+
+// MatchWeakAltName returns the weak alias of index which the query's name matched, or an empty string when none did.
+// Weak aliases aren't compared by Similarity, so this reports matches against them separately.
+func MatchWeakAltName[Q any, I any](query Entity[Q], index Entity[I]) string {
+	qTerms := filterSignificantTerms(strings.Fields(normalizeName(query.Name)))
+	if len(qTerms) == 0 {
+		return ""
+	}
+
+	var best string
+	var bestScore float64
+	for _, alt := range index.WeakAltNames {
+		match := compareNameTerms(defaultNameScorer, defaultWeakTerms, qTerms, normalizeName(alt))
+		if match.score >= nameMatchThreshold && match.score > bestScore {
+			best, bestScore = alt, match.score
+		}
+	}
+	return best
+}
+
+// NormalizeName returns a name as it's compared with other names: romanized, lowercased and without punctuation
+func NormalizeName(name string) string {
+	return normalizeName(name)
+}
+
 // normalizeName performs thorough name normalization
 func normalizeName(name string) string {
+	// Romanize non-Latin names so they can match the Latin-script names of other lists
+	return normalizeNativeName(prepare.Transliterate(name))
+}
+
+// NameScript returns the script a name is written in (cyrillic, arabic, han, hangul or other), which is empty
+// for names written in Latin letters
+func NameScript(name string) string {
+	if script := prepare.DetectScript(name); script != prepare.ScriptLatin {
+		return string(script)
+	}
+	return ""
+}
+
+// normalizeNativeName normalizes a name like normalizeName, but leaves it in the script it's written in
+func normalizeNativeName(name string) string {
 	// Convert to lowercase and trim spaces
 	name = strings.ToLower(strings.TrimSpace(name))
 
@@ -134,7 +327,8 @@ func normalizeName(name string) string {
 	return strings.TrimSpace(normalized.String())
 }
 
-// filterSignificantTerms removes common noise and insignificant terms
+// filterSignificantTerms removes terms too short to compare. Common words are kept, but count for less when
+// they're compared, see WeakTerms.
 func filterSignificantTerms(terms []string) []string {
 	filtered := make([]string, 0, len(terms))
 	for _, term := range terms {
@@ -142,59 +336,45 @@ func filterSignificantTerms(terms []string) []string {
 		if len(term) < minTermLength {
 			continue
 		}
-
-		// Skip common noise terms (expand this list as needed)
-		term = strings.TrimSpace(strings.ToLower(term))
-
-		switch term {
-		case "the", "and", "or", "of", "in", "at", "by":
-			continue
-		}
-
-		filtered = append(filtered, term)
+		filtered = append(filtered, strings.TrimSpace(strings.ToLower(term)))
 	}
 	return filtered
 }
 
-// compareNameTerms performs detailed term-by-term comparison
-func compareNameTerms(queryTerms []string, indexName string) nameMatch {
+// isNoiseTerm returns true for common words which aren't highlighted on their own (expand this list as needed)
+func isNoiseTerm(term string) bool {
+	switch term {
+	case "the", "and", "or", "of", "in", "at", "by":
+		return true
+	}
+	return false
+}
+
+// compareNameTerms performs detailed term-by-term comparison, with weak terms counting for less
+func compareNameTerms(scorer NameScorer, weak *WeakTermSet, queryTerms []string, indexName string) nameMatch {
 	indexTerms := filterSignificantTerms(strings.Fields(indexName))
 	if len(indexTerms) == 0 {
 		return nameMatch{score: 0}
 	}
 
-	// Track individual term matches
-	termScores := make([]float64, len(queryTerms))
-	matchingTerms := 0
-
-	// For each query term, find its best match in index terms
-	for i, qTerm := range queryTerms {
-		bestTermScore := 0.0
-		for _, iTerm := range indexTerms {
-			score := stringscore.JaroWinkler(qTerm, iTerm)
-			if score > bestTermScore {
-				bestTermScore = score
-				if score > termMatchThreshold {
-					matchingTerms++
-				}
-			}
-		}
-		termScores[i] = bestTermScore
-	}
-
-	// Calculate overall score
-	var totalScore float64
-	for _, score := range termScores {
-		totalScore += score
-	}
-	avgScore := totalScore / float64(len(termScores))
+	score, matchingTerms := weak.scoreTerms(scorer, queryTerms, indexTerms)
 
 	return nameMatch{
-		score:         avgScore,
+		score:         score,
 		matchingTerms: matchingTerms,
 		totalTerms:    len(queryTerms),
-		isExact:       avgScore > exactMatchThreshold,
+		isExact:       score > exactMatchThreshold,
+	}
+}
+
+// compareTerm scores two name terms with Jaro-Winkler. When soundAlike is set, terms which sound alike
+// (e.g. "mohammed" and "muhamad") are boosted halfway from their Jaro-Winkler score towards a perfect match.
+func compareTerm(queryTerm, indexTerm string, soundAlike bool) float64 {
+	score := stringscore.JaroWinkler(queryTerm, indexTerm)
+	if soundAlike && score < 1.0 {
+		score += (1.0 - score) * phoneticMatchBoost
 	}
+	return score
 }
 
 // adjustScoreBasedOnQuality applies additional quality criteria
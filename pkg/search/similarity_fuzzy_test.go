@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/moov-io/watchman/internal/stringscore"
+
 	"github.com/stretchr/testify/assert"
 )
 
@@ -86,8 +88,9 @@ func TestCompareName(t *testing.T) {
 			index: Entity[any]{
 				Name: "AEROCARIBBEAN AIRLINES",
 			},
+			// "the" and "company" are weak terms, which count for little
 			expectedScore: 0.85,
-			shouldMatch:   false,
+			shouldMatch:   true,
 			exact:         false,
 		},
 		{
@@ -130,15 +133,15 @@ func TestCompareName(t *testing.T) {
 			exact:         true,
 		},
 		{
-			name: "minimum term matches",
+			name: "only weak terms match",
 			query: Entity[any]{
 				Name: "CARIBBEAN TRADING LIMITED",
 			},
 			index: Entity[any]{
 				Name: "PACIFIC TRADING LIMITED",
 			},
-			expectedScore: 0.8628,
-			shouldMatch:   true,
+			expectedScore: 0.6508,
+			shouldMatch:   false,
 			exact:         false,
 		},
 		{
@@ -169,6 +172,21 @@ func TestCompareName(t *testing.T) {
 	}
 }
 
+func TestCompareName_Phonetic(t *testing.T) {
+	query := Entity[any]{Name: "Mohammed Ali Hassan"}
+	index := Entity[any]{Name: "Muhamad Ali Hasan"}
+
+	without := compareName(nil, query, index, nameWeight)
+	with := compareWeightedName(nil, query, index, Weights{Name: nameWeight, AltName: nameWeight}, SimilarityConfig{Phonetic: true})
+
+	assert.Greater(t, with.score, without.score)
+	assert.True(t, with.matched)
+
+	// Terms which don't sound alike aren't boosted
+	scorer := phoneticScorer(true, jaroWinklerScorer{}, Entity[any]{Name: "Robert"}, Entity[any]{Name: "Rubin"})
+	assert.InDelta(t, stringscore.JaroWinkler("robert", "rubin"), scorer.ScoreTerm("robert", "rubin"), 0.001)
+}
+
 func TestCompareEntityTitlesFuzzy(t *testing.T) {
 	var buf bytes.Buffer
 
@@ -418,6 +436,142 @@ func TestCompareAffiliationsFuzzy(t *testing.T) {
 
 // Helper function tests
 
+func TestCompareName_Nicknames(t *testing.T) {
+	index := Entity[any]{Name: "Robert SMITH"}
+
+	nickname := compareName(nil, Entity[any]{Name: "Bob Smith"}, index, nameWeight)
+	assert.True(t, nickname.matched)
+	assert.Greater(t, nickname.score, 0.9)
+
+	// An unrelated first name still scores lower
+	other := compareName(nil, Entity[any]{Name: "Bill Smith"}, index, nameWeight)
+	assert.Greater(t, nickname.score, other.score)
+
+	// The exact formal name is preferred over the nickname
+	formal := compareName(nil, Entity[any]{Name: "Robert Smith"}, index, nameWeight)
+	assert.Greater(t, formal.score, nickname.score)
+}
+
+func TestCompareName_AltNames(t *testing.T) {
+	// Aliases of businesses are compared as well as those of people
+	index := Entity[any]{
+		Name:     "Islamic Revolutionary Guard Corps",
+		Type:     EntityBusiness,
+		Business: &Business{Name: "Islamic Revolutionary Guard Corps", AltNames: []string{"IRGC", "Sepah Pasdaran"}},
+	}
+	query := Entity[any]{Name: "Sepah Pasdaran", Type: EntityBusiness}
+
+	alias := compareName(nil, query, index, nameWeight)
+	assert.True(t, alias.matched)
+	assert.Greater(t, alias.score, 0.95)
+	assert.Equal(t, "Sepah Pasdaran", MatchAltName(query, index, SimilarityConfig{}))
+
+	// Nothing is reported when the primary name matched best
+	query.Name = "Islamic Revolutionary Guard Corps"
+	assert.Empty(t, MatchAltName(query, index, SimilarityConfig{}))
+}
+
+func TestCompareName_AltNameBoost(t *testing.T) {
+	query := Entity[any]{Name: "Ali Hassan", Type: EntityPerson}
+	one := Entity[any]{
+		Name:   "Ali Hasan",
+		Type:   EntityPerson,
+		Person: &Person{Name: "Ali Hasan"},
+	}
+	several := Entity[any]{
+		Name:   "Ali Hasan",
+		Type:   EntityPerson,
+		Person: &Person{Name: "Ali Hasan", AltNames: []string{"Aly Hassan", "Ali Hassen", "ALI HASAN"}},
+	}
+
+	cfg := SimilarityConfig{AltNameBoost: 0.2}
+	single := compareWeightedName(nil, query, one, Weights{}.Or(DefaultWeights()), cfg)
+	boosted := compareWeightedName(nil, query, several, Weights{}.Or(DefaultWeights()), cfg)
+	assert.Greater(t, boosted.score, single.score)
+	assert.LessOrEqual(t, boosted.score, 1.0)
+
+	// Without a boost the number of matching aliases doesn't matter
+	unboosted := compareWeightedName(nil, query, several, Weights{}.Or(DefaultWeights()), SimilarityConfig{})
+	assert.InDelta(t, boosted.score, unboosted.score+(1-unboosted.score)*(1-0.8*0.8), 0.0001)
+}
+
+func TestBoostAltNameMatches(t *testing.T) {
+	assert.Equal(t, 0.8, boostAltNameMatches(0.8, 1, 0.5))
+	assert.Equal(t, 0.8, boostAltNameMatches(0.8, 4, 0))
+	assert.InDelta(t, 0.9, boostAltNameMatches(0.8, 2, 0.5), 0.0001)
+
+	// Only maxAltNameBoosts names are counted
+	assert.Equal(t, boostAltNameMatches(0.8, 1+maxAltNameBoosts, 0.5), boostAltNameMatches(0.8, 10, 0.5))
+}
+
+func TestCompareName_Transliterated(t *testing.T) {
+	index := Entity[any]{Name: "Dmitry Yuryevich KHOROSHEV"}
+
+	cyrillic := compareName(nil, Entity[any]{Name: "Дмитрий Юрьевич Хорошев"}, index, nameWeight)
+	assert.True(t, cyrillic.matched)
+	assert.Greater(t, cyrillic.score, 0.85)
+
+	// and the other way around
+	latin := compareName(nil, Entity[any]{Name: "Vladimir Putin"}, Entity[any]{Name: "Владимир Путин"}, nameWeight)
+	assert.True(t, latin.matched)
+	assert.Greater(t, latin.score, 0.95)
+}
+
+func TestCompareName_Scripts(t *testing.T) {
+	// Names of different scripts are compared once they're romanized
+	arabic := Entity[any]{Name: "محمد علي"}
+	bridged := compareName(nil, arabic, Entity[any]{Name: "Мухаммад Али"}, nameWeight)
+	assert.True(t, bridged.matched)
+	assert.Greater(t, bridged.score, 0.95)
+
+	// but not Han characters and Hangul, whose romanizations are unrelated
+	han := Entity[any]{Name: "习近平"}
+	unrelated := compareName(nil, han, Entity[any]{Name: "김정은"}, nameWeight)
+	assert.Zero(t, unrelated.score)
+
+	// Latin aliases are still compared
+	index := Entity[any]{
+		Name:   "김정은",
+		Type:   EntityPerson,
+		Person: &Person{Name: "김정은", AltNames: []string{"Xi Jinping", "XI Jin Ping"}},
+	}
+	latin := compareName(nil, han, index, nameWeight)
+	assert.True(t, latin.matched)
+	assert.Equal(t, "XI Jin Ping", MatchAltName(han, index, SimilarityConfig{}))
+
+	// Names of the query's own script are compared as written
+	cyrillic := compareName(nil, Entity[any]{Name: "Владимир Путин"}, Entity[any]{Name: "ВЛАДИМИР ПУТИН"}, nameWeight)
+	assert.Equal(t, 1.0, cyrillic.score)
+}
+
+func TestNameScript(t *testing.T) {
+	assert.Equal(t, "", NameScript("Vladimir Putin"))
+	assert.Equal(t, "cyrillic", NameScript("Владимир Путин"))
+	assert.Equal(t, "han", NameScript("习近平"))
+}
+
+func TestMatchWeakAltName(t *testing.T) {
+	index := Entity[any]{
+		Name:         "Dmitry Yuryevich KHOROSHEV",
+		Type:         EntityPerson,
+		Person:       &Person{Name: "Dmitry Yuryevich KHOROSHEV"},
+		WeakAltNames: []string{"LOCKBITSUPP"},
+	}
+	query := Entity[any]{
+		Name:   "LockBitSupp",
+		Type:   EntityPerson,
+		Person: &Person{Name: "LockBitSupp"},
+	}
+
+	// Weak aliases aren't scored, but the match is reported
+	name := compareName(nil, query, index, nameWeight)
+	assert.False(t, name.matched)
+	assert.Equal(t, "LOCKBITSUPP", MatchWeakAltName(query, index))
+
+	query.Name = "Dmitry Khoroshev"
+	assert.Empty(t, MatchWeakAltName(query, index))
+}
+
 func TestNormalizeName(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -454,6 +608,11 @@ func TestNormalizeName(t *testing.T) {
 			input:    ".,!@#$%^&*()",
 			expected: "",
 		},
+		{
+			name:     "cyrillic",
+			input:    "Дмитрий Хорошев",
+			expected: "dmitriy khoroshev",
+		},
 	}
 
 	for _, tt := range tests {
@@ -641,9 +800,9 @@ func TestFilterSignificantTerms(t *testing.T) {
 			expected: []string{"banco", "nacional", "cuba"},
 		},
 		{
-			name:     "with noise terms",
+			name:     "with weak terms",
 			input:    []string{"the", "banco", "of", "nacional", "and", "cuba"},
-			expected: []string{"banco", "nacional", "cuba"},
+			expected: []string{"the", "banco", "nacional", "and", "cuba"},
 		},
 		{
 			name:     "with short terms",
@@ -651,9 +810,9 @@ func TestFilterSignificantTerms(t *testing.T) {
 			expected: []string{"banco", "nacional"},
 		},
 		{
-			name:     "only noise terms",
+			name:     "only weak terms",
 			input:    []string{"the", "of", "and", "in", "at"},
-			expected: []string{},
+			expected: []string{"the", "and"},
 		},
 		{
 			name:     "empty input",
@@ -663,7 +822,7 @@ func TestFilterSignificantTerms(t *testing.T) {
 		{
 			name:     "mixed case terms",
 			input:    []string{"THE", "Banco", "OF", "Nacional"},
-			expected: []string{"banco", "nacional"},
+			expected: []string{"the", "banco", "nacional"},
 		},
 	}
 
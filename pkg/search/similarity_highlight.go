@@ -0,0 +1,134 @@
+package search
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NameHighlight marks the words of one of an entity's names which matched a query's name, so they can be
+// highlighted when the result is reviewed
+type NameHighlight struct {
+	// Name is written as the entity lists it
+	Name string `json:"name"`
+
+	// Kind is "primary" or "alt"
+	Kind string `json:"kind"`
+
+	Spans []HighlightSpan `json:"spans"`
+}
+
+// HighlightSpan is a word of a name which matched a term of the query. Start and End count characters
+// (Unicode code points) from the start of the name, with End excluded.
+type HighlightSpan struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Text  string `json:"text"`
+
+	// Query is the term of the query's name the word matched, after normalizing it
+	Query string  `json:"query"`
+	Score float64 `json:"score"`
+}
+
+// highlightThreshold is how closely a word has to match a query term to be highlighted
+const highlightThreshold = nameMatchThreshold
+
+// HighlightNames returns the words of index's name and alternate names which matched the query's name, for
+// each name with at least one match. Words are compared with the name scorer of cfg, so spelling variations
+// are highlighted as they were scored.
+func HighlightNames[Q any, I any](query Entity[Q], index Entity[I], cfg SimilarityConfig) []NameHighlight {
+	var qTerms []string
+	for _, term := range strings.Fields(normalizeName(query.Name)) {
+		if !isNoiseTerm(term) {
+			qTerms = append(qTerms, term)
+		}
+	}
+	if len(qTerms) == 0 {
+		return nil
+	}
+	scorer := withTypoTolerance(cfg.nameScorer(), cfg.TypoTolerance)
+
+	var out []NameHighlight
+	add := func(kind, name string) {
+		if spans := highlightName(scorer, qTerms, name); len(spans) > 0 {
+			out = append(out, NameHighlight{Name: name, Kind: kind, Spans: spans})
+		}
+	}
+	add("primary", index.Name)
+	for _, name := range indexAltNames(index) {
+		add("alt", name)
+	}
+	return out
+}
+
+func highlightName(scorer NameScorer, qTerms []string, name string) []HighlightSpan {
+	var out []HighlightSpan
+	for _, word := range nameWordSpans(name) {
+		span := HighlightSpan{Start: word.start, End: word.end, Text: word.text}
+		for _, term := range strings.Fields(normalizeName(word.text)) {
+			if isNoiseTerm(term) {
+				continue
+			}
+			for _, qTerm := range qTerms {
+				score := 0.0
+				switch {
+				case term == qTerm:
+					score = 1.0
+				case len(term) >= minTermLength && len(qTerm) >= minTermLength:
+					score = scorer.ScoreTerm(qTerm, term)
+				}
+				if score > span.Score {
+					span.Query, span.Score = qTerm, score
+				}
+			}
+		}
+		if span.Score >= highlightThreshold {
+			out = append(out, span)
+		}
+	}
+	return out
+}
+
+type wordSpan struct {
+	start, end int
+	text       string
+}
+
+// nameWordSpans splits name into its words, which are runs of letters, marks and digits
+func nameWordSpans(name string) []wordSpan {
+	var out []wordSpan
+	runes := []rune(name)
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			i++
+			continue
+		}
+		end := i
+		for end < len(runes) && isWordRune(runes[end]) {
+			end++
+		}
+		out = append(out, wordSpan{start: i, end: end, text: string(runes[i:end])})
+		i = end
+	}
+	return out
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsMark(r) || unicode.IsDigit(r)
+}
+
+// indexAltNames returns the alternate names of an entity's type
+func indexAltNames[I any](index Entity[I]) []string {
+	switch {
+	case index.Person != nil:
+		return index.Person.AltNames
+	case index.Business != nil:
+		return index.Business.AltNames
+	case index.Organization != nil:
+		return index.Organization.AltNames
+	case index.Aircraft != nil:
+		return index.Aircraft.AltNames
+	case index.Vessel != nil:
+		return index.Vessel.AltNames
+	}
+	return nil
+}
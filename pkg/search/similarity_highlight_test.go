@@ -0,0 +1,48 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlightNames(t *testing.T) {
+	query := Entity[any]{Name: "Nicolas Madur0 Moros"}
+	index := Entity[any]{
+		Name: "MADURO MOROS, Nicolás",
+		Type: EntityPerson,
+		Person: &Person{
+			Name:     "MADURO MOROS, Nicolás",
+			AltNames: []string{"Nicolas MADURO", "El Presidente"},
+		},
+	}
+
+	highlights := HighlightNames(query, index, SimilarityConfig{})
+	require.Len(t, highlights, 2)
+
+	primary := highlights[0]
+	require.Equal(t, "primary", primary.Kind)
+	require.Len(t, primary.Spans, 3)
+	require.Equal(t, HighlightSpan{Start: 0, End: 6, Text: "MADURO", Query: primary.Spans[0].Query, Score: primary.Spans[0].Score}, primary.Spans[0])
+	require.Equal(t, "moros", primary.Spans[1].Query)
+	require.InDelta(t, 1.0, primary.Spans[1].Score, 0.001)
+
+	// Offsets count characters, so the accented name's span ends at its last letter
+	nicolas := primary.Spans[2]
+	require.Equal(t, "Nicolás", nicolas.Text)
+	require.Equal(t, 14, nicolas.Start)
+	require.Equal(t, 21, nicolas.End)
+	require.Equal(t, "Nicolás", string([]rune(index.Name)[nicolas.Start:nicolas.End]))
+
+	require.Equal(t, "alt", highlights[1].Kind)
+	require.Equal(t, "Nicolas MADURO", highlights[1].Name)
+	require.Len(t, highlights[1].Spans, 2)
+
+	// Short words are only highlighted when they're the same
+	highlights = HighlightNames(Entity[any]{Name: "Kim Jong Il"}, Entity[any]{Name: "KIM Jong Un"}, SimilarityConfig{})
+	require.Len(t, highlights, 1)
+	require.Len(t, highlights[0].Spans, 2)
+
+	require.Empty(t, HighlightNames(Entity[any]{Name: "the"}, index, SimilarityConfig{}))
+	require.Empty(t, HighlightNames(Entity[any]{Name: "Acme Shipping"}, index, SimilarityConfig{}))
+}
@@ -0,0 +1,81 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/internal/stringscore"
+)
+
+// NameTerms describes how a query's name is compared against the names of indexed entities, which helps tune
+// the preparation of names without searching
+type NameTerms struct {
+	// Normalized is the name lowercased, without punctuation and romanized
+	Normalized string `json:"normalized"`
+
+	// Script is the script the name is written in, which is empty for Latin names, see NameScript
+	Script string `json:"script,omitempty"`
+
+	// Terms are compared against the terms of each indexed name
+	Terms []NameTerm `json:"terms"`
+
+	// Native terms are compared as written against names in the same non-Latin script as the query
+	Native []string `json:"native,omitempty"`
+
+	// Dropped terms are too short to be compared
+	Dropped []string `json:"dropped,omitempty"`
+
+	// Nicknames are the terms with nicknames replaced by formal names, which are compared as well
+	Nicknames [][]string `json:"nicknames,omitempty"`
+}
+
+// NameTerm is one term of a name as it's compared
+type NameTerm struct {
+	Term string `json:"term"`
+
+	// Soundex is the term's phonetic code, which lets terms spelled differently still match
+	Soundex string `json:"soundex,omitempty"`
+
+	// Weak terms count for Weight of an ordinary term, see WeakTerms
+	Weak   bool    `json:"weak,omitempty"`
+	Weight float64 `json:"weight"`
+}
+
+// ExplainNameTerms splits name into the terms it's compared with, as a search with cfg would
+func ExplainNameTerms(name string, cfg SimilarityConfig) NameTerms {
+	weak := cfg.weakTerms()
+
+	normalized := normalizeName(name)
+	fields := strings.Fields(normalized)
+	terms := filterSignificantTerms(fields)
+	query := newQueryName(name, terms, weak)
+
+	out := NameTerms{
+		Normalized: normalized,
+		Script:     NameScript(name),
+		Native:     query.native,
+	}
+	for _, term := range terms {
+		t := NameTerm{
+			Term:    term,
+			Soundex: stringscore.Soundex(term),
+			Weak:    weak.weak(term),
+			Weight:  1,
+		}
+		if t.Weak {
+			t.Weight = weak.Weight()
+		}
+		out.Terms = append(out.Terms, t)
+	}
+	for _, field := range fields {
+		if len(field) < minTermLength {
+			out.Dropped = append(out.Dropped, field)
+		}
+	}
+	for _, variant := range prepare.NicknameVariants(fields) {
+		if terms := filterSignificantTerms(variant); len(terms) > 0 {
+			out.Nicknames = append(out.Nicknames, terms)
+		}
+	}
+	return out
+}
@@ -0,0 +1,26 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainNameTerms(t *testing.T) {
+	got := ExplainNameTerms("Владимир ПУТИН", SimilarityConfig{})
+	require.Equal(t, "cyrillic", got.Script)
+	require.Equal(t, []string{"владимир", "путин"}, got.Native)
+	require.Equal(t, got.Normalized, got.Terms[0].Term+" "+got.Terms[1].Term)
+	require.NotEmpty(t, got.Terms[0].Soundex)
+
+	weak, err := NewWeakTermSet(WeakTerms{Terms: []string{"bank"}, Weight: 0.5})
+	require.NoError(t, err)
+
+	got = ExplainNameTerms("Bank of Tehran", SimilarityConfig{WeakTerms: weak})
+	require.Empty(t, got.Script)
+	require.Equal(t, []NameTerm{
+		{Term: "bank", Soundex: "B520", Weak: true, Weight: 0.5},
+		{Term: "tehran", Soundex: "T650", Weight: 1},
+	}, got.Terms)
+	require.Equal(t, []string{"of"}, got.Dropped)
+}
@@ -45,6 +45,7 @@ func TestSimilarity_OFAC_SDN_Person(t *testing.T) {
 				Contact: search.ContactInfo{
 					EmailAddresses: []string{"khoroshev1@icloud.com"},
 				},
+				Addresses: []search.Address{{Country: "Russia"}},
 			},
 			expected: 1.00,
 		},
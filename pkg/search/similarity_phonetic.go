@@ -0,0 +1,95 @@
+package search
+
+import (
+	"strings"
+
+	"github.com/moov-io/watchman/internal/prepare"
+	"github.com/moov-io/watchman/internal/stringscore"
+)
+
+// WithPhoneticCodes returns entity with the Soundex code of each term of its names in PhoneticCodes, which are
+// compared instead of computing the codes again for every comparison when SimilarityConfig.Phonetic is set.
+// Indexes compute them once, as entities are indexed.
+func WithPhoneticCodes[T any](entity Entity[T]) Entity[T] {
+	entity.PhoneticCodes = phoneticCodesOf(entity)
+	return entity
+}
+
+// phoneticCodesOf returns the code of each term of the entity's names, and of the formal names of its nicknames
+func phoneticCodesOf[T any](entity Entity[T]) map[string]string {
+	codes := make(map[string]string)
+	addTerms := func(terms []string) {
+		for _, term := range filterSignificantTerms(terms) {
+			if _, exists := codes[term]; exists {
+				continue
+			}
+			if code := stringscore.Soundex(term); code != "" {
+				codes[term] = code
+			}
+		}
+	}
+	add := func(name string) {
+		fields := strings.Fields(normalizeName(name))
+		addTerms(fields)
+		for _, variant := range prepare.NicknameVariants(fields) {
+			addTerms(variant)
+		}
+	}
+
+	add(entity.Name)
+	for _, altName := range indexAltNames(entity) {
+		add(altName)
+	}
+	for _, hist := range entity.HistoricalInfo {
+		if strings.EqualFold(hist.Type, "Former Name") {
+			add(hist.Value)
+		}
+	}
+	return codes
+}
+
+// phoneticCodes returns the stored codes of an entity, or computes them for entities which weren't indexed
+func phoneticCodes[T any](entity Entity[T]) map[string]string {
+	if entity.PhoneticCodes != nil {
+		return entity.PhoneticCodes
+	}
+	return phoneticCodesOf(entity)
+}
+
+// phoneticScorer returns scorer boosting the terms of query and index which sound alike when enabled is set.
+// Only Jaro-Winkler and hybrid scoring boost them, other scorers compare how terms are spelled.
+func phoneticScorer[Q any, I any](enabled bool, scorer NameScorer, query Entity[Q], index Entity[I]) NameScorer {
+	if !enabled {
+		return scorer
+	}
+	switch scorer.(type) {
+	case jaroWinklerScorer:
+		return soundsAlikeScorer{query: phoneticCodes(query), index: phoneticCodes(index)}
+	case hybridScorer:
+		return soundsAlikeScorer{hybrid: true, query: phoneticCodes(query), index: phoneticCodes(index)}
+	}
+	return scorer
+}
+
+// soundsAlikeScorer is jaroWinklerScorer, or hybridScorer, with the phonetic codes of the terms it compares
+type soundsAlikeScorer struct {
+	hybrid bool
+
+	query, index map[string]string // codes by term
+}
+
+func (s soundsAlikeScorer) soundsAlike(queryTerm, indexTerm string) bool {
+	code := s.query[queryTerm]
+	return code != "" && code == s.index[indexTerm]
+}
+
+func (s soundsAlikeScorer) ScoreTerm(queryTerm, indexTerm string) float64 {
+	if s.hybrid {
+		return hybridTermScore(queryTerm, indexTerm, s.soundsAlike(queryTerm, indexTerm))
+	}
+	return compareTerm(queryTerm, indexTerm, s.soundsAlike(queryTerm, indexTerm))
+}
+
+func (s soundsAlikeScorer) ScoreTerms(queryTerms, indexTerms []string) (float64, int) {
+	return bestTermScores(queryTerms, indexTerms, s.ScoreTerm)
+}
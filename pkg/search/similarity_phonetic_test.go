@@ -0,0 +1,50 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithPhoneticCodes(t *testing.T) {
+	entity := Entity[any]{
+		Name: "Mohammed Ali",
+		Type: EntityPerson,
+		Person: &Person{
+			Name:     "Mohammed Ali",
+			AltNames: []string{"Bob Hasan"},
+		},
+		HistoricalInfo: []HistoricalInfo{{Type: "Former Name", Value: "Muhamad Hasan"}},
+	}
+	require.Nil(t, entity.PhoneticCodes)
+	require.Equal(t, map[string]string{
+		"mohammed": "M530",
+		"ali":      "A400",
+		"bob":      "B100",
+		"robert":   "R163", // the formal name of bob
+		"hasan":    "H250",
+		"muhamad":  "M530",
+	}, WithPhoneticCodes(entity).PhoneticCodes)
+}
+
+func TestPhoneticScorer(t *testing.T) {
+	query := WithPhoneticCodes(Entity[any]{Name: "Mohammed"})
+	index := WithPhoneticCodes(Entity[any]{Name: "Muhamad"})
+
+	scorer := phoneticScorer(true, jaroWinklerScorer{}, query, index)
+	require.Greater(t, scorer.ScoreTerm("mohammed", "muhamad"), jaroWinklerScorer{}.ScoreTerm("mohammed", "muhamad"))
+
+	hybrid := phoneticScorer(true, hybridScorer{}, query, index)
+	require.Greater(t, hybrid.ScoreTerm("mohammed", "muhamad"), hybridScorer{}.ScoreTerm("mohammed", "muhamad"))
+
+	// The stored codes are compared, rather than computed for each pair of terms
+	index.PhoneticCodes = map[string]string{"muhamad": "X000"}
+	scorer = phoneticScorer(true, jaroWinklerScorer{}, query, index)
+	require.Equal(t, jaroWinklerScorer{}.ScoreTerm("mohammed", "muhamad"), scorer.ScoreTerm("mohammed", "muhamad"))
+
+	// Other scorers compare how terms are spelled
+	require.Equal(t, levenshteinScorer{}, phoneticScorer(true, levenshteinScorer{}, query, index))
+
+	// Nothing is boosted unless phonetic matching is enabled
+	require.Equal(t, jaroWinklerScorer{}, phoneticScorer(false, jaroWinklerScorer{}, query, index))
+}
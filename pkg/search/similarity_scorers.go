@@ -0,0 +1,251 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// NameScorer compares the terms of a query name against the terms of an indexed name.
+//
+// Terms given to a NameScorer have been normalized and had insignificant terms removed.
+type NameScorer interface {
+	// ScoreTerm compares one query term against one indexed term, returning a score between 0 and 1.
+	ScoreTerm(queryTerm, indexTerm string) float64
+
+	// ScoreTerms compares every query term against the indexed terms, returning a score between 0 and 1
+	// along with how many query terms closely matched an indexed term.
+	ScoreTerms(queryTerms, indexTerms []string) (float64, int)
+}
+
+const (
+	AlgorithmJaroWinkler = "jaro-winkler"
+	AlgorithmLevenshtein = "levenshtein"
+	AlgorithmTokenSet    = "token-set"
+	AlgorithmTokenSort   = "token-sort"
+	AlgorithmHybrid      = "hybrid"
+)
+
+var (
+	nameScorers = map[string]NameScorer{
+		AlgorithmJaroWinkler: jaroWinklerScorer{},
+		AlgorithmLevenshtein: levenshteinScorer{},
+		AlgorithmTokenSet:    tokenSetScorer{},
+		AlgorithmTokenSort:   tokenSortScorer{},
+		AlgorithmHybrid:      hybridScorer{},
+	}
+
+	// defaultNameScorer is used when a request doesn't choose an algorithm
+	defaultNameScorer NameScorer = jaroWinklerScorer{}
+)
+
+// Algorithms returns the names of each NameScorer that can be found with NameScorerFor
+func Algorithms() []string {
+	var out []string
+	for name := range nameScorers {
+		out = append(out, name)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// NameScorerFor returns the NameScorer of a given algorithm. Jaro-Winkler is returned for an empty name.
+func NameScorerFor(algorithm string) (NameScorer, error) {
+	algorithm = strings.ToLower(strings.TrimSpace(algorithm))
+	if algorithm == "" {
+		return defaultNameScorer, nil
+	}
+	scorer, exists := nameScorers[algorithm]
+	if !exists {
+		return nil, fmt.Errorf("unknown algorithm %q, expected one of %s", algorithm, strings.Join(Algorithms(), ", "))
+	}
+	return scorer, nil
+}
+
+// bestTermScores averages the best score for each query term, counting how many were above termMatchThreshold
+func bestTermScores(queryTerms, indexTerms []string, score func(queryTerm, indexTerm string) float64) (float64, int) {
+	if len(queryTerms) == 0 {
+		return 0.0, 0
+	}
+
+	var totalScore float64
+	matchingTerms := 0
+
+	// For each query term, find its best match in index terms
+	for _, qTerm := range queryTerms {
+		bestTermScore := 0.0
+		for _, iTerm := range indexTerms {
+			score := score(qTerm, iTerm)
+			if score > bestTermScore {
+				bestTermScore = score
+				if score > termMatchThreshold {
+					matchingTerms++
+				}
+			}
+		}
+		totalScore += bestTermScore
+	}
+
+	return totalScore / float64(len(queryTerms)), matchingTerms
+}
+
+// jaroWinklerScorer is the default scorer, matching each query term to its highest Jaro-Winkler match
+type jaroWinklerScorer struct{}
+
+func (jaroWinklerScorer) ScoreTerm(queryTerm, indexTerm string) float64 {
+	return compareTerm(queryTerm, indexTerm, false)
+}
+
+func (s jaroWinklerScorer) ScoreTerms(queryTerms, indexTerms []string) (float64, int) {
+	return bestTermScores(queryTerms, indexTerms, s.ScoreTerm)
+}
+
+// levenshteinScorer matches each query term to the indexed term with the fewest edits, relative to their length
+type levenshteinScorer struct{}
+
+func (levenshteinScorer) ScoreTerm(queryTerm, indexTerm string) float64 {
+	return levenshteinSimilarity(queryTerm, indexTerm)
+}
+
+func (levenshteinScorer) ScoreTerms(queryTerms, indexTerms []string) (float64, int) {
+	return bestTermScores(queryTerms, indexTerms, levenshteinSimilarity)
+}
+
+// levenshteinSimilarity returns 1 minus the edit distance divided by the longer string's length
+func levenshteinSimilarity(s1, s2 string) float64 {
+	longest := utf8.RuneCountInString(s1)
+	if n := utf8.RuneCountInString(s2); n > longest {
+		longest = n
+	}
+	if longest == 0 {
+		return 0.0
+	}
+	return 1.0 - float64(levenshtein(s1, s2))/float64(longest)
+}
+
+func levenshtein(s1, s2 string) int {
+	r1, r2 := []rune(s1), []rune(s2)
+
+	prev := make([]int, len(r2)+1)
+	curr := make([]int, len(r2)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(r1); i++ {
+		curr[0] = i
+		for j := 1; j <= len(r2); j++ {
+			cost := 1
+			if r1[i-1] == r2[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if n := prev[j] + 1; n < curr[j] {
+				curr[j] = n
+			}
+			if n := curr[j-1] + 1; n < curr[j] {
+				curr[j] = n
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(r2)]
+}
+
+// tokenSetScorer ignores the order and duplication of terms. The shared terms are compared against
+// each name's full set of terms, so "john smith" scores highly against "smith john michael".
+type tokenSetScorer struct{}
+
+func (tokenSetScorer) ScoreTerm(queryTerm, indexTerm string) float64 {
+	return levenshteinSimilarity(queryTerm, indexTerm)
+}
+
+func (tokenSetScorer) ScoreTerms(queryTerms, indexTerms []string) (float64, int) {
+	if len(queryTerms) == 0 || len(indexTerms) == 0 {
+		return 0.0, 0
+	}
+
+	query := uniqueSorted(queryTerms)
+	index := uniqueSorted(indexTerms)
+
+	var shared, queryOnly, indexOnly []string
+	for _, term := range query {
+		if _, found := slices.BinarySearch(index, term); found {
+			shared = append(shared, term)
+		} else {
+			queryOnly = append(queryOnly, term)
+		}
+	}
+	for _, term := range index {
+		if _, found := slices.BinarySearch(query, term); !found {
+			indexOnly = append(indexOnly, term)
+		}
+	}
+
+	sharedJoined := strings.Join(shared, " ")
+	queryJoined := strings.TrimSpace(sharedJoined + " " + strings.Join(queryOnly, " "))
+	indexJoined := strings.TrimSpace(sharedJoined + " " + strings.Join(indexOnly, " "))
+
+	score := levenshteinSimilarity(queryJoined, indexJoined)
+	if len(shared) > 0 {
+		score = math.Max(score, levenshteinSimilarity(sharedJoined, queryJoined))
+		score = math.Max(score, levenshteinSimilarity(sharedJoined, indexJoined))
+	}
+
+	return score, len(shared)
+}
+
+// tokenSortScorer sorts the terms of both names before comparing them as one string, so "maduro moros nicolas"
+// and "nicolas maduro moros" are the same name. Unlike tokenSetScorer every term of both names counts.
+type tokenSortScorer struct{}
+
+func (tokenSortScorer) ScoreTerm(queryTerm, indexTerm string) float64 {
+	return levenshteinSimilarity(queryTerm, indexTerm)
+}
+
+func (tokenSortScorer) ScoreTerms(queryTerms, indexTerms []string) (float64, int) {
+	if len(queryTerms) == 0 || len(indexTerms) == 0 {
+		return 0.0, 0
+	}
+
+	query := slices.Clone(queryTerms)
+	slices.Sort(query)
+	index := slices.Clone(indexTerms)
+	slices.Sort(index)
+
+	score := levenshteinSimilarity(strings.Join(query, " "), strings.Join(index, " "))
+
+	matchingTerms := 0
+	for _, term := range query {
+		for _, indexTerm := range index {
+			if levenshteinSimilarity(term, indexTerm) > termMatchThreshold {
+				matchingTerms++
+				break
+			}
+		}
+	}
+	return score, matchingTerms
+}
+
+func uniqueSorted(terms []string) []string {
+	out := slices.Clone(terms)
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
+// hybridScorer averages the Jaro-Winkler and Levenshtein scores of each term. Jaro-Winkler favors
+// matching prefixes while Levenshtein is stricter about differences at the end of terms.
+type hybridScorer struct{}
+
+func (hybridScorer) ScoreTerm(queryTerm, indexTerm string) float64 {
+	return hybridTermScore(queryTerm, indexTerm, false)
+}
+
+func (s hybridScorer) ScoreTerms(queryTerms, indexTerms []string) (float64, int) {
+	return bestTermScores(queryTerms, indexTerms, s.ScoreTerm)
+}
+
+func hybridTermScore(queryTerm, indexTerm string, soundAlike bool) float64 {
+	return (compareTerm(queryTerm, indexTerm, soundAlike) + levenshteinSimilarity(queryTerm, indexTerm)) / 2.0
+}
@@ -0,0 +1,96 @@
+package search
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNameScorerFor(t *testing.T) {
+	scorer, err := NameScorerFor("")
+	require.NoError(t, err)
+	require.Equal(t, jaroWinklerScorer{}, scorer)
+
+	for _, algorithm := range Algorithms() {
+		scorer, err := NameScorerFor(strings.ToUpper(algorithm))
+		require.NoError(t, err)
+		require.NotNil(t, scorer)
+	}
+
+	_, err = NameScorerFor("soundex")
+	require.ErrorContains(t, err, `unknown algorithm "soundex"`)
+}
+
+func TestLevenshteinSimilarity(t *testing.T) {
+	require.InDelta(t, 1.0, levenshteinSimilarity("smith", "smith"), 0.001)
+	require.InDelta(t, 0.8, levenshteinSimilarity("smith", "smyth"), 0.001)
+	require.InDelta(t, 0.571, levenshteinSimilarity("kitten", "sitting"), 0.001)
+	require.InDelta(t, 0.0, levenshteinSimilarity("", ""), 0.001)
+	require.InDelta(t, 0.75, levenshteinSimilarity("müll", "mull"), 0.001)
+}
+
+func TestNameScorers(t *testing.T) {
+	cases := []struct {
+		algorithm            string
+		query, index         string
+		expected             float64
+		expectedMatchedTerms int
+	}{
+		{AlgorithmJaroWinkler, "john smith", "john smith", 1.0, 2},
+		{AlgorithmJaroWinkler, "jon smyth", "john smith", 0.916, 1},
+		{AlgorithmLevenshtein, "jon smyth", "john smith", 0.775, 0},
+		{AlgorithmHybrid, "jon smyth", "john smith", 0.846, 0},
+		{AlgorithmTokenSet, "smith john", "john michael smith", 1.0, 2},
+		{AlgorithmTokenSet, "jon smyth", "john smith", 0.8, 0},
+		{AlgorithmTokenSort, "maduro moros nicolas", "nicolas maduro moros", 1.0, 3},
+		{AlgorithmTokenSort, "nicolas maduro", "nicolas maduro moros", 0.7, 2},
+		{AlgorithmTokenSort, "moros nicola madro", "nicolas maduro moros", 0.9, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.algorithm+" "+tc.query, func(t *testing.T) {
+			scorer, err := NameScorerFor(tc.algorithm)
+			require.NoError(t, err)
+
+			score, matched := scorer.ScoreTerms(strings.Fields(tc.query), strings.Fields(tc.index))
+			require.InDelta(t, tc.expected, score, 0.01)
+			require.Equal(t, tc.expectedMatchedTerms, matched)
+		})
+	}
+}
+
+func TestNameScorers_TermOrder(t *testing.T) {
+	index := Entity[any]{Name: "MADURO MOROS, Nicolas", Type: EntityPerson, Person: &Person{Name: "MADURO MOROS, Nicolas"}}
+	orders := [][]string{
+		{"Nicolas Maduro Moros", "MADURO MOROS, Nicolas", "Moros Nicolas Maduro"},
+		{"Nicola Madro Moros", "Moros, Madro Nicola", "MADRO Nicola Moros"},
+	}
+	for _, algorithm := range Algorithms() {
+		scorer, err := NameScorerFor(algorithm)
+		require.NoError(t, err)
+
+		for _, names := range orders {
+			var scores []float64
+			for _, name := range names {
+				query := Entity[any]{Name: name, Type: EntityPerson, Person: &Person{Name: name}}
+				scores = append(scores, SimilarityWithConfig(query, index, SimilarityConfig{NameScorer: scorer}))
+			}
+			for i := range scores {
+				require.InDelta(t, scores[0], scores[i], 0.0001, "%s: %q and %q", algorithm, names[0], names[i])
+			}
+		}
+	}
+}
+
+func TestSimilarityWithConfig(t *testing.T) {
+	query := Entity[any]{Name: "Jon Smyth", Type: EntityPerson}
+	index := Entity[any]{Name: "John Smith", Type: EntityPerson}
+
+	jaroWinkler := Similarity(query, index)
+
+	scorer, err := NameScorerFor(AlgorithmLevenshtein)
+	require.NoError(t, err)
+	levenshtein := SimilarityWithConfig(query, index, SimilarityConfig{NameScorer: scorer})
+
+	require.Greater(t, jaroWinkler, levenshtein)
+}
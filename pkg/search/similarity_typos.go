@@ -0,0 +1,210 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// TypoTolerance is how many character edits a name term can have and still be treated as the same term, by the
+// length of the query term. A term within its edits ("madruo" for "maduro") scores at least 1 minus
+// typoEditPenalty for each edit, however the NameScorer scores it.
+type TypoTolerance []TypoRule
+
+// TypoRule allows Edits edits to query terms of at least MinLength characters
+type TypoRule struct {
+	MinLength int
+	Edits     int
+}
+
+const (
+	// typoEditPenalty is how much each edit lowers the score of a term within its tolerance
+	typoEditPenalty = 0.03
+
+	// maxTypoEdits is the most edits a rule can allow, beyond which terms aren't typos of each other
+	maxTypoEdits = 3
+)
+
+// ParseTypoTolerance reads comma separated rules of minLength:edits, such as "4:1,8:2" which allows one edit to
+// terms of four to seven characters and two edits to longer terms.
+func ParseTypoTolerance(value string) (TypoTolerance, error) {
+	var out TypoTolerance
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		length, edits, found := strings.Cut(part, ":")
+		if !found {
+			return nil, fmt.Errorf("expected minLength:edits, found %q", part)
+		}
+		var rule TypoRule
+		var err error
+		if rule.MinLength, err = strconv.Atoi(strings.TrimSpace(length)); err != nil {
+			return nil, fmt.Errorf("invalid minimum length %q", length)
+		}
+		if rule.Edits, err = strconv.Atoi(strings.TrimSpace(edits)); err != nil {
+			return nil, fmt.Errorf("invalid edits %q", edits)
+		}
+		out = append(out, rule)
+	}
+	if err := out.Validate(); err != nil {
+		return nil, err
+	}
+	slices.SortFunc(out, func(a, b TypoRule) int {
+		return a.MinLength - b.MinLength
+	})
+	return out, nil
+}
+
+// Validate returns an error when a rule allows too many edits or has no minimum length
+func (t TypoTolerance) Validate() error {
+	for _, rule := range t {
+		if rule.MinLength < 1 {
+			return fmt.Errorf("invalid minimum length %d", rule.MinLength)
+		}
+		if rule.Edits < 0 || rule.Edits > maxTypoEdits {
+			return fmt.Errorf("edits %d must be between 0 and %d", rule.Edits, maxTypoEdits)
+		}
+		if rule.Edits >= rule.MinLength {
+			return fmt.Errorf("%d edits would match every term of %d characters", rule.Edits, rule.MinLength)
+		}
+	}
+	return nil
+}
+
+// maxEdits returns how many edits a query term of length characters can have, from the rule with the longest
+// minimum length it meets
+func (t TypoTolerance) maxEdits(length int) int {
+	edits, longest := 0, 0
+	for _, rule := range t {
+		if length >= rule.MinLength && rule.MinLength > longest {
+			edits, longest = rule.Edits, rule.MinLength
+		}
+	}
+	return edits
+}
+
+// score returns the lowest score of queryTerm against indexTerm when they're within the tolerance, or zero
+func (t TypoTolerance) score(queryTerm, indexTerm string) float64 {
+	allowed := t.maxEdits(utf8.RuneCountInString(queryTerm))
+	if allowed == 0 {
+		return 0.0
+	}
+	diff := utf8.RuneCountInString(queryTerm) - utf8.RuneCountInString(indexTerm)
+	if diff > allowed || -diff > allowed {
+		return 0.0 // too many insertions or deletions
+	}
+	edits := typoDistance(queryTerm, indexTerm)
+	if edits > allowed {
+		return 0.0
+	}
+	return 1.0 - typoEditPenalty*float64(edits)
+}
+
+// withTypoTolerance returns scorer raising the scores of terms within tolerance, or scorer itself without any
+func withTypoTolerance(scorer NameScorer, tolerance TypoTolerance) NameScorer {
+	if len(tolerance) == 0 {
+		return scorer
+	}
+	return typoScorer{scorer: scorer, tolerance: tolerance}
+}
+
+// typoScorer applies a TypoTolerance to any NameScorer. Scorers which compare whole names, such as token-set
+// and token-sort, can't score one term on its own, so each query term which is a typo of an indexed term is
+// spelled as that term before the names are compared, and its edits are taken from the score afterwards.
+type typoScorer struct {
+	scorer    NameScorer
+	tolerance TypoTolerance
+}
+
+func (s typoScorer) ScoreTerm(queryTerm, indexTerm string) float64 {
+	score := s.scorer.ScoreTerm(queryTerm, indexTerm)
+	if score < 1.0 {
+		score = math.Max(score, s.tolerance.score(queryTerm, indexTerm))
+	}
+	return score
+}
+
+func (s typoScorer) ScoreTerms(queryTerms, indexTerms []string) (float64, int) {
+	if len(queryTerms) == 0 {
+		return s.scorer.ScoreTerms(queryTerms, indexTerms)
+	}
+
+	var corrected []string
+	var penalty float64
+	for n, qTerm := range queryTerms {
+		var typo string
+		var typoScore float64
+		for _, iTerm := range indexTerms {
+			if score := s.tolerance.score(qTerm, iTerm); score > typoScore {
+				typo, typoScore = iTerm, score
+			}
+		}
+		if typo == "" || typo == qTerm {
+			continue
+		}
+		// Terms the scorer already rates as highly as their typos are left as they're spelled
+		better := true
+		for _, iTerm := range indexTerms {
+			if s.scorer.ScoreTerm(qTerm, iTerm) >= typoScore {
+				better = false
+				break
+			}
+		}
+		if !better {
+			continue
+		}
+		if corrected == nil {
+			corrected = slices.Clone(queryTerms)
+		}
+		corrected[n] = typo
+		penalty += 1.0 - typoScore
+	}
+
+	if corrected == nil {
+		return s.scorer.ScoreTerms(queryTerms, indexTerms)
+	}
+	score, matching := s.scorer.ScoreTerms(corrected, indexTerms)
+	return math.Max(0.0, score-penalty/float64(len(queryTerms))), matching
+}
+
+// typoDistance is the Levenshtein distance where swapping two adjacent characters, the most common typo,
+// counts as one edit rather than two (the optimal string alignment distance)
+func typoDistance(s1, s2 string) int {
+	r1, r2 := []rune(s1), []rune(s2)
+
+	// rows i-2, i-1 and i of the distance matrix
+	prev2 := make([]int, len(r2)+1)
+	prev := make([]int, len(r2)+1)
+	curr := make([]int, len(r2)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(r1); i++ {
+		curr[0] = i
+		for j := 1; j <= len(r2); j++ {
+			cost := 1
+			if r1[i-1] == r2[j-1] {
+				cost = 0
+			}
+			curr[j] = prev[j-1] + cost
+			if n := prev[j] + 1; n < curr[j] {
+				curr[j] = n
+			}
+			if n := curr[j-1] + 1; n < curr[j] {
+				curr[j] = n
+			}
+			if i > 1 && j > 1 && r1[i-1] == r2[j-2] && r1[i-2] == r2[j-1] {
+				if n := prev2[j-2] + 1; n < curr[j] {
+					curr[j] = n
+				}
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+	return prev[len(r2)]
+}
@@ -0,0 +1,85 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/moov-io/watchman/internal/stringscore"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTypoTolerance(t *testing.T) {
+	tolerance, err := ParseTypoTolerance(" 8:2, 4:1 ")
+	require.NoError(t, err)
+	require.Equal(t, TypoTolerance{{MinLength: 4, Edits: 1}, {MinLength: 8, Edits: 2}}, tolerance)
+
+	require.Equal(t, 0, tolerance.maxEdits(3))
+	require.Equal(t, 1, tolerance.maxEdits(7))
+	require.Equal(t, 2, tolerance.maxEdits(12))
+
+	tolerance, err = ParseTypoTolerance("")
+	require.NoError(t, err)
+	require.Empty(t, tolerance)
+
+	for _, value := range []string{"4", "x:1", "4:x", "0:0", "4:-1", "10:4", "2:2"} {
+		_, err := ParseTypoTolerance(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestTypoDistance(t *testing.T) {
+	require.Equal(t, 0, typoDistance("maduro", "maduro"))
+	require.Equal(t, 1, typoDistance("madruo", "maduro")) // swapped letters
+	require.Equal(t, 1, typoDistance("madurp", "maduro"))
+	require.Equal(t, 1, typoDistance("maduro", "madur"))
+	require.Equal(t, 2, typoDistance("nicolsa", "nikolas"))
+	require.Equal(t, 3, typoDistance("", "abc"))
+}
+
+func TestTypoScorer(t *testing.T) {
+	tolerance := TypoTolerance{{MinLength: 4, Edits: 1}, {MinLength: 8, Edits: 2}}
+	scorer := withTypoTolerance(jaroWinklerScorer{}, tolerance)
+
+	without := jaroWinklerScorer{}.ScoreTerm("madruo", "maduro")
+	require.InDelta(t, stringscore.JaroWinkler("madruo", "maduro"), without, 0.001)
+
+	with := scorer.ScoreTerm("madruo", "maduro")
+	require.Greater(t, with, without)
+	require.InDelta(t, 0.97, with, 0.001)
+
+	// Short terms and those with too many edits are scored by Jaro-Winkler
+	require.InDelta(t, stringscore.JaroWinkler("ali", "aly"), scorer.ScoreTerm("ali", "aly"), 0.001)
+	require.InDelta(t, stringscore.JaroWinkler("maduro", "madiru"), scorer.ScoreTerm("madiru", "maduro"), 0.001)
+
+	// No tolerance leaves the scorer as it is
+	require.Equal(t, jaroWinklerScorer{}, withTypoTolerance(jaroWinklerScorer{}, nil))
+
+	// Scorers comparing whole names score a typo as the term it's a typo of, less its edits
+	for _, inner := range []NameScorer{tokenSetScorer{}, tokenSortScorer{}, levenshteinScorer{}, hybridScorer{}} {
+		query, index := []string{"nicolas", "madruo"}, []string{"maduro", "nicolas"}
+		plain, _ := inner.ScoreTerms(query, index)
+		typos, matching := withTypoTolerance(inner, tolerance).ScoreTerms(query, index)
+		require.Greater(t, typos, plain, "%T", inner)
+		require.InDelta(t, 1.0-typoEditPenalty/2, typos, 0.001, "%T", inner)
+		require.Equal(t, 2, matching, "%T", inner)
+	}
+
+	// A name with a typo ranks its entity above similar names
+	cfg := SimilarityConfig{TypoTolerance: tolerance}
+	query := Entity[any]{Name: "Nicolas Madruo"}
+	maduro := compareWeightedName(nil, query, Entity[any]{Name: "Nicolas Maduro"}, Weights{Name: nameWeight, AltName: nameWeight}, cfg)
+	madero := compareWeightedName(nil, query, Entity[any]{Name: "Nicolas Madero"}, Weights{Name: nameWeight, AltName: nameWeight}, cfg)
+	assert.Greater(t, maduro.score, madero.score)
+	assert.True(t, maduro.matched)
+}
+
+func TestTypoTolerance_Validate(t *testing.T) {
+	require.NoError(t, TypoTolerance{{MinLength: 8, Edits: 2}, {MinLength: 4, Edits: 1}}.Validate())
+	require.Error(t, TypoTolerance{{MinLength: 0, Edits: 0}}.Validate())
+	require.Error(t, TypoTolerance{{MinLength: 10, Edits: 4}}.Validate())
+	require.Error(t, TypoTolerance{{MinLength: 2, Edits: 2}}.Validate())
+
+	// Rules are applied by their minimum length, however they're ordered
+	require.Equal(t, 2, TypoTolerance{{MinLength: 8, Edits: 2}, {MinLength: 4, Edits: 1}}.maxEdits(9))
+}
@@ -0,0 +1,173 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// WeakTerms are the words of names which don't tell one party from another, such as "the", "company" or
+// "trading". Rather than being removed, they count for Weight of an ordinary term when names are compared, so
+// "Global Trading Company" isn't a close match of "Pacific Trading Company" on its generic words alone.
+type WeakTerms struct {
+	// Terms are weak in names of every language
+	Terms []string `json:"terms,omitempty"`
+
+	// Languages chooses the built in terms of each language, by ISO 639-1 code, see WeakTermLanguages.
+	// Names on the lists mix languages, so the terms of every chosen language are weak in each name.
+	// Every built in language is used when it's empty.
+	Languages []string `json:"languages,omitempty"`
+
+	// Weight is how much a weak term counts compared to other terms, from 0 (ignored) to 1 (an ordinary term).
+	// defaultWeakTermWeight is used when it's zero, so Ignore is set to ignore them.
+	Weight float64 `json:"weight,omitempty"`
+
+	// Ignore removes weak terms from names before they're compared, as if Weight was 0
+	Ignore bool `json:"ignore,omitempty"`
+}
+
+const defaultWeakTermWeight = 0.25
+
+var (
+	// weakTermsByLanguage are articles, conjunctions and the generic words of business names, written as
+	// normalizeName writes them. Terms shorter than minTermLength are never compared, so they're left out.
+	weakTermsByLanguage = map[string][]string{
+		"en": {
+			"the", "and", "for", "company", "companies", "corporation", "corp", "inc", "llc", "ltd", "limited",
+			"trading", "traders", "general", "group", "holding", "holdings", "international", "intl", "industries",
+			"industrial", "enterprise", "enterprises", "services", "import", "export", "commercial", "global",
+		},
+		"es": {
+			"del", "las", "los", "compania", "comercial", "comercializadora", "empresa", "grupo", "internacional",
+			"sociedad", "servicios", "industrias", "importadora", "exportadora",
+		},
+		"fr": {
+			"des", "les", "societe", "compagnie", "generale", "general", "groupe", "commerce", "commerciale",
+			"internationale", "services", "industries",
+		},
+		"de": {
+			"der", "die", "das", "und", "gesellschaft", "handel", "handels", "gruppe", "international", "industrie",
+		},
+		"ru": {
+			// Romanized, as in "Obshchestvo s Ogranichennoy Otvetstvennostyu" (a limited liability company)
+			"obshchestvo", "ogranichennoy", "ogranichennoi", "otvetstvennostyu", "aktsionernoe", "zakrytoe",
+			"otkrytoe", "publichnoe", "kompaniya", "torgovyy", "torgovy", "gruppa",
+		},
+		"ar": {
+			// Romanized, as in "Sharikat al-Tijara al-Amma" (the general trading company)
+			"sharikat", "sharika", "sherkat", "tijara", "tijariya", "amma", "mu'assasat", "muassasat", "lil",
+		},
+	}
+
+	defaultWeakTerms = func() *WeakTermSet {
+		set, err := NewWeakTermSet(WeakTerms{})
+		if err != nil {
+			panic(err) //nolint:forbidigo
+		}
+		return set
+	}()
+)
+
+// WeakTermLanguages returns the languages with built in weak terms
+func WeakTermLanguages() []string {
+	out := make([]string, 0, len(weakTermsByLanguage))
+	for lang := range weakTermsByLanguage {
+		out = append(out, lang)
+	}
+	slices.Sort(out)
+	return out
+}
+
+// WeakTermSet is the weak terms of a WeakTerms, ready for comparing names
+type WeakTermSet struct {
+	terms  map[string]bool
+	weight float64
+}
+
+// NewWeakTermSet validates conf and collects its terms
+func NewWeakTermSet(conf WeakTerms) (*WeakTermSet, error) {
+	weight := conf.Weight
+	switch {
+	case conf.Ignore:
+		weight = 0
+	case math.IsNaN(weight) || weight < 0 || weight > 1:
+		return nil, fmt.Errorf("weak term weight of %v must be between 0 and 1", weight)
+	case weight == 0:
+		weight = defaultWeakTermWeight
+	}
+
+	languages := conf.Languages
+	if len(languages) == 0 {
+		languages = WeakTermLanguages()
+	}
+	out := &WeakTermSet{
+		terms:  make(map[string]bool),
+		weight: weight,
+	}
+	for _, lang := range languages {
+		terms, exists := weakTermsByLanguage[strings.ToLower(strings.TrimSpace(lang))]
+		if !exists {
+			return nil, fmt.Errorf("no weak terms for language %q, expected one of %s", lang, strings.Join(WeakTermLanguages(), ", "))
+		}
+		for _, term := range terms {
+			out.terms[term] = true
+		}
+	}
+	for _, term := range conf.Terms {
+		for _, t := range strings.Fields(normalizeName(term)) {
+			out.terms[t] = true
+		}
+	}
+	return out, nil
+}
+
+// Contains returns true when term is weak, after it's normalized like the terms of names
+func (s *WeakTermSet) Contains(term string) bool {
+	return s.weak(normalizeName(term))
+}
+
+// Weight is how much each weak term counts compared to other terms
+func (s *WeakTermSet) Weight() float64 {
+	return s.weight
+}
+
+func (s *WeakTermSet) weak(term string) bool {
+	return s != nil && s.terms[term]
+}
+
+// split separates the weak terms from the others
+func (s *WeakTermSet) split(terms []string) (strong, weak []string) {
+	for _, term := range terms {
+		if s.weak(term) {
+			weak = append(weak, term)
+		} else {
+			strong = append(strong, term)
+		}
+	}
+	return strong, weak
+}
+
+// scoreTerms compares the query's terms against an indexed name's like scorer.ScoreTerms, but with each weak
+// query term counting for the set's weight of another term. Other query terms are compared against the
+// indexed terms which aren't weak, so the generic words of either name can't make up for its others.
+// Names made only of weak terms are compared as they are.
+func (s *WeakTermSet) scoreTerms(scorer NameScorer, queryTerms, indexTerms []string) (float64, int) {
+	strong, weak := s.split(queryTerms)
+	if len(strong) == 0 {
+		return scorer.ScoreTerms(queryTerms, indexTerms)
+	}
+	indexStrong, _ := s.split(indexTerms)
+	if len(indexStrong) == 0 {
+		indexStrong = indexTerms
+	}
+
+	score, matching := scorer.ScoreTerms(strong, indexStrong)
+	if len(weak) == 0 || s.weight <= 0 {
+		return score, matching
+	}
+	weakScore, weakMatching := scorer.ScoreTerms(weak, indexTerms)
+
+	n, w := float64(len(strong)), float64(len(weak))*s.weight
+	return (score*n + weakScore*w) / (n + w), matching + weakMatching
+}
@@ -0,0 +1,87 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWeakTermSet(t *testing.T) {
+	set, err := NewWeakTermSet(WeakTerms{})
+	require.NoError(t, err)
+	require.True(t, set.Contains("THE"))
+	require.True(t, set.Contains("Trading"))
+	require.True(t, set.Contains("Obshchestvo"))
+	require.False(t, set.Contains("caribbean"))
+	require.InDelta(t, defaultWeakTermWeight, set.Weight(), 0.001)
+
+	set, err = NewWeakTermSet(WeakTerms{Terms: []string{"Shipping Lines"}, Languages: []string{"ES"}, Weight: 0.1})
+	require.NoError(t, err)
+	require.True(t, set.Contains("shipping"))
+	require.True(t, set.Contains("lines"))
+	require.True(t, set.Contains("sociedad"))
+	require.False(t, set.Contains("trading"))
+	require.InDelta(t, 0.1, set.Weight(), 0.001)
+
+	set, err = NewWeakTermSet(WeakTerms{Weight: 0.5, Ignore: true})
+	require.NoError(t, err)
+	require.Zero(t, set.Weight())
+
+	_, err = NewWeakTermSet(WeakTerms{Languages: []string{"klingon"}})
+	require.ErrorContains(t, err, `no weak terms for language "klingon"`)
+
+	_, err = NewWeakTermSet(WeakTerms{Weight: -1})
+	require.ErrorContains(t, err, "must be between 0 and 1")
+
+	require.Equal(t, []string{"ar", "de", "en", "es", "fr", "ru"}, WeakTermLanguages())
+}
+
+func TestWeakTermSet_scoreTerms(t *testing.T) {
+	scorer := jaroWinklerScorer{}
+
+	weighted, err := NewWeakTermSet(WeakTerms{})
+	require.NoError(t, err)
+	ordinary, err := NewWeakTermSet(WeakTerms{Weight: 1})
+	require.NoError(t, err)
+	ignored, err := NewWeakTermSet(WeakTerms{Ignore: true})
+	require.NoError(t, err)
+
+	query := []string{"caribbean", "trading", "company"}
+	index := []string{"pacific", "trading", "company"}
+
+	// Generic words make up most of the score when they count like others
+	plain, matching := ordinary.scoreTerms(scorer, query, index)
+	require.Greater(t, plain, 0.8)
+	require.Equal(t, 2, matching)
+
+	score, matching := weighted.scoreTerms(scorer, query, index)
+	require.Less(t, score, 0.7)
+	require.Equal(t, 2, matching)
+
+	score, matching = ignored.scoreTerms(scorer, query, index)
+	strong, _ := scorer.ScoreTerms([]string{"caribbean"}, []string{"pacific"})
+	require.InDelta(t, strong, score, 0.001)
+	require.Equal(t, 0, matching)
+
+	// Weak terms missing from either name cost little
+	score, _ = weighted.scoreTerms(scorer, []string{"aerocaribbean", "airlines", "company"}, []string{"aerocaribbean", "airlines"})
+	require.Greater(t, score, 0.9)
+
+	// Names of only weak terms are compared as they are
+	score, _ = weighted.scoreTerms(scorer, []string{"general", "trading"}, []string{"acme", "general", "trading"})
+	require.InDelta(t, 1.0, score, 0.001)
+
+	score, _ = weighted.scoreTerms(scorer, []string{"acme"}, []string{"general", "trading"})
+	require.Less(t, score, 0.7)
+}
+
+func TestSimilarity_WeakTerms(t *testing.T) {
+	query := Entity[any]{Name: "Caribbean Trading Company", Type: EntityBusiness, Business: &Business{Name: "Caribbean Trading Company"}}
+	index := Entity[any]{Name: "Pacific Trading Company", Type: EntityBusiness, Business: &Business{Name: "Pacific Trading Company"}}
+
+	ordinary, err := NewWeakTermSet(WeakTerms{Weight: 1})
+	require.NoError(t, err)
+
+	generic := SimilarityWithConfig(query, index, SimilarityConfig{WeakTerms: ordinary})
+	require.Greater(t, generic, SimilarityWithConfig(query, index, SimilarityConfig{}))
+}
@@ -0,0 +1,115 @@
+package search
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Weights are how much each field counts towards a score, relative to the others.
+// Fields left at zero use the default weight.
+type Weights struct {
+	Name    float64 `json:"name,omitempty"`
+	AltName float64 `json:"altName,omitempty"` // alternate and former names
+	Address float64 `json:"address,omitempty"`
+	Dates   float64 `json:"dates,omitempty"` // birth dates of people, created dates of businesses
+
+	// Identifiers are government IDs, crypto addresses and the IMO, call sign and other numbers of vehicles
+	Identifiers float64 `json:"identifiers,omitempty"`
+}
+
+// maxWeight keeps any one field from drowning out every other
+const maxWeight = 100.0
+
+// DefaultWeights returns the weights used when a field's weight isn't set
+func DefaultWeights() Weights {
+	return Weights{
+		Name:        nameWeight,
+		AltName:     nameWeight,
+		Address:     supportingInfoWeight,
+		Dates:       supportingInfoWeight,
+		Identifiers: criticalIdWeight,
+	}
+}
+
+// Or returns w with any fields left at zero taken from other
+func (w Weights) Or(other Weights) Weights {
+	or := func(a, b float64) float64 {
+		if a != 0 {
+			return a
+		}
+		return b
+	}
+	return Weights{
+		Name:        or(w.Name, other.Name),
+		AltName:     or(w.AltName, other.AltName),
+		Address:     or(w.Address, other.Address),
+		Dates:       or(w.Dates, other.Dates),
+		Identifiers: or(w.Identifiers, other.Identifiers),
+	}
+}
+
+// Empty returns true when no weight is set
+func (w Weights) Empty() bool {
+	return w == Weights{}
+}
+
+// Validate returns an error when a weight is negative, above 100 or not a number
+func (w Weights) Validate() error {
+	for _, field := range w.fields() {
+		v := *field.value
+		if math.IsNaN(v) || v < 0 || v > maxWeight {
+			return fmt.Errorf("%s weight of %v must be between 0 and %v", field.name, v, maxWeight)
+		}
+	}
+	return nil
+}
+
+// ParseWeights reads weights written as comma separated field:weight pairs, such as "name:40,address:5".
+// Fields are name, altName, address, dates and identifiers.
+func ParseWeights(input string) (Weights, error) {
+	var out Weights
+	fields := out.fields()
+
+	for _, pair := range strings.Split(input, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, found := strings.Cut(pair, ":")
+		if !found {
+			return out, fmt.Errorf("invalid weight %q, expected field:weight", pair)
+		}
+		idx := -1
+		for i := range fields {
+			if strings.EqualFold(fields[i].name, strings.TrimSpace(name)) {
+				idx = i
+			}
+		}
+		if idx < 0 {
+			return out, fmt.Errorf("unknown weight field %q", name)
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return out, fmt.Errorf("invalid %s weight %q: %w", fields[idx].name, value, err)
+		}
+		*fields[idx].value = v
+	}
+	return out, out.Validate()
+}
+
+type weightField struct {
+	name  string
+	value *float64
+}
+
+func (w *Weights) fields() []weightField {
+	return []weightField{
+		{"name", &w.Name},
+		{"altName", &w.AltName},
+		{"address", &w.Address},
+		{"dates", &w.Dates},
+		{"identifiers", &w.Identifiers},
+	}
+}
@@ -0,0 +1,80 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWeights(t *testing.T) {
+	weights, err := ParseWeights("name:40, ADDRESS:5,altName:30")
+	require.NoError(t, err)
+	require.Equal(t, Weights{Name: 40, AltName: 30, Address: 5}, weights)
+
+	weights, err = ParseWeights("")
+	require.NoError(t, err)
+	require.True(t, weights.Empty())
+
+	_, err = ParseWeights("name")
+	require.ErrorContains(t, err, `invalid weight "name"`)
+
+	_, err = ParseWeights("height:10")
+	require.ErrorContains(t, err, `unknown weight field "height"`)
+
+	_, err = ParseWeights("dates:ten")
+	require.ErrorContains(t, err, "invalid dates weight")
+
+	_, err = ParseWeights("identifiers:101")
+	require.ErrorContains(t, err, "identifiers weight of 101 must be between 0 and 100")
+
+	_, err = ParseWeights("address:-1")
+	require.ErrorContains(t, err, "address weight of -1 must be between 0 and 100")
+}
+
+func TestWeights_Or(t *testing.T) {
+	weights := Weights{Address: 2}.Or(DefaultWeights())
+	require.Equal(t, 2.0, weights.Address)
+	require.Equal(t, nameWeight, weights.Name)
+	require.Equal(t, criticalIdWeight, weights.Identifiers)
+}
+
+func TestSimilarity_Weights(t *testing.T) {
+	query := Entity[any]{
+		Name:   "John Smith",
+		Type:   EntityPerson,
+		Person: &Person{Name: "John Smith"},
+		Addresses: []Address{
+			{Line1: "123 Main St", City: "Springfield", Country: "US"},
+		},
+	}
+	index := Entity[any]{
+		Name:   "John Smith",
+		Type:   EntityPerson,
+		Person: &Person{Name: "John Smith"},
+		Addresses: []Address{
+			{Line1: "9 Rue de Rivoli", City: "Paris", Country: "FR"},
+		},
+	}
+
+	defaults := SimilarityWithConfig(query, index, SimilarityConfig{})
+	lowAddress := SimilarityWithConfig(query, index, SimilarityConfig{
+		Weights: Weights{Address: 1},
+	})
+	require.Greater(t, lowAddress, defaults)
+
+	// Matches of alternate names are weighted on their own
+	index = Entity[any]{
+		Name:   "Jonathan Smithson",
+		Type:   EntityPerson,
+		Person: &Person{Name: "Jonathan Smithson", AltNames: []string{"John Smith"}},
+	}
+	query.Addresses = nil
+
+	piece := compareWeightedName(nil, query, index, Weights{Name: 35, AltName: 20}, SimilarityConfig{})
+	require.True(t, piece.matched)
+	require.Equal(t, 20.0, piece.weight)
+
+	index.Name = "John Smith"
+	piece = compareWeightedName(nil, query, index, Weights{Name: 35, AltName: 20}, SimilarityConfig{})
+	require.Equal(t, 35.0, piece.weight)
+}
@@ -0,0 +1,228 @@
+// Copyright The Moov Authors
+// Use of this source code is governed by an Apache License
+// license that can be found in the LICENSE file.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: watchman.proto
+
+package watchmanpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Watchman_Search_FullMethodName     = "/moov.watchman.v2.Watchman/Search"
+	Watchman_BulkSearch_FullMethodName = "/moov.watchman.v2.Watchman/BulkSearch"
+	Watchman_ListInfo_FullMethodName   = "/moov.watchman.v2.Watchman/ListInfo"
+)
+
+// WatchmanClient is the client API for Watchman service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WatchmanClient interface {
+	// Search finds the closest matching entities for a single query.
+	Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error)
+	// BulkSearch screens a stream of queries. One response is sent for each request
+	// and carries the request's correlation_id. Responses may be sent out of order.
+	BulkSearch(ctx context.Context, opts ...grpc.CallOption) (Watchman_BulkSearchClient, error)
+	// ListInfo returns the lists currently loaded and their entity counts.
+	ListInfo(ctx context.Context, in *ListInfoRequest, opts ...grpc.CallOption) (*ListInfoResponse, error)
+}
+
+type watchmanClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWatchmanClient(cc grpc.ClientConnInterface) WatchmanClient {
+	return &watchmanClient{cc}
+}
+
+func (c *watchmanClient) Search(ctx context.Context, in *SearchRequest, opts ...grpc.CallOption) (*SearchResponse, error) {
+	out := new(SearchResponse)
+	err := c.cc.Invoke(ctx, Watchman_Search_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *watchmanClient) BulkSearch(ctx context.Context, opts ...grpc.CallOption) (Watchman_BulkSearchClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Watchman_ServiceDesc.Streams[0], Watchman_BulkSearch_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &watchmanBulkSearchClient{stream}
+	return x, nil
+}
+
+type Watchman_BulkSearchClient interface {
+	Send(*SearchRequest) error
+	Recv() (*SearchResponse, error)
+	grpc.ClientStream
+}
+
+type watchmanBulkSearchClient struct {
+	grpc.ClientStream
+}
+
+func (x *watchmanBulkSearchClient) Send(m *SearchRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *watchmanBulkSearchClient) Recv() (*SearchResponse, error) {
+	m := new(SearchResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *watchmanClient) ListInfo(ctx context.Context, in *ListInfoRequest, opts ...grpc.CallOption) (*ListInfoResponse, error) {
+	out := new(ListInfoResponse)
+	err := c.cc.Invoke(ctx, Watchman_ListInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WatchmanServer is the server API for Watchman service.
+// All implementations must embed UnimplementedWatchmanServer
+// for forward compatibility
+type WatchmanServer interface {
+	// Search finds the closest matching entities for a single query.
+	Search(context.Context, *SearchRequest) (*SearchResponse, error)
+	// BulkSearch screens a stream of queries. One response is sent for each request
+	// and carries the request's correlation_id. Responses may be sent out of order.
+	BulkSearch(Watchman_BulkSearchServer) error
+	// ListInfo returns the lists currently loaded and their entity counts.
+	ListInfo(context.Context, *ListInfoRequest) (*ListInfoResponse, error)
+	mustEmbedUnimplementedWatchmanServer()
+}
+
+// UnimplementedWatchmanServer must be embedded to have forward compatible implementations.
+type UnimplementedWatchmanServer struct {
+}
+
+func (UnimplementedWatchmanServer) Search(context.Context, *SearchRequest) (*SearchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
+func (UnimplementedWatchmanServer) BulkSearch(Watchman_BulkSearchServer) error {
+	return status.Errorf(codes.Unimplemented, "method BulkSearch not implemented")
+}
+func (UnimplementedWatchmanServer) ListInfo(context.Context, *ListInfoRequest) (*ListInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInfo not implemented")
+}
+func (UnimplementedWatchmanServer) mustEmbedUnimplementedWatchmanServer() {}
+
+// UnsafeWatchmanServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WatchmanServer will
+// result in compilation errors.
+type UnsafeWatchmanServer interface {
+	mustEmbedUnimplementedWatchmanServer()
+}
+
+func RegisterWatchmanServer(s grpc.ServiceRegistrar, srv WatchmanServer) {
+	s.RegisterService(&Watchman_ServiceDesc, srv)
+}
+
+func _Watchman_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchmanServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Watchman_Search_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchmanServer).Search(ctx, req.(*SearchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Watchman_BulkSearch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(WatchmanServer).BulkSearch(&watchmanBulkSearchServer{stream})
+}
+
+type Watchman_BulkSearchServer interface {
+	Send(*SearchResponse) error
+	Recv() (*SearchRequest, error)
+	grpc.ServerStream
+}
+
+type watchmanBulkSearchServer struct {
+	grpc.ServerStream
+}
+
+func (x *watchmanBulkSearchServer) Send(m *SearchResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *watchmanBulkSearchServer) Recv() (*SearchRequest, error) {
+	m := new(SearchRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Watchman_ListInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WatchmanServer).ListInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Watchman_ListInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WatchmanServer).ListInfo(ctx, req.(*ListInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Watchman_ServiceDesc is the grpc.ServiceDesc for Watchman service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Watchman_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "moov.watchman.v2.Watchman",
+	HandlerType: (*WatchmanServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Search",
+			Handler:    _Watchman_Search_Handler,
+		},
+		{
+			MethodName: "ListInfo",
+			Handler:    _Watchman_ListInfo_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "BulkSearch",
+			Handler:       _Watchman_BulkSearch_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "watchman.proto",
+}